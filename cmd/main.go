@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/9triver/iarnet/internal/bootstrap"
 	"github.com/9triver/iarnet/internal/config"
@@ -23,6 +24,9 @@ func main() {
 		log.Fatalf("Load config: %v", err)
 	}
 	util.InitLogger()
+	if err := util.ConfigureLogger(cfg.Logging.Format, cfg.Logging.Level, cfg.Logging.Modules); err != nil {
+		log.Fatalf("Configure logger: %v", err)
+	}
 
 	// 使用 Bootstrap 初始化所有模块
 	iarnet, err := bootstrap.Initialize(cfg)
@@ -35,22 +39,40 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// 由滚动重启启动时，先恢复旧进程的 component 路由状态，使重新连接的 component 的消息能够送达
+	if err := iarnet.RestoreHandover(ctx); err != nil {
+		logrus.Fatalf("Failed to restore handover state: %v", err)
+	}
+
 	// 启动所有服务
 	if err := iarnet.Start(ctx); err != nil {
 		logrus.Fatalf("Failed to start services: %v", err)
 	}
-
-	iarnet.IgnisPlatform.CreateController(ctx, "test")
+	if err := iarnet.CompleteHandover(); err != nil {
+		logrus.Errorf("Failed to complete handover: %v", err)
+	}
 
 	logrus.Info("Iarnet started successfully")
 
-	// 优雅关闭
+	// SIGUSR2 触发滚动重启，交接失败时继续提供服务；SIGINT、SIGTERM 优雅关闭
 	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
-	<-sigCh
-	logrus.Info("Shutting down...")
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGUSR2)
+	for sig := range sigCh {
+		if sig != syscall.SIGUSR2 {
+			logrus.Info("Shutting down...")
+			// 停止接受新的部署，等待进行中的部署完成（有超时），再取消上下文
+			iarnet.Drain(time.Duration(cfg.ShutdownTimeoutSeconds) * time.Second)
+			break
+		}
+		logrus.Info("Handing over to new process...")
+		if err := iarnet.Handover(ctx); err != nil {
+			logrus.Errorf("%v", err)
+			continue
+		}
+		break
+	}
 
-	// 取消上下文以停止组件管理器和 ZMQ 接收器
+	// 取消上下文以停止组件管理器和 ZMQ 接收器，随后由 defer 的 Stop 停止服务并关闭数据库
 	cancel()
 
 	logrus.Info("Shutdown complete")
@@ -0,0 +1,120 @@
+// Package main 是 component 端口转发的命令行客户端
+// 在运维人员本机监听端口，将每条 TCP 连接经 iarnet 节点的管理 API 转发到 component 端口，类似 kubectl port-forward
+//
+// 用法:
+//
+//	portforward -server http://node:8083 -component comp.xxx -token <token> 8080:80
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/9triver/iarnet/pkg/client"
+	"github.com/gorilla/websocket"
+)
+
+func main() {
+	server := flag.String("server", "http://localhost:8083", "iarnet management API address")
+	componentID := flag.String("component", "", "Target component ID")
+	token := flag.String("token", os.Getenv("IARNET_TOKEN"), "RBAC access token (defaults to $IARNET_TOKEN)")
+	address := flag.String("address", "127.0.0.1", "Local address to listen on")
+	flag.Parse()
+
+	if *componentID == "" || flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: portforward -component <id> [-server url] [-token token] <local-port>:<remote-port>")
+		os.Exit(2)
+	}
+	localPort, remotePort, err := parsePortMapping(flag.Arg(0))
+	if err != nil {
+		log.Fatalf("Invalid port mapping: %v", err)
+	}
+
+	api, err := client.New(*server, client.WithToken(*token))
+	if err != nil {
+		log.Fatalf("Invalid server address: %v", err)
+	}
+	target, err := api.PortForwardURL(*componentID, remotePort)
+	if err != nil {
+		log.Fatalf("Invalid server address: %v", err)
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(*address, localPort))
+	if err != nil {
+		log.Fatalf("Listen: %v", err)
+	}
+	log.Printf("Forwarding from %s -> %s:%s", listener.Addr(), *componentID, remotePort)
+
+	header := api.Header()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			log.Fatalf("Accept: %v", err)
+		}
+		go handleConnection(conn, target, header)
+	}
+}
+
+// parsePortMapping 解析 <local>:<remote> 或 <port>（本地与远端端口相同）
+func parsePortMapping(mapping string) (string, string, error) {
+	local, remote, found := strings.Cut(mapping, ":")
+	if !found {
+		remote = local
+	}
+	if local == "" || remote == "" {
+		return "", "", fmt.Errorf("expected <local-port>:<remote-port>, got %q", mapping)
+	}
+	return local, remote, nil
+}
+
+// handleConnection 为一条本地连接建立 WebSocket 隧道并双向转发数据
+func handleConnection(conn net.Conn, target string, header http.Header) {
+	defer conn.Close()
+
+	ws, resp, err := websocket.DefaultDialer.Dial(target, header)
+	if err != nil {
+		if resp != nil {
+			log.Printf("Failed to open tunnel: %v (HTTP %s)", err, resp.Status)
+		} else {
+			log.Printf("Failed to open tunnel: %v", err)
+		}
+		return
+	}
+	defer ws.Close()
+	log.Printf("Handling connection from %s", conn.RemoteAddr())
+
+	go func() {
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := conn.Read(buf)
+			if n > 0 {
+				if werr := ws.WriteMessage(websocket.BinaryMessage, buf[:n]); werr != nil {
+					return
+				}
+			}
+			if err != nil {
+				// 本地连接结束发送，通知远端半关闭
+				ws.WriteMessage(websocket.TextMessage, []byte("close_write"))
+				return
+			}
+		}
+	}()
+
+	for {
+		msgType, data, err := ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		if msgType != websocket.BinaryMessage {
+			continue
+		}
+		if _, err := conn.Write(data); err != nil {
+			return
+		}
+	}
+}
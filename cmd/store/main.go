@@ -0,0 +1,127 @@
+// Package main 是独立的 store 服务
+// 同域的多个节点通过 resource.store.shared_address 共用该 store，对象持久化在数据目录中；
+// component（包括委托到其他节点的 component）直接读写该 store，无需回连发起部署的节点。
+// 配置 access.token_key_file 后使用同域共用的令牌密钥校验 component 令牌与节点令牌，并按 access 配置执行对象访问控制
+//
+// 用法:
+//
+//	store -config store.yaml
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	"github.com/9triver/iarnet/internal/config"
+	domainstore "github.com/9triver/iarnet/internal/domain/resource/store"
+	storepb "github.com/9triver/iarnet/internal/proto/resource/store"
+	storerpc "github.com/9triver/iarnet/internal/transport/rpc/resource/store"
+	"github.com/9triver/iarnet/internal/util"
+	"github.com/9triver/iarnet/internal/util/compress"
+	"github.com/9triver/iarnet/internal/util/identity"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	configFile := flag.String("config", "store.yaml", "Path to store config file")
+	flag.Parse()
+
+	cfg, err := loadConfig(*configFile)
+	if err != nil {
+		log.Fatalf("Load config: %v", err)
+	}
+	util.InitLogger()
+	if err := util.ConfigureLogger(cfg.Logging.Format, cfg.Logging.Level, nil); err != nil {
+		log.Fatalf("Configure logger: %v", err)
+	}
+
+	alg, err := compress.ParseAlgorithm(cfg.Compression)
+	if err != nil {
+		logrus.Fatalf("Invalid compression: %v", err)
+	}
+	compress.SetGRPCAlgorithm(alg)
+
+	st, err := domainstore.Open(cfg.DataDir, domainstore.Limits{
+		MaxObjectBytes: cfg.MaxObjectBytes,
+		MaxTotalBytes:  cfg.MaxTotalBytes,
+	})
+	if err != nil {
+		logrus.Fatalf("Failed to open store: %v", err)
+	}
+
+	opts := append([]grpc.ServerOption{grpc.MaxRecvMsgSize(cfg.MaxGRPCMessageBytes)},
+		compress.ServerOptions(compress.ChannelStore)...)
+	// 校验各节点使用同域密钥签发的 component 令牌与节点令牌
+	access := cfg.Access
+	if access.TokenKeyFile != "" {
+		tokens, err := identity.LoadTokenKey(access.TokenKeyFile)
+		if err != nil {
+			logrus.Fatalf("Failed to load token key: %v", err)
+		}
+		opts = append(opts, tokens.ServerOptions(access.RequireToken)...)
+	}
+	server := grpc.NewServer(opts...)
+
+	service := domainstore.NewService(st)
+	var audit *domainstore.AuditLog
+	if access.Enforce || access.AuditLog {
+		policy := domainstore.AccessPolicy{Enforce: access.Enforce}
+		if access.AuditLog {
+			audit, err = domainstore.NewAuditLog(access.AuditPath)
+			if err != nil {
+				logrus.Fatalf("Failed to open store audit log: %v", err)
+			}
+			policy.Audit = audit
+			logrus.Infof("Store audit log enabled at %s", access.AuditPath)
+		}
+		service.SetAccessPolicy(policy)
+		if access.Enforce {
+			logrus.Info("Store access control enforced")
+		}
+	}
+	storepb.RegisterServiceServer(server, storerpc.NewServer(service))
+
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		logrus.Fatalf("Failed to listen on %s: %v", addr, err)
+	}
+	go func() {
+		if err := server.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			logrus.Fatalf("Store server stopped unexpectedly: %v", err)
+		}
+	}()
+	logrus.Infof("Store %s listening on %s", st.GetID(), addr)
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	sig := <-sigCh
+	logrus.Infof("Received %v, shutting down", sig)
+	// 对象在保存时已写入文件，等待进行中的请求结束即可
+	server.GracefulStop()
+	if audit != nil {
+		if err := audit.Close(); err != nil {
+			logrus.Warnf("Failed to close store audit log: %v", err)
+		}
+	}
+	objects, bytes := st.Usage()
+	logrus.Infof("Store stopped with %d object(s), %d bytes", objects, bytes)
+}
+
+// loadConfig 加载配置文件，文件不存在时使用默认配置
+func loadConfig(file string) (*config.StoreServerConfig, error) {
+	cfg, err := config.LoadStoreServerConfig(file)
+	if errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintf(os.Stderr, "Config file %s not found, using defaults\n", file)
+		return config.StoreServerDefaults(), nil
+	}
+	return cfg, err
+}
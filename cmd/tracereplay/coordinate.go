@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// startDelay 所有参与者加入后到同时开始重放的间隔，留出屏障响应送达各参与者的时间
+const startDelay = 2 * time.Second
+
+// joinRetryInterval 协调者尚未启动时重试加入的间隔
+const joinRetryInterval = time.Second
+
+// joinTimeout 参与者等待协调者启动的最长时间
+const joinTimeout = 10 * time.Minute
+
+// joinRequest 参与者加入启动屏障
+type joinRequest struct {
+	Server  string `json:"server"`  // 参与者重放的目标节点
+	Deploys int    `json:"deploys"` // 参与者 trace 中待重放的部署数，决定其任务 ID 区间的大小
+}
+
+// assignment 所有参与者加入后协调者分配的序号与任务 ID 区间 [TaskIDBase, TaskIDBase+Deploys)
+type assignment struct {
+	Index        int   `json:"index"`
+	Participants int   `json:"participants"`
+	TaskIDBase   int   `json:"task_id_base"`
+	StartInMs    int64 `json:"start_in_ms"` // 收到响应后等待该时长再开始重放
+}
+
+// resultUpload 参与者重放结束后上传的结果
+type resultUpload struct {
+	Index   int           `json:"index"`
+	Results []*taskResult `json:"results"`
+}
+
+// coordinator 多节点实验的启动屏障：指定数量的 tracereplay 进程都加入后同时开始重放，
+// 重放结束后收集各参与者的结果，由运行协调者的进程合并
+type coordinator struct {
+	participants int
+
+	mu          sync.Mutex
+	joined      []joinRequest
+	assignments []assignment
+	released    chan struct{}         // 所有参与者加入后关闭
+	results     map[int][]*taskResult // 参与者序号 -> 上传的结果
+	uploaded    chan struct{}         // 所有参与者上传结果后关闭
+}
+
+func newCoordinator(participants int) *coordinator {
+	return &coordinator{
+		participants: participants,
+		released:     make(chan struct{}),
+		results:      make(map[int][]*taskResult),
+		uploaded:     make(chan struct{}),
+	}
+}
+
+// join 加入屏障，阻塞到所有参与者加入；任务 ID 区间按加入顺序依次分配，互不重叠
+func (c *coordinator) join(req joinRequest) (assignment, error) {
+	c.mu.Lock()
+	if len(c.joined) >= c.participants {
+		c.mu.Unlock()
+		return assignment{}, fmt.Errorf("all %d participants have already joined", c.participants)
+	}
+	index := len(c.joined)
+	c.joined = append(c.joined, req)
+	log.Printf("Participant %d (%s, %d deployments) joined, %d of %d", index, req.Server, req.Deploys, len(c.joined), c.participants)
+	if len(c.joined) == c.participants {
+		base := 0
+		for i, p := range c.joined {
+			c.assignments = append(c.assignments, assignment{
+				Index:        i,
+				Participants: c.participants,
+				TaskIDBase:   base,
+				StartInMs:    startDelay.Milliseconds(),
+			})
+			base += p.Deploys
+		}
+		close(c.released)
+	}
+	c.mu.Unlock()
+
+	<-c.released
+	return c.assignments[index], nil
+}
+
+// addResults 保存参与者的结果，重复上传时以最后一次为准
+func (c *coordinator) addResults(upload resultUpload) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if upload.Index < 0 || upload.Index >= len(c.assignments) {
+		return fmt.Errorf("unknown participant %d", upload.Index)
+	}
+	c.results[upload.Index] = upload.Results
+	log.Printf("Received %d result(s) from participant %d, %d of %d", len(upload.Results), upload.Index, len(c.results), c.participants)
+	if len(c.results) == c.participants {
+		select {
+		case <-c.uploaded:
+		default:
+			close(c.uploaded)
+		}
+	}
+	return nil
+}
+
+// mergedResults 等待所有参与者上传结果后返回合并的结果；超时时返回已收到的部分并报告缺失的参与者
+func (c *coordinator) mergedResults(timeout time.Duration) ([]*taskResult, error) {
+	var err error
+	select {
+	case <-c.uploaded:
+	case <-time.After(timeout):
+		err = fmt.Errorf("timed out waiting for results from %d participant(s)", c.participants-c.received())
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var merged []*taskResult
+	for _, results := range c.results {
+		merged = append(merged, results...)
+	}
+	return merged, err
+}
+
+func (c *coordinator) received() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.results)
+}
+
+// serve 提供 POST /join（阻塞到所有参与者加入）与 POST /results
+func (c *coordinator) serve(addr string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /join", func(w http.ResponseWriter, req *http.Request) {
+		var join joinRequest
+		if err := json.NewDecoder(req.Body).Decode(&join); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		a, err := c.join(join)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(a)
+	})
+	mux.HandleFunc("POST /results", func(w http.ResponseWriter, req *http.Request) {
+		var upload resultUpload
+		if err := json.NewDecoder(req.Body).Decode(&upload); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := c.addResults(upload); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	log.Printf("Experiment coordinator listening on %s, waiting for %d participant(s)", addr, c.participants)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		log.Fatalf("Coordinator stopped: %v", err)
+	}
+}
+
+// joinBarrier 加入其他进程运行的协调者；协调者尚未启动时重试，直到 joinTimeout
+func joinBarrier(client *http.Client, coordinatorURL string, req joinRequest) (assignment, error) {
+	body, _ := json.Marshal(req)
+	deadline := time.Now().Add(joinTimeout)
+	for {
+		resp, err := client.Post(coordinatorURL+"/join", "application/json", bytes.NewReader(body))
+		if err != nil {
+			if time.Now().After(deadline) {
+				return assignment{}, fmt.Errorf("coordinator %s unreachable: %w", coordinatorURL, err)
+			}
+			time.Sleep(joinRetryInterval)
+			continue
+		}
+		var a assignment
+		if err := decodeCoordinatorResponse(resp, &a); err != nil {
+			return assignment{}, err
+		}
+		return a, nil
+	}
+}
+
+// uploadResults 将本进程的结果上传给协调者
+func uploadResults(client *http.Client, coordinatorURL string, upload resultUpload) error {
+	body, err := json.Marshal(upload)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Post(coordinatorURL+"/results", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	return decodeCoordinatorResponse(resp, nil)
+}
+
+func decodeCoordinatorResponse(resp *http.Response, data any) error {
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		var msg bytes.Buffer
+		msg.ReadFrom(resp.Body)
+		return fmt.Errorf("HTTP %s: %s", resp.Status, strings.TrimSpace(msg.String()))
+	}
+	if data == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(data); err != nil {
+		return fmt.Errorf("invalid coordinator response: %w", err)
+	}
+	return nil
+}
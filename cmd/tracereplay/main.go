@@ -0,0 +1,439 @@
+// Package main 重放部署请求 trace
+// 按 trace 中的时间间隔（可加速）向测试集群节点的管理 API 重新提交部署与删除请求，
+// 汇总重放结果与原始结果的差异，用于在真实负载上验证调度策略的改动。trace 由节点配置 resource.trace 记录
+// 指定 -status 时在该地址提供实时进度页（/）与 JSON（/status）：提交、完成、失败数，
+// 最近部署的延迟分位数，以及 -server 与 -nodes 各节点的资源利用率。
+// 部署接口返回时实例只是已创建；指定 -wait 时通过 /resource/components/{id}/wait 等待实例结束，
+// 统计从提交到实例真正结束的延迟（适用于 docker、k8s 等异步运行的 provider）
+// 指定 -csv 时按部署写出任务 ID、提交时间、延迟与结果
+//
+// 多节点实验：一个进程以 -coordinate 运行启动屏障，其余进程以 -coordinator 加入，
+// 各进程通常以 -server 指向不同的入口节点。-participants 个进程都加入后同时开始重放，
+// 各进程的任务 ID 区间互不重叠；结束后各进程将结果上传给协调者，由其合并写入 -csv，
+// 用于测量多个入口节点同时提交时的竞争
+//
+// 用法:
+//
+//	tracereplay -server http://test-node:8083 -token <token> -speed 10 -output replay.jsonl deploy_trace.jsonl
+//	tracereplay -server http://test-node:8083 -nodes http://peer:8083 -status :9090 -wait 10m deploy_trace.jsonl
+//	tracereplay -server http://node-a:8083 -coordinate :9100 -participants 2 -csv merged.csv deploy_trace.jsonl
+//	tracereplay -server http://node-b:8083 -coordinator http://node-a:9100 -csv node-b.csv deploy_trace.jsonl
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/trace"
+	"github.com/9triver/iarnet/pkg/client"
+)
+
+func main() {
+	server := flag.String("server", "http://localhost:8083", "iarnet management API address of the test cluster node")
+	token := flag.String("token", os.Getenv("IARNET_TOKEN"), "RBAC access token (defaults to $IARNET_TOKEN)")
+	speed := flag.Float64("speed", 1, "Replay speed-up factor, e.g. 10 replays one hour of trace in six minutes")
+	output := flag.String("output", "", "Write replay results to this file in trace format (optional)")
+	timeout := flag.Int("timeout", 0, "Per-deployment timeout in seconds, 0 means no limit")
+	keep := flag.Bool("keep", false, "Keep components that are still running when the trace ends")
+	status := flag.String("status", "", "Serve live replay progress on this address, e.g. :9090 (optional)")
+	wait := flag.Duration("wait", 0, "Wait up to this long for each deployed component to finish and report completion latency, 0 disables")
+	nodes := flag.String("nodes", "", "Comma-separated management API addresses of other nodes to show utilization for on the status page")
+	csvOutput := flag.String("csv", "", "Write per-deployment results to this CSV file; with -coordinate, results of all participants are merged into it")
+	coordinate := flag.String("coordinate", "", "Host the start barrier of a multi-node experiment on this address, e.g. :9100; this process takes part as well")
+	coordinatorURL := flag.String("coordinator", "", "Join the start barrier hosted by another tracereplay process, e.g. http://node-a:9100")
+	participants := flag.Int("participants", 1, "Number of processes taking part in the experiment, including the coordinator (with -coordinate)")
+	mergeTimeout := flag.Duration("merge-timeout", 30*time.Minute, "How long the coordinator waits for the other participants' results after its own replay (with -coordinate)")
+	flag.Parse()
+
+	if flag.NArg() != 1 || *speed <= 0 || *timeout < 0 || *wait < 0 || *participants < 1 || *mergeTimeout < 0 ||
+		(*coordinate != "" && *coordinatorURL != "") {
+		fmt.Fprintln(os.Stderr, "usage: tracereplay [-server url] [-token token] [-speed n] [-output file] [-csv file] [-timeout seconds] [-wait duration] [-keep] [-status addr] [-nodes urls] [-coordinate addr -participants n [-merge-timeout duration] | -coordinator url] <trace.jsonl>")
+		os.Exit(2)
+	}
+	entries, err := trace.Load(flag.Arg(0))
+	if err != nil {
+		log.Fatalf("Load trace: %v", err)
+	}
+	if len(entries) == 0 {
+		log.Fatalf("Trace %s is empty", flag.Arg(0))
+	}
+
+	api, err := client.New(*server, client.WithToken(*token))
+	if err != nil {
+		log.Fatalf("Invalid -server: %v", err)
+	}
+	r := &replayer{
+		api:     api,
+		server:  api.Server(),
+		timeout: *timeout,
+		wait:    *wait,
+		pending: make(map[string]*replayed),
+	}
+	*coordinatorURL = strings.TrimSuffix(*coordinatorURL, "/")
+	if *output != "" {
+		if r.recorder, err = trace.NewRecorder(*output); err != nil {
+			log.Fatalf("Open output: %v", err)
+		}
+		defer r.recorder.Close()
+	}
+
+	if *status != "" {
+		clients := []*client.Client{api}
+		for _, node := range strings.Split(*nodes, ",") {
+			if node = strings.TrimSuffix(strings.TrimSpace(node), "/"); node != "" && node != r.server {
+				c, err := client.New(node, client.WithToken(*token))
+				if err != nil {
+					log.Fatalf("Invalid -nodes: %v", err)
+				}
+				clients = append(clients, c)
+			}
+		}
+		r.progress = newProgress(countDeploys(entries), clients)
+		stop := make(chan struct{})
+		defer close(stop)
+		go r.progress.pollNodes(stop)
+		go r.progress.serve(*status)
+	}
+
+	var coord *coordinator
+	if *coordinate != "" || *coordinatorURL != "" {
+		join := joinRequest{Server: r.server, Deploys: countDeploys(entries)}
+		var a assignment
+		if *coordinate != "" {
+			coord = newCoordinator(*participants)
+			go coord.serve(*coordinate)
+			a, err = coord.join(join)
+		} else {
+			log.Printf("Joining experiment coordinator %s", *coordinatorURL)
+			a, err = joinBarrier(http.DefaultClient, *coordinatorURL, join)
+		}
+		if err != nil {
+			log.Fatalf("Join start barrier: %v", err)
+		}
+		r.participant = a.Index
+		r.nextTaskID = a.TaskIDBase
+		log.Printf("Participant %d of %d, task IDs from %d, starting in %dms", a.Index, a.Participants, a.TaskIDBase, a.StartInMs)
+		time.Sleep(time.Duration(a.StartInMs) * time.Millisecond)
+	}
+
+	log.Printf("Replaying %d trace entries against %s at %gx speed", len(entries), r.server, *speed)
+	r.run(entries, *speed)
+	if !*keep {
+		r.cleanup()
+	}
+	r.summary.print()
+
+	results := r.results
+	switch {
+	case coord != nil:
+		coord.addResults(resultUpload{Index: r.participant, Results: results})
+		if *participants > 1 {
+			log.Printf("Waiting for results from the other participants")
+		}
+		if results, err = coord.mergedResults(*mergeTimeout); err != nil {
+			log.Printf("Merging partial results: %v", err)
+		}
+	case *coordinatorURL != "":
+		if err := uploadResults(http.DefaultClient, *coordinatorURL, resultUpload{Index: r.participant, Results: results}); err != nil {
+			log.Printf("Upload results to coordinator: %v", err)
+		}
+	}
+	if *csvOutput != "" {
+		if err := writeResultsCSV(*csvOutput, results); err != nil {
+			log.Fatalf("Write results: %v", err)
+		}
+		log.Printf("Wrote %d result(s) to %s", len(results), *csvOutput)
+	}
+}
+
+// replayed 一次重放的部署，done 关闭后 componentID 可用（部署失败时为空）
+type replayed struct {
+	done        chan struct{}
+	componentID string
+	undeployed  bool
+}
+
+type replayer struct {
+	api      *client.Client
+	server   string
+	timeout  int
+	wait     time.Duration // 等待实例结束的最长时间，0 表示不等待
+	recorder *trace.Recorder
+	progress *progress // 未指定 -status 时为 nil
+
+	participant int // 多节点实验中的参与者序号
+	nextTaskID  int // 下一个部署的任务 ID，多节点实验中从协调者分配的区间起点开始
+
+	mu      sync.Mutex
+	pending map[string]*replayed // 原始 component ID -> 重放的部署
+	results []*taskResult
+	summary summary
+	wg      sync.WaitGroup
+}
+
+// run 按 trace 的时间间隔提交请求，等待所有请求完成后返回
+func (r *replayer) run(entries []*trace.Entry, speed float64) {
+	base := entries[0].Time
+	start := time.Now()
+	for _, entry := range entries {
+		offset := time.Duration(float64(entry.Time.Sub(base)) / speed)
+		if wait := time.Until(start.Add(offset)); wait > 0 {
+			time.Sleep(wait)
+		}
+		switch entry.Event {
+		case trace.EventDeploy:
+			if entry.Request == nil {
+				continue
+			}
+			rep := &replayed{done: make(chan struct{})}
+			result := &taskResult{TaskID: r.nextTaskID, Participant: r.participant, Server: r.server}
+			r.nextTaskID++
+			r.mu.Lock()
+			if entry.ComponentID != "" {
+				r.pending[entry.ComponentID] = rep
+			}
+			r.results = append(r.results, result)
+			r.mu.Unlock()
+			r.progress.submit()
+			r.wg.Add(1)
+			go func() {
+				defer r.wg.Done()
+				r.deploy(entry, rep, result)
+			}()
+		case trace.EventUndeploy:
+			// 只重放成功的删除，失败的删除在原环境中并未释放资源
+			r.mu.Lock()
+			rep, ok := r.pending[entry.ComponentID]
+			r.mu.Unlock()
+			if !ok || !entry.Success {
+				continue
+			}
+			r.wg.Add(1)
+			go func() {
+				defer r.wg.Done()
+				<-rep.done
+				r.undeploy(rep)
+			}()
+		}
+	}
+	r.wg.Wait()
+}
+
+func (r *replayer) deploy(original *trace.Entry, rep *replayed, task *taskResult) {
+	defer close(rep.done)
+
+	req := original.Request
+	startedAt := time.Now()
+	comp, err := r.api.DeployComponent(context.Background(), &client.DeployRequest{
+		RuntimeEnv:     req.RuntimeEnv,
+		CPU:            req.CPU,
+		Memory:         req.Memory,
+		GPU:            req.GPU,
+		Tags:           req.Tags,
+		NodeSelector:   req.NodeSelector,
+		TimeoutSeconds: r.timeout,
+	})
+	latency := time.Since(startedAt)
+	var componentID string
+	if err == nil {
+		componentID = comp.ID
+	}
+	rep.componentID = componentID
+
+	result := &trace.Entry{
+		Time:        startedAt,
+		Event:       trace.EventDeploy,
+		ComponentID: componentID,
+		Request:     req,
+		Success:     err == nil,
+		LatencyMs:   latency.Milliseconds(),
+	}
+	if err != nil {
+		result.Error = err.Error()
+		log.Printf("Deploy failed (originally success=%t): %v", original.Success, err)
+	}
+	r.record(result)
+
+	r.mu.Lock()
+	r.summary.add(original, err == nil, latency)
+	task.SubmittedAt = startedAt
+	task.ComponentID = componentID
+	task.Success = err == nil
+	task.LatencyMs = result.LatencyMs
+	task.Error = result.Error
+	r.mu.Unlock()
+	r.progress.finish(err == nil, latency)
+
+	if err == nil && r.wait > 0 {
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			r.waitCompletion(componentID, startedAt, task)
+		}()
+	}
+}
+
+// waitCompletion 长轮询等待实例结束，统计从提交到结束的延迟
+// 实例在结束前被删除（trace 中的删除先于结束）时不计入
+func (r *replayer) waitCompletion(componentID string, submittedAt time.Time, task *taskResult) {
+	deadline := submittedAt.Add(r.wait)
+	for {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			r.mu.Lock()
+			r.summary.unfinished++
+			r.mu.Unlock()
+			return
+		}
+		c, err := r.api.WaitComponent(context.Background(), componentID, min(remaining, time.Minute))
+		if err != nil {
+			log.Printf("Wait for component %s failed: %v", componentID, err)
+			r.mu.Lock()
+			r.summary.unfinished++
+			r.mu.Unlock()
+			return
+		}
+		if !c.Finished {
+			continue
+		}
+		if c.State != "exited" {
+			return
+		}
+		latency := time.Since(submittedAt)
+		r.mu.Lock()
+		r.summary.addCompletion(c.ExitCode, latency)
+		task.CompletionMs = latency.Milliseconds()
+		r.mu.Unlock()
+		r.progress.finishInstance(latency)
+		return
+	}
+}
+
+func (r *replayer) undeploy(rep *replayed) {
+	r.mu.Lock()
+	if rep.componentID == "" || rep.undeployed {
+		r.mu.Unlock()
+		return
+	}
+	rep.undeployed = true
+	r.mu.Unlock()
+
+	startedAt := time.Now()
+	err := r.api.UndeployComponent(context.Background(), rep.componentID)
+	result := &trace.Entry{
+		Time:        startedAt,
+		Event:       trace.EventUndeploy,
+		ComponentID: rep.componentID,
+		Success:     err == nil,
+		LatencyMs:   time.Since(startedAt).Milliseconds(),
+	}
+	if err != nil {
+		result.Error = err.Error()
+		log.Printf("Undeploy %s failed: %v", rep.componentID, err)
+	}
+	r.record(result)
+}
+
+// cleanup 删除 trace 结束时仍在运行的 component，避免占用测试集群
+func (r *replayer) cleanup() {
+	r.mu.Lock()
+	reps := make([]*replayed, 0, len(r.pending))
+	for _, rep := range r.pending {
+		reps = append(reps, rep)
+	}
+	r.mu.Unlock()
+	for _, rep := range reps {
+		r.undeploy(rep)
+	}
+}
+
+func (r *replayer) record(entry *trace.Entry) {
+	if r.recorder == nil {
+		return
+	}
+	if err := r.recorder.Record(entry); err != nil {
+		log.Printf("Failed to write replay result: %v", err)
+	}
+}
+
+// countDeploys trace 中会被重放的部署数
+func countDeploys(entries []*trace.Entry) int {
+	n := 0
+	for _, entry := range entries {
+		if entry.Event == trace.EventDeploy && entry.Request != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// summary 重放结果与原始结果的对比
+type summary struct {
+	deploys         int
+	originalSuccess int
+	replaySuccess   int
+	regressed       int // 原本成功、重放失败
+	improved        int // 原本失败、重放成功
+	originalLatency time.Duration
+	replayLatency   time.Duration
+
+	// 指定 -wait 时统计
+	finished          int // 实例已结束
+	exitedNonZero     int // 以非零退出码结束
+	unfinished        int // 等待超时或查询失败
+	completionLatency time.Duration
+}
+
+func (s *summary) addCompletion(exitCode int32, latency time.Duration) {
+	s.finished++
+	if exitCode != 0 {
+		s.exitedNonZero++
+	}
+	s.completionLatency += latency
+}
+
+func (s *summary) add(original *trace.Entry, success bool, latency time.Duration) {
+	s.deploys++
+	if original.Success {
+		s.originalSuccess++
+	}
+	if success {
+		s.replaySuccess++
+	}
+	switch {
+	case original.Success && !success:
+		s.regressed++
+	case !original.Success && success:
+		s.improved++
+	}
+	s.originalLatency += time.Duration(original.LatencyMs) * time.Millisecond
+	s.replayLatency += latency
+}
+
+func (s *summary) print() {
+	if s.deploys == 0 {
+		fmt.Println("No deployments replayed")
+		return
+	}
+	n := time.Duration(s.deploys)
+	fmt.Printf("Deployments:       %d\n", s.deploys)
+	fmt.Printf("Success (orig):    %d (%.1f%%)\n", s.originalSuccess, 100*float64(s.originalSuccess)/float64(s.deploys))
+	fmt.Printf("Success (replay):  %d (%.1f%%)\n", s.replaySuccess, 100*float64(s.replaySuccess)/float64(s.deploys))
+	fmt.Printf("Regressed:         %d\n", s.regressed)
+	fmt.Printf("Improved:          %d\n", s.improved)
+	fmt.Printf("Mean latency:      %s (orig) / %s (replay)\n",
+		(s.originalLatency / n).Round(time.Millisecond), (s.replayLatency / n).Round(time.Millisecond))
+	if s.finished > 0 || s.unfinished > 0 {
+		fmt.Printf("Finished:          %d (%d non-zero exit, %d did not finish)\n", s.finished, s.exitedNonZero, s.unfinished)
+	}
+	if s.finished > 0 {
+		fmt.Printf("Mean completion:   %s\n", (s.completionLatency / time.Duration(s.finished)).Round(time.Millisecond))
+	}
+}
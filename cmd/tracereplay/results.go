@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// taskResult 一次重放部署的结果，按 -csv 写出；多节点实验中由各参与者上传给协调者合并
+type taskResult struct {
+	TaskID       int       `json:"task_id"`     // 实验内唯一，多节点实验中各参与者的区间互不重叠
+	Participant  int       `json:"participant"` // 参与者序号，单节点重放时为 0
+	Server       string    `json:"server"`
+	SubmittedAt  time.Time `json:"submitted_at"`
+	ComponentID  string    `json:"component_id"`
+	Success      bool      `json:"success"`
+	LatencyMs    int64     `json:"latency_ms"`
+	CompletionMs int64     `json:"completion_ms"` // 指定 -wait 时从提交到实例结束的延迟，未结束为 0
+	Error        string    `json:"error"`
+}
+
+// writeResultsCSV 按提交时间排序写出结果，同一时刻按任务 ID 排序
+func writeResultsCSV(path string, results []*taskResult) error {
+	sorted := slices.Clone(results)
+	slices.SortFunc(sorted, func(a, b *taskResult) int {
+		if c := a.SubmittedAt.Compare(b.SubmittedAt); c != 0 {
+			return c
+		}
+		return a.TaskID - b.TaskID
+	})
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	w.Write([]string{"task_id", "participant", "server", "submitted_at", "component_id", "success", "latency_ms", "completion_ms", "error"})
+	for _, r := range sorted {
+		w.Write([]string{
+			strconv.Itoa(r.TaskID),
+			strconv.Itoa(r.Participant),
+			r.Server,
+			r.SubmittedAt.Format(time.RFC3339Nano),
+			r.ComponentID,
+			strconv.FormatBool(r.Success),
+			strconv.FormatInt(r.LatencyMs, 10),
+			strconv.FormatInt(r.CompletionMs, 10),
+			r.Error,
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return file.Close()
+}
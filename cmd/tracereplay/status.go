@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet/pkg/client"
+)
+
+// latencyWindow 滚动延迟分位数统计的最近部署数
+const latencyWindow = 200
+
+// utilizationInterval 轮询节点利用率的间隔
+const utilizationInterval = 5 * time.Second
+
+// progress 重放进度，由 -status 指定的 HTTP 地址实时提供
+type progress struct {
+	mu                sync.Mutex
+	startedAt         time.Time
+	total             int // trace 中待重放的部署数
+	submitted         int
+	completed         int
+	failed            int
+	finished          int // 指定 -wait 时实例已结束的部署数
+	deployLatency     window
+	completionLatency window                 // 指定 -wait 时从提交到实例结束的延迟
+	nodes             map[string]*nodeStatus // 管理 API 地址 -> 最近一次利用率
+	clients           []*client.Client       // 轮询利用率的节点
+}
+
+// window 最近 latencyWindow 个延迟样本，环形缓冲
+type window struct {
+	samples []time.Duration
+	next    int
+}
+
+func (w *window) add(latency time.Duration) {
+	if len(w.samples) < latencyWindow {
+		w.samples = append(w.samples, latency)
+	} else {
+		w.samples[w.next] = latency
+	}
+	w.next = (w.next + 1) % latencyWindow
+}
+
+func (w *window) stats() latencyStats {
+	n := len(w.samples)
+	if n == 0 {
+		return latencyStats{}
+	}
+	sorted := slices.Clone(w.samples)
+	slices.Sort(sorted)
+	at := func(q float64) int64 { return sorted[int(q*float64(n-1))].Milliseconds() }
+	return latencyStats{Samples: n, P50: at(0.5), P90: at(0.9), P99: at(0.99), Max: sorted[n-1].Milliseconds()}
+}
+
+// nodeStatus 节点利用率快照
+type nodeStatus struct {
+	Server string `json:"server"`
+	client.NodeUtilization
+	SampledAt time.Time `json:"sampled_at"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// statusSnapshot /status 的响应
+type statusSnapshot struct {
+	StartedAt time.Time     `json:"started_at"`
+	Elapsed   string        `json:"elapsed"`
+	Total     int           `json:"total"`
+	Submitted int           `json:"submitted"`
+	Completed int           `json:"completed"`
+	Failed    int           `json:"failed"`
+	InFlight  int           `json:"in_flight"`
+	Finished  int           `json:"finished"`
+	Latency   latencyStats  `json:"latency"`            // 提交到部署返回
+	Finish    latencyStats  `json:"completion_latency"` // 提交到实例结束，指定 -wait 时有效
+	Nodes     []*nodeStatus `json:"nodes"`
+}
+
+// latencyStats 最近 latencyWindow 个样本的延迟分位数（毫秒）
+type latencyStats struct {
+	Samples int   `json:"samples"`
+	P50     int64 `json:"p50_ms"`
+	P90     int64 `json:"p90_ms"`
+	P99     int64 `json:"p99_ms"`
+	Max     int64 `json:"max_ms"`
+}
+
+func newProgress(total int, clients []*client.Client) *progress {
+	p := &progress{
+		startedAt: time.Now(),
+		total:     total,
+		nodes:     make(map[string]*nodeStatus, len(clients)),
+		clients:   clients,
+	}
+	for _, c := range clients {
+		p.nodes[c.Server()] = &nodeStatus{Server: c.Server()}
+	}
+	return p
+}
+
+func (p *progress) submit() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	p.submitted++
+	p.mu.Unlock()
+}
+
+func (p *progress) finish(success bool, latency time.Duration) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if success {
+		p.completed++
+	} else {
+		p.failed++
+	}
+	p.deployLatency.add(latency)
+}
+
+// finishInstance 记录实例结束，latency 为从提交到结束的时间
+func (p *progress) finishInstance(latency time.Duration) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.finished++
+	p.completionLatency.add(latency)
+}
+
+func (p *progress) snapshot() *statusSnapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	s := &statusSnapshot{
+		StartedAt: p.startedAt,
+		Elapsed:   time.Since(p.startedAt).Round(time.Second).String(),
+		Total:     p.total,
+		Submitted: p.submitted,
+		Completed: p.completed,
+		Failed:    p.failed,
+		InFlight:  p.submitted - p.completed - p.failed,
+		Finished:  p.finished,
+		Latency:   p.deployLatency.stats(),
+		Finish:    p.completionLatency.stats(),
+	}
+	for _, node := range p.nodes {
+		copied := *node
+		s.Nodes = append(s.Nodes, &copied)
+	}
+	sort.Slice(s.Nodes, func(i, j int) bool { return s.Nodes[i].Server < s.Nodes[j].Server })
+	return s
+}
+
+// pollNodes 定期查询各节点的利用率，直到 stop 关闭
+func (p *progress) pollNodes(stop <-chan struct{}) {
+	ticker := time.NewTicker(utilizationInterval)
+	defer ticker.Stop()
+	for {
+		for _, c := range p.clients {
+			node := &nodeStatus{Server: c.Server()}
+			if utilization, err := c.GetNodeUtilization(context.Background()); err != nil {
+				node.Error = err.Error()
+			} else {
+				node.NodeUtilization = *utilization
+			}
+			node.SampledAt = time.Now()
+			p.mu.Lock()
+			p.nodes[node.Server] = node
+			p.mu.Unlock()
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// serve 提供 / 状态页和 /status JSON
+func (p *progress) serve(addr string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(p.snapshot())
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/" {
+			http.NotFound(w, req)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := statusPage.Execute(w, p.snapshot()); err != nil {
+			log.Printf("Render status page: %v", err)
+		}
+	})
+	log.Printf("Replay status available at http://%s/", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		log.Printf("Status server stopped: %v", err)
+	}
+}
+
+var statusPage = template.Must(template.New("status").Funcs(template.FuncMap{
+	"percent": func(used, total int64) string {
+		if total == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%.1f%%", 100*float64(used)/float64(total))
+	},
+	"gib": func(bytes int64) string {
+		return fmt.Sprintf("%.1f", float64(bytes)/(1<<30))
+	},
+	"components": func(node *nodeStatus) int {
+		n := 0
+		for _, p := range node.Providers {
+			n += p.Components
+		}
+		return n
+	},
+}).Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><meta http-equiv="refresh" content="2"><title>tracereplay</title>
+<style>body{font-family:sans-serif;margin:2em}table{border-collapse:collapse;margin-bottom:1.5em}td,th{border:1px solid #ccc;padding:4px 10px;text-align:right}th{background:#f4f4f4}</style>
+</head><body>
+<h2>Trace replay — {{.Elapsed}}</h2>
+<table>
+<tr><th>Total</th><th>Submitted</th><th>In flight</th><th>Completed</th><th>Failed</th><th>Finished</th></tr>
+<tr><td>{{.Total}}</td><td>{{.Submitted}}</td><td>{{.InFlight}}</td><td>{{.Completed}}</td><td>{{.Failed}}</td><td>{{.Finished}}</td></tr>
+</table>
+<h3>Latency</h3>
+<table>
+<tr><th></th><th>samples</th><th>p50</th><th>p90</th><th>p99</th><th>max</th></tr>
+<tr><th>deploy</th><td>{{.Latency.Samples}}</td><td>{{.Latency.P50}} ms</td><td>{{.Latency.P90}} ms</td><td>{{.Latency.P99}} ms</td><td>{{.Latency.Max}} ms</td></tr>
+{{if .Finish.Samples}}<tr><th>completion</th><td>{{.Finish.Samples}}</td><td>{{.Finish.P50}} ms</td><td>{{.Finish.P90}} ms</td><td>{{.Finish.P99}} ms</td><td>{{.Finish.Max}} ms</td></tr>{{end}}
+</table>
+<h3>Node utilization</h3>
+<table>
+<tr><th>Node</th><th>CPU (m)</th><th>Memory (GiB)</th><th>GPU</th><th>Providers</th><th>Components</th><th>Sampled</th></tr>
+{{range .Nodes}}<tr><td>{{if .NodeName}}{{.NodeName}}{{else}}{{.Server}}{{end}}</td>
+{{if .Error}}<td colspan="6">{{.Error}}</td>{{else}}
+<td>{{.Used.CPU}} / {{.Total.CPU}} ({{percent .Used.CPU .Total.CPU}})</td>
+<td>{{gib .Used.Memory}} / {{gib .Total.Memory}} ({{percent .Used.Memory .Total.Memory}})</td>
+<td>{{.Used.GPU}} / {{.Total.GPU}}</td>
+<td>{{len .Providers}}</td><td>{{components .}}</td><td>{{.SampledAt.Format "15:04:05"}}</td>{{end}}</tr>
+{{end}}</table>
+</body></html>`))
@@ -29,6 +29,7 @@ from proto.common import messages_pb2 as common_messages
 from proto.resource.component import component_pb2 as component
 
 from encdec import EncDec
+from framing import accept_encodings, decode_frame, encode_frame
 from models import RemoteFunction
 from store_client import StoreClient
 
@@ -484,7 +485,7 @@ class Actor:
         logger.info("Waiting for Function message...")
         while True:
             try:
-                msg_bytes = socket.recv()
+                msg_bytes = decode_frame(socket.recv())
                 # 首先解析为 component.Message
                 component_msg = component.Message.FromString(msg_bytes)
 
@@ -505,7 +506,8 @@ class Actor:
                         )
                         ack_component_msg = self._wrap_actor_message(
                             ack_actor_msg)
-                        socket.send(ack_component_msg.SerializeToString())
+                        socket.send(encode_frame(
+                            ack_component_msg.SerializeToString()))
                         return True
                     else:
                         return False
@@ -531,7 +533,7 @@ class Actor:
         """
         while True:
             try:
-                msg_bytes = socket.recv()
+                msg_bytes = decode_frame(socket.recv())
                 # 首先解析为 component.Message
                 component_msg = component.Message.FromString(msg_bytes)
 
@@ -576,7 +578,8 @@ class Actor:
                 # 如果消息是 actor.Message，需要包装为 component.Message
                 if isinstance(msg, actor.Message):
                     component_msg = self._wrap_actor_message(msg)
-                    socket.send(component_msg.SerializeToString())
+                    socket.send(encode_frame(
+                        component_msg.SerializeToString()))
                 else:
                     logger.error(f"Unknown message type: {type(msg)}")
             except Exception as e:
@@ -609,9 +612,10 @@ class Actor:
         logger.info(f"Connected to ZMQ: {zmq_addr}")
 
         # 发送初始 READY 消息，让 Go 端识别此 Actor 并发送缓存的 Function 消息
+        # 同时声明可解压的帧压缩算法，Go 端据此压缩大消息
         ready_msg = component.Message(
             Type=component.MessageType.READY,
-            Ready=common_messages.Ready()
+            Ready=common_messages.Ready(AcceptEncodings=accept_encodings())
         )
         socket.send(ready_msg.SerializeToString())
         logger.info("Initial READY message sent to identify actor")
@@ -0,0 +1,59 @@
+"""
+ZMQ 帧压缩
+与 Go 端 internal/transport/zmq/frame.go 的帧格式一致：
+压缩帧为 [0x00, codec, payload...]，合法的 protobuf 消息首字节不为 0，未压缩的帧原样传输
+"""
+
+import gzip
+
+try:
+    import zstandard
+except ImportError:  # zstd 为可选依赖，缺失时只声明 gzip
+    zstandard = None
+
+FRAME_MARKER = 0x00
+
+CODEC_NONE = 0
+CODEC_GZIP = 1
+CODEC_ZSTD = 2
+
+# 小于该大小的消息不压缩
+MIN_COMPRESS_BYTES = 64 * 1024
+
+
+def accept_encodings() -> list[str]:
+    """返回本组件可解压的算法，在 READY 消息中声明"""
+    encodings = ["gzip"]
+    if zstandard is not None:
+        encodings.append("zstd")
+    return encodings
+
+
+def encode_frame(data: bytes) -> bytes:
+    """压缩大消息，压缩后不更小时原样返回"""
+    if len(data) < MIN_COMPRESS_BYTES:
+        return data
+    if zstandard is not None:
+        codec, compressed = CODEC_ZSTD, zstandard.ZstdCompressor().compress(data)
+    else:
+        codec, compressed = CODEC_GZIP, gzip.compress(data)
+    if len(compressed) + 2 >= len(data):
+        return data
+    return bytes([FRAME_MARKER, codec]) + compressed
+
+
+def decode_frame(frame: bytes) -> bytes:
+    """解析帧头并解压，未压缩的帧原样返回"""
+    if not frame or frame[0] != FRAME_MARKER:
+        return frame
+    if len(frame) < 2:
+        raise ValueError("truncated compressed frame")
+    codec, payload = frame[1], frame[2:]
+    if codec == CODEC_NONE:
+        return payload
+    if codec == CODEC_GZIP:
+        return gzip.decompress(payload)
+    if codec == CODEC_ZSTD and zstandard is not None:
+        # 流式解压，不依赖帧头中的内容长度
+        return zstandard.ZstdDecompressor().decompressobj().decompress(payload)
+    raise ValueError(f"unsupported frame codec {codec}")
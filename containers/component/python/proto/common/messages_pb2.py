@@ -24,7 +24,7 @@ _sym_db = _symbol_database.Default()
 
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x15\x63ommon/messages.proto\x12\x06\x63ommon\"\x14\n\x03\x41\x63k\x12\r\n\x05\x45rror\x18\x01 \x01(\t\"\x07\n\x05ReadyB1Z/github.com/9triver/iarnet/internal/proto/commonb\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x15\x63ommon/messages.proto\x12\x06\x63ommon\"\x14\n\x03\x41\x63k\x12\r\n\x05\x45rror\x18\x01 \x01(\t\" \n\x05Ready\x12\x17\n\x0f\x41\x63\x63\x65ptEncodings\x18\x01 \x03(\tB1Z/github.com/9triver/iarnet/internal/proto/commonb\x06proto3')
 
 _globals = globals()
 _builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
@@ -35,5 +35,5 @@ if not _descriptor._USE_C_DESCRIPTORS:
   _globals['_ACK']._serialized_start=33
   _globals['_ACK']._serialized_end=53
   _globals['_READY']._serialized_start=55
-  _globals['_READY']._serialized_end=62
+  _globals['_READY']._serialized_end=87
 # @@protoc_insertion_point(module_scope)
@@ -1,5 +1,7 @@
+from google.protobuf.internal import containers as _containers
 from google.protobuf import descriptor as _descriptor
 from google.protobuf import message as _message
+from collections.abc import Iterable as _Iterable
 from typing import ClassVar as _ClassVar, Optional as _Optional
 
 DESCRIPTOR: _descriptor.FileDescriptor
@@ -11,5 +13,7 @@ class Ack(_message.Message):
     def __init__(self, Error: _Optional[str] = ...) -> None: ...
 
 class Ready(_message.Message):
-    __slots__ = ()
-    def __init__(self) -> None: ...
+    __slots__ = ("AcceptEncodings",)
+    ACCEPTENCODINGS_FIELD_NUMBER: _ClassVar[int]
+    AcceptEncodings: _containers.RepeatedScalarFieldContainer[str]
+    def __init__(self, AcceptEncodings: _Optional[_Iterable[str]] = ...) -> None: ...
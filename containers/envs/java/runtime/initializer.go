@@ -0,0 +1,173 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+	"time"
+
+	proto "github.com/9triver/ignis/proto"
+	"github.com/9triver/ignis/proto/cluster"
+	"github.com/9triver/ignis/utils/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// executorMainClass 执行器入口类
+	executorMainClass = "io.iarnet.executor.Executor"
+	// defaultMavenRepository 依赖下载使用的默认 Maven 仓库
+	defaultMavenRepository = "https://repo1.maven.org/maven2"
+)
+
+// Initializer Java 运行时初始化器
+// 函数定义中的 PickledObject 为函数所在的 JAR 包，由执行器加载并通过标准输入输出桥接调用
+type Initializer struct {
+	timeout     time.Duration
+	executorJar string
+	workDir     string
+	javaExec    string
+	cmd         *exec.Cmd
+	stdin       io.WriteCloser
+	stdout      io.ReadCloser
+}
+
+func NewInitializer(workDir string, executorJar string) (*Initializer, error) {
+	if err := os.MkdirAll(path.Join(workDir, "lib"), 0755); err != nil {
+		return nil, errors.WrapWith(err, "java %s: path creation failed", workDir)
+	}
+
+	javaExec := "java"
+	if home := os.Getenv("JAVA_HOME"); home != "" {
+		javaExec = path.Join(home, "bin", "java")
+	}
+
+	return &Initializer{
+		timeout:     10 * time.Minute,
+		executorJar: executorJar,
+		workDir:     workDir,
+		javaExec:    javaExec,
+	}, nil
+}
+
+// InstallDependencies 下载依赖 JAR 到 lib 目录
+// 依赖可以是 JAR 的 URL，或 Maven 坐标 group:artifact:version
+func (i *Initializer) InstallDependencies(requirements []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), i.timeout)
+	defer cancel()
+
+	repo := strings.TrimSuffix(strings.TrimSpace(os.Getenv("MAVEN_REPOSITORY")), "/")
+	if repo == "" {
+		repo = defaultMavenRepository
+	}
+
+	for _, r := range requirements {
+		r = strings.TrimSpace(r)
+		if r == "" {
+			continue
+		}
+		url, name, err := resolveRequirement(repo, r)
+		if err != nil {
+			return err
+		}
+		logrus.Infof("downloading java dependency %s", r)
+		if err := download(ctx, url, path.Join(i.workDir, "lib", name)); err != nil {
+			return errors.WrapWith(err, "java: download %s failed", r)
+		}
+	}
+	return nil
+}
+
+// resolveRequirement 将依赖声明解析为下载地址和文件名
+func resolveRequirement(repo, requirement string) (string, string, error) {
+	if strings.HasPrefix(requirement, "http://") || strings.HasPrefix(requirement, "https://") {
+		return requirement, path.Base(requirement), nil
+	}
+	parts := strings.Split(requirement, ":")
+	if len(parts) != 3 {
+		return "", "", fmt.Errorf("invalid java requirement %q, expected group:artifact:version or jar url", requirement)
+	}
+	group, artifact, version := parts[0], parts[1], parts[2]
+	name := fmt.Sprintf("%s-%s.jar", artifact, version)
+	url := fmt.Sprintf("%s/%s/%s/%s/%s", repo, strings.ReplaceAll(group, ".", "/"), artifact, version, name)
+	return url, name, nil
+}
+
+func download(ctx context.Context, url, dst string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	_, err = io.Copy(out, resp.Body)
+	return err
+}
+
+func (i *Initializer) Initialize(ctx context.Context, fn *cluster.Function, addr string, connId string) error {
+	jarPath := path.Join(i.workDir, "function.jar")
+	if err := os.WriteFile(jarPath, fn.PickledObject, 0644); err != nil {
+		return errors.WrapWith(err, "java %s: write jar failed", fn.Name)
+	}
+
+	if err := i.InstallDependencies(fn.Requirements); err != nil {
+		return err
+	}
+	logrus.Infof("java requirements installed for function %s", fn.Name)
+
+	classpath := strings.Join([]string{i.executorJar, jarPath, path.Join(i.workDir, "lib", "*")}, string(os.PathListSeparator))
+	cmd := exec.CommandContext(context.TODO(), i.javaExec, "-cp", classpath, executorMainClass, "--jar", jarPath, "--conn-id", connId)
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return errors.WrapWith(err, "java %s: stdin pipe failed", fn.Name)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return errors.WrapWith(err, "java %s: stdout pipe failed", fn.Name)
+	}
+	if err := cmd.Start(); err != nil {
+		return errors.WrapWith(err, "java %s: executor start failed", fn.Name)
+	}
+
+	i.cmd, i.stdin, i.stdout = cmd, stdin, stdout
+	go func() {
+		if err := cmd.Wait(); err != nil {
+			logrus.Errorf("java executor failed for function %s: %v", fn.Name, err)
+		}
+	}()
+	return nil
+}
+
+// Stdio 返回执行器进程的标准输入与标准输出
+func (i *Initializer) Stdio() (io.WriteCloser, io.ReadCloser) {
+	return i.stdin, i.stdout
+}
+
+func (i *Initializer) Cleanup(ctx context.Context) error {
+	if i.cmd == nil || i.cmd.Process == nil {
+		return nil
+	}
+	i.stdin.Close()
+	return i.cmd.Process.Kill()
+}
+
+func (i *Initializer) Language() proto.Language { return proto.Language_LANG_JAVA }
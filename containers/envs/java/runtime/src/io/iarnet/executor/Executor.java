@@ -0,0 +1,181 @@
+package io.iarnet.executor;
+
+import java.io.BufferedReader;
+import java.io.InputStreamReader;
+import java.io.PrintStream;
+import java.lang.reflect.InvocationTargetException;
+import java.lang.reflect.Method;
+import java.lang.reflect.Modifier;
+import java.nio.charset.StandardCharsets;
+import java.util.ArrayList;
+import java.util.Enumeration;
+import java.util.HashMap;
+import java.util.List;
+import java.util.Map;
+import java.util.concurrent.ConcurrentHashMap;
+import java.util.concurrent.ExecutorService;
+import java.util.concurrent.Executors;
+import java.util.jar.JarEntry;
+import java.util.jar.JarFile;
+
+/**
+ * Java 执行器：加载函数 JAR，并通过标准输入输出与 component 进程交换 JSON Lines 消息。
+ *
+ * <pre>
+ *   请求：{"id": "...", "name": "...", "method": "...", "params": [...], "args": {...}}
+ *   响应：{"id": "...", "result": ...} 或 {"id": "...", "error": "..."}
+ * </pre>
+ *
+ * 标准输出只用于协议消息，用户代码的 System.out 输出重定向到标准错误。
+ */
+public final class Executor {
+    private final JarFile jar;
+    private final PrintStream protocol;
+    private final Map<String, Class<?>> classes = new ConcurrentHashMap<>();
+    private final Map<Class<?>, Object> instances = new ConcurrentHashMap<>();
+
+    private Executor(JarFile jar, PrintStream protocol) {
+        this.jar = jar;
+        this.protocol = protocol;
+    }
+
+    public static void main(String[] argv) throws Exception {
+        Map<String, String> args = parseArgs(argv);
+        String jarPath = args.get("jar");
+        if (jarPath == null) {
+            throw new IllegalArgumentException("--jar is required");
+        }
+
+        PrintStream protocol = new PrintStream(System.out, true, StandardCharsets.UTF_8);
+        System.setOut(System.err);
+        System.err.printf("Starting java executor, conn-id: %s, jar: %s%n", args.get("conn-id"), jarPath);
+
+        Executor executor = new Executor(new JarFile(jarPath), protocol);
+        executor.serve();
+    }
+
+    private static Map<String, String> parseArgs(String[] argv) {
+        Map<String, String> args = new HashMap<>();
+        for (int i = 0; i + 1 < argv.length; i += 2) {
+            args.put(argv[i].replaceFirst("^--", ""), argv[i + 1]);
+        }
+        return args;
+    }
+
+    private void serve() throws Exception {
+        ExecutorService pool = Executors.newCachedThreadPool();
+        send(Map.of("ready", true));
+
+        BufferedReader reader = new BufferedReader(new InputStreamReader(System.in, StandardCharsets.UTF_8));
+        String line;
+        while ((line = reader.readLine()) != null) {
+            if (line.isBlank()) {
+                continue;
+            }
+            Object parsed;
+            try {
+                parsed = Json.parse(line);
+            } catch (RuntimeException e) {
+                System.err.println("invalid request: " + e.getMessage());
+                continue;
+            }
+            @SuppressWarnings("unchecked")
+            Map<String, Object> req = (Map<String, Object>) parsed;
+            pool.submit(() -> handle(req));
+        }
+        pool.shutdown();
+    }
+
+    @SuppressWarnings("unchecked")
+    private void handle(Map<String, Object> req) {
+        Object id = req.get("id");
+        try {
+            String name = (String) req.get("name");
+            String method = (String) req.get("method");
+            Map<String, Object> args = (Map<String, Object>) req.getOrDefault("args", Map.of());
+            List<Object> params = (List<Object>) req.get("params");
+            if (params == null) {
+                params = new ArrayList<>(args.keySet());
+            }
+
+            List<Object> values = new ArrayList<>();
+            for (Object param : params) {
+                values.add(args.get(String.valueOf(param)));
+            }
+
+            reply(id, "result", invoke(name, method, values));
+        } catch (InvocationTargetException e) {
+            reply(id, "error", String.valueOf(e.getTargetException()));
+        } catch (Exception e) {
+            reply(id, "error", String.valueOf(e));
+        }
+    }
+
+    private void reply(Object id, String key, Object value) {
+        Map<String, Object> resp = new HashMap<>();
+        resp.put("id", id);
+        resp.put(key, value);
+        send(resp);
+    }
+
+    private Object invoke(String name, String method, List<Object> values) throws Exception {
+        Class<?> cls = resolveClass(name);
+        Method target = null;
+        for (Method m : cls.getMethods()) {
+            if (m.getName().equals(method) && m.getParameterCount() == values.size()) {
+                target = m;
+                break;
+            }
+        }
+        if (target == null) {
+            throw new NoSuchMethodException(name + "." + method + " with " + values.size() + " parameters");
+        }
+
+        Class<?>[] types = target.getParameterTypes();
+        Object[] converted = new Object[types.length];
+        for (int i = 0; i < types.length; i++) {
+            converted[i] = Json.coerce(values.get(i), types[i]);
+        }
+
+        Object receiver = null;
+        if (!Modifier.isStatic(target.getModifiers())) {
+            receiver = instances.computeIfAbsent(cls, Executor::newInstance);
+        }
+        return target.invoke(receiver, converted);
+    }
+
+    private static Object newInstance(Class<?> cls) {
+        try {
+            return cls.getDeclaredConstructor().newInstance();
+        } catch (ReflectiveOperationException e) {
+            throw new IllegalStateException("cannot instantiate " + cls.getName(), e);
+        }
+    }
+
+    /** 按全限定名或简单类名在函数 JAR 中查找类。 */
+    private Class<?> resolveClass(String name) throws ClassNotFoundException {
+        Class<?> cached = classes.get(name);
+        if (cached != null) {
+            return cached;
+        }
+        Enumeration<JarEntry> entries = jar.entries();
+        while (entries.hasMoreElements()) {
+            String entry = entries.nextElement().getName();
+            if (!entry.endsWith(".class") || entry.contains("$")) {
+                continue;
+            }
+            String className = entry.substring(0, entry.length() - ".class".length()).replace('/', '.');
+            String simpleName = className.substring(className.lastIndexOf('.') + 1);
+            if (className.equals(name) || simpleName.equals(name)) {
+                Class<?> cls = Class.forName(className);
+                classes.put(name, cls);
+                return cls;
+            }
+        }
+        throw new ClassNotFoundException(name);
+    }
+
+    private synchronized void send(Map<String, Object> msg) {
+        protocol.println(Json.stringify(msg));
+    }
+}
@@ -0,0 +1,265 @@
+package io.iarnet.executor;
+
+import java.lang.reflect.Array;
+import java.util.ArrayList;
+import java.util.Collection;
+import java.util.LinkedHashMap;
+import java.util.List;
+import java.util.Map;
+
+/** 执行器使用的最小 JSON 编解码实现，避免引入第三方依赖。 */
+final class Json {
+    private final String src;
+    private int pos;
+
+    private Json(String src) {
+        this.src = src;
+    }
+
+    static Object parse(String src) {
+        Json p = new Json(src);
+        p.skipWhitespace();
+        Object value = p.readValue();
+        p.skipWhitespace();
+        if (p.pos != src.length()) {
+            throw p.error("trailing characters");
+        }
+        return value;
+    }
+
+    static String stringify(Object value) {
+        StringBuilder sb = new StringBuilder();
+        write(sb, value);
+        return sb.toString();
+    }
+
+    /** 将 JSON 解码结果转换为方法参数类型。 */
+    static Object coerce(Object value, Class<?> type) {
+        if (value == null) {
+            if (type.isPrimitive()) {
+                throw new IllegalArgumentException("null cannot be passed as " + type.getName());
+            }
+            return null;
+        }
+        if (value instanceof Number n) {
+            if (type == int.class || type == Integer.class) return n.intValue();
+            if (type == long.class || type == Long.class) return n.longValue();
+            if (type == double.class || type == Double.class) return n.doubleValue();
+            if (type == float.class || type == Float.class) return n.floatValue();
+            if (type == short.class || type == Short.class) return n.shortValue();
+            if (type == byte.class || type == Byte.class) return n.byteValue();
+        }
+        if (type.isInstance(value) || (type == boolean.class && value instanceof Boolean)) {
+            return value;
+        }
+        if (type == String.class) {
+            return stringify(value);
+        }
+        throw new IllegalArgumentException("cannot convert " + value.getClass().getSimpleName() + " to " + type.getName());
+    }
+
+    private Object readValue() {
+        if (pos >= src.length()) {
+            throw error("unexpected end of input");
+        }
+        char c = src.charAt(pos);
+        switch (c) {
+            case '{':
+                return readObject();
+            case '[':
+                return readArray();
+            case '"':
+                return readString();
+            case 't':
+                expect("true");
+                return Boolean.TRUE;
+            case 'f':
+                expect("false");
+                return Boolean.FALSE;
+            case 'n':
+                expect("null");
+                return null;
+            default:
+                return readNumber();
+        }
+    }
+
+    private Map<String, Object> readObject() {
+        Map<String, Object> map = new LinkedHashMap<>();
+        pos++;
+        skipWhitespace();
+        if (peek() == '}') {
+            pos++;
+            return map;
+        }
+        while (true) {
+            skipWhitespace();
+            String key = readString();
+            skipWhitespace();
+            consume(':');
+            skipWhitespace();
+            map.put(key, readValue());
+            skipWhitespace();
+            if (peek() == ',') {
+                pos++;
+                continue;
+            }
+            consume('}');
+            return map;
+        }
+    }
+
+    private List<Object> readArray() {
+        List<Object> list = new ArrayList<>();
+        pos++;
+        skipWhitespace();
+        if (peek() == ']') {
+            pos++;
+            return list;
+        }
+        while (true) {
+            skipWhitespace();
+            list.add(readValue());
+            skipWhitespace();
+            if (peek() == ',') {
+                pos++;
+                continue;
+            }
+            consume(']');
+            return list;
+        }
+    }
+
+    private String readString() {
+        consume('"');
+        StringBuilder sb = new StringBuilder();
+        while (pos < src.length()) {
+            char c = src.charAt(pos++);
+            if (c == '"') {
+                return sb.toString();
+            }
+            if (c != '\\') {
+                sb.append(c);
+                continue;
+            }
+            char esc = src.charAt(pos++);
+            switch (esc) {
+                case 'b' -> sb.append('\b');
+                case 'f' -> sb.append('\f');
+                case 'n' -> sb.append('\n');
+                case 'r' -> sb.append('\r');
+                case 't' -> sb.append('\t');
+                case 'u' -> {
+                    sb.append((char) Integer.parseInt(src.substring(pos, pos + 4), 16));
+                    pos += 4;
+                }
+                default -> sb.append(esc);
+            }
+        }
+        throw error("unterminated string");
+    }
+
+    private Number readNumber() {
+        int start = pos;
+        while (pos < src.length() && "+-0123456789.eE".indexOf(src.charAt(pos)) >= 0) {
+            pos++;
+        }
+        String num = src.substring(start, pos);
+        if (num.isEmpty()) {
+            throw error("unexpected character");
+        }
+        if (num.contains(".") || num.contains("e") || num.contains("E")) {
+            return Double.parseDouble(num);
+        }
+        return Long.parseLong(num);
+    }
+
+    private void expect(String literal) {
+        if (!src.startsWith(literal, pos)) {
+            throw error("expected " + literal);
+        }
+        pos += literal.length();
+    }
+
+    private void consume(char c) {
+        if (peek() != c) {
+            throw error("expected '" + c + "'");
+        }
+        pos++;
+    }
+
+    private char peek() {
+        return pos < src.length() ? src.charAt(pos) : '\0';
+    }
+
+    private void skipWhitespace() {
+        while (pos < src.length() && Character.isWhitespace(src.charAt(pos))) {
+            pos++;
+        }
+    }
+
+    private IllegalArgumentException error(String msg) {
+        return new IllegalArgumentException("invalid json at " + pos + ": " + msg);
+    }
+
+    private static void write(StringBuilder sb, Object value) {
+        if (value == null) {
+            sb.append("null");
+        } else if (value instanceof String s) {
+            writeString(sb, s);
+        } else if (value instanceof Number || value instanceof Boolean) {
+            sb.append(value);
+        } else if (value instanceof Map<?, ?> map) {
+            sb.append('{');
+            boolean first = true;
+            for (Map.Entry<?, ?> e : map.entrySet()) {
+                if (!first) sb.append(',');
+                first = false;
+                writeString(sb, String.valueOf(e.getKey()));
+                sb.append(':');
+                write(sb, e.getValue());
+            }
+            sb.append('}');
+        } else if (value instanceof Collection<?> items) {
+            sb.append('[');
+            boolean first = true;
+            for (Object item : items) {
+                if (!first) sb.append(',');
+                first = false;
+                write(sb, item);
+            }
+            sb.append(']');
+        } else if (value.getClass().isArray()) {
+            sb.append('[');
+            for (int i = 0; i < Array.getLength(value); i++) {
+                if (i > 0) sb.append(',');
+                write(sb, Array.get(value, i));
+            }
+            sb.append(']');
+        } else {
+            writeString(sb, value.toString());
+        }
+    }
+
+    private static void writeString(StringBuilder sb, String s) {
+        sb.append('"');
+        for (int i = 0; i < s.length(); i++) {
+            char c = s.charAt(i);
+            switch (c) {
+                case '"' -> sb.append("\\\"");
+                case '\\' -> sb.append("\\\\");
+                case '\n' -> sb.append("\\n");
+                case '\r' -> sb.append("\\r");
+                case '\t' -> sb.append("\\t");
+                default -> {
+                    if (c < 0x20) {
+                        sb.append(String.format("\\u%04x", (int) c));
+                    } else {
+                        sb.append(c);
+                    }
+                }
+            }
+        }
+        sb.append('"');
+    }
+}
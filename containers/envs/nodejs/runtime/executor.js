@@ -0,0 +1,95 @@
+'use strict';
+
+// Node.js 执行器：加载函数模块，并通过标准输入输出与 component 进程交换 JSON Lines 消息
+//   请求：{"id": "...", "name": "...", "method": "...", "params": [...], "args": {...}}
+//   响应：{"id": "...", "result": ...} 或 {"id": "...", "error": "..."}
+// 标准输出只用于协议消息，用户代码的 console 输出重定向到标准错误
+
+const readline = require('readline');
+
+function parseArgs(argv) {
+  const args = {};
+  for (let i = 0; i < argv.length; i += 2) {
+    args[argv[i].replace(/^--/, '')] = argv[i + 1];
+  }
+  if (!args.module) {
+    throw new Error('--module is required');
+  }
+  return args;
+}
+
+function redirectConsole() {
+  const toStderr = (...items) => process.stderr.write(items.map(String).join(' ') + '\n');
+  console.log = toStderr;
+  console.info = toStderr;
+  console.debug = toStderr;
+}
+
+function send(msg) {
+  process.stdout.write(JSON.stringify(msg) + '\n');
+}
+
+function resolveTarget(mod, name, method) {
+  let target = mod[name];
+  if (target === undefined && typeof mod === 'function' && mod.name === name) {
+    target = mod;
+  }
+  if (target === undefined) {
+    throw new Error(`function ${name} is not exported by module`);
+  }
+  if (!method) {
+    if (typeof target !== 'function') {
+      throw new Error(`${name} is not a function`);
+    }
+    return target;
+  }
+  const fn = target[method];
+  if (typeof fn !== 'function') {
+    throw new Error(`${name}.${method} is not a function`);
+  }
+  return fn.bind(target);
+}
+
+async function handle(mod, req) {
+  try {
+    const fn = resolveTarget(mod, req.name, req.method);
+    const params = req.params || Object.keys(req.args || {});
+    const args = params.map((p) => (req.args || {})[p]);
+    const result = await fn(...args);
+    send({ id: req.id, result: result === undefined ? null : result });
+  } catch (err) {
+    send({ id: req.id, error: err && err.stack ? err.stack : String(err) });
+  }
+}
+
+function main() {
+  const args = parseArgs(process.argv.slice(2));
+  redirectConsole();
+  process.stderr.write(`Starting nodejs executor, conn-id: ${args['conn-id']}, module: ${args.module}\n`);
+
+  const mod = require(args.module);
+
+  const inflight = new Set();
+  const rl = readline.createInterface({ input: process.stdin, crlfDelay: Infinity });
+  rl.on('line', (line) => {
+    if (!line.trim()) {
+      return;
+    }
+    let req;
+    try {
+      req = JSON.parse(line);
+    } catch (err) {
+      process.stderr.write(`invalid request: ${err}\n`);
+      return;
+    }
+    const call = handle(mod, req);
+    inflight.add(call);
+    call.finally(() => inflight.delete(call));
+  });
+  // 标准输入关闭后等待正在执行的调用完成再退出
+  rl.on('close', () => Promise.allSettled([...inflight]).then(() => process.exit(0)));
+
+  send({ ready: true });
+}
+
+main();
@@ -0,0 +1,121 @@
+package runtime
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+	"time"
+
+	proto "github.com/9triver/ignis/proto"
+	"github.com/9triver/ignis/proto/cluster"
+	"github.com/9triver/ignis/utils/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Initializer Node.js 运行时初始化器
+// 函数定义中的 PickledObject 为 CommonJS 模块源码，由 executor.js 加载并通过标准输入输出桥接调用
+type Initializer struct {
+	timeout      time.Duration
+	executorPath string
+	workDir      string
+	cmd          *exec.Cmd
+	stdin        io.WriteCloser
+	stdout       io.ReadCloser
+}
+
+func NewInitializer(workDir string, executorPath string) (*Initializer, error) {
+	if err := os.MkdirAll(workDir, 0755); err != nil {
+		return nil, errors.WrapWith(err, "nodejs %s: path creation failed", workDir)
+	}
+
+	return &Initializer{
+		timeout:      10 * time.Minute,
+		executorPath: executorPath,
+		workDir:      workDir,
+	}, nil
+}
+
+func (i *Initializer) InstallDependencies(requirements []string) error {
+	pkgs := make([]string, 0, len(requirements))
+	for _, r := range requirements {
+		r = strings.TrimSpace(r)
+		if r != "" {
+			pkgs = append(pkgs, r)
+		}
+	}
+	if len(pkgs) == 0 {
+		logrus.Info("no npm requirements provided; skipping installation")
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), i.timeout)
+	defer cancel()
+
+	args := []string{"install", "--no-save", "--no-audit", "--no-fund", "--prefix", i.workDir}
+	// 允许通过环境变量指定 npm 源
+	if registry := strings.TrimSpace(os.Getenv("NPM_REGISTRY")); registry != "" {
+		args = append(args, "--registry", registry)
+	}
+	args = append(args, pkgs...)
+
+	logrus.Infof("installing npm packages: %s", strings.Join(pkgs, ", "))
+	cmd := exec.CommandContext(ctx, "npm", args...)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	return cmd.Run()
+}
+
+func (i *Initializer) Initialize(ctx context.Context, fn *cluster.Function, addr string, connId string) error {
+	modulePath := path.Join(i.workDir, "index.js")
+	if err := os.WriteFile(modulePath, fn.PickledObject, 0644); err != nil {
+		return errors.WrapWith(err, "nodejs %s: write module failed", fn.Name)
+	}
+
+	if err := i.InstallDependencies(fn.Requirements); err != nil {
+		return err
+	}
+	logrus.Infof("npm requirements installed for function %s", fn.Name)
+
+	cmd := exec.CommandContext(context.TODO(), "node", i.executorPath, "--module", modulePath, "--conn-id", connId)
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), "NODE_PATH="+path.Join(i.workDir, "node_modules"))
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return errors.WrapWith(err, "nodejs %s: stdin pipe failed", fn.Name)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return errors.WrapWith(err, "nodejs %s: stdout pipe failed", fn.Name)
+	}
+	if err := cmd.Start(); err != nil {
+		return errors.WrapWith(err, "nodejs %s: executor start failed", fn.Name)
+	}
+
+	i.cmd, i.stdin, i.stdout = cmd, stdin, stdout
+	go func() {
+		if err := cmd.Wait(); err != nil {
+			logrus.Errorf("nodejs executor failed for function %s: %v", fn.Name, err)
+		}
+	}()
+	return nil
+}
+
+// Stdio 返回执行器进程的标准输入与标准输出
+func (i *Initializer) Stdio() (io.WriteCloser, io.ReadCloser) {
+	return i.stdin, i.stdout
+}
+
+func (i *Initializer) Cleanup(ctx context.Context) error {
+	if i.cmd == nil || i.cmd.Process == nil {
+		return nil
+	}
+	i.stdin.Close()
+	return i.cmd.Process.Kill()
+}
+
+func (i *Initializer) Language() proto.Language { return proto.Language_LANG_NODEJS }
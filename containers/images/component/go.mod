@@ -6,7 +6,13 @@ replace github.com/9triver/ignis => ../../../ignis/ignis-go
 
 replace github.com/9triver/iarnet/component/python/runtime => ../../envs/python/runtime
 
+replace github.com/9triver/iarnet/component/java/runtime => ../../envs/java/runtime
+
+replace github.com/9triver/iarnet/component/nodejs/runtime => ../../envs/nodejs/runtime
+
 require (
+	github.com/9triver/iarnet/component/java/runtime v0.0.0-00010101000000-000000000000
+	github.com/9triver/iarnet/component/nodejs/runtime v0.0.0-00010101000000-000000000000
 	github.com/9triver/iarnet/component/python/runtime v0.0.0-00010101000000-000000000000
 	github.com/9triver/ignis v0.0.0-00010101000000-000000000000
 	github.com/asynkron/protoactor-go v0.0.0-20240822202345-3c0e61ca19c9
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+)
+
+// livenessConfig 会话保活与存活检测配置，均可通过环境变量覆盖
+type livenessConfig struct {
+	// KeepaliveTime HTTP/2 keepalive ping 间隔（KEEPALIVE_TIME_SECONDS）
+	// gRPC 服务端默认拒绝间隔小于 5 分钟的 ping，调小前需确认 Ignis 放宽了 EnforcementPolicy
+	KeepaliveTime time.Duration
+	// KeepaliveTimeout 等待 keepalive ping 响应的超时（KEEPALIVE_TIMEOUT_SECONDS），超时后连接被关闭
+	KeepaliveTimeout time.Duration
+	// PingInterval 应用层探测间隔（PING_INTERVAL_SECONDS），探测流量同时维持 NAT 映射
+	PingInterval time.Duration
+	// PingTimeout 单次探测超时（PING_TIMEOUT_SECONDS）
+	PingTimeout time.Duration
+	// MissThreshold 连续多少次探测无响应后重建会话（PING_MISS_THRESHOLD）
+	MissThreshold int
+}
+
+// livenessConfigFromEnv 从环境变量读取存活检测配置，非法值回退为默认值
+//
+// 返回值:
+//   - livenessConfig: 存活检测配置
+func livenessConfigFromEnv() livenessConfig {
+	return livenessConfig{
+		KeepaliveTime:    envSeconds("KEEPALIVE_TIME_SECONDS", 5*time.Minute),
+		KeepaliveTimeout: envSeconds("KEEPALIVE_TIMEOUT_SECONDS", 20*time.Second),
+		PingInterval:     envSeconds("PING_INTERVAL_SECONDS", 30*time.Second),
+		PingTimeout:      envSeconds("PING_TIMEOUT_SECONDS", 10*time.Second),
+		MissThreshold:    envInt("PING_MISS_THRESHOLD", 3),
+	}
+}
+
+func envInt(key string, def int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		logrus.Warnf("invalid %s %q, using default %d", key, v, def)
+		return def
+	}
+	return n
+}
+
+func envSeconds(key string, def time.Duration) time.Duration {
+	return time.Duration(envInt(key, int(def/time.Second))) * time.Second
+}
+
+// dialOption 返回 gRPC keepalive 客户端参数
+func (c livenessConfig) dialOption() grpc.DialOption {
+	return grpc.WithKeepaliveParams(keepalive.ClientParameters{
+		Time:    c.KeepaliveTime,
+		Timeout: c.KeepaliveTimeout,
+	})
+}
+
+// monitorLiveness 周期性地在会话所在连接上发送应用层探测，连续 MissThreshold 次无响应时重建会话
+// 探测使用标准 gRPC 健康检查请求：cluster 协议由 Ignis 定义，没有 ping/pong 消息；
+// 服务端未注册健康检查服务时返回 Unimplemented，同样说明对端可达，视为收到响应
+// 参数:
+//   - ctx: 上下文，结束后停止探测
+//   - conn: 会话所在的 gRPC 连接
+//   - sess: 会话
+//   - cfg: 存活检测配置
+func monitorLiveness(ctx context.Context, conn *grpc.ClientConn, sess *session, cfg livenessConfig) {
+	client := healthpb.NewHealthClient(conn)
+	ticker := time.NewTicker(cfg.PingInterval)
+	defer ticker.Stop()
+
+	misses := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// 断线期间由接收循环负责重连，不计入未响应次数
+		if sess.current() == nil {
+			misses = 0
+			continue
+		}
+
+		pingCtx, cancel := context.WithTimeout(ctx, cfg.PingTimeout)
+		_, err := client.Check(pingCtx, &healthpb.HealthCheckRequest{})
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		if !missed(err) {
+			misses = 0
+			continue
+		}
+
+		misses++
+		logrus.Warnf("Liveness ping missed (%d/%d): %v", misses, cfg.MissThreshold, err)
+		if misses >= cfg.MissThreshold {
+			sess.Reset("liveness ping missed too many times")
+			misses = 0
+		}
+	}
+}
+
+// missed 探测是否未得到对端响应
+func missed(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.DeadlineExceeded, codes.Unavailable, codes.Canceled:
+		return true
+	}
+	return false
+}
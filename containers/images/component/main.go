@@ -5,7 +5,6 @@ package main
 import (
 	"context"
 	"fmt"
-	"io"
 	"os"
 	"os/signal"
 	"strings"
@@ -18,6 +17,8 @@ import (
 	"github.com/asynkron/protoactor-go/actor"
 	"github.com/sirupsen/logrus"
 
+	java "github.com/9triver/iarnet/component/java/runtime"
+	node "github.com/9triver/iarnet/component/nodejs/runtime"
 	py "github.com/9triver/iarnet/component/python/runtime"
 	"github.com/9triver/iarnet/component/runtime"
 	"github.com/9triver/ignis/actor/compute"
@@ -38,7 +39,9 @@ const (
 )
 
 func main() {
-	ctx := context.Background()
+	// 收到关闭信号时取消 ctx，会话流随之关闭且不再重连
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
 	// 从环境变量获取配置
 	ignisAddr := os.Getenv("IGNIS_ADDR")
@@ -52,14 +55,16 @@ func main() {
 	}
 
 	// 创建 gRPC 连接
-	conn, err := createGRPCConnection(ignisAddr)
+	liveness := livenessConfigFromEnv()
+	conn, err := createGRPCConnection(ignisAddr, liveness)
 	if err != nil {
 		logrus.Fatalf("failed to connect to ignis server: %v", err)
 	}
 	defer conn.Close()
 
 	// 创建会话并接收函数定义
-	stream, funcMsg, err := createSessionAndReceiveFunction(ctx, conn, connId)
+	sess := newSession(conn, connId)
+	funcMsg, err := sess.Start(ctx)
 	if err != nil {
 		logrus.Fatalf("failed to create session and receive function: %v", err)
 	}
@@ -70,36 +75,30 @@ func main() {
 		logrus.Fatalf("runtime error: %v", err)
 	}
 
-	// 设置并启动 IPC 管理器
-	ipcAddr := getIPCAddress()
-	if err := cleanupSocketFile(ipcAddr); err != nil {
-		logrus.Warnf("failed to cleanup socket file: %v", err)
-	}
-
-	im := ipc.NewManager(ipcAddr)
-	rm := runtime.NewManager()
-
-	if err := im.Start(ctx); err != nil {
-		logrus.Fatalf("ipc manager start failed: %v", err)
-	}
-
-	// 创建执行连接并启动函数
-	execConn := runtime.NewConnection(ipcAddr, connId, im.NewExecutor(ctx, connId))
-	f, err := rm.Start(ctx, execConn, funcMsg, initializer)
+	f, err := startFunction(ctx, connId, funcMsg, initializer)
 	if err != nil {
-		logrus.Fatalf("runtime manager start failed: %v", err)
+		logrus.Fatalf("runtime start failed: %v", err)
 	}
 
 	logrus.Infof("Function %s loaded and ready for execution", funcMsg.Name)
 
 	// 创建 Actor 系统并注册路由
-	sys := setupActorSystem(connId, stream, f)
+	sys, register := setupActorSystem(connId, sess, f)
+
+	// 会话重连后重新注册 Actor 路由，保证 Ignis 重启后消息仍能送达
+	sess.onReconnect = register
+
+	// 启动消息接收循环（断线后自动重连）
+	go sess.Receive(ctx, func(msg *cluster.Message) {
+		handleMessage(msg, sys.Root)
+	})
 
-	// 启动消息接收循环
-	go receiveMessages(stream, sys.Root)
+	// 启动存活检测，连接假死（如 NAT 超时）时主动重建会话
+	go monitorLiveness(ctx, conn, sess, liveness)
 
 	// 等待优雅关闭信号
 	waitForShutdown()
+	cancel()
 
 	logrus.Info("Shutting down component...")
 }
@@ -107,13 +106,15 @@ func main() {
 // createGRPCConnection 创建到 Ignis 服务器的 gRPC 连接
 // 参数:
 //   - addr: 服务器地址
+//   - liveness: 保活配置
 //
 // 返回值:
 //   - *grpc.ClientConn: gRPC 连接
 //   - error: 连接错误
-func createGRPCConnection(addr string) (*grpc.ClientConn, error) {
+func createGRPCConnection(addr string, liveness livenessConfig) (*grpc.ClientConn, error) {
 	return grpc.NewClient(addr,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		liveness.dialOption(),
 		grpc.WithDefaultCallOptions(
 			grpc.MaxCallRecvMsgSize(maxMessageSize),
 			grpc.MaxCallSendMsgSize(maxMessageSize),
@@ -121,55 +122,47 @@ func createGRPCConnection(addr string) (*grpc.ClientConn, error) {
 	)
 }
 
-// createSessionAndReceiveFunction 创建会话并接收函数定义
+// startFunction 启动函数执行器并返回函数实例
+// 支持标准输入输出桥接的运行时（Java、Node.js）直接通过 Bridge 调用，其余运行时通过 IPC 管理器调用
 // 参数:
 //   - ctx: 上下文
-//   - conn: gRPC 连接
 //   - connId: 连接标识符
+//   - funcMsg: 函数定义
+//   - initializer: 运行时初始化器
 //
 // 返回值:
-//   - grpc.BidiStreamingClient[cluster.Message, cluster.Message]: 双向流
-//   - *cluster.Function: 函数定义
-//   - error: 错误
-func createSessionAndReceiveFunction(
+//   - *runtime.Funciton: 函数实例
+//   - error: 启动错误
+func startFunction(
 	ctx context.Context,
-	conn *grpc.ClientConn,
 	connId string,
-) (grpc.BidiStreamingClient[cluster.Message, cluster.Message], *cluster.Function, error) {
-	client := cluster.NewServiceClient(conn)
-
-	stream, err := client.Session(ctx)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create session stream: %w", err)
-	}
-
-	// 发送 Ready 消息
-	readyMsg := &cluster.Message{
-		Type:   cluster.MessageType_READY,
-		ConnID: connId,
-		Message: &cluster.Message_Ready{
-			Ready: &cluster.Ready{},
-		},
+	funcMsg *cluster.Function,
+	initializer runtime.Initializer,
+) (*runtime.Funciton, error) {
+	if stdio, ok := initializer.(runtime.StdioInitializer); ok {
+		bridge, err := runtime.NewBridge(ctx, stdio, funcMsg, connId)
+		if err != nil {
+			return nil, fmt.Errorf("executor bridge start failed: %w", err)
+		}
+		return runtime.NewFunciton(bridge, funcMsg), nil
 	}
 
-	if err = stream.Send(readyMsg); err != nil {
-		return nil, nil, fmt.Errorf("failed to send ready message: %w", err)
+	// 设置并启动 IPC 管理器
+	ipcAddr := getIPCAddress()
+	if err := cleanupSocketFile(ipcAddr); err != nil {
+		logrus.Warnf("failed to cleanup socket file: %v", err)
 	}
 
-	// 接收函数定义
-	msg, err := stream.Recv()
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to receive message: %w", err)
-	}
+	im := ipc.NewManager(ipcAddr)
+	rm := runtime.NewManager()
 
-	if msg.Type != cluster.MessageType_FUNCTION {
-		return nil, nil, fmt.Errorf("expected function message, but got: %v", msg.Type)
+	if err := im.Start(ctx); err != nil {
+		return nil, fmt.Errorf("ipc manager start failed: %w", err)
 	}
 
-	funcMsg := msg.GetFunction()
-	logrus.Infof("Successfully received function: %s", funcMsg.Name)
-
-	return stream, funcMsg, nil
+	// 创建执行连接并启动函数
+	execConn := runtime.NewConnection(ipcAddr, connId, im.NewExecutor(ctx, connId))
+	return rm.Start(ctx, execConn, funcMsg, initializer)
 }
 
 // getIPCAddress 获取 IPC 地址
@@ -203,69 +196,66 @@ func cleanupSocketFile(ipcAddr string) error {
 // setupActorSystem 创建 Actor 系统并注册所有路由
 // 参数:
 //   - connId: 连接标识符
-//   - stream: gRPC 双向流
+//   - sess: 与 Ignis 的会话
 //   - f: 函数实例
 //
 // 返回值:
 //   - *actor.ActorSystem: Actor 系统实例
+//   - func(): 重新注册所有路由，会话重连后调用
 func setupActorSystem(
 	connId string,
-	stream grpc.BidiStreamingClient[cluster.Message, cluster.Message],
+	sess *session,
 	f *runtime.Funciton,
-) *actor.ActorSystem {
+) (*actor.ActorSystem, func()) {
 	sys := actor.NewActorSystem(utils.WithLogger())
 
-	// 创建并注册 Stub Actor
+	// 创建 Stub Actor
 	stubPid := sys.Root.Spawn(actor.PropsFromProducer(func() actor.Actor {
-		return NewStub(connId, stream)
+		return NewStub(connId, sess)
 	}))
-	router.Register("stub-"+connId, stubPid)
-	router.SetDefaultTarget(stubPid)
 
-	// 创建并注册 Store Actor
+	// 创建 Store Actor
 	sr := store.Spawn(sys.Root, nil, "store-"+connId)
-	router.Register("store"+connId, sr.PID)
 
-	// 创建并注册 Compute Actor
+	// 创建 Compute Actor
 	props := compute.NewActor(connId, f, sr.PID)
 	pid, err := sys.Root.SpawnNamed(props, connId)
 	if err != nil {
 		logrus.Fatalf("failed to spawn compute actor: %v", err)
 	}
-	router.Register(connId, pid)
+
+	// Actor 在重连前后保持不变，只需重新注册路由
+	register := func() {
+		router.Register("stub-"+connId, stubPid)
+		router.SetDefaultTarget(stubPid)
+		router.Register("store"+connId, sr.PID)
+		router.Register(connId, pid)
+	}
+	register()
 
 	logrus.Infof("Actor system initialized with connId: %s", connId)
-	return sys
+	return sys, register
 }
 
-// receiveMessages 持续接收并处理来自流的消息
+// handleMessage 处理来自会话流的消息
 // 参数:
-//   - stream: gRPC 双向流
+//   - msg: 会话消息
 //   - root: Actor 系统的根上下文
-func receiveMessages(
-	stream grpc.BidiStreamingClient[cluster.Message, cluster.Message],
-	root *actor.RootContext,
-) {
-	for {
-		msg, err := stream.Recv()
-		if err == io.EOF {
-			logrus.Info("Stream closed by server")
-			return
-		}
-		if err != nil {
-			logrus.Errorf("Error receiving message: %v", err)
-			return
-		}
+func handleMessage(msg *cluster.Message, root *actor.RootContext) {
+	// 重连握手后 Ignis 可能重新下发函数定义，函数已加载，直接忽略
+	if msg.Type == cluster.MessageType_FUNCTION {
+		logrus.Infof("Ignoring function definition %s re-sent after reconnect", msg.GetFunction().GetName())
+		return
+	}
 
-		m := msg.Unwrap()
-		logrus.Infof("Received message: %+v", m)
+	m := msg.Unwrap()
+	logrus.Infof("Received message: %+v", m)
 
-		// 处理转发消息
-		if forwardMsg, ok := m.(store.ForwardMessage); ok {
-			router.Send(root, forwardMsg.GetTarget(), forwardMsg)
-		} else {
-			logrus.Warnf("unsupported message type: %+v", m)
-		}
+	// 处理转发消息
+	if forwardMsg, ok := m.(store.ForwardMessage); ok {
+		router.Send(root, forwardMsg.GetTarget(), forwardMsg)
+	} else {
+		logrus.Warnf("unsupported message type: %+v", m)
 	}
 }
 
@@ -297,29 +287,51 @@ func getInitializer(language proto.Language) (runtime.Initializer, error) {
 		}
 		return py.NewInitializer(venvPath, executorPath)
 
+	case proto.Language_LANG_JAVA:
+		workDir := os.Getenv("JAVA_WORKDIR")
+		if workDir == "" {
+			workDir = "/app/java"
+		}
+		executorJar := os.Getenv("EXECUTOR_JAR")
+		if executorJar == "" {
+			executorJar = "/app/java/executor.jar"
+		}
+		return java.NewInitializer(workDir, executorJar)
+
+	case proto.Language_LANG_NODEJS:
+		workDir := os.Getenv("NODE_WORKDIR")
+		if workDir == "" {
+			workDir = "/app/node"
+		}
+		executorPath := os.Getenv("EXECUTOR_PATH")
+		if executorPath == "" {
+			executorPath = "/app/node/executor.js"
+		}
+		return node.NewInitializer(workDir, executorPath)
+
 	default:
 		return nil, fmt.Errorf("unsupported language: %v", language)
 	}
 }
 
 // Stub 是消息存根 Actor
-// 负责将本地 Actor 消息转发到 gRPC 流
+// 负责将本地 Actor 消息转发到 Ignis 会话
 type Stub struct {
-	connId string                                                     // 连接标识符
-	stream grpc.BidiStreamingClient[cluster.Message, cluster.Message] // gRPC 双向流
+	connId string   // 连接标识符
+	sess   *session // 与 Ignis 的会话，断线期间发送的消息会被丢弃并记录日志
 }
 
 // NewStub 创建一个新的 Stub Actor 实例
 // 参数:
 //   - connId: 连接标识符
-//   - stream: gRPC 双向流
+//   - sess: 与 Ignis 的会话
 //
 // 返回值:
 //   - *Stub: Stub Actor 实例
-func NewStub(connId string, stream grpc.BidiStreamingClient[cluster.Message, cluster.Message]) *Stub {
+func NewStub(connId string, sess *session) *Stub {
 	return &Stub{
 		connId: connId,
-		stream: stream,
+		sess:   sess,
 	}
 }
 
@@ -348,7 +360,7 @@ func (s *Stub) Receive(ctx actor.Context) {
 	}
 
 	m.ConnID = s.connId
-	if err := s.stream.Send(m); err != nil {
+	if err := s.sess.Send(m); err != nil {
 		ctx.Logger().Error("failed to send message", "msg", m, "error", err)
 	}
 }
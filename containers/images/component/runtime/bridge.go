@@ -0,0 +1,189 @@
+package runtime
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/9triver/ignis/configs"
+	"github.com/9triver/ignis/objects"
+	"github.com/9triver/ignis/proto"
+	"github.com/9triver/ignis/proto/cluster"
+	"github.com/9triver/ignis/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// bridgeReadyTimeout 等待执行器进程就绪的超时时间
+const bridgeReadyTimeout = 2 * time.Minute
+
+// maxBridgeMessageSize 单条 JSON Lines 消息的最大长度
+const maxBridgeMessageSize = 512 * 1024 * 1024
+
+// StdioInitializer 通过标准输入输出桥接执行器进程的运行时初始化器
+// 用于 Java、Node.js 等没有实现 IPC 执行器协议的语言
+type StdioInitializer interface {
+	Initializer
+	// Stdio 返回执行器进程的标准输入与标准输出，Initialize 成功后可用
+	Stdio() (io.WriteCloser, io.ReadCloser)
+}
+
+// bridgeRequest 发送给执行器进程的调用请求（JSON Lines）
+type bridgeRequest struct {
+	ID     string                     `json:"id"`
+	Name   string                     `json:"name"`
+	Method string                     `json:"method,omitempty"`
+	Params []string                   `json:"params"` // 参数声明顺序，执行器据此组装位置参数
+	Args   map[string]json.RawMessage `json:"args"`
+}
+
+// bridgeResponse 执行器进程的响应（JSON Lines）
+// 进程启动完成后先输出 {"ready": true}
+type bridgeResponse struct {
+	ID     string          `json:"id"`
+	Ready  bool            `json:"ready"`
+	Result json.RawMessage `json:"result"`
+	Error  string          `json:"error"`
+}
+
+// Bridge 通过 JSON Lines 协议与执行器进程交换调用请求和结果
+// 参数与返回值只支持 JSON 对象，跨语言时以 JSON 作为通用编码
+type Bridge struct {
+	mu      sync.Mutex
+	params  []string
+	encoder *json.Encoder
+	futures map[string]utils.Future[objects.Interface]
+
+	ready     chan struct{}
+	readyOnce sync.Once     // 执行器可能重复输出就绪消息
+	exited    chan struct{} // 执行器输出结束（进程退出）时关闭
+}
+
+// NewBridge 初始化执行器进程并建立桥接
+func NewBridge(ctx context.Context, initializer StdioInitializer, fn *cluster.Function, connId string) (*Bridge, error) {
+	if err := initializer.Initialize(ctx, fn, "", connId); err != nil {
+		return nil, err
+	}
+	stdin, stdout := initializer.Stdio()
+
+	b := &Bridge{
+		params:  fn.Params,
+		encoder: json.NewEncoder(stdin),
+		futures: make(map[string]utils.Future[objects.Interface]),
+		ready:   make(chan struct{}),
+		exited:  make(chan struct{}),
+	}
+	go b.readLoop(stdout)
+
+	// 执行器未就绪即退出时立即失败；超时后结束执行器进程，避免遗留
+	select {
+	case <-b.ready:
+	case <-b.exited:
+		return nil, fmt.Errorf("%s executor exited before ready", initializer.Language())
+	case <-time.After(bridgeReadyTimeout):
+		if err := initializer.Cleanup(ctx); err != nil {
+			logrus.Warnf("failed to stop %s executor: %v", initializer.Language(), err)
+		}
+		return nil, fmt.Errorf("%s executor not ready after %v", initializer.Language(), bridgeReadyTimeout)
+	}
+	logrus.Infof("%s executor ready for function %s", initializer.Language(), fn.Name)
+	return b, nil
+}
+
+func (b *Bridge) Execute(name, method string, args map[string]objects.Interface) utils.Future[objects.Interface] {
+	fut := utils.NewFuture[objects.Interface](configs.ExecutionTimeout)
+
+	encoded := make(map[string]json.RawMessage, len(args))
+	for param, obj := range args {
+		if _, ok := obj.(*objects.Stream); ok {
+			fut.Reject(fmt.Errorf("param %s: stream arguments are not supported by bridged executors", param))
+			return fut
+		}
+		enc, err := obj.Encode()
+		if err != nil {
+			fut.Reject(err)
+			return fut
+		}
+		if enc.GetLanguage() != proto.Language_LANG_JSON {
+			fut.Reject(fmt.Errorf("param %s: only JSON objects can be passed to bridged executors, got %v", param, enc.GetLanguage()))
+			return fut
+		}
+		encoded[param] = enc.GetData()
+	}
+
+	corrId := utils.GenID()
+	b.mu.Lock()
+	b.futures[corrId] = fut
+	err := b.encoder.Encode(&bridgeRequest{
+		ID:     corrId,
+		Name:   name,
+		Method: method,
+		Params: b.params,
+		Args:   encoded,
+	})
+	if err != nil {
+		delete(b.futures, corrId)
+	}
+	b.mu.Unlock()
+
+	if err != nil {
+		fut.Reject(fmt.Errorf("failed to send request to executor: %w", err))
+	}
+	return fut
+}
+
+// readLoop 读取执行器输出并完成对应的 future
+// 非 JSON 的输出视为执行器日志
+func (b *Bridge) readLoop(stdout io.ReadCloser) {
+	defer close(b.exited)
+	defer stdout.Close()
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), maxBridgeMessageSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		var resp bridgeResponse
+		if err := json.Unmarshal(line, &resp); err != nil {
+			logrus.Info(string(line))
+			continue
+		}
+		if resp.Ready {
+			b.readyOnce.Do(func() { close(b.ready) })
+			continue
+		}
+		b.onResponse(&resp)
+	}
+	if err := scanner.Err(); err != nil {
+		logrus.Errorf("bridge: failed to read executor output: %v", err)
+	}
+
+	// 执行器退出后，所有未完成的调用都失败
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for corrId, fut := range b.futures {
+		fut.Reject(fmt.Errorf("executor exited"))
+		delete(b.futures, corrId)
+	}
+}
+
+func (b *Bridge) onResponse(resp *bridgeResponse) {
+	b.mu.Lock()
+	fut, ok := b.futures[resp.ID]
+	delete(b.futures, resp.ID)
+	b.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	if resp.Error != "" {
+		fut.Reject(fmt.Errorf("%s", resp.Error))
+		return
+	}
+	fut.Resolve(&objects.Remote{
+		Data:     resp.Result,
+		Language: proto.Language_LANG_JSON,
+	})
+}
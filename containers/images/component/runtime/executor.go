@@ -0,0 +1,17 @@
+package runtime
+
+import (
+	"github.com/9triver/ignis/objects"
+	"github.com/9triver/ignis/utils"
+)
+
+// Executor 执行函数调用
+// Manager 通过 IPC 与 Python 执行器通信；Bridge 通过标准输入输出与 Java、Node.js 执行器通信
+type Executor interface {
+	Execute(name, method string, args map[string]objects.Interface) utils.Future[objects.Interface]
+}
+
+var (
+	_ Executor = (*Manager)(nil)
+	_ Executor = (*Bridge)(nil)
+)
@@ -19,10 +19,10 @@ type Funciton struct {
 	requirements  []string
 	pickledObject []byte
 	language      proto.Language
-	manager       *Manager
+	executor      Executor
 }
 
-func NewFunciton(manager *Manager, funcMsg *cluster.Function) *Funciton {
+func NewFunciton(executor Executor, funcMsg *cluster.Function) *Funciton {
 	dec := functions.Declare(funcMsg.Name, funcMsg.Params)
 	return &Funciton{
 		FuncDec:       dec,
@@ -31,7 +31,7 @@ func NewFunciton(manager *Manager, funcMsg *cluster.Function) *Funciton {
 		params:        funcMsg.Params,
 		requirements:  funcMsg.Requirements,
 		pickledObject: funcMsg.PickledObject,
-		manager:       manager,
+		executor:      executor,
 	}
 }
 
@@ -44,7 +44,7 @@ func (f *Funciton) Call(params map[string]objects.Interface) (objects.Interface,
 		obj, method = f.Name(), ""
 	}
 	logrus.Infof("runtime: call function %s.%s with params %v", obj, method, params)
-	result, err := f.manager.Execute(obj, method, params).Result()
+	result, err := f.executor.Execute(obj, method, params).Result()
 	if err != nil {
 		return nil, errors.WrapWith(err, "%s: execution failed", f.name)
 	}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/9triver/ignis/proto/cluster"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+const (
+	// 重连退避的初始等待时间，每次失败后翻倍
+	reconnectInitialBackoff = time.Second
+
+	// 重连退避的最大等待时间
+	reconnectMaxBackoff = 30 * time.Second
+)
+
+// errSessionDisconnected 会话流断开、尚未重连成功
+var errSessionDisconnected = errors.New("session stream is disconnected")
+
+// session 管理与 Ignis 之间的双向会话流
+// 流因 Ignis 重启等原因断开时，按指数退避重新建立会话、重新发送 Ready 握手，并通知调用方重新注册 Actor
+type session struct {
+	client cluster.ServiceClient
+	connId string
+
+	mu     sync.Mutex
+	stream grpc.BidiStreamingClient[cluster.Message, cluster.Message] // 断线期间为 nil
+	cancel context.CancelFunc                                         // 关闭当前会话流
+
+	// onReconnect 会话重新建立后调用，用于重新注册 Actor 路由
+	onReconnect func()
+}
+
+// newSession 创建会话管理器
+// 参数:
+//   - conn: gRPC 连接
+//   - connId: 连接标识符
+//
+// 返回值:
+//   - *session: 会话管理器
+func newSession(conn *grpc.ClientConn, connId string) *session {
+	return &session{
+		client: cluster.NewServiceClient(conn),
+		connId: connId,
+	}
+}
+
+// open 建立新的会话流并发送 Ready 握手
+// 每条会话流使用独立的子 ctx，存活检测失败时可单独关闭当前流触发重连
+func (s *session) open(ctx context.Context) (grpc.BidiStreamingClient[cluster.Message, cluster.Message], context.CancelFunc, error) {
+	streamCtx, cancel := context.WithCancel(ctx)
+	stream, err := s.client.Session(streamCtx)
+	if err != nil {
+		cancel()
+		return nil, nil, fmt.Errorf("failed to create session stream: %w", err)
+	}
+
+	readyMsg := &cluster.Message{
+		Type:   cluster.MessageType_READY,
+		ConnID: s.connId,
+		Message: &cluster.Message_Ready{
+			Ready: &cluster.Ready{},
+		},
+	}
+	if err := stream.Send(readyMsg); err != nil {
+		cancel()
+		return nil, nil, fmt.Errorf("failed to send ready message: %w", err)
+	}
+	return stream, cancel, nil
+}
+
+// Start 首次建立会话并接收函数定义
+// 参数:
+//   - ctx: 上下文，取消后会话流随之关闭
+//
+// 返回值:
+//   - *cluster.Function: 函数定义
+//   - error: 错误
+func (s *session) Start(ctx context.Context) (*cluster.Function, error) {
+	stream, cancel, err := s.open(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := stream.Recv()
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to receive message: %w", err)
+	}
+	if msg.Type != cluster.MessageType_FUNCTION {
+		cancel()
+		return nil, fmt.Errorf("expected function message, but got: %v", msg.Type)
+	}
+
+	funcMsg := msg.GetFunction()
+	logrus.Infof("Successfully received function: %s", funcMsg.Name)
+
+	s.setStream(stream, cancel)
+	return funcMsg, nil
+}
+
+// Send 通过当前会话流发送消息
+// 断线期间返回 errSessionDisconnected，发送失败时将流标记为断开，由接收循环负责重连
+func (s *session) Send(msg *cluster.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stream == nil {
+		return errSessionDisconnected
+	}
+	if err := s.stream.Send(msg); err != nil {
+		s.closeLocked()
+		return err
+	}
+	return nil
+}
+
+// setStream 设置当前会话流
+func (s *session) setStream(stream grpc.BidiStreamingClient[cluster.Message, cluster.Message], cancel context.CancelFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stream = stream
+	s.cancel = cancel
+}
+
+// Reset 关闭当前会话流，接收循环随即按退避策略重连
+func (s *session) Reset(reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stream == nil {
+		return
+	}
+	logrus.Warnf("Resetting session stream: %s", reason)
+	s.closeLocked()
+}
+
+func (s *session) closeLocked() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.stream = nil
+	s.cancel = nil
+}
+
+// current 返回当前会话流
+func (s *session) current() grpc.BidiStreamingClient[cluster.Message, cluster.Message] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stream
+}
+
+// Receive 持续接收消息并交给 handle 处理，流断开时自动重连，直到 ctx 结束
+// 参数:
+//   - ctx: 上下文
+//   - handle: 消息处理函数
+func (s *session) Receive(ctx context.Context, handle func(msg *cluster.Message)) {
+	stream := s.current()
+	for {
+		if stream != nil {
+			msg, err := stream.Recv()
+			if err == nil {
+				handle(msg)
+				continue
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			if err == io.EOF {
+				logrus.Warn("Stream closed by server, reconnecting")
+			} else {
+				logrus.Errorf("Error receiving message: %v, reconnecting", err)
+			}
+		}
+
+		s.mu.Lock()
+		s.closeLocked()
+		s.mu.Unlock()
+
+		var ok bool
+		if stream, ok = s.reconnect(ctx); !ok {
+			return
+		}
+	}
+}
+
+// reconnect 按指数退避（带随机抖动）重新建立会话，ctx 结束时返回 false
+func (s *session) reconnect(ctx context.Context) (grpc.BidiStreamingClient[cluster.Message, cluster.Message], bool) {
+	backoff := reconnectInitialBackoff
+	for attempt := 1; ; attempt++ {
+		// 等待 [backoff/2, backoff) 的随机时间，避免大量组件在 Ignis 重启后同时重连
+		wait := backoff/2 + rand.N(backoff/2)
+		select {
+		case <-ctx.Done():
+			return nil, false
+		case <-time.After(wait):
+		}
+
+		stream, cancel, err := s.open(ctx)
+		if err != nil {
+			logrus.Warnf("Reconnect attempt %d failed: %v", attempt, err)
+			backoff = min(backoff*2, reconnectMaxBackoff)
+			continue
+		}
+
+		s.setStream(stream, cancel)
+		logrus.Infof("Session re-established after %d attempt(s)", attempt)
+
+		if s.onReconnect != nil {
+			s.onReconnect()
+		}
+		return stream, true
+	}
+}
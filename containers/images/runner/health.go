@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+// DEFAULT_HEALTH_PORT 健康检查接口的默认端口，可通过 HEALTH_PORT 覆盖
+const DEFAULT_HEALTH_PORT = "8090"
+
+// HealthResponse 健康检查接口的响应
+// runner 能够响应即说明 runner 本身正常，app 描述应用进程的状态
+type HealthResponse struct {
+	Healthy bool      `json:"healthy"`
+	AppID   string    `json:"app_id"`
+	App     AppStatus `json:"app"`
+}
+
+func healthPort() string {
+	if port := os.Getenv("HEALTH_PORT"); port != "" {
+		return port
+	}
+	return DEFAULT_HEALTH_PORT
+}
+
+// healthy 应用处于退避重启或最终失败状态时视为不健康
+func healthy(state string) bool {
+	return state != AppStateBackoff && state != AppStateFailed
+}
+
+// serveHealth 在后台启动健康检查接口 GET /healthz，不健康时返回 503
+func serveHealth(appID string, sup *supervisor) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		resp := HealthResponse{AppID: appID, App: sup.Status()}
+		resp.Healthy = healthy(resp.App.State)
+		w.Header().Set("Content-Type", "application/json")
+		if !resp.Healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+
+	addr := ":" + healthPort()
+	go func() {
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			logrus.Warnf("health endpoint on %s stopped: %v", addr, err)
+		}
+	}()
+	logrus.Infof("Health endpoint listening on %s/healthz", addr)
+}
+
+// runHealthcheck 实现 `runner healthcheck` 子命令，供容器 HEALTHCHECK 调用
+func runHealthcheck() int {
+	client := &http.Client{Timeout: 3 * time.Second}
+	resp, err := client.Get("http://127.0.0.1:" + healthPort() + "/healthz")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "health check failed: %v\n", err)
+		return 1
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		fmt.Fprintf(os.Stderr, "unhealthy: status %d\n", resp.StatusCode)
+		return 1
+	}
+	return 0
+}
@@ -48,17 +48,6 @@ func (h *remoteLogHook) Levels() []logrus.Level {
 }
 
 func (h *remoteLogHook) Fire(entry *logrus.Entry) error {
-	h.mu.Lock()
-	defer h.mu.Unlock()
-
-	if h.stream == nil {
-		var err error
-		h.stream, err = h.client.StreamLogs(context.Background())
-		if err != nil {
-			return err
-		}
-	}
-
 	logEntry := &commonpb.LogEntry{
 		Timestamp: entry.Time.UnixNano(),
 		Level:     logrusLevelToProto(entry.Level),
@@ -87,9 +76,25 @@ func (h *remoteLogHook) Fire(entry *logrus.Entry) error {
 		}
 	}
 
+	return h.Send(logEntry)
+}
+
+// Send 直接发送一条日志记录，用于转发子进程输出
+func (h *remoteLogHook) Send(entry *commonpb.LogEntry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.stream == nil {
+		var err error
+		h.stream, err = h.client.StreamLogs(context.Background())
+		if err != nil {
+			return err
+		}
+	}
+
 	msg := &loggerpb.LogStreamMessage{
 		ApplicationId: h.appID,
-		Entry:         logEntry,
+		Entry:         entry,
 	}
 
 	if err := h.stream.Send(msg); err != nil {
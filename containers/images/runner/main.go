@@ -2,9 +2,13 @@ package main
 
 import (
 	"context"
+	"errors"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
+	"syscall"
+	"time"
 
 	logrus "github.com/sirupsen/logrus"
 )
@@ -12,18 +16,36 @@ import (
 const APP_CODE_PATH = "/iarnet/app/"
 const ENV_INSTALLED_MARKER = ".env_installed"
 
+// APP_STOP_GRACE 容器停止时应用收到 SIGTERM 后的退出宽限期
+const APP_STOP_GRACE = 10 * time.Second
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "healthcheck" {
+		os.Exit(runHealthcheck())
+	}
+
+	// runner 自身故障统一以 EXIT_RUNNER_FAILURE 退出，与应用的退出码区分
+	logrus.StandardLogger().ExitFunc = func(int) { os.Exit(EXIT_RUNNER_FAILURE) }
+
 	appID := os.Getenv("APP_ID")
 	ignisPort := os.Getenv("IGNIS_PORT")
 	loggerPort := os.Getenv("LOGGER_PORT")
 	envInstallCmd := os.Getenv("ENV_INSTALL_CMD")
 	executeCmd := os.Getenv("EXECUTE_CMD")
 
-	if ignisPort == "" {
-		logrus.Fatalf("IGNIS_PORT environment variable is required")
+	// 作为 component 部署到 provider 时，节点不在容器宿主机上，由 IGNIS_ADDR / APP_LOGGER_ADDR 给出完整地址
+	ignisAddr := os.Getenv("IGNIS_ADDR")
+	loggerAddr := os.Getenv("APP_LOGGER_ADDR")
+	if ignisAddr == "" {
+		if ignisPort == "" {
+			logrus.Fatalf("IGNIS_PORT environment variable is required")
+		}
+		ignisAddr = "host.internal:" + ignisPort
+	}
+	if loggerAddr == "" && loggerPort != "" {
+		loggerAddr = "host.internal:" + loggerPort
 	}
 
-	loggerAddr := "host.internal:" + loggerPort
 	hook, err := newRemoteLogHook(context.Background(), loggerAddr, appID)
 	if err != nil {
 		logrus.Warnf("failed to initialize remote logging hook: %v", err)
@@ -37,17 +59,38 @@ func main() {
 		logrus.Fatalf("APP_ID environment variable is required")
 	}
 
-	if loggerPort == "" {
+	if loggerAddr == "" {
 		logrus.Fatalf("LOGGER_PORT environment variable is required")
 	}
 	if executeCmd == "" {
 		logrus.Fatalf("EXECUTE_CMD environment variable is required")
 	}
+	policy, err := restartPolicyFromEnv()
+	if err != nil {
+		logrus.Fatalf("%v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
 
-	os.Setenv("MASTER_ADDR", "host.internal:"+ignisPort)
-	os.Setenv("LOGGER_ADDR", "host.internal:"+loggerPort)
+	execStdout := newOutputWriter(hook, os.Stdout, "execute", "stdout")
+	execStderr := newOutputWriter(hook, os.Stderr, "execute", "stderr")
+	sup := newSupervisor(policy, func() *exec.Cmd {
+		// 支持多行执行命令，使用bash -c来执行；容器停止时先向应用发送 SIGTERM
+		cmd := exec.CommandContext(ctx, "bash", "-c", executeCmd)
+		cmd.Dir = APP_CODE_PATH
+		cmd.Stdout = execStdout
+		cmd.Stderr = execStderr
+		cmd.Cancel = func() error { return cmd.Process.Signal(syscall.SIGTERM) }
+		cmd.WaitDelay = APP_STOP_GRACE
+		return cmd
+	})
+	serveHealth(appID, sup)
 
-	logrus.Infof("Registering app %s to Ignis platform at port %s", appID, ignisPort)
+	os.Setenv("MASTER_ADDR", ignisAddr)
+	os.Setenv("LOGGER_ADDR", loggerAddr)
+
+	logrus.Infof("Registering app %s to Ignis platform at %s", appID, ignisAddr)
 
 	markerPath := filepath.Join(APP_CODE_PATH, ENV_INSTALLED_MARKER)
 
@@ -57,13 +100,26 @@ func main() {
 
 	if envInstallCmd != "" && !envInstalled {
 		logrus.Infof("Executing environment installation command for app %s", appID)
+		sup.setState(AppStateInstalling)
 		// 支持多行环境安装命令
 		envCmd := exec.Command("bash", "-c", envInstallCmd)
 		envCmd.Dir = APP_CODE_PATH
-		envCmd.Stdout = os.Stdout
-		envCmd.Stderr = os.Stderr
-		if err := envCmd.Run(); err != nil {
-			logrus.Fatalf("failed to install env %s: %v", envInstallCmd, err)
+		envStdout := newOutputWriter(hook, os.Stdout, "env_install", "stdout")
+		envStderr := newOutputWriter(hook, os.Stderr, "env_install", "stderr")
+		envCmd.Stdout = envStdout
+		envCmd.Stderr = envStderr
+		err := envCmd.Run()
+		envStdout.Flush()
+		envStderr.Flush()
+		if err != nil {
+			// 环境安装命令由应用提供，失败视为应用失败，以安装命令的退出码退出
+			sup.setState(AppStateFailed)
+			var exitErr *exec.ExitError
+			if !errors.As(err, &exitErr) {
+				logrus.Fatalf("failed to install env %s: %v", envInstallCmd, err)
+			}
+			logrus.Errorf("failed to install env %s: %v", envInstallCmd, err)
+			exit(hook, exitErr.ExitCode())
 		}
 
 		// 创建标记文件，表示环境已安装
@@ -78,14 +134,23 @@ func main() {
 		logrus.Infof("Environment already installed for app %s, skipping installation", appID)
 	}
 
-	// 支持多行执行命令，使用bash -c来执行
-	execCmd := exec.Command("bash", "-c", executeCmd)
-	execCmd.Dir = APP_CODE_PATH
-	execCmd.Stdout = os.Stdout
-	execCmd.Stderr = os.Stderr
-	if err := execCmd.Run(); err != nil {
-		logrus.Fatalf("failed to execute command %s: %v", executeCmd, err)
+	sup.setState(AppStateStarting)
+	exitCode := sup.Run(ctx)
+	execStdout.Flush()
+	execStderr.Flush()
+	if exitCode != 0 {
+		status := sup.Status()
+		logrus.Errorf("App %s exited with code %d after %d restart(s)", appID, exitCode, status.Restarts)
+		exit(hook, exitCode)
 	}
 
 	logrus.Infof("Successfully executed app %s", appID)
 }
+
+// exit 关闭远程日志后以指定退出码退出（os.Exit 不会执行 defer）
+func exit(hook *remoteLogHook, code int) {
+	if hook != nil {
+		hook.Close()
+	}
+	os.Exit(code)
+}
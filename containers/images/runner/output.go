@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	commonpb "github.com/9triver/iarnet/runner/proto/common"
+)
+
+// maxOutputLine 单条日志记录的最大长度，超长的行会被拆分为多条
+const maxOutputLine = 64 * 1024
+
+// outputWriter 将子进程输出按行转发为结构化日志，同时写到本地控制台
+// stage 标明输出来自环境安装（env_install）还是应用执行（execute），stream 为 stdout / stderr
+type outputWriter struct {
+	hook    *remoteLogHook
+	console io.Writer
+	level   commonpb.LogLevel
+	fields  []*commonpb.LogField
+
+	mu  sync.Mutex
+	buf []byte
+}
+
+func newOutputWriter(hook *remoteLogHook, console io.Writer, stage, stream string) *outputWriter {
+	// stderr 上不一定是错误（如 pip 进度、Python logging 默认输出），按 WARN 记录
+	level := commonpb.LogLevel_LOG_LEVEL_INFO
+	if stream == "stderr" {
+		level = commonpb.LogLevel_LOG_LEVEL_WARN
+	}
+	return &outputWriter{
+		hook:    hook,
+		console: console,
+		level:   level,
+		fields: []*commonpb.LogField{
+			{Key: "stage", Value: strconv.Quote(stage)},
+			{Key: "stream", Value: strconv.Quote(stream)},
+		},
+	}
+}
+
+func (w *outputWriter) Write(p []byte) (int, error) {
+	if _, err := w.console.Write(p); err != nil {
+		return 0, err
+	}
+	if w.hook == nil {
+		return len(p), nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			if len(w.buf) >= maxOutputLine {
+				w.send(w.buf[:maxOutputLine])
+				w.buf = w.buf[maxOutputLine:]
+				continue
+			}
+			break
+		}
+		w.send(w.buf[:i])
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush 发送缓冲区中未以换行结尾的剩余输出
+func (w *outputWriter) Flush() {
+	if w.hook == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.buf) > 0 {
+		w.send(w.buf)
+		w.buf = nil
+	}
+}
+
+// send 转发一行输出，发送失败时只丢弃该行，不影响子进程运行
+func (w *outputWriter) send(line []byte) {
+	line = bytes.TrimRight(line, "\r")
+	if len(line) == 0 {
+		return
+	}
+	_ = w.hook.Send(&commonpb.LogEntry{
+		Timestamp: time.Now().UnixNano(),
+		Level:     w.level,
+		Message:   string(line),
+		Fields:    w.fields,
+	})
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+// EXIT_RUNNER_FAILURE runner 自身故障（缺少环境变量、环境安装失败等）时的退出码
+// 应用失败时 runner 以应用的退出码退出，iarnet 据此区分应用失败与 runner 失败
+const EXIT_RUNNER_FAILURE = 125
+
+// 重启策略
+const (
+	RestartNever     = "never"      // 应用退出后不再重启
+	RestartOnFailure = "on-failure" // 仅在应用以非零退出码退出时重启
+	RestartAlways    = "always"     // 应用退出后总是重启
+)
+
+// 崩溃退避参数：每次连续崩溃后等待时间翻倍，应用稳定运行超过 stableRunDuration 后重置
+const (
+	maxRestartBackoff = 60 * time.Second
+	stableRunDuration = 60 * time.Second
+)
+
+// 应用状态
+const (
+	AppStateInstalling = "installing" // 执行环境安装命令
+	AppStateStarting   = "starting"   // 等待首次启动
+	AppStateRunning    = "running"    // 应用进程运行中
+	AppStateBackoff    = "backoff"    // 应用崩溃，等待重启
+	AppStateSucceeded  = "succeeded"  // 应用正常退出且不再重启
+	AppStateFailed     = "failed"     // 应用失败且不再重启
+)
+
+// restartPolicy 应用重启策略，从环境变量读取
+type restartPolicy struct {
+	Policy      string        // RESTART_POLICY，默认 on-failure
+	MaxRestarts int           // MAX_RESTARTS，默认 3，0 表示不限制
+	Backoff     time.Duration // RESTART_BACKOFF_SECONDS，首次重启前的等待时间，默认 1 秒
+}
+
+func restartPolicyFromEnv() (restartPolicy, error) {
+	p := restartPolicy{Policy: RestartOnFailure, MaxRestarts: 3, Backoff: time.Second}
+	if v := os.Getenv("RESTART_POLICY"); v != "" {
+		switch v {
+		case RestartNever, RestartOnFailure, RestartAlways:
+			p.Policy = v
+		default:
+			return p, fmt.Errorf("invalid RESTART_POLICY %q, must be never, on-failure or always", v)
+		}
+	}
+	if v := os.Getenv("MAX_RESTARTS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return p, fmt.Errorf("invalid MAX_RESTARTS %q", v)
+		}
+		p.MaxRestarts = n
+	}
+	if v := os.Getenv("RESTART_BACKOFF_SECONDS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return p, fmt.Errorf("invalid RESTART_BACKOFF_SECONDS %q", v)
+		}
+		p.Backoff = time.Duration(n) * time.Second
+	}
+	return p, nil
+}
+
+// shouldRestart 应用以 exitCode 退出后是否重启
+func (p restartPolicy) shouldRestart(exitCode, restarts int) bool {
+	if p.MaxRestarts > 0 && restarts >= p.MaxRestarts {
+		return false
+	}
+	switch p.Policy {
+	case RestartAlways:
+		return true
+	case RestartOnFailure:
+		return exitCode != 0
+	}
+	return false
+}
+
+// AppStatus 健康检查接口返回的应用状态
+type AppStatus struct {
+	State         string     `json:"state"`
+	Restarts      int        `json:"restarts"`
+	PID           int        `json:"pid,omitempty"`
+	StartedAt     *time.Time `json:"started_at,omitempty"`
+	LastExitCode  *int       `json:"last_exit_code,omitempty"`
+	LastExitError string     `json:"last_exit_error,omitempty"`
+	LastExitedAt  *time.Time `json:"last_exited_at,omitempty"`
+	NextRestartAt *time.Time `json:"next_restart_at,omitempty"`
+}
+
+// supervisor 运行 EXECUTE_CMD 并按重启策略在应用崩溃后重启
+type supervisor struct {
+	policy  restartPolicy
+	command func() *exec.Cmd
+
+	mu     sync.RWMutex
+	status AppStatus
+}
+
+func newSupervisor(policy restartPolicy, command func() *exec.Cmd) *supervisor {
+	return &supervisor{
+		policy:  policy,
+		command: command,
+		status:  AppStatus{State: AppStateStarting},
+	}
+}
+
+// Status 返回应用状态快照
+func (s *supervisor) Status() AppStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.status
+}
+
+func (s *supervisor) setState(state string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status.State = state
+}
+
+// Run 运行应用直到不再重启，返回应用最后一次的退出码
+func (s *supervisor) Run(ctx context.Context) int {
+	backoff := s.policy.Backoff
+	for {
+		exitCode, ranFor, err := s.runOnce()
+		if err != nil {
+			// 进程未能启动或无法等待其退出，视为 runner 故障
+			logrus.Errorf("failed to start application: %v", err)
+			s.setState(AppStateFailed)
+			return EXIT_RUNNER_FAILURE
+		}
+
+		s.mu.RLock()
+		restarts := s.status.Restarts
+		s.mu.RUnlock()
+
+		if !s.policy.shouldRestart(exitCode, restarts) || ctx.Err() != nil {
+			if exitCode == 0 {
+				s.setState(AppStateSucceeded)
+			} else {
+				s.setState(AppStateFailed)
+			}
+			return exitCode
+		}
+
+		if ranFor >= stableRunDuration {
+			backoff = s.policy.Backoff
+		}
+		next := time.Now().Add(backoff)
+		s.mu.Lock()
+		s.status.State = AppStateBackoff
+		s.status.NextRestartAt = &next
+		s.mu.Unlock()
+		logrus.Warnf("Application exited with code %d, restarting in %s (restart %d)", exitCode, backoff, restarts+1)
+
+		select {
+		case <-ctx.Done():
+			s.setState(AppStateFailed)
+			return exitCode
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxRestartBackoff)
+
+		s.mu.Lock()
+		s.status.Restarts++
+		s.status.NextRestartAt = nil
+		s.mu.Unlock()
+	}
+}
+
+// runOnce 运行一次应用，返回应用的退出码；被信号终止时退出码为 128+信号值
+// 仅在进程无法启动或等待失败时返回错误
+func (s *supervisor) runOnce() (exitCode int, ranFor time.Duration, err error) {
+	cmd := s.command()
+	if err := cmd.Start(); err != nil {
+		return 0, 0, err
+	}
+	started := time.Now()
+	s.mu.Lock()
+	s.status.State = AppStateRunning
+	s.status.PID = cmd.Process.Pid
+	s.status.StartedAt = &started
+	s.mu.Unlock()
+
+	waitErr := cmd.Wait()
+	exited := time.Now()
+	if waitErr != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(waitErr, &exitErr) {
+			return 0, exited.Sub(started), waitErr
+		}
+		exitCode = exitErr.ExitCode()
+		if ws, ok := exitErr.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+			exitCode = 128 + int(ws.Signal())
+		}
+	}
+
+	s.mu.Lock()
+	s.status.PID = 0
+	s.status.LastExitCode = &exitCode
+	s.status.LastExitedAt = &exited
+	s.status.LastExitError = ""
+	if waitErr != nil {
+		s.status.LastExitError = waitErr.Error()
+	}
+	s.mu.Unlock()
+	return exitCode, exited.Sub(started), nil
+}
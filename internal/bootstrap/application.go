@@ -3,12 +3,22 @@ package bootstrap
 import (
 	"context"
 	"fmt"
+	"net"
+	"strconv"
+	"strings"
 
 	"github.com/9triver/iarnet/internal/domain/application"
+	"github.com/9triver/iarnet/internal/domain/application/build"
 	"github.com/9triver/iarnet/internal/domain/application/logger"
 	"github.com/9triver/iarnet/internal/domain/application/metadata"
 	"github.com/9triver/iarnet/internal/domain/application/runner"
+	apptypes "github.com/9triver/iarnet/internal/domain/application/types"
 	"github.com/9triver/iarnet/internal/domain/application/workspace"
+	"github.com/9triver/iarnet/internal/domain/resource"
+	"github.com/9triver/iarnet/internal/domain/resource/accounting"
+	"github.com/9triver/iarnet/internal/domain/resource/component"
+	"github.com/9triver/iarnet/internal/domain/resource/provider"
+	resourcetypes "github.com/9triver/iarnet/internal/domain/resource/types"
 	apploggerrepo "github.com/9triver/iarnet/internal/infra/repository/application"
 	"github.com/moby/moby/client"
 	"github.com/sirupsen/logrus"
@@ -52,6 +62,14 @@ func bootstrapApplication(iarnet *Iarnet) error {
 		runnerImages,
 	)
 
+	// 初始化 Build 模块（构建步骤与生成的镜像均基于 runner 镜像）
+	registryConfig := iarnet.Config.Application.Registry
+	buildService := build.NewService(iarnet.DockerClient, runnerImages, iarnet.Config.Application.BuildCacheDir, build.RegistryConfig{
+		Address:  registryConfig.Address,
+		Username: registryConfig.Username,
+		Password: registryConfig.Password,
+	})
+
 	// 初始化 Metadata 模块
 	metadataCache := metadata.NewCache()
 	metadataService := metadata.NewService(metadataCache)
@@ -65,6 +83,16 @@ func bootstrapApplication(iarnet *Iarnet) error {
 		loggerService = nil
 	} else {
 		loggerService = logger.NewService(loggerRepo)
+		iarnet.addCloser("application logger repository", loggerRepo)
+	}
+
+	// 初始化 CronJob 仓库，失败时 CronJob 只保存在内存中
+	cronJobRepo, err := apploggerrepo.NewCronJobRepoSQLite(iarnet.Config.Database.ApplicationDBPath, iarnet.Config)
+	if err != nil {
+		logrus.Warnf("Failed to initialize cron job repository: %v, cron jobs will not survive restarts", err)
+		cronJobRepo = nil
+	} else {
+		iarnet.addCloser("application cron job repository", cronJobRepo)
 	}
 
 	// 组装 Application Manager
@@ -73,9 +101,112 @@ func bootstrapApplication(iarnet *Iarnet) error {
 		SetApplicationWorkspaceService(workspaceService).
 		SetApplicationMetadataService(metadataService).
 		SetIgnisPlatform(iarnet.IgnisPlatform).
-		SetApplicationLoggerService(loggerService)
+		SetApplicationLoggerService(loggerService).
+		SetApplicationBuildService(buildService).
+		SetProviderStateResolver(func(providerID string) apptypes.ComponentState {
+			p := iarnet.ResourceManager.GetProvider(providerID)
+			if p == nil {
+				// 委托到其他节点的 component，其 provider 不在本节点管理范围内
+				return apptypes.ComponentStateRunning
+			}
+			if p.GetStatus() != resourcetypes.ProviderStatusConnected {
+				return apptypes.ComponentStateUnhealthy
+			}
+			return apptypes.ComponentStateRunning
+		}).
+		SetJobComponentRunner(&jobComponentRunner{resMgr: iarnet.ResourceManager}).
+		SetImageComponentRunner(&imageComponentRunner{
+			resMgr:     iarnet.ResourceManager,
+			ignisAddr:  net.JoinHostPort(iarnet.Config.Host, strconv.Itoa(iarnet.Config.Transport.RPC.Ignis.Port)),
+			loggerAddr: net.JoinHostPort(iarnet.Config.Host, strconv.Itoa(iarnet.Config.Transport.RPC.Logger.Port)),
+		}).
+		SetCronJobRepo(cronJobRepo)
+	// 应用违反 SLO 时，由再平衡将相关 component 迁移到负载更低的 provider
+	appManager.OnSLOViolation(func(ctx context.Context, violation apptypes.SLOViolation) {
+		iarnet.ResourceManager.ReportSLOViolation(violation.ComponentIDs,
+			fmt.Sprintf("application %s: %s", violation.AppID, violation.Reason))
+	})
 	iarnet.ApplicationManager = appManager
 
 	logrus.Info("Application module initialized")
 	return nil
 }
+
+// jobComponentRunner 通过 resource 模块部署 Job 的 component 并查询其退出状态
+type jobComponentRunner struct {
+	resMgr *resource.Manager
+}
+
+func (r *jobComponentRunner) DeployJobComponent(ctx context.Context, spec apptypes.JobSpec, env map[string]string) (string, error) {
+	runtimeEnv := spec.RuntimeEnv
+	if runtimeEnv == "" {
+		runtimeEnv = resourcetypes.RuntimeEnvPython
+	}
+	comp, err := r.resMgr.DeployComponent(provider.WithDeploymentEnv(ctx, env), runtimeEnv, &resourcetypes.Info{
+		CPU:    spec.CPU,
+		Memory: spec.Memory,
+		GPU:    spec.GPU,
+	})
+	if err != nil {
+		return "", err
+	}
+	return comp.GetID(), nil
+}
+
+func (r *jobComponentRunner) GetJobComponentStatus(ctx context.Context, componentID string) (*application.JobComponentStatus, error) {
+	if r.resMgr.GetComponent(componentID) == nil {
+		return &application.JobComponentStatus{Gone: true}, nil
+	}
+	status, err := r.resMgr.GetComponentInstanceStatus(ctx, componentID)
+	if err != nil {
+		return nil, err
+	}
+	switch status.State {
+	case provider.InstanceStateExited:
+		return &application.JobComponentStatus{Exited: true, ExitCode: status.ExitCode, Reason: status.Reason}, nil
+	case provider.InstanceStateNotFound:
+		return &application.JobComponentStatus{Gone: true}, nil
+	default:
+		return &application.JobComponentStatus{}, nil
+	}
+}
+
+func (r *jobComponentRunner) UndeployJobComponent(ctx context.Context, componentID string) error {
+	err := r.resMgr.UndeployComponent(ctx, componentID)
+	if err != nil && strings.Contains(err.Error(), "not found") {
+		return nil
+	}
+	return err
+}
+
+// imageComponentRunner 通过 resource 模块将构建出的应用镜像部署为 component
+// 应用运行在 provider 上，通过节点地址而非 host.internal 连接 Ignis 与应用日志服务
+type imageComponentRunner struct {
+	resMgr     *resource.Manager
+	ignisAddr  string
+	loggerAddr string
+}
+
+func (r *imageComponentRunner) DeployImageComponent(ctx context.Context, appID, image, envInstallCmd, executeCmd string) (string, error) {
+	env := map[string]string{
+		"APP_ID":          appID,
+		"IGNIS_ADDR":      r.ignisAddr,
+		"APP_LOGGER_ADDR": r.loggerAddr,
+		"ENV_INSTALL_CMD": envInstallCmd,
+		"EXECUTE_CMD":     executeCmd,
+	}
+	ctx = accounting.WithApplication(component.WithComponentImage(ctx, image), appID)
+	comp, err := r.resMgr.DeployComponent(provider.WithDeploymentEnv(ctx, env), resourcetypes.RuntimeEnvPython, &resourcetypes.Info{})
+	if err != nil {
+		return "", err
+	}
+	return comp.GetID(), nil
+}
+
+func (r *imageComponentRunner) UndeployImageComponent(ctx context.Context, componentID string) error {
+	err := r.resMgr.UndeployComponent(ctx, componentID)
+	if err != nil && strings.Contains(err.Error(), "not found") {
+		return nil
+	}
+	return err
+}
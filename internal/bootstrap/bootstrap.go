@@ -1,45 +1,102 @@
 package bootstrap
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/9triver/iarnet/internal/bootstrap/module"
 	"github.com/9triver/iarnet/internal/config"
+	"github.com/9triver/iarnet/internal/util/ports"
 	"github.com/sirupsen/logrus"
 )
 
-// Initialize 初始化所有模块
-// 按照依赖顺序初始化：基础设施 -> Resource -> Application -> Ignis -> Transport
-func Initialize(cfg *config.Config) (*Iarnet, error) {
-	iarnet := &Iarnet{
-		Config:             cfg,
-		DockerClient:       nil,
-		Channeler:          nil,
-		ResourceManager:    nil,
-		ApplicationManager: nil,
-		IgnisPlatform:      nil,
-		HTTPServer:         nil,
+// modules 节点启动依赖图
+// 核心模块：resource、ignis、application、rpc、http，任一失败则节点启动失败
+// 可选模块：zmq、discovery，失败时节点以降级模式运行，并在后台重试
+func modules() []moduleSpec {
+	return []moduleSpec{
+		{
+			name: "resource",
+			init: bootstrapResource,
+			start: func(ctx context.Context, iarnet *Iarnet) error {
+				return iarnet.ResourceManager.Start(ctx)
+			},
+		},
+		{
+			name:     "zmq",
+			deps:     []string{"resource"},
+			optional: true,
+			start:    bootstrapZMQ,
+		},
+		{
+			name:     "discovery",
+			deps:     []string{"resource"},
+			optional: true,
+			enabled: func(iarnet *Iarnet) bool {
+				return iarnet.Config.Resource.Discovery.Enabled
+			},
+			start: func(ctx context.Context, iarnet *Iarnet) error {
+				return iarnet.DiscoveryService.Start(ctx)
+			},
+		},
+		{
+			name: "ignis",
+			deps: []string{"resource"},
+			init: bootstrapIgnis,
+		},
+		{
+			name: "application",
+			deps: []string{"resource", "ignis"},
+			init: bootstrapApplication,
+			start: func(ctx context.Context, iarnet *Iarnet) error {
+				return iarnet.ApplicationManager.Start(ctx)
+			},
+		},
+		{
+			name: "rpc",
+			deps: []string{"resource", "ignis", "application"},
+			init: bootstrapRPC,
+			start: func(ctx context.Context, iarnet *Iarnet) error {
+				return iarnet.RPCManager.Start()
+			},
+		},
+		{
+			name: "http",
+			deps: []string{"resource", "ignis", "application"},
+			init: bootstrapHTTP,
+			start: func(ctx context.Context, iarnet *Iarnet) error {
+				iarnet.HTTPServer.Start()
+				return nil
+			},
+		},
 	}
+}
 
-	// 1. 初始化 Resource 模块
-	if err := bootstrapResource(iarnet); err != nil {
-		return nil, fmt.Errorf("failed to initialize resource module: %w", err)
+// Initialize 按依赖图初始化所有模块
+// 核心模块初始化失败时返回错误；可选模块失败时标记为 degraded，在 Start 后由后台重试
+func Initialize(cfg *config.Config) (*Iarnet, error) {
+	iarnet := &Iarnet{
+		Config:  cfg,
+		Modules: module.NewRegistry(),
+		Ports:   ports.NewRegistry(""),
 	}
 
-	// 2. 初始化 Ignis 模块
-	if err := bootstrapIgnis(iarnet); err != nil {
-		return nil, fmt.Errorf("failed to initialize ignis module: %w", err)
+	// 先登记所有监听端口，端口被同一主机上的其他服务占用时报告具体的服务
+	if err := iarnet.Ports.Claim(portBindings(cfg)...); err != nil {
+		return nil, err
 	}
+	iarnet.addCloser("port leases", iarnet.Ports)
 
-	// 3. 初始化 Application 模块
-	if err := bootstrapApplication(iarnet); err != nil {
-		return nil, fmt.Errorf("failed to initialize application module: %w", err)
+	graph, err := newModuleGraph(modules(), iarnet.Modules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid module graph: %w", err)
 	}
+	iarnet.graph = graph
 
-	// 4. 初始化 Transport 层
-	if err := bootstrapTransport(iarnet); err != nil {
-		return nil, fmt.Errorf("failed to initialize transport layer: %w", err)
+	if err := graph.initAll(iarnet); err != nil {
+		return nil, err
 	}
 
-	logrus.Info("All modules initialized successfully")
+	logrus.Info("All core modules initialized successfully")
 	return iarnet, nil
 }
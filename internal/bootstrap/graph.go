@@ -0,0 +1,277 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet/internal/bootstrap/module"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// moduleRetryInitialBackoff 可选模块首次重试的等待时间
+	moduleRetryInitialBackoff = 2 * time.Second
+	// moduleRetryMaxBackoff 可选模块重试等待时间上限
+	moduleRetryMaxBackoff = time.Minute
+	// moduleRetryTick 后台重试循环的检查间隔
+	moduleRetryTick = time.Second
+)
+
+// moduleSpec 启动依赖图中的一个模块
+// 核心模块失败时节点启动失败；可选模块失败时标记为 degraded 并在后台重试
+type moduleSpec struct {
+	name     string
+	deps     []string
+	optional bool
+	// enabled 返回 false 时模块标记为 disabled 且不会启动，nil 表示总是启用
+	enabled func(iarnet *Iarnet) bool
+	// init 构建阶段，在 Initialize 中执行，可为 nil
+	init func(iarnet *Iarnet) error
+	// start 运行阶段，在 Start 中执行，可为 nil
+	start func(ctx context.Context, iarnet *Iarnet) error
+}
+
+type moduleNode struct {
+	spec        moduleSpec
+	disabled    bool
+	initialized bool
+	started     bool
+	backoff     time.Duration
+	nextRetry   time.Time
+}
+
+// moduleGraph 按依赖拓扑顺序初始化、启动模块，并在后台重试降级的可选模块
+type moduleGraph struct {
+	mu       sync.Mutex
+	nodes    map[string]*moduleNode
+	order    []string
+	registry *module.Registry
+}
+
+// newModuleGraph 校验依赖关系并计算启动顺序
+// 依赖必须存在且不能成环，核心模块不能依赖可选模块
+func newModuleGraph(specs []moduleSpec, registry *module.Registry) (*moduleGraph, error) {
+	g := &moduleGraph{
+		nodes:    make(map[string]*moduleNode, len(specs)),
+		registry: registry,
+	}
+	for _, spec := range specs {
+		if _, ok := g.nodes[spec.name]; ok {
+			return nil, fmt.Errorf("duplicate module %q", spec.name)
+		}
+		g.nodes[spec.name] = &moduleNode{spec: spec}
+	}
+	for _, spec := range specs {
+		for _, dep := range spec.deps {
+			node, ok := g.nodes[dep]
+			if !ok {
+				return nil, fmt.Errorf("module %q depends on unknown module %q", spec.name, dep)
+			}
+			if !spec.optional && node.spec.optional {
+				return nil, fmt.Errorf("core module %q cannot depend on optional module %q", spec.name, dep)
+			}
+		}
+	}
+
+	// 按声明顺序做拓扑排序，保证无依赖关系的模块保持声明时的相对顺序
+	visited := make(map[string]bool, len(specs))
+	visiting := make(map[string]bool, len(specs))
+	var visit func(name string) error
+	visit = func(name string) error {
+		if visited[name] {
+			return nil
+		}
+		if visiting[name] {
+			return fmt.Errorf("module dependency cycle detected at %q", name)
+		}
+		visiting[name] = true
+		for _, dep := range g.nodes[name].spec.deps {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		visiting[name] = false
+		visited[name] = true
+		g.order = append(g.order, name)
+		return nil
+	}
+	for _, spec := range specs {
+		if err := visit(spec.name); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, name := range g.order {
+		spec := g.nodes[name].spec
+		registry.Register(name, spec.optional, spec.deps)
+	}
+	return g, nil
+}
+
+// initAll 按拓扑顺序执行各模块的构建阶段
+func (g *moduleGraph) initAll(iarnet *Iarnet) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, name := range g.order {
+		node := g.nodes[name]
+		if node.spec.enabled != nil && !node.spec.enabled(iarnet) {
+			node.disabled = true
+			g.registry.Set(name, module.StatusDisabled, nil)
+			logrus.Infof("Module %s is disabled", name)
+			continue
+		}
+		if dep := g.unavailableDep(node, false); dep != "" {
+			err := fmt.Errorf("dependency %s is unavailable", dep)
+			if !node.spec.optional {
+				g.registry.Set(name, module.StatusFailed, err)
+				return fmt.Errorf("failed to initialize %s module: %w", name, err)
+			}
+			g.degrade(node, err)
+			continue
+		}
+		if err := g.runInit(node, iarnet); err != nil {
+			if !node.spec.optional {
+				return fmt.Errorf("failed to initialize %s module: %w", name, err)
+			}
+			g.degrade(node, err)
+		}
+	}
+	return nil
+}
+
+// startAll 按拓扑顺序执行各模块的运行阶段，随后启动后台重试循环
+func (g *moduleGraph) startAll(ctx context.Context, iarnet *Iarnet) error {
+	g.mu.Lock()
+	for _, name := range g.order {
+		node := g.nodes[name]
+		if node.disabled || !node.initialized {
+			continue
+		}
+		if dep := g.unavailableDep(node, true); dep != "" {
+			err := fmt.Errorf("dependency %s is unavailable", dep)
+			if !node.spec.optional {
+				g.registry.Set(name, module.StatusFailed, err)
+				g.mu.Unlock()
+				return fmt.Errorf("failed to start %s module: %w", name, err)
+			}
+			g.degrade(node, err)
+			continue
+		}
+		if err := g.runStart(ctx, node, iarnet); err != nil {
+			if !node.spec.optional {
+				g.mu.Unlock()
+				return fmt.Errorf("failed to start %s module: %w", name, err)
+			}
+			g.degrade(node, err)
+		}
+	}
+	g.mu.Unlock()
+
+	go g.retryLoop(ctx, iarnet)
+	return nil
+}
+
+// retryLoop 定期重试降级的可选模块，直到 ctx 结束
+// 仅在模块的依赖全部运行后才重试，每次失败后退避时间翻倍
+func (g *moduleGraph) retryLoop(ctx context.Context, iarnet *Iarnet) {
+	ticker := time.NewTicker(moduleRetryTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			g.mu.Lock()
+			for _, name := range g.order {
+				node := g.nodes[name]
+				if node.disabled || node.started || now.Before(node.nextRetry) {
+					continue
+				}
+				if g.unavailableDep(node, true) != "" {
+					continue
+				}
+				logrus.Infof("Retrying degraded module %s", name)
+				if !node.initialized {
+					if err := g.runInit(node, iarnet); err != nil {
+						g.degrade(node, err)
+						continue
+					}
+				}
+				if err := g.runStart(ctx, node, iarnet); err != nil {
+					g.degrade(node, err)
+					continue
+				}
+				logrus.Infof("Module %s recovered", name)
+			}
+			g.mu.Unlock()
+		}
+	}
+}
+
+// unavailableDep 返回第一个未就绪的依赖名，全部就绪时返回空字符串
+// requireStarted 为 true 时要求依赖已完成运行阶段，否则只要求完成构建阶段
+func (g *moduleGraph) unavailableDep(node *moduleNode, requireStarted bool) string {
+	for _, dep := range node.spec.deps {
+		d := g.nodes[dep]
+		if d.disabled || !d.initialized || (requireStarted && !d.started) {
+			return dep
+		}
+	}
+	return ""
+}
+
+func (g *moduleGraph) runInit(node *moduleNode, iarnet *Iarnet) error {
+	g.registry.Attempt(node.spec.name)
+	if node.spec.init != nil {
+		if err := callModule(func() error { return node.spec.init(iarnet) }); err != nil {
+			if !node.spec.optional {
+				g.registry.Set(node.spec.name, module.StatusFailed, err)
+			}
+			return err
+		}
+	}
+	node.initialized = true
+	return nil
+}
+
+func (g *moduleGraph) runStart(ctx context.Context, node *moduleNode, iarnet *Iarnet) error {
+	if node.spec.start != nil {
+		if err := callModule(func() error { return node.spec.start(ctx, iarnet) }); err != nil {
+			if !node.spec.optional {
+				g.registry.Set(node.spec.name, module.StatusFailed, err)
+			}
+			return err
+		}
+	}
+	node.started = true
+	node.backoff = 0
+	g.registry.Set(node.spec.name, module.StatusRunning, nil)
+	logrus.Infof("Module %s started", node.spec.name)
+	return nil
+}
+
+// degrade 将可选模块标记为 degraded 并安排下一次重试
+func (g *moduleGraph) degrade(node *moduleNode, err error) {
+	if node.backoff == 0 {
+		node.backoff = moduleRetryInitialBackoff
+	} else {
+		node.backoff = min(node.backoff*2, moduleRetryMaxBackoff)
+	}
+	node.nextRetry = time.Now().Add(node.backoff)
+	g.registry.Set(node.spec.name, module.StatusDegraded, err)
+	logrus.Warnf("Module %s is degraded, retrying in %s: %v", node.spec.name, node.backoff, err)
+}
+
+// callModule 执行模块的构建或运行函数，将 panic 转换为错误，避免单个模块拖垮整个节点
+func callModule(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn()
+}
@@ -0,0 +1,98 @@
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/component"
+	"github.com/9triver/iarnet/internal/transport/handover"
+	"github.com/sirupsen/logrus"
+)
+
+// Handover 将节点交接给以相同参数启动的新进程（滚动重启）
+// 依次排空进行中的部署、导出 component 路由状态与会话、释放 ZMQ 端口，再启动新进程并传递 HTTP、gRPC 的监听端口；
+// 新进程就绪后返回 nil，调用方随即停止本进程。交接失败时本进程重新绑定 ZMQ、恢复会话与部署并继续提供服务
+func (iarnet *Iarnet) Handover(ctx context.Context) error {
+	if iarnet.ResourceManager == nil {
+		return fmt.Errorf("resource manager not initialized")
+	}
+	cfg := iarnet.Config.Handover
+
+	iarnet.Drain(time.Duration(iarnet.Config.ShutdownTimeoutSeconds) * time.Second)
+	state := iarnet.ResourceManager.ExportHandoverState()
+
+	statePath, err := writeHandoverState(state)
+	if err != nil {
+		iarnet.ResourceManager.ResumeDeployments()
+		return err
+	}
+
+	// 新进程需要绑定同一个 ZMQ 端口，component 的 dealer 会自动重连到新进程
+	if iarnet.Channeler != nil {
+		iarnet.ResourceManager.SetChanneler(component.NewNullChanneler())
+		if err := iarnet.Channeler.Close(); err != nil {
+			logrus.Warnf("Error closing ZMQ channeler for handover: %v", err)
+		}
+		iarnet.Channeler = nil
+	}
+
+	logrus.Infof("Handing over %d component(s) and %d session(s) to new process", len(state.Components), len(state.Sessions))
+	process, err := handover.Spawn(statePath, time.Duration(cfg.TimeoutSeconds)*time.Second)
+	if err == nil {
+		logrus.Infof("New process %d is ready, stopping this process", process.Pid)
+		return nil
+	}
+
+	os.Remove(statePath)
+	logrus.Errorf("Handover failed, resuming service in this process: %v", err)
+	if zmqErr := bootstrapZMQ(ctx, iarnet); zmqErr != nil {
+		logrus.Errorf("Failed to rebind ZMQ after failed handover: %v", zmqErr)
+	} else {
+		iarnet.ResourceManager.RestoreHandoverState(ctx, state, time.Duration(cfg.ResumeGraceSeconds)*time.Second)
+	}
+	iarnet.ResourceManager.ResumeDeployments()
+	return fmt.Errorf("handover failed: %w", err)
+}
+
+// RestoreHandover 本进程由交接启动时，恢复旧进程导出的 component 路由状态，应在 Start 之前调用
+func (iarnet *Iarnet) RestoreHandover(ctx context.Context) error {
+	statePath := handover.StatePath()
+	if statePath == "" || iarnet.ResourceManager == nil {
+		return nil
+	}
+	defer os.Remove(statePath)
+
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		return fmt.Errorf("failed to read handover state: %w", err)
+	}
+	state := &component.HandoverState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return fmt.Errorf("failed to decode handover state: %w", err)
+	}
+	grace := time.Duration(iarnet.Config.Handover.ResumeGraceSeconds) * time.Second
+	iarnet.ResourceManager.RestoreHandoverState(ctx, state, grace)
+	return nil
+}
+
+// CompleteHandover 本进程由交接启动时，关闭未使用的继承端口并通知旧进程退出，应在 Start 之后调用
+func (iarnet *Iarnet) CompleteHandover() error {
+	handover.CloseUnused()
+	return handover.NotifyReady()
+}
+
+func writeHandoverState(state *component.HandoverState) (string, error) {
+	f, err := os.CreateTemp("", "iarnet-handover-*.json")
+	if err != nil {
+		return "", fmt.Errorf("failed to create handover state file: %w", err)
+	}
+	defer f.Close()
+	if err := json.NewEncoder(f).Encode(state); err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("failed to write handover state: %w", err)
+	}
+	return f.Name(), nil
+}
@@ -3,7 +3,10 @@ package bootstrap
 import (
 	"context"
 	"fmt"
+	"io"
+	"time"
 
+	"github.com/9triver/iarnet/internal/bootstrap/module"
 	"github.com/9triver/iarnet/internal/config"
 	"github.com/9triver/iarnet/internal/domain/application"
 	"github.com/9triver/iarnet/internal/domain/ignis"
@@ -13,6 +16,8 @@ import (
 	"github.com/9triver/iarnet/internal/domain/resource/scheduler"
 	"github.com/9triver/iarnet/internal/transport/http"
 	"github.com/9triver/iarnet/internal/transport/rpc"
+	"github.com/9triver/iarnet/internal/util/identity"
+	"github.com/9triver/iarnet/internal/util/ports"
 	"github.com/moby/moby/client"
 	"github.com/sirupsen/logrus"
 )
@@ -30,6 +35,15 @@ type Iarnet struct {
 	// Resource 模块
 	ResourceManager *resource.Manager
 
+	// 本机端口租约，与同一主机上的其他节点、provider 协调端口
+	Ports *ports.Registry
+
+	// 节点身份密钥
+	Identity *identity.Identity
+
+	// 上游令牌签发与校验，未启用时为 nil
+	UpstreamTokens *identity.ComponentTokens
+
 	// Discovery 模块
 	DiscoveryManager *discovery.NodeDiscoveryManager
 	DiscoveryService discovery.Service
@@ -42,57 +56,69 @@ type Iarnet struct {
 
 	// Ignis 模块
 	IgnisPlatform *ignis.Platform
+
+	// 各启动模块的运行状态
+	Modules *module.Registry
+	graph   *moduleGraph
+
+	// 关闭时最后释放的持久化资源（数据库连接等），按注册的逆序关闭
+	closers []namedCloser
 }
 
-// Start 启动所有服务
-func (iarnet *Iarnet) Start(ctx context.Context) error {
+type namedCloser struct {
+	name   string
+	closer io.Closer
+}
 
-	// 1. 启动组件管理器
-	if iarnet.ResourceManager != nil {
-		iarnet.ResourceManager.Start(ctx)
-		logrus.Info("Component manager started")
-	} else {
-		return fmt.Errorf("resource manager is not initialized")
-	}
+// addCloser 注册需要在 Stop 时关闭的资源
+func (iarnet *Iarnet) addCloser(name string, closer io.Closer) {
+	iarnet.closers = append(iarnet.closers, namedCloser{name: name, closer: closer})
+}
 
-	// 1.5. 启动 Discovery 服务（如果启用）
-	if iarnet.DiscoveryService != nil {
-		if err := iarnet.DiscoveryService.Start(ctx); err != nil {
-			return fmt.Errorf("failed to start discovery service: %w", err)
-		}
-		logrus.Info("Discovery service started")
+// Start 按依赖图启动所有模块
+// 核心模块启动失败时返回错误；可选模块失败时节点以降级模式运行，并在 ctx 结束前持续后台重试
+func (iarnet *Iarnet) Start(ctx context.Context) error {
+	if iarnet.graph == nil {
+		return fmt.Errorf("iarnet is not initialized")
 	}
-
-	// 2. 启动 Application 管理器
-	if iarnet.ApplicationManager != nil {
-		iarnet.ApplicationManager.Start(ctx)
-		logrus.Info("Application manager started")
-	} else {
-		return fmt.Errorf("application manager is not initialized")
+	if err := iarnet.graph.startAll(ctx, iarnet); err != nil {
+		return err
 	}
 
-	// 3. 启动 RPC 服务器
-	if iarnet.RPCManager != nil {
-		if err := iarnet.RPCManager.Start(); err != nil {
-			return fmt.Errorf("failed to start rpc servers: %w", err)
+	for _, state := range iarnet.Modules.List() {
+		if state.Status == module.StatusDegraded {
+			logrus.Warnf("Node started in degraded mode: module %s is unavailable: %s", state.Name, state.Error)
 		}
-	} else {
-		return fmt.Errorf("rpc manager is not initialized")
 	}
+	return nil
+}
 
-	// 4. 启动 HTTP 服务器
-	if iarnet.HTTPServer != nil {
-		iarnet.HTTPServer.Start()
-		logrus.Info("HTTP server started")
-	} else {
-		return fmt.Errorf("http server is not initialized")
+// Drain 关闭的第一步：停止接受新的 component 部署，并在 timeout 内等待进行中的部署完成，
+// 避免取消 context 时中断部署、在 provider 或远端节点上遗留未完成的资源占用
+// 超时后不再等待，剩余部署会随 context 取消而中止
+func (iarnet *Iarnet) Drain(timeout time.Duration) {
+	if iarnet.ResourceManager == nil {
+		return
 	}
 
-	return nil
+	logrus.Infof("Draining in-flight deployments (timeout %s)", timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if remaining, err := iarnet.ResourceManager.Drain(ctx); err != nil {
+		logrus.Warnf("Drain timed out with %d deployment(s) still in flight: %v", remaining, err)
+		return
+	}
+	logrus.Info("All in-flight deployments finished")
 }
 
 // Stop 停止所有服务并清理资源
+// 先停止对外服务，再停止内部模块，最后关闭数据库等持久化资源，确保日志等数据已写入
 func (iarnet *Iarnet) Stop() error {
+	// 停止 HTTP 服务器
+	if iarnet.HTTPServer != nil {
+		iarnet.HTTPServer.Stop()
+	}
+
 	// 停止 RPC 服务器
 	if iarnet.RPCManager != nil {
 		iarnet.RPCManager.Stop()
@@ -124,6 +150,14 @@ func (iarnet *Iarnet) Stop() error {
 		logrus.Info("Discovery service stopped")
 	}
 
+	// 关闭持久化资源
+	for i := len(iarnet.closers) - 1; i >= 0; i-- {
+		c := iarnet.closers[i]
+		if err := c.closer.Close(); err != nil {
+			logrus.Errorf("Error closing %s: %v", c.name, err)
+		}
+	}
+
 	logrus.Info("All services stopped")
 	return nil
 }
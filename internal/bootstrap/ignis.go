@@ -10,6 +10,12 @@ import (
 func bootstrapIgnis(iarnet *Iarnet) error {
 	// 初始化 Controller Manager
 	controllerManager := controller.NewManager(iarnet.ResourceManager)
+	controllerManager.SetMaxControllers(iarnet.Config.Ignis.MaxControllers)
+	controllerManager.SetAutoCreate(iarnet.Config.Ignis.AutoCreateControllers)
+	// 池满时只回收已停止或已结束应用的控制器；Application 模块在 Ignis 之后初始化
+	controllerManager.SetEvictable(func(appID string) bool {
+		return iarnet.ApplicationManager != nil && iarnet.ApplicationManager.ControllerEvictable(appID)
+	})
 	controllerService := controller.NewService(controllerManager, iarnet.ResourceManager, iarnet.ResourceManager)
 
 	// 初始化 Ignis Platform
@@ -0,0 +1,118 @@
+// Package module 记录节点各启动模块的运行状态
+// bootstrap 按依赖图启动模块时更新状态，管理 API 通过 Registry 对外报告
+package module
+
+import (
+	"sync"
+	"time"
+)
+
+// Status 模块状态
+type Status string
+
+const (
+	StatusPending  Status = "pending"  // 尚未启动
+	StatusRunning  Status = "running"  // 正常运行
+	StatusDegraded Status = "degraded" // 可选模块启动失败或依赖不可用，后台重试中
+	StatusFailed   Status = "failed"   // 核心模块启动失败
+	StatusDisabled Status = "disabled" // 配置中未启用
+)
+
+// State 单个模块的状态快照
+type State struct {
+	Name      string    `json:"name"`
+	Optional  bool      `json:"optional"`
+	DependsOn []string  `json:"depends_on,omitempty"`
+	Status    Status    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	Attempts  int       `json:"attempts"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Registry 模块状态表，并发安全
+type Registry struct {
+	mu     sync.RWMutex
+	order  []string
+	states map[string]*State
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		states: make(map[string]*State),
+	}
+}
+
+// Register 登记模块，初始状态为 pending
+func (r *Registry) Register(name string, optional bool, dependsOn []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.states[name]; !ok {
+		r.order = append(r.order, name)
+	}
+	r.states[name] = &State{
+		Name:      name,
+		Optional:  optional,
+		DependsOn: append([]string(nil), dependsOn...),
+		Status:    StatusPending,
+		UpdatedAt: time.Now(),
+	}
+}
+
+// Set 更新模块状态，err 为 nil 时清除错误信息
+func (r *Registry) Set(name string, status Status, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	state, ok := r.states[name]
+	if !ok {
+		return
+	}
+	state.Status = status
+	state.Error = ""
+	if err != nil {
+		state.Error = err.Error()
+	}
+	state.UpdatedAt = time.Now()
+}
+
+// Attempt 记录一次启动尝试
+func (r *Registry) Attempt(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if state, ok := r.states[name]; ok {
+		state.Attempts++
+	}
+}
+
+// Get 获取单个模块的状态快照
+func (r *Registry) Get(name string) (State, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	state, ok := r.states[name]
+	if !ok {
+		return State{}, false
+	}
+	return *state, true
+}
+
+// List 按启动顺序返回所有模块的状态快照
+func (r *Registry) List() []State {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	states := make([]State, 0, len(r.order))
+	for _, name := range r.order {
+		states = append(states, *r.states[name])
+	}
+	return states
+}
+
+// Healthy 所有核心模块均在运行时返回 true；可选模块降级不影响结果
+func (r *Registry) Healthy() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, state := range r.states {
+		if !state.Optional && state.Status != StatusRunning {
+			return false
+		}
+	}
+	return true
+}
@@ -1,18 +1,30 @@
 package bootstrap
 
 import (
+	"context"
 	"fmt"
 	"time"
 
+	"github.com/9triver/iarnet/internal/config"
 	"github.com/9triver/iarnet/internal/domain/resource"
+	"github.com/9triver/iarnet/internal/domain/resource/accounting"
 	"github.com/9triver/iarnet/internal/domain/resource/component"
+	"github.com/9triver/iarnet/internal/domain/resource/decision"
 	"github.com/9triver/iarnet/internal/domain/resource/discovery"
 	"github.com/9triver/iarnet/internal/domain/resource/logger"
 	"github.com/9triver/iarnet/internal/domain/resource/provider"
 	"github.com/9triver/iarnet/internal/domain/resource/scheduler"
 	"github.com/9triver/iarnet/internal/domain/resource/store"
+	"github.com/9triver/iarnet/internal/domain/resource/trace"
+	"github.com/9triver/iarnet/internal/domain/resource/types"
 	providerrepo "github.com/9triver/iarnet/internal/infra/repository/resource"
+	"github.com/9triver/iarnet/internal/util"
+	"github.com/9triver/iarnet/internal/util/compress"
+	"github.com/9triver/iarnet/internal/util/identity"
+	"github.com/9triver/iarnet/internal/util/tunnel"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
 // BootstrapResource 初始化 Resource 模块
@@ -27,9 +39,26 @@ func bootstrapResource(iarnet *Iarnet) error {
 		logrus.Warnf("Failed to initialize provider repository: %v, continuing without persistence", err)
 	} else {
 		providerRepo = repo
+		iarnet.addCloser("provider repository", repo)
 		logrus.Infof("Provider repository initialized at %s", dbPath)
 	}
 
+	// 加载上游令牌密钥，为以本节点为上游的 component 签发与其 ID 绑定的令牌
+	tokenCfg := iarnet.Config.Resource.Delegation.UpstreamTokens
+	if tokenCfg.Enabled {
+		var tokens *identity.ComponentTokens
+		var err error
+		if tokenCfg.KeyFile != "" {
+			tokens, err = identity.LoadTokenKey(tokenCfg.KeyFile)
+		} else {
+			tokens, err = identity.LoadOrGenerateTokenKey(iarnet.Config.DataDir)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load upstream token key: %w", err)
+		}
+		iarnet.UpstreamTokens = tokens
+	}
+
 	// 使用占位符 channeler 初始化 Resource Manager
 	// 真正的 channeler 会在 Transport 层创建后注入
 	nullChanneler := component.NewNullChanneler()
@@ -43,6 +72,9 @@ func bootstrapResource(iarnet *Iarnet) error {
 			ZMQPort:    iarnet.Config.Transport.ZMQ.Port,
 			StorePort:  iarnet.Config.Transport.RPC.Store.Port,
 			LoggerPort: iarnet.Config.Transport.RPC.ResourceLogger.Port,
+
+			SharedStoreAddress: iarnet.Config.Resource.Store.SharedAddress,
+			UpstreamTokens:     iarnet.UpstreamTokens,
 		},
 		iarnet.Config.Resource.Name,
 		iarnet.Config.Resource.Description,
@@ -57,9 +89,19 @@ func bootstrapResource(iarnet *Iarnet) error {
 		resourceLoggerService = nil
 	} else {
 		resourceLoggerService = logger.NewService(resourceLoggerRepo)
+		iarnet.addCloser("resource logger repository", resourceLoggerRepo)
 	}
 	iarnet.ResourceManager = resourceManager.SetLoggerService(resourceLoggerService)
 
+	// 加载节点身份密钥，注册、健康检查与节点间调用均由该密钥签名
+	nodeIdentity, err := identity.LoadOrGenerate(iarnet.Config.DataDir, resourceManager.GetNodeID())
+	if err != nil {
+		return fmt.Errorf("failed to load node identity: %w", err)
+	}
+	iarnet.Identity = nodeIdentity
+	resourceManager.SetIdentity(nodeIdentity)
+	resourceManager.SetUpstreamTokenEnforcement(tokenCfg.Require)
+
 	// 设置全局注册中心地址
 	if iarnet.Config.Resource.GlobalRegistryAddr != "" {
 		iarnet.ResourceManager.SetGlobalRegistryAddr(iarnet.Config.Resource.GlobalRegistryAddr)
@@ -81,6 +123,229 @@ func bootstrapResource(iarnet *Iarnet) error {
 		logrus.Infof("Global registry address configured: %s", iarnet.Config.Resource.GlobalRegistryAddr)
 	}
 
+	// 设置 provider 容量缓存的最大陈旧时间，调度优先使用缓存避免网络往返
+	iarnet.ResourceManager.SetCapacityCacheTTL(time.Duration(iarnet.Config.Resource.CapacityCacheTTLSeconds) * time.Second)
+
+	// 设置会话亲和的默认空闲超时
+	iarnet.ResourceManager.SetAffinityTTL(time.Duration(iarnet.Config.Resource.AffinityTTLSeconds) * time.Second)
+
+	// 设置 provider 注册时的微基准测试
+	bench := iarnet.Config.Resource.Benchmark
+	iarnet.ResourceManager.SetProviderBenchmark(bench.OnRegister, time.Duration(bench.TimeoutSeconds)*time.Second)
+
+	// 设置配置文件中声明的 provider，Start 时同步仓库并自动注册
+	// 反向连接的 provider 经隧道接入，validate 已保证 reverse 的 provider 均在隧道启用时配置
+	var hub *tunnel.Hub
+	if iarnet.Config.Transport.Tunnel.Enabled {
+		var err error
+		if hub, err = bootstrapTunnel(iarnet); err != nil {
+			return err
+		}
+	}
+	if static := iarnet.Config.Resource.StaticProviders; len(static) > 0 {
+		providers := make([]provider.StaticProvider, 0, len(static))
+		for _, sp := range static {
+			p := provider.StaticProvider{
+				Name:          sp.Name,
+				Host:          sp.Host,
+				Port:          sp.Port,
+				Token:         sp.Token,
+				CapacityClass: types.CapacityClass(sp.CapacityClass),
+			}
+			if sp.Reverse && hub != nil {
+				hub.Allow(sp.Name, sp.Token)
+				p.Dialer = hub.Dialer(sp.Name)
+			}
+			providers = append(providers, p)
+		}
+		iarnet.ResourceManager.SetStaticProviders(providers, time.Duration(iarnet.Config.Resource.StaticProviderRetrySeconds)*time.Second)
+	}
+
+	// 设置外部策略 webhook
+	webhook := iarnet.Config.Resource.PolicyWebhook
+	iarnet.ResourceManager.SetPolicyWebhook(webhook.URL, time.Duration(webhook.TimeoutSeconds)*time.Second, webhook.FailOpen)
+
+	// 设置时间窗口调度规则
+	if len(iarnet.Config.Resource.TimeWindows) > 0 {
+		rules, err := buildTimeWindowRules(iarnet.Config.Resource.TimeWindows)
+		if err != nil {
+			return fmt.Errorf("invalid resource.time_windows: %w", err)
+		}
+		iarnet.ResourceManager.SetTimeWindowRules(rules)
+		logrus.Infof("Scheduling time window rules configured: %d rules", len(rules))
+	}
+
+	// 设置本地部署的重试策略
+	retry := iarnet.Config.Resource.DeployRetry
+	if err := iarnet.ResourceManager.SetDeployRetryPolicy(component.RetryPolicy{
+		MaxAttempts:    retry.MaxAttempts,
+		InitialBackoff: time.Duration(retry.InitialBackoffMs) * time.Millisecond,
+		MaxBackoff:     time.Duration(retry.MaxBackoffMs) * time.Millisecond,
+		Jitter:         retry.Jitter,
+	}); err != nil {
+		return err
+	}
+
+	// 设置历史放置统计
+	history := iarnet.Config.Resource.PlacementHistory
+	iarnet.ResourceManager.SetPlacementHistory(history.Enabled, history.MinSamples)
+
+	// 设置 component 数量上限
+	limits := iarnet.Config.Resource.ComponentLimits
+	if err := iarnet.ResourceManager.SetComponentLimits(component.DensityLimits{
+		MaxPerProvider: limits.MaxPerProvider,
+		MaxPerNode:     limits.MaxPerNode,
+	}); err != nil {
+		return err
+	}
+
+	// 设置委托部署的并行探测参数
+	delegation := iarnet.Config.Resource.Delegation
+	iarnet.ResourceManager.SetDelegationProbing(delegation.ParallelProbes, time.Duration(delegation.ProbeTimeoutSeconds)*time.Second)
+	iarnet.ResourceManager.SetReconcileInterval(time.Duration(delegation.ReconcileIntervalSeconds) * time.Second)
+
+	// 设置调度决策日志（离线分析用）
+	if dl := iarnet.Config.Resource.DecisionLog; dl.Enabled {
+		if sink, err := openDecisionLog(dl); err != nil {
+			logrus.Warnf("Failed to open scheduling decision log: %v, continuing without decision log", err)
+		} else {
+			iarnet.ResourceManager.SetDecisionLog(sink)
+			iarnet.addCloser("scheduling decision log", sink)
+			logrus.Infof("Scheduling decision log enabled at %s", dl.Path)
+		}
+	}
+
+	// 设置部署请求 trace（重放用）
+	if tr := iarnet.Config.Resource.Trace; tr.Enabled {
+		if recorder, err := trace.NewRecorder(tr.Path); err != nil {
+			logrus.Warnf("Failed to open deploy trace: %v, continuing without trace", err)
+		} else {
+			iarnet.ResourceManager.SetTraceRecorder(recorder)
+			iarnet.addCloser("deploy trace", recorder)
+			logrus.Infof("Deploy trace enabled at %s", tr.Path)
+		}
+	}
+
+	// 在同域共用的独立 store（cmd/store）中保存对象，component 直接访问该 store，无需回连本节点
+	if addr := iarnet.Config.Resource.Store.SharedAddress; addr != "" {
+		maxMsg := iarnet.Config.Transport.MessageLimits.MaxGRPCMessageBytes
+		opts := append([]grpc.DialOption{
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxMsg), grpc.MaxCallSendMsgSize(maxMsg)),
+		}, compress.DialOptions(compress.ChannelStore, nil)...)
+		conn, err := grpc.NewClient(addr, opts...)
+		if err != nil {
+			return fmt.Errorf("failed to connect to shared store %s: %w", addr, err)
+		}
+		iarnet.addCloser("shared store connection", conn)
+		iarnet.ResourceManager.UseSharedStore(store.NewRemoteService(conn, iarnet.UpstreamTokens, iarnet.ResourceManager.GetNodeID()))
+		logrus.Infof("Using shared store at %s", addr)
+	}
+
+	// 设置 store 对象访问控制与审计日志
+	if access := iarnet.Config.Resource.Store.Access; access.Enforce || access.AuditLog {
+		policy := store.AccessPolicy{Enforce: access.Enforce}
+		if access.AuditLog {
+			if audit, err := store.NewAuditLog(access.AuditPath); err != nil {
+				logrus.Warnf("Failed to open store audit log: %v, continuing without audit log", err)
+			} else {
+				policy.Audit = audit
+				iarnet.addCloser("store audit log", audit)
+				logrus.Infof("Store audit log enabled at %s", access.AuditPath)
+			}
+		}
+		iarnet.ResourceManager.SetAccessPolicy(policy)
+		if access.Enforce {
+			logrus.Info("Store access control enforced")
+		}
+	}
+
+	// 设置利用率采样日志（离线分析用）
+	if ul := iarnet.Config.Resource.UtilizationLog; ul.Enabled {
+		if log, err := resource.NewUtilizationLog(ul.Path); err != nil {
+			logrus.Warnf("Failed to open utilization log: %v, continuing without utilization log", err)
+		} else {
+			iarnet.ResourceManager.SetUtilizationLog(log, time.Duration(ul.IntervalSeconds)*time.Second)
+			iarnet.addCloser("utilization log", log)
+			logrus.Infof("Utilization log enabled at %s (interval=%ds)", ul.Path, ul.IntervalSeconds)
+		}
+	}
+
+	// 设置资源占用记账
+	if iarnet.Config.Resource.Accounting.Enabled {
+		if err := enableAccounting(iarnet); err != nil {
+			logrus.Warnf("Failed to enable resource accounting: %v, continuing without accounting", err)
+		}
+	}
+
+	// 设置使用量轮询与 provider 健康检测的自适应间隔
+	if polling := iarnet.Config.Resource.Polling; polling.Adaptive {
+		iarnet.ResourceManager.SetPollingPolicy(resource.PollingPolicy{
+			Enabled:          true,
+			UsageMin:         time.Duration(polling.UsageMinMs) * time.Millisecond,
+			UsageMax:         time.Duration(polling.UsageMaxMs) * time.Millisecond,
+			HealthCheckMin:   time.Duration(polling.HealthCheckMinSeconds) * time.Second,
+			HealthCheckMax:   time.Duration(polling.HealthCheckMaxSeconds) * time.Second,
+			UtilizationDelta: polling.UtilizationDelta,
+		})
+		logrus.Infof("Adaptive polling enabled (usage %dms-%dms, health check %ds-%ds)",
+			polling.UsageMinMs, polling.UsageMaxMs, polling.HealthCheckMinSeconds, polling.HealthCheckMaxSeconds)
+	}
+
+	// 设置反应式再平衡策略（未启用时仍可通过 HTTP 接口生成 dry-run 迁移计划）
+	rb := iarnet.Config.Resource.Rebalance
+	iarnet.ResourceManager.SetRebalancePolicy(resource.RebalancePolicy{
+		Enabled:          rb.Enabled,
+		DryRun:           rb.DryRun,
+		Interval:         time.Duration(rb.IntervalSeconds) * time.Second,
+		HighWatermark:    rb.HighWatermark,
+		LowWatermark:     rb.LowWatermark,
+		MinSkew:          rb.MinSkew,
+		MaxMigrations:    rb.MaxMigrationsPerInterval,
+		Cooldown:         time.Duration(rb.CooldownSeconds) * time.Second,
+		IncludeDedicated: rb.IncludeDedicated,
+	})
+	if rb.Enabled {
+		logrus.Infof("Rebalancer enabled (dry_run=%v, interval=%ds)", rb.DryRun, rb.IntervalSeconds)
+	}
+
+	// 设置 component 默认出站网络策略
+	if egress := iarnet.Config.Resource.Egress; egress.Enabled {
+		policy := &provider.EgressPolicy{}
+		for _, rule := range egress.Allow {
+			policy.Allow = append(policy.Allow, provider.EgressRule{
+				Destination: rule.Destination,
+				Port:        rule.Port,
+				Protocol:    rule.Protocol,
+			})
+		}
+		iarnet.ResourceManager.SetEgressPolicy(policy)
+		logrus.Infof("Component egress policy enabled with %d extra allow rules", len(policy.Allow))
+	}
+
+	// 设置 component 环境变量模板
+	if len(iarnet.Config.Resource.ComponentEnv) > 0 {
+		if err := iarnet.ResourceManager.SetComponentEnv(iarnet.Config.Resource.ComponentEnv); err != nil {
+			return fmt.Errorf("invalid resource.component_env: %w", err)
+		}
+		logrus.Infof("Component env templates configured: %d variables", len(iarnet.Config.Resource.ComponentEnv))
+	}
+
+	// 设置 component 镜像支持的 CPU 架构
+	if len(iarnet.Config.Resource.ComponentImageArchitectures) > 0 {
+		if err := iarnet.ResourceManager.SetComponentImageArchitectures(iarnet.Config.Resource.ComponentImageArchitectures); err != nil {
+			return fmt.Errorf("invalid resource.component_image_architectures: %w", err)
+		}
+	}
+
+	// 设置 sidecar 注入规则
+	if len(iarnet.Config.Resource.SidecarInjection) > 0 {
+		if err := iarnet.ResourceManager.SetSidecarInjection(buildSidecarInjectionRules(iarnet.Config.Resource.SidecarInjection)); err != nil {
+			return fmt.Errorf("invalid resource.sidecar_injection: %w", err)
+		}
+		logrus.Infof("Sidecar injection configured: %d rules", len(iarnet.Config.Resource.SidecarInjection))
+	}
+
 	// 初始化 Discovery 服务（如果启用）
 	if iarnet.Config.Resource.Discovery.Enabled {
 		// 获取节点信息
@@ -123,6 +388,18 @@ func bootstrapResource(iarnet *Iarnet) error {
 		// 设置配置参数
 		discoveryManager.SetMaxGossipPeers(iarnet.Config.Resource.Discovery.MaxGossipPeers)
 		discoveryManager.SetMaxHops(iarnet.Config.Resource.Discovery.MaxHops)
+		discoveryManager.SetSuspectTimeout(time.Duration(iarnet.Config.Resource.Discovery.SuspectTimeoutSeconds) * time.Second)
+		discoveryManager.SetTombstoneTTL(time.Duration(iarnet.Config.Resource.Discovery.TombstoneTTLSeconds) * time.Second)
+		if energy := iarnet.Config.Resource.Energy; energy.WattsPerCore > 0 || energy.BatteryPowered {
+			discoveryManager.SetLocalEnergyProfile(&types.EnergyProfile{
+				WattsPerCore:   energy.WattsPerCore,
+				BatteryPowered: energy.BatteryPowered,
+			})
+		}
+
+		if len(iarnet.Config.Resource.Labels) > 0 {
+			discoveryManager.SetLocalLabels(iarnet.Config.Resource.Labels)
+		}
 
 		// 创建 discovery 服务
 		discoveryService := discovery.NewService(discoveryManager)
@@ -144,10 +421,149 @@ func bootstrapResource(iarnet *Iarnet) error {
 		resourceManager,
 		iarnet.DiscoveryService,
 	)
+	if ne := iarnet.Config.Resource.NetworkEmulation; ne.Enabled {
+		emulation := &scheduler.NetworkEmulation{
+			Default: linkProfile(ne.Default),
+			Peers:   make(map[string]scheduler.LinkProfile, len(ne.Peers)),
+		}
+		for peer, link := range ne.Peers {
+			emulation.Peers[peer] = linkProfile(link)
+		}
+		schedulerService.SetNetworkEmulation(emulation)
+		logrus.Warnf("Network emulation enabled: default rtt=%dms, %d peer overrides", ne.Default.RTTMs, len(ne.Peers))
+	}
+	schedulerService.SetIdentity(iarnet.Identity)
+	upstreamPolicy, err := scheduler.ParseUpstreamPolicy(iarnet.Config.Resource.Delegation.UpstreamAllowedCIDRs)
+	if err != nil {
+		return fmt.Errorf("invalid upstream allowed cidrs: %w", err)
+	}
+	schedulerService.SetUpstreamPolicy(upstreamPolicy)
 	resourceManager.SetSchedulerService(schedulerService)
 	iarnet.SchedulerService = schedulerService
 	resourceManager.SetIsHead(iarnet.Config.Resource.IsHead)
+	failover := iarnet.Config.Resource.HeadFailover
+	resourceManager.SetHeadFailover(resource.HeadFailoverPolicy{
+		Standby:       failover.Standby,
+		Priority:      int32(failover.Priority),
+		CheckInterval: time.Duration(failover.CheckIntervalSeconds) * time.Second,
+		MissThreshold: failover.MissThreshold,
+		StartupGrace:  time.Duration(iarnet.Config.Resource.Discovery.SuspectTimeoutSeconds) * time.Second,
+	})
+	resourceManager.SetNodeLabels(iarnet.Config.Resource.Labels)
 
 	logrus.Info("Resource module initialized")
 	return nil
 }
+
+// buildTimeWindowRules 将配置中的时间窗口规则转换为调度策略规则
+func buildTimeWindowRules(windows []config.TimeWindowConfig) ([]provider.TimeWindowRule, error) {
+	rules := make([]provider.TimeWindowRule, 0, len(windows))
+	for _, w := range windows {
+		schedule, err := util.ParseCron(w.Schedule)
+		if err != nil {
+			return nil, err
+		}
+		var location *time.Location
+		if w.Timezone != "" {
+			if location, err = time.LoadLocation(w.Timezone); err != nil {
+				return nil, fmt.Errorf("rule %q: %w", w.Name, err)
+			}
+		}
+		tasks := provider.TimeWindowTasks(w.Tasks)
+		if tasks == "" {
+			tasks = provider.TimeWindowTasksAll
+		}
+		rules = append(rules, provider.TimeWindowRule{
+			Name:              w.Name,
+			Schedule:          schedule,
+			Location:          location,
+			Providers:         w.Providers,
+			ProviderTags:      w.ProviderTags,
+			Tasks:             tasks,
+			AllowNodeSelector: w.AllowNodeSelector,
+		})
+	}
+	return rules, nil
+}
+
+// buildSidecarInjectionRules 将配置中的 sidecar 注入规则转换为 component 注入规则，sidecar 以规则名称命名
+func buildSidecarInjectionRules(configs []config.SidecarInjectionConfig) []component.SidecarInjectionRule {
+	rules := make([]component.SidecarInjectionRule, 0, len(configs))
+	for _, c := range configs {
+		runtimeEnvs := make([]types.RuntimeEnv, 0, len(c.RuntimeEnvs))
+		for _, env := range c.RuntimeEnvs {
+			runtimeEnvs = append(runtimeEnvs, types.RuntimeEnv(env))
+		}
+		volumes := make([]provider.VolumeMount, 0, len(c.Volumes))
+		for _, v := range c.Volumes {
+			volumes = append(volumes, provider.VolumeMount{
+				Name:      v.Name,
+				HostPath:  v.HostPath,
+				MountPath: v.MountPath,
+				ReadOnly:  v.ReadOnly,
+			})
+		}
+		rules = append(rules, component.SidecarInjectionRule{
+			Name:         c.Name,
+			RuntimeEnvs:  runtimeEnvs,
+			Applications: c.Applications,
+			Sidecar: provider.Sidecar{
+				Name:    c.Name,
+				Image:   c.Image,
+				CPU:     c.CPU,
+				Memory:  c.MemoryMB * 1024 * 1024,
+				GPU:     c.GPU,
+				Env:     c.Env,
+				Command: c.Command,
+			},
+			ComponentEnv: c.ComponentEnv,
+			Volumes:      volumes,
+		})
+	}
+	return rules
+}
+
+// enableAccounting 打开记账数据库并为资源管理器启用资源占用记账
+func enableAccounting(iarnet *Iarnet) error {
+	dbPath := iarnet.Config.Database.AccountingDBPath
+	repo, err := providerrepo.NewAccountingRepoSQLite(dbPath, iarnet.Config)
+	if err != nil {
+		return err
+	}
+	svc, err := accounting.NewService(context.Background(), repo)
+	if err != nil {
+		repo.Close()
+		return err
+	}
+	iarnet.ResourceManager.SetAccounting(svc)
+	iarnet.addCloser("resource accounting", svc)
+	logrus.Infof("Resource accounting enabled at %s", dbPath)
+	return nil
+}
+
+// openDecisionLog 打开调度决策日志；配置了 latency_csv_path 时同时写入各阶段耗时 CSV
+func openDecisionLog(dl config.DecisionLogConfig) (decision.Sink, error) {
+	jsonl, err := decision.NewJSONLSink(dl.Path, int64(dl.MaxSizeMB)*1024*1024, dl.MaxBackups)
+	if err != nil {
+		return nil, err
+	}
+	if dl.LatencyCSVPath == "" {
+		return jsonl, nil
+	}
+	latency, err := decision.NewCSVSink(dl.LatencyCSVPath)
+	if err != nil {
+		jsonl.Close()
+		return nil, err
+	}
+	logrus.Infof("Scheduling latency breakdown enabled at %s", dl.LatencyCSVPath)
+	return decision.Tee(jsonl, latency), nil
+}
+
+// linkProfile 将配置中的链路特性转换为 scheduler 使用的单位
+func linkProfile(link config.LinkConfig) scheduler.LinkProfile {
+	return scheduler.LinkProfile{
+		RTT:       time.Duration(link.RTTMs) * time.Millisecond,
+		Jitter:    time.Duration(link.JitterMs) * time.Millisecond,
+		Bandwidth: int64(link.BandwidthKbps) * 1000 / 8,
+	}
+}
@@ -1,18 +1,84 @@
 package bootstrap
 
 import (
+	"context"
 	"fmt"
+	"net"
+	"path/filepath"
+	"time"
 
+	"github.com/9triver/iarnet/internal/config"
 	"github.com/9triver/iarnet/internal/transport/http"
 	"github.com/9triver/iarnet/internal/transport/rpc"
 	"github.com/9triver/iarnet/internal/transport/zmq"
+	"github.com/9triver/iarnet/internal/util/compress"
+	"github.com/9triver/iarnet/internal/util/identity"
+	"github.com/9triver/iarnet/internal/util/msgguard"
+	"github.com/9triver/iarnet/internal/util/ports"
+	"github.com/9triver/iarnet/internal/util/tunnel"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
 )
 
-// BootstrapTransport 初始化 Transport 层（RPC、HTTP 等）
-func bootstrapTransport(iarnet *Iarnet) error {
-	// 创建 ZMQ Channeler（现在可以在 Resource 之后初始化）
-	channeler := zmq.NewChanneler(iarnet.Config.Transport.ZMQ.Port)
+// portBindings 节点的 HTTP 与 gRPC 监听端口
+// ZMQ 为可选模块，端口在 bootstrapZMQ 中单独登记，被占用时节点以降级模式运行
+func portBindings(cfg *config.Config) []ports.Binding {
+	t := cfg.Transport
+	bindings := []ports.Binding{
+		{Service: "iarnet http", Port: t.HTTP.Port},
+		{Service: "iarnet ignis rpc", Port: t.RPC.Ignis.Port},
+		{Service: "iarnet store rpc", Port: t.RPC.Store.Port},
+		{Service: "iarnet logger rpc", Port: t.RPC.Logger.Port},
+		{Service: "iarnet resource logger rpc", Port: t.RPC.ResourceLogger.Port},
+		{Service: "iarnet discovery rpc", Port: t.RPC.Discovery.Port},
+		{Service: "iarnet scheduler rpc", Port: t.RPC.Scheduler.Port},
+	}
+	if t.Tunnel.Enabled {
+		bindings = append(bindings, ports.Binding{Service: "iarnet provider tunnel", Port: t.Tunnel.Port})
+	}
+	return bindings
+}
+
+// bootstrapTunnel 启动反向连接隧道，供 NAT 后的 provider 主动接入
+func bootstrapTunnel(iarnet *Iarnet) (*tunnel.Hub, error) {
+	port := iarnet.Config.Transport.Tunnel.Port
+	lis, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%d", port))
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on provider tunnel port %d: %w", port, err)
+	}
+	hub := tunnel.NewHub()
+	go func() {
+		if err := hub.Serve(lis); err != nil {
+			logrus.Errorf("Provider tunnel stopped: %v", err)
+		}
+	}()
+	iarnet.addCloser("provider tunnel", hub)
+	logrus.Infof("Provider tunnel listening on port %d", port)
+	return hub, nil
+}
+
+// bootstrapZMQ 创建 ZMQ Channeler 并注入到 ResourceManager
+// ZMQ 在后台 goroutine 中绑定端口且绑定失败时会直接 panic，因此先检查端口是否可用，
+// 使端口被占用等常见故障表现为可重试的错误
+func bootstrapZMQ(ctx context.Context, iarnet *Iarnet) error {
+	port := iarnet.Config.Transport.ZMQ.Port
+	if err := iarnet.Ports.Claim(ports.Binding{Service: "iarnet zmq", Port: port}); err != nil {
+		return fmt.Errorf("zmq port %d is unavailable: %w", port, err)
+	}
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return fmt.Errorf("zmq port %d is unavailable: %w", port, err)
+	}
+	listener.Close()
+
+	channeler := zmq.NewChanneler(port)
+	compression := iarnet.Config.Transport.Compression
+	alg, err := compress.ParseAlgorithm(compression.ZMQ)
+	if err != nil {
+		return fmt.Errorf("invalid zmq compression: %w", err)
+	}
+	channeler.SetCompression(alg, compression.ZMQMinBytes)
+	channeler.SetMessageLimits(iarnet.Config.Transport.MessageLimits.MaxZMQFrameBytes)
 
 	// 将真正的 channeler 注入到 ResourceManager
 	if iarnet.ResourceManager != nil {
@@ -22,6 +88,25 @@ func bootstrapTransport(iarnet *Iarnet) error {
 
 	// 保存 channeler 引用（用于后续关闭）
 	iarnet.Channeler = channeler
+	return nil
+}
+
+// bootstrapRPC 创建 RPC 服务器管理器
+func bootstrapRPC(iarnet *Iarnet) error {
+	// gRPC 压缩为进程级设置，discovery、scheduler 连接 peer 时同样使用
+	alg, err := compress.ParseAlgorithm(iarnet.Config.Transport.Compression.GRPC)
+	if err != nil {
+		return fmt.Errorf("invalid grpc compression: %w", err)
+	}
+	compress.SetGRPCAlgorithm(alg)
+
+	// 非法消息的容忍度同样为进程级设置，ZMQ 与 ignis 会话共用
+	limits := iarnet.Config.Transport.MessageLimits
+	msgguard.SetLimits(msgguard.Limits{
+		MaxBadMessages: limits.MaxBadMessages,
+		Window:         time.Duration(limits.WindowSeconds) * time.Second,
+		BlockDuration:  time.Duration(limits.BlockSeconds) * time.Second,
+	})
 
 	// 构建 RPC 服务器地址
 	ignisAddr := fmt.Sprintf("0.0.0.0:%d", iarnet.Config.Transport.RPC.Ignis.Port)
@@ -31,6 +116,26 @@ func bootstrapTransport(iarnet *Iarnet) error {
 	discoveryAddr := fmt.Sprintf("0.0.0.0:%d", iarnet.Config.Transport.RPC.Discovery.Port)
 	schedulerAddr := fmt.Sprintf("0.0.0.0:%d", iarnet.Config.Transport.RPC.Scheduler.Port)
 
+	// 校验其他节点对 scheduler 调用的签名，对端公钥在首次出现时绑定到其 node ID，签名须指向本节点
+	peerKeys, err := identity.LoadPins(filepath.Join(iarnet.Config.DataDir, "peer_keys.json"))
+	if err != nil {
+		return fmt.Errorf("failed to load peer keys: %w", err)
+	}
+	idCfg := iarnet.Config.Resource.Identity
+	schedulerServerOpts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(identity.UnaryServerInterceptor(peerKeys, identity.VerifyOptions{
+			NodeID:        iarnet.ResourceManager.GetNodeID(),
+			RequireSigned: idCfg.RequireSignedPeers,
+			MaxClockSkew:  time.Duration(idCfg.MaxClockSkewSeconds) * time.Second,
+		})),
+	}
+
+	// 校验 component 与 provider 回连 store、logger 时出示的上游令牌
+	var upstreamServerOpts []grpc.ServerOption
+	if iarnet.UpstreamTokens != nil {
+		upstreamServerOpts = iarnet.UpstreamTokens.ServerOptions(iarnet.Config.Resource.Delegation.UpstreamTokens.Require)
+	}
+
 	// 创建 RPC 服务器管理器（不启动，启动操作在 Start 方法中统一执行）
 	opts := rpc.Options{
 		IgnisAddr:             ignisAddr,
@@ -46,11 +151,20 @@ func bootstrapTransport(iarnet *Iarnet) error {
 		DiscoveryManager:      iarnet.DiscoveryManager,
 		SchedulerAddr:         schedulerAddr,
 		SchedulerService:      iarnet.SchedulerService,
+		SchedulerServerOpts:   schedulerServerOpts,
+
+		StoreServerOpts:          upstreamServerOpts,
+		ResourceLoggerServerOpts: upstreamServerOpts,
+		MaxRecvMsgSize:           limits.MaxGRPCMessageBytes,
 	}
 
 	iarnet.RPCManager = rpc.NewManager(opts)
+	logrus.Info("RPC transport initialized")
+	return nil
+}
 
-	// 创建 HTTP 服务器
+// bootstrapHTTP 创建 HTTP 服务器
+func bootstrapHTTP(iarnet *Iarnet) error {
 	iarnet.HTTPServer = http.NewServer(http.Options{
 		Port:             iarnet.Config.Transport.HTTP.Port,
 		AppMgr:           iarnet.ApplicationManager,
@@ -58,8 +172,9 @@ func bootstrapTransport(iarnet *Iarnet) error {
 		Platform:         iarnet.IgnisPlatform,
 		Config:           iarnet.Config,
 		DiscoveryService: iarnet.DiscoveryService,
+		Modules:          iarnet.Modules,
 	})
 
-	logrus.Info("Transport layer initialized")
+	logrus.Info("HTTP transport initialized")
 	return nil
 }
@@ -4,13 +4,20 @@ package config
 // 包含应用运行所需的所有配置，各模块配置通过组合方式引入
 type Config struct {
 	// 应用基础配置
-	Host              string            `yaml:"host"`                // Host for external connection. TODO: 通信问题待解决
-	ListenAddr        string            `yaml:"listen_addr"`         // e.g., ":8080"
-	PeerListenAddr    string            `yaml:"peer_listen_addr"`    // e.g., ":50051" for gRPC
-	InitialPeers      []string          `yaml:"initial_peers"`       // e.g., ["peer1:50051"]
-	ResourceLimits    map[string]string `yaml:"resource_limits"`     // e.g., {"cpu": "4", "memory": "8Gi", "gpu": "2"}
-	DataDir           string            `yaml:"data_dir"`            // e.g., "./data" - directory for SQLite databases
-	EnableLocalDocker bool              `yaml:"enable_local_docker"` // e.g., true - enable local docker provider
+	Host                   string            `yaml:"host"`                     // Host for external connection. TODO: 通信问题待解决
+	ListenAddr             string            `yaml:"listen_addr"`              // e.g., ":8080"
+	PeerListenAddr         string            `yaml:"peer_listen_addr"`         // e.g., ":50051" for gRPC
+	InitialPeers           []string          `yaml:"initial_peers"`            // e.g., ["peer1:50051"]
+	ResourceLimits         map[string]string `yaml:"resource_limits"`          // e.g., {"cpu": "4", "memory": "8Gi", "gpu": "2"}
+	DataDir                string            `yaml:"data_dir"`                 // e.g., "./data" - directory for SQLite databases
+	EnableLocalDocker      bool              `yaml:"enable_local_docker"`      // e.g., true - enable local docker provider
+	ShutdownTimeoutSeconds int               `yaml:"shutdown_timeout_seconds"` // e.g., 30 - max seconds to wait for in-flight deployments on shutdown
+
+	// 日志配置
+	Logging LoggingConfig `yaml:"logging"` // Log format and per-module log levels
+
+	// 滚动重启配置
+	Handover HandoverConfig `yaml:"handover"` // Upgrade-safe restart (SIGUSR2) configuration
 
 	// 领域模块配置（内联定义，避免循环依赖）
 	Application ApplicationConfig `yaml:"application"` // Application module configuration
@@ -20,22 +27,320 @@ type Config struct {
 	Database    DatabaseConfig    `yaml:"database"`    // Database configuration
 }
 
+// LoggingConfig 日志配置
+// 模块级别可在运行时通过 PUT /system/logging 调整，调整不会写回配置文件
+type LoggingConfig struct {
+	Format  string            `yaml:"format"`  // e.g., "text" or "json"
+	Level   string            `yaml:"level"`   // e.g., "info" - level for logs outside the modules below
+	Modules map[string]string `yaml:"modules"` // e.g., {"scheduler": "debug"} - keys: resource, scheduler, discovery, providers
+}
+
+// HandoverConfig 滚动重启配置
+// 收到 SIGUSR2 时节点以相同参数启动新进程，传递监听端口与 component 会话状态，新进程就绪后旧进程退出，已部署的 component 无需重新部署
+type HandoverConfig struct {
+	TimeoutSeconds     int `yaml:"timeout_seconds"`      // e.g., 60 - max seconds to wait for the new process to become ready
+	ResumeGraceSeconds int `yaml:"resume_grace_seconds"` // e.g., 2 - seconds for components to reconnect before queued messages are sent
+}
+
 // ApplicationConfig Application 模块配置
 type ApplicationConfig struct {
-	WorkspaceDir string            `yaml:"workspace_dir"` // e.g., "./workspaces" - directory for git repositories
-	RunnerImages map[string]string `yaml:"runner_images"` // e.g., "python:3.11-alpine" - image to use for runner containers
+	WorkspaceDir  string            `yaml:"workspace_dir"`   // e.g., "./workspaces" - directory for git repositories
+	RunnerImages  map[string]string `yaml:"runner_images"`   // e.g., "python:3.11-alpine" - image to use for runner containers
+	BuildCacheDir string            `yaml:"build_cache_dir"` // e.g., "./build-cache" - directory for build artifacts keyed by commit SHA
+	Registry      RegistryConfig    `yaml:"registry"`        // OCI registry for images built from application source
+}
+
+// RegistryConfig 应用镜像推送的目标 registry
+type RegistryConfig struct {
+	Address  string `yaml:"address"`  // e.g., "registry.local:5000"，为空时镜像只保存在本地，只有与本节点共用 Docker daemon 的 provider 能运行
+	Username string `yaml:"username"` // registry 用户名（可选）
+	Password string `yaml:"password"` // registry 密码（可选）
 }
 
 // ResourceConfig Resource 模块配置
 type ResourceConfig struct {
-	GlobalRegistryAddr string            `yaml:"global_registry_addr"` // e.g., "localhost:50010" - address of the global registry
-	Name               string            `yaml:"name"`                 // e.g., "node.1" - name of the node
-	Description        string            `yaml:"description"`          // e.g., "node.1 description" - description of the node
-	DomainID           string            `yaml:"domain_id"`            // e.g., "domain.AT9xbJe6RxzkPSL65bkwud" - domain ID of the node
-	IsHead             bool              `yaml:"is_head"`              // 是否为 head 节点
-	ComponentImages    map[string]string `yaml:"component_images"`     // e.g., "python:3.11-alpine" - image to use for actor containers
-	Store              StoreConfig       `yaml:"store"`                // Store configuration
-	Discovery          DiscoveryConfig   `yaml:"discovery"`            // Gossip 节点发现配置
+	GlobalRegistryAddr string             `yaml:"global_registry_addr"` // e.g., "localhost:50010" - address of the global registry
+	Name               string             `yaml:"name"`                 // e.g., "node.1" - name of the node
+	Description        string             `yaml:"description"`          // e.g., "node.1 description" - description of the node
+	DomainID           string             `yaml:"domain_id"`            // e.g., "domain.AT9xbJe6RxzkPSL65bkwud" - domain ID of the node
+	IsHead             bool               `yaml:"is_head"`              // 是否为 head 节点
+	HeadFailover       HeadFailoverConfig `yaml:"head_failover"`        // head 角色故障转移（备用 head）
+	ComponentImages    map[string]string  `yaml:"component_images"`     // e.g., "python:3.11-alpine" - image to use for actor containers
+	ComponentEnv       map[string]string  `yaml:"component_env"`        // 部署 component 时附加的环境变量模板（可选），e.g., "NODE_IP": "{{.NodeIP}}"
+	Store              StoreConfig        `yaml:"store"`                // Store configuration
+	Discovery          DiscoveryConfig    `yaml:"discovery"`            // Gossip 节点发现配置
+	Energy             EnergyConfig       `yaml:"energy"`               // 节点能耗画像（可选）
+	Labels             map[string]string  `yaml:"labels"`               // 节点标签（可选），随 gossip 传播并上报全局注册中心，用于按标签查询节点和部署请求的节点标签约束
+	Egress             EgressConfig       `yaml:"egress"`               // component 出站网络策略（可选）
+	Delegation         DelegationConfig   `yaml:"delegation"`           // 委托部署到同域节点的探测配置
+	DecisionLog        DecisionLogConfig  `yaml:"decision_log"`         // 调度决策日志（离线分析用）
+	Trace              TraceConfig        `yaml:"trace"`                // 部署请求 trace（由 cmd/tracereplay 重放）
+	Rebalance          RebalanceConfig    `yaml:"rebalance"`            // 基于负载的反应式再平衡
+	Benchmark          BenchmarkConfig    `yaml:"benchmark"`            // provider 注册时的微基准测试
+	Polling            PollingConfig      `yaml:"polling"`              // 使用量轮询与 provider 健康检测的自适应间隔
+
+	StaticProviders            []StaticProviderConfig `yaml:"static_providers"`              // 启动时自动注册的 provider，API 中只读
+	StaticProviderRetrySeconds int                    `yaml:"static_provider_retry_seconds"` // e.g., 10 - 配置的 provider 连接失败后的重试间隔
+
+	CapacityCacheTTLSeconds int `yaml:"capacity_cache_ttl_seconds"` // e.g., 2 - provider 容量缓存最大陈旧时间，0 表示不过期
+	AffinityTTLSeconds      int `yaml:"affinity_ttl_seconds"`       // e.g., 1800 - 会话亲和的默认空闲超时
+
+	// component 镜像支持的 CPU 架构（可选），键与 component_images 相同，e.g., "python": ["amd64"]；未配置的镜像视为多架构镜像
+	ComponentImageArchitectures map[string][]string `yaml:"component_image_architectures"`
+
+	// 外部策略 webhook（可选），部署前由外部端点审查候选 provider
+	PolicyWebhook PolicyWebhookConfig `yaml:"policy_webhook"`
+
+	// 时间窗口调度规则（可选），e.g., 工作时间不向办公桌面机部署大任务
+	TimeWindows []TimeWindowConfig `yaml:"time_windows"`
+
+	// 资源占用记账，按应用与域生成计费报表
+	Accounting AccountingConfig `yaml:"accounting"`
+
+	// 利用率采样日志（离线分析用），按 provider 记录利用率，可与调度决策日志中的 provider_id 关联
+	UtilizationLog UtilizationLogConfig `yaml:"utilization_log"`
+
+	// 本地部署失败时的重试策略，只重试暂时性错误（provider 复核容量失败、provider 暂时不可达）
+	DeployRetry DeployRetryConfig `yaml:"deploy_retry"`
+
+	// 按历史放置结果调整调度偏好
+	PlacementHistory PlacementHistoryConfig `yaml:"placement_history"`
+
+	// 同时运行的 component 数量上限（可选），保护小型边缘设备在 CPU/内存看似空闲时不被大量 component 压垮
+	ComponentLimits ComponentLimitsConfig `yaml:"component_limits"`
+
+	// 节点间网络仿真（实验用），对发往其他节点的调度调用注入时延与带宽限制
+	NetworkEmulation NetworkEmulationConfig `yaml:"network_emulation"`
+
+	// 节点身份：注册、健康检查与节点间 scheduler 调用的签名校验
+	Identity IdentityConfig `yaml:"identity"`
+
+	// sidecar 注入规则（可选），为本节点部署的全部或部分 component 附加标准 sidecar（日志采集、代理、安全代理等）
+	SidecarInjection []SidecarInjectionConfig `yaml:"sidecar_injection"`
+}
+
+// SidecarInjectionConfig sidecar 注入规则
+// sidecar 与主容器部署在同一 provider 并共享网络，资源计入 component 的资源请求，只会选择支持 sidecar 的 provider
+type SidecarInjectionConfig struct {
+	Name         string                `yaml:"name"`          // e.g., "log-shipper" - 规则名称，同时作为 sidecar 名称
+	RuntimeEnvs  []string              `yaml:"runtime_envs"`  // 只注入这些运行时环境（component_images 的键）的 component，为空表示全部
+	Applications []string              `yaml:"applications"`  // 只注入这些应用的 component，为空表示全部
+	Image        string                `yaml:"image"`         // e.g., "fluent/fluent-bit:3.0"
+	CPU          int64                 `yaml:"cpu"`           // e.g., 100 - millicores
+	MemoryMB     int64                 `yaml:"memory_mb"`     // e.g., 64
+	GPU          int64                 `yaml:"gpu"`           // e.g., 0
+	Command      []string              `yaml:"command"`       // 非空时覆盖镜像的 entrypoint
+	Env          map[string]string     `yaml:"env"`           // sidecar 环境变量模板，可用字段与 component_env 相同
+	ComponentEnv map[string]string     `yaml:"component_env"` // 注入主容器的环境变量模板，e.g., "HTTP_PROXY": "http://127.0.0.1:15001"
+	Volumes      []SidecarVolumeConfig `yaml:"volumes"`       // 同时挂载到主容器与 sidecar，e.g., 主容器写日志、sidecar 采集；需 provider 支持卷挂载
+}
+
+// SidecarVolumeConfig 注入规则的共享卷，name 与 host_path 二选一
+type SidecarVolumeConfig struct {
+	Name      string `yaml:"name"`       // provider 管理的命名卷
+	HostPath  string `yaml:"host_path"`  // provider 主机上的绝对路径
+	MountPath string `yaml:"mount_path"` // 容器内的绝对路径
+	ReadOnly  bool   `yaml:"read_only"`
+}
+
+// IdentityConfig 节点身份配置
+// 节点密钥在首次启动时生成并保存在 data_dir/node_key；其他节点的公钥在首次出现时绑定到其 node ID，保存在 data_dir/peer_keys.json
+type IdentityConfig struct {
+	RequireSignedPeers  bool `yaml:"require_signed_peers"`   // 拒绝未签名的 scheduler 调用；为 false 时兼容尚未升级的节点
+	MaxClockSkewSeconds int  `yaml:"max_clock_skew_seconds"` // e.g., 300 - 签名时间与本地时间允许的最大偏差，超过视为重放
+}
+
+// NetworkEmulationConfig 节点间网络仿真配置
+// 同一局域网内的实验节点互相调用几乎没有时延，启用后按目标节点模拟广域网链路
+type NetworkEmulationConfig struct {
+	Enabled bool                  `yaml:"enabled"` // 是否启用网络仿真，生产环境应保持关闭
+	Default LinkConfig            `yaml:"default"` // 未单独配置的节点使用的链路特性
+	Peers   map[string]LinkConfig `yaml:"peers"`   // 按目标节点 ID 或名称覆盖 default，e.g., "node.2": {rtt_ms: 80}
+}
+
+// LinkConfig 仿真链路特性，0 表示不注入对应的延迟
+type LinkConfig struct {
+	RTTMs         int `yaml:"rtt_ms"`         // e.g., 50 - 往返时延
+	JitterMs      int `yaml:"jitter_ms"`      // e.g., 10 - 往返时延随机增减的范围
+	BandwidthKbps int `yaml:"bandwidth_kbps"` // e.g., 10000 - 链路带宽，按消息大小计算传输时间
+}
+
+// PlacementHistoryConfig 历史放置统计配置
+// 按任务规模（small/large/gpu）分别统计各 provider 的部署结果与各节点的委托结果，偏向历史上成功率高、耗时短的目标
+type PlacementHistoryConfig struct {
+	Enabled    bool `yaml:"enabled"`     // 是否按历史放置结果调整调度偏好
+	MinSamples int  `yaml:"min_samples"` // e.g., 5 - 目标的放置次数达到该值后才按历史统计排序
+}
+
+// ComponentLimitsConfig component 数量上限，0 表示不限制
+type ComponentLimitsConfig struct {
+	MaxPerProvider int `yaml:"max_per_provider"` // e.g., 20 - 每个 provider 上同时运行的 component 数上限
+	MaxPerNode     int `yaml:"max_per_node"`     // e.g., 50 - 本节点所有 provider 上同时运行的 component 数上限
+}
+
+// HeadFailoverConfig head 角色故障转移配置
+// 备用节点通过 gossip 观察 head 的存活状态（依赖 resource.discovery），head 连续多次不存活时由优先级最高的存活备用节点接管，
+// 并立即向 global registry 上报新的 head 与任期
+type HeadFailoverConfig struct {
+	Standby              bool `yaml:"standby"`                // 本节点是否为备用 head，不能与 is_head 同时设置
+	Priority             int  `yaml:"priority"`               // e.g., 10 - 多个备用节点均可接管时优先级高者接管，相同时节点 ID 小者接管
+	CheckIntervalSeconds int  `yaml:"check_interval_seconds"` // e.g., 10 - 检查 head 存活的间隔
+	MissThreshold        int  `yaml:"miss_threshold"`         // e.g., 3 - head 连续多少次检查不存活后接管
+}
+
+// DeployRetryConfig 本地部署的重试策略
+// 每次重试前重新选择 provider，并等待带抖动的指数退避时间；重试耗尽后再委托给其他节点
+type DeployRetryConfig struct {
+	MaxAttempts      int     `yaml:"max_attempts"`       // e.g., 3 - 总尝试次数（含首次），1 表示不重试
+	InitialBackoffMs int     `yaml:"initial_backoff_ms"` // e.g., 200 - 第一次重试前的退避时间
+	MaxBackoffMs     int     `yaml:"max_backoff_ms"`     // e.g., 2000 - 退避时间上限
+	Jitter           float64 `yaml:"jitter"`             // e.g., 0.5 - 退避时间随机缩短的最大比例（0-1）
+}
+
+// UtilizationLogConfig 利用率采样日志配置
+// 启用后周期性追加 CSV：每次采样一行节点汇总与每个 provider 一行（provider ID、类型、状态、component 数与容量）
+type UtilizationLogConfig struct {
+	Enabled         bool   `yaml:"enabled"`          // 是否记录利用率
+	Path            string `yaml:"path"`             // e.g., "./data/utilization.csv"
+	IntervalSeconds int    `yaml:"interval_seconds"` // e.g., 10 - 采样间隔
+}
+
+// DelegationConfig 委托部署配置
+// 委托前并行向排名前 K 的候选节点发送部署探测，提交给排名最高的接受者
+type DelegationConfig struct {
+	ParallelProbes      int `yaml:"parallel_probes"`       // e.g., 3 - 同时探测的候选节点数 K
+	ProbeTimeoutSeconds int `yaml:"probe_timeout_seconds"` // e.g., 2 - 单个节点的探测超时
+
+	// e.g., 60 - 接受委托部署的节点定期向委托方上报由其委托部署的 component，删除委托方没有记录的
+	// （委托方提交部署后未收到响应而遗留的）；0 表示不对账
+	ReconcileIntervalSeconds int `yaml:"reconcile_interval_seconds"`
+
+	// 接受其他节点委托部署时允许的上游 ZMQ/Store/Logger 地址网段（可选），e.g., ["10.0.0.0/8"]；
+	// 为空时只校验地址格式并拒绝未指定、链路本地与组播地址
+	UpstreamAllowedCIDRs []string `yaml:"upstream_allowed_cidrs"`
+
+	// 与 component ID 绑定的上游令牌
+	UpstreamTokens UpstreamTokenConfig `yaml:"upstream_tokens"`
+}
+
+// UpstreamTokenConfig 上游令牌配置
+// 启用后本节点为每个以本节点为上游的 component（包括委托到其他节点部署的）签发与其 ID 绑定的令牌，
+// 通过环境变量 IARNET_UPSTREAM_TOKEN 注入；component 与 provider 回连 store、logger 时在 gRPC metadata 中出示，连接 ZMQ 时在 Ready 消息中出示
+type UpstreamTokenConfig struct {
+	Enabled bool `yaml:"enabled"` // 是否签发令牌；出示了令牌的连接始终校验
+	Require bool `yaml:"require"` // 拒绝未出示令牌的连接，需 component 运行时支持出示令牌
+	// 令牌密钥文件（可选），e.g., "/etc/iarnet/token.key"；为空时使用数据目录中自动生成的密钥。
+	// 使用 resource.store.shared_address 时同域节点与独立 store 需配置同一密钥文件，独立 store 才能校验各节点签发的令牌
+	KeyFile string `yaml:"key_file"`
+}
+
+// DecisionLogConfig 调度决策日志配置
+// 启用后每次部署追加一条 JSONL 记录：资源请求、考察过的候选、选中的目标、结果、端到端耗时与各阶段耗时
+type DecisionLogConfig struct {
+	Enabled    bool   `yaml:"enabled"`     // 是否记录调度决策
+	Path       string `yaml:"path"`        // e.g., "./data/decisions.jsonl"
+	MaxSizeMB  int    `yaml:"max_size_mb"` // e.g., 100 - 单个文件大小上限，超过后轮转
+	MaxBackups int    `yaml:"max_backups"` // e.g., 5 - 保留的历史文件数，0 表示轮转时丢弃
+
+	// 各阶段耗时 CSV（可选），e.g., "./data/decision_latency.csv"；每次部署追加一行，为空时不写入
+	LatencyCSVPath string `yaml:"latency_csv_path"`
+}
+
+// TraceConfig 部署请求 trace 配置
+// 启用后逐行记录每次部署与删除（时间、资源请求、结果），可用 cmd/tracereplay 在测试集群上重放以验证调度策略的改动
+type TraceConfig struct {
+	Enabled bool   `yaml:"enabled"` // 是否记录 trace
+	Path    string `yaml:"path"`    // e.g., "./data/deploy_trace.jsonl"
+}
+
+// RebalanceConfig 反应式再平衡配置
+// 定期检查本节点各 provider 的利用率偏差，将可迁移的 component 从过载 provider 迁移到空闲 provider
+type RebalanceConfig struct {
+	Enabled                  bool    `yaml:"enabled"`                     // 是否启用再平衡
+	DryRun                   bool    `yaml:"dry_run"`                     // 只生成迁移计划，不实际迁移
+	IntervalSeconds          int     `yaml:"interval_seconds"`            // e.g., 60 - 检查间隔
+	HighWatermark            float64 `yaml:"high_watermark"`              // e.g., 0.8 - 利用率超过该值的 provider 视为过载
+	LowWatermark             float64 `yaml:"low_watermark"`               // e.g., 0.6 - 迁入后利用率不得超过该值
+	MinSkew                  float64 `yaml:"min_skew"`                    // e.g., 0.2 - 源与目标 provider 利用率的最小差值
+	MaxMigrationsPerInterval int     `yaml:"max_migrations_per_interval"` // e.g., 2 - 每个周期最多迁移的 component 数
+	CooldownSeconds          int     `yaml:"cooldown_seconds"`            // e.g., 600 - 同一 component 两次迁移的最小间隔
+	IncludeDedicated         bool    `yaml:"include_dedicated"`           // 是否迁移 dedicated provider 上的 component（默认只迁移可驱逐的）
+}
+
+// PollingConfig 自适应轮询配置
+// 启用后使用量轮询与 provider 健康检测的间隔在上下限之间自适应：利用率变化快或有部署进行中时回到下限，
+// 空闲时逐轮翻倍直到上限；未启用时固定为 2 秒与 30 秒
+type PollingConfig struct {
+	Adaptive              bool    `yaml:"adaptive"`                 // 是否启用自适应间隔
+	UsageMinMs            int     `yaml:"usage_min_ms"`             // e.g., 2000 - 使用量轮询间隔下限
+	UsageMaxMs            int     `yaml:"usage_max_ms"`             // e.g., 30000 - 使用量轮询间隔上限
+	HealthCheckMinSeconds int     `yaml:"health_check_min_seconds"` // e.g., 10 - provider 健康检测间隔下限
+	HealthCheckMaxSeconds int     `yaml:"health_check_max_seconds"` // e.g., 120 - provider 健康检测间隔上限
+	UtilizationDelta      float64 `yaml:"utilization_delta"`        // e.g., 0.1 - 两次采样间 provider 利用率变化超过该值时按下限轮询
+}
+
+// StaticProviderConfig 配置文件管理的 provider
+// 启动时按配置同步 provider 仓库并自动注册，连接失败时后台重试；从配置中移除后随下次启动删除
+type StaticProviderConfig struct {
+	Name          string `yaml:"name"`           // 唯一名称，provider ID 由名称派生（provider.static.<name>）
+	Host          string `yaml:"host"`           // e.g., "localhost"
+	Port          int    `yaml:"port"`           // e.g., 50051
+	Token         string `yaml:"token"`          // 注册令牌（可选），需与 provider 的 server.token 一致
+	CapacityClass string `yaml:"capacity_class"` // guaranteed（默认）或 best-effort
+	Reverse       bool   `yaml:"reverse"`        // 反向连接：provider 主动连接 transport.tunnel，无需 host/port，token 必填
+}
+
+// BenchmarkConfig provider 微基准测试配置
+// 启用后注册 provider 时在后台测量 CPU、内存带宽、磁盘 IO 并探测 GPU，结果用于调度策略排序
+type BenchmarkConfig struct {
+	OnRegister     bool `yaml:"on_register"`     // 注册 provider 后是否运行微基准测试
+	TimeoutSeconds int  `yaml:"timeout_seconds"` // e.g., 30 - 单次基准测试超时
+}
+
+// PolicyWebhookConfig 外部策略 webhook 配置
+// 配置 url 后，调度时将资源请求与候选 provider POST 到该端点，按返回的 provider ID 列表过滤并排序候选
+type PolicyWebhookConfig struct {
+	URL            string `yaml:"url"`             // e.g., "http://policy.internal/placement"，为空表示不启用
+	TimeoutSeconds int    `yaml:"timeout_seconds"` // e.g., 2 - 单次调用超时
+	FailOpen       bool   `yaml:"fail_open"`       // 超时或出错时是否放行全部候选，默认拒绝
+}
+
+// AccountingConfig 资源占用记账配置
+// 启用后记录每个 component 的资源占用时段（存放在 database.accounting_db_path），可通过 API 生成按应用与域汇总的报表
+type AccountingConfig struct {
+	Enabled bool `yaml:"enabled"` // 是否记录资源占用
+}
+
+// TimeWindowConfig 时间窗口调度规则
+// schedule 匹配当前时刻时，规则选中的 provider 不接受规则限制的任务
+type TimeWindowConfig struct {
+	Name              string            `yaml:"name"`                // 规则名称，用于日志
+	Schedule          string            `yaml:"schedule"`            // 类 cron 表达式（分 时 日 月 周），e.g., "* 9-17 * * 1-5" 表示工作日 9:00-18:00
+	Timezone          string            `yaml:"timezone"`            // e.g., "Asia/Shanghai"，为空表示本地时区
+	Providers         []string          `yaml:"providers"`           // provider 名称或 ID 通配符，e.g., "desktop-*"，为空表示全部
+	ProviderTags      []string          `yaml:"provider_tags"`       // 只作用于具备这些资源标签的 provider，e.g., ["gpu"]
+	Tasks             string            `yaml:"tasks"`               // all / large / small，为空表示 all
+	AllowNodeSelector map[string]string `yaml:"allow_node_selector"` // 节点标签约束包含这些键值的请求不受限制，e.g., {"team": "a"}
+}
+
+// EgressConfig component 默认出站网络策略
+// 启用后 component 仅能访问 iarnet 上游地址及 allow 中列出的目的地
+type EgressConfig struct {
+	Enabled bool               `yaml:"enabled"` // 是否启用出站限制
+	Allow   []EgressRuleConfig `yaml:"allow"`   // 额外放行的目的地
+}
+
+// EgressRuleConfig 出站放行规则
+type EgressRuleConfig struct {
+	Destination string `yaml:"destination"` // 目标 IP、CIDR 或主机名
+	Port        int    `yaml:"port"`        // 目标端口，0 表示任意端口
+	Protocol    string `yaml:"protocol"`    // tcp / udp，空表示任意协议
+}
+
+// EnergyConfig 节点能耗画像配置，随 gossip 传播供调度参考
+type EnergyConfig struct {
+	WattsPerCore   float64 `yaml:"watts_per_core"`  // 每核功耗（瓦），0 表示未知
+	BatteryPowered bool    `yaml:"battery_powered"` // 是否由电池供电
 }
 
 // DiscoveryConfig Gossip 节点发现配置
@@ -43,6 +348,8 @@ type DiscoveryConfig struct {
 	Enabled                    bool `yaml:"enabled"`                       // 是否启用 gossip 发现
 	GossipIntervalSeconds      int  `yaml:"gossip_interval_seconds"`       // Gossip 间隔（秒）
 	NodeTTLSeconds             int  `yaml:"node_ttl_seconds"`              // 节点信息过期时间（秒）
+	SuspectTimeoutSeconds      int  `yaml:"suspect_timeout_seconds"`       // 未观察到心跳多久后视为 suspect，不再参与调度（秒）
+	TombstoneTTLSeconds        int  `yaml:"tombstone_ttl_seconds"`         // 离开或过期节点的墓碑保留时间（秒）
 	MaxGossipPeers             int  `yaml:"max_gossip_peers"`              // 每次 gossip 的最大 peer 数量
 	MaxHops                    int  `yaml:"max_hops"`                      // 最大跳数
 	QueryTimeoutSeconds        int  `yaml:"query_timeout_seconds"`         // 资源查询超时时间（秒）
@@ -52,13 +359,58 @@ type DiscoveryConfig struct {
 }
 
 type TransportConfig struct {
-	ZMQ  ZMQConfig  `yaml:"zmq"`
-	RPC  RPCConfig  `yaml:"rpc"`
-	HTTP HTTPConfig `yaml:"http"`
+	ZMQ         ZMQConfig         `yaml:"zmq"`
+	RPC         RPCConfig         `yaml:"rpc"`
+	HTTP        HTTPConfig        `yaml:"http"`
+	Compression CompressionConfig `yaml:"compression"` // 大消息路径的负载压缩
+	Tunnel      TunnelConfig      `yaml:"tunnel"`      // 反向连接隧道，供 NAT 后的 provider 主动接入
+
+	MessageLimits MessageLimitsConfig `yaml:"message_limits"` // component ZMQ 通道与 ignis 会话流的消息校验
+}
+
+// MessageLimitsConfig 边界消息校验配置
+// 超长、无法解码或不符合消息定义的消息被丢弃并计入对端的非法消息数，达到上限的对端被断开并在一段时间内拒绝
+type MessageLimitsConfig struct {
+	MaxZMQFrameBytes    int `yaml:"max_zmq_frame_bytes"`    // e.g., 536870912 - component 发来的单条 ZMQ 消息（解压后）上限
+	MaxGRPCMessageBytes int `yaml:"max_grpc_message_bytes"` // e.g., 536870912 - 节点各 gRPC 服务接收的单条消息上限
+	MaxBadMessages      int `yaml:"max_bad_messages"`       // e.g., 20 - 对端在 window_seconds 内的非法消息达到该值后被断开，0 表示只记录
+	WindowSeconds       int `yaml:"window_seconds"`         // e.g., 60 - 非法消息的统计窗口
+	BlockSeconds        int `yaml:"block_seconds"`          // e.g., 300 - 断开后拒绝该对端的时长
+}
+
+// TunnelConfig 反向连接隧道配置
+// provider 无法接受入站连接时主动连接该端口，节点经此连接调用 provider 的 gRPC 服务
+type TunnelConfig struct {
+	Enabled bool `yaml:"enabled"`
+	Port    int  `yaml:"port"` // e.g., 50007
+}
+
+// CompressionConfig 负载压缩配置，算法为 none / gzip / zstd
+// gRPC 压缩作用于 ignis、store、discovery、scheduler 通道，仅在对端声明支持时启用；
+// ZMQ 压缩仅作用于不小于 zmq_min_bytes 且 component 在 READY 中声明支持该算法的消息
+type CompressionConfig struct {
+	GRPC        string `yaml:"grpc"`          // gRPC 通道发送方向使用的算法
+	ZMQ         string `yaml:"zmq"`           // ZMQ 帧使用的算法
+	ZMQMinBytes int    `yaml:"zmq_min_bytes"` // e.g., 65536 - ZMQ 帧压缩阈值（字节）
 }
 
 type HTTPConfig struct {
-	Port int `yaml:"port"` // e.g., 8080 - HTTP server port
+	Port int        `yaml:"port"` // e.g., 8080 - HTTP server port
+	RBAC RBACConfig `yaml:"rbac"` // 敏感接口（如 component exec）的访问控制
+}
+
+// RBACConfig 基于角色的 HTTP 接口访问控制配置
+type RBACConfig struct {
+	Enabled bool                `yaml:"enabled"` // 未启用时受保护接口一律拒绝
+	Roles   map[string][]string `yaml:"roles"`   // 角色 -> 权限列表，e.g., {"operator": ["component:exec"]}，"*" 表示全部权限
+	Tokens  []RBACTokenConfig   `yaml:"tokens"`  // 访问令牌
+}
+
+// RBACTokenConfig 访问令牌与其绑定的角色
+type RBACTokenConfig struct {
+	Token   string   `yaml:"token"`   // Bearer 令牌
+	Subject string   `yaml:"subject"` // 令牌所属主体，用于审计日志
+	Roles   []string `yaml:"roles"`   // 绑定的角色
 }
 
 // RPCConfig RPC 配置
@@ -107,6 +459,19 @@ type RPCSchedulerConfig struct {
 
 // StoreConfig Store 服务配置
 type StoreConfig struct {
+	Access StoreAccessConfig `yaml:"access"` // 通过 store 端口访问对象的访问控制
+	// 同域共用的独立 store 服务（cmd/store）地址（可选），e.g., "10.0.0.5:50020"；
+	// 设置后本节点在该 store 中保存与读取对象，component（包括委托到其他节点的 component）也直接访问该地址
+	SharedAddress string `yaml:"shared_address"`
+}
+
+// StoreAccessConfig store 对象访问控制配置
+// 通过 store 端口保存的对象归属于出示 component 令牌（见 delegation.upstream_tokens）的 component 及其所属应用，
+// 只有同一 component 或同一应用的调用方可以读取或覆盖；未出示令牌的调用方被拒绝
+type StoreAccessConfig struct {
+	Enforce   bool   `yaml:"enforce"`    // 拒绝越权访问；关闭时只记录审计日志
+	AuditLog  bool   `yaml:"audit_log"`  // 是否记录访问审计日志
+	AuditPath string `yaml:"audit_path"` // e.g., "./data/store_audit.jsonl"
 }
 
 // ZMQConfig ZMQ 配置
@@ -116,6 +481,8 @@ type ZMQConfig struct {
 
 // IgnisConfig Ignis 模块配置
 type IgnisConfig struct {
+	MaxControllers        int  `yaml:"max_controllers"`         // 控制器池上限，池满时回收应用已停止或已结束的最久未使用的空闲控制器，0 表示不限制
+	AutoCreateControllers bool `yaml:"auto_create_controllers"` // 客户端连接未创建应用的 app id 时是否自动创建控制器
 }
 
 // DatabaseConfig 数据库配置
@@ -123,6 +490,7 @@ type DatabaseConfig struct {
 	ApplicationDBPath      string `yaml:"application_db_path"`       // Application 数据库路径
 	ResourceProviderDBPath string `yaml:"resource_provider_db_path"` // Resource Provider 数据库路径
 	ResourceLoggerDBPath   string `yaml:"resource_logger_db_path"`   // Resource Logger 数据库路径
+	AccountingDBPath       string `yaml:"accounting_db_path"`        // 资源占用记账数据库路径
 	MaxOpenConns           int    `yaml:"max_open_conns"`            // 最大打开连接数
 	MaxIdleConns           int    `yaml:"max_idle_conns"`            // 最大空闲连接数
 	ConnMaxLifetimeSeconds int    `yaml:"conn_max_lifetime_seconds"` // 连接最大生存时间（秒）
@@ -1,81 +1,201 @@
 package config
 
 import (
+	"fmt"
 	"os"
 
 	"gopkg.in/yaml.v2"
 )
 
-// LoadConfig 从文件加载配置并应用默认值
+// LoadConfig 从文件加载配置
+// 先以 Defaults() 为基础解析 YAML（文件中未出现的字段保留默认值），再执行语义校验，
+// 校验失败时返回 *ValidationError，其中列出所有不合法的字段
 func LoadConfig(file string) (*Config, error) {
 	data, err := os.ReadFile(file)
 	if err != nil {
 		return nil, err
 	}
 
-	cfg := &Config{}
+	cfg := Defaults()
 	if err := yaml.Unmarshal(data, cfg); err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to parse config file %s: %w", file, err)
 	}
 
-	// 应用默认值
-	ApplyDefaults(cfg)
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
 
 	return cfg, nil
 }
 
-// ApplyDefaults 为配置项设置默认值
-func ApplyDefaults(cfg *Config) {
-	// 数据目录默认值
-	if cfg.DataDir == "" {
-		cfg.DataDir = "./data"
-	}
-
-	// 数据库配置默认值
-	if cfg.Database.ApplicationDBPath == "" {
-		cfg.Database.ApplicationDBPath = "./data/applications.db"
-	}
-	if cfg.Database.ResourceProviderDBPath == "" {
-		cfg.Database.ResourceProviderDBPath = "./data/resource_providers.db"
-	}
-	if cfg.Database.MaxOpenConns == 0 {
-		cfg.Database.MaxOpenConns = 10
-	}
-	if cfg.Database.MaxIdleConns == 0 {
-		cfg.Database.MaxIdleConns = 5
-	}
-	if cfg.Database.ConnMaxLifetimeSeconds == 0 {
-		cfg.Database.ConnMaxLifetimeSeconds = 300
-	}
-
-	// RPC 配置默认值
-	if cfg.Transport.RPC.Logger.Port == 0 {
-		cfg.Transport.RPC.Logger.Port = 50003 // 默认日志服务端口
-	}
-	if cfg.Transport.RPC.Discovery.Port == 0 {
-		cfg.Transport.RPC.Discovery.Port = 50005 // 默认节点发现服务端口
-	}
-	if cfg.Transport.RPC.Scheduler.Port == 0 {
-		cfg.Transport.RPC.Scheduler.Port = 50006 // 默认调度服务端口
-	}
-
-	// Discovery 配置默认值
-	if cfg.Resource.Discovery.GossipIntervalSeconds == 0 {
-		cfg.Resource.Discovery.GossipIntervalSeconds = 30 // 默认 30 秒
-	}
-	if cfg.Resource.Discovery.NodeTTLSeconds == 0 {
-		cfg.Resource.Discovery.NodeTTLSeconds = 180 // 默认 180 秒（3 分钟）
-	}
-	if cfg.Resource.Discovery.MaxGossipPeers == 0 {
-		cfg.Resource.Discovery.MaxGossipPeers = 10 // 默认 10 个
-	}
-	if cfg.Resource.Discovery.MaxHops == 0 {
-		cfg.Resource.Discovery.MaxHops = 5 // 默认 5 跳
-	}
-	if cfg.Resource.Discovery.QueryTimeoutSeconds == 0 {
-		cfg.Resource.Discovery.QueryTimeoutSeconds = 5 // 默认 5 秒
-	}
-	if cfg.Resource.Discovery.Fanout == 0 {
-		cfg.Resource.Discovery.Fanout = 3 // 默认 3 个
+// Defaults 返回带有全部文档化默认值的配置
+// 这是默认值的唯一来源，配置文件中显式给出的值会覆盖这里的默认值
+//
+// 默认值:
+//   - data_dir: ./data
+//   - shutdown_timeout_seconds: 30
+//   - logging: format=text, level=info
+//   - handover: timeout_seconds=60, resume_grace_seconds=2
+//   - application.workspace_dir: ./workspaces
+//   - database: application_db_path=./data/applications.db, resource_provider_db_path=./data/resource_providers.db,
+//     resource_logger_db_path=./data/resource_logger.db, accounting_db_path=./data/accounting.db,
+//     max_open_conns=10, max_idle_conns=5, conn_max_lifetime_seconds=300
+//   - transport.http.port: 8083
+//   - transport.zmq.port: 5555
+//   - transport.rpc: resource=50051, ignis=50001, store=50002, logger=50003, resource_logger=50004,
+//     discovery=50005, scheduler=50006
+//   - transport.compression: grpc=none, zmq=none, zmq_min_bytes=65536
+//   - transport.message_limits: max_zmq_frame_bytes=536870912, max_grpc_message_bytes=536870912, max_bad_messages=20,
+//     window_seconds=60, block_seconds=300
+//   - resource.capacity_cache_ttl_seconds: 2
+//   - resource.affinity_ttl_seconds: 1800
+//   - resource.delegation: parallel_probes=3, probe_timeout_seconds=2, reconcile_interval_seconds=60
+//   - resource.decision_log: enabled=false, path=./data/decisions.jsonl, max_size_mb=100, max_backups=5
+//   - resource.rebalance: enabled=false, dry_run=true, interval_seconds=60, high_watermark=0.8, low_watermark=0.6,
+//     min_skew=0.2, max_migrations_per_interval=2, cooldown_seconds=600
+//   - resource.benchmark: on_register=false, timeout_seconds=30
+//   - resource.polling: adaptive=false, usage_min_ms=2000, usage_max_ms=30000, health_check_min_seconds=10,
+//     health_check_max_seconds=120, utilization_delta=0.1
+//   - resource.policy_webhook: timeout_seconds=2, fail_open=false
+//   - resource.accounting: enabled=false
+//   - resource.utilization_log: enabled=false, path=./data/utilization.csv, interval_seconds=10
+//   - resource.deploy_retry: max_attempts=3, initial_backoff_ms=200, max_backoff_ms=2000, jitter=0.5
+//   - resource.placement_history: enabled=true, min_samples=5
+//   - resource.head_failover: standby=false, check_interval_seconds=10, miss_threshold=3
+//   - resource.discovery: gossip_interval_seconds=30, node_ttl_seconds=180, suspect_timeout_seconds=90,
+//     tombstone_ttl_seconds=600, max_gossip_peers=10, max_hops=5, query_timeout_seconds=5, fanout=3,
+//     anti_entropy_interval_seconds=300
+//   - ignis: max_controllers=64, auto_create_controllers=true
+func Defaults() *Config {
+	return &Config{
+		DataDir:                "./data",
+		ShutdownTimeoutSeconds: 30,
+		Logging: LoggingConfig{
+			Format: "text",
+			Level:  "info",
+		},
+		Handover: HandoverConfig{
+			TimeoutSeconds:     60,
+			ResumeGraceSeconds: 2,
+		},
+		Application: ApplicationConfig{
+			WorkspaceDir: "./workspaces",
+		},
+		Resource: ResourceConfig{
+			CapacityCacheTTLSeconds:    2,
+			AffinityTTLSeconds:         1800,
+			StaticProviderRetrySeconds: 10,
+			Delegation: DelegationConfig{
+				ParallelProbes:           3,
+				ProbeTimeoutSeconds:      2,
+				ReconcileIntervalSeconds: 60,
+			},
+			DecisionLog: DecisionLogConfig{
+				Path:       "./data/decisions.jsonl",
+				MaxSizeMB:  100,
+				MaxBackups: 5,
+			},
+			Rebalance: RebalanceConfig{
+				DryRun:                   true,
+				IntervalSeconds:          60,
+				HighWatermark:            0.8,
+				LowWatermark:             0.6,
+				MinSkew:                  0.2,
+				MaxMigrationsPerInterval: 2,
+				CooldownSeconds:          600,
+			},
+			Benchmark: BenchmarkConfig{
+				TimeoutSeconds: 30,
+			},
+			Polling: PollingConfig{
+				UsageMinMs:            2000,
+				UsageMaxMs:            30000,
+				HealthCheckMinSeconds: 10,
+				HealthCheckMaxSeconds: 120,
+				UtilizationDelta:      0.1,
+			},
+			PolicyWebhook: PolicyWebhookConfig{
+				TimeoutSeconds: 2,
+			},
+			Trace: TraceConfig{
+				Path: "./data/deploy_trace.jsonl",
+			},
+			Store: StoreConfig{
+				Access: StoreAccessConfig{
+					AuditPath: "./data/store_audit.jsonl",
+				},
+			},
+			UtilizationLog: UtilizationLogConfig{
+				Path:            "./data/utilization.csv",
+				IntervalSeconds: 10,
+			},
+			DeployRetry: DeployRetryConfig{
+				MaxAttempts:      3,
+				InitialBackoffMs: 200,
+				MaxBackoffMs:     2000,
+				Jitter:           0.5,
+			},
+			PlacementHistory: PlacementHistoryConfig{
+				Enabled:    true,
+				MinSamples: 5,
+			},
+			Identity: IdentityConfig{
+				MaxClockSkewSeconds: 300,
+			},
+			HeadFailover: HeadFailoverConfig{
+				CheckIntervalSeconds: 10,
+				MissThreshold:        3,
+			},
+			Discovery: DiscoveryConfig{
+				GossipIntervalSeconds:      30,
+				NodeTTLSeconds:             180,
+				SuspectTimeoutSeconds:      90,
+				TombstoneTTLSeconds:        600,
+				MaxGossipPeers:             10,
+				MaxHops:                    5,
+				QueryTimeoutSeconds:        5,
+				Fanout:                     3,
+				AntiEntropyIntervalSeconds: 300,
+			},
+		},
+		Ignis: IgnisConfig{
+			MaxControllers:        64,
+			AutoCreateControllers: true,
+		},
+		Transport: TransportConfig{
+			HTTP: HTTPConfig{Port: 8083},
+			ZMQ:  ZMQConfig{Port: 5555},
+			RPC: RPCConfig{
+				Resource:       RPCResourceConfig{Port: 50051},
+				Ignis:          RPCIgnisConfig{Port: 50001},
+				Store:          RPCStoreConfig{Port: 50002},
+				Logger:         RPCLoggerConfig{Port: 50003},
+				ResourceLogger: RPCResourceLoggerConfig{Port: 50004},
+				Discovery:      RPCDiscoveryConfig{Port: 50005},
+				Scheduler:      RPCSchedulerConfig{Port: 50006},
+			},
+			Compression: CompressionConfig{
+				GRPC:        "none",
+				ZMQ:         "none",
+				ZMQMinBytes: 64 * 1024,
+			},
+			Tunnel: TunnelConfig{Port: 50007},
+			MessageLimits: MessageLimitsConfig{
+				MaxZMQFrameBytes:    512 * 1024 * 1024,
+				MaxGRPCMessageBytes: 512 * 1024 * 1024,
+				MaxBadMessages:      20,
+				WindowSeconds:       60,
+				BlockSeconds:        300,
+			},
+		},
+		Database: DatabaseConfig{
+			ApplicationDBPath:      "./data/applications.db",
+			ResourceProviderDBPath: "./data/resource_providers.db",
+			ResourceLoggerDBPath:   "./data/resource_logger.db",
+			AccountingDBPath:       "./data/accounting.db",
+			MaxOpenConns:           10,
+			MaxIdleConns:           5,
+			ConnMaxLifetimeSeconds: 300,
+		},
 	}
 }
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/9triver/iarnet/internal/util"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+// StoreServerConfig 独立 store 服务（cmd/store）的配置
+// 同域的多个节点通过 resource.store.shared_address 共用一个 store，对象持久化在 data_dir 中，重启后仍可读取
+type StoreServerConfig struct {
+	Host                string            `yaml:"host"`                   // 监听地址，e.g., "0.0.0.0"
+	Port                int               `yaml:"port"`                   // gRPC 端口，e.g., 50020
+	DataDir             string            `yaml:"data_dir"`               // 对象持久化目录，e.g., "./data/store"
+	MaxObjectBytes      int64             `yaml:"max_object_bytes"`       // 单个对象的大小上限，0 表示不限制
+	MaxTotalBytes       int64             `yaml:"max_total_bytes"`        // 保存的对象总大小上限，0 表示不限制
+	MaxGRPCMessageBytes int               `yaml:"max_grpc_message_bytes"` // 单条 gRPC 消息的大小上限
+	Compression         string            `yaml:"compression"`            // gRPC 压缩算法，e.g., "none", "gzip" or "zstd"
+	Access              StoreServerAccess `yaml:"access"`                 // component 令牌校验与对象访问控制
+	Logging             LoggingConfig     `yaml:"logging"`
+}
+
+// StoreServerAccess 独立 store 服务的访问控制配置
+// token_key_file 与同域节点的 resource.delegation.upstream_tokens.key_file 为同一密钥，
+// 用于校验各节点为 component 签发的令牌以及节点转发访问时出示的节点令牌
+type StoreServerAccess struct {
+	TokenKeyFile string `yaml:"token_key_file"` // 令牌密钥文件，e.g., "/etc/iarnet/token.key"；为空时不校验令牌
+	RequireToken bool   `yaml:"require_token"`  // 拒绝未出示令牌的连接
+	Enforce      bool   `yaml:"enforce"`        // 只允许对象所属的 component 或同一应用访问对象；关闭时只记录审计日志
+	AuditLog     bool   `yaml:"audit_log"`      // 是否记录对象访问审计日志
+	AuditPath    string `yaml:"audit_path"`     // e.g., "./data/store_audit.jsonl"
+}
+
+// StoreServerDefaults 返回独立 store 服务的默认配置
+//
+// 默认值:
+//   - host: 0.0.0.0, port: 50020
+//   - data_dir: ./data/store
+//   - max_object_bytes: 0, max_total_bytes: 0（不限制）
+//   - max_grpc_message_bytes: 536870912
+//   - compression: none
+//   - logging: format=text, level=info
+func StoreServerDefaults() *StoreServerConfig {
+	return &StoreServerConfig{
+		Host:                "0.0.0.0",
+		Port:                50020,
+		DataDir:             "./data/store",
+		MaxGRPCMessageBytes: 512 << 20,
+		Compression:         "none",
+		Logging: LoggingConfig{
+			Format: "text",
+			Level:  "info",
+		},
+	}
+}
+
+// LoadStoreServerConfig 从文件加载独立 store 服务的配置，未出现的字段保留默认值
+func LoadStoreServerConfig(file string) (*StoreServerConfig, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := StoreServerDefaults()
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", file, err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Validate 校验独立 store 服务的配置，返回 *ValidationError
+func (c *StoreServerConfig) Validate() error {
+	v := &validator{}
+	v.port("port", c.Port)
+	v.required("data_dir", c.DataDir)
+	if c.MaxObjectBytes < 0 {
+		v.add("max_object_bytes", c.MaxObjectBytes, "must not be negative")
+	}
+	if c.MaxTotalBytes < 0 {
+		v.add("max_total_bytes", c.MaxTotalBytes, "must not be negative")
+	}
+	if c.MaxTotalBytes > 0 && c.MaxObjectBytes > c.MaxTotalBytes {
+		v.add("max_object_bytes", c.MaxObjectBytes, "must not exceed max_total_bytes")
+	}
+	v.positive("max_grpc_message_bytes", c.MaxGRPCMessageBytes)
+	if !slices.Contains(compressionAlgorithms, c.Compression) {
+		v.add("compression", fmt.Sprintf("%q", c.Compression), "must be one of %s", strings.Join(compressionAlgorithms, ", "))
+	}
+	if (c.Access.Enforce || c.Access.RequireToken) && c.Access.TokenKeyFile == "" {
+		v.add("access.token_key_file", "", "is required when access.enforce or access.require_token is set")
+	}
+	if c.Access.AuditLog {
+		v.required("access.audit_path", c.Access.AuditPath)
+	}
+	if c.Logging.Format != util.LogFormatText && c.Logging.Format != util.LogFormatJSON {
+		v.add("logging.format", c.Logging.Format, "must be one of text, json")
+	}
+	if _, err := logrus.ParseLevel(c.Logging.Level); err != nil {
+		v.add("logging.level", c.Logging.Level, "must be a valid log level (e.g., debug, info, warn)")
+	}
+
+	if len(v.errors) > 0 {
+		return &ValidationError{Errors: v.errors}
+	}
+	return nil
+}
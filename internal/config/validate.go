@@ -0,0 +1,622 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"net"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/9triver/iarnet/internal/util"
+	"github.com/sirupsen/logrus"
+)
+
+// FieldError 单个配置字段的校验错误
+type FieldError struct {
+	Field   string // YAML 路径，e.g., "transport.http.port"
+	Value   any    // 实际配置的值
+	Message string // 错误原因
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s (got %v)", e.Field, e.Message, e.Value)
+}
+
+// ValidationError 配置校验错误，包含所有不合法的字段
+type ValidationError struct {
+	Errors []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, 0, len(e.Errors)+1)
+	lines = append(lines, fmt.Sprintf("invalid config: %d error(s)", len(e.Errors)))
+	for _, fe := range e.Errors {
+		lines = append(lines, "  - "+fe.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// validator 收集校验过程中的字段错误
+type validator struct {
+	errors []*FieldError
+}
+
+func (v *validator) add(field string, value any, format string, args ...any) {
+	v.errors = append(v.errors, &FieldError{Field: field, Value: value, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) required(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add(field, fmt.Sprintf("%q", value), "must not be empty")
+	}
+}
+
+func (v *validator) port(field string, value int) {
+	if value < 1 || value > 65535 {
+		v.add(field, value, "must be a port in range 1-65535")
+	}
+}
+
+func (v *validator) positive(field string, value int) {
+	if value <= 0 {
+		v.add(field, value, "must be greater than 0")
+	}
+}
+
+// imageMap 校验运行环境到镜像的映射：至少一项且镜像不为空
+func (v *validator) imageMap(field string, images map[string]string) {
+	if len(images) == 0 {
+		v.add(field, "{}", "must map at least one runtime environment to an image")
+		return
+	}
+	envs := make([]string, 0, len(images))
+	for env := range images {
+		envs = append(envs, env)
+	}
+	sort.Strings(envs)
+	for _, env := range envs {
+		image := images[env]
+		if strings.TrimSpace(env) == "" {
+			v.add(field, fmt.Sprintf("%q", env), "runtime environment name must not be empty")
+		}
+		v.required(field+"."+env, image)
+	}
+}
+
+// Validate 校验配置语义，返回 *ValidationError 列出所有不合法的字段
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	v.required("host", c.Host)
+	v.required("data_dir", c.DataDir)
+	v.positive("shutdown_timeout_seconds", c.ShutdownTimeoutSeconds)
+	c.validateLogging(v)
+	v.positive("handover.timeout_seconds", c.Handover.TimeoutSeconds)
+	v.positive("handover.resume_grace_seconds", c.Handover.ResumeGraceSeconds)
+
+	v.required("application.workspace_dir", c.Application.WorkspaceDir)
+	v.imageMap("application.runner_images", c.Application.RunnerImages)
+	if c.Application.Registry.Address == "" && c.Application.Registry.Username != "" {
+		v.add("application.registry.address", `""`, "must be set when registry credentials are configured")
+	}
+
+	v.imageMap("resource.component_images", c.Resource.ComponentImages)
+	c.validateComponentEnv(v)
+	c.validateComponentImageArchitectures(v)
+	c.validateSidecarInjection(v)
+	c.validateDiscovery(v)
+	for i, rule := range c.Resource.Egress.Allow {
+		field := fmt.Sprintf("resource.egress.allow[%d]", i)
+		v.required(field+".destination", rule.Destination)
+		if rule.Port < 0 || rule.Port > 65535 {
+			v.add(field+".port", rule.Port, "must be 0 (any) or a port in range 1-65535")
+		}
+		if p := strings.ToLower(rule.Protocol); p != "" && p != "tcp" && p != "udp" {
+			v.add(field+".protocol", rule.Protocol, "must be tcp, udp or empty")
+		}
+	}
+	if c.Resource.CapacityCacheTTLSeconds < 0 {
+		v.add("resource.capacity_cache_ttl_seconds", c.Resource.CapacityCacheTTLSeconds, "must not be negative")
+	}
+	v.positive("resource.affinity_ttl_seconds", c.Resource.AffinityTTLSeconds)
+	v.positive("resource.delegation.parallel_probes", c.Resource.Delegation.ParallelProbes)
+	v.positive("resource.delegation.probe_timeout_seconds", c.Resource.Delegation.ProbeTimeoutSeconds)
+	if c.Resource.Delegation.ReconcileIntervalSeconds < 0 {
+		v.add("resource.delegation.reconcile_interval_seconds", c.Resource.Delegation.ReconcileIntervalSeconds, "must not be negative")
+	}
+	for _, cidr := range c.Resource.Delegation.UpstreamAllowedCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			v.add("resource.delegation.upstream_allowed_cidrs", cidr, "must be a CIDR, e.g. 10.0.0.0/8")
+		}
+	}
+	if tokens := c.Resource.Delegation.UpstreamTokens; tokens.Require && !tokens.Enabled {
+		v.add("resource.delegation.upstream_tokens.require", tokens.Require, "requires resource.delegation.upstream_tokens.enabled")
+	}
+	if dl := c.Resource.DecisionLog; dl.Enabled {
+		v.required("resource.decision_log.path", dl.Path)
+		v.positive("resource.decision_log.max_size_mb", dl.MaxSizeMB)
+		if dl.MaxBackups < 0 {
+			v.add("resource.decision_log.max_backups", dl.MaxBackups, "must not be negative")
+		}
+	}
+	if tr := c.Resource.Trace; tr.Enabled {
+		v.required("resource.trace.path", tr.Path)
+	}
+	access := c.Resource.Store.Access
+	if access.Enforce && !c.Resource.Delegation.UpstreamTokens.Enabled {
+		v.add("resource.store.access.enforce", access.Enforce, "requires resource.delegation.upstream_tokens.enabled")
+	}
+	if access.AuditLog {
+		v.required("resource.store.access.audit_path", access.AuditPath)
+	}
+	if shared := c.Resource.Store.SharedAddress; shared != "" {
+		if _, _, err := net.SplitHostPort(shared); err != nil {
+			v.add("resource.store.shared_address", shared, "must be a host:port address")
+		}
+		// 共用的 store 按自身配置执行访问控制，需与同域节点使用同一令牌密钥才能校验各节点签发的令牌
+		if access.Enforce && c.Resource.Delegation.UpstreamTokens.KeyFile == "" {
+			v.add("resource.delegation.upstream_tokens.key_file", "", "is required when resource.store.access.enforce is used with resource.store.shared_address")
+		}
+	}
+	if ul := c.Resource.UtilizationLog; ul.Enabled {
+		v.required("resource.utilization_log.path", ul.Path)
+		v.positive("resource.utilization_log.interval_seconds", ul.IntervalSeconds)
+	}
+	c.validateRebalance(v)
+	c.validatePolling(v)
+	c.validateDeployRetry(v)
+	c.validateHeadFailover(v)
+	c.validateStaticProviders(v)
+	if ph := c.Resource.PlacementHistory; ph.Enabled {
+		v.positive("resource.placement_history.min_samples", ph.MinSamples)
+	}
+	if limits := c.Resource.ComponentLimits; limits.MaxPerProvider < 0 {
+		v.add("resource.component_limits.max_per_provider", limits.MaxPerProvider, "must not be negative")
+	}
+	if limits := c.Resource.ComponentLimits; limits.MaxPerNode < 0 {
+		v.add("resource.component_limits.max_per_node", limits.MaxPerNode, "must not be negative")
+	}
+	c.validateNetworkEmulation(v)
+	v.positive("resource.identity.max_clock_skew_seconds", c.Resource.Identity.MaxClockSkewSeconds)
+	v.positive("resource.benchmark.timeout_seconds", c.Resource.Benchmark.TimeoutSeconds)
+	c.validatePolicyWebhook(v)
+	c.validateTimeWindows(v)
+	for key := range c.Resource.Labels {
+		if strings.TrimSpace(key) == "" {
+			v.add("resource.labels", fmt.Sprintf("%q", key), "label key must not be empty")
+		}
+	}
+	if c.Resource.Energy.WattsPerCore < 0 {
+		v.add("resource.energy.watts_per_core", c.Resource.Energy.WattsPerCore, "must not be negative")
+	}
+
+	if c.Ignis.MaxControllers < 0 {
+		v.add("ignis.max_controllers", c.Ignis.MaxControllers, "must not be negative")
+	}
+
+	c.validateTransport(v)
+	c.validateDatabase(v)
+
+	if len(v.errors) > 0 {
+		return &ValidationError{Errors: v.errors}
+	}
+	return nil
+}
+
+// componentEnvReserved provider 部署时自动注入的环境变量，不能通过 component_env 覆盖
+var componentEnvReserved = []string{"COMPONENT_ID", "ZMQ_ADDR", "STORE_ADDR", "LOGGER_ADDR"}
+
+// imageArchitectures component_image_architectures 中允许的架构名称（GOARCH 命名）
+var imageArchitectures = []string{"amd64", "arm64", "arm", "386", "ppc64le", "s390x", "riscv64"}
+
+func (c *Config) validateComponentImageArchitectures(v *validator) {
+	envs := make([]string, 0, len(c.Resource.ComponentImageArchitectures))
+	for env := range c.Resource.ComponentImageArchitectures {
+		envs = append(envs, env)
+	}
+	sort.Strings(envs)
+	for _, env := range envs {
+		field := "resource.component_image_architectures." + env
+		if _, ok := c.Resource.ComponentImages[env]; !ok {
+			v.add(field, env, "has no matching entry in resource.component_images")
+		}
+		archs := c.Resource.ComponentImageArchitectures[env]
+		if len(archs) == 0 {
+			v.add(field, "[]", "must list at least one architecture or be omitted")
+		}
+		for _, arch := range archs {
+			if !slices.Contains(imageArchitectures, arch) {
+				v.add(field, arch, "must be one of %s", strings.Join(imageArchitectures, ", "))
+			}
+		}
+	}
+}
+
+// sidecarNamePattern 与 provider 侧 sidecar 名称规则一致，main 为主容器保留
+var sidecarNamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,30}[a-z0-9])?$`)
+
+func (c *Config) validateSidecarInjection(v *validator) {
+	seen := make(map[string]struct{}, len(c.Resource.SidecarInjection))
+	for i, rule := range c.Resource.SidecarInjection {
+		field := fmt.Sprintf("resource.sidecar_injection[%d]", i)
+		if !sidecarNamePattern.MatchString(rule.Name) || rule.Name == "main" {
+			v.add(field+".name", rule.Name, "must match %s and not be \"main\"", sidecarNamePattern.String())
+		} else if _, dup := seen[rule.Name]; dup {
+			v.add(field+".name", rule.Name, "is used by another rule")
+		}
+		seen[rule.Name] = struct{}{}
+		v.required(field+".image", rule.Image)
+		for _, env := range rule.RuntimeEnvs {
+			if _, ok := c.Resource.ComponentImages[env]; !ok {
+				v.add(field+".runtime_envs", env, "has no matching entry in resource.component_images")
+			}
+		}
+		if rule.CPU < 0 || rule.MemoryMB < 0 || rule.GPU < 0 {
+			v.add(field, fmt.Sprintf("cpu=%d memory_mb=%d gpu=%d", rule.CPU, rule.MemoryMB, rule.GPU), "resources must not be negative")
+		}
+		for _, envs := range []struct {
+			name string
+			env  map[string]string
+		}{{"env", rule.Env}, {"component_env", rule.ComponentEnv}} {
+			for _, key := range slices.Sorted(maps.Keys(envs.env)) {
+				value := envs.env[key]
+				if slices.Contains(componentEnvReserved, key) {
+					v.add(field+"."+envs.name+"."+key, key, "is reserved and set by the provider")
+					continue
+				}
+				if _, err := template.New(key).Parse(value); err != nil {
+					v.add(field+"."+envs.name+"."+key, value, "invalid template: %v", err)
+				}
+			}
+		}
+		for j, vol := range rule.Volumes {
+			volField := fmt.Sprintf("%s.volumes[%d]", field, j)
+			if (vol.Name == "") == (vol.HostPath == "") {
+				v.add(volField, vol.MountPath, "exactly one of name and host_path is required")
+			}
+			if vol.HostPath != "" && !path.IsAbs(vol.HostPath) {
+				v.add(volField+".host_path", vol.HostPath, "must be an absolute path")
+			}
+			if !path.IsAbs(vol.MountPath) || path.Clean(vol.MountPath) == "/" {
+				v.add(volField+".mount_path", vol.MountPath, "must be an absolute path other than /")
+			}
+		}
+	}
+}
+
+func (c *Config) validateComponentEnv(v *validator) {
+	keys := make([]string, 0, len(c.Resource.ComponentEnv))
+	for key := range c.Resource.ComponentEnv {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if strings.TrimSpace(key) == "" {
+			v.add("resource.component_env", fmt.Sprintf("%q", key), "env key must not be empty")
+			continue
+		}
+		field := "resource.component_env." + key
+		if slices.Contains(componentEnvReserved, key) {
+			v.add(field, key, "is reserved and set by the provider")
+			continue
+		}
+		if _, err := template.New(key).Parse(c.Resource.ComponentEnv[key]); err != nil {
+			v.add(field, c.Resource.ComponentEnv[key], "invalid template: %v", err)
+		}
+	}
+}
+
+func (c *Config) validatePolicyWebhook(v *validator) {
+	w := c.Resource.PolicyWebhook
+	if w.URL == "" {
+		return
+	}
+	if u, err := url.Parse(w.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.add("resource.policy_webhook.url", fmt.Sprintf("%q", w.URL), "must be an http or https URL")
+	}
+	v.positive("resource.policy_webhook.timeout_seconds", w.TimeoutSeconds)
+}
+
+// validProviderTags time_windows.provider_tags 中允许的 provider 资源标签
+var validProviderTags = []string{"cpu", "gpu", "memory", "camera"}
+
+func (c *Config) validateTimeWindows(v *validator) {
+	for i, w := range c.Resource.TimeWindows {
+		field := fmt.Sprintf("resource.time_windows[%d]", i)
+		if _, err := util.ParseCron(w.Schedule); err != nil {
+			v.add(field+".schedule", fmt.Sprintf("%q", w.Schedule), "%v", err)
+		}
+		if w.Timezone != "" {
+			if _, err := time.LoadLocation(w.Timezone); err != nil {
+				v.add(field+".timezone", fmt.Sprintf("%q", w.Timezone), "unknown time zone")
+			}
+		}
+		for _, pattern := range w.Providers {
+			if _, err := path.Match(pattern, ""); err != nil {
+				v.add(field+".providers", fmt.Sprintf("%q", pattern), "invalid pattern")
+			}
+		}
+		for _, tag := range w.ProviderTags {
+			if !slices.Contains(validProviderTags, strings.ToLower(tag)) {
+				v.add(field+".provider_tags", fmt.Sprintf("%q", tag), "must be one of %s", strings.Join(validProviderTags, ", "))
+			}
+		}
+		switch w.Tasks {
+		case "", "all", "large", "small":
+		default:
+			v.add(field+".tasks", fmt.Sprintf("%q", w.Tasks), "must be all, large or small")
+		}
+	}
+}
+
+func (c *Config) validateRebalance(v *validator) {
+	r := c.Resource.Rebalance
+	if !r.Enabled {
+		return
+	}
+	v.positive("resource.rebalance.interval_seconds", r.IntervalSeconds)
+	v.positive("resource.rebalance.max_migrations_per_interval", r.MaxMigrationsPerInterval)
+	if r.HighWatermark <= 0 || r.HighWatermark > 1 {
+		v.add("resource.rebalance.high_watermark", r.HighWatermark, "must be in range (0, 1]")
+	}
+	if r.LowWatermark <= 0 || r.LowWatermark >= r.HighWatermark {
+		v.add("resource.rebalance.low_watermark", r.LowWatermark, "must be greater than 0 and less than high_watermark (%v)", r.HighWatermark)
+	}
+	if r.MinSkew < 0 || r.MinSkew >= 1 {
+		v.add("resource.rebalance.min_skew", r.MinSkew, "must be in range [0, 1)")
+	}
+	if r.CooldownSeconds < 0 {
+		v.add("resource.rebalance.cooldown_seconds", r.CooldownSeconds, "must not be negative")
+	}
+}
+
+func (c *Config) validatePolling(v *validator) {
+	p := c.Resource.Polling
+	if !p.Adaptive {
+		return
+	}
+	v.positive("resource.polling.usage_min_ms", p.UsageMinMs)
+	if p.UsageMaxMs < p.UsageMinMs {
+		v.add("resource.polling.usage_max_ms", p.UsageMaxMs, "must not be less than usage_min_ms (%d)", p.UsageMinMs)
+	}
+	v.positive("resource.polling.health_check_min_seconds", p.HealthCheckMinSeconds)
+	if p.HealthCheckMaxSeconds < p.HealthCheckMinSeconds {
+		v.add("resource.polling.health_check_max_seconds", p.HealthCheckMaxSeconds, "must not be less than health_check_min_seconds (%d)", p.HealthCheckMinSeconds)
+	}
+	if p.UtilizationDelta < 0 || p.UtilizationDelta > 1 {
+		v.add("resource.polling.utilization_delta", p.UtilizationDelta, "must be in range [0, 1]")
+	}
+}
+
+func (c *Config) validateDeployRetry(v *validator) {
+	r := c.Resource.DeployRetry
+	v.positive("resource.deploy_retry.max_attempts", r.MaxAttempts)
+	if r.MaxAttempts <= 1 {
+		return
+	}
+	v.positive("resource.deploy_retry.initial_backoff_ms", r.InitialBackoffMs)
+	if r.MaxBackoffMs < r.InitialBackoffMs {
+		v.add("resource.deploy_retry.max_backoff_ms", r.MaxBackoffMs, "must not be less than initial_backoff_ms (%d)", r.InitialBackoffMs)
+	}
+	if r.Jitter < 0 || r.Jitter > 1 {
+		v.add("resource.deploy_retry.jitter", r.Jitter, "must be in range [0, 1]")
+	}
+}
+
+func (c *Config) validateNetworkEmulation(v *validator) {
+	ne := c.Resource.NetworkEmulation
+	if !ne.Enabled {
+		return
+	}
+	validateLink := func(field string, link LinkConfig) {
+		if link.RTTMs < 0 {
+			v.add(field+".rtt_ms", link.RTTMs, "must not be negative")
+		}
+		if link.JitterMs < 0 {
+			v.add(field+".jitter_ms", link.JitterMs, "must not be negative")
+		}
+		if link.BandwidthKbps < 0 {
+			v.add(field+".bandwidth_kbps", link.BandwidthKbps, "must not be negative")
+		}
+	}
+	validateLink("resource.network_emulation.default", ne.Default)
+	for peer, link := range ne.Peers {
+		if strings.TrimSpace(peer) == "" {
+			v.add("resource.network_emulation.peers", fmt.Sprintf("%q", peer), "peer must not be empty")
+			continue
+		}
+		validateLink("resource.network_emulation.peers."+peer, link)
+	}
+}
+
+func (c *Config) validateHeadFailover(v *validator) {
+	h := c.Resource.HeadFailover
+	if !h.Standby {
+		return
+	}
+	if c.Resource.IsHead {
+		v.add("resource.head_failover.standby", h.Standby, "must not be set together with resource.is_head")
+	}
+	if !c.Resource.Discovery.Enabled {
+		v.add("resource.head_failover.standby", h.Standby, "requires resource.discovery.enabled")
+	}
+	v.positive("resource.head_failover.check_interval_seconds", h.CheckIntervalSeconds)
+	v.positive("resource.head_failover.miss_threshold", h.MissThreshold)
+}
+
+func (c *Config) validateStaticProviders(v *validator) {
+	names := make(map[string]int, len(c.Resource.StaticProviders))
+	for i, sp := range c.Resource.StaticProviders {
+		field := fmt.Sprintf("resource.static_providers[%d]", i)
+		v.required(field+".name", sp.Name)
+		if sp.Reverse {
+			if !c.Transport.Tunnel.Enabled {
+				v.add(field+".reverse", sp.Reverse, "requires transport.tunnel.enabled")
+			}
+			v.required(field+".token", sp.Token)
+		} else {
+			v.required(field+".host", sp.Host)
+			v.port(field+".port", sp.Port)
+		}
+		if sp.CapacityClass != "" && sp.CapacityClass != "guaranteed" && sp.CapacityClass != "best-effort" {
+			v.add(field+".capacity_class", sp.CapacityClass, "must be guaranteed, best-effort or empty")
+		}
+		if other, ok := names[sp.Name]; ok && sp.Name != "" {
+			v.add(field+".name", sp.Name, "duplicates resource.static_providers[%d].name", other)
+			continue
+		}
+		names[sp.Name] = i
+	}
+	if len(c.Resource.StaticProviders) > 0 {
+		v.positive("resource.static_provider_retry_seconds", c.Resource.StaticProviderRetrySeconds)
+	}
+}
+
+func (c *Config) validateDiscovery(v *validator) {
+	d := c.Resource.Discovery
+	if !d.Enabled {
+		return
+	}
+	v.positive("resource.discovery.gossip_interval_seconds", d.GossipIntervalSeconds)
+	v.positive("resource.discovery.node_ttl_seconds", d.NodeTTLSeconds)
+	v.positive("resource.discovery.suspect_timeout_seconds", d.SuspectTimeoutSeconds)
+	v.positive("resource.discovery.tombstone_ttl_seconds", d.TombstoneTTLSeconds)
+	v.positive("resource.discovery.max_gossip_peers", d.MaxGossipPeers)
+	v.positive("resource.discovery.max_hops", d.MaxHops)
+	v.positive("resource.discovery.query_timeout_seconds", d.QueryTimeoutSeconds)
+	v.positive("resource.discovery.fanout", d.Fanout)
+	if d.UseAntiEntropy {
+		v.positive("resource.discovery.anti_entropy_interval_seconds", d.AntiEntropyIntervalSeconds)
+	}
+	if d.GossipIntervalSeconds > 0 && d.NodeTTLSeconds > 0 && d.NodeTTLSeconds <= d.GossipIntervalSeconds {
+		v.add("resource.discovery.node_ttl_seconds", d.NodeTTLSeconds, "must be greater than gossip_interval_seconds (%d)", d.GossipIntervalSeconds)
+	}
+	if d.GossipIntervalSeconds > 0 && d.SuspectTimeoutSeconds > 0 && d.SuspectTimeoutSeconds <= d.GossipIntervalSeconds {
+		v.add("resource.discovery.suspect_timeout_seconds", d.SuspectTimeoutSeconds, "must be greater than gossip_interval_seconds (%d)", d.GossipIntervalSeconds)
+	}
+	if d.NodeTTLSeconds > 0 && d.SuspectTimeoutSeconds > d.NodeTTLSeconds {
+		v.add("resource.discovery.suspect_timeout_seconds", d.SuspectTimeoutSeconds, "must not exceed node_ttl_seconds (%d)", d.NodeTTLSeconds)
+	}
+}
+
+// compressionAlgorithms 支持的负载压缩算法
+var compressionAlgorithms = []string{"none", "gzip", "zstd"}
+
+func (c *Config) validateTransport(v *validator) {
+	t := c.Transport
+	// 节点实际监听的端口，同时检查端口冲突
+	type portField struct {
+		field string
+		port  int
+	}
+	ports := []portField{
+		{"transport.http.port", t.HTTP.Port},
+		{"transport.zmq.port", t.ZMQ.Port},
+		{"transport.rpc.ignis.port", t.RPC.Ignis.Port},
+		{"transport.rpc.store.port", t.RPC.Store.Port},
+		{"transport.rpc.logger.port", t.RPC.Logger.Port},
+		{"transport.rpc.resource_logger.port", t.RPC.ResourceLogger.Port},
+		{"transport.rpc.discovery.port", t.RPC.Discovery.Port},
+		{"transport.rpc.scheduler.port", t.RPC.Scheduler.Port},
+	}
+	if t.Tunnel.Enabled {
+		ports = append(ports, portField{"transport.tunnel.port", t.Tunnel.Port})
+	}
+	used := make(map[int]string, len(ports))
+	for _, p := range ports {
+		v.port(p.field, p.port)
+		if other, ok := used[p.port]; ok && p.port != 0 {
+			v.add(p.field, p.port, "conflicts with %s", other)
+			continue
+		}
+		used[p.port] = p.field
+	}
+
+	compression := t.Compression
+	for _, alg := range []struct {
+		field string
+		value string
+	}{
+		{"transport.compression.grpc", compression.GRPC},
+		{"transport.compression.zmq", compression.ZMQ},
+	} {
+		if !slices.Contains(compressionAlgorithms, alg.value) {
+			v.add(alg.field, fmt.Sprintf("%q", alg.value), "must be one of %s", strings.Join(compressionAlgorithms, ", "))
+		}
+	}
+	if compression.ZMQMinBytes < 0 {
+		v.add("transport.compression.zmq_min_bytes", compression.ZMQMinBytes, "must not be negative")
+	}
+
+	limits := t.MessageLimits
+	v.positive("transport.message_limits.max_zmq_frame_bytes", limits.MaxZMQFrameBytes)
+	v.positive("transport.message_limits.max_grpc_message_bytes", limits.MaxGRPCMessageBytes)
+	if limits.MaxBadMessages < 0 {
+		v.add("transport.message_limits.max_bad_messages", limits.MaxBadMessages, "must not be negative")
+	}
+	if limits.MaxBadMessages > 0 {
+		v.positive("transport.message_limits.window_seconds", limits.WindowSeconds)
+		v.positive("transport.message_limits.block_seconds", limits.BlockSeconds)
+	}
+
+	rbac := t.HTTP.RBAC
+	if !rbac.Enabled {
+		return
+	}
+	if len(rbac.Tokens) == 0 {
+		v.add("transport.http.rbac.tokens", "[]", "must contain at least one token when rbac is enabled")
+	}
+	for i, token := range rbac.Tokens {
+		field := fmt.Sprintf("transport.http.rbac.tokens[%d]", i)
+		v.required(field+".token", token.Token)
+		for _, role := range token.Roles {
+			if _, ok := rbac.Roles[role]; !ok {
+				v.add(field+".roles", role, "references undefined role")
+			}
+		}
+	}
+}
+
+func (c *Config) validateDatabase(v *validator) {
+	db := c.Database
+	v.required("database.application_db_path", db.ApplicationDBPath)
+	v.required("database.resource_provider_db_path", db.ResourceProviderDBPath)
+	v.required("database.resource_logger_db_path", db.ResourceLoggerDBPath)
+	if c.Resource.Accounting.Enabled {
+		v.required("database.accounting_db_path", db.AccountingDBPath)
+	}
+	v.positive("database.max_open_conns", db.MaxOpenConns)
+	if db.MaxIdleConns < 0 || db.MaxIdleConns > db.MaxOpenConns {
+		v.add("database.max_idle_conns", db.MaxIdleConns, "must be between 0 and max_open_conns (%d)", db.MaxOpenConns)
+	}
+	if db.ConnMaxLifetimeSeconds < 0 {
+		v.add("database.conn_max_lifetime_seconds", db.ConnMaxLifetimeSeconds, "must not be negative")
+	}
+}
+
+func (c *Config) validateLogging(v *validator) {
+	logging := c.Logging
+	if logging.Format != util.LogFormatText && logging.Format != util.LogFormatJSON {
+		v.add("logging.format", logging.Format, "must be one of text, json")
+	}
+	if _, err := logrus.ParseLevel(logging.Level); err != nil {
+		v.add("logging.level", logging.Level, "must be a valid log level (e.g., debug, info, warn)")
+	}
+	modules := util.LogModules()
+	for module, level := range logging.Modules {
+		field := "logging.modules." + module
+		if !slices.Contains(modules, module) {
+			v.add(field, level, "unknown module, must be one of %s", strings.Join(modules, ", "))
+			continue
+		}
+		if _, err := logrus.ParseLevel(level); err != nil {
+			v.add(field, level, "must be a valid log level (e.g., debug, info, warn)")
+		}
+	}
+}
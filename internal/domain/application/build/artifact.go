@@ -0,0 +1,74 @@
+package build
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// markerFile 构建成功后写入产物目录的标记文件，存在即表示缓存可用
+const markerFile = ".iarnet-build"
+
+// Artifact 构建产物
+// 产物目录会被挂载到 runner 容器的 /iarnet/app
+type Artifact struct {
+	CommitSHA string // 源码提交 SHA
+	Dir       string // 产物目录
+	Cached    bool   // 是否命中缓存
+}
+
+// cacheKey 生成缓存键：以提交 SHA 为主键，并区分运行环境与构建命令
+// 同一提交使用不同构建命令时产物不同，不能共用缓存
+func cacheKey(commitSHA, env, buildCmd string) string {
+	sum := sha256.Sum256([]byte(env + "\x00" + buildCmd))
+	return commitSHA + "-" + hex.EncodeToString(sum[:])[:12]
+}
+
+// copySource 复制源码到产物目录，跳过 .git
+func copySource(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if relPath == "." {
+			return nil
+		}
+		if info.IsDir() && info.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		targetPath := filepath.Join(dst, relPath)
+		if info.IsDir() {
+			return os.MkdirAll(targetPath, info.Mode())
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, targetPath)
+		}
+
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+
+		out, err := os.OpenFile(targetPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode())
+		if err != nil {
+			return err
+		}
+		defer out.Close()
+
+		_, err = io.Copy(out, in)
+		return err
+	})
+}
@@ -0,0 +1,284 @@
+package build
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/9triver/iarnet/internal/domain/application/types"
+	buildtypes "github.com/moby/moby/api/types/build"
+	"github.com/moby/moby/api/types/image"
+	"github.com/moby/moby/api/types/registry"
+	"github.com/sirupsen/logrus"
+)
+
+// generatedDockerfile 源码中没有 Dockerfile 时生成的 Dockerfile 名称
+const generatedDockerfile = "Dockerfile.iarnet"
+
+// Image 构建出的 OCI 镜像
+type Image struct {
+	Ref       string // 完整镜像引用（含 registry 与 tag）
+	CommitSHA string // 源码提交 SHA
+	Cached    bool   // 镜像是否已存在而未重新构建
+}
+
+// languageMarkers 用于探测源码语言的标记文件（类似 buildpack 的 detect 阶段）
+var languageMarkers = []struct {
+	file string
+	lang string
+}{
+	{"requirements.txt", "python"},
+	{"pyproject.toml", "python"},
+	{"setup.py", "python"},
+	{"go.mod", "go"},
+	{"pom.xml", "java"},
+	{"build.gradle", "java"},
+}
+
+// detectLanguage 根据标记文件探测源码语言
+func detectLanguage(sourceDir string) string {
+	for _, marker := range languageMarkers {
+		if _, err := os.Stat(filepath.Join(sourceDir, marker.file)); err == nil {
+			return marker.lang
+		}
+	}
+	return ""
+}
+
+// baseImageFor 选择生成 Dockerfile 时使用的基础镜像
+// 优先使用应用指定的运行环境，否则按探测到的语言匹配运行环境
+func (s *service) baseImageFor(sourceDir string, env types.RunnerEnv) (string, error) {
+	if image, ok := s.images[env]; ok {
+		return image, nil
+	}
+	lang := detectLanguage(sourceDir)
+	if lang == "" {
+		return "", fmt.Errorf("no Dockerfile found and failed to detect source language")
+	}
+	for runnerEnv, image := range s.images {
+		if strings.HasPrefix(string(runnerEnv), lang) {
+			return image, nil
+		}
+	}
+	return "", fmt.Errorf("no runner image available for detected language %s", lang)
+}
+
+// BuildImage 将应用源码构建为 OCI 镜像并推送到配置的 registry
+// 源码根目录存在 Dockerfile 时直接使用；否则基于 runner 镜像生成 Dockerfile，将源码放入 /iarnet/app
+func (s *service) BuildImage(ctx context.Context, appID, sourceDir, commitSHA string, env types.RunnerEnv) (*Image, error) {
+	if s.dockerClient == nil {
+		return nil, fmt.Errorf("docker client not available")
+	}
+	if commitSHA == "" {
+		return nil, fmt.Errorf("commit SHA is required to tag image")
+	}
+
+	ref := s.imageRef(appID, commitSHA)
+
+	lock := s.lockFor(ref)
+	lock.Lock()
+	defer lock.Unlock()
+
+	// 本地已有镜像时，只有 registry 中也存在才视为命中缓存；上次推送失败时补推本地镜像
+	if _, err := s.dockerClient.ImageInspect(ctx, ref); err == nil {
+		if s.registry != "" && !s.pushed(ctx, ref) {
+			logrus.Infof("Image %s built for application %s but missing from registry, pushing", ref, appID)
+			if err := s.pushImage(ctx, ref); err != nil {
+				return nil, err
+			}
+		}
+		logrus.Infof("Image %s already built for application %s", ref, appID)
+		return &Image{Ref: ref, CommitSHA: commitSHA, Cached: true}, nil
+	}
+
+	dockerfile := "Dockerfile"
+	var extra map[string][]byte
+	if _, err := os.Stat(filepath.Join(sourceDir, dockerfile)); err != nil {
+		baseImage, err := s.baseImageFor(sourceDir, env)
+		if err != nil {
+			return nil, err
+		}
+		dockerfile = generatedDockerfile
+		extra = map[string][]byte{
+			generatedDockerfile: []byte(fmt.Sprintf("FROM %s\nCOPY . /iarnet/app\n", baseImage)),
+		}
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeBuildContext(pw, sourceDir, extra))
+	}()
+	defer pr.Close()
+
+	logrus.Infof("Building image %s for application %s", ref, appID)
+	resp, err := s.dockerClient.ImageBuild(ctx, pr, buildtypes.ImageBuildOptions{
+		Tags:        []string{ref},
+		Dockerfile:  dockerfile,
+		Remove:      true,
+		ForceRemove: true,
+		Labels: map[string]string{
+			"iarnet.app_id":     appID,
+			"iarnet.commit_sha": commitSHA,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build image: %w", err)
+	}
+	err = drainJSONStream(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build image: %w", err)
+	}
+
+	if s.registry != "" {
+		if err := s.pushImage(ctx, ref); err != nil {
+			return nil, err
+		}
+	}
+
+	logrus.Infof("Built image %s for application %s", ref, appID)
+	return &Image{Ref: ref, CommitSHA: commitSHA}, nil
+}
+
+// imageRef 生成镜像引用：<registry>/iarnet-app-<appID>:<commit>
+func (s *service) imageRef(appID, commitSHA string) string {
+	name := "iarnet-app-" + strings.ToLower(strings.ReplaceAll(appID, ".", "-"))
+	if s.registry != "" {
+		name = strings.TrimSuffix(s.registry, "/") + "/" + name
+	}
+	tag := commitSHA
+	if len(tag) > 12 {
+		tag = tag[:12]
+	}
+	return name + ":" + tag
+}
+
+// registryAuth 编码访问 registry 的认证信息
+func (s *service) registryAuth() (string, error) {
+	return registry.EncodeAuthConfig(registry.AuthConfig{
+		Username:      s.registryUsername,
+		Password:      s.registryPassword,
+		ServerAddress: s.registry,
+	})
+}
+
+// pushed 查询 registry 中是否已存在该镜像
+func (s *service) pushed(ctx context.Context, ref string) bool {
+	auth, err := s.registryAuth()
+	if err != nil {
+		return false
+	}
+	if _, err := s.dockerClient.DistributionInspect(ctx, ref, auth); err != nil {
+		logrus.Debugf("Image %s not found in registry: %v", ref, err)
+		return false
+	}
+	return true
+}
+
+func (s *service) pushImage(ctx context.Context, ref string) error {
+	auth, err := s.registryAuth()
+	if err != nil {
+		return fmt.Errorf("failed to encode registry auth: %w", err)
+	}
+
+	reader, err := s.dockerClient.ImagePush(ctx, ref, image.PushOptions{RegistryAuth: auth})
+	if err != nil {
+		return fmt.Errorf("failed to push image %s: %w", ref, err)
+	}
+	defer reader.Close()
+
+	if err := drainJSONStream(reader); err != nil {
+		return fmt.Errorf("failed to push image %s: %w", ref, err)
+	}
+	logrus.Infof("Pushed image %s", ref)
+	return nil
+}
+
+// drainJSONStream 读取 Docker 构建/推送返回的 JSON 消息流，遇到错误消息时返回
+func drainJSONStream(r io.Reader) error {
+	decoder := json.NewDecoder(r)
+	for {
+		var msg struct {
+			Stream string `json:"stream"`
+			Error  string `json:"error"`
+		}
+		if err := decoder.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if msg.Error != "" {
+			return errors.New(msg.Error)
+		}
+		if line := strings.TrimSpace(msg.Stream); line != "" {
+			logrus.Debug(line)
+		}
+	}
+}
+
+// writeBuildContext 将源码目录（跳过 .git）与额外文件打包为 tar 构建上下文
+func writeBuildContext(w io.Writer, sourceDir string, extra map[string][]byte) error {
+	tw := tar.NewWriter(w)
+
+	err := filepath.Walk(sourceDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(sourceDir, path)
+		if err != nil {
+			return err
+		}
+		if relPath == "." {
+			return nil
+		}
+		if info.IsDir() && info.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		var link string
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		}
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(relPath)
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	for name, content := range extra {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}); err != nil {
+			return err
+		}
+		if _, err := tw.Write(content); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
@@ -0,0 +1,202 @@
+package build
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/application/types"
+	"github.com/moby/moby/api/pkg/stdcopy"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+	"github.com/sirupsen/logrus"
+)
+
+// buildTimeout 单次构建的最长时间
+const buildTimeout = 30 * time.Minute
+
+// Service 构建服务接口
+// 将工作空间中的源码构建为 runner 可直接使用的产物或 OCI 镜像，均按提交 SHA 缓存
+type Service interface {
+	BuildArtifact(ctx context.Context, appID, sourceDir, commitSHA string, env types.RunnerEnv, buildCmd string) (*Artifact, error)
+	BuildImage(ctx context.Context, appID, sourceDir, commitSHA string, env types.RunnerEnv) (*Image, error)
+}
+
+// RegistryConfig 镜像推送的目标 registry
+type RegistryConfig struct {
+	Address  string // 为空时镜像只保存在本地 Docker daemon
+	Username string
+	Password string
+}
+
+type service struct {
+	dockerClient     *client.Client
+	images           map[types.RunnerEnv]string
+	cacheDir         string
+	registry         string
+	registryUsername string
+	registryPassword string
+
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex // 按缓存键加锁，避免同一产物被并发构建
+}
+
+// NewService 创建构建服务
+// 构建步骤在对应运行环境的 runner 镜像中执行，保证与运行时工具链一致
+func NewService(dockerClient *client.Client, images map[types.RunnerEnv]string, cacheDir string, registry RegistryConfig) Service {
+	if cacheDir == "" {
+		cacheDir = "./build-cache"
+	}
+
+	// 确保缓存目录存在
+	os.MkdirAll(cacheDir, 0755)
+
+	return &service{
+		dockerClient:     dockerClient,
+		images:           images,
+		cacheDir:         cacheDir,
+		registry:         registry.Address,
+		registryUsername: registry.Username,
+		registryPassword: registry.Password,
+		locks:            make(map[string]*sync.Mutex),
+	}
+}
+
+// BuildArtifact 构建应用产物
+// commitSHA 为空时无法判断源码版本，不使用缓存：每次都重新构建到该应用固定的目录中，替换上次的产物
+func (s *service) BuildArtifact(ctx context.Context, appID, sourceDir, commitSHA string, env types.RunnerEnv, buildCmd string) (*Artifact, error) {
+	image, ok := s.images[env]
+	if !ok {
+		return nil, fmt.Errorf("image not found for environment %s", env)
+	}
+
+	key := cacheKey(commitSHA, env, buildCmd)
+	if commitSHA == "" {
+		key = appID + "-unversioned"
+	}
+	artifactDir, err := filepath.Abs(filepath.Join(s.cacheDir, key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve artifact dir: %w", err)
+	}
+
+	lock := s.lockFor(key)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if _, err := os.Stat(filepath.Join(artifactDir, markerFile)); err == nil && commitSHA != "" {
+		logrus.Infof("Build cache hit for application %s at commit %s", appID, commitSHA)
+		return &Artifact{CommitSHA: commitSHA, Dir: artifactDir, Cached: true}, nil
+	}
+
+	// 在临时目录中构建，成功后再原子地重命名，避免留下不完整的产物
+	workDir := artifactDir + ".tmp"
+	if err := os.RemoveAll(workDir); err != nil {
+		return nil, fmt.Errorf("failed to clean build dir: %w", err)
+	}
+	if err := os.MkdirAll(workDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create build dir: %w", err)
+	}
+	if err := copySource(sourceDir, workDir); err != nil {
+		os.RemoveAll(workDir)
+		return nil, fmt.Errorf("failed to copy source: %w", err)
+	}
+
+	logrus.Infof("Building application %s at commit %s: %s", appID, commitSHA, buildCmd)
+	if err := s.runBuild(ctx, image, workDir, buildCmd); err != nil {
+		os.RemoveAll(workDir)
+		return nil, err
+	}
+
+	if err := os.WriteFile(filepath.Join(workDir, markerFile), []byte(commitSHA+"\n"), 0644); err != nil {
+		os.RemoveAll(workDir)
+		return nil, fmt.Errorf("failed to write build marker: %w", err)
+	}
+	os.RemoveAll(artifactDir)
+	if err := os.Rename(workDir, artifactDir); err != nil {
+		os.RemoveAll(workDir)
+		return nil, fmt.Errorf("failed to finalize artifact: %w", err)
+	}
+
+	logrus.Infof("Built artifact for application %s at %s", appID, artifactDir)
+	return &Artifact{CommitSHA: commitSHA, Dir: artifactDir}, nil
+}
+
+// runBuild 在 runner 镜像中执行构建命令
+func (s *service) runBuild(ctx context.Context, image, workDir, buildCmd string) error {
+	if s.dockerClient == nil {
+		return fmt.Errorf("docker client not available")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, buildTimeout)
+	defer cancel()
+
+	containerConfig := &container.Config{
+		Image:      image,
+		Entrypoint: []string{"sh", "-c"},
+		Cmd:        []string{buildCmd},
+		WorkingDir: "/iarnet/app",
+	}
+	hostConfig := &container.HostConfig{
+		Binds: []string{workDir + ":/iarnet/app"},
+	}
+
+	resp, err := s.dockerClient.ContainerCreate(ctx, containerConfig, hostConfig, nil, nil, "")
+	if err != nil {
+		return fmt.Errorf("failed to create build container: %w", err)
+	}
+	defer func() {
+		if err := s.dockerClient.ContainerRemove(context.Background(), resp.ID, container.RemoveOptions{Force: true}); err != nil {
+			logrus.Warnf("Failed to remove build container %s: %v", resp.ID, err)
+		}
+	}()
+
+	if err := s.dockerClient.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
+		return fmt.Errorf("failed to start build container: %w", err)
+	}
+
+	resultC, errC := s.dockerClient.ContainerWait(ctx, resp.ID, container.WaitConditionNotRunning)
+	select {
+	case err := <-errC:
+		return fmt.Errorf("failed to wait for build container: %w", err)
+	case result := <-resultC:
+		if result.StatusCode != 0 {
+			return fmt.Errorf("build command exited with code %d: %s", result.StatusCode, s.tailLogs(resp.ID))
+		}
+	}
+	return nil
+}
+
+// tailLogs 获取构建容器的最后若干行输出，用于错误信息
+func (s *service) tailLogs(containerID string) string {
+	reader, err := s.dockerClient.ContainerLogs(context.Background(), containerID, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Tail:       "20",
+	})
+	if err != nil {
+		return ""
+	}
+	defer reader.Close()
+
+	var out bytes.Buffer
+	if _, err := stdcopy.StdCopy(&out, &out, reader); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out.String())
+}
+
+func (s *service) lockFor(key string) *sync.Mutex {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lock, ok := s.locks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		s.locks[key] = lock
+	}
+	return lock
+}
@@ -0,0 +1,447 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/application/types"
+	apprepo "github.com/9triver/iarnet/internal/infra/repository/application"
+	"github.com/9triver/iarnet/internal/util"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// defaultSuccessfulJobsHistoryLimit/defaultFailedJobsHistoryLimit 未指定时保留的已结束 Job 数
+	defaultSuccessfulJobsHistoryLimit = 3
+	defaultFailedJobsHistoryLimit     = 1
+	// cronRepoTimeout 持久化 CronJob 的超时
+	cronRepoTimeout = 5 * time.Second
+)
+
+// cronJob 已登记的 CronJob 及解析后的触发规则
+type cronJob struct {
+	status   types.CronJobStatus
+	schedule *util.CronSchedule
+	location *time.Location
+}
+
+// due 判断 CronJob 在 t 所在的分钟是否应当触发，同一分钟只触发一次
+func (c *cronJob) due(t time.Time) bool {
+	if c.status.Spec.Suspend {
+		return false
+	}
+	minute := t.Truncate(time.Minute)
+	if !c.status.LastScheduleTime.Before(minute) {
+		return false
+	}
+	return c.schedule.Matches(t.In(c.location))
+}
+
+// next 下一次触发时间，暂停时为零值
+func (c *cronJob) next(after time.Time) time.Time {
+	if c.status.Spec.Suspend {
+		return time.Time{}
+	}
+	return c.schedule.Next(after.In(c.location))
+}
+
+// cronJobs 记录本节点登记的 CronJob，定义持久化在 repo 中
+type cronJobs struct {
+	mu      sync.Mutex
+	entries map[types.CronJobID]*cronJob
+	repo    apprepo.CronJobRepo
+}
+
+func newCronJobs() *cronJobs {
+	return &cronJobs{entries: make(map[types.CronJobID]*cronJob)}
+}
+
+// ValidateCronJobSpec 校验 CronJob 定义并补全缺省值，返回解析后的表达式与时区
+// 并发策略缺省为 allow，历史保留数为负数时使用缺省值
+func ValidateCronJobSpec(spec *types.CronJobSpec) (*util.CronSchedule, *time.Location, error) {
+	schedule, err := util.ParseCron(spec.Schedule)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid schedule: %w", err)
+	}
+	location := time.Local
+	if spec.Timezone != "" {
+		if location, err = time.LoadLocation(spec.Timezone); err != nil {
+			return nil, nil, fmt.Errorf("invalid timezone %q: %w", spec.Timezone, err)
+		}
+	}
+	switch spec.ConcurrencyPolicy {
+	case "":
+		spec.ConcurrencyPolicy = types.ConcurrencyAllow
+	case types.ConcurrencyAllow, types.ConcurrencyForbid, types.ConcurrencyReplace:
+	default:
+		return nil, nil, fmt.Errorf("invalid concurrency policy %q, expected allow, forbid or replace", spec.ConcurrencyPolicy)
+	}
+	if spec.SuccessfulJobsHistoryLimit < 0 {
+		spec.SuccessfulJobsHistoryLimit = defaultSuccessfulJobsHistoryLimit
+	}
+	if spec.FailedJobsHistoryLimit < 0 {
+		spec.FailedJobsHistoryLimit = defaultFailedJobsHistoryLimit
+	}
+	if err := ValidateJobSpec(&spec.JobTemplate); err != nil {
+		return nil, nil, fmt.Errorf("invalid job template: %w", err)
+	}
+	return schedule, location, nil
+}
+
+// SetCronJobRepo 设置 CronJob 的持久化仓库，未设置时 CronJob 只保存在内存中
+func (m *Manager) SetCronJobRepo(repo apprepo.CronJobRepo) *Manager {
+	m.cronJobs.repo = repo
+	return m
+}
+
+// CreateCronJob 为应用登记按 cron 表达式周期触发的 Job
+func (m *Manager) CreateCronJob(ctx context.Context, appID string, spec types.CronJobSpec) (*types.CronJobStatus, error) {
+	if m.jobRunner == nil {
+		return nil, fmt.Errorf("job runner not configured")
+	}
+	metadata, err := m.metadataSvc.GetAppMetadata(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	if metadata.ID == "" {
+		return nil, fmt.Errorf("application not found: %s", appID)
+	}
+	schedule, location, err := ValidateCronJobSpec(&spec)
+	if err != nil {
+		return nil, err
+	}
+
+	cj := &cronJob{
+		status: types.CronJobStatus{
+			ID:        util.GenIDWith("cronjob."),
+			AppID:     appID,
+			Spec:      spec,
+			CreatedAt: time.Now(),
+		},
+		schedule: schedule,
+		location: location,
+	}
+	if err := m.saveCronJob(ctx, cj); err != nil {
+		return nil, err
+	}
+
+	m.cronJobs.mu.Lock()
+	m.cronJobs.entries[cj.status.ID] = cj
+	m.cronJobs.mu.Unlock()
+
+	logrus.Infof("CronJob %s registered for application %s (schedule %q, concurrency %s)", cj.status.ID, appID, spec.Schedule, spec.ConcurrencyPolicy)
+	return m.cronJobStatus(cj), nil
+}
+
+// GetCronJob 获取 CronJob 定义与触发记录
+func (m *Manager) GetCronJob(cronJobID string) (*types.CronJobStatus, error) {
+	m.cronJobs.mu.Lock()
+	cj := m.cronJobs.entries[cronJobID]
+	m.cronJobs.mu.Unlock()
+	if cj == nil {
+		return nil, fmt.Errorf("cron job not found: %s", cronJobID)
+	}
+	return m.cronJobStatus(cj), nil
+}
+
+// ListCronJobs 列出应用的 CronJob，按创建时间排序
+func (m *Manager) ListCronJobs(appID string) []*types.CronJobStatus {
+	m.cronJobs.mu.Lock()
+	var matched []*cronJob
+	for _, cj := range m.cronJobs.entries {
+		if cj.status.AppID == appID {
+			matched = append(matched, cj)
+		}
+	}
+	m.cronJobs.mu.Unlock()
+
+	result := make([]*types.CronJobStatus, 0, len(matched))
+	for _, cj := range matched {
+		result = append(result, m.cronJobStatus(cj))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result
+}
+
+// SetCronJobSuspend 暂停或恢复 CronJob，已触发的 Job 不受影响
+func (m *Manager) SetCronJobSuspend(ctx context.Context, cronJobID string, suspend bool) (*types.CronJobStatus, error) {
+	m.cronJobs.mu.Lock()
+	cj := m.cronJobs.entries[cronJobID]
+	if cj == nil {
+		m.cronJobs.mu.Unlock()
+		return nil, fmt.Errorf("cron job not found: %s", cronJobID)
+	}
+	previous := cj.status.Spec.Suspend
+	cj.status.Spec.Suspend = suspend
+	m.cronJobs.mu.Unlock()
+
+	if err := m.saveCronJob(ctx, cj); err != nil {
+		m.cronJobs.mu.Lock()
+		cj.status.Spec.Suspend = previous
+		m.cronJobs.mu.Unlock()
+		return nil, err
+	}
+	return m.cronJobStatus(cj), nil
+}
+
+// DeleteCronJob 删除 CronJob，不再触发新的 Job；已触发的 Job 继续运行
+func (m *Manager) DeleteCronJob(ctx context.Context, cronJobID string) error {
+	m.cronJobs.mu.Lock()
+	_, ok := m.cronJobs.entries[cronJobID]
+	m.cronJobs.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("cron job not found: %s", cronJobID)
+	}
+	if m.cronJobs.repo != nil {
+		if err := m.cronJobs.repo.Delete(ctx, cronJobID); err != nil {
+			return err
+		}
+	}
+
+	m.cronJobs.mu.Lock()
+	delete(m.cronJobs.entries, cronJobID)
+	m.cronJobs.mu.Unlock()
+	logrus.Infof("CronJob %s deleted", cronJobID)
+	return nil
+}
+
+// forgetCronJobs 删除应用时移除其所有 CronJob
+func (m *Manager) forgetCronJobs(ctx context.Context, appID string) {
+	m.cronJobs.mu.Lock()
+	var ids []string
+	for id, cj := range m.cronJobs.entries {
+		if cj.status.AppID == appID {
+			ids = append(ids, id)
+		}
+	}
+	m.cronJobs.mu.Unlock()
+
+	for _, id := range ids {
+		if err := m.DeleteCronJob(ctx, id); err != nil {
+			logrus.Warnf("Failed to delete cron job %s of application %s: %v", id, appID, err)
+		}
+	}
+}
+
+// cronJobStatus 复制 CronJob 当前状态，并补充下一次触发时间与运行中的 Job
+func (m *Manager) cronJobStatus(cj *cronJob) *types.CronJobStatus {
+	m.cronJobs.mu.Lock()
+	s := cj.status
+	s.NextScheduleTime = cj.next(time.Now())
+	m.cronJobs.mu.Unlock()
+
+	for _, job := range m.activeCronJobRuns(s.ID) {
+		s.ActiveJobs = append(s.ActiveJobs, job.ID)
+	}
+	return &s
+}
+
+// ListCronJobJobs 列出 CronJob 触发且仍保留的 Job，按提交时间排序
+func (m *Manager) ListCronJobJobs(cronJobID string) []*types.JobStatus {
+	return m.jobsWhere(func(s *types.JobStatus) bool { return s.CronJobID == cronJobID })
+}
+
+func (m *Manager) activeCronJobRuns(cronJobID string) []*types.JobStatus {
+	return m.jobsWhere(func(s *types.JobStatus) bool {
+		return s.CronJobID == cronJobID && !s.IsFinished()
+	})
+}
+
+// saveCronJob 持久化 CronJob 定义
+func (m *Manager) saveCronJob(ctx context.Context, cj *cronJob) error {
+	if m.cronJobs.repo == nil {
+		return nil
+	}
+	m.cronJobs.mu.Lock()
+	status := cj.status
+	m.cronJobs.mu.Unlock()
+
+	template, err := json.Marshal(status.Spec.JobTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to encode job template: %w", err)
+	}
+	dao := &apprepo.CronJobDAO{
+		ID:                         status.ID,
+		ApplicationID:              status.AppID,
+		Name:                       status.Spec.Name,
+		Schedule:                   status.Spec.Schedule,
+		Timezone:                   status.Spec.Timezone,
+		ConcurrencyPolicy:          string(status.Spec.ConcurrencyPolicy),
+		Suspend:                    status.Spec.Suspend,
+		SuccessfulJobsHistoryLimit: status.Spec.SuccessfulJobsHistoryLimit,
+		FailedJobsHistoryLimit:     status.Spec.FailedJobsHistoryLimit,
+		JobTemplate:                string(template),
+		CreatedAt:                  status.CreatedAt,
+		UpdatedAt:                  time.Now(),
+	}
+	if !status.LastScheduleTime.IsZero() {
+		last := status.LastScheduleTime
+		dao.LastScheduleAt = &last
+	}
+	return m.cronJobs.repo.Save(ctx, dao)
+}
+
+// loadCronJobs 从仓库恢复 CronJob，无法解析的记录跳过
+func (m *Manager) loadCronJobs(ctx context.Context) error {
+	if m.cronJobs.repo == nil {
+		return nil
+	}
+	daos, err := m.cronJobs.repo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	m.cronJobs.mu.Lock()
+	defer m.cronJobs.mu.Unlock()
+	for _, dao := range daos {
+		spec := types.CronJobSpec{
+			Name:                       dao.Name,
+			Schedule:                   dao.Schedule,
+			Timezone:                   dao.Timezone,
+			ConcurrencyPolicy:          types.ConcurrencyPolicy(dao.ConcurrencyPolicy),
+			Suspend:                    dao.Suspend,
+			SuccessfulJobsHistoryLimit: dao.SuccessfulJobsHistoryLimit,
+			FailedJobsHistoryLimit:     dao.FailedJobsHistoryLimit,
+		}
+		if err := json.Unmarshal([]byte(dao.JobTemplate), &spec.JobTemplate); err != nil {
+			logrus.Warnf("Skipping cron job %s: invalid job template: %v", dao.ID, err)
+			continue
+		}
+		schedule, location, err := ValidateCronJobSpec(&spec)
+		if err != nil {
+			logrus.Warnf("Skipping cron job %s: %v", dao.ID, err)
+			continue
+		}
+		cj := &cronJob{
+			status: types.CronJobStatus{
+				ID:        dao.ID,
+				AppID:     dao.ApplicationID,
+				Spec:      spec,
+				CreatedAt: dao.CreatedAt,
+			},
+			schedule: schedule,
+			location: location,
+		}
+		if dao.LastScheduleAt != nil {
+			cj.status.LastScheduleTime = *dao.LastScheduleAt
+		}
+		m.cronJobs.entries[dao.ID] = cj
+	}
+	logrus.Infof("Loaded %d cron job(s)", len(m.cronJobs.entries))
+	return nil
+}
+
+// cronLoop 每分钟开始时检查到期的 CronJob 并触发，节点停机期间错过的触发不会补偿
+func (m *Manager) cronLoop(ctx context.Context) {
+	for {
+		now := time.Now()
+		timer := time.NewTimer(now.Truncate(time.Minute).Add(time.Minute).Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case t := <-timer.C:
+			m.runDueCronJobs(ctx, t)
+		}
+	}
+}
+
+// runDueCronJobs 触发在 t 所在分钟到期的 CronJob，并清理超出保留数的历史 Job
+func (m *Manager) runDueCronJobs(ctx context.Context, t time.Time) {
+	m.cronJobs.mu.Lock()
+	var due, all []*cronJob
+	for _, cj := range m.cronJobs.entries {
+		all = append(all, cj)
+		if cj.due(t) {
+			cj.status.LastScheduleTime = t.Truncate(time.Minute)
+			due = append(due, cj)
+		}
+	}
+	m.cronJobs.mu.Unlock()
+
+	for _, cj := range due {
+		m.triggerCronJob(ctx, cj)
+	}
+	for _, cj := range all {
+		m.pruneCronJobHistory(cj)
+	}
+}
+
+// triggerCronJob 按并发策略启动一次 Job
+func (m *Manager) triggerCronJob(ctx context.Context, cj *cronJob) {
+	m.cronJobs.mu.Lock()
+	id, appID := cj.status.ID, cj.status.AppID
+	spec := cj.status.Spec
+	scheduledAt := cj.status.LastScheduleTime
+	m.cronJobs.mu.Unlock()
+
+	if m.cronJobs.repo != nil {
+		repoCtx, cancel := context.WithTimeout(ctx, cronRepoTimeout)
+		if err := m.cronJobs.repo.UpdateLastSchedule(repoCtx, id, scheduledAt); err != nil {
+			logrus.Warnf("Failed to record schedule time of cron job %s: %v", id, err)
+		}
+		cancel()
+	}
+
+	active := m.activeCronJobRuns(id)
+	if len(active) > 0 {
+		switch spec.ConcurrencyPolicy {
+		case types.ConcurrencyForbid:
+			logrus.Infof("CronJob %s: skipping run at %s, %d job(s) still active", id, scheduledAt.Format(time.RFC3339), len(active))
+			return
+		case types.ConcurrencyReplace:
+			for _, job := range active {
+				logrus.Infof("CronJob %s: replacing active job %s", id, job.ID)
+				if _, err := m.CancelJob(ctx, job.ID); err != nil {
+					logrus.Warnf("CronJob %s: failed to cancel job %s: %v", id, job.ID, err)
+				}
+			}
+		}
+	}
+
+	job, err := m.submitJob(ctx, appID, spec.JobTemplate, id)
+	if err != nil {
+		logrus.Errorf("CronJob %s: failed to start job: %v", id, err)
+		m.lifecycle.record(appID, types.AppEvent{
+			Time:   time.Now(),
+			Reason: fmt.Sprintf("cron job %s failed to start job: %v", id, err),
+		})
+		return
+	}
+	logrus.Infof("CronJob %s: started job %s for run at %s", id, job.ID, scheduledAt.Format(time.RFC3339))
+}
+
+// pruneCronJobHistory 按保留数删除最早的已结束 Job
+func (m *Manager) pruneCronJobHistory(cj *cronJob) {
+	m.cronJobs.mu.Lock()
+	id := cj.status.ID
+	keepSucceeded := cj.status.Spec.SuccessfulJobsHistoryLimit
+	keepFailed := cj.status.Spec.FailedJobsHistoryLimit
+	m.cronJobs.mu.Unlock()
+
+	var succeeded, failed []*types.JobStatus
+	for _, job := range m.jobsWhere(func(s *types.JobStatus) bool { return s.CronJobID == id && s.IsFinished() }) {
+		if job.State == types.JobStateSucceeded {
+			succeeded = append(succeeded, job)
+		} else {
+			failed = append(failed, job)
+		}
+	}
+	// jobsWhere 按提交时间排序，超出保留数的部分从最早的开始删除
+	for _, group := range []struct {
+		jobs []*types.JobStatus
+		keep int
+	}{{succeeded, keepSucceeded}, {failed, keepFailed}} {
+		for i := 0; i < len(group.jobs)-group.keep; i++ {
+			if err := m.DeleteJob(group.jobs[i].ID); err != nil {
+				logrus.Debugf("CronJob %s: failed to remove job %s from history: %v", id, group.jobs[i].ID, err)
+			}
+		}
+	}
+}
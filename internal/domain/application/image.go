@@ -0,0 +1,90 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/9triver/iarnet/internal/domain/application/types"
+	"github.com/sirupsen/logrus"
+)
+
+// ImageComponentRunner 将镜像模式的应用作为 component 部署到 provider 上
+type ImageComponentRunner interface {
+	// DeployImageComponent 使用构建出的镜像部署应用，返回 component ID
+	DeployImageComponent(ctx context.Context, appID, image, envInstallCmd, executeCmd string) (string, error)
+	UndeployImageComponent(ctx context.Context, componentID string) error
+}
+
+// imageComponents 记录镜像模式应用当前运行的 component
+type imageComponents struct {
+	mu    sync.Mutex
+	byApp map[string]string // appID -> component ID
+}
+
+func newImageComponents() *imageComponents {
+	return &imageComponents{byApp: make(map[string]string)}
+}
+
+// SetImageComponentRunner 设置镜像模式应用使用的 component 部署器，未设置时不能运行镜像模式应用
+func (m *Manager) SetImageComponentRunner(runner ImageComponentRunner) *Manager {
+	m.imageRunner = runner
+	return m
+}
+
+// runImageApplication 将源码构建为镜像并作为 component 部署，替换该应用之前部署的 component
+func (m *Manager) runImageApplication(ctx context.Context, appID, codeDir string, metadata types.AppMetadata) error {
+	if m.imageRunner == nil {
+		return fmt.Errorf("image component runner not configured")
+	}
+
+	image, err := m.buildAppImage(ctx, appID, codeDir)
+	if err != nil {
+		return err
+	}
+
+	if err := m.stopImageComponent(ctx, appID); err != nil {
+		return fmt.Errorf("failed to remove previous component: %w", err)
+	}
+
+	componentID, err := m.imageRunner.DeployImageComponent(ctx, appID, image, metadata.EnvInstallCmd, metadata.ExecuteCmd)
+	if err != nil {
+		return fmt.Errorf("failed to deploy image %s: %w", image, err)
+	}
+
+	m.imageComponents.mu.Lock()
+	m.imageComponents.byApp[appID] = componentID
+	m.imageComponents.mu.Unlock()
+
+	logrus.Infof("Deployed image %s for application %s as component %s", image, appID, componentID)
+	return nil
+}
+
+// stopImageComponent 删除镜像模式应用的 component，应用没有运行中的 component 时直接返回
+func (m *Manager) stopImageComponent(ctx context.Context, appID string) error {
+	m.imageComponents.mu.Lock()
+	componentID, ok := m.imageComponents.byApp[appID]
+	m.imageComponents.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	if err := m.imageRunner.UndeployImageComponent(ctx, componentID); err != nil {
+		return err
+	}
+
+	m.imageComponents.mu.Lock()
+	if m.imageComponents.byApp[appID] == componentID {
+		delete(m.imageComponents.byApp, appID)
+	}
+	m.imageComponents.mu.Unlock()
+	return nil
+}
+
+// hasImageComponent 判断应用是否有运行中的镜像 component
+func (m *Manager) hasImageComponent(appID string) bool {
+	m.imageComponents.mu.Lock()
+	defer m.imageComponents.mu.Unlock()
+	_, ok := m.imageComponents.byApp[appID]
+	return ok
+}
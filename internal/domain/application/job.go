@@ -0,0 +1,526 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/application/types"
+	"github.com/9triver/iarnet/internal/util"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// jobPollInterval 查询 Job component 退出状态的间隔
+	jobPollInterval = 5 * time.Second
+	// jobBackoffBase/jobBackoffMax 失败后重新启动 component 的退避时间，按失败次数指数增长
+	jobBackoffBase = 10 * time.Second
+	jobBackoffMax  = 6 * time.Minute
+	// defaultJobBackoffLimit 未指定时允许的失败次数
+	defaultJobBackoffLimit = 6
+	// jobStatusErrorLimit 连续查询退出状态失败的次数上限，超过后该次执行按失败处理
+	jobStatusErrorLimit = 3
+	// maxJobAttempts 每个 Job 保留的最近执行记录数
+	maxJobAttempts = 100
+	// jobUndeployTimeout 删除已结束 component 的超时
+	jobUndeployTimeout = 30 * time.Second
+)
+
+// Job component 注入的环境变量，批处理程序据此区分自身负责的分片
+const (
+	JobEnvID          = "IARNET_JOB_ID"
+	JobEnvIndex       = "IARNET_JOB_INDEX"
+	JobEnvCompletions = "IARNET_JOB_COMPLETIONS"
+)
+
+// JobComponentStatus Job component 的退出状态
+type JobComponentStatus struct {
+	Exited   bool // 已退出，ExitCode 有效
+	Gone     bool // provider 上已不存在（被外部删除或驱逐）
+	ExitCode int32
+	Reason   string
+}
+
+// JobComponentRunner 为 Job 部署、查询与删除 component，由 resource 模块适配实现
+type JobComponentRunner interface {
+	DeployJobComponent(ctx context.Context, spec types.JobSpec, env map[string]string) (string, error)
+	GetJobComponentStatus(ctx context.Context, componentID string) (*JobComponentStatus, error)
+	UndeployJobComponent(ctx context.Context, componentID string) error
+}
+
+// ValidateJobSpec 校验 Job 定义并补全缺省值：completions 与 parallelism 缺省为 1，
+// parallelism 不超过 completions，backoff limit 为负数时使用缺省值
+func ValidateJobSpec(spec *types.JobSpec) error {
+	if spec.CPU < 0 || spec.Memory < 0 || spec.GPU < 0 {
+		return fmt.Errorf("resources must not be negative")
+	}
+	if spec.Completions < 0 || spec.Parallelism < 0 {
+		return fmt.Errorf("completions and parallelism must not be negative")
+	}
+	if spec.Completions == 0 {
+		spec.Completions = 1
+	}
+	if spec.Parallelism == 0 {
+		spec.Parallelism = 1
+	}
+	if spec.Parallelism > spec.Completions {
+		spec.Parallelism = spec.Completions
+	}
+	if spec.BackoffLimit < 0 {
+		spec.BackoffLimit = defaultJobBackoffLimit
+	}
+	for _, key := range []string{JobEnvID, JobEnvIndex, JobEnvCompletions} {
+		if _, ok := spec.Env[key]; ok {
+			return fmt.Errorf("env %s is reserved for jobs", key)
+		}
+	}
+	return nil
+}
+
+// jobBackoff 第 failed 次失败后重新启动前的等待时间
+func jobBackoff(failed int) time.Duration {
+	if failed <= 0 {
+		return 0
+	}
+	d := jobBackoffBase
+	for i := 1; i < failed && d < jobBackoffMax; i++ {
+		d *= 2
+	}
+	return min(d, jobBackoffMax)
+}
+
+// jobRun 单个 Job 的运行状态，由 run 协程独占推进，查询时加锁复制
+type jobRun struct {
+	mu        sync.Mutex
+	status    types.JobStatus // Attempts 由 attempts 在查询时生成
+	attempts  []*types.JobAttempt
+	active    map[string]*activeAttempt // componentID -> 运行中的执行
+	completed map[int]struct{}          // 已成功完成的序号
+	nextStart time.Time                 // 退避结束时间
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+// activeAttempt 运行中的执行及其连续查询失败次数
+type activeAttempt struct {
+	attempt     *types.JobAttempt
+	statusFails int
+}
+
+// jobs 记录本节点提交的 Job
+type jobs struct {
+	mu   sync.Mutex
+	runs map[types.JobID]*jobRun
+}
+
+func newJobs() *jobs {
+	return &jobs{runs: make(map[types.JobID]*jobRun)}
+}
+
+func (j *jobs) get(id types.JobID) *jobRun {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.runs[id]
+}
+
+// SetJobComponentRunner 设置 Job 使用的 component 部署器，未设置时不能提交 Job
+func (m *Manager) SetJobComponentRunner(runner JobComponentRunner) *Manager {
+	m.jobRunner = runner
+	return m
+}
+
+// SubmitJob 提交批处理任务：按 parallelism 并发部署 component，直至成功完成 completions 次
+// 或失败次数超过 backoff limit
+func (m *Manager) SubmitJob(ctx context.Context, appID string, spec types.JobSpec) (*types.JobStatus, error) {
+	return m.submitJob(ctx, appID, spec, "")
+}
+
+// submitJob 提交 Job，cronJobID 非空时表示由该 CronJob 触发
+func (m *Manager) submitJob(ctx context.Context, appID string, spec types.JobSpec, cronJobID string) (*types.JobStatus, error) {
+	if m.jobRunner == nil {
+		return nil, fmt.Errorf("job runner not configured")
+	}
+	metadata, err := m.metadataSvc.GetAppMetadata(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	if metadata.ID == "" {
+		return nil, fmt.Errorf("application not found: %s", appID)
+	}
+	if err := ValidateJobSpec(&spec); err != nil {
+		return nil, err
+	}
+
+	jobCtx, cancel := context.WithCancel(context.Background())
+	run := &jobRun{
+		status: types.JobStatus{
+			ID:        util.GenIDWith("job."),
+			AppID:     appID,
+			CronJobID: cronJobID,
+			Spec:      spec,
+			State:     types.JobStatePending,
+			CreatedAt: time.Now(),
+		},
+		active:    make(map[string]*activeAttempt),
+		completed: make(map[int]struct{}),
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+	m.jobs.mu.Lock()
+	m.jobs.runs[run.status.ID] = run
+	m.jobs.mu.Unlock()
+
+	m.lifecycle.record(appID, types.AppEvent{
+		Time:   time.Now(),
+		Reason: fmt.Sprintf("job %s submitted: completions=%d parallelism=%d backoff_limit=%d", run.status.ID, spec.Completions, spec.Parallelism, spec.BackoffLimit),
+	})
+	logrus.Infof("Job %s submitted for application %s (completions=%d, parallelism=%d)", run.status.ID, appID, spec.Completions, spec.Parallelism)
+
+	go m.runJob(jobCtx, run)
+	return run.snapshot(), nil
+}
+
+// GetJob 获取 Job 状态
+func (m *Manager) GetJob(jobID string) (*types.JobStatus, error) {
+	run := m.jobs.get(jobID)
+	if run == nil {
+		return nil, fmt.Errorf("job not found: %s", jobID)
+	}
+	return run.snapshot(), nil
+}
+
+// ListJobs 列出应用的 Job，按提交时间排序
+func (m *Manager) ListJobs(appID string) []*types.JobStatus {
+	return m.jobsWhere(func(s *types.JobStatus) bool { return s.AppID == appID })
+}
+
+// jobsWhere 列出满足条件的 Job，按提交时间排序
+func (m *Manager) jobsWhere(match func(*types.JobStatus) bool) []*types.JobStatus {
+	m.jobs.mu.Lock()
+	runs := make([]*jobRun, 0, len(m.jobs.runs))
+	for _, run := range m.jobs.runs {
+		runs = append(runs, run)
+	}
+	m.jobs.mu.Unlock()
+
+	var result []*types.JobStatus
+	for _, run := range runs {
+		if s := run.snapshot(); match(s) {
+			result = append(result, s)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result
+}
+
+// CancelJob 取消 Job 并删除其运行中的 component，已结束的 Job 不受影响
+func (m *Manager) CancelJob(ctx context.Context, jobID string) (*types.JobStatus, error) {
+	run := m.jobs.get(jobID)
+	if run == nil {
+		return nil, fmt.Errorf("job not found: %s", jobID)
+	}
+	run.cancel()
+	select {
+	case <-run.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return run.snapshot(), nil
+}
+
+// DeleteJob 删除已结束的 Job 记录
+func (m *Manager) DeleteJob(jobID string) error {
+	m.jobs.mu.Lock()
+	defer m.jobs.mu.Unlock()
+	run := m.jobs.runs[jobID]
+	if run == nil {
+		return fmt.Errorf("job not found: %s", jobID)
+	}
+	if !run.snapshot().IsFinished() {
+		return fmt.Errorf("job %s is still running, cancel it first", jobID)
+	}
+	delete(m.jobs.runs, jobID)
+	return nil
+}
+
+// forgetJobs 删除应用时停止并移除其所有 Job
+func (m *Manager) forgetJobs(appID string) {
+	m.jobs.mu.Lock()
+	defer m.jobs.mu.Unlock()
+	for id, run := range m.jobs.runs {
+		if run.snapshot().AppID == appID {
+			run.cancel()
+			delete(m.jobs.runs, id)
+		}
+	}
+}
+
+// runJob 推进 Job 直至进入终止状态：轮询运行中 component 的退出状态，
+// 失败后按退避时间重试相同序号，成功次数达到 completions 时完成
+func (m *Manager) runJob(ctx context.Context, run *jobRun) {
+	defer close(run.done)
+
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		m.pollJob(ctx, run)
+		if m.settleJob(run) {
+			return
+		}
+		m.launchJobComponents(ctx, run)
+
+		select {
+		case <-ctx.Done():
+			m.finishJob(run, types.JobStateCancelled, "cancelled by request")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// pollJob 查询运行中 component 的退出状态并记录结果
+func (m *Manager) pollJob(ctx context.Context, run *jobRun) {
+	run.mu.Lock()
+	ids := make([]string, 0, len(run.active))
+	for id := range run.active {
+		ids = append(ids, id)
+	}
+	run.mu.Unlock()
+
+	for _, id := range ids {
+		status, err := m.jobRunner.GetJobComponentStatus(ctx, id)
+		if ctx.Err() != nil {
+			return
+		}
+
+		run.mu.Lock()
+		active := run.active[id]
+		switch {
+		case err != nil:
+			active.statusFails++
+			if active.statusFails >= jobStatusErrorLimit {
+				run.endAttemptLocked(id, -1, fmt.Sprintf("exit status unavailable: %v", err))
+			} else {
+				logrus.Debugf("Job %s: failed to get status of component %s: %v", run.status.ID, id, err)
+			}
+		case status.Gone:
+			run.endAttemptLocked(id, -1, "component disappeared before exiting")
+		case status.Exited:
+			run.endAttemptLocked(id, status.ExitCode, status.Reason)
+		default:
+			active.statusFails = 0
+		}
+		_, stillActive := run.active[id]
+		run.mu.Unlock()
+
+		if !stillActive {
+			m.undeployJobComponent(ctx, run.status.ID, id)
+		}
+	}
+}
+
+// endAttemptLocked 结束一次执行，退出码为 0 时计为成功完成，否则计为失败并开始退避
+func (r *jobRun) endAttemptLocked(componentID string, exitCode int32, reason string) {
+	active := r.active[componentID]
+	delete(r.active, componentID)
+	attempt := active.attempt
+	attempt.ExitCode = exitCode
+	attempt.Reason = reason
+	attempt.FinishedAt = time.Now()
+	r.status.Active = len(r.active)
+
+	if exitCode == 0 {
+		attempt.State = types.JobAttemptSucceeded
+		r.completed[attempt.Index] = struct{}{}
+		r.status.Succeeded++
+		logrus.Infof("Job %s: index %d completed by component %s", r.status.ID, attempt.Index, componentID)
+		return
+	}
+	attempt.State = types.JobAttemptFailed
+	r.recordFailureLocked()
+	logrus.Warnf("Job %s: index %d failed on component %s (exit code %d, %s)", r.status.ID, attempt.Index, componentID, exitCode, reason)
+}
+
+func (r *jobRun) recordFailureLocked() {
+	r.status.Failed++
+	r.nextStart = time.Now().Add(jobBackoff(r.status.Failed))
+}
+
+// settleJob 判断 Job 是否已完成或失败，返回是否进入终止状态
+func (m *Manager) settleJob(run *jobRun) bool {
+	run.mu.Lock()
+	succeeded, failed := run.status.Succeeded, run.status.Failed
+	spec := run.status.Spec
+	run.mu.Unlock()
+
+	switch {
+	case succeeded >= spec.Completions:
+		m.finishJob(run, types.JobStateSucceeded, fmt.Sprintf("%d/%d completions succeeded", succeeded, spec.Completions))
+		return true
+	case failed > spec.BackoffLimit:
+		// 超过失败次数上限后不再等待其余 component
+		m.stopJobComponents(run)
+		m.finishJob(run, types.JobStateFailed, fmt.Sprintf("backoff limit exceeded: %d failures (limit %d)", failed, spec.BackoffLimit))
+		return true
+	}
+	return false
+}
+
+// launchJobComponents 在退避结束后补足运行中的 component，每个 component 负责一个未完成的序号
+func (m *Manager) launchJobComponents(ctx context.Context, run *jobRun) {
+	for ctx.Err() == nil {
+		run.mu.Lock()
+		if time.Now().Before(run.nextStart) || len(run.active) >= run.status.Spec.Parallelism {
+			run.mu.Unlock()
+			return
+		}
+		index, ok := run.nextIndexLocked()
+		if !ok {
+			run.mu.Unlock()
+			return
+		}
+		spec := run.status.Spec
+		jobID := run.status.ID
+		run.mu.Unlock()
+
+		env := make(map[string]string, len(spec.Env)+3)
+		for k, v := range spec.Env {
+			env[k] = v
+		}
+		env[JobEnvID] = jobID
+		env[JobEnvIndex] = strconv.Itoa(index)
+		env[JobEnvCompletions] = strconv.Itoa(spec.Completions)
+
+		componentID, err := m.jobRunner.DeployJobComponent(ctx, spec, env)
+		if ctx.Err() != nil {
+			if err == nil {
+				m.undeployJobComponent(ctx, jobID, componentID)
+			}
+			return
+		}
+
+		run.mu.Lock()
+		attempt := run.addAttemptLocked(types.JobAttempt{
+			Index:       index,
+			ComponentID: componentID,
+			State:       types.JobAttemptRunning,
+			StartedAt:   time.Now(),
+		})
+		if err != nil {
+			attempt.State = types.JobAttemptFailed
+			attempt.ExitCode = -1
+			attempt.Reason = fmt.Sprintf("deploy failed: %v", err)
+			attempt.FinishedAt = attempt.StartedAt
+			run.recordFailureLocked()
+			run.mu.Unlock()
+			logrus.Warnf("Job %s: failed to deploy component for index %d: %v", jobID, index, err)
+			return
+		}
+		run.active[componentID] = &activeAttempt{attempt: attempt}
+		run.status.Active = len(run.active)
+		run.mu.Unlock()
+		logrus.Infof("Job %s: started component %s for index %d", jobID, componentID, index)
+	}
+}
+
+// nextIndexLocked 返回最小的既未完成也未在运行的序号
+func (r *jobRun) nextIndexLocked() (int, bool) {
+	running := make(map[int]struct{}, len(r.active))
+	for _, a := range r.active {
+		running[a.attempt.Index] = struct{}{}
+	}
+	for i := 0; i < r.status.Spec.Completions; i++ {
+		if _, done := r.completed[i]; done {
+			continue
+		}
+		if _, busy := running[i]; busy {
+			continue
+		}
+		return i, true
+	}
+	return 0, false
+}
+
+// addAttemptLocked 追加执行记录，超过上限时丢弃最早的已结束记录
+func (r *jobRun) addAttemptLocked(attempt types.JobAttempt) *types.JobAttempt {
+	if len(r.attempts) >= maxJobAttempts {
+		for i, a := range r.attempts {
+			if a.State != types.JobAttemptRunning {
+				r.attempts = append(r.attempts[:i], r.attempts[i+1:]...)
+				break
+			}
+		}
+	}
+	a := &attempt
+	r.attempts = append(r.attempts, a)
+	r.status.State = types.JobStateRunning
+	return a
+}
+
+// stopJobComponents 删除 Job 所有运行中的 component
+func (m *Manager) stopJobComponents(run *jobRun) {
+	run.mu.Lock()
+	ids := make([]string, 0, len(run.active))
+	for id, active := range run.active {
+		ids = append(ids, id)
+		active.attempt.State = types.JobAttemptFailed
+		active.attempt.ExitCode = -1
+		active.attempt.Reason = "stopped"
+		active.attempt.FinishedAt = time.Now()
+	}
+	run.active = make(map[string]*activeAttempt)
+	run.status.Active = 0
+	jobID := run.status.ID
+	run.mu.Unlock()
+
+	for _, id := range ids {
+		m.undeployJobComponent(context.Background(), jobID, id)
+	}
+}
+
+// finishJob 使 Job 进入终止状态并记录应用事件
+func (m *Manager) finishJob(run *jobRun, state types.JobState, message string) {
+	if state == types.JobStateCancelled {
+		m.stopJobComponents(run)
+	}
+
+	run.mu.Lock()
+	run.status.State = state
+	run.status.Message = message
+	run.status.CompletedAt = time.Now()
+	appID, jobID := run.status.AppID, run.status.ID
+	run.mu.Unlock()
+
+	m.lifecycle.record(appID, types.AppEvent{
+		Time:   time.Now(),
+		Reason: fmt.Sprintf("job %s %s: %s", jobID, state, message),
+	})
+	logrus.Infof("Job %s of application %s %s: %s", jobID, appID, state, message)
+}
+
+// undeployJobComponent 删除已结束的 component，失败只记录日志
+func (m *Manager) undeployJobComponent(ctx context.Context, jobID, componentID string) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), jobUndeployTimeout)
+	defer cancel()
+	if err := m.jobRunner.UndeployJobComponent(ctx, componentID); err != nil {
+		logrus.Warnf("Job %s: failed to remove component %s: %v", jobID, componentID, err)
+	}
+}
+
+// snapshot 复制 Job 当前状态
+func (r *jobRun) snapshot() *types.JobStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := r.status
+	s.Attempts = make([]types.JobAttempt, 0, len(r.attempts))
+	for _, a := range r.attempts {
+		s.Attempts = append(s.Attempts, *a)
+	}
+	return &s
+}
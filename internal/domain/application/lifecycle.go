@@ -0,0 +1,281 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/application/types"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// maxAppEvents 每个应用保留的最近事件数
+	maxAppEvents = 50
+	// statusReconcileInterval 根据 component 状态校正应用状态的间隔
+	statusReconcileInterval = 10 * time.Second
+)
+
+// appTransitions 应用生命周期状态机：当前状态 -> 允许迁移到的状态
+// 主线为 pending -> deploying -> running -> degraded -> stopped -> failed，
+// cloning/building/idle 为部署前的准备阶段
+var appTransitions = map[types.AppStatus][]types.AppStatus{
+	types.AppStatusPending:    {types.AppStatusCloning, types.AppStatusDeploying, types.AppStatusFailed},
+	types.AppStatusCloning:    {types.AppStatusBuilding, types.AppStatusUndeployed, types.AppStatusFailed},
+	types.AppStatusBuilding:   {types.AppStatusUndeployed, types.AppStatusFailed},
+	types.AppStatusUndeployed: {types.AppStatusCloning, types.AppStatusDeploying, types.AppStatusStopped},
+	types.AppStatusDeploying:  {types.AppStatusRunning, types.AppStatusStopped, types.AppStatusFailed},
+	types.AppStatusRunning:    {types.AppStatusDegraded, types.AppStatusDeploying, types.AppStatusStopped, types.AppStatusFailed},
+	types.AppStatusDegraded:   {types.AppStatusRunning, types.AppStatusDeploying, types.AppStatusStopped, types.AppStatusFailed},
+	types.AppStatusStopped:    {types.AppStatusDeploying, types.AppStatusUndeployed},
+	types.AppStatusFailed:     {types.AppStatusCloning, types.AppStatusDeploying, types.AppStatusUndeployed, types.AppStatusStopped},
+}
+
+// CanTransition 检查应用状态能否从 from 迁移到 to，相同状态视为允许
+func CanTransition(from, to types.AppStatus) bool {
+	if from == to {
+		return true
+	}
+	for _, next := range appTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ProviderStateResolver 根据 provider ID 推导其上 component 的状态
+type ProviderStateResolver func(providerID string) types.ComponentState
+
+// lifecycle 记录应用的生命周期事件和 component 最近一次观察到的状态
+type lifecycle struct {
+	mu              sync.Mutex
+	events          map[types.AppID][]types.AppEvent
+	componentStates map[types.AppID]map[string]types.ComponentState
+}
+
+func newLifecycle() *lifecycle {
+	return &lifecycle{
+		events:          make(map[types.AppID][]types.AppEvent),
+		componentStates: make(map[types.AppID]map[string]types.ComponentState),
+	}
+}
+
+func (l *lifecycle) record(appID types.AppID, event types.AppEvent) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	events := append(l.events[appID], event)
+	if len(events) > maxAppEvents {
+		events = events[len(events)-maxAppEvents:]
+	}
+	l.events[appID] = events
+}
+
+func (l *lifecycle) recent(appID types.AppID) []types.AppEvent {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]types.AppEvent(nil), l.events[appID]...)
+}
+
+// observe 记录 component 的最新状态，返回状态发生变化的 component 及其原状态
+func (l *lifecycle) observe(appID types.AppID, components []types.ComponentStatus) map[string]types.ComponentState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	previous := l.componentStates[appID]
+	current := make(map[string]types.ComponentState, len(components))
+	changed := make(map[string]types.ComponentState)
+	for _, c := range components {
+		current[c.ComponentID] = c.State
+		if old, ok := previous[c.ComponentID]; ok && old != c.State {
+			changed[c.ComponentID] = old
+		}
+	}
+	l.componentStates[appID] = current
+	return changed
+}
+
+func (l *lifecycle) forget(appID types.AppID) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.events, appID)
+	delete(l.componentStates, appID)
+}
+
+// SetProviderStateResolver 设置 provider 状态解析器，用于推导 component 状态
+// 未设置时已分配 provider 的 component 均视为运行中
+func (m *Manager) SetProviderStateResolver(resolver ProviderStateResolver) *Manager {
+	m.providerState = resolver
+	return m
+}
+
+// transition 按状态机迁移应用状态并记录事件，不允许的迁移返回错误
+func (m *Manager) transition(ctx context.Context, appID string, to types.AppStatus, reason string) error {
+	m.transitionMu.Lock()
+	defer m.transitionMu.Unlock()
+
+	metadata, err := m.metadataSvc.GetAppMetadata(ctx, appID)
+	if err != nil {
+		return err
+	}
+	if metadata.ID == "" {
+		return fmt.Errorf("application not found: %s", appID)
+	}
+	from := metadata.Status
+	if from == to {
+		return nil
+	}
+	if !CanTransition(from, to) {
+		return fmt.Errorf("invalid application status transition for %s: %s -> %s", appID, from, to)
+	}
+	if err := m.metadataSvc.UpdateAppStatus(ctx, appID, to); err != nil {
+		return err
+	}
+
+	m.lifecycle.record(appID, types.AppEvent{
+		Time:   time.Now(),
+		From:   from,
+		To:     to,
+		Reason: reason,
+	})
+	logrus.Infof("Application %s status: %s -> %s (%s)", appID, from, to, reason)
+	return nil
+}
+
+// setStatus 迁移应用状态，失败只记录日志（用于不影响主流程的状态更新）
+func (m *Manager) setStatus(ctx context.Context, appID string, to types.AppStatus, reason string) {
+	if err := m.transition(ctx, appID, to, reason); err != nil {
+		logrus.Errorf("Failed to update application %s status to %s: %v", appID, to, err)
+	}
+}
+
+// componentStatuses 获取应用所有 component 的状态，按函数名和 actor ID 排序
+// 应用没有控制器或尚未部署 actor 时返回空列表
+func (m *Manager) componentStatuses(appID string) []types.ComponentStatus {
+	if m.platform == nil {
+		return nil
+	}
+	actors, err := m.platform.GetActors(appID)
+	if err != nil {
+		return nil
+	}
+
+	var components []types.ComponentStatus
+	for function, group := range actors {
+		for _, actor := range group {
+			comp := actor.GetComponent()
+			if comp == nil {
+				continue
+			}
+			status := types.ComponentStatus{
+				ComponentID: comp.GetID(),
+				ActorID:     actor.GetID(),
+				Function:    function,
+				ProviderID:  comp.GetProviderID(),
+				State:       types.ComponentStateRunning,
+			}
+			if status.ProviderID == "" {
+				status.State = types.ComponentStateDeploying
+			} else if m.providerState != nil {
+				status.State = m.providerState(status.ProviderID)
+			}
+			components = append(components, status)
+		}
+	}
+	sort.Slice(components, func(i, j int) bool {
+		if components[i].Function != components[j].Function {
+			return components[i].Function < components[j].Function
+		}
+		return components[i].ActorID < components[j].ActorID
+	})
+	return components
+}
+
+// reconcileStatus 根据 component 状态校正运行中应用的状态：
+// 存在不健康的 component 时 running -> degraded，全部恢复后 degraded -> running
+func (m *Manager) reconcileStatus(ctx context.Context, appID string) []types.ComponentStatus {
+	components := m.componentStatuses(appID)
+	changed := m.lifecycle.observe(appID, components)
+	for _, c := range components {
+		if old, ok := changed[c.ComponentID]; ok {
+			m.lifecycle.record(appID, types.AppEvent{
+				Time:        time.Now(),
+				ComponentID: c.ComponentID,
+				Reason:      fmt.Sprintf("component %s: %s -> %s", c.ComponentID, old, c.State),
+			})
+		}
+	}
+
+	metadata, err := m.metadataSvc.GetAppMetadata(ctx, appID)
+	if err != nil || metadata.ID == "" {
+		return components
+	}
+
+	var unhealthy []string
+	for _, c := range components {
+		if c.State == types.ComponentStateUnhealthy {
+			unhealthy = append(unhealthy, c.ComponentID)
+		}
+	}
+	switch {
+	case metadata.Status == types.AppStatusRunning && len(unhealthy) > 0:
+		m.setStatus(ctx, appID, types.AppStatusDegraded, fmt.Sprintf("%d component(s) unhealthy: %v", len(unhealthy), unhealthy))
+	case metadata.Status == types.AppStatusDegraded && len(unhealthy) == 0:
+		m.setStatus(ctx, appID, types.AppStatusRunning, "all components healthy")
+	}
+	return components
+}
+
+// reconcileLoop 定期校正所有运行中应用的状态，并评估设置了 SLO 的应用
+func (m *Manager) reconcileLoop(ctx context.Context) {
+	ticker := time.NewTicker(statusReconcileInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			apps, err := m.metadataSvc.GetAllAppMetadata(ctx)
+			if err != nil {
+				logrus.Warnf("Failed to list applications for status reconcile: %v", err)
+				continue
+			}
+			for _, app := range apps {
+				if app.Status == types.AppStatusRunning || app.Status == types.AppStatusDegraded {
+					components := m.reconcileStatus(ctx, app.ID)
+					if app.SLO != nil {
+						m.enforceSLO(ctx, app.ID, *app.SLO, components)
+					}
+				}
+			}
+		}
+	}
+}
+
+// GetApplicationStatus 获取应用状态，包含 component 明细和最近的生命周期事件
+// 查询时会先根据 component 状态校正应用状态
+func (m *Manager) GetApplicationStatus(ctx context.Context, appID string) (*types.AppStatusReport, error) {
+	metadata, err := m.metadataSvc.GetAppMetadata(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	if metadata.ID == "" {
+		return nil, fmt.Errorf("application not found: %s", appID)
+	}
+
+	components := m.reconcileStatus(ctx, appID)
+	metadata, err = m.metadataSvc.GetAppMetadata(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	return &types.AppStatusReport{
+		AppID:      metadata.ID,
+		Status:     metadata.Status,
+		Components: components,
+		Events:     m.lifecycle.recent(appID),
+	}, nil
+}
@@ -3,14 +3,17 @@ package application
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/9triver/iarnet/internal/domain/application/build"
 	"github.com/9triver/iarnet/internal/domain/application/logger"
 	"github.com/9triver/iarnet/internal/domain/application/metadata"
 	"github.com/9triver/iarnet/internal/domain/application/runner"
 	"github.com/9triver/iarnet/internal/domain/application/types"
 	"github.com/9triver/iarnet/internal/domain/application/workspace"
 	"github.com/9triver/iarnet/internal/domain/ignis"
+	"github.com/9triver/iarnet/internal/domain/ignis/controller"
 	"github.com/9triver/iarnet/internal/domain/ignis/task"
 	logrus "github.com/sirupsen/logrus"
 )
@@ -28,10 +31,35 @@ type Manager struct {
 	metadataSvc  metadata.Service
 	platform     *ignis.Platform
 	loggerSvc    logger.Service
+	buildSvc     build.Service
+
+	// 生命周期：状态迁移串行执行，事件与 component 状态记录在 lifecycle 中
+	transitionMu  sync.Mutex
+	lifecycle     *lifecycle
+	providerState ProviderStateResolver
+
+	// 批处理任务：run-to-completion 的 component 由 jobRunner 部署并查询退出状态
+	jobs      *jobs
+	jobRunner JobComponentRunner
+	cronJobs  *cronJobs
+
+	// 镜像模式：构建出的镜像由 imageRunner 作为 component 部署
+	imageRunner     ImageComponentRunner
+	imageComponents *imageComponents
+
+	// SLO：调用延迟来自 ignis 控制器事件，可用率来自状态校正，违反信号交给注册的处理函数
+	slo *sloTracker
 }
 
 func NewManager() *Manager {
-	return &Manager{}
+	return &Manager{
+		lifecycle: newLifecycle(),
+		jobs:      newJobs(),
+		cronJobs:  newCronJobs(),
+		slo:       newSLOTracker(),
+
+		imageComponents: newImageComponents(),
+	}
 }
 
 // Dependency Injection
@@ -61,9 +89,22 @@ func (m *Manager) SetApplicationLoggerService(loggerSvc logger.Service) *Manager
 	return m
 }
 
+func (m *Manager) SetApplicationBuildService(buildSvc build.Service) *Manager {
+	m.buildSvc = buildSvc
+	return m
+}
+
 // Start starts the application manager
+// 启动后台循环，根据 component 状态校正运行中应用的状态、评估 SLO，并按 cron 表达式触发 CronJob
 func (m *Manager) Start(ctx context.Context) error {
-
+	if err := m.loadCronJobs(ctx); err != nil {
+		return fmt.Errorf("failed to load cron jobs: %w", err)
+	}
+	if m.platform != nil {
+		m.platform.Subscribe(controller.EventTypeInvocationCompleted, m.handleInvocationCompleted)
+	}
+	go m.reconcileLoop(ctx)
+	go m.cronLoop(ctx)
 	return nil
 }
 
@@ -80,11 +121,19 @@ func (m *Manager) GetRunnerImages() map[runner.RunnerEnv]string {
 	return m.runnerSvc.GetRunnerImages()
 }
 
+// StopRunner 停止 runner；镜像模式的应用没有 runner，删除其 component
 func (m *Manager) StopRunner(ctx context.Context, appID string) error {
+	if m.hasImageComponent(appID) {
+		return m.stopImageComponent(ctx, appID)
+	}
 	return m.runnerSvc.StopRunner(ctx, appID)
 }
 
+// RemoveRunner 删除 runner；镜像模式应用的 component 已在停止时删除
 func (m *Manager) RemoveRunner(ctx context.Context, appID string) error {
+	if m.hasImageComponent(appID) {
+		return m.stopImageComponent(ctx, appID)
+	}
 	return m.runnerSvc.RemoveRunner(ctx, appID)
 }
 
@@ -97,6 +146,10 @@ func (m *Manager) PullRepository(ctx context.Context, appID string) error {
 	return m.workspaceSvc.PullRepository(ctx, appID)
 }
 
+func (m *Manager) GetHeadCommit(ctx context.Context, appID string) (string, error) {
+	return m.workspaceSvc.GetHeadCommit(ctx, appID)
+}
+
 func (m *Manager) GetFileTree(ctx context.Context, appID string, path string) ([]types.FileInfo, error) {
 	return m.workspaceSvc.GetFileTree(ctx, appID, path)
 }
@@ -150,11 +203,16 @@ func (m *Manager) UpdateAppMetadata(ctx context.Context, appID string, metadata
 	return m.metadataSvc.UpdateAppMetadata(ctx, appID, metadata)
 }
 
+// UpdateAppStatus 更新应用状态，迁移需符合生命周期状态机
 func (m *Manager) UpdateAppStatus(ctx context.Context, appID string, status types.AppStatus) error {
-	return m.metadataSvc.UpdateAppStatus(ctx, appID, status)
+	return m.transition(ctx, appID, status, "status updated")
 }
 
 func (m *Manager) RemoveAppMetadata(ctx context.Context, appID string) error {
+	m.lifecycle.forget(appID)
+	m.forgetCronJobs(ctx, appID)
+	m.forgetJobs(appID)
+	m.slo.forget(appID)
 	return m.metadataSvc.RemoveAppMetadata(ctx, appID)
 }
 
@@ -175,8 +233,8 @@ func (m *Manager) GetLogsByTimeRange(ctx context.Context, applicationID string,
 // CreateApplication 创建应用，包括创建元数据和异步克隆 Git 仓库
 // 返回应用 ID 和错误
 func (m *Manager) CreateApplication(ctx context.Context, metadata types.AppMetadata) (types.AppID, error) {
-	// 设置初始状态为 cloning
-	metadata.Status = types.AppStatusCloning
+	// 设置初始状态为 pending，克隆开始后迁移到 cloning
+	metadata.Status = types.AppStatusPending
 
 	// 创建应用元数据
 	appID, err := m.metadataSvc.CreateAppMetadata(ctx, metadata)
@@ -184,9 +242,10 @@ func (m *Manager) CreateApplication(ctx context.Context, metadata types.AppMetad
 		logrus.Errorf("Failed to create app metadata: %v", err)
 		return "", err
 	}
+	m.lifecycle.record(appID, types.AppEvent{Time: time.Now(), To: types.AppStatusPending, Reason: "application created"})
 
-	// 创建控制器
-	_, err = m.platform.CreateController(ctx, string(appID))
+	// 从控制器池获取控制器
+	_, err = m.platform.AcquireController(ctx, string(appID))
 	if err != nil {
 		logrus.Errorf("Failed to create controller for application %s: %v", appID, err)
 		return "", err
@@ -196,24 +255,31 @@ func (m *Manager) CreateApplication(ctx context.Context, metadata types.AppMetad
 	go func() {
 		ctx := context.Background()
 		logrus.Infof("Starting async clone for application %s", appID)
+		m.setStatus(ctx, string(appID), types.AppStatusCloning, "cloning repository")
 
 		codeDir, err := m.workspaceSvc.CloneRepository(ctx, string(appID), metadata.GitUrl, metadata.Branch)
 		if err != nil {
 			logrus.Errorf("Failed to clone repository for application %s: %v", appID, err)
 			// 克隆失败，更新状态为 error
-			if updateErr := m.metadataSvc.UpdateAppStatus(ctx, string(appID), types.AppStatusFailed); updateErr != nil {
-				logrus.Errorf("Failed to update app status to error: %v", updateErr)
-			}
+			m.setStatus(ctx, string(appID), types.AppStatusFailed, fmt.Sprintf("clone failed: %v", err))
 			return
 		}
 
 		// 克隆成功
 		logrus.Infof("Successfully cloned repository for application %s to %s", appID, codeDir)
 
-		// 更新状态为 idle（未部署）
-		if err := m.metadataSvc.UpdateAppStatus(ctx, string(appID), types.AppStatusUndeployed); err != nil {
-			logrus.Errorf("Failed to update app status to idle: %v", err)
+		// 配置了构建命令时提前构建产物，后续运行可直接命中缓存
+		if metadata.BuildCmd != "" {
+			m.setStatus(ctx, string(appID), types.AppStatusBuilding, "building artifact")
+			if _, err := m.prepareCodeDir(ctx, string(appID)); err != nil {
+				logrus.Errorf("Failed to build application %s: %v", appID, err)
+				m.setStatus(ctx, string(appID), types.AppStatusFailed, fmt.Sprintf("build failed: %v", err))
+				return
+			}
 		}
+
+		// 更新状态为 idle（未部署）
+		m.setStatus(ctx, string(appID), types.AppStatusUndeployed, "workspace ready")
 	}()
 
 	logrus.Infof("Application created successfully: id=%s (cloning in background)", appID)
@@ -223,52 +289,173 @@ func (m *Manager) CreateApplication(ctx context.Context, metadata types.AppMetad
 // RunApplication 运行应用，包括创建和启动 runner
 func (m *Manager) RunApplication(ctx context.Context, appID string) error {
 	// 更新应用状态为部署中
-	if err := m.metadataSvc.UpdateAppStatus(ctx, appID, types.AppStatusDeploying); err != nil {
+	if err := m.transition(ctx, appID, types.AppStatusDeploying, "deployment requested"); err != nil {
 		logrus.Errorf("Failed to update application status to deploying: %v", err)
-		return fmt.Errorf("application not found: %s", appID)
+		return err
+	}
+
+	// 控制器可能在上次停止时被回收，或因池满被淘汰，运行前重新获取
+	if _, err := m.platform.AcquireController(ctx, appID); err != nil {
+		logrus.Errorf("Failed to acquire controller for application %s: %v", appID, err)
+		m.setStatus(ctx, appID, types.AppStatusFailed, fmt.Sprintf("failed to acquire controller: %v", err))
+		return fmt.Errorf("failed to acquire controller: %w", err)
 	}
 
 	// 获取应用元数据
 	metadata, err := m.metadataSvc.GetAppMetadata(ctx, appID)
 	if err != nil {
 		logrus.Errorf("Failed to get app metadata: %v", err)
-		m.metadataSvc.UpdateAppStatus(ctx, appID, types.AppStatusFailed)
+		m.setStatus(ctx, appID, types.AppStatusFailed, fmt.Sprintf("failed to get metadata: %v", err))
 		return fmt.Errorf("application not found: %s", appID)
 	}
 
-	// 获取工作空间目录
-	codeDir, err := m.workspaceSvc.GetWorkspaceDir(ctx, appID)
+	// 获取代码目录（配置了构建命令时为构建产物目录）
+	codeDir, err := m.prepareCodeDir(ctx, appID)
 	if err != nil {
-		logrus.Errorf("Failed to get workspace directory for application %s: %v", appID, err)
-		m.metadataSvc.UpdateAppStatus(ctx, appID, types.AppStatusFailed)
-		return fmt.Errorf("failed to get workspace directory: %w", err)
+		logrus.Errorf("Failed to prepare code directory for application %s: %v", appID, err)
+		m.setStatus(ctx, appID, types.AppStatusFailed, fmt.Sprintf("failed to prepare code directory: %v", err))
+		return fmt.Errorf("failed to prepare code directory: %w", err)
+	}
+
+	// 镜像模式：源码构建为 OCI 镜像，作为 component 部署到 provider 上运行
+	if metadata.BuildImage {
+		if err := m.runImageApplication(ctx, appID, codeDir, metadata); err != nil {
+			logrus.Errorf("Failed to run image for application %s: %v", appID, err)
+			m.setStatus(ctx, appID, types.AppStatusFailed, err.Error())
+			return err
+		}
+		m.setStatus(ctx, appID, types.AppStatusRunning, "image component deployed")
+		logrus.Infof("Successfully started application %s", appID)
+		return nil
 	}
 
 	// 创建 runner（如果还没有创建）
 	// 注意：runner 可能在创建应用时已经创建，这里需要检查或直接创建
 	if err := m.runnerSvc.CreateRunner(ctx, appID, codeDir, runner.RunnerEnv(metadata.RunnerEnv), metadata.EnvInstallCmd, metadata.ExecuteCmd); err != nil {
 		logrus.Errorf("Failed to create runner for application %s: %v", appID, err)
-		m.metadataSvc.UpdateAppStatus(ctx, appID, types.AppStatusFailed)
+		m.setStatus(ctx, appID, types.AppStatusFailed, fmt.Sprintf("failed to create runner: %v", err))
 		return fmt.Errorf("failed to create runner: %w", err)
 	}
 
 	// 启动 runner
 	if err := m.runnerSvc.StartRunner(ctx, appID); err != nil {
 		logrus.Errorf("Failed to start runner for application %s: %v", appID, err)
-		m.metadataSvc.UpdateAppStatus(ctx, appID, types.AppStatusFailed)
+		m.setStatus(ctx, appID, types.AppStatusFailed, fmt.Sprintf("failed to start runner: %v", err))
 		return fmt.Errorf("failed to start runner: %w", err)
 	}
 
-	// 更新应用状态为运行中
-	if err := m.metadataSvc.UpdateAppStatus(ctx, appID, types.AppStatusRunning); err != nil {
-		logrus.Errorf("Failed to update application status to running: %v", err)
-		// 不返回错误，因为 runner 已经启动成功
-	}
+	// 更新应用状态为运行中（不返回错误，因为 runner 已经启动成功）
+	m.setStatus(ctx, appID, types.AppStatusRunning, "runner started")
 
 	logrus.Infof("Successfully started application %s", appID)
 	return nil
 }
 
+// StopApplication 停止应用：停止 runner（镜像模式下删除应用的 component）、迁移到 stopped 并回收控制器
+func (m *Manager) StopApplication(ctx context.Context, appID string) error {
+	if err := m.StopRunner(ctx, appID); err != nil {
+		return err
+	}
+	if err := m.transition(ctx, appID, types.AppStatusStopped, "stopped by request"); err != nil {
+		logrus.Errorf("Failed to update application status to stopped: %v", err)
+	}
+	m.ReleaseController(ctx, appID)
+	return nil
+}
+
+// ReleaseController 回收应用的控制器，下次运行时重新创建
+func (m *Manager) ReleaseController(ctx context.Context, appID string) {
+	if m.platform != nil {
+		m.platform.ReleaseController(ctx, appID)
+	}
+}
+
+// ControllerEvictable 判断应用的控制器能否从池中回收：应用已停止、已失败或未部署时可以回收；
+// 没有对应应用（客户端连接未知 app id 时自动创建）的控制器在会话结束后即视为已结束
+func (m *Manager) ControllerEvictable(appID string) bool {
+	metadata, err := m.metadataSvc.GetAppMetadata(context.Background(), appID)
+	if err != nil {
+		return false
+	}
+	if metadata.ID == "" {
+		return true
+	}
+	switch metadata.Status {
+	case types.AppStatusStopped, types.AppStatusFailed, types.AppStatusUndeployed:
+		return true
+	default:
+		return false
+	}
+}
+
+// ListControllers 列出控制器池中的所有控制器
+func (m *Manager) ListControllers() []controller.Info {
+	if m.platform == nil {
+		return nil
+	}
+	return m.platform.ListControllers()
+}
+
+// prepareCodeDir 获取挂载到 runner 的代码目录
+// 未配置构建命令时直接使用工作空间；否则按当前提交构建产物（命中缓存时直接复用）
+func (m *Manager) prepareCodeDir(ctx context.Context, appID string) (string, error) {
+	codeDir, err := m.workspaceSvc.GetWorkspaceDir(ctx, appID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get workspace directory: %w", err)
+	}
+
+	metadata, err := m.metadataSvc.GetAppMetadata(ctx, appID)
+	if err != nil {
+		return "", err
+	}
+
+	commitSHA, err := m.workspaceSvc.GetHeadCommit(ctx, appID)
+	if err != nil {
+		logrus.Warnf("Failed to resolve commit for application %s: %v", appID, err)
+	} else if commitSHA != metadata.CommitSHA {
+		metadata.CommitSHA = commitSHA
+		if err := m.metadataSvc.UpdateAppMetadata(ctx, appID, metadata); err != nil {
+			logrus.Warnf("Failed to record commit for application %s: %v", appID, err)
+		}
+	}
+
+	if metadata.BuildCmd == "" {
+		return codeDir, nil
+	}
+	if m.buildSvc == nil {
+		return "", fmt.Errorf("build service not configured")
+	}
+
+	artifact, err := m.buildSvc.BuildArtifact(ctx, appID, codeDir, commitSHA, metadata.RunnerEnv, metadata.BuildCmd)
+	if err != nil {
+		return "", fmt.Errorf("failed to build application: %w", err)
+	}
+	return artifact.Dir, nil
+}
+
+// buildAppImage 将代码目录构建为 OCI 镜像并记录镜像引用
+func (m *Manager) buildAppImage(ctx context.Context, appID, codeDir string) (string, error) {
+	if m.buildSvc == nil {
+		return "", fmt.Errorf("build service not configured")
+	}
+
+	metadata, err := m.metadataSvc.GetAppMetadata(ctx, appID)
+	if err != nil {
+		return "", err
+	}
+
+	image, err := m.buildSvc.BuildImage(ctx, appID, codeDir, metadata.CommitSHA, metadata.RunnerEnv)
+	if err != nil {
+		return "", fmt.Errorf("failed to build image: %w", err)
+	}
+
+	metadata.Image = image.Ref
+	if err := m.metadataSvc.UpdateAppMetadata(ctx, appID, metadata); err != nil {
+		logrus.Warnf("Failed to record image for application %s: %v", appID, err)
+	}
+	return image.Ref, nil
+}
+
 func (m *Manager) GetApplicationDAGs(ctx context.Context, appID string) (map[string]*task.DAG, error) {
 	return m.platform.GetDAGs(appID)
 }
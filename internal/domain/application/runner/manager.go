@@ -105,10 +105,22 @@ func (m *Manager) updateRunnerStatus(ctx context.Context, appID string, runner *
 	// 如果容器未运行
 	if !inspect.State.Running {
 		if inspect.State.Status == "exited" {
-			// 容器已退出
-			if currentStatus != types.RunnerStatusStopped && currentStatus != types.RunnerStatusStopping {
+			// 容器已退出：runner 以应用的退出码退出，runner 自身故障时退出码为 runnerFailureExitCode
+			exitCode := inspect.State.ExitCode
+			settled := currentStatus == types.RunnerStatusStopped || currentStatus == types.RunnerStatusStopping ||
+				currentStatus == types.RunnerStatusFailed
+			switch {
+			case settled:
+				// 已记录过退出，不重复更新
+			case exitCode == 0:
 				m.updateStatus(runner, types.RunnerStatusStopped)
 				logrus.Infof("Container %s for app %s has exited", runner.containerID, appID)
+			case exitCode == runnerFailureExitCode:
+				m.updateStatus(runner, types.RunnerStatusFailed)
+				logrus.Errorf("Runner in container %s for app %s failed (exit code %d)", runner.containerID, appID, exitCode)
+			default:
+				m.updateStatus(runner, types.RunnerStatusFailed)
+				logrus.Warnf("Application %s failed with exit code %d (container %s)", appID, exitCode, runner.containerID)
 			}
 		} else {
 			// 其他状态（如 created, removing 等）
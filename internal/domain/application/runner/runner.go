@@ -13,6 +13,10 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// runnerFailureExitCode runner 自身故障（而非应用失败）时容器的退出码，
+// 与 containers/images/runner 中的 EXIT_RUNNER_FAILURE 保持一致
+const runnerFailureExitCode = 125
+
 type EnvVars struct {
 	IgnisPort     int
 	LoggerPort    int
@@ -0,0 +1,303 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/application/types"
+	"github.com/9triver/iarnet/internal/domain/ignis/controller"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// defaultSLOWindow 未指定统计窗口时使用的窗口
+	defaultSLOWindow = 5 * time.Minute
+	// minSLOLatencySamples 窗口内调用数少于该值时不评估延迟目标，避免少量调用的抖动触发迁移
+	minSLOLatencySamples = 10
+	// maxSLOLatencySamples 每个应用保留的最近调用延迟样本数
+	maxSLOLatencySamples = 4096
+)
+
+// SLOViolationHandler 处理 SLO 违反，应用处于违反状态时每次评估（随状态校正周期）都会调用
+type SLOViolationHandler func(ctx context.Context, violation types.SLOViolation)
+
+// latencySample 一次函数调用的延迟
+type latencySample struct {
+	at          time.Time
+	componentID string
+	latency     time.Duration
+}
+
+// availabilitySample 一次状态校正时健康的 component 数
+type availabilitySample struct {
+	at      time.Time
+	healthy int
+	total   int
+}
+
+// sloTracker 记录设置了 SLO 的应用的调用延迟与可用率样本，以及当前处于违反状态的目标
+type sloTracker struct {
+	mu           sync.Mutex
+	latency      map[types.AppID][]latencySample
+	availability map[types.AppID][]availabilitySample
+	violated     map[types.AppID]map[types.SLOViolationKind]bool
+	handlers     []SLOViolationHandler
+}
+
+func newSLOTracker() *sloTracker {
+	return &sloTracker{
+		latency:      make(map[types.AppID][]latencySample),
+		availability: make(map[types.AppID][]availabilitySample),
+		violated:     make(map[types.AppID]map[types.SLOViolationKind]bool),
+	}
+}
+
+func (t *sloTracker) forget(appID types.AppID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.latency, appID)
+	delete(t.availability, appID)
+	delete(t.violated, appID)
+}
+
+// ValidateSLO 校验 SLO：至少设置一项目标，可用率在 0-1 之间，窗口不短于状态校正间隔
+func ValidateSLO(slo *types.SLO) error {
+	if slo.LatencyP95 < 0 || slo.Window < 0 {
+		return fmt.Errorf("latency target and window must not be negative")
+	}
+	if slo.MinAvailability < 0 || slo.MinAvailability > 1 {
+		return fmt.Errorf("min availability must be between 0 and 1")
+	}
+	if slo.LatencyP95 == 0 && slo.MinAvailability == 0 {
+		return fmt.Errorf("SLO must set a latency or availability target")
+	}
+	if slo.Window != 0 && slo.Window < statusReconcileInterval {
+		return fmt.Errorf("SLO window must be at least %v", statusReconcileInterval)
+	}
+	return nil
+}
+
+// SetSLO 设置应用的 SLO，slo 为 nil 时移除；移除或修改后重新开始统计
+func (m *Manager) SetSLO(ctx context.Context, appID string, slo *types.SLO) error {
+	if slo != nil {
+		if err := ValidateSLO(slo); err != nil {
+			return err
+		}
+	}
+	metadata, err := m.metadataSvc.GetAppMetadata(ctx, appID)
+	if err != nil {
+		return err
+	}
+	if metadata.ID == "" {
+		return fmt.Errorf("application not found: %s", appID)
+	}
+	metadata.SLO = slo
+	if err := m.metadataSvc.UpdateAppMetadata(ctx, appID, metadata); err != nil {
+		return err
+	}
+	m.slo.forget(appID)
+
+	reason := "SLO removed"
+	if slo != nil {
+		reason = fmt.Sprintf("SLO set: p95 latency %v, min availability %.4f", slo.LatencyP95, slo.MinAvailability)
+	}
+	m.lifecycle.record(appID, types.AppEvent{Time: time.Now(), Reason: reason})
+	return nil
+}
+
+// GetSLOStatus 获取应用在当前窗口内的 SLO 达成情况，应用未设置 SLO 时返回 nil
+func (m *Manager) GetSLOStatus(ctx context.Context, appID string) (*types.SLOStatus, error) {
+	metadata, err := m.metadataSvc.GetAppMetadata(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	if metadata.ID == "" {
+		return nil, fmt.Errorf("application not found: %s", appID)
+	}
+	if metadata.SLO == nil {
+		return nil, nil
+	}
+	return m.slo.evaluate(appID, *metadata.SLO, time.Now()), nil
+}
+
+// OnSLOViolation 注册 SLO 违反的处理函数，用于将违反信号传递给扩缩容与再平衡
+func (m *Manager) OnSLOViolation(handler SLOViolationHandler) {
+	if handler == nil {
+		return
+	}
+	m.slo.mu.Lock()
+	defer m.slo.mu.Unlock()
+	m.slo.handlers = append(m.slo.handlers, handler)
+}
+
+// handleInvocationCompleted 记录设置了 SLO 的应用的调用延迟
+func (m *Manager) handleInvocationCompleted(ctx context.Context, event controller.Event) {
+	e, ok := event.(*controller.InvocationCompletedEvent)
+	if !ok {
+		return
+	}
+	metadata, err := m.metadataSvc.GetAppMetadata(ctx, e.AppID)
+	if err != nil || metadata.SLO == nil || metadata.SLO.LatencyP95 == 0 {
+		return
+	}
+
+	m.slo.mu.Lock()
+	defer m.slo.mu.Unlock()
+	samples := append(m.slo.latency[e.AppID], latencySample{at: time.Now(), componentID: e.ComponentID, latency: e.Latency})
+	if len(samples) > maxSLOLatencySamples {
+		samples = samples[len(samples)-maxSLOLatencySamples:]
+	}
+	m.slo.latency[e.AppID] = samples
+}
+
+// enforceSLO 记录本次状态校正的可用率样本并评估 SLO：
+// 违反状态变化时记录生命周期事件，处于违反状态时调用已注册的处理函数
+func (m *Manager) enforceSLO(ctx context.Context, appID string, slo types.SLO, components []types.ComponentStatus) {
+	now := time.Now()
+	sample := availabilitySample{at: now}
+	for _, c := range components {
+		switch c.State {
+		case types.ComponentStateRunning:
+			sample.healthy++
+			sample.total++
+		case types.ComponentStateUnhealthy:
+			sample.total++
+		}
+	}
+
+	m.slo.mu.Lock()
+	if sample.total > 0 {
+		m.slo.availability[appID] = append(m.slo.availability[appID], sample)
+	}
+	m.slo.mu.Unlock()
+
+	status := m.slo.evaluate(appID, slo, now)
+	for i := range status.Violations {
+		if status.Violations[i].Kind != types.SLOViolationAvailability {
+			continue
+		}
+		for _, c := range components {
+			if c.State == types.ComponentStateUnhealthy {
+				status.Violations[i].ComponentIDs = append(status.Violations[i].ComponentIDs, c.ComponentID)
+			}
+		}
+	}
+
+	m.slo.mu.Lock()
+	previous := m.slo.violated[appID]
+	current := make(map[types.SLOViolationKind]bool, len(status.Violations))
+	for _, v := range status.Violations {
+		current[v.Kind] = true
+	}
+	m.slo.violated[appID] = current
+	handlers := append([]SLOViolationHandler(nil), m.slo.handlers...)
+	m.slo.mu.Unlock()
+
+	for _, v := range status.Violations {
+		if !previous[v.Kind] {
+			logrus.Warnf("Application %s violates its SLO: %s", appID, v.Reason)
+			m.lifecycle.record(appID, types.AppEvent{Time: now, Reason: "SLO violated: " + v.Reason})
+		}
+		for _, handler := range handlers {
+			handler(ctx, v)
+		}
+	}
+	for kind := range previous {
+		if !current[kind] {
+			logrus.Infof("Application %s meets its %s SLO again", appID, kind)
+			m.lifecycle.record(appID, types.AppEvent{Time: now, Reason: fmt.Sprintf("SLO restored: %s", kind)})
+		}
+	}
+}
+
+// evaluate 清理窗口之外的样本并计算窗口内的 p95 延迟与可用率
+func (t *sloTracker) evaluate(appID string, slo types.SLO, now time.Time) *types.SLOStatus {
+	window := slo.Window
+	if window == 0 {
+		window = defaultSLOWindow
+	}
+	cutoff := now.Add(-window)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	latency := t.latency[appID]
+	for len(latency) > 0 && latency[0].at.Before(cutoff) {
+		latency = latency[1:]
+	}
+	t.latency[appID] = latency
+	availability := t.availability[appID]
+	for len(availability) > 0 && availability[0].at.Before(cutoff) {
+		availability = availability[1:]
+	}
+	t.availability[appID] = availability
+
+	status := &types.SLOStatus{
+		SLO:          slo,
+		Invocations:  len(latency),
+		Availability: 1,
+		EvaluatedAt:  now,
+	}
+
+	all := make([]time.Duration, 0, len(latency))
+	byComponent := make(map[string][]time.Duration)
+	for _, s := range latency {
+		all = append(all, s.latency)
+		if s.componentID != "" {
+			byComponent[s.componentID] = append(byComponent[s.componentID], s.latency)
+		}
+	}
+	status.LatencyP95 = p95(all)
+	if slo.LatencyP95 > 0 && len(all) >= minSLOLatencySamples && status.LatencyP95 > slo.LatencyP95 {
+		// 优先指向自身 p95 超标的 component，没有时整个应用的 component 都视为相关
+		var slow, observed []string
+		for id, samples := range byComponent {
+			observed = append(observed, id)
+			if p95(samples) > slo.LatencyP95 {
+				slow = append(slow, id)
+			}
+		}
+		if len(slow) == 0 {
+			slow = observed
+		}
+		slices.Sort(slow)
+		status.Violations = append(status.Violations, types.SLOViolation{
+			AppID:        appID,
+			Kind:         types.SLOViolationLatency,
+			Reason:       fmt.Sprintf("p95 latency %v exceeds %v over %d invocation(s)", status.LatencyP95, slo.LatencyP95, len(all)),
+			ComponentIDs: slow,
+		})
+	}
+
+	var healthy, total int
+	for _, s := range availability {
+		healthy += s.healthy
+		total += s.total
+	}
+	if total > 0 {
+		status.Availability = float64(healthy) / float64(total)
+	}
+	if slo.MinAvailability > 0 && status.Availability < slo.MinAvailability {
+		status.Violations = append(status.Violations, types.SLOViolation{
+			AppID:  appID,
+			Kind:   types.SLOViolationAvailability,
+			Reason: fmt.Sprintf("availability %.4f is below %.4f", status.Availability, slo.MinAvailability),
+		})
+	}
+	return status
+}
+
+// p95 按最近秩法计算第 95 百分位，没有样本时返回 0
+func p95(samples []time.Duration) time.Duration {
+	if len(samples) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(samples)
+	slices.Sort(sorted)
+	rank := int(math.Ceil(0.95*float64(len(sorted)))) - 1
+	return sorted[max(rank, 0)]
+}
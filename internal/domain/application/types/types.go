@@ -20,8 +20,46 @@ const (
 	AppStatusUndeployed AppStatus = "idle"      // 未部署
 	AppStatusDeploying  AppStatus = "deploying" // 部署中
 	AppStatusCloning    AppStatus = "cloning"   // 克隆中
+	AppStatusBuilding   AppStatus = "building"  // 构建中
+	AppStatusPending    AppStatus = "pending"   // 已创建，等待准备
+	AppStatusDegraded   AppStatus = "degraded"  // 运行中但部分 component 不健康
 )
 
+// ComponentState 应用 component 的状态，由所在 provider 的状态推导
+type ComponentState string
+
+const (
+	ComponentStateDeploying ComponentState = "deploying" // 尚未分配 provider
+	ComponentStateRunning   ComponentState = "running"   // provider 正常
+	ComponentStateUnhealthy ComponentState = "unhealthy" // provider 已断开或不可用
+)
+
+// ComponentStatus 应用中单个 component 的状态
+type ComponentStatus struct {
+	ComponentID string
+	ActorID     string
+	Function    string // 所属函数（actor 组名）
+	ProviderID  string
+	State       ComponentState
+}
+
+// AppEvent 应用生命周期事件：状态迁移或 component 状态变化
+type AppEvent struct {
+	Time        time.Time
+	From        AppStatus
+	To          AppStatus
+	ComponentID string // component 状态变化时填写
+	Reason      string
+}
+
+// AppStatusReport 应用状态报告，包含 component 明细和最近的事件
+type AppStatusReport struct {
+	AppID      AppID
+	Status     AppStatus
+	Components []ComponentStatus
+	Events     []AppEvent
+}
+
 type AppMetadata struct {
 	ID            AppID
 	Name          string
@@ -34,6 +72,44 @@ type AppMetadata struct {
 	ExecuteCmd    string
 	EnvInstallCmd string
 	RunnerEnv     string
+	BuildCmd      string // 构建命令（可选），设置后运行前会先构建产物
+	CommitSHA     string // 当前工作空间检出的提交 SHA
+	BuildImage    bool   // 是否将源码构建为 OCI 镜像后部署
+	Image         string // 最近一次构建出的镜像引用
+	SLO           *SLO   // 服务等级目标，nil 表示未设置
+}
+
+// SLO 应用的服务等级目标，目标为 0 表示不约束该项
+type SLO struct {
+	LatencyP95      time.Duration // 函数调用 p95 延迟上限
+	MinAvailability float64       // 可用率下限（0-1），按窗口内健康的 component 占比计算
+	Window          time.Duration // 统计窗口，0 表示默认窗口
+}
+
+// SLOViolationKind SLO 违反的类型
+type SLOViolationKind string
+
+const (
+	SLOViolationLatency      SLOViolationKind = "latency"
+	SLOViolationAvailability SLOViolationKind = "availability"
+)
+
+// SLOViolation 一次评估中发现的 SLO 违反
+type SLOViolation struct {
+	AppID        AppID
+	Kind         SLOViolationKind
+	Reason       string
+	ComponentIDs []string // 导致违反的 component：延迟超标或不健康的 component
+}
+
+// SLOStatus 应用在当前窗口内的 SLO 达成情况
+type SLOStatus struct {
+	SLO          SLO
+	LatencyP95   time.Duration // 窗口内调用延迟的 p95，没有样本时为 0
+	Invocations  int           // 窗口内的调用数
+	Availability float64       // 窗口内的可用率，没有样本时为 1
+	Violations   []SLOViolation
+	EvaluatedAt  time.Time
 }
 
 type RunnerEnv = string
@@ -57,3 +133,105 @@ const (
 )
 
 type AppID = string
+
+type JobID = string
+
+// JobState 批处理任务（Job）的状态
+type JobState string
+
+const (
+	JobStatePending   JobState = "pending"   // 已提交，尚未启动任何 component
+	JobStateRunning   JobState = "running"   // 有 component 在运行或等待重试
+	JobStateSucceeded JobState = "succeeded" // 成功完成次数达到 completions
+	JobStateFailed    JobState = "failed"    // 失败次数超过 backoff limit
+	JobStateCancelled JobState = "cancelled" // 被取消
+)
+
+// JobSpec run-to-completion 批处理任务的定义
+// 每次执行部署一个 component，component 退出码为 0 视为一次成功完成
+type JobSpec struct {
+	Name         string
+	RuntimeEnv   string // component 运行环境，决定使用的镜像
+	CPU          int64  // millicores
+	Memory       int64  // bytes
+	GPU          int64
+	Env          map[string]string // 注入 component 的额外环境变量
+	Completions  int               // 需要成功完成的次数
+	Parallelism  int               // 同时运行的 component 数上限
+	BackoffLimit int               // 允许的失败次数，超过后 Job 失败
+}
+
+// JobAttemptState 单次执行的状态
+type JobAttemptState string
+
+const (
+	JobAttemptRunning   JobAttemptState = "running"
+	JobAttemptSucceeded JobAttemptState = "succeeded"
+	JobAttemptFailed    JobAttemptState = "failed"
+)
+
+// JobAttempt Job 的一次执行，对应一个 component
+type JobAttempt struct {
+	Index       int    // 完成序号，失败后以相同序号重试
+	ComponentID string // 部署失败时为空
+	State       JobAttemptState
+	ExitCode    int32
+	Reason      string
+	StartedAt   time.Time
+	FinishedAt  time.Time
+}
+
+// JobStatus Job 的状态与执行记录
+type JobStatus struct {
+	ID          JobID
+	AppID       AppID
+	CronJobID   CronJobID // 由 CronJob 触发时填写
+	Spec        JobSpec
+	State       JobState
+	Active      int
+	Succeeded   int
+	Failed      int
+	Message     string
+	Attempts    []JobAttempt
+	CreatedAt   time.Time
+	CompletedAt time.Time // 进入终止状态的时间
+}
+
+// IsFinished Job 是否已进入终止状态
+func (s *JobStatus) IsFinished() bool {
+	return s.State == JobStateSucceeded || s.State == JobStateFailed || s.State == JobStateCancelled
+}
+
+type CronJobID = string
+
+// ConcurrencyPolicy CronJob 触发时上一次的 Job 仍在运行的处理方式
+type ConcurrencyPolicy string
+
+const (
+	ConcurrencyAllow   ConcurrencyPolicy = "allow"   // 允许同时运行
+	ConcurrencyForbid  ConcurrencyPolicy = "forbid"  // 跳过本次触发
+	ConcurrencyReplace ConcurrencyPolicy = "replace" // 取消运行中的 Job 后启动新的 Job
+)
+
+// CronJobSpec 按 cron 表达式周期触发的 Job
+type CronJobSpec struct {
+	Name                       string
+	Schedule                   string // 5 字段 cron 表达式：分 时 日 月 周
+	Timezone                   string // IANA 时区，为空表示本地时区
+	ConcurrencyPolicy          ConcurrencyPolicy
+	Suspend                    bool // 暂停后不再触发，已触发的 Job 不受影响
+	SuccessfulJobsHistoryLimit int  // 保留的已成功 Job 数
+	FailedJobsHistoryLimit     int  // 保留的已失败或已取消 Job 数
+	JobTemplate                JobSpec
+}
+
+// CronJobStatus CronJob 的定义与触发记录
+type CronJobStatus struct {
+	ID               CronJobID
+	AppID            AppID
+	Spec             CronJobSpec
+	LastScheduleTime time.Time
+	NextScheduleTime time.Time // 暂停时为零值
+	ActiveJobs       []JobID
+	CreatedAt        time.Time
+}
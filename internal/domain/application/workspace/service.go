@@ -15,6 +15,8 @@ type Service interface {
 	// Git 仓库管理
 	CloneRepository(ctx context.Context, appID, gitURL, branch string) (string, error)
 	PullRepository(ctx context.Context, appID string) error
+	// GetHeadCommit 获取工作空间当前检出的提交 SHA
+	GetHeadCommit(ctx context.Context, appID string) (string, error)
 
 	// 文件操作
 	GetFileTree(ctx context.Context, appID, path string) ([]types.FileInfo, error)
@@ -84,6 +86,17 @@ func (s *service) PullRepository(ctx context.Context, appID string) error {
 	return workspace.PullRepository()
 }
 
+// GetHeadCommit 获取工作空间当前检出的提交 SHA
+func (s *service) GetHeadCommit(ctx context.Context, appID string) (string, error) {
+	workspace, err := s.manager.Get(appID)
+	if err != nil {
+		return "", err
+	}
+
+	// 委托给领域对象解析提交
+	return workspace.HeadCommit()
+}
+
 // GetFileTree 获取文件树
 func (s *service) GetFileTree(ctx context.Context, appID, path string) ([]types.FileInfo, error) {
 	workspace, err := s.manager.Get(appID)
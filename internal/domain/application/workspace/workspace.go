@@ -45,9 +45,14 @@ func (w *Workspace) CloneRepository(gitURL, branch string) error {
 	return w.cloneFromGit(gitURL, branch)
 }
 
-func (w *Workspace) cloneFromGit(gitURL, branch string) error {
+func (w *Workspace) cloneFromGit(gitURL, ref string) error {
+	// ref 为提交 SHA 时无法通过 -b 指定，需要完整克隆后再检出
+	if isCommitSHA(ref) {
+		return w.cloneAndCheckout(gitURL, ref)
+	}
+
 	// 执行 git clone
-	cmd := exec.Command("git", "clone", "-b", branch, "--single-branch", gitURL, w.dir)
+	cmd := exec.Command("git", "clone", "-b", ref, "--single-branch", gitURL, w.dir)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
@@ -60,6 +65,52 @@ func (w *Workspace) cloneFromGit(gitURL, branch string) error {
 	return nil
 }
 
+func (w *Workspace) cloneAndCheckout(gitURL, commit string) error {
+	cmd := exec.Command("git", "clone", gitURL, w.dir)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		os.RemoveAll(w.dir)
+		return fmt.Errorf("failed to clone repository: %v", err)
+	}
+
+	cmd = exec.Command("git", "checkout", "--detach", commit)
+	cmd.Dir = w.dir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		os.RemoveAll(w.dir)
+		return fmt.Errorf("failed to checkout commit %s: %v", commit, err)
+	}
+
+	logrus.Infof("Successfully cloned repository to %s at commit %s", w.dir, commit)
+	return nil
+}
+
+// isCommitSHA 判断 ref 是否为（可能缩写的）提交 SHA
+func isCommitSHA(ref string) bool {
+	if len(ref) < 7 || len(ref) > 40 {
+		return false
+	}
+	for _, c := range ref {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// HeadCommit 获取工作空间当前检出的提交 SHA
+func (w *Workspace) HeadCommit() (string, error) {
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = w.dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve head commit: %v", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
 func (w *Workspace) cloneFromTestRepo() error {
 	sourceDir, err := filepath.Abs(testRepoDir)
 	if err != nil {
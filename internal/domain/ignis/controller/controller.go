@@ -6,9 +6,13 @@ import (
 	"fmt"
 	"maps"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/9triver/iarnet/internal/domain/ignis/task"
+	"github.com/9triver/iarnet/internal/domain/resource/accounting"
 	"github.com/9triver/iarnet/internal/domain/resource/component"
+	"github.com/9triver/iarnet/internal/domain/resource/provider"
 	"github.com/9triver/iarnet/internal/domain/resource/store"
 	resourceTypes "github.com/9triver/iarnet/internal/domain/resource/types"
 	commonpb "github.com/9triver/iarnet/internal/proto/common"
@@ -25,27 +29,99 @@ type Controller struct {
 	storeService     store.Service
 	functions        map[string]*task.Function // functionName -> Function
 	dags             map[string]*task.DAG      // sessionID -> DAG
+
+	// 池化管理使用的状态
+	mu         sync.RWMutex
+	createdAt  time.Time
+	lastActive time.Time
+	closed     bool
+}
+
+// Info 控制器概要信息
+type Info struct {
+	AppID      string
+	Functions  int
+	Actors     int
+	Sessions   int  // DAG 数量（每个客户端 session 一个 DAG）
+	Connected  bool // 是否有活跃的客户端连接
+	CreatedAt  time.Time
+	LastActive time.Time
 }
 
 func NewController(componentService component.Service, storeService store.Service, appID string) *Controller {
+	now := time.Now()
 	return &Controller{
 		appID:            appID,
 		componentService: componentService,
 		storeService:     storeService,
 		functions:        make(map[string]*task.Function),
 		dags:             make(map[string]*task.DAG),
+		createdAt:        now,
+		lastActive:       now,
+	}
+}
+
+// Info 返回控制器概要信息
+func (c *Controller) Info() Info {
+	actors := 0
+	for _, function := range c.functions {
+		actors += len(function.GetActors())
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return Info{
+		AppID:      c.appID,
+		Functions:  len(c.functions),
+		Actors:     actors,
+		Sessions:   len(c.dags),
+		Connected:  c.toClientChan != nil,
+		CreatedAt:  c.createdAt,
+		LastActive: c.lastActive,
 	}
 }
 
+// touch 记录最近一次使用时间，池满时优先回收最久未使用的控制器
+func (c *Controller) touch() {
+	c.mu.Lock()
+	c.lastActive = time.Now()
+	c.mu.Unlock()
+}
+
+// LastActive 最近一次使用时间
+func (c *Controller) LastActive() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lastActive
+}
+
+// Close 关闭控制器，仍在进行的客户端会话会在处理下一条消息时结束
+// 已部署的 component 不在此处回收
+func (c *Controller) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+}
+
+// Closed 控制器是否已关闭
+func (c *Controller) Closed() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.closed
+}
+
 func (c *Controller) GetDAGs() map[string]*task.DAG {
 	return c.dags
 }
 
 func (c *Controller) SetToClientChan(toClientChan chan *ctrlpb.Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.toClientChan = toClientChan
 }
 
 func (c *Controller) ClearToClientChan() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.toClientChan = nil
 }
 
@@ -54,11 +130,18 @@ func (c *Controller) SetEvents(events *EventHub) {
 }
 
 func (c *Controller) GetToClientChan() chan *ctrlpb.Message {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.toClientChan
 }
 
 func (c *Controller) AppID() string { return c.appID }
 
+// storeContext 以应用身份访问 store，使对象归属于应用并可被应用内的 component 读取
+func (c *Controller) storeContext(ctx context.Context) context.Context {
+	return store.WithPrincipal(ctx, store.Principal{AppID: c.appID})
+}
+
 // HandleActorMessage 处理 Actor 消息
 func (c *Controller) HandleActorMessage(ctx context.Context, msg *actorpb.Message) error {
 	switch m := msg.GetMessage().(type) {
@@ -77,7 +160,18 @@ func (c *Controller) handleInvokeResponse(ctx context.Context, m *actorpb.Invoke
 		logrus.Errorf("Function not found for function name %s", functionName)
 		return fmt.Errorf("Function not found for function name %s", functionName)
 	}
-	function.Done(ctx, m.RuntimeID, m.Info)
+	if actor, latency, err := function.Done(ctx, m.RuntimeID, m.Info); err == nil {
+		event := &InvocationCompletedEvent{
+			AppID:    c.appID,
+			Function: functionName,
+			ActorID:  actor.GetID(),
+			Latency:  latency,
+		}
+		if comp := actor.GetComponent(); comp != nil {
+			event.ComponentID = comp.GetID()
+		}
+		c.emit(ctx, event)
+	}
 	logrus.WithFields(logrus.Fields{"function": functionName, "runtime": m.RuntimeID}).Info("control: invoke response")
 
 	dag, ok := c.dags[sessionID]
@@ -158,7 +252,7 @@ func (c *Controller) handleAppendData(ctx context.Context, m *ctrlpb.AppendData)
 	}
 
 	go func() {
-		resp, err := c.storeService.SaveObject(ctx, m.Object)
+		resp, err := c.storeService.SaveObject(c.storeContext(ctx), m.Object)
 		if err != nil {
 			logrus.Errorf("Failed to save object: %v", err)
 			dataNode.Status = task.DAGNodeStatusFailed
@@ -189,15 +283,30 @@ func (c *Controller) handleAppendPyFunc(ctx context.Context, m *ctrlpb.AppendPyF
 		Memory: int64(m.GetResources().GetMemory()),
 		GPU:    int64(m.GetResources().GetGPU()),
 		Tags:   append([]string(nil), m.GetTags()...),
+		Stream: m.GetStream(),
+	}
+	// 每个副本启动前都预置相同的数据、挂载相同的卷
+	dataSources := provider.DataSourcesFromProto(m.GetData())
+	if err := provider.ValidateDataSources(dataSources); err != nil {
+		return fmt.Errorf("invalid data sources for function %s: %w", m.GetName(), err)
+	}
+	volumes := provider.VolumeMountsFromProto(m.GetVolumes())
+	if err := provider.ValidateVolumeMounts(volumes); err != nil {
+		return fmt.Errorf("invalid volumes for function %s: %w", m.GetName(), err)
+	}
+	deployCtx := provider.WithDataSources(accounting.WithApplication(ctx, c.appID), dataSources)
+	deployCtx = provider.WithVolumes(deployCtx, volumes)
+	if sc := provider.SecurityContextFromProto(m.GetSecurity()); sc != nil {
+		if err := sc.Validate(); err != nil {
+			return fmt.Errorf("invalid security context for function %s: %w", m.GetName(), err)
+		}
+		deployCtx = provider.WithSecurityContext(deployCtx, sc)
 	}
 
 	for i := 0; i < replicas; i++ {
 		actorName := fmt.Sprintf("%s-%d", m.GetName(), i)
 		logrus.Infof("Deploying component for actor %s", actorName)
-		component, err := c.componentService.DeployComponent(
-			ctx, resourceTypes.RuntimeEnvPython,
-			resourceReq,
-		)
+		component, err := c.componentService.DeployComponent(deployCtx, resourceTypes.RuntimeEnvPython, resourceReq)
 		if err != nil {
 			logrus.Errorf("Failed to deploy component: %v", err)
 			return err
@@ -205,15 +314,22 @@ func (c *Controller) handleAppendPyFunc(ctx context.Context, m *ctrlpb.AppendPyF
 		logrus.Infof("Component deployed successfully: %s", component.GetID())
 
 		actor := task.NewActor(actorName, component)
-		actor.Send(&actorpb.Function{
+		function := &actorpb.Function{
 			Name:          m.GetName(),
 			Params:        m.GetParams(),
 			Requirements:  m.GetRequirements(),
 			PickledObject: m.GetPickledObject(),
 			Language:      m.GetLanguage(),
-		})
+		}
+		actor.Send(function)
 		logrus.Infof("Function sent to actor: %s", actor.GetID())
 
+		// component 被驱逐并重新调度后，需要重新下发函数定义
+		component.OnRescheduled(func() {
+			logrus.Infof("Component %s rescheduled, resending function to actor %s", component.GetID(), actor.GetID())
+			actor.Send(function)
+		})
+
 		go func() {
 			for {
 				msg := actor.Receive(ctx)
@@ -274,7 +390,7 @@ func (c *Controller) handleAppendArg(ctx context.Context, m *ctrlpb.AppendArg) e
 	case *ctrlpb.Data_Encoded:
 		logrus.WithFields(logrus.Fields{"id": v.Encoded.ID, "session": m.SessionID, "instance": m.InstanceID}).Info("control: append encoded arg")
 		go func() {
-			resp, err := c.storeService.SaveObject(ctx, v.Encoded)
+			resp, err := c.storeService.SaveObject(c.storeContext(ctx), v.Encoded)
 			if err != nil {
 				logrus.Errorf("Failed to save object: %v", err)
 				ret := ctrlpb.NewReturnResult(m.SessionID, m.InstanceID, m.Name, nil, err)
@@ -468,7 +584,7 @@ func (c *Controller) handleAppendDataNode(ctx context.Context, dag *task.DAG, pb
 
 func (c *Controller) handleRequestObject(ctx context.Context, m *ctrlpb.RequestObject) error {
 	logrus.WithFields(logrus.Fields{"id": m.ID, "source": m.Source}).Info("control: request object")
-	object, err := c.storeService.GetObject(ctx, &commonpb.ObjectRef{
+	object, err := c.storeService.GetObject(c.storeContext(ctx), &commonpb.ObjectRef{
 		ID:     m.ID,
 		Source: m.Source,
 	})
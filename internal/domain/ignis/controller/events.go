@@ -3,12 +3,14 @@ package controller
 import (
 	"context"
 	"sync"
+	"time"
 )
 
 type EventType string
 
 const (
 	EventTypeDAGNodeStatusChanged EventType = "dag_node_status_changed"
+	EventTypeInvocationCompleted  EventType = "invocation_completed"
 )
 
 type Event interface {
@@ -70,3 +72,17 @@ type DAGNodeStatusChangedEvent struct {
 func (e *DAGNodeStatusChangedEvent) Type() EventType {
 	return EventTypeDAGNodeStatusChanged
 }
+
+// InvocationCompletedEvent 一次函数调用收到响应，用于统计应用的调用延迟
+type InvocationCompletedEvent struct {
+	AppID       string
+	Function    string
+	ActorID     string
+	ComponentID string        // 执行调用的 component，actor 尚未绑定 component 时为空
+	Latency     time.Duration // 从发送调用到收到响应的总延迟
+}
+
+// Type 实现 Event 接口。
+func (e *InvocationCompletedEvent) Type() EventType {
+	return EventTypeInvocationCompleted
+}
@@ -3,6 +3,7 @@ package controller
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 
 	"github.com/9triver/iarnet/internal/domain/resource/component"
@@ -13,6 +14,21 @@ import (
 type Manager interface {
 	Add(controller *Controller) error
 	Get(appID string) *Controller
+	// Acquire 获取应用的控制器，不存在时通过工厂创建；池满时回收应用已停止或已结束的最久未使用的空闲控制器，
+	// 没有可回收的控制器时返回池满错误
+	Acquire(appID string) (*Controller, error)
+	// Remove 移除并关闭应用的控制器，控制器不存在时返回 false
+	Remove(appID string) bool
+	// List 列出所有控制器
+	List() []*Controller
+	// SetFactory 设置创建控制器的工厂
+	SetFactory(factory func(appID string) *Controller)
+	// SetMaxControllers 设置控制器数量上限，<= 0 表示不限制
+	SetMaxControllers(max int)
+	// SetAutoCreate 设置客户端连接未知应用时是否自动创建控制器
+	SetAutoCreate(autoCreate bool)
+	// SetEvictable 设置判断应用是否已停止或已结束的函数，池满时只回收这些应用的控制器；未设置时不回收
+	SetEvictable(evictable func(appID string) bool)
 	On(eventType EventType, handler EventHandler)
 	HandleSession(ctx context.Context, recv func() (*ctrlpb.Message, error), send func(*ctrlpb.Message) error) error
 }
@@ -22,6 +38,12 @@ type manager struct {
 	componentService component.Service
 	controllers      map[string]*Controller
 	events           *EventHub
+
+	// 控制器池
+	factory        func(appID string) *Controller
+	maxControllers int
+	autoCreate     bool
+	evictable      func(appID string) bool
 }
 
 func NewManager(componentService component.Service) Manager {
@@ -49,6 +71,106 @@ func (m *manager) Get(appID string) *Controller {
 	return m.controllers[appID]
 }
 
+func (m *manager) Acquire(appID string) (*Controller, error) {
+	if appID == "" {
+		return nil, errors.New("application id is empty")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if controller, ok := m.controllers[appID]; ok {
+		controller.touch()
+		return controller, nil
+	}
+	if m.factory == nil {
+		return nil, errors.New("controller factory not configured")
+	}
+	if m.maxControllers > 0 && len(m.controllers) >= m.maxControllers {
+		if !m.evictIdleLocked() {
+			return nil, fmt.Errorf("controller pool is full (%d controllers, none idle with a stopped or finished application)", len(m.controllers))
+		}
+	}
+
+	controller := m.factory(appID)
+	controller.SetEvents(m.events)
+	m.controllers[appID] = controller
+	logrus.Infof("Controller created for application %s (%d in pool)", appID, len(m.controllers))
+	return controller, nil
+}
+
+// evictIdleLocked 回收应用已停止或已结束、且没有活跃会话的最久未使用的控制器，调用方需持有写锁
+// 运行中应用的控制器保存着 DAG 与 actor 状态，即使暂时没有会话也不回收
+func (m *manager) evictIdleLocked() bool {
+	if m.evictable == nil {
+		return false
+	}
+	var victim *Controller
+	for _, controller := range m.controllers {
+		if controller.GetToClientChan() != nil || !m.evictable(controller.AppID()) {
+			continue
+		}
+		if victim == nil || controller.LastActive().Before(victim.LastActive()) {
+			victim = controller
+		}
+	}
+	if victim == nil {
+		return false
+	}
+	delete(m.controllers, victim.AppID())
+	victim.Close()
+	logrus.Infof("Controller for application %s evicted from pool (idle since %s)", victim.AppID(), victim.LastActive().Format("15:04:05"))
+	return true
+}
+
+func (m *manager) Remove(appID string) bool {
+	m.mu.Lock()
+	controller, ok := m.controllers[appID]
+	delete(m.controllers, appID)
+	m.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+	controller.Close()
+	logrus.Infof("Controller for application %s removed", appID)
+	return true
+}
+
+func (m *manager) List() []*Controller {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	controllers := make([]*Controller, 0, len(m.controllers))
+	for _, controller := range m.controllers {
+		controllers = append(controllers, controller)
+	}
+	return controllers
+}
+
+func (m *manager) SetFactory(factory func(appID string) *Controller) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.factory = factory
+}
+
+func (m *manager) SetMaxControllers(max int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.maxControllers = max
+}
+
+func (m *manager) SetAutoCreate(autoCreate bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.autoCreate = autoCreate
+}
+
+func (m *manager) SetEvictable(evictable func(appID string) bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.evictable = evictable
+}
+
 func (m *manager) On(eventType EventType, handler EventHandler) {
 	m.events.Subscribe(eventType, handler)
 }
@@ -73,11 +195,19 @@ func (m *manager) HandleSession(ctx context.Context, recv func() (*ctrlpb.Messag
 
 	m.mu.RLock()
 	controller = m.controllers[expectedAppID]
+	autoCreate := m.autoCreate
 	m.mu.RUnlock()
+	if controller == nil && autoCreate {
+		if controller, err = m.Acquire(expectedAppID); err != nil {
+			logrus.Errorf("failed to create controller for application %s: %v", expectedAppID, err)
+			return err
+		}
+	}
 	if controller == nil {
 		logrus.Errorf("controller not found")
 		return errors.New("controller not found")
 	}
+	controller.touch()
 
 	if controller.GetToClientChan() != nil {
 		logrus.Errorf("session already exists for application %s", expectedAppID)
@@ -157,6 +287,12 @@ func (m *manager) HandleSession(ctx context.Context, recv func() (*ctrlpb.Messag
 			return errors.New("controller app id mismatch")
 		}
 
+		if controller.Closed() {
+			logrus.Warnf("controller for application %s closed, ending session", expectedAppID)
+			return errors.New("controller closed for application " + expectedAppID)
+		}
+		controller.touch()
+
 		if err := controller.HandleClientMessage(ctx, msg); err != nil {
 			return err
 		}
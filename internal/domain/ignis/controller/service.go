@@ -3,15 +3,24 @@ package controller
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/9triver/iarnet/internal/domain/ignis/task"
+	"github.com/9triver/iarnet/internal/domain/resource/accounting"
 	"github.com/9triver/iarnet/internal/domain/resource/component"
 	"github.com/9triver/iarnet/internal/domain/resource/store"
 	ctrlpb "github.com/9triver/iarnet/internal/proto/ignis/controller"
+	"github.com/sirupsen/logrus"
 )
 
 type Service interface {
 	CreateController(ctx context.Context, appID string) (*Controller, error)
+	// AcquireController 获取应用的控制器，已存在时复用，否则从池中创建
+	AcquireController(ctx context.Context, appID string) (*Controller, error)
+	// ReleaseController 应用结束后回收其控制器
+	ReleaseController(ctx context.Context, appID string) bool
+	// ListControllers 列出池中所有控制器的概要信息，按应用 ID 排序
+	ListControllers() []Info
 	GetDAGs(appID string) (map[string]*task.DAG, error)
 	GetActors(appID string) (map[string][]*task.Actor, error)
 	Subscribe(eventType EventType, handler EventHandler)
@@ -25,11 +34,15 @@ type service struct {
 }
 
 func NewService(manager Manager, componentService component.Service, storeService store.Service) Service {
-	return &service{
+	s := &service{
 		manager:          manager,
 		componentService: componentService,
 		storeService:     storeService,
 	}
+	manager.SetFactory(func(appID string) *Controller {
+		return NewController(s.componentService, s.storeService, appID)
+	})
+	return s
 }
 
 func (s *service) CreateController(ctx context.Context, appID string) (*Controller, error) {
@@ -40,6 +53,30 @@ func (s *service) CreateController(ctx context.Context, appID string) (*Controll
 	return controller, nil
 }
 
+func (s *service) AcquireController(ctx context.Context, appID string) (*Controller, error) {
+	return s.manager.Acquire(appID)
+}
+
+func (s *service) ReleaseController(ctx context.Context, appID string) bool {
+	// 应用结束，其 component 不再计入资源占用
+	if closer, ok := s.componentService.(accounting.ApplicationUsageCloser); ok {
+		if err := closer.CloseApplicationUsage(ctx, appID); err != nil {
+			logrus.Warnf("Failed to close resource usage of application %s: %v", appID, err)
+		}
+	}
+	return s.manager.Remove(appID)
+}
+
+func (s *service) ListControllers() []Info {
+	controllers := s.manager.List()
+	infos := make([]Info, 0, len(controllers))
+	for _, controller := range controllers {
+		infos = append(infos, controller.Info())
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].AppID < infos[j].AppID })
+	return infos
+}
+
 func (s *service) GetDAGs(appID string) (map[string]*task.DAG, error) {
 	controller := s.manager.Get(appID)
 	if controller == nil {
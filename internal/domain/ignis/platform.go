@@ -24,6 +24,18 @@ func (p *Platform) CreateController(ctx context.Context, appID string) (*control
 	return p.controllerService.CreateController(ctx, appID)
 }
 
+func (p *Platform) AcquireController(ctx context.Context, appID string) (*controller.Controller, error) {
+	return p.controllerService.AcquireController(ctx, appID)
+}
+
+func (p *Platform) ReleaseController(ctx context.Context, appID string) bool {
+	return p.controllerService.ReleaseController(ctx, appID)
+}
+
+func (p *Platform) ListControllers() []controller.Info {
+	return p.controllerService.ListControllers()
+}
+
 func (p *Platform) GetDAGs(appID string) (map[string]*task.DAG, error) {
 	return p.controllerService.GetDAGs(appID)
 }
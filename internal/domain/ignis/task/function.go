@@ -88,19 +88,21 @@ func (f *Function) Invoke(ctx context.Context, runtimeID types.RuntimeID) error
 }
 
 // Complete 完成函数执行
-func (f *Function) Done(ctx context.Context, runtimeID types.RuntimeID, actorInfo *actorpb.ActorInfo) error {
+// 返回执行调用的 actor 及从发送调用到收到响应的总延迟
+func (f *Function) Done(ctx context.Context, runtimeID types.RuntimeID, actorInfo *actorpb.ActorInfo) (*Actor, time.Duration, error) {
 	runtime, ok := f.runtimes[runtimeID]
 	if !ok {
 		logrus.WithFields(logrus.Fields{"runtime": runtimeID}).Errorf("task: runtime not found")
-		return fmt.Errorf("runtime not found: %s", runtimeID)
+		return nil, 0, fmt.Errorf("runtime not found: %s", runtimeID)
 	}
+	latency := time.Since(runtime.invokeTime)
 	actor := runtime.Done(ctx, actorInfo)
 	if actor == nil {
 		logrus.WithFields(logrus.Fields{"runtime": runtimeID}).Errorf("task: actor not found")
-		return fmt.Errorf("actor not found: %s", runtimeID)
+		return nil, 0, fmt.Errorf("actor not found: %s", runtimeID)
 	}
 	f.group.Push(actor)
-	return nil
+	return actor, latency, nil
 }
 
 type Runtime struct {
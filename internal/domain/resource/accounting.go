@@ -0,0 +1,52 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/accounting"
+	"github.com/9triver/iarnet/internal/domain/resource/component"
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+	"github.com/sirupsen/logrus"
+)
+
+// SetAccounting 设置资源占用记账服务，nil 表示不记账
+func (m *Manager) SetAccounting(svc accounting.Service) {
+	m.accounting = svc
+}
+
+// startUsage 记录 component 开始占用资源，归属到 context 中的应用与本节点所在的域
+// 记账失败只记录警告，不影响部署结果
+func (m *Manager) startUsage(ctx context.Context, comp *component.Component, resourceRequest *types.Info) {
+	if m.accounting == nil {
+		return
+	}
+	usage := &accounting.Usage{
+		ComponentID: comp.GetID(),
+		AppID:       accounting.GetApplication(ctx),
+		DomainID:    m.domainID,
+		NodeID:      m.nodeID,
+		Target:      comp.GetProviderID(),
+		Resources:   resourceRequest,
+	}
+	if err := m.accounting.Start(ctx, usage); err != nil {
+		logrus.Warnf("Failed to record resource usage of component %s: %v", comp.GetID(), err)
+	}
+}
+
+// CloseApplicationUsage 结束应用所有 component 的资源占用记录，在应用的控制器回收时调用
+func (m *Manager) CloseApplicationUsage(ctx context.Context, appID string) error {
+	if m.accounting == nil {
+		return nil
+	}
+	return m.accounting.StopApplication(ctx, appID)
+}
+
+// GetAccountingReport 生成 [from, to) 期间按域、应用汇总的资源时长报表
+func (m *Manager) GetAccountingReport(ctx context.Context, from, to time.Time) (*accounting.Report, error) {
+	if m.accounting == nil {
+		return nil, fmt.Errorf("accounting is not enabled")
+	}
+	return m.accounting.Report(ctx, from, to)
+}
@@ -0,0 +1,188 @@
+// Package accounting 记录 component 的资源占用，按应用与域生成计费报表
+// component 部署成功时开始一段占用，所属应用的控制器回收时结束；报表按占用时长与申请的资源量折算资源时长
+package accounting
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+	accountingrepo "github.com/9triver/iarnet/internal/infra/repository/resource"
+	"github.com/9triver/iarnet/internal/util"
+	"github.com/sirupsen/logrus"
+)
+
+type applicationKey struct{}
+
+// WithApplication 在 context 中附加部署 component 的应用 ID，占用记录据此归属到应用
+func WithApplication(ctx context.Context, appID string) context.Context {
+	return context.WithValue(ctx, applicationKey{}, appID)
+}
+
+// GetApplication 获取 context 中的应用 ID
+func GetApplication(ctx context.Context) string {
+	appID, _ := ctx.Value(applicationKey{}).(string)
+	return appID
+}
+
+// Usage component 的一段资源占用
+type Usage struct {
+	ID          string
+	ComponentID string
+	AppID       string
+	DomainID    string
+	NodeID      string
+	Target      string      // component 所在位置，格式同 component 的 provider ID
+	Resources   *types.Info // 申请的资源量
+	StartedAt   time.Time
+	EndedAt     *time.Time // 为空表示仍在占用
+}
+
+// Service 资源占用记账服务
+type Service interface {
+	// Start 记录 component 开始占用资源
+	Start(ctx context.Context, usage *Usage) error
+	// StopApplication 结束应用所有 component 的占用
+	StopApplication(ctx context.Context, appID string) error
+	// Report 生成 [from, to) 期间的报表
+	Report(ctx context.Context, from, to time.Time) (*Report, error)
+	// Close 以当前时刻结束所有尚未结束的占用并关闭仓库，节点关闭时调用
+	Close() error
+}
+
+// ApplicationUsageCloser 能够结束应用资源占用记录的组件服务（e.g., resource.Manager）
+type ApplicationUsageCloser interface {
+	// CloseApplicationUsage 结束应用所有 component 的资源占用记录
+	CloseApplicationUsage(ctx context.Context, appID string) error
+}
+
+type service struct {
+	repo accountingrepo.AccountingRepo
+
+	mu   sync.Mutex
+	open map[string]*Usage // 占用记录 ID -> 尚未结束的占用
+}
+
+// NewService 创建记账服务
+// 上次运行异常退出时遗留的未结束记录对应的 component 已失去跟踪，以启动时刻结束
+func NewService(ctx context.Context, repo accountingrepo.AccountingRepo) (Service, error) {
+	stale, err := repo.GetOpenUsages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load open usages: %w", err)
+	}
+	now := time.Now()
+	for _, dao := range stale {
+		if err := repo.EndUsage(ctx, dao.ID, now); err != nil {
+			return nil, err
+		}
+	}
+	if len(stale) > 0 {
+		logrus.Warnf("Closed %d usage record(s) left open by previous run", len(stale))
+	}
+	return &service{
+		repo: repo,
+		open: make(map[string]*Usage),
+	}, nil
+}
+
+func (s *service) Start(ctx context.Context, usage *Usage) error {
+	if usage.ID == "" {
+		usage.ID = util.GenIDWith("usage.")
+	}
+	if usage.StartedAt.IsZero() {
+		usage.StartedAt = time.Now()
+	}
+	if usage.Resources == nil {
+		usage.Resources = &types.Info{}
+	}
+	if err := s.repo.CreateUsage(ctx, toDAO(usage)); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.open[usage.ID] = usage
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *service) StopApplication(ctx context.Context, appID string) error {
+	s.mu.Lock()
+	var stopping []*Usage
+	for id, usage := range s.open {
+		if usage.AppID == appID {
+			stopping = append(stopping, usage)
+			delete(s.open, id)
+		}
+	}
+	s.mu.Unlock()
+
+	now := time.Now()
+	for _, usage := range stopping {
+		usage.EndedAt = &now
+		if err := s.repo.EndUsage(ctx, usage.ID, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *service) Close() error {
+	s.mu.Lock()
+	open := s.open
+	s.open = make(map[string]*Usage)
+	s.mu.Unlock()
+
+	now := time.Now()
+	for _, usage := range open {
+		if err := s.repo.EndUsage(context.Background(), usage.ID, now); err != nil {
+			logrus.Warnf("Failed to close usage %s: %v", usage.ID, err)
+		}
+	}
+	return s.repo.Close()
+}
+
+func (s *service) Report(ctx context.Context, from, to time.Time) (*Report, error) {
+	if !from.Before(to) {
+		return nil, fmt.Errorf("report period is empty: from %s, to %s", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+	daos, err := s.repo.GetUsagesInRange(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	usages := make([]*Usage, 0, len(daos))
+	for _, dao := range daos {
+		usages = append(usages, fromDAO(dao))
+	}
+	return BuildReport(usages, from, to, time.Now()), nil
+}
+
+func toDAO(usage *Usage) *accountingrepo.UsageDAO {
+	return &accountingrepo.UsageDAO{
+		ID:          usage.ID,
+		ComponentID: usage.ComponentID,
+		AppID:       usage.AppID,
+		DomainID:    usage.DomainID,
+		NodeID:      usage.NodeID,
+		Target:      usage.Target,
+		CPU:         usage.Resources.CPU,
+		Memory:      usage.Resources.Memory,
+		GPU:         usage.Resources.GPU,
+		StartedAt:   usage.StartedAt,
+		EndedAt:     usage.EndedAt,
+	}
+}
+
+func fromDAO(dao *accountingrepo.UsageDAO) *Usage {
+	return &Usage{
+		ID:          dao.ID,
+		ComponentID: dao.ComponentID,
+		AppID:       dao.AppID,
+		DomainID:    dao.DomainID,
+		NodeID:      dao.NodeID,
+		Target:      dao.Target,
+		Resources:   &types.Info{CPU: dao.CPU, Memory: dao.Memory, GPU: dao.GPU},
+		StartedAt:   dao.StartedAt,
+		EndedAt:     dao.EndedAt,
+	}
+}
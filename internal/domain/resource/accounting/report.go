@@ -0,0 +1,120 @@
+package accounting
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Line 报表中一个应用在期间内的资源时长
+type Line struct {
+	DomainID      string
+	AppID         string
+	Components    int     // 期间内占用过资源的 component 数
+	CPUMs         float64 // CPU 核毫秒：1 核占用 1 秒计 1000
+	MemoryGBHours float64 // 内存 GiB 小时
+	GPUHours      float64 // GPU 卡小时
+}
+
+// Report 计费报表
+// 跨越期间边界的占用只计入期间内的部分，尚未结束的占用计算到生成报表时刻为止
+type Report struct {
+	From        time.Time
+	To          time.Time
+	GeneratedAt time.Time
+	Lines       []Line // 按域、应用排序
+	Total       Line
+}
+
+// MonthPeriod 返回 loc 时区下某月的起止时刻 [from, to)
+func MonthPeriod(year int, month time.Month, loc *time.Location) (time.Time, time.Time) {
+	from := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	return from, from.AddDate(0, 1, 0)
+}
+
+// BuildReport 按域、应用汇总 [from, to) 期间的资源时长
+func BuildReport(usages []*Usage, from, to, now time.Time) *Report {
+	type key struct{ domainID, appID string }
+	lines := make(map[key]*Line)
+	components := make(map[key]map[string]struct{})
+	report := &Report{From: from, To: to, GeneratedAt: now}
+	totalComponents := make(map[string]struct{})
+
+	for _, usage := range usages {
+		start, end := usage.StartedAt, now
+		if usage.EndedAt != nil {
+			end = *usage.EndedAt
+		}
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if !end.After(start) {
+			continue
+		}
+
+		k := key{usage.DomainID, usage.AppID}
+		line, ok := lines[k]
+		if !ok {
+			line = &Line{DomainID: usage.DomainID, AppID: usage.AppID}
+			lines[k] = line
+			components[k] = make(map[string]struct{})
+		}
+		components[k][usage.ComponentID] = struct{}{}
+		totalComponents[usage.ComponentID] = struct{}{}
+
+		d := end.Sub(start)
+		hours := d.Hours()
+		line.CPUMs += float64(usage.Resources.CPU) / 1000 * float64(d.Milliseconds())
+		line.MemoryGBHours += float64(usage.Resources.Memory) / (1 << 30) * hours
+		line.GPUHours += float64(usage.Resources.GPU) * hours
+	}
+
+	report.Lines = make([]Line, 0, len(lines))
+	for k, line := range lines {
+		line.Components = len(components[k])
+		report.Lines = append(report.Lines, *line)
+		report.Total.CPUMs += line.CPUMs
+		report.Total.MemoryGBHours += line.MemoryGBHours
+		report.Total.GPUHours += line.GPUHours
+	}
+	report.Total.Components = len(totalComponents)
+	sort.Slice(report.Lines, func(i, j int) bool {
+		if report.Lines[i].DomainID != report.Lines[j].DomainID {
+			return report.Lines[i].DomainID < report.Lines[j].DomainID
+		}
+		return report.Lines[i].AppID < report.Lines[j].AppID
+	})
+	return report
+}
+
+// WriteCSV 以 CSV 格式导出报表，首行为表头
+func (r *Report) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	header := []string{"period_from", "period_to", "domain_id", "app_id", "components", "cpu_ms", "memory_gb_hours", "gpu_hours"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	from, to := r.From.Format(time.RFC3339), r.To.Format(time.RFC3339)
+	for _, line := range r.Lines {
+		record := []string{
+			from,
+			to,
+			line.DomainID,
+			line.AppID,
+			strconv.Itoa(line.Components),
+			strconv.FormatFloat(line.CPUMs, 'f', 0, 64),
+			strconv.FormatFloat(line.MemoryGBHours, 'f', 4, 64),
+			strconv.FormatFloat(line.GPUHours, 'f', 4, 64),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
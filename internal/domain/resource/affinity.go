@@ -0,0 +1,253 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/component"
+	"github.com/9triver/iarnet/internal/domain/resource/provider"
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+	"github.com/sirupsen/logrus"
+)
+
+// defaultAffinityTTL 会话亲和的默认空闲超时
+const defaultAffinityTTL = 30 * time.Minute
+
+// AffinitySession 会话亲和的当前绑定
+type AffinitySession struct {
+	Key         string
+	Scope       provider.AffinityScope
+	NodeID      string
+	ProviderID  string
+	ComponentID string
+	CreatedAt   time.Time
+	LastUsed    time.Time
+	ExpiresAt   time.Time
+}
+
+// affinitySession 会话绑定的内部状态
+type affinitySession struct {
+	scope      provider.AffinityScope
+	nodeID     string
+	providerID string
+	component  *component.Component
+	ttl        time.Duration
+	createdAt  time.Time
+	lastUsed   time.Time
+}
+
+func (s *affinitySession) expired(now time.Time) bool {
+	return now.Sub(s.lastUsed) >= s.ttl
+}
+
+// affinityTable 会话亲和表，会话在空闲超过 TTL 后惰性过期
+type affinityTable struct {
+	mu         sync.Mutex
+	sessions   map[string]*affinitySession
+	defaultTTL time.Duration
+}
+
+func newAffinityTable() *affinityTable {
+	return &affinityTable{
+		sessions:   make(map[string]*affinitySession),
+		defaultTTL: defaultAffinityTTL,
+	}
+}
+
+// lookup 查找未过期的会话并刷新其最近使用时间，返回副本
+func (t *affinityTable) lookup(key string) *affinitySession {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	session, ok := t.sessions[key]
+	if !ok {
+		return nil
+	}
+	now := time.Now()
+	if session.expired(now) {
+		delete(t.sessions, key)
+		return nil
+	}
+	session.lastUsed = now
+	copied := *session
+	return &copied
+}
+
+// pin 将会话绑定到 component 所在的位置，已存在的会话会被更新（如原目标不可用后重新调度）
+func (t *affinityTable) pin(affinity *provider.Affinity, nodeID, providerID string, comp *component.Component) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	for key, session := range t.sessions {
+		if session.expired(now) {
+			delete(t.sessions, key)
+		}
+	}
+
+	ttl := affinity.TTL
+	if ttl <= 0 {
+		ttl = t.defaultTTL
+	}
+	session := &affinitySession{
+		scope:      affinity.Scope,
+		nodeID:     nodeID,
+		providerID: providerID,
+		component:  comp,
+		ttl:        ttl,
+		createdAt:  now,
+		lastUsed:   now,
+	}
+	if old, ok := t.sessions[affinity.Key]; ok {
+		session.createdAt = old.createdAt
+	}
+	t.sessions[affinity.Key] = session
+}
+
+// SetAffinityTTL 设置会话亲和的默认空闲超时，ttl <= 0 时使用默认值
+func (m *Manager) SetAffinityTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = defaultAffinityTTL
+	}
+	m.affinity.mu.Lock()
+	defer m.affinity.mu.Unlock()
+	m.affinity.defaultTTL = ttl
+}
+
+// ListAffinitySessions 列出未过期的会话亲和，按 key 排序
+func (m *Manager) ListAffinitySessions() []AffinitySession {
+	m.affinity.mu.Lock()
+	defer m.affinity.mu.Unlock()
+
+	now := time.Now()
+	sessions := make([]AffinitySession, 0, len(m.affinity.sessions))
+	for key, s := range m.affinity.sessions {
+		if s.expired(now) {
+			delete(m.affinity.sessions, key)
+			continue
+		}
+		item := AffinitySession{
+			Key:        key,
+			Scope:      s.scope,
+			NodeID:     s.nodeID,
+			ProviderID: s.providerID,
+			CreatedAt:  s.createdAt,
+			LastUsed:   s.lastUsed,
+			ExpiresAt:  s.lastUsed.Add(s.ttl),
+		}
+		if s.component != nil {
+			item.ComponentID = s.component.GetID()
+		}
+		sessions = append(sessions, item)
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].Key < sessions[j].Key })
+	return sessions
+}
+
+// ReleaseAffinity 提前结束会话亲和，会话不存在时返回 false
+// 已部署的 component 不受影响，之后相同 key 的部署会重新调度
+func (m *Manager) ReleaseAffinity(key string) bool {
+	m.affinity.mu.Lock()
+	defer m.affinity.mu.Unlock()
+	_, ok := m.affinity.sessions[key]
+	delete(m.affinity.sessions, key)
+	return ok
+}
+
+// pinAffinity 将会话绑定到 component 的部署位置
+func (m *Manager) pinAffinity(affinity *provider.Affinity, comp *component.Component) {
+	if comp == nil {
+		return
+	}
+	nodeID, providerID := m.placementOf(comp)
+	m.affinity.pin(affinity, nodeID, providerID, comp)
+	logrus.Debugf("Affinity session %s pinned to node %s, provider %s, component %s", affinity.Key, nodeID, providerID, comp.GetID())
+}
+
+// placementOf 解析 component 所在的节点和 provider
+// 委托部署的 component 的 provider ID 形如 remote.<provider>@<node> 或 global.<provider>@<node>
+func (m *Manager) placementOf(comp *component.Component) (nodeID, providerID string) {
+	providerID = comp.GetProviderID()
+	for _, prefix := range []string{"remote.", "global."} {
+		if rest, ok := strings.CutPrefix(providerID, prefix); ok {
+			if p, node, found := strings.Cut(rest, "@"); found {
+				return node, p
+			}
+		}
+	}
+	return m.nodeID, providerID
+}
+
+// placeByAffinity 按会话亲和放置 component
+// 会话不存在或绑定的目标已不可用时返回 nil，由调用方重新调度并重新绑定
+func (m *Manager) placeByAffinity(ctx context.Context, affinity *provider.Affinity, runtimeEnv types.RuntimeEnv, resourceRequest *types.Info) *component.Component {
+	session := m.affinity.lookup(affinity.Key)
+	if session == nil {
+		return nil
+	}
+	if !m.nodeMatchesSelector(session.nodeID, resourceRequest) {
+		logrus.Infof("Affinity session %s: pinned node %s does not satisfy node selector, rescheduling", affinity.Key, session.nodeID)
+		return nil
+	}
+
+	var comp *component.Component
+	var err error
+	switch {
+	case session.scope == provider.AffinityScopeComponent:
+		if session.component != nil && m.componentManager.Get(session.component.GetID()) != nil {
+			return session.component
+		}
+		err = fmt.Errorf("pinned component no longer exists")
+	case session.nodeID == m.nodeID:
+		localCtx := ctx
+		if session.scope == provider.AffinityScopeProvider {
+			localCtx = provider.WithPreferredProvider(ctx, session.providerID)
+		}
+		comp, err = m.componentService.DeployComponent(localCtx, runtimeEnv, resourceRequest)
+	default:
+		comp, err = m.deployToPinnedNode(ctx, session.nodeID, runtimeEnv, resourceRequest)
+	}
+	if err != nil {
+		logrus.Infof("Affinity session %s: pinned %s unavailable (%v), rescheduling", affinity.Key, session.scope, err)
+		return nil
+	}
+
+	m.pinAffinity(affinity, comp)
+	return comp
+}
+
+// nodeMatchesSelector 判断会话绑定的节点是否满足请求的节点标签约束，未知节点视为不满足
+func (m *Manager) nodeMatchesSelector(nodeID string, resourceRequest *types.Info) bool {
+	if resourceRequest == nil || len(resourceRequest.NodeSelector) == 0 {
+		return true
+	}
+	if nodeID == m.nodeID {
+		return types.MatchLabels(resourceRequest.NodeSelector, m.labels)
+	}
+	if m.discoveryService == nil {
+		return false
+	}
+	for _, node := range m.discoveryService.GetKnownNodes() {
+		if node.NodeID == nodeID {
+			return types.MatchLabels(resourceRequest.NodeSelector, node.Labels)
+		}
+	}
+	return false
+}
+
+// deployToPinnedNode 将 component 部署到会话绑定的其他节点，节点需仍为已知的存活节点
+func (m *Manager) deployToPinnedNode(ctx context.Context, nodeID string, runtimeEnv types.RuntimeEnv, resourceRequest *types.Info) (*component.Component, error) {
+	if m.discoveryService == nil || m.schedulerService == nil {
+		return nil, fmt.Errorf("discovery service or scheduler service not configured")
+	}
+	for _, node := range m.discoveryService.GetKnownNodes() {
+		if node.NodeID == nodeID {
+			return m.commitDelegation(ctx, runtimeEnv, resourceRequest, node)
+		}
+	}
+	return nil, fmt.Errorf("node %s is no longer known", nodeID)
+}
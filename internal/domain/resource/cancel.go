@@ -0,0 +1,132 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/scheduler"
+	schedulerpb "github.com/9triver/iarnet/internal/proto/resource/scheduler"
+	"github.com/sirupsen/logrus"
+)
+
+// rollbackTimeout 部署被取消后发送补偿回滚请求的超时，调用方 ctx 已取消，因此独立计时
+const rollbackTimeout = 10 * time.Second
+
+// ErrCancelled 调用方取消了进行中的部署
+var ErrCancelled = errors.New("deployment cancelled")
+
+// CancelledError 调用方 ctx 在部署链路的某个阶段被取消
+// 返回前已回滚本次部署在本地登记的 component，并通知远端节点删除可能已创建的 component
+// 可通过 errors.Is(err, ErrCancelled) 或 errors.Is(err, context.Canceled) 判断，Stage 指明取消发生的阶段
+type CancelledError struct {
+	Stage DeployStage
+	Err   error
+}
+
+func (e *CancelledError) Error() string {
+	return fmt.Sprintf("deployment cancelled during %s: %v", e.Stage, e.Err)
+}
+
+func (e *CancelledError) Unwrap() []error {
+	return []error{ErrCancelled, context.Canceled, e.Err}
+}
+
+// cancelledError 若调用方 ctx 已被取消，返回带阶段信息的 CancelledError，否则返回 nil
+// 已经是 CancelledError 的错误原样返回，保留最早取消的阶段
+func cancelledError(ctx context.Context, stage DeployStage, err error) error {
+	var cancelledErr *CancelledError
+	if errors.As(err, &cancelledErr) {
+		return cancelledErr
+	}
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		return nil
+	}
+	if err == nil {
+		err = ctx.Err()
+	}
+	return &CancelledError{Stage: stage, Err: err}
+}
+
+// interruptError 部署因调用方截止时间耗尽或取消而中断时，返回对应的 DeadlineExceededError 或 CancelledError，否则返回 nil
+func interruptError(ctx context.Context, stage DeployStage, err error) error {
+	if deadlineErr := deadlineError(ctx, stage, err); deadlineErr != nil {
+		return deadlineErr
+	}
+	return cancelledError(ctx, stage, err)
+}
+
+// rollbackDelegation 委托部署被取消时，通知目标节点删除可能已创建的 component，并移除本地登记的路由
+func (m *Manager) rollbackDelegation(ctx context.Context, nodeID, address, componentID string) {
+	m.componentManager.RemoveComponent(componentID)
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), rollbackTimeout)
+	defer cancel()
+	err := m.schedulerService.UndeployComponent(ctx, &scheduler.UndeployRequest{
+		ComponentID:   componentID,
+		TargetNodeID:  nodeID,
+		TargetAddress: address,
+	})
+	switch {
+	case errors.Is(err, scheduler.ErrUndeployUnsupported):
+		logrus.Warnf("Deployment of component %s was cancelled, but node %s cannot roll it back", componentID, nodeID)
+	case err != nil:
+		// 目标节点在自身的部署被取消时已清理，此时找不到 component 属正常情况
+		logrus.Debugf("Rollback of cancelled deployment %s on node %s: %v", componentID, nodeID, err)
+	default:
+		logrus.Infof("Rolled back cancelled deployment of component %s on node %s", componentID, nodeID)
+	}
+}
+
+// rollbackGlobal 委托给全局调度器的部署被取消时，通过全局调度器删除已在 nodeID 上创建的 component
+func (m *Manager) rollbackGlobal(ctx context.Context, client schedulerpb.SchedulerServiceClient, nodeID, componentID string) {
+	m.componentManager.RemoveComponent(componentID)
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), rollbackTimeout)
+	defer cancel()
+	resp, err := client.UndeployComponent(ctx, &schedulerpb.UndeployComponentRequest{
+		ComponentId:  componentID,
+		TargetNodeId: nodeID,
+	})
+	if err == nil && !resp.Success {
+		err = errors.New(resp.Error)
+	}
+	if err != nil {
+		logrus.Warnf("Failed to roll back cancelled deployment of component %s via global scheduler: %v", componentID, err)
+		return
+	}
+	logrus.Infof("Rolled back cancelled deployment of component %s on node %s via global scheduler", componentID, nodeID)
+}
+
+// UndeployComponent 删除 component；委托到其他节点的 component 由所在节点删除
+func (m *Manager) UndeployComponent(ctx context.Context, componentID string) error {
+	if m.traceRecorder == nil {
+		return m.undeployComponent(ctx, componentID)
+	}
+	startedAt := time.Now()
+	err := m.undeployComponent(ctx, componentID)
+	m.recordUndeploy(startedAt, componentID, err)
+	return err
+}
+
+func (m *Manager) undeployComponent(ctx context.Context, componentID string) error {
+	comp := m.componentManager.Get(componentID)
+	if comp == nil {
+		return fmt.Errorf("component %s not found", componentID)
+	}
+	if nodeID, _ := m.placementOf(comp); nodeID != m.nodeID {
+		if m.schedulerService == nil {
+			return fmt.Errorf("scheduler service not configured")
+		}
+		if err := m.schedulerService.UndeployComponent(ctx, &scheduler.UndeployRequest{
+			ComponentID:  componentID,
+			TargetNodeID: nodeID,
+		}); err != nil {
+			return err
+		}
+		m.componentManager.RemoveComponent(componentID)
+		return nil
+	}
+	return m.componentService.UndeployComponent(ctx, componentID)
+}
@@ -0,0 +1,49 @@
+package resource
+
+import (
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/provider"
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+	"github.com/sirupsen/logrus"
+)
+
+// reconcileCapacity provider 的总容量变化后核对本节点放置在其上的 component 的资源请求
+// 已分配超过新的总容量时记录告警（provider 不会驱逐已运行的实例），回落到总容量以内时解除告警；
+// 随后立即向注册中心与 gossip 上报节点的新容量，不等待下一次健康检查
+func (m *Manager) reconcileCapacity(change provider.CapacityChange) {
+	p := m.providerManager.Get(change.ProviderID)
+	if p == nil || change.Current == nil || change.Current.Total == nil {
+		return
+	}
+
+	total := *change.Current.Total
+	allocated := m.allocatedOn(change.ProviderID)
+	if allocated.CPU > total.CPU || allocated.Memory > total.Memory || allocated.GPU > total.GPU {
+		p.SetCapacityAlert(&provider.CapacityAlert{Total: total, Allocated: allocated, Since: time.Now()})
+		logrus.Errorf("Provider %s capacity reduced below its allocations: total CPU=%d Memory=%d GPU=%d, allocated CPU=%d Memory=%d GPU=%d; "+
+			"running components are kept, new deployments will not fit until allocations drop",
+			change.ProviderID, total.CPU, total.Memory, total.GPU, allocated.CPU, allocated.Memory, allocated.GPU)
+	} else if p.GetCapacityAlert() != nil {
+		p.SetCapacityAlert(nil)
+		logrus.Infof("Provider %s allocations are within its capacity again", change.ProviderID)
+	}
+
+	select {
+	case m.resourceChanged <- struct{}{}:
+	default:
+	}
+}
+
+// allocatedOn 本节点放置在 provider 上的 component 的资源请求之和（含 sidecar）
+func (m *Manager) allocatedOn(providerID string) types.Info {
+	var allocated types.Info
+	for _, comp := range m.componentManager.GetByProvider(providerID) {
+		if usage := comp.GetResourceUsage(); usage != nil {
+			allocated.CPU += usage.CPU
+			allocated.Memory += usage.Memory
+			allocated.GPU += usage.GPU
+		}
+	}
+	return allocated
+}
@@ -0,0 +1,145 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/provider"
+	"github.com/sirupsen/logrus"
+)
+
+// completionPollInterval 等待 component 结束时查询实例状态的间隔，也是结束时间的精度
+const completionPollInterval = time.Second
+
+// completionMaxErrors 连续查询失败达到该次数后放弃等待
+const completionMaxErrors = 5
+
+// ComponentCompletion component 实例结束的通知
+// 部署返回只表示实例已创建；docker、k8s 等 provider 上实例异步运行，结束时间以此为准
+type ComponentCompletion struct {
+	ComponentID string
+	State       provider.InstanceState // exited，或实例已被删除时为 not_found
+	ExitCode    int32                  // State 为 exited 时有效
+	Reason      string
+	FinishedAt  time.Time // 节点观察到实例结束的时间
+}
+
+// completionWatch 一个 component 的结束监视，同一 component 的多个等待方共享一次轮询
+type completionWatch struct {
+	done    chan struct{}
+	result  *ComponentCompletion
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// completionWatches 进行中的结束监视
+type completionWatches struct {
+	mu      sync.Mutex
+	watches map[string]*completionWatch
+}
+
+func newCompletionWatches() *completionWatches {
+	return &completionWatches{watches: make(map[string]*completionWatch)}
+}
+
+// WaitComponentCompletion 阻塞直到本节点的 component 实例结束或 ctx 结束
+// 委托到其他节点的 component 需在其所在节点上等待
+func (m *Manager) WaitComponentCompletion(ctx context.Context, componentID string) (*ComponentCompletion, error) {
+	comp := m.componentManager.Get(componentID)
+	if comp == nil {
+		return nil, fmt.Errorf("component %s not found", componentID)
+	}
+	if nodeID, _ := m.placementOf(comp); nodeID != m.nodeID {
+		return nil, fmt.Errorf("component %s is deployed on node %s, wait for its completion there", componentID, nodeID)
+	}
+
+	w := m.completions
+	w.mu.Lock()
+	watch, ok := w.watches[componentID]
+	if !ok {
+		pollCtx, cancel := context.WithCancel(context.Background())
+		watch = &completionWatch{done: make(chan struct{}), cancel: cancel}
+		w.watches[componentID] = watch
+		go m.pollCompletion(pollCtx, componentID, watch)
+	}
+	watch.waiters++
+	w.mu.Unlock()
+
+	select {
+	case <-watch.done:
+		return watch.result, watch.err
+	case <-ctx.Done():
+		// 最后一个等待方离开时停止轮询
+		w.mu.Lock()
+		watch.waiters--
+		if watch.waiters == 0 && w.watches[componentID] == watch {
+			delete(w.watches, componentID)
+			watch.cancel()
+		}
+		w.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// pollCompletion 定期查询实例状态，直到实例结束、component 被删除或连续查询失败
+func (m *Manager) pollCompletion(ctx context.Context, componentID string, watch *completionWatch) {
+	defer func() {
+		m.completions.mu.Lock()
+		if m.completions.watches[componentID] == watch {
+			delete(m.completions.watches, componentID)
+		}
+		m.completions.mu.Unlock()
+		watch.cancel()
+		close(watch.done)
+	}()
+
+	ticker := time.NewTicker(completionPollInterval)
+	defer ticker.Stop()
+	failures := 0
+	for {
+		if m.componentManager.Get(componentID) == nil {
+			watch.result = &ComponentCompletion{
+				ComponentID: componentID,
+				State:       provider.InstanceStateNotFound,
+				Reason:      "undeployed",
+				FinishedAt:  time.Now(),
+			}
+			return
+		}
+
+		status, err := m.GetComponentInstanceStatus(ctx, componentID)
+		switch {
+		case ctx.Err() != nil:
+			watch.err = ctx.Err()
+			return
+		case err != nil:
+			failures++
+			logrus.Debugf("Failed to query instance status of component %s (%d/%d): %v", componentID, failures, completionMaxErrors, err)
+			if failures >= completionMaxErrors {
+				watch.err = fmt.Errorf("failed to query instance status of component %s: %w", componentID, err)
+				return
+			}
+		case status.State == provider.InstanceStateExited || status.State == provider.InstanceStateNotFound:
+			watch.result = &ComponentCompletion{
+				ComponentID: componentID,
+				State:       status.State,
+				ExitCode:    status.ExitCode,
+				Reason:      status.Reason,
+				FinishedAt:  time.Now(),
+			}
+			return
+		default:
+			failures = 0
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			watch.err = ctx.Err()
+			return
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package component
 
-import "context"
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
 
 // Channeler 定义组件通信通道接口
 // 领域层不依赖具体的传输实现，只依赖此接口
@@ -8,7 +12,8 @@ type Channeler interface {
 	// StartReceiver 启动消息接收器，当收到消息时调用 onMessage 回调
 	// componentID: 组件 ID
 	// data: 消息数据
-	StartReceiver(ctx context.Context, onMessage func(componentID string, data []byte))
+	// onMessage 返回 error 表示消息非法，实现应将其计入发送方的非法消息数
+	StartReceiver(ctx context.Context, onMessage func(componentID string, data []byte) error)
 
 	// Send 向指定组件发送消息
 	// componentID: 目标组件 ID
@@ -19,20 +24,27 @@ type Channeler interface {
 	Close() error
 }
 
+// EncodingNegotiator 可选接口：支持帧压缩的 channeler 实现
+// component 在 READY 消息中声明可解压的算法，之后发往该 component 的大消息才会被压缩
+type EncodingNegotiator interface {
+	SetAcceptEncodings(componentID string, encodings []string)
+}
+
+// nullChanneler 占位 channeler，在 ZMQ 传输可用前使用
+// 不接收任何消息，发送的消息会被丢弃并记录日志，使 ZMQ 启动失败时节点仍能以降级模式运行
 type nullChanneler struct{}
 
 func NewNullChanneler() Channeler {
 	return &nullChanneler{}
 }
 
-func (n *nullChanneler) StartReceiver(ctx context.Context, onMessage func(componentID string, data []byte)) {
-	panic("channeler is not initialized")
+func (n *nullChanneler) StartReceiver(ctx context.Context, onMessage func(componentID string, data []byte) error) {
 }
 
 func (n *nullChanneler) Send(componentID string, data []byte) {
-	panic("channeler is not initialized")
+	logrus.Errorf("Dropping message to component %s: channeler is not available", componentID)
 }
 
 func (n *nullChanneler) Close() error {
-	panic("channeler is not initialized")
+	return nil
 }
@@ -2,8 +2,12 @@ package component
 
 import (
 	"context"
+	"fmt"
+	"maps"
+	"strings"
 	"sync"
 
+	"github.com/9triver/iarnet/internal/domain/resource/provider"
 	"github.com/9triver/iarnet/internal/domain/resource/types"
 	componentpb "github.com/9triver/iarnet/internal/proto/resource/component"
 )
@@ -15,9 +19,101 @@ type Component struct {
 	id            string
 	providerID    string
 	image         string
+	appID         string // 所属应用，用于 store 对象的访问控制
+	labels        map[string]string
+	origin        string // 委托部署时委托方的节点 ID，本节点发起的部署为空
 	resourceUsage *types.Info
 	buffer        chan *componentpb.Message
 	sender        Sender
+
+	// evictable 部署在 best-effort provider 上的 component 可能被驱逐
+	evictable     bool
+	onRescheduled []func()
+
+	// 首次部署时的上游地址覆盖、额外环境变量、出站策略、预置数据、挂载的卷、安全配置与 sidecar，重新调度时需沿用
+	envOverride     *provider.DeploymentEnvOverride
+	env             map[string]string
+	egressPolicy    *provider.EgressPolicy
+	dataSources     []provider.DataSource
+	volumes         []provider.VolumeMount
+	securityContext *provider.SecurityContext
+	sidecars        []provider.Sidecar
+	injected        []string // 按注入规则附加的 sidecar 对应的规则名称，部署时渲染其环境变量
+}
+
+type componentIDCtxKey struct{}
+
+// WithComponentID 在 context 中指定新部署 component 的 ID，调用方可在部署被取消时据此回滚
+func WithComponentID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, componentIDCtxKey{}, id)
+}
+
+// GetComponentID 获取 context 中指定的 component ID
+func GetComponentID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(componentIDCtxKey{}).(string)
+	return id, ok
+}
+
+type componentImageCtxKey struct{}
+
+// WithComponentImage 在 context 中指定新部署 component 使用的镜像，替代运行时环境对应的 component 镜像
+// 用于部署由应用源码构建出的镜像
+func WithComponentImage(ctx context.Context, image string) context.Context {
+	if image == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, componentImageCtxKey{}, image)
+}
+
+// GetComponentImage 获取 context 中指定的 component 镜像
+func GetComponentImage(ctx context.Context) (string, bool) {
+	image, ok := ctx.Value(componentImageCtxKey{}).(string)
+	return image, ok
+}
+
+type componentLabelsCtxKey struct{}
+
+// WithComponentLabels 在 context 中指定新部署 component 的标签，可按标签查询整个域的 component
+func WithComponentLabels(ctx context.Context, labels map[string]string) context.Context {
+	if len(labels) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, componentLabelsCtxKey{}, labels)
+}
+
+// GetComponentLabels 获取 context 中指定的 component 标签
+func GetComponentLabels(ctx context.Context) map[string]string {
+	labels, _ := ctx.Value(componentLabelsCtxKey{}).(map[string]string)
+	return labels
+}
+
+type componentOriginCtxKey struct{}
+
+// WithComponentOrigin 在 context 中指定委托部署的委托方节点 ID，本节点据此向委托方对账
+func WithComponentOrigin(ctx context.Context, nodeID string) context.Context {
+	if nodeID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, componentOriginCtxKey{}, nodeID)
+}
+
+// GetComponentOrigin 获取 context 中指定的委托方节点 ID
+func GetComponentOrigin(ctx context.Context) string {
+	nodeID, _ := ctx.Value(componentOriginCtxKey{}).(string)
+	return nodeID
+}
+
+// ValidateLabels 检查 component 标签，键不能为空或包含 '='（查询时按 key=value 解析）
+func ValidateLabels(labels map[string]string) error {
+	for key := range labels {
+		if key == "" || strings.Contains(key, "=") {
+			return fmt.Errorf("invalid component label key %q", key)
+		}
+	}
+	return nil
 }
 
 func NewComponent(id, image string, resourceUsage *types.Info) *Component {
@@ -43,6 +139,133 @@ func (c *Component) SetProviderID(providerID string) {
 	c.providerID = providerID
 }
 
+// GetAppID 获取 component 所属应用
+func (c *Component) GetAppID() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.appID
+}
+
+// SetAppID 设置 component 所属应用
+func (c *Component) SetAppID(appID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.appID = appID
+}
+
+// GetLabels 获取 component 标签
+func (c *Component) GetLabels() map[string]string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.labels
+}
+
+// SetLabels 设置 component 标签
+func (c *Component) SetLabels(labels map[string]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.labels = maps.Clone(labels)
+}
+
+// GetOrigin 获取委托方节点 ID，本节点发起的部署为空
+func (c *Component) GetOrigin() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.origin
+}
+
+// SetOrigin 设置委托方节点 ID
+func (c *Component) SetOrigin(nodeID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.origin = nodeID
+}
+
+// SetEnvOverride 更新上游地址覆盖，nil 表示使用本节点的地址；重新部署后生效
+func (c *Component) SetEnvOverride(override *provider.DeploymentEnvOverride) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.envOverride = override
+}
+
+// HasVolumes 是否挂载了卷，挂载了卷的 component 与卷所在的 provider 绑定
+func (c *Component) HasVolumes() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.volumes) > 0
+}
+
+// IsEvictable 是否可被驱逐
+func (c *Component) IsEvictable() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.evictable
+}
+
+// SetEvictable 设置是否可被驱逐
+func (c *Component) SetEvictable(evictable bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.evictable = evictable
+}
+
+// OnRescheduled 注册重新调度完成后的回调
+// component 被驱逐后会以相同 ID 部署到新的 provider，上层可借此重新发送初始化消息
+func (c *Component) OnRescheduled(fn func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onRescheduled = append(c.onRescheduled, fn)
+}
+
+// rememberDeployOptions 记录部署 context 中携带的部署选项
+func (c *Component) rememberDeployOptions(ctx context.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if override, ok := provider.GetDeploymentEnvOverride(ctx); ok {
+		c.envOverride = override
+	}
+	if env, ok := provider.GetDeploymentEnv(ctx); ok {
+		c.env = env
+	}
+	if policy, ok := provider.GetEgressPolicy(ctx); ok {
+		c.egressPolicy = policy
+	}
+	if sources, ok := provider.GetDataSources(ctx); ok {
+		c.dataSources = sources
+	}
+	if mounts, ok := provider.GetVolumes(ctx); ok {
+		c.volumes = mounts
+	}
+	if sc, ok := provider.GetSecurityContext(ctx); ok {
+		c.securityContext = sc
+	}
+	if sidecars, ok := provider.GetSidecars(ctx); ok {
+		c.sidecars = sidecars
+	}
+}
+
+// withDeployOptions 将记录的部署选项重新附加到 context
+func (c *Component) withDeployOptions(ctx context.Context) context.Context {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	ctx = provider.WithDeploymentEnvOverride(ctx, c.envOverride)
+	ctx = provider.WithDeploymentEnv(ctx, c.env)
+	ctx = provider.WithDataSources(ctx, c.dataSources)
+	ctx = provider.WithVolumes(ctx, c.volumes)
+	ctx = provider.WithSecurityContext(ctx, c.securityContext)
+	ctx = provider.WithSidecars(ctx, c.sidecars)
+	return provider.WithEgressPolicy(ctx, c.egressPolicy)
+}
+
+func (c *Component) notifyRescheduled() {
+	c.mu.RLock()
+	handlers := append([]func(){}, c.onRescheduled...)
+	c.mu.RUnlock()
+	for _, fn := range handlers {
+		fn()
+	}
+}
+
 func (c *Component) GetID() string {
 	return c.id
 }
@@ -57,6 +280,20 @@ func (c *Component) GetResourceUsage() *types.Info {
 	return c.resourceUsage
 }
 
+// GetSidecars 获取与主容器一起部署的 sidecar，资源已计入 GetResourceUsage
+func (c *Component) GetSidecars() []provider.Sidecar {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.sidecars
+}
+
+// getInjected 获取按注入规则附加 sidecar 的规则名称
+func (c *Component) getInjected() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.injected
+}
+
 func (c *Component) SetSender(sender Sender) {
 	c.sender = sender
 }
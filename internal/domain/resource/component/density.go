@@ -0,0 +1,107 @@
+package component
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/9triver/iarnet/internal/domain/resource/provider"
+)
+
+// DensityLimits 同时运行的 component 数量上限
+// 小型边缘设备的 CPU/内存看似空闲时，仍可能被大量 component 耗尽进程数、文件句柄等资源，因此按数量限制部署密度
+type DensityLimits struct {
+	MaxPerProvider int // 每个 provider 上的 component 数上限，<= 0 表示不限制
+	MaxPerNode     int // 本节点所有 provider 上的 component 数上限，<= 0 表示不限制
+}
+
+func (l DensityLimits) enabled() bool {
+	return l.MaxPerProvider > 0 || l.MaxPerNode > 0
+}
+
+// DensityLimitSetter 支持限制 component 部署密度的 Service
+type DensityLimitSetter interface {
+	// SetDensityLimits 设置 provider 与本节点上同时运行的 component 数量上限
+	SetDensityLimits(limits DensityLimits)
+}
+
+func (c *componentService) SetDensityLimits(limits DensityLimits) {
+	c.densityMu.Lock()
+	defer c.densityMu.Unlock()
+	c.density = limits
+}
+
+// countComponents 统计本节点各 provider 上的 component 数，包括已占用名额、正在部署的 component 与可行性检查中假设放置的 component
+// comp 为待放置的 component，其当前位于本节点的 provider 上时（迁移、驱逐后重新调度）不计入节点总数
+// 调用方需持有 densityMu
+func (c *componentService) countComponents(comp *Component, ledger *provider.PlanLedger) (perProvider map[string]int, total int) {
+	perProvider = make(map[string]int)
+	for _, p := range c.providerService.GetAllProviders() {
+		id := p.GetID()
+		n := len(c.manager.GetByProvider(id)) + c.pending[id]
+		if ledger != nil {
+			n += ledger.Placements(id)
+		}
+		perProvider[id] = n
+		total += n
+	}
+	if comp != nil && c.providerService.GetProvider(comp.GetProviderID()) != nil {
+		total--
+	}
+	return perProvider, total
+}
+
+// withDensityLimits 检查本节点的 component 数上限，并在 context 中附加 component 数已达上限的 provider
+// 节点已满时返回 provider.ErrNoAvailableProvider，调用方可委托给其他节点
+func (c *componentService) withDensityLimits(ctx context.Context, comp *Component) (context.Context, error) {
+	c.densityMu.Lock()
+	defer c.densityMu.Unlock()
+	limits := c.density
+	if !limits.enabled() {
+		return ctx, nil
+	}
+
+	ledger, _ := provider.GetPlanLedger(ctx)
+	perProvider, total := c.countComponents(comp, ledger)
+	if limits.MaxPerNode > 0 && total >= limits.MaxPerNode {
+		return ctx, fmt.Errorf("node component limit (%d) reached: %w", limits.MaxPerNode, provider.ErrNoAvailableProvider)
+	}
+	if limits.MaxPerProvider <= 0 {
+		return ctx, nil
+	}
+	full := make(map[string]struct{})
+	for id, n := range perProvider {
+		if n >= limits.MaxPerProvider {
+			full[id] = struct{}{}
+		}
+	}
+	return provider.WithFullProviders(ctx, full), nil
+}
+
+// admitDensity 部署到 p 之前再次检查上限并占用名额，避免并发部署同时通过检查后超出上限
+// provider 已满时返回 provider.ErrNoCapacity，由重试重新选择 provider；返回的 release 需在部署结束后调用
+func (c *componentService) admitDensity(p *provider.Provider, comp *Component) (release func(), err error) {
+	c.densityMu.Lock()
+	defer c.densityMu.Unlock()
+	limits := c.density
+	if !limits.enabled() {
+		return func() {}, nil
+	}
+
+	perProvider, total := c.countComponents(comp, nil)
+	if limits.MaxPerNode > 0 && total >= limits.MaxPerNode {
+		return nil, fmt.Errorf("node component limit (%d) reached: %w", limits.MaxPerNode, provider.ErrNoAvailableProvider)
+	}
+	id := p.GetID()
+	if limits.MaxPerProvider > 0 && perProvider[id] >= limits.MaxPerProvider {
+		return nil, fmt.Errorf("provider %s component limit (%d) reached: %w", id, limits.MaxPerProvider, provider.ErrNoCapacity)
+	}
+
+	c.pending[id]++
+	return func() {
+		c.densityMu.Lock()
+		defer c.densityMu.Unlock()
+		if c.pending[id]--; c.pending[id] <= 0 {
+			delete(c.pending, id)
+		}
+	}, nil
+}
@@ -0,0 +1,77 @@
+package component
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"text/template"
+
+	"github.com/9triver/iarnet/internal/domain/resource/provider"
+)
+
+// NodeMetadata 本节点的元数据，部署时供环境变量模板引用
+type NodeMetadata struct {
+	NodeID   string
+	NodeName string
+	NodeIP   string // 本节点对外暴露的地址（配置中的 host）
+	DomainID string
+}
+
+// EnvTemplateData 环境变量模板可引用的字段，e.g., "{{.NodeIP}}:{{.ProviderID}}"
+type EnvTemplateData struct {
+	NodeMetadata
+	ProviderID   string
+	ProviderName string
+	ProviderHost string
+	ComponentID  string
+	Image        string
+}
+
+// EnvTemplate component 环境变量模板，在部署时选定 provider 后渲染
+type EnvTemplate struct {
+	keys      []string
+	templates map[string]*template.Template
+}
+
+// ParseEnvTemplate 解析环境变量模板
+// 不允许覆盖 provider 自动注入的环境变量；解析后会用空数据试渲染一次，提前发现引用了不存在的字段
+func ParseEnvTemplate(env map[string]string) (*EnvTemplate, error) {
+	t := &EnvTemplate{templates: make(map[string]*template.Template, len(env))}
+	for key, value := range env {
+		if key == "" {
+			return nil, fmt.Errorf("env key must not be empty")
+		}
+		if provider.IsReservedEnvKey(key) {
+			return nil, fmt.Errorf("env %s is reserved and set by the provider", key)
+		}
+		tmpl, err := template.New(key).Option("missingkey=error").Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse env template %s: %w", key, err)
+		}
+		t.keys = append(t.keys, key)
+		t.templates[key] = tmpl
+	}
+	sort.Strings(t.keys)
+
+	if _, err := t.Render(EnvTemplateData{}); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Render 使用部署时的元数据渲染全部环境变量
+func (t *EnvTemplate) Render(data EnvTemplateData) (map[string]string, error) {
+	if t == nil || len(t.keys) == 0 {
+		return nil, nil
+	}
+	env := make(map[string]string, len(t.keys))
+	var buf bytes.Buffer
+	for _, key := range t.keys {
+		buf.Reset()
+		if err := t.templates[key].Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render env template %s: %w", key, err)
+		}
+		env[key] = buf.String()
+	}
+	return env, nil
+}
@@ -0,0 +1,131 @@
+package component
+
+import (
+	"context"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/provider"
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+	"github.com/sirupsen/logrus"
+)
+
+// HandoverState 进程交接时由旧进程导出、新进程恢复的 component 路由状态
+// 新进程据此继续向已部署的 component 收发消息，无需重新部署
+type HandoverState struct {
+	Components []ComponentState `json:"components"`
+	Sessions   []SessionState   `json:"sessions"`
+}
+
+// ComponentState 一个已部署 component 的路由信息
+type ComponentState struct {
+	ID            string                          `json:"id"`
+	Image         string                          `json:"image"`
+	ProviderID    string                          `json:"provider_id"`
+	AppID         string                          `json:"app_id,omitempty"`
+	Labels        map[string]string               `json:"labels,omitempty"`
+	Origin        string                          `json:"origin,omitempty"`
+	ResourceUsage *types.Info                     `json:"resource_usage,omitempty"`
+	Evictable     bool                            `json:"evictable,omitempty"`
+	EnvOverride   *provider.DeploymentEnvOverride `json:"env_override,omitempty"`
+	EgressPolicy  *provider.EgressPolicy          `json:"egress_policy,omitempty"`
+	DataSources   []provider.DataSource           `json:"data_sources,omitempty"`
+	Volumes       []provider.VolumeMount          `json:"volumes,omitempty"`
+	Security      *provider.SecurityContext       `json:"security_context,omitempty"`
+}
+
+// SessionState channeler 中一个 component 会话的状态
+type SessionState struct {
+	ComponentID     string   `json:"component_id"`
+	AcceptEncodings []string `json:"accept_encodings,omitempty"`
+	Pending         [][]byte `json:"pending,omitempty"` // 尚未发出的消息
+}
+
+// SessionHandover 可选接口：支持进程交接的 channeler 实现
+type SessionHandover interface {
+	// ExportSessions 导出所有 component 会话
+	ExportSessions() []SessionState
+	// ResumeSessions 恢复旧进程的会话；component 在 grace 内重新连接，期间发往它们的消息先排队
+	ResumeSessions(sessions []SessionState, grace time.Duration)
+}
+
+// Export 导出 component 路由状态，channeler 不支持交接时不包含会话
+func (m *manager) Export() *HandoverState {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	state := &HandoverState{Components: make([]ComponentState, 0, len(m.components))}
+	for _, c := range m.components {
+		c.mu.RLock()
+		state.Components = append(state.Components, ComponentState{
+			ID:            c.id,
+			Image:         c.image,
+			ProviderID:    c.providerID,
+			AppID:         c.appID,
+			Labels:        c.labels,
+			Origin:        c.origin,
+			ResourceUsage: c.resourceUsage,
+			Evictable:     c.evictable,
+			EnvOverride:   c.envOverride,
+			EgressPolicy:  c.egressPolicy,
+			DataSources:   c.dataSources,
+			Volumes:       c.volumes,
+			Security:      c.securityContext,
+		})
+		c.mu.RUnlock()
+	}
+	if h, ok := m.channeler.(SessionHandover); ok {
+		state.Sessions = h.ExportSessions()
+	}
+	return state
+}
+
+// Restore 恢复旧进程导出的 component 路由状态，已注册的 component 保持不变
+// 会话在支持交接的 channeler 上恢复；channeler 尚未就绪时，待 SetChanneler 注入后再恢复
+func (m *manager) Restore(ctx context.Context, state *HandoverState, grace time.Duration) {
+	for _, cs := range state.Components {
+		if m.Get(cs.ID) != nil {
+			continue
+		}
+		c := NewComponent(cs.ID, cs.Image, cs.ResourceUsage)
+		c.providerID = cs.ProviderID
+		c.appID = cs.AppID
+		c.labels = cs.Labels
+		c.origin = cs.Origin
+		c.evictable = cs.Evictable
+		c.envOverride = cs.EnvOverride
+		c.egressPolicy = cs.EgressPolicy
+		c.dataSources = cs.DataSources
+		c.volumes = cs.Volumes
+		c.securityContext = cs.Security
+		if err := m.AddComponent(ctx, c); err != nil {
+			logrus.Warnf("Failed to restore component %s: %v", cs.ID, err)
+			continue
+		}
+		m.mu.Lock()
+		m.verified[cs.ID] = struct{}{}
+		m.mu.Unlock()
+	}
+	logrus.Infof("Restored %d component(s) from previous process", len(state.Components))
+
+	m.mu.Lock()
+	m.resuming = state.Sessions
+	m.resumeGrace = grace
+	m.mu.Unlock()
+	m.resumeSessions()
+}
+
+// resumeSessions 在当前 channeler 支持交接时恢复待恢复的会话
+func (m *manager) resumeSessions() {
+	m.mu.Lock()
+	h, ok := m.channeler.(SessionHandover)
+	if !ok || m.resuming == nil {
+		m.mu.Unlock()
+		return
+	}
+	sessions, grace := m.resuming, m.resumeGrace
+	m.resuming = nil
+	m.mu.Unlock()
+
+	h.ResumeSessions(sessions, grace)
+	logrus.Infof("Resumed %d component session(s) from previous process", len(sessions))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	componentpb "github.com/9triver/iarnet/internal/proto/resource/component"
 	"github.com/sirupsen/logrus"
@@ -14,12 +15,29 @@ type Manager interface {
 	AddComponent(ctx context.Context, component *Component) error
 	Start(ctx context.Context) error
 	SetChanneler(channeler Channeler) // 用于后续注入真正的 channeler
+	GetByProvider(providerID string) []*Component
+	Get(id string) *Component
+	GetAll() []*Component                                                   // 获取所有 component，包括委托给其他节点部署的
+	RemoveComponent(id string)                                              // 移除 component 的路由，不影响 provider 上的实例
+	Export() *HandoverState                                                 // 导出 component 路由状态，用于进程交接
+	Restore(ctx context.Context, state *HandoverState, grace time.Duration) // 恢复旧进程导出的 component 路由状态
+	SetTokenVerifier(verify TokenVerifier, require bool)                    // 设置 ZMQ 通道的上游令牌校验
 }
 
 type manager struct {
 	mu         sync.RWMutex
 	channeler  Channeler // 使用接口而不是具体实现
 	components map[string]*Component
+	started    context.Context // Start 时传入的 context，非 nil 表示接收器已启动
+
+	// 进程交接后待恢复的会话，channeler 支持交接时恢复
+	resuming    []SessionState
+	resumeGrace time.Duration
+
+	// 上游令牌校验，verifyToken 为 nil 时不校验
+	verifyToken  TokenVerifier
+	requireToken bool
+	verified     map[string]struct{} // 已出示有效令牌的 component
 }
 
 func NewManager(channeler Channeler) Manager {
@@ -27,33 +45,74 @@ func NewManager(channeler Channeler) Manager {
 		mu:         sync.RWMutex{},
 		components: make(map[string]*Component),
 		channeler:  channeler,
+		verified:   make(map[string]struct{}),
 	}
 }
 
 func (m *manager) Start(ctx context.Context) error {
-	// Start receiver that marks components as connected and processes messages
-	m.channeler.StartReceiver(ctx, func(componentID string, data []byte) {
+	m.mu.Lock()
+	m.started = ctx
+	channeler := m.channeler
+	m.mu.Unlock()
+
+	m.startReceiver(ctx, channeler)
+	return nil
+}
+
+// startReceiver 在 channeler 上启动接收器，处理 component 发来的消息
+// 未知 component、无法解析或不符合消息定义的消息返回 error，由 channeler 计入发送方的非法消息数
+func (m *manager) startReceiver(ctx context.Context, channeler Channeler) {
+	channeler.StartReceiver(ctx, func(componentID string, data []byte) error {
 		m.mu.RLock()
 		component, ok := m.components[componentID]
 		m.mu.RUnlock()
 
 		if !ok {
-			logrus.Warnf("component %s not found", componentID)
-			return
+			return fmt.Errorf("component %s not found", componentID)
 		}
 
 		message := &componentpb.Message{}
 		if err := proto.Unmarshal(data, message); err != nil {
-			logrus.Errorf("failed to unmarshal message: %v", err)
-			return
+			return fmt.Errorf("failed to unmarshal message: %w", err)
+		}
+		if err := validateMessage(message); err != nil {
+			return err
 		}
 
 		if message.GetType() == componentpb.MessageType_READY {
 			// TODO: mark component as connected 暂时不用实现，请忽略
-		} else {
+			m.checkReady(componentID, message.GetReady().GetUpstreamToken())
+			if negotiator, ok := channeler.(EncodingNegotiator); ok {
+				negotiator.SetAcceptEncodings(componentID, message.GetReady().GetAcceptEncodings())
+			}
+		} else if m.accepts(componentID) {
 			component.Push(message)
+		} else {
+			logrus.Warnf("Dropping message from component %s: no valid upstream token presented", componentID)
 		}
+		return nil
 	})
+}
+
+// validateMessage 检查消息类型已知且与携带的内容一致
+// READY 的消息体可省略（早期 component 不携带），PAYLOAD 必须携带带类型的 payload
+func validateMessage(message *componentpb.Message) error {
+	switch message.GetType() {
+	case componentpb.MessageType_READY:
+		if message.GetPayload() != nil {
+			return fmt.Errorf("READY message carries a payload")
+		}
+	case componentpb.MessageType_PAYLOAD:
+		payload := message.GetPayload()
+		if payload == nil {
+			return fmt.Errorf("PAYLOAD message without payload")
+		}
+		if payload.GetTypeUrl() == "" {
+			return fmt.Errorf("PAYLOAD message without payload type")
+		}
+	default:
+		return fmt.Errorf("unknown message type %d", message.GetType())
+	}
 	return nil
 }
 
@@ -67,7 +126,10 @@ func (m *manager) AddComponent(ctx context.Context, component *Component) error
 			logrus.Errorf("failed to marshal message: %v, err: %v", msg, err)
 			return
 		}
-		m.channeler.Send(componentID, data)
+		m.mu.RLock()
+		channeler := m.channeler
+		m.mu.RUnlock()
+		channeler.Send(componentID, data)
 	})
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -76,8 +138,54 @@ func (m *manager) AddComponent(ctx context.Context, component *Component) error
 	return nil
 }
 
+// SetChanneler 替换 channeler；若管理器已启动，则立即在新 channeler 上启动接收器，并恢复进程交接待恢复的会话
 func (m *manager) SetChanneler(channeler Channeler) {
 	m.mu.Lock()
-	defer m.mu.Unlock()
 	m.channeler = channeler
+	ctx := m.started
+	m.mu.Unlock()
+
+	if ctx != nil {
+		m.startReceiver(ctx, channeler)
+	}
+	m.resumeSessions()
+}
+
+// GetByProvider 获取部署在指定 provider 上的所有 component
+func (m *manager) GetByProvider(providerID string) []*Component {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	components := make([]*Component, 0)
+	for _, c := range m.components {
+		if c.GetProviderID() == providerID {
+			components = append(components, c)
+		}
+	}
+	return components
+}
+
+// GetAll 获取所有 component
+func (m *manager) GetAll() []*Component {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	components := make([]*Component, 0, len(m.components))
+	for _, c := range m.components {
+		components = append(components, c)
+	}
+	return components
+}
+
+// RemoveComponent 移除 component 的路由，不存在时不做任何事
+func (m *manager) RemoveComponent(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.components, id)
+	delete(m.verified, id)
+}
+
+// Get 按 ID 获取 component，不存在时返回 nil
+func (m *manager) Get(id string) *Component {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.components[id]
 }
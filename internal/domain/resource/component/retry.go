@@ -0,0 +1,71 @@
+package component
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy DeployComponent 的重试策略
+// 只重试 provider.IsRetryable 判定为暂时性的错误，每次重试前重新选择 provider 并等待带抖动的退避时间
+type RetryPolicy struct {
+	MaxAttempts    int           // 总尝试次数（含首次），<= 1 表示不重试
+	InitialBackoff time.Duration // 第一次重试前的退避时间
+	MaxBackoff     time.Duration // 退避时间上限，每次重试翻倍直至该上限
+	Jitter         float64       // 0-1，实际等待时间在 [backoff*(1-Jitter), backoff] 内随机，避免并发部署同时重试
+}
+
+// DefaultRetryPolicy 未配置时使用的重试策略
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 200 * time.Millisecond,
+	MaxBackoff:     2 * time.Second,
+	Jitter:         0.5,
+}
+
+// RetryPolicySetter 支持配置部署重试策略的 Service
+type RetryPolicySetter interface {
+	// SetRetryPolicy 设置部署 component 默认使用的重试策略
+	SetRetryPolicy(policy RetryPolicy)
+}
+
+type retryPolicyCtxKey struct{}
+
+// WithRetryPolicy 为本次部署指定重试策略，覆盖 Service 的默认策略
+func WithRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyCtxKey{}, policy)
+}
+
+// GetRetryPolicy 获取 context 中指定的重试策略
+func GetRetryPolicy(ctx context.Context) (RetryPolicy, bool) {
+	policy, ok := ctx.Value(retryPolicyCtxKey{}).(RetryPolicy)
+	return policy, ok
+}
+
+// backoff 第 attempt 次尝试失败后、下一次尝试前的等待时间
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 1; i < attempt && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if p.MaxBackoff > 0 {
+		backoff = min(backoff, p.MaxBackoff)
+	}
+	if p.Jitter <= 0 || backoff <= 0 {
+		return backoff
+	}
+	window := time.Duration(float64(backoff) * min(p.Jitter, 1))
+	return backoff - time.Duration(rand.Int64N(int64(window)+1))
+}
+
+// waitRetry 等待 d，ctx 结束时提前返回 false
+func waitRetry(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
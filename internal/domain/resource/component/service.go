@@ -2,22 +2,85 @@ package component
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
 
+	"github.com/9triver/iarnet/internal/domain/resource/accounting"
+	"github.com/9triver/iarnet/internal/domain/resource/decision"
 	"github.com/9triver/iarnet/internal/domain/resource/provider"
 	"github.com/9triver/iarnet/internal/domain/resource/types"
+	"github.com/9triver/iarnet/internal/proto/common"
 	"github.com/9triver/iarnet/internal/util"
 	"github.com/sirupsen/logrus"
 )
 
 type Service interface {
 	DeployComponent(ctx context.Context, runtimeEnv types.RuntimeEnv, resourceRequest *types.Info) (*Component, error)
+	// ProposeDeployment 判断本节点能否部署 component 而不实际部署，返回选中 provider 的可用资源
+	ProposeDeployment(ctx context.Context, runtimeEnv types.RuntimeEnv, resourceRequest *types.Info) (*types.Info, error)
+	// EvictProvider 驱逐指定 provider 上的可驱逐 component，并重新调度到其他 provider
+	EvictProvider(ctx context.Context, providerID string) error
+	// UndeployEvicted 删除 provider 重新连接后其上仍保留的已驱逐 component 实例
+	UndeployEvicted(ctx context.Context, providerID string) error
+	// MigrateComponent 将 component 迁移到本节点的指定 provider
+	MigrateComponent(ctx context.Context, componentID, targetProviderID string) error
+	// RedeployComponent 以相同 ID 重新部署 component，使更新后的部署选项（e.g., 上游地址）生效
+	RedeployComponent(ctx context.Context, componentID string) error
+	// ExecComponent 在 component 所在容器内启动调试命令
+	ExecComponent(ctx context.Context, componentID string, opts provider.ExecOptions) (*provider.ExecSession, error)
+	// PortForwardComponent 建立到 component 端口的隧道
+	PortForwardComponent(ctx context.Context, componentID string, port uint32) (*provider.PortForwardConn, error)
+	// UndeployComponent 删除 provider 上的 component 实例并移除其路由
+	UndeployComponent(ctx context.Context, componentID string) error
 }
 
+// EnvTemplateSetter 支持部署时渲染环境变量模板的 Service
+type EnvTemplateSetter interface {
+	// SetEnvTemplate 设置部署时渲染的环境变量模板及本节点元数据
+	SetEnvTemplate(tmpl *EnvTemplate, node NodeMetadata)
+}
+
+// PlacementPlanner 支持可行性检查的 Service
+type PlacementPlanner interface {
+	// PlanPlacement 为 component 选择本节点的 provider 而不实际部署
+	// context 中附加了 provider.PlanLedger 时，账本中已假设放置的资源视为已占用
+	PlanPlacement(ctx context.Context, runtimeEnv types.RuntimeEnv, resourceRequest *types.Info) (*provider.Provider, error)
+}
+
+// ImageArchitectureSetter 支持按镜像架构筛选 provider 的 Service
+type ImageArchitectureSetter interface {
+	// SetImageArchitectures 设置各运行时环境镜像支持的 CPU 架构，未设置的镜像视为多架构镜像
+	SetImageArchitectures(archs map[string][]string)
+}
+
+// abortedCleanupTimeout 清理中断部署的超时
+const abortedCleanupTimeout = 10 * time.Second
+
 type componentService struct {
 	manager         Manager
 	providerService provider.Service
 	images          map[types.RuntimeEnv]string
+	architectures   map[types.RuntimeEnv][]string // 运行时环境 -> 镜像支持的 CPU 架构
+	envTemplate     *EnvTemplate
+	node            NodeMetadata
+	retryPolicy     RetryPolicy
+
+	// component 数量上限，pending 为已占用名额但尚未完成部署的 component 数（按 provider）
+	densityMu sync.Mutex
+	density   DensityLimits
+	pending   map[string]int
+
+	// sidecar 注入规则
+	injectionMu sync.RWMutex
+	injection   []*injectionRule
+
+	// 已驱逐并重新调度的 component（按原 provider），原 provider 重新连接后删除其上遗留的实例
+	evictedMu sync.Mutex
+	evicted   map[string][]string
 }
 
 func NewService(manager Manager, providerService provider.Service, componentImages map[string]string) Service {
@@ -25,6 +88,9 @@ func NewService(manager Manager, providerService provider.Service, componentImag
 		manager:         manager,
 		providerService: providerService,
 		images:          componentImages,
+		retryPolicy:     DefaultRetryPolicy,
+		pending:         make(map[string]int),
+		evicted:         make(map[string][]string),
 	}
 }
 
@@ -33,30 +99,418 @@ func (c *componentService) DeployComponent(ctx context.Context, runtimeEnv types
 		return nil, fmt.Errorf("resource request is required")
 	}
 
-	image, ok := c.images[runtimeEnv]
+	image, ok := GetComponentImage(ctx)
 	if !ok {
-		return nil, fmt.Errorf("image for runtime environment %s not found", runtimeEnv)
+		if image, ok = c.images[runtimeEnv]; !ok {
+			return nil, fmt.Errorf("image for runtime environment %s not found", runtimeEnv)
+		}
 	}
 
-	id := util.GenIDWith("comp.")
+	id, ok := GetComponentID(ctx)
+	if !ok {
+		id = util.GenIDWith("comp.")
+	} else if c.manager.Get(id) != nil {
+		return nil, fmt.Errorf("component %s already exists", id)
+	}
 	component := NewComponent(id, image, resourceRequest)
+	component.rememberDeployOptions(ctx)
+	if err := c.injectSidecars(ctx, runtimeEnv, component); err != nil {
+		return nil, err
+	}
+	ctx = component.withDeployOptions(ctx)
 
 	if err := c.manager.AddComponent(ctx, component); err != nil {
 		return nil, fmt.Errorf("failed to add component to manager: %w", err)
 	}
 
+	if err := c.placeWithRetry(ctx, component); err != nil {
+		c.manager.RemoveComponent(id)
+		return nil, err
+	}
+
+	// TODO: 保存到 repository
+
+	return component, nil
+}
+
+func (c *componentService) ProposeDeployment(ctx context.Context, runtimeEnv types.RuntimeEnv, resourceRequest *types.Info) (*types.Info, error) {
+	p, err := c.PlanPlacement(ctx, runtimeEnv, resourceRequest)
+	if err != nil {
+		return nil, err
+	}
+	return p.GetAvailable(ctx)
+}
+
+// PlanPlacement 为 component 选择本节点的 provider 而不实际部署
+func (c *componentService) PlanPlacement(ctx context.Context, runtimeEnv types.RuntimeEnv, resourceRequest *types.Info) (*provider.Provider, error) {
+	if resourceRequest == nil {
+		return nil, fmt.Errorf("resource request is required")
+	}
+	image, ok := c.images[runtimeEnv]
+	if !ok {
+		return nil, fmt.Errorf("image for runtime environment %s not found", runtimeEnv)
+	}
+
+	ctx, err := c.withDensityLimits(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	// 按注入 sidecar 后的合计资源与能力要求选择 provider
+	if rules := c.matchInjection(ctx, runtimeEnv); len(rules) > 0 {
+		sidecars, _ := provider.GetSidecars(ctx)
+		sidecars = slices.Clone(sidecars)
+		for _, rule := range rules {
+			sidecars = append(sidecars, rule.injectedSidecar())
+		}
+		extra := provider.SidecarResources(sidecars)
+		request := *resourceRequest
+		request.CPU += extra.CPU
+		request.Memory += extra.Memory
+		request.GPU += extra.GPU
+		resourceRequest = &request
+		ctx = provider.WithSidecars(ctx, sidecars)
+	}
+	p, err := c.providerService.FindAvailableProvider(c.withImageArchitectures(ctx, image), resourceRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find available provider: %w", err)
+	}
+	return p, nil
+}
+
+func (c *componentService) SetEnvTemplate(tmpl *EnvTemplate, node NodeMetadata) {
+	c.envTemplate = tmpl
+	c.node = node
+}
+
+func (c *componentService) SetRetryPolicy(policy RetryPolicy) {
+	c.retryPolicy = policy
+}
+
+func (c *componentService) SetImageArchitectures(archs map[string][]string) {
+	c.architectures = make(map[types.RuntimeEnv][]string, len(archs))
+	for runtimeEnv, list := range archs {
+		normalized := make([]string, 0, len(list))
+		for _, arch := range list {
+			normalized = append(normalized, types.NormalizeArchitecture(arch))
+		}
+		c.architectures[runtimeEnv] = normalized
+	}
+}
+
+// withImageArchitectures 在 context 中附加镜像支持的 CPU 架构
+// component 只记录镜像名，因此按镜像反查其所属运行时环境的架构配置
+func (c *componentService) withImageArchitectures(ctx context.Context, image string) context.Context {
+	for runtimeEnv, img := range c.images {
+		if img == image {
+			return provider.WithImageArchitectures(ctx, c.architectures[runtimeEnv])
+		}
+	}
+	return ctx
+}
+
+// envData 环境变量模板在选中 provider 后可引用的元数据
+func (c *componentService) envData(p *provider.Provider, component *Component) EnvTemplateData {
+	return EnvTemplateData{
+		NodeMetadata: c.node,
+		ProviderID:   p.GetID(),
+		ProviderName: p.GetName(),
+		ProviderHost: p.GetHost(),
+		ComponentID:  component.GetID(),
+		Image:        component.GetImage(),
+	}
+}
+
+// renderEnv 使用选中 provider 的元数据渲染 component 环境变量
+func (c *componentService) renderEnv(p *provider.Provider, component *Component) (map[string]string, error) {
+	return c.envTemplate.Render(c.envData(p, component))
+}
+
+// place 为 component 查找可用的 provider 并部署
+func (c *componentService) place(ctx context.Context, component *Component) error {
+	resourceRequest := component.GetResourceUsage()
+	ctx = c.withImageArchitectures(ctx, component.GetImage())
+	ctx, err := c.withDensityLimits(ctx, component)
+	if err != nil {
+		return err
+	}
+
 	// 通过 provider service 查找可用的 provider
 	p, err := c.providerService.FindAvailableProvider(ctx, resourceRequest)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find available provider: %w", err)
+		return fmt.Errorf("failed to find available provider: %w", err)
+	}
+	release, err := c.admitDensity(p, component)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return c.deployTo(ctx, p, component)
+}
+
+// placeWithRetry 按重试策略放置 component，只重试暂时性错误，每次重试都重新选择 provider
+func (c *componentService) placeWithRetry(ctx context.Context, component *Component) error {
+	policy, ok := GetRetryPolicy(ctx)
+	if !ok {
+		policy = c.retryPolicy
+	}
+	for attempt := 1; ; attempt++ {
+		err := c.place(ctx, component)
+		if err == nil || attempt >= policy.MaxAttempts || !provider.IsRetryable(err) {
+			return err
+		}
+		wait := policy.backoff(attempt)
+		logrus.Warnf("Deployment of component %s failed (attempt %d/%d): %v, retrying in %v",
+			component.GetID(), attempt, policy.MaxAttempts, err, wait)
+		if !waitRetry(ctx, wait) {
+			return err
+		}
+	}
+}
+
+// deployTo 将 component 部署到指定 provider
+func (c *componentService) deployTo(ctx context.Context, p *provider.Provider, component *Component) error {
+	env, err := c.renderEnv(p, component)
+	if err != nil {
+		return fmt.Errorf("failed to render env for component %s: %w", component.GetID(), err)
+	}
+	// 调用方为本次部署指定的额外环境变量优先于模板渲染的结果
+	if extra, ok := provider.GetDeploymentEnv(ctx); ok {
+		if env == nil {
+			env = make(map[string]string, len(extra))
+		}
+		maps.Copy(env, extra)
+	}
+	// 重新调度的 context 不带所属应用，provider 签发的上游令牌需要绑定应用
+	if appID := component.GetAppID(); appID != "" && accounting.GetApplication(ctx) == "" {
+		ctx = accounting.WithApplication(ctx, appID)
+	}
+	ctx, env, err = c.renderInjection(ctx, c.envData(p, component), component, env)
+	if err != nil {
+		return fmt.Errorf("failed to render injected sidecars for component %s: %w", component.GetID(), err)
 	}
 	logrus.Infof("Deploying component on provider %s", p.GetID())
-	if err := p.Deploy(ctx, id, image, resourceRequest); err != nil {
-		return nil, fmt.Errorf("failed to deploy component on provider %s: %w", p.GetID(), err)
+	start := time.Now()
+	err = p.Deploy(provider.WithDeploymentEnv(ctx, env), component.GetID(), component.GetImage(), component.GetResourceUsage())
+	decision.Since(ctx, decision.StageProviderDeploy, start)
+	// 调用方取消或超时导致的失败与 provider 无关，不计入历史放置统计
+	if err == nil || ctx.Err() == nil {
+		c.providerService.RecordPlacement(component.GetResourceUsage(), p.GetID(), err == nil, time.Since(start))
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			c.cleanupAborted(ctx, p, component.GetID())
+		}
+		return fmt.Errorf("failed to deploy component on provider %s: %w", p.GetID(), err)
 	}
 	component.SetProviderID(p.GetID())
+	component.SetEvictable(p.IsBestEffort())
+	return nil
+}
 
-	// TODO: 保存到 repository
+// cleanupAborted 部署因取消或超时中断时，provider 可能已创建了实例，尽力删除
+// 调用方 ctx 已结束，因此使用独立的超时
+func (c *componentService) cleanupAborted(ctx context.Context, p *provider.Provider, componentID string) {
+	if !p.SupportsCapability(common.CapUndeploy) {
+		logrus.Warnf("Deployment of component %s was aborted, provider %s cannot undeploy the partially created instance", componentID, p.GetID())
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), abortedCleanupTimeout)
+	defer cancel()
+	if err := p.Undeploy(ctx, componentID); err != nil {
+		logrus.Warnf("Failed to clean up aborted deployment of component %s on provider %s: %v", componentID, p.GetID(), err)
+		return
+	}
+	logrus.Infof("Cleaned up aborted deployment of component %s on provider %s", componentID, p.GetID())
+}
 
-	return component, nil
+// UndeployComponent 删除 provider 上的 component 实例并移除其路由
+// 尚未放置到 provider 上的 component 只移除路由
+func (c *componentService) UndeployComponent(ctx context.Context, componentID string) error {
+	component := c.manager.Get(componentID)
+	if component == nil {
+		return fmt.Errorf("component %s not found", componentID)
+	}
+	if providerID := component.GetProviderID(); providerID != "" {
+		p := c.providerService.GetProvider(providerID)
+		if p == nil {
+			return fmt.Errorf("provider %s of component %s not found", providerID, componentID)
+		}
+		if err := p.Undeploy(ctx, componentID); err != nil {
+			return err
+		}
+	}
+	c.manager.RemoveComponent(componentID)
+	logrus.Infof("Component %s undeployed", componentID)
+	return nil
+}
+
+// MigrateComponent 将 component 迁移到指定 provider
+// 先在目标 provider 上以相同 ID 部署新实例，成功后再删除原实例，因此上层持有的 component 引用无需变更
+func (c *componentService) MigrateComponent(ctx context.Context, componentID, targetProviderID string) error {
+	component := c.manager.Get(componentID)
+	if component == nil {
+		return fmt.Errorf("component %s not found", componentID)
+	}
+	sourceProviderID := component.GetProviderID()
+	if sourceProviderID == targetProviderID {
+		return fmt.Errorf("component %s is already on provider %s", componentID, targetProviderID)
+	}
+	target := c.providerService.GetProvider(targetProviderID)
+	if target == nil {
+		return fmt.Errorf("provider %s not found", targetProviderID)
+	}
+	if target.GetStatus() != types.ProviderStatusConnected {
+		return fmt.Errorf("provider %s is not connected", targetProviderID)
+	}
+	// 旧版 provider 不支持 Undeploy，迁移后原实例无法回收，因此不迁移其上的 component
+	if source := c.providerService.GetProvider(sourceProviderID); source != nil && !source.SupportsCapability(common.CapUndeploy) {
+		return fmt.Errorf("%w: provider %s cannot undeploy component %s", provider.ErrCapabilityUnsupported, sourceProviderID, componentID)
+	}
+
+	ctx = c.withImageArchitectures(ctx, component.GetImage())
+	if archs, _ := provider.GetImageArchitectures(ctx); !types.ArchitectureCompatible(target.GetArchitectures(), archs) {
+		return fmt.Errorf("provider %s architectures %v cannot run image %s (%v)", targetProviderID, target.GetArchitectures(), component.GetImage(), archs)
+	}
+
+	release, err := c.admitDensity(target, component)
+	if err != nil {
+		return err
+	}
+	defer release()
+	if err := c.deployTo(component.withDeployOptions(ctx), target, component); err != nil {
+		return err
+	}
+	if source := c.providerService.GetProvider(sourceProviderID); source != nil {
+		if err := source.Undeploy(ctx, componentID); err != nil {
+			logrus.Warnf("Component %s migrated but failed to undeploy it from provider %s: %v", componentID, sourceProviderID, err)
+		}
+	}
+	logrus.Infof("Component %s migrated from provider %s to %s", componentID, sourceProviderID, targetProviderID)
+	component.notifyRescheduled()
+	return nil
+}
+
+// RedeployComponent 以相同 ID 在原 provider 上重新部署 component，使更新后的部署选项（e.g., 上游地址）生效
+// 原 provider 已不可用或重新部署失败时重新调度到其他 provider，均失败时移除其路由
+func (c *componentService) RedeployComponent(ctx context.Context, componentID string) error {
+	component := c.manager.Get(componentID)
+	if component == nil {
+		return fmt.Errorf("component %s not found", componentID)
+	}
+	providerID := component.GetProviderID()
+	p := c.providerService.GetProvider(providerID)
+	if p != nil {
+		// 旧版 provider 不支持 Undeploy，原实例无法回收，因此不重新部署其上的 component
+		if !p.SupportsCapability(common.CapUndeploy) {
+			return fmt.Errorf("%w: provider %s cannot undeploy component %s", provider.ErrCapabilityUnsupported, providerID, componentID)
+		}
+		if err := p.Undeploy(ctx, componentID); err != nil {
+			return fmt.Errorf("failed to undeploy component %s from provider %s: %w", componentID, providerID, err)
+		}
+	}
+
+	ctx = component.withDeployOptions(ctx)
+	if p != nil && p.GetStatus() == types.ProviderStatusConnected {
+		err := c.deployTo(ctx, p, component)
+		if err == nil {
+			logrus.Infof("Component %s redeployed on provider %s", componentID, providerID)
+			component.notifyRescheduled()
+			return nil
+		}
+		logrus.Warnf("Failed to redeploy component %s on provider %s: %v, rescheduling", componentID, providerID, err)
+	}
+	if err := c.place(ctx, component); err != nil {
+		c.manager.RemoveComponent(componentID)
+		return fmt.Errorf("failed to redeploy component %s: %w", componentID, err)
+	}
+	logrus.Infof("Component %s redeployed on provider %s", componentID, component.GetProviderID())
+	component.notifyRescheduled()
+	return nil
+}
+
+// EvictProvider 驱逐指定 provider 上的可驱逐 component，并重新调度到其他 provider
+// 重新调度时沿用原 component ID，因此上层持有的 component 引用无需变更
+func (c *componentService) EvictProvider(ctx context.Context, providerID string) error {
+	var errs []error
+	for _, component := range c.manager.GetByProvider(providerID) {
+		if !component.IsEvictable() {
+			continue
+		}
+		logrus.Infof("Component %s evicted from provider %s, rescheduling", component.GetID(), providerID)
+		if err := c.place(component.withDeployOptions(ctx), component); err != nil {
+			logrus.Errorf("Failed to reschedule evicted component %s: %v", component.GetID(), err)
+			errs = append(errs, fmt.Errorf("component %s: %w", component.GetID(), err))
+			continue
+		}
+		component.notifyRescheduled()
+		c.recordEvicted(providerID, component.GetID())
+	}
+	return errors.Join(errs...)
+}
+
+// recordEvicted 记录已从 provider 驱逐的 component，provider 下线时无法删除其上的实例
+func (c *componentService) recordEvicted(providerID, componentID string) {
+	c.evictedMu.Lock()
+	defer c.evictedMu.Unlock()
+	c.evicted[providerID] = append(c.evicted[providerID], componentID)
+}
+
+// UndeployEvicted 删除 provider 重新连接后其上仍保留的已驱逐 component 实例
+// component 已被调度回该 provider 时不删除；删除失败的实例留待下次重新连接时重试
+func (c *componentService) UndeployEvicted(ctx context.Context, providerID string) error {
+	c.evictedMu.Lock()
+	componentIDs := c.evicted[providerID]
+	delete(c.evicted, providerID)
+	c.evictedMu.Unlock()
+	if len(componentIDs) == 0 {
+		return nil
+	}
+
+	p := c.providerService.GetProvider(providerID)
+	if p == nil {
+		return fmt.Errorf("provider %s not found", providerID)
+	}
+	var errs []error
+	for _, componentID := range componentIDs {
+		if component := c.manager.Get(componentID); component != nil && component.GetProviderID() == providerID {
+			continue
+		}
+		if err := p.Undeploy(ctx, componentID); err != nil {
+			c.recordEvicted(providerID, componentID)
+			errs = append(errs, fmt.Errorf("component %s: %w", componentID, err))
+			continue
+		}
+		logrus.Infof("Removed instance of evicted component %s left on provider %s", componentID, providerID)
+	}
+	return errors.Join(errs...)
+}
+
+func (c *componentService) ExecComponent(ctx context.Context, componentID string, opts provider.ExecOptions) (*provider.ExecSession, error) {
+	p, err := c.providerOf(componentID)
+	if err != nil {
+		return nil, err
+	}
+	return p.Exec(ctx, componentID, opts)
+}
+
+func (c *componentService) PortForwardComponent(ctx context.Context, componentID string, port uint32) (*provider.PortForwardConn, error) {
+	p, err := c.providerOf(componentID)
+	if err != nil {
+		return nil, err
+	}
+	return p.PortForward(ctx, componentID, port)
+}
+
+// providerOf 获取 component 当前所在的 provider
+func (c *componentService) providerOf(componentID string) (*provider.Provider, error) {
+	component := c.manager.Get(componentID)
+	if component == nil {
+		return nil, fmt.Errorf("component %s not found", componentID)
+	}
+	providerID := component.GetProviderID()
+	p := c.providerService.GetProvider(providerID)
+	if p == nil {
+		return nil, fmt.Errorf("provider %s of component %s not found", providerID, componentID)
+	}
+	return p, nil
 }
@@ -0,0 +1,189 @@
+package component
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/9triver/iarnet/internal/domain/resource/accounting"
+	"github.com/9triver/iarnet/internal/domain/resource/provider"
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+)
+
+// SidecarInjectionRule 运维配置的 sidecar 注入规则，e.g., 为所有 component 附加日志采集或安全代理
+// 命中规则的 component 部署时自动附加 sidecar，资源计入 component 的资源请求
+type SidecarInjectionRule struct {
+	Name         string
+	RuntimeEnvs  []types.RuntimeEnv // 只注入这些运行时环境的 component，为空表示全部
+	Applications []string           // 只注入这些应用的 component，为空表示全部
+
+	// Sidecar 的 Env 与 ComponentEnv 均为模板，可引用的字段与 component_env 相同，在选定 provider 后渲染
+	Sidecar      provider.Sidecar
+	ComponentEnv map[string]string      // 注入主容器的环境变量，e.g., "HTTP_PROXY": "http://127.0.0.1:15001"
+	Volumes      []provider.VolumeMount // 同时挂载到主容器与 sidecar，e.g., 日志目录
+}
+
+// SidecarInjectionSetter 支持 sidecar 注入的 Service
+type SidecarInjectionSetter interface {
+	// SetSidecarInjection 设置 sidecar 注入规则，规则按顺序应用
+	SetSidecarInjection(rules []SidecarInjectionRule) error
+}
+
+// injectionRule 解析后的注入规则
+type injectionRule struct {
+	SidecarInjectionRule
+	sidecarEnv   *EnvTemplate
+	componentEnv *EnvTemplate
+}
+
+func (c *componentService) SetSidecarInjection(rules []SidecarInjectionRule) error {
+	parsed := make([]*injectionRule, 0, len(rules))
+	sidecars := make([]provider.Sidecar, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Name == "" {
+			return fmt.Errorf("sidecar injection rule name is required")
+		}
+		for _, env := range rule.RuntimeEnvs {
+			if _, ok := c.images[env]; !ok {
+				return fmt.Errorf("sidecar injection rule %s: unknown runtime environment %s", rule.Name, env)
+			}
+		}
+		sidecarEnv, err := ParseEnvTemplate(rule.Sidecar.Env)
+		if err != nil {
+			return fmt.Errorf("sidecar injection rule %s: sidecar env: %w", rule.Name, err)
+		}
+		componentEnv, err := ParseEnvTemplate(rule.ComponentEnv)
+		if err != nil {
+			return fmt.Errorf("sidecar injection rule %s: component env: %w", rule.Name, err)
+		}
+		if err := provider.ValidateVolumeMounts(rule.Volumes); err != nil {
+			return fmt.Errorf("sidecar injection rule %s: %w", rule.Name, err)
+		}
+		parsed = append(parsed, &injectionRule{SidecarInjectionRule: rule, sidecarEnv: sidecarEnv, componentEnv: componentEnv})
+		sidecars = append(sidecars, rule.injectedSidecar())
+	}
+	// 同一 component 可能命中全部规则，sidecar 名称需互不重复
+	if err := provider.ValidateSidecars(sidecars); err != nil {
+		return err
+	}
+
+	c.injectionMu.Lock()
+	c.injection = parsed
+	c.injectionMu.Unlock()
+	return nil
+}
+
+// injectedSidecar 注入的 sidecar，共享卷同时挂载到 sidecar
+func (r *SidecarInjectionRule) injectedSidecar() provider.Sidecar {
+	sc := r.Sidecar
+	sc.Volumes = slices.Concat(sc.Volumes, r.Volumes)
+	return sc
+}
+
+// matches 判断部署是否命中规则
+func (r *injectionRule) matches(ctx context.Context, runtimeEnv types.RuntimeEnv) bool {
+	if len(r.RuntimeEnvs) > 0 && !slices.Contains(r.RuntimeEnvs, runtimeEnv) {
+		return false
+	}
+	if len(r.Applications) > 0 && !slices.Contains(r.Applications, accounting.GetApplication(ctx)) {
+		return false
+	}
+	return true
+}
+
+// matchInjection 返回部署命中的注入规则
+func (c *componentService) matchInjection(ctx context.Context, runtimeEnv types.RuntimeEnv) []*injectionRule {
+	c.injectionMu.RLock()
+	defer c.injectionMu.RUnlock()
+	var matched []*injectionRule
+	for _, rule := range c.injection {
+		if rule.matches(ctx, runtimeEnv) {
+			matched = append(matched, rule)
+		}
+	}
+	return matched
+}
+
+// injectionFor 按名称查找注入规则，规则已被移除时返回 nil
+func (c *componentService) injectionFor(name string) *injectionRule {
+	c.injectionMu.RLock()
+	defer c.injectionMu.RUnlock()
+	for _, rule := range c.injection {
+		if rule.Name == name {
+			return rule
+		}
+	}
+	return nil
+}
+
+// injectSidecars 为 component 附加命中规则的 sidecar 与共享卷，并将其资源计入 component 的资源请求
+func (c *componentService) injectSidecars(ctx context.Context, runtimeEnv types.RuntimeEnv, component *Component) error {
+	rules := c.matchInjection(ctx, runtimeEnv)
+	if len(rules) == 0 {
+		return nil
+	}
+
+	component.mu.Lock()
+	defer component.mu.Unlock()
+	sidecars := slices.Clone(component.sidecars)
+	volumes := slices.Clone(component.volumes)
+	injected := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		sidecars = append(sidecars, rule.injectedSidecar())
+		volumes = append(volumes, rule.Volumes...)
+		injected = append(injected, rule.Name)
+	}
+	if err := provider.ValidateSidecars(sidecars); err != nil {
+		return fmt.Errorf("failed to inject sidecars: %w", err)
+	}
+	if err := provider.ValidateVolumeMounts(volumes); err != nil {
+		return fmt.Errorf("failed to inject sidecar volumes: %w", err)
+	}
+
+	extra := provider.SidecarResources(sidecars[len(component.sidecars):])
+	usage := *component.resourceUsage
+	usage.CPU += extra.CPU
+	usage.Memory += extra.Memory
+	usage.GPU += extra.GPU
+	component.resourceUsage = &usage
+	component.sidecars = sidecars
+	component.volumes = volumes
+	component.injected = injected
+	return nil
+}
+
+// renderInjection 使用选中 provider 的元数据渲染注入 sidecar 的环境变量，并合并注入主容器的环境变量
+func (c *componentService) renderInjection(ctx context.Context, data EnvTemplateData, component *Component, env map[string]string) (context.Context, map[string]string, error) {
+	injected := component.getInjected()
+	if len(injected) == 0 {
+		return ctx, env, nil
+	}
+
+	sidecars := slices.Clone(component.GetSidecars())
+	merged := maps.Clone(env)
+	if merged == nil {
+		merged = make(map[string]string)
+	}
+	for _, name := range injected {
+		rule := c.injectionFor(name)
+		if rule == nil {
+			return ctx, nil, fmt.Errorf("sidecar injection rule %s no longer exists", name)
+		}
+		sidecarEnv, err := rule.sidecarEnv.Render(data)
+		if err != nil {
+			return ctx, nil, fmt.Errorf("sidecar injection rule %s: %w", name, err)
+		}
+		componentEnv, err := rule.componentEnv.Render(data)
+		if err != nil {
+			return ctx, nil, fmt.Errorf("sidecar injection rule %s: %w", name, err)
+		}
+		maps.Copy(merged, componentEnv)
+		for i := range sidecars {
+			if sidecars[i].Name == rule.Sidecar.Name {
+				sidecars[i].Env = sidecarEnv
+			}
+		}
+	}
+	return provider.WithSidecars(ctx, sidecars), merged, nil
+}
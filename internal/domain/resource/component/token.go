@@ -0,0 +1,44 @@
+package component
+
+import "github.com/sirupsen/logrus"
+
+// TokenVerifier 校验 component 在 Ready 消息中出示的上游令牌
+type TokenVerifier func(componentID, token string) error
+
+// SetTokenVerifier 设置 ZMQ 通道的上游令牌校验
+// component 在 Ready 消息中出示令牌；require 为 true 时丢弃未通过校验的 component 发来的消息。
+// 进程交接恢复的 component 视为已通过校验：旧进程按同样的策略接收过它们的 Ready
+func (m *manager) SetTokenVerifier(verify TokenVerifier, require bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.verifyToken = verify
+	m.requireToken = require
+}
+
+// checkReady 校验 Ready 消息中的令牌并记录结果
+func (m *manager) checkReady(componentID, token string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.verifyToken == nil {
+		return
+	}
+	if err := m.verifyToken(componentID, token); err != nil {
+		delete(m.verified, componentID)
+		if token != "" || m.requireToken {
+			logrus.Warnf("Component %s presented an invalid upstream token: %v", componentID, err)
+		}
+		return
+	}
+	m.verified[componentID] = struct{}{}
+}
+
+// accepts 判断是否处理 component 发来的消息
+func (m *manager) accepts(componentID string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if !m.requireToken {
+		return true
+	}
+	_, ok := m.verified[componentID]
+	return ok
+}
@@ -0,0 +1,136 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/component"
+	"github.com/9triver/iarnet/internal/domain/resource/discovery"
+	"github.com/9triver/iarnet/internal/domain/resource/scheduler"
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+)
+
+// componentQueryTimeout 查询单个节点 component 的超时
+const componentQueryTimeout = 5 * time.Second
+
+// ClusterComponents 整个域满足条件的 component
+type ClusterComponents struct {
+	Components []scheduler.ComponentSummary // 本节点在前，其余节点按节点 ID 排列，节点内按 component ID 排序
+	Nodes      []NodeComponentsResult       // 各节点的查询结果，本节点在前
+}
+
+// NodeComponentsResult 单个节点的查询结果
+type NodeComponentsResult struct {
+	NodeID     string
+	NodeName   string
+	Components int    // 满足条件的 component 数
+	Error      string // 查询失败或节点不支持时的原因，此时该节点的 component 不在结果中
+}
+
+// ListComponents 按条件列举本节点上运行的 component，按 component ID 排序
+// 委托给同域其他节点的 component 由其所在节点列举；委托给全局调度器的 component 位于其他域，不在结果中
+func (m *Manager) ListComponents(ctx context.Context, query scheduler.ComponentQuery) (*scheduler.ComponentList, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	list := &scheduler.ComponentList{NodeID: m.nodeID, NodeName: m.name}
+	for _, comp := range m.GetAllComponents() {
+		if nodeID, _ := m.placementOf(comp); nodeID != m.nodeID {
+			continue
+		}
+		summary := m.componentSummary(comp)
+		if query.Match(&summary) {
+			list.Components = append(list.Components, summary)
+		}
+	}
+	sort.Slice(list.Components, func(i, j int) bool { return list.Components[i].ID < list.Components[j].ID })
+	return list, nil
+}
+
+// componentSummary 汇总本节点 component 的应用、标签、所在 provider 与状态
+func (m *Manager) componentSummary(comp *component.Component) scheduler.ComponentSummary {
+	summary := scheduler.ComponentSummary{
+		ID:         comp.GetID(),
+		Image:      comp.GetImage(),
+		AppID:      comp.GetAppID(),
+		Labels:     comp.GetLabels(),
+		NodeID:     m.nodeID,
+		NodeName:   m.name,
+		ProviderID: comp.GetProviderID(),
+		State:      scheduler.ComponentStateDeploying,
+		Resources:  comp.GetResourceUsage(),
+		Evictable:  comp.IsEvictable(),
+		Origin:     comp.GetOrigin(),
+	}
+	if summary.ProviderID == "" {
+		return summary
+	}
+	summary.State = scheduler.ComponentStateUnreachable
+	if p := m.providerService.GetProvider(summary.ProviderID); p != nil {
+		summary.ProviderType = string(p.GetType())
+		if p.GetStatus() == types.ProviderStatusConnected {
+			summary.State = scheduler.ComponentStateRunning
+		}
+	}
+	return summary
+}
+
+// ListClusterComponents 按条件列举整个域的 component：本节点直接列举，同域其他节点通过 scheduler RPC 并行查询
+// 查询失败、不支持或处于 suspect 状态的节点记录在 Nodes 中，不影响其他节点的结果
+func (m *Manager) ListClusterComponents(ctx context.Context, query scheduler.ComponentQuery) (*ClusterComponents, error) {
+	local, err := m.ListComponents(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	result := &ClusterComponents{
+		Components: local.Components,
+		Nodes: []NodeComponentsResult{{
+			NodeID:     m.nodeID,
+			NodeName:   m.name,
+			Components: len(local.Components),
+		}},
+	}
+
+	var peers []*discovery.PeerNode
+	if m.discoveryService != nil && m.schedulerService != nil {
+		for _, node := range m.discoveryService.GetKnownNodes() {
+			if node.NodeID != m.nodeID {
+				peers = append(peers, node)
+			}
+		}
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i].NodeID < peers[j].NodeID })
+
+	remote := make([]*scheduler.ComponentList, len(peers))
+	errs := make([]error, len(peers))
+	var wg sync.WaitGroup
+	for i, node := range peers {
+		if node.Liveness == discovery.NodeLivenessSuspect {
+			errs[i] = errors.New("node is suspect")
+			continue
+		}
+		wg.Add(1)
+		go func(i int, node *discovery.PeerNode) {
+			defer wg.Done()
+			nodeCtx, cancel := context.WithTimeout(ctx, componentQueryTimeout)
+			defer cancel()
+			remote[i], errs[i] = m.schedulerService.ListRemoteComponents(nodeCtx, node.NodeID, peerSchedulerAddress(node), query)
+		}(i, node)
+	}
+	wg.Wait()
+
+	for i, node := range peers {
+		nodeResult := NodeComponentsResult{NodeID: node.NodeID, NodeName: node.NodeName}
+		if errs[i] != nil {
+			nodeResult.Error = errs[i].Error()
+		} else {
+			nodeResult.Components = len(remote[i].Components)
+			result.Components = append(result.Components, remote[i].Components...)
+		}
+		result.Nodes = append(result.Nodes, nodeResult)
+	}
+	return result, nil
+}
@@ -0,0 +1,57 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/9triver/iarnet/internal/domain/resource/provider"
+	"github.com/9triver/iarnet/internal/proto/common"
+	"github.com/sirupsen/logrus"
+)
+
+// GetComponentUsage 查询本节点 component 的实时资源使用情况
+// 委托到其他节点的 component 需在其所在节点上查询
+func (m *Manager) GetComponentUsage(ctx context.Context, componentID string) (*provider.ComponentUsage, error) {
+	comp := m.componentManager.Get(componentID)
+	if comp == nil {
+		return nil, fmt.Errorf("component %s not found", componentID)
+	}
+	nodeID, providerID := m.placementOf(comp)
+	if nodeID != m.nodeID {
+		return nil, fmt.Errorf("component %s is deployed on node %s, query its usage there", componentID, nodeID)
+	}
+	if providerID == "" {
+		return nil, fmt.Errorf("component %s has not been placed on a provider yet", componentID)
+	}
+	p := m.providerService.GetProvider(providerID)
+	if p == nil {
+		return nil, fmt.Errorf("provider %s of component %s not found", providerID, componentID)
+	}
+
+	usages, err := p.GetComponentUsage(ctx, componentID)
+	if err != nil {
+		return nil, err
+	}
+	if len(usages) == 0 {
+		return nil, fmt.Errorf("component %s is not running on provider %s", componentID, providerID)
+	}
+	return usages[0], nil
+}
+
+// ListComponentUsage 查询本节点所有 provider 上运行中 component 的实时资源使用情况
+// 不支持按 component 上报的 provider 与查询失败的 provider 被跳过
+func (m *Manager) ListComponentUsage(ctx context.Context) []*provider.ComponentUsage {
+	var usages []*provider.ComponentUsage
+	for _, p := range m.providerService.GetAllProviders() {
+		if !p.SupportsCapability(common.CapComponentUsage) {
+			continue
+		}
+		providerUsages, err := p.GetComponentUsage(ctx)
+		if err != nil {
+			logrus.Warnf("Failed to get component usage from provider %s: %v", p.GetID(), err)
+			continue
+		}
+		usages = append(usages, providerUsages...)
+	}
+	return usages
+}
@@ -0,0 +1,66 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DeployStage 部署链路中的阶段，用于标识超时发生的位置
+type DeployStage string
+
+const (
+	StageDiscoveryQuery DeployStage = "discovery-query" // 通过 discovery 查询候选节点
+	StagePropose        DeployStage = "propose"         // 向候选节点发送部署探测
+	StageCommit         DeployStage = "commit"          // 在接受探测的节点上实际部署
+	StageProviderDeploy DeployStage = "provider-deploy" // 在本地 provider 上部署
+	StageGlobalSchedule DeployStage = "global-schedule" // 委托给全局调度器
+)
+
+// DeadlineExceededError 调用方 ctx 的截止时间在部署链路的某个阶段耗尽
+// 可通过 errors.Is(err, context.DeadlineExceeded) 判断，Stage 指明超时发生的阶段
+type DeadlineExceededError struct {
+	Stage DeployStage
+	Err   error
+}
+
+func (e *DeadlineExceededError) Error() string {
+	return fmt.Sprintf("deployment deadline exceeded during %s: %v", e.Stage, e.Err)
+}
+
+func (e *DeadlineExceededError) Unwrap() []error {
+	return []error{context.DeadlineExceeded, e.Err}
+}
+
+// deadlineError 若 ctx 截止时间已到或 err 由超时引起，返回带阶段信息的 DeadlineExceededError，否则返回 nil
+// 已经是 DeadlineExceededError 的错误原样返回，保留最早超时的阶段
+func deadlineError(ctx context.Context, stage DeployStage, err error) error {
+	var deadlineErr *DeadlineExceededError
+	if errors.As(err, &deadlineErr) {
+		return deadlineErr
+	}
+	if !isDeadlineExceeded(ctx, err) {
+		return nil
+	}
+	if err == nil {
+		err = ctx.Err()
+	}
+	return &DeadlineExceededError{Stage: stage, Err: err}
+}
+
+// isDeadlineExceeded 判断 ctx 截止时间是否已到，或 err 是否为本地或远端 gRPC 返回的超时
+func isDeadlineExceeded(ctx context.Context, err error) bool {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return true
+	}
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	return status.Code(err) == codes.DeadlineExceeded
+}
@@ -0,0 +1,126 @@
+package decision
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// CSVSink 每次部署追加一行 CSV：请求、目标、结果、端到端耗时与各阶段耗时，供实验统计调度延迟分布
+// 文件为空时先写入表头；不轮转
+type CSVSink struct {
+	mu     sync.Mutex
+	path   string
+	file   *os.File
+	writer *csv.Writer
+}
+
+// NewCSVSink 打开（或创建）延迟 CSV 文件
+func NewCSVSink(path string) (*CSVSink, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create decision latency directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open decision latency file %s: %w", path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to stat decision latency file %s: %w", path, err)
+	}
+
+	s := &CSVSink{path: path, file: file, writer: csv.NewWriter(file)}
+	if info.Size() == 0 {
+		header := []string{"id", "node_id", "started_at", "runtime_env", "cpu", "memory", "gpu", "target", "target_node", "provider_id", "success", "latency_ms"}
+		for _, stage := range Stages {
+			header = append(header, string(stage)+"_ms")
+		}
+		header = append(header, "error")
+		if err := s.writeRow(header); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Write 追加一条记录，未发生的阶段耗时记为 0
+func (s *CSVSink) Write(record *Record) error {
+	row := []string{
+		record.ID,
+		record.NodeID,
+		record.StartedAt.Format(time.RFC3339Nano),
+		record.Request.RuntimeEnv,
+		strconv.FormatInt(record.Request.CPU, 10),
+		strconv.FormatInt(record.Request.Memory, 10),
+		strconv.FormatInt(record.Request.GPU, 10),
+		record.Target,
+		record.TargetNode,
+		record.ProviderID,
+		strconv.FormatBool(record.Success),
+		strconv.FormatInt(record.LatencyMs, 10),
+	}
+	for _, stage := range Stages {
+		row = append(row, strconv.FormatFloat(record.Timings[stage], 'f', 3, 64))
+	}
+	row = append(row, record.Error)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return fmt.Errorf("decision latency file %s is closed", s.path)
+	}
+	return s.writeRow(row)
+}
+
+func (s *CSVSink) writeRow(row []string) error {
+	if err := s.writer.Write(row); err != nil {
+		return fmt.Errorf("failed to write decision latency file %s: %w", s.path, err)
+	}
+	s.writer.Flush()
+	if err := s.writer.Error(); err != nil {
+		return fmt.Errorf("failed to write decision latency file %s: %w", s.path, err)
+	}
+	return nil
+}
+
+// Close 关闭文件
+func (s *CSVSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// Tee 将每条记录依次写入多个 sink，任一失败时返回合并后的错误
+func Tee(sinks ...Sink) Sink {
+	return teeSink(sinks)
+}
+
+type teeSink []Sink
+
+func (t teeSink) Write(record *Record) error {
+	var errs []error
+	for _, sink := range t {
+		errs = append(errs, sink.Write(record))
+	}
+	return errors.Join(errs...)
+}
+
+func (t teeSink) Close() error {
+	var errs []error
+	for _, sink := range t {
+		errs = append(errs, sink.Close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,126 @@
+// Package decision 记录调度决策，供离线分析调度策略
+// 每次部署生成一条 Record：资源请求、考察过的候选、选中的目标、结果、端到端耗时与各阶段耗时
+package decision
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+)
+
+// CandidateKind 候选类型
+type CandidateKind string
+
+const (
+	CandidateProvider CandidateKind = "provider" // 本节点的 provider
+	CandidatePeer     CandidateKind = "peer"     // 同域的其他节点
+)
+
+// Request 资源请求快照
+type Request struct {
+	RuntimeEnv string   `json:"runtime_env"`
+	CPU        int64    `json:"cpu"`
+	Memory     int64    `json:"memory"`
+	GPU        int64    `json:"gpu"`
+	Tags       []string `json:"tags,omitempty"`
+	// NodeSelector 节点标签约束
+	NodeSelector map[string]string `json:"node_selector,omitempty"`
+	// Stream 绑定的数据流
+	Stream string `json:"stream,omitempty"`
+}
+
+// Candidate 调度过程中考察过的一个候选
+// Rank 为策略排序后的名次（从 0 开始，越小越优先），Available 为考察时的可用资源
+type Candidate struct {
+	Kind      CandidateKind `json:"kind"`
+	ID        string        `json:"id"`
+	Name      string        `json:"name,omitempty"`
+	Rank      int           `json:"rank"`
+	Available *types.Info   `json:"available,omitempty"`
+	Accepted  bool          `json:"accepted"`
+	Reason    string        `json:"reason,omitempty"`
+}
+
+// Record 一次调度决策
+type Record struct {
+	ID         string      `json:"id"`
+	NodeID     string      `json:"node_id"`
+	StartedAt  time.Time   `json:"started_at"`
+	Request    Request     `json:"request"`
+	Candidates []Candidate `json:"candidates"`
+	Target     string      `json:"target,omitempty"`      // 选中的目标，格式同 component 的 provider ID（local./remote./global. 前缀）
+	TargetNode string      `json:"target_node,omitempty"` // component 所在节点，委托部署时为对端节点
+	ProviderID string      `json:"provider_id,omitempty"` // component 所在的 provider，可与利用率记录关联
+	Success    bool        `json:"success"`
+	Error      string      `json:"error,omitempty"`
+	LatencyMs  int64       `json:"latency_ms"`
+
+	// Timings 各阶段累计耗时（毫秒），用于定位调度瓶颈
+	Timings map[Stage]float64 `json:"timings_ms,omitempty"`
+}
+
+// NewRequest 从资源请求生成快照
+func NewRequest(runtimeEnv types.RuntimeEnv, info *types.Info) Request {
+	req := Request{RuntimeEnv: string(runtimeEnv)}
+	if info != nil {
+		req.CPU = info.CPU
+		req.Memory = info.Memory
+		req.GPU = info.GPU
+		req.Tags = append([]string(nil), info.Tags...)
+		req.NodeSelector = info.NodeSelector
+		req.Stream = info.Stream
+	}
+	return req
+}
+
+// Sink 决策记录的持久化目标
+type Sink interface {
+	Write(record *Record) error
+	Close() error
+}
+
+// Trace 收集一次部署过程中的候选与各阶段耗时，通过 context 在调度各环节间传递，并发安全
+type Trace struct {
+	mu         sync.Mutex
+	candidates []Candidate
+	timings    map[Stage]time.Duration
+}
+
+type traceKey struct{}
+
+// WithTrace 返回附加了 trace 的 context
+func WithTrace(ctx context.Context, trace *Trace) context.Context {
+	return context.WithValue(ctx, traceKey{}, trace)
+}
+
+// GetTrace 获取 context 中的 trace，未附加时返回 false
+func GetTrace(ctx context.Context) (*Trace, bool) {
+	trace, ok := ctx.Value(traceKey{}).(*Trace)
+	return trace, ok && trace != nil
+}
+
+// Consider 在 context 附加了 trace 时记录一个候选，否则什么也不做
+func Consider(ctx context.Context, candidate Candidate) {
+	if trace, ok := GetTrace(ctx); ok {
+		trace.add(candidate)
+	}
+}
+
+func (t *Trace) add(candidate Candidate) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if candidate.Available != nil {
+		available := *candidate.Available
+		candidate.Available = &available
+	}
+	t.candidates = append(t.candidates, candidate)
+}
+
+// Candidates 返回已记录候选的副本
+func (t *Trace) Candidates() []Candidate {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]Candidate(nil), t.candidates...)
+}
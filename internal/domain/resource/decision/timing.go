@@ -0,0 +1,59 @@
+package decision
+
+import (
+	"context"
+	"time"
+)
+
+// Stage 部署链路中分别计时的阶段
+type Stage string
+
+const (
+	StageLocalSchedule  Stage = "local_schedule"  // 在本节点选择 provider，含容量查询与策略链评估
+	StagePolicy         Stage = "policy"          // 其中 provider 策略链的评估（含外部策略 webhook）
+	StageDiscoveryQuery Stage = "discovery_query" // 通过 discovery 查询候选节点
+	StagePropose        Stage = "propose"         // 等待候选节点的部署探测，并行探测按墙钟时间计
+	StageCommit         Stage = "commit"          // 在接受探测的节点上实际部署
+	StageProviderDeploy Stage = "provider_deploy" // 在本地 provider 上部署
+	StageGlobalSchedule Stage = "global_schedule" // 委托给全局调度器
+)
+
+// Stages 全部计时阶段，顺序即 CSV 中的列顺序
+var Stages = []Stage{
+	StageLocalSchedule,
+	StagePolicy,
+	StageDiscoveryQuery,
+	StagePropose,
+	StageCommit,
+	StageProviderDeploy,
+	StageGlobalSchedule,
+}
+
+// Observe 在 context 附加了 trace 时将 d 累加到 stage 的耗时，否则什么也不做
+// 同一阶段在一次部署中多次发生时（如重试本地部署、多批探测）耗时累加
+func Observe(ctx context.Context, stage Stage, d time.Duration) {
+	if trace, ok := GetTrace(ctx); ok {
+		trace.mu.Lock()
+		defer trace.mu.Unlock()
+		if trace.timings == nil {
+			trace.timings = make(map[Stage]time.Duration)
+		}
+		trace.timings[stage] += d
+	}
+}
+
+// Since 将 start 至今的耗时累加到 stage，便于 defer 使用
+func Since(ctx context.Context, stage Stage, start time.Time) {
+	Observe(ctx, stage, time.Since(start))
+}
+
+// Timings 返回各阶段累计耗时（毫秒），未发生的阶段不包含在内
+func (t *Trace) Timings() map[Stage]float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	timings := make(map[Stage]float64, len(t.timings))
+	for stage, d := range t.timings {
+		timings[stage] = float64(d.Microseconds()) / 1000
+	}
+	return timings
+}
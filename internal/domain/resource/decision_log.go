@@ -0,0 +1,48 @@
+package resource
+
+import (
+	"context"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/component"
+	"github.com/9triver/iarnet/internal/domain/resource/decision"
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+	"github.com/9triver/iarnet/internal/util"
+	"github.com/sirupsen/logrus"
+)
+
+// SetDecisionLog 设置调度决策日志，nil 表示不记录
+// sink 的关闭由调用方负责，应在部署排空之后进行
+func (m *Manager) SetDecisionLog(sink decision.Sink) {
+	m.decisionLog = sink
+}
+
+// traceDecision 为一次部署附加候选收集 trace，返回的 finish 在部署结束后写入决策记录
+// 写入失败只记录警告，不影响部署结果
+func (m *Manager) traceDecision(ctx context.Context, runtimeEnv types.RuntimeEnv, resourceRequest *types.Info) (context.Context, func(*component.Component, error)) {
+	trace := &decision.Trace{}
+	record := &decision.Record{
+		ID:        util.GenIDWith("decision."),
+		NodeID:    m.nodeID,
+		StartedAt: time.Now(),
+		Request:   decision.NewRequest(runtimeEnv, resourceRequest),
+	}
+
+	finish := func(comp *component.Component, err error) {
+		record.LatencyMs = time.Since(record.StartedAt).Milliseconds()
+		record.Candidates = trace.Candidates()
+		record.Timings = trace.Timings()
+		record.Success = err == nil
+		if err != nil {
+			record.Error = err.Error()
+		}
+		if comp != nil {
+			record.Target = comp.GetProviderID()
+			record.TargetNode, record.ProviderID = m.placementOf(comp)
+		}
+		if writeErr := m.decisionLog.Write(record); writeErr != nil {
+			logrus.Warnf("Failed to write scheduling decision %s: %v", record.ID, writeErr)
+		}
+	}
+	return decision.WithTrace(ctx, trace), finish
+}
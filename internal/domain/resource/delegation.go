@@ -0,0 +1,205 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/accounting"
+	"github.com/9triver/iarnet/internal/domain/resource/component"
+	"github.com/9triver/iarnet/internal/domain/resource/decision"
+	"github.com/9triver/iarnet/internal/domain/resource/discovery"
+	"github.com/9triver/iarnet/internal/domain/resource/provider"
+	"github.com/9triver/iarnet/internal/domain/resource/scheduler"
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+	"github.com/9triver/iarnet/internal/util"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// defaultDelegationProbes 默认同时探测的候选节点数
+	defaultDelegationProbes = 3
+	// defaultDelegationProbeTimeout 默认单个节点的探测超时
+	defaultDelegationProbeTimeout = 2 * time.Second
+)
+
+// SetDelegationProbing 设置委托部署的并行探测参数
+// probes: 同时探测的候选节点数 K；timeout: 单个节点的探测超时
+func (m *Manager) SetDelegationProbing(probes int, timeout time.Duration) {
+	if probes > 0 {
+		m.delegationProbes = probes
+	}
+	if timeout > 0 {
+		m.delegationProbeTimeout = timeout
+	}
+}
+
+// probeOutcome 单个候选节点的探测结果
+type probeOutcome struct {
+	index     int
+	accepted  bool
+	reason    string
+	available *types.Info // 对端报告的可用资源，未知时为 nil
+}
+
+// probeAndCommit 并行探测一批候选节点（已按偏好排序），按排名顺序提交给第一个接受的节点
+// 排名更高的节点探测结束前不会提交给排名更低的节点；提交失败时依次尝试下一个接受者
+// 整批均未成功时返回 nil, nil；ctx 截止时间耗尽或被取消时返回 DeadlineExceededError 或 CancelledError
+// rankOffset 为本批第一个节点在全部候选中的名次，用于记录调度决策
+// 返回时取消仍在进行的探测
+func (m *Manager) probeAndCommit(ctx context.Context, runtimeEnv types.RuntimeEnv, resourceRequest *types.Info, batch []*discovery.PeerNode, rankOffset int) (*component.Component, error) {
+	probeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan probeOutcome, len(batch))
+	for i, node := range batch {
+		go func(i int, node *discovery.PeerNode) {
+			results <- m.probePeer(probeCtx, runtimeEnv, resourceRequest, i, node)
+		}(i, node)
+	}
+
+	outcomes := make([]*probeOutcome, len(batch))
+	next := 0
+	// 探测耗时按等待探测结果的墙钟时间计，不含提交耗时
+	proposeStart := time.Now()
+	defer func() { decision.Since(ctx, decision.StagePropose, proposeStart) }()
+	for received := 0; received < len(batch); received++ {
+		outcome := <-results
+		outcomes[outcome.index] = &outcome
+
+		// 按排名顺序处理已结束的探测
+		for next < len(batch) && outcomes[next] != nil {
+			node, result := batch[next], outcomes[next]
+			rank := rankOffset + next
+			next++
+			if !result.accepted {
+				logrus.Debugf("Node %s (%s) declined deployment proposal: %s", node.NodeName, node.NodeID, result.reason)
+				considerPeer(ctx, rank, node, result.available, result.reason)
+				continue
+			}
+			decision.Since(ctx, decision.StagePropose, proposeStart)
+			commitStart := time.Now()
+			comp, err := m.commitDelegation(ctx, runtimeEnv, resourceRequest, node)
+			decision.Since(ctx, decision.StageCommit, commitStart)
+			proposeStart = time.Now()
+			if err != nil {
+				considerPeer(ctx, rank, node, result.available, "commit failed: "+err.Error())
+				if abortErr := interruptError(ctx, StageCommit, err); abortErr != nil {
+					return nil, abortErr
+				}
+				m.peerHistory.Record(provider.ClassifyTask(resourceRequest), node.NodeID, false, time.Since(commitStart))
+				logrus.Warnf("Failed to commit delegated deployment to node %s (%s): %v", node.NodeName, node.NodeID, err)
+				continue
+			}
+			m.peerHistory.Record(provider.ClassifyTask(resourceRequest), node.NodeID, true, time.Since(commitStart))
+			considerPeer(ctx, rank, node, result.available, "")
+			return comp, nil
+		}
+	}
+	// 探测因调用方截止时间或取消而中断时，被视为拒绝的节点并非真正拒绝
+	if abortErr := interruptError(ctx, StagePropose, nil); abortErr != nil {
+		return nil, abortErr
+	}
+	return nil, nil
+}
+
+// probePeer 向单个候选节点发送部署探测，超时或出错视为拒绝
+// 不支持探测的节点视为接受，由提交阶段决定结果
+// 探测超时取配置值与调用方剩余时间中的较小者，截止时间随 gRPC 请求传递给对端
+func (m *Manager) probePeer(ctx context.Context, runtimeEnv types.RuntimeEnv, resourceRequest *types.Info, index int, node *discovery.PeerNode) probeOutcome {
+	ctx, cancel := context.WithTimeout(ctx, m.delegationProbeTimeout)
+	defer cancel()
+
+	resp, err := m.schedulerService.ProposeDeployment(ctx, &scheduler.ProposeRequest{
+		RuntimeEnv:      runtimeEnv,
+		ResourceRequest: resourceRequest,
+		TargetNodeID:    node.NodeID,
+		TargetAddress:   peerSchedulerAddress(node),
+	})
+	switch {
+	case errors.Is(err, scheduler.ErrProposeUnsupported):
+		return probeOutcome{index: index, accepted: true}
+	case err != nil:
+		return probeOutcome{index: index, reason: err.Error()}
+	case !resp.Accepted:
+		return probeOutcome{index: index, reason: resp.Reason, available: resp.Available}
+	}
+	return probeOutcome{index: index, accepted: true, available: resp.Available}
+}
+
+// considerPeer 将考察过的候选节点记录到调度决策 trace，reason 为空表示选中
+func considerPeer(ctx context.Context, rank int, node *discovery.PeerNode, available *types.Info, reason string) {
+	decision.Consider(ctx, decision.Candidate{
+		Kind:      decision.CandidatePeer,
+		ID:        node.NodeID,
+		Name:      node.NodeName,
+		Rank:      rank,
+		Available: available,
+		Accepted:  reason == "",
+		Reason:    reason,
+	})
+}
+
+// commitDelegation 在已接受探测的节点上实际部署 component 并在本地登记
+// component ID 由本节点生成，部署被取消时据此通知目标节点回滚，并返回 CancelledError
+func (m *Manager) commitDelegation(ctx context.Context, runtimeEnv types.RuntimeEnv, resourceRequest *types.Info, node *discovery.PeerNode) (*component.Component, error) {
+	affinity, _ := provider.GetAffinity(ctx)
+	dataSources, _ := provider.GetDataSources(ctx)
+	securityContext, _ := provider.GetSecurityContext(ctx)
+	sidecars, _ := provider.GetSidecars(ctx)
+	componentID := util.GenIDWith("comp.")
+	// 目标节点在响应返回前对账时答复待确认；响应丢失时提交结束后未登记的 component 由目标节点对账后删除
+	m.commits.begin(componentID)
+	defer m.commits.end(componentID)
+	resp, err := m.schedulerService.DeployComponent(ctx, &scheduler.DeployRequest{
+		RuntimeEnv:            runtimeEnv,
+		ResourceRequest:       resourceRequest,
+		TargetNodeID:          node.NodeID,
+		TargetAddress:         peerSchedulerAddress(node),
+		UpstreamZMQAddress:    m.getZMQAddress(),
+		UpstreamStoreAddress:  m.getStoreAddress(),
+		UpstreamLoggerAddress: m.getLoggerAddress(),
+		UpstreamToken:         m.upstreamToken(componentID, accounting.GetApplication(ctx)),
+		Affinity:              affinity,
+		ComponentID:           componentID,
+		DataSources:           dataSources,
+		SecurityContext:       securityContext,
+		Sidecars:              sidecars,
+		AppID:                 accounting.GetApplication(ctx),
+		Labels:                component.GetComponentLabels(ctx),
+		OriginNodeID:          m.nodeID,
+	})
+	// 远程部署的错误以失败响应返回，因此按 ctx 判断是否被取消；部署已完成但调用方已离开时同样回滚
+	if cancelErr := cancelledError(ctx, StageCommit, err); cancelErr != nil {
+		if resp != nil && resp.Component != nil {
+			componentID = resp.Component.GetID()
+		}
+		m.rollbackDelegation(ctx, node.NodeID, peerSchedulerAddress(node), componentID)
+		return nil, cancelErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || !resp.Success {
+		if resp != nil && resp.Error != "" {
+			return nil, fmt.Errorf("node rejected deployment: %s", resp.Error)
+		}
+		return nil, fmt.Errorf("node rejected deployment")
+	}
+	if resp.Component != nil {
+		if err := m.componentManager.AddComponent(ctx, resp.Component); err != nil {
+			return nil, fmt.Errorf("failed to register remote component %s locally: %w", resp.Component.GetID(), err)
+		}
+		resp.Component.SetProviderID(fmt.Sprintf("remote.%s@%s", resp.ProviderID, resp.NodeID))
+	}
+	logrus.Infof("Delegated component deployment to node %s (%s)", node.NodeName, node.NodeID)
+	return resp.Component, nil
+}
+
+func peerSchedulerAddress(node *discovery.PeerNode) string {
+	if node.SchedulerAddress != "" {
+		return node.SchedulerAddress
+	}
+	return node.Address
+}
@@ -0,0 +1,232 @@
+package discovery
+
+import (
+	"sort"
+
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+)
+
+// NodeQuery 节点查询条件，各条件之间为"与"关系，零值表示不限制
+type NodeQuery struct {
+	Tags      *ResourceTags     // 必须支持的资源类型
+	Labels    map[string]string // 必须完全匹配的节点标签
+	MinCPU    int64             // 最少可用 CPU（millicores）
+	MinMemory int64             // 最少可用内存（bytes）
+	MinGPU    int64             // 最少可用 GPU 数量
+	Limit     int               // 最多返回的节点数，<= 0 表示不限制
+}
+
+// nodeIndex 已知节点的查询索引，按资源标签、节点标签和可用 CPU 建立
+// 节点信息变化时增量更新，查询时从最小的候选集合出发过滤，避免在大域内全量扫描
+// 只索引在线节点；并发保护由 NodeDiscoveryManager 的锁负责
+type nodeIndex struct {
+	nodes   map[string]*indexedNode
+	byTag   map[string]map[string]struct{}            // 资源类型 -> 节点 ID 集合
+	byLabel map[string]map[string]map[string]struct{} // 标签键 -> 标签值 -> 节点 ID 集合
+	byCPU   []cpuEntry                                // 按可用 CPU 降序
+}
+
+// indexedNode 记录节点建立索引时使用的键
+// 节点可能被原地更新，移除时必须使用当时的键而不是节点的当前值
+type indexedNode struct {
+	node   *PeerNode
+	tags   []string
+	labels map[string]string
+}
+
+type cpuEntry struct {
+	nodeID string
+	cpu    int64
+}
+
+func newNodeIndex() *nodeIndex {
+	return &nodeIndex{
+		nodes:   make(map[string]*indexedNode),
+		byTag:   make(map[string]map[string]struct{}),
+		byLabel: make(map[string]map[string]map[string]struct{}),
+	}
+}
+
+// upsert 添加或更新节点；节点不在线时从索引中移除
+func (idx *nodeIndex) upsert(node *PeerNode) {
+	idx.remove(node.NodeID)
+	if node.Status != NodeStatusOnline {
+		return
+	}
+
+	entry := &indexedNode{
+		node:   node,
+		tags:   nodeTags(node.ResourceTags),
+		labels: make(map[string]string, len(node.Labels)),
+	}
+	idx.nodes[node.NodeID] = entry
+	for _, tag := range entry.tags {
+		set, ok := idx.byTag[tag]
+		if !ok {
+			set = make(map[string]struct{})
+			idx.byTag[tag] = set
+		}
+		set[node.NodeID] = struct{}{}
+	}
+	for key, value := range node.Labels {
+		entry.labels[key] = value
+		values, ok := idx.byLabel[key]
+		if !ok {
+			values = make(map[string]map[string]struct{})
+			idx.byLabel[key] = values
+		}
+		set, ok := values[value]
+		if !ok {
+			set = make(map[string]struct{})
+			values[value] = set
+		}
+		set[node.NodeID] = struct{}{}
+	}
+
+	cpu := cpuEntry{nodeID: node.NodeID, cpu: availableOf(node).CPU}
+	i := sort.Search(len(idx.byCPU), func(i int) bool { return idx.byCPU[i].cpu < cpu.cpu })
+	idx.byCPU = append(idx.byCPU, cpuEntry{})
+	copy(idx.byCPU[i+1:], idx.byCPU[i:])
+	idx.byCPU[i] = cpu
+}
+
+// remove 从索引中移除节点
+func (idx *nodeIndex) remove(nodeID string) {
+	entry, ok := idx.nodes[nodeID]
+	if !ok {
+		return
+	}
+	delete(idx.nodes, nodeID)
+
+	for _, tag := range entry.tags {
+		if set := idx.byTag[tag]; set != nil {
+			delete(set, nodeID)
+			if len(set) == 0 {
+				delete(idx.byTag, tag)
+			}
+		}
+	}
+	for key, value := range entry.labels {
+		if set := idx.byLabel[key][value]; set != nil {
+			delete(set, nodeID)
+			if len(set) == 0 {
+				delete(idx.byLabel[key], value)
+			}
+		}
+		if len(idx.byLabel[key]) == 0 {
+			delete(idx.byLabel, key)
+		}
+	}
+	for i, cpu := range idx.byCPU {
+		if cpu.nodeID == nodeID {
+			idx.byCPU = append(idx.byCPU[:i], idx.byCPU[i+1:]...)
+			break
+		}
+	}
+}
+
+// query 返回满足条件且被 accept 接受的节点，按可用资源降序排列
+// 索引中的记录可能是上次 upsert 之后被原地修改过的节点，因此候选节点总会按全部条件重新校验
+func (idx *nodeIndex) query(q *NodeQuery, accept func(*PeerNode) bool) []*PeerNode {
+	candidates := idx.candidates(q)
+
+	result := make([]*PeerNode, 0, len(candidates))
+	for _, id := range candidates {
+		entry := idx.nodes[id]
+		if entry != nil && matchesQuery(entry.node, q) && (accept == nil || accept(entry.node)) {
+			result = append(result, entry.node)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return availabilityScore(result[i]) > availabilityScore(result[j])
+	})
+	if q.Limit > 0 && len(result) > q.Limit {
+		result = result[:q.Limit]
+	}
+	return result
+}
+
+// candidates 从最小的候选集合出发：资源标签集合、节点标签集合或可用 CPU 满足下限的前缀
+func (idx *nodeIndex) candidates(q *NodeQuery) []string {
+	// 可用 CPU 降序排列，满足下限的是一个前缀
+	cpuPrefix := sort.Search(len(idx.byCPU), func(i int) bool { return idx.byCPU[i].cpu < q.MinCPU })
+	best := -1
+	var bestSet map[string]struct{}
+
+	consider := func(set map[string]struct{}) {
+		if best < 0 || len(set) < best {
+			best = len(set)
+			bestSet = set
+		}
+	}
+	for _, tag := range nodeTags(q.Tags) {
+		consider(idx.byTag[tag])
+	}
+	for key, value := range q.Labels {
+		consider(idx.byLabel[key][value])
+	}
+
+	if best >= 0 && best < cpuPrefix {
+		ids := make([]string, 0, len(bestSet))
+		for id := range bestSet {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	ids := make([]string, 0, cpuPrefix)
+	for _, entry := range idx.byCPU[:cpuPrefix] {
+		ids = append(ids, entry.nodeID)
+	}
+	return ids
+}
+
+// matchesQuery 检查节点是否满足全部查询条件
+func matchesQuery(node *PeerNode, q *NodeQuery) bool {
+	if node.Status != NodeStatusOnline {
+		return false
+	}
+	for _, tag := range nodeTags(q.Tags) {
+		if !node.ResourceTags.HasResource(tag) {
+			return false
+		}
+	}
+	for key, value := range q.Labels {
+		if node.Labels[key] != value {
+			return false
+		}
+	}
+	if q.MinCPU > 0 || q.MinMemory > 0 || q.MinGPU > 0 {
+		if node.ResourceCapacity == nil || node.ResourceCapacity.Available == nil {
+			return false
+		}
+	}
+	available := availableOf(node)
+	return available.CPU >= q.MinCPU && available.Memory >= q.MinMemory && available.GPU >= q.MinGPU
+}
+
+// nodeTags 返回资源标签中支持的资源类型列表
+func nodeTags(tags *ResourceTags) []string {
+	if tags == nil {
+		return nil
+	}
+	var result []string
+	for _, tag := range []string{"cpu", "gpu", "memory", "camera"} {
+		if tags.HasResource(tag) {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+func availableOf(node *PeerNode) types.Info {
+	if node.ResourceCapacity == nil || node.ResourceCapacity.Available == nil {
+		return types.Info{}
+	}
+	return *node.ResourceCapacity.Available
+}
+
+// availabilityScore 可用资源综合评分，与聚合视图的排序方式一致
+func availabilityScore(node *PeerNode) int64 {
+	available := availableOf(node)
+	return available.CPU + available.Memory/1024/1024 + available.GPU*1000
+}
@@ -0,0 +1,134 @@
+package discovery
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// tombstone 已离开或已过期节点的墓碑，用于拒绝其他节点转发的旧信息，防止节点被"复活"
+// 只有版本号或心跳比墓碑更新的信息才会让节点重新加入
+type tombstone struct {
+	node      *PeerNode // 离开时的节点信息（状态为 offline）
+	departed  bool      // 是否为节点主动离开（主动离开的墓碑会随 gossip 传播）
+	expiresAt time.Time // 墓碑过期时间
+}
+
+// SetSuspectTimeout 设置 suspect 超时：超过该时间未观察到心跳推进的节点不再出现在存活视图中
+func (m *NodeDiscoveryManager) SetSuspectTimeout(timeout time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.suspectTimeout = timeout
+}
+
+// SetTombstoneTTL 设置墓碑保留时间
+func (m *NodeDiscoveryManager) SetTombstoneTTL(ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tombstoneTTL = ttl
+}
+
+// GetAliveNodes 获取存活的已知节点（不包括 suspect 节点），供调度使用
+func (m *NodeDiscoveryManager) GetAliveNodes() []*PeerNode {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	nodes := make([]*PeerNode, 0, len(m.knownNodes))
+	for _, node := range m.knownNodes {
+		if m.livenessOf(node) == NodeLivenessAlive {
+			nodes = append(nodes, m.copyPeerNode(node))
+		}
+	}
+	return nodes
+}
+
+// MarkDeparted 将已知节点标记为已离开：立即移除并记录墓碑，墓碑会随 gossip 传播给其他节点
+// 节点未知时返回 false
+func (m *NodeDiscoveryManager) MarkDeparted(nodeID string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	node, ok := m.knownNodes[nodeID]
+	if !ok {
+		return false
+	}
+	departed := m.copyPeerNode(node)
+	departed.Status = NodeStatusOffline
+	departed.Version++
+	departed.LastUpdated = time.Now()
+	m.tombstoneLocked(departed, true)
+	return true
+}
+
+// Leave 宣告本地节点离开：标记为 offline 并推进版本，下一次 gossip 会把离开信息传播出去
+func (m *NodeDiscoveryManager) Leave() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.localNode.Status = NodeStatusOffline
+	m.localNode.Version++
+	m.localNode.LastSeen = now
+	m.localNode.LastUpdated = now
+	m.index.upsert(m.localNode)
+	m.updateAggregateView()
+}
+
+// IsTombstoned 检查节点是否处于墓碑状态
+func (m *NodeDiscoveryManager) IsTombstoned(nodeID string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.tombstones[nodeID]
+	return ok
+}
+
+// livenessOf 计算节点的存活状态
+func (m *NodeDiscoveryManager) livenessOf(node *PeerNode) NodeLiveness {
+	if node == m.localNode || m.suspectTimeout <= 0 || !node.IsStale(m.suspectTimeout) {
+		return NodeLivenessAlive
+	}
+	return NodeLivenessSuspect
+}
+
+// tombstoneLocked 移除节点并记录墓碑，调用方需持有写锁
+func (m *NodeDiscoveryManager) tombstoneLocked(node *PeerNode, departed bool) {
+	m.tombstones[node.NodeID] = &tombstone{
+		node:      node,
+		departed:  departed,
+		expiresAt: time.Now().Add(m.tombstoneTTL),
+	}
+
+	existing, ok := m.knownNodes[node.NodeID]
+	if !ok {
+		return
+	}
+	delete(m.knownNodes, node.NodeID)
+	if m.addressToNodeID[existing.Address] == node.NodeID {
+		delete(m.addressToNodeID, existing.Address)
+	}
+	m.index.remove(node.NodeID)
+	m.updateAggregateView()
+
+	if departed {
+		logrus.Infof("Node %s (%s) departed, tombstoned", existing.NodeName, node.NodeID)
+	} else {
+		logrus.Infof("Node %s (%s) is stale, tombstoned", existing.NodeName, node.NodeID)
+	}
+	if m.onNodeLost != nil {
+		go m.onNodeLost(node.NodeID)
+	}
+}
+
+// acceptAfterTombstone 检查节点信息能否越过墓碑：比墓碑新的信息会清除墓碑，调用方需持有写锁
+func (m *NodeDiscoveryManager) acceptAfterTombstone(node *PeerNode) bool {
+	ts, ok := m.tombstones[node.NodeID]
+	if !ok {
+		return true
+	}
+	if !node.newerThan(ts.node.Version, ts.node.LastSeen) {
+		return false
+	}
+	delete(m.tombstones, node.NodeID)
+	logrus.Infof("Node %s (%s) rejoined after tombstone (version: %d -> %d)", node.NodeName, node.NodeID, ts.node.Version, node.Version)
+	return true
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/9triver/iarnet/internal/domain/resource/types"
+	commonpb "github.com/9triver/iarnet/internal/proto/common"
 	"github.com/sirupsen/logrus"
 )
 
@@ -32,6 +33,11 @@ type NodeDiscoveryManager struct {
 	maxGossipPeers int           // 每次 gossip 的最大 peer 数量
 	maxHops        int           // 最大跳数
 
+	// 存活跟踪
+	suspectTimeout time.Duration         // 超过该时间未观察到心跳推进的节点视为 suspect
+	tombstoneTTL   time.Duration         // 墓碑保留时间
+	tombstones     map[string]*tombstone // 已离开或已过期节点的墓碑（节点 ID -> 墓碑）
+
 	// 消息去重（防止重复处理）
 	processedMessages map[string]time.Time // message_id -> timestamp
 	messageTTL        time.Duration        // 消息去重 TTL
@@ -48,6 +54,9 @@ type NodeDiscoveryManager struct {
 
 	// 资源聚合视图
 	aggregateView *ResourceAggregateView // 聚合所有节点的资源视图
+
+	// 节点查询索引（包括本地节点），节点信息变化时增量更新
+	index *nodeIndex
 }
 
 // NewNodeDiscoveryManager 创建节点发现管理器
@@ -72,6 +81,10 @@ func NewNodeDiscoveryManager(
 		LastSeen:         time.Now(),
 		LastUpdated:      time.Now(),
 		Version:          1,
+
+		ProtocolVersion:    commonpb.ProtocolVersion,
+		MinProtocolVersion: commonpb.MinProtocolVersion,
+		Capabilities:       append([]string(nil), commonpb.NodeCapabilities...),
 	}
 
 	peerAddresses := make(map[string]struct{})
@@ -81,6 +94,9 @@ func NewNodeDiscoveryManager(
 		}
 	}
 
+	index := newNodeIndex()
+	index.upsert(localNode)
+
 	return &NodeDiscoveryManager{
 		localNode:         localNode,
 		knownNodes:        make(map[string]*PeerNode),
@@ -90,10 +106,14 @@ func NewNodeDiscoveryManager(
 		nodeTTL:           nodeTTL,
 		maxGossipPeers:    10,
 		maxHops:           5,
+		suspectTimeout:    nodeTTL / 2,
+		tombstoneTTL:      3 * nodeTTL,
+		tombstones:        make(map[string]*tombstone),
 		processedMessages: make(map[string]time.Time),
 		messageTTL:        5 * time.Minute, // 消息去重 TTL：5 分钟
 		gossipStop:        make(chan struct{}),
 		aggregateView:     NewResourceAggregateView(),
+		index:             index,
 	}
 }
 
@@ -161,8 +181,42 @@ func (m *NodeDiscoveryManager) UpdateLocalNode(
 	logrus.Infof("Updated local node resources (version: %d -> %d): %s -> %s",
 		oldVersion, m.localNode.Version, oldResourceInfo, newResourceInfo)
 
-	// 更新聚合视图
+	// 更新聚合视图和查询索引
 	m.updateAggregateView()
+	m.index.upsert(m.localNode)
+}
+
+// SetLocalEnergyProfile 设置本地节点的能耗画像（来自配置，随 gossip 传播）
+func (m *NodeDiscoveryManager) SetLocalEnergyProfile(profile *types.EnergyProfile) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.localNode.EnergyProfile = profile
+	m.localNode.LastUpdated = time.Now()
+	m.localNode.Version++
+}
+
+// SetLocalLabels 设置本地节点的标签（来自配置，随 gossip 传播，可用于按标签查询节点）
+func (m *NodeDiscoveryManager) SetLocalLabels(labels map[string]string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.localNode.Labels = copyLabels(labels)
+	m.localNode.LastUpdated = time.Now()
+	m.localNode.Version++
+	m.index.upsert(m.localNode)
+}
+
+// SetLocalHeadRole 设置本地节点的 head 角色、任期与接管优先级（随 gossip 传播）
+func (m *NodeDiscoveryManager) SetLocalHeadRole(role HeadRole, term uint64, priority int32) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.localNode.HeadRole = role
+	m.localNode.HeadTerm = term
+	m.localNode.HeadPriority = priority
+	m.localNode.LastUpdated = time.Now()
+	m.localNode.Version++
 }
 
 // GetKnownNodes 获取所有已知节点
@@ -288,14 +342,32 @@ func (m *NodeDiscoveryManager) ProcessNodeInfo(node *PeerNode, sourcePeer string
 		return
 	}
 
+	// 墓碑中的节点只接受比墓碑更新的信息
+	if !m.acceptAfterTombstone(node) {
+		logrus.Debugf("Ignoring stale info for tombstoned node %s (version: %d)", node.NodeID, node.Version)
+		return
+	}
+
 	existing, exists := m.knownNodes[node.NodeID]
 
+	// 节点宣告离开：除非本地已有更新的信息，否则移除并记录墓碑
+	if node.Status == NodeStatusOffline {
+		if exists && existing.newerThan(node.Version, node.LastSeen) {
+			return
+		}
+		m.tombstoneLocked(m.copyPeerNode(node), true)
+		return
+	}
+
 	if !exists {
 		// 新节点发现
+		// 首次发现时以节点上报的心跳作为存活时间，转发来的旧信息不会被当作新近存活
 		node.DiscoveredAt = time.Now()
+		node.LastHeard = node.LastSeen
 		node.SourcePeer = sourcePeer
 		m.knownNodes[node.NodeID] = node
 		m.addressToNodeID[node.Address] = node.NodeID
+		m.index.upsert(node)
 
 		// 记录资源信息
 		resourceInfo := "no resources"
@@ -317,9 +389,14 @@ func (m *NodeDiscoveryManager) ProcessNodeInfo(node *PeerNode, sourcePeer string
 	} else {
 		// 更新现有节点
 		oldVersion := existing.Version
+		oldHeartbeat := existing.LastSeen
 		if existing.UpdateFrom(node) {
 			existing.SourcePeer = sourcePeer
-			existing.LastSeen = time.Now()
+			// 只有版本或心跳推进才说明节点仍然存活，转发的旧副本不刷新存活时间
+			if existing.newerThan(oldVersion, oldHeartbeat) {
+				existing.LastHeard = time.Now()
+			}
+			m.index.upsert(existing)
 
 			// 记录资源信息变化
 			oldResourceInfo := "no resources"
@@ -344,7 +421,8 @@ func (m *NodeDiscoveryManager) ProcessNodeInfo(node *PeerNode, sourcePeer string
 	}
 }
 
-// FindAvailableNodes 查找满足资源要求的可用节点
+// FindAvailableNodes 查找满足资源要求的可用节点（包括本地节点），按可用资源降序排列
+// 未上报资源容量的节点不会被返回
 func (m *NodeDiscoveryManager) FindAvailableNodes(
 	resourceRequest *ResourceRequest,
 	requiredTags *ResourceTags,
@@ -353,17 +431,40 @@ func (m *NodeDiscoveryManager) FindAvailableNodes(
 		return nil
 	}
 
-	// 转换为 types.Info
-	req := &types.Info{
-		CPU:    resourceRequest.CPU,
-		Memory: resourceRequest.Memory,
-		GPU:    resourceRequest.GPU,
+	nodes := m.QueryNodes(&NodeQuery{
+		Tags:      requiredTags,
+		Labels:    resourceRequest.NodeSelector,
+		MinCPU:    resourceRequest.CPU,
+		MinMemory: resourceRequest.Memory,
+		MinGPU:    resourceRequest.GPU,
+	})
+	available := nodes[:0]
+	for _, node := range nodes {
+		if node.ResourceCapacity != nil && node.ResourceCapacity.Available != nil {
+			available = append(available, node)
+		}
+	}
+	return available
+}
+
+// QueryNodes 通过索引查询满足条件的存活节点（包括本地节点），按可用资源降序排列
+// suspect 节点不会被返回；返回节点信息的副本
+func (m *NodeDiscoveryManager) QueryNodes(query *NodeQuery) []*PeerNode {
+	if query == nil {
+		query = &NodeQuery{}
 	}
 
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	return m.aggregateView.FindAvailableNodes(req, requiredTags)
+	matched := m.index.query(query, func(node *PeerNode) bool {
+		return m.livenessOf(node) == NodeLivenessAlive
+	})
+	nodes := make([]*PeerNode, 0, len(matched))
+	for _, node := range matched {
+		nodes = append(nodes, m.copyPeerNode(node))
+	}
+	return nodes
 }
 
 // GetAggregateView 获取资源聚合视图
@@ -435,8 +536,12 @@ func (m *NodeDiscoveryManager) performGossip(ctx context.Context) {
 		}
 	}
 
-	// 更新聚合视图
+	// 推进本地心跳并更新聚合视图
+	// 心跳只在 gossip 轮次中推进，其他节点据此区分本节点的新信息和转发的旧副本
 	m.mu.Lock()
+	if m.localNode.Status == NodeStatusOnline {
+		m.localNode.LastSeen = time.Now()
+	}
 	m.updateAggregateView()
 	m.mu.Unlock()
 }
@@ -455,21 +560,25 @@ func (m *NodeDiscoveryManager) cleanupLoop(ctx context.Context) {
 	}
 }
 
-// cleanup 清理过期节点和消息
+// cleanup 清理过期节点、墓碑和消息
+// 过期节点会记录墓碑，避免其他节点转发的旧信息让它重新出现
 func (m *NodeDiscoveryManager) cleanup() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	now := time.Now()
-	var lostNodes []string
 
 	// 清理过期节点
-	for nodeID, node := range m.knownNodes {
+	for _, node := range m.knownNodes {
 		if node.IsStale(m.nodeTTL) {
-			logrus.Infof("Node %s (%s) is stale, removing", node.NodeName, nodeID)
-			delete(m.knownNodes, nodeID)
-			delete(m.addressToNodeID, node.Address)
-			lostNodes = append(lostNodes, nodeID)
+			m.tombstoneLocked(m.copyPeerNode(node), false)
+		}
+	}
+
+	// 清理过期墓碑
+	for nodeID, ts := range m.tombstones {
+		if now.After(ts.expiresAt) {
+			delete(m.tombstones, nodeID)
 		}
 	}
 
@@ -479,18 +588,6 @@ func (m *NodeDiscoveryManager) cleanup() {
 			delete(m.processedMessages, messageID)
 		}
 	}
-
-	// 更新聚合视图
-	if len(lostNodes) > 0 {
-		m.updateAggregateView()
-
-		// 触发节点丢失回调
-		if m.onNodeLost != nil {
-			for _, nodeID := range lostNodes {
-				go m.onNodeLost(nodeID)
-			}
-		}
-	}
 }
 
 // updateAggregateView 更新聚合视图
@@ -521,10 +618,20 @@ func (m *NodeDiscoveryManager) copyPeerNode(node *PeerNode) *PeerNode {
 		Status:           node.Status,
 		LastSeen:         node.LastSeen,
 		LastUpdated:      node.LastUpdated,
+		LastHeard:        node.LastHeard,
+		Liveness:         m.livenessOf(node),
 		DiscoveredAt:     node.DiscoveredAt,
 		SourcePeer:       node.SourcePeer,
 		Version:          node.Version,
 		GossipCount:      node.GossipCount,
+
+		ProtocolVersion:    node.ProtocolVersion,
+		MinProtocolVersion: node.MinProtocolVersion,
+		Capabilities:       append([]string(nil), node.Capabilities...),
+
+		HeadRole:     node.HeadRole,
+		HeadTerm:     node.HeadTerm,
+		HeadPriority: node.HeadPriority,
 	}
 
 	// 复制资源容量
@@ -563,6 +670,14 @@ func (m *NodeDiscoveryManager) copyPeerNode(node *PeerNode) *PeerNode {
 		}
 	}
 
+	// 复制能耗画像
+	if node.EnergyProfile != nil {
+		energy := *node.EnergyProfile
+		copy.EnergyProfile = &energy
+	}
+
+	copy.Labels = copyLabels(node.Labels)
+
 	return copy
 }
 
@@ -583,15 +698,21 @@ func (m *NodeDiscoveryManager) MarkMessageProcessed(messageID string) {
 	m.processedMessages[messageID] = time.Now()
 }
 
-// GetNodesForGossip 获取用于 gossip 的节点列表（包括本地节点和已知节点）
+// GetNodesForGossip 获取用于 gossip 的节点列表（包括本地节点、已知节点和主动离开节点的墓碑）
+// 墓碑以 offline 状态发送，接收方据此移除已离开的节点
 func (m *NodeDiscoveryManager) GetNodesForGossip() []*PeerNode {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	nodes := make([]*PeerNode, 0, len(m.knownNodes)+1)
+	nodes := make([]*PeerNode, 0, len(m.knownNodes)+len(m.tombstones)+1)
 	nodes = append(nodes, m.copyPeerNode(m.localNode))
 	for _, node := range m.knownNodes {
 		nodes = append(nodes, m.copyPeerNode(node))
 	}
+	for _, ts := range m.tombstones {
+		if ts.departed {
+			nodes = append(nodes, m.copyPeerNode(ts.node))
+		}
+	}
 	return nodes
 }
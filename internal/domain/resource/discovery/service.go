@@ -6,14 +6,19 @@ import (
 	"time"
 
 	"github.com/9triver/iarnet/internal/domain/resource/types"
+	commonpb "github.com/9triver/iarnet/internal/proto/common"
 	registrypb "github.com/9triver/iarnet/internal/proto/global/registry"
 	discoverypb "github.com/9triver/iarnet/internal/proto/resource/discovery"
 	"github.com/9triver/iarnet/internal/util"
+	"github.com/9triver/iarnet/internal/util/compress"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
+// leaveGossipTimeout 停止时宣告离开的 gossip 超时时间
+const leaveGossipTimeout = 2 * time.Second
+
 // Service 节点发现服务接口
 type Service interface {
 	// Start 启动服务
@@ -28,7 +33,7 @@ type Service interface {
 	// QueryResources 查询资源（主动查询）
 	QueryResources(ctx context.Context, resourceRequest *types.Info, requiredTags *ResourceTags) ([]*PeerNode, error)
 
-	// GetKnownNodes 获取所有已知节点
+	// GetKnownNodes 获取所有已知节点（包括 suspect 节点，Liveness 标明存活状态）
 	GetKnownNodes() []*PeerNode
 
 	// GetLocalNode 获取本地节点信息
@@ -39,6 +44,12 @@ type Service interface {
 	UpdateLocalNode(resourceCapacity *types.Capacity, resourceTags interface{})
 }
 
+// HeadRoleAnnouncer 支持通过 gossip 宣告本节点 head 角色的 Service
+type HeadRoleAnnouncer interface {
+	// SetLocalHeadRole 设置本节点的 head 角色、任期与接管优先级，下一次 gossip 传播给同域节点
+	SetLocalHeadRole(role HeadRole, term uint64, priority int32)
+}
+
 type service struct {
 	manager *NodeDiscoveryManager
 }
@@ -50,6 +61,11 @@ func NewService(manager *NodeDiscoveryManager) Service {
 	}
 }
 
+// SetLocalHeadRole 设置本节点的 head 角色
+func (s *service) SetLocalHeadRole(role HeadRole, term uint64, priority int32) {
+	s.manager.SetLocalHeadRole(role, term, priority)
+}
+
 // Start 启动服务
 func (s *service) Start(ctx context.Context) error {
 	// 设置 gossip 回调，让 manager 的 gossipLoop 能够调用 service 的 PerformGossip
@@ -58,7 +74,14 @@ func (s *service) Start(ctx context.Context) error {
 }
 
 // Stop 停止服务
+// 停止前宣告本节点离开并执行最后一次 gossip，使其他节点立即移除本节点而不是等待过期
 func (s *service) Stop() {
+	s.manager.Leave()
+	ctx, cancel := context.WithTimeout(context.Background(), leaveGossipTimeout)
+	defer cancel()
+	if err := s.PerformGossip(ctx); err != nil {
+		logrus.Debugf("Failed to announce departure: %v", err)
+	}
 	s.manager.Stop()
 }
 
@@ -90,10 +113,23 @@ func (s *service) PerformGossip(ctx context.Context) error {
 	return nil
 }
 
+// dialPeer 连接 peer 的 discovery 服务
+// 仅在 peer 已通过 gossip 声明对应压缩能力时压缩请求，首次联系或版本不兼容的 peer 不压缩
+func (s *service) dialPeer(peerAddr string) (*grpc.ClientConn, error) {
+	var remote *commonpb.ProtocolInfo
+	if node, ok := s.manager.GetNodeByAddress(peerAddr); ok {
+		remote = node.Protocol()
+	}
+	protocol, _ := commonpb.Negotiate(commonpb.NewProtocolInfo(commonpb.NodeCapabilities...), remote)
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	opts = append(opts, compress.DialOptions(compress.ChannelDiscovery, protocol)...)
+	return grpc.NewClient(peerAddr, opts...)
+}
+
 // gossipWithPeer 与单个 peer 进行 gossip
 func (s *service) gossipWithPeer(ctx context.Context, peerAddr string, nodesToSend []*PeerNode) error {
 	// 创建 gRPC 连接
-	conn, err := grpc.NewClient(peerAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := s.dialPeer(peerAddr)
 	if err != nil {
 		return fmt.Errorf("failed to connect to peer %s: %w", peerAddr, err)
 	}
@@ -173,9 +209,10 @@ func (s *service) QueryResources(ctx context.Context, resourceRequest *types.Inf
 
 	// 转换为内部类型
 	req := &ResourceRequest{
-		CPU:    resourceRequest.CPU,
-		Memory: resourceRequest.Memory,
-		GPU:    resourceRequest.GPU,
+		CPU:          resourceRequest.CPU,
+		Memory:       resourceRequest.Memory,
+		GPU:          resourceRequest.GPU,
+		NodeSelector: resourceRequest.NodeSelector,
 	}
 
 	if requiredTags != nil {
@@ -207,9 +244,10 @@ func (s *service) QueryResources(ctx context.Context, resourceRequest *types.Inf
 		RequesterAddress:  localNode.Address,
 		RequesterDomainId: localNode.DomainID,
 		ResourceRequest: &discoverypb.ResourceRequest{
-			Cpu:    resourceRequest.CPU,
-			Memory: resourceRequest.Memory,
-			Gpu:    resourceRequest.GPU,
+			Cpu:          resourceRequest.CPU,
+			Memory:       resourceRequest.Memory,
+			Gpu:          resourceRequest.GPU,
+			NodeSelector: resourceRequest.NodeSelector,
 		},
 		Timestamp:   time.Now().UnixNano(),
 		MaxHops:     int32(s.manager.GetMaxHops()),
@@ -229,20 +267,23 @@ func (s *service) QueryResources(ctx context.Context, resourceRequest *types.Inf
 
 	// 向所有已知 peer 发送查询请求
 	for _, peerAddr := range peerAddresses {
-		// 创建带超时的上下文
-		queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
-		defer cancel()
+		// 调用方截止时间已到时停止查询，返回已找到的节点或超时错误
+		if ctx.Err() != nil {
+			break
+		}
 
 		// 创建 gRPC 连接
-		conn, err := grpc.NewClient(peerAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		conn, err := s.dialPeer(peerAddr)
 		if err != nil {
 			logrus.Debugf("Failed to connect to peer %s for resource query: %v", peerAddr, err)
 			continue
 		}
 
-		// 创建客户端并查询
+		// 单个 peer 的查询超时不超过调用方剩余时间（由父 ctx 约束）
+		queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 		client := discoverypb.NewDiscoveryServiceClient(conn)
 		resp, err := client.QueryResources(queryCtx, protoReq)
+		cancel()
 		conn.Close()
 
 		if err != nil {
@@ -272,6 +313,9 @@ func (s *service) QueryResources(ctx context.Context, resourceRequest *types.Inf
 	if len(availableNodes) > 0 {
 		return availableNodes, nil
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("resource query interrupted: %w", err)
+	}
 
 	return nil, fmt.Errorf("no available nodes found")
 }
@@ -282,6 +326,11 @@ func (s *service) nodeSatisfiesRequest(node *PeerNode, req *ResourceRequest, req
 		return false
 	}
 
+	// 检查节点标签约束
+	if !types.MatchLabels(req.NodeSelector, node.Labels) {
+		return false
+	}
+
 	// 检查资源标签
 	if requiredTags != nil {
 		if requiredTags.CPU && (node.ResourceTags == nil || !node.ResourceTags.CPU) {
@@ -364,6 +413,25 @@ func convertPeerNodeToProto(node *PeerNode, gossipCount int) *discoverypb.PeerNo
 		}
 	}
 
+	// 转换能耗画像
+	if node.EnergyProfile != nil {
+		protoNode.EnergyProfile = &discoverypb.EnergyProfile{
+			WattsPerCore:   node.EnergyProfile.WattsPerCore,
+			BatteryPowered: node.EnergyProfile.BatteryPowered,
+		}
+	}
+
+	if len(node.Labels) > 0 {
+		protoNode.Labels = make(map[string]string, len(node.Labels))
+		for k, v := range node.Labels {
+			protoNode.Labels[k] = v
+		}
+	}
+	protoNode.HeadRole = string(node.HeadRole)
+	protoNode.HeadTerm = node.HeadTerm
+	protoNode.HeadPriority = node.HeadPriority
+	protoNode.Protocol = node.Protocol()
+
 	return protoNode
 }
 
@@ -422,6 +490,30 @@ func convertProtoToPeerNode(proto *discoverypb.PeerNodeInfo) *PeerNode {
 		}
 	}
 
+	// 转换能耗画像
+	if proto.EnergyProfile != nil {
+		node.EnergyProfile = &types.EnergyProfile{
+			WattsPerCore:   proto.EnergyProfile.WattsPerCore,
+			BatteryPowered: proto.EnergyProfile.BatteryPowered,
+		}
+	}
+
+	if len(proto.Labels) > 0 {
+		node.Labels = make(map[string]string, len(proto.Labels))
+		for k, v := range proto.Labels {
+			node.Labels[k] = v
+		}
+	}
+	node.HeadRole = HeadRole(proto.HeadRole)
+	node.HeadTerm = proto.HeadTerm
+	node.HeadPriority = proto.HeadPriority
+
+	if p := proto.Protocol; p != nil {
+		node.ProtocolVersion = p.Version
+		node.MinProtocolVersion = p.MinVersion
+		node.Capabilities = append([]string(nil), p.Capabilities...)
+	}
+
 	return node
 }
 
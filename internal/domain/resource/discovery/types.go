@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/9triver/iarnet/internal/domain/resource/types"
+	commonpb "github.com/9triver/iarnet/internal/proto/common"
 )
 
 // ResourceTags 资源标签（描述节点支持的计算资源类型）
@@ -67,11 +68,34 @@ const (
 	NodeStatusUnknown NodeStatus = "unknown"
 )
 
+// NodeLiveness 节点存活状态，由本节点根据最后一次收到心跳推进的时间计算，不随 gossip 传播
+type NodeLiveness string
+
+const (
+	// NodeLivenessAlive 最近收到过该节点的心跳
+	NodeLivenessAlive NodeLiveness = "alive"
+	// NodeLivenessSuspect 超过 suspect 超时未收到心跳，不参与调度，超过节点 TTL 后被移除
+	NodeLivenessSuspect NodeLiveness = "suspect"
+)
+
+// HeadRole 节点在域内的 head 角色，随 gossip 传播
+type HeadRole string
+
+const (
+	// HeadRoleNone 普通节点
+	HeadRoleNone HeadRole = ""
+	// HeadRoleActive 当前的 head 节点
+	HeadRoleActive HeadRole = "head"
+	// HeadRoleStandby 备用 head 节点，head 失联后接管
+	HeadRoleStandby HeadRole = "standby"
+)
+
 // ResourceRequest 资源请求（用于查询）
 type ResourceRequest struct {
-	CPU    int64
-	Memory int64
-	GPU    int64
+	CPU          int64
+	Memory       int64
+	GPU          int64
+	NodeSelector map[string]string // 节点标签约束，节点须具备全部标签
 }
 
 // PeerNode 表示通过 gossip 发现的同域节点
@@ -85,14 +109,30 @@ type PeerNode struct {
 	DomainID         string // 所属域 ID（只发现同域节点）
 
 	// 资源信息（复用现有类型）
-	ResourceCapacity *types.Capacity // 资源容量（Total/Used/Available）
-	ResourceTags     *ResourceTags   // 资源标签（CPU/GPU/Memory/Camera）
+	ResourceCapacity *types.Capacity      // 资源容量（Total/Used/Available）
+	ResourceTags     *ResourceTags        // 资源标签（CPU/GPU/Memory/Camera）
+	EnergyProfile    *types.EnergyProfile // 能耗画像（可选）
+	Labels           map[string]string    // 节点标签（来自配置，如 zone=edge），可用于按标签查询
+
+	// 协议信息（旧版节点不携带，ProtocolVersion 为 0）
+	ProtocolVersion    uint32   // 节点实现的协议版本
+	MinProtocolVersion uint32   // 节点仍兼容的最低协议版本
+	Capabilities       []string // 节点支持的可选能力
+
+	// head 角色（旧版节点不携带，视为普通节点）
+	HeadRole     HeadRole // 域内 head 角色
+	HeadTerm     uint64   // head 任期，每次故障转移递增
+	HeadPriority int32    // 备用 head 的接管优先级，高者优先
 
 	// 状态信息
 	Status      NodeStatus // 节点状态（online/offline/error）
-	LastSeen    time.Time  // 最后活跃时间
+	LastSeen    time.Time  // 最后活跃时间（节点自身的心跳时间，随 gossip 传播）
 	LastUpdated time.Time  // 最后更新时间
 
+	// 存活信息（仅本地维护，不随 gossip 传播）
+	LastHeard time.Time    // 本地最后一次观察到该节点心跳或版本推进的时间
+	Liveness  NodeLiveness // 存活状态（仅在返回的副本中填充）
+
 	// Gossip 元数据
 	DiscoveredAt time.Time // 首次发现时间
 	SourcePeer   string    // 发现来源（哪个 peer 告知的）
@@ -100,9 +140,31 @@ type PeerNode struct {
 	GossipCount  int       // 传播次数（用于 TTL）
 }
 
+// Protocol 返回节点声明的协议信息，旧版节点返回 nil（按 legacy 协商）
+func (n *PeerNode) Protocol() *commonpb.ProtocolInfo {
+	if n == nil || n.ProtocolVersion == 0 {
+		return nil
+	}
+	return &commonpb.ProtocolInfo{
+		Version:      n.ProtocolVersion,
+		MinVersion:   n.MinProtocolVersion,
+		Capabilities: append([]string(nil), n.Capabilities...),
+	}
+}
+
 // IsStale 检查节点信息是否过期
+// 以本地观察到心跳推进的时间为准：其他节点转发的旧信息不会让已离开的节点保持存活
 func (n *PeerNode) IsStale(ttl time.Duration) bool {
-	return time.Since(n.LastSeen) > ttl
+	heard := n.LastHeard
+	if heard.IsZero() {
+		heard = n.LastSeen
+	}
+	return time.Since(heard) > ttl
+}
+
+// newerThan 判断节点信息是否比给定的版本和心跳更新
+func (n *PeerNode) newerThan(version uint64, heartbeat time.Time) bool {
+	return n.Version > version || n.LastSeen.After(heartbeat)
 }
 
 // UpdateFrom 从另一个节点信息更新（版本控制）
@@ -129,11 +191,34 @@ func (n *PeerNode) UpdateFrom(other *PeerNode) bool {
 	// 如果节点资源信息从有值变为 nil，说明节点失去了资源，也应该更新
 	n.ResourceCapacity = other.ResourceCapacity
 	n.ResourceTags = other.ResourceTags
+	n.EnergyProfile = other.EnergyProfile
+	n.Labels = other.Labels
+	n.ProtocolVersion = other.ProtocolVersion
+	n.MinProtocolVersion = other.MinProtocolVersion
+	n.Capabilities = other.Capabilities
+	n.HeadRole = other.HeadRole
+	n.HeadTerm = other.HeadTerm
+	n.HeadPriority = other.HeadPriority
 	n.Status = other.Status
-	n.LastSeen = other.LastSeen
+	// 心跳只前进不后退：经不同路径转发的旧副本不能回退心跳
+	if other.LastSeen.After(n.LastSeen) {
+		n.LastSeen = other.LastSeen
+	}
 	n.LastUpdated = other.LastUpdated
 	n.Version = other.Version
 	n.GossipCount = other.GossipCount
 
 	return true
 }
+
+// copyLabels 复制节点标签，空标签返回 nil
+func copyLabels(labels map[string]string) map[string]string {
+	if len(labels) == 0 {
+		return nil
+	}
+	copied := make(map[string]string, len(labels))
+	for k, v := range labels {
+		copied[k] = v
+	}
+	return copied
+}
@@ -0,0 +1,93 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrShuttingDown 节点正在关闭，不再接受新的部署
+var ErrShuttingDown = errors.New("node is shutting down, not accepting new deployments")
+
+// deploymentTracker 跟踪进行中的 component 部署，用于关闭时排空
+type deploymentTracker struct {
+	mu       sync.Mutex
+	draining bool
+	inflight int
+	idle     chan struct{} // 排空期间 inflight 归零时关闭
+}
+
+func newDeploymentTracker() *deploymentTracker {
+	return &deploymentTracker{}
+}
+
+// begin 登记一次部署，排空开始后返回 false
+func (t *deploymentTracker) begin() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.draining {
+		return false
+	}
+	t.inflight++
+	return true
+}
+
+// end 结束一次部署
+func (t *deploymentTracker) end() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.inflight--
+	if t.draining && t.inflight == 0 && t.idle != nil {
+		close(t.idle)
+		t.idle = nil
+	}
+}
+
+// active 进行中的部署数
+func (t *deploymentTracker) active() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.inflight
+}
+
+// drain 停止接受新的部署并等待进行中的部署结束，ctx 结束时返回仍未完成的部署数
+func (t *deploymentTracker) drain(ctx context.Context) (int, error) {
+	t.mu.Lock()
+	t.draining = true
+	if t.inflight == 0 {
+		t.mu.Unlock()
+		return 0, nil
+	}
+	if t.idle == nil {
+		t.idle = make(chan struct{})
+	}
+	idle := t.idle
+	t.mu.Unlock()
+
+	select {
+	case <-idle:
+		return 0, nil
+	case <-ctx.Done():
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		return t.inflight, ctx.Err()
+	}
+}
+
+// Drain 停止接受新的 component 部署（返回 ErrShuttingDown），并等待进行中的部署完成
+// 包括本地部署以及委托给其他节点或全局调度器的部署；ctx 超时时返回错误和仍未完成的部署数
+func (m *Manager) Drain(ctx context.Context) (int, error) {
+	return m.deployments.drain(ctx)
+}
+
+// resume 恢复接受部署
+func (t *deploymentTracker) resume() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.draining = false
+}
+
+// ResumeDeployments 在 Drain 之后恢复接受新的部署，用于进程交接失败、旧进程继续提供服务时
+func (m *Manager) ResumeDeployments() {
+	m.deployments.resume()
+}
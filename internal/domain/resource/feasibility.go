@@ -0,0 +1,126 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/9triver/iarnet/internal/domain/resource/component"
+	"github.com/9triver/iarnet/internal/domain/resource/decision"
+	"github.com/9triver/iarnet/internal/domain/resource/discovery"
+	"github.com/9triver/iarnet/internal/domain/resource/provider"
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+)
+
+// PlanItem 可行性检查中一个 component 的假设放置
+type PlanItem struct {
+	Request    *types.Info
+	Placed     bool
+	Kind       decision.CandidateKind // 放置在本节点的 provider 还是同域的其他节点
+	TargetID   string                 // provider ID 或节点 ID
+	TargetName string
+	Available  *types.Info // 放置前目标的可用资源（已扣除此前的假设放置），未知时为 nil
+	Reason     string      // 无法放置的原因
+}
+
+// DeploymentPlan 可行性检查结果
+type DeploymentPlan struct {
+	Feasible  bool
+	Items     []PlanItem // 与请求顺序一致
+	Shortfall types.Info // 无法放置的 component 的资源总和
+}
+
+// PlanDeployment 检查一组 component 能否全部部署，返回假设的放置方案或资源缺口
+// 按请求顺序依次走与实际部署相同的调度流程（本地 provider 策略链，其次向同域节点发送部署探测），但不实际部署；
+// 已假设放置的资源会从后续 component 可用的资源中扣除。全局调度没有无副作用的探测接口，不参与检查
+func (m *Manager) PlanDeployment(ctx context.Context, runtimeEnv types.RuntimeEnv, requests []*types.Info) (*DeploymentPlan, error) {
+	planner, ok := m.componentService.(component.PlacementPlanner)
+	if !ok {
+		return nil, fmt.Errorf("component service does not support placement planning")
+	}
+
+	localLedger := provider.NewPlanLedger()
+	peerLedger := provider.NewPlanLedger()
+	localCtx := provider.WithPlanLedger(ctx, localLedger)
+
+	plan := &DeploymentPlan{Feasible: true, Items: make([]PlanItem, 0, len(requests))}
+	for _, request := range requests {
+		item := PlanItem{Request: request}
+
+		p, localErr := planner.PlanPlacement(localCtx, runtimeEnv, request)
+		if localErr == nil {
+			available, _ := p.GetCachedAvailable()
+			item.Placed = true
+			item.Kind = decision.CandidateProvider
+			item.TargetID = p.GetID()
+			item.TargetName = p.GetName()
+			item.Available = localLedger.Remaining(p.GetID(), available)
+			localLedger.Reserve(p.GetID(), request)
+		} else if node, available, peerErr := m.planOnPeers(ctx, runtimeEnv, request, peerLedger); peerErr == nil {
+			item.Placed = true
+			item.Kind = decision.CandidatePeer
+			item.TargetID = node.NodeID
+			item.TargetName = node.NodeName
+			item.Available = available
+			peerLedger.Reserve(node.NodeID, request)
+		} else {
+			item.Reason = fmt.Sprintf("local: %v; peers: %v", localErr, peerErr)
+			plan.Feasible = false
+			addInfo(&plan.Shortfall, request)
+		}
+		plan.Items = append(plan.Items, item)
+	}
+	return plan, nil
+}
+
+// planOnPeers 向同域候选节点发送部署探测，返回第一个接受且扣除此前假设放置后仍有足够资源的节点
+// 对端不报告可用资源时使用 gossip 传播的可用资源
+func (m *Manager) planOnPeers(ctx context.Context, runtimeEnv types.RuntimeEnv, request *types.Info, ledger *provider.PlanLedger) (*discovery.PeerNode, *types.Info, error) {
+	if m.discoveryService == nil || m.schedulerService == nil {
+		return nil, nil, fmt.Errorf("discovery service or scheduler service not configured")
+	}
+	nodes, err := m.discoveryService.QueryResources(ctx, request, discoveryTagsFor(request))
+	if err != nil {
+		return nil, nil, fmt.Errorf("query resources via discovery service failed: %w", err)
+	}
+	if len(nodes) == 0 {
+		return nil, nil, fmt.Errorf("no in-domain nodes have sufficient resources")
+	}
+	rankPeerNodes(nodes, request, m.peerHistory)
+
+	for start := 0; start < len(nodes); start += m.delegationProbes {
+		batch := nodes[start:min(start+m.delegationProbes, len(nodes))]
+		results := make(chan probeOutcome, len(batch))
+		for i, node := range batch {
+			go func(i int, node *discovery.PeerNode) {
+				results <- m.probePeer(ctx, runtimeEnv, request, i, node)
+			}(i, node)
+		}
+		outcomes := make([]probeOutcome, len(batch))
+		for range batch {
+			outcome := <-results
+			outcomes[outcome.index] = outcome
+		}
+
+		for i, outcome := range outcomes {
+			if !outcome.accepted {
+				continue
+			}
+			node := batch[i]
+			available := outcome.available
+			if available == nil && node.ResourceCapacity != nil {
+				available = node.ResourceCapacity.Available
+			}
+			remaining := ledger.Remaining(node.NodeID, available)
+			if remaining != nil && !coversRequest(remaining, request) {
+				continue
+			}
+			return node, remaining, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("all candidate nodes rejected the deployment request")
+}
+
+// coversRequest 可用资源是否足以容纳请求
+func coversRequest(available, request *types.Info) bool {
+	return available.CPU >= request.CPU && available.Memory >= request.Memory && available.GPU >= request.GPU
+}
@@ -0,0 +1,20 @@
+package resource
+
+import (
+	"context"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/component"
+)
+
+// ExportHandoverState 导出已部署 component 的路由状态与会话，用于滚动重启时交接给新进程
+// 调用前应先 Drain，避免导出后仍有新的部署
+func (m *Manager) ExportHandoverState() *component.HandoverState {
+	return m.componentManager.Export()
+}
+
+// RestoreHandoverState 恢复旧进程导出的状态，新进程继续与已部署的 component 通信而无需重新部署
+// 应在 Start 之前调用，使 component 重新连接后发来的消息能够找到对应的 component
+func (m *Manager) RestoreHandoverState(ctx context.Context, state *component.HandoverState, grace time.Duration) {
+	m.componentManager.Restore(ctx, state, grace)
+}
@@ -0,0 +1,221 @@
+package resource
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/discovery"
+	"github.com/sirupsen/logrus"
+)
+
+// HeadFailoverPolicy head 角色故障转移配置
+// 备用节点通过 gossip 观察 head 的存活状态，head 连续多次不存活时由优先级最高的存活备用节点接管
+type HeadFailoverPolicy struct {
+	Standby       bool          // 本节点是否为备用 head
+	Priority      int32         // 多个备用节点均可接管时，优先级高者接管，相同时节点 ID 小者接管
+	CheckInterval time.Duration // 检查 head 存活的间隔
+	MissThreshold int           // head 连续多少次检查不存活后接管
+	StartupGrace  time.Duration // 启动后的宽限期，gossip 收敛前尚未得知 head 时不接管
+}
+
+// headRole 本节点的 head 角色状态
+// 任期（term）在每次接管时递增：同时出现多个 head 时（e.g., 网络分区恢复后），任期低者退为备用节点
+type headRole struct {
+	mu     sync.Mutex
+	policy HeadFailoverPolicy
+	isHead bool
+	term   uint64
+	misses int       // head 连续不存活的检查次数
+	since  time.Time // 存活检查开始时间
+
+	stop   chan struct{}
+	notify chan struct{} // 角色变化后通知健康检查循环立即上报 registry
+}
+
+func newHeadRole() *headRole {
+	return &headRole{
+		stop:   make(chan struct{}),
+		notify: make(chan struct{}, 1),
+	}
+}
+
+// state 返回当前是否为 head 及任期
+func (h *headRole) state() (bool, uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.isHead, h.term
+}
+
+// SetIsHead 设置当前节点是否为 head 节点，配置的 head 从任期 1 开始
+func (m *Manager) SetIsHead(isHead bool) {
+	m.head.mu.Lock()
+	defer m.head.mu.Unlock()
+	m.head.isHead = isHead
+	if isHead && m.head.term == 0 {
+		m.head.term = 1
+	}
+}
+
+// SetHeadFailover 设置 head 角色故障转移配置
+func (m *Manager) SetHeadFailover(policy HeadFailoverPolicy) {
+	m.head.mu.Lock()
+	defer m.head.mu.Unlock()
+	m.head.policy = policy
+}
+
+// GetHeadRole 返回本节点当前的 head 角色与任期
+func (m *Manager) GetHeadRole() (discovery.HeadRole, uint64) {
+	m.head.mu.Lock()
+	defer m.head.mu.Unlock()
+	return m.head.roleLocked(), m.head.term
+}
+
+func (h *headRole) roleLocked() discovery.HeadRole {
+	switch {
+	case h.isHead:
+		return discovery.HeadRoleActive
+	case h.policy.Standby:
+		return discovery.HeadRoleStandby
+	}
+	return discovery.HeadRoleNone
+}
+
+// startHeadFailover 通过 gossip 宣告本节点的 head 角色，head 与备用节点启动存活检查
+func (m *Manager) startHeadFailover(ctx context.Context) {
+	m.announceHeadRole()
+
+	m.head.mu.Lock()
+	policy, isHead := m.head.policy, m.head.isHead
+	m.head.since = time.Now()
+	m.head.mu.Unlock()
+	if (!policy.Standby && !isHead) || policy.CheckInterval <= 0 || m.discoveryService == nil {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(policy.CheckInterval)
+		defer ticker.Stop()
+
+		logrus.Infof("Head failover started (head=%v, standby=%v, priority=%d)", isHead, policy.Standby, policy.Priority)
+		for {
+			select {
+			case <-ticker.C:
+				m.checkHead()
+			case <-m.head.stop:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// stopHeadFailover 停止存活检查
+func (m *Manager) stopHeadFailover() {
+	select {
+	case <-m.head.stop:
+	default:
+		close(m.head.stop)
+	}
+}
+
+// announceHeadRole 通过 gossip 宣告本节点的 head 角色，discovery 未启用时不做任何事
+func (m *Manager) announceHeadRole() {
+	announcer, ok := m.discoveryService.(discovery.HeadRoleAnnouncer)
+	if !ok {
+		return
+	}
+	m.head.mu.Lock()
+	role, term, priority := m.head.roleLocked(), m.head.term, m.head.policy.Priority
+	m.head.mu.Unlock()
+	announcer.SetLocalHeadRole(role, term, priority)
+}
+
+// checkHead 执行一次 head 存活检查
+// head 节点发现任期更高的存活 head 时退为备用节点；备用节点在 head 连续不存活达到阈值后，
+// 若自己是优先级最高的存活备用节点则以更高的任期接管。角色变化后立即通过 gossip 与健康检查通知同域节点和 registry
+func (m *Manager) checkHead() {
+	nodes := m.discoveryService.GetKnownNodes()
+
+	h := m.head
+	h.mu.Lock()
+	maxTerm := h.term
+	var head *discovery.PeerNode
+	for _, node := range nodes {
+		if node.NodeID == m.nodeID {
+			continue
+		}
+		maxTerm = max(maxTerm, node.HeadTerm)
+		if node.HeadRole != discovery.HeadRoleActive || node.Liveness != discovery.NodeLivenessAlive {
+			continue
+		}
+		if head == nil || headOutranks(node.HeadTerm, node.NodeID, head.HeadTerm, head.NodeID) {
+			head = node
+		}
+	}
+
+	changed := false
+	switch {
+	case h.isHead:
+		if head != nil && headOutranks(head.HeadTerm, head.NodeID, h.term, m.nodeID) {
+			logrus.Warnf("Node %s (%s) is head with term %d, stepping down from term %d to standby", head.NodeName, head.NodeID, head.HeadTerm, h.term)
+			h.isHead = false
+			h.term = head.HeadTerm
+			h.policy.Standby = true
+			h.misses = 0
+			changed = true
+		}
+	case head != nil:
+		h.misses = 0
+		h.term = max(h.term, head.HeadTerm)
+	default:
+		if time.Since(h.since) < h.policy.StartupGrace {
+			break
+		}
+		h.misses++
+		if h.misses < h.policy.MissThreshold {
+			logrus.Debugf("No alive head in domain (%d/%d checks)", h.misses, h.policy.MissThreshold)
+			break
+		}
+		if rival := m.preferredStandby(nodes, h.policy.Priority); rival != nil {
+			logrus.Debugf("No alive head in domain, waiting for standby %s (%s) with higher priority to take over", rival.NodeName, rival.NodeID)
+			break
+		}
+		h.isHead = true
+		h.term = maxTerm + 1
+		h.misses = 0
+		changed = true
+		logrus.Warnf("No alive head in domain for %d checks, node %s promoted to head with term %d", h.policy.MissThreshold, m.nodeID, h.term)
+	}
+	h.mu.Unlock()
+
+	if changed {
+		m.announceHeadRole()
+		select {
+		case h.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// preferredStandby 返回比本节点更适合接管的存活备用节点，不存在时返回 nil
+func (m *Manager) preferredStandby(nodes []*discovery.PeerNode, priority int32) *discovery.PeerNode {
+	for _, node := range nodes {
+		if node.NodeID == m.nodeID || node.HeadRole != discovery.HeadRoleStandby || node.Liveness != discovery.NodeLivenessAlive {
+			continue
+		}
+		if node.HeadPriority > priority || (node.HeadPriority == priority && node.NodeID < m.nodeID) {
+			return node
+		}
+	}
+	return nil
+}
+
+// headOutranks 判断 head a 是否优先于 head b：任期高者优先，任期相同时节点 ID 小者优先
+func headOutranks(termA uint64, idA string, termB uint64, idB string) bool {
+	if termA != termB {
+		return termA > termB
+	}
+	return idA < idB
+}
@@ -0,0 +1,29 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/9triver/iarnet/internal/domain/resource/provider"
+)
+
+// GetComponentInstanceStatus 查询本节点 component 实例的运行状态与退出码
+// 委托到其他节点的 component 需在其所在节点上查询
+func (m *Manager) GetComponentInstanceStatus(ctx context.Context, componentID string) (*provider.InstanceStatus, error) {
+	comp := m.componentManager.Get(componentID)
+	if comp == nil {
+		return nil, fmt.Errorf("component %s not found", componentID)
+	}
+	nodeID, providerID := m.placementOf(comp)
+	if nodeID != m.nodeID {
+		return nil, fmt.Errorf("component %s is deployed on node %s, query its instance status there", componentID, nodeID)
+	}
+	if providerID == "" {
+		return nil, fmt.Errorf("component %s has not been placed on a provider yet", componentID)
+	}
+	p := m.providerService.GetProvider(providerID)
+	if p == nil {
+		return nil, fmt.Errorf("provider %s of component %s not found", providerID, componentID)
+	}
+	return p.GetInstanceStatus(ctx, componentID)
+}
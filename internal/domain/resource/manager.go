@@ -2,19 +2,25 @@ package resource
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/9triver/iarnet/internal/domain/resource/accounting"
 	"github.com/9triver/iarnet/internal/domain/resource/component"
+	"github.com/9triver/iarnet/internal/domain/resource/decision"
 	"github.com/9triver/iarnet/internal/domain/resource/discovery"
 	"github.com/9triver/iarnet/internal/domain/resource/logger"
 	"github.com/9triver/iarnet/internal/domain/resource/provider"
 	"github.com/9triver/iarnet/internal/domain/resource/scheduler"
 	"github.com/9triver/iarnet/internal/domain/resource/store"
+	"github.com/9triver/iarnet/internal/domain/resource/trace"
 	"github.com/9triver/iarnet/internal/domain/resource/types"
 	providerrepo "github.com/9triver/iarnet/internal/infra/repository/resource"
 	commonpb "github.com/9triver/iarnet/internal/proto/common"
@@ -23,6 +29,7 @@ import (
 	schedulerpb "github.com/9triver/iarnet/internal/proto/resource/scheduler"
 	storepb "github.com/9triver/iarnet/internal/proto/resource/store"
 	"github.com/9triver/iarnet/internal/util"
+	"github.com/9triver/iarnet/internal/util/identity"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -43,23 +50,64 @@ type Manager struct {
 	providerManager    *provider.Manager
 	loggerService      logger.Service
 	envVariables       *provider.EnvVariables
+	egressPolicy       *provider.EgressPolicy // 默认出站网络策略，nil 表示不限制
 	nodeID             string
 	name               string
 	description        string
 	domainID           string
 	domainName         string
-	isHead             bool
-	globalRegistryAddr string        // 全局注册中心地址
-	nodeAddress        string        // 节点地址 (host:port)，用于健康检查上报
-	healthCheckStop    chan struct{} // 用于停止健康检查 goroutine
+	head               *headRole        // head 角色与故障转移
+	globalRegistryAddr string           // 全局注册中心地址
+	nodeAddress        string           // 节点地址 (host:port)，用于健康检查上报
+	healthCheckStop    chan struct{}    // 用于停止健康检查 goroutine
+	resourceChanged    chan struct{}    // provider 总容量变化后通知健康检查循环立即上报
+	providerJournal    *providerJournal // 分页列举 provider 的版本与变更记录
 	discoveryService   discovery.Service
 	schedulerService   scheduler.Service
+	deployments        *deploymentTracker         // 进行中的部署，关闭时排空
+	decisionLog        decision.Sink              // 调度决策日志，nil 表示不记录
+	traceRecorder      *trace.Recorder            // 部署请求 trace（重放用），nil 表示不记录
+	rebalancer         *rebalancer                // 反应式再平衡
+	affinity           *affinityTable             // 会话亲和
+	completions        *completionWatches         // 等待 component 结束的监视
+	peerHistory        *provider.PlacementHistory // 按节点统计的历史委托结果，nil 表示不使用
+
+	// 节点标签，随全局注册上报，并用于判断本节点是否满足部署请求的节点标签约束
+	labels map[string]string
+
+	// 节点身份密钥，用于签名注册与健康检查请求，nil 表示不签名
+	identity *identity.Identity
+
+	// 资源占用记账，nil 表示不记账
+	accounting accounting.Service
+
+	// 利用率采样日志（离线分析用），nil 表示不记录
+	utilizationLog         *UtilizationLog
+	utilizationLogInterval time.Duration
+	utilizationLogStop     chan struct{}
+
+	// 配置文件中声明的 provider，启动时自动注册，连接失败时按 staticProviderRetry 重试
+	staticProviders     []provider.StaticProvider
+	staticProviderRetry time.Duration
+
+	// 委托部署并行探测
+	delegationProbes       int           // 同时探测的候选节点数
+	delegationProbeTimeout time.Duration // 单个节点的探测超时
+
+	// 委托部署对账：目标节点定期上报由本节点委托部署的 component，清理提交后响应丢失而遗留的
+	commits           *delegationCommits // 进行中的委托提交
+	reconcileInterval time.Duration      // 本节点作为目标节点时向委托方对账的间隔，0 表示不对账
+	reconcileStop     chan struct{}
 
 	// 实时负载轮询服务
 	usagePollingCtx    context.Context
 	usagePollingCancel context.CancelFunc
 	usagePollingWg     sync.WaitGroup
-	usagePollInterval  time.Duration // 轮询间隔，默认 5 秒
+	usagePollInterval  time.Duration // 轮询间隔，默认 2 秒；自适应轮询时为下限
+	usagePollMax       time.Duration // 自适应轮询的间隔上限，0 表示固定间隔
+	usageActivity      *usageActivity
+	usageWatchMu       sync.Mutex
+	usageWatches       map[string]*usageWatch // provider ID -> 使用量推送流状态
 }
 
 // loadOrGenerateNodeID 从文件加载节点 ID，如果不存在则生成新的并保存
@@ -111,22 +159,58 @@ func NewManager(channeler component.Channeler, s *store.Store, componentImages m
 	// 初始化轮询上下文
 	usagePollingCtx, usagePollingCancel := context.WithCancel(context.Background())
 
-	return &Manager{
-		componentService:   component.NewService(componentManager, providerService, componentImages),
-		storeService:       store.NewService(s),
-		providerService:    providerService,
-		componentManager:   componentManager,
-		providerManager:    providerManager,
-		nodeID:             nodeID,
-		name:               name,
-		description:        description,
-		domainID:           domainID,
-		envVariables:       envVariables,
-		healthCheckStop:    make(chan struct{}),
-		usagePollingCtx:    usagePollingCtx,
-		usagePollingCancel: usagePollingCancel,
-		usagePollInterval:  2 * time.Second, // 默认 2 秒轮询一次（与前端最小间隔一致）
+	componentService := component.NewService(componentManager, providerService, componentImages)
+
+	// best-effort provider 下线时驱逐其上的 component 并重新调度
+	providerManager.SetEvictionHandler(func(p *provider.Provider) {
+		go func() {
+			if err := componentService.EvictProvider(context.Background(), p.GetID()); err != nil {
+				logrus.Warnf("Failed to reschedule components evicted from provider %s: %v", p.GetID(), err)
+			}
+		}()
+	})
+	// 被驱逐的 component 在原 provider 上的实例下线时无法删除，重新连接后删除
+	providerManager.SetReconnectHandler(func(p *provider.Provider) {
+		go func() {
+			if err := componentService.UndeployEvicted(context.Background(), p.GetID()); err != nil {
+				logrus.Warnf("Failed to remove evicted components left on provider %s: %v", p.GetID(), err)
+			}
+		}()
+	})
+
+	m := &Manager{
+		componentService:       componentService,
+		storeService:           store.NewService(s),
+		providerService:        providerService,
+		componentManager:       componentManager,
+		providerManager:        providerManager,
+		nodeID:                 nodeID,
+		name:                   name,
+		description:            description,
+		domainID:               domainID,
+		envVariables:           envVariables,
+		healthCheckStop:        make(chan struct{}),
+		resourceChanged:        make(chan struct{}, 1),
+		providerJournal:        newProviderJournal(),
+		deployments:            newDeploymentTracker(),
+		rebalancer:             newRebalancer(),
+		affinity:               newAffinityTable(),
+		completions:            newCompletionWatches(),
+		head:                   newHeadRole(),
+		delegationProbes:       defaultDelegationProbes,
+		delegationProbeTimeout: defaultDelegationProbeTimeout,
+		commits:                newDelegationCommits(),
+		reconcileInterval:      defaultReconcileInterval,
+		usagePollingCtx:        usagePollingCtx,
+		usagePollingCancel:     usagePollingCancel,
+		usagePollInterval:      2 * time.Second, // 默认 2 秒轮询一次（与前端最小间隔一致）
+		usageWatches:           make(map[string]*usageWatch),
+		usageActivity:          &usageActivity{last: make(map[string]float64)},
 	}
+
+	// provider 总容量变化（e.g., 修改配置后重启）时核对已分配的资源并立即上报
+	providerManager.SetCapacityChangeHandler(m.reconcileCapacity)
+	return m
 }
 
 // dependency injection
@@ -152,9 +236,137 @@ func (m *Manager) SetNodeAddress(addr string) {
 	m.nodeAddress = addr
 }
 
-// SetIsHead 设置当前节点是否为 head 节点
-func (m *Manager) SetIsHead(isHead bool) {
-	m.isHead = isHead
+// SetEgressPolicy 设置本节点部署 component 时默认使用的出站网络策略
+// 部署 context 中已附加策略时以 context 中的为准
+func (m *Manager) SetEgressPolicy(policy *provider.EgressPolicy) {
+	m.egressPolicy = policy
+}
+
+// SetComponentEnv 设置部署 component 时附加的环境变量模板
+// 模板可引用节点与 provider 元数据（e.g., {{.NodeIP}}、{{.ProviderID}}、{{.ComponentID}}），在选定 provider 后渲染
+func (m *Manager) SetComponentEnv(env map[string]string) error {
+	tmpl, err := component.ParseEnvTemplate(env)
+	if err != nil {
+		return err
+	}
+	node := component.NodeMetadata{
+		NodeID:   m.nodeID,
+		NodeName: m.name,
+		DomainID: m.domainID,
+	}
+	if m.envVariables != nil {
+		node.NodeIP = m.envVariables.IarnetHost
+	}
+	setter, ok := m.componentService.(component.EnvTemplateSetter)
+	if !ok {
+		return fmt.Errorf("component service does not support env templates")
+	}
+	setter.SetEnvTemplate(tmpl, node)
+	return nil
+}
+
+// SetComponentImageArchitectures 设置各运行时环境 component 镜像支持的 CPU 架构
+// 部署时只考虑可运行该架构的 provider，未配置的镜像视为多架构镜像
+func (m *Manager) SetComponentImageArchitectures(archs map[string][]string) error {
+	setter, ok := m.componentService.(component.ImageArchitectureSetter)
+	if !ok {
+		return fmt.Errorf("component service does not support image architectures")
+	}
+	setter.SetImageArchitectures(archs)
+	return nil
+}
+
+// SetSidecarInjection 设置 sidecar 注入规则，命中规则的本地部署自动附加 sidecar
+// 委托到其他节点的部署按目标节点的规则注入
+func (m *Manager) SetSidecarInjection(rules []component.SidecarInjectionRule) error {
+	setter, ok := m.componentService.(component.SidecarInjectionSetter)
+	if !ok {
+		return fmt.Errorf("component service does not support sidecar injection")
+	}
+	return setter.SetSidecarInjection(rules)
+}
+
+// SetDeployRetryPolicy 设置本地部署 component 默认使用的重试策略
+func (m *Manager) SetDeployRetryPolicy(policy component.RetryPolicy) error {
+	setter, ok := m.componentService.(component.RetryPolicySetter)
+	if !ok {
+		return fmt.Errorf("component service does not support retry policies")
+	}
+	setter.SetRetryPolicy(policy)
+	return nil
+}
+
+// SetComponentLimits 设置本节点每个 provider 与整个节点上同时运行的 component 数量上限
+// 达到上限的 provider 在调度时被跳过，节点已满时部署请求委托给其他节点，来自其他节点的部署探测与提交同样被拒绝
+func (m *Manager) SetComponentLimits(limits component.DensityLimits) error {
+	setter, ok := m.componentService.(component.DensityLimitSetter)
+	if !ok {
+		return fmt.Errorf("component service does not support component limits")
+	}
+	setter.SetDensityLimits(limits)
+	return nil
+}
+
+// SetPlacementHistory 设置是否按历史放置结果调整调度偏好
+// 启用后按任务规模分别统计各 provider 的部署结果与各节点的委托结果，偏向历史上成功率高、耗时短的目标
+func (m *Manager) SetPlacementHistory(enabled bool, minSamples int) {
+	if !enabled {
+		m.providerService.SetPlacementHistory(nil)
+		m.peerHistory = nil
+		return
+	}
+	m.providerService.SetPlacementHistory(provider.NewPlacementHistory(minSamples))
+	m.peerHistory = provider.NewPlacementHistory(minSamples)
+}
+
+// SetCapacityCacheTTL 设置 provider 资源容量缓存的最大陈旧时间，ttl <= 0 表示缓存不过期
+func (m *Manager) SetCapacityCacheTTL(ttl time.Duration) {
+	m.providerService.SetCapacityCacheTTL(ttl)
+}
+
+// SetProviderBenchmark 设置注册 provider 后是否运行微基准测试及其超时
+func (m *Manager) SetProviderBenchmark(enabled bool, timeout time.Duration) {
+	m.providerService.SetBenchmarkOnRegister(enabled, timeout)
+}
+
+// BenchmarkProvider 对指定 provider 重新运行微基准测试
+func (m *Manager) BenchmarkProvider(ctx context.Context, id string) (*types.BenchmarkResult, error) {
+	return m.providerService.BenchmarkProvider(ctx, id)
+}
+
+// SetIdentity 设置节点身份密钥，注册与健康检查请求将携带公钥与签名
+func (m *Manager) SetIdentity(id *identity.Identity) {
+	m.identity = id
+}
+
+// SetNodeLabels 设置节点标签
+// 标签随全局注册上报，本节点标签不满足部署请求的节点标签约束时不在本地部署
+func (m *Manager) SetNodeLabels(labels map[string]string) {
+	m.labels = make(map[string]string, len(labels))
+	for k, v := range labels {
+		m.labels[k] = v
+	}
+	m.providerService.SetNodeLabels(labels)
+}
+
+// SetPolicyWebhook 设置外部策略 webhook，url 为空表示不使用
+// 部署前将候选 provider 交由外部端点审查，超时或出错时按 failOpen 决定放行还是拒绝
+func (m *Manager) SetPolicyWebhook(url string, timeout time.Duration, failOpen bool) {
+	if url == "" {
+		m.providerService.SetPolicyWebhook(nil)
+		return
+	}
+	m.providerService.SetPolicyWebhook(provider.NewWebhookPolicy(url, timeout, failOpen))
+}
+
+// SetTimeWindowRules 设置调度的时间窗口规则
+func (m *Manager) SetTimeWindowRules(rules []provider.TimeWindowRule) {
+	m.providerService.SetTimeWindowRules(rules)
+}
+
+// GetNodeLabels 获取节点标签
+func (m *Manager) GetNodeLabels() map[string]string {
+	return m.labels
 }
 
 // GetNodeID 获取节点 ID
@@ -198,7 +410,7 @@ func (m *Manager) getStoreAddress() string {
 	if m.envVariables == nil {
 		return ""
 	}
-	return fmt.Sprintf("%s:%d", m.envVariables.IarnetHost, m.envVariables.StorePort)
+	return m.envVariables.StoreAddress()
 }
 
 func (m *Manager) getLoggerAddress() string {
@@ -208,6 +420,27 @@ func (m *Manager) getLoggerAddress() string {
 	return fmt.Sprintf("%s:%d", m.envVariables.IarnetHost, m.envVariables.LoggerPort)
 }
 
+// upstreamToken 为委托到其他节点的 component 签发回连本节点时出示的令牌，令牌绑定所属应用；未启用上游令牌时返回空
+func (m *Manager) upstreamToken(componentID, appID string) string {
+	if m.envVariables == nil || m.envVariables.UpstreamTokens == nil {
+		return ""
+	}
+	return m.envVariables.UpstreamTokens.Issue(componentID, appID)
+}
+
+// SetUpstreamTokenEnforcement 设置 ZMQ 通道的上游令牌校验，需在 EnvVariables 中启用上游令牌
+// require 为 true 时丢弃未在 Ready 消息中出示有效令牌的 component 发来的消息
+func (m *Manager) SetUpstreamTokenEnforcement(require bool) {
+	if m.envVariables == nil || m.envVariables.UpstreamTokens == nil {
+		return
+	}
+	tokens := m.envVariables.UpstreamTokens
+	m.componentManager.SetTokenVerifier(func(componentID, token string) error {
+		_, err := tokens.Verify(componentID, token)
+		return err
+	}, require)
+}
+
 func convertStringsToDiscoveryTags(tags []string) *discovery.ResourceTags {
 	if len(tags) == 0 {
 		return nil
@@ -238,6 +471,15 @@ func convertStringsToDiscoveryTags(tags []string) *discovery.ResourceTags {
 	return rt
 }
 
+// discoveryTagsFor 请求要求的节点资源标签；绑定数据流的请求只能由具有摄像头的节点接收
+func discoveryTagsFor(request *types.Info) *discovery.ResourceTags {
+	tags := request.Tags
+	if request.Stream != "" {
+		tags = append(slices.Clone(tags), "camera")
+	}
+	return convertStringsToDiscoveryTags(tags)
+}
+
 // Start starts the component manager to receive messages from components
 func (m *Manager) Start(ctx context.Context) error {
 	// 从 repository 加载 provider
@@ -246,6 +488,9 @@ func (m *Manager) Start(ctx context.Context) error {
 		// 不返回错误，继续启动
 	}
 
+	// 同步并注册配置文件中声明的 provider
+	m.startStaticProviders(ctx)
+
 	// 启动组件管理器
 	if err := m.componentManager.Start(ctx); err != nil {
 		return err
@@ -259,6 +504,18 @@ func (m *Manager) Start(ctx context.Context) error {
 	// 启动实时负载轮询服务
 	m.startUsagePolling(ctx)
 
+	// 启动反应式再平衡（如果启用）
+	m.startRebalancer(ctx)
+
+	// 启动利用率采样（如果启用）
+	m.startUtilizationLog(ctx)
+
+	// 宣告 head 角色，head 与备用 head 启动存活检查
+	m.startHeadFailover(ctx)
+
+	// 定期向委托方对账由其委托部署到本节点的 component
+	m.startReconcile(ctx)
+
 	// 注册节点到全局注册中心
 	if m.globalRegistryAddr != "" {
 		if err := m.registerToGlobalRegistry(ctx); err != nil {
@@ -284,18 +541,25 @@ func (m *Manager) startUsagePolling(ctx context.Context) {
 	go func() {
 		defer m.usagePollingWg.Done()
 
-		ticker := time.NewTicker(m.usagePollInterval)
-		defer ticker.Stop()
+		interval := m.newUsagePollInterval()
+		timer := time.NewTimer(interval.Current())
+		defer timer.Stop()
 
-		logrus.Infof("Real-time usage polling service started with interval %v", m.usagePollInterval)
+		if m.usagePollMax > m.usagePollInterval {
+			logrus.Infof("Real-time usage polling service started with adaptive interval %v-%v", m.usagePollInterval, m.usagePollMax)
+		} else {
+			logrus.Infof("Real-time usage polling service started with interval %v", m.usagePollInterval)
+		}
 
 		// 立即执行一次轮询
 		m.pollProviderUsage(ctx)
 
 		for {
 			select {
-			case <-ticker.C:
+			case <-timer.C:
 				m.pollProviderUsage(ctx)
+				// 利用率变化快或有部署进行中时按下限轮询，否则逐轮放宽
+				timer.Reset(interval.Next(m.usageActivity.takeChanged() || m.hasDeploymentsInFlight()))
 			case <-m.usagePollingCtx.Done():
 				logrus.Info("Real-time usage polling service stopped")
 				return
@@ -308,8 +572,10 @@ func (m *Manager) startUsagePolling(ctx context.Context) {
 }
 
 // pollProviderUsage 轮询所有已连接的 provider 获取实时使用量
+// 已建立 WatchUsage 推送流的 provider 由推送更新，不再轮询
 func (m *Manager) pollProviderUsage(ctx context.Context) {
 	providers := m.providerService.GetAllProviders()
+	m.pruneUsageWatches(providers)
 	if len(providers) == 0 {
 		return
 	}
@@ -324,6 +590,9 @@ func (m *Manager) pollProviderUsage(ctx context.Context) {
 		if p.GetStatus() != types.ProviderStatusConnected {
 			continue
 		}
+		if m.ensureUsageWatch(ctx, p) {
+			continue
+		}
 
 		wg.Add(1)
 		go func(provider *provider.Provider) {
@@ -343,29 +612,7 @@ func (m *Manager) pollProviderUsage(ctx context.Context) {
 				return
 			}
 
-			if capacity == nil || capacity.Total == nil {
-				return
-			}
-
-			// 计算使用率
-			var cpuRate, memoryRate, gpuRate float64
-			if capacity.Total.CPU > 0 {
-				cpuRate = float64(usage.CPU) / float64(capacity.Total.CPU) * 100
-			}
-			if capacity.Total.Memory > 0 {
-				memoryRate = float64(usage.Memory) / float64(capacity.Total.Memory) * 100
-			}
-			if capacity.Total.GPU > 0 {
-				gpuRate = float64(usage.GPU) / float64(capacity.Total.GPU) * 100
-			}
-
-			// 记录数据点（目前记录到日志，后续可以扩展为持久化存储）
-			logrus.Debugf("Provider %s usage: CPU=%.3f%% (%d/%d millicores), Memory=%.3f%% (%d/%d bytes), GPU=%.3f%% (%d/%d)",
-				provider.GetID(),
-				cpuRate, usage.CPU, capacity.Total.CPU,
-				memoryRate, usage.Memory, capacity.Total.Memory,
-				gpuRate, usage.GPU, capacity.Total.GPU,
-			)
+			m.recordUsage(provider, usage, capacity)
 		}(p)
 	}
 
@@ -384,8 +631,43 @@ func (m *Manager) pollProviderUsage(ctx context.Context) {
 	}
 }
 
+// recordUsage 记录 provider 的一次使用量数据点
+func (m *Manager) recordUsage(p *provider.Provider, usage *types.Info, capacity *types.Capacity) {
+	if usage == nil || capacity == nil || capacity.Total == nil {
+		return
+	}
+
+	// 计算使用率
+	var cpuRate, memoryRate, gpuRate float64
+	if capacity.Total.CPU > 0 {
+		cpuRate = float64(usage.CPU) / float64(capacity.Total.CPU) * 100
+	}
+	if capacity.Total.Memory > 0 {
+		memoryRate = float64(usage.Memory) / float64(capacity.Total.Memory) * 100
+	}
+	if capacity.Total.GPU > 0 {
+		gpuRate = float64(usage.GPU) / float64(capacity.Total.GPU) * 100
+	}
+
+	m.recordUsageSample(p, usage)
+	m.usageActivity.observe(p.GetID(), max(cpuRate, memoryRate, gpuRate)/100)
+
+	// 记录数据点（目前记录到日志，后续可以扩展为持久化存储）
+	logrus.Debugf("Provider %s usage: CPU=%.3f%% (%d/%d millicores), Memory=%.3f%% (%d/%d bytes), GPU=%.3f%% (%d/%d)",
+		p.GetID(),
+		cpuRate, usage.CPU, capacity.Total.CPU,
+		memoryRate, usage.Memory, capacity.Total.Memory,
+		gpuRate, usage.GPU, capacity.Total.GPU,
+	)
+}
+
 // Stop 停止所有后台服务
 func (m *Manager) Stop() {
+	m.stopRebalancer()
+	m.stopUtilizationLog()
+	m.stopHeadFailover()
+	m.stopReconcile()
+
 	// 停止实时负载轮询服务
 	if m.usagePollingCancel != nil {
 		m.usagePollingCancel()
@@ -429,11 +711,19 @@ func (m *Manager) registerToGlobalRegistry(ctx context.Context) error {
 	client := registrypb.NewServiceClient(conn)
 
 	// 构建注册请求
+	local := commonpb.NewProtocolInfo(commonpb.NodeCapabilities...)
 	req := &registrypb.RegisterNodeRequest{
 		DomainId:        m.domainID,
 		NodeId:          m.nodeID,
 		NodeName:        m.name,
 		NodeDescription: m.description,
+		Protocol:        local,
+		Labels:          m.labels,
+	}
+	if m.identity != nil {
+		if err := m.identity.SignRegisterNode(req); err != nil {
+			return fmt.Errorf("failed to sign registration: %w", err)
+		}
 	}
 
 	// 调用注册方法
@@ -447,6 +737,11 @@ func (m *Manager) registerToGlobalRegistry(ctx context.Context) error {
 		m.domainName = resp.GetDomainName()
 	}
 
+	// 协商协议：旧版 registry 不携带 ProtocolInfo，按 legacy 处理；注册已经成功，协商失败时同样按 legacy 继续
+	if _, err := commonpb.Negotiate(local, resp.GetProtocol()); err != nil {
+		logrus.Warnf("Global registry protocol is incompatible, continuing with the legacy protocol: %v", err)
+	}
+
 	return nil
 }
 
@@ -491,6 +786,12 @@ func (m *Manager) startHealthCheckLoop(ctx context.Context) {
 				ticker.Reset(interval)
 				logrus.Debugf("Updated health check interval to %v", interval)
 			}
+		case <-m.head.notify:
+			// head 角色变化后立即上报，registry 据此切换域的 head
+			m.performHealthCheck(ctx, client, interval)
+		case <-m.resourceChanged:
+			// provider 总容量变化后立即上报，其他节点据此调整委托决策
+			m.performHealthCheck(ctx, client, interval)
 		case <-m.healthCheckStop:
 			logrus.Info("Health check loop stopped")
 			return
@@ -515,6 +816,7 @@ func (m *Manager) performHealthCheck(ctx context.Context, client registrypb.Serv
 	// 因为能够发送健康检查本身就说明服务正常运行
 
 	// 构建健康检查请求
+	isHead, headTerm := m.head.state()
 	req := &registrypb.HealthCheckRequest{
 		NodeId:           m.nodeID,
 		DomainId:         m.domainID,
@@ -523,7 +825,8 @@ func (m *Manager) performHealthCheck(ctx context.Context, client registrypb.Serv
 		ResourceTags:     resourceTags,
 		Address:          m.nodeAddress,
 		Timestamp:        time.Now().UnixNano(),
-		IsHead:           m.isHead,
+		IsHead:           isHead,
+		HeadTerm:         headTerm,
 	}
 
 	// 同步更新 discovery 服务的本地节点信息
@@ -567,6 +870,13 @@ func (m *Manager) performHealthCheck(ctx context.Context, client registrypb.Serv
 		return 0
 	}
 
+	if m.identity != nil {
+		if err := m.identity.SignHealthCheck(req); err != nil {
+			logrus.Warnf("Failed to sign health check: %v", err)
+			return 0
+		}
+	}
+
 	// 调用健康检查 RPC
 	resp, err := client.HealthCheck(ctx, req)
 	if err != nil {
@@ -717,12 +1027,133 @@ func (m *Manager) GetLogsByTimeRange(ctx context.Context, componentID string, st
 	return m.loggerService.GetLogsByTimeRange(ctx, componentID, startTime, endTime, limit)
 }
 
+// EvictProvider 驱逐指定 provider 上的可驱逐 component，并重新调度
+func (m *Manager) EvictProvider(ctx context.Context, providerID string) error {
+	return m.componentService.EvictProvider(ctx, providerID)
+}
+
+// UndeployEvicted 删除 provider 重新连接后其上仍保留的已驱逐 component 实例
+func (m *Manager) UndeployEvicted(ctx context.Context, providerID string) error {
+	return m.componentService.UndeployEvicted(ctx, providerID)
+}
+
+// MigrateComponent 将 component 迁移到本节点的指定 provider
+func (m *Manager) MigrateComponent(ctx context.Context, componentID, targetProviderID string) error {
+	return m.componentService.MigrateComponent(ctx, componentID, targetProviderID)
+}
+
+// RedeployComponent 以相同 ID 重新部署本节点的 component，使更新后的部署选项生效
+func (m *Manager) RedeployComponent(ctx context.Context, componentID string) error {
+	return m.componentService.RedeployComponent(ctx, componentID)
+}
+
+// GetComponent 按 ID 获取 component，不存在时返回 nil
+func (m *Manager) GetComponent(componentID string) *component.Component {
+	return m.componentManager.Get(componentID)
+}
+
+// GetAllComponents 获取本节点登记的所有 component，包括委托给其他节点部署的
+func (m *Manager) GetAllComponents() []*component.Component {
+	return m.componentManager.GetAll()
+}
+
+// ExecComponent 在 component 内启动调试命令
+func (m *Manager) ExecComponent(ctx context.Context, componentID string, opts provider.ExecOptions) (*provider.ExecSession, error) {
+	return m.componentService.ExecComponent(ctx, componentID, opts)
+}
+
+// PortForwardComponent 建立到 component 端口的隧道
+func (m *Manager) PortForwardComponent(ctx context.Context, componentID string, port uint32) (*provider.PortForwardConn, error) {
+	return m.componentService.PortForwardComponent(ctx, componentID, port)
+}
+
 func (m *Manager) DeployComponent(ctx context.Context, runtimeEnv types.RuntimeEnv, resourceRequest *types.Info) (*component.Component, error) {
+	if !m.deployments.begin() {
+		return nil, ErrShuttingDown
+	}
+	defer m.deployments.end()
+
+	var (
+		comp      *component.Component
+		err       error
+		startedAt = time.Now()
+	)
+	if m.decisionLog == nil {
+		comp, err = m.placeComponent(ctx, runtimeEnv, resourceRequest)
+	} else {
+		traceCtx, finish := m.traceDecision(ctx, runtimeEnv, resourceRequest)
+		comp, err = m.placeComponent(traceCtx, runtimeEnv, resourceRequest)
+		finish(comp, err)
+	}
+	if m.traceRecorder != nil {
+		m.recordDeploy(startedAt, runtimeEnv, resourceRequest, comp, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	comp.SetAppID(accounting.GetApplication(ctx))
+	comp.SetLabels(component.GetComponentLabels(ctx))
+	if origin := component.GetComponentOrigin(ctx); origin != "" {
+		comp.SetOrigin(origin)
+	}
+	m.startUsage(ctx, comp, resourceRequest)
+	return comp, nil
+}
+
+// placeComponent 放置 component：携带会话亲和时优先使用会话绑定的位置，否则重新调度并绑定
+func (m *Manager) placeComponent(ctx context.Context, runtimeEnv types.RuntimeEnv, resourceRequest *types.Info) (*component.Component, error) {
+	affinity, ok := provider.GetAffinity(ctx)
+	if !ok {
+		return m.scheduleComponent(ctx, runtimeEnv, resourceRequest)
+	}
+	if comp := m.placeByAffinity(ctx, affinity, runtimeEnv, resourceRequest); comp != nil {
+		return comp, nil
+	}
+	comp, err := m.scheduleComponent(ctx, runtimeEnv, resourceRequest)
+	if err == nil {
+		m.pinAffinity(affinity, comp)
+	}
+	return comp, err
+}
+
+// scheduleComponent 按本地部署、同域委托、全局调度的顺序放置 component
+func (m *Manager) scheduleComponent(ctx context.Context, runtimeEnv types.RuntimeEnv, resourceRequest *types.Info) (*component.Component, error) {
+	if _, ok := provider.GetEgressPolicy(ctx); !ok {
+		ctx = provider.WithEgressPolicy(ctx, m.egressPolicy)
+	}
+
+	// 卷位于本节点的 provider 主机上，挂载了卷的 component 只能部署在本节点
+	if _, ok := provider.GetVolumes(ctx); ok {
+		component, err := m.componentService.DeployComponent(ctx, runtimeEnv, resourceRequest)
+		if err != nil {
+			if abortErr := interruptError(ctx, StageProviderDeploy, err); abortErr != nil {
+				return nil, abortErr
+			}
+			return nil, fmt.Errorf("component with volumes is pinned to node %s: %w", m.nodeID, err)
+		}
+		return component, nil
+	}
+
+	// 委托部署不携带指定的镜像与额外环境变量，指定了镜像的 component 只能部署在本节点
+	if image, ok := component.GetComponentImage(ctx); ok {
+		comp, err := m.componentService.DeployComponent(ctx, runtimeEnv, resourceRequest)
+		if err != nil {
+			if abortErr := interruptError(ctx, StageProviderDeploy, err); abortErr != nil {
+				return nil, abortErr
+			}
+			return nil, fmt.Errorf("component with image %s is pinned to node %s: %w", image, m.nodeID, err)
+		}
+		return comp, nil
+	}
+
 	component, err := m.componentService.DeployComponent(ctx, runtimeEnv, resourceRequest)
 	if err == nil {
-		component.SetProviderID("local." + component.GetProviderID())
 		return component, nil
 	}
+	// 截止时间已到或部署被取消时不再委托，直接返回中断所在的阶段
+	if abortErr := interruptError(ctx, StageProviderDeploy, err); abortErr != nil {
+		return nil, abortErr
+	}
 
 	if !m.shouldDelegateDeployment(err) {
 		return nil, err
@@ -733,23 +1164,39 @@ func (m *Manager) DeployComponent(ctx context.Context, runtimeEnv types.RuntimeE
 	if peerErr == nil {
 		return peerComponent, nil
 	}
+	if abortErr := interruptError(ctx, StageDiscoveryQuery, peerErr); abortErr != nil {
+		return nil, abortErr
+	}
 	logrus.Warnf("Delegation to peer nodes failed: %v", peerErr)
 
 	globalComponent, globalErr := m.delegateToGlobalScheduler(ctx, runtimeEnv, resourceRequest)
 	if globalErr == nil {
 		return globalComponent, nil
 	}
+	if abortErr := interruptError(ctx, StageGlobalSchedule, globalErr); abortErr != nil {
+		return nil, abortErr
+	}
 
 	return nil, fmt.Errorf("local deployment failed: %w; peer delegation failed: %v; global delegation failed: %v", err, peerErr, globalErr)
 }
 
+// ProposeDeployment 判断本节点能否部署 component 而不实际部署，供其他节点委托前探测
+// 节点正在关闭时返回 ErrShuttingDown
+func (m *Manager) ProposeDeployment(ctx context.Context, runtimeEnv types.RuntimeEnv, resourceRequest *types.Info) (*types.Info, error) {
+	if !m.deployments.begin() {
+		return nil, ErrShuttingDown
+	}
+	defer m.deployments.end()
+
+	return m.componentService.ProposeDeployment(ctx, runtimeEnv, resourceRequest)
+}
+
 func (m *Manager) shouldDelegateDeployment(err error) bool {
 	if err == nil {
 		return false
 	}
-	msg := err.Error()
-	return strings.Contains(msg, "failed to find available provider") ||
-		strings.Contains(msg, "no available provider")
+	// 重试后仍因容量不足失败时，同样交由其他节点部署
+	return errors.Is(err, provider.ErrNoAvailableProvider) || errors.Is(err, provider.ErrNoCapacity)
 }
 
 func (m *Manager) delegateToPeerNodes(ctx context.Context, runtimeEnv types.RuntimeEnv, resourceRequest *types.Info) (*component.Component, error) {
@@ -760,55 +1207,57 @@ func (m *Manager) delegateToPeerNodes(ctx context.Context, runtimeEnv types.Runt
 		return nil, fmt.Errorf("resource request is nil")
 	}
 
-	requiredTags := convertStringsToDiscoveryTags(resourceRequest.Tags)
+	requiredTags := discoveryTagsFor(resourceRequest)
+	queryStart := time.Now()
 	nodes, err := m.discoveryService.QueryResources(ctx, resourceRequest, requiredTags)
+	decision.Since(ctx, decision.StageDiscoveryQuery, queryStart)
 	if err != nil {
+		if abortErr := interruptError(ctx, StageDiscoveryQuery, err); abortErr != nil {
+			return nil, abortErr
+		}
 		return nil, fmt.Errorf("query resources via discovery service failed: %w", err)
 	}
 	if len(nodes) == 0 {
 		return nil, fmt.Errorf("no in-domain nodes have sufficient resources")
 	}
 
-	for _, node := range nodes {
-		targetAddr := node.SchedulerAddress
-		if targetAddr == "" {
-			targetAddr = node.Address
-		}
+	rankPeerNodes(nodes, resourceRequest, m.peerHistory)
 
-		resp, deployErr := m.schedulerService.DeployComponent(ctx, &scheduler.DeployRequest{
-			RuntimeEnv:            runtimeEnv,
-			ResourceRequest:       resourceRequest,
-			TargetNodeID:          node.NodeID,
-			TargetAddress:         targetAddr,
-			UpstreamZMQAddress:    m.getZMQAddress(),
-			UpstreamStoreAddress:  m.getStoreAddress(),
-			UpstreamLoggerAddress: m.getLoggerAddress(),
-		})
-		if deployErr != nil {
-			logrus.Warnf("Failed to delegate deployment to node %s (%s): %v", node.NodeName, node.NodeID, deployErr)
-			continue
-		}
-		if resp == nil || !resp.Success {
-			if resp != nil && resp.Error != "" {
-				logrus.Warnf("Node %s rejected deployment: %s", node.NodeID, resp.Error)
-			}
-			continue
+	// 每批并行探测 K 个候选节点，提交给排名最高的接受者；整批都未成功时继续下一批
+	// 截止时间耗尽或部署被取消时返回带阶段信息的错误，不再尝试后续批次
+	for start := 0; start < len(nodes); start += m.delegationProbes {
+		batch := nodes[start:min(start+m.delegationProbes, len(nodes))]
+		comp, err := m.probeAndCommit(ctx, runtimeEnv, resourceRequest, batch, start)
+		if err != nil {
+			return nil, err
 		}
-		if resp.Component != nil {
-			if err := m.componentManager.AddComponent(ctx, resp.Component); err != nil {
-				logrus.Errorf("Failed to register remote component %s locally: %v", resp.Component.GetID(), err)
-				continue
-			}
-			resp.Component.SetProviderID(fmt.Sprintf("remote.%s@%s", resp.ProviderID, resp.NodeID))
+		if comp != nil {
+			return comp, nil
 		}
-		logrus.Infof("Delegated component deployment to node %s (%s)", node.NodeName, node.NodeID)
-		return resp.Component, nil
 	}
 
 	return nil, fmt.Errorf("all candidate nodes rejected the deployment request")
 }
 
+// rankPeerNodes 按偏好对候选节点排序
+// 优先按同规模任务的历史委托结果（history 为 nil 时跳过），
+// 最后按能耗画像排序：优先低功耗节点，大任务避开电池供电节点
+func rankPeerNodes(nodes []*discovery.PeerNode, resourceRequest *types.Info, history *provider.PlacementHistory) {
+	avoidBattery := !provider.IsSmallTask(resourceRequest)
+	class := provider.ClassifyTask(resourceRequest)
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if history.Less(class, nodes[i].NodeID, nodes[j].NodeID) {
+			return true
+		}
+		if history.Less(class, nodes[j].NodeID, nodes[i].NodeID) {
+			return false
+		}
+		return provider.EnergyLess(nodes[i].EnergyProfile, nodes[j].EnergyProfile, avoidBattery)
+	})
+}
+
 func (m *Manager) delegateToGlobalScheduler(ctx context.Context, runtimeEnv types.RuntimeEnv, resourceRequest *types.Info) (*component.Component, error) {
+	defer decision.Since(ctx, decision.StageGlobalSchedule, time.Now())
 	if m.globalRegistryAddr == "" {
 		return nil, fmt.Errorf("global scheduler address is not configured")
 	}
@@ -823,20 +1272,46 @@ func (m *Manager) delegateToGlobalScheduler(ctx context.Context, runtimeEnv type
 	defer conn.Close()
 
 	client := schedulerpb.NewSchedulerServiceClient(conn)
+	componentID := util.GenIDWith("comp.")
 	protoReq := &schedulerpb.DeployComponentRequest{
 		RuntimeEnv: string(runtimeEnv),
 		ResourceRequest: &resourcepb.Info{
-			Cpu:    resourceRequest.CPU,
-			Memory: resourceRequest.Memory,
-			Gpu:    resourceRequest.GPU,
-			Tags:   resourceRequest.Tags,
+			Cpu:          resourceRequest.CPU,
+			Memory:       resourceRequest.Memory,
+			Gpu:          resourceRequest.GPU,
+			Tags:         resourceRequest.Tags,
+			NodeSelector: resourceRequest.NodeSelector,
+			Stream:       resourceRequest.Stream,
 		},
 		UpstreamZmqAddress:    m.getZMQAddress(),
 		UpstreamStoreAddress:  m.getStoreAddress(),
 		UpstreamLoggerAddress: m.getLoggerAddress(),
+		UpstreamToken:         m.upstreamToken(componentID, accounting.GetApplication(ctx)),
+		ComponentId:           componentID,
+		AppId:                 accounting.GetApplication(ctx),
+		Labels:                component.GetComponentLabels(ctx),
+	}
+	if sources, ok := provider.GetDataSources(ctx); ok {
+		protoReq.DataSources = provider.DataSourcesToProto(sources)
+	}
+	if sc, ok := provider.GetSecurityContext(ctx); ok {
+		protoReq.SecurityContext = sc.ToProto()
+	}
+	if sidecars, ok := provider.GetSidecars(ctx); ok {
+		protoReq.Sidecars = provider.SidecarsToProto(sidecars)
 	}
 
 	protoResp, err := client.DeployComponent(ctx, protoReq)
+	// 部署被取消时只有拿到响应才知道 component 所在的节点，否则由目标节点在自身的部署被取消时清理
+	if errors.Is(ctx.Err(), context.Canceled) {
+		if protoResp != nil && protoResp.Success {
+			if info := protoResp.Component; info != nil && info.ComponentId != "" {
+				componentID = info.ComponentId
+			}
+			m.rollbackGlobal(ctx, client, protoResp.NodeId, componentID)
+		}
+		return nil, cancelledError(ctx, StageGlobalSchedule, err)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("global scheduler RPC failed: %w", err)
 	}
@@ -883,8 +1358,8 @@ func convertProtoComponent(info *schedulerpb.ComponentInfo) (*component.Componen
 	return comp, nil
 }
 
-func (m *Manager) RegisterProvider(name string, host string, port int) (*provider.Provider, error) {
-	return m.providerService.RegisterProvider(context.Background(), name, host, port)
+func (m *Manager) RegisterProvider(name string, host string, port int, capacityClass types.CapacityClass) (*provider.Provider, error) {
+	return m.providerService.RegisterProvider(context.Background(), name, host, port, capacityClass)
 }
 
 // UnregisterProvider 注销 Provider
@@ -903,19 +1378,42 @@ func (m *Manager) GetProvider(id string) *provider.Provider {
 }
 
 func (m *Manager) SaveObject(ctx context.Context, obj *commonpb.EncodedObject) (*commonpb.ObjectRef, error) {
-	return m.storeService.SaveObject(ctx, obj)
+	return m.storeService.SaveObject(m.resolvePrincipal(ctx), obj)
 }
 
 func (m *Manager) SaveStreamChunk(ctx context.Context, chunk *commonpb.StreamChunk) error {
-	return m.storeService.SaveStreamChunk(ctx, chunk)
+	return m.storeService.SaveStreamChunk(m.resolvePrincipal(ctx), chunk)
 }
 
 func (m *Manager) GetObject(ctx context.Context, ref *commonpb.ObjectRef) (*commonpb.EncodedObject, error) {
-	return m.storeService.GetObject(ctx, ref)
+	return m.storeService.GetObject(m.resolvePrincipal(ctx), ref)
 }
 
 func (m *Manager) GetStreamChunk(ctx context.Context, id string, offset int64) (*commonpb.StreamChunk, error) {
-	return m.storeService.GetStreamChunk(ctx, id, offset)
+	return m.storeService.GetStreamChunk(m.resolvePrincipal(ctx), id, offset)
+}
+
+// UseSharedStore 改为在同域共用的独立 store 中保存与读取对象，需在 Start 之前调用
+// 节点 store 端口收到的访问同样转发到共用的 store
+func (m *Manager) UseSharedStore(svc store.Service) {
+	m.storeService = svc
+}
+
+// SetAccessPolicy 设置本地 store 的访问控制策略
+func (m *Manager) SetAccessPolicy(policy store.AccessPolicy) {
+	m.storeService.SetAccessPolicy(policy)
+}
+
+// resolvePrincipal 按 component 记录补全调用方所属的应用，使同一应用的 component 可以互相读取对象
+func (m *Manager) resolvePrincipal(ctx context.Context) context.Context {
+	p, ok := store.PrincipalFrom(ctx)
+	if !ok || p.ComponentID == "" || p.AppID != "" {
+		return ctx
+	}
+	if comp := m.componentManager.Get(p.ComponentID); comp != nil {
+		p.AppID = comp.GetAppID()
+	}
+	return store.WithPrincipal(ctx, p)
 }
 
 // TODO: implement resource manager
@@ -0,0 +1,90 @@
+package resource
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet/internal/util"
+)
+
+// PollingPolicy 自适应轮询策略
+// 使用量轮询与 provider 健康检测的间隔在上下限之间自适应：利用率变化快或有部署进行中时回到下限，空闲时逐轮翻倍直到上限，
+// 降低 provider 较多的节点上持续的 RPC 负载
+type PollingPolicy struct {
+	Enabled          bool
+	UsageMin         time.Duration
+	UsageMax         time.Duration
+	HealthCheckMin   time.Duration
+	HealthCheckMax   time.Duration
+	UtilizationDelta float64 // 两次采样间 provider 利用率（0-1）变化超过该值视为变化快
+}
+
+// usageActivity 记录两次轮询之间 provider 利用率的显著变化
+type usageActivity struct {
+	mu      sync.Mutex
+	delta   float64            // 视为显著变化的利用率差值，0 表示不跟踪
+	last    map[string]float64 // provider ID -> 上次采样的利用率
+	changed bool
+}
+
+// observe 记录 provider 的一次利用率采样
+func (a *usageActivity) observe(providerID string, utilization float64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.delta <= 0 {
+		return
+	}
+	if last, ok := a.last[providerID]; ok && math.Abs(utilization-last) >= a.delta {
+		a.changed = true
+	}
+	a.last[providerID] = utilization
+}
+
+// takeChanged 返回上次调用以来是否有显著变化并清除标记
+func (a *usageActivity) takeChanged() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	changed := a.changed
+	a.changed = false
+	return changed
+}
+
+// forget 清理已移除 provider 的采样
+func (a *usageActivity) forget(known map[string]struct{}) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for id := range a.last {
+		if _, ok := known[id]; !ok {
+			delete(a.last, id)
+		}
+	}
+}
+
+// SetPollingPolicy 设置自适应轮询策略，需在 Start 前调用；未启用时使用固定的轮询与健康检测间隔
+func (m *Manager) SetPollingPolicy(policy PollingPolicy) {
+	if !policy.Enabled {
+		return
+	}
+	m.usagePollInterval = policy.UsageMin
+	m.usagePollMax = policy.UsageMax
+	m.usageActivity.mu.Lock()
+	m.usageActivity.delta = policy.UtilizationDelta
+	m.usageActivity.mu.Unlock()
+	m.providerManager.SetAdaptiveHealthCheck(policy.HealthCheckMin, policy.HealthCheckMax, m.hasDeploymentsInFlight)
+}
+
+// newUsagePollInterval 创建使用量轮询的间隔，未启用自适应轮询时为固定间隔
+func (m *Manager) newUsagePollInterval() *util.AdaptiveInterval {
+	return util.NewAdaptiveInterval(m.usagePollInterval, max(m.usagePollMax, m.usagePollInterval))
+}
+
+// usageSampleMaxAge 实时使用量样本的有效期，按轮询间隔上限计算
+func (m *Manager) usageSampleMaxAge() time.Duration {
+	return usageSampleTTL * max(m.usagePollMax, m.usagePollInterval)
+}
+
+// hasDeploymentsInFlight 是否有进行中的部署
+func (m *Manager) hasDeploymentsInFlight() bool {
+	return m.deployments.active() > 0
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"time"
+)
+
+// AffinityScope 会话亲和的粒度
+type AffinityScope string
+
+const (
+	// AffinityScopeNode 同一会话的部署固定到同一节点
+	AffinityScopeNode AffinityScope = "node"
+	// AffinityScopeProvider 同一会话的部署固定到同一 provider
+	AffinityScopeProvider AffinityScope = "provider"
+	// AffinityScopeComponent 同一会话复用同一个 component（适用于保存内存状态的 component）
+	AffinityScopeComponent AffinityScope = "component"
+)
+
+// ParseAffinityScope 解析亲和粒度，空字符串视为 provider
+func ParseAffinityScope(s string) (AffinityScope, bool) {
+	switch AffinityScope(s) {
+	case "", AffinityScopeProvider:
+		return AffinityScopeProvider, true
+	case AffinityScopeNode, AffinityScopeComponent:
+		return AffinityScope(s), true
+	}
+	return "", false
+}
+
+// Affinity 部署请求携带的会话亲和
+// 相同 Key 的部署会被固定到首次部署所在的节点/provider/component，会话空闲超过 TTL 后失效
+type Affinity struct {
+	Key   string
+	Scope AffinityScope
+	TTL   time.Duration // 0 表示使用节点默认的会话超时
+}
+
+type affinityCtxKey struct{}
+
+// WithAffinity 在 context 中附加会话亲和
+func WithAffinity(ctx context.Context, affinity *Affinity) context.Context {
+	if affinity == nil || affinity.Key == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, affinityCtxKey{}, affinity)
+}
+
+// GetAffinity 从 context 获取会话亲和
+func GetAffinity(ctx context.Context) (*Affinity, bool) {
+	val := ctx.Value(affinityCtxKey{})
+	if val == nil {
+		return nil, false
+	}
+	affinity, ok := val.(*Affinity)
+	return affinity, ok
+}
+
+type preferredProviderCtxKey struct{}
+
+// WithPreferredProvider 在 context 中指定优先尝试的 provider
+// 查找可用 provider 时该 provider 排在最前，不满足要求时仍按策略链选择其他 provider
+func WithPreferredProvider(ctx context.Context, providerID string) context.Context {
+	if providerID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, preferredProviderCtxKey{}, providerID)
+}
+
+// preferProvider 将 context 中指定的 provider 移到候选列表最前
+func preferProvider(ctx context.Context, providers []*Provider) []*Provider {
+	preferred, _ := ctx.Value(preferredProviderCtxKey{}).(string)
+	if preferred == "" {
+		return providers
+	}
+	for i, p := range providers {
+		if p.GetID() != preferred {
+			continue
+		}
+		ordered := make([]*Provider, 0, len(providers))
+		ordered = append(ordered, p)
+		ordered = append(ordered, providers[:i]...)
+		return append(ordered, providers[i+1:]...)
+	}
+	return providers
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+)
+
+type imageArchitecturesCtxKey struct{}
+
+// WithImageArchitectures 在 context 中附加待部署镜像支持的 CPU 架构
+// 查找 provider 时跳过架构不兼容的 provider，部署时随请求下发给 provider
+func WithImageArchitectures(ctx context.Context, archs []string) context.Context {
+	if len(archs) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, imageArchitecturesCtxKey{}, archs)
+}
+
+// GetImageArchitectures 从 context 获取镜像支持的 CPU 架构
+func GetImageArchitectures(ctx context.Context) ([]string, bool) {
+	archs, ok := ctx.Value(imageArchitecturesCtxKey{}).([]string)
+	return archs, ok
+}
+
+// normalizeArchitectures 规范化 provider 上报的架构列表，去除空值与重复项
+func normalizeArchitectures(archs []string) []string {
+	if len(archs) == 0 {
+		return nil
+	}
+	normalized := make([]string, 0, len(archs))
+	seen := make(map[string]struct{}, len(archs))
+	for _, arch := range archs {
+		arch = types.NormalizeArchitecture(arch)
+		if arch == "" {
+			continue
+		}
+		if _, ok := seen[arch]; ok {
+			continue
+		}
+		seen[arch] = struct{}{}
+		normalized = append(normalized, arch)
+	}
+	return normalized
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultCapacityCacheTTL 资源容量缓存的默认最大陈旧时间
+const DefaultCapacityCacheTTL = 2 * time.Second
+
+// SetCapacityCacheTTL 设置资源容量缓存的最大陈旧时间，ttl <= 0 表示缓存不过期
+// 超过该时间的缓存不再用于调度决策，GetCapacity/GetAvailable 会重新从 provider 获取
+func (p *Provider) SetCapacityCacheTTL(ttl time.Duration) {
+	p.cacheMu.Lock()
+	defer p.cacheMu.Unlock()
+	p.cacheTTL = ttl
+}
+
+// capacityCacheFresh 判断容量缓存是否在陈旧时间内，调用方需持有 cacheMu
+// 使用量推送流活跃时 provider 会在容量变化时主动推送，缓存始终视为最新
+func (p *Provider) capacityCacheFresh() bool {
+	if p.cachedCapacity == nil {
+		return false
+	}
+	if p.cacheLive || p.cacheTTL <= 0 {
+		return true
+	}
+	return time.Since(p.cacheTimestamp) <= p.cacheTTL
+}
+
+// GetCachedAvailable 仅从缓存读取可用资源，不发起网络请求
+// 缓存不存在或已超过陈旧时间时返回 false
+func (p *Provider) GetCachedAvailable() (*types.Info, bool) {
+	p.cacheMu.RLock()
+	defer p.cacheMu.RUnlock()
+
+	if !p.capacityCacheFresh() || p.cachedCapacity.Available == nil {
+		return nil, false
+	}
+	available := *p.cachedCapacity.Available
+	return &available, true
+}
+
+// InvalidateCapacityCache 使容量缓存失效，下一次读取将从 provider 重新获取
+func (p *Provider) InvalidateCapacityCache() {
+	p.cacheMu.Lock()
+	defer p.cacheMu.Unlock()
+	p.cacheTimestamp = time.Time{}
+	p.cacheLive = false
+}
+
+// setCapacityCacheLive 标记使用量推送流是否活跃
+func (p *Provider) setCapacityCacheLive(live bool) {
+	p.cacheMu.Lock()
+	defer p.cacheMu.Unlock()
+	p.cacheLive = live
+}
+
+// storeCapacity 写入容量缓存，容量发生变化时记录日志，总容量变化时通知 Manager 核对已分配的资源，调用方需持有 cacheMu
+func (p *Provider) storeCapacity(capacity *types.Capacity, source string) {
+	if old := p.cachedCapacity; old != nil && !capacityEqual(old, capacity) {
+		logrus.Debugf("Provider %s capacity changed (%s): available %v -> %v", p.id, source, *old.Available, *capacity.Available)
+		p.notifyCapacityChangeLocked(old, capacity, source)
+	}
+	p.cachedCapacity = capacity
+	p.cacheTimestamp = time.Now()
+}
+
+func capacityEqual(a, b *types.Capacity) bool {
+	return infoEqual(a.Total, b.Total) && infoEqual(a.Used, b.Used) && infoEqual(a.Available, b.Available)
+}
+
+func infoEqual(a, b *types.Info) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.CPU == b.CPU && a.Memory == b.Memory && a.GPU == b.GPU
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+	resourcepb "github.com/9triver/iarnet/internal/proto/resource"
+	"github.com/sirupsen/logrus"
+)
+
+// CapacityChange provider 上报的总容量与之前不同，通常是运维修改了 provider 配置的容量后重启
+type CapacityChange struct {
+	ProviderID string
+	Previous   types.Info // 变化前的总容量
+	Current    *types.Capacity
+	Source     string // 上报途径：connect / health check / watch
+}
+
+// CapacityAlert 节点已分配到 provider 上的资源超过其新的总容量
+// provider 不会因容量下调驱逐已运行的实例，超出部分需要运维处理或等待 component 结束
+type CapacityAlert struct {
+	Total     types.Info // provider 新的总容量
+	Allocated types.Info // 本节点放置在该 provider 上的 component 的资源请求之和
+	Since     time.Time  // 首次发现超出的时间
+}
+
+// setCapacityChangeHandler 设置总容量变化时的回调，由 Manager.Add 设置
+func (p *Provider) setCapacityChangeHandler(handler func(change CapacityChange)) {
+	p.cacheMu.Lock()
+	defer p.cacheMu.Unlock()
+	p.capacityChangeHandler = handler
+}
+
+// notifyCapacityChangeLocked 总容量变化时在后台回调，调用方需持有 cacheMu
+func (p *Provider) notifyCapacityChangeLocked(old, capacity *types.Capacity, source string) {
+	if old == nil || old.Total == nil || capacity.Total == nil || infoEqual(old.Total, capacity.Total) {
+		return
+	}
+	logrus.Infof("Provider %s total capacity changed (%s): CPU %d -> %d, Memory %d -> %d, GPU %d -> %d",
+		p.id, source,
+		old.Total.CPU, capacity.Total.CPU,
+		old.Total.Memory, capacity.Total.Memory,
+		old.Total.GPU, capacity.Total.GPU)
+	if handler := p.capacityChangeHandler; handler != nil {
+		go handler(CapacityChange{ProviderID: p.id, Previous: *old.Total, Current: capacity, Source: source})
+	}
+}
+
+// GetCapacityAlert 获取当前的容量告警，已分配资源未超过总容量时返回 nil
+func (p *Provider) GetCapacityAlert() *CapacityAlert {
+	p.cacheMu.RLock()
+	defer p.cacheMu.RUnlock()
+	if p.capacityAlert == nil {
+		return nil
+	}
+	alert := *p.capacityAlert
+	return &alert
+}
+
+// SetCapacityAlert 设置容量告警，nil 表示解除；告警持续期间保留首次发现的时间
+func (p *Provider) SetCapacityAlert(alert *CapacityAlert) {
+	p.cacheMu.Lock()
+	defer p.cacheMu.Unlock()
+	if alert != nil && p.capacityAlert != nil {
+		alert.Since = p.capacityAlert.Since
+	}
+	p.capacityAlert = alert
+}
+
+// capacityFromProto 转换 provider 上报的容量，字段不完整时返回 nil
+func capacityFromProto(c *resourcepb.Capacity) *types.Capacity {
+	if c == nil || c.Total == nil || c.Used == nil || c.Available == nil {
+		return nil
+	}
+	return &types.Capacity{
+		Total:     &types.Info{CPU: c.Total.Cpu, Memory: c.Total.Memory, GPU: c.Total.Gpu},
+		Used:      &types.Info{CPU: c.Used.Cpu, Memory: c.Used.Memory, GPU: c.Used.Gpu},
+		Available: &types.Info{CPU: c.Available.Cpu, Memory: c.Available.Memory, GPU: c.Available.Gpu},
+	}
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+	"github.com/9triver/iarnet/internal/proto/common"
+	resourcepb "github.com/9triver/iarnet/internal/proto/resource"
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+)
+
+// ComponentUsage 单个 component 实例的资源使用情况，用于自动扩缩容与资源规格调整
+type ComponentUsage struct {
+	ComponentID string
+	ProviderID  string
+	Usage       *types.Info // 实时使用量
+	Limit       *types.Info // 部署时分配的资源上限，provider 未上报时为 nil
+	MemoryRSS   int64       // 常驻内存（bytes），不含页缓存；无法获取时为 0
+	MemoryCache int64       // 页缓存（bytes）；无法获取时为 0
+	SampledAt   time.Time
+}
+
+// GetComponentUsage 查询 provider 上 component 实例的实时资源使用情况
+// componentIDs 为空时返回该 provider 部署的全部实例；未运行或已不存在的实例不返回
+func (p *Provider) GetComponentUsage(ctx context.Context, componentIDs ...string) ([]*ComponentUsage, error) {
+	if p.client == nil {
+		return nil, fmt.Errorf("provider not connected")
+	}
+	if err := p.requireCapability(common.CapComponentUsage); err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.GetComponentUsage(ctx, &providerpb.GetComponentUsageRequest{
+		ProviderId:  p.id,
+		InstanceIds: componentIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get component usage: %w", err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("failed to get component usage: %s", resp.Error)
+	}
+
+	usages := make([]*ComponentUsage, 0, len(resp.Components))
+	for _, c := range resp.Components {
+		usages = append(usages, &ComponentUsage{
+			ComponentID: c.InstanceId,
+			ProviderID:  p.id,
+			Usage:       infoFromProto(c.Usage),
+			Limit:       infoFromProto(c.Limit),
+			MemoryRSS:   c.MemoryRss,
+			MemoryCache: c.MemoryCache,
+			SampledAt:   time.UnixMilli(c.Timestamp),
+		})
+	}
+	return usages, nil
+}
+
+func infoFromProto(info *resourcepb.Info) *types.Info {
+	if info == nil {
+		return nil
+	}
+	return &types.Info{
+		CPU:    info.Cpu,
+		Memory: info.Memory,
+		GPU:    info.Gpu,
+	}
+}
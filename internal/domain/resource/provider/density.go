@@ -0,0 +1,20 @@
+package provider
+
+import "context"
+
+type fullProvidersCtxKey struct{}
+
+// WithFullProviders 在 context 中附加 component 数量已达上限的 provider
+// FindAvailableProvider 跳过这些 provider，即使其 CPU/内存仍有空闲
+func WithFullProviders(ctx context.Context, ids map[string]struct{}) context.Context {
+	if len(ids) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, fullProvidersCtxKey{}, ids)
+}
+
+// GetFullProviders 获取 context 中 component 数量已达上限的 provider
+func GetFullProviders(ctx context.Context) (map[string]struct{}, bool) {
+	ids, ok := ctx.Value(fullProvidersCtxKey{}).(map[string]struct{})
+	return ids, ok && len(ids) > 0
+}
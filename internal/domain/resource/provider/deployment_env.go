@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/9triver/iarnet/internal/util/identity"
+)
+
+// reservedEnvKeys provider 部署时自动注入的环境变量，额外环境变量不能覆盖
+var reservedEnvKeys = map[string]struct{}{
+	"COMPONENT_ID":    {},
+	"ZMQ_ADDR":        {},
+	"STORE_ADDR":      {},
+	"LOGGER_ADDR":     {},
+	"IARNET_DATA_DIR": {},
+
+	StreamNameEnv: {},
+	StreamKindEnv: {},
+	StreamURLEnv:  {},
+
+	identity.UpstreamTokenEnv: {},
+}
+
+// IsReservedEnvKey 判断环境变量是否由 provider 自动注入
+func IsReservedEnvKey(key string) bool {
+	_, ok := reservedEnvKeys[key]
+	return ok
+}
+
+type deploymentEnvCtxKey struct{}
+
+// WithDeploymentEnv 在 context 中附加一次部署的额外环境变量
+func WithDeploymentEnv(ctx context.Context, env map[string]string) context.Context {
+	if len(env) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, deploymentEnvCtxKey{}, env)
+}
+
+// GetDeploymentEnv 从 context 获取额外环境变量
+func GetDeploymentEnv(ctx context.Context) (map[string]string, bool) {
+	val := ctx.Value(deploymentEnvCtxKey{})
+	if val == nil {
+		return nil, false
+	}
+	env, ok := val.(map[string]string)
+	return env, ok
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"net"
+	"strconv"
+
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+)
+
+// EgressRule 出站放行规则
+type EgressRule struct {
+	Destination string // 目标 IP、CIDR 或主机名
+	Port        int    // 目标端口，0 表示任意端口
+	Protocol    string // tcp / udp，空表示任意协议
+}
+
+// EgressPolicy 组件出站网络策略
+// 启用后仅允许访问 Allow 中列出的目的地，其余出站流量全部拒绝
+type EgressPolicy struct {
+	Allow []EgressRule
+}
+
+type egressPolicyCtxKey struct{}
+
+// WithEgressPolicy 在 context 中附加出站网络策略
+func WithEgressPolicy(ctx context.Context, policy *EgressPolicy) context.Context {
+	if policy == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, egressPolicyCtxKey{}, policy)
+}
+
+// GetEgressPolicy 从 context 获取出站网络策略
+func GetEgressPolicy(ctx context.Context) (*EgressPolicy, bool) {
+	val := ctx.Value(egressPolicyCtxKey{})
+	if val == nil {
+		return nil, false
+	}
+	policy, ok := val.(*EgressPolicy)
+	return policy, ok
+}
+
+// toProto 转换为 proto 消息，并自动放行组件必需的上游地址（ZMQ/Store/Logger）
+func (p *EgressPolicy) toProto(upstreams ...string) *providerpb.EgressPolicy {
+	pb := &providerpb.EgressPolicy{Enabled: true}
+	for _, rule := range p.Allow {
+		pb.Allow = append(pb.Allow, &providerpb.EgressRule{
+			Destination: rule.Destination,
+			Port:        int32(rule.Port),
+			Protocol:    rule.Protocol,
+		})
+	}
+	for _, addr := range upstreams {
+		host, portStr, err := net.SplitHostPort(addr)
+		if err != nil {
+			continue
+		}
+		port, err := strconv.Atoi(portStr)
+		if err != nil {
+			continue
+		}
+		pb.Allow = append(pb.Allow, &providerpb.EgressRule{
+			Destination: host,
+			Port:        int32(port),
+			Protocol:    "tcp",
+		})
+	}
+	return pb
+}
@@ -7,6 +7,7 @@ type DeploymentEnvOverride struct {
 	ZMQAddress    string
 	StoreAddress  string
 	LoggerAddress string
+	Token         string // 上游节点为该 component 签发的令牌（可选）
 }
 
 type envOverrideCtxKey struct{}
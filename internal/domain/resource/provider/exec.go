@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/9triver/iarnet/internal/proto/common"
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+)
+
+// ExecOptions component 内调试命令的启动参数
+type ExecOptions struct {
+	Command []string // 要执行的命令，为空时由 provider 使用 /bin/sh
+	TTY     bool     // 是否分配伪终端
+}
+
+// ExecOutput Exec 会话的一段输出
+type ExecOutput struct {
+	Stdout   []byte
+	Stderr   []byte
+	Exited   bool // 命令已退出，ExitCode 有效
+	ExitCode int32
+}
+
+// ExecSession 与 provider 之间的双向 Exec 流
+type ExecSession struct {
+	stream providerpb.Service_ExecClient
+	cancel context.CancelFunc
+}
+
+// Exec 在部署于该 provider 上的 component 内启动调试命令
+func (p *Provider) Exec(ctx context.Context, instanceID string, opts ExecOptions) (*ExecSession, error) {
+	if p.client == nil {
+		return nil, fmt.Errorf("provider not connected")
+	}
+	if p.id == "" {
+		return nil, fmt.Errorf("provider not connected, please call Connect first")
+	}
+
+	if err := p.requireCapability(common.CapExec); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := p.client.Exec(ctx)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to open exec stream: %w", err)
+	}
+
+	start := &providerpb.ExecRequest{
+		Payload: &providerpb.ExecRequest_Start{
+			Start: &providerpb.ExecStart{
+				ProviderId: p.id,
+				InstanceId: instanceID,
+				Command:    opts.Command,
+				Tty:        opts.TTY,
+			},
+		},
+	}
+	if err := stream.Send(start); err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to start exec: %w", err)
+	}
+
+	return &ExecSession{stream: stream, cancel: cancel}, nil
+}
+
+// Write 向命令的标准输入写入数据
+func (s *ExecSession) Write(data []byte) error {
+	return s.stream.Send(&providerpb.ExecRequest{Payload: &providerpb.ExecRequest_Stdin{Stdin: data}})
+}
+
+// Resize 调整伪终端窗口大小
+func (s *ExecSession) Resize(rows, cols uint32) error {
+	return s.stream.Send(&providerpb.ExecRequest{
+		Payload: &providerpb.ExecRequest_Resize{Resize: &providerpb.ExecResize{Rows: rows, Cols: cols}},
+	})
+}
+
+// CloseStdin 关闭命令的标准输入
+func (s *ExecSession) CloseStdin() error {
+	return s.stream.Send(&providerpb.ExecRequest{Payload: &providerpb.ExecRequest_CloseStdin{CloseStdin: true}})
+}
+
+// Recv 读取下一段输出，provider 报告的错误以 error 返回
+func (s *ExecSession) Recv() (*ExecOutput, error) {
+	resp, err := s.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("exec failed: %s", resp.Error)
+	}
+	return &ExecOutput{
+		Stdout:   resp.Stdout,
+		Stderr:   resp.Stderr,
+		Exited:   resp.Exited,
+		ExitCode: resp.ExitCode,
+	}, nil
+}
+
+// Close 结束 Exec 会话
+func (s *ExecSession) Close() {
+	s.stream.CloseSend()
+	s.cancel()
+}
@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+)
+
+// SizeClass 任务规模分类，历史放置统计按规模分别记录
+type SizeClass string
+
+const (
+	SizeClassSmall SizeClass = "small" // 见 IsSmallTask
+	SizeClassLarge SizeClass = "large"
+	SizeClassGPU   SizeClass = "gpu"
+)
+
+// ClassifyTask 按资源请求划分任务规模
+func ClassifyTask(request *types.Info) SizeClass {
+	switch {
+	case request.GPU > 0:
+		return SizeClassGPU
+	case IsSmallTask(request):
+		return SizeClassSmall
+	}
+	return SizeClassLarge
+}
+
+const (
+	// DefaultHistoryMinSamples 目标的放置次数达到该值后才按历史统计排序
+	DefaultHistoryMinSamples = 5
+	// historyWeight 指数加权平均中最新一次放置的权重
+	historyWeight = 0.2
+	// successBuckets 成功率按 1/successBuckets 分档，档内按延迟排序
+	successBuckets = 10
+)
+
+// PlacementStats 某一规模的任务在某一目标上的历史放置统计
+type PlacementStats struct {
+	Samples     int           // 放置次数
+	SuccessRate float64       // 成功率（指数加权平均）
+	Latency     time.Duration // 成功放置的耗时（指数加权平均）
+}
+
+type historyKey struct {
+	class  SizeClass
+	target string
+}
+
+// PlacementHistory 按 (任务规模, 目标) 记录历史放置的成功率与耗时，目标为 provider ID 或节点 ID
+// 统计使用指数加权平均，近期的结果影响更大，目标恢复后可逐步重新获得偏好
+type PlacementHistory struct {
+	mu         sync.Mutex
+	minSamples int
+	stats      map[historyKey]*PlacementStats
+}
+
+// NewPlacementHistory 创建历史放置统计，minSamples <= 0 时使用默认值
+func NewPlacementHistory(minSamples int) *PlacementHistory {
+	if minSamples <= 0 {
+		minSamples = DefaultHistoryMinSamples
+	}
+	return &PlacementHistory{
+		minSamples: minSamples,
+		stats:      make(map[historyKey]*PlacementStats),
+	}
+}
+
+// Record 记录一次放置结果，h 为 nil 时不做任何事
+func (h *PlacementHistory) Record(class SizeClass, target string, success bool, latency time.Duration) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key := historyKey{class: class, target: target}
+	s, ok := h.stats[key]
+	if !ok {
+		s = &PlacementStats{}
+		h.stats[key] = s
+	}
+	outcome := 0.0
+	if success {
+		outcome = 1
+	}
+	if s.Samples == 0 {
+		s.SuccessRate = outcome
+	} else {
+		s.SuccessRate += historyWeight * (outcome - s.SuccessRate)
+	}
+	if success {
+		if s.Latency == 0 {
+			s.Latency = latency
+		} else {
+			s.Latency += time.Duration(historyWeight * float64(latency-s.Latency))
+		}
+	}
+	s.Samples++
+}
+
+// Get 获取 (任务规模, 目标) 的历史放置统计
+func (h *PlacementHistory) Get(class SizeClass, target string) (PlacementStats, bool) {
+	if h == nil {
+		return PlacementStats{}, false
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s, ok := h.stats[historyKey{class: class, target: target}]
+	if !ok {
+		return PlacementStats{}, false
+	}
+	return *s, true
+}
+
+// Less 比较两个目标对该规模任务的历史表现，a 更优时返回 true
+// 成功率按档比较，同档内耗时短者优先；样本不足的目标视为成功率最高、耗时为 0，以便新目标得到尝试
+func (h *PlacementHistory) Less(class SizeClass, a, b string) bool {
+	if h == nil {
+		return false
+	}
+	bucketA, latencyA := h.rank(class, a)
+	bucketB, latencyB := h.rank(class, b)
+	if bucketA != bucketB {
+		return bucketA > bucketB
+	}
+	return latencyA < latencyB
+}
+
+func (h *PlacementHistory) rank(class SizeClass, target string) (int, time.Duration) {
+	s, ok := h.Get(class, target)
+	if !ok || s.Samples < h.minSamples {
+		return successBuckets, 0
+	}
+	return int(s.SuccessRate * successBuckets), s.Latency
+}
+
+// HistoryPolicy 历史放置策略
+// 偏向同规模任务历史上成功率高、部署耗时短的 provider；History 为 nil 时不改变顺序
+type HistoryPolicy struct {
+	History *PlacementHistory
+}
+
+func (p *HistoryPolicy) Name() string { return "history" }
+
+func (p *HistoryPolicy) Apply(request *types.Info, candidates []*Provider) []*Provider {
+	if p.History == nil {
+		return candidates
+	}
+	class := ClassifyTask(request)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return p.History.Less(class, candidates[i].GetID(), candidates[j].GetID())
+	})
+	return candidates
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/9triver/iarnet/internal/proto/common"
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+)
+
+// InstanceState component 实例在 provider 上的运行状态
+type InstanceState string
+
+const (
+	InstanceStatePending  InstanceState = "pending"   // 已创建但尚未运行
+	InstanceStateRunning  InstanceState = "running"   // 运行中
+	InstanceStateExited   InstanceState = "exited"    // 已退出，退出码有效
+	InstanceStateNotFound InstanceState = "not_found" // provider 上已不存在
+)
+
+// InstanceStatus component 实例的运行状态与退出码
+type InstanceStatus struct {
+	State    InstanceState
+	ExitCode int32  // State 为 exited 时有效
+	Reason   string // 退出原因（如 OOMKilled），可为空
+}
+
+// GetInstanceStatus 查询 component 实例的运行状态，run-to-completion 的任务据此判断是否结束
+func (p *Provider) GetInstanceStatus(ctx context.Context, instanceID string) (*InstanceStatus, error) {
+	if p.client == nil {
+		return nil, fmt.Errorf("provider not connected")
+	}
+	if err := p.requireCapability(common.CapInstanceStatus); err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.GetInstanceStatus(ctx, &providerpb.GetInstanceStatusRequest{
+		ProviderId: p.id,
+		InstanceId: instanceID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get instance status: %w", err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("failed to get instance status: %s", resp.Error)
+	}
+	return &InstanceStatus{
+		State:    InstanceState(resp.State),
+		ExitCode: resp.ExitCode,
+		Reason:   resp.Reason,
+	}, nil
+}
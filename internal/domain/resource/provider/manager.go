@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/9triver/iarnet/internal/domain/resource/types"
+	"github.com/9triver/iarnet/internal/util"
 	"github.com/sirupsen/logrus"
 )
 
@@ -16,11 +17,22 @@ type Manager struct {
 	providers map[string]*Provider // provider ID -> Provider
 
 	// 健康检测相关
-	healthCheckInterval time.Duration // 健康检测间隔
+	healthCheckInterval time.Duration // 健康检测间隔（自适应时为下限）
+	healthCheckMax      time.Duration // 自适应健康检测的间隔上限，0 表示固定间隔
+	healthCheckBusy     func() bool   // 自适应健康检测时判断节点是否繁忙（e.g., 有进行中的部署）
 	healthCheckTimeout  time.Duration // 健康检测超时时间
 	healthCheckCtx      context.Context
 	healthCheckCancel   context.CancelFunc
 	healthCheckWg       sync.WaitGroup
+
+	// best-effort provider 下线时的回调（用于驱逐并重新调度其上的 component）
+	evictionHandler func(provider *Provider)
+
+	// provider 重新连接成功时的回调（用于删除驱逐时遗留在其上的 component 实例）
+	reconnectHandler func(provider *Provider)
+
+	// provider 总容量变化时的回调（用于核对已分配的资源并重新上报节点容量）
+	capacityChangeHandler func(change CapacityChange)
 }
 
 // NewManager 创建 Provider 管理器
@@ -50,25 +62,48 @@ func (m *Manager) Stop() {
 	logrus.Info("Provider health check stopped")
 }
 
+// SetAdaptiveHealthCheck 设置自适应健康检测：provider 状态发生变化或 busy 返回 true 时按下限间隔检测，
+// 否则每轮翻倍直到上限；需在 Start 前调用
+func (m *Manager) SetAdaptiveHealthCheck(lower, upper time.Duration, busy func() bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.healthCheckInterval = lower
+	m.healthCheckMax = upper
+	m.healthCheckBusy = busy
+}
+
 // healthCheckLoop 健康检测循环
 func (m *Manager) healthCheckLoop() {
 	defer m.healthCheckWg.Done()
 
-	ticker := time.NewTicker(m.healthCheckInterval)
-	defer ticker.Stop()
+	m.mu.RLock()
+	upper := m.healthCheckMax
+	if upper == 0 {
+		upper = m.healthCheckInterval
+	}
+	interval := util.NewAdaptiveInterval(m.healthCheckInterval, upper)
+	busy := m.healthCheckBusy
+	m.mu.RUnlock()
+
+	timer := time.NewTimer(interval.Current())
+	defer timer.Stop()
 
 	for {
 		select {
 		case <-m.healthCheckCtx.Done():
 			return
-		case <-ticker.C:
-			m.performHealthCheck()
+		case <-timer.C:
+			changed := m.performHealthCheck()
+			next := interval.Next(changed || (busy != nil && busy()))
+			logrus.Debugf("Next provider health check in %v", next)
+			timer.Reset(next)
 		}
 	}
 }
 
-// performHealthCheck 执行健康检测
-func (m *Manager) performHealthCheck() {
+// performHealthCheck 执行健康检测，返回是否有 provider 的连接状态发生变化（检测失败或重新连接成功）
+// 持续不可达的 provider 不算作变化，避免一个长期离线的 provider 使检测始终保持最短间隔
+func (m *Manager) performHealthCheck() bool {
 	m.mu.RLock()
 	providers := make([]*Provider, 0, len(m.providers))
 	for _, p := range m.providers {
@@ -76,9 +111,13 @@ func (m *Manager) performHealthCheck() {
 	}
 	m.mu.RUnlock()
 
+	changed := false
 	for _, provider := range providers {
-		// 只检测已连接的 provider
+		// 未连接的 provider 尝试重新连接（e.g., provider 重启），成功后在下一轮检测
 		if provider.GetStatus() != types.ProviderStatusConnected {
+			if m.reconnect(provider) {
+				changed = true
+			}
 			continue
 		}
 
@@ -94,6 +133,18 @@ func (m *Manager) performHealthCheck() {
 			logrus.Warnf("Provider %s (host: %s:%d) health check failed: %v, updating status to disconnected",
 				providerID, provider.GetHost(), provider.GetPort(), err)
 			provider.SetStatus(types.ProviderStatusDisconnected)
+			changed = true
+
+			// best-effort provider 的容量随时可能消失，下线即视为驱逐
+			if provider.IsBestEffort() {
+				m.mu.RLock()
+				handler := m.evictionHandler
+				m.mu.RUnlock()
+				if handler != nil {
+					logrus.Infof("Best-effort provider %s is gone, evicting its components", providerID)
+					handler(provider)
+				}
+			}
 		} else {
 			// 健康检查成功，记录日志
 			tags := provider.GetResourceTags()
@@ -105,6 +156,56 @@ func (m *Manager) performHealthCheck() {
 			}
 		}
 	}
+	return changed
+}
+
+// reconnect 重新连接健康检测失败的 provider，provider 仍不可达时等待下一轮；返回是否重新连接成功
+func (m *Manager) reconnect(provider *Provider) bool {
+	ctx, cancel := context.WithTimeout(m.healthCheckCtx, m.healthCheckTimeout)
+	defer cancel()
+	if err := provider.Reconnect(ctx); err != nil {
+		logrus.Debugf("Provider %s is still unreachable: %v", provider.GetID(), err)
+		return false
+	}
+	logrus.Infof("Provider %s (host: %s:%d) reconnected", provider.GetID(), provider.GetHost(), provider.GetPort())
+	m.mu.RLock()
+	handler := m.reconnectHandler
+	m.mu.RUnlock()
+	if handler != nil {
+		handler(provider)
+	}
+	return true
+}
+
+// SetEvictionHandler 设置 best-effort provider 下线时的回调
+func (m *Manager) SetEvictionHandler(handler func(provider *Provider)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.evictionHandler = handler
+}
+
+// SetReconnectHandler 设置 provider 重新连接成功时的回调
+func (m *Manager) SetReconnectHandler(handler func(provider *Provider)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reconnectHandler = handler
+}
+
+// SetCapacityChangeHandler 设置 provider 总容量变化时的回调
+func (m *Manager) SetCapacityChangeHandler(handler func(change CapacityChange)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.capacityChangeHandler = handler
+}
+
+// notifyCapacityChange 转发 provider 的总容量变化
+func (m *Manager) notifyCapacityChange(change CapacityChange) {
+	m.mu.RLock()
+	handler := m.capacityChangeHandler
+	m.mu.RUnlock()
+	if handler != nil {
+		handler(change)
+	}
 }
 
 // Add 添加 Provider 到管理器
@@ -112,6 +213,7 @@ func (m *Manager) Add(provider *Provider) {
 	if provider == nil {
 		return
 	}
+	provider.setCapacityChangeHandler(m.notifyCapacityChange)
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	// 使用 ID 作为 key
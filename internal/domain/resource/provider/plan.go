@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"sync"
+
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+)
+
+// PlanLedger 可行性检查中已假设放置的资源，按 provider（或节点）ID 累计
+// 可行性检查不实际部署，后续 component 的放置需要扣除前面已假设放置的资源
+type PlanLedger struct {
+	mu       sync.Mutex
+	reserved map[string]*types.Info
+	placed   map[string]int // 假设放置的 component 数
+}
+
+// NewPlanLedger 创建空的假设放置账本
+func NewPlanLedger() *PlanLedger {
+	return &PlanLedger{reserved: make(map[string]*types.Info), placed: make(map[string]int)}
+}
+
+// Reserve 记录在 id 上假设放置了 request
+func (l *PlanLedger) Reserve(id string, request *types.Info) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	r, ok := l.reserved[id]
+	if !ok {
+		r = &types.Info{}
+		l.reserved[id] = r
+	}
+	r.CPU += request.CPU
+	r.Memory += request.Memory
+	r.GPU += request.GPU
+	l.placed[id]++
+}
+
+// Placements 返回 id 上假设放置的 component 数
+func (l *PlanLedger) Placements(id string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.placed[id]
+}
+
+// Remaining 返回扣除 id 上已假设放置的资源后的可用资源，available 为 nil 时返回 nil
+func (l *PlanLedger) Remaining(id string, available *types.Info) *types.Info {
+	if available == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	r, ok := l.reserved[id]
+	if !ok {
+		return available
+	}
+	remaining := *available
+	remaining.CPU -= r.CPU
+	remaining.Memory -= r.Memory
+	remaining.GPU -= r.GPU
+	return &remaining
+}
+
+type planLedgerKey struct{}
+
+// WithPlanLedger 在 context 中附加假设放置账本，FindAvailableProvider 据此扣除已假设放置的资源
+func WithPlanLedger(ctx context.Context, ledger *PlanLedger) context.Context {
+	return context.WithValue(ctx, planLedgerKey{}, ledger)
+}
+
+// GetPlanLedger 获取 context 中的假设放置账本
+func GetPlanLedger(ctx context.Context) (*PlanLedger, bool) {
+	ledger, ok := ctx.Value(planLedgerKey{}).(*PlanLedger)
+	return ledger, ok && ledger != nil
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"sort"
+
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+)
+
+// Policy 调度策略
+// 对候选 provider 进行排序（或过滤），不负责检查资源是否充足
+type Policy interface {
+	// Name 策略名称
+	Name() string
+	// Apply 返回按偏好排序后的候选 provider
+	Apply(request *types.Info, candidates []*Provider) []*Provider
+}
+
+// PolicyChain 策略链
+// 链中靠前的策略优先级更高：各策略均为稳定排序，按从后往前的顺序依次执行，
+// 因此靠后的策略只在靠前策略认为"同等"的 provider 之间起作用
+type PolicyChain []Policy
+
+// Apply 依次执行策略链
+func (c PolicyChain) Apply(request *types.Info, candidates []*Provider) []*Provider {
+	ordered := append([]*Provider(nil), candidates...)
+	for i := len(c) - 1; i >= 0; i-- {
+		ordered = c[i].Apply(request, ordered)
+	}
+	return ordered
+}
+
+// DefaultPolicyChain 默认策略链
+func DefaultPolicyChain() PolicyChain {
+	return PolicyChain{
+		&NodeSelectorPolicy{},
+		&TimeWindowPolicy{},
+		&CapacityClassPolicy{},
+		&HistoryPolicy{},
+		&EnergyPolicy{},
+		&PerformancePolicy{},
+	}
+}
+
+// NodeSelectorPolicy 节点标签策略
+// 本节点标签不满足请求的节点标签约束时过滤掉全部候选，部署随之委托给满足约束的同域节点
+type NodeSelectorPolicy struct {
+	NodeLabels map[string]string // 本节点标签
+}
+
+func (p *NodeSelectorPolicy) Name() string { return "node-selector" }
+
+func (p *NodeSelectorPolicy) Apply(request *types.Info, candidates []*Provider) []*Provider {
+	if !types.MatchLabels(request.NodeSelector, p.NodeLabels) {
+		return nil
+	}
+	return candidates
+}
+
+// 小任务阈值：不需要 GPU，且 CPU 与内存均不超过阈值
+const (
+	smallTaskMaxCPU    int64 = 1000               // 1 核（millicores）
+	smallTaskMaxMemory int64 = 1024 * 1024 * 1024 // 1 GiB
+)
+
+// IsSmallTask 判断资源请求是否为小任务
+func IsSmallTask(request *types.Info) bool {
+	return request.GPU == 0 && request.CPU <= smallTaskMaxCPU && request.Memory <= smallTaskMaxMemory
+}
+
+// CapacityClassPolicy 容量类别策略
+// 小任务优先调度到 best-effort provider，其他任务优先调度到 guaranteed provider
+type CapacityClassPolicy struct{}
+
+func (p *CapacityClassPolicy) Name() string { return "capacity-class" }
+
+func (p *CapacityClassPolicy) Apply(request *types.Info, candidates []*Provider) []*Provider {
+	preferBestEffort := IsSmallTask(request)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].IsBestEffort() == preferBestEffort && candidates[j].IsBestEffort() != preferBestEffort
+	})
+	return candidates
+}
+
+// EnergyPolicy 能耗策略
+// 优先选择每核功耗更低的 provider；大任务尽量避开电池供电的 provider
+// 未上报能耗画像的 provider 排在已上报的之后
+type EnergyPolicy struct{}
+
+func (p *EnergyPolicy) Name() string { return "energy" }
+
+func (p *EnergyPolicy) Apply(request *types.Info, candidates []*Provider) []*Provider {
+	avoidBattery := !IsSmallTask(request)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return EnergyLess(candidates[i].GetEnergyProfile(), candidates[j].GetEnergyProfile(), avoidBattery)
+	})
+	return candidates
+}
+
+// EnergyLess 比较两个能耗画像，a 更优时返回 true
+// 也用于对候选节点排序
+func EnergyLess(a, b *types.EnergyProfile, avoidBattery bool) bool {
+	if avoidBattery {
+		aBattery := a != nil && a.BatteryPowered
+		bBattery := b != nil && b.BatteryPowered
+		if aBattery != bBattery {
+			return !aBattery
+		}
+	}
+	aKnown := a != nil && a.WattsPerCore > 0
+	bKnown := b != nil && b.WattsPerCore > 0
+	if aKnown != bKnown {
+		return aKnown
+	}
+	if !aKnown {
+		return false
+	}
+	return a.WattsPerCore < b.WattsPerCore
+}
+
+// PerformancePolicy 性能策略（基于注册时的微基准测试结果）
+// 请求 GPU 的任务优先选择实测存在 GPU 的 provider，其余按 CPU 得分从高到低排序
+// 未运行基准测试的 provider 排在已测量的之后
+type PerformancePolicy struct{}
+
+func (p *PerformancePolicy) Name() string { return "performance" }
+
+func (p *PerformancePolicy) Apply(request *types.Info, candidates []*Provider) []*Provider {
+	needGPU := request.GPU > 0
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return BenchmarkLess(candidates[i].GetBenchmark(), candidates[j].GetBenchmark(), needGPU)
+	})
+	return candidates
+}
+
+// BenchmarkLess 比较两个基准测试结果，a 更优时返回 true
+func BenchmarkLess(a, b *types.BenchmarkResult, needGPU bool) bool {
+	if needGPU {
+		aGPU := a != nil && a.GPUPresent
+		bGPU := b != nil && b.GPUPresent
+		if aGPU != bGPU {
+			return aGPU
+		}
+	}
+	aKnown := a != nil && a.CPUScore > 0
+	bKnown := b != nil && b.CPUScore > 0
+	if aKnown != bKnown {
+		return aKnown
+	}
+	if !aKnown {
+		return false
+	}
+	return a.CPUScore > b.CPUScore
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"path"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+	"github.com/9triver/iarnet/internal/util"
+)
+
+// TimeWindowTasks 时间窗口规则限制的任务范围
+type TimeWindowTasks string
+
+const (
+	TimeWindowTasksAll   TimeWindowTasks = "all"   // 所有任务
+	TimeWindowTasksLarge TimeWindowTasks = "large" // 仅大任务（见 IsSmallTask）
+	TimeWindowTasksSmall TimeWindowTasks = "small" // 仅小任务
+)
+
+// TimeWindowRule 时间窗口规则
+// 当前时刻匹配 Schedule 时，规则选中的 provider 不接受规则限制的任务
+// e.g., 工作时间不向办公桌面机部署大任务；工作日 GPU provider 只留给指定团队
+type TimeWindowRule struct {
+	Name     string
+	Schedule *util.CronSchedule
+	Location *time.Location // Schedule 所用的时区，nil 表示本地时区
+
+	// 规则作用的 provider：名称或 ID 匹配 Providers 中任一通配符（为空表示全部），且具备 ProviderTags 中全部资源标签
+	Providers    []string
+	ProviderTags []string
+
+	// 规则限制的任务：Tasks 范围内、且节点标签约束不包含 AllowNodeSelector 全部键值的请求
+	Tasks             TimeWindowTasks
+	AllowNodeSelector map[string]string
+}
+
+// Active 判断规则在时刻 t 是否生效
+func (r *TimeWindowRule) Active(t time.Time) bool {
+	if r.Location != nil {
+		t = t.In(r.Location)
+	}
+	return r.Schedule.Matches(t)
+}
+
+// restricts 判断规则是否限制该请求
+func (r *TimeWindowRule) restricts(request *types.Info) bool {
+	switch r.Tasks {
+	case TimeWindowTasksLarge:
+		if IsSmallTask(request) {
+			return false
+		}
+	case TimeWindowTasksSmall:
+		if !IsSmallTask(request) {
+			return false
+		}
+	}
+	return len(r.AllowNodeSelector) == 0 || !types.MatchLabels(r.AllowNodeSelector, request.NodeSelector)
+}
+
+// covers 判断规则是否作用于该 provider
+func (r *TimeWindowRule) covers(p *Provider) bool {
+	if len(r.ProviderTags) > 0 && !providerHasRequiredTags(p.GetResourceTags(), r.ProviderTags) {
+		return false
+	}
+	if len(r.Providers) == 0 {
+		return true
+	}
+	for _, pattern := range r.Providers {
+		if ok, _ := path.Match(pattern, p.GetName()); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, p.GetID()); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// TimeWindowPolicy 时间窗口策略
+// 过滤掉当前生效的时间窗口规则所禁止的 provider，不改变其余候选的顺序
+type TimeWindowPolicy struct {
+	Rules []TimeWindowRule
+}
+
+func (p *TimeWindowPolicy) Name() string { return "time-window" }
+
+func (p *TimeWindowPolicy) Apply(request *types.Info, candidates []*Provider) []*Provider {
+	if len(p.Rules) == 0 {
+		return candidates
+	}
+	now := time.Now()
+	var active []*TimeWindowRule
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if rule.Active(now) && rule.restricts(request) {
+			active = append(active, rule)
+		}
+	}
+	if len(active) == 0 {
+		return candidates
+	}
+
+	allowed := candidates[:0]
+	for _, candidate := range candidates {
+		if !coveredByAny(active, candidate) {
+			allowed = append(allowed, candidate)
+		}
+	}
+	return allowed
+}
+
+func coveredByAny(rules []*TimeWindowRule, p *Provider) bool {
+	for _, rule := range rules {
+		if rule.covers(p) {
+			return true
+		}
+	}
+	return false
+}
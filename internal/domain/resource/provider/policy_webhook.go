@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultPolicyWebhookTimeout 外部策略 webhook 的默认超时
+const DefaultPolicyWebhookTimeout = 2 * time.Second
+
+// PolicyWebhookRequest 发送给外部策略 webhook 的请求体
+type PolicyWebhookRequest struct {
+	Request    *types.Info              `json:"request"`
+	Candidates []PolicyWebhookCandidate `json:"candidates"` // 已按链中靠后的策略排好序
+}
+
+// PolicyWebhookCandidate 候选 provider 快照
+type PolicyWebhookCandidate struct {
+	ID            string                 `json:"id"`
+	Name          string                 `json:"name"`
+	Host          string                 `json:"host"`
+	CapacityClass types.CapacityClass    `json:"capacity_class"`
+	Architectures []string               `json:"architectures,omitempty"`
+	Available     *types.Info            `json:"available,omitempty"` // 缓存的可用资源，缓存缺失或陈旧时为空
+	Energy        *types.EnergyProfile   `json:"energy,omitempty"`
+	Benchmark     *types.BenchmarkResult `json:"benchmark,omitempty"`
+}
+
+// PolicyWebhookResponse 外部策略 webhook 的响应体
+// Providers 为批准的 provider ID，按偏好排序；未列出的候选视为被拒绝
+type PolicyWebhookResponse struct {
+	Providers []string `json:"providers"`
+}
+
+// WebhookPolicy 外部策略
+// 将资源请求与候选 provider POST 到外部 HTTP 端点（e.g., 成本控制、合规审查），按其返回的结果过滤并排序候选
+// webhook 超时或出错时，FailOpen 为 true 则保留候选原样继续调度，否则拒绝全部候选
+type WebhookPolicy struct {
+	URL      string
+	Timeout  time.Duration
+	FailOpen bool
+
+	client *http.Client
+}
+
+// NewWebhookPolicy 创建外部策略，timeout <= 0 时使用默认超时
+func NewWebhookPolicy(url string, timeout time.Duration, failOpen bool) *WebhookPolicy {
+	if timeout <= 0 {
+		timeout = DefaultPolicyWebhookTimeout
+	}
+	return &WebhookPolicy{
+		URL:      url,
+		Timeout:  timeout,
+		FailOpen: failOpen,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+func (p *WebhookPolicy) Name() string { return "webhook" }
+
+func (p *WebhookPolicy) Apply(request *types.Info, candidates []*Provider) []*Provider {
+	if len(candidates) == 0 {
+		return candidates
+	}
+	approved, err := p.query(request, candidates)
+	if err != nil {
+		if p.FailOpen {
+			logrus.Warnf("Policy webhook %s failed, keeping candidates (fail-open): %v", p.URL, err)
+			return candidates
+		}
+		logrus.Warnf("Policy webhook %s failed, rejecting all candidates (fail-closed): %v", p.URL, err)
+		return nil
+	}
+
+	byID := make(map[string]*Provider, len(candidates))
+	for _, candidate := range candidates {
+		byID[candidate.GetID()] = candidate
+	}
+	ordered := make([]*Provider, 0, len(approved))
+	for _, id := range approved {
+		if candidate, ok := byID[id]; ok {
+			ordered = append(ordered, candidate)
+			delete(byID, id)
+		}
+	}
+	return ordered
+}
+
+// query 调用 webhook，返回批准的 provider ID
+func (p *WebhookPolicy) query(request *types.Info, candidates []*Provider) ([]string, error) {
+	body := PolicyWebhookRequest{
+		Request:    request,
+		Candidates: make([]PolicyWebhookCandidate, 0, len(candidates)),
+	}
+	for _, candidate := range candidates {
+		available, _ := candidate.GetCachedAvailable()
+		body.Candidates = append(body.Candidates, PolicyWebhookCandidate{
+			ID:            candidate.GetID(),
+			Name:          candidate.GetName(),
+			Host:          candidate.GetHost(),
+			CapacityClass: candidate.GetCapacityClass(),
+			Architectures: candidate.GetArchitectures(),
+			Available:     available,
+			Energy:        candidate.GetEnergyProfile(),
+			Benchmark:     candidate.GetBenchmark(),
+		})
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.URL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	var decision PolicyWebhookResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decision); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook response: %w", err)
+	}
+	return decision.Providers, nil
+}
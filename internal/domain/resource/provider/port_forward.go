@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/9triver/iarnet/internal/proto/common"
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+)
+
+// PortForwardConn 经 provider 转发到 component 端口的一条 TCP 连接
+// 实现 io.ReadWriteCloser，读到 io.EOF 表示 component 端已关闭连接
+type PortForwardConn struct {
+	stream  providerpb.Service_PortForwardClient
+	cancel  context.CancelFunc
+	pending []byte
+}
+
+// PortForward 建立到部署于该 provider 上的 component 端口的隧道
+func (p *Provider) PortForward(ctx context.Context, instanceID string, port uint32) (*PortForwardConn, error) {
+	if p.client == nil {
+		return nil, fmt.Errorf("provider not connected")
+	}
+	if p.id == "" {
+		return nil, fmt.Errorf("provider not connected, please call Connect first")
+	}
+
+	if err := p.requireCapability(common.CapPortForward); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := p.client.PortForward(ctx)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to open port-forward stream: %w", err)
+	}
+
+	start := &providerpb.PortForwardRequest{
+		Payload: &providerpb.PortForwardRequest_Start{
+			Start: &providerpb.PortForwardStart{
+				ProviderId: p.id,
+				InstanceId: instanceID,
+				Port:       port,
+			},
+		},
+	}
+	if err := stream.Send(start); err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to start port-forward: %w", err)
+	}
+
+	return &PortForwardConn{stream: stream, cancel: cancel}, nil
+}
+
+func (c *PortForwardConn) Read(p []byte) (int, error) {
+	for len(c.pending) == 0 {
+		resp, err := c.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if resp.Error != "" {
+			return 0, fmt.Errorf("port-forward failed: %s", resp.Error)
+		}
+		c.pending = resp.Data
+	}
+	n := copy(p, c.pending)
+	c.pending = c.pending[n:]
+	return n, nil
+}
+
+func (c *PortForwardConn) Write(p []byte) (int, error) {
+	data := append([]byte(nil), p...)
+	if err := c.stream.Send(&providerpb.PortForwardRequest{Payload: &providerpb.PortForwardRequest_Data{Data: data}}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// CloseWrite 通知 component 端不再发送数据
+func (c *PortForwardConn) CloseWrite() error {
+	return c.stream.Send(&providerpb.PortForwardRequest{Payload: &providerpb.PortForwardRequest_CloseWrite{CloseWrite: true}})
+}
+
+// Close 关闭隧道，可与 Read/Write 并发调用
+func (c *PortForwardConn) Close() error {
+	c.cancel()
+	return nil
+}
+
+var _ io.ReadWriteCloser = (*PortForwardConn)(nil)
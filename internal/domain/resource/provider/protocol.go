@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/9triver/iarnet/internal/proto/common"
+)
+
+// ErrCapabilityUnsupported provider 未在握手中声明所需的可选能力（通常为旧版 provider）
+var ErrCapabilityUnsupported = errors.New("capability not supported by provider")
+
+// GetProtocol 获取 Connect 握手协商出的协议，未连接时返回 nil
+func (p *Provider) GetProtocol() *common.Negotiated {
+	p.cacheMu.RLock()
+	defer p.cacheMu.RUnlock()
+	return p.protocol
+}
+
+// SupportsCapability provider 是否支持指定的可选能力
+func (p *Provider) SupportsCapability(capability string) bool {
+	return p.GetProtocol().Supports(capability)
+}
+
+// requireCapability 检查 provider 是否支持指定能力，不支持时返回包装了 ErrCapabilityUnsupported 的错误
+func (p *Provider) requireCapability(capability string) error {
+	if p.SupportsCapability(capability) {
+		return nil
+	}
+	version := common.LegacyProtocolVersion
+	if n := p.GetProtocol(); n != nil {
+		version = n.Version
+	}
+	return fmt.Errorf("%w: %s (provider %s, protocol v%d)", ErrCapabilityUnsupported, capability, p.id, version)
+}
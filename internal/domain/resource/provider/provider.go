@@ -2,19 +2,26 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"strconv"
 	"sync"
 	"time"
 
+	"github.com/9triver/iarnet/internal/domain/resource/accounting"
 	"github.com/9triver/iarnet/internal/domain/resource/types"
+	"github.com/9triver/iarnet/internal/proto/common"
 	resourcepb "github.com/9triver/iarnet/internal/proto/resource"
 	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
 	"github.com/9triver/iarnet/internal/util"
+	"github.com/9triver/iarnet/internal/util/identity"
+	"github.com/9triver/iarnet/internal/util/tunnel"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
 type EnvVariables struct {
@@ -22,6 +29,20 @@ type EnvVariables struct {
 	ZMQPort    int
 	StorePort  int
 	LoggerPort int
+
+	// SharedStoreAddress 同域共用的独立 store 地址，设置后代替本节点的 store 端口注入 component
+	SharedStoreAddress string
+
+	// UpstreamTokens 为连接本节点的 component 签发上游令牌，nil 表示不签发
+	UpstreamTokens *identity.ComponentTokens
+}
+
+// StoreAddress component 访问的 store 地址：同域共用的独立 store，未配置时为本节点的 store 端口
+func (e *EnvVariables) StoreAddress() string {
+	if e.SharedStoreAddress != "" {
+		return e.SharedStoreAddress
+	}
+	return net.JoinHostPort(e.IarnetHost, strconv.Itoa(e.StorePort))
 }
 
 // ResourceTags 资源标签（描述 provider 支持的计算资源类型）
@@ -41,17 +62,34 @@ type Provider struct {
 	providerType   types.ProviderType
 	lastUpdateTime time.Time
 	status         types.ProviderStatus
+	capacityClass  types.CapacityClass
+	token          string // Connect 时出示的注册令牌
+	static         bool   // 由配置文件管理（static_providers），API 只读
+
+	// dialer 反向连接模式下经隧道建立连接，nil 表示直接连接 host:port
+	dialer func(ctx context.Context, addr string) (net.Conn, error)
 
-	conn   *grpc.ClientConn
-	client providerpb.ServiceClient
+	conn     *grpc.ClientConn
+	client   providerpb.ServiceClient
+	protocol *common.Negotiated // Connect 握手协商出的协议版本与能力
 
 	envVariables *EnvVariables
 
 	// 资源缓存（从健康检测响应中获取）
 	cachedCapacity *types.Capacity
 	cachedTags     *ResourceTags
+	cachedEnergy   *types.EnergyProfile
+	architectures  []string               // provider 可运行的 CPU 架构，旧版 provider 不上报
+	gpus           []types.GPUDevice      // GPU 拓扑与逐卡分配情况，只按数量记账的 provider 不上报
+	streams        []types.MediaStream    // 拥有的数据流，没有摄像头的 provider 不上报
+	benchmark      *types.BenchmarkResult // 注册时微基准测试的结果（可选）
 	cacheTimestamp time.Time
+	cacheTTL       time.Duration // 容量缓存最大陈旧时间
+	cacheLive      bool          // 使用量推送流活跃，容量变化会被主动推送
 	cacheMu        sync.RWMutex
+
+	capacityChangeHandler func(change CapacityChange) // 总容量变化时的回调
+	capacityAlert         *CapacityAlert              // 已分配资源超过总容量的告警，nil 表示没有
 }
 
 // NewProvider 创建新的 provider，如果未提供 ID，将通过 RPC 服务注册并获取分配的 ID
@@ -64,6 +102,8 @@ func NewProvider(name string, host string, port int, envVariables *EnvVariables)
 		port:           port,
 		lastUpdateTime: time.Now(),
 		status:         types.ProviderStatusDisconnected,
+		capacityClass:  types.CapacityClassGuaranteed,
+		cacheTTL:       DefaultCapacityCacheTTL,
 	}
 }
 
@@ -78,24 +118,25 @@ func NewProviderWithID(id, name string, host string, port int, envVariables *Env
 		port:           port,
 		lastUpdateTime: time.Now(),
 		status:         types.ProviderStatusDisconnected,
+		capacityClass:  types.CapacityClassGuaranteed,
+		cacheTTL:       DefaultCapacityCacheTTL,
 		// conn 和 client 保持为 nil，需要在业务层重新连接
 	}
 }
 
 func (p *Provider) Connect(ctx context.Context) error {
 	// 如果未提供 ID，通过 RPC 服务注册并获取分配的 ID
-	if p.host == "" || p.port == 0 {
-		return fmt.Errorf("service host and port are required when ID is not provided")
-	}
-
-	conn, err := grpc.NewClient(fmt.Sprintf("%s:%d", p.host, p.port), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := p.newClientConn()
 	if err != nil {
 		return fmt.Errorf("failed to create provider connection: %w", err)
 	}
 	client := providerpb.NewServiceClient(conn)
 
+	local := common.NewProtocolInfo(common.ProviderCapabilities...)
 	req := &providerpb.ConnectRequest{
 		ProviderId: p.id,
+		Protocol:   local,
+		Token:      p.token,
 	}
 	resp, err := client.Connect(ctx, req)
 	if err != nil {
@@ -107,9 +148,29 @@ func (p *Provider) Connect(ctx context.Context) error {
 		return fmt.Errorf("failed to assign ID: %s", resp.Error)
 	}
 
+	// 协商协议：旧版 provider 不携带 ProtocolInfo，按 legacy 处理，可选能力均视为不支持
+	protocol, err := common.Negotiate(local, resp.Protocol)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("incompatible provider protocol: %w", err)
+	}
+	if protocol.Legacy {
+		logrus.Warnf("Provider %s does not negotiate protocol versions (legacy), optional features are disabled", p.id)
+	} else {
+		logrus.Debugf("Provider %s negotiated protocol v%d with capabilities %v", p.id, protocol.Version, protocol.Capabilities())
+	}
+
 	p.providerType = types.ProviderType(resp.ProviderType.Name)
 	p.client = client
 	p.conn = conn
+	p.cacheMu.Lock()
+	p.protocol = protocol
+	p.architectures = normalizeArchitectures(resp.Architectures)
+	// 重启后的 provider 在连接时上报当前容量，配置的总容量变化可被立即发现
+	if capacity := capacityFromProto(resp.Capacity); capacity != nil {
+		p.storeCapacity(capacity, "connect")
+	}
+	p.cacheMu.Unlock()
 	p.status = types.ProviderStatusConnected
 	return nil
 }
@@ -147,6 +208,63 @@ func (p *Provider) GetStatus() types.ProviderStatus {
 	return p.status
 }
 
+// GetCapacityClass 获取 provider 的容量类别
+func (p *Provider) GetCapacityClass() types.CapacityClass {
+	return p.capacityClass
+}
+
+// SetCapacityClass 设置 provider 的容量类别
+func (p *Provider) SetCapacityClass(class types.CapacityClass) {
+	p.capacityClass = class
+	p.lastUpdateTime = time.Now()
+}
+
+// SetDialer 设置反向连接模式下经隧道建立连接的拨号函数
+func (p *Provider) SetDialer(dialer func(ctx context.Context, addr string) (net.Conn, error)) {
+	p.dialer = dialer
+}
+
+// IsReverse 是否为反向连接模式（provider 主动连接本节点）
+func (p *Provider) IsReverse() bool {
+	return p.dialer != nil
+}
+
+// newClientConn 创建到 provider 的 gRPC 连接
+// 反向连接模式下所有 RPC 复用 provider 主动建立的隧道连接
+func (p *Provider) newClientConn() (*grpc.ClientConn, error) {
+	if p.dialer != nil {
+		opts := append([]grpc.DialOption{
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithContextDialer(p.dialer),
+		}, tunnel.DialOptions()...)
+		return grpc.NewClient("passthrough:///"+p.id, opts...)
+	}
+	if p.host == "" || p.port == 0 {
+		return nil, fmt.Errorf("provider host and port are required")
+	}
+	return grpc.NewClient(fmt.Sprintf("%s:%d", p.host, p.port), grpc.WithTransportCredentials(insecure.NewCredentials()))
+}
+
+// SetToken 设置 Connect 时出示的注册令牌
+func (p *Provider) SetToken(token string) {
+	p.token = token
+}
+
+// IsStatic 是否由配置文件管理，配置管理的 provider 不能通过 API 修改或注销
+func (p *Provider) IsStatic() bool {
+	return p.static
+}
+
+// SetStatic 标记 provider 由配置文件管理
+func (p *Provider) SetStatic(static bool) {
+	p.static = static
+}
+
+// IsBestEffort 是否为尽力而为型 provider
+func (p *Provider) IsBestEffort() bool {
+	return p.capacityClass == types.CapacityClassBestEffort
+}
+
 // SetStatus 设置 provider 状态
 func (p *Provider) SetStatus(status types.ProviderStatus) {
 	p.status = status
@@ -173,6 +291,19 @@ func (p *Provider) Disconnect() {
 	p.client = nil
 }
 
+// Reconnect 重新连接健康检测失败的 provider，沿用原有的 provider ID
+// provider 重启后会丢失连接状态，重新连接时上报的容量使配置的变化被立即发现
+func (p *Provider) Reconnect(ctx context.Context) error {
+	old := p.conn
+	if err := p.Connect(ctx); err != nil {
+		return err
+	}
+	if old != nil {
+		old.Close()
+	}
+	return nil
+}
+
 // HealthCheck 健康检测，检查 provider 是否仍然连接，并更新资源缓存
 func (p *Provider) HealthCheck(ctx context.Context) error {
 	if p.client == nil || p.id == "" {
@@ -183,6 +314,8 @@ func (p *Provider) HealthCheck(ctx context.Context) error {
 	}
 	resp, err := p.client.HealthCheck(ctx, req)
 	if err != nil {
+		// provider 不可达时缓存不再可信
+		p.InvalidateCapacityCache()
 		return fmt.Errorf("failed to health check: %w", err)
 	}
 
@@ -217,7 +350,7 @@ func (p *Provider) updateCacheFromHealthCheckResponse(resp *providerpb.HealthChe
 	defer p.cacheMu.Unlock()
 
 	if resp.Capacity != nil {
-		p.cachedCapacity = &types.Capacity{
+		p.storeCapacity(&types.Capacity{
 			Total: &types.Info{
 				CPU:    resp.Capacity.Total.Cpu,
 				Memory: resp.Capacity.Total.Memory,
@@ -233,7 +366,7 @@ func (p *Provider) updateCacheFromHealthCheckResponse(resp *providerpb.HealthChe
 				Memory: resp.Capacity.Available.Memory,
 				GPU:    resp.Capacity.Available.Gpu,
 			},
-		}
+		}, "health check")
 	}
 
 	if resp.ResourceTags != nil {
@@ -249,6 +382,34 @@ func (p *Provider) updateCacheFromHealthCheckResponse(resp *providerpb.HealthChe
 		logrus.Debugf("Provider %s health check response has no resource tags", p.id)
 	}
 
+	if resp.EnergyProfile != nil {
+		p.cachedEnergy = &types.EnergyProfile{
+			WattsPerCore:   resp.EnergyProfile.WattsPerCore,
+			BatteryPowered: resp.EnergyProfile.BatteryPowered,
+		}
+	}
+
+	if len(resp.Architectures) > 0 {
+		p.architectures = normalizeArchitectures(resp.Architectures)
+	}
+
+	p.gpus = p.gpus[:0]
+	for _, g := range resp.Gpus {
+		p.gpus = append(p.gpus, types.GPUDevice{
+			ID:          g.Id,
+			Index:       g.Index,
+			Name:        g.Name,
+			Memory:      g.Memory,
+			NVLinkGroup: g.NvlinkGroup,
+			InstanceID:  g.InstanceId,
+		})
+	}
+
+	p.streams = p.streams[:0]
+	for _, s := range resp.Streams {
+		p.streams = append(p.streams, types.MediaStream{Name: s.Name, Kind: s.Kind, URL: s.Url})
+	}
+
 	p.cacheTimestamp = time.Now()
 	logrus.Debugf("Updated resource cache for provider %s at %v", p.id, p.cacheTimestamp)
 }
@@ -271,12 +432,83 @@ func (p *Provider) GetResourceTags() *ResourceTags {
 	}
 }
 
-// getCachedCapacity 获取缓存的资源容量（返回副本以避免并发问题）
+// GetEnergyProfile 获取 provider 上报的能耗画像，未上报时返回 nil
+func (p *Provider) GetEnergyProfile() *types.EnergyProfile {
+	p.cacheMu.RLock()
+	defer p.cacheMu.RUnlock()
+
+	if p.cachedEnergy == nil {
+		return nil
+	}
+	energy := *p.cachedEnergy
+	return &energy
+}
+
+// GetArchitectures 获取 provider 可运行的 CPU 架构，未上报时返回 nil
+func (p *Provider) GetArchitectures() []string {
+	p.cacheMu.RLock()
+	defer p.cacheMu.RUnlock()
+	return append([]string(nil), p.architectures...)
+}
+
+// GetGPUs 获取 provider 上报的 GPU 拓扑与逐卡分配情况，未上报时返回 nil
+func (p *Provider) GetGPUs() []types.GPUDevice {
+	p.cacheMu.RLock()
+	defer p.cacheMu.RUnlock()
+	if len(p.gpus) == 0 {
+		return nil
+	}
+	return append([]types.GPUDevice(nil), p.gpus...)
+}
+
+// GetStreams 获取 provider 拥有的数据流，未上报时返回 nil
+func (p *Provider) GetStreams() []types.MediaStream {
+	p.cacheMu.RLock()
+	defer p.cacheMu.RUnlock()
+	if len(p.streams) == 0 {
+		return nil
+	}
+	return append([]types.MediaStream(nil), p.streams...)
+}
+
+// GetStream 获取 provider 拥有的指定名称的数据流，不存在时返回 nil
+func (p *Provider) GetStream(name string) *types.MediaStream {
+	p.cacheMu.RLock()
+	defer p.cacheMu.RUnlock()
+	for i := range p.streams {
+		if p.streams[i].Name == name {
+			s := p.streams[i]
+			return &s
+		}
+	}
+	return nil
+}
+
+// GetBenchmark 获取 provider 的微基准测试结果，未测量时返回 nil
+func (p *Provider) GetBenchmark() *types.BenchmarkResult {
+	p.cacheMu.RLock()
+	defer p.cacheMu.RUnlock()
+
+	if p.benchmark == nil {
+		return nil
+	}
+	bench := *p.benchmark
+	return &bench
+}
+
+// SetBenchmark 设置微基准测试结果（从持久化数据恢复时使用）
+func (p *Provider) SetBenchmark(bench *types.BenchmarkResult) {
+	p.cacheMu.Lock()
+	defer p.cacheMu.Unlock()
+	p.benchmark = bench
+}
+
+// getCachedCapacity 获取未超过陈旧时间的缓存资源容量（返回副本以避免并发问题）
 func (p *Provider) getCachedCapacity() *types.Capacity {
 	p.cacheMu.RLock()
 	defer p.cacheMu.RUnlock()
 
-	if p.cachedCapacity == nil {
+	if !p.capacityCacheFresh() {
 		return nil
 	}
 
@@ -306,18 +538,11 @@ func (p *Provider) refreshCapacityCache(ctx context.Context) error {
 		return fmt.Errorf("provider not connected")
 	}
 
-	// 实时获取容量
-	capacity, err := p.fetchCapacityFromProvider(ctx)
-	if err != nil {
+	// 实时获取容量（fetchCapacityFromProvider 会更新缓存）
+	if _, err := p.fetchCapacityFromProvider(ctx); err != nil {
 		return err
 	}
 
-	// 更新缓存
-	p.cacheMu.Lock()
-	p.cachedCapacity = capacity
-	p.cacheTimestamp = time.Now()
-	p.cacheMu.Unlock()
-
 	logrus.Debugf("Refreshed capacity cache for provider %s", p.id)
 	return nil
 }
@@ -332,10 +557,7 @@ func (p *Provider) fetchCapacityFromProvider(ctx context.Context) (*types.Capaci
 		client = p.client
 	} else {
 		// 创建临时连接（用于测试场景）
-		if p.host == "" || p.port == 0 {
-			return nil, fmt.Errorf("provider host and port are required")
-		}
-		conn, err = grpc.NewClient(fmt.Sprintf("%s:%d", p.host, p.port), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		conn, err = p.newClientConn()
 		if err != nil {
 			return nil, fmt.Errorf("failed to create provider connection: %w", err)
 		}
@@ -359,14 +581,13 @@ func (p *Provider) fetchCapacityFromProvider(ctx context.Context) (*types.Capaci
 
 	// 更新缓存
 	p.cacheMu.Lock()
-	p.cachedCapacity = capacity
-	p.cacheTimestamp = time.Now()
+	p.storeCapacity(capacity, "fetch")
 	p.cacheMu.Unlock()
 
 	return capacity, nil
 }
 
-// GetCapacity 获取资源容量，优先使用缓存
+// GetCapacity 获取资源容量，缓存未超过陈旧时间时使用缓存
 // forceRefresh: 如果为 true，强制从 provider 实时获取
 func (p *Provider) GetCapacity(ctx context.Context, forceRefresh ...bool) (*types.Capacity, error) {
 	shouldRefresh := len(forceRefresh) > 0 && forceRefresh[0]
@@ -378,28 +599,23 @@ func (p *Provider) GetCapacity(ctx context.Context, forceRefresh ...bool) (*type
 		}
 	}
 
-	// 缓存不存在或需要强制刷新，从 provider 实时获取
+	// 缓存不存在、已陈旧或需要强制刷新，从 provider 实时获取
 	return p.fetchCapacityFromProvider(ctx)
 }
 
-// GetAvailable 获取可用资源，优先使用缓存
+// GetAvailable 获取可用资源，缓存未超过陈旧时间时使用缓存
 // forceRefresh: 如果为 true，强制从 provider 实时获取
 func (p *Provider) GetAvailable(ctx context.Context, forceRefresh ...bool) (*types.Info, error) {
 	shouldRefresh := len(forceRefresh) > 0 && forceRefresh[0]
 
 	// 如果不需要强制刷新，尝试使用缓存
 	if !shouldRefresh {
-		if cached := p.getCachedCapacity(); cached != nil && cached.Available != nil {
-			// 返回缓存的可用资源副本
-			return &types.Info{
-				CPU:    cached.Available.CPU,
-				Memory: cached.Available.Memory,
-				GPU:    cached.Available.GPU,
-			}, nil
+		if cached, ok := p.GetCachedAvailable(); ok {
+			return cached, nil
 		}
 	}
 
-	// 缓存不存在或需要强制刷新，从 provider 实时获取
+	// 缓存不存在、已陈旧或需要强制刷新，从 provider 实时获取
 	capacity, err := p.fetchCapacityFromProvider(ctx)
 	if err != nil {
 		return nil, err
@@ -415,6 +631,19 @@ func (p *Provider) GetLogs(d string, lines int) ([]string, error) {
 	return nil, fmt.Errorf("not implemented")
 }
 
+// ErrNoCapacity provider 在提交部署时发现剩余容量已不足以容纳该 component
+// 调度决策基于缓存的可用容量，决策与提交之间使用量可能已变化，provider 会在分配前原子地复核并拒绝超额部署
+var ErrNoCapacity = errors.New("provider has no capacity for deployment")
+
+// ErrNoAvailableProvider 本节点没有满足资源要求的 provider，调用方可委托给其他节点
+var ErrNoAvailableProvider = errors.New("no available provider found that satisfies the resource requirements")
+
+// IsRetryable 判断部署错误是否为暂时性错误，重新选择 provider 后可能成功：
+// provider 提交时复核容量失败（缓存的可用容量已过期），或 provider 暂时不可达
+func IsRetryable(err error) bool {
+	return errors.Is(err, ErrNoCapacity) || status.Code(err) == codes.Unavailable
+}
+
 func (p *Provider) Deploy(ctx context.Context, id, image string, resourceRequest *types.Info) error {
 	if p.client == nil {
 		return fmt.Errorf("provider not connected")
@@ -423,8 +652,13 @@ func (p *Provider) Deploy(ctx context.Context, id, image string, resourceRequest
 		return fmt.Errorf("provider not connected, please call Connect first")
 	}
 	zmqAddr := net.JoinHostPort(p.envVariables.IarnetHost, strconv.Itoa(p.envVariables.ZMQPort))
-	storeAddr := net.JoinHostPort(p.envVariables.IarnetHost, strconv.Itoa(p.envVariables.StorePort))
+	storeAddr := p.envVariables.StoreAddress()
 	loggerAddr := net.JoinHostPort(p.envVariables.IarnetHost, strconv.Itoa(p.envVariables.LoggerPort))
+	// 上游为本节点时由本节点签发令牌；委托部署的上游为委托方，使用委托方签发的令牌
+	var upstreamToken string
+	if p.envVariables.UpstreamTokens != nil {
+		upstreamToken = p.envVariables.UpstreamTokens.Issue(id, accounting.GetApplication(ctx))
+	}
 	if override, ok := GetDeploymentEnvOverride(ctx); ok && override != nil {
 		if override.ZMQAddress != "" {
 			zmqAddr = override.ZMQAddress
@@ -435,6 +669,7 @@ func (p *Provider) Deploy(ctx context.Context, id, image string, resourceRequest
 		if override.LoggerAddress != "" {
 			loggerAddr = override.LoggerAddress
 		}
+		upstreamToken = override.Token
 	}
 
 	req := &providerpb.DeployRequest{
@@ -444,6 +679,7 @@ func (p *Provider) Deploy(ctx context.Context, id, image string, resourceRequest
 			Cpu:    resourceRequest.CPU,
 			Memory: resourceRequest.Memory,
 			Gpu:    resourceRequest.GPU,
+			Stream: resourceRequest.Stream,
 		},
 		EnvVars: map[string]string{
 			"COMPONENT_ID": id,
@@ -453,10 +689,84 @@ func (p *Provider) Deploy(ctx context.Context, id, image string, resourceRequest
 		},
 		ProviderId: p.id, // 必须传递 provider_id
 	}
+	if upstreamToken != "" {
+		req.EnvVars[identity.UpstreamTokenEnv] = upstreamToken
+	}
+	// 绑定数据流无法降级：接入组件离开数据流所在的 provider 就无法读取数据
+	if resourceRequest.Stream != "" {
+		stream := p.GetStream(resourceRequest.Stream)
+		if stream == nil {
+			return fmt.Errorf("%w: %s on provider %s", ErrStreamNotFound, resourceRequest.Stream, p.id)
+		}
+		setStreamEnv(req.EnvVars, stream)
+	}
+	if env, ok := GetDeploymentEnv(ctx); ok {
+		for key, value := range env {
+			if IsReservedEnvKey(key) {
+				logrus.Warnf("Ignoring deployment env %s for component %s: reserved by provider", key, id)
+				continue
+			}
+			req.EnvVars[key] = value
+		}
+	}
+	if policy, ok := GetEgressPolicy(ctx); ok && policy != nil {
+		if !p.SupportsCapability(common.CapEgressPolicy) {
+			logrus.Warnf("Provider %s does not enforce egress policies, component %s will run without egress restrictions", p.id, id)
+		}
+		req.EgressPolicy = policy.toProto(zmqAddr, storeAddr, loggerAddr)
+	}
+	if archs, ok := GetImageArchitectures(ctx); ok {
+		req.Architectures = archs
+	}
+	// 数据预置无法降级：缺少数据时 component 无法正常运行
+	if sources, ok := GetDataSources(ctx); ok {
+		if err := p.requireCapability(common.CapDataStaging); err != nil {
+			return err
+		}
+		req.DataSources = DataSourcesToProto(sources)
+		req.DataStoreAddress = storeAddr
+	}
+	// 安全配置无法降级：不执行时 component 会以比请求更宽松的权限运行
+	if sc, ok := GetSecurityContext(ctx); ok {
+		if err := p.requireCapability(common.CapSecurity); err != nil {
+			return err
+		}
+		req.SecurityContext = sc.ToProto()
+	}
+	if mounts, ok := GetVolumes(ctx); ok {
+		if err := p.requireCapability(common.CapVolumes); err != nil {
+			return err
+		}
+		req.Volumes = VolumeMountsToProto(mounts)
+	}
+	// sidecar 无法降级：调度时已按合计资源选中该 provider，且 component 依赖 sidecar 运行
+	// 资源请求为合计值，provider 侧的主容器只分得扣除 sidecar 后的部分
+	if sidecars, ok := GetSidecars(ctx); ok {
+		if err := p.requireCapability(common.CapSidecars); err != nil {
+			return err
+		}
+		if HasSidecarVolumes(sidecars) {
+			if err := p.requireCapability(common.CapVolumes); err != nil {
+				return err
+			}
+		}
+		req.Sidecars = SidecarsToProto(sidecars)
+		extra := SidecarResources(sidecars)
+		req.ResourceRequest.Cpu = max(req.ResourceRequest.Cpu-extra.CPU, 0)
+		req.ResourceRequest.Memory = max(req.ResourceRequest.Memory-extra.Memory, 0)
+		req.ResourceRequest.Gpu = max(req.ResourceRequest.Gpu-extra.GPU, 0)
+	}
 	resp, err := p.client.Deploy(ctx, req)
 	if err != nil {
 		return fmt.Errorf("failed to deploy component: %w", err)
 	}
+	if resp.NoCapacity {
+		// 缓存的可用容量已过期，立即刷新，避免后续调度继续选中该 provider
+		if err := p.refreshCapacityCache(ctx); err != nil {
+			logrus.Warnf("Failed to refresh capacity cache after rejected deployment for provider %s: %v", p.id, err)
+		}
+		return fmt.Errorf("%w: %s", ErrNoCapacity, resp.Error)
+	}
 	if resp.Error != "" {
 		return fmt.Errorf("failed to deploy component: %s", resp.Error)
 	}
@@ -470,6 +780,73 @@ func (p *Provider) Deploy(ctx context.Context, id, image string, resourceRequest
 	return nil
 }
 
+// Undeploy 停止并删除 provider 上的 component 实例
+func (p *Provider) Undeploy(ctx context.Context, id string) error {
+	if p.client == nil {
+		return fmt.Errorf("provider not connected")
+	}
+	if p.id == "" {
+		return fmt.Errorf("provider not connected, please call Connect first")
+	}
+
+	if err := p.requireCapability(common.CapUndeploy); err != nil {
+		return err
+	}
+
+	resp, err := p.client.Undeploy(ctx, &providerpb.UndeployRequest{
+		ProviderId: p.id,
+		InstanceId: id,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to undeploy component: %w", err)
+	}
+	if resp.Error != "" {
+		return fmt.Errorf("failed to undeploy component: %s", resp.Error)
+	}
+
+	if err := p.refreshCapacityCache(ctx); err != nil {
+		logrus.Warnf("Failed to refresh capacity cache after undeployment for provider %s: %v", p.id, err)
+	}
+	return nil
+}
+
+// Benchmark 请求 provider 运行微基准测试，结果保存为 provider 的元数据
+func (p *Provider) Benchmark(ctx context.Context) (*types.BenchmarkResult, error) {
+	if p.client == nil {
+		return nil, fmt.Errorf("provider not connected")
+	}
+
+	if err := p.requireCapability(common.CapBenchmark); err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Benchmark(ctx, &providerpb.BenchmarkRequest{
+		ProviderId: p.id,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to run benchmark: %w", err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("failed to run benchmark: %s", resp.Error)
+	}
+	if resp.Result == nil {
+		return nil, fmt.Errorf("failed to run benchmark: empty result")
+	}
+
+	bench := &types.BenchmarkResult{
+		CPUScore:            resp.Result.CpuScore,
+		MemoryBandwidthMBps: resp.Result.MemoryBandwidthMbps,
+		DiskWriteMBps:       resp.Result.DiskWriteMbps,
+		DiskReadMBps:        resp.Result.DiskReadMbps,
+		GPUPresent:          resp.Result.GpuPresent,
+		GPUCount:            int(resp.Result.GpuCount),
+		DurationMs:          resp.Result.DurationMs,
+		MeasuredAt:          time.Now(),
+	}
+	p.SetBenchmark(bench)
+	return p.GetBenchmark(), nil
+}
+
 // GetRealTimeUsage 获取实时资源使用情况
 func (p *Provider) GetRealTimeUsage(ctx context.Context) (*types.Info, error) {
 	if p.client == nil {
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/9triver/iarnet/internal/proto/common"
+)
+
+// SeccompUnconfined / AppArmorUnconfined 关闭对应的安全配置
+const (
+	SeccompUnconfined  = "unconfined"
+	AppArmorUnconfined = "unconfined"
+)
+
+// SecurityContext 部署请求携带的容器安全配置
+// 未携带时由 provider 使用其默认（受限）配置；携带时整体替换 provider 的默认配置
+type SecurityContext struct {
+	ReadOnlyRootFS           bool // 根文件系统只读，/tmp 仍可写
+	AllowPrivilegeEscalation bool // 为 false 时设置 no-new-privileges
+	DropCapabilities         []string
+	AddCapabilities          []string
+	SeccompProfile           string // 空为运行时默认，unconfined 或 provider 上的 profile 名称
+	AppArmorProfile          string // 空为运行时默认，unconfined 或宿主机已加载的 profile 名称
+}
+
+var (
+	capabilityPattern      = regexp.MustCompile(`^(CAP_)?[A-Z_]+$`)
+	securityProfilePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+)
+
+// Validate 校验 capability 与 profile 名称
+func (sc *SecurityContext) Validate() error {
+	for _, c := range append(append([]string(nil), sc.DropCapabilities...), sc.AddCapabilities...) {
+		if !capabilityPattern.MatchString(strings.ToUpper(c)) {
+			return fmt.Errorf("invalid capability %q", c)
+		}
+	}
+	if sc.SeccompProfile != "" && !securityProfilePattern.MatchString(sc.SeccompProfile) {
+		return fmt.Errorf("invalid seccomp profile %q", sc.SeccompProfile)
+	}
+	if sc.AppArmorProfile != "" && !securityProfilePattern.MatchString(sc.AppArmorProfile) {
+		return fmt.Errorf("invalid apparmor profile %q", sc.AppArmorProfile)
+	}
+	return nil
+}
+
+// SecurityContextFromProto 从 proto 消息转换，未设置时返回 nil
+func SecurityContextFromProto(pb *common.SecurityContext) *SecurityContext {
+	if pb == nil {
+		return nil
+	}
+	return &SecurityContext{
+		ReadOnlyRootFS:           pb.GetReadOnlyRootFS(),
+		AllowPrivilegeEscalation: pb.GetAllowPrivilegeEscalation(),
+		DropCapabilities:         pb.GetDropCapabilities(),
+		AddCapabilities:          pb.GetAddCapabilities(),
+		SeccompProfile:           pb.GetSeccompProfile(),
+		AppArmorProfile:          pb.GetAppArmorProfile(),
+	}
+}
+
+// ToProto 转换为 proto 消息
+func (sc *SecurityContext) ToProto() *common.SecurityContext {
+	if sc == nil {
+		return nil
+	}
+	return &common.SecurityContext{
+		ReadOnlyRootFS:           sc.ReadOnlyRootFS,
+		AllowPrivilegeEscalation: sc.AllowPrivilegeEscalation,
+		DropCapabilities:         sc.DropCapabilities,
+		AddCapabilities:          sc.AddCapabilities,
+		SeccompProfile:           sc.SeccompProfile,
+		AppArmorProfile:          sc.AppArmorProfile,
+	}
+}
+
+type securityContextCtxKey struct{}
+
+// WithSecurityContext 在 context 中附加容器安全配置
+// 附加后只会选择能够执行安全配置的 provider
+func WithSecurityContext(ctx context.Context, sc *SecurityContext) context.Context {
+	if sc == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, securityContextCtxKey{}, sc)
+}
+
+// GetSecurityContext 从 context 获取容器安全配置
+func GetSecurityContext(ctx context.Context) (*SecurityContext, bool) {
+	sc, ok := ctx.Value(securityContextCtxKey{}).(*SecurityContext)
+	return sc, ok && sc != nil
+}
@@ -2,12 +2,16 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/9triver/iarnet/internal/domain/resource/decision"
 	"github.com/9triver/iarnet/internal/domain/resource/types"
 	providerrepo "github.com/9triver/iarnet/internal/infra/repository/resource"
+	"github.com/9triver/iarnet/internal/proto/common"
 	"github.com/sirupsen/logrus"
 )
 
@@ -18,9 +22,16 @@ type Service interface {
 	LoadProviders(ctx context.Context) error
 
 	// RegisterProvider 注册 Provider 并建立连接
-	RegisterProvider(ctx context.Context, name string, host string, port int) (*Provider, error)
+	// capacityClass 为空时视为 guaranteed
+	RegisterProvider(ctx context.Context, name string, host string, port int, capacityClass types.CapacityClass) (*Provider, error)
 
-	// UnregisterProvider 注销 Provider 并断开连接
+	// SyncStaticProviders 按配置同步 repository 中由配置管理的 provider
+	SyncStaticProviders(ctx context.Context, providers []StaticProvider) error
+
+	// RegisterStaticProvider 连接配置管理的 provider 并加入 manager
+	RegisterStaticProvider(ctx context.Context, sp StaticProvider) (*Provider, error)
+
+	// UnregisterProvider 注销 Provider 并断开连接，配置管理的 provider 返回 ErrStaticProvider
 	UnregisterProvider(ctx context.Context, id string) error
 
 	// FindAvailableProvider 查找满足资源要求的可用 Provider
@@ -31,12 +42,45 @@ type Service interface {
 
 	// GetAllProviders 获取所有 Provider
 	GetAllProviders() []*Provider
+
+	// SetCapacityCacheTTL 设置所有 provider 资源容量缓存的最大陈旧时间
+	SetCapacityCacheTTL(ttl time.Duration)
+
+	// SetBenchmarkOnRegister 设置注册 provider 后是否运行微基准测试及其超时
+	SetBenchmarkOnRegister(enabled bool, timeout time.Duration)
+
+	// BenchmarkProvider 对指定 provider 运行微基准测试，并持久化结果
+	BenchmarkProvider(ctx context.Context, id string) (*types.BenchmarkResult, error)
+
+	// SetNodeLabels 设置本节点标签，策略链据此过滤节点标签约束不满足的请求
+	SetNodeLabels(labels map[string]string)
+
+	// SetPolicyWebhook 设置外部策略 webhook，nil 表示不使用
+	SetPolicyWebhook(policy *WebhookPolicy)
+
+	// SetTimeWindowRules 设置时间窗口规则，生效中的规则禁止向其选中的 provider 部署受限任务
+	SetTimeWindowRules(rules []TimeWindowRule)
+
+	// SetPlacementHistory 设置历史放置统计，策略链据此偏向历史上表现好的 provider，nil 表示不使用
+	SetPlacementHistory(history *PlacementHistory)
+
+	// RecordPlacement 记录一次部署到 provider 的结果与耗时
+	RecordPlacement(resourceRequest *types.Info, providerID string, success bool, latency time.Duration)
 }
 
+// DefaultBenchmarkTimeout 微基准测试的默认超时
+const DefaultBenchmarkTimeout = 30 * time.Second
+
 type service struct {
 	manager      *Manager
 	repo         providerrepo.ProviderRepo
 	envVariables *EnvVariables
+	policies     PolicyChain
+	cacheTTL     time.Duration
+	history      *PlacementHistory
+
+	benchmarkOnRegister bool
+	benchmarkTimeout    time.Duration
 }
 
 // NewService 创建 Provider 服务
@@ -45,6 +89,10 @@ func NewService(manager *Manager, repo providerrepo.ProviderRepo, envVariables *
 		manager:      manager,
 		repo:         repo,
 		envVariables: envVariables,
+		policies:     DefaultPolicyChain(),
+		cacheTTL:     DefaultCapacityCacheTTL,
+
+		benchmarkTimeout: DefaultBenchmarkTimeout,
 	}
 	return s
 }
@@ -65,7 +113,25 @@ func (s *service) LoadProviders(ctx context.Context) error {
 	logrus.Infof("Loading %d providers from repository", len(daos))
 
 	for _, dao := range daos {
+		// 配置管理的 provider 按配置注册（需要出示配置中的令牌）
+		if dao.Static {
+			continue
+		}
 		provider := NewProviderWithID(dao.ID, dao.Name, dao.Host, dao.Port, s.envVariables)
+		provider.SetCapacityCacheTTL(s.cacheTTL)
+		if class, err := types.ParseCapacityClass(dao.CapacityClass); err == nil {
+			provider.SetCapacityClass(class)
+		} else {
+			logrus.Warnf("Provider %s has invalid capacity class %q, falling back to guaranteed", dao.ID, dao.CapacityClass)
+		}
+		if dao.Benchmark != "" {
+			var bench types.BenchmarkResult
+			if err := json.Unmarshal([]byte(dao.Benchmark), &bench); err == nil {
+				provider.SetBenchmark(&bench)
+			} else {
+				logrus.Warnf("Provider %s has invalid benchmark result, ignoring: %v", dao.ID, err)
+			}
+		}
 		if err := provider.Connect(ctx); err != nil {
 			logrus.Warnf("Failed to connect to provider %s: %v", dao.ID, err)
 			continue
@@ -91,19 +157,27 @@ func (s *service) LoadProviders(ctx context.Context) error {
 }
 
 // RegisterProvider 注册 Provider 并建立连接
-func (s *service) RegisterProvider(ctx context.Context, name string, host string, port int) (*Provider, error) {
+func (s *service) RegisterProvider(ctx context.Context, name string, host string, port int, capacityClass types.CapacityClass) (*Provider, error) {
+	class, err := types.ParseCapacityClass(string(capacityClass))
+	if err != nil {
+		return nil, err
+	}
+
 	// 创建 provider 实例
 	provider := NewProvider(name, host, port, s.envVariables)
+	provider.SetCapacityClass(class)
+	provider.SetCapacityCacheTTL(s.cacheTTL)
 
 	// 持久化到数据库
 	if s.repo != nil {
 		dao := &providerrepo.ProviderDAO{
-			ID:        provider.GetID(),
-			Name:      provider.GetName(),
-			Host:      provider.GetHost(),
-			Port:      provider.GetPort(),
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
+			ID:            provider.GetID(),
+			Name:          provider.GetName(),
+			Host:          provider.GetHost(),
+			Port:          provider.GetPort(),
+			CapacityClass: string(provider.GetCapacityClass()),
+			CreatedAt:     time.Now(),
+			UpdatedAt:     time.Now(),
 		}
 		if err := s.repo.Create(ctx, dao); err != nil {
 			logrus.Warnf("Failed to persist provider %s to database: %v", provider.GetID(), err)
@@ -131,7 +205,16 @@ func (s *service) RegisterProvider(ctx context.Context, name string, host string
 		}
 	}
 
-	logrus.Infof("Provider %s registered and connected", provider.GetID())
+	// 微基准测试耗时数秒，在后台运行，不阻塞注册；旧版 provider 不支持时跳过
+	if s.benchmarkOnRegister && provider.SupportsCapability(common.CapBenchmark) {
+		go func() {
+			if _, err := s.BenchmarkProvider(context.Background(), provider.GetID()); err != nil {
+				logrus.Warnf("Benchmark for provider %s failed: %v", provider.GetID(), err)
+			}
+		}()
+	}
+
+	logrus.Infof("Provider %s registered and connected (capacity class: %s)", provider.GetID(), provider.GetCapacityClass())
 	return provider, nil
 }
 
@@ -146,6 +229,9 @@ func (s *service) UnregisterProvider(ctx context.Context, id string) error {
 	if provider == nil {
 		return fmt.Errorf("provider %s not found", id)
 	}
+	if provider.IsStatic() {
+		return fmt.Errorf("%w: %s", ErrStaticProvider, id)
+	}
 
 	// 断开连接（如果已连接）
 	if provider.GetStatus() == types.ProviderStatusConnected {
@@ -169,75 +255,163 @@ func (s *service) UnregisterProvider(ctx context.Context, id string) error {
 }
 
 // FindAvailableProvider 查找满足资源要求的可用 Provider
-// 优先使用缓存数据，如果找不到合适的 provider，会尝试强制刷新后重试
+// 优先使用未超过陈旧时间的缓存数据，仅对缓存缺失或陈旧的 provider 发起刷新
+// 候选 provider 的顺序由策略链决定；context 中附加了假设放置账本时，可用资源扣除账本中已假设放置的部分
 func (s *service) FindAvailableProvider(ctx context.Context, resourceRequest *types.Info) (*Provider, error) {
 	if resourceRequest == nil {
 		return nil, fmt.Errorf("resource request is required")
 	}
 
-	// 获取所有已连接的 Provider
-	connectedProviders := s.manager.GetByStatus(types.ProviderStatusConnected)
-
-	// 第一轮：使用缓存数据查找
-	for _, provider := range connectedProviders {
+	// 获取所有已连接的 Provider，并按策略链排序
+	// context 中指定了优先 provider（e.g., 会话亲和）时，该 provider 最先被考察
+	defer decision.Since(ctx, decision.StageLocalSchedule, time.Now())
+	policyStart := time.Now()
+	connectedProviders := s.policies.Apply(resourceRequest, s.manager.GetByStatus(types.ProviderStatusConnected))
+	decision.Since(ctx, decision.StagePolicy, policyStart)
+	connectedProviders = preferProvider(ctx, connectedProviders)
+	archs, _ := GetImageArchitectures(ctx)
+	_, staging := GetDataSources(ctx)
+	mounts, _ := GetVolumes(ctx)
+	_, hardening := GetSecurityContext(ctx)
+	sidecarList, sidecars := GetSidecars(ctx)
+	// sidecar 挂载的卷与主容器的卷一样要求 provider 支持卷挂载
+	for _, sc := range sidecarList {
+		mounts = slices.Concat(mounts, sc.Volumes)
+	}
+	mounting := len(mounts) > 0
+	full, _ := GetFullProviders(ctx)
+	// 命名卷已存在于某些 provider 上时，只能部署到这些 provider；都不存在时由选中的 provider 创建
+	holders := volumeHolders(ctx, connectedProviders, namedVolumes(mounts))
+	ledger, planning := GetPlanLedger(ctx)
+
+	// 第一轮：只使用未超过陈旧时间的缓存数据，不发起网络请求
+	// 考察过的候选会记录到 context 中的调度决策 trace（如有）
+	var stale []int
+	for rank, provider := range connectedProviders {
 		// 检查 Provider 状态
 		if provider.GetStatus() != types.ProviderStatusConnected {
 			logrus.Debugf("Skipping provider %s: status is not connected", provider.GetID())
+			considerProvider(ctx, rank, provider, nil, "not connected")
+			continue
+		}
+
+		if _, ok := full[provider.GetID()]; ok {
+			logrus.Debugf("Provider %s has reached its component limit", provider.GetID())
+			considerProvider(ctx, rank, provider, nil, "component limit reached")
 			continue
 		}
 
 		if !providerHasRequiredTags(provider.GetResourceTags(), resourceRequest.Tags) {
 			logrus.Debugf("Provider %s does not satisfy required tags", provider.GetID())
+			considerProvider(ctx, rank, provider, nil, "missing required tags")
 			continue
 		}
 
-		// 获取可用资源（优先使用缓存）
-		available, err := provider.GetAvailable(ctx)
-		if err != nil {
-			logrus.Warnf("Failed to get available resources from provider %s: %v", provider.GetID(), err)
+		if resourceRequest.Stream != "" && provider.GetStream(resourceRequest.Stream) == nil {
+			logrus.Debugf("Provider %s does not own stream %s", provider.GetID(), resourceRequest.Stream)
+			considerProvider(ctx, rank, provider, nil, "stream not owned")
 			continue
 		}
-		logrus.Debugf("Available resources from provider %s (cached): %v", provider.GetID(), available)
 
-		// 检查是否满足资源要求
-		if !satisfiesResourceRequest(available, resourceRequest) {
-			logrus.Debugf("Provider %s does not have sufficient resources (cached data)", provider.GetID())
+		if !types.ArchitectureCompatible(provider.GetArchitectures(), archs) {
+			logrus.Debugf("Provider %s architectures %v do not match image architectures %v", provider.GetID(), provider.GetArchitectures(), archs)
+			considerProvider(ctx, rank, provider, nil, "unsupported architecture")
 			continue
 		}
 
-		return provider, nil
-	}
+		if staging && !provider.SupportsCapability(common.CapDataStaging) {
+			logrus.Debugf("Provider %s does not support data staging", provider.GetID())
+			considerProvider(ctx, rank, provider, nil, "data staging unsupported")
+			continue
+		}
 
-	// 第二轮：如果第一轮没找到，强制刷新后重试
-	logrus.Debugf("No provider found with cached data, trying with fresh data...")
-	for _, provider := range connectedProviders {
-		if provider.GetStatus() != types.ProviderStatusConnected {
+		if hardening && !provider.SupportsCapability(common.CapSecurity) {
+			logrus.Debugf("Provider %s does not enforce security contexts", provider.GetID())
+			considerProvider(ctx, rank, provider, nil, "security context unsupported")
 			continue
 		}
 
-		if !providerHasRequiredTags(provider.GetResourceTags(), resourceRequest.Tags) {
-			logrus.Debugf("Provider %s does not satisfy required tags (fresh)", provider.GetID())
+		if sidecars && !provider.SupportsCapability(common.CapSidecars) {
+			logrus.Debugf("Provider %s does not support sidecars", provider.GetID())
+			considerProvider(ctx, rank, provider, nil, "sidecars unsupported")
+			continue
+		}
+
+		if mounting && !provider.SupportsCapability(common.CapVolumes) {
+			logrus.Debugf("Provider %s does not support volumes", provider.GetID())
+			considerProvider(ctx, rank, provider, nil, "volumes unsupported")
+			continue
+		}
+
+		if _, ok := holders[provider.GetID()]; len(holders) > 0 && !ok {
+			logrus.Debugf("Provider %s does not hold the requested volumes", provider.GetID())
+			considerProvider(ctx, rank, provider, nil, "volume on another provider")
+			continue
+		}
+
+		available, ok := provider.GetCachedAvailable()
+		if !ok {
+			stale = append(stale, rank)
+			continue
+		}
+		if planning {
+			available = ledger.Remaining(provider.GetID(), available)
+		}
+		logrus.Debugf("Available resources from provider %s (cached): %v", provider.GetID(), available)
+
+		// 检查是否满足资源要求
+		if !satisfiesResourceRequest(available, resourceRequest) {
+			logrus.Debugf("Provider %s does not have sufficient resources (cached data)", provider.GetID())
+			considerProvider(ctx, rank, provider, available, "insufficient resources (cached)")
 			continue
 		}
 
-		// 强制刷新并获取可用资源
+		considerProvider(ctx, rank, provider, available, "")
+		return provider, nil
+	}
+
+	// 第二轮：缓存缺失或已陈旧的 provider 刷新后重试，缓存新鲜的 provider 不再重复查询
+	if len(stale) > 0 {
+		logrus.Debugf("No provider found with cached data, refreshing %d stale provider(s)...", len(stale))
+	}
+	for _, rank := range stale {
+		provider := connectedProviders[rank]
 		available, err := provider.GetAvailable(ctx, true) // forceRefresh = true
 		if err != nil {
 			logrus.Warnf("Failed to get available resources from provider %s (fresh): %v", provider.GetID(), err)
+			considerProvider(ctx, rank, provider, nil, err.Error())
 			continue
 		}
 		logrus.Debugf("Available resources from provider %s (fresh): %v", provider.GetID(), available)
+		if planning {
+			available = ledger.Remaining(provider.GetID(), available)
+		}
 
 		// 检查是否满足资源要求
 		if !satisfiesResourceRequest(available, resourceRequest) {
 			logrus.Debugf("Provider %s does not have sufficient resources (fresh data)", provider.GetID())
+			considerProvider(ctx, rank, provider, available, "insufficient resources")
 			continue
 		}
 
+		considerProvider(ctx, rank, provider, available, "")
 		return provider, nil
 	}
 
-	return nil, fmt.Errorf("no available provider found that satisfies the resource requirements")
+	return nil, ErrNoAvailableProvider
+}
+
+// considerProvider 将考察过的 provider 记录到调度决策 trace，reason 为空表示选中
+func considerProvider(ctx context.Context, rank int, provider *Provider, available *types.Info, reason string) {
+	decision.Consider(ctx, decision.Candidate{
+		Kind:      decision.CandidateProvider,
+		ID:        provider.GetID(),
+		Name:      provider.GetName(),
+		Rank:      rank,
+		Available: available,
+		Accepted:  reason == "",
+		Reason:    reason,
+	})
 }
 
 // GetProvider 获取指定 ID 的 Provider
@@ -250,6 +424,117 @@ func (s *service) GetAllProviders() []*Provider {
 	return s.manager.GetAll()
 }
 
+// SetBenchmarkOnRegister 设置注册 provider 后是否运行微基准测试，timeout <= 0 时使用默认超时
+func (s *service) SetBenchmarkOnRegister(enabled bool, timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = DefaultBenchmarkTimeout
+	}
+	s.benchmarkOnRegister = enabled
+	s.benchmarkTimeout = timeout
+}
+
+// SetNodeLabels 设置策略链中节点标签策略使用的本节点标签
+func (s *service) SetNodeLabels(labels map[string]string) {
+	copied := make(map[string]string, len(labels))
+	for k, v := range labels {
+		copied[k] = v
+	}
+	for _, policy := range s.policies {
+		if p, ok := policy.(*NodeSelectorPolicy); ok {
+			p.NodeLabels = copied
+		}
+	}
+}
+
+// SetTimeWindowRules 设置策略链中时间窗口策略使用的规则
+func (s *service) SetTimeWindowRules(rules []TimeWindowRule) {
+	copied := append([]TimeWindowRule(nil), rules...)
+	for _, policy := range s.policies {
+		if p, ok := policy.(*TimeWindowPolicy); ok {
+			p.Rules = copied
+		}
+	}
+}
+
+// SetPlacementHistory 设置策略链中历史放置策略使用的统计
+func (s *service) SetPlacementHistory(history *PlacementHistory) {
+	s.history = history
+	for _, policy := range s.policies {
+		if p, ok := policy.(*HistoryPolicy); ok {
+			p.History = history
+		}
+	}
+}
+
+// RecordPlacement 记录一次部署到 provider 的结果与耗时，未设置历史放置统计时不做任何事
+func (s *service) RecordPlacement(resourceRequest *types.Info, providerID string, success bool, latency time.Duration) {
+	if resourceRequest == nil {
+		return
+	}
+	s.history.Record(ClassifyTask(resourceRequest), providerID, success, latency)
+}
+
+// SetPolicyWebhook 设置外部策略 webhook
+// webhook 紧随节点标签策略之后，优先级高于内置的排序策略，其返回的顺序即最终候选顺序
+func (s *service) SetPolicyWebhook(policy *WebhookPolicy) {
+	chain := make(PolicyChain, 0, len(s.policies)+1)
+	for _, existing := range s.policies {
+		if _, ok := existing.(*WebhookPolicy); ok {
+			continue
+		}
+		chain = append(chain, existing)
+		if _, ok := existing.(*NodeSelectorPolicy); ok && policy != nil {
+			chain = append(chain, policy)
+		}
+	}
+	s.policies = chain
+}
+
+// BenchmarkProvider 对指定 provider 运行微基准测试，结果保存为 provider 元数据并持久化
+// 不支持 Benchmark RPC 的 provider 返回错误，其已有结果保持不变
+func (s *service) BenchmarkProvider(ctx context.Context, id string) (*types.BenchmarkResult, error) {
+	provider := s.manager.Get(id)
+	if provider == nil {
+		return nil, fmt.Errorf("provider %s not found", id)
+	}
+
+	benchCtx, cancel := context.WithTimeout(ctx, s.benchmarkTimeout)
+	defer cancel()
+	bench, err := provider.Benchmark(benchCtx)
+	if err != nil {
+		return nil, err
+	}
+	logrus.Infof("Provider %s benchmark: cpu=%.0f MiB/s, memory=%.0f MiB/s, disk write=%.0f MiB/s, read=%.0f MiB/s, gpus=%d",
+		id, bench.CPUScore, bench.MemoryBandwidthMBps, bench.DiskWriteMBps, bench.DiskReadMBps, bench.GPUCount)
+
+	if s.repo != nil {
+		data, err := json.Marshal(bench)
+		if err != nil {
+			return bench, nil
+		}
+		dao := &providerrepo.ProviderDAO{
+			ID:            provider.GetID(),
+			Name:          provider.GetName(),
+			Host:          provider.GetHost(),
+			Port:          provider.GetPort(),
+			CapacityClass: string(provider.GetCapacityClass()),
+			Benchmark:     string(data),
+		}
+		if err := s.repo.Update(ctx, dao); err != nil {
+			logrus.Warnf("Failed to persist benchmark of provider %s: %v", id, err)
+		}
+	}
+	return bench, nil
+}
+
+// SetCapacityCacheTTL 设置资源容量缓存的最大陈旧时间，对已注册和之后注册的 provider 均生效
+func (s *service) SetCapacityCacheTTL(ttl time.Duration) {
+	s.cacheTTL = ttl
+	for _, p := range s.manager.GetAll() {
+		p.SetCapacityCacheTTL(ttl)
+	}
+}
+
 // satisfiesResourceRequest 检查可用资源是否满足资源请求
 func satisfiesResourceRequest(available *types.Info, request *types.Info) bool {
 	if available == nil || request == nil {
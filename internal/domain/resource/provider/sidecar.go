@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+	"github.com/9triver/iarnet/internal/proto/common"
+)
+
+// MaxSidecars 单个 component 允许的 sidecar 数量上限
+const MaxSidecars = 8
+
+// Sidecar 与 component 主容器部署到同一 provider 的辅助容器（e.g., 指标导出、数据拉取）
+// sidecar 与主容器共享网络，资源与主容器合并核算：调度路径上的资源请求为主容器与所有 sidecar 的合计
+type Sidecar struct {
+	Name    string // component 内唯一，e.g., "exporter"
+	Image   string
+	CPU     int64 // millicores
+	Memory  int64 // bytes
+	GPU     int64
+	Env     map[string]string
+	Command []string      // 非空时覆盖镜像的 entrypoint
+	Volumes []VolumeMount // 只挂载到 sidecar，需 provider 支持卷挂载
+}
+
+// HasSidecarVolumes 是否有 sidecar 挂载了卷
+func HasSidecarVolumes(sidecars []Sidecar) bool {
+	for _, sc := range sidecars {
+		if len(sc.Volumes) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// sidecarNamePattern 同时满足 Kubernetes 容器名与 Docker 容器名后缀的规则；main 为主容器保留
+var sidecarNamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,30}[a-z0-9])?$`)
+
+// ValidateSidecars 校验 sidecar：名称合法且互不重复、镜像非空、资源非负、卷挂载合法
+func ValidateSidecars(sidecars []Sidecar) error {
+	if len(sidecars) > MaxSidecars {
+		return fmt.Errorf("at most %d sidecars are allowed, got %d", MaxSidecars, len(sidecars))
+	}
+	seen := make(map[string]struct{}, len(sidecars))
+	for i, sc := range sidecars {
+		if !sidecarNamePattern.MatchString(sc.Name) || sc.Name == "main" {
+			return fmt.Errorf("sidecar %d: invalid name %q: must match %s and not be \"main\"", i, sc.Name, sidecarNamePattern.String())
+		}
+		if _, ok := seen[sc.Name]; ok {
+			return fmt.Errorf("sidecar %d: duplicate name %q", i, sc.Name)
+		}
+		seen[sc.Name] = struct{}{}
+		if sc.Image == "" {
+			return fmt.Errorf("sidecar %s: image is required", sc.Name)
+		}
+		if sc.CPU < 0 || sc.Memory < 0 || sc.GPU < 0 {
+			return fmt.Errorf("sidecar %s: resources must not be negative", sc.Name)
+		}
+		for key := range sc.Env {
+			if IsReservedEnvKey(key) {
+				return fmt.Errorf("sidecar %s: env %s is reserved by the provider", sc.Name, key)
+			}
+		}
+		if err := ValidateVolumeMounts(sc.Volumes); err != nil {
+			return fmt.Errorf("sidecar %s: %w", sc.Name, err)
+		}
+	}
+	return nil
+}
+
+// SidecarResources sidecar 的资源合计
+func SidecarResources(sidecars []Sidecar) types.Info {
+	var total types.Info
+	for _, sc := range sidecars {
+		total.CPU += sc.CPU
+		total.Memory += sc.Memory
+		total.GPU += sc.GPU
+	}
+	return total
+}
+
+// SidecarsFromProto 从 proto 消息转换
+func SidecarsFromProto(pbs []*common.Sidecar) []Sidecar {
+	if len(pbs) == 0 {
+		return nil
+	}
+	sidecars := make([]Sidecar, 0, len(pbs))
+	for _, pb := range pbs {
+		sidecars = append(sidecars, Sidecar{
+			Name:    pb.GetName(),
+			Image:   pb.GetImage(),
+			CPU:     pb.GetCPU(),
+			Memory:  pb.GetMemory(),
+			GPU:     pb.GetGPU(),
+			Env:     pb.GetEnv(),
+			Command: pb.GetCommand(),
+			Volumes: VolumeMountsFromProto(pb.GetVolumes()),
+		})
+	}
+	return sidecars
+}
+
+// SidecarsToProto 转换为 proto 消息
+func SidecarsToProto(sidecars []Sidecar) []*common.Sidecar {
+	if len(sidecars) == 0 {
+		return nil
+	}
+	pbs := make([]*common.Sidecar, 0, len(sidecars))
+	for _, sc := range sidecars {
+		pbs = append(pbs, &common.Sidecar{
+			Name:    sc.Name,
+			Image:   sc.Image,
+			CPU:     sc.CPU,
+			Memory:  sc.Memory,
+			GPU:     sc.GPU,
+			Env:     sc.Env,
+			Command: sc.Command,
+			Volumes: VolumeMountsToProto(sc.Volumes),
+		})
+	}
+	return pbs
+}
+
+type sidecarsCtxKey struct{}
+
+// WithSidecars 在 context 中附加 sidecar
+// 附加后只会选择支持 sidecar 的 provider，调用方传入的资源请求需已包含 sidecar 的资源
+func WithSidecars(ctx context.Context, sidecars []Sidecar) context.Context {
+	if len(sidecars) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, sidecarsCtxKey{}, sidecars)
+}
+
+// GetSidecars 从 context 获取 sidecar
+func GetSidecars(ctx context.Context) ([]Sidecar, bool) {
+	sidecars, ok := ctx.Value(sidecarsCtxKey{}).([]Sidecar)
+	return sidecars, ok && len(sidecars) > 0
+}
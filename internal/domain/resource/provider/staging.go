@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/9triver/iarnet/internal/proto/common"
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+)
+
+// DataSource 部署前由 provider 预置到 component 工作目录的数据，URL 与 ObjectID 二选一
+type DataSource struct {
+	URL      string // http(s) 下载地址
+	ObjectID string // 应用 store 中的对象 ID，provider 从部署时的上游 store 拉取
+	Path     string // 工作目录内的相对路径
+	SHA256   string // 十六进制 SHA-256 校验和（可选），不匹配时部署失败
+}
+
+// StagingProgress 单个数据源的预置进度
+type StagingProgress struct {
+	Path       string
+	State      string // pending / downloading / verifying / done / failed
+	BytesDone  int64
+	BytesTotal int64 // 未知时为 0
+	Error      string
+}
+
+// ValidateDataSources 校验数据源：来源二选一、路径为不越出工作目录的相对路径且互不重复
+func ValidateDataSources(sources []DataSource) error {
+	seen := make(map[string]struct{}, len(sources))
+	for i, s := range sources {
+		if (s.URL == "") == (s.ObjectID == "") {
+			return fmt.Errorf("data source %d: exactly one of url and object_id is required", i)
+		}
+		if s.URL != "" {
+			u, err := url.Parse(s.URL)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("data source %d: url must be an http(s) URL", i)
+			}
+		}
+		clean := path.Clean(s.Path)
+		if s.Path == "" || path.IsAbs(clean) || clean == "." || clean == ".." || strings.HasPrefix(clean, "../") {
+			return fmt.Errorf("data source %d: path %q must be a relative path inside the workspace", i, s.Path)
+		}
+		if _, dup := seen[clean]; dup {
+			return fmt.Errorf("data source %d: duplicate path %q", i, s.Path)
+		}
+		seen[clean] = struct{}{}
+		if s.SHA256 != "" {
+			if b, err := hex.DecodeString(s.SHA256); err != nil || len(b) != 32 {
+				return fmt.Errorf("data source %d: sha256 must be 64 hex characters", i)
+			}
+		}
+	}
+	return nil
+}
+
+// DataSourcesFromProto 从 proto 消息转换
+func DataSourcesFromProto(pbs []*common.DataSource) []DataSource {
+	if len(pbs) == 0 {
+		return nil
+	}
+	sources := make([]DataSource, 0, len(pbs))
+	for _, pb := range pbs {
+		sources = append(sources, DataSource{
+			URL:      pb.GetURL(),
+			ObjectID: pb.GetObjectID(),
+			Path:     pb.GetPath(),
+			SHA256:   pb.GetSHA256(),
+		})
+	}
+	return sources
+}
+
+// DataSourcesToProto 转换为 proto 消息
+func DataSourcesToProto(sources []DataSource) []*common.DataSource {
+	if len(sources) == 0 {
+		return nil
+	}
+	pbs := make([]*common.DataSource, 0, len(sources))
+	for _, s := range sources {
+		pbs = append(pbs, &common.DataSource{
+			URL:      s.URL,
+			ObjectID: s.ObjectID,
+			Path:     s.Path,
+			SHA256:   s.SHA256,
+		})
+	}
+	return pbs
+}
+
+type dataSourcesCtxKey struct{}
+
+// WithDataSources 在 context 中附加部署前需要预置的数据
+// 附加后只会选择支持数据预置的 provider
+func WithDataSources(ctx context.Context, sources []DataSource) context.Context {
+	if len(sources) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, dataSourcesCtxKey{}, sources)
+}
+
+// GetDataSources 从 context 获取部署前需要预置的数据
+func GetDataSources(ctx context.Context) ([]DataSource, bool) {
+	sources, ok := ctx.Value(dataSourcesCtxKey{}).([]DataSource)
+	return sources, ok && len(sources) > 0
+}
+
+// GetStagingStatus 查询 component 的数据预置进度
+func (p *Provider) GetStagingStatus(ctx context.Context, instanceID string) ([]StagingProgress, error) {
+	if p.client == nil {
+		return nil, fmt.Errorf("provider not connected")
+	}
+	if err := p.requireCapability(common.CapDataStaging); err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.GetStagingStatus(ctx, &providerpb.GetStagingStatusRequest{
+		ProviderId: p.id,
+		InstanceId: instanceID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get staging status: %w", err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("failed to get staging status: %s", resp.Error)
+	}
+	items := make([]StagingProgress, 0, len(resp.Items))
+	for _, item := range resp.Items {
+		items = append(items, StagingProgress{
+			Path:       item.Path,
+			State:      item.State,
+			BytesDone:  item.BytesDone,
+			BytesTotal: item.BytesTotal,
+			Error:      item.Error,
+		})
+	}
+	return items, nil
+}
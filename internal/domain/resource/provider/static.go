@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+	providerrepo "github.com/9triver/iarnet/internal/infra/repository/resource"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrStaticProvider 配置管理的 provider 只能通过修改配置文件变更
+var ErrStaticProvider = errors.New("provider is managed by static_providers in the config file")
+
+// StaticProvider 配置文件 static_providers 中声明的 provider，节点启动时自动注册
+type StaticProvider struct {
+	Name          string
+	Host          string
+	Port          int
+	Token         string // Connect 时出示的注册令牌
+	CapacityClass types.CapacityClass
+
+	// Dialer 反向连接模式下经隧道建立连接，nil 表示直接连接 Host:Port
+	Dialer func(ctx context.Context, addr string) (net.Conn, error)
+}
+
+// StaticProviderID 配置管理的 provider 使用由名称派生的固定 ID，重启后 component 的放置记录仍然有效
+func StaticProviderID(name string) string {
+	return "provider.static." + name
+}
+
+// SyncStaticProviders 按配置同步 repository：新增或更新配置中的 provider，删除已从配置中移除的 provider
+func (s *service) SyncStaticProviders(ctx context.Context, providers []StaticProvider) error {
+	if s.repo == nil {
+		return nil
+	}
+
+	daos, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load providers from repository: %w", err)
+	}
+	existing := make(map[string]*providerrepo.ProviderDAO, len(daos))
+	for _, dao := range daos {
+		existing[dao.ID] = dao
+	}
+
+	configured := make(map[string]struct{}, len(providers))
+	for _, sp := range providers {
+		id := StaticProviderID(sp.Name)
+		configured[id] = struct{}{}
+
+		class, err := types.ParseCapacityClass(string(sp.CapacityClass))
+		if err != nil {
+			return fmt.Errorf("static provider %s: %w", sp.Name, err)
+		}
+		if dao, ok := existing[id]; ok {
+			dao.Name, dao.Host, dao.Port = sp.Name, sp.Host, sp.Port
+			dao.CapacityClass = string(class)
+			dao.Static = true
+			dao.UpdatedAt = time.Now()
+			if err := s.repo.Update(ctx, dao); err != nil {
+				return fmt.Errorf("failed to update static provider %s: %w", sp.Name, err)
+			}
+			continue
+		}
+		dao := &providerrepo.ProviderDAO{
+			ID:            id,
+			Name:          sp.Name,
+			Host:          sp.Host,
+			Port:          sp.Port,
+			CapacityClass: string(class),
+			Static:        true,
+			CreatedAt:     time.Now(),
+			UpdatedAt:     time.Now(),
+		}
+		if err := s.repo.Create(ctx, dao); err != nil {
+			return fmt.Errorf("failed to persist static provider %s: %w", sp.Name, err)
+		}
+	}
+
+	for _, dao := range daos {
+		if _, ok := configured[dao.ID]; !dao.Static || ok {
+			continue
+		}
+		if err := s.repo.Delete(ctx, dao.ID); err != nil {
+			logrus.Warnf("Failed to delete static provider %s removed from config: %v", dao.ID, err)
+			continue
+		}
+		logrus.Infof("Static provider %s removed from config, deleted from repository", dao.ID)
+	}
+	return nil
+}
+
+// RegisterStaticProvider 连接配置管理的 provider 并加入 manager，已加入时直接返回
+// 连接失败时返回错误，由调用方重试
+func (s *service) RegisterStaticProvider(ctx context.Context, sp StaticProvider) (*Provider, error) {
+	id := StaticProviderID(sp.Name)
+	if p := s.manager.Get(id); p != nil {
+		return p, nil
+	}
+
+	class, err := types.ParseCapacityClass(string(sp.CapacityClass))
+	if err != nil {
+		return nil, err
+	}
+	provider := NewProviderWithID(id, sp.Name, sp.Host, sp.Port, s.envVariables)
+	provider.SetCapacityClass(class)
+	provider.SetCapacityCacheTTL(s.cacheTTL)
+	provider.SetToken(sp.Token)
+	provider.SetStatic(true)
+	if sp.Dialer != nil {
+		provider.SetDialer(sp.Dialer)
+	}
+	if s.repo != nil {
+		if dao, err := s.repo.Get(ctx, id); err == nil && dao.Benchmark != "" {
+			var bench types.BenchmarkResult
+			if err := json.Unmarshal([]byte(dao.Benchmark), &bench); err == nil {
+				provider.SetBenchmark(&bench)
+			}
+		}
+	}
+
+	if err := provider.Connect(ctx); err != nil {
+		return nil, fmt.Errorf("failed to connect to static provider %s: %w", sp.Name, err)
+	}
+	s.manager.Add(provider)
+
+	healthCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := provider.HealthCheck(healthCtx); err != nil {
+		logrus.Warnf("Failed to perform initial health check for static provider %s: %v (will retry in next health check cycle)", id, err)
+	}
+
+	if provider.IsReverse() {
+		logrus.Infof("Static provider %s registered and connected through tunnel", id)
+	} else {
+		logrus.Infof("Static provider %s registered and connected at %s:%d", id, sp.Host, sp.Port)
+	}
+	return provider, nil
+}
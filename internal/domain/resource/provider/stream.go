@@ -0,0 +1,23 @@
+package provider
+
+import (
+	"errors"
+
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+)
+
+// 绑定数据流的 component 部署时注入的环境变量
+const (
+	StreamNameEnv = "IARNET_STREAM_NAME"
+	StreamKindEnv = "IARNET_STREAM_KIND"
+	StreamURLEnv  = "IARNET_STREAM_URL" // RTSP 地址或 V4L2 设备路径，设备由 provider 映射进容器的同一路径
+)
+
+// ErrStreamNotFound provider 不拥有部署请求绑定的数据流
+var ErrStreamNotFound = errors.New("stream not found")
+
+func setStreamEnv(env map[string]string, stream *types.MediaStream) {
+	env[StreamNameEnv] = stream.Name
+	env[StreamKindEnv] = stream.Kind
+	env[StreamURLEnv] = stream.URL
+}
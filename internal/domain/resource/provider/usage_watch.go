@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+	"github.com/9triver/iarnet/internal/proto/common"
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrUsageWatchUnsupported provider 未实现 WatchUsage，调用方应退回到轮询
+var ErrUsageWatchUnsupported = errors.New("provider does not support usage watch")
+
+// UsageSnapshot provider 最近一次推送的资源使用情况与容量
+type UsageSnapshot struct {
+	Usage    *types.Info
+	Capacity *types.Capacity
+}
+
+// WatchUsage 订阅 provider 推送的资源使用情况，直到 ctx 结束或流断开
+// 每条推送合并到缓存后以完整快照回调 onUpdate；推送的容量同时刷新容量缓存
+// provider 未声明或未实现该能力时返回 ErrUsageWatchUnsupported
+func (p *Provider) WatchUsage(ctx context.Context, minInterval time.Duration, onUpdate func(snapshot *UsageSnapshot)) error {
+	if p.client == nil {
+		return fmt.Errorf("provider not connected")
+	}
+	if p.id == "" {
+		return fmt.Errorf("provider not connected, please call Connect first")
+	}
+
+	if !p.SupportsCapability(common.CapWatchUsage) {
+		return ErrUsageWatchUnsupported
+	}
+
+	stream, err := p.client.WatchUsage(ctx, &providerpb.WatchUsageRequest{
+		ProviderId:    p.id,
+		MinIntervalMs: minInterval.Milliseconds(),
+	})
+	if err != nil {
+		return watchUsageError(err)
+	}
+
+	// 推送流结束后缓存重新受陈旧时间约束
+	defer p.setCapacityCacheLive(false)
+
+	snapshot := &UsageSnapshot{}
+	for {
+		update, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) || ctx.Err() != nil {
+				return nil
+			}
+			return watchUsageError(err)
+		}
+
+		if u := update.Usage; u != nil {
+			snapshot.Usage = &types.Info{CPU: u.Cpu, Memory: u.Memory, GPU: u.Gpu}
+		}
+		if c := update.Capacity; c != nil && c.Total != nil && c.Used != nil && c.Available != nil {
+			capacity := &types.Capacity{
+				Total:     &types.Info{CPU: c.Total.Cpu, Memory: c.Total.Memory, GPU: c.Total.Gpu},
+				Used:      &types.Info{CPU: c.Used.Cpu, Memory: c.Used.Memory, GPU: c.Used.Gpu},
+				Available: &types.Info{CPU: c.Available.Cpu, Memory: c.Available.Memory, GPU: c.Available.Gpu},
+			}
+			snapshot.Capacity = capacity
+
+			p.cacheMu.Lock()
+			p.storeCapacity(capacity, "watch")
+			p.cacheMu.Unlock()
+		}
+
+		if snapshot.Usage != nil && snapshot.Capacity != nil {
+			// 已收到完整快照，之后容量变化会被主动推送
+			p.setCapacityCacheLive(true)
+			onUpdate(&UsageSnapshot{Usage: snapshot.Usage, Capacity: snapshot.Capacity})
+		}
+	}
+}
+
+func watchUsageError(err error) error {
+	if status.Code(err) == codes.Unimplemented {
+		return ErrUsageWatchUnsupported
+	}
+	return fmt.Errorf("usage watch failed: %w", err)
+}
@@ -0,0 +1,232 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/9triver/iarnet/internal/proto/common"
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+)
+
+// VolumeMount 挂载到 component 的持久化存储，Name 与 HostPath 二选一
+// 两者都位于 provider 所在主机上，因此挂载了卷的 component 固定部署在本节点
+type VolumeMount struct {
+	Name      string // provider 管理的命名卷，不存在时在部署时创建
+	HostPath  string // provider 主机上的绝对路径
+	MountPath string // component 内的绝对路径
+	ReadOnly  bool
+}
+
+// Volume provider 上的命名卷
+type Volume struct {
+	Name       string
+	ProviderID string
+	CreatedAt  time.Time // 未知时为零值
+}
+
+// volumeNamePattern 与 Docker 命名卷的命名规则一致
+var volumeNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]+$`)
+
+// ValidateVolumeName 校验命名卷名称
+func ValidateVolumeName(name string) error {
+	if !volumeNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid volume name %q: must match %s", name, volumeNamePattern.String())
+	}
+	return nil
+}
+
+// ValidateVolumeMounts 校验卷挂载：来源二选一、路径为绝对路径且挂载点互不重复
+func ValidateVolumeMounts(mounts []VolumeMount) error {
+	seen := make(map[string]struct{}, len(mounts))
+	for i, m := range mounts {
+		if (m.Name == "") == (m.HostPath == "") {
+			return fmt.Errorf("volume %d: exactly one of name and host_path is required", i)
+		}
+		if m.Name != "" {
+			if err := ValidateVolumeName(m.Name); err != nil {
+				return fmt.Errorf("volume %d: %w", i, err)
+			}
+		}
+		if m.HostPath != "" && !path.IsAbs(m.HostPath) {
+			return fmt.Errorf("volume %d: host_path %q must be an absolute path", i, m.HostPath)
+		}
+		clean := path.Clean(m.MountPath)
+		if !path.IsAbs(m.MountPath) || clean == "/" {
+			return fmt.Errorf("volume %d: mount_path %q must be an absolute path other than /", i, m.MountPath)
+		}
+		if _, dup := seen[clean]; dup {
+			return fmt.Errorf("volume %d: duplicate mount_path %q", i, m.MountPath)
+		}
+		seen[clean] = struct{}{}
+	}
+	return nil
+}
+
+// VolumeMountsFromProto 从 proto 消息转换
+func VolumeMountsFromProto(pbs []*common.VolumeMount) []VolumeMount {
+	if len(pbs) == 0 {
+		return nil
+	}
+	mounts := make([]VolumeMount, 0, len(pbs))
+	for _, pb := range pbs {
+		mounts = append(mounts, VolumeMount{
+			Name:      pb.GetName(),
+			HostPath:  pb.GetHostPath(),
+			MountPath: pb.GetMountPath(),
+			ReadOnly:  pb.GetReadOnly(),
+		})
+	}
+	return mounts
+}
+
+// VolumeMountsToProto 转换为 proto 消息
+func VolumeMountsToProto(mounts []VolumeMount) []*common.VolumeMount {
+	if len(mounts) == 0 {
+		return nil
+	}
+	pbs := make([]*common.VolumeMount, 0, len(mounts))
+	for _, m := range mounts {
+		pbs = append(pbs, &common.VolumeMount{
+			Name:      m.Name,
+			HostPath:  m.HostPath,
+			MountPath: m.MountPath,
+			ReadOnly:  m.ReadOnly,
+		})
+	}
+	return pbs
+}
+
+// namedVolumes 返回挂载中引用的命名卷
+func namedVolumes(mounts []VolumeMount) []string {
+	var names []string
+	for _, m := range mounts {
+		if m.Name != "" {
+			names = append(names, m.Name)
+		}
+	}
+	return names
+}
+
+type volumesCtxKey struct{}
+
+// WithVolumes 在 context 中附加部署时挂载的卷
+// 附加后只会选择支持卷的本地 provider，且不会委托给其他节点
+func WithVolumes(ctx context.Context, mounts []VolumeMount) context.Context {
+	if len(mounts) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, volumesCtxKey{}, mounts)
+}
+
+// GetVolumes 从 context 获取部署时挂载的卷
+func GetVolumes(ctx context.Context) ([]VolumeMount, bool) {
+	mounts, ok := ctx.Value(volumesCtxKey{}).([]VolumeMount)
+	return mounts, ok && len(mounts) > 0
+}
+
+// CreateVolume 在 provider 上创建命名卷，已存在时返回现有的卷
+func (p *Provider) CreateVolume(ctx context.Context, name string) (*Volume, error) {
+	if p.client == nil {
+		return nil, fmt.Errorf("provider not connected")
+	}
+	if err := p.requireCapability(common.CapVolumes); err != nil {
+		return nil, err
+	}
+	if err := ValidateVolumeName(name); err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.CreateVolume(ctx, &providerpb.CreateVolumeRequest{
+		ProviderId: p.id,
+		Name:       name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create volume: %w", err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("failed to create volume: %s", resp.Error)
+	}
+	return p.volumeFromProto(resp.Volume), nil
+}
+
+// ListVolumes 列出 provider 上由 iarnet 管理的命名卷
+func (p *Provider) ListVolumes(ctx context.Context) ([]*Volume, error) {
+	if p.client == nil {
+		return nil, fmt.Errorf("provider not connected")
+	}
+	if err := p.requireCapability(common.CapVolumes); err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.ListVolumes(ctx, &providerpb.ListVolumesRequest{ProviderId: p.id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list volumes: %w", err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("failed to list volumes: %s", resp.Error)
+	}
+	volumes := make([]*Volume, 0, len(resp.Volumes))
+	for _, v := range resp.Volumes {
+		volumes = append(volumes, p.volumeFromProto(v))
+	}
+	return volumes, nil
+}
+
+// DeleteVolume 删除 provider 上的命名卷，仍被 component 挂载时失败
+func (p *Provider) DeleteVolume(ctx context.Context, name string) error {
+	if p.client == nil {
+		return fmt.Errorf("provider not connected")
+	}
+	if err := p.requireCapability(common.CapVolumes); err != nil {
+		return err
+	}
+
+	resp, err := p.client.DeleteVolume(ctx, &providerpb.DeleteVolumeRequest{
+		ProviderId: p.id,
+		Name:       name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete volume: %w", err)
+	}
+	if resp.Error != "" {
+		return fmt.Errorf("failed to delete volume: %s", resp.Error)
+	}
+	return nil
+}
+
+func (p *Provider) volumeFromProto(v *providerpb.Volume) *Volume {
+	volume := &Volume{Name: v.GetName(), ProviderID: p.id}
+	if v.GetCreatedAt() > 0 {
+		volume.CreatedAt = time.Unix(v.GetCreatedAt(), 0)
+	}
+	return volume
+}
+
+// volumeHolders 返回持有任一命名卷的 provider ID，用于将 component 固定到卷所在的 provider
+// 查询失败的 provider 视为不持有
+func volumeHolders(ctx context.Context, providers []*Provider, names []string) map[string]struct{} {
+	holders := make(map[string]struct{})
+	if len(names) == 0 {
+		return holders
+	}
+	for _, p := range providers {
+		if !p.SupportsCapability(common.CapVolumes) {
+			continue
+		}
+		volumes, err := p.ListVolumes(ctx)
+		if err != nil {
+			continue
+		}
+		for _, v := range volumes {
+			if slices.Contains(names, v.Name) {
+				holders[p.GetID()] = struct{}{}
+				break
+			}
+		}
+	}
+	return holders
+}
@@ -0,0 +1,170 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/scheduler"
+)
+
+// maxRemovedProviders 保留的 provider 移除记录数，更早的增量查询返回全量结果
+const maxRemovedProviders = 1024
+
+// ListProviders 分页列举本节点的 provider，按 provider ID 排序
+// 每次列举都与上一次的快照比较，为有变化的 provider 分配新版本；
+// SinceVersion 非 0 时只返回此后有变化的 provider，并在第一页携带此后移除的 provider
+func (m *Manager) ListProviders(ctx context.Context, query scheduler.ProviderListQuery) (*scheduler.ProviderList, error) {
+	cursor, err := scheduler.ParsePageToken(query.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	providers := m.providerService.GetAllProviders()
+	items := make([]scheduler.ProviderUtilization, 0, len(providers))
+	for _, p := range providers {
+		items = append(items, m.providerUtilization(ctx, p))
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ProviderID < items[j].ProviderID })
+	version := m.providerJournal.record(items)
+
+	list := &scheduler.ProviderList{NodeID: m.nodeID, NodeName: m.name, Version: version}
+	since := query.SinceVersion
+	if cursor != nil {
+		// 后续页沿用第一页的版本与增量起点
+		list.Version = cursor.Version
+		since = cursor.Since
+	}
+	if since > 0 {
+		changed, removed, ok := m.providerJournal.since(since)
+		if !ok {
+			since = 0
+			list.Full = true
+		} else {
+			items = slices.DeleteFunc(items, func(item scheduler.ProviderUtilization) bool {
+				return !changed[item.ProviderID]
+			})
+			if cursor == nil {
+				list.Removed = removed
+			}
+		}
+	}
+
+	if cursor != nil {
+		start := sort.Search(len(items), func(i int) bool { return items[i].ProviderID > cursor.After })
+		items = items[start:]
+	}
+	if limit := query.PageLimit(); len(items) > limit {
+		items = items[:limit]
+		list.NextPageToken = scheduler.PageCursor{
+			Version: list.Version,
+			Since:   since,
+			After:   items[limit-1].ProviderID,
+		}.Encode()
+	}
+	list.Providers = items
+	return list, nil
+}
+
+// providerJournal 记录各 provider 最近一次变化的版本以及移除记录，用于增量列举
+// 版本从节点启动时的毫秒时间戳开始递增，重启前的版本早于 horizon，增量查询返回全量结果
+type providerJournal struct {
+	mu      sync.Mutex
+	version uint64
+	horizon uint64 // 早于该版本的增量无法计算
+	entries map[string]journalEntry
+	removed []journalRemoval // 按版本递增
+}
+
+type journalEntry struct {
+	fingerprint string
+	version     uint64
+}
+
+type journalRemoval struct {
+	providerID string
+	version    uint64
+}
+
+func newProviderJournal() *providerJournal {
+	start := uint64(time.Now().UnixMilli())
+	return &providerJournal{
+		version: start,
+		horizon: start,
+		entries: make(map[string]journalEntry),
+	}
+}
+
+// record 与上一次的快照比较，有变化时推进版本，返回当前版本
+func (j *providerJournal) record(items []scheduler.ProviderUtilization) uint64 {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	next := j.version + 1
+	changed := false
+	seen := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		seen[item.ProviderID] = struct{}{}
+		fp := providerFingerprint(item)
+		entry, ok := j.entries[item.ProviderID]
+		if ok && entry.fingerprint == fp {
+			continue
+		}
+		if !ok {
+			// 重新加入的 provider 不再出现在移除记录中
+			j.removed = slices.DeleteFunc(j.removed, func(r journalRemoval) bool { return r.providerID == item.ProviderID })
+		}
+		j.entries[item.ProviderID] = journalEntry{fingerprint: fp, version: next}
+		changed = true
+	}
+	for id := range j.entries {
+		if _, ok := seen[id]; !ok {
+			delete(j.entries, id)
+			j.removed = append(j.removed, journalRemoval{providerID: id, version: next})
+			changed = true
+		}
+	}
+	if drop := len(j.removed) - maxRemovedProviders; drop > 0 {
+		j.horizon = j.removed[drop-1].version
+		j.removed = append([]journalRemoval(nil), j.removed[drop:]...)
+	}
+	if changed {
+		j.version = next
+	}
+	return j.version
+}
+
+// since 返回 since 之后有变化的 provider 与移除的 provider；since 早于 horizon 或晚于当前版本时 ok 为 false
+func (j *providerJournal) since(since uint64) (changed map[string]bool, removed []string, ok bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if since < j.horizon || since > j.version {
+		return nil, nil, false
+	}
+	changed = make(map[string]bool)
+	for id, entry := range j.entries {
+		if entry.version > since {
+			changed[id] = true
+		}
+	}
+	for _, r := range j.removed {
+		if r.version > since {
+			removed = append(removed, r.providerID)
+		}
+	}
+	sort.Strings(removed)
+	return changed, removed, true
+}
+
+// providerFingerprint provider 明细的摘要，任一字段变化时不同
+func providerFingerprint(item scheduler.ProviderUtilization) string {
+	fp := fmt.Sprintf("%s|%s|%s|%d|%s", item.ProviderName, item.ProviderType, item.Status, item.Components, item.Error)
+	if c := item.Capacity; c != nil {
+		fp += fmt.Sprintf("|%v|%v|%v", c.Total, c.Used, c.Available)
+	}
+	return fp
+}
@@ -0,0 +1,544 @@
+package resource
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/component"
+	"github.com/9triver/iarnet/internal/domain/resource/discovery"
+	"github.com/9triver/iarnet/internal/domain/resource/provider"
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+	commonpb "github.com/9triver/iarnet/internal/proto/common"
+	"github.com/sirupsen/logrus"
+)
+
+// usageSampleTTL 实时使用量样本的有效期（相对轮询间隔上限的倍数），超过后改用已分配量估算利用率
+const usageSampleTTL = 3
+
+// sloPressureTTL SLO 违反信号的有效期，应用持续违反时信号会被周期性刷新
+const sloPressureTTL = time.Minute
+
+// 迁移原因
+const (
+	MigrationReasonUtilization = "utilization" // 源 provider 过载
+	MigrationReasonSLO         = "slo"         // component 所属应用违反 SLO
+)
+
+// RebalancePolicy 反应式再平衡策略
+type RebalancePolicy struct {
+	Enabled          bool
+	DryRun           bool          // 只生成迁移计划，不实际迁移
+	Interval         time.Duration // 检查间隔，同时也是迁移限流的时间窗口
+	HighWatermark    float64       // 利用率超过该值的 provider 视为过载
+	LowWatermark     float64       // 迁入后目标 provider 利用率不得超过该值
+	MinSkew          float64       // 源与目标 provider 利用率的最小差值
+	MaxMigrations    int           // 每个时间窗口内最多迁移的 component 数
+	Cooldown         time.Duration // 同一 component 两次迁移的最小间隔
+	IncludeDedicated bool          // 是否迁移 dedicated provider 上的 component，默认只迁移可驱逐的
+}
+
+// ProviderLoad provider 的负载
+type ProviderLoad struct {
+	ProviderID   string
+	ProviderName string
+	Utilization  float64 // CPU/内存/GPU 利用率中的最大值
+	FromUsage    bool    // true 表示基于实时使用量，false 表示基于已分配量
+}
+
+// NodeLoad 节点的负载（来自 discovery 传播的资源容量）
+type NodeLoad struct {
+	NodeID      string
+	NodeName    string
+	Utilization float64
+}
+
+// RebalanceMigration 一次计划中的迁移
+type RebalanceMigration struct {
+	ComponentID     string
+	FromProviderID  string
+	ToProviderID    string
+	FromUtilization float64 // 迁移前源 provider 的利用率
+	ToUtilization   float64 // 迁移前目标 provider 的利用率
+	Reason          string  // MigrationReasonUtilization 或 MigrationReasonSLO
+	Detail          string  // SLO 迁移时为违反的描述
+	Executed        bool
+	Error           string
+}
+
+// RebalanceReport 一次再平衡检查的结果
+// 跨节点的偏差只做观测：component 与本节点的通信通道绑定，迁移只在本节点的 provider 之间进行
+type RebalanceReport struct {
+	Time         time.Time
+	DryRun       bool
+	Providers    []ProviderLoad
+	Nodes        []NodeLoad
+	ProviderSkew float64 // 本节点 provider 利用率最大值与最小值之差
+	NodeSkew     float64 // 已知节点利用率最大值与最小值之差
+	Migrations   []RebalanceMigration
+	Budget       int // 本次检查时剩余的迁移配额
+}
+
+// usageSample 最近一次轮询或推送得到的 provider 实时使用量
+type usageSample struct {
+	usage *types.Info
+	at    time.Time
+}
+
+// sloPressure component 所属应用违反 SLO 的信号
+type sloPressure struct {
+	reason string
+	until  time.Time
+}
+
+// rebalancer 再平衡状态
+type rebalancer struct {
+	mu           sync.Mutex
+	policy       RebalancePolicy
+	samples      map[string]usageSample // provider ID -> 最近的使用量样本
+	migrated     map[string]time.Time   // component ID -> 最近一次迁移时间
+	migrationLog []time.Time            // 时间窗口内已执行的迁移，用于限流
+	pressure     map[string]sloPressure // component ID -> 未过期的 SLO 违反信号
+	lastReport   *RebalanceReport
+	stop         chan struct{}
+}
+
+func newRebalancer() *rebalancer {
+	return &rebalancer{
+		samples:  make(map[string]usageSample),
+		migrated: make(map[string]time.Time),
+		pressure: make(map[string]sloPressure),
+		stop:     make(chan struct{}),
+	}
+}
+
+// providerLoadState 规划过程中 provider 的负载估算，迁移计划会逐步修改其中的 used
+type providerLoadState struct {
+	provider *provider.Provider
+	used     types.Info
+	total    types.Info
+	load     ProviderLoad
+}
+
+func (s *providerLoadState) utilization() float64 {
+	return utilizationOf(&s.used, &s.total)
+}
+
+// fits 判断目标 provider 的剩余资源能否容纳请求
+func (s *providerLoadState) fits(request *types.Info) bool {
+	return s.total.CPU-s.used.CPU >= request.CPU &&
+		s.total.Memory-s.used.Memory >= request.Memory &&
+		s.total.GPU-s.used.GPU >= request.GPU
+}
+
+// utilizationOf 计算 CPU/内存/GPU 利用率中的最大值
+func utilizationOf(used, total *types.Info) float64 {
+	if used == nil || total == nil {
+		return 0
+	}
+	var u float64
+	if total.CPU > 0 {
+		u = max(u, float64(used.CPU)/float64(total.CPU))
+	}
+	if total.Memory > 0 {
+		u = max(u, float64(used.Memory)/float64(total.Memory))
+	}
+	if total.GPU > 0 {
+		u = max(u, float64(used.GPU)/float64(total.GPU))
+	}
+	return u
+}
+
+// SetRebalancePolicy 设置反应式再平衡策略，需在 Start 之前调用
+func (m *Manager) SetRebalancePolicy(policy RebalancePolicy) {
+	m.rebalancer.mu.Lock()
+	defer m.rebalancer.mu.Unlock()
+	m.rebalancer.policy = policy
+}
+
+// recordUsageSample 保存 provider 的实时使用量，供再平衡估算利用率
+func (m *Manager) recordUsageSample(p *provider.Provider, usage *types.Info) {
+	m.rebalancer.mu.Lock()
+	defer m.rebalancer.mu.Unlock()
+	m.rebalancer.samples[p.GetID()] = usageSample{usage: usage, at: time.Now()}
+}
+
+// ReportSLOViolation 标记所属应用违反 SLO 的 component，信号在 sloPressureTTL 内有效
+// 再平衡时这些 component 优先迁移到利用率更低的 provider，即使源 provider 未超过高水位
+func (m *Manager) ReportSLOViolation(componentIDs []string, reason string) {
+	m.rebalancer.mu.Lock()
+	defer m.rebalancer.mu.Unlock()
+	until := time.Now().Add(sloPressureTTL)
+	for _, id := range componentIDs {
+		m.rebalancer.pressure[id] = sloPressure{reason: reason, until: until}
+	}
+}
+
+// GetRebalanceReport 获取最近一次再平衡检查的结果，尚未检查过时返回 nil
+func (m *Manager) GetRebalanceReport() *RebalanceReport {
+	m.rebalancer.mu.Lock()
+	defer m.rebalancer.mu.Unlock()
+	return m.rebalancer.lastReport
+}
+
+// startRebalancer 启动再平衡循环
+func (m *Manager) startRebalancer(ctx context.Context) {
+	m.rebalancer.mu.Lock()
+	policy := m.rebalancer.policy
+	m.rebalancer.mu.Unlock()
+	if !policy.Enabled || policy.Interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(policy.Interval)
+		defer ticker.Stop()
+
+		logrus.Infof("Rebalancer started with interval %v (dry_run=%v)", policy.Interval, policy.DryRun)
+		for {
+			select {
+			case <-ticker.C:
+				m.Rebalance(ctx, policy.DryRun)
+			case <-m.rebalancer.stop:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// stopRebalancer 停止再平衡循环
+func (m *Manager) stopRebalancer() {
+	select {
+	case <-m.rebalancer.stop:
+	default:
+		close(m.rebalancer.stop)
+	}
+}
+
+// Rebalance 执行一次再平衡检查：估算各 provider 的利用率，为过载 provider 上可迁移的 component
+// 规划迁移目标，dryRun 为 false 时在限流配额内执行迁移
+func (m *Manager) Rebalance(ctx context.Context, dryRun bool) *RebalanceReport {
+	m.rebalancer.mu.Lock()
+	policy := m.rebalancer.policy
+	m.rebalancer.mu.Unlock()
+
+	report := &RebalanceReport{
+		Time:   time.Now(),
+		DryRun: dryRun,
+		Budget: m.migrationBudget(policy),
+	}
+
+	states := m.providerLoads(ctx)
+	for _, s := range states {
+		report.Providers = append(report.Providers, s.load)
+	}
+	report.ProviderSkew = skewOf(states)
+	report.Nodes, report.NodeSkew = m.nodeLoads()
+
+	report.Migrations = m.planMigrations(policy, states, report.Budget)
+	if !dryRun {
+		for i := range report.Migrations {
+			mig := &report.Migrations[i]
+			if err := m.componentService.MigrateComponent(ctx, mig.ComponentID, mig.ToProviderID); err != nil {
+				mig.Error = err.Error()
+				logrus.Warnf("Rebalance: failed to migrate component %s to provider %s: %v", mig.ComponentID, mig.ToProviderID, err)
+				continue
+			}
+			mig.Executed = true
+			m.recordMigration(mig.ComponentID)
+			if mig.Reason == MigrationReasonSLO {
+				m.clearSLOPressure(mig.ComponentID)
+			}
+		}
+	}
+
+	if len(report.Migrations) > 0 {
+		logrus.Infof("Rebalance: provider skew %.2f, node skew %.2f, %d migration(s) planned (dry_run=%v)",
+			report.ProviderSkew, report.NodeSkew, len(report.Migrations), dryRun)
+	}
+
+	m.rebalancer.mu.Lock()
+	m.rebalancer.lastReport = report
+	m.rebalancer.mu.Unlock()
+	return report
+}
+
+// providerLoads 估算本节点已连接 provider 的负载，按利用率从高到低排序
+// 优先使用未过期的实时使用量样本，否则使用容量缓存中的已分配量
+func (m *Manager) providerLoads(ctx context.Context) []*providerLoadState {
+	m.rebalancer.mu.Lock()
+	samples := make(map[string]usageSample, len(m.rebalancer.samples))
+	for id, s := range m.rebalancer.samples {
+		samples[id] = s
+	}
+	m.rebalancer.mu.Unlock()
+
+	var states []*providerLoadState
+	for _, p := range m.providerService.GetAllProviders() {
+		if p.GetStatus() != types.ProviderStatusConnected {
+			continue
+		}
+		capacity, err := p.GetCapacity(ctx)
+		if err != nil || capacity.Total == nil || capacity.Used == nil {
+			logrus.Debugf("Rebalance: skipping provider %s without capacity: %v", p.GetID(), err)
+			continue
+		}
+		state := &providerLoadState{
+			provider: p,
+			used:     *capacity.Used,
+			total:    *capacity.Total,
+			load:     ProviderLoad{ProviderID: p.GetID(), ProviderName: p.GetName()},
+		}
+		if s, ok := samples[p.GetID()]; ok && s.usage != nil && time.Since(s.at) < m.usageSampleMaxAge() {
+			state.used = types.Info{CPU: s.usage.CPU, Memory: s.usage.Memory, GPU: s.usage.GPU}
+			state.load.FromUsage = true
+		}
+		state.load.Utilization = state.utilization()
+		states = append(states, state)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].load.Utilization > states[j].load.Utilization
+	})
+	return states
+}
+
+// nodeLoads 根据 discovery 中存活节点的资源容量计算节点负载及偏差
+func (m *Manager) nodeLoads() ([]NodeLoad, float64) {
+	if m.discoveryService == nil {
+		return nil, 0
+	}
+	nodes := m.discoveryService.GetKnownNodes()
+	if local := m.discoveryService.GetLocalNode(); local != nil {
+		nodes = append(nodes, local)
+	}
+
+	var loads []NodeLoad
+	for _, node := range nodes {
+		if node.Status != discovery.NodeStatusOnline || node.Liveness == discovery.NodeLivenessSuspect {
+			continue
+		}
+		if node.ResourceCapacity == nil || node.ResourceCapacity.Total == nil {
+			continue
+		}
+		loads = append(loads, NodeLoad{
+			NodeID:      node.NodeID,
+			NodeName:    node.NodeName,
+			Utilization: utilizationOf(node.ResourceCapacity.Used, node.ResourceCapacity.Total),
+		})
+	}
+	sort.Slice(loads, func(i, j int) bool {
+		return loads[i].Utilization > loads[j].Utilization
+	})
+	if len(loads) < 2 {
+		return loads, 0
+	}
+	return loads, loads[0].Utilization - loads[len(loads)-1].Utilization
+}
+
+func skewOf(states []*providerLoadState) float64 {
+	if len(states) < 2 {
+		return 0
+	}
+	return states[0].load.Utilization - states[len(states)-1].load.Utilization
+}
+
+// planMigrations 先为违反 SLO 的 component 规划迁移，再为过载 provider 规划迁移，每规划一次都会更新源和目标的负载估算
+// 目标需满足：资源足够、迁入后利用率不超过低水位、迁移前与源的利用率差不小于 MinSkew
+func (m *Manager) planMigrations(policy RebalancePolicy, states []*providerLoadState, budget int) []RebalanceMigration {
+	plan := m.planSLOMigrations(policy, states, budget)
+	planned := make(map[string]bool, len(plan))
+	for _, mig := range plan {
+		planned[mig.ComponentID] = true
+	}
+	for _, source := range states {
+		if len(plan) >= budget {
+			break
+		}
+		if source.utilization() <= policy.HighWatermark {
+			continue
+		}
+		for _, comp := range m.movableComponents(policy, source.provider.GetID()) {
+			if len(plan) >= budget || source.utilization() <= policy.HighWatermark {
+				break
+			}
+			if planned[comp.GetID()] {
+				continue
+			}
+			request := comp.GetResourceUsage()
+			target := pickRebalanceTarget(policy, states, source, request)
+			if target == nil {
+				continue
+			}
+			plan = append(plan, RebalanceMigration{
+				ComponentID:     comp.GetID(),
+				FromProviderID:  source.provider.GetID(),
+				ToProviderID:    target.provider.GetID(),
+				FromUtilization: source.utilization(),
+				ToUtilization:   target.utilization(),
+				Reason:          MigrationReasonUtilization,
+			})
+			subtractInfo(&source.used, request)
+			addInfo(&target.used, request)
+		}
+	}
+	return plan
+}
+
+// planSLOMigrations 为所属应用违反 SLO 的 component 规划迁移，不要求源 provider 过载
+// SLO 信号表明应用已受影响，因此 dedicated provider 上的 component 也会迁移
+func (m *Manager) planSLOMigrations(policy RebalancePolicy, states []*providerLoadState, budget int) []RebalanceMigration {
+	m.rebalancer.mu.Lock()
+	pressure := make(map[string]sloPressure, len(m.rebalancer.pressure))
+	for id, p := range m.rebalancer.pressure {
+		if time.Now().After(p.until) {
+			delete(m.rebalancer.pressure, id)
+			continue
+		}
+		pressure[id] = p
+	}
+	m.rebalancer.mu.Unlock()
+	if len(pressure) == 0 {
+		return nil
+	}
+
+	sloPolicy := policy
+	sloPolicy.IncludeDedicated = true
+	var plan []RebalanceMigration
+	for _, source := range states {
+		for _, comp := range m.movableComponents(sloPolicy, source.provider.GetID()) {
+			if len(plan) >= budget {
+				return plan
+			}
+			p, ok := pressure[comp.GetID()]
+			if !ok {
+				continue
+			}
+			request := comp.GetResourceUsage()
+			target := pickRebalanceTarget(policy, states, source, request)
+			if target == nil {
+				continue
+			}
+			plan = append(plan, RebalanceMigration{
+				ComponentID:     comp.GetID(),
+				FromProviderID:  source.provider.GetID(),
+				ToProviderID:    target.provider.GetID(),
+				FromUtilization: source.utilization(),
+				ToUtilization:   target.utilization(),
+				Reason:          MigrationReasonSLO,
+				Detail:          p.reason,
+			})
+			subtractInfo(&source.used, request)
+			addInfo(&target.used, request)
+		}
+	}
+	return plan
+}
+
+// pickRebalanceTarget 在满足约束的 provider 中选择利用率最低的一个
+func pickRebalanceTarget(policy RebalancePolicy, states []*providerLoadState, source *providerLoadState, request *types.Info) *providerLoadState {
+	var best *providerLoadState
+	for _, candidate := range states {
+		if candidate == source || !candidate.fits(request) {
+			continue
+		}
+		if source.utilization()-candidate.utilization() < policy.MinSkew {
+			continue
+		}
+		after := candidate.used
+		addInfo(&after, request)
+		if utilizationOf(&after, &candidate.total) > policy.LowWatermark {
+			continue
+		}
+		if best == nil || candidate.utilization() < best.utilization() {
+			best = candidate
+		}
+	}
+	return best
+}
+
+// movableComponents 获取 provider 上可迁移的 component，按 CPU 请求从大到小排序，迁移大的可以减少迁移次数
+// 默认只迁移可驱逐（best-effort）的 component，冷却期内迁移过的 component 不再迁移
+func (m *Manager) movableComponents(policy RebalancePolicy, providerID string) []*component.Component {
+	// 不支持 Undeploy 的旧版 provider 无法回收迁移前的实例
+	if p := m.providerService.GetProvider(providerID); p == nil || !p.SupportsCapability(commonpb.CapUndeploy) {
+		return nil
+	}
+
+	m.rebalancer.mu.Lock()
+	defer m.rebalancer.mu.Unlock()
+
+	var movable []*component.Component
+	for _, comp := range m.componentManager.GetByProvider(providerID) {
+		if !comp.IsEvictable() && !policy.IncludeDedicated {
+			continue
+		}
+		if at, ok := m.rebalancer.migrated[comp.GetID()]; ok && time.Since(at) < policy.Cooldown {
+			continue
+		}
+		if comp.GetResourceUsage() == nil {
+			continue
+		}
+		// 卷中的数据不会随迁移转移
+		if comp.HasVolumes() {
+			continue
+		}
+		// 绑定数据流的 component 只能运行在拥有该数据流的 provider 上
+		if comp.GetResourceUsage().Stream != "" {
+			continue
+		}
+		movable = append(movable, comp)
+	}
+	sort.Slice(movable, func(i, j int) bool {
+		return movable[i].GetResourceUsage().CPU > movable[j].GetResourceUsage().CPU
+	})
+	return movable
+}
+
+// migrationBudget 计算当前时间窗口内剩余的迁移配额，同时清理已过冷却期的迁移记录
+func (m *Manager) migrationBudget(policy RebalancePolicy) int {
+	m.rebalancer.mu.Lock()
+	defer m.rebalancer.mu.Unlock()
+
+	cutoff := time.Now().Add(-policy.Interval)
+	recent := m.rebalancer.migrationLog[:0]
+	for _, at := range m.rebalancer.migrationLog {
+		if at.After(cutoff) {
+			recent = append(recent, at)
+		}
+	}
+	m.rebalancer.migrationLog = recent
+	for id, at := range m.rebalancer.migrated {
+		if time.Since(at) >= policy.Cooldown {
+			delete(m.rebalancer.migrated, id)
+		}
+	}
+	return max(policy.MaxMigrations-len(recent), 0)
+}
+
+// clearSLOPressure 迁移后清除 component 的 SLO 信号，迁移后仍违反时由下一次评估重新标记
+func (m *Manager) clearSLOPressure(componentID string) {
+	m.rebalancer.mu.Lock()
+	defer m.rebalancer.mu.Unlock()
+	delete(m.rebalancer.pressure, componentID)
+}
+
+// recordMigration 记录一次已执行的迁移，用于冷却和限流
+func (m *Manager) recordMigration(componentID string) {
+	m.rebalancer.mu.Lock()
+	defer m.rebalancer.mu.Unlock()
+	now := time.Now()
+	m.rebalancer.migrated[componentID] = now
+	m.rebalancer.migrationLog = append(m.rebalancer.migrationLog, now)
+}
+
+func subtractInfo(sum, info *types.Info) {
+	if info == nil {
+		return
+	}
+	sum.CPU -= info.CPU
+	sum.Memory -= info.Memory
+	sum.GPU -= info.GPU
+}
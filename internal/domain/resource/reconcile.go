@@ -0,0 +1,165 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/scheduler"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// defaultReconcileInterval 默认向委托方对账的间隔
+	defaultReconcileInterval = time.Minute
+	// reconcileTimeout 向单个委托方对账的超时
+	reconcileTimeout = 10 * time.Second
+)
+
+// delegationCommits 进行中的委托提交
+// 提交前登记 component ID，目标节点在响应返回前发来对账时据此答复待确认，而不是删除
+type delegationCommits struct {
+	mu  sync.Mutex
+	ids map[string]struct{}
+}
+
+func newDelegationCommits() *delegationCommits {
+	return &delegationCommits{ids: make(map[string]struct{})}
+}
+
+func (c *delegationCommits) begin(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ids[id] = struct{}{}
+}
+
+func (c *delegationCommits) end(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.ids, id)
+}
+
+func (c *delegationCommits) inFlight(id string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.ids[id]
+	return ok
+}
+
+// SetReconcileInterval 设置向委托方对账的间隔，0 表示不对账，需在 Start 之前调用
+func (m *Manager) SetReconcileInterval(interval time.Duration) {
+	if interval >= 0 {
+		m.reconcileInterval = interval
+	}
+}
+
+// ReconcileComponents 确认 nodeID 上报的、由本节点委托部署的 component 应保留还是删除
+// 本节点登记了该 component 时保留，并按上报的 provider 更新路由（e.g., component 在目标节点上被重新调度）；
+// 提交仍在进行时待确认；本节点没有记录（提交后未收到响应，或已删除）时删除
+func (m *Manager) ReconcileComponents(ctx context.Context, nodeID string, components []scheduler.DelegatedComponent) (map[string]scheduler.ReconcileAction, error) {
+	actions := make(map[string]scheduler.ReconcileAction, len(components))
+	for _, c := range components {
+		// 先检查进行中的提交：提交在本地登记 component 之后才结束，反过来可能误判为没有记录
+		if m.commits.inFlight(c.ID) {
+			actions[c.ID] = scheduler.ReconcilePending
+			continue
+		}
+		comp := m.componentManager.Get(c.ID)
+		if comp == nil {
+			logrus.Infof("Component %s on node %s is not registered locally, instructing deletion", c.ID, nodeID)
+			actions[c.ID] = scheduler.ReconcileDelete
+			continue
+		}
+		if placedOn, _ := m.placementOf(comp); placedOn != nodeID {
+			logrus.Warnf("Component %s is registered on node %s, instructing node %s to delete its copy", c.ID, placedOn, nodeID)
+			actions[c.ID] = scheduler.ReconcileDelete
+			continue
+		}
+		if route := fmt.Sprintf("remote.%s@%s", c.ProviderID, nodeID); c.ProviderID != "" && comp.GetProviderID() != route {
+			logrus.Infof("Component %s moved to provider %s on node %s, updating route", c.ID, c.ProviderID, nodeID)
+			comp.SetProviderID(route)
+		}
+		actions[c.ID] = scheduler.ReconcileAdopt
+	}
+	return actions, nil
+}
+
+// startReconcile 启动对账循环，定期向委托方上报由其委托部署到本节点的 component
+func (m *Manager) startReconcile(ctx context.Context) {
+	if m.reconcileInterval <= 0 || m.schedulerService == nil {
+		return
+	}
+	m.reconcileStop = make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(m.reconcileInterval)
+		defer ticker.Stop()
+
+		logrus.Infof("Delegated component reconciliation started with interval %v", m.reconcileInterval)
+		for {
+			select {
+			case <-ticker.C:
+				m.reconcileDelegatedComponents(ctx)
+			case <-m.reconcileStop:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// stopReconcile 停止对账循环
+func (m *Manager) stopReconcile() {
+	if m.reconcileStop == nil {
+		return
+	}
+	select {
+	case <-m.reconcileStop:
+	default:
+		close(m.reconcileStop)
+	}
+}
+
+// reconcileDelegatedComponents 按委托方分组上报本节点上由其他节点委托部署的 component，删除委托方没有记录的
+// 委托方不可达或不支持对账时保留 component，下次再试
+func (m *Manager) reconcileDelegatedComponents(ctx context.Context) {
+	byOrigin := make(map[string][]scheduler.DelegatedComponent)
+	for _, comp := range m.componentManager.GetAll() {
+		origin := comp.GetOrigin()
+		if origin == "" || origin == m.nodeID {
+			continue
+		}
+		byOrigin[origin] = append(byOrigin[origin], scheduler.DelegatedComponent{
+			ID:         comp.GetID(),
+			ProviderID: comp.GetProviderID(),
+		})
+	}
+
+	for origin, components := range byOrigin {
+		callCtx, cancel := context.WithTimeout(ctx, reconcileTimeout)
+		actions, err := m.schedulerService.ReconcileRemoteComponents(callCtx, origin, "", components)
+		cancel()
+		switch {
+		case errors.Is(err, scheduler.ErrReconcileUnsupported):
+			logrus.Debugf("Node %s does not support reconciling its %d delegated component(s)", origin, len(components))
+			continue
+		case err != nil:
+			logrus.Warnf("Failed to reconcile %d delegated component(s) with node %s: %v", len(components), origin, err)
+			continue
+		}
+
+		for _, c := range components {
+			if actions[c.ID] != scheduler.ReconcileDelete {
+				continue
+			}
+			if err := m.UndeployComponent(ctx, c.ID); err != nil {
+				logrus.Warnf("Failed to delete orphaned component %s delegated by node %s: %v", c.ID, origin, err)
+				continue
+			}
+			logrus.Infof("Deleted orphaned component %s delegated by node %s", c.ID, origin)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+	commonpb "github.com/9triver/iarnet/internal/proto/common"
+	resourcepb "github.com/9triver/iarnet/internal/proto/resource"
+	schedulerpb "github.com/9triver/iarnet/internal/proto/resource/scheduler"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ComponentSummary 中 component 的状态
+const (
+	ComponentStateDeploying   = "deploying"   // 尚未放置到 provider
+	ComponentStateRunning     = "running"     // 所在 provider 已连接
+	ComponentStateUnreachable = "unreachable" // 所在 provider 已断开或已移除
+)
+
+var componentStates = []string{ComponentStateDeploying, ComponentStateRunning, ComponentStateUnreachable}
+
+// ErrListComponentsUnsupported 目标节点不支持列举 component
+var ErrListComponentsUnsupported = errors.New("node does not support listing components")
+
+// ComponentQuery 列举 component 的条件，各条件为空时不过滤
+type ComponentQuery struct {
+	AppID        string
+	Labels       map[string]string // 必须完全匹配的 component 标签
+	ProviderType string
+	State        string
+	Origin       string // 委托方节点 ID
+}
+
+// Validate 检查查询条件
+func (q ComponentQuery) Validate() error {
+	if q.State != "" && !slices.Contains(componentStates, q.State) {
+		return fmt.Errorf("unknown component state %q", q.State)
+	}
+	return nil
+}
+
+// Match 判断 component 是否满足查询条件
+func (q ComponentQuery) Match(c *ComponentSummary) bool {
+	return (q.AppID == "" || c.AppID == q.AppID) &&
+		(q.ProviderType == "" || c.ProviderType == q.ProviderType) &&
+		(q.State == "" || c.State == q.State) &&
+		(q.Origin == "" || c.Origin == q.Origin) &&
+		types.MatchLabels(q.Labels, c.Labels)
+}
+
+// ComponentSummary 列举结果中的 component
+type ComponentSummary struct {
+	ID           string
+	Image        string
+	AppID        string
+	Labels       map[string]string
+	NodeID       string
+	NodeName     string
+	ProviderID   string // 尚未放置时为空
+	ProviderType string
+	State        string
+	Resources    *types.Info // 部署时请求的资源
+	Evictable    bool
+	Origin       string // 委托方节点 ID，本节点发起的部署为空
+}
+
+// ComponentList 一个节点上满足条件的 component，按 component ID 排序
+type ComponentList struct {
+	NodeID     string
+	NodeName   string
+	Components []ComponentSummary
+}
+
+// ListComponents 列举本地节点上运行的 component
+func (s *service) ListComponents(ctx context.Context, query ComponentQuery) (*ComponentList, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	lister, ok := s.localResourceManager.(interface {
+		ListComponents(ctx context.Context, query ComponentQuery) (*ComponentList, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("local node does not list components")
+	}
+	return lister.ListComponents(ctx, query)
+}
+
+// ListRemoteComponents 列举同域其他节点上运行的 component
+func (s *service) ListRemoteComponents(ctx context.Context, nodeID, address string, query ComponentQuery) (*ComponentList, error) {
+	if nodeID == "" {
+		return nil, fmt.Errorf("node id is required")
+	}
+	if nodeID == s.localResourceManager.GetNodeID() {
+		return s.ListComponents(ctx, query)
+	}
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	targetAddress, err := s.resolveTargetAddress(nodeID, address)
+	if err != nil {
+		return nil, err
+	}
+	protocol, err := s.peerProtocol(nodeID)
+	if err != nil {
+		return nil, err
+	}
+	if !protocol.Supports(commonpb.CapComponentList) {
+		return nil, ErrListComponentsUnsupported
+	}
+	conn, err := s.dialPeer(nodeID, targetAddress, protocol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to target node: %w", err)
+	}
+	defer conn.Close()
+
+	client := schedulerpb.NewSchedulerServiceClient(conn)
+	protoResp, err := client.ListComponents(ctx, &schedulerpb.ListComponentsRequest{
+		AppId:        query.AppID,
+		Labels:       query.Labels,
+		ProviderType: query.ProviderType,
+		State:        query.State,
+		OriginNodeId: query.Origin,
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return nil, ErrListComponentsUnsupported
+		}
+		return nil, fmt.Errorf("failed to list components of remote node: %w", err)
+	}
+	if !protoResp.Success {
+		return nil, fmt.Errorf("remote node failed to list components: %s", protoResp.Error)
+	}
+
+	list := &ComponentList{
+		NodeID:     protoResp.NodeId,
+		NodeName:   protoResp.NodeName,
+		Components: make([]ComponentSummary, 0, len(protoResp.Components)),
+	}
+	for _, c := range protoResp.Components {
+		list.Components = append(list.Components, ComponentSummaryFromProto(c, protoResp.NodeId, protoResp.NodeName))
+	}
+	return list, nil
+}
+
+// ComponentSummaryFromProto 转换节点 nodeID 上的 component 摘要
+func ComponentSummaryFromProto(c *schedulerpb.ComponentSummary, nodeID, nodeName string) ComponentSummary {
+	return ComponentSummary{
+		ID:           c.ComponentId,
+		Image:        c.Image,
+		AppID:        c.AppId,
+		Labels:       c.Labels,
+		NodeID:       nodeID,
+		NodeName:     nodeName,
+		ProviderID:   c.ProviderId,
+		ProviderType: c.ProviderType,
+		State:        c.State,
+		Resources:    convertInfoFromProto(c.ResourceRequest),
+		Evictable:    c.Evictable,
+		Origin:       c.OriginNodeId,
+	}
+}
+
+// ToProto 转换为 proto 消息
+func (c *ComponentSummary) ToProto() *schedulerpb.ComponentSummary {
+	summary := &schedulerpb.ComponentSummary{
+		ComponentId:  c.ID,
+		Image:        c.Image,
+		AppId:        c.AppID,
+		Labels:       c.Labels,
+		ProviderId:   c.ProviderID,
+		ProviderType: c.ProviderType,
+		State:        c.State,
+		Evictable:    c.Evictable,
+		OriginNodeId: c.Origin,
+	}
+	if c.Resources != nil {
+		summary.ResourceRequest = &resourcepb.Info{Cpu: c.Resources.CPU, Memory: c.Resources.Memory, Gpu: c.Resources.GPU}
+	}
+	return summary
+}
+
+// convertInfoFromProto 转换资源请求，info 为 nil 时返回 nil
+func convertInfoFromProto(info *resourcepb.Info) *types.Info {
+	if info == nil {
+		return nil
+	}
+	return &types.Info{CPU: info.Cpu, Memory: info.Memory, GPU: info.Gpu}
+}
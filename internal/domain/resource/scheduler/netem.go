@@ -0,0 +1,82 @@
+package scheduler
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// LinkProfile 仿真的节点间链路特性
+type LinkProfile struct {
+	RTT       time.Duration // 往返时延
+	Jitter    time.Duration // 往返时延随机增减的最大值
+	Bandwidth int64         // 吞吐量上限（bytes/s），0 表示不限制
+}
+
+// NetworkEmulation 实验用的节点间网络仿真
+// 同一局域网内的节点互相调用几乎没有时延，跨节点与本地部署的对比因此失真；
+// 启用后对 scheduler 发往其他节点的每次调用注入往返时延，并按消息大小与带宽计算传输时间
+type NetworkEmulation struct {
+	Default LinkProfile
+	Peers   map[string]LinkProfile // 按目标节点 ID 或名称覆盖 Default
+}
+
+// profileFor 返回到目标节点的链路特性，节点 ID 优先于名称
+func (e *NetworkEmulation) profileFor(nodeID, nodeName string) LinkProfile {
+	if p, ok := e.Peers[nodeID]; ok {
+		return p
+	}
+	if p, ok := e.Peers[nodeName]; ok && nodeName != "" {
+		return p
+	}
+	return e.Default
+}
+
+// oneWay 单向传输 size 字节的耗时：半个往返时延（含抖动）加上传输时间
+func (p LinkProfile) oneWay(size int) time.Duration {
+	d := p.RTT / 2
+	if p.Jitter > 0 {
+		d += time.Duration(rand.Int64N(int64(p.Jitter)+1)) - p.Jitter/2
+	}
+	if p.Bandwidth > 0 {
+		d += time.Duration(float64(size) / float64(p.Bandwidth) * float64(time.Second))
+	}
+	return max(d, 0)
+}
+
+// interceptor 在请求发出前与响应返回后分别等待单向传输时间，ctx 结束时提前返回
+func (p LinkProfile) interceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if err := sleepCtx(ctx, p.oneWay(messageSize(req))); err != nil {
+			return err
+		}
+		if err := invoker(ctx, method, req, reply, cc, opts...); err != nil {
+			return err
+		}
+		return sleepCtx(ctx, p.oneWay(messageSize(reply)))
+	}
+}
+
+func messageSize(msg any) int {
+	if m, ok := msg.(proto.Message); ok {
+		return proto.Size(m)
+	}
+	return 0
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,242 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	commonpb "github.com/9triver/iarnet/internal/proto/common"
+	schedulerpb "github.com/9triver/iarnet/internal/proto/resource/scheduler"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// 分页大小
+const (
+	DefaultProviderPageSize = 100
+	MaxProviderPageSize     = 1000
+)
+
+// ProviderUtilization 可通过字段掩码选择的字段（与 proto 字段名一致），provider_id 始终返回
+const (
+	ProviderFieldName       = "provider_name"
+	ProviderFieldType       = "provider_type"
+	ProviderFieldStatus     = "status"
+	ProviderFieldComponents = "components"
+	ProviderFieldCapacity   = "capacity"
+	ProviderFieldError      = "error"
+)
+
+var providerFields = []string{
+	ProviderFieldName, ProviderFieldType, ProviderFieldStatus,
+	ProviderFieldComponents, ProviderFieldCapacity, ProviderFieldError,
+}
+
+// ErrListProvidersUnsupported 目标节点不支持分页列举 provider，调用方可退回 GetNodeUtilization
+var ErrListProvidersUnsupported = errors.New("node does not support listing providers")
+
+// ErrInvalidPageToken 分页令牌无法解析
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// ProviderListQuery 分页列举 provider 的查询条件
+type ProviderListQuery struct {
+	PageSize     int      // 每页数量，0 表示 DefaultProviderPageSize，超过 MaxProviderPageSize 时按上限返回
+	PageToken    string   // 上一页的 NextPageToken，为空表示第一页
+	Fields       []string // 字段掩码，为空时返回全部字段
+	SinceVersion uint64   // 非 0 时只返回该版本之后有变化的 provider
+}
+
+// ProviderList 一页 provider，按 provider ID 排序
+type ProviderList struct {
+	NodeID        string
+	NodeName      string
+	Providers     []ProviderUtilization
+	NextPageToken string   // 为空表示已是最后一页
+	Version       uint64   // 遍历完所有页后作为下一次增量查询的 SinceVersion
+	Removed       []string // 增量响应中自 SinceVersion 以来移除的 provider，只在第一页携带
+	Full          bool     // SinceVersion 早于节点保留的变更记录，返回的是全量结果
+}
+
+// PageCursor 分页令牌中记录的位置
+// 后续页沿用第一页的版本与增量起点，遍历期间发生的变化在下一次增量查询中返回
+type PageCursor struct {
+	Version uint64 // 第一页的版本
+	Since   uint64 // 增量起点，0 表示全量
+	After   string // 上一页最后一个 provider ID
+}
+
+// Encode 编码为分页令牌
+func (c PageCursor) Encode() string {
+	raw := strconv.FormatUint(c.Version, 10) + ":" + strconv.FormatUint(c.Since, 10) + ":" + c.After
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParsePageToken 解析分页令牌，空令牌返回 nil
+func ParsePageToken(token string) (*PageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	parts := strings.SplitN(string(raw), ":", 3)
+	if len(parts) != 3 || parts[2] == "" {
+		return nil, ErrInvalidPageToken
+	}
+	version, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	since, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	return &PageCursor{Version: version, Since: since, After: parts[2]}, nil
+}
+
+// PageLimit 规范化后的每页数量
+func (q ProviderListQuery) PageLimit() int {
+	switch {
+	case q.PageSize <= 0:
+		return DefaultProviderPageSize
+	case q.PageSize > MaxProviderPageSize:
+		return MaxProviderPageSize
+	default:
+		return q.PageSize
+	}
+}
+
+// validateFields 检查字段掩码中的字段名
+func validateFields(fields []string) error {
+	for _, f := range fields {
+		if f == "provider_id" {
+			continue
+		}
+		if !slices.Contains(providerFields, f) {
+			return fmt.Errorf("unknown provider field %q", f)
+		}
+	}
+	return nil
+}
+
+// applyFieldMask 清除掩码之外的字段，fields 为空时保留全部
+func applyFieldMask(providers []ProviderUtilization, fields []string) {
+	if len(fields) == 0 {
+		return
+	}
+	keep := func(f string) bool { return slices.Contains(fields, f) }
+	for i := range providers {
+		p := &providers[i]
+		if !keep(ProviderFieldName) {
+			p.ProviderName = ""
+		}
+		if !keep(ProviderFieldType) {
+			p.ProviderType = ""
+		}
+		if !keep(ProviderFieldStatus) {
+			p.Status = ""
+		}
+		if !keep(ProviderFieldComponents) {
+			p.Components = 0
+		}
+		if !keep(ProviderFieldCapacity) {
+			p.Capacity = nil
+		}
+		if !keep(ProviderFieldError) {
+			p.Error = ""
+		}
+	}
+}
+
+// ListProviders 分页列举本地节点的 provider
+func (s *service) ListProviders(ctx context.Context, query ProviderListQuery) (*ProviderList, error) {
+	if err := validateFields(query.Fields); err != nil {
+		return nil, err
+	}
+	lister, ok := s.localResourceManager.(interface {
+		ListProviders(ctx context.Context, query ProviderListQuery) (*ProviderList, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("local node does not list providers")
+	}
+	list, err := lister.ListProviders(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	applyFieldMask(list.Providers, query.Fields)
+	return list, nil
+}
+
+// ListRemoteProviders 分页列举同域其他节点的 provider
+func (s *service) ListRemoteProviders(ctx context.Context, nodeID, address string, query ProviderListQuery) (*ProviderList, error) {
+	if nodeID == "" {
+		return nil, fmt.Errorf("node id is required")
+	}
+	if nodeID == s.localResourceManager.GetNodeID() {
+		return s.ListProviders(ctx, query)
+	}
+	if err := validateFields(query.Fields); err != nil {
+		return nil, err
+	}
+
+	targetAddress, err := s.resolveTargetAddress(nodeID, address)
+	if err != nil {
+		return nil, err
+	}
+	protocol, err := s.peerProtocol(nodeID)
+	if err != nil {
+		return nil, err
+	}
+	if !protocol.Supports(commonpb.CapProviderList) {
+		return nil, ErrListProvidersUnsupported
+	}
+	conn, err := s.dialPeer(nodeID, targetAddress, protocol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to target node: %w", err)
+	}
+	defer conn.Close()
+
+	client := schedulerpb.NewSchedulerServiceClient(conn)
+	protoResp, err := client.ListProviders(ctx, &schedulerpb.ListProvidersRequest{
+		PageSize:     int32(query.PageSize),
+		PageToken:    query.PageToken,
+		Fields:       query.Fields,
+		SinceVersion: query.SinceVersion,
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return nil, ErrListProvidersUnsupported
+		}
+		return nil, fmt.Errorf("failed to list providers of remote node: %w", err)
+	}
+	if !protoResp.Success {
+		return nil, fmt.Errorf("remote node failed to list providers: %s", protoResp.Error)
+	}
+
+	list := &ProviderList{
+		NodeID:        protoResp.NodeId,
+		NodeName:      protoResp.NodeName,
+		Providers:     make([]ProviderUtilization, 0, len(protoResp.Providers)),
+		NextPageToken: protoResp.NextPageToken,
+		Version:       protoResp.Version,
+		Removed:       protoResp.RemovedProviderIds,
+		Full:          protoResp.Full,
+	}
+	for _, p := range protoResp.Providers {
+		list.Providers = append(list.Providers, ProviderUtilization{
+			ProviderID:   p.ProviderId,
+			ProviderName: p.ProviderName,
+			ProviderType: p.ProviderType,
+			Status:       p.Status,
+			Components:   int(p.Components),
+			Capacity:     convertCapacityFromProto(p.Capacity),
+			Error:        p.Error,
+		})
+	}
+	return list, nil
+}
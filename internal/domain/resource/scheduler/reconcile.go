@@ -0,0 +1,129 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	commonpb "github.com/9triver/iarnet/internal/proto/common"
+	schedulerpb "github.com/9triver/iarnet/internal/proto/resource/scheduler"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrReconcileUnsupported 委托方节点不支持 component 对账
+var ErrReconcileUnsupported = errors.New("node does not support reconciling components")
+
+// ReconcileAction 委托方对上报的 component 的处理方式
+type ReconcileAction int
+
+const (
+	ReconcilePending ReconcileAction = iota // 委托方的部署仍在进行，下次对账时再确认
+	ReconcileAdopt                          // 委托方登记了该 component，保留
+	ReconcileDelete                         // 委托方没有该 component 的记录，删除
+)
+
+func (a ReconcileAction) String() string {
+	switch a {
+	case ReconcileAdopt:
+		return "adopt"
+	case ReconcileDelete:
+		return "delete"
+	}
+	return "pending"
+}
+
+// DelegatedComponent 由其他节点委托部署到本节点的 component
+type DelegatedComponent struct {
+	ID         string
+	ProviderID string
+}
+
+// ReconcileComponents 由本地节点确认 nodeID 上报的、由本节点委托部署的 component
+// 返回 component ID -> 处理方式，未列出的 component 按 ReconcilePending 处理
+func (s *service) ReconcileComponents(ctx context.Context, nodeID string, components []DelegatedComponent) (map[string]ReconcileAction, error) {
+	if nodeID == "" {
+		return nil, fmt.Errorf("node id is required")
+	}
+	reconciler, ok := s.localResourceManager.(interface {
+		ReconcileComponents(ctx context.Context, nodeID string, components []DelegatedComponent) (map[string]ReconcileAction, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("local node does not reconcile components")
+	}
+	return reconciler.ReconcileComponents(ctx, nodeID, components)
+}
+
+// ReconcileRemoteComponents 向委托方节点上报本节点上由其委托部署的 component，返回委托方给出的处理方式
+func (s *service) ReconcileRemoteComponents(ctx context.Context, originNodeID, address string, components []DelegatedComponent) (map[string]ReconcileAction, error) {
+	if originNodeID == "" {
+		return nil, fmt.Errorf("origin node id is required")
+	}
+	targetAddress, err := s.resolveTargetAddress(originNodeID, address)
+	if err != nil {
+		return nil, err
+	}
+	protocol, err := s.peerProtocol(originNodeID)
+	if err != nil {
+		return nil, err
+	}
+	if !protocol.Supports(commonpb.CapComponentOrigin) {
+		return nil, ErrReconcileUnsupported
+	}
+	conn, err := s.dialPeer(originNodeID, targetAddress, protocol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to origin node: %w", err)
+	}
+	defer conn.Close()
+
+	protoReq := &schedulerpb.ReconcileComponentsRequest{
+		NodeId:     s.localResourceManager.GetNodeID(),
+		Components: make([]*schedulerpb.DelegatedComponent, 0, len(components)),
+	}
+	for _, c := range components {
+		protoReq.Components = append(protoReq.Components, &schedulerpb.DelegatedComponent{
+			ComponentId: c.ID,
+			ProviderId:  c.ProviderID,
+		})
+	}
+
+	client := schedulerpb.NewSchedulerServiceClient(conn)
+	protoResp, err := client.ReconcileComponents(ctx, protoReq)
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return nil, ErrReconcileUnsupported
+		}
+		return nil, fmt.Errorf("failed to reconcile components with origin node: %w", err)
+	}
+	if !protoResp.Success {
+		return nil, fmt.Errorf("origin node failed to reconcile components: %s", protoResp.Error)
+	}
+
+	actions := make(map[string]ReconcileAction, len(protoResp.Actions))
+	for id, action := range protoResp.Actions {
+		actions[id] = ReconcileActionFromProto(action)
+	}
+	return actions, nil
+}
+
+// ReconcileActionFromProto 转换处理方式，未知的取值按 ReconcilePending 处理
+func ReconcileActionFromProto(action schedulerpb.ReconcileAction) ReconcileAction {
+	switch action {
+	case schedulerpb.ReconcileAction_RECONCILE_ACTION_ADOPT:
+		return ReconcileAdopt
+	case schedulerpb.ReconcileAction_RECONCILE_ACTION_DELETE:
+		return ReconcileDelete
+	}
+	return ReconcilePending
+}
+
+// ToProto 转换为 proto 取值
+func (a ReconcileAction) ToProto() schedulerpb.ReconcileAction {
+	switch a {
+	case ReconcileAdopt:
+		return schedulerpb.ReconcileAction_RECONCILE_ACTION_ADOPT
+	case ReconcileDelete:
+		return schedulerpb.ReconcileAction_RECONCILE_ACTION_DELETE
+	}
+	return schedulerpb.ReconcileAction_RECONCILE_ACTION_PENDING
+}
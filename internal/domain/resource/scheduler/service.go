@@ -2,16 +2,25 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/9triver/iarnet/internal/domain/resource/accounting"
 	"github.com/9triver/iarnet/internal/domain/resource/component"
 	"github.com/9triver/iarnet/internal/domain/resource/discovery"
 	"github.com/9triver/iarnet/internal/domain/resource/provider"
 	"github.com/9triver/iarnet/internal/domain/resource/types"
+	commonpb "github.com/9triver/iarnet/internal/proto/common"
 	resourcepb "github.com/9triver/iarnet/internal/proto/resource"
 	schedulerpb "github.com/9triver/iarnet/internal/proto/resource/scheduler"
+	"github.com/9triver/iarnet/internal/util/compress"
+	"github.com/9triver/iarnet/internal/util/identity"
+	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
 // Service 提供远程调度服务
@@ -21,6 +30,106 @@ type Service interface {
 
 	// GetDeploymentStatus 获取部署状态
 	GetDeploymentStatus(ctx context.Context, componentID string, nodeID string) (*DeploymentStatus, error)
+
+	// ProposeDeployment 询问本地或远程节点能否部署 component，不实际部署
+	// 目标节点不支持探测时返回 ErrProposeUnsupported
+	ProposeDeployment(ctx context.Context, req *ProposeRequest) (*ProposeResponse, error)
+
+	// GetNodeUtilization 获取节点聚合后的资源利用率及各 provider 明细
+	// nodeID 为空时返回本地节点
+	GetNodeUtilization(ctx context.Context, nodeID string) (*NodeUtilization, error)
+
+	// UndeployComponent 删除本地或远程节点上由 DeployComponent 部署的 component
+	// 目标节点不支持时返回 ErrUndeployUnsupported
+	UndeployComponent(ctx context.Context, req *UndeployRequest) error
+
+	// ListProviders 分页列举本地节点的 provider，支持字段掩码与增量
+	ListProviders(ctx context.Context, query ProviderListQuery) (*ProviderList, error)
+
+	// ListRemoteProviders 分页列举同域其他节点的 provider，address 为空时从 discovery 查找
+	// 目标节点不支持时返回 ErrListProvidersUnsupported
+	ListRemoteProviders(ctx context.Context, nodeID, address string, query ProviderListQuery) (*ProviderList, error)
+
+	// ListComponents 按应用、标签、provider 类型与状态列举本地节点上运行的 component
+	ListComponents(ctx context.Context, query ComponentQuery) (*ComponentList, error)
+
+	// ListRemoteComponents 列举同域其他节点上运行的 component，address 为空时从 discovery 查找
+	// 目标节点不支持时返回 ErrListComponentsUnsupported
+	ListRemoteComponents(ctx context.Context, nodeID, address string, query ComponentQuery) (*ComponentList, error)
+
+	// ReconcileComponents 确认 nodeID 上报的、由本地节点委托部署的 component 应保留还是删除
+	ReconcileComponents(ctx context.Context, nodeID string, components []DelegatedComponent) (map[string]ReconcileAction, error)
+
+	// ReconcileRemoteComponents 向委托方节点上报本地节点上由其委托部署的 component，address 为空时从 discovery 查找
+	// 委托方节点不支持时返回 ErrReconcileUnsupported
+	ReconcileRemoteComponents(ctx context.Context, originNodeID, address string, components []DelegatedComponent) (map[string]ReconcileAction, error)
+
+	// TransferComponents 处理其他节点的请求，将本地节点上由已离开的节点委托部署的 component 转移给接管节点
+	// 调用方必须已签名且就是接管节点
+	TransferComponents(ctx context.Context, req TransferRequest) (*TransferResult, error)
+
+	// TransferRemoteComponents 请求同域其他节点转移其上由已离开的节点委托部署的 component，address 为空时从 discovery 查找
+	// 目标节点不支持时返回 ErrTransferUnsupported
+	TransferRemoteComponents(ctx context.Context, nodeID, address string, req TransferRequest) (*TransferResult, error)
+
+	// SetNetworkEmulation 设置节点间网络仿真（实验用），nil 表示关闭
+	SetNetworkEmulation(emulation *NetworkEmulation)
+
+	// SetIdentity 设置节点身份密钥，发往其他节点的调用将被签名，nil 表示不签名
+	SetIdentity(id *identity.Identity)
+
+	// SetUpstreamPolicy 设置委托部署上游地址的校验策略，nil 表示使用默认策略
+	SetUpstreamPolicy(policy *UpstreamPolicy)
+}
+
+// ErrProposeUnsupported 目标节点不支持部署探测，调用方可直接提交部署
+var ErrProposeUnsupported = errors.New("node does not support deployment proposals")
+
+// ErrUndeployUnsupported 目标节点不支持删除 component，也不接受调用方指定的 component ID
+var ErrUndeployUnsupported = errors.New("node does not support undeploying components")
+
+// UndeployRequest 删除 component 请求
+type UndeployRequest struct {
+	ComponentID   string
+	TargetNodeID  string // 目标节点 ID，为空则删除本地 component
+	TargetAddress string // 目标节点地址（可选）
+}
+
+// ProposeRequest 部署探测请求
+type ProposeRequest struct {
+	RuntimeEnv      types.RuntimeEnv
+	ResourceRequest *types.Info
+	TargetNodeID    string // 目标节点 ID，为空则探测本地节点
+	TargetAddress   string // 目标节点地址（可选）
+}
+
+// ProposeResponse 部署探测响应
+type ProposeResponse struct {
+	Accepted  bool
+	Reason    string      // 拒绝原因
+	Available *types.Info // 选中 provider 的可用资源
+	NodeID    string
+	NodeName  string
+}
+
+// NodeUtilization 节点资源利用率
+// Capacity 为所有已连接 provider 的聚合值，Providers 为各 provider 明细（含未连接的 provider）
+type NodeUtilization struct {
+	NodeID    string
+	NodeName  string
+	Capacity  *types.Capacity
+	Providers []ProviderUtilization
+}
+
+// ProviderUtilization 单个 provider 的资源利用率
+type ProviderUtilization struct {
+	ProviderID   string
+	ProviderName string
+	ProviderType string
+	Status       string
+	Components   int             // 部署在该 provider 上的 component 数
+	Capacity     *types.Capacity // 未连接或获取失败时为 nil
+	Error        string          // 获取容量失败时的错误信息
 }
 
 // DeployRequest 部署请求
@@ -32,6 +141,15 @@ type DeployRequest struct {
 	UpstreamZMQAddress    string
 	UpstreamStoreAddress  string
 	UpstreamLoggerAddress string
+	UpstreamToken         string                    // 委托方为该 component 签发的上游令牌（可选）
+	Affinity              *provider.Affinity        // 会话亲和（可选），远程部署时一并传给目标节点
+	ComponentID           string                    // 调用方指定的 component ID（可选），取消部署时据此回滚
+	DataSources           []provider.DataSource     // 启动前预置的数据（可选），store 对象从 UpstreamStoreAddress 拉取
+	SecurityContext       *provider.SecurityContext // 容器安全配置（可选）
+	Sidecars              []provider.Sidecar        // 与主容器同机部署的 sidecar（可选），ResourceRequest 为合计
+	AppID                 string                    // 所属应用（可选），目标节点据此核算并响应按应用的查询
+	Labels                map[string]string         // component 标签（可选）
+	OriginNodeID          string                    // 委托方节点 ID（可选），目标节点据此定期向委托方对账
 }
 
 // DeployResponse 部署响应
@@ -74,6 +192,15 @@ type service struct {
 
 	// Discovery 服务（用于查找远程节点）
 	discoveryService discovery.Service
+
+	// 节点间网络仿真（实验用），为 nil 时不注入时延
+	netem *NetworkEmulation
+
+	// 节点身份密钥，为 nil 时不签名
+	identity *identity.Identity
+
+	// 委托部署上游地址的校验策略，为 nil 时使用默认策略
+	upstreamPolicy *UpstreamPolicy
 }
 
 // NewService 创建调度服务
@@ -113,13 +240,31 @@ func (s *service) DeployComponent(ctx context.Context, req *DeployRequest) (*Dep
 func (s *service) deployLocally(ctx context.Context, req *DeployRequest) (*DeployResponse, error) {
 	localCtx := ctx
 	if req.UpstreamZMQAddress != "" || req.UpstreamStoreAddress != "" || req.UpstreamLoggerAddress != "" {
+		if err := s.validateUpstreams(ctx, req); err != nil {
+			logrus.Warnf("Rejected delegated deployment: %v", err)
+			return &DeployResponse{
+				Success: false,
+				Error:   err.Error(),
+			}, nil
+		}
 		override := &provider.DeploymentEnvOverride{
 			ZMQAddress:    req.UpstreamZMQAddress,
 			StoreAddress:  req.UpstreamStoreAddress,
 			LoggerAddress: req.UpstreamLoggerAddress,
+			Token:         req.UpstreamToken,
 		}
 		localCtx = provider.WithDeploymentEnvOverride(ctx, override)
 	}
+	localCtx = provider.WithAffinity(localCtx, req.Affinity)
+	localCtx = component.WithComponentID(localCtx, req.ComponentID)
+	localCtx = provider.WithDataSources(localCtx, req.DataSources)
+	localCtx = provider.WithSecurityContext(localCtx, req.SecurityContext)
+	localCtx = provider.WithSidecars(localCtx, req.Sidecars)
+	localCtx = component.WithComponentLabels(localCtx, req.Labels)
+	localCtx = component.WithComponentOrigin(localCtx, req.OriginNodeID)
+	if req.AppID != "" {
+		localCtx = accounting.WithApplication(localCtx, req.AppID)
+	}
 
 	comp, err := s.localResourceManager.DeployComponent(localCtx, req.RuntimeEnv, req.ResourceRequest)
 	if err != nil {
@@ -140,50 +285,24 @@ func (s *service) deployLocally(ctx context.Context, req *DeployRequest) (*Deplo
 
 // deployRemotely 在远程节点部署
 func (s *service) deployRemotely(ctx context.Context, req *DeployRequest) (*DeployResponse, error) {
-	// 获取目标节点地址
-	targetAddress := req.TargetAddress
-	if targetAddress == "" {
-		// 从 discovery service 获取节点地址
-		if s.discoveryService == nil {
-			return &DeployResponse{
-				Success: false,
-				Error:   "discovery service is not available",
-			}, nil
-		}
-
-		// 查找目标节点（通过已知节点列表）
-		knownNodes := s.discoveryService.GetKnownNodes()
-		var targetNode *discovery.PeerNode
-		for _, node := range knownNodes {
-			if node.NodeID == req.TargetNodeID {
-				targetNode = node
-				break
-			}
-		}
-
-		if targetNode == nil {
-			return &DeployResponse{
-				Success: false,
-				Error:   fmt.Sprintf("target node %s not found", req.TargetNodeID),
-			}, nil
-		}
-
-		if targetNode.SchedulerAddress != "" {
-			targetAddress = targetNode.SchedulerAddress
-		} else {
-			targetAddress = targetNode.Address
-		}
+	targetAddress, err := s.resolveTargetAddress(req.TargetNodeID, req.TargetAddress)
+	if err != nil {
+		return &DeployResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
 	}
 
-	if targetAddress == "" {
+	protocol, err := s.peerProtocol(req.TargetNodeID)
+	if err != nil {
 		return &DeployResponse{
 			Success: false,
-			Error:   "target address is empty",
+			Error:   err.Error(),
 		}, nil
 	}
 
 	// 连接到远程节点的 scheduler RPC 服务
-	conn, err := grpc.NewClient(targetAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := s.dialPeer(req.TargetNodeID, targetAddress, protocol)
 	if err != nil {
 		return &DeployResponse{
 			Success: false,
@@ -198,15 +317,67 @@ func (s *service) deployRemotely(ctx context.Context, req *DeployRequest) (*Depl
 	protoReq := &schedulerpb.DeployComponentRequest{
 		RuntimeEnv: string(req.RuntimeEnv),
 		ResourceRequest: &resourcepb.Info{
-			Cpu:    req.ResourceRequest.CPU,
-			Memory: req.ResourceRequest.Memory,
-			Gpu:    req.ResourceRequest.GPU,
-			Tags:   req.ResourceRequest.Tags,
+			Cpu:          req.ResourceRequest.CPU,
+			Memory:       req.ResourceRequest.Memory,
+			Gpu:          req.ResourceRequest.GPU,
+			Tags:         req.ResourceRequest.Tags,
+			NodeSelector: req.ResourceRequest.NodeSelector,
+			Stream:       req.ResourceRequest.Stream,
 		},
 		TargetNodeId:          "", // 远程节点本地部署，不需要再指定目标
 		UpstreamZmqAddress:    req.UpstreamZMQAddress,
 		UpstreamStoreAddress:  req.UpstreamStoreAddress,
 		UpstreamLoggerAddress: req.UpstreamLoggerAddress,
+		UpstreamToken:         req.UpstreamToken,
+		// 旧版节点会忽略应用与标签，component 仍然部署，但在按应用、标签的查询中缺少这些信息
+		AppId:  req.AppID,
+		Labels: req.Labels,
+	}
+	// 旧版节点会忽略会话亲和字段，此时仍然部署，但亲和只在本节点侧生效
+	if req.Affinity != nil && !protocol.Supports(commonpb.CapAffinity) {
+		logrus.Warnf("Node %s does not support session affinity, deploying without affinity key %s", req.TargetNodeID, req.Affinity.Key)
+	} else if req.Affinity != nil {
+		protoReq.AffinityKey = req.Affinity.Key
+		protoReq.AffinityScope = string(req.Affinity.Scope)
+		protoReq.AffinityTtlSeconds = int64(req.Affinity.TTL / time.Second)
+	}
+	// 预置数据无法降级，旧版节点会忽略该字段，因此不向其部署
+	if len(req.DataSources) > 0 {
+		if !protocol.Supports(commonpb.CapDataStaging) {
+			return &DeployResponse{
+				Success: false,
+				Error:   fmt.Sprintf("node %s does not support data staging", req.TargetNodeID),
+			}, nil
+		}
+		protoReq.DataSources = provider.DataSourcesToProto(req.DataSources)
+	}
+	// 安全配置同样无法降级，旧版节点会忽略该字段并以 provider 默认配置运行
+	if req.SecurityContext != nil {
+		if !protocol.Supports(commonpb.CapSecurity) {
+			return &DeployResponse{
+				Success: false,
+				Error:   fmt.Sprintf("node %s does not support security contexts", req.TargetNodeID),
+			}, nil
+		}
+		protoReq.SecurityContext = req.SecurityContext.ToProto()
+	}
+	// 旧版节点会忽略 sidecar 并按合计资源只部署主容器，因此不向其部署
+	if len(req.Sidecars) > 0 {
+		if !protocol.Supports(commonpb.CapSidecars) {
+			return &DeployResponse{
+				Success: false,
+				Error:   fmt.Sprintf("node %s does not support sidecars", req.TargetNodeID),
+			}, nil
+		}
+		protoReq.Sidecars = provider.SidecarsToProto(req.Sidecars)
+	}
+	// 旧版节点会忽略指定的 component ID，此时部署被取消后无法回滚
+	if protocol.Supports(commonpb.CapUndeployComponent) {
+		protoReq.ComponentId = req.ComponentID
+	}
+	// 委托方按指定的 component ID 对账，旧版节点不记录委托方，提交后响应丢失时遗留的 component 只能手动清理
+	if protocol.Supports(commonpb.CapComponentOrigin) {
+		protoReq.OriginNodeId = req.OriginNodeID
 	}
 
 	protoResp, err := client.DeployComponent(ctx, protoReq)
@@ -238,6 +409,298 @@ func (s *service) deployRemotely(ctx context.Context, req *DeployRequest) (*Depl
 	}, nil
 }
 
+// resolveTargetAddress 获取目标节点的 scheduler RPC 地址，未指定地址时从 discovery 已知节点中查找
+func (s *service) resolveTargetAddress(targetNodeID, targetAddress string) (string, error) {
+	if targetAddress != "" {
+		return targetAddress, nil
+	}
+	if s.discoveryService == nil {
+		return "", fmt.Errorf("discovery service is not available")
+	}
+
+	// 查找目标节点（通过已知节点列表）
+	for _, node := range s.discoveryService.GetKnownNodes() {
+		if node.NodeID != targetNodeID {
+			continue
+		}
+		if node.SchedulerAddress != "" {
+			return node.SchedulerAddress, nil
+		}
+		if node.Address != "" {
+			return node.Address, nil
+		}
+		return "", fmt.Errorf("target address is empty")
+	}
+	return "", fmt.Errorf("target node %s not found", targetNodeID)
+}
+
+// peerProtocol 按目标节点通过 gossip 声明的协议信息进行协商
+// 目标节点未知或未声明协议时按 legacy 处理，版本不兼容时返回错误
+func (s *service) peerProtocol(targetNodeID string) (*commonpb.Negotiated, error) {
+	var remote *commonpb.ProtocolInfo
+	if s.discoveryService != nil && targetNodeID != "" {
+		for _, node := range s.discoveryService.GetKnownNodes() {
+			if node.NodeID == targetNodeID {
+				remote = node.Protocol()
+				break
+			}
+		}
+	}
+	protocol, err := commonpb.Negotiate(commonpb.NewProtocolInfo(commonpb.NodeCapabilities...), remote)
+	if err != nil {
+		return nil, fmt.Errorf("node %s is incompatible: %w", targetNodeID, err)
+	}
+	return protocol, nil
+}
+
+// dialPeer 连接远程节点的 scheduler 服务，按协商的压缩能力决定是否压缩请求
+// 设置了节点身份时为每次调用签名；启用网络仿真时按目标节点的链路特性注入时延
+func (s *service) dialPeer(targetNodeID, targetAddress string, protocol *commonpb.Negotiated) (*grpc.ClientConn, error) {
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	opts = append(opts, compress.DialOptions(compress.ChannelScheduler, protocol)...)
+	var interceptors []grpc.UnaryClientInterceptor
+	if s.identity != nil {
+		interceptors = append(interceptors, s.identity.UnaryClientInterceptor(targetNodeID))
+	}
+	if s.netem != nil {
+		link := s.netem.profileFor(targetNodeID, s.peerName(targetNodeID))
+		interceptors = append(interceptors, link.interceptor())
+	}
+	if len(interceptors) > 0 {
+		opts = append(opts, grpc.WithChainUnaryInterceptor(interceptors...))
+	}
+	return grpc.NewClient(targetAddress, opts...)
+}
+
+// SetUpstreamPolicy 设置委托部署上游地址的校验策略，nil 表示使用默认策略
+func (s *service) SetUpstreamPolicy(policy *UpstreamPolicy) {
+	s.upstreamPolicy = policy
+}
+
+// SetIdentity 设置节点身份密钥，nil 表示不签名
+func (s *service) SetIdentity(id *identity.Identity) {
+	s.identity = id
+}
+
+// SetNetworkEmulation 设置节点间网络仿真，nil 表示关闭
+func (s *service) SetNetworkEmulation(emulation *NetworkEmulation) {
+	s.netem = emulation
+}
+
+// peerName 按 gossip 信息查找节点名称，未知时返回空
+func (s *service) peerName(nodeID string) string {
+	if s.discoveryService == nil || nodeID == "" {
+		return ""
+	}
+	for _, node := range s.discoveryService.GetKnownNodes() {
+		if node.NodeID == nodeID {
+			return node.NodeName
+		}
+	}
+	return ""
+}
+
+// ProposeDeployment 询问节点能否部署 component
+func (s *service) ProposeDeployment(ctx context.Context, req *ProposeRequest) (*ProposeResponse, error) {
+	if req == nil || req.ResourceRequest == nil {
+		return nil, fmt.Errorf("resource request is required")
+	}
+	if req.TargetNodeID == "" {
+		return s.proposeLocally(ctx, req)
+	}
+	return s.proposeRemotely(ctx, req)
+}
+
+// proposeLocally 判断本地节点能否部署
+func (s *service) proposeLocally(ctx context.Context, req *ProposeRequest) (*ProposeResponse, error) {
+	proposer, ok := s.localResourceManager.(interface {
+		ProposeDeployment(ctx context.Context, runtimeEnv types.RuntimeEnv, resourceRequest *types.Info) (*types.Info, error)
+	})
+	if !ok {
+		return nil, ErrProposeUnsupported
+	}
+
+	resp := &ProposeResponse{
+		NodeID:   s.localResourceManager.GetNodeID(),
+		NodeName: s.localResourceManager.GetNodeName(),
+	}
+	available, err := proposer.ProposeDeployment(ctx, req.RuntimeEnv, req.ResourceRequest)
+	if err != nil {
+		resp.Reason = err.Error()
+		return resp, nil
+	}
+	resp.Accepted = true
+	resp.Available = available
+	return resp, nil
+}
+
+// proposeRemotely 询问远程节点能否部署
+func (s *service) proposeRemotely(ctx context.Context, req *ProposeRequest) (*ProposeResponse, error) {
+	targetAddress, err := s.resolveTargetAddress(req.TargetNodeID, req.TargetAddress)
+	if err != nil {
+		return nil, err
+	}
+	protocol, err := s.peerProtocol(req.TargetNodeID)
+	if err != nil {
+		return nil, err
+	}
+	if !protocol.Supports(commonpb.CapProposeDeployment) {
+		return nil, ErrProposeUnsupported
+	}
+
+	conn, err := s.dialPeer(req.TargetNodeID, targetAddress, protocol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to target node: %w", err)
+	}
+	defer conn.Close()
+
+	client := schedulerpb.NewSchedulerServiceClient(conn)
+	protoResp, err := client.ProposeDeployment(ctx, &schedulerpb.ProposeDeploymentRequest{
+		RuntimeEnv: string(req.RuntimeEnv),
+		ResourceRequest: &resourcepb.Info{
+			Cpu:          req.ResourceRequest.CPU,
+			Memory:       req.ResourceRequest.Memory,
+			Gpu:          req.ResourceRequest.GPU,
+			Tags:         req.ResourceRequest.Tags,
+			NodeSelector: req.ResourceRequest.NodeSelector,
+			Stream:       req.ResourceRequest.Stream,
+		},
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return nil, ErrProposeUnsupported
+		}
+		return nil, fmt.Errorf("failed to propose deployment to remote node: %w", err)
+	}
+
+	resp := &ProposeResponse{
+		Accepted: protoResp.Accepted,
+		Reason:   protoResp.Reason,
+		NodeID:   protoResp.NodeId,
+		NodeName: protoResp.NodeName,
+	}
+	if a := protoResp.Available; a != nil {
+		resp.Available = &types.Info{CPU: a.Cpu, Memory: a.Memory, GPU: a.Gpu}
+	}
+	return resp, nil
+}
+
+// UndeployComponent 删除 component
+func (s *service) UndeployComponent(ctx context.Context, req *UndeployRequest) error {
+	if req == nil || req.ComponentID == "" {
+		return fmt.Errorf("component id is required")
+	}
+	if req.TargetNodeID == "" {
+		return s.undeployLocally(ctx, req.ComponentID)
+	}
+	return s.undeployRemotely(ctx, req)
+}
+
+// undeployLocally 删除本地节点上的 component
+func (s *service) undeployLocally(ctx context.Context, componentID string) error {
+	undeployer, ok := s.localResourceManager.(interface {
+		UndeployComponent(ctx context.Context, componentID string) error
+	})
+	if !ok {
+		return ErrUndeployUnsupported
+	}
+	return undeployer.UndeployComponent(ctx, componentID)
+}
+
+// undeployRemotely 删除远程节点上的 component
+func (s *service) undeployRemotely(ctx context.Context, req *UndeployRequest) error {
+	targetAddress, err := s.resolveTargetAddress(req.TargetNodeID, req.TargetAddress)
+	if err != nil {
+		return err
+	}
+	protocol, err := s.peerProtocol(req.TargetNodeID)
+	if err != nil {
+		return err
+	}
+	if !protocol.Supports(commonpb.CapUndeployComponent) {
+		return ErrUndeployUnsupported
+	}
+
+	conn, err := s.dialPeer(req.TargetNodeID, targetAddress, protocol)
+	if err != nil {
+		return fmt.Errorf("failed to connect to target node: %w", err)
+	}
+	defer conn.Close()
+
+	client := schedulerpb.NewSchedulerServiceClient(conn)
+	protoResp, err := client.UndeployComponent(ctx, &schedulerpb.UndeployComponentRequest{
+		ComponentId: req.ComponentID,
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return ErrUndeployUnsupported
+		}
+		return fmt.Errorf("failed to undeploy component on remote node: %w", err)
+	}
+	if !protoResp.Success {
+		return fmt.Errorf("remote node failed to undeploy component: %s", protoResp.Error)
+	}
+	return nil
+}
+
+// GetNodeUtilization 获取节点资源利用率
+func (s *service) GetNodeUtilization(ctx context.Context, nodeID string) (*NodeUtilization, error) {
+	if nodeID == "" || nodeID == s.localResourceManager.GetNodeID() {
+		reporter, ok := s.localResourceManager.(interface {
+			GetNodeUtilization(ctx context.Context) *NodeUtilization
+		})
+		if !ok {
+			return nil, fmt.Errorf("local node does not report utilization")
+		}
+		return reporter.GetNodeUtilization(ctx), nil
+	}
+
+	targetAddress, err := s.resolveTargetAddress(nodeID, "")
+	if err != nil {
+		return nil, err
+	}
+	protocol, err := s.peerProtocol(nodeID)
+	if err != nil {
+		return nil, err
+	}
+	if !protocol.Supports(commonpb.CapNodeUtilization) {
+		return nil, fmt.Errorf("node %s does not report utilization (protocol v%d)", nodeID, protocol.Version)
+	}
+	conn, err := s.dialPeer(nodeID, targetAddress, protocol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to target node: %w", err)
+	}
+	defer conn.Close()
+
+	client := schedulerpb.NewSchedulerServiceClient(conn)
+	protoResp, err := client.GetNodeUtilization(ctx, &schedulerpb.GetNodeUtilizationRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get utilization from remote node: %w", err)
+	}
+	if !protoResp.Success {
+		return nil, fmt.Errorf("remote node failed to report utilization: %s", protoResp.Error)
+	}
+
+	utilization := &NodeUtilization{
+		NodeID:    protoResp.NodeId,
+		NodeName:  protoResp.NodeName,
+		Capacity:  convertCapacityFromProto(protoResp.Capacity),
+		Providers: make([]ProviderUtilization, 0, len(protoResp.Providers)),
+	}
+	for _, p := range protoResp.Providers {
+		utilization.Providers = append(utilization.Providers, ProviderUtilization{
+			ProviderID:   p.ProviderId,
+			ProviderName: p.ProviderName,
+			ProviderType: p.ProviderType,
+			Status:       p.Status,
+			Components:   int(p.Components),
+			Capacity:     convertCapacityFromProto(p.Capacity),
+			Error:        p.Error,
+		})
+	}
+	return utilization, nil
+}
+
 // GetDeploymentStatus 获取部署状态
 func (s *service) GetDeploymentStatus(ctx context.Context, componentID string, nodeID string) (*DeploymentStatus, error) {
 	// TODO: 实现获取部署状态的逻辑
@@ -267,3 +730,21 @@ func convertComponentInfoFromProto(info *schedulerpb.ComponentInfo) *component.C
 	comp := component.NewComponent(info.ComponentId, info.Image, usage)
 	return comp
 }
+
+// convertCapacityFromProto 转换 proto 资源容量，capacity 为 nil 时返回 nil
+func convertCapacityFromProto(capacity *resourcepb.Capacity) *types.Capacity {
+	if capacity == nil {
+		return nil
+	}
+	convert := func(info *resourcepb.Info) *types.Info {
+		if info == nil {
+			return &types.Info{}
+		}
+		return &types.Info{CPU: info.Cpu, Memory: info.Memory, GPU: info.Gpu}
+	}
+	return &types.Capacity{
+		Total:     convert(capacity.Total),
+		Used:      convert(capacity.Used),
+		Available: convert(capacity.Available),
+	}
+}
@@ -0,0 +1,181 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/9triver/iarnet/internal/domain/resource/discovery"
+	commonpb "github.com/9triver/iarnet/internal/proto/common"
+	schedulerpb "github.com/9triver/iarnet/internal/proto/resource/scheduler"
+	"github.com/9triver/iarnet/internal/util/identity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrTransferUnsupported 目标节点不支持转移 component 所有权
+var ErrTransferUnsupported = errors.New("node does not support transferring components")
+
+// TransferRequest component 所有权转移请求
+type TransferRequest struct {
+	FromNodeID            string // 原委托方节点 ID，只有该节点已不存活时才转移
+	ToNodeID              string // 接管节点 ID
+	UpstreamZMQAddress    string
+	UpstreamStoreAddress  string
+	UpstreamLoggerAddress string
+	UpstreamTokens        map[string]string // component ID -> 接管节点签发的上游令牌（可选）
+	ComponentIDs          []string          // 要转移的 component
+}
+
+// Validate 检查转移请求
+func (r *TransferRequest) Validate() error {
+	switch {
+	case r.FromNodeID == "":
+		return fmt.Errorf("from node id is required")
+	case r.ToNodeID == "":
+		return fmt.Errorf("to node id is required")
+	case r.FromNodeID == r.ToNodeID:
+		return fmt.Errorf("from and to node must differ")
+	case len(r.ComponentIDs) == 0:
+		return fmt.Errorf("component ids are required")
+	}
+	return nil
+}
+
+// TransferResult component 所有权转移结果
+type TransferResult struct {
+	NodeID     string
+	NodeName   string
+	Components []ComponentSummary // 已转移并重新部署的 component
+	Failed     map[string]string  // component ID -> 转移失败的原因
+}
+
+// TransferComponents 处理其他节点的请求，将本地节点上由 req.FromNodeID 委托部署的 component 转移给 req.ToNodeID
+// 转移会把 component 的上游地址改为请求中的地址，只接受接管节点本身签名的请求，其他节点不能借此劫持 component
+// 节点密钥在首次见到时登记，任何节点都能签名，因此接管节点还必须是 discovery 中已知的存活同域节点或当前 head
+func (s *service) TransferComponents(ctx context.Context, req TransferRequest) (*TransferResult, error) {
+	caller, ok := identity.PeerNodeID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("transfer request must be signed by the adopting node")
+	}
+	if caller != req.ToNodeID {
+		return nil, fmt.Errorf("node %s cannot request transfer to node %s", caller, req.ToNodeID)
+	}
+	if err := s.checkAdopter(req.ToNodeID); err != nil {
+		return nil, err
+	}
+	return s.transferLocally(ctx, req)
+}
+
+// checkAdopter 检查接管节点是否为已知的存活同域节点，或同域中任期最高的 head
+func (s *service) checkAdopter(nodeID string) error {
+	if s.discoveryService == nil {
+		return fmt.Errorf("discovery service is not available")
+	}
+	var domainID string
+	if local := s.discoveryService.GetLocalNode(); local != nil {
+		domainID = local.DomainID
+	}
+
+	var adopter, head *discovery.PeerNode
+	for _, node := range s.discoveryService.GetKnownNodes() {
+		if node.DomainID != domainID {
+			continue
+		}
+		if node.NodeID == nodeID {
+			adopter = node
+		}
+		if node.HeadRole == discovery.HeadRoleActive && (head == nil || node.HeadTerm > head.HeadTerm) {
+			head = node
+		}
+	}
+	switch {
+	case adopter == nil:
+		return fmt.Errorf("adopting node %s is not a known member of the domain", nodeID)
+	case adopter.Liveness == discovery.NodeLivenessAlive, adopter == head:
+		return nil
+	}
+	return fmt.Errorf("adopting node %s is not alive", nodeID)
+}
+
+// transferLocally 在本地节点执行转移，接管节点为本节点时由本节点直接调用
+func (s *service) transferLocally(ctx context.Context, req TransferRequest) (*TransferResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.validateUpstreams(ctx, &DeployRequest{
+		UpstreamZMQAddress:    req.UpstreamZMQAddress,
+		UpstreamStoreAddress:  req.UpstreamStoreAddress,
+		UpstreamLoggerAddress: req.UpstreamLoggerAddress,
+	}); err != nil {
+		return nil, err
+	}
+	transferer, ok := s.localResourceManager.(interface {
+		TransferComponents(ctx context.Context, req TransferRequest) (*TransferResult, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("local node does not transfer components")
+	}
+	return transferer.TransferComponents(ctx, req)
+}
+
+// TransferRemoteComponents 请求同域其他节点将其上由 req.FromNodeID 委托部署的 component 转移给 req.ToNodeID
+func (s *service) TransferRemoteComponents(ctx context.Context, nodeID, address string, req TransferRequest) (*TransferResult, error) {
+	if nodeID == "" {
+		return nil, fmt.Errorf("node id is required")
+	}
+	if nodeID == s.localResourceManager.GetNodeID() {
+		return s.transferLocally(ctx, req)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	targetAddress, err := s.resolveTargetAddress(nodeID, address)
+	if err != nil {
+		return nil, err
+	}
+	protocol, err := s.peerProtocol(nodeID)
+	if err != nil {
+		return nil, err
+	}
+	if !protocol.Supports(commonpb.CapComponentTransfer) {
+		return nil, ErrTransferUnsupported
+	}
+	conn, err := s.dialPeer(nodeID, targetAddress, protocol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to target node: %w", err)
+	}
+	defer conn.Close()
+
+	client := schedulerpb.NewSchedulerServiceClient(conn)
+	protoResp, err := client.TransferComponents(ctx, &schedulerpb.TransferComponentsRequest{
+		FromNodeId:            req.FromNodeID,
+		ToNodeId:              req.ToNodeID,
+		UpstreamZmqAddress:    req.UpstreamZMQAddress,
+		UpstreamStoreAddress:  req.UpstreamStoreAddress,
+		UpstreamLoggerAddress: req.UpstreamLoggerAddress,
+		UpstreamTokens:        req.UpstreamTokens,
+		ComponentIds:          req.ComponentIDs,
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return nil, ErrTransferUnsupported
+		}
+		return nil, fmt.Errorf("failed to transfer components on remote node: %w", err)
+	}
+	if !protoResp.Success {
+		return nil, fmt.Errorf("remote node failed to transfer components: %s", protoResp.Error)
+	}
+
+	result := &TransferResult{
+		NodeID:     protoResp.NodeId,
+		NodeName:   protoResp.NodeName,
+		Components: make([]ComponentSummary, 0, len(protoResp.Components)),
+		Failed:     protoResp.Failed,
+	}
+	for _, c := range protoResp.Components {
+		result.Components = append(result.Components, ComponentSummaryFromProto(c, protoResp.NodeId, protoResp.NodeName))
+	}
+	return result, nil
+}
@@ -0,0 +1,104 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+)
+
+// ErrUpstreamRejected 委托部署请求携带的上游地址未通过校验
+var ErrUpstreamRejected = errors.New("upstream address rejected")
+
+// upstreamResolveTimeout 解析上游主机名的超时
+const upstreamResolveTimeout = 2 * time.Second
+
+// UpstreamPolicy 校验其他节点委托部署时要求 component 回连的上游 ZMQ/Store/Logger 地址
+// 这些地址会原样写入 component 的环境变量，不加校验时委托方可以让本节点上的 component 与 provider 连接任意地址，
+// 例如云主机的元数据服务
+type UpstreamPolicy struct {
+	// AllowedNetworks 允许的上游网段，为空时只拒绝未指定、链路本地与组播地址
+	AllowedNetworks []*net.IPNet
+}
+
+// ParseUpstreamPolicy 从 CIDR 列表创建校验策略
+func ParseUpstreamPolicy(cidrs []string) (*UpstreamPolicy, error) {
+	policy := &UpstreamPolicy{}
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid upstream network %q: %w", cidr, err)
+		}
+		policy.AllowedNetworks = append(policy.AllowedNetworks, network)
+	}
+	return policy, nil
+}
+
+// Validate 校验一个上游地址，主机名解析出的所有地址都必须被允许
+func (p *UpstreamPolicy) Validate(ctx context.Context, name, address string) error {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s address %q: %v", ErrUpstreamRejected, name, address, err)
+	}
+	if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+		return fmt.Errorf("%w: %s address %q has invalid port", ErrUpstreamRejected, name, address)
+	}
+	if host == "" {
+		return fmt.Errorf("%w: %s address %q has no host", ErrUpstreamRejected, name, address)
+	}
+
+	ips := []net.IP{net.ParseIP(host)}
+	if ips[0] == nil {
+		resolveCtx, cancel := context.WithTimeout(ctx, upstreamResolveTimeout)
+		defer cancel()
+		addrs, err := net.DefaultResolver.LookupIPAddr(resolveCtx, host)
+		if err != nil {
+			return fmt.Errorf("%w: cannot resolve %s host %q: %v", ErrUpstreamRejected, name, host, err)
+		}
+		ips = ips[:0]
+		for _, addr := range addrs {
+			ips = append(ips, addr.IP)
+		}
+	}
+	for _, ip := range ips {
+		if !p.allows(ip) {
+			return fmt.Errorf("%w: %s address %q resolves to %s, which is not allowed", ErrUpstreamRejected, name, address, ip)
+		}
+	}
+	return nil
+}
+
+func (p *UpstreamPolicy) allows(ip net.IP) bool {
+	if len(p.AllowedNetworks) == 0 {
+		return !ip.IsUnspecified() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() && !ip.IsMulticast()
+	}
+	for _, network := range p.AllowedNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateUpstreams 校验请求中所有非空的上游地址，未设置策略时使用默认策略
+func (s *service) validateUpstreams(ctx context.Context, req *DeployRequest) error {
+	policy := s.upstreamPolicy
+	if policy == nil {
+		policy = &UpstreamPolicy{}
+	}
+	for _, upstream := range []struct{ name, address string }{
+		{"zmq", req.UpstreamZMQAddress},
+		{"store", req.UpstreamStoreAddress},
+		{"logger", req.UpstreamLoggerAddress},
+	} {
+		if upstream.address == "" {
+			continue
+		}
+		if err := policy.Validate(ctx, upstream.name, upstream.address); err != nil {
+			return err
+		}
+	}
+	return nil
+}
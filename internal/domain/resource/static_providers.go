@@ -0,0 +1,55 @@
+package resource
+
+import (
+	"context"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/provider"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultStaticProviderRetry 配置管理的 provider 连接失败后的默认重试间隔
+const DefaultStaticProviderRetry = 10 * time.Second
+
+// SetStaticProviders 设置配置文件中声明的 provider 及连接失败后的重试间隔，需在 Start 之前调用
+func (m *Manager) SetStaticProviders(providers []provider.StaticProvider, retry time.Duration) {
+	if retry <= 0 {
+		retry = DefaultStaticProviderRetry
+	}
+	m.staticProviders = providers
+	m.staticProviderRetry = retry
+}
+
+// startStaticProviders 按配置同步 repository，并在后台注册配置管理的 provider
+// 连接失败的 provider 按重试间隔持续重试，直到连接成功或 ctx 结束
+func (m *Manager) startStaticProviders(ctx context.Context) {
+	if err := m.providerService.SyncStaticProviders(ctx, m.staticProviders); err != nil {
+		logrus.Warnf("Failed to sync static providers with repository: %v", err)
+	}
+
+	for _, sp := range m.staticProviders {
+		_, err := m.providerService.RegisterStaticProvider(ctx, sp)
+		if err == nil {
+			continue
+		}
+		logrus.Warnf("%v, retrying every %v", err, m.staticProviderRetry)
+		go m.retryStaticProvider(ctx, sp)
+	}
+}
+
+func (m *Manager) retryStaticProvider(ctx context.Context, sp provider.StaticProvider) {
+	ticker := time.NewTicker(m.staticProviderRetry)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := m.providerService.RegisterStaticProvider(ctx, sp); err != nil {
+				logrus.Debugf("Static provider %s still unavailable: %v", sp.Name, err)
+				continue
+			}
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
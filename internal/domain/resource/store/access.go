@@ -0,0 +1,113 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrAccessDenied 调用方无权访问对象
+var ErrAccessDenied = errors.New("store access denied")
+
+// Principal 通过 store 端口访问对象的调用方
+// ComponentID 与 AppID 均为空表示未出示令牌的匿名调用方
+type Principal struct {
+	ComponentID string // 通过上游令牌校验的 component ID，应用控制器等节点内调用方为空
+	AppID       string // 调用方所属应用，未知时为空
+}
+
+func (p Principal) anonymous() bool {
+	return p.ComponentID == "" && p.AppID == ""
+}
+
+type principalKey struct{}
+
+// WithPrincipal 在 context 中附加访问 store 的调用方
+// 未附加调用方的访问视为节点内部访问，不做访问控制也不记录审计日志
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFrom 获取 context 中的调用方
+func PrincipalFrom(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// Owner 对象的归属元数据，在对象首次保存时记录
+type Owner struct {
+	ComponentID string
+	AppID       string
+	CreatedAt   time.Time
+}
+
+// allows 调用方是否可以访问该对象：同一 component 或同一应用
+func (o *Owner) allows(p Principal) bool {
+	if p.ComponentID != "" && p.ComponentID == o.ComponentID {
+		return true
+	}
+	return p.AppID != "" && p.AppID == o.AppID
+}
+
+// AccessPolicy store 访问控制策略
+type AccessPolicy struct {
+	Enforce bool      // 拒绝越权访问；关闭时只记录审计日志
+	Audit   *AuditLog // 访问审计日志，nil 表示不记录
+}
+
+// authorize 校验调用方对对象的访问，并记录审计日志
+// 无归属记录的对象（如节点内部保存的对象）只拒绝匿名调用方
+func (s *service) authorize(ctx context.Context, op Operation, objectID string) error {
+	p, ok := PrincipalFrom(ctx)
+	if !ok {
+		return nil
+	}
+
+	owner := s.store.GetOwner(objectID)
+	allowed := !p.anonymous() && (owner == nil || owner.allows(p))
+
+	policy := s.getPolicy()
+	if policy.Audit != nil {
+		entry := &AuditEntry{
+			Time:        time.Now(),
+			Op:          op,
+			ObjectID:    objectID,
+			ComponentID: p.ComponentID,
+			AppID:       p.AppID,
+			Allowed:     allowed,
+			Enforced:    policy.Enforce,
+		}
+		if owner != nil {
+			entry.OwnerComponentID = owner.ComponentID
+			entry.OwnerAppID = owner.AppID
+		}
+		if err := policy.Audit.Record(entry); err != nil {
+			logrus.Warnf("Failed to record store audit entry: %v", err)
+		}
+	}
+
+	if allowed {
+		return nil
+	}
+	if !policy.Enforce {
+		logrus.Debugf("Store access to %s by component %q (app %q) would be denied", objectID, p.ComponentID, p.AppID)
+		return nil
+	}
+	return fmt.Errorf("%w: %s %s by component %q (app %q)", ErrAccessDenied, op, objectID, p.ComponentID, p.AppID)
+}
+
+// claim 首次保存对象时记录调用方为对象的归属
+func (s *service) claim(ctx context.Context, objectID string) {
+	p, ok := PrincipalFrom(ctx)
+	if !ok || p.anonymous() {
+		return
+	}
+	s.store.SetOwnerIfAbsent(objectID, &Owner{
+		ComponentID: p.ComponentID,
+		AppID:       p.AppID,
+		CreatedAt:   time.Now(),
+	})
+}
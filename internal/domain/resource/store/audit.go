@@ -0,0 +1,83 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Operation store 访问类型
+type Operation string
+
+const (
+	OpSaveObject      Operation = "save_object"
+	OpGetObject       Operation = "get_object"
+	OpSaveStreamChunk Operation = "save_stream_chunk"
+	OpGetStreamChunk  Operation = "get_stream_chunk"
+)
+
+// AuditEntry 一条 store 访问审计记录
+type AuditEntry struct {
+	Time             time.Time `json:"time"`
+	Op               Operation `json:"op"`
+	ObjectID         string    `json:"object_id"`
+	ComponentID      string    `json:"component_id,omitempty"`       // 调用方 component，匿名或节点内调用方为空
+	AppID            string    `json:"app_id,omitempty"`             // 调用方所属应用
+	OwnerComponentID string    `json:"owner_component_id,omitempty"` // 对象归属，无归属记录时为空
+	OwnerAppID       string    `json:"owner_app_id,omitempty"`
+	Allowed          bool      `json:"allowed"`  // 是否满足访问策略
+	Enforced         bool      `json:"enforced"` // 策略是否强制执行，未强制执行时不满足策略的访问同样放行
+}
+
+// AuditLog 将 store 访问审计记录逐行以 JSON 追加写入文件
+type AuditLog struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+}
+
+// NewAuditLog 打开（或创建）审计日志文件
+func NewAuditLog(path string) (*AuditLog, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log %s: %w", path, err)
+	}
+	return &AuditLog{path: path, file: file}, nil
+}
+
+// Record 追加一条记录
+func (a *AuditLog) Record(entry *AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+	line = append(line, '\n')
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.file == nil {
+		return fmt.Errorf("audit log %s is closed", a.path)
+	}
+	if _, err := a.file.Write(line); err != nil {
+		return fmt.Errorf("failed to write audit log %s: %w", a.path, err)
+	}
+	return nil
+}
+
+// Close 关闭审计日志文件
+func (a *AuditLog) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.file == nil {
+		return nil
+	}
+	err := a.file.Close()
+	a.file = nil
+	return err
+}
@@ -0,0 +1,174 @@
+package store
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/9triver/iarnet/internal/domain/resource/store/object"
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+	commonpb "github.com/9triver/iarnet/internal/proto/common"
+	"github.com/9triver/iarnet/internal/util"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+)
+
+// storeIDFileName 持久化 store 的 ID 文件；ID 在重启后保持不变，已签发的对象引用继续有效
+const storeIDFileName = "store_id"
+
+// objectsDirName 对象文件目录，每个对象一个文件，内容为编码后的 EncodedObject
+const objectsDirName = "objects"
+
+var (
+	// ErrObjectTooLarge 对象超过单个对象的大小上限
+	ErrObjectTooLarge = errors.New("object exceeds the store object size limit")
+	// ErrStoreFull 保存对象后总大小将超过 store 的容量上限
+	ErrStoreFull = errors.New("store capacity exceeded")
+)
+
+// Limits store 的大小限制，0 表示不限制
+type Limits struct {
+	MaxObjectBytes int64 // 单个对象编码后的大小上限
+	MaxTotalBytes  int64 // 所有对象编码后的总大小上限
+}
+
+func (l Limits) unlimited() bool {
+	return l.MaxObjectBytes == 0 && l.MaxTotalBytes == 0
+}
+
+// Open 打开数据目录中的持久化 store，加载已保存的对象；目录不存在时创建
+// 对象在保存时写入文件，流式数据只保存在内存中
+func Open(dir string, limits Limits) (*Store, error) {
+	objectsDir := filepath.Join(dir, objectsDirName)
+	if err := os.MkdirAll(objectsDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create store directory %s: %w", objectsDir, err)
+	}
+	id, err := loadOrGenerateStoreID(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	s := NewStore()
+	s.id = id
+	s.dir = dir
+	s.limits = limits
+	s.sizes = make(map[types.ObjectID]int64)
+
+	entries, err := os.ReadDir(objectsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read store directory %s: %w", objectsDir, err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(objectsDir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read object file %s: %w", path, err)
+		}
+		obj := &commonpb.EncodedObject{}
+		if err := proto.Unmarshal(data, obj); err != nil {
+			logrus.Warnf("Skipping unreadable object file %s: %v", path, err)
+			continue
+		}
+		// 磁盘上损坏的对象不再提供读取
+		if err := obj.Verify(); err != nil {
+			logrus.Warnf("Skipping corrupted object file %s: %v", path, err)
+			continue
+		}
+		s.objects[obj.GetID()] = obj
+		s.sizes[obj.GetID()] = int64(len(data))
+		s.used += int64(len(data))
+	}
+	logrus.Infof("Store %s opened at %s with %d object(s), %d bytes", s.id, dir, len(s.objects), s.used)
+	return s, nil
+}
+
+// Usage 返回 store 中的对象数与对象编码后的总大小（只统计持久化或有大小限制的 store）
+func (s *Store) Usage() (int, int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.objects), s.used
+}
+
+// persistObject 检查大小限制并写入对象文件，写入成功后替换内存中的对象
+func (s *Store) persistObject(obj object.Interface) error {
+	encoded, err := obj.Encode()
+	if err != nil {
+		return err
+	}
+	data, err := proto.Marshal(encoded)
+	if err != nil {
+		return fmt.Errorf("failed to encode object %s: %w", obj.GetID(), err)
+	}
+	size := int64(len(data))
+	if s.limits.MaxObjectBytes > 0 && size > s.limits.MaxObjectBytes {
+		return fmt.Errorf("%w: %s is %d bytes, limit %d", ErrObjectTooLarge, obj.GetID(), size, s.limits.MaxObjectBytes)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 覆盖已有对象时释放其原有大小
+	used := s.used - s.sizes[obj.GetID()] + size
+	if s.limits.MaxTotalBytes > 0 && used > s.limits.MaxTotalBytes {
+		return fmt.Errorf("%w: %d of %d bytes used", ErrStoreFull, s.used, s.limits.MaxTotalBytes)
+	}
+	if s.dir != "" {
+		if err := writeFileAtomic(s.objectPath(obj.GetID()), data); err != nil {
+			return fmt.Errorf("failed to persist object %s: %w", obj.GetID(), err)
+		}
+	}
+	s.objects[obj.GetID()] = obj
+	s.sizes[obj.GetID()] = size
+	s.used = used
+	return nil
+}
+
+// objectPath 对象文件路径，对象 ID 经 URL 安全的 base64 编码后作为文件名
+func (s *Store) objectPath(id types.ObjectID) string {
+	return filepath.Join(s.dir, objectsDirName, base64.RawURLEncoding.EncodeToString([]byte(id)))
+}
+
+// writeFileAtomic 先写入临时文件再重命名，进程中途退出时不会留下不完整的对象文件
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// loadOrGenerateStoreID 从数据目录加载 store ID，不存在时生成并保存
+func loadOrGenerateStoreID(dir string) (types.StoreID, error) {
+	path := filepath.Join(dir, storeIDFileName)
+	if data, err := os.ReadFile(path); err == nil {
+		if id := strings.TrimSpace(string(data)); id != "" {
+			return id, nil
+		}
+	} else if !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to read store id %s: %w", path, err)
+	}
+
+	id := util.GenIDWith("store.")
+	if err := os.WriteFile(path, []byte(id+"\n"), 0o644); err != nil {
+		return "", fmt.Errorf("failed to save store id %s: %w", path, err)
+	}
+	return id, nil
+}
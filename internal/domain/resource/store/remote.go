@@ -0,0 +1,87 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	commonpb "github.com/9triver/iarnet/internal/proto/common"
+	storepb "github.com/9triver/iarnet/internal/proto/resource/store"
+	"github.com/9triver/iarnet/internal/util/identity"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+// remoteService 在同域共用的独立 store 服务（cmd/store）中保存与读取对象
+type remoteService struct {
+	client storepb.ServiceClient
+	tokens *identity.ComponentTokens
+	nodeID string
+}
+
+// NewRemoteService 创建访问独立 store 服务的 Service
+// 访问控制由独立 store 按自身配置执行：tokens 为同域共用的令牌密钥时，节点内部访问出示节点令牌，
+// 经本节点 store 端口转发的 component 访问出示为该 component 签发的令牌；tokens 为 nil 时不出示令牌
+func NewRemoteService(conn grpc.ClientConnInterface, tokens *identity.ComponentTokens, nodeID string) Service {
+	return &remoteService{client: storepb.NewServiceClient(conn), tokens: tokens, nodeID: nodeID}
+}
+
+func (s *remoteService) SetAccessPolicy(policy AccessPolicy) {
+	if policy.Enforce || policy.Audit != nil {
+		logrus.Info("Store access policy is enforced by the shared store according to its own configuration")
+	}
+}
+
+// withCredentials 按调用方附加转发到独立 store 时出示的令牌
+func (s *remoteService) withCredentials(ctx context.Context) context.Context {
+	if s.tokens == nil {
+		return ctx
+	}
+	p, ok := PrincipalFrom(ctx)
+	switch {
+	case !ok:
+		return s.tokens.WithNodeToken(ctx, s.nodeID)
+	case p.ComponentID != "":
+		return identity.WithComponentToken(ctx, p.ComponentID, s.tokens.Issue(p.ComponentID, p.AppID))
+	default:
+		return ctx
+	}
+}
+
+func (s *remoteService) SaveObject(ctx context.Context, obj *commonpb.EncodedObject) (*commonpb.ObjectRef, error) {
+	resp, err := s.client.SaveObject(s.withCredentials(ctx), &storepb.SaveObjectRequest{Object: obj})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Success {
+		return nil, fmt.Errorf("shared store failed to save object %s: %s", obj.GetID(), resp.Error)
+	}
+	return resp.ObjectRef, nil
+}
+
+func (s *remoteService) SaveStreamChunk(ctx context.Context, chunk *commonpb.StreamChunk) error {
+	_, err := s.client.SaveStreamChunk(s.withCredentials(ctx), &storepb.SaveStreamChunkRequest{Chunk: chunk})
+	return err
+}
+
+func (s *remoteService) GetObject(ctx context.Context, ref *commonpb.ObjectRef) (*commonpb.EncodedObject, error) {
+	resp, err := s.client.GetObject(s.withCredentials(ctx), &storepb.GetObjectRequest{ObjectRef: ref})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Object == nil {
+		return nil, fmt.Errorf("object not found")
+	}
+	// 与本地 store 一致，拒绝与引用中的摘要不符的对象
+	if err := resp.Object.VerifyRef(ref); err != nil {
+		return nil, err
+	}
+	return resp.Object, nil
+}
+
+func (s *remoteService) GetStreamChunk(ctx context.Context, id string, offset int64) (*commonpb.StreamChunk, error) {
+	resp, err := s.client.GetStreamChunk(s.withCredentials(ctx), &storepb.GetStreamChunkRequest{ObjectID: id, Offset: offset})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Chunk, nil
+}
@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"sync"
 
 	commonpb "github.com/9triver/iarnet/internal/proto/common"
 )
@@ -12,10 +13,15 @@ type Service interface {
 	SaveStreamChunk(ctx context.Context, chunk *commonpb.StreamChunk) error
 	GetObject(ctx context.Context, ref *commonpb.ObjectRef) (*commonpb.EncodedObject, error)
 	GetStreamChunk(ctx context.Context, id string, offset int64) (*commonpb.StreamChunk, error)
+	// SetAccessPolicy 设置对携带调用方（见 WithPrincipal）的访问的控制策略
+	SetAccessPolicy(policy AccessPolicy)
 }
 
 type service struct {
 	store *Store
+
+	policyMu sync.RWMutex
+	policy   AccessPolicy
 }
 
 func NewService(store *Store) Service {
@@ -24,19 +30,57 @@ func NewService(store *Store) Service {
 	}
 }
 
+func (s *service) SetAccessPolicy(policy AccessPolicy) {
+	s.policyMu.Lock()
+	defer s.policyMu.Unlock()
+	s.policy = policy
+}
+
+func (s *service) getPolicy() AccessPolicy {
+	s.policyMu.RLock()
+	defer s.policyMu.RUnlock()
+	return s.policy
+}
+
 func (s *service) SaveObject(ctx context.Context, obj *commonpb.EncodedObject) (*commonpb.ObjectRef, error) {
-	s.store.SaveObject(obj)
+	// 已有归属的对象只能由归属方覆盖
+	if err := s.authorize(ctx, OpSaveObject, obj.GetID()); err != nil {
+		return nil, err
+	}
+	// 拒绝传输中损坏的对象，未携带摘要的对象在保存时计算
+	if err := obj.Verify(); err != nil {
+		return nil, err
+	}
+	obj.Seal()
+	if err := s.store.SaveObject(obj); err != nil {
+		return nil, err
+	}
+	s.claim(ctx, obj.GetID())
 	return &commonpb.ObjectRef{
 		ID:     obj.ID,
 		Source: s.store.GetID(),
+		Digest: obj.Digest,
 	}, nil
 }
 
 func (s *service) SaveStreamChunk(ctx context.Context, chunk *commonpb.StreamChunk) error {
-	return s.store.SaveStreamChunk(chunk)
+	if err := s.authorize(ctx, OpSaveStreamChunk, chunk.GetObjectID()); err != nil {
+		return err
+	}
+	if err := chunk.GetValue().Verify(); err != nil {
+		return err
+	}
+	if err := s.store.SaveStreamChunk(chunk); err != nil {
+		return err
+	}
+	s.claim(ctx, chunk.GetObjectID())
+	return nil
 }
 
 func (s *service) GetObject(ctx context.Context, ref *commonpb.ObjectRef) (*commonpb.EncodedObject, error) {
+	if err := s.authorize(ctx, OpGetObject, ref.GetID()); err != nil {
+		return nil, err
+	}
 	obj, err := s.store.GetObject(ref.ID)
 	if err != nil {
 		return nil, err
@@ -45,9 +89,15 @@ func (s *service) GetObject(ctx context.Context, ref *commonpb.ObjectRef) (*comm
 	if err != nil {
 		return nil, err
 	}
+	if err := encodedObj.VerifyRef(ref); err != nil {
+		return nil, err
+	}
 	return encodedObj, nil
 }
 
 func (s *service) GetStreamChunk(ctx context.Context, id string, offset int64) (*commonpb.StreamChunk, error) {
+	if err := s.authorize(ctx, OpGetStreamChunk, id); err != nil {
+		return nil, err
+	}
 	return s.store.GetStreamChunk(id, offset)
 }
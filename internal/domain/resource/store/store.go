@@ -14,14 +14,22 @@ type Store struct {
 	id           types.StoreID
 	objects      map[types.ObjectID]object.Interface
 	streamChunks map[string]map[int64]*commonpb.StreamChunk
+	owners       map[types.ObjectID]*Owner // 对象归属，节点内部保存的对象没有记录
 	mu           sync.Mutex
 	cond         *sync.Cond
+
+	// 持久化与容量限制，仅由 Open 创建的 store 使用
+	dir    string                   // 对象文件目录，为空表示只保存在内存中
+	limits Limits                   // 大小限制
+	sizes  map[types.ObjectID]int64 // 对象编码后的大小
+	used   int64                    // 所有对象编码后的总大小
 }
 
 func NewStore() *Store {
 	s := &Store{
 		id:      util.GenIDWith("store."),
 		objects: make(map[types.ObjectID]object.Interface),
+		owners:  make(map[types.ObjectID]*Owner),
 	}
 	s.cond = sync.NewCond(&s.mu)
 	return s
@@ -31,8 +39,31 @@ func (s *Store) GetID() types.StoreID {
 	return s.id
 }
 
-func (s *Store) SaveObject(obj object.Interface) {
-	s.objects[obj.GetID()] = obj
+// SaveObject 保存对象，持久化的 store 先写入对象文件，超过大小限制时返回错误
+func (s *Store) SaveObject(obj object.Interface) error {
+	if s.dir == "" && s.limits.unlimited() {
+		s.mu.Lock()
+		s.objects[obj.GetID()] = obj
+		s.mu.Unlock()
+		return nil
+	}
+	return s.persistObject(obj)
+}
+
+// GetOwner 获取对象的归属，没有记录时返回 nil
+func (s *Store) GetOwner(id types.ObjectID) *Owner {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.owners[id]
+}
+
+// SetOwnerIfAbsent 在对象尚无归属记录时记录其归属
+func (s *Store) SetOwnerIfAbsent(id types.ObjectID, owner *Owner) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.owners[id]; !ok {
+		s.owners[id] = owner
+	}
 }
 
 func (s *Store) SaveStreamChunk(chunk *commonpb.StreamChunk) error {
@@ -64,7 +95,9 @@ func (s *Store) SaveStreamChunk(chunk *commonpb.StreamChunk) error {
 }
 
 func (s *Store) GetObject(id types.ObjectID) (object.Interface, error) {
+	s.mu.Lock()
 	obj, ok := s.objects[id]
+	s.mu.Unlock()
 	if !ok {
 		return nil, fmt.Errorf("object not found")
 	}
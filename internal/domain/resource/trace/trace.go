@@ -0,0 +1,116 @@
+// Package trace 记录生产环境的部署请求序列，供 cmd/tracereplay 在测试集群上重放以验证调度策略的改动
+// 与 decision 包的调度决策日志不同，trace 只保留重放所需的信息：请求时间、资源请求、结果，以及 component 的删除时间（决定重放时的占用时长）
+package trace
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/decision"
+)
+
+// Event 事件类型
+type Event string
+
+const (
+	EventDeploy   Event = "deploy"
+	EventUndeploy Event = "undeploy"
+)
+
+// Entry 一条 trace 记录
+// 部署失败的请求同样记录，ComponentID 为空；重放时仍会提交，用于对比策略改动前后的成功率
+type Entry struct {
+	Time        time.Time         `json:"time"`
+	Event       Event             `json:"event"`
+	ComponentID string            `json:"component_id,omitempty"`
+	Request     *decision.Request `json:"request,omitempty"` // 仅 deploy 事件
+	Success     bool              `json:"success"`
+	Error       string            `json:"error,omitempty"`
+	LatencyMs   int64             `json:"latency_ms"`
+}
+
+// Recorder 将 trace 逐行以 JSON 追加写入文件
+// trace 需要完整才能重放，因此不做轮转
+type Recorder struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+}
+
+// NewRecorder 打开（或创建）trace 文件
+func NewRecorder(path string) (*Recorder, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create trace directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open trace %s: %w", path, err)
+	}
+	return &Recorder{path: path, file: file}, nil
+}
+
+// Record 追加一条记录
+func (r *Recorder) Record(entry *Entry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode trace entry: %w", err)
+	}
+	line = append(line, '\n')
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return fmt.Errorf("trace %s is closed", r.path)
+	}
+	if _, err := r.file.Write(line); err != nil {
+		return fmt.Errorf("failed to write trace %s: %w", r.path, err)
+	}
+	return nil
+}
+
+// Close 关闭 trace 文件
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// Load 读取 trace 文件，按时间排序返回
+func Load(path string) ([]*Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open trace %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var entries []*Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		entry := &Entry{}
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			return nil, fmt.Errorf("invalid trace entry at %s:%d: %w", path, line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read trace %s: %w", path, err)
+	}
+	// 并发部署按完成顺序写入，重放按请求时间排序
+	slices.SortStableFunc(entries, func(a, b *Entry) int { return a.Time.Compare(b.Time) })
+	return entries, nil
+}
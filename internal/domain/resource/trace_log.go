@@ -0,0 +1,55 @@
+package resource
+
+import (
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/component"
+	"github.com/9triver/iarnet/internal/domain/resource/decision"
+	"github.com/9triver/iarnet/internal/domain/resource/trace"
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+	"github.com/sirupsen/logrus"
+)
+
+// SetTraceRecorder 设置部署请求 trace，nil 表示不记录
+// recorder 的关闭由调用方负责，应在部署排空之后进行
+func (m *Manager) SetTraceRecorder(recorder *trace.Recorder) {
+	m.traceRecorder = recorder
+}
+
+// recordDeploy 记录一次部署请求及其结果，写入失败只记录警告，不影响部署结果
+func (m *Manager) recordDeploy(startedAt time.Time, runtimeEnv types.RuntimeEnv, resourceRequest *types.Info, comp *component.Component, err error) {
+	request := decision.NewRequest(runtimeEnv, resourceRequest)
+	entry := &trace.Entry{
+		Time:      startedAt,
+		Event:     trace.EventDeploy,
+		Request:   &request,
+		Success:   err == nil,
+		LatencyMs: time.Since(startedAt).Milliseconds(),
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	if comp != nil {
+		entry.ComponentID = comp.GetID()
+	}
+	if writeErr := m.traceRecorder.Record(entry); writeErr != nil {
+		logrus.Warnf("Failed to record deploy trace: %v", writeErr)
+	}
+}
+
+// recordUndeploy 记录一次删除，重放时据此还原 component 的占用时长
+func (m *Manager) recordUndeploy(startedAt time.Time, componentID string, err error) {
+	entry := &trace.Entry{
+		Time:        startedAt,
+		Event:       trace.EventUndeploy,
+		ComponentID: componentID,
+		Success:     err == nil,
+		LatencyMs:   time.Since(startedAt).Milliseconds(),
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	if writeErr := m.traceRecorder.Record(entry); writeErr != nil {
+		logrus.Warnf("Failed to record undeploy trace for %s: %v", componentID, writeErr)
+	}
+}
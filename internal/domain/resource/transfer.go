@@ -0,0 +1,221 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/9triver/iarnet/internal/domain/resource/component"
+	"github.com/9triver/iarnet/internal/domain/resource/discovery"
+	"github.com/9triver/iarnet/internal/domain/resource/provider"
+	"github.com/9triver/iarnet/internal/domain/resource/scheduler"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrNodeAlive 节点仍然存活，其委托部署的 component 不能被其他节点接管
+var ErrNodeAlive = errors.New("node is still alive")
+
+// AdoptionReport 接管已离开节点委托部署的 component 的结果
+type AdoptionReport struct {
+	FromNodeID string
+	Components []scheduler.ComponentSummary // 已接管的 component，本节点在前，其余节点按节点 ID 排列
+	Nodes      []NodeAdoptionResult         // 各节点的转移结果，本节点在前
+}
+
+// NodeAdoptionResult 单个节点的转移结果
+type NodeAdoptionResult struct {
+	NodeID   string
+	NodeName string
+	Adopted  int
+	Failed   map[string]string // component ID -> 转移失败的原因
+	Error    string            // 查询或转移失败、节点不支持时的原因，此时该节点的 component 未被接管
+}
+
+// nodeAlive 判断 discovery 中节点是否存活，未知节点视为已离开
+func (m *Manager) nodeAlive(nodeID string) bool {
+	if m.discoveryService == nil {
+		return false
+	}
+	for _, node := range m.discoveryService.GetKnownNodes() {
+		if node.NodeID == nodeID {
+			return node.Liveness == discovery.NodeLivenessAlive
+		}
+	}
+	return false
+}
+
+// TransferComponents 将本节点上由 req.FromNodeID 委托部署的 component 转移给 req.ToNodeID
+// 更新 component 的上游地址与令牌并在 provider 上以相同 ID 重新部署，此后向接管节点对账；接管节点为本节点时改用本节点的地址
+// req.FromNodeID 仍然存活时返回 ErrNodeAlive，不是由其委托部署或已再次委托给其他节点的 component 记录在 Failed 中
+func (m *Manager) TransferComponents(ctx context.Context, req scheduler.TransferRequest) (*scheduler.TransferResult, error) {
+	if m.nodeAlive(req.FromNodeID) {
+		return nil, fmt.Errorf("%w: %s", ErrNodeAlive, req.FromNodeID)
+	}
+
+	result := &scheduler.TransferResult{NodeID: m.nodeID, NodeName: m.name, Failed: make(map[string]string)}
+	for _, id := range req.ComponentIDs {
+		comp := m.componentManager.Get(id)
+		if comp == nil {
+			result.Failed[id] = "component not found"
+			continue
+		}
+		if origin := comp.GetOrigin(); origin != req.FromNodeID {
+			result.Failed[id] = fmt.Sprintf("component was not delegated by node %s", req.FromNodeID)
+			continue
+		}
+		if nodeID, _ := m.placementOf(comp); nodeID != m.nodeID {
+			result.Failed[id] = fmt.Sprintf("component is placed on node %s", nodeID)
+			continue
+		}
+
+		if req.ToNodeID == m.nodeID {
+			comp.SetEnvOverride(nil)
+			comp.SetOrigin("")
+		} else {
+			comp.SetEnvOverride(&provider.DeploymentEnvOverride{
+				ZMQAddress:    req.UpstreamZMQAddress,
+				StoreAddress:  req.UpstreamStoreAddress,
+				LoggerAddress: req.UpstreamLoggerAddress,
+				Token:         req.UpstreamTokens[id],
+			})
+			comp.SetOrigin(req.ToNodeID)
+		}
+		// 重新部署失败时 component 已改为向接管节点对账，接管节点没有其记录，由对账删除
+		if err := m.componentService.RedeployComponent(ctx, id); err != nil {
+			logrus.Warnf("Failed to redeploy component %s transferred to node %s: %v", id, req.ToNodeID, err)
+			result.Failed[id] = err.Error()
+			continue
+		}
+		result.Components = append(result.Components, m.componentSummary(comp))
+	}
+	logrus.Infof("Transferred %d component(s) delegated by node %s to node %s (%d failed)",
+		len(result.Components), req.FromNodeID, req.ToNodeID, len(result.Failed))
+	return result, nil
+}
+
+// AdoptComponents 接管已离开的节点 fromNodeID 委托部署到本节点及同域其他节点的 component
+// 各节点更新 component 的上游地址为本节点并重新部署，本节点登记其路由，此后可通过本节点管理这些 component
+// fromNodeID 仍然存活时返回 ErrNodeAlive；查询或转移失败、不支持的节点记录在 Nodes 中，不影响其他节点
+func (m *Manager) AdoptComponents(ctx context.Context, fromNodeID string) (*AdoptionReport, error) {
+	switch {
+	case fromNodeID == "":
+		return nil, fmt.Errorf("node id is required")
+	case fromNodeID == m.nodeID:
+		return nil, fmt.Errorf("cannot adopt components delegated by this node")
+	case m.schedulerService == nil:
+		return nil, fmt.Errorf("scheduler service not configured")
+	case m.nodeAlive(fromNodeID):
+		return nil, fmt.Errorf("%w: %s", ErrNodeAlive, fromNodeID)
+	}
+
+	report := &AdoptionReport{FromNodeID: fromNodeID}
+	adopted, nodeResult := m.adoptFrom(ctx, fromNodeID, m.nodeID, m.name, "")
+	report.Components = append(report.Components, adopted...)
+	report.Nodes = append(report.Nodes, nodeResult)
+
+	var peers []*discovery.PeerNode
+	if m.discoveryService != nil {
+		for _, node := range m.discoveryService.GetKnownNodes() {
+			if node.NodeID != m.nodeID && node.NodeID != fromNodeID {
+				peers = append(peers, node)
+			}
+		}
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i].NodeID < peers[j].NodeID })
+	for _, node := range peers {
+		if node.Liveness == discovery.NodeLivenessSuspect {
+			report.Nodes = append(report.Nodes, NodeAdoptionResult{NodeID: node.NodeID, NodeName: node.NodeName, Error: "node is suspect"})
+			continue
+		}
+		adopted, nodeResult := m.adoptFrom(ctx, fromNodeID, node.NodeID, node.NodeName, peerSchedulerAddress(node))
+		report.Components = append(report.Components, adopted...)
+		report.Nodes = append(report.Nodes, nodeResult)
+	}
+	logrus.Infof("Adopted %d component(s) delegated by departed node %s", len(report.Components), fromNodeID)
+	return report, nil
+}
+
+// adoptFrom 列举节点 nodeID 上由 fromNodeID 委托部署的 component，请求转移给本节点并登记路由
+func (m *Manager) adoptFrom(ctx context.Context, fromNodeID, nodeID, nodeName, address string) ([]scheduler.ComponentSummary, NodeAdoptionResult) {
+	nodeResult := NodeAdoptionResult{NodeID: nodeID, NodeName: nodeName}
+	queryCtx, cancel := context.WithTimeout(ctx, componentQueryTimeout)
+	list, err := m.schedulerService.ListRemoteComponents(queryCtx, nodeID, address, scheduler.ComponentQuery{Origin: fromNodeID})
+	cancel()
+	if err != nil {
+		nodeResult.Error = err.Error()
+		return nil, nodeResult
+	}
+
+	local := nodeID == m.nodeID
+	req := scheduler.TransferRequest{FromNodeID: fromNodeID, ToNodeID: m.nodeID}
+	if !local {
+		req.UpstreamZMQAddress = m.getZMQAddress()
+		req.UpstreamStoreAddress = m.getStoreAddress()
+		req.UpstreamLoggerAddress = m.getLoggerAddress()
+		req.UpstreamTokens = make(map[string]string)
+	}
+	for _, c := range list.Components {
+		// 旧版节点忽略委托方条件，返回的 component 不带委托方，这里再过滤一次
+		if c.Origin != fromNodeID {
+			continue
+		}
+		req.ComponentIDs = append(req.ComponentIDs, c.ID)
+		if token := m.upstreamToken(c.ID, c.AppID); token != "" && !local {
+			req.UpstreamTokens[c.ID] = token
+		}
+	}
+	if len(req.ComponentIDs) == 0 {
+		return nil, nodeResult
+	}
+
+	// 转移后所在节点改向本节点对账，登记路由前答复待确认
+	if !local {
+		for _, id := range req.ComponentIDs {
+			m.commits.begin(id)
+			defer m.commits.end(id)
+		}
+	}
+	result, err := m.schedulerService.TransferRemoteComponents(ctx, nodeID, address, req)
+	if err != nil {
+		nodeResult.Error = err.Error()
+		return nil, nodeResult
+	}
+	nodeResult.Failed = result.Failed
+	if local {
+		nodeResult.Adopted = len(result.Components)
+		return result.Components, nodeResult
+	}
+
+	adopted := make([]scheduler.ComponentSummary, 0, len(result.Components))
+	for _, c := range result.Components {
+		if err := m.registerAdopted(ctx, nodeID, c); err != nil {
+			logrus.Warnf("Component %s was transferred from node %s but could not be registered locally: %v", c.ID, nodeID, err)
+			if nodeResult.Failed == nil {
+				nodeResult.Failed = make(map[string]string)
+			}
+			nodeResult.Failed[c.ID] = err.Error()
+			continue
+		}
+		adopted = append(adopted, c)
+	}
+	nodeResult.Adopted = len(adopted)
+	return adopted, nodeResult
+}
+
+// registerAdopted 在本节点登记转移给本节点的 component 的路由
+func (m *Manager) registerAdopted(ctx context.Context, nodeID string, summary scheduler.ComponentSummary) error {
+	route := fmt.Sprintf("remote.%s@%s", summary.ProviderID, nodeID)
+	if comp := m.componentManager.Get(summary.ID); comp != nil {
+		comp.SetProviderID(route)
+		return nil
+	}
+	comp := component.NewComponent(summary.ID, summary.Image, summary.Resources)
+	comp.SetAppID(summary.AppID)
+	comp.SetLabels(summary.Labels)
+	if err := m.componentManager.AddComponent(ctx, comp); err != nil {
+		return err
+	}
+	comp.SetProviderID(route)
+	return nil
+}
@@ -1,5 +1,12 @@
 package types
 
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
 type RuntimeEnv = string
 
 type Info struct {
@@ -7,6 +14,93 @@ type Info struct {
 	Memory int64    `json:"memory"` // bytes
 	GPU    int64    `json:"gpu"`
 	Tags   []string `json:"tags,omitempty"`
+
+	// NodeSelector 节点标签约束（可选），只能部署到具备全部标签的节点（如 zone=edge-1）
+	NodeSelector map[string]string `json:"node_selector,omitempty"`
+
+	// Stream 绑定的数据流名称（可选），只能部署到拥有该数据流的 provider（如摄像头接入组件）
+	Stream string `json:"stream,omitempty"`
+}
+
+// MatchLabels 判断节点标签是否满足标签约束：约束中的每个键值都必须完全匹配，空约束总是满足
+func MatchLabels(selector, labels map[string]string) bool {
+	for key, value := range selector {
+		if got, ok := labels[key]; !ok || got != value {
+			return false
+		}
+	}
+	return true
+}
+
+// NormalizeArchitecture 将 CPU 架构名称规范为 GOARCH 命名（如 x86_64 -> amd64、aarch64 -> arm64）
+func NormalizeArchitecture(arch string) string {
+	switch a := strings.ToLower(strings.TrimSpace(arch)); a {
+	case "x86_64", "x86-64", "x64":
+		return "amd64"
+	case "aarch64", "arm64v8":
+		return "arm64"
+	case "armv7l", "armv7", "armv6l", "armhf":
+		return "arm"
+	case "i386", "i686", "x86":
+		return "386"
+	default:
+		return a
+	}
+}
+
+// ArchitectureCompatible 判断 provider 可运行的架构与镜像支持的架构是否有交集
+// 任一方未知（为空）时视为兼容，以兼容未上报架构的旧版 provider 和多架构镜像
+func ArchitectureCompatible(supported, required []string) bool {
+	if len(supported) == 0 || len(required) == 0 {
+		return true
+	}
+	for _, arch := range required {
+		if slices.Contains(supported, arch) {
+			return true
+		}
+	}
+	return false
+}
+
+// EnergyProfile 能耗画像（由 provider / 节点可选上报）
+type EnergyProfile struct {
+	WattsPerCore   float64 `json:"watts_per_core"`  // 每核功耗（瓦），0 表示未知
+	BatteryPowered bool    `json:"battery_powered"` // 是否由电池供电
+}
+
+// GPUDevice provider 主机上的一块 GPU 及其占用情况（由按块分配 GPU 的 provider 上报）
+type GPUDevice struct {
+	ID          string `json:"id"`
+	Index       int32  `json:"index"`
+	Name        string `json:"name,omitempty"`
+	Memory      int64  `json:"memory"`                // 显存（bytes），0 表示未知
+	NVLinkGroup int32  `json:"nvlink_group"`          // NVLink 互联组，-1 表示未与其他 GPU 互联
+	InstanceID  string `json:"instance_id,omitempty"` // 占用该 GPU 的 component 实例，空表示空闲
+}
+
+// 数据流类型
+const (
+	StreamKindRTSP = "rtsp" // 网络摄像头，URL 为 RTSP 地址
+	StreamKindV4L2 = "v4l2" // 本地摄像头，URL 为设备路径，部署时映射进容器
+)
+
+// MediaStream 具有 camera 标签的 provider 拥有的数据流，部署请求通过 Info.Stream 按名称绑定
+type MediaStream struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"` // rtsp / v4l2
+	URL  string `json:"url"`  // RTSP 地址或 V4L2 设备路径
+}
+
+// BenchmarkResult provider 注册时微基准测试的结果（吞吐单位均为 MiB/s，0 表示未测量）
+type BenchmarkResult struct {
+	CPUScore            float64   `json:"cpu_score"`             // 单核 SHA-256 吞吐，越大越快
+	MemoryBandwidthMBps float64   `json:"memory_bandwidth_mbps"` // 内存拷贝带宽
+	DiskWriteMBps       float64   `json:"disk_write_mbps"`       // 顺序写（含 fsync）吞吐
+	DiskReadMBps        float64   `json:"disk_read_mbps"`        // 顺序读吞吐
+	GPUPresent          bool      `json:"gpu_present"`           // 是否探测到 GPU
+	GPUCount            int       `json:"gpu_count"`             // 探测到的 GPU 数量
+	DurationMs          int64     `json:"duration_ms"`           // 基准测试耗时
+	MeasuredAt          time.Time `json:"measured_at"`           // 测量时间
 }
 
 type Capacity struct {
@@ -31,6 +125,40 @@ const (
 	ProviderStatusDisconnected ProviderStatus = 2
 )
 
+func (s ProviderStatus) String() string {
+	switch s {
+	case ProviderStatusConnected:
+		return "connected"
+	case ProviderStatusDisconnected:
+		return "disconnected"
+	default:
+		return "unknown"
+	}
+}
+
+// CapacityClass Provider 的容量类别
+type CapacityClass string
+
+const (
+	// CapacityClassGuaranteed 保证型容量，provider 长期在线
+	CapacityClassGuaranteed CapacityClass = "guaranteed"
+	// CapacityClassBestEffort 尽力而为型容量，provider 可能随时下线（如夜间共享的桌面机）
+	// 部署在其上的 component 会被标记为可驱逐
+	CapacityClassBestEffort CapacityClass = "best-effort"
+)
+
+// ParseCapacityClass 解析容量类别，空字符串视为保证型
+func ParseCapacityClass(s string) (CapacityClass, error) {
+	switch CapacityClass(s) {
+	case "", CapacityClassGuaranteed:
+		return CapacityClassGuaranteed, nil
+	case CapacityClassBestEffort:
+		return CapacityClassBestEffort, nil
+	default:
+		return "", fmt.Errorf("unknown capacity class: %s", s)
+	}
+}
+
 type ObjectID = string
 
 type StoreID = string
@@ -0,0 +1,100 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/provider"
+	"github.com/sirupsen/logrus"
+)
+
+// usageWatchRetryInterval 推送流断开后重新建立前的等待时间，期间退回到轮询
+const usageWatchRetryInterval = 30 * time.Second
+
+// usageWatch 单个 provider 的 WatchUsage 推送流状态
+type usageWatch struct {
+	running     bool      // 推送流 goroutine 正在运行
+	active      bool      // 推送流已收到数据，可替代轮询
+	unsupported bool      // provider 未实现 WatchUsage，始终轮询
+	retryAt     time.Time // 推送流断开后下次重新建立的时间
+}
+
+// ensureUsageWatch 确保 provider 的使用量推送流已建立
+// 返回 true 表示该 provider 由推送更新，本轮无需轮询；推送流尚未收到数据、断开或不受支持时返回 false
+func (m *Manager) ensureUsageWatch(ctx context.Context, p *provider.Provider) bool {
+	m.usageWatchMu.Lock()
+	defer m.usageWatchMu.Unlock()
+
+	w, ok := m.usageWatches[p.GetID()]
+	if !ok {
+		w = &usageWatch{}
+		m.usageWatches[p.GetID()] = w
+	}
+	if w.unsupported {
+		return false
+	}
+	if w.running {
+		return w.active
+	}
+	if time.Now().Before(w.retryAt) {
+		return false
+	}
+
+	w.running = true
+	m.usagePollingWg.Add(1)
+	go m.runUsageWatch(ctx, p, w)
+	return false
+}
+
+// runUsageWatch 运行 provider 的使用量推送流，直到流断开或服务停止
+func (m *Manager) runUsageWatch(ctx context.Context, p *provider.Provider, w *usageWatch) {
+	defer m.usagePollingWg.Done()
+
+	watchCtx, cancel := context.WithCancel(m.usagePollingCtx)
+	defer cancel()
+	stop := context.AfterFunc(ctx, cancel)
+	defer stop()
+
+	err := p.WatchUsage(watchCtx, m.usagePollInterval, func(snapshot *provider.UsageSnapshot) {
+		m.usageWatchMu.Lock()
+		if !w.active {
+			w.active = true
+			logrus.Infof("Receiving usage updates from provider %s via watch stream", p.GetID())
+		}
+		m.usageWatchMu.Unlock()
+		m.recordUsage(p, snapshot.Usage, snapshot.Capacity)
+	})
+
+	m.usageWatchMu.Lock()
+	defer m.usageWatchMu.Unlock()
+	w.running = false
+	w.active = false
+	if errors.Is(err, provider.ErrUsageWatchUnsupported) {
+		w.unsupported = true
+		logrus.Infof("Provider %s does not support usage watch, falling back to polling", p.GetID())
+		return
+	}
+	w.retryAt = time.Now().Add(usageWatchRetryInterval)
+	if watchCtx.Err() == nil {
+		logrus.Debugf("Usage watch for provider %s ended (%v), polling until it is re-established", p.GetID(), err)
+	}
+}
+
+// pruneUsageWatches 清理已注销 provider 的推送流状态
+func (m *Manager) pruneUsageWatches(providers []*provider.Provider) {
+	known := make(map[string]struct{}, len(providers))
+	for _, p := range providers {
+		known[p.GetID()] = struct{}{}
+	}
+
+	m.usageActivity.forget(known)
+
+	m.usageWatchMu.Lock()
+	defer m.usageWatchMu.Unlock()
+	for id, w := range m.usageWatches {
+		if _, ok := known[id]; !ok && !w.running {
+			delete(m.usageWatches, id)
+		}
+	}
+}
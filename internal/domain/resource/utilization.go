@@ -0,0 +1,67 @@
+package resource
+
+import (
+	"context"
+
+	"github.com/9triver/iarnet/internal/domain/resource/provider"
+	"github.com/9triver/iarnet/internal/domain/resource/scheduler"
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+)
+
+// GetNodeUtilization 聚合本节点所有已连接 provider 的资源容量，并返回各 provider 明细
+// 优先使用未超过陈旧时间的容量缓存；单个 provider 获取失败时记录在明细中，不计入聚合值
+func (m *Manager) GetNodeUtilization(ctx context.Context) *scheduler.NodeUtilization {
+	providers := m.providerService.GetAllProviders()
+	utilization := &scheduler.NodeUtilization{
+		NodeID:   m.nodeID,
+		NodeName: m.name,
+		Capacity: &types.Capacity{
+			Total:     &types.Info{},
+			Used:      &types.Info{},
+			Available: &types.Info{},
+		},
+		Providers: make([]scheduler.ProviderUtilization, 0, len(providers)),
+	}
+
+	for _, p := range providers {
+		item := m.providerUtilization(ctx, p)
+		utilization.Providers = append(utilization.Providers, item)
+		if item.Capacity == nil {
+			continue
+		}
+		addInfo(utilization.Capacity.Total, item.Capacity.Total)
+		addInfo(utilization.Capacity.Used, item.Capacity.Used)
+		addInfo(utilization.Capacity.Available, item.Capacity.Available)
+	}
+	return utilization
+}
+
+// providerUtilization 单个 provider 的资源明细，未连接或获取容量失败时不含容量
+func (m *Manager) providerUtilization(ctx context.Context, p *provider.Provider) scheduler.ProviderUtilization {
+	item := scheduler.ProviderUtilization{
+		ProviderID:   p.GetID(),
+		ProviderName: p.GetName(),
+		ProviderType: string(p.GetType()),
+		Status:       p.GetStatus().String(),
+		Components:   len(m.componentManager.GetByProvider(p.GetID())),
+	}
+	if p.GetStatus() != types.ProviderStatusConnected {
+		return item
+	}
+	capacity, err := p.GetCapacity(ctx)
+	if err != nil {
+		item.Error = err.Error()
+		return item
+	}
+	item.Capacity = capacity
+	return item
+}
+
+func addInfo(sum, info *types.Info) {
+	if info == nil {
+		return
+	}
+	sum.CPU += info.CPU
+	sum.Memory += info.Memory
+	sum.GPU += info.GPU
+}
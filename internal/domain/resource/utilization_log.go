@@ -0,0 +1,168 @@
+package resource
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource/scheduler"
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+	"github.com/sirupsen/logrus"
+)
+
+// UtilizationLog 周期采样本节点及各 provider 的资源利用率并追加到 CSV，每次采样写入一行节点汇总与每个 provider 一行
+// provider 行带有 provider ID，可与调度决策日志中的 provider_id 关联，分析放置结果与热点 provider 的关系
+type UtilizationLog struct {
+	mu     sync.Mutex
+	path   string
+	file   *os.File
+	writer *csv.Writer
+}
+
+// utilizationLogHeader CSV 表头，scope 为 node 的行是节点汇总，provider 相关列为空
+var utilizationLogHeader = []string{
+	"sampled_at", "node_id", "scope", "provider_id", "provider_name", "provider_type", "status", "components",
+	"cpu_total", "cpu_used", "cpu_available",
+	"memory_total", "memory_used", "memory_available",
+	"gpu_total", "gpu_used", "gpu_available",
+	"error",
+}
+
+// NewUtilizationLog 打开（或创建）利用率 CSV 文件，文件为空时先写入表头
+func NewUtilizationLog(path string) (*UtilizationLog, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create utilization log directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open utilization log %s: %w", path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to stat utilization log %s: %w", path, err)
+	}
+
+	l := &UtilizationLog{path: path, file: file, writer: csv.NewWriter(file)}
+	if info.Size() == 0 {
+		if err := l.writeRows([][]string{utilizationLogHeader}); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// Write 追加一次采样
+func (l *UtilizationLog) Write(sampledAt time.Time, u *scheduler.NodeUtilization) error {
+	at := sampledAt.Format(time.RFC3339Nano)
+	components := 0
+	for _, p := range u.Providers {
+		components += p.Components
+	}
+
+	rows := make([][]string, 0, len(u.Providers)+1)
+	rows = append(rows, utilizationRow(at, u.NodeID, "node", "", "", "", "", components, u.Capacity, ""))
+	for _, p := range u.Providers {
+		rows = append(rows, utilizationRow(at, u.NodeID, "provider", p.ProviderID, p.ProviderName, p.ProviderType, p.Status, p.Components, p.Capacity, p.Error))
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return fmt.Errorf("utilization log %s is closed", l.path)
+	}
+	return l.writeRows(rows)
+}
+
+func utilizationRow(at, nodeID, scope, providerID, providerName, providerType, status string, components int, capacity *types.Capacity, errMsg string) []string {
+	row := []string{at, nodeID, scope, providerID, providerName, providerType, status, strconv.Itoa(components)}
+	var total, used, available types.Info
+	if capacity != nil {
+		if capacity.Total != nil {
+			total = *capacity.Total
+		}
+		if capacity.Used != nil {
+			used = *capacity.Used
+		}
+		if capacity.Available != nil {
+			available = *capacity.Available
+		}
+	}
+	for _, v := range []int64{
+		total.CPU, used.CPU, available.CPU,
+		total.Memory, used.Memory, available.Memory,
+		total.GPU, used.GPU, available.GPU,
+	} {
+		row = append(row, strconv.FormatInt(v, 10))
+	}
+	return append(row, errMsg)
+}
+
+func (l *UtilizationLog) writeRows(rows [][]string) error {
+	if err := l.writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write utilization log %s: %w", l.path, err)
+	}
+	return nil
+}
+
+// Close 关闭文件
+func (l *UtilizationLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+// SetUtilizationLog 设置利用率采样日志与采样间隔，需在 Start 之前调用
+func (m *Manager) SetUtilizationLog(log *UtilizationLog, interval time.Duration) {
+	m.utilizationLog = log
+	m.utilizationLogInterval = interval
+}
+
+// startUtilizationLog 启动利用率采样循环
+func (m *Manager) startUtilizationLog(ctx context.Context) {
+	if m.utilizationLog == nil || m.utilizationLogInterval <= 0 {
+		return
+	}
+	m.utilizationLogStop = make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(m.utilizationLogInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case now := <-ticker.C:
+				if err := m.utilizationLog.Write(now, m.GetNodeUtilization(ctx)); err != nil {
+					logrus.Warnf("Failed to record resource utilization: %v", err)
+				}
+			case <-m.utilizationLogStop:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// stopUtilizationLog 停止利用率采样循环
+func (m *Manager) stopUtilizationLog() {
+	if m.utilizationLogStop == nil {
+		return
+	}
+	select {
+	case <-m.utilizationLogStop:
+	default:
+		close(m.utilizationLogStop)
+	}
+}
@@ -0,0 +1,240 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/9triver/iarnet/internal/config"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/sirupsen/logrus"
+)
+
+// ============================================================================
+// CronJobDAO - 数据访问对象
+// ============================================================================
+
+// CronJobDAO CronJob 数据访问对象
+// Job 模板以 JSON 保存，由领域层负责编解码
+type CronJobDAO struct {
+	ID                         string     `db:"id"`
+	ApplicationID              string     `db:"application_id"`
+	Name                       string     `db:"name"`
+	Schedule                   string     `db:"schedule"`
+	Timezone                   string     `db:"timezone"`
+	ConcurrencyPolicy          string     `db:"concurrency_policy"`
+	Suspend                    bool       `db:"suspend"`
+	SuccessfulJobsHistoryLimit int        `db:"successful_jobs_history_limit"`
+	FailedJobsHistoryLimit     int        `db:"failed_jobs_history_limit"`
+	JobTemplate                string     `db:"job_template"`
+	LastScheduleAt             *time.Time `db:"last_schedule_at"`
+	CreatedAt                  time.Time  `db:"created_at"`
+	UpdatedAt                  time.Time  `db:"updated_at"`
+}
+
+// ============================================================================
+// CronJobRepo - 接口定义
+// ============================================================================
+
+// CronJobRepo CronJob 仓库接口
+type CronJobRepo interface {
+	// Save 新增或覆盖一个 CronJob
+	Save(ctx context.Context, dao *CronJobDAO) error
+	// UpdateLastSchedule 记录最近一次触发时间
+	UpdateLastSchedule(ctx context.Context, id string, at time.Time) error
+	Delete(ctx context.Context, id string) error
+	GetAll(ctx context.Context) ([]*CronJobDAO, error)
+	Close() error
+}
+
+// ============================================================================
+// CronJobRepoSQLite - SQLite 实现
+// ============================================================================
+
+// cronJobRepoSQLite SQLite 实现的 CronJobRepo
+type cronJobRepoSQLite struct {
+	db *sql.DB
+}
+
+// NewCronJobRepoSQLite 创建基于 SQLite 的 CronJobRepo
+func NewCronJobRepoSQLite(dbPath string, cfg *config.Config) (CronJobRepo, error) {
+	// 确保数据库目录存在
+	dir := filepath.Dir(dbPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create database directory: %w", err)
+	}
+
+	// 打开数据库连接
+	db, err := sql.Open("sqlite3", dbPath+"?_foreign_keys=1&_journal_mode=WAL")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// 设置连接池参数
+	if cfg != nil {
+		db.SetMaxOpenConns(cfg.Database.MaxOpenConns)
+		db.SetMaxIdleConns(cfg.Database.MaxIdleConns)
+		if cfg.Database.ConnMaxLifetimeSeconds > 0 {
+			db.SetConnMaxLifetime(time.Duration(cfg.Database.ConnMaxLifetimeSeconds) * time.Second)
+		}
+	}
+
+	// 测试连接
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	repo := &cronJobRepoSQLite{db: db}
+	if err := repo.initSchema(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize schema: %w", err)
+	}
+
+	logrus.Infof("CronJob repository initialized with SQLite at %s", dbPath)
+	return repo, nil
+}
+
+// initSchema 初始化数据库表结构
+func (r *cronJobRepoSQLite) initSchema() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS cron_jobs (
+		id TEXT PRIMARY KEY,
+		application_id TEXT NOT NULL,
+		name TEXT NOT NULL DEFAULT '',
+		schedule TEXT NOT NULL,
+		timezone TEXT NOT NULL DEFAULT '',
+		concurrency_policy TEXT NOT NULL DEFAULT 'allow',
+		suspend INTEGER NOT NULL DEFAULT 0,
+		successful_jobs_history_limit INTEGER NOT NULL DEFAULT 3,
+		failed_jobs_history_limit INTEGER NOT NULL DEFAULT 1,
+		job_template TEXT NOT NULL,
+		last_schedule_at DATETIME,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_cron_jobs_application_id ON cron_jobs(application_id);
+	`
+
+	if _, err := r.db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create table: %w", err)
+	}
+	return nil
+}
+
+// Close 关闭数据库连接
+func (r *cronJobRepoSQLite) Close() error {
+	if r.db != nil {
+		return r.db.Close()
+	}
+	return nil
+}
+
+// Save 新增或覆盖一个 CronJob
+func (r *cronJobRepoSQLite) Save(ctx context.Context, dao *CronJobDAO) error {
+	query := `
+		INSERT INTO cron_jobs (id, application_id, name, schedule, timezone, concurrency_policy, suspend,
+			successful_jobs_history_limit, failed_jobs_history_limit, job_template, last_schedule_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			name = excluded.name,
+			schedule = excluded.schedule,
+			timezone = excluded.timezone,
+			concurrency_policy = excluded.concurrency_policy,
+			suspend = excluded.suspend,
+			successful_jobs_history_limit = excluded.successful_jobs_history_limit,
+			failed_jobs_history_limit = excluded.failed_jobs_history_limit,
+			job_template = excluded.job_template,
+			last_schedule_at = excluded.last_schedule_at,
+			updated_at = excluded.updated_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		dao.ID,
+		dao.ApplicationID,
+		dao.Name,
+		dao.Schedule,
+		dao.Timezone,
+		dao.ConcurrencyPolicy,
+		dao.Suspend,
+		dao.SuccessfulJobsHistoryLimit,
+		dao.FailedJobsHistoryLimit,
+		dao.JobTemplate,
+		dao.LastScheduleAt,
+		dao.CreatedAt,
+		dao.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save cron job: %w", err)
+	}
+	return nil
+}
+
+// UpdateLastSchedule 记录最近一次触发时间
+func (r *cronJobRepoSQLite) UpdateLastSchedule(ctx context.Context, id string, at time.Time) error {
+	query := `UPDATE cron_jobs SET last_schedule_at = ? WHERE id = ?`
+
+	if _, err := r.db.ExecContext(ctx, query, at, id); err != nil {
+		return fmt.Errorf("failed to update cron job schedule time: %w", err)
+	}
+	return nil
+}
+
+// Delete 删除 CronJob
+func (r *cronJobRepoSQLite) Delete(ctx context.Context, id string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM cron_jobs WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete cron job: %w", err)
+	}
+	return nil
+}
+
+// GetAll 获取所有 CronJob，按创建时间排序
+func (r *cronJobRepoSQLite) GetAll(ctx context.Context) ([]*CronJobDAO, error) {
+	query := `
+		SELECT id, application_id, name, schedule, timezone, concurrency_policy, suspend,
+			successful_jobs_history_limit, failed_jobs_history_limit, job_template, last_schedule_at, created_at, updated_at
+		FROM cron_jobs
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cron jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var daos []*CronJobDAO
+	for rows.Next() {
+		dao := &CronJobDAO{}
+		var lastScheduleAt sql.NullTime
+		if err := rows.Scan(
+			&dao.ID,
+			&dao.ApplicationID,
+			&dao.Name,
+			&dao.Schedule,
+			&dao.Timezone,
+			&dao.ConcurrencyPolicy,
+			&dao.Suspend,
+			&dao.SuccessfulJobsHistoryLimit,
+			&dao.FailedJobsHistoryLimit,
+			&dao.JobTemplate,
+			&lastScheduleAt,
+			&dao.CreatedAt,
+			&dao.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan cron job: %w", err)
+		}
+		if lastScheduleAt.Valid {
+			dao.LastScheduleAt = &lastScheduleAt.Time
+		}
+		daos = append(daos, dao)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate cron jobs: %w", err)
+	}
+	return daos, nil
+}
@@ -0,0 +1,233 @@
+package resource
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/9triver/iarnet/internal/config"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/sirupsen/logrus"
+)
+
+// ============================================================================
+// UsageDAO - 数据访问对象
+// ============================================================================
+
+// UsageDAO component 资源占用记录数据访问对象
+// 一条记录对应 component 从部署成功到释放的一段占用，EndedAt 为空表示仍在占用
+type UsageDAO struct {
+	ID          string     `db:"id"`
+	ComponentID string     `db:"component_id"`
+	AppID       string     `db:"app_id"`
+	DomainID    string     `db:"domain_id"`
+	NodeID      string     `db:"node_id"`
+	Target      string     `db:"target"` // component 所在位置，格式同 component 的 provider ID
+	CPU         int64      `db:"cpu"`    // millicores
+	Memory      int64      `db:"memory"` // bytes
+	GPU         int64      `db:"gpu"`
+	StartedAt   time.Time  `db:"started_at"`
+	EndedAt     *time.Time `db:"ended_at"`
+}
+
+// ============================================================================
+// AccountingRepo - 接口定义
+// ============================================================================
+
+// AccountingRepo 资源占用记录仓库接口
+type AccountingRepo interface {
+	// CreateUsage 新增一条占用记录
+	CreateUsage(ctx context.Context, dao *UsageDAO) error
+	// EndUsage 结束一条占用记录，已结束的记录不受影响
+	EndUsage(ctx context.Context, id string, endedAt time.Time) error
+	// GetOpenUsages 获取所有尚未结束的占用记录
+	GetOpenUsages(ctx context.Context) ([]*UsageDAO, error)
+	// GetUsagesInRange 获取与 [from, to) 有交集的占用记录
+	GetUsagesInRange(ctx context.Context, from, to time.Time) ([]*UsageDAO, error)
+	Close() error
+}
+
+// ============================================================================
+// AccountingRepoSQLite - SQLite 实现
+// ============================================================================
+
+// accountingRepoSQLite SQLite 实现的 AccountingRepo
+type accountingRepoSQLite struct {
+	db *sql.DB
+}
+
+// NewAccountingRepoSQLite 创建基于 SQLite 的 AccountingRepo
+func NewAccountingRepoSQLite(dbPath string, cfg *config.Config) (AccountingRepo, error) {
+	// 确保数据库目录存在
+	dir := filepath.Dir(dbPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create database directory: %w", err)
+	}
+
+	// 打开数据库连接
+	db, err := sql.Open("sqlite3", dbPath+"?_foreign_keys=1&_journal_mode=WAL")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// 设置连接池参数
+	if cfg != nil {
+		db.SetMaxOpenConns(cfg.Database.MaxOpenConns)
+		db.SetMaxIdleConns(cfg.Database.MaxIdleConns)
+		if cfg.Database.ConnMaxLifetimeSeconds > 0 {
+			db.SetConnMaxLifetime(time.Duration(cfg.Database.ConnMaxLifetimeSeconds) * time.Second)
+		}
+	}
+
+	// 测试连接
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	repo := &accountingRepoSQLite{db: db}
+	if err := repo.initSchema(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize schema: %w", err)
+	}
+
+	logrus.Infof("Accounting repository initialized with SQLite at %s", dbPath)
+	return repo, nil
+}
+
+// initSchema 初始化数据库表结构
+func (r *accountingRepoSQLite) initSchema() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS usages (
+		id TEXT PRIMARY KEY,
+		component_id TEXT NOT NULL,
+		app_id TEXT NOT NULL DEFAULT '',
+		domain_id TEXT NOT NULL DEFAULT '',
+		node_id TEXT NOT NULL DEFAULT '',
+		target TEXT NOT NULL DEFAULT '',
+		cpu INTEGER NOT NULL DEFAULT 0,
+		memory INTEGER NOT NULL DEFAULT 0,
+		gpu INTEGER NOT NULL DEFAULT 0,
+		started_at DATETIME NOT NULL,
+		ended_at DATETIME
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_usages_started_at ON usages(started_at);
+	CREATE INDEX IF NOT EXISTS idx_usages_ended_at ON usages(ended_at);
+	`
+
+	if _, err := r.db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create table: %w", err)
+	}
+	return nil
+}
+
+// Close 关闭数据库连接
+func (r *accountingRepoSQLite) Close() error {
+	if r.db != nil {
+		return r.db.Close()
+	}
+	return nil
+}
+
+// CreateUsage 新增一条占用记录
+func (r *accountingRepoSQLite) CreateUsage(ctx context.Context, dao *UsageDAO) error {
+	query := `
+		INSERT INTO usages (id, component_id, app_id, domain_id, node_id, target, cpu, memory, gpu, started_at, ended_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		dao.ID,
+		dao.ComponentID,
+		dao.AppID,
+		dao.DomainID,
+		dao.NodeID,
+		dao.Target,
+		dao.CPU,
+		dao.Memory,
+		dao.GPU,
+		dao.StartedAt,
+		dao.EndedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create usage: %w", err)
+	}
+	return nil
+}
+
+// EndUsage 结束一条占用记录
+func (r *accountingRepoSQLite) EndUsage(ctx context.Context, id string, endedAt time.Time) error {
+	query := `UPDATE usages SET ended_at = ? WHERE id = ? AND ended_at IS NULL`
+
+	if _, err := r.db.ExecContext(ctx, query, endedAt, id); err != nil {
+		return fmt.Errorf("failed to end usage: %w", err)
+	}
+	return nil
+}
+
+// GetOpenUsages 获取所有尚未结束的占用记录
+func (r *accountingRepoSQLite) GetOpenUsages(ctx context.Context) ([]*UsageDAO, error) {
+	query := `
+		SELECT id, component_id, app_id, domain_id, node_id, target, cpu, memory, gpu, started_at, ended_at
+		FROM usages
+		WHERE ended_at IS NULL
+		ORDER BY started_at
+	`
+	return r.queryUsages(ctx, query)
+}
+
+// GetUsagesInRange 获取与 [from, to) 有交集的占用记录
+func (r *accountingRepoSQLite) GetUsagesInRange(ctx context.Context, from, to time.Time) ([]*UsageDAO, error) {
+	query := `
+		SELECT id, component_id, app_id, domain_id, node_id, target, cpu, memory, gpu, started_at, ended_at
+		FROM usages
+		WHERE started_at < ? AND (ended_at IS NULL OR ended_at > ?)
+		ORDER BY started_at
+	`
+	return r.queryUsages(ctx, query, to, from)
+}
+
+func (r *accountingRepoSQLite) queryUsages(ctx context.Context, query string, args ...any) ([]*UsageDAO, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query usages: %w", err)
+	}
+	defer rows.Close()
+
+	var daos []*UsageDAO
+	for rows.Next() {
+		var (
+			dao     UsageDAO
+			endedAt sql.NullTime
+		)
+		err := rows.Scan(
+			&dao.ID,
+			&dao.ComponentID,
+			&dao.AppID,
+			&dao.DomainID,
+			&dao.NodeID,
+			&dao.Target,
+			&dao.CPU,
+			&dao.Memory,
+			&dao.GPU,
+			&dao.StartedAt,
+			&endedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan usage: %w", err)
+		}
+		if endedAt.Valid {
+			dao.EndedAt = &endedAt.Time
+		}
+		daos = append(daos, &dao)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating usages: %w", err)
+	}
+	return daos, nil
+}
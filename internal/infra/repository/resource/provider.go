@@ -21,10 +21,16 @@ import (
 // 用于数据库持久化，只保存基本信息
 // DAO 层只定义数据结构，不依赖领域对象
 type ProviderDAO struct {
-	ID        string    `db:"id"`
-	Name      string    `db:"name"`
-	Host      string    `db:"host"`
-	Port      int       `db:"port"`
+	ID   string `db:"id"`
+	Name string `db:"name"`
+	Host string `db:"host"`
+	Port int    `db:"port"`
+	// CapacityClass 容量类别（guaranteed / best-effort）
+	CapacityClass string `db:"capacity_class"`
+	// Benchmark 注册时微基准测试结果（JSON），未测量时为空
+	Benchmark string `db:"benchmark"`
+	// Static 是否由配置文件（static_providers）管理，启动时按配置同步
+	Static    bool      `db:"static"`
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
 }
@@ -41,6 +47,7 @@ type ProviderRepo interface {
 	Delete(ctx context.Context, id string) error
 	Get(ctx context.Context, id string) (*ProviderDAO, error)
 	GetAll(ctx context.Context) ([]*ProviderDAO, error)
+	Close() error
 }
 
 // ============================================================================
@@ -96,6 +103,9 @@ func (r *providerRepoSQLite) initSchema() error {
 		name TEXT NOT NULL,
 		host TEXT NOT NULL,
 		port INTEGER NOT NULL,
+		capacity_class TEXT NOT NULL DEFAULT 'guaranteed',
+		benchmark TEXT NOT NULL DEFAULT '',
+		static INTEGER NOT NULL DEFAULT 0,
 		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
 		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
 	);
@@ -107,6 +117,53 @@ func (r *providerRepoSQLite) initSchema() error {
 		return fmt.Errorf("failed to create table: %w", err)
 	}
 
+	// 兼容旧版本数据库：补充 capacity_class 列
+	if err := r.ensureColumn("capacity_class", "TEXT NOT NULL DEFAULT 'guaranteed'"); err != nil {
+		return err
+	}
+	// 兼容旧版本数据库：补充 benchmark 列
+	if err := r.ensureColumn("benchmark", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+	// 兼容旧版本数据库：补充 static 列
+	if err := r.ensureColumn("static", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ensureColumn 检查 providers 表中是否存在指定列，不存在则添加
+func (r *providerRepoSQLite) ensureColumn(name, definition string) error {
+	rows, err := r.db.Query(`PRAGMA table_info(providers)`)
+	if err != nil {
+		return fmt.Errorf("failed to read table info: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid        int
+			colName    string
+			colType    string
+			notNull    int
+			defaultVal sql.NullString
+			pk         int
+		)
+		if err := rows.Scan(&cid, &colName, &colType, &notNull, &defaultVal, &pk); err != nil {
+			return fmt.Errorf("failed to scan table info: %w", err)
+		}
+		if colName == name {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating table info: %w", err)
+	}
+
+	if _, err := r.db.Exec(fmt.Sprintf("ALTER TABLE providers ADD COLUMN %s %s", name, definition)); err != nil {
+		return fmt.Errorf("failed to add column %s: %w", name, err)
+	}
 	return nil
 }
 
@@ -130,8 +187,8 @@ func (r *providerRepoSQLite) Create(ctx context.Context, dao *ProviderDAO) error
 	}
 
 	query := `
-		INSERT INTO providers (id, name, host, port, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?)
+		INSERT INTO providers (id, name, host, port, capacity_class, benchmark, static, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	_, err := r.db.ExecContext(ctx, query,
@@ -139,6 +196,9 @@ func (r *providerRepoSQLite) Create(ctx context.Context, dao *ProviderDAO) error
 		dao.Name,
 		dao.Host,
 		dao.Port,
+		capacityClassOrDefault(dao.CapacityClass),
+		dao.Benchmark,
+		dao.Static,
 		dao.CreatedAt,
 		dao.UpdatedAt,
 	)
@@ -161,7 +221,7 @@ func (r *providerRepoSQLite) Update(ctx context.Context, dao *ProviderDAO) error
 
 	query := `
 		UPDATE providers
-		SET name = ?, host = ?, port = ?, updated_at = ?
+		SET name = ?, host = ?, port = ?, capacity_class = ?, benchmark = ?, static = ?, updated_at = ?
 		WHERE id = ?
 	`
 
@@ -169,6 +229,9 @@ func (r *providerRepoSQLite) Update(ctx context.Context, dao *ProviderDAO) error
 		dao.Name,
 		dao.Host,
 		dao.Port,
+		capacityClassOrDefault(dao.CapacityClass),
+		dao.Benchmark,
+		dao.Static,
 		dao.UpdatedAt,
 		dao.ID,
 	)
@@ -215,7 +278,7 @@ func (r *providerRepoSQLite) Delete(ctx context.Context, id string) error {
 // Get 获取指定 ID 的 Provider
 func (r *providerRepoSQLite) Get(ctx context.Context, id string) (*ProviderDAO, error) {
 	query := `
-		SELECT id, name, host, port, created_at, updated_at
+		SELECT id, name, host, port, capacity_class, benchmark, static, created_at, updated_at
 		FROM providers
 		WHERE id = ?
 	`
@@ -226,6 +289,9 @@ func (r *providerRepoSQLite) Get(ctx context.Context, id string) (*ProviderDAO,
 		&dao.Name,
 		&dao.Host,
 		&dao.Port,
+		&dao.CapacityClass,
+		&dao.Benchmark,
+		&dao.Static,
 		&dao.CreatedAt,
 		&dao.UpdatedAt,
 	)
@@ -243,7 +309,7 @@ func (r *providerRepoSQLite) Get(ctx context.Context, id string) (*ProviderDAO,
 // GetAll 获取所有 Provider
 func (r *providerRepoSQLite) GetAll(ctx context.Context) ([]*ProviderDAO, error) {
 	query := `
-		SELECT id, name, host, port, created_at, updated_at
+		SELECT id, name, host, port, capacity_class, benchmark, static, created_at, updated_at
 		FROM providers
 		ORDER BY created_at DESC
 	`
@@ -262,6 +328,9 @@ func (r *providerRepoSQLite) GetAll(ctx context.Context) ([]*ProviderDAO, error)
 			&dao.Name,
 			&dao.Host,
 			&dao.Port,
+			&dao.CapacityClass,
+			&dao.Benchmark,
+			&dao.Static,
 			&dao.CreatedAt,
 			&dao.UpdatedAt,
 		)
@@ -278,3 +347,11 @@ func (r *providerRepoSQLite) GetAll(ctx context.Context) ([]*ProviderDAO, error)
 
 	return daos, nil
 }
+
+// capacityClassOrDefault 未指定容量类别时使用 guaranteed
+func capacityClassOrDefault(class string) string {
+	if class == "" {
+		return "guaranteed"
+	}
+	return class
+}
@@ -68,9 +68,11 @@ func (x *Ack) GetError() string {
 
 // Ready indicates that the component is ready to receive messages
 type Ready struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AcceptEncodings []string               `protobuf:"bytes,1,rep,name=AcceptEncodings,proto3" json:"AcceptEncodings,omitempty"` // frame compressions the component can decode (e.g. gzip, zstd)
+	UpstreamToken   string                 `protobuf:"bytes,2,opt,name=UpstreamToken,proto3" json:"UpstreamToken,omitempty"`     // token bound to the component ID (IARNET_UPSTREAM_TOKEN), empty if none was issued
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *Ready) Reset() {
@@ -103,14 +105,30 @@ func (*Ready) Descriptor() ([]byte, []int) {
 	return file_common_messages_proto_rawDescGZIP(), []int{1}
 }
 
+func (x *Ready) GetAcceptEncodings() []string {
+	if x != nil {
+		return x.AcceptEncodings
+	}
+	return nil
+}
+
+func (x *Ready) GetUpstreamToken() string {
+	if x != nil {
+		return x.UpstreamToken
+	}
+	return ""
+}
+
 var File_common_messages_proto protoreflect.FileDescriptor
 
 const file_common_messages_proto_rawDesc = "" +
 	"\n" +
 	"\x15common/messages.proto\x12\x06common\"\x1b\n" +
 	"\x03Ack\x12\x14\n" +
-	"\x05Error\x18\x01 \x01(\tR\x05Error\"\a\n" +
-	"\x05ReadyB1Z/github.com/9triver/iarnet/internal/proto/commonb\x06proto3"
+	"\x05Error\x18\x01 \x01(\tR\x05Error\"W\n" +
+	"\x05Ready\x12(\n" +
+	"\x0fAcceptEncodings\x18\x01 \x03(\tR\x0fAcceptEncodings\x12$\n" +
+	"\rUpstreamToken\x18\x02 \x01(\tR\rUpstreamTokenB1Z/github.com/9triver/iarnet/internal/proto/commonb\x06proto3"
 
 var (
 	file_common_messages_proto_rawDescOnce sync.Once
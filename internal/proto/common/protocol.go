@@ -0,0 +1,130 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+)
+
+// 协议版本
+// 版本 1 为引入版本协商之前的协议，握手中不携带 ProtocolInfo 的对端均视为版本 1
+const (
+	ProtocolVersion       uint32 = 2
+	MinProtocolVersion    uint32 = 1
+	LegacyProtocolVersion uint32 = 1
+)
+
+// 可选能力：对端声明支持后才会使用，未声明时按能力缺失降级
+const (
+	// provider 能力
+	CapUndeploy       = "undeploy"        // Undeploy RPC（迁移、回收）
+	CapBenchmark      = "benchmark"       // Benchmark RPC（注册时微基准测试）
+	CapWatchUsage     = "watch_usage"     // WatchUsage 推送流，缺失时退化为轮询
+	CapExec           = "exec"            // Exec 调试会话
+	CapPortForward    = "port_forward"    // PortForward 端口转发
+	CapExportImage    = "export_image"    // ExportImage P2P 镜像分发
+	CapEgressPolicy   = "egress_policy"   // 部署时执行出站网络策略
+	CapDataStaging    = "data_staging"    // 部署时预置数据到 component 工作目录，并支持 GetStagingStatus
+	CapVolumes        = "volumes"         // 部署时挂载卷，并支持 CreateVolume/ListVolumes/DeleteVolume
+	CapSecurity       = "security"        // 部署时执行容器安全配置（只读根文件系统、capabilities、seccomp/AppArmor）
+	CapInstanceStatus = "instance_status" // GetInstanceStatus 查询实例运行状态与退出码
+	CapComponentUsage = "component_usage" // GetComponentUsage 按 component 实例上报资源使用情况
+	CapSidecars       = "sidecars"        // 部署时与主容器一起运行 sidecar 容器，合计核算资源（节点间委托同样使用）
+
+	// 节点（peer）能力
+	CapProposeDeployment = "propose_deployment" // ProposeDeployment 部署探测
+	CapNodeUtilization   = "node_utilization"   // GetNodeUtilization 利用率查询
+	CapAffinity          = "affinity"           // 跨节点部署携带会话亲和
+	CapCompressionGzip   = "compression_gzip"   // 可解压 gzip 压缩的 gRPC 消息
+	CapCompressionZstd   = "compression_zstd"   // 可解压 zstd 压缩的 gRPC 消息
+	CapUndeployComponent = "undeploy_component" // 部署时接受调用方指定的 component ID，并支持 UndeployComponent 回滚
+	CapProviderList      = "provider_list"      // ListProviders 分页、字段掩码与增量列举 provider
+	CapComponentList     = "component_list"     // ListComponents 按应用、标签、provider 类型与状态列举 component
+	CapComponentOrigin   = "component_origin"   // 记录委托部署的委托方，并通过 ReconcileComponents 对账遗留的 component
+	CapComponentTransfer = "component_transfer" // TransferComponents 将已离开节点委托部署的 component 转移给接管节点
+)
+
+// NodeCapabilities iarnet 节点作为 peer 提供的能力
+var NodeCapabilities = []string{
+	CapProposeDeployment, CapNodeUtilization, CapAffinity, CapCompressionGzip, CapCompressionZstd,
+	CapUndeployComponent, CapDataStaging, CapSecurity, CapSidecars, CapProviderList, CapComponentList,
+	CapComponentOrigin, CapComponentTransfer,
+}
+
+// ProviderCapabilities iarnet 节点作为 provider 调用方能够使用的能力
+var ProviderCapabilities = []string{
+	CapUndeploy, CapBenchmark, CapWatchUsage, CapExec, CapPortForward, CapExportImage, CapEgressPolicy,
+	CapDataStaging, CapVolumes, CapSecurity, CapInstanceStatus, CapComponentUsage, CapSidecars,
+}
+
+// NewProtocolInfo 创建声明本端协议版本与能力的 ProtocolInfo
+func NewProtocolInfo(capabilities ...string) *ProtocolInfo {
+	return &ProtocolInfo{
+		Version:      ProtocolVersion,
+		MinVersion:   MinProtocolVersion,
+		Capabilities: append([]string(nil), capabilities...),
+	}
+}
+
+// Negotiated 协商结果：双方共同使用的协议版本与能力
+type Negotiated struct {
+	Version      uint32
+	Legacy       bool // 对端未携带 ProtocolInfo
+	capabilities map[string]struct{}
+}
+
+// Negotiate 协商本端与对端的协议
+// remote 为 nil 时对端视为 legacy；双方版本区间不相交时返回错误
+// 协商后的能力为双方声明能力的交集
+func Negotiate(local, remote *ProtocolInfo) (*Negotiated, error) {
+	if remote == nil {
+		remote = &ProtocolInfo{Version: LegacyProtocolVersion, MinVersion: LegacyProtocolVersion}
+	}
+	remoteMin := remote.MinVersion
+	if remoteMin == 0 {
+		remoteMin = remote.Version
+	}
+	if remote.Version < local.MinVersion {
+		return nil, fmt.Errorf("peer protocol version %d is older than the minimum supported version %d", remote.Version, local.MinVersion)
+	}
+	if local.Version < remoteMin {
+		return nil, fmt.Errorf("peer requires protocol version >= %d, local version is %d", remoteMin, local.Version)
+	}
+
+	n := &Negotiated{
+		Version:      min(local.Version, remote.Version),
+		Legacy:       remote.Version == LegacyProtocolVersion && len(remote.Capabilities) == 0,
+		capabilities: make(map[string]struct{}),
+	}
+	offered := make(map[string]struct{}, len(local.Capabilities))
+	for _, c := range local.Capabilities {
+		offered[c] = struct{}{}
+	}
+	for _, c := range remote.Capabilities {
+		if _, ok := offered[c]; ok {
+			n.capabilities[c] = struct{}{}
+		}
+	}
+	return n, nil
+}
+
+// Supports 是否协商了指定能力，nil 视为 legacy
+func (n *Negotiated) Supports(capability string) bool {
+	if n == nil {
+		return false
+	}
+	_, ok := n.capabilities[capability]
+	return ok
+}
+
+// Capabilities 返回协商后的能力列表（已排序）
+func (n *Negotiated) Capabilities() []string {
+	if n == nil {
+		return nil
+	}
+	caps := make([]string, 0, len(n.capabilities))
+	for c := range n.capabilities {
+		caps = append(caps, c)
+	}
+	sort.Strings(caps)
+	return caps
+}
@@ -0,0 +1,143 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.31.1
+// source: common/protocol.proto
+
+package common
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ProtocolInfo 协议版本与能力声明，在握手（provider Connect、registry RegisterNode、gossip 节点信息）中交换
+// 未携带该字段的对端视为 legacy（版本 1，不具备任何可选能力）
+type ProtocolInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       uint32                 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`                         // 本端实现的协议版本
+	MinVersion    uint32                 `protobuf:"varint,2,opt,name=min_version,json=minVersion,proto3" json:"min_version,omitempty"` // 本端仍兼容的最低协议版本
+	Capabilities  []string               `protobuf:"bytes,3,rep,name=capabilities,proto3" json:"capabilities,omitempty"`                // 本端支持的可选能力（如 undeploy、watch_usage）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProtocolInfo) Reset() {
+	*x = ProtocolInfo{}
+	mi := &file_common_protocol_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtocolInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtocolInfo) ProtoMessage() {}
+
+func (x *ProtocolInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_common_protocol_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtocolInfo.ProtoReflect.Descriptor instead.
+func (*ProtocolInfo) Descriptor() ([]byte, []int) {
+	return file_common_protocol_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ProtocolInfo) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ProtocolInfo) GetMinVersion() uint32 {
+	if x != nil {
+		return x.MinVersion
+	}
+	return 0
+}
+
+func (x *ProtocolInfo) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+var File_common_protocol_proto protoreflect.FileDescriptor
+
+const file_common_protocol_proto_rawDesc = "" +
+	"\n" +
+	"\x15common/protocol.proto\x12\x06common\"m\n" +
+	"\fProtocolInfo\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\rR\aversion\x12\x1f\n" +
+	"\vmin_version\x18\x02 \x01(\rR\n" +
+	"minVersion\x12\"\n" +
+	"\fcapabilities\x18\x03 \x03(\tR\fcapabilitiesB1Z/github.com/9triver/iarnet/internal/proto/commonb\x06proto3"
+
+var (
+	file_common_protocol_proto_rawDescOnce sync.Once
+	file_common_protocol_proto_rawDescData []byte
+)
+
+func file_common_protocol_proto_rawDescGZIP() []byte {
+	file_common_protocol_proto_rawDescOnce.Do(func() {
+		file_common_protocol_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_common_protocol_proto_rawDesc), len(file_common_protocol_proto_rawDesc)))
+	})
+	return file_common_protocol_proto_rawDescData
+}
+
+var file_common_protocol_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_common_protocol_proto_goTypes = []any{
+	(*ProtocolInfo)(nil), // 0: common.ProtocolInfo
+}
+var file_common_protocol_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_common_protocol_proto_init() }
+func file_common_protocol_proto_init() {
+	if File_common_protocol_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_common_protocol_proto_rawDesc), len(file_common_protocol_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_common_protocol_proto_goTypes,
+		DependencyIndexes: file_common_protocol_proto_depIdxs,
+		MessageInfos:      file_common_protocol_proto_msgTypes,
+	}.Build()
+	File_common_protocol_proto = out.File
+	file_common_protocol_proto_goTypes = nil
+	file_common_protocol_proto_depIdxs = nil
+}
@@ -2,11 +2,64 @@ package common
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/gob"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 )
 
+// IntegrityError 对象内容与其 SHA-256 摘要不一致
+type IntegrityError struct {
+	ObjectID string
+	Expected string
+	Actual   string
+}
+
+func (e *IntegrityError) Error() string {
+	return fmt.Sprintf("object %s is corrupted: expected sha256 %s, got %s", e.ObjectID, e.Expected, e.Actual)
+}
+
+// ContentDigest 计算对象数据的 SHA-256 摘要（十六进制）
+func (obj *EncodedObject) ContentDigest() string {
+	sum := sha256.Sum256(obj.GetData())
+	return hex.EncodeToString(sum[:])
+}
+
+// Seal 未设置摘要时按当前数据计算摘要，流式对象不计算
+func (obj *EncodedObject) Seal() {
+	if obj.IsStream || obj.Digest != "" {
+		return
+	}
+	obj.Digest = obj.ContentDigest()
+}
+
+// Verify 校验对象数据与摘要是否一致，未设置摘要的对象不校验
+func (obj *EncodedObject) Verify() error {
+	if obj.GetIsStream() || obj.GetDigest() == "" {
+		return nil
+	}
+	if actual := obj.ContentDigest(); actual != obj.Digest {
+		return &IntegrityError{ObjectID: obj.ID, Expected: obj.Digest, Actual: actual}
+	}
+	return nil
+}
+
+// VerifyRef 校验对象是否与引用中记录的摘要一致，引用未记录摘要时只校验对象自身
+func (obj *EncodedObject) VerifyRef(ref *ObjectRef) error {
+	if err := obj.Verify(); err != nil {
+		return err
+	}
+	if ref.GetDigest() == "" || obj.GetIsStream() {
+		return nil
+	}
+	if actual := obj.ContentDigest(); actual != ref.Digest {
+		return &IntegrityError{ObjectID: obj.ID, Expected: ref.Digest, Actual: actual}
+	}
+	return nil
+}
+
 func (obj *EncodedObject) Value() (any, error) {
 	if obj.IsStream {
 		return nil, errors.New("cannot get object directly on stream")
@@ -79,6 +79,7 @@ type ObjectRef struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ID            string                 `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`         // Object ID
 	Source        string                 `protobuf:"bytes,2,opt,name=Source,proto3" json:"Source,omitempty"` // Source store ID (optional)
+	Digest        string                 `protobuf:"bytes,3,opt,name=Digest,proto3" json:"Digest,omitempty"` // hex encoded SHA-256 of the object data (optional), readers reject objects that do not match
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -127,6 +128,342 @@ func (x *ObjectRef) GetSource() string {
 	return ""
 }
 
+func (x *ObjectRef) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+// DataSource is a dataset staged into the component workspace before execution starts
+// Exactly one of URL and ObjectID is set
+type DataSource struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	URL           string                 `protobuf:"bytes,1,opt,name=URL,proto3" json:"URL,omitempty"`           // http(s) URL downloaded by the provider
+	ObjectID      string                 `protobuf:"bytes,2,opt,name=ObjectID,proto3" json:"ObjectID,omitempty"` // object in the application's store
+	Path          string                 `protobuf:"bytes,3,opt,name=Path,proto3" json:"Path,omitempty"`         // relative path inside the component workspace
+	SHA256        string                 `protobuf:"bytes,4,opt,name=SHA256,proto3" json:"SHA256,omitempty"`     // hex encoded SHA-256 checksum (optional), staging fails on mismatch
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DataSource) Reset() {
+	*x = DataSource{}
+	mi := &file_common_types_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataSource) ProtoMessage() {}
+
+func (x *DataSource) ProtoReflect() protoreflect.Message {
+	mi := &file_common_types_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataSource.ProtoReflect.Descriptor instead.
+func (*DataSource) Descriptor() ([]byte, []int) {
+	return file_common_types_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DataSource) GetURL() string {
+	if x != nil {
+		return x.URL
+	}
+	return ""
+}
+
+func (x *DataSource) GetObjectID() string {
+	if x != nil {
+		return x.ObjectID
+	}
+	return ""
+}
+
+func (x *DataSource) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *DataSource) GetSHA256() string {
+	if x != nil {
+		return x.SHA256
+	}
+	return ""
+}
+
+// VolumeMount mounts persistent storage into the component
+// Exactly one of Name and HostPath is set; both live on the provider host, so the component is pinned to its node
+type VolumeMount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`           // named volume managed by the provider, created on first use
+	HostPath      string                 `protobuf:"bytes,2,opt,name=HostPath,proto3" json:"HostPath,omitempty"`   // absolute directory on the provider host
+	MountPath     string                 `protobuf:"bytes,3,opt,name=MountPath,proto3" json:"MountPath,omitempty"` // absolute path inside the component
+	ReadOnly      bool                   `protobuf:"varint,4,opt,name=ReadOnly,proto3" json:"ReadOnly,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VolumeMount) Reset() {
+	*x = VolumeMount{}
+	mi := &file_common_types_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VolumeMount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolumeMount) ProtoMessage() {}
+
+func (x *VolumeMount) ProtoReflect() protoreflect.Message {
+	mi := &file_common_types_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolumeMount.ProtoReflect.Descriptor instead.
+func (*VolumeMount) Descriptor() ([]byte, []int) {
+	return file_common_types_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *VolumeMount) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VolumeMount) GetHostPath() string {
+	if x != nil {
+		return x.HostPath
+	}
+	return ""
+}
+
+func (x *VolumeMount) GetMountPath() string {
+	if x != nil {
+		return x.MountPath
+	}
+	return ""
+}
+
+func (x *VolumeMount) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+// SecurityContext hardens the component container
+// When absent the provider applies its own restrictive default; when present it replaces that default
+type SecurityContext struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	ReadOnlyRootFS           bool                   `protobuf:"varint,1,opt,name=ReadOnlyRootFS,proto3" json:"ReadOnlyRootFS,omitempty"`                     // mount the root filesystem read-only, /tmp stays writable
+	AllowPrivilegeEscalation bool                   `protobuf:"varint,2,opt,name=AllowPrivilegeEscalation,proto3" json:"AllowPrivilegeEscalation,omitempty"` // no-new-privileges is set unless this is true
+	DropCapabilities         []string               `protobuf:"bytes,3,rep,name=DropCapabilities,proto3" json:"DropCapabilities,omitempty"`                  // e.g. ALL, NET_RAW
+	AddCapabilities          []string               `protobuf:"bytes,4,rep,name=AddCapabilities,proto3" json:"AddCapabilities,omitempty"`                    // added back after dropping
+	SeccompProfile           string                 `protobuf:"bytes,5,opt,name=SeccompProfile,proto3" json:"SeccompProfile,omitempty"`                      // empty for the runtime default, "unconfined", or a profile name known to the provider
+	AppArmorProfile          string                 `protobuf:"bytes,6,opt,name=AppArmorProfile,proto3" json:"AppArmorProfile,omitempty"`                    // empty for the runtime default, "unconfined", or a profile loaded on the provider host
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *SecurityContext) Reset() {
+	*x = SecurityContext{}
+	mi := &file_common_types_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecurityContext) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecurityContext) ProtoMessage() {}
+
+func (x *SecurityContext) ProtoReflect() protoreflect.Message {
+	mi := &file_common_types_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecurityContext.ProtoReflect.Descriptor instead.
+func (*SecurityContext) Descriptor() ([]byte, []int) {
+	return file_common_types_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SecurityContext) GetReadOnlyRootFS() bool {
+	if x != nil {
+		return x.ReadOnlyRootFS
+	}
+	return false
+}
+
+func (x *SecurityContext) GetAllowPrivilegeEscalation() bool {
+	if x != nil {
+		return x.AllowPrivilegeEscalation
+	}
+	return false
+}
+
+func (x *SecurityContext) GetDropCapabilities() []string {
+	if x != nil {
+		return x.DropCapabilities
+	}
+	return nil
+}
+
+func (x *SecurityContext) GetAddCapabilities() []string {
+	if x != nil {
+		return x.AddCapabilities
+	}
+	return nil
+}
+
+func (x *SecurityContext) GetSeccompProfile() string {
+	if x != nil {
+		return x.SeccompProfile
+	}
+	return ""
+}
+
+func (x *SecurityContext) GetAppArmorProfile() string {
+	if x != nil {
+		return x.AppArmorProfile
+	}
+	return ""
+}
+
+// Sidecar is an auxiliary container (e.g. a metrics exporter or data fetcher) that runs next to the
+// component's main container on the same provider, sharing its network namespace
+// Its resources are accounted together with the main container's
+type Sidecar struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"` // unique within the component, e.g. "exporter"
+	Image         string                 `protobuf:"bytes,2,opt,name=Image,proto3" json:"Image,omitempty"`
+	CPU           int64                  `protobuf:"varint,3,opt,name=CPU,proto3" json:"CPU,omitempty"`       // millicores
+	Memory        int64                  `protobuf:"varint,4,opt,name=Memory,proto3" json:"Memory,omitempty"` // bytes
+	GPU           int64                  `protobuf:"varint,5,opt,name=GPU,proto3" json:"GPU,omitempty"`
+	Env           map[string]string      `protobuf:"bytes,6,rep,name=Env,proto3" json:"Env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Command       []string               `protobuf:"bytes,7,rep,name=Command,proto3" json:"Command,omitempty"` // overrides the image entrypoint when set
+	Volumes       []*VolumeMount         `protobuf:"bytes,8,rep,name=Volumes,proto3" json:"Volumes,omitempty"` // mounted into the sidecar only; the provider must support volumes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Sidecar) Reset() {
+	*x = Sidecar{}
+	mi := &file_common_types_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Sidecar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sidecar) ProtoMessage() {}
+
+func (x *Sidecar) ProtoReflect() protoreflect.Message {
+	mi := &file_common_types_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sidecar.ProtoReflect.Descriptor instead.
+func (*Sidecar) Descriptor() ([]byte, []int) {
+	return file_common_types_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Sidecar) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Sidecar) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *Sidecar) GetCPU() int64 {
+	if x != nil {
+		return x.CPU
+	}
+	return 0
+}
+
+func (x *Sidecar) GetMemory() int64 {
+	if x != nil {
+		return x.Memory
+	}
+	return 0
+}
+
+func (x *Sidecar) GetGPU() int64 {
+	if x != nil {
+		return x.GPU
+	}
+	return 0
+}
+
+func (x *Sidecar) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *Sidecar) GetCommand() []string {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *Sidecar) GetVolumes() []*VolumeMount {
+	if x != nil {
+		return x.Volumes
+	}
+	return nil
+}
+
 // EncodedObject stores a byte encoded object
 // This is a unified version used across the system
 type EncodedObject struct {
@@ -136,13 +473,14 @@ type EncodedObject struct {
 	Source        string                 `protobuf:"bytes,3,opt,name=Source,proto3" json:"Source,omitempty"`                           // source store ID (optional, for store service)
 	Language      Language               `protobuf:"varint,4,opt,name=Language,proto3,enum=common.Language" json:"Language,omitempty"` // if is JSON, it can be decoded to either Go, Python, or else it can only be decoded to corresponding language.
 	IsStream      bool                   `protobuf:"varint,5,opt,name=IsStream,proto3" json:"IsStream,omitempty"`                      // mark if the object is a stream (unified field name)
+	Digest        string                 `protobuf:"bytes,6,opt,name=Digest,proto3" json:"Digest,omitempty"`                           // hex encoded SHA-256 of Data (optional), set by the store on save and verified on read
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *EncodedObject) Reset() {
 	*x = EncodedObject{}
-	mi := &file_common_types_proto_msgTypes[1]
+	mi := &file_common_types_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -154,7 +492,7 @@ func (x *EncodedObject) String() string {
 func (*EncodedObject) ProtoMessage() {}
 
 func (x *EncodedObject) ProtoReflect() protoreflect.Message {
-	mi := &file_common_types_proto_msgTypes[1]
+	mi := &file_common_types_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -167,7 +505,7 @@ func (x *EncodedObject) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EncodedObject.ProtoReflect.Descriptor instead.
 func (*EncodedObject) Descriptor() ([]byte, []int) {
-	return file_common_types_proto_rawDescGZIP(), []int{1}
+	return file_common_types_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *EncodedObject) GetID() string {
@@ -205,6 +543,13 @@ func (x *EncodedObject) GetIsStream() bool {
 	return false
 }
 
+func (x *EncodedObject) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
 type StreamChunk struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ObjectID      string                 `protobuf:"bytes,1,opt,name=ObjectID,proto3" json:"ObjectID,omitempty"`
@@ -218,7 +563,7 @@ type StreamChunk struct {
 
 func (x *StreamChunk) Reset() {
 	*x = StreamChunk{}
-	mi := &file_common_types_proto_msgTypes[2]
+	mi := &file_common_types_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -230,7 +575,7 @@ func (x *StreamChunk) String() string {
 func (*StreamChunk) ProtoMessage() {}
 
 func (x *StreamChunk) ProtoReflect() protoreflect.Message {
-	mi := &file_common_types_proto_msgTypes[2]
+	mi := &file_common_types_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -243,7 +588,7 @@ func (x *StreamChunk) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamChunk.ProtoReflect.Descriptor instead.
 func (*StreamChunk) Descriptor() ([]byte, []int) {
-	return file_common_types_proto_rawDescGZIP(), []int{2}
+	return file_common_types_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *StreamChunk) GetObjectID() string {
@@ -285,16 +630,48 @@ var File_common_types_proto protoreflect.FileDescriptor
 
 const file_common_types_proto_rawDesc = "" +
 	"\n" +
-	"\x12common/types.proto\x12\x06common\"3\n" +
+	"\x12common/types.proto\x12\x06common\"K\n" +
 	"\tObjectRef\x12\x0e\n" +
 	"\x02ID\x18\x01 \x01(\tR\x02ID\x12\x16\n" +
-	"\x06Source\x18\x02 \x01(\tR\x06Source\"\x95\x01\n" +
+	"\x06Source\x18\x02 \x01(\tR\x06Source\x12\x16\n" +
+	"\x06Digest\x18\x03 \x01(\tR\x06Digest\"f\n" +
+	"\n" +
+	"DataSource\x12\x10\n" +
+	"\x03URL\x18\x01 \x01(\tR\x03URL\x12\x1a\n" +
+	"\bObjectID\x18\x02 \x01(\tR\bObjectID\x12\x12\n" +
+	"\x04Path\x18\x03 \x01(\tR\x04Path\x12\x16\n" +
+	"\x06SHA256\x18\x04 \x01(\tR\x06SHA256\"w\n" +
+	"\vVolumeMount\x12\x12\n" +
+	"\x04Name\x18\x01 \x01(\tR\x04Name\x12\x1a\n" +
+	"\bHostPath\x18\x02 \x01(\tR\bHostPath\x12\x1c\n" +
+	"\tMountPath\x18\x03 \x01(\tR\tMountPath\x12\x1a\n" +
+	"\bReadOnly\x18\x04 \x01(\bR\bReadOnly\"\x9d\x02\n" +
+	"\x0fSecurityContext\x12&\n" +
+	"\x0eReadOnlyRootFS\x18\x01 \x01(\bR\x0eReadOnlyRootFS\x12:\n" +
+	"\x18AllowPrivilegeEscalation\x18\x02 \x01(\bR\x18AllowPrivilegeEscalation\x12*\n" +
+	"\x10DropCapabilities\x18\x03 \x03(\tR\x10DropCapabilities\x12(\n" +
+	"\x0fAddCapabilities\x18\x04 \x03(\tR\x0fAddCapabilities\x12&\n" +
+	"\x0eSeccompProfile\x18\x05 \x01(\tR\x0eSeccompProfile\x12(\n" +
+	"\x0fAppArmorProfile\x18\x06 \x01(\tR\x0fAppArmorProfile\"\x9c\x02\n" +
+	"\aSidecar\x12\x12\n" +
+	"\x04Name\x18\x01 \x01(\tR\x04Name\x12\x14\n" +
+	"\x05Image\x18\x02 \x01(\tR\x05Image\x12\x10\n" +
+	"\x03CPU\x18\x03 \x01(\x03R\x03CPU\x12\x16\n" +
+	"\x06Memory\x18\x04 \x01(\x03R\x06Memory\x12\x10\n" +
+	"\x03GPU\x18\x05 \x01(\x03R\x03GPU\x12*\n" +
+	"\x03Env\x18\x06 \x03(\v2\x18.common.Sidecar.EnvEntryR\x03Env\x12\x18\n" +
+	"\aCommand\x18\a \x03(\tR\aCommand\x12-\n" +
+	"\aVolumes\x18\b \x03(\v2\x13.common.VolumeMountR\aVolumes\x1a6\n" +
+	"\bEnvEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xad\x01\n" +
 	"\rEncodedObject\x12\x0e\n" +
 	"\x02ID\x18\x01 \x01(\tR\x02ID\x12\x12\n" +
 	"\x04Data\x18\x02 \x01(\fR\x04Data\x12\x16\n" +
 	"\x06Source\x18\x03 \x01(\tR\x06Source\x12,\n" +
 	"\bLanguage\x18\x04 \x01(\x0e2\x10.common.LanguageR\bLanguage\x12\x1a\n" +
-	"\bIsStream\x18\x05 \x01(\bR\bIsStream\"\x96\x01\n" +
+	"\bIsStream\x18\x05 \x01(\bR\bIsStream\x12\x16\n" +
+	"\x06Digest\x18\x06 \x01(\tR\x06Digest\"\x96\x01\n" +
 	"\vStreamChunk\x12\x1a\n" +
 	"\bObjectID\x18\x01 \x01(\tR\bObjectID\x12\x16\n" +
 	"\x06Offset\x18\x02 \x01(\x03R\x06Offset\x12\x10\n" +
@@ -320,21 +697,28 @@ func file_common_types_proto_rawDescGZIP() []byte {
 }
 
 var file_common_types_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_common_types_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_common_types_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_common_types_proto_goTypes = []any{
-	(Language)(0),         // 0: common.Language
-	(*ObjectRef)(nil),     // 1: common.ObjectRef
-	(*EncodedObject)(nil), // 2: common.EncodedObject
-	(*StreamChunk)(nil),   // 3: common.StreamChunk
+	(Language)(0),           // 0: common.Language
+	(*ObjectRef)(nil),       // 1: common.ObjectRef
+	(*DataSource)(nil),      // 2: common.DataSource
+	(*VolumeMount)(nil),     // 3: common.VolumeMount
+	(*SecurityContext)(nil), // 4: common.SecurityContext
+	(*Sidecar)(nil),         // 5: common.Sidecar
+	(*EncodedObject)(nil),   // 6: common.EncodedObject
+	(*StreamChunk)(nil),     // 7: common.StreamChunk
+	nil,                     // 8: common.Sidecar.EnvEntry
 }
 var file_common_types_proto_depIdxs = []int32{
-	0, // 0: common.EncodedObject.Language:type_name -> common.Language
-	2, // 1: common.StreamChunk.Value:type_name -> common.EncodedObject
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	8, // 0: common.Sidecar.Env:type_name -> common.Sidecar.EnvEntry
+	3, // 1: common.Sidecar.Volumes:type_name -> common.VolumeMount
+	0, // 2: common.EncodedObject.Language:type_name -> common.Language
+	6, // 3: common.StreamChunk.Value:type_name -> common.EncodedObject
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_common_types_proto_init() }
@@ -348,7 +732,7 @@ func file_common_types_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_common_types_proto_rawDesc), len(file_common_types_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   3,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -7,6 +7,7 @@
 package registry
 
 import (
+	common "github.com/9triver/iarnet/internal/proto/common"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -80,6 +81,10 @@ type RegisterNodeRequest struct {
 	NodeId          string                 `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
 	NodeName        string                 `protobuf:"bytes,3,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
 	NodeDescription string                 `protobuf:"bytes,4,opt,name=node_description,json=nodeDescription,proto3" json:"node_description,omitempty"`
+	Protocol        *common.ProtocolInfo   `protobuf:"bytes,5,opt,name=protocol,proto3" json:"protocol,omitempty"`                                                                       // 节点的协议版本与能力
+	Labels          map[string]string      `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 节点标签（如 zone=edge-1, arch=arm64）
+	PublicKey       []byte                 `protobuf:"bytes,7,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`                                                    // 节点的 ed25519 公钥，registry 在首次注册时与 node_id 绑定
+	Signature       []byte                 `protobuf:"bytes,8,opt,name=signature,proto3" json:"signature,omitempty"`                                                                     // 节点私钥对请求（signature 为空）的签名
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -142,10 +147,39 @@ func (x *RegisterNodeRequest) GetNodeDescription() string {
 	return ""
 }
 
+func (x *RegisterNodeRequest) GetProtocol() *common.ProtocolInfo {
+	if x != nil {
+		return x.Protocol
+	}
+	return nil
+}
+
+func (x *RegisterNodeRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *RegisterNodeRequest) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *RegisterNodeRequest) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
 type RegisterNodeResponse struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	DomainName        string                 `protobuf:"bytes,1,opt,name=domain_name,json=domainName,proto3" json:"domain_name,omitempty"`
 	DomainDescription string                 `protobuf:"bytes,2,opt,name=domain_description,json=domainDescription,proto3" json:"domain_description,omitempty"`
+	Protocol          *common.ProtocolInfo   `protobuf:"bytes,3,opt,name=protocol,proto3" json:"protocol,omitempty"` // registry 的协议版本与能力，旧版 registry 不携带
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -194,6 +228,13 @@ func (x *RegisterNodeResponse) GetDomainDescription() string {
 	return ""
 }
 
+func (x *RegisterNodeResponse) GetProtocol() *common.ProtocolInfo {
+	if x != nil {
+		return x.Protocol
+	}
+	return nil
+}
+
 // ResourceInfo 资源信息
 type ResourceInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -396,6 +437,9 @@ type HealthCheckRequest struct {
 	Address          string                 `protobuf:"bytes,6,opt,name=address,proto3" json:"address,omitempty"`                                           // 节点地址 (host:port)
 	Timestamp        int64                  `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                                      // 时间戳 (Unix nanoseconds)
 	IsHead           bool                   `protobuf:"varint,8,opt,name=is_head,json=isHead,proto3" json:"is_head,omitempty"`                              // 是否为 head 节点
+	HeadTerm         uint64                 `protobuf:"varint,9,opt,name=head_term,json=headTerm,proto3" json:"head_term,omitempty"`                        // head 任期，备用节点接管后递增，registry 以任期高者为域的 head
+	PublicKey        []byte                 `protobuf:"bytes,10,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`                     // 节点的 ed25519 公钥
+	Signature        []byte                 `protobuf:"bytes,11,opt,name=signature,proto3" json:"signature,omitempty"`                                      // 节点私钥对请求（signature 为空）的签名
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -486,6 +530,27 @@ func (x *HealthCheckRequest) GetIsHead() bool {
 	return false
 }
 
+func (x *HealthCheckRequest) GetHeadTerm() uint64 {
+	if x != nil {
+		return x.HeadTerm
+	}
+	return 0
+}
+
+func (x *HealthCheckRequest) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *HealthCheckRequest) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
 // HealthCheckResponse 健康检查响应
 type HealthCheckResponse struct {
 	state                      protoimpl.MessageState `protogen:"open.v1"`
@@ -567,16 +632,25 @@ var File_registry_registry_proto protoreflect.FileDescriptor
 
 const file_registry_registry_proto_rawDesc = "" +
 	"\n" +
-	"\x17registry/registry.proto\x12\bregistry\"\x93\x01\n" +
+	"\x17registry/registry.proto\x12\bregistry\x1a\x15common/protocol.proto\"\x80\x03\n" +
 	"\x13RegisterNodeRequest\x12\x1b\n" +
 	"\tdomain_id\x18\x01 \x01(\tR\bdomainId\x12\x17\n" +
 	"\anode_id\x18\x02 \x01(\tR\x06nodeId\x12\x1b\n" +
 	"\tnode_name\x18\x03 \x01(\tR\bnodeName\x12)\n" +
-	"\x10node_description\x18\x04 \x01(\tR\x0fnodeDescription\"f\n" +
+	"\x10node_description\x18\x04 \x01(\tR\x0fnodeDescription\x120\n" +
+	"\bprotocol\x18\x05 \x01(\v2\x14.common.ProtocolInfoR\bprotocol\x12A\n" +
+	"\x06labels\x18\x06 \x03(\v2).registry.RegisterNodeRequest.LabelsEntryR\x06labels\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\a \x01(\fR\tpublicKey\x12\x1c\n" +
+	"\tsignature\x18\b \x01(\fR\tsignature\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x98\x01\n" +
 	"\x14RegisterNodeResponse\x12\x1f\n" +
 	"\vdomain_name\x18\x01 \x01(\tR\n" +
 	"domainName\x12-\n" +
-	"\x12domain_description\x18\x02 \x01(\tR\x11domainDescription\"J\n" +
+	"\x12domain_description\x18\x02 \x01(\tR\x11domainDescription\x120\n" +
+	"\bprotocol\x18\x03 \x01(\v2\x14.common.ProtocolInfoR\bprotocol\"J\n" +
 	"\fResourceInfo\x12\x10\n" +
 	"\x03cpu\x18\x01 \x01(\x03R\x03cpu\x12\x16\n" +
 	"\x06memory\x18\x02 \x01(\x03R\x06memory\x12\x10\n" +
@@ -589,7 +663,7 @@ const file_registry_registry_proto_rawDesc = "" +
 	"\x03cpu\x18\x01 \x01(\bR\x03cpu\x12\x10\n" +
 	"\x03gpu\x18\x02 \x01(\bR\x03gpu\x12\x16\n" +
 	"\x06memory\x18\x03 \x01(\bR\x06memory\x12\x16\n" +
-	"\x06camera\x18\x04 \x01(\bR\x06camera\"\xcf\x02\n" +
+	"\x06camera\x18\x04 \x01(\bR\x06camera\"\xa9\x03\n" +
 	"\x12HealthCheckRequest\x12\x17\n" +
 	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\x1b\n" +
 	"\tdomain_id\x18\x02 \x01(\tR\bdomainId\x12,\n" +
@@ -598,7 +672,12 @@ const file_registry_registry_proto_rawDesc = "" +
 	"\rresource_tags\x18\x05 \x01(\v2\x16.registry.ResourceTagsR\fresourceTags\x12\x18\n" +
 	"\aaddress\x18\x06 \x01(\tR\aaddress\x12\x1c\n" +
 	"\ttimestamp\x18\a \x01(\x03R\ttimestamp\x12\x17\n" +
-	"\ais_head\x18\b \x01(\bR\x06isHead\"\xec\x01\n" +
+	"\ais_head\x18\b \x01(\bR\x06isHead\x12\x1b\n" +
+	"\thead_term\x18\t \x01(\x04R\bheadTerm\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\n" +
+	" \x01(\fR\tpublicKey\x12\x1c\n" +
+	"\tsignature\x18\v \x01(\fR\tsignature\"\xec\x01\n" +
 	"\x13HealthCheckResponse\x12)\n" +
 	"\x10server_timestamp\x18\x01 \x01(\x03R\x0fserverTimestamp\x12@\n" +
 	"\x1crecommended_interval_seconds\x18\x02 \x01(\x05R\x1arecommendedIntervalSeconds\x12-\n" +
@@ -629,7 +708,7 @@ func file_registry_registry_proto_rawDescGZIP() []byte {
 }
 
 var file_registry_registry_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_registry_registry_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_registry_registry_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_registry_registry_proto_goTypes = []any{
 	(NodeStatus)(0),              // 0: registry.NodeStatus
 	(*RegisterNodeRequest)(nil),  // 1: registry.RegisterNodeRequest
@@ -639,23 +718,28 @@ var file_registry_registry_proto_goTypes = []any{
 	(*ResourceTags)(nil),         // 5: registry.ResourceTags
 	(*HealthCheckRequest)(nil),   // 6: registry.HealthCheckRequest
 	(*HealthCheckResponse)(nil),  // 7: registry.HealthCheckResponse
+	nil,                          // 8: registry.RegisterNodeRequest.LabelsEntry
+	(*common.ProtocolInfo)(nil),  // 9: common.ProtocolInfo
 }
 var file_registry_registry_proto_depIdxs = []int32{
-	3, // 0: registry.ResourceCapacity.total:type_name -> registry.ResourceInfo
-	3, // 1: registry.ResourceCapacity.used:type_name -> registry.ResourceInfo
-	3, // 2: registry.ResourceCapacity.available:type_name -> registry.ResourceInfo
-	0, // 3: registry.HealthCheckRequest.status:type_name -> registry.NodeStatus
-	4, // 4: registry.HealthCheckRequest.resource_capacity:type_name -> registry.ResourceCapacity
-	5, // 5: registry.HealthCheckRequest.resource_tags:type_name -> registry.ResourceTags
-	1, // 6: registry.Service.RegisterNode:input_type -> registry.RegisterNodeRequest
-	6, // 7: registry.Service.HealthCheck:input_type -> registry.HealthCheckRequest
-	2, // 8: registry.Service.RegisterNode:output_type -> registry.RegisterNodeResponse
-	7, // 9: registry.Service.HealthCheck:output_type -> registry.HealthCheckResponse
-	8, // [8:10] is the sub-list for method output_type
-	6, // [6:8] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	9,  // 0: registry.RegisterNodeRequest.protocol:type_name -> common.ProtocolInfo
+	8,  // 1: registry.RegisterNodeRequest.labels:type_name -> registry.RegisterNodeRequest.LabelsEntry
+	9,  // 2: registry.RegisterNodeResponse.protocol:type_name -> common.ProtocolInfo
+	3,  // 3: registry.ResourceCapacity.total:type_name -> registry.ResourceInfo
+	3,  // 4: registry.ResourceCapacity.used:type_name -> registry.ResourceInfo
+	3,  // 5: registry.ResourceCapacity.available:type_name -> registry.ResourceInfo
+	0,  // 6: registry.HealthCheckRequest.status:type_name -> registry.NodeStatus
+	4,  // 7: registry.HealthCheckRequest.resource_capacity:type_name -> registry.ResourceCapacity
+	5,  // 8: registry.HealthCheckRequest.resource_tags:type_name -> registry.ResourceTags
+	1,  // 9: registry.Service.RegisterNode:input_type -> registry.RegisterNodeRequest
+	6,  // 10: registry.Service.HealthCheck:input_type -> registry.HealthCheckRequest
+	2,  // 11: registry.Service.RegisterNode:output_type -> registry.RegisterNodeResponse
+	7,  // 12: registry.Service.HealthCheck:output_type -> registry.HealthCheckResponse
+	11, // [11:13] is the sub-list for method output_type
+	9,  // [9:11] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_registry_registry_proto_init() }
@@ -669,7 +753,7 @@ func file_registry_registry_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_registry_registry_proto_rawDesc), len(file_registry_registry_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   7,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
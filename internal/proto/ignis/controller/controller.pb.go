@@ -2,7 +2,7 @@
 // versions:
 // 	protoc-gen-go v1.36.10
 // 	protoc        v6.31.1
-// source: controller/controller.proto
+// source: ignis/controller/controller.proto
 
 package controller
 
@@ -88,11 +88,11 @@ func (x CommandType) String() string {
 }
 
 func (CommandType) Descriptor() protoreflect.EnumDescriptor {
-	return file_controller_controller_proto_enumTypes[0].Descriptor()
+	return file_ignis_controller_controller_proto_enumTypes[0].Descriptor()
 }
 
 func (CommandType) Type() protoreflect.EnumType {
-	return &file_controller_controller_proto_enumTypes[0]
+	return &file_ignis_controller_controller_proto_enumTypes[0]
 }
 
 func (x CommandType) Number() protoreflect.EnumNumber {
@@ -101,7 +101,7 @@ func (x CommandType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CommandType.Descriptor instead.
 func (CommandType) EnumDescriptor() ([]byte, []int) {
-	return file_controller_controller_proto_rawDescGZIP(), []int{0}
+	return file_ignis_controller_controller_proto_rawDescGZIP(), []int{0}
 }
 
 type DAGNodeType int32
@@ -137,11 +137,11 @@ func (x DAGNodeType) String() string {
 }
 
 func (DAGNodeType) Descriptor() protoreflect.EnumDescriptor {
-	return file_controller_controller_proto_enumTypes[1].Descriptor()
+	return file_ignis_controller_controller_proto_enumTypes[1].Descriptor()
 }
 
 func (DAGNodeType) Type() protoreflect.EnumType {
-	return &file_controller_controller_proto_enumTypes[1]
+	return &file_ignis_controller_controller_proto_enumTypes[1]
 }
 
 func (x DAGNodeType) Number() protoreflect.EnumNumber {
@@ -150,7 +150,7 @@ func (x DAGNodeType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use DAGNodeType.Descriptor instead.
 func (DAGNodeType) EnumDescriptor() ([]byte, []int) {
-	return file_controller_controller_proto_rawDescGZIP(), []int{1}
+	return file_ignis_controller_controller_proto_rawDescGZIP(), []int{1}
 }
 
 type Data_ObjectType int32
@@ -189,11 +189,11 @@ func (x Data_ObjectType) String() string {
 }
 
 func (Data_ObjectType) Descriptor() protoreflect.EnumDescriptor {
-	return file_controller_controller_proto_enumTypes[2].Descriptor()
+	return file_ignis_controller_controller_proto_enumTypes[2].Descriptor()
 }
 
 func (Data_ObjectType) Type() protoreflect.EnumType {
-	return &file_controller_controller_proto_enumTypes[2]
+	return &file_ignis_controller_controller_proto_enumTypes[2]
 }
 
 func (x Data_ObjectType) Number() protoreflect.EnumNumber {
@@ -202,7 +202,7 @@ func (x Data_ObjectType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Data_ObjectType.Descriptor instead.
 func (Data_ObjectType) EnumDescriptor() ([]byte, []int) {
-	return file_controller_controller_proto_rawDescGZIP(), []int{0, 0}
+	return file_ignis_controller_controller_proto_rawDescGZIP(), []int{0, 0}
 }
 
 type Data struct {
@@ -219,7 +219,7 @@ type Data struct {
 
 func (x *Data) Reset() {
 	*x = Data{}
-	mi := &file_controller_controller_proto_msgTypes[0]
+	mi := &file_ignis_controller_controller_proto_msgTypes[0]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -231,7 +231,7 @@ func (x *Data) String() string {
 func (*Data) ProtoMessage() {}
 
 func (x *Data) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_controller_proto_msgTypes[0]
+	mi := &file_ignis_controller_controller_proto_msgTypes[0]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -244,7 +244,7 @@ func (x *Data) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Data.ProtoReflect.Descriptor instead.
 func (*Data) Descriptor() ([]byte, []int) {
-	return file_controller_controller_proto_rawDescGZIP(), []int{0}
+	return file_ignis_controller_controller_proto_rawDescGZIP(), []int{0}
 }
 
 func (x *Data) GetType() Data_ObjectType {
@@ -305,7 +305,7 @@ type AppendActor struct {
 
 func (x *AppendActor) Reset() {
 	*x = AppendActor{}
-	mi := &file_controller_controller_proto_msgTypes[1]
+	mi := &file_ignis_controller_controller_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -317,7 +317,7 @@ func (x *AppendActor) String() string {
 func (*AppendActor) ProtoMessage() {}
 
 func (x *AppendActor) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_controller_proto_msgTypes[1]
+	mi := &file_ignis_controller_controller_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -330,7 +330,7 @@ func (x *AppendActor) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AppendActor.ProtoReflect.Descriptor instead.
 func (*AppendActor) Descriptor() ([]byte, []int) {
-	return file_controller_controller_proto_rawDescGZIP(), []int{1}
+	return file_ignis_controller_controller_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *AppendActor) GetName() string {
@@ -358,7 +358,7 @@ type Resources struct {
 
 func (x *Resources) Reset() {
 	*x = Resources{}
-	mi := &file_controller_controller_proto_msgTypes[2]
+	mi := &file_ignis_controller_controller_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -370,7 +370,7 @@ func (x *Resources) String() string {
 func (*Resources) ProtoMessage() {}
 
 func (x *Resources) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_controller_proto_msgTypes[2]
+	mi := &file_ignis_controller_controller_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -383,7 +383,7 @@ func (x *Resources) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Resources.ProtoReflect.Descriptor instead.
 func (*Resources) Descriptor() ([]byte, []int) {
-	return file_controller_controller_proto_rawDescGZIP(), []int{2}
+	return file_ignis_controller_controller_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Resources) GetCPU() int64 {
@@ -408,23 +408,27 @@ func (x *Resources) GetGPU() int64 {
 }
 
 type AppendPyFunc struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`                               // function name
-	Params        []string               `protobuf:"bytes,2,rep,name=Params,proto3" json:"Params,omitempty"`                           //function params
-	Venv          string                 `protobuf:"bytes,3,opt,name=Venv,proto3" json:"Venv,omitempty"`                               // function virtual environment
-	Requirements  []string               `protobuf:"bytes,4,rep,name=Requirements,proto3" json:"Requirements,omitempty"`               // function dependencies
-	PickledObject []byte                 `protobuf:"bytes,5,opt,name=PickledObject,proto3" json:"PickledObject,omitempty"`             // encoded function impl
-	Language      common.Language        `protobuf:"varint,6,opt,name=Language,proto3,enum=common.Language" json:"Language,omitempty"` // return type of function
-	Resources     *Resources             `protobuf:"bytes,7,opt,name=Resources,proto3" json:"Resources,omitempty"`                     // resources required by function
-	Replicas      int32                  `protobuf:"varint,8,opt,name=Replicas,proto3" json:"Replicas,omitempty"`                      // number of replicas
-	Tags          []string               `protobuf:"bytes,9,rep,name=Tags,proto3" json:"Tags,omitempty"`                               // resource tags requirement
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Name          string                  `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`                               // function name
+	Params        []string                `protobuf:"bytes,2,rep,name=Params,proto3" json:"Params,omitempty"`                           //function params
+	Venv          string                  `protobuf:"bytes,3,opt,name=Venv,proto3" json:"Venv,omitempty"`                               // function virtual environment
+	Requirements  []string                `protobuf:"bytes,4,rep,name=Requirements,proto3" json:"Requirements,omitempty"`               // function dependencies
+	PickledObject []byte                  `protobuf:"bytes,5,opt,name=PickledObject,proto3" json:"PickledObject,omitempty"`             // encoded function impl
+	Language      common.Language         `protobuf:"varint,6,opt,name=Language,proto3,enum=common.Language" json:"Language,omitempty"` // return type of function
+	Resources     *Resources              `protobuf:"bytes,7,opt,name=Resources,proto3" json:"Resources,omitempty"`                     // resources required by function
+	Replicas      int32                   `protobuf:"varint,8,opt,name=Replicas,proto3" json:"Replicas,omitempty"`                      // number of replicas
+	Tags          []string                `protobuf:"bytes,9,rep,name=Tags,proto3" json:"Tags,omitempty"`                               // resource tags requirement
+	Data          []*common.DataSource    `protobuf:"bytes,10,rep,name=Data,proto3" json:"Data,omitempty"`                              // datasets staged into the component workspace before the function runs
+	Volumes       []*common.VolumeMount   `protobuf:"bytes,11,rep,name=Volumes,proto3" json:"Volumes,omitempty"`                        // persistent storage mounted into every replica
+	Security      *common.SecurityContext `protobuf:"bytes,12,opt,name=Security,proto3" json:"Security,omitempty"`                      // container hardening, the provider default applies when unset
+	Stream        string                  `protobuf:"bytes,13,opt,name=Stream,proto3" json:"Stream,omitempty"`                          // data stream the function ingests, replicas are placed on the provider owning it
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AppendPyFunc) Reset() {
 	*x = AppendPyFunc{}
-	mi := &file_controller_controller_proto_msgTypes[3]
+	mi := &file_ignis_controller_controller_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -436,7 +440,7 @@ func (x *AppendPyFunc) String() string {
 func (*AppendPyFunc) ProtoMessage() {}
 
 func (x *AppendPyFunc) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_controller_proto_msgTypes[3]
+	mi := &file_ignis_controller_controller_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -449,7 +453,7 @@ func (x *AppendPyFunc) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AppendPyFunc.ProtoReflect.Descriptor instead.
 func (*AppendPyFunc) Descriptor() ([]byte, []int) {
-	return file_controller_controller_proto_rawDescGZIP(), []int{3}
+	return file_ignis_controller_controller_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *AppendPyFunc) GetName() string {
@@ -515,6 +519,34 @@ func (x *AppendPyFunc) GetTags() []string {
 	return nil
 }
 
+func (x *AppendPyFunc) GetData() []*common.DataSource {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *AppendPyFunc) GetVolumes() []*common.VolumeMount {
+	if x != nil {
+		return x.Volumes
+	}
+	return nil
+}
+
+func (x *AppendPyFunc) GetSecurity() *common.SecurityContext {
+	if x != nil {
+		return x.Security
+	}
+	return nil
+}
+
+func (x *AppendPyFunc) GetStream() string {
+	if x != nil {
+		return x.Stream
+	}
+	return ""
+}
+
 type AppendPyClass struct {
 	state         protoimpl.MessageState       `protogen:"open.v1"`
 	Name          string                       `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"` // class name
@@ -531,7 +563,7 @@ type AppendPyClass struct {
 
 func (x *AppendPyClass) Reset() {
 	*x = AppendPyClass{}
-	mi := &file_controller_controller_proto_msgTypes[4]
+	mi := &file_ignis_controller_controller_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -543,7 +575,7 @@ func (x *AppendPyClass) String() string {
 func (*AppendPyClass) ProtoMessage() {}
 
 func (x *AppendPyClass) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_controller_proto_msgTypes[4]
+	mi := &file_ignis_controller_controller_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -556,7 +588,7 @@ func (x *AppendPyClass) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AppendPyClass.ProtoReflect.Descriptor instead.
 func (*AppendPyClass) Descriptor() ([]byte, []int) {
-	return file_controller_controller_proto_rawDescGZIP(), []int{4}
+	return file_ignis_controller_controller_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *AppendPyClass) GetName() string {
@@ -626,7 +658,7 @@ type AppendData struct {
 
 func (x *AppendData) Reset() {
 	*x = AppendData{}
-	mi := &file_controller_controller_proto_msgTypes[5]
+	mi := &file_ignis_controller_controller_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -638,7 +670,7 @@ func (x *AppendData) String() string {
 func (*AppendData) ProtoMessage() {}
 
 func (x *AppendData) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_controller_proto_msgTypes[5]
+	mi := &file_ignis_controller_controller_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -651,7 +683,7 @@ func (x *AppendData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AppendData.ProtoReflect.Descriptor instead.
 func (*AppendData) Descriptor() ([]byte, []int) {
-	return file_controller_controller_proto_rawDescGZIP(), []int{5}
+	return file_ignis_controller_controller_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *AppendData) GetSessionID() string {
@@ -688,7 +720,7 @@ type AppendArg struct {
 
 func (x *AppendArg) Reset() {
 	*x = AppendArg{}
-	mi := &file_controller_controller_proto_msgTypes[6]
+	mi := &file_ignis_controller_controller_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -700,7 +732,7 @@ func (x *AppendArg) String() string {
 func (*AppendArg) ProtoMessage() {}
 
 func (x *AppendArg) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_controller_proto_msgTypes[6]
+	mi := &file_ignis_controller_controller_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -713,7 +745,7 @@ func (x *AppendArg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AppendArg.ProtoReflect.Descriptor instead.
 func (*AppendArg) Descriptor() ([]byte, []int) {
-	return file_controller_controller_proto_rawDescGZIP(), []int{6}
+	return file_ignis_controller_controller_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *AppendArg) GetSessionID() string {
@@ -764,7 +796,7 @@ type AppendClassMethodArg struct {
 
 func (x *AppendClassMethodArg) Reset() {
 	*x = AppendClassMethodArg{}
-	mi := &file_controller_controller_proto_msgTypes[7]
+	mi := &file_ignis_controller_controller_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -776,7 +808,7 @@ func (x *AppendClassMethodArg) String() string {
 func (*AppendClassMethodArg) ProtoMessage() {}
 
 func (x *AppendClassMethodArg) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_controller_proto_msgTypes[7]
+	mi := &file_ignis_controller_controller_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -789,7 +821,7 @@ func (x *AppendClassMethodArg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AppendClassMethodArg.ProtoReflect.Descriptor instead.
 func (*AppendClassMethodArg) Descriptor() ([]byte, []int) {
-	return file_controller_controller_proto_rawDescGZIP(), []int{7}
+	return file_ignis_controller_controller_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *AppendClassMethodArg) GetSessionID() string {
@@ -838,7 +870,7 @@ type Invoke struct {
 
 func (x *Invoke) Reset() {
 	*x = Invoke{}
-	mi := &file_controller_controller_proto_msgTypes[8]
+	mi := &file_ignis_controller_controller_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -850,7 +882,7 @@ func (x *Invoke) String() string {
 func (*Invoke) ProtoMessage() {}
 
 func (x *Invoke) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_controller_proto_msgTypes[8]
+	mi := &file_ignis_controller_controller_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -863,7 +895,7 @@ func (x *Invoke) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Invoke.ProtoReflect.Descriptor instead.
 func (*Invoke) Descriptor() ([]byte, []int) {
-	return file_controller_controller_proto_rawDescGZIP(), []int{8}
+	return file_ignis_controller_controller_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *Invoke) GetSessionID() string {
@@ -903,7 +935,7 @@ type ReturnResult struct {
 
 func (x *ReturnResult) Reset() {
 	*x = ReturnResult{}
-	mi := &file_controller_controller_proto_msgTypes[9]
+	mi := &file_ignis_controller_controller_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -915,7 +947,7 @@ func (x *ReturnResult) String() string {
 func (*ReturnResult) ProtoMessage() {}
 
 func (x *ReturnResult) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_controller_proto_msgTypes[9]
+	mi := &file_ignis_controller_controller_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -928,7 +960,7 @@ func (x *ReturnResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReturnResult.ProtoReflect.Descriptor instead.
 func (*ReturnResult) Descriptor() ([]byte, []int) {
-	return file_controller_controller_proto_rawDescGZIP(), []int{9}
+	return file_ignis_controller_controller_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ReturnResult) GetSessionID() string {
@@ -1009,7 +1041,7 @@ type ControlNode struct {
 
 func (x *ControlNode) Reset() {
 	*x = ControlNode{}
-	mi := &file_controller_controller_proto_msgTypes[10]
+	mi := &file_ignis_controller_controller_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1021,7 +1053,7 @@ func (x *ControlNode) String() string {
 func (*ControlNode) ProtoMessage() {}
 
 func (x *ControlNode) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_controller_proto_msgTypes[10]
+	mi := &file_ignis_controller_controller_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1034,7 +1066,7 @@ func (x *ControlNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ControlNode.ProtoReflect.Descriptor instead.
 func (*ControlNode) Descriptor() ([]byte, []int) {
-	return file_controller_controller_proto_rawDescGZIP(), []int{10}
+	return file_ignis_controller_controller_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ControlNode) GetId() string {
@@ -1100,7 +1132,7 @@ type DataNode struct {
 
 func (x *DataNode) Reset() {
 	*x = DataNode{}
-	mi := &file_controller_controller_proto_msgTypes[11]
+	mi := &file_ignis_controller_controller_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1112,7 +1144,7 @@ func (x *DataNode) String() string {
 func (*DataNode) ProtoMessage() {}
 
 func (x *DataNode) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_controller_proto_msgTypes[11]
+	mi := &file_ignis_controller_controller_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1125,7 +1157,7 @@ func (x *DataNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DataNode.ProtoReflect.Descriptor instead.
 func (*DataNode) Descriptor() ([]byte, []int) {
-	return file_controller_controller_proto_rawDescGZIP(), []int{11}
+	return file_ignis_controller_controller_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *DataNode) GetId() string {
@@ -1185,7 +1217,7 @@ type AppendDAGNode struct {
 
 func (x *AppendDAGNode) Reset() {
 	*x = AppendDAGNode{}
-	mi := &file_controller_controller_proto_msgTypes[12]
+	mi := &file_ignis_controller_controller_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1197,7 +1229,7 @@ func (x *AppendDAGNode) String() string {
 func (*AppendDAGNode) ProtoMessage() {}
 
 func (x *AppendDAGNode) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_controller_proto_msgTypes[12]
+	mi := &file_ignis_controller_controller_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1210,7 +1242,7 @@ func (x *AppendDAGNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AppendDAGNode.ProtoReflect.Descriptor instead.
 func (*AppendDAGNode) Descriptor() ([]byte, []int) {
-	return file_controller_controller_proto_rawDescGZIP(), []int{12}
+	return file_ignis_controller_controller_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *AppendDAGNode) GetSessionID() string {
@@ -1278,7 +1310,7 @@ type RequestObject struct {
 
 func (x *RequestObject) Reset() {
 	*x = RequestObject{}
-	mi := &file_controller_controller_proto_msgTypes[13]
+	mi := &file_ignis_controller_controller_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1290,7 +1322,7 @@ func (x *RequestObject) String() string {
 func (*RequestObject) ProtoMessage() {}
 
 func (x *RequestObject) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_controller_proto_msgTypes[13]
+	mi := &file_ignis_controller_controller_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1303,7 +1335,7 @@ func (x *RequestObject) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestObject.ProtoReflect.Descriptor instead.
 func (*RequestObject) Descriptor() ([]byte, []int) {
-	return file_controller_controller_proto_rawDescGZIP(), []int{13}
+	return file_ignis_controller_controller_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *RequestObject) GetID() string {
@@ -1331,7 +1363,7 @@ type ResponseObject struct {
 
 func (x *ResponseObject) Reset() {
 	*x = ResponseObject{}
-	mi := &file_controller_controller_proto_msgTypes[14]
+	mi := &file_ignis_controller_controller_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1343,7 +1375,7 @@ func (x *ResponseObject) String() string {
 func (*ResponseObject) ProtoMessage() {}
 
 func (x *ResponseObject) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_controller_proto_msgTypes[14]
+	mi := &file_ignis_controller_controller_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1356,7 +1388,7 @@ func (x *ResponseObject) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseObject.ProtoReflect.Descriptor instead.
 func (*ResponseObject) Descriptor() ([]byte, []int) {
-	return file_controller_controller_proto_rawDescGZIP(), []int{14}
+	return file_ignis_controller_controller_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ResponseObject) GetID() string {
@@ -1406,7 +1438,7 @@ type Message struct {
 
 func (x *Message) Reset() {
 	*x = Message{}
-	mi := &file_controller_controller_proto_msgTypes[15]
+	mi := &file_ignis_controller_controller_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1418,7 +1450,7 @@ func (x *Message) String() string {
 func (*Message) ProtoMessage() {}
 
 func (x *Message) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_controller_proto_msgTypes[15]
+	mi := &file_ignis_controller_controller_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1431,7 +1463,7 @@ func (x *Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Message.ProtoReflect.Descriptor instead.
 func (*Message) Descriptor() ([]byte, []int) {
-	return file_controller_controller_proto_rawDescGZIP(), []int{15}
+	return file_ignis_controller_controller_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *Message) GetType() CommandType {
@@ -1664,7 +1696,7 @@ type AppendPyClass_ClassMethod struct {
 
 func (x *AppendPyClass_ClassMethod) Reset() {
 	*x = AppendPyClass_ClassMethod{}
-	mi := &file_controller_controller_proto_msgTypes[16]
+	mi := &file_ignis_controller_controller_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1676,7 +1708,7 @@ func (x *AppendPyClass_ClassMethod) String() string {
 func (*AppendPyClass_ClassMethod) ProtoMessage() {}
 
 func (x *AppendPyClass_ClassMethod) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_controller_proto_msgTypes[16]
+	mi := &file_ignis_controller_controller_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1689,7 +1721,7 @@ func (x *AppendPyClass_ClassMethod) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AppendPyClass_ClassMethod.ProtoReflect.Descriptor instead.
 func (*AppendPyClass_ClassMethod) Descriptor() ([]byte, []int) {
-	return file_controller_controller_proto_rawDescGZIP(), []int{4, 0}
+	return file_ignis_controller_controller_proto_rawDescGZIP(), []int{4, 0}
 }
 
 func (x *AppendPyClass_ClassMethod) GetName() string {
@@ -1706,11 +1738,11 @@ func (x *AppendPyClass_ClassMethod) GetParams() []string {
 	return nil
 }
 
-var File_controller_controller_proto protoreflect.FileDescriptor
+var File_ignis_controller_controller_proto protoreflect.FileDescriptor
 
-const file_controller_controller_proto_rawDesc = "" +
+const file_ignis_controller_controller_proto_rawDesc = "" +
 	"\n" +
-	"\x1bcontroller/controller.proto\x12\n" +
+	"!ignis/controller/controller.proto\x12\n" +
 	"controller\x1a\x12common/types.proto\x1a\x15common/messages.proto\"\xec\x01\n" +
 	"\x04Data\x12/\n" +
 	"\x04Type\x18\x01 \x01(\x0e2\x1b.controller.Data.ObjectTypeR\x04Type\x12%\n" +
@@ -1730,7 +1762,7 @@ const file_controller_controller_proto_rawDesc = "" +
 	"\tResources\x12\x10\n" +
 	"\x03CPU\x18\x01 \x01(\x03R\x03CPU\x12\x16\n" +
 	"\x06Memory\x18\x02 \x01(\x03R\x06Memory\x12\x10\n" +
-	"\x03GPU\x18\x03 \x01(\x03R\x03GPU\"\xab\x02\n" +
+	"\x03GPU\x18\x03 \x01(\x03R\x03GPU\"\xcf\x03\n" +
 	"\fAppendPyFunc\x12\x12\n" +
 	"\x04Name\x18\x01 \x01(\tR\x04Name\x12\x16\n" +
 	"\x06Params\x18\x02 \x03(\tR\x06Params\x12\x12\n" +
@@ -1740,7 +1772,12 @@ const file_controller_controller_proto_rawDesc = "" +
 	"\bLanguage\x18\x06 \x01(\x0e2\x10.common.LanguageR\bLanguage\x123\n" +
 	"\tResources\x18\a \x01(\v2\x15.controller.ResourcesR\tResources\x12\x1a\n" +
 	"\bReplicas\x18\b \x01(\x05R\bReplicas\x12\x12\n" +
-	"\x04Tags\x18\t \x03(\tR\x04Tags\"\xfc\x02\n" +
+	"\x04Tags\x18\t \x03(\tR\x04Tags\x12&\n" +
+	"\x04Data\x18\n" +
+	" \x03(\v2\x12.common.DataSourceR\x04Data\x12-\n" +
+	"\aVolumes\x18\v \x03(\v2\x13.common.VolumeMountR\aVolumes\x123\n" +
+	"\bSecurity\x18\f \x01(\v2\x17.common.SecurityContextR\bSecurity\x12\x16\n" +
+	"\x06Stream\x18\r \x01(\tR\x06Stream\"\xfc\x02\n" +
 	"\rAppendPyClass\x12\x12\n" +
 	"\x04Name\x18\x01 \x01(\tR\x04Name\x12?\n" +
 	"\aMethods\x18\x02 \x03(\v2%.controller.AppendPyClass.ClassMethodR\aMethods\x12\x12\n" +
@@ -1872,20 +1909,20 @@ const file_controller_controller_proto_rawDesc = "" +
 	"\aSession\x12\x13.controller.Message\x1a\x13.controller.Message\"\x00(\x010\x01B;Z9github.com/9triver/iarnet/internal/proto/ignis/controllerb\x06proto3"
 
 var (
-	file_controller_controller_proto_rawDescOnce sync.Once
-	file_controller_controller_proto_rawDescData []byte
+	file_ignis_controller_controller_proto_rawDescOnce sync.Once
+	file_ignis_controller_controller_proto_rawDescData []byte
 )
 
-func file_controller_controller_proto_rawDescGZIP() []byte {
-	file_controller_controller_proto_rawDescOnce.Do(func() {
-		file_controller_controller_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_controller_controller_proto_rawDesc), len(file_controller_controller_proto_rawDesc)))
+func file_ignis_controller_controller_proto_rawDescGZIP() []byte {
+	file_ignis_controller_controller_proto_rawDescOnce.Do(func() {
+		file_ignis_controller_controller_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_ignis_controller_controller_proto_rawDesc), len(file_ignis_controller_controller_proto_rawDesc)))
 	})
-	return file_controller_controller_proto_rawDescData
+	return file_ignis_controller_controller_proto_rawDescData
 }
 
-var file_controller_controller_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_controller_controller_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
-var file_controller_controller_proto_goTypes = []any{
+var file_ignis_controller_controller_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_ignis_controller_controller_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_ignis_controller_controller_proto_goTypes = []any{
 	(CommandType)(0),                  // 0: controller.CommandType
 	(DAGNodeType)(0),                  // 1: controller.DAGNodeType
 	(Data_ObjectType)(0),              // 2: controller.Data.ObjectType
@@ -1910,69 +1947,75 @@ var file_controller_controller_proto_goTypes = []any{
 	(*common.ObjectRef)(nil),          // 21: common.ObjectRef
 	(*common.EncodedObject)(nil),      // 22: common.EncodedObject
 	(common.Language)(0),              // 23: common.Language
-	(*common.Ack)(nil),                // 24: common.Ack
-	(*common.Ready)(nil),              // 25: common.Ready
+	(*common.DataSource)(nil),         // 24: common.DataSource
+	(*common.VolumeMount)(nil),        // 25: common.VolumeMount
+	(*common.SecurityContext)(nil),    // 26: common.SecurityContext
+	(*common.Ack)(nil),                // 27: common.Ack
+	(*common.Ready)(nil),              // 28: common.Ready
 }
-var file_controller_controller_proto_depIdxs = []int32{
+var file_ignis_controller_controller_proto_depIdxs = []int32{
 	2,  // 0: controller.Data.Type:type_name -> controller.Data.ObjectType
 	21, // 1: controller.Data.Ref:type_name -> common.ObjectRef
 	22, // 2: controller.Data.Encoded:type_name -> common.EncodedObject
 	23, // 3: controller.AppendPyFunc.Language:type_name -> common.Language
 	5,  // 4: controller.AppendPyFunc.Resources:type_name -> controller.Resources
-	19, // 5: controller.AppendPyClass.Methods:type_name -> controller.AppendPyClass.ClassMethod
-	23, // 6: controller.AppendPyClass.Language:type_name -> common.Language
-	5,  // 7: controller.AppendPyClass.Resources:type_name -> controller.Resources
-	22, // 8: controller.AppendData.Object:type_name -> common.EncodedObject
-	3,  // 9: controller.AppendArg.Value:type_name -> controller.Data
-	3,  // 10: controller.AppendClassMethodArg.Value:type_name -> controller.Data
-	3,  // 11: controller.ReturnResult.Value:type_name -> controller.Data
-	20, // 12: controller.ControlNode.Params:type_name -> controller.ControlNode.ParamsEntry
-	1,  // 13: controller.AppendDAGNode.Type:type_name -> controller.DAGNodeType
-	13, // 14: controller.AppendDAGNode.ControlNode:type_name -> controller.ControlNode
-	14, // 15: controller.AppendDAGNode.DataNode:type_name -> controller.DataNode
-	22, // 16: controller.ResponseObject.Value:type_name -> common.EncodedObject
-	0,  // 17: controller.Message.Type:type_name -> controller.CommandType
-	24, // 18: controller.Message.Ack:type_name -> common.Ack
-	25, // 19: controller.Message.Ready:type_name -> common.Ready
-	8,  // 20: controller.Message.AppendData:type_name -> controller.AppendData
-	4,  // 21: controller.Message.AppendActor:type_name -> controller.AppendActor
-	6,  // 22: controller.Message.AppendPyFunc:type_name -> controller.AppendPyFunc
-	7,  // 23: controller.Message.AppendPyClass:type_name -> controller.AppendPyClass
-	9,  // 24: controller.Message.AppendArg:type_name -> controller.AppendArg
-	10, // 25: controller.Message.AppendClassMethodArg:type_name -> controller.AppendClassMethodArg
-	11, // 26: controller.Message.Invoke:type_name -> controller.Invoke
-	12, // 27: controller.Message.ReturnResult:type_name -> controller.ReturnResult
-	15, // 28: controller.Message.AppendDAGNode:type_name -> controller.AppendDAGNode
-	16, // 29: controller.Message.RequestObject:type_name -> controller.RequestObject
-	17, // 30: controller.Message.ResponseObject:type_name -> controller.ResponseObject
-	18, // 31: controller.Service.Session:input_type -> controller.Message
-	18, // 32: controller.Service.Session:output_type -> controller.Message
-	32, // [32:33] is the sub-list for method output_type
-	31, // [31:32] is the sub-list for method input_type
-	31, // [31:31] is the sub-list for extension type_name
-	31, // [31:31] is the sub-list for extension extendee
-	0,  // [0:31] is the sub-list for field type_name
-}
-
-func init() { file_controller_controller_proto_init() }
-func file_controller_controller_proto_init() {
-	if File_controller_controller_proto != nil {
+	24, // 5: controller.AppendPyFunc.Data:type_name -> common.DataSource
+	25, // 6: controller.AppendPyFunc.Volumes:type_name -> common.VolumeMount
+	26, // 7: controller.AppendPyFunc.Security:type_name -> common.SecurityContext
+	19, // 8: controller.AppendPyClass.Methods:type_name -> controller.AppendPyClass.ClassMethod
+	23, // 9: controller.AppendPyClass.Language:type_name -> common.Language
+	5,  // 10: controller.AppendPyClass.Resources:type_name -> controller.Resources
+	22, // 11: controller.AppendData.Object:type_name -> common.EncodedObject
+	3,  // 12: controller.AppendArg.Value:type_name -> controller.Data
+	3,  // 13: controller.AppendClassMethodArg.Value:type_name -> controller.Data
+	3,  // 14: controller.ReturnResult.Value:type_name -> controller.Data
+	20, // 15: controller.ControlNode.Params:type_name -> controller.ControlNode.ParamsEntry
+	1,  // 16: controller.AppendDAGNode.Type:type_name -> controller.DAGNodeType
+	13, // 17: controller.AppendDAGNode.ControlNode:type_name -> controller.ControlNode
+	14, // 18: controller.AppendDAGNode.DataNode:type_name -> controller.DataNode
+	22, // 19: controller.ResponseObject.Value:type_name -> common.EncodedObject
+	0,  // 20: controller.Message.Type:type_name -> controller.CommandType
+	27, // 21: controller.Message.Ack:type_name -> common.Ack
+	28, // 22: controller.Message.Ready:type_name -> common.Ready
+	8,  // 23: controller.Message.AppendData:type_name -> controller.AppendData
+	4,  // 24: controller.Message.AppendActor:type_name -> controller.AppendActor
+	6,  // 25: controller.Message.AppendPyFunc:type_name -> controller.AppendPyFunc
+	7,  // 26: controller.Message.AppendPyClass:type_name -> controller.AppendPyClass
+	9,  // 27: controller.Message.AppendArg:type_name -> controller.AppendArg
+	10, // 28: controller.Message.AppendClassMethodArg:type_name -> controller.AppendClassMethodArg
+	11, // 29: controller.Message.Invoke:type_name -> controller.Invoke
+	12, // 30: controller.Message.ReturnResult:type_name -> controller.ReturnResult
+	15, // 31: controller.Message.AppendDAGNode:type_name -> controller.AppendDAGNode
+	16, // 32: controller.Message.RequestObject:type_name -> controller.RequestObject
+	17, // 33: controller.Message.ResponseObject:type_name -> controller.ResponseObject
+	18, // 34: controller.Service.Session:input_type -> controller.Message
+	18, // 35: controller.Service.Session:output_type -> controller.Message
+	35, // [35:36] is the sub-list for method output_type
+	34, // [34:35] is the sub-list for method input_type
+	34, // [34:34] is the sub-list for extension type_name
+	34, // [34:34] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
+}
+
+func init() { file_ignis_controller_controller_proto_init() }
+func file_ignis_controller_controller_proto_init() {
+	if File_ignis_controller_controller_proto != nil {
 		return
 	}
-	file_controller_controller_proto_msgTypes[0].OneofWrappers = []any{
+	file_ignis_controller_controller_proto_msgTypes[0].OneofWrappers = []any{
 		(*Data_Ref)(nil),
 		(*Data_Encoded)(nil),
 	}
-	file_controller_controller_proto_msgTypes[9].OneofWrappers = []any{
+	file_ignis_controller_controller_proto_msgTypes[9].OneofWrappers = []any{
 		(*ReturnResult_Value)(nil),
 		(*ReturnResult_Error)(nil),
 	}
-	file_controller_controller_proto_msgTypes[11].OneofWrappers = []any{}
-	file_controller_controller_proto_msgTypes[12].OneofWrappers = []any{
+	file_ignis_controller_controller_proto_msgTypes[11].OneofWrappers = []any{}
+	file_ignis_controller_controller_proto_msgTypes[12].OneofWrappers = []any{
 		(*AppendDAGNode_ControlNode)(nil),
 		(*AppendDAGNode_DataNode)(nil),
 	}
-	file_controller_controller_proto_msgTypes[15].OneofWrappers = []any{
+	file_ignis_controller_controller_proto_msgTypes[15].OneofWrappers = []any{
 		(*Message_Ack)(nil),
 		(*Message_Ready)(nil),
 		(*Message_AppendData)(nil),
@@ -1991,18 +2034,18 @@ func file_controller_controller_proto_init() {
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: unsafe.Slice(unsafe.StringData(file_controller_controller_proto_rawDesc), len(file_controller_controller_proto_rawDesc)),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ignis_controller_controller_proto_rawDesc), len(file_ignis_controller_controller_proto_rawDesc)),
 			NumEnums:      3,
 			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
-		GoTypes:           file_controller_controller_proto_goTypes,
-		DependencyIndexes: file_controller_controller_proto_depIdxs,
-		EnumInfos:         file_controller_controller_proto_enumTypes,
-		MessageInfos:      file_controller_controller_proto_msgTypes,
+		GoTypes:           file_ignis_controller_controller_proto_goTypes,
+		DependencyIndexes: file_ignis_controller_controller_proto_depIdxs,
+		EnumInfos:         file_ignis_controller_controller_proto_enumTypes,
+		MessageInfos:      file_ignis_controller_controller_proto_msgTypes,
 	}.Build()
-	File_controller_controller_proto = out.File
-	file_controller_controller_proto_goTypes = nil
-	file_controller_controller_proto_depIdxs = nil
+	File_ignis_controller_controller_proto = out.File
+	file_ignis_controller_controller_proto_goTypes = nil
+	file_ignis_controller_controller_proto_depIdxs = nil
 }
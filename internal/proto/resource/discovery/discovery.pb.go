@@ -7,6 +7,7 @@
 package discovery
 
 import (
+	common "github.com/9triver/iarnet/internal/proto/common"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -265,6 +266,59 @@ func (x *ResourceTags) GetCamera() bool {
 	return false
 }
 
+// EnergyProfile 能耗画像
+type EnergyProfile struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	WattsPerCore   float64                `protobuf:"fixed64,1,opt,name=watts_per_core,json=wattsPerCore,proto3" json:"watts_per_core,omitempty"`    // 每核功耗（瓦），0 表示未知
+	BatteryPowered bool                   `protobuf:"varint,2,opt,name=battery_powered,json=batteryPowered,proto3" json:"battery_powered,omitempty"` // 是否由电池供电
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *EnergyProfile) Reset() {
+	*x = EnergyProfile{}
+	mi := &file_resource_discovery_discovery_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnergyProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnergyProfile) ProtoMessage() {}
+
+func (x *EnergyProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_discovery_discovery_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnergyProfile.ProtoReflect.Descriptor instead.
+func (*EnergyProfile) Descriptor() ([]byte, []int) {
+	return file_resource_discovery_discovery_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *EnergyProfile) GetWattsPerCore() float64 {
+	if x != nil {
+		return x.WattsPerCore
+	}
+	return 0
+}
+
+func (x *EnergyProfile) GetBatteryPowered() bool {
+	if x != nil {
+		return x.BatteryPowered
+	}
+	return false
+}
+
 // PeerNodeInfo 节点信息（用于 gossip 交换）
 type PeerNodeInfo struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
@@ -279,15 +333,21 @@ type PeerNodeInfo struct {
 	LastSeen         int64                  `protobuf:"varint,8,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`          // Unix nanoseconds
 	LastUpdated      int64                  `protobuf:"varint,9,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"` // Unix nanoseconds
 	// Gossip 元数据
-	Version       uint64 `protobuf:"varint,10,opt,name=version,proto3" json:"version,omitempty"`                            // 版本号（用于冲突解决）
-	GossipCount   int32  `protobuf:"varint,11,opt,name=gossip_count,json=gossipCount,proto3" json:"gossip_count,omitempty"` // 传播次数（用于 TTL）
+	Version       uint64               `protobuf:"varint,10,opt,name=version,proto3" json:"version,omitempty"`                                                                        // 版本号（用于冲突解决）
+	GossipCount   int32                `protobuf:"varint,11,opt,name=gossip_count,json=gossipCount,proto3" json:"gossip_count,omitempty"`                                             // 传播次数（用于 TTL）
+	EnergyProfile *EnergyProfile       `protobuf:"bytes,13,opt,name=energy_profile,json=energyProfile,proto3" json:"energy_profile,omitempty"`                                        // 能耗画像（可选）
+	Labels        map[string]string    `protobuf:"bytes,15,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 节点标签（如 zone=edge）
+	Protocol      *common.ProtocolInfo `protobuf:"bytes,16,opt,name=protocol,proto3" json:"protocol,omitempty"`                                                                       // 节点的协议版本与能力，旧版节点不携带
+	HeadRole      string               `protobuf:"bytes,17,opt,name=head_role,json=headRole,proto3" json:"head_role,omitempty"`                                                       // 域内 head 角色：head / standby，空表示普通节点
+	HeadTerm      uint64               `protobuf:"varint,18,opt,name=head_term,json=headTerm,proto3" json:"head_term,omitempty"`                                                      // head 任期，每次故障转移递增，任期高者为当前 head
+	HeadPriority  int32                `protobuf:"varint,19,opt,name=head_priority,json=headPriority,proto3" json:"head_priority,omitempty"`                                          // 备用 head 的接管优先级
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PeerNodeInfo) Reset() {
 	*x = PeerNodeInfo{}
-	mi := &file_resource_discovery_discovery_proto_msgTypes[3]
+	mi := &file_resource_discovery_discovery_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -299,7 +359,7 @@ func (x *PeerNodeInfo) String() string {
 func (*PeerNodeInfo) ProtoMessage() {}
 
 func (x *PeerNodeInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_resource_discovery_discovery_proto_msgTypes[3]
+	mi := &file_resource_discovery_discovery_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -312,7 +372,7 @@ func (x *PeerNodeInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PeerNodeInfo.ProtoReflect.Descriptor instead.
 func (*PeerNodeInfo) Descriptor() ([]byte, []int) {
-	return file_resource_discovery_discovery_proto_rawDescGZIP(), []int{3}
+	return file_resource_discovery_discovery_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *PeerNodeInfo) GetNodeId() string {
@@ -399,6 +459,48 @@ func (x *PeerNodeInfo) GetGossipCount() int32 {
 	return 0
 }
 
+func (x *PeerNodeInfo) GetEnergyProfile() *EnergyProfile {
+	if x != nil {
+		return x.EnergyProfile
+	}
+	return nil
+}
+
+func (x *PeerNodeInfo) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *PeerNodeInfo) GetProtocol() *common.ProtocolInfo {
+	if x != nil {
+		return x.Protocol
+	}
+	return nil
+}
+
+func (x *PeerNodeInfo) GetHeadRole() string {
+	if x != nil {
+		return x.HeadRole
+	}
+	return ""
+}
+
+func (x *PeerNodeInfo) GetHeadTerm() uint64 {
+	if x != nil {
+		return x.HeadTerm
+	}
+	return 0
+}
+
+func (x *PeerNodeInfo) GetHeadPriority() int32 {
+	if x != nil {
+		return x.HeadPriority
+	}
+	return 0
+}
+
 // NodeInfoGossipMessage 节点信息 gossip 消息
 type NodeInfoGossipMessage struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -419,7 +521,7 @@ type NodeInfoGossipMessage struct {
 
 func (x *NodeInfoGossipMessage) Reset() {
 	*x = NodeInfoGossipMessage{}
-	mi := &file_resource_discovery_discovery_proto_msgTypes[4]
+	mi := &file_resource_discovery_discovery_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -431,7 +533,7 @@ func (x *NodeInfoGossipMessage) String() string {
 func (*NodeInfoGossipMessage) ProtoMessage() {}
 
 func (x *NodeInfoGossipMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_resource_discovery_discovery_proto_msgTypes[4]
+	mi := &file_resource_discovery_discovery_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -444,7 +546,7 @@ func (x *NodeInfoGossipMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NodeInfoGossipMessage.ProtoReflect.Descriptor instead.
 func (*NodeInfoGossipMessage) Descriptor() ([]byte, []int) {
-	return file_resource_discovery_discovery_proto_rawDescGZIP(), []int{4}
+	return file_resource_discovery_discovery_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *NodeInfoGossipMessage) GetSenderNodeId() string {
@@ -517,7 +619,7 @@ type NodeInfoGossipResponse struct {
 
 func (x *NodeInfoGossipResponse) Reset() {
 	*x = NodeInfoGossipResponse{}
-	mi := &file_resource_discovery_discovery_proto_msgTypes[5]
+	mi := &file_resource_discovery_discovery_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -529,7 +631,7 @@ func (x *NodeInfoGossipResponse) String() string {
 func (*NodeInfoGossipResponse) ProtoMessage() {}
 
 func (x *NodeInfoGossipResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_resource_discovery_discovery_proto_msgTypes[5]
+	mi := &file_resource_discovery_discovery_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -542,7 +644,7 @@ func (x *NodeInfoGossipResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NodeInfoGossipResponse.ProtoReflect.Descriptor instead.
 func (*NodeInfoGossipResponse) Descriptor() ([]byte, []int) {
-	return file_resource_discovery_discovery_proto_rawDescGZIP(), []int{5}
+	return file_resource_discovery_discovery_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *NodeInfoGossipResponse) GetNodes() []*PeerNodeInfo {
@@ -569,16 +671,17 @@ func (x *NodeInfoGossipResponse) GetTimestamp() int64 {
 // ResourceRequest 资源请求
 type ResourceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Cpu           int64                  `protobuf:"varint,1,opt,name=cpu,proto3" json:"cpu,omitempty"`       // millicores
-	Memory        int64                  `protobuf:"varint,2,opt,name=memory,proto3" json:"memory,omitempty"` // bytes
-	Gpu           int64                  `protobuf:"varint,3,opt,name=gpu,proto3" json:"gpu,omitempty"`       // count
+	Cpu           int64                  `protobuf:"varint,1,opt,name=cpu,proto3" json:"cpu,omitempty"`                                                                                                                // millicores
+	Memory        int64                  `protobuf:"varint,2,opt,name=memory,proto3" json:"memory,omitempty"`                                                                                                          // bytes
+	Gpu           int64                  `protobuf:"varint,3,opt,name=gpu,proto3" json:"gpu,omitempty"`                                                                                                                // count
+	NodeSelector  map[string]string      `protobuf:"bytes,4,rep,name=node_selector,json=nodeSelector,proto3" json:"node_selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 节点标签约束
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ResourceRequest) Reset() {
 	*x = ResourceRequest{}
-	mi := &file_resource_discovery_discovery_proto_msgTypes[6]
+	mi := &file_resource_discovery_discovery_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -590,7 +693,7 @@ func (x *ResourceRequest) String() string {
 func (*ResourceRequest) ProtoMessage() {}
 
 func (x *ResourceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_resource_discovery_discovery_proto_msgTypes[6]
+	mi := &file_resource_discovery_discovery_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -603,7 +706,7 @@ func (x *ResourceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResourceRequest.ProtoReflect.Descriptor instead.
 func (*ResourceRequest) Descriptor() ([]byte, []int) {
-	return file_resource_discovery_discovery_proto_rawDescGZIP(), []int{6}
+	return file_resource_discovery_discovery_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ResourceRequest) GetCpu() int64 {
@@ -627,6 +730,13 @@ func (x *ResourceRequest) GetGpu() int64 {
 	return 0
 }
 
+func (x *ResourceRequest) GetNodeSelector() map[string]string {
+	if x != nil {
+		return x.NodeSelector
+	}
+	return nil
+}
+
 // ResourceQueryRequest 资源查询请求
 type ResourceQueryRequest struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
@@ -648,7 +758,7 @@ type ResourceQueryRequest struct {
 
 func (x *ResourceQueryRequest) Reset() {
 	*x = ResourceQueryRequest{}
-	mi := &file_resource_discovery_discovery_proto_msgTypes[7]
+	mi := &file_resource_discovery_discovery_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -660,7 +770,7 @@ func (x *ResourceQueryRequest) String() string {
 func (*ResourceQueryRequest) ProtoMessage() {}
 
 func (x *ResourceQueryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_resource_discovery_discovery_proto_msgTypes[7]
+	mi := &file_resource_discovery_discovery_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -673,7 +783,7 @@ func (x *ResourceQueryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResourceQueryRequest.ProtoReflect.Descriptor instead.
 func (*ResourceQueryRequest) Descriptor() ([]byte, []int) {
-	return file_resource_discovery_discovery_proto_rawDescGZIP(), []int{7}
+	return file_resource_discovery_discovery_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ResourceQueryRequest) GetQueryId() string {
@@ -763,7 +873,7 @@ type ResourceQueryResponse struct {
 
 func (x *ResourceQueryResponse) Reset() {
 	*x = ResourceQueryResponse{}
-	mi := &file_resource_discovery_discovery_proto_msgTypes[8]
+	mi := &file_resource_discovery_discovery_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -775,7 +885,7 @@ func (x *ResourceQueryResponse) String() string {
 func (*ResourceQueryResponse) ProtoMessage() {}
 
 func (x *ResourceQueryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_resource_discovery_discovery_proto_msgTypes[8]
+	mi := &file_resource_discovery_discovery_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -788,7 +898,7 @@ func (x *ResourceQueryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResourceQueryResponse.ProtoReflect.Descriptor instead.
 func (*ResourceQueryResponse) Descriptor() ([]byte, []int) {
-	return file_resource_discovery_discovery_proto_rawDescGZIP(), []int{8}
+	return file_resource_discovery_discovery_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ResourceQueryResponse) GetQueryId() string {
@@ -848,7 +958,7 @@ type PeerListExchangeRequest struct {
 
 func (x *PeerListExchangeRequest) Reset() {
 	*x = PeerListExchangeRequest{}
-	mi := &file_resource_discovery_discovery_proto_msgTypes[9]
+	mi := &file_resource_discovery_discovery_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -860,7 +970,7 @@ func (x *PeerListExchangeRequest) String() string {
 func (*PeerListExchangeRequest) ProtoMessage() {}
 
 func (x *PeerListExchangeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_resource_discovery_discovery_proto_msgTypes[9]
+	mi := &file_resource_discovery_discovery_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -873,7 +983,7 @@ func (x *PeerListExchangeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PeerListExchangeRequest.ProtoReflect.Descriptor instead.
 func (*PeerListExchangeRequest) Descriptor() ([]byte, []int) {
-	return file_resource_discovery_discovery_proto_rawDescGZIP(), []int{9}
+	return file_resource_discovery_discovery_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *PeerListExchangeRequest) GetRequesterNodeId() string {
@@ -923,7 +1033,7 @@ type PeerListExchangeResponse struct {
 
 func (x *PeerListExchangeResponse) Reset() {
 	*x = PeerListExchangeResponse{}
-	mi := &file_resource_discovery_discovery_proto_msgTypes[10]
+	mi := &file_resource_discovery_discovery_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -935,7 +1045,7 @@ func (x *PeerListExchangeResponse) String() string {
 func (*PeerListExchangeResponse) ProtoMessage() {}
 
 func (x *PeerListExchangeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_resource_discovery_discovery_proto_msgTypes[10]
+	mi := &file_resource_discovery_discovery_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -948,7 +1058,7 @@ func (x *PeerListExchangeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PeerListExchangeResponse.ProtoReflect.Descriptor instead.
 func (*PeerListExchangeResponse) Descriptor() ([]byte, []int) {
-	return file_resource_discovery_discovery_proto_rawDescGZIP(), []int{10}
+	return file_resource_discovery_discovery_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *PeerListExchangeResponse) GetKnownPeers() []string {
@@ -974,7 +1084,7 @@ type GetLocalNodeInfoRequest struct {
 
 func (x *GetLocalNodeInfoRequest) Reset() {
 	*x = GetLocalNodeInfoRequest{}
-	mi := &file_resource_discovery_discovery_proto_msgTypes[11]
+	mi := &file_resource_discovery_discovery_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -986,7 +1096,7 @@ func (x *GetLocalNodeInfoRequest) String() string {
 func (*GetLocalNodeInfoRequest) ProtoMessage() {}
 
 func (x *GetLocalNodeInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_resource_discovery_discovery_proto_msgTypes[11]
+	mi := &file_resource_discovery_discovery_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -999,7 +1109,7 @@ func (x *GetLocalNodeInfoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLocalNodeInfoRequest.ProtoReflect.Descriptor instead.
 func (*GetLocalNodeInfoRequest) Descriptor() ([]byte, []int) {
-	return file_resource_discovery_discovery_proto_rawDescGZIP(), []int{11}
+	return file_resource_discovery_discovery_proto_rawDescGZIP(), []int{12}
 }
 
 // GetLocalNodeInfoResponse 获取本地节点信息响应
@@ -1012,7 +1122,7 @@ type GetLocalNodeInfoResponse struct {
 
 func (x *GetLocalNodeInfoResponse) Reset() {
 	*x = GetLocalNodeInfoResponse{}
-	mi := &file_resource_discovery_discovery_proto_msgTypes[12]
+	mi := &file_resource_discovery_discovery_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1024,7 +1134,7 @@ func (x *GetLocalNodeInfoResponse) String() string {
 func (*GetLocalNodeInfoResponse) ProtoMessage() {}
 
 func (x *GetLocalNodeInfoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_resource_discovery_discovery_proto_msgTypes[12]
+	mi := &file_resource_discovery_discovery_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1037,7 +1147,7 @@ func (x *GetLocalNodeInfoResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLocalNodeInfoResponse.ProtoReflect.Descriptor instead.
 func (*GetLocalNodeInfoResponse) Descriptor() ([]byte, []int) {
-	return file_resource_discovery_discovery_proto_rawDescGZIP(), []int{12}
+	return file_resource_discovery_discovery_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GetLocalNodeInfoResponse) GetNodeInfo() *PeerNodeInfo {
@@ -1051,7 +1161,7 @@ var File_resource_discovery_discovery_proto protoreflect.FileDescriptor
 
 const file_resource_discovery_discovery_proto_rawDesc = "" +
 	"\n" +
-	"\"resource/discovery/discovery.proto\x12\tdiscovery\"J\n" +
+	"\"resource/discovery/discovery.proto\x12\tdiscovery\x1a\x15common/protocol.proto\"J\n" +
 	"\fResourceInfo\x12\x10\n" +
 	"\x03cpu\x18\x01 \x01(\x03R\x03cpu\x12\x16\n" +
 	"\x06memory\x18\x02 \x01(\x03R\x06memory\x12\x10\n" +
@@ -1064,7 +1174,10 @@ const file_resource_discovery_discovery_proto_rawDesc = "" +
 	"\x03cpu\x18\x01 \x01(\bR\x03cpu\x12\x10\n" +
 	"\x03gpu\x18\x02 \x01(\bR\x03gpu\x12\x16\n" +
 	"\x06memory\x18\x03 \x01(\bR\x06memory\x12\x16\n" +
-	"\x06camera\x18\x04 \x01(\bR\x06camera\"\xdc\x03\n" +
+	"\x06camera\x18\x04 \x01(\bR\x06camera\"^\n" +
+	"\rEnergyProfile\x12$\n" +
+	"\x0ewatts_per_core\x18\x01 \x01(\x01R\fwattsPerCore\x12'\n" +
+	"\x0fbattery_powered\x18\x02 \x01(\bR\x0ebatteryPowered\"\xa6\x06\n" +
 	"\fPeerNodeInfo\x12\x17\n" +
 	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\x1b\n" +
 	"\tnode_name\x18\x02 \x01(\tR\bnodeName\x12\x18\n" +
@@ -1078,7 +1191,16 @@ const file_resource_discovery_discovery_proto_rawDesc = "" +
 	"\flast_updated\x18\t \x01(\x03R\vlastUpdated\x12\x18\n" +
 	"\aversion\x18\n" +
 	" \x01(\x04R\aversion\x12!\n" +
-	"\fgossip_count\x18\v \x01(\x05R\vgossipCount\"\xa7\x02\n" +
+	"\fgossip_count\x18\v \x01(\x05R\vgossipCount\x12?\n" +
+	"\x0eenergy_profile\x18\r \x01(\v2\x18.discovery.EnergyProfileR\renergyProfile\x12;\n" +
+	"\x06labels\x18\x0f \x03(\v2#.discovery.PeerNodeInfo.LabelsEntryR\x06labels\x120\n" +
+	"\bprotocol\x18\x10 \x01(\v2\x14.common.ProtocolInfoR\bprotocol\x12\x1b\n" +
+	"\thead_role\x18\x11 \x01(\tR\bheadRole\x12\x1b\n" +
+	"\thead_term\x18\x12 \x01(\x04R\bheadTerm\x12#\n" +
+	"\rhead_priority\x18\x13 \x01(\x05R\fheadPriority\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa7\x02\n" +
 	"\x15NodeInfoGossipMessage\x12$\n" +
 	"\x0esender_node_id\x18\x01 \x01(\tR\fsenderNodeId\x12%\n" +
 	"\x0esender_address\x18\x02 \x01(\tR\rsenderAddress\x12(\n" +
@@ -1093,11 +1215,15 @@ const file_resource_discovery_discovery_proto_rawDesc = "" +
 	"\x05nodes\x18\x01 \x03(\v2\x17.discovery.PeerNodeInfoR\x05nodes\x12\x1d\n" +
 	"\n" +
 	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x1c\n" +
-	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\"M\n" +
+	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\"\xe1\x01\n" +
 	"\x0fResourceRequest\x12\x10\n" +
 	"\x03cpu\x18\x01 \x01(\x03R\x03cpu\x12\x16\n" +
 	"\x06memory\x18\x02 \x01(\x03R\x06memory\x12\x10\n" +
-	"\x03gpu\x18\x03 \x01(\x03R\x03gpu\"\xad\x03\n" +
+	"\x03gpu\x18\x03 \x01(\x03R\x03gpu\x12Q\n" +
+	"\rnode_selector\x18\x04 \x03(\v2,.discovery.ResourceRequest.NodeSelectorEntryR\fnodeSelector\x1a?\n" +
+	"\x11NodeSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xad\x03\n" +
 	"\x14ResourceQueryRequest\x12\x19\n" +
 	"\bquery_id\x18\x01 \x01(\tR\aqueryId\x12*\n" +
 	"\x11requester_node_id\x18\x02 \x01(\tR\x0frequesterNodeId\x12+\n" +
@@ -1156,22 +1282,26 @@ func file_resource_discovery_discovery_proto_rawDescGZIP() []byte {
 }
 
 var file_resource_discovery_discovery_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_resource_discovery_discovery_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_resource_discovery_discovery_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_resource_discovery_discovery_proto_goTypes = []any{
 	(NodeStatus)(0),                  // 0: discovery.NodeStatus
 	(*ResourceInfo)(nil),             // 1: discovery.ResourceInfo
 	(*ResourceCapacity)(nil),         // 2: discovery.ResourceCapacity
 	(*ResourceTags)(nil),             // 3: discovery.ResourceTags
-	(*PeerNodeInfo)(nil),             // 4: discovery.PeerNodeInfo
-	(*NodeInfoGossipMessage)(nil),    // 5: discovery.NodeInfoGossipMessage
-	(*NodeInfoGossipResponse)(nil),   // 6: discovery.NodeInfoGossipResponse
-	(*ResourceRequest)(nil),          // 7: discovery.ResourceRequest
-	(*ResourceQueryRequest)(nil),     // 8: discovery.ResourceQueryRequest
-	(*ResourceQueryResponse)(nil),    // 9: discovery.ResourceQueryResponse
-	(*PeerListExchangeRequest)(nil),  // 10: discovery.PeerListExchangeRequest
-	(*PeerListExchangeResponse)(nil), // 11: discovery.PeerListExchangeResponse
-	(*GetLocalNodeInfoRequest)(nil),  // 12: discovery.GetLocalNodeInfoRequest
-	(*GetLocalNodeInfoResponse)(nil), // 13: discovery.GetLocalNodeInfoResponse
+	(*EnergyProfile)(nil),            // 4: discovery.EnergyProfile
+	(*PeerNodeInfo)(nil),             // 5: discovery.PeerNodeInfo
+	(*NodeInfoGossipMessage)(nil),    // 6: discovery.NodeInfoGossipMessage
+	(*NodeInfoGossipResponse)(nil),   // 7: discovery.NodeInfoGossipResponse
+	(*ResourceRequest)(nil),          // 8: discovery.ResourceRequest
+	(*ResourceQueryRequest)(nil),     // 9: discovery.ResourceQueryRequest
+	(*ResourceQueryResponse)(nil),    // 10: discovery.ResourceQueryResponse
+	(*PeerListExchangeRequest)(nil),  // 11: discovery.PeerListExchangeRequest
+	(*PeerListExchangeResponse)(nil), // 12: discovery.PeerListExchangeResponse
+	(*GetLocalNodeInfoRequest)(nil),  // 13: discovery.GetLocalNodeInfoRequest
+	(*GetLocalNodeInfoResponse)(nil), // 14: discovery.GetLocalNodeInfoResponse
+	nil,                              // 15: discovery.PeerNodeInfo.LabelsEntry
+	nil,                              // 16: discovery.ResourceRequest.NodeSelectorEntry
+	(*common.ProtocolInfo)(nil),      // 17: common.ProtocolInfo
 }
 var file_resource_discovery_discovery_proto_depIdxs = []int32{
 	1,  // 0: discovery.ResourceCapacity.total:type_name -> discovery.ResourceInfo
@@ -1180,25 +1310,29 @@ var file_resource_discovery_discovery_proto_depIdxs = []int32{
 	2,  // 3: discovery.PeerNodeInfo.resource_capacity:type_name -> discovery.ResourceCapacity
 	3,  // 4: discovery.PeerNodeInfo.resource_tags:type_name -> discovery.ResourceTags
 	0,  // 5: discovery.PeerNodeInfo.status:type_name -> discovery.NodeStatus
-	4,  // 6: discovery.NodeInfoGossipMessage.nodes:type_name -> discovery.PeerNodeInfo
-	4,  // 7: discovery.NodeInfoGossipResponse.nodes:type_name -> discovery.PeerNodeInfo
-	7,  // 8: discovery.ResourceQueryRequest.resource_request:type_name -> discovery.ResourceRequest
-	3,  // 9: discovery.ResourceQueryRequest.required_tags:type_name -> discovery.ResourceTags
-	4,  // 10: discovery.ResourceQueryResponse.available_nodes:type_name -> discovery.PeerNodeInfo
-	4,  // 11: discovery.GetLocalNodeInfoResponse.node_info:type_name -> discovery.PeerNodeInfo
-	5,  // 12: discovery.DiscoveryService.GossipNodeInfo:input_type -> discovery.NodeInfoGossipMessage
-	8,  // 13: discovery.DiscoveryService.QueryResources:input_type -> discovery.ResourceQueryRequest
-	10, // 14: discovery.DiscoveryService.ExchangePeerList:input_type -> discovery.PeerListExchangeRequest
-	12, // 15: discovery.DiscoveryService.GetLocalNodeInfo:input_type -> discovery.GetLocalNodeInfoRequest
-	6,  // 16: discovery.DiscoveryService.GossipNodeInfo:output_type -> discovery.NodeInfoGossipResponse
-	9,  // 17: discovery.DiscoveryService.QueryResources:output_type -> discovery.ResourceQueryResponse
-	11, // 18: discovery.DiscoveryService.ExchangePeerList:output_type -> discovery.PeerListExchangeResponse
-	13, // 19: discovery.DiscoveryService.GetLocalNodeInfo:output_type -> discovery.GetLocalNodeInfoResponse
-	16, // [16:20] is the sub-list for method output_type
-	12, // [12:16] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	4,  // 6: discovery.PeerNodeInfo.energy_profile:type_name -> discovery.EnergyProfile
+	15, // 7: discovery.PeerNodeInfo.labels:type_name -> discovery.PeerNodeInfo.LabelsEntry
+	17, // 8: discovery.PeerNodeInfo.protocol:type_name -> common.ProtocolInfo
+	5,  // 9: discovery.NodeInfoGossipMessage.nodes:type_name -> discovery.PeerNodeInfo
+	5,  // 10: discovery.NodeInfoGossipResponse.nodes:type_name -> discovery.PeerNodeInfo
+	16, // 11: discovery.ResourceRequest.node_selector:type_name -> discovery.ResourceRequest.NodeSelectorEntry
+	8,  // 12: discovery.ResourceQueryRequest.resource_request:type_name -> discovery.ResourceRequest
+	3,  // 13: discovery.ResourceQueryRequest.required_tags:type_name -> discovery.ResourceTags
+	5,  // 14: discovery.ResourceQueryResponse.available_nodes:type_name -> discovery.PeerNodeInfo
+	5,  // 15: discovery.GetLocalNodeInfoResponse.node_info:type_name -> discovery.PeerNodeInfo
+	6,  // 16: discovery.DiscoveryService.GossipNodeInfo:input_type -> discovery.NodeInfoGossipMessage
+	9,  // 17: discovery.DiscoveryService.QueryResources:input_type -> discovery.ResourceQueryRequest
+	11, // 18: discovery.DiscoveryService.ExchangePeerList:input_type -> discovery.PeerListExchangeRequest
+	13, // 19: discovery.DiscoveryService.GetLocalNodeInfo:input_type -> discovery.GetLocalNodeInfoRequest
+	7,  // 20: discovery.DiscoveryService.GossipNodeInfo:output_type -> discovery.NodeInfoGossipResponse
+	10, // 21: discovery.DiscoveryService.QueryResources:output_type -> discovery.ResourceQueryResponse
+	12, // 22: discovery.DiscoveryService.ExchangePeerList:output_type -> discovery.PeerListExchangeResponse
+	14, // 23: discovery.DiscoveryService.GetLocalNodeInfo:output_type -> discovery.GetLocalNodeInfoResponse
+	20, // [20:24] is the sub-list for method output_type
+	16, // [16:20] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_resource_discovery_discovery_proto_init() }
@@ -1212,7 +1346,7 @@ func file_resource_discovery_discovery_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_resource_discovery_discovery_proto_rawDesc), len(file_resource_discovery_discovery_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   13,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -7,6 +7,7 @@
 package provider
 
 import (
+	common "github.com/9triver/iarnet/internal/proto/common"
 	resource "github.com/9triver/iarnet/internal/proto/resource"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -69,6 +70,8 @@ func (x *ProviderType) GetName() string {
 type ConnectRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	Protocol      *common.ProtocolInfo   `protobuf:"bytes,2,opt,name=protocol,proto3" json:"protocol,omitempty"` // 调用方（iarnet 节点）的协议版本与能力
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`       // 注册令牌，provider 配置了令牌时需一致
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -110,11 +113,28 @@ func (x *ConnectRequest) GetProviderId() string {
 	return ""
 }
 
+func (x *ConnectRequest) GetProtocol() *common.ProtocolInfo {
+	if x != nil {
+		return x.Protocol
+	}
+	return nil
+}
+
+func (x *ConnectRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
 type ConnectResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	ProviderType  *ProviderType          `protobuf:"bytes,3,opt,name=provider_type,json=providerType,proto3" json:"provider_type,omitempty"`
+	Protocol      *common.ProtocolInfo   `protobuf:"bytes,4,opt,name=protocol,proto3" json:"protocol,omitempty"`           // provider 的协议版本与能力，旧版 provider 不携带
+	Architectures []string               `protobuf:"bytes,5,rep,name=architectures,proto3" json:"architectures,omitempty"` // provider 可运行的 CPU 架构（GOARCH 命名，如 amd64、arm64），旧版 provider 不携带
+	Capacity      *resource.Capacity     `protobuf:"bytes,6,opt,name=capacity,proto3" json:"capacity,omitempty"`           // provider 当前的容量，重启后重新连接时节点据此立即获知配置的变化，旧版 provider 不携带
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -170,6 +190,27 @@ func (x *ConnectResponse) GetProviderType() *ProviderType {
 	return nil
 }
 
+func (x *ConnectResponse) GetProtocol() *common.ProtocolInfo {
+	if x != nil {
+		return x.Protocol
+	}
+	return nil
+}
+
+func (x *ConnectResponse) GetArchitectures() []string {
+	if x != nil {
+		return x.Architectures
+	}
+	return nil
+}
+
+func (x *ConnectResponse) GetCapacity() *resource.Capacity {
+	if x != nil {
+		return x.Capacity
+	}
+	return nil
+}
+
 type GetCapacityRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"` // 可选的 provider_id，用于鉴权
@@ -347,14 +388,21 @@ func (x *GetAvailableResponse) GetAvailable() *resource.Info {
 }
 
 type DeployRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	InstanceId      string                 `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
-	Image           string                 `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
-	ResourceRequest *resource.Info         `protobuf:"bytes,3,opt,name=resource_request,json=resourceRequest,proto3" json:"resource_request,omitempty"`
-	EnvVars         map[string]string      `protobuf:"bytes,4,rep,name=env_vars,json=envVars,proto3" json:"env_vars,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	ProviderId      string                 `protobuf:"bytes,5,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"` // 可选的 provider_id，用于鉴权
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	state            protoimpl.MessageState  `protogen:"open.v1"`
+	InstanceId       string                  `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	Image            string                  `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	ResourceRequest  *resource.Info          `protobuf:"bytes,3,opt,name=resource_request,json=resourceRequest,proto3" json:"resource_request,omitempty"`
+	EnvVars          map[string]string       `protobuf:"bytes,4,rep,name=env_vars,json=envVars,proto3" json:"env_vars,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ProviderId       string                  `protobuf:"bytes,5,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`                     // 可选的 provider_id，用于鉴权
+	EgressPolicy     *EgressPolicy           `protobuf:"bytes,6,opt,name=egress_policy,json=egressPolicy,proto3" json:"egress_policy,omitempty"`               // 可选的出站网络策略，未设置时不做限制
+	Architectures    []string                `protobuf:"bytes,7,rep,name=architectures,proto3" json:"architectures,omitempty"`                                 // 镜像支持的 CPU 架构（可选），provider 需将实例放到兼容的节点上
+	DataSources      []*common.DataSource    `protobuf:"bytes,8,rep,name=data_sources,json=dataSources,proto3" json:"data_sources,omitempty"`                  // 启动前预置到 component 工作目录的数据（可选）
+	DataStoreAddress string                  `protobuf:"bytes,9,opt,name=data_store_address,json=dataStoreAddress,proto3" json:"data_store_address,omitempty"` // 拉取 data_sources 中 store 对象的 store 地址
+	Volumes          []*common.VolumeMount   `protobuf:"bytes,10,rep,name=volumes,proto3" json:"volumes,omitempty"`                                            // 挂载到 component 的持久化存储（可选）
+	SecurityContext  *common.SecurityContext `protobuf:"bytes,11,opt,name=security_context,json=securityContext,proto3" json:"security_context,omitempty"`     // 容器安全配置（可选），未设置时使用 provider 的默认配置
+	Sidecars         []*common.Sidecar       `protobuf:"bytes,12,rep,name=sidecars,proto3" json:"sidecars,omitempty"`                                          // 与主容器同机运行的 sidecar 容器（可选），resource_request 仅为主容器的资源
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *DeployRequest) Reset() {
@@ -422,27 +470,79 @@ func (x *DeployRequest) GetProviderId() string {
 	return ""
 }
 
-type DeployResponse struct {
+func (x *DeployRequest) GetEgressPolicy() *EgressPolicy {
+	if x != nil {
+		return x.EgressPolicy
+	}
+	return nil
+}
+
+func (x *DeployRequest) GetArchitectures() []string {
+	if x != nil {
+		return x.Architectures
+	}
+	return nil
+}
+
+func (x *DeployRequest) GetDataSources() []*common.DataSource {
+	if x != nil {
+		return x.DataSources
+	}
+	return nil
+}
+
+func (x *DeployRequest) GetDataStoreAddress() string {
+	if x != nil {
+		return x.DataStoreAddress
+	}
+	return ""
+}
+
+func (x *DeployRequest) GetVolumes() []*common.VolumeMount {
+	if x != nil {
+		return x.Volumes
+	}
+	return nil
+}
+
+func (x *DeployRequest) GetSecurityContext() *common.SecurityContext {
+	if x != nil {
+		return x.SecurityContext
+	}
+	return nil
+}
+
+func (x *DeployRequest) GetSidecars() []*common.Sidecar {
+	if x != nil {
+		return x.Sidecars
+	}
+	return nil
+}
+
+// EgressRule 出站放行规则
+type EgressRule struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Destination   string                 `protobuf:"bytes,1,opt,name=destination,proto3" json:"destination,omitempty"` // 目标 IP、CIDR 或主机名
+	Port          int32                  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`              // 目标端口，0 表示任意端口
+	Protocol      string                 `protobuf:"bytes,3,opt,name=protocol,proto3" json:"protocol,omitempty"`       // tcp / udp，空表示任意协议
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeployResponse) Reset() {
-	*x = DeployResponse{}
+func (x *EgressRule) Reset() {
+	*x = EgressRule{}
 	mi := &file_resource_provider_provider_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeployResponse) String() string {
+func (x *EgressRule) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeployResponse) ProtoMessage() {}
+func (*EgressRule) ProtoMessage() {}
 
-func (x *DeployResponse) ProtoReflect() protoreflect.Message {
+func (x *EgressRule) ProtoReflect() protoreflect.Message {
 	mi := &file_resource_provider_provider_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -454,39 +554,55 @@ func (x *DeployResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeployResponse.ProtoReflect.Descriptor instead.
-func (*DeployResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use EgressRule.ProtoReflect.Descriptor instead.
+func (*EgressRule) Descriptor() ([]byte, []int) {
 	return file_resource_provider_provider_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *DeployResponse) GetError() string {
+func (x *EgressRule) GetDestination() string {
 	if x != nil {
-		return x.Error
+		return x.Destination
 	}
 	return ""
 }
 
-type HealthCheckRequest struct {
+func (x *EgressRule) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *EgressRule) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+// EgressPolicy 组件出站网络策略（白名单）
+type EgressPolicy struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"` // 可选的 provider_id，用于鉴权
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"` // 是否启用出站限制
+	Allow         []*EgressRule          `protobuf:"bytes,2,rep,name=allow,proto3" json:"allow,omitempty"`      // 放行规则，启用后未命中的出站流量全部拒绝
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *HealthCheckRequest) Reset() {
-	*x = HealthCheckRequest{}
+func (x *EgressPolicy) Reset() {
+	*x = EgressPolicy{}
 	mi := &file_resource_provider_provider_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HealthCheckRequest) String() string {
+func (x *EgressPolicy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HealthCheckRequest) ProtoMessage() {}
+func (*EgressPolicy) ProtoMessage() {}
 
-func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
+func (x *EgressPolicy) ProtoReflect() protoreflect.Message {
 	mi := &file_resource_provider_provider_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -498,43 +614,47 @@ func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HealthCheckRequest.ProtoReflect.Descriptor instead.
-func (*HealthCheckRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use EgressPolicy.ProtoReflect.Descriptor instead.
+func (*EgressPolicy) Descriptor() ([]byte, []int) {
 	return file_resource_provider_provider_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *HealthCheckRequest) GetProviderId() string {
+func (x *EgressPolicy) GetEnabled() bool {
 	if x != nil {
-		return x.ProviderId
+		return x.Enabled
 	}
-	return ""
+	return false
 }
 
-// ResourceTags 资源标签（描述 provider 支持的计算资源类型）
-type ResourceTags struct {
+func (x *EgressPolicy) GetAllow() []*EgressRule {
+	if x != nil {
+		return x.Allow
+	}
+	return nil
+}
+
+type DeployResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Cpu           bool                   `protobuf:"varint,1,opt,name=cpu,proto3" json:"cpu,omitempty"`       // 是否支持 CPU
-	Gpu           bool                   `protobuf:"varint,2,opt,name=gpu,proto3" json:"gpu,omitempty"`       // 是否支持 GPU
-	Memory        bool                   `protobuf:"varint,3,opt,name=memory,proto3" json:"memory,omitempty"` // 是否支持内存
-	Camera        bool                   `protobuf:"varint,4,opt,name=camera,proto3" json:"camera,omitempty"` // 是否支持摄像头
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	NoCapacity    bool                   `protobuf:"varint,2,opt,name=no_capacity,json=noCapacity,proto3" json:"no_capacity,omitempty"` // 提交时剩余容量不足，部署被拒绝（可改投其他 provider）
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ResourceTags) Reset() {
-	*x = ResourceTags{}
+func (x *DeployResponse) Reset() {
+	*x = DeployResponse{}
 	mi := &file_resource_provider_provider_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ResourceTags) String() string {
+func (x *DeployResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResourceTags) ProtoMessage() {}
+func (*DeployResponse) ProtoMessage() {}
 
-func (x *ResourceTags) ProtoReflect() protoreflect.Message {
+func (x *DeployResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_resource_provider_provider_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -546,61 +666,48 @@ func (x *ResourceTags) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResourceTags.ProtoReflect.Descriptor instead.
-func (*ResourceTags) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeployResponse.ProtoReflect.Descriptor instead.
+func (*DeployResponse) Descriptor() ([]byte, []int) {
 	return file_resource_provider_provider_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *ResourceTags) GetCpu() bool {
-	if x != nil {
-		return x.Cpu
-	}
-	return false
-}
-
-func (x *ResourceTags) GetGpu() bool {
-	if x != nil {
-		return x.Gpu
-	}
-	return false
-}
-
-func (x *ResourceTags) GetMemory() bool {
+func (x *DeployResponse) GetError() string {
 	if x != nil {
-		return x.Memory
+		return x.Error
 	}
-	return false
+	return ""
 }
 
-func (x *ResourceTags) GetCamera() bool {
+func (x *DeployResponse) GetNoCapacity() bool {
 	if x != nil {
-		return x.Camera
+		return x.NoCapacity
 	}
 	return false
 }
 
-type HealthCheckResponse struct {
+// UndeployRequest 停止并删除 component 实例（迁移或回收时使用）
+type UndeployRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Capacity      *resource.Capacity     `protobuf:"bytes,1,opt,name=capacity,proto3" json:"capacity,omitempty"`                             // 当前资源使用情况（总容量、已使用、可用）
-	ResourceTags  *ResourceTags          `protobuf:"bytes,2,opt,name=resource_tags,json=resourceTags,proto3" json:"resource_tags,omitempty"` // 所具有的资源类型
+	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"` // provider_id，用于鉴权
+	InstanceId    string                 `protobuf:"bytes,2,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"` // component 实例 ID
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *HealthCheckResponse) Reset() {
-	*x = HealthCheckResponse{}
+func (x *UndeployRequest) Reset() {
+	*x = UndeployRequest{}
 	mi := &file_resource_provider_provider_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HealthCheckResponse) String() string {
+func (x *UndeployRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HealthCheckResponse) ProtoMessage() {}
+func (*UndeployRequest) ProtoMessage() {}
 
-func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
+func (x *UndeployRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_resource_provider_provider_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -612,46 +719,46 @@ func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HealthCheckResponse.ProtoReflect.Descriptor instead.
-func (*HealthCheckResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use UndeployRequest.ProtoReflect.Descriptor instead.
+func (*UndeployRequest) Descriptor() ([]byte, []int) {
 	return file_resource_provider_provider_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *HealthCheckResponse) GetCapacity() *resource.Capacity {
+func (x *UndeployRequest) GetProviderId() string {
 	if x != nil {
-		return x.Capacity
+		return x.ProviderId
 	}
-	return nil
+	return ""
 }
 
-func (x *HealthCheckResponse) GetResourceTags() *ResourceTags {
+func (x *UndeployRequest) GetInstanceId() string {
 	if x != nil {
-		return x.ResourceTags
+		return x.InstanceId
 	}
-	return nil
+	return ""
 }
 
-type DisconnectRequest struct {
+type UndeployResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DisconnectRequest) Reset() {
-	*x = DisconnectRequest{}
+func (x *UndeployResponse) Reset() {
+	*x = UndeployResponse{}
 	mi := &file_resource_provider_provider_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DisconnectRequest) String() string {
+func (x *UndeployResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DisconnectRequest) ProtoMessage() {}
+func (*UndeployResponse) ProtoMessage() {}
 
-func (x *DisconnectRequest) ProtoReflect() protoreflect.Message {
+func (x *UndeployResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_resource_provider_provider_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -663,38 +770,40 @@ func (x *DisconnectRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DisconnectRequest.ProtoReflect.Descriptor instead.
-func (*DisconnectRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UndeployResponse.ProtoReflect.Descriptor instead.
+func (*UndeployResponse) Descriptor() ([]byte, []int) {
 	return file_resource_provider_provider_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *DisconnectRequest) GetProviderId() string {
+func (x *UndeployResponse) GetError() string {
 	if x != nil {
-		return x.ProviderId
+		return x.Error
 	}
 	return ""
 }
 
-type DisconnectResponse struct {
+// BenchmarkRequest 请求 provider 运行一次微基准测试（注册时可选执行）
+type BenchmarkRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"` // provider_id，用于鉴权
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DisconnectResponse) Reset() {
-	*x = DisconnectResponse{}
+func (x *BenchmarkRequest) Reset() {
+	*x = BenchmarkRequest{}
 	mi := &file_resource_provider_provider_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DisconnectResponse) String() string {
+func (x *BenchmarkRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DisconnectResponse) ProtoMessage() {}
+func (*BenchmarkRequest) ProtoMessage() {}
 
-func (x *DisconnectResponse) ProtoReflect() protoreflect.Message {
+func (x *BenchmarkRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_resource_provider_provider_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -706,32 +815,46 @@ func (x *DisconnectResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DisconnectResponse.ProtoReflect.Descriptor instead.
-func (*DisconnectResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use BenchmarkRequest.ProtoReflect.Descriptor instead.
+func (*BenchmarkRequest) Descriptor() ([]byte, []int) {
 	return file_resource_provider_provider_proto_rawDescGZIP(), []int{13}
 }
 
-type GetRealTimeUsageRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"` // provider_id，用于鉴权
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *BenchmarkRequest) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
 }
 
-func (x *GetRealTimeUsageRequest) Reset() {
-	*x = GetRealTimeUsageRequest{}
+// BenchmarkResult 微基准测试结果，数值为 0 表示该项未测量
+type BenchmarkResult struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	CpuScore            float64                `protobuf:"fixed64,1,opt,name=cpu_score,json=cpuScore,proto3" json:"cpu_score,omitempty"`                                    // 单核 SHA-256 吞吐（MiB/s），越大越快
+	MemoryBandwidthMbps float64                `protobuf:"fixed64,2,opt,name=memory_bandwidth_mbps,json=memoryBandwidthMbps,proto3" json:"memory_bandwidth_mbps,omitempty"` // 内存拷贝带宽（MiB/s）
+	DiskWriteMbps       float64                `protobuf:"fixed64,3,opt,name=disk_write_mbps,json=diskWriteMbps,proto3" json:"disk_write_mbps,omitempty"`                   // 顺序写（含 fsync）吞吐（MiB/s）
+	DiskReadMbps        float64                `protobuf:"fixed64,4,opt,name=disk_read_mbps,json=diskReadMbps,proto3" json:"disk_read_mbps,omitempty"`                      // 顺序读吞吐（MiB/s）
+	GpuPresent          bool                   `protobuf:"varint,5,opt,name=gpu_present,json=gpuPresent,proto3" json:"gpu_present,omitempty"`                               // 是否探测到 GPU
+	GpuCount            int32                  `protobuf:"varint,6,opt,name=gpu_count,json=gpuCount,proto3" json:"gpu_count,omitempty"`                                     // 探测到的 GPU 数量
+	DurationMs          int64                  `protobuf:"varint,7,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`                               // 基准测试总耗时（毫秒）
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *BenchmarkResult) Reset() {
+	*x = BenchmarkResult{}
 	mi := &file_resource_provider_provider_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetRealTimeUsageRequest) String() string {
+func (x *BenchmarkResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRealTimeUsageRequest) ProtoMessage() {}
+func (*BenchmarkResult) ProtoMessage() {}
 
-func (x *GetRealTimeUsageRequest) ProtoReflect() protoreflect.Message {
+func (x *BenchmarkResult) ProtoReflect() protoreflect.Message {
 	mi := &file_resource_provider_provider_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -743,39 +866,82 @@ func (x *GetRealTimeUsageRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRealTimeUsageRequest.ProtoReflect.Descriptor instead.
-func (*GetRealTimeUsageRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BenchmarkResult.ProtoReflect.Descriptor instead.
+func (*BenchmarkResult) Descriptor() ([]byte, []int) {
 	return file_resource_provider_provider_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *GetRealTimeUsageRequest) GetProviderId() string {
+func (x *BenchmarkResult) GetCpuScore() float64 {
 	if x != nil {
-		return x.ProviderId
+		return x.CpuScore
 	}
-	return ""
+	return 0
 }
 
-type GetRealTimeUsageResponse struct {
+func (x *BenchmarkResult) GetMemoryBandwidthMbps() float64 {
+	if x != nil {
+		return x.MemoryBandwidthMbps
+	}
+	return 0
+}
+
+func (x *BenchmarkResult) GetDiskWriteMbps() float64 {
+	if x != nil {
+		return x.DiskWriteMbps
+	}
+	return 0
+}
+
+func (x *BenchmarkResult) GetDiskReadMbps() float64 {
+	if x != nil {
+		return x.DiskReadMbps
+	}
+	return 0
+}
+
+func (x *BenchmarkResult) GetGpuPresent() bool {
+	if x != nil {
+		return x.GpuPresent
+	}
+	return false
+}
+
+func (x *BenchmarkResult) GetGpuCount() int32 {
+	if x != nil {
+		return x.GpuCount
+	}
+	return 0
+}
+
+func (x *BenchmarkResult) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+type BenchmarkResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Usage         *resource.Info         `protobuf:"bytes,1,opt,name=usage,proto3" json:"usage,omitempty"` // 实时资源使用情况（CPU、内存、GPU）
+	Result        *BenchmarkResult       `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetRealTimeUsageResponse) Reset() {
-	*x = GetRealTimeUsageResponse{}
+func (x *BenchmarkResponse) Reset() {
+	*x = BenchmarkResponse{}
 	mi := &file_resource_provider_provider_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetRealTimeUsageResponse) String() string {
+func (x *BenchmarkResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRealTimeUsageResponse) ProtoMessage() {}
+func (*BenchmarkResponse) ProtoMessage() {}
 
-func (x *GetRealTimeUsageResponse) ProtoReflect() protoreflect.Message {
+func (x *BenchmarkResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_resource_provider_provider_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -787,32 +953,2183 @@ func (x *GetRealTimeUsageResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRealTimeUsageResponse.ProtoReflect.Descriptor instead.
-func (*GetRealTimeUsageResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use BenchmarkResponse.ProtoReflect.Descriptor instead.
+func (*BenchmarkResponse) Descriptor() ([]byte, []int) {
 	return file_resource_provider_provider_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *GetRealTimeUsageResponse) GetUsage() *resource.Info {
+func (x *BenchmarkResponse) GetResult() *BenchmarkResult {
 	if x != nil {
-		return x.Usage
+		return x.Result
 	}
 	return nil
 }
 
-var File_resource_provider_provider_proto protoreflect.FileDescriptor
+func (x *BenchmarkResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
 
-const file_resource_provider_provider_proto_rawDesc = "" +
-	"\n" +
-	" resource/provider/provider.proto\x12\bprovider\x1a\x17resource/resource.proto\"\"\n" +
-	"\fProviderType\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\"1\n" +
-	"\x0eConnectRequest\x12\x1f\n" +
-	"\vprovider_id\x18\x01 \x01(\tR\n" +
-	"providerId\"~\n" +
-	"\x0fConnectResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\x12;\n" +
-	"\rprovider_type\x18\x03 \x01(\v2\x16.provider.ProviderTypeR\fproviderType\"5\n" +
+type HealthCheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"` // 可选的 provider_id，用于鉴权
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthCheckRequest) Reset() {
+	*x = HealthCheckRequest{}
+	mi := &file_resource_provider_provider_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthCheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthCheckRequest) ProtoMessage() {}
+
+func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthCheckRequest.ProtoReflect.Descriptor instead.
+func (*HealthCheckRequest) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *HealthCheckRequest) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+// ResourceTags 资源标签（描述 provider 支持的计算资源类型）
+type ResourceTags struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cpu           bool                   `protobuf:"varint,1,opt,name=cpu,proto3" json:"cpu,omitempty"`       // 是否支持 CPU
+	Gpu           bool                   `protobuf:"varint,2,opt,name=gpu,proto3" json:"gpu,omitempty"`       // 是否支持 GPU
+	Memory        bool                   `protobuf:"varint,3,opt,name=memory,proto3" json:"memory,omitempty"` // 是否支持内存
+	Camera        bool                   `protobuf:"varint,4,opt,name=camera,proto3" json:"camera,omitempty"` // 是否支持摄像头
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResourceTags) Reset() {
+	*x = ResourceTags{}
+	mi := &file_resource_provider_provider_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceTags) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceTags) ProtoMessage() {}
+
+func (x *ResourceTags) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceTags.ProtoReflect.Descriptor instead.
+func (*ResourceTags) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ResourceTags) GetCpu() bool {
+	if x != nil {
+		return x.Cpu
+	}
+	return false
+}
+
+func (x *ResourceTags) GetGpu() bool {
+	if x != nil {
+		return x.Gpu
+	}
+	return false
+}
+
+func (x *ResourceTags) GetMemory() bool {
+	if x != nil {
+		return x.Memory
+	}
+	return false
+}
+
+func (x *ResourceTags) GetCamera() bool {
+	if x != nil {
+		return x.Camera
+	}
+	return false
+}
+
+// EnergyProfile 能耗画像（可选上报）
+type EnergyProfile struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	WattsPerCore   float64                `protobuf:"fixed64,1,opt,name=watts_per_core,json=wattsPerCore,proto3" json:"watts_per_core,omitempty"`    // 每核功耗（瓦），0 表示未知
+	BatteryPowered bool                   `protobuf:"varint,2,opt,name=battery_powered,json=batteryPowered,proto3" json:"battery_powered,omitempty"` // 是否由电池供电
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *EnergyProfile) Reset() {
+	*x = EnergyProfile{}
+	mi := &file_resource_provider_provider_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnergyProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnergyProfile) ProtoMessage() {}
+
+func (x *EnergyProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnergyProfile.ProtoReflect.Descriptor instead.
+func (*EnergyProfile) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *EnergyProfile) GetWattsPerCore() float64 {
+	if x != nil {
+		return x.WattsPerCore
+	}
+	return 0
+}
+
+func (x *EnergyProfile) GetBatteryPowered() bool {
+	if x != nil {
+		return x.BatteryPowered
+	}
+	return false
+}
+
+// GPUDevice provider 主机上的一块 GPU
+type GPUDevice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                       // 设备 ID（nvidia-smi 中的 UUID 或序号），部署时作为 device_ids 传给容器运行时
+	Index         int32                  `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`                                // nvidia-smi 中的序号
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`                                   // 型号
+	Memory        int64                  `protobuf:"varint,4,opt,name=memory,proto3" json:"memory,omitempty"`                              // 显存（bytes），0 表示未知
+	NvlinkGroup   int32                  `protobuf:"varint,5,opt,name=nvlink_group,json=nvlinkGroup,proto3" json:"nvlink_group,omitempty"` // 通过 NVLink 互联的 GPU 组，组内 GPU 的编号相同；-1 表示未与其他 GPU 互联
+	InstanceId    string                 `protobuf:"bytes,6,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`     // 占用该 GPU 的 component 实例，空表示空闲
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GPUDevice) Reset() {
+	*x = GPUDevice{}
+	mi := &file_resource_provider_provider_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GPUDevice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GPUDevice) ProtoMessage() {}
+
+func (x *GPUDevice) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GPUDevice.ProtoReflect.Descriptor instead.
+func (*GPUDevice) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GPUDevice) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GPUDevice) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *GPUDevice) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GPUDevice) GetMemory() int64 {
+	if x != nil {
+		return x.Memory
+	}
+	return 0
+}
+
+func (x *GPUDevice) GetNvlinkGroup() int32 {
+	if x != nil {
+		return x.NvlinkGroup
+	}
+	return 0
+}
+
+func (x *GPUDevice) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+// MediaStream 具有 camera 标签的 provider 拥有的数据流（摄像头等），部署请求可按名称绑定
+type MediaStream struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // 数据流名称，在 provider 内唯一
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"` // rtsp / v4l2
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`   // RTSP 地址或 V4L2 设备路径（e.g., /dev/video0）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MediaStream) Reset() {
+	*x = MediaStream{}
+	mi := &file_resource_provider_provider_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MediaStream) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MediaStream) ProtoMessage() {}
+
+func (x *MediaStream) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MediaStream.ProtoReflect.Descriptor instead.
+func (*MediaStream) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *MediaStream) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MediaStream) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *MediaStream) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type HealthCheckResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Capacity      *resource.Capacity     `protobuf:"bytes,1,opt,name=capacity,proto3" json:"capacity,omitempty"`                                // 当前资源使用情况（总容量、已使用、可用）
+	ResourceTags  *ResourceTags          `protobuf:"bytes,2,opt,name=resource_tags,json=resourceTags,proto3" json:"resource_tags,omitempty"`    // 所具有的资源类型
+	EnergyProfile *EnergyProfile         `protobuf:"bytes,3,opt,name=energy_profile,json=energyProfile,proto3" json:"energy_profile,omitempty"` // 能耗画像（可选）
+	Architectures []string               `protobuf:"bytes,4,rep,name=architectures,proto3" json:"architectures,omitempty"`                      // 可运行的 CPU 架构（可选）
+	Gpus          []*GPUDevice           `protobuf:"bytes,5,rep,name=gpus,proto3" json:"gpus,omitempty"`                                        // GPU 拓扑与逐卡分配情况（可选），只上报 GPU 数量的 provider 不携带
+	Streams       []*MediaStream         `protobuf:"bytes,6,rep,name=streams,proto3" json:"streams,omitempty"`                                  // 拥有的数据流（可选）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthCheckResponse) Reset() {
+	*x = HealthCheckResponse{}
+	mi := &file_resource_provider_provider_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthCheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthCheckResponse) ProtoMessage() {}
+
+func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthCheckResponse.ProtoReflect.Descriptor instead.
+func (*HealthCheckResponse) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *HealthCheckResponse) GetCapacity() *resource.Capacity {
+	if x != nil {
+		return x.Capacity
+	}
+	return nil
+}
+
+func (x *HealthCheckResponse) GetResourceTags() *ResourceTags {
+	if x != nil {
+		return x.ResourceTags
+	}
+	return nil
+}
+
+func (x *HealthCheckResponse) GetEnergyProfile() *EnergyProfile {
+	if x != nil {
+		return x.EnergyProfile
+	}
+	return nil
+}
+
+func (x *HealthCheckResponse) GetArchitectures() []string {
+	if x != nil {
+		return x.Architectures
+	}
+	return nil
+}
+
+func (x *HealthCheckResponse) GetGpus() []*GPUDevice {
+	if x != nil {
+		return x.Gpus
+	}
+	return nil
+}
+
+func (x *HealthCheckResponse) GetStreams() []*MediaStream {
+	if x != nil {
+		return x.Streams
+	}
+	return nil
+}
+
+type DisconnectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisconnectRequest) Reset() {
+	*x = DisconnectRequest{}
+	mi := &file_resource_provider_provider_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisconnectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisconnectRequest) ProtoMessage() {}
+
+func (x *DisconnectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisconnectRequest.ProtoReflect.Descriptor instead.
+func (*DisconnectRequest) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DisconnectRequest) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+type DisconnectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisconnectResponse) Reset() {
+	*x = DisconnectResponse{}
+	mi := &file_resource_provider_provider_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisconnectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisconnectResponse) ProtoMessage() {}
+
+func (x *DisconnectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisconnectResponse.ProtoReflect.Descriptor instead.
+func (*DisconnectResponse) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{23}
+}
+
+type GetRealTimeUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"` // provider_id，用于鉴权
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRealTimeUsageRequest) Reset() {
+	*x = GetRealTimeUsageRequest{}
+	mi := &file_resource_provider_provider_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRealTimeUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRealTimeUsageRequest) ProtoMessage() {}
+
+func (x *GetRealTimeUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRealTimeUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetRealTimeUsageRequest) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetRealTimeUsageRequest) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+type GetRealTimeUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Usage         *resource.Info         `protobuf:"bytes,1,opt,name=usage,proto3" json:"usage,omitempty"` // 实时资源使用情况（CPU、内存、GPU）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRealTimeUsageResponse) Reset() {
+	*x = GetRealTimeUsageResponse{}
+	mi := &file_resource_provider_provider_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRealTimeUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRealTimeUsageResponse) ProtoMessage() {}
+
+func (x *GetRealTimeUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRealTimeUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetRealTimeUsageResponse) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetRealTimeUsageResponse) GetUsage() *resource.Info {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+// WatchUsageRequest 订阅 provider 的资源使用情况推送
+type WatchUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`             // provider_id，用于鉴权
+	MinIntervalMs int64                  `protobuf:"varint,2,opt,name=min_interval_ms,json=minIntervalMs,proto3" json:"min_interval_ms,omitempty"` // 两次采样的最小间隔（毫秒），0 表示由 provider 决定
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchUsageRequest) Reset() {
+	*x = WatchUsageRequest{}
+	mi := &file_resource_provider_provider_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchUsageRequest) ProtoMessage() {}
+
+func (x *WatchUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchUsageRequest.ProtoReflect.Descriptor instead.
+func (*WatchUsageRequest) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *WatchUsageRequest) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+func (x *WatchUsageRequest) GetMinIntervalMs() int64 {
+	if x != nil {
+		return x.MinIntervalMs
+	}
+	return 0
+}
+
+// UsageUpdate provider 推送的资源变化（增量）
+// 第一条消息包含全部字段，之后只携带发生变化的字段，未变化的字段不设置
+type UsageUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Usage         *resource.Info         `protobuf:"bytes,1,opt,name=usage,proto3" json:"usage,omitempty"`       // 实时资源使用情况
+	Capacity      *resource.Capacity     `protobuf:"bytes,2,opt,name=capacity,proto3" json:"capacity,omitempty"` // 资源容量
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UsageUpdate) Reset() {
+	*x = UsageUpdate{}
+	mi := &file_resource_provider_provider_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsageUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageUpdate) ProtoMessage() {}
+
+func (x *UsageUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageUpdate.ProtoReflect.Descriptor instead.
+func (*UsageUpdate) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UsageUpdate) GetUsage() *resource.Info {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+func (x *UsageUpdate) GetCapacity() *resource.Capacity {
+	if x != nil {
+		return x.Capacity
+	}
+	return nil
+}
+
+// ExportImageRequest 向同域 provider 请求镜像（P2P 镜像分发）
+type ExportImageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Image         string                 `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"` // 镜像引用
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"` // 共享令牌，需与对端配置一致
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportImageRequest) Reset() {
+	*x = ExportImageRequest{}
+	mi := &file_resource_provider_provider_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportImageRequest) ProtoMessage() {}
+
+func (x *ExportImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportImageRequest.ProtoReflect.Descriptor instead.
+func (*ExportImageRequest) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ExportImageRequest) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *ExportImageRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// ImageChunk 镜像归档（docker save 格式）的数据块
+type ImageChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImageChunk) Reset() {
+	*x = ImageChunk{}
+	mi := &file_resource_provider_provider_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImageChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageChunk) ProtoMessage() {}
+
+func (x *ImageChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageChunk.ProtoReflect.Descriptor instead.
+func (*ImageChunk) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ImageChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// ExecStart 在 component 容器内启动调试命令（Exec 流的第一条消息）
+type ExecStart struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"` // provider_id，用于鉴权
+	InstanceId    string                 `protobuf:"bytes,2,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"` // component 实例 ID
+	Command       []string               `protobuf:"bytes,3,rep,name=command,proto3" json:"command,omitempty"`                         // 要执行的命令，为空时使用 /bin/sh
+	Tty           bool                   `protobuf:"varint,4,opt,name=tty,proto3" json:"tty,omitempty"`                                // 是否分配伪终端
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecStart) Reset() {
+	*x = ExecStart{}
+	mi := &file_resource_provider_provider_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecStart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecStart) ProtoMessage() {}
+
+func (x *ExecStart) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecStart.ProtoReflect.Descriptor instead.
+func (*ExecStart) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ExecStart) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+func (x *ExecStart) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *ExecStart) GetCommand() []string {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *ExecStart) GetTty() bool {
+	if x != nil {
+		return x.Tty
+	}
+	return false
+}
+
+// ExecResize 调整伪终端窗口大小
+type ExecResize struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rows          uint32                 `protobuf:"varint,1,opt,name=rows,proto3" json:"rows,omitempty"`
+	Cols          uint32                 `protobuf:"varint,2,opt,name=cols,proto3" json:"cols,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecResize) Reset() {
+	*x = ExecResize{}
+	mi := &file_resource_provider_provider_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecResize) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecResize) ProtoMessage() {}
+
+func (x *ExecResize) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecResize.ProtoReflect.Descriptor instead.
+func (*ExecResize) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ExecResize) GetRows() uint32 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *ExecResize) GetCols() uint32 {
+	if x != nil {
+		return x.Cols
+	}
+	return 0
+}
+
+// ExecRequest 客户端发往 provider 的 Exec 消息
+type ExecRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ExecRequest_Start
+	//	*ExecRequest_Stdin
+	//	*ExecRequest_Resize
+	//	*ExecRequest_CloseStdin
+	Payload       isExecRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecRequest) Reset() {
+	*x = ExecRequest{}
+	mi := &file_resource_provider_provider_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecRequest) ProtoMessage() {}
+
+func (x *ExecRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecRequest.ProtoReflect.Descriptor instead.
+func (*ExecRequest) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ExecRequest) GetPayload() isExecRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ExecRequest) GetStart() *ExecStart {
+	if x != nil {
+		if x, ok := x.Payload.(*ExecRequest_Start); ok {
+			return x.Start
+		}
+	}
+	return nil
+}
+
+func (x *ExecRequest) GetStdin() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*ExecRequest_Stdin); ok {
+			return x.Stdin
+		}
+	}
+	return nil
+}
+
+func (x *ExecRequest) GetResize() *ExecResize {
+	if x != nil {
+		if x, ok := x.Payload.(*ExecRequest_Resize); ok {
+			return x.Resize
+		}
+	}
+	return nil
+}
+
+func (x *ExecRequest) GetCloseStdin() bool {
+	if x != nil {
+		if x, ok := x.Payload.(*ExecRequest_CloseStdin); ok {
+			return x.CloseStdin
+		}
+	}
+	return false
+}
+
+type isExecRequest_Payload interface {
+	isExecRequest_Payload()
+}
+
+type ExecRequest_Start struct {
+	Start *ExecStart `protobuf:"bytes,1,opt,name=start,proto3,oneof"` // 启动命令，必须为第一条消息
+}
+
+type ExecRequest_Stdin struct {
+	Stdin []byte `protobuf:"bytes,2,opt,name=stdin,proto3,oneof"` // 标准输入数据
+}
+
+type ExecRequest_Resize struct {
+	Resize *ExecResize `protobuf:"bytes,3,opt,name=resize,proto3,oneof"` // 调整终端大小
+}
+
+type ExecRequest_CloseStdin struct {
+	CloseStdin bool `protobuf:"varint,4,opt,name=close_stdin,json=closeStdin,proto3,oneof"` // 关闭标准输入
+}
+
+func (*ExecRequest_Start) isExecRequest_Payload() {}
+
+func (*ExecRequest_Stdin) isExecRequest_Payload() {}
+
+func (*ExecRequest_Resize) isExecRequest_Payload() {}
+
+func (*ExecRequest_CloseStdin) isExecRequest_Payload() {}
+
+// ExecResponse provider 返回的 Exec 输出
+type ExecResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stdout        []byte                 `protobuf:"bytes,1,opt,name=stdout,proto3" json:"stdout,omitempty"`
+	Stderr        []byte                 `protobuf:"bytes,2,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	Exited        bool                   `protobuf:"varint,3,opt,name=exited,proto3" json:"exited,omitempty"` // 命令已退出，exit_code 有效
+	ExitCode      int32                  `protobuf:"varint,4,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecResponse) Reset() {
+	*x = ExecResponse{}
+	mi := &file_resource_provider_provider_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecResponse) ProtoMessage() {}
+
+func (x *ExecResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecResponse.ProtoReflect.Descriptor instead.
+func (*ExecResponse) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ExecResponse) GetStdout() []byte {
+	if x != nil {
+		return x.Stdout
+	}
+	return nil
+}
+
+func (x *ExecResponse) GetStderr() []byte {
+	if x != nil {
+		return x.Stderr
+	}
+	return nil
+}
+
+func (x *ExecResponse) GetExited() bool {
+	if x != nil {
+		return x.Exited
+	}
+	return false
+}
+
+func (x *ExecResponse) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *ExecResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// PortForwardStart 建立到 component 端口的隧道（PortForward 流的第一条消息）
+type PortForwardStart struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"` // provider_id，用于鉴权
+	InstanceId    string                 `protobuf:"bytes,2,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"` // component 实例 ID
+	Port          uint32                 `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`                              // component 内的目标端口
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PortForwardStart) Reset() {
+	*x = PortForwardStart{}
+	mi := &file_resource_provider_provider_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PortForwardStart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PortForwardStart) ProtoMessage() {}
+
+func (x *PortForwardStart) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PortForwardStart.ProtoReflect.Descriptor instead.
+func (*PortForwardStart) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *PortForwardStart) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+func (x *PortForwardStart) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *PortForwardStart) GetPort() uint32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+// PortForwardRequest 客户端发往 provider 的隧道消息
+type PortForwardRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*PortForwardRequest_Start
+	//	*PortForwardRequest_Data
+	//	*PortForwardRequest_CloseWrite
+	Payload       isPortForwardRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PortForwardRequest) Reset() {
+	*x = PortForwardRequest{}
+	mi := &file_resource_provider_provider_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PortForwardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PortForwardRequest) ProtoMessage() {}
+
+func (x *PortForwardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PortForwardRequest.ProtoReflect.Descriptor instead.
+func (*PortForwardRequest) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *PortForwardRequest) GetPayload() isPortForwardRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *PortForwardRequest) GetStart() *PortForwardStart {
+	if x != nil {
+		if x, ok := x.Payload.(*PortForwardRequest_Start); ok {
+			return x.Start
+		}
+	}
+	return nil
+}
+
+func (x *PortForwardRequest) GetData() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*PortForwardRequest_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *PortForwardRequest) GetCloseWrite() bool {
+	if x != nil {
+		if x, ok := x.Payload.(*PortForwardRequest_CloseWrite); ok {
+			return x.CloseWrite
+		}
+	}
+	return false
+}
+
+type isPortForwardRequest_Payload interface {
+	isPortForwardRequest_Payload()
+}
+
+type PortForwardRequest_Start struct {
+	Start *PortForwardStart `protobuf:"bytes,1,opt,name=start,proto3,oneof"` // 建立隧道，必须为第一条消息
+}
+
+type PortForwardRequest_Data struct {
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3,oneof"` // 发往 component 端口的数据
+}
+
+type PortForwardRequest_CloseWrite struct {
+	CloseWrite bool `protobuf:"varint,3,opt,name=close_write,json=closeWrite,proto3,oneof"` // 客户端已结束发送
+}
+
+func (*PortForwardRequest_Start) isPortForwardRequest_Payload() {}
+
+func (*PortForwardRequest_Data) isPortForwardRequest_Payload() {}
+
+func (*PortForwardRequest_CloseWrite) isPortForwardRequest_Payload() {}
+
+// PortForwardResponse provider 返回的隧道数据
+type PortForwardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PortForwardResponse) Reset() {
+	*x = PortForwardResponse{}
+	mi := &file_resource_provider_provider_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PortForwardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PortForwardResponse) ProtoMessage() {}
+
+func (x *PortForwardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PortForwardResponse.ProtoReflect.Descriptor instead.
+func (*PortForwardResponse) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *PortForwardResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *PortForwardResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// GetStagingStatusRequest 查询 component 的数据预置进度
+type GetStagingStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"` // provider_id，用于鉴权
+	InstanceId    string                 `protobuf:"bytes,2,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"` // component 实例 ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStagingStatusRequest) Reset() {
+	*x = GetStagingStatusRequest{}
+	mi := &file_resource_provider_provider_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStagingStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStagingStatusRequest) ProtoMessage() {}
+
+func (x *GetStagingStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStagingStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetStagingStatusRequest) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetStagingStatusRequest) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+func (x *GetStagingStatusRequest) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+// StagingProgress 单个数据源的预置进度
+type StagingProgress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                                // 工作目录内的相对路径
+	State         string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`                              // pending / downloading / verifying / done / failed
+	BytesDone     int64                  `protobuf:"varint,3,opt,name=bytes_done,json=bytesDone,proto3" json:"bytes_done,omitempty"`    // 已下载的字节数
+	BytesTotal    int64                  `protobuf:"varint,4,opt,name=bytes_total,json=bytesTotal,proto3" json:"bytes_total,omitempty"` // 总字节数，未知时为 0
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`                              // 失败原因
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StagingProgress) Reset() {
+	*x = StagingProgress{}
+	mi := &file_resource_provider_provider_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StagingProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StagingProgress) ProtoMessage() {}
+
+func (x *StagingProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StagingProgress.ProtoReflect.Descriptor instead.
+func (*StagingProgress) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *StagingProgress) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *StagingProgress) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *StagingProgress) GetBytesDone() int64 {
+	if x != nil {
+		return x.BytesDone
+	}
+	return 0
+}
+
+func (x *StagingProgress) GetBytesTotal() int64 {
+	if x != nil {
+		return x.BytesTotal
+	}
+	return 0
+}
+
+func (x *StagingProgress) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type GetStagingStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*StagingProgress     `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStagingStatusResponse) Reset() {
+	*x = GetStagingStatusResponse{}
+	mi := &file_resource_provider_provider_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStagingStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStagingStatusResponse) ProtoMessage() {}
+
+func (x *GetStagingStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStagingStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetStagingStatusResponse) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetStagingStatusResponse) GetItems() []*StagingProgress {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *GetStagingStatusResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Volume provider 管理的命名卷
+type Volume struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // 创建时间（Unix 秒），未知时为 0
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Volume) Reset() {
+	*x = Volume{}
+	mi := &file_resource_provider_provider_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Volume) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Volume) ProtoMessage() {}
+
+func (x *Volume) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Volume.ProtoReflect.Descriptor instead.
+func (*Volume) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *Volume) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Volume) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type CreateVolumeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"` // provider_id，用于鉴权
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateVolumeRequest) Reset() {
+	*x = CreateVolumeRequest{}
+	mi := &file_resource_provider_provider_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateVolumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVolumeRequest) ProtoMessage() {}
+
+func (x *CreateVolumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVolumeRequest.ProtoReflect.Descriptor instead.
+func (*CreateVolumeRequest) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CreateVolumeRequest) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+func (x *CreateVolumeRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateVolumeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Volume        *Volume                `protobuf:"bytes,1,opt,name=volume,proto3" json:"volume,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateVolumeResponse) Reset() {
+	*x = CreateVolumeResponse{}
+	mi := &file_resource_provider_provider_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateVolumeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVolumeResponse) ProtoMessage() {}
+
+func (x *CreateVolumeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVolumeResponse.ProtoReflect.Descriptor instead.
+func (*CreateVolumeResponse) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CreateVolumeResponse) GetVolume() *Volume {
+	if x != nil {
+		return x.Volume
+	}
+	return nil
+}
+
+func (x *CreateVolumeResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ListVolumesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"` // provider_id，用于鉴权
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVolumesRequest) Reset() {
+	*x = ListVolumesRequest{}
+	mi := &file_resource_provider_provider_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVolumesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVolumesRequest) ProtoMessage() {}
+
+func (x *ListVolumesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVolumesRequest.ProtoReflect.Descriptor instead.
+func (*ListVolumesRequest) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListVolumesRequest) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+type ListVolumesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Volumes       []*Volume              `protobuf:"bytes,1,rep,name=volumes,proto3" json:"volumes,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVolumesResponse) Reset() {
+	*x = ListVolumesResponse{}
+	mi := &file_resource_provider_provider_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVolumesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVolumesResponse) ProtoMessage() {}
+
+func (x *ListVolumesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVolumesResponse.ProtoReflect.Descriptor instead.
+func (*ListVolumesResponse) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListVolumesResponse) GetVolumes() []*Volume {
+	if x != nil {
+		return x.Volumes
+	}
+	return nil
+}
+
+func (x *ListVolumesResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// DeleteVolumeRequest 删除命名卷，仍被 component 挂载时失败
+type DeleteVolumeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"` // provider_id，用于鉴权
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteVolumeRequest) Reset() {
+	*x = DeleteVolumeRequest{}
+	mi := &file_resource_provider_provider_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteVolumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteVolumeRequest) ProtoMessage() {}
+
+func (x *DeleteVolumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteVolumeRequest.ProtoReflect.Descriptor instead.
+func (*DeleteVolumeRequest) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *DeleteVolumeRequest) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+func (x *DeleteVolumeRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteVolumeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteVolumeResponse) Reset() {
+	*x = DeleteVolumeResponse{}
+	mi := &file_resource_provider_provider_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteVolumeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteVolumeResponse) ProtoMessage() {}
+
+func (x *DeleteVolumeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteVolumeResponse.ProtoReflect.Descriptor instead.
+func (*DeleteVolumeResponse) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *DeleteVolumeResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// GetInstanceStatusRequest 查询 component 实例的运行状态，run-to-completion 的任务据此判断是否结束
+type GetInstanceStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"` // provider_id，用于鉴权
+	InstanceId    string                 `protobuf:"bytes,2,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"` // component 实例 ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInstanceStatusRequest) Reset() {
+	*x = GetInstanceStatusRequest{}
+	mi := &file_resource_provider_provider_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInstanceStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInstanceStatusRequest) ProtoMessage() {}
+
+func (x *GetInstanceStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInstanceStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetInstanceStatusRequest) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GetInstanceStatusRequest) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+func (x *GetInstanceStatusRequest) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+type GetInstanceStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         string                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`                        // pending / running / exited / not_found
+	ExitCode      int32                  `protobuf:"varint,2,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"` // state 为 exited 时有效
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`                      // 退出原因（如 OOMKilled），可为空
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInstanceStatusResponse) Reset() {
+	*x = GetInstanceStatusResponse{}
+	mi := &file_resource_provider_provider_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInstanceStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInstanceStatusResponse) ProtoMessage() {}
+
+func (x *GetInstanceStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInstanceStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetInstanceStatusResponse) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetInstanceStatusResponse) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *GetInstanceStatusResponse) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *GetInstanceStatusResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *GetInstanceStatusResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// GetComponentUsageRequest 查询各 component 实例的实时资源使用情况，用于自动扩缩容与资源规格调整
+type GetComponentUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`    // provider_id，用于鉴权
+	InstanceIds   []string               `protobuf:"bytes,2,rep,name=instance_ids,json=instanceIds,proto3" json:"instance_ids,omitempty"` // 只查询指定的 component 实例，为空时返回该 provider 部署的全部实例
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetComponentUsageRequest) Reset() {
+	*x = GetComponentUsageRequest{}
+	mi := &file_resource_provider_provider_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetComponentUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetComponentUsageRequest) ProtoMessage() {}
+
+func (x *GetComponentUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetComponentUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetComponentUsageRequest) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetComponentUsageRequest) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+func (x *GetComponentUsageRequest) GetInstanceIds() []string {
+	if x != nil {
+		return x.InstanceIds
+	}
+	return nil
+}
+
+// ComponentUsage 单个 component 实例的资源使用情况
+type ComponentUsage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InstanceId    string                 `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`     // component 实例 ID
+	Usage         *resource.Info         `protobuf:"bytes,2,opt,name=usage,proto3" json:"usage,omitempty"`                                 // 实时使用量（CPU 毫核、内存 bytes、GPU）
+	Limit         *resource.Info         `protobuf:"bytes,3,opt,name=limit,proto3" json:"limit,omitempty"`                                 // 部署时分配的资源上限，未知时不设置
+	MemoryRss     int64                  `protobuf:"varint,4,opt,name=memory_rss,json=memoryRss,proto3" json:"memory_rss,omitempty"`       // 常驻内存（bytes），不含页缓存；无法获取时为 0
+	MemoryCache   int64                  `protobuf:"varint,5,opt,name=memory_cache,json=memoryCache,proto3" json:"memory_cache,omitempty"` // 页缓存（bytes）；无法获取时为 0
+	Timestamp     int64                  `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                        // 采样时间（Unix 毫秒）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComponentUsage) Reset() {
+	*x = ComponentUsage{}
+	mi := &file_resource_provider_provider_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComponentUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComponentUsage) ProtoMessage() {}
+
+func (x *ComponentUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComponentUsage.ProtoReflect.Descriptor instead.
+func (*ComponentUsage) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ComponentUsage) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *ComponentUsage) GetUsage() *resource.Info {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+func (x *ComponentUsage) GetLimit() *resource.Info {
+	if x != nil {
+		return x.Limit
+	}
+	return nil
+}
+
+func (x *ComponentUsage) GetMemoryRss() int64 {
+	if x != nil {
+		return x.MemoryRss
+	}
+	return 0
+}
+
+func (x *ComponentUsage) GetMemoryCache() int64 {
+	if x != nil {
+		return x.MemoryCache
+	}
+	return 0
+}
+
+func (x *ComponentUsage) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type GetComponentUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Components    []*ComponentUsage      `protobuf:"bytes,1,rep,name=components,proto3" json:"components,omitempty"` // 未运行或已不存在的实例不返回
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetComponentUsageResponse) Reset() {
+	*x = GetComponentUsageResponse{}
+	mi := &file_resource_provider_provider_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetComponentUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetComponentUsageResponse) ProtoMessage() {}
+
+func (x *GetComponentUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_provider_provider_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetComponentUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetComponentUsageResponse) Descriptor() ([]byte, []int) {
+	return file_resource_provider_provider_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetComponentUsageResponse) GetComponents() []*ComponentUsage {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *GetComponentUsageResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+var File_resource_provider_provider_proto protoreflect.FileDescriptor
+
+const file_resource_provider_provider_proto_rawDesc = "" +
+	"\n" +
+	" resource/provider/provider.proto\x12\bprovider\x1a\x17resource/resource.proto\x1a\x15common/protocol.proto\x1a\x12common/types.proto\"\"\n" +
+	"\fProviderType\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"y\n" +
+	"\x0eConnectRequest\x12\x1f\n" +
+	"\vprovider_id\x18\x01 \x01(\tR\n" +
+	"providerId\x120\n" +
+	"\bprotocol\x18\x02 \x01(\v2\x14.common.ProtocolInfoR\bprotocol\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\"\x86\x02\n" +
+	"\x0fConnectResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12;\n" +
+	"\rprovider_type\x18\x03 \x01(\v2\x16.provider.ProviderTypeR\fproviderType\x120\n" +
+	"\bprotocol\x18\x04 \x01(\v2\x14.common.ProtocolInfoR\bprotocol\x12$\n" +
+	"\rarchitectures\x18\x05 \x03(\tR\rarchitectures\x12.\n" +
+	"\bcapacity\x18\x06 \x01(\v2\x12.resource.CapacityR\bcapacity\"5\n" +
 	"\x12GetCapacityRequest\x12\x1f\n" +
 	"\vprovider_id\x18\x01 \x01(\tR\n" +
 	"providerId\"E\n" +
@@ -822,7 +3139,7 @@ const file_resource_provider_provider_proto_rawDesc = "" +
 	"\vprovider_id\x18\x01 \x01(\tR\n" +
 	"providerId\"D\n" +
 	"\x14GetAvailableResponse\x12,\n" +
-	"\tavailable\x18\x01 \x01(\v2\x0e.resource.InfoR\tavailable\"\x9f\x02\n" +
+	"\tavailable\x18\x01 \x01(\v2\x0e.resource.InfoR\tavailable\"\x87\x05\n" +
 	"\rDeployRequest\x12\x1f\n" +
 	"\vinstance_id\x18\x01 \x01(\tR\n" +
 	"instanceId\x12\x14\n" +
@@ -830,12 +3147,53 @@ const file_resource_provider_provider_proto_rawDesc = "" +
 	"\x10resource_request\x18\x03 \x01(\v2\x0e.resource.InfoR\x0fresourceRequest\x12?\n" +
 	"\benv_vars\x18\x04 \x03(\v2$.provider.DeployRequest.EnvVarsEntryR\aenvVars\x12\x1f\n" +
 	"\vprovider_id\x18\x05 \x01(\tR\n" +
-	"providerId\x1a:\n" +
+	"providerId\x12;\n" +
+	"\regress_policy\x18\x06 \x01(\v2\x16.provider.EgressPolicyR\fegressPolicy\x12$\n" +
+	"\rarchitectures\x18\a \x03(\tR\rarchitectures\x125\n" +
+	"\fdata_sources\x18\b \x03(\v2\x12.common.DataSourceR\vdataSources\x12,\n" +
+	"\x12data_store_address\x18\t \x01(\tR\x10dataStoreAddress\x12-\n" +
+	"\avolumes\x18\n" +
+	" \x03(\v2\x13.common.VolumeMountR\avolumes\x12B\n" +
+	"\x10security_context\x18\v \x01(\v2\x17.common.SecurityContextR\x0fsecurityContext\x12+\n" +
+	"\bsidecars\x18\f \x03(\v2\x0f.common.SidecarR\bsidecars\x1a:\n" +
 	"\fEnvVarsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"&\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"^\n" +
+	"\n" +
+	"EgressRule\x12 \n" +
+	"\vdestination\x18\x01 \x01(\tR\vdestination\x12\x12\n" +
+	"\x04port\x18\x02 \x01(\x05R\x04port\x12\x1a\n" +
+	"\bprotocol\x18\x03 \x01(\tR\bprotocol\"T\n" +
+	"\fEgressPolicy\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12*\n" +
+	"\x05allow\x18\x02 \x03(\v2\x14.provider.EgressRuleR\x05allow\"G\n" +
 	"\x0eDeployResponse\x12\x14\n" +
-	"\x05error\x18\x01 \x01(\tR\x05error\"5\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\x12\x1f\n" +
+	"\vno_capacity\x18\x02 \x01(\bR\n" +
+	"noCapacity\"S\n" +
+	"\x0fUndeployRequest\x12\x1f\n" +
+	"\vprovider_id\x18\x01 \x01(\tR\n" +
+	"providerId\x12\x1f\n" +
+	"\vinstance_id\x18\x02 \x01(\tR\n" +
+	"instanceId\"(\n" +
+	"\x10UndeployResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\"3\n" +
+	"\x10BenchmarkRequest\x12\x1f\n" +
+	"\vprovider_id\x18\x01 \x01(\tR\n" +
+	"providerId\"\x8f\x02\n" +
+	"\x0fBenchmarkResult\x12\x1b\n" +
+	"\tcpu_score\x18\x01 \x01(\x01R\bcpuScore\x122\n" +
+	"\x15memory_bandwidth_mbps\x18\x02 \x01(\x01R\x13memoryBandwidthMbps\x12&\n" +
+	"\x0fdisk_write_mbps\x18\x03 \x01(\x01R\rdiskWriteMbps\x12$\n" +
+	"\x0edisk_read_mbps\x18\x04 \x01(\x01R\fdiskReadMbps\x12\x1f\n" +
+	"\vgpu_present\x18\x05 \x01(\bR\n" +
+	"gpuPresent\x12\x1b\n" +
+	"\tgpu_count\x18\x06 \x01(\x05R\bgpuCount\x12\x1f\n" +
+	"\vduration_ms\x18\a \x01(\x03R\n" +
+	"durationMs\"\\\n" +
+	"\x11BenchmarkResponse\x121\n" +
+	"\x06result\x18\x01 \x01(\v2\x19.provider.BenchmarkResultR\x06result\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"5\n" +
 	"\x12HealthCheckRequest\x12\x1f\n" +
 	"\vprovider_id\x18\x01 \x01(\tR\n" +
 	"providerId\"b\n" +
@@ -843,10 +3201,29 @@ const file_resource_provider_provider_proto_rawDesc = "" +
 	"\x03cpu\x18\x01 \x01(\bR\x03cpu\x12\x10\n" +
 	"\x03gpu\x18\x02 \x01(\bR\x03gpu\x12\x16\n" +
 	"\x06memory\x18\x03 \x01(\bR\x06memory\x12\x16\n" +
-	"\x06camera\x18\x04 \x01(\bR\x06camera\"\x82\x01\n" +
+	"\x06camera\x18\x04 \x01(\bR\x06camera\"^\n" +
+	"\rEnergyProfile\x12$\n" +
+	"\x0ewatts_per_core\x18\x01 \x01(\x01R\fwattsPerCore\x12'\n" +
+	"\x0fbattery_powered\x18\x02 \x01(\bR\x0ebatteryPowered\"\xa1\x01\n" +
+	"\tGPUDevice\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\x05R\x05index\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06memory\x18\x04 \x01(\x03R\x06memory\x12!\n" +
+	"\fnvlink_group\x18\x05 \x01(\x05R\vnvlinkGroup\x12\x1f\n" +
+	"\vinstance_id\x18\x06 \x01(\tR\n" +
+	"instanceId\"G\n" +
+	"\vMediaStream\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\"\xc2\x02\n" +
 	"\x13HealthCheckResponse\x12.\n" +
 	"\bcapacity\x18\x01 \x01(\v2\x12.resource.CapacityR\bcapacity\x12;\n" +
-	"\rresource_tags\x18\x02 \x01(\v2\x16.provider.ResourceTagsR\fresourceTags\"4\n" +
+	"\rresource_tags\x18\x02 \x01(\v2\x16.provider.ResourceTagsR\fresourceTags\x12>\n" +
+	"\x0eenergy_profile\x18\x03 \x01(\v2\x17.provider.EnergyProfileR\renergyProfile\x12$\n" +
+	"\rarchitectures\x18\x04 \x03(\tR\rarchitectures\x12'\n" +
+	"\x04gpus\x18\x05 \x03(\v2\x13.provider.GPUDeviceR\x04gpus\x12/\n" +
+	"\astreams\x18\x06 \x03(\v2\x15.provider.MediaStreamR\astreams\"4\n" +
 	"\x11DisconnectRequest\x12\x1f\n" +
 	"\vprovider_id\x18\x01 \x01(\tR\n" +
 	"providerId\"\x14\n" +
@@ -855,16 +3232,148 @@ const file_resource_provider_provider_proto_rawDesc = "" +
 	"\vprovider_id\x18\x01 \x01(\tR\n" +
 	"providerId\"@\n" +
 	"\x18GetRealTimeUsageResponse\x12$\n" +
-	"\x05usage\x18\x01 \x01(\v2\x0e.resource.InfoR\x05usage2\x91\x04\n" +
+	"\x05usage\x18\x01 \x01(\v2\x0e.resource.InfoR\x05usage\"\\\n" +
+	"\x11WatchUsageRequest\x12\x1f\n" +
+	"\vprovider_id\x18\x01 \x01(\tR\n" +
+	"providerId\x12&\n" +
+	"\x0fmin_interval_ms\x18\x02 \x01(\x03R\rminIntervalMs\"c\n" +
+	"\vUsageUpdate\x12$\n" +
+	"\x05usage\x18\x01 \x01(\v2\x0e.resource.InfoR\x05usage\x12.\n" +
+	"\bcapacity\x18\x02 \x01(\v2\x12.resource.CapacityR\bcapacity\"@\n" +
+	"\x12ExportImageRequest\x12\x14\n" +
+	"\x05image\x18\x01 \x01(\tR\x05image\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\" \n" +
+	"\n" +
+	"ImageChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"y\n" +
+	"\tExecStart\x12\x1f\n" +
+	"\vprovider_id\x18\x01 \x01(\tR\n" +
+	"providerId\x12\x1f\n" +
+	"\vinstance_id\x18\x02 \x01(\tR\n" +
+	"instanceId\x12\x18\n" +
+	"\acommand\x18\x03 \x03(\tR\acommand\x12\x10\n" +
+	"\x03tty\x18\x04 \x01(\bR\x03tty\"4\n" +
+	"\n" +
+	"ExecResize\x12\x12\n" +
+	"\x04rows\x18\x01 \x01(\rR\x04rows\x12\x12\n" +
+	"\x04cols\x18\x02 \x01(\rR\x04cols\"\xb0\x01\n" +
+	"\vExecRequest\x12+\n" +
+	"\x05start\x18\x01 \x01(\v2\x13.provider.ExecStartH\x00R\x05start\x12\x16\n" +
+	"\x05stdin\x18\x02 \x01(\fH\x00R\x05stdin\x12.\n" +
+	"\x06resize\x18\x03 \x01(\v2\x14.provider.ExecResizeH\x00R\x06resize\x12!\n" +
+	"\vclose_stdin\x18\x04 \x01(\bH\x00R\n" +
+	"closeStdinB\t\n" +
+	"\apayload\"\x89\x01\n" +
+	"\fExecResponse\x12\x16\n" +
+	"\x06stdout\x18\x01 \x01(\fR\x06stdout\x12\x16\n" +
+	"\x06stderr\x18\x02 \x01(\fR\x06stderr\x12\x16\n" +
+	"\x06exited\x18\x03 \x01(\bR\x06exited\x12\x1b\n" +
+	"\texit_code\x18\x04 \x01(\x05R\bexitCode\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"h\n" +
+	"\x10PortForwardStart\x12\x1f\n" +
+	"\vprovider_id\x18\x01 \x01(\tR\n" +
+	"providerId\x12\x1f\n" +
+	"\vinstance_id\x18\x02 \x01(\tR\n" +
+	"instanceId\x12\x12\n" +
+	"\x04port\x18\x03 \x01(\rR\x04port\"\x8c\x01\n" +
+	"\x12PortForwardRequest\x122\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.provider.PortForwardStartH\x00R\x05start\x12\x14\n" +
+	"\x04data\x18\x02 \x01(\fH\x00R\x04data\x12!\n" +
+	"\vclose_write\x18\x03 \x01(\bH\x00R\n" +
+	"closeWriteB\t\n" +
+	"\apayload\"?\n" +
+	"\x13PortForwardResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"[\n" +
+	"\x17GetStagingStatusRequest\x12\x1f\n" +
+	"\vprovider_id\x18\x01 \x01(\tR\n" +
+	"providerId\x12\x1f\n" +
+	"\vinstance_id\x18\x02 \x01(\tR\n" +
+	"instanceId\"\x91\x01\n" +
+	"\x0fStagingProgress\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12\x1d\n" +
+	"\n" +
+	"bytes_done\x18\x03 \x01(\x03R\tbytesDone\x12\x1f\n" +
+	"\vbytes_total\x18\x04 \x01(\x03R\n" +
+	"bytesTotal\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"a\n" +
+	"\x18GetStagingStatusResponse\x12/\n" +
+	"\x05items\x18\x01 \x03(\v2\x19.provider.StagingProgressR\x05items\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\";\n" +
+	"\x06Volume\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\x03R\tcreatedAt\"J\n" +
+	"\x13CreateVolumeRequest\x12\x1f\n" +
+	"\vprovider_id\x18\x01 \x01(\tR\n" +
+	"providerId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"V\n" +
+	"\x14CreateVolumeResponse\x12(\n" +
+	"\x06volume\x18\x01 \x01(\v2\x10.provider.VolumeR\x06volume\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"5\n" +
+	"\x12ListVolumesRequest\x12\x1f\n" +
+	"\vprovider_id\x18\x01 \x01(\tR\n" +
+	"providerId\"W\n" +
+	"\x13ListVolumesResponse\x12*\n" +
+	"\avolumes\x18\x01 \x03(\v2\x10.provider.VolumeR\avolumes\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"J\n" +
+	"\x13DeleteVolumeRequest\x12\x1f\n" +
+	"\vprovider_id\x18\x01 \x01(\tR\n" +
+	"providerId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\",\n" +
+	"\x14DeleteVolumeResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\"\\\n" +
+	"\x18GetInstanceStatusRequest\x12\x1f\n" +
+	"\vprovider_id\x18\x01 \x01(\tR\n" +
+	"providerId\x12\x1f\n" +
+	"\vinstance_id\x18\x02 \x01(\tR\n" +
+	"instanceId\"|\n" +
+	"\x19GetInstanceStatusResponse\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\tR\x05state\x12\x1b\n" +
+	"\texit_code\x18\x02 \x01(\x05R\bexitCode\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"^\n" +
+	"\x18GetComponentUsageRequest\x12\x1f\n" +
+	"\vprovider_id\x18\x01 \x01(\tR\n" +
+	"providerId\x12!\n" +
+	"\finstance_ids\x18\x02 \x03(\tR\vinstanceIds\"\xdd\x01\n" +
+	"\x0eComponentUsage\x12\x1f\n" +
+	"\vinstance_id\x18\x01 \x01(\tR\n" +
+	"instanceId\x12$\n" +
+	"\x05usage\x18\x02 \x01(\v2\x0e.resource.InfoR\x05usage\x12$\n" +
+	"\x05limit\x18\x03 \x01(\v2\x0e.resource.InfoR\x05limit\x12\x1d\n" +
+	"\n" +
+	"memory_rss\x18\x04 \x01(\x03R\tmemoryRss\x12!\n" +
+	"\fmemory_cache\x18\x05 \x01(\x03R\vmemoryCache\x12\x1c\n" +
+	"\ttimestamp\x18\x06 \x01(\x03R\ttimestamp\"k\n" +
+	"\x19GetComponentUsageResponse\x128\n" +
+	"\n" +
+	"components\x18\x01 \x03(\v2\x18.provider.ComponentUsageR\n" +
+	"components\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error2\xaf\v\n" +
 	"\aService\x12>\n" +
 	"\aConnect\x12\x18.provider.ConnectRequest\x1a\x19.provider.ConnectResponse\x12G\n" +
 	"\n" +
 	"Disconnect\x12\x1b.provider.DisconnectRequest\x1a\x1c.provider.DisconnectResponse\x12J\n" +
 	"\vGetCapacity\x12\x1c.provider.GetCapacityRequest\x1a\x1d.provider.GetCapacityResponse\x12M\n" +
 	"\fGetAvailable\x12\x1d.provider.GetAvailableRequest\x1a\x1e.provider.GetAvailableResponse\x12;\n" +
-	"\x06Deploy\x12\x17.provider.DeployRequest\x1a\x18.provider.DeployResponse\x12J\n" +
-	"\vHealthCheck\x12\x1c.provider.HealthCheckRequest\x1a\x1d.provider.HealthCheckResponse\x12Y\n" +
-	"\x10GetRealTimeUsage\x12!.provider.GetRealTimeUsageRequest\x1a\".provider.GetRealTimeUsageResponseB<Z:github.com/9triver/iarnet/internal/proto/resource/providerb\x06proto3"
+	"\x06Deploy\x12\x17.provider.DeployRequest\x1a\x18.provider.DeployResponse\x12A\n" +
+	"\bUndeploy\x12\x19.provider.UndeployRequest\x1a\x1a.provider.UndeployResponse\x12J\n" +
+	"\vHealthCheck\x12\x1c.provider.HealthCheckRequest\x1a\x1d.provider.HealthCheckResponse\x12D\n" +
+	"\tBenchmark\x12\x1a.provider.BenchmarkRequest\x1a\x1b.provider.BenchmarkResponse\x12Y\n" +
+	"\x10GetRealTimeUsage\x12!.provider.GetRealTimeUsageRequest\x1a\".provider.GetRealTimeUsageResponse\x12B\n" +
+	"\n" +
+	"WatchUsage\x12\x1b.provider.WatchUsageRequest\x1a\x15.provider.UsageUpdate0\x01\x12C\n" +
+	"\vExportImage\x12\x1c.provider.ExportImageRequest\x1a\x14.provider.ImageChunk0\x01\x129\n" +
+	"\x04Exec\x12\x15.provider.ExecRequest\x1a\x16.provider.ExecResponse(\x010\x01\x12N\n" +
+	"\vPortForward\x12\x1c.provider.PortForwardRequest\x1a\x1d.provider.PortForwardResponse(\x010\x01\x12Y\n" +
+	"\x10GetStagingStatus\x12!.provider.GetStagingStatusRequest\x1a\".provider.GetStagingStatusResponse\x12M\n" +
+	"\fCreateVolume\x12\x1d.provider.CreateVolumeRequest\x1a\x1e.provider.CreateVolumeResponse\x12J\n" +
+	"\vListVolumes\x12\x1c.provider.ListVolumesRequest\x1a\x1d.provider.ListVolumesResponse\x12M\n" +
+	"\fDeleteVolume\x12\x1d.provider.DeleteVolumeRequest\x1a\x1e.provider.DeleteVolumeResponse\x12\\\n" +
+	"\x11GetInstanceStatus\x12\".provider.GetInstanceStatusRequest\x1a#.provider.GetInstanceStatusResponse\x12\\\n" +
+	"\x11GetComponentUsage\x12\".provider.GetComponentUsageRequest\x1a#.provider.GetComponentUsageResponseB<Z:github.com/9triver/iarnet/internal/proto/resource/providerb\x06proto3"
 
 var (
 	file_resource_provider_provider_proto_rawDescOnce sync.Once
@@ -878,56 +3387,145 @@ func file_resource_provider_provider_proto_rawDescGZIP() []byte {
 	return file_resource_provider_provider_proto_rawDescData
 }
 
-var file_resource_provider_provider_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_resource_provider_provider_proto_msgTypes = make([]protoimpl.MessageInfo, 53)
 var file_resource_provider_provider_proto_goTypes = []any{
-	(*ProviderType)(nil),             // 0: provider.ProviderType
-	(*ConnectRequest)(nil),           // 1: provider.ConnectRequest
-	(*ConnectResponse)(nil),          // 2: provider.ConnectResponse
-	(*GetCapacityRequest)(nil),       // 3: provider.GetCapacityRequest
-	(*GetCapacityResponse)(nil),      // 4: provider.GetCapacityResponse
-	(*GetAvailableRequest)(nil),      // 5: provider.GetAvailableRequest
-	(*GetAvailableResponse)(nil),     // 6: provider.GetAvailableResponse
-	(*DeployRequest)(nil),            // 7: provider.DeployRequest
-	(*DeployResponse)(nil),           // 8: provider.DeployResponse
-	(*HealthCheckRequest)(nil),       // 9: provider.HealthCheckRequest
-	(*ResourceTags)(nil),             // 10: provider.ResourceTags
-	(*HealthCheckResponse)(nil),      // 11: provider.HealthCheckResponse
-	(*DisconnectRequest)(nil),        // 12: provider.DisconnectRequest
-	(*DisconnectResponse)(nil),       // 13: provider.DisconnectResponse
-	(*GetRealTimeUsageRequest)(nil),  // 14: provider.GetRealTimeUsageRequest
-	(*GetRealTimeUsageResponse)(nil), // 15: provider.GetRealTimeUsageResponse
-	nil,                              // 16: provider.DeployRequest.EnvVarsEntry
-	(*resource.Capacity)(nil),        // 17: resource.Capacity
-	(*resource.Info)(nil),            // 18: resource.Info
+	(*ProviderType)(nil),              // 0: provider.ProviderType
+	(*ConnectRequest)(nil),            // 1: provider.ConnectRequest
+	(*ConnectResponse)(nil),           // 2: provider.ConnectResponse
+	(*GetCapacityRequest)(nil),        // 3: provider.GetCapacityRequest
+	(*GetCapacityResponse)(nil),       // 4: provider.GetCapacityResponse
+	(*GetAvailableRequest)(nil),       // 5: provider.GetAvailableRequest
+	(*GetAvailableResponse)(nil),      // 6: provider.GetAvailableResponse
+	(*DeployRequest)(nil),             // 7: provider.DeployRequest
+	(*EgressRule)(nil),                // 8: provider.EgressRule
+	(*EgressPolicy)(nil),              // 9: provider.EgressPolicy
+	(*DeployResponse)(nil),            // 10: provider.DeployResponse
+	(*UndeployRequest)(nil),           // 11: provider.UndeployRequest
+	(*UndeployResponse)(nil),          // 12: provider.UndeployResponse
+	(*BenchmarkRequest)(nil),          // 13: provider.BenchmarkRequest
+	(*BenchmarkResult)(nil),           // 14: provider.BenchmarkResult
+	(*BenchmarkResponse)(nil),         // 15: provider.BenchmarkResponse
+	(*HealthCheckRequest)(nil),        // 16: provider.HealthCheckRequest
+	(*ResourceTags)(nil),              // 17: provider.ResourceTags
+	(*EnergyProfile)(nil),             // 18: provider.EnergyProfile
+	(*GPUDevice)(nil),                 // 19: provider.GPUDevice
+	(*MediaStream)(nil),               // 20: provider.MediaStream
+	(*HealthCheckResponse)(nil),       // 21: provider.HealthCheckResponse
+	(*DisconnectRequest)(nil),         // 22: provider.DisconnectRequest
+	(*DisconnectResponse)(nil),        // 23: provider.DisconnectResponse
+	(*GetRealTimeUsageRequest)(nil),   // 24: provider.GetRealTimeUsageRequest
+	(*GetRealTimeUsageResponse)(nil),  // 25: provider.GetRealTimeUsageResponse
+	(*WatchUsageRequest)(nil),         // 26: provider.WatchUsageRequest
+	(*UsageUpdate)(nil),               // 27: provider.UsageUpdate
+	(*ExportImageRequest)(nil),        // 28: provider.ExportImageRequest
+	(*ImageChunk)(nil),                // 29: provider.ImageChunk
+	(*ExecStart)(nil),                 // 30: provider.ExecStart
+	(*ExecResize)(nil),                // 31: provider.ExecResize
+	(*ExecRequest)(nil),               // 32: provider.ExecRequest
+	(*ExecResponse)(nil),              // 33: provider.ExecResponse
+	(*PortForwardStart)(nil),          // 34: provider.PortForwardStart
+	(*PortForwardRequest)(nil),        // 35: provider.PortForwardRequest
+	(*PortForwardResponse)(nil),       // 36: provider.PortForwardResponse
+	(*GetStagingStatusRequest)(nil),   // 37: provider.GetStagingStatusRequest
+	(*StagingProgress)(nil),           // 38: provider.StagingProgress
+	(*GetStagingStatusResponse)(nil),  // 39: provider.GetStagingStatusResponse
+	(*Volume)(nil),                    // 40: provider.Volume
+	(*CreateVolumeRequest)(nil),       // 41: provider.CreateVolumeRequest
+	(*CreateVolumeResponse)(nil),      // 42: provider.CreateVolumeResponse
+	(*ListVolumesRequest)(nil),        // 43: provider.ListVolumesRequest
+	(*ListVolumesResponse)(nil),       // 44: provider.ListVolumesResponse
+	(*DeleteVolumeRequest)(nil),       // 45: provider.DeleteVolumeRequest
+	(*DeleteVolumeResponse)(nil),      // 46: provider.DeleteVolumeResponse
+	(*GetInstanceStatusRequest)(nil),  // 47: provider.GetInstanceStatusRequest
+	(*GetInstanceStatusResponse)(nil), // 48: provider.GetInstanceStatusResponse
+	(*GetComponentUsageRequest)(nil),  // 49: provider.GetComponentUsageRequest
+	(*ComponentUsage)(nil),            // 50: provider.ComponentUsage
+	(*GetComponentUsageResponse)(nil), // 51: provider.GetComponentUsageResponse
+	nil,                               // 52: provider.DeployRequest.EnvVarsEntry
+	(*common.ProtocolInfo)(nil),       // 53: common.ProtocolInfo
+	(*resource.Capacity)(nil),         // 54: resource.Capacity
+	(*resource.Info)(nil),             // 55: resource.Info
+	(*common.DataSource)(nil),         // 56: common.DataSource
+	(*common.VolumeMount)(nil),        // 57: common.VolumeMount
+	(*common.SecurityContext)(nil),    // 58: common.SecurityContext
+	(*common.Sidecar)(nil),            // 59: common.Sidecar
 }
 var file_resource_provider_provider_proto_depIdxs = []int32{
-	0,  // 0: provider.ConnectResponse.provider_type:type_name -> provider.ProviderType
-	17, // 1: provider.GetCapacityResponse.capacity:type_name -> resource.Capacity
-	18, // 2: provider.GetAvailableResponse.available:type_name -> resource.Info
-	18, // 3: provider.DeployRequest.resource_request:type_name -> resource.Info
-	16, // 4: provider.DeployRequest.env_vars:type_name -> provider.DeployRequest.EnvVarsEntry
-	17, // 5: provider.HealthCheckResponse.capacity:type_name -> resource.Capacity
-	10, // 6: provider.HealthCheckResponse.resource_tags:type_name -> provider.ResourceTags
-	18, // 7: provider.GetRealTimeUsageResponse.usage:type_name -> resource.Info
-	1,  // 8: provider.Service.Connect:input_type -> provider.ConnectRequest
-	12, // 9: provider.Service.Disconnect:input_type -> provider.DisconnectRequest
-	3,  // 10: provider.Service.GetCapacity:input_type -> provider.GetCapacityRequest
-	5,  // 11: provider.Service.GetAvailable:input_type -> provider.GetAvailableRequest
-	7,  // 12: provider.Service.Deploy:input_type -> provider.DeployRequest
-	9,  // 13: provider.Service.HealthCheck:input_type -> provider.HealthCheckRequest
-	14, // 14: provider.Service.GetRealTimeUsage:input_type -> provider.GetRealTimeUsageRequest
-	2,  // 15: provider.Service.Connect:output_type -> provider.ConnectResponse
-	13, // 16: provider.Service.Disconnect:output_type -> provider.DisconnectResponse
-	4,  // 17: provider.Service.GetCapacity:output_type -> provider.GetCapacityResponse
-	6,  // 18: provider.Service.GetAvailable:output_type -> provider.GetAvailableResponse
-	8,  // 19: provider.Service.Deploy:output_type -> provider.DeployResponse
-	11, // 20: provider.Service.HealthCheck:output_type -> provider.HealthCheckResponse
-	15, // 21: provider.Service.GetRealTimeUsage:output_type -> provider.GetRealTimeUsageResponse
-	15, // [15:22] is the sub-list for method output_type
-	8,  // [8:15] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	53, // 0: provider.ConnectRequest.protocol:type_name -> common.ProtocolInfo
+	0,  // 1: provider.ConnectResponse.provider_type:type_name -> provider.ProviderType
+	53, // 2: provider.ConnectResponse.protocol:type_name -> common.ProtocolInfo
+	54, // 3: provider.ConnectResponse.capacity:type_name -> resource.Capacity
+	54, // 4: provider.GetCapacityResponse.capacity:type_name -> resource.Capacity
+	55, // 5: provider.GetAvailableResponse.available:type_name -> resource.Info
+	55, // 6: provider.DeployRequest.resource_request:type_name -> resource.Info
+	52, // 7: provider.DeployRequest.env_vars:type_name -> provider.DeployRequest.EnvVarsEntry
+	9,  // 8: provider.DeployRequest.egress_policy:type_name -> provider.EgressPolicy
+	56, // 9: provider.DeployRequest.data_sources:type_name -> common.DataSource
+	57, // 10: provider.DeployRequest.volumes:type_name -> common.VolumeMount
+	58, // 11: provider.DeployRequest.security_context:type_name -> common.SecurityContext
+	59, // 12: provider.DeployRequest.sidecars:type_name -> common.Sidecar
+	8,  // 13: provider.EgressPolicy.allow:type_name -> provider.EgressRule
+	14, // 14: provider.BenchmarkResponse.result:type_name -> provider.BenchmarkResult
+	54, // 15: provider.HealthCheckResponse.capacity:type_name -> resource.Capacity
+	17, // 16: provider.HealthCheckResponse.resource_tags:type_name -> provider.ResourceTags
+	18, // 17: provider.HealthCheckResponse.energy_profile:type_name -> provider.EnergyProfile
+	19, // 18: provider.HealthCheckResponse.gpus:type_name -> provider.GPUDevice
+	20, // 19: provider.HealthCheckResponse.streams:type_name -> provider.MediaStream
+	55, // 20: provider.GetRealTimeUsageResponse.usage:type_name -> resource.Info
+	55, // 21: provider.UsageUpdate.usage:type_name -> resource.Info
+	54, // 22: provider.UsageUpdate.capacity:type_name -> resource.Capacity
+	30, // 23: provider.ExecRequest.start:type_name -> provider.ExecStart
+	31, // 24: provider.ExecRequest.resize:type_name -> provider.ExecResize
+	34, // 25: provider.PortForwardRequest.start:type_name -> provider.PortForwardStart
+	38, // 26: provider.GetStagingStatusResponse.items:type_name -> provider.StagingProgress
+	40, // 27: provider.CreateVolumeResponse.volume:type_name -> provider.Volume
+	40, // 28: provider.ListVolumesResponse.volumes:type_name -> provider.Volume
+	55, // 29: provider.ComponentUsage.usage:type_name -> resource.Info
+	55, // 30: provider.ComponentUsage.limit:type_name -> resource.Info
+	50, // 31: provider.GetComponentUsageResponse.components:type_name -> provider.ComponentUsage
+	1,  // 32: provider.Service.Connect:input_type -> provider.ConnectRequest
+	22, // 33: provider.Service.Disconnect:input_type -> provider.DisconnectRequest
+	3,  // 34: provider.Service.GetCapacity:input_type -> provider.GetCapacityRequest
+	5,  // 35: provider.Service.GetAvailable:input_type -> provider.GetAvailableRequest
+	7,  // 36: provider.Service.Deploy:input_type -> provider.DeployRequest
+	11, // 37: provider.Service.Undeploy:input_type -> provider.UndeployRequest
+	16, // 38: provider.Service.HealthCheck:input_type -> provider.HealthCheckRequest
+	13, // 39: provider.Service.Benchmark:input_type -> provider.BenchmarkRequest
+	24, // 40: provider.Service.GetRealTimeUsage:input_type -> provider.GetRealTimeUsageRequest
+	26, // 41: provider.Service.WatchUsage:input_type -> provider.WatchUsageRequest
+	28, // 42: provider.Service.ExportImage:input_type -> provider.ExportImageRequest
+	32, // 43: provider.Service.Exec:input_type -> provider.ExecRequest
+	35, // 44: provider.Service.PortForward:input_type -> provider.PortForwardRequest
+	37, // 45: provider.Service.GetStagingStatus:input_type -> provider.GetStagingStatusRequest
+	41, // 46: provider.Service.CreateVolume:input_type -> provider.CreateVolumeRequest
+	43, // 47: provider.Service.ListVolumes:input_type -> provider.ListVolumesRequest
+	45, // 48: provider.Service.DeleteVolume:input_type -> provider.DeleteVolumeRequest
+	47, // 49: provider.Service.GetInstanceStatus:input_type -> provider.GetInstanceStatusRequest
+	49, // 50: provider.Service.GetComponentUsage:input_type -> provider.GetComponentUsageRequest
+	2,  // 51: provider.Service.Connect:output_type -> provider.ConnectResponse
+	23, // 52: provider.Service.Disconnect:output_type -> provider.DisconnectResponse
+	4,  // 53: provider.Service.GetCapacity:output_type -> provider.GetCapacityResponse
+	6,  // 54: provider.Service.GetAvailable:output_type -> provider.GetAvailableResponse
+	10, // 55: provider.Service.Deploy:output_type -> provider.DeployResponse
+	12, // 56: provider.Service.Undeploy:output_type -> provider.UndeployResponse
+	21, // 57: provider.Service.HealthCheck:output_type -> provider.HealthCheckResponse
+	15, // 58: provider.Service.Benchmark:output_type -> provider.BenchmarkResponse
+	25, // 59: provider.Service.GetRealTimeUsage:output_type -> provider.GetRealTimeUsageResponse
+	27, // 60: provider.Service.WatchUsage:output_type -> provider.UsageUpdate
+	29, // 61: provider.Service.ExportImage:output_type -> provider.ImageChunk
+	33, // 62: provider.Service.Exec:output_type -> provider.ExecResponse
+	36, // 63: provider.Service.PortForward:output_type -> provider.PortForwardResponse
+	39, // 64: provider.Service.GetStagingStatus:output_type -> provider.GetStagingStatusResponse
+	42, // 65: provider.Service.CreateVolume:output_type -> provider.CreateVolumeResponse
+	44, // 66: provider.Service.ListVolumes:output_type -> provider.ListVolumesResponse
+	46, // 67: provider.Service.DeleteVolume:output_type -> provider.DeleteVolumeResponse
+	48, // 68: provider.Service.GetInstanceStatus:output_type -> provider.GetInstanceStatusResponse
+	51, // 69: provider.Service.GetComponentUsage:output_type -> provider.GetComponentUsageResponse
+	51, // [51:70] is the sub-list for method output_type
+	32, // [32:51] is the sub-list for method input_type
+	32, // [32:32] is the sub-list for extension type_name
+	32, // [32:32] is the sub-list for extension extendee
+	0,  // [0:32] is the sub-list for field type_name
 }
 
 func init() { file_resource_provider_provider_proto_init() }
@@ -935,13 +3533,24 @@ func file_resource_provider_provider_proto_init() {
 	if File_resource_provider_provider_proto != nil {
 		return
 	}
+	file_resource_provider_provider_proto_msgTypes[32].OneofWrappers = []any{
+		(*ExecRequest_Start)(nil),
+		(*ExecRequest_Stdin)(nil),
+		(*ExecRequest_Resize)(nil),
+		(*ExecRequest_CloseStdin)(nil),
+	}
+	file_resource_provider_provider_proto_msgTypes[35].OneofWrappers = []any{
+		(*PortForwardRequest_Start)(nil),
+		(*PortForwardRequest_Data)(nil),
+		(*PortForwardRequest_CloseWrite)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_resource_provider_provider_proto_rawDesc), len(file_resource_provider_provider_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   17,
+			NumMessages:   53,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
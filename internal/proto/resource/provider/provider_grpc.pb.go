@@ -19,13 +19,25 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Service_Connect_FullMethodName          = "/provider.Service/Connect"
-	Service_Disconnect_FullMethodName       = "/provider.Service/Disconnect"
-	Service_GetCapacity_FullMethodName      = "/provider.Service/GetCapacity"
-	Service_GetAvailable_FullMethodName     = "/provider.Service/GetAvailable"
-	Service_Deploy_FullMethodName           = "/provider.Service/Deploy"
-	Service_HealthCheck_FullMethodName      = "/provider.Service/HealthCheck"
-	Service_GetRealTimeUsage_FullMethodName = "/provider.Service/GetRealTimeUsage"
+	Service_Connect_FullMethodName           = "/provider.Service/Connect"
+	Service_Disconnect_FullMethodName        = "/provider.Service/Disconnect"
+	Service_GetCapacity_FullMethodName       = "/provider.Service/GetCapacity"
+	Service_GetAvailable_FullMethodName      = "/provider.Service/GetAvailable"
+	Service_Deploy_FullMethodName            = "/provider.Service/Deploy"
+	Service_Undeploy_FullMethodName          = "/provider.Service/Undeploy"
+	Service_HealthCheck_FullMethodName       = "/provider.Service/HealthCheck"
+	Service_Benchmark_FullMethodName         = "/provider.Service/Benchmark"
+	Service_GetRealTimeUsage_FullMethodName  = "/provider.Service/GetRealTimeUsage"
+	Service_WatchUsage_FullMethodName        = "/provider.Service/WatchUsage"
+	Service_ExportImage_FullMethodName       = "/provider.Service/ExportImage"
+	Service_Exec_FullMethodName              = "/provider.Service/Exec"
+	Service_PortForward_FullMethodName       = "/provider.Service/PortForward"
+	Service_GetStagingStatus_FullMethodName  = "/provider.Service/GetStagingStatus"
+	Service_CreateVolume_FullMethodName      = "/provider.Service/CreateVolume"
+	Service_ListVolumes_FullMethodName       = "/provider.Service/ListVolumes"
+	Service_DeleteVolume_FullMethodName      = "/provider.Service/DeleteVolume"
+	Service_GetInstanceStatus_FullMethodName = "/provider.Service/GetInstanceStatus"
+	Service_GetComponentUsage_FullMethodName = "/provider.Service/GetComponentUsage"
 )
 
 // ServiceClient is the client API for Service service.
@@ -37,8 +49,20 @@ type ServiceClient interface {
 	GetCapacity(ctx context.Context, in *GetCapacityRequest, opts ...grpc.CallOption) (*GetCapacityResponse, error)
 	GetAvailable(ctx context.Context, in *GetAvailableRequest, opts ...grpc.CallOption) (*GetAvailableResponse, error)
 	Deploy(ctx context.Context, in *DeployRequest, opts ...grpc.CallOption) (*DeployResponse, error)
+	Undeploy(ctx context.Context, in *UndeployRequest, opts ...grpc.CallOption) (*UndeployResponse, error)
 	HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error)
+	Benchmark(ctx context.Context, in *BenchmarkRequest, opts ...grpc.CallOption) (*BenchmarkResponse, error)
 	GetRealTimeUsage(ctx context.Context, in *GetRealTimeUsageRequest, opts ...grpc.CallOption) (*GetRealTimeUsageResponse, error)
+	WatchUsage(ctx context.Context, in *WatchUsageRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[UsageUpdate], error)
+	ExportImage(ctx context.Context, in *ExportImageRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ImageChunk], error)
+	Exec(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ExecRequest, ExecResponse], error)
+	PortForward(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[PortForwardRequest, PortForwardResponse], error)
+	GetStagingStatus(ctx context.Context, in *GetStagingStatusRequest, opts ...grpc.CallOption) (*GetStagingStatusResponse, error)
+	CreateVolume(ctx context.Context, in *CreateVolumeRequest, opts ...grpc.CallOption) (*CreateVolumeResponse, error)
+	ListVolumes(ctx context.Context, in *ListVolumesRequest, opts ...grpc.CallOption) (*ListVolumesResponse, error)
+	DeleteVolume(ctx context.Context, in *DeleteVolumeRequest, opts ...grpc.CallOption) (*DeleteVolumeResponse, error)
+	GetInstanceStatus(ctx context.Context, in *GetInstanceStatusRequest, opts ...grpc.CallOption) (*GetInstanceStatusResponse, error)
+	GetComponentUsage(ctx context.Context, in *GetComponentUsageRequest, opts ...grpc.CallOption) (*GetComponentUsageResponse, error)
 }
 
 type serviceClient struct {
@@ -99,6 +123,16 @@ func (c *serviceClient) Deploy(ctx context.Context, in *DeployRequest, opts ...g
 	return out, nil
 }
 
+func (c *serviceClient) Undeploy(ctx context.Context, in *UndeployRequest, opts ...grpc.CallOption) (*UndeployResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UndeployResponse)
+	err := c.cc.Invoke(ctx, Service_Undeploy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *serviceClient) HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(HealthCheckResponse)
@@ -109,6 +143,16 @@ func (c *serviceClient) HealthCheck(ctx context.Context, in *HealthCheckRequest,
 	return out, nil
 }
 
+func (c *serviceClient) Benchmark(ctx context.Context, in *BenchmarkRequest, opts ...grpc.CallOption) (*BenchmarkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BenchmarkResponse)
+	err := c.cc.Invoke(ctx, Service_Benchmark_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *serviceClient) GetRealTimeUsage(ctx context.Context, in *GetRealTimeUsageRequest, opts ...grpc.CallOption) (*GetRealTimeUsageResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetRealTimeUsageResponse)
@@ -119,6 +163,130 @@ func (c *serviceClient) GetRealTimeUsage(ctx context.Context, in *GetRealTimeUsa
 	return out, nil
 }
 
+func (c *serviceClient) WatchUsage(ctx context.Context, in *WatchUsageRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[UsageUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[0], Service_WatchUsage_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchUsageRequest, UsageUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Service_WatchUsageClient = grpc.ServerStreamingClient[UsageUpdate]
+
+func (c *serviceClient) ExportImage(ctx context.Context, in *ExportImageRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ImageChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[1], Service_ExportImage_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportImageRequest, ImageChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Service_ExportImageClient = grpc.ServerStreamingClient[ImageChunk]
+
+func (c *serviceClient) Exec(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ExecRequest, ExecResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[2], Service_Exec_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExecRequest, ExecResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Service_ExecClient = grpc.BidiStreamingClient[ExecRequest, ExecResponse]
+
+func (c *serviceClient) PortForward(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[PortForwardRequest, PortForwardResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[3], Service_PortForward_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[PortForwardRequest, PortForwardResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Service_PortForwardClient = grpc.BidiStreamingClient[PortForwardRequest, PortForwardResponse]
+
+func (c *serviceClient) GetStagingStatus(ctx context.Context, in *GetStagingStatusRequest, opts ...grpc.CallOption) (*GetStagingStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStagingStatusResponse)
+	err := c.cc.Invoke(ctx, Service_GetStagingStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) CreateVolume(ctx context.Context, in *CreateVolumeRequest, opts ...grpc.CallOption) (*CreateVolumeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateVolumeResponse)
+	err := c.cc.Invoke(ctx, Service_CreateVolume_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) ListVolumes(ctx context.Context, in *ListVolumesRequest, opts ...grpc.CallOption) (*ListVolumesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVolumesResponse)
+	err := c.cc.Invoke(ctx, Service_ListVolumes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) DeleteVolume(ctx context.Context, in *DeleteVolumeRequest, opts ...grpc.CallOption) (*DeleteVolumeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteVolumeResponse)
+	err := c.cc.Invoke(ctx, Service_DeleteVolume_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) GetInstanceStatus(ctx context.Context, in *GetInstanceStatusRequest, opts ...grpc.CallOption) (*GetInstanceStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetInstanceStatusResponse)
+	err := c.cc.Invoke(ctx, Service_GetInstanceStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) GetComponentUsage(ctx context.Context, in *GetComponentUsageRequest, opts ...grpc.CallOption) (*GetComponentUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetComponentUsageResponse)
+	err := c.cc.Invoke(ctx, Service_GetComponentUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ServiceServer is the server API for Service service.
 // All implementations must embed UnimplementedServiceServer
 // for forward compatibility.
@@ -128,8 +296,20 @@ type ServiceServer interface {
 	GetCapacity(context.Context, *GetCapacityRequest) (*GetCapacityResponse, error)
 	GetAvailable(context.Context, *GetAvailableRequest) (*GetAvailableResponse, error)
 	Deploy(context.Context, *DeployRequest) (*DeployResponse, error)
+	Undeploy(context.Context, *UndeployRequest) (*UndeployResponse, error)
 	HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error)
+	Benchmark(context.Context, *BenchmarkRequest) (*BenchmarkResponse, error)
 	GetRealTimeUsage(context.Context, *GetRealTimeUsageRequest) (*GetRealTimeUsageResponse, error)
+	WatchUsage(*WatchUsageRequest, grpc.ServerStreamingServer[UsageUpdate]) error
+	ExportImage(*ExportImageRequest, grpc.ServerStreamingServer[ImageChunk]) error
+	Exec(grpc.BidiStreamingServer[ExecRequest, ExecResponse]) error
+	PortForward(grpc.BidiStreamingServer[PortForwardRequest, PortForwardResponse]) error
+	GetStagingStatus(context.Context, *GetStagingStatusRequest) (*GetStagingStatusResponse, error)
+	CreateVolume(context.Context, *CreateVolumeRequest) (*CreateVolumeResponse, error)
+	ListVolumes(context.Context, *ListVolumesRequest) (*ListVolumesResponse, error)
+	DeleteVolume(context.Context, *DeleteVolumeRequest) (*DeleteVolumeResponse, error)
+	GetInstanceStatus(context.Context, *GetInstanceStatusRequest) (*GetInstanceStatusResponse, error)
+	GetComponentUsage(context.Context, *GetComponentUsageRequest) (*GetComponentUsageResponse, error)
 	mustEmbedUnimplementedServiceServer()
 }
 
@@ -155,12 +335,48 @@ func (UnimplementedServiceServer) GetAvailable(context.Context, *GetAvailableReq
 func (UnimplementedServiceServer) Deploy(context.Context, *DeployRequest) (*DeployResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Deploy not implemented")
 }
+func (UnimplementedServiceServer) Undeploy(context.Context, *UndeployRequest) (*UndeployResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Undeploy not implemented")
+}
 func (UnimplementedServiceServer) HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HealthCheck not implemented")
 }
+func (UnimplementedServiceServer) Benchmark(context.Context, *BenchmarkRequest) (*BenchmarkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Benchmark not implemented")
+}
 func (UnimplementedServiceServer) GetRealTimeUsage(context.Context, *GetRealTimeUsageRequest) (*GetRealTimeUsageResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRealTimeUsage not implemented")
 }
+func (UnimplementedServiceServer) WatchUsage(*WatchUsageRequest, grpc.ServerStreamingServer[UsageUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchUsage not implemented")
+}
+func (UnimplementedServiceServer) ExportImage(*ExportImageRequest, grpc.ServerStreamingServer[ImageChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportImage not implemented")
+}
+func (UnimplementedServiceServer) Exec(grpc.BidiStreamingServer[ExecRequest, ExecResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Exec not implemented")
+}
+func (UnimplementedServiceServer) PortForward(grpc.BidiStreamingServer[PortForwardRequest, PortForwardResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method PortForward not implemented")
+}
+func (UnimplementedServiceServer) GetStagingStatus(context.Context, *GetStagingStatusRequest) (*GetStagingStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStagingStatus not implemented")
+}
+func (UnimplementedServiceServer) CreateVolume(context.Context, *CreateVolumeRequest) (*CreateVolumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateVolume not implemented")
+}
+func (UnimplementedServiceServer) ListVolumes(context.Context, *ListVolumesRequest) (*ListVolumesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVolumes not implemented")
+}
+func (UnimplementedServiceServer) DeleteVolume(context.Context, *DeleteVolumeRequest) (*DeleteVolumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteVolume not implemented")
+}
+func (UnimplementedServiceServer) GetInstanceStatus(context.Context, *GetInstanceStatusRequest) (*GetInstanceStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstanceStatus not implemented")
+}
+func (UnimplementedServiceServer) GetComponentUsage(context.Context, *GetComponentUsageRequest) (*GetComponentUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetComponentUsage not implemented")
+}
 func (UnimplementedServiceServer) mustEmbedUnimplementedServiceServer() {}
 func (UnimplementedServiceServer) testEmbeddedByValue()                 {}
 
@@ -272,6 +488,24 @@ func _Service_Deploy_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_Undeploy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeployRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).Undeploy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_Undeploy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).Undeploy(ctx, req.(*UndeployRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Service_HealthCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(HealthCheckRequest)
 	if err := dec(in); err != nil {
@@ -290,6 +524,24 @@ func _Service_HealthCheck_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_Benchmark_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BenchmarkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).Benchmark(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_Benchmark_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).Benchmark(ctx, req.(*BenchmarkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Service_GetRealTimeUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetRealTimeUsageRequest)
 	if err := dec(in); err != nil {
@@ -308,6 +560,150 @@ func _Service_GetRealTimeUsage_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_WatchUsage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchUsageRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServiceServer).WatchUsage(m, &grpc.GenericServerStream[WatchUsageRequest, UsageUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Service_WatchUsageServer = grpc.ServerStreamingServer[UsageUpdate]
+
+func _Service_ExportImage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportImageRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServiceServer).ExportImage(m, &grpc.GenericServerStream[ExportImageRequest, ImageChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Service_ExportImageServer = grpc.ServerStreamingServer[ImageChunk]
+
+func _Service_Exec_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ServiceServer).Exec(&grpc.GenericServerStream[ExecRequest, ExecResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Service_ExecServer = grpc.BidiStreamingServer[ExecRequest, ExecResponse]
+
+func _Service_PortForward_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ServiceServer).PortForward(&grpc.GenericServerStream[PortForwardRequest, PortForwardResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Service_PortForwardServer = grpc.BidiStreamingServer[PortForwardRequest, PortForwardResponse]
+
+func _Service_GetStagingStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStagingStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetStagingStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_GetStagingStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetStagingStatus(ctx, req.(*GetStagingStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_CreateVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).CreateVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_CreateVolume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).CreateVolume(ctx, req.(*CreateVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ListVolumes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVolumesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ListVolumes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_ListVolumes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ListVolumes(ctx, req.(*ListVolumesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_DeleteVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).DeleteVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_DeleteVolume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).DeleteVolume(ctx, req.(*DeleteVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_GetInstanceStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInstanceStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetInstanceStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_GetInstanceStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetInstanceStatus(ctx, req.(*GetInstanceStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_GetComponentUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetComponentUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetComponentUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_GetComponentUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetComponentUsage(ctx, req.(*GetComponentUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Service_ServiceDesc is the grpc.ServiceDesc for Service service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -335,15 +731,70 @@ var Service_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Deploy",
 			Handler:    _Service_Deploy_Handler,
 		},
+		{
+			MethodName: "Undeploy",
+			Handler:    _Service_Undeploy_Handler,
+		},
 		{
 			MethodName: "HealthCheck",
 			Handler:    _Service_HealthCheck_Handler,
 		},
+		{
+			MethodName: "Benchmark",
+			Handler:    _Service_Benchmark_Handler,
+		},
 		{
 			MethodName: "GetRealTimeUsage",
 			Handler:    _Service_GetRealTimeUsage_Handler,
 		},
+		{
+			MethodName: "GetStagingStatus",
+			Handler:    _Service_GetStagingStatus_Handler,
+		},
+		{
+			MethodName: "CreateVolume",
+			Handler:    _Service_CreateVolume_Handler,
+		},
+		{
+			MethodName: "ListVolumes",
+			Handler:    _Service_ListVolumes_Handler,
+		},
+		{
+			MethodName: "DeleteVolume",
+			Handler:    _Service_DeleteVolume_Handler,
+		},
+		{
+			MethodName: "GetInstanceStatus",
+			Handler:    _Service_GetInstanceStatus_Handler,
+		},
+		{
+			MethodName: "GetComponentUsage",
+			Handler:    _Service_GetComponentUsage_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchUsage",
+			Handler:       _Service_WatchUsage_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportImage",
+			Handler:       _Service_ExportImage_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Exec",
+			Handler:       _Service_Exec_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "PortForward",
+			Handler:       _Service_PortForward_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "resource/provider/provider.proto",
 }
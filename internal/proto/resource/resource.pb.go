@@ -27,6 +27,8 @@ type Info struct {
 	Memory        int64                  `protobuf:"varint,2,opt,name=memory,proto3" json:"memory,omitempty"`
 	Gpu           int64                  `protobuf:"varint,3,opt,name=gpu,proto3" json:"gpu,omitempty"`
 	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	NodeSelector  map[string]string      `protobuf:"bytes,5,rep,name=node_selector,json=nodeSelector,proto3" json:"node_selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 节点标签约束，目标节点须具备全部标签（如 zone=edge-1）
+	Stream        string                 `protobuf:"bytes,6,opt,name=stream,proto3" json:"stream,omitempty"`                                                                                                           // 绑定的数据流名称（可选），只能部署到拥有该数据流的 provider
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -89,6 +91,20 @@ func (x *Info) GetTags() []string {
 	return nil
 }
 
+func (x *Info) GetNodeSelector() map[string]string {
+	if x != nil {
+		return x.NodeSelector
+	}
+	return nil
+}
+
+func (x *Info) GetStream() string {
+	if x != nil {
+		return x.Stream
+	}
+	return ""
+}
+
 type Capacity struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Total         *Info                  `protobuf:"bytes,1,opt,name=total,proto3" json:"total,omitempty"`
@@ -197,12 +213,17 @@ var File_resource_resource_proto protoreflect.FileDescriptor
 
 const file_resource_resource_proto_rawDesc = "" +
 	"\n" +
-	"\x17resource/resource.proto\x12\bresource\"V\n" +
+	"\x17resource/resource.proto\x12\bresource\"\xf6\x01\n" +
 	"\x04Info\x12\x10\n" +
 	"\x03cpu\x18\x01 \x01(\x03R\x03cpu\x12\x16\n" +
 	"\x06memory\x18\x02 \x01(\x03R\x06memory\x12\x10\n" +
 	"\x03gpu\x18\x03 \x01(\x03R\x03gpu\x12\x12\n" +
-	"\x04tags\x18\x04 \x03(\tR\x04tags\"\x82\x01\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12E\n" +
+	"\rnode_selector\x18\x05 \x03(\v2 .resource.Info.NodeSelectorEntryR\fnodeSelector\x12\x16\n" +
+	"\x06stream\x18\x06 \x01(\tR\x06stream\x1a?\n" +
+	"\x11NodeSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x82\x01\n" +
 	"\bCapacity\x12$\n" +
 	"\x05total\x18\x01 \x01(\v2\x0e.resource.InfoR\x05total\x12\"\n" +
 	"\x04used\x18\x02 \x01(\v2\x0e.resource.InfoR\x04used\x12,\n" +
@@ -222,22 +243,24 @@ func file_resource_resource_proto_rawDescGZIP() []byte {
 	return file_resource_resource_proto_rawDescData
 }
 
-var file_resource_resource_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_resource_resource_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_resource_resource_proto_goTypes = []any{
 	(*Info)(nil),            // 0: resource.Info
 	(*Capacity)(nil),        // 1: resource.Capacity
 	(*ResourceRequest)(nil), // 2: resource.ResourceRequest
+	nil,                     // 3: resource.Info.NodeSelectorEntry
 }
 var file_resource_resource_proto_depIdxs = []int32{
-	0, // 0: resource.Capacity.total:type_name -> resource.Info
-	0, // 1: resource.Capacity.used:type_name -> resource.Info
-	0, // 2: resource.Capacity.available:type_name -> resource.Info
-	0, // 3: resource.ResourceRequest.info:type_name -> resource.Info
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	3, // 0: resource.Info.node_selector:type_name -> resource.Info.NodeSelectorEntry
+	0, // 1: resource.Capacity.total:type_name -> resource.Info
+	0, // 2: resource.Capacity.used:type_name -> resource.Info
+	0, // 3: resource.Capacity.available:type_name -> resource.Info
+	0, // 4: resource.ResourceRequest.info:type_name -> resource.Info
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_resource_resource_proto_init() }
@@ -251,7 +274,7 @@ func file_resource_resource_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_resource_resource_proto_rawDesc), len(file_resource_resource_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
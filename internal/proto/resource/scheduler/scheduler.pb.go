@@ -7,6 +7,7 @@
 package scheduler
 
 import (
+	common "github.com/9triver/iarnet/internal/proto/common"
 	resource "github.com/9triver/iarnet/internal/proto/resource"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -22,6 +23,56 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// ReconcileAction 委托方对上报的 component 的处理方式
+type ReconcileAction int32
+
+const (
+	ReconcileAction_RECONCILE_ACTION_PENDING ReconcileAction = 0 // 委托方的部署仍在进行，下次对账时再确认
+	ReconcileAction_RECONCILE_ACTION_ADOPT   ReconcileAction = 1 // 委托方登记了该 component，保留
+	ReconcileAction_RECONCILE_ACTION_DELETE  ReconcileAction = 2 // 委托方没有该 component 的记录，删除
+)
+
+// Enum value maps for ReconcileAction.
+var (
+	ReconcileAction_name = map[int32]string{
+		0: "RECONCILE_ACTION_PENDING",
+		1: "RECONCILE_ACTION_ADOPT",
+		2: "RECONCILE_ACTION_DELETE",
+	}
+	ReconcileAction_value = map[string]int32{
+		"RECONCILE_ACTION_PENDING": 0,
+		"RECONCILE_ACTION_ADOPT":   1,
+		"RECONCILE_ACTION_DELETE":  2,
+	}
+)
+
+func (x ReconcileAction) Enum() *ReconcileAction {
+	p := new(ReconcileAction)
+	*p = x
+	return p
+}
+
+func (x ReconcileAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReconcileAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_resource_scheduler_scheduler_proto_enumTypes[0].Descriptor()
+}
+
+func (ReconcileAction) Type() protoreflect.EnumType {
+	return &file_resource_scheduler_scheduler_proto_enumTypes[0]
+}
+
+func (x ReconcileAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReconcileAction.Descriptor instead.
+func (ReconcileAction) EnumDescriptor() ([]byte, []int) {
+	return file_resource_scheduler_scheduler_proto_rawDescGZIP(), []int{0}
+}
+
 // ComponentStatus Component 状态
 type ComponentStatus int32
 
@@ -62,11 +113,11 @@ func (x ComponentStatus) String() string {
 }
 
 func (ComponentStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_resource_scheduler_scheduler_proto_enumTypes[0].Descriptor()
+	return file_resource_scheduler_scheduler_proto_enumTypes[1].Descriptor()
 }
 
 func (ComponentStatus) Type() protoreflect.EnumType {
-	return &file_resource_scheduler_scheduler_proto_enumTypes[0]
+	return &file_resource_scheduler_scheduler_proto_enumTypes[1]
 }
 
 func (x ComponentStatus) Number() protoreflect.EnumNumber {
@@ -75,7 +126,7 @@ func (x ComponentStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ComponentStatus.Descriptor instead.
 func (ComponentStatus) EnumDescriptor() ([]byte, []int) {
-	return file_resource_scheduler_scheduler_proto_rawDescGZIP(), []int{0}
+	return file_resource_scheduler_scheduler_proto_rawDescGZIP(), []int{1}
 }
 
 // DeployComponentRequest 部署 component 请求
@@ -94,8 +145,28 @@ type DeployComponentRequest struct {
 	UpstreamZmqAddress    string `protobuf:"bytes,5,opt,name=upstream_zmq_address,json=upstreamZmqAddress,proto3" json:"upstream_zmq_address,omitempty"`
 	UpstreamStoreAddress  string `protobuf:"bytes,6,opt,name=upstream_store_address,json=upstreamStoreAddress,proto3" json:"upstream_store_address,omitempty"`
 	UpstreamLoggerAddress string `protobuf:"bytes,7,opt,name=upstream_logger_address,json=upstreamLoggerAddress,proto3" json:"upstream_logger_address,omitempty"`
-	unknownFields         protoimpl.UnknownFields
-	sizeCache             protoimpl.SizeCache
+	// 会话亲和（可选）：相同 key 的部署固定到同一节点/provider/component
+	AffinityKey        string `protobuf:"bytes,8,opt,name=affinity_key,json=affinityKey,proto3" json:"affinity_key,omitempty"`
+	AffinityScope      string `protobuf:"bytes,9,opt,name=affinity_scope,json=affinityScope,proto3" json:"affinity_scope,omitempty"`                    // node / provider / component，空表示 provider
+	AffinityTtlSeconds int64  `protobuf:"varint,10,opt,name=affinity_ttl_seconds,json=affinityTtlSeconds,proto3" json:"affinity_ttl_seconds,omitempty"` // 会话空闲超时，0 表示使用目标节点的默认值
+	// 由调用方指定的 component ID（可选），为空时由目标节点生成
+	// 调用方取消部署时据此回滚可能已创建的 component
+	ComponentId string `protobuf:"bytes,11,opt,name=component_id,json=componentId,proto3" json:"component_id,omitempty"`
+	// 启动前预置到 component 工作目录的数据（可选），store 对象从 upstream_store_address 拉取
+	DataSources []*common.DataSource `protobuf:"bytes,12,rep,name=data_sources,json=dataSources,proto3" json:"data_sources,omitempty"`
+	// 容器安全配置（可选），未设置时由目标节点的 provider 使用其默认配置
+	SecurityContext *common.SecurityContext `protobuf:"bytes,13,opt,name=security_context,json=securityContext,proto3" json:"security_context,omitempty"`
+	// 委托方为该 component 签发的令牌（可选），component 与 provider 回连上游 store/logger/ZMQ 时出示
+	UpstreamToken string `protobuf:"bytes,14,opt,name=upstream_token,json=upstreamToken,proto3" json:"upstream_token,omitempty"`
+	// 与主容器部署到同一 provider 的 sidecar 容器（可选），resource_request 为主容器与 sidecar 的合计
+	Sidecars []*common.Sidecar `protobuf:"bytes,15,rep,name=sidecars,proto3" json:"sidecars,omitempty"`
+	// 所属应用与 component 标签（可选），目标节点据此响应按应用、标签的 component 查询
+	AppId  string            `protobuf:"bytes,16,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Labels map[string]string `protobuf:"bytes,17,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// 委托方节点 ID（可选），目标节点据此定期向委托方核对该 component 是否仍被登记
+	OriginNodeId  string `protobuf:"bytes,18,opt,name=origin_node_id,json=originNodeId,proto3" json:"origin_node_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DeployComponentRequest) Reset() {
@@ -177,6 +248,83 @@ func (x *DeployComponentRequest) GetUpstreamLoggerAddress() string {
 	return ""
 }
 
+func (x *DeployComponentRequest) GetAffinityKey() string {
+	if x != nil {
+		return x.AffinityKey
+	}
+	return ""
+}
+
+func (x *DeployComponentRequest) GetAffinityScope() string {
+	if x != nil {
+		return x.AffinityScope
+	}
+	return ""
+}
+
+func (x *DeployComponentRequest) GetAffinityTtlSeconds() int64 {
+	if x != nil {
+		return x.AffinityTtlSeconds
+	}
+	return 0
+}
+
+func (x *DeployComponentRequest) GetComponentId() string {
+	if x != nil {
+		return x.ComponentId
+	}
+	return ""
+}
+
+func (x *DeployComponentRequest) GetDataSources() []*common.DataSource {
+	if x != nil {
+		return x.DataSources
+	}
+	return nil
+}
+
+func (x *DeployComponentRequest) GetSecurityContext() *common.SecurityContext {
+	if x != nil {
+		return x.SecurityContext
+	}
+	return nil
+}
+
+func (x *DeployComponentRequest) GetUpstreamToken() string {
+	if x != nil {
+		return x.UpstreamToken
+	}
+	return ""
+}
+
+func (x *DeployComponentRequest) GetSidecars() []*common.Sidecar {
+	if x != nil {
+		return x.Sidecars
+	}
+	return nil
+}
+
+func (x *DeployComponentRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *DeployComponentRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *DeployComponentRequest) GetOriginNodeId() string {
+	if x != nil {
+		return x.OriginNodeId
+	}
+	return ""
+}
+
 // DeployComponentResponse 部署 component 响应
 type DeployComponentResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -268,35 +416,33 @@ func (x *DeployComponentResponse) GetProviderId() string {
 	return ""
 }
 
-// ComponentInfo Component 信息
-type ComponentInfo struct {
+// UndeployComponentRequest 删除 component 请求
+type UndeployComponentRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Component ID
 	ComponentId string `protobuf:"bytes,1,opt,name=component_id,json=componentId,proto3" json:"component_id,omitempty"`
-	// 镜像名称
-	Image string `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
-	// 资源使用情况
-	ResourceUsage *resource.Info `protobuf:"bytes,3,opt,name=resource_usage,json=resourceUsage,proto3" json:"resource_usage,omitempty"`
-	// Provider ID
-	ProviderId    string `protobuf:"bytes,4,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// 目标节点 ID（可选，为空则删除本地节点上的 component）
+	TargetNodeId string `protobuf:"bytes,2,opt,name=target_node_id,json=targetNodeId,proto3" json:"target_node_id,omitempty"`
+	// 目标节点地址（可选）
+	TargetNodeAddress string `protobuf:"bytes,3,opt,name=target_node_address,json=targetNodeAddress,proto3" json:"target_node_address,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *ComponentInfo) Reset() {
-	*x = ComponentInfo{}
+func (x *UndeployComponentRequest) Reset() {
+	*x = UndeployComponentRequest{}
 	mi := &file_resource_scheduler_scheduler_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ComponentInfo) String() string {
+func (x *UndeployComponentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ComponentInfo) ProtoMessage() {}
+func (*UndeployComponentRequest) ProtoMessage() {}
 
-func (x *ComponentInfo) ProtoReflect() protoreflect.Message {
+func (x *UndeployComponentRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_resource_scheduler_scheduler_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -308,64 +454,57 @@ func (x *ComponentInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ComponentInfo.ProtoReflect.Descriptor instead.
-func (*ComponentInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use UndeployComponentRequest.ProtoReflect.Descriptor instead.
+func (*UndeployComponentRequest) Descriptor() ([]byte, []int) {
 	return file_resource_scheduler_scheduler_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *ComponentInfo) GetComponentId() string {
+func (x *UndeployComponentRequest) GetComponentId() string {
 	if x != nil {
 		return x.ComponentId
 	}
 	return ""
 }
 
-func (x *ComponentInfo) GetImage() string {
+func (x *UndeployComponentRequest) GetTargetNodeId() string {
 	if x != nil {
-		return x.Image
+		return x.TargetNodeId
 	}
 	return ""
 }
 
-func (x *ComponentInfo) GetResourceUsage() *resource.Info {
-	if x != nil {
-		return x.ResourceUsage
-	}
-	return nil
-}
-
-func (x *ComponentInfo) GetProviderId() string {
+func (x *UndeployComponentRequest) GetTargetNodeAddress() string {
 	if x != nil {
-		return x.ProviderId
+		return x.TargetNodeAddress
 	}
 	return ""
 }
 
-// GetDeploymentStatusRequest 获取部署状态请求
-type GetDeploymentStatusRequest struct {
+// UndeployComponentResponse 删除 component 响应
+type UndeployComponentResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Component ID
-	ComponentId string `protobuf:"bytes,1,opt,name=component_id,json=componentId,proto3" json:"component_id,omitempty"`
-	// 节点 ID（可选，如果指定则从该节点查询）
-	NodeId        string `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// 是否成功
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// 错误信息（如果失败）
+	Error         string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDeploymentStatusRequest) Reset() {
-	*x = GetDeploymentStatusRequest{}
+func (x *UndeployComponentResponse) Reset() {
+	*x = UndeployComponentResponse{}
 	mi := &file_resource_scheduler_scheduler_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDeploymentStatusRequest) String() string {
+func (x *UndeployComponentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDeploymentStatusRequest) ProtoMessage() {}
+func (*UndeployComponentResponse) ProtoMessage() {}
 
-func (x *GetDeploymentStatusRequest) ProtoReflect() protoreflect.Message {
+func (x *UndeployComponentResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_resource_scheduler_scheduler_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -377,54 +516,50 @@ func (x *GetDeploymentStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDeploymentStatusRequest.ProtoReflect.Descriptor instead.
-func (*GetDeploymentStatusRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UndeployComponentResponse.ProtoReflect.Descriptor instead.
+func (*UndeployComponentResponse) Descriptor() ([]byte, []int) {
 	return file_resource_scheduler_scheduler_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetDeploymentStatusRequest) GetComponentId() string {
+func (x *UndeployComponentResponse) GetSuccess() bool {
 	if x != nil {
-		return x.ComponentId
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *GetDeploymentStatusRequest) GetNodeId() string {
+func (x *UndeployComponentResponse) GetError() string {
 	if x != nil {
-		return x.NodeId
+		return x.Error
 	}
 	return ""
 }
 
-// GetDeploymentStatusResponse 获取部署状态响应
-type GetDeploymentStatusResponse struct {
+// ProposeDeploymentRequest 部署探测请求
+type ProposeDeploymentRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// 是否成功
-	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	// 错误信息（如果失败）
-	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	// Component 状态
-	Status ComponentStatus `protobuf:"varint,3,opt,name=status,proto3,enum=scheduler.ComponentStatus" json:"status,omitempty"`
-	// Component 信息
-	Component     *ComponentInfo `protobuf:"bytes,4,opt,name=component,proto3" json:"component,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// 运行时环境（如 "python"）
+	RuntimeEnv string `protobuf:"bytes,1,opt,name=runtime_env,json=runtimeEnv,proto3" json:"runtime_env,omitempty"`
+	// 资源请求
+	ResourceRequest *resource.Info `protobuf:"bytes,2,opt,name=resource_request,json=resourceRequest,proto3" json:"resource_request,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *GetDeploymentStatusResponse) Reset() {
-	*x = GetDeploymentStatusResponse{}
+func (x *ProposeDeploymentRequest) Reset() {
+	*x = ProposeDeploymentRequest{}
 	mi := &file_resource_scheduler_scheduler_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDeploymentStatusResponse) String() string {
+func (x *ProposeDeploymentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDeploymentStatusResponse) ProtoMessage() {}
+func (*ProposeDeploymentRequest) ProtoMessage() {}
 
-func (x *GetDeploymentStatusResponse) ProtoReflect() protoreflect.Message {
+func (x *ProposeDeploymentRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_resource_scheduler_scheduler_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -436,61 +571,1623 @@ func (x *GetDeploymentStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDeploymentStatusResponse.ProtoReflect.Descriptor instead.
-func (*GetDeploymentStatusResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ProposeDeploymentRequest.ProtoReflect.Descriptor instead.
+func (*ProposeDeploymentRequest) Descriptor() ([]byte, []int) {
 	return file_resource_scheduler_scheduler_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetDeploymentStatusResponse) GetSuccess() bool {
+func (x *ProposeDeploymentRequest) GetRuntimeEnv() string {
 	if x != nil {
-		return x.Success
+		return x.RuntimeEnv
+	}
+	return ""
+}
+
+func (x *ProposeDeploymentRequest) GetResourceRequest() *resource.Info {
+	if x != nil {
+		return x.ResourceRequest
+	}
+	return nil
+}
+
+// ProposeDeploymentResponse 部署探测响应
+type ProposeDeploymentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 节点是否能够部署
+	Accepted bool `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	// 拒绝原因（如果未接受）
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// 选中 provider 的可用资源
+	Available *resource.Info `protobuf:"bytes,3,opt,name=available,proto3" json:"available,omitempty"`
+	// 节点 ID
+	NodeId string `protobuf:"bytes,4,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// 节点名称
+	NodeName      string `protobuf:"bytes,5,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProposeDeploymentResponse) Reset() {
+	*x = ProposeDeploymentResponse{}
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProposeDeploymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProposeDeploymentResponse) ProtoMessage() {}
+
+func (x *ProposeDeploymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProposeDeploymentResponse.ProtoReflect.Descriptor instead.
+func (*ProposeDeploymentResponse) Descriptor() ([]byte, []int) {
+	return file_resource_scheduler_scheduler_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ProposeDeploymentResponse) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
 	}
 	return false
 }
 
-func (x *GetDeploymentStatusResponse) GetError() string {
+func (x *ProposeDeploymentResponse) GetReason() string {
 	if x != nil {
-		return x.Error
+		return x.Reason
 	}
 	return ""
 }
 
-func (x *GetDeploymentStatusResponse) GetStatus() ComponentStatus {
+func (x *ProposeDeploymentResponse) GetAvailable() *resource.Info {
 	if x != nil {
-		return x.Status
+		return x.Available
 	}
-	return ComponentStatus_COMPONENT_STATUS_UNKNOWN
+	return nil
 }
 
-func (x *GetDeploymentStatusResponse) GetComponent() *ComponentInfo {
+func (x *ProposeDeploymentResponse) GetNodeId() string {
 	if x != nil {
-		return x.Component
+		return x.NodeId
 	}
-	return nil
+	return ""
 }
 
-var File_resource_scheduler_scheduler_proto protoreflect.FileDescriptor
+func (x *ProposeDeploymentResponse) GetNodeName() string {
+	if x != nil {
+		return x.NodeName
+	}
+	return ""
+}
 
-const file_resource_scheduler_scheduler_proto_rawDesc = "" +
-	"\n" +
-	"\"resource/scheduler/scheduler.proto\x12\tscheduler\x1a\x17resource/resource.proto\"\xea\x02\n" +
-	"\x16DeployComponentRequest\x12\x1f\n" +
-	"\vruntime_env\x18\x01 \x01(\tR\n" +
-	"runtimeEnv\x129\n" +
-	"\x10resource_request\x18\x02 \x01(\v2\x0e.resource.InfoR\x0fresourceRequest\x12$\n" +
-	"\x0etarget_node_id\x18\x03 \x01(\tR\ftargetNodeId\x12.\n" +
-	"\x13target_node_address\x18\x04 \x01(\tR\x11targetNodeAddress\x120\n" +
-	"\x14upstream_zmq_address\x18\x05 \x01(\tR\x12upstreamZmqAddress\x124\n" +
-	"\x16upstream_store_address\x18\x06 \x01(\tR\x14upstreamStoreAddress\x126\n" +
-	"\x17upstream_logger_address\x18\a \x01(\tR\x15upstreamLoggerAddress\"\xd8\x01\n" +
-	"\x17DeployComponentResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\x126\n" +
-	"\tcomponent\x18\x03 \x01(\v2\x18.scheduler.ComponentInfoR\tcomponent\x12\x17\n" +
-	"\anode_id\x18\x04 \x01(\tR\x06nodeId\x12\x1b\n" +
-	"\tnode_name\x18\x05 \x01(\tR\bnodeName\x12\x1f\n" +
-	"\vprovider_id\x18\x06 \x01(\tR\n" +
-	"providerId\"\xa0\x01\n" +
+// GetNodeUtilizationRequest 获取节点资源利用率请求
+type GetNodeUtilizationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 节点 ID（可选，为空则返回本节点）
+	NodeId        string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNodeUtilizationRequest) Reset() {
+	*x = GetNodeUtilizationRequest{}
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNodeUtilizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNodeUtilizationRequest) ProtoMessage() {}
+
+func (x *GetNodeUtilizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNodeUtilizationRequest.ProtoReflect.Descriptor instead.
+func (*GetNodeUtilizationRequest) Descriptor() ([]byte, []int) {
+	return file_resource_scheduler_scheduler_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetNodeUtilizationRequest) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+// GetNodeUtilizationResponse 获取节点资源利用率响应
+type GetNodeUtilizationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 是否成功
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// 错误信息（如果失败）
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// 节点 ID
+	NodeId string `protobuf:"bytes,3,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// 节点名称
+	NodeName string `protobuf:"bytes,4,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	// 所有已连接 provider 聚合后的总量、已用、可用资源
+	Capacity *resource.Capacity `protobuf:"bytes,5,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	// 各 provider 的资源明细
+	Providers     []*ProviderUtilization `protobuf:"bytes,6,rep,name=providers,proto3" json:"providers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNodeUtilizationResponse) Reset() {
+	*x = GetNodeUtilizationResponse{}
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNodeUtilizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNodeUtilizationResponse) ProtoMessage() {}
+
+func (x *GetNodeUtilizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNodeUtilizationResponse.ProtoReflect.Descriptor instead.
+func (*GetNodeUtilizationResponse) Descriptor() ([]byte, []int) {
+	return file_resource_scheduler_scheduler_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetNodeUtilizationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetNodeUtilizationResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetNodeUtilizationResponse) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *GetNodeUtilizationResponse) GetNodeName() string {
+	if x != nil {
+		return x.NodeName
+	}
+	return ""
+}
+
+func (x *GetNodeUtilizationResponse) GetCapacity() *resource.Capacity {
+	if x != nil {
+		return x.Capacity
+	}
+	return nil
+}
+
+func (x *GetNodeUtilizationResponse) GetProviders() []*ProviderUtilization {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+// ProviderUtilization 单个 provider 的资源利用率
+type ProviderUtilization struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Provider ID
+	ProviderId string `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	// Provider 名称
+	ProviderName string `protobuf:"bytes,2,opt,name=provider_name,json=providerName,proto3" json:"provider_name,omitempty"`
+	// Provider 状态（connected / disconnected 等）
+	Status string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	// 资源容量（未连接或获取失败时为空）
+	Capacity *resource.Capacity `protobuf:"bytes,4,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	// 获取容量失败时的错误信息
+	Error string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	// Provider 类型（docker / k8s 等）
+	ProviderType string `protobuf:"bytes,6,opt,name=provider_type,json=providerType,proto3" json:"provider_type,omitempty"`
+	// 部署在该 provider 上的 component 数
+	Components    int32 `protobuf:"varint,7,opt,name=components,proto3" json:"components,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProviderUtilization) Reset() {
+	*x = ProviderUtilization{}
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProviderUtilization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderUtilization) ProtoMessage() {}
+
+func (x *ProviderUtilization) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderUtilization.ProtoReflect.Descriptor instead.
+func (*ProviderUtilization) Descriptor() ([]byte, []int) {
+	return file_resource_scheduler_scheduler_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ProviderUtilization) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+func (x *ProviderUtilization) GetProviderName() string {
+	if x != nil {
+		return x.ProviderName
+	}
+	return ""
+}
+
+func (x *ProviderUtilization) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ProviderUtilization) GetCapacity() *resource.Capacity {
+	if x != nil {
+		return x.Capacity
+	}
+	return nil
+}
+
+func (x *ProviderUtilization) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ProviderUtilization) GetProviderType() string {
+	if x != nil {
+		return x.ProviderType
+	}
+	return ""
+}
+
+func (x *ProviderUtilization) GetComponents() int32 {
+	if x != nil {
+		return x.Components
+	}
+	return 0
+}
+
+// ListProvidersRequest 分页列举 provider 请求
+type ListProvidersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 每页数量，0 表示默认值（100），超过上限（1000）时按上限返回
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// 上一页响应中的 next_page_token，为空表示第一页
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// 字段掩码：返回的 ProviderUtilization 字段名（如 provider_name、status、capacity），
+	// provider_id 始终返回；为空时返回全部字段
+	Fields []string `protobuf:"bytes,3,rep,name=fields,proto3" json:"fields,omitempty"`
+	// 非 0 时只返回该版本之后有变化的 provider，并在第一页携带此后移除的 provider ID
+	SinceVersion  uint64 `protobuf:"varint,4,opt,name=since_version,json=sinceVersion,proto3" json:"since_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProvidersRequest) Reset() {
+	*x = ListProvidersRequest{}
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProvidersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProvidersRequest) ProtoMessage() {}
+
+func (x *ListProvidersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProvidersRequest.ProtoReflect.Descriptor instead.
+func (*ListProvidersRequest) Descriptor() ([]byte, []int) {
+	return file_resource_scheduler_scheduler_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListProvidersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListProvidersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListProvidersRequest) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *ListProvidersRequest) GetSinceVersion() uint64 {
+	if x != nil {
+		return x.SinceVersion
+	}
+	return 0
+}
+
+// ListRemoteProvidersRequest 列举其他节点 provider 请求
+type ListRemoteProvidersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 目标节点 ID
+	NodeId string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// 目标节点地址（可选）
+	NodeAddress string `protobuf:"bytes,2,opt,name=node_address,json=nodeAddress,proto3" json:"node_address,omitempty"`
+	// 查询条件
+	Query         *ListProvidersRequest `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRemoteProvidersRequest) Reset() {
+	*x = ListRemoteProvidersRequest{}
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRemoteProvidersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRemoteProvidersRequest) ProtoMessage() {}
+
+func (x *ListRemoteProvidersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRemoteProvidersRequest.ProtoReflect.Descriptor instead.
+func (*ListRemoteProvidersRequest) Descriptor() ([]byte, []int) {
+	return file_resource_scheduler_scheduler_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListRemoteProvidersRequest) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *ListRemoteProvidersRequest) GetNodeAddress() string {
+	if x != nil {
+		return x.NodeAddress
+	}
+	return ""
+}
+
+func (x *ListRemoteProvidersRequest) GetQuery() *ListProvidersRequest {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+// ListProvidersResponse 分页列举 provider 响应
+type ListProvidersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 是否成功
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// 错误信息（如果失败）
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// 节点 ID
+	NodeId string `protobuf:"bytes,3,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// 节点名称
+	NodeName string `protobuf:"bytes,4,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	// 本页的 provider，按 provider ID 排序
+	Providers []*ProviderUtilization `protobuf:"bytes,5,rep,name=providers,proto3" json:"providers,omitempty"`
+	// 下一页的令牌，为空表示已是最后一页
+	NextPageToken string `protobuf:"bytes,6,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// 列举所基于的版本，遍历完所有页后作为下一次增量查询的 since_version
+	Version uint64 `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	// 增量响应中自 since_version 以来移除的 provider（只在第一页携带）
+	RemovedProviderIds []string `protobuf:"bytes,8,rep,name=removed_provider_ids,json=removedProviderIds,proto3" json:"removed_provider_ids,omitempty"`
+	// since_version 早于节点保留的变更记录（e.g., 节点重启）时返回全量结果，调用方需丢弃本地缓存
+	Full          bool `protobuf:"varint,9,opt,name=full,proto3" json:"full,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProvidersResponse) Reset() {
+	*x = ListProvidersResponse{}
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProvidersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProvidersResponse) ProtoMessage() {}
+
+func (x *ListProvidersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProvidersResponse.ProtoReflect.Descriptor instead.
+func (*ListProvidersResponse) Descriptor() ([]byte, []int) {
+	return file_resource_scheduler_scheduler_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListProvidersResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListProvidersResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListProvidersResponse) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *ListProvidersResponse) GetNodeName() string {
+	if x != nil {
+		return x.NodeName
+	}
+	return ""
+}
+
+func (x *ListProvidersResponse) GetProviders() []*ProviderUtilization {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+func (x *ListProvidersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListProvidersResponse) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ListProvidersResponse) GetRemovedProviderIds() []string {
+	if x != nil {
+		return x.RemovedProviderIds
+	}
+	return nil
+}
+
+func (x *ListProvidersResponse) GetFull() bool {
+	if x != nil {
+		return x.Full
+	}
+	return false
+}
+
+// ListComponentsRequest 列举 component 请求，各条件为空时不过滤
+type ListComponentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 所属应用
+	AppId string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// 必须完全匹配的 component 标签
+	Labels map[string]string `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Provider 类型（docker / k8s 等）
+	ProviderType string `protobuf:"bytes,3,opt,name=provider_type,json=providerType,proto3" json:"provider_type,omitempty"`
+	// Component 状态（deploying / running / unreachable）
+	State string `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	// 委托方节点 ID，只列举由该节点委托部署的 component
+	OriginNodeId  string `protobuf:"bytes,5,opt,name=origin_node_id,json=originNodeId,proto3" json:"origin_node_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListComponentsRequest) Reset() {
+	*x = ListComponentsRequest{}
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListComponentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListComponentsRequest) ProtoMessage() {}
+
+func (x *ListComponentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListComponentsRequest.ProtoReflect.Descriptor instead.
+func (*ListComponentsRequest) Descriptor() ([]byte, []int) {
+	return file_resource_scheduler_scheduler_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListComponentsRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *ListComponentsRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ListComponentsRequest) GetProviderType() string {
+	if x != nil {
+		return x.ProviderType
+	}
+	return ""
+}
+
+func (x *ListComponentsRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ListComponentsRequest) GetOriginNodeId() string {
+	if x != nil {
+		return x.OriginNodeId
+	}
+	return ""
+}
+
+// ListComponentsResponse 列举 component 响应
+type ListComponentsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 是否成功
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// 错误信息（如果失败）
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// 节点 ID
+	NodeId string `protobuf:"bytes,3,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// 节点名称
+	NodeName string `protobuf:"bytes,4,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	// 满足条件的 component，按 component ID 排序
+	Components    []*ComponentSummary `protobuf:"bytes,5,rep,name=components,proto3" json:"components,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListComponentsResponse) Reset() {
+	*x = ListComponentsResponse{}
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListComponentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListComponentsResponse) ProtoMessage() {}
+
+func (x *ListComponentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListComponentsResponse.ProtoReflect.Descriptor instead.
+func (*ListComponentsResponse) Descriptor() ([]byte, []int) {
+	return file_resource_scheduler_scheduler_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListComponentsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListComponentsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListComponentsResponse) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *ListComponentsResponse) GetNodeName() string {
+	if x != nil {
+		return x.NodeName
+	}
+	return ""
+}
+
+func (x *ListComponentsResponse) GetComponents() []*ComponentSummary {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+// ComponentSummary 列举结果中的 component
+type ComponentSummary struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Component ID
+	ComponentId string `protobuf:"bytes,1,opt,name=component_id,json=componentId,proto3" json:"component_id,omitempty"`
+	// 镜像名称
+	Image string `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	// 所属应用
+	AppId string `protobuf:"bytes,3,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// Component 标签
+	Labels map[string]string `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Provider ID，尚未放置时为空
+	ProviderId string `protobuf:"bytes,5,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	// Provider 类型
+	ProviderType string `protobuf:"bytes,6,opt,name=provider_type,json=providerType,proto3" json:"provider_type,omitempty"`
+	// Component 状态（deploying / running / unreachable）
+	State string `protobuf:"bytes,7,opt,name=state,proto3" json:"state,omitempty"`
+	// 部署时请求的资源
+	ResourceRequest *resource.Info `protobuf:"bytes,8,opt,name=resource_request,json=resourceRequest,proto3" json:"resource_request,omitempty"`
+	// 是否可被驱逐
+	Evictable bool `protobuf:"varint,9,opt,name=evictable,proto3" json:"evictable,omitempty"`
+	// 委托方节点 ID，本节点发起的部署为空
+	OriginNodeId  string `protobuf:"bytes,10,opt,name=origin_node_id,json=originNodeId,proto3" json:"origin_node_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComponentSummary) Reset() {
+	*x = ComponentSummary{}
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComponentSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComponentSummary) ProtoMessage() {}
+
+func (x *ComponentSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComponentSummary.ProtoReflect.Descriptor instead.
+func (*ComponentSummary) Descriptor() ([]byte, []int) {
+	return file_resource_scheduler_scheduler_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ComponentSummary) GetComponentId() string {
+	if x != nil {
+		return x.ComponentId
+	}
+	return ""
+}
+
+func (x *ComponentSummary) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *ComponentSummary) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *ComponentSummary) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ComponentSummary) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+func (x *ComponentSummary) GetProviderType() string {
+	if x != nil {
+		return x.ProviderType
+	}
+	return ""
+}
+
+func (x *ComponentSummary) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ComponentSummary) GetResourceRequest() *resource.Info {
+	if x != nil {
+		return x.ResourceRequest
+	}
+	return nil
+}
+
+func (x *ComponentSummary) GetEvictable() bool {
+	if x != nil {
+		return x.Evictable
+	}
+	return false
+}
+
+func (x *ComponentSummary) GetOriginNodeId() string {
+	if x != nil {
+		return x.OriginNodeId
+	}
+	return ""
+}
+
+// ReconcileComponentsRequest component 对账请求
+type ReconcileComponentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 上报节点（component 所在节点）的 ID
+	NodeId string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// 上报节点上由接收方委托部署的 component
+	Components    []*DelegatedComponent `protobuf:"bytes,2,rep,name=components,proto3" json:"components,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileComponentsRequest) Reset() {
+	*x = ReconcileComponentsRequest{}
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileComponentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileComponentsRequest) ProtoMessage() {}
+
+func (x *ReconcileComponentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileComponentsRequest.ProtoReflect.Descriptor instead.
+func (*ReconcileComponentsRequest) Descriptor() ([]byte, []int) {
+	return file_resource_scheduler_scheduler_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ReconcileComponentsRequest) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *ReconcileComponentsRequest) GetComponents() []*DelegatedComponent {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+// DelegatedComponent 委托部署到上报节点的 component
+type DelegatedComponent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Component ID
+	ComponentId string `protobuf:"bytes,1,opt,name=component_id,json=componentId,proto3" json:"component_id,omitempty"`
+	// 所在的 provider ID
+	ProviderId    string `protobuf:"bytes,2,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DelegatedComponent) Reset() {
+	*x = DelegatedComponent{}
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DelegatedComponent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DelegatedComponent) ProtoMessage() {}
+
+func (x *DelegatedComponent) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DelegatedComponent.ProtoReflect.Descriptor instead.
+func (*DelegatedComponent) Descriptor() ([]byte, []int) {
+	return file_resource_scheduler_scheduler_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DelegatedComponent) GetComponentId() string {
+	if x != nil {
+		return x.ComponentId
+	}
+	return ""
+}
+
+func (x *DelegatedComponent) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+// ReconcileComponentsResponse component 对账响应
+type ReconcileComponentsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 是否成功
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// 错误信息（如果失败）
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// component ID -> 处理方式，未列出的 component 按 RECONCILE_ACTION_PENDING 处理
+	Actions       map[string]ReconcileAction `protobuf:"bytes,3,rep,name=actions,proto3" json:"actions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value,enum=scheduler.ReconcileAction"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileComponentsResponse) Reset() {
+	*x = ReconcileComponentsResponse{}
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileComponentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileComponentsResponse) ProtoMessage() {}
+
+func (x *ReconcileComponentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileComponentsResponse.ProtoReflect.Descriptor instead.
+func (*ReconcileComponentsResponse) Descriptor() ([]byte, []int) {
+	return file_resource_scheduler_scheduler_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ReconcileComponentsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReconcileComponentsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ReconcileComponentsResponse) GetActions() map[string]ReconcileAction {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+// TransferComponentsRequest component 所有权转移请求
+type TransferComponentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 原委托方节点 ID，只有该节点已不存活时才转移
+	FromNodeId string `protobuf:"bytes,1,opt,name=from_node_id,json=fromNodeId,proto3" json:"from_node_id,omitempty"`
+	// 接管节点 ID
+	ToNodeId string `protobuf:"bytes,2,opt,name=to_node_id,json=toNodeId,proto3" json:"to_node_id,omitempty"`
+	// 接管节点的上游 ZMQ/Store/Logger 地址
+	UpstreamZmqAddress    string `protobuf:"bytes,3,opt,name=upstream_zmq_address,json=upstreamZmqAddress,proto3" json:"upstream_zmq_address,omitempty"`
+	UpstreamStoreAddress  string `protobuf:"bytes,4,opt,name=upstream_store_address,json=upstreamStoreAddress,proto3" json:"upstream_store_address,omitempty"`
+	UpstreamLoggerAddress string `protobuf:"bytes,5,opt,name=upstream_logger_address,json=upstreamLoggerAddress,proto3" json:"upstream_logger_address,omitempty"`
+	// component ID -> 接管节点为其签发的上游令牌（可选）
+	UpstreamTokens map[string]string `protobuf:"bytes,6,rep,name=upstream_tokens,json=upstreamTokens,proto3" json:"upstream_tokens,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// 要转移的 component
+	ComponentIds  []string `protobuf:"bytes,7,rep,name=component_ids,json=componentIds,proto3" json:"component_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferComponentsRequest) Reset() {
+	*x = TransferComponentsRequest{}
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferComponentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferComponentsRequest) ProtoMessage() {}
+
+func (x *TransferComponentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferComponentsRequest.ProtoReflect.Descriptor instead.
+func (*TransferComponentsRequest) Descriptor() ([]byte, []int) {
+	return file_resource_scheduler_scheduler_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TransferComponentsRequest) GetFromNodeId() string {
+	if x != nil {
+		return x.FromNodeId
+	}
+	return ""
+}
+
+func (x *TransferComponentsRequest) GetToNodeId() string {
+	if x != nil {
+		return x.ToNodeId
+	}
+	return ""
+}
+
+func (x *TransferComponentsRequest) GetUpstreamZmqAddress() string {
+	if x != nil {
+		return x.UpstreamZmqAddress
+	}
+	return ""
+}
+
+func (x *TransferComponentsRequest) GetUpstreamStoreAddress() string {
+	if x != nil {
+		return x.UpstreamStoreAddress
+	}
+	return ""
+}
+
+func (x *TransferComponentsRequest) GetUpstreamLoggerAddress() string {
+	if x != nil {
+		return x.UpstreamLoggerAddress
+	}
+	return ""
+}
+
+func (x *TransferComponentsRequest) GetUpstreamTokens() map[string]string {
+	if x != nil {
+		return x.UpstreamTokens
+	}
+	return nil
+}
+
+func (x *TransferComponentsRequest) GetComponentIds() []string {
+	if x != nil {
+		return x.ComponentIds
+	}
+	return nil
+}
+
+// TransferComponentsResponse component 所有权转移响应
+type TransferComponentsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 是否成功
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// 错误信息（如果失败）
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// 节点 ID
+	NodeId string `protobuf:"bytes,3,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// 节点名称
+	NodeName string `protobuf:"bytes,4,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	// 已转移并重新部署的 component
+	Components []*ComponentSummary `protobuf:"bytes,5,rep,name=components,proto3" json:"components,omitempty"`
+	// component ID -> 转移失败的原因
+	Failed        map[string]string `protobuf:"bytes,6,rep,name=failed,proto3" json:"failed,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferComponentsResponse) Reset() {
+	*x = TransferComponentsResponse{}
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferComponentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferComponentsResponse) ProtoMessage() {}
+
+func (x *TransferComponentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferComponentsResponse.ProtoReflect.Descriptor instead.
+func (*TransferComponentsResponse) Descriptor() ([]byte, []int) {
+	return file_resource_scheduler_scheduler_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *TransferComponentsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *TransferComponentsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *TransferComponentsResponse) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *TransferComponentsResponse) GetNodeName() string {
+	if x != nil {
+		return x.NodeName
+	}
+	return ""
+}
+
+func (x *TransferComponentsResponse) GetComponents() []*ComponentSummary {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *TransferComponentsResponse) GetFailed() map[string]string {
+	if x != nil {
+		return x.Failed
+	}
+	return nil
+}
+
+// ComponentInfo Component 信息
+type ComponentInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Component ID
+	ComponentId string `protobuf:"bytes,1,opt,name=component_id,json=componentId,proto3" json:"component_id,omitempty"`
+	// 镜像名称
+	Image string `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	// 资源使用情况
+	ResourceUsage *resource.Info `protobuf:"bytes,3,opt,name=resource_usage,json=resourceUsage,proto3" json:"resource_usage,omitempty"`
+	// Provider ID
+	ProviderId    string `protobuf:"bytes,4,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComponentInfo) Reset() {
+	*x = ComponentInfo{}
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComponentInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComponentInfo) ProtoMessage() {}
+
+func (x *ComponentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComponentInfo.ProtoReflect.Descriptor instead.
+func (*ComponentInfo) Descriptor() ([]byte, []int) {
+	return file_resource_scheduler_scheduler_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ComponentInfo) GetComponentId() string {
+	if x != nil {
+		return x.ComponentId
+	}
+	return ""
+}
+
+func (x *ComponentInfo) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *ComponentInfo) GetResourceUsage() *resource.Info {
+	if x != nil {
+		return x.ResourceUsage
+	}
+	return nil
+}
+
+func (x *ComponentInfo) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+// GetDeploymentStatusRequest 获取部署状态请求
+type GetDeploymentStatusRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Component ID
+	ComponentId string `protobuf:"bytes,1,opt,name=component_id,json=componentId,proto3" json:"component_id,omitempty"`
+	// 节点 ID（可选，如果指定则从该节点查询）
+	NodeId        string `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeploymentStatusRequest) Reset() {
+	*x = GetDeploymentStatusRequest{}
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeploymentStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeploymentStatusRequest) ProtoMessage() {}
+
+func (x *GetDeploymentStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeploymentStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetDeploymentStatusRequest) Descriptor() ([]byte, []int) {
+	return file_resource_scheduler_scheduler_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetDeploymentStatusRequest) GetComponentId() string {
+	if x != nil {
+		return x.ComponentId
+	}
+	return ""
+}
+
+func (x *GetDeploymentStatusRequest) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+// GetDeploymentStatusResponse 获取部署状态响应
+type GetDeploymentStatusResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 是否成功
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// 错误信息（如果失败）
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// Component 状态
+	Status ComponentStatus `protobuf:"varint,3,opt,name=status,proto3,enum=scheduler.ComponentStatus" json:"status,omitempty"`
+	// Component 信息
+	Component     *ComponentInfo `protobuf:"bytes,4,opt,name=component,proto3" json:"component,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeploymentStatusResponse) Reset() {
+	*x = GetDeploymentStatusResponse{}
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeploymentStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeploymentStatusResponse) ProtoMessage() {}
+
+func (x *GetDeploymentStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_scheduler_scheduler_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeploymentStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetDeploymentStatusResponse) Descriptor() ([]byte, []int) {
+	return file_resource_scheduler_scheduler_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetDeploymentStatusResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetDeploymentStatusResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetDeploymentStatusResponse) GetStatus() ComponentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ComponentStatus_COMPONENT_STATUS_UNKNOWN
+}
+
+func (x *GetDeploymentStatusResponse) GetComponent() *ComponentInfo {
+	if x != nil {
+		return x.Component
+	}
+	return nil
+}
+
+var File_resource_scheduler_scheduler_proto protoreflect.FileDescriptor
+
+const file_resource_scheduler_scheduler_proto_rawDesc = "" +
+	"\n" +
+	"\"resource/scheduler/scheduler.proto\x12\tscheduler\x1a\x17resource/resource.proto\x1a\x12common/types.proto\"\x97\a\n" +
+	"\x16DeployComponentRequest\x12\x1f\n" +
+	"\vruntime_env\x18\x01 \x01(\tR\n" +
+	"runtimeEnv\x129\n" +
+	"\x10resource_request\x18\x02 \x01(\v2\x0e.resource.InfoR\x0fresourceRequest\x12$\n" +
+	"\x0etarget_node_id\x18\x03 \x01(\tR\ftargetNodeId\x12.\n" +
+	"\x13target_node_address\x18\x04 \x01(\tR\x11targetNodeAddress\x120\n" +
+	"\x14upstream_zmq_address\x18\x05 \x01(\tR\x12upstreamZmqAddress\x124\n" +
+	"\x16upstream_store_address\x18\x06 \x01(\tR\x14upstreamStoreAddress\x126\n" +
+	"\x17upstream_logger_address\x18\a \x01(\tR\x15upstreamLoggerAddress\x12!\n" +
+	"\faffinity_key\x18\b \x01(\tR\vaffinityKey\x12%\n" +
+	"\x0eaffinity_scope\x18\t \x01(\tR\raffinityScope\x120\n" +
+	"\x14affinity_ttl_seconds\x18\n" +
+	" \x01(\x03R\x12affinityTtlSeconds\x12!\n" +
+	"\fcomponent_id\x18\v \x01(\tR\vcomponentId\x125\n" +
+	"\fdata_sources\x18\f \x03(\v2\x12.common.DataSourceR\vdataSources\x12B\n" +
+	"\x10security_context\x18\r \x01(\v2\x17.common.SecurityContextR\x0fsecurityContext\x12%\n" +
+	"\x0eupstream_token\x18\x0e \x01(\tR\rupstreamToken\x12+\n" +
+	"\bsidecars\x18\x0f \x03(\v2\x0f.common.SidecarR\bsidecars\x12\x15\n" +
+	"\x06app_id\x18\x10 \x01(\tR\x05appId\x12E\n" +
+	"\x06labels\x18\x11 \x03(\v2-.scheduler.DeployComponentRequest.LabelsEntryR\x06labels\x12$\n" +
+	"\x0eorigin_node_id\x18\x12 \x01(\tR\foriginNodeId\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd8\x01\n" +
+	"\x17DeployComponentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x126\n" +
+	"\tcomponent\x18\x03 \x01(\v2\x18.scheduler.ComponentInfoR\tcomponent\x12\x17\n" +
+	"\anode_id\x18\x04 \x01(\tR\x06nodeId\x12\x1b\n" +
+	"\tnode_name\x18\x05 \x01(\tR\bnodeName\x12\x1f\n" +
+	"\vprovider_id\x18\x06 \x01(\tR\n" +
+	"providerId\"\x93\x01\n" +
+	"\x18UndeployComponentRequest\x12!\n" +
+	"\fcomponent_id\x18\x01 \x01(\tR\vcomponentId\x12$\n" +
+	"\x0etarget_node_id\x18\x02 \x01(\tR\ftargetNodeId\x12.\n" +
+	"\x13target_node_address\x18\x03 \x01(\tR\x11targetNodeAddress\"K\n" +
+	"\x19UndeployComponentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"v\n" +
+	"\x18ProposeDeploymentRequest\x12\x1f\n" +
+	"\vruntime_env\x18\x01 \x01(\tR\n" +
+	"runtimeEnv\x129\n" +
+	"\x10resource_request\x18\x02 \x01(\v2\x0e.resource.InfoR\x0fresourceRequest\"\xb3\x01\n" +
+	"\x19ProposeDeploymentResponse\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\bR\baccepted\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12,\n" +
+	"\tavailable\x18\x03 \x01(\v2\x0e.resource.InfoR\tavailable\x12\x17\n" +
+	"\anode_id\x18\x04 \x01(\tR\x06nodeId\x12\x1b\n" +
+	"\tnode_name\x18\x05 \x01(\tR\bnodeName\"4\n" +
+	"\x19GetNodeUtilizationRequest\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\"\xf0\x01\n" +
+	"\x1aGetNodeUtilizationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x17\n" +
+	"\anode_id\x18\x03 \x01(\tR\x06nodeId\x12\x1b\n" +
+	"\tnode_name\x18\x04 \x01(\tR\bnodeName\x12.\n" +
+	"\bcapacity\x18\x05 \x01(\v2\x12.resource.CapacityR\bcapacity\x12<\n" +
+	"\tproviders\x18\x06 \x03(\v2\x1e.scheduler.ProviderUtilizationR\tproviders\"\xfe\x01\n" +
+	"\x13ProviderUtilization\x12\x1f\n" +
+	"\vprovider_id\x18\x01 \x01(\tR\n" +
+	"providerId\x12#\n" +
+	"\rprovider_name\x18\x02 \x01(\tR\fproviderName\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12.\n" +
+	"\bcapacity\x18\x04 \x01(\v2\x12.resource.CapacityR\bcapacity\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12#\n" +
+	"\rprovider_type\x18\x06 \x01(\tR\fproviderType\x12\x1e\n" +
+	"\n" +
+	"components\x18\a \x01(\x05R\n" +
+	"components\"\x8f\x01\n" +
+	"\x14ListProvidersRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x16\n" +
+	"\x06fields\x18\x03 \x03(\tR\x06fields\x12#\n" +
+	"\rsince_version\x18\x04 \x01(\x04R\fsinceVersion\"\x8f\x01\n" +
+	"\x1aListRemoteProvidersRequest\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12!\n" +
+	"\fnode_address\x18\x02 \x01(\tR\vnodeAddress\x125\n" +
+	"\x05query\x18\x03 \x01(\v2\x1f.scheduler.ListProvidersRequestR\x05query\"\xc3\x02\n" +
+	"\x15ListProvidersResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x17\n" +
+	"\anode_id\x18\x03 \x01(\tR\x06nodeId\x12\x1b\n" +
+	"\tnode_name\x18\x04 \x01(\tR\bnodeName\x12<\n" +
+	"\tproviders\x18\x05 \x03(\v2\x1e.scheduler.ProviderUtilizationR\tproviders\x12&\n" +
+	"\x0fnext_page_token\x18\x06 \x01(\tR\rnextPageToken\x12\x18\n" +
+	"\aversion\x18\a \x01(\x04R\aversion\x120\n" +
+	"\x14removed_provider_ids\x18\b \x03(\tR\x12removedProviderIds\x12\x12\n" +
+	"\x04full\x18\t \x01(\bR\x04full\"\x90\x02\n" +
+	"\x15ListComponentsRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\tR\x05appId\x12D\n" +
+	"\x06labels\x18\x02 \x03(\v2,.scheduler.ListComponentsRequest.LabelsEntryR\x06labels\x12#\n" +
+	"\rprovider_type\x18\x03 \x01(\tR\fproviderType\x12\x14\n" +
+	"\x05state\x18\x04 \x01(\tR\x05state\x12$\n" +
+	"\x0eorigin_node_id\x18\x05 \x01(\tR\foriginNodeId\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xbb\x01\n" +
+	"\x16ListComponentsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x17\n" +
+	"\anode_id\x18\x03 \x01(\tR\x06nodeId\x12\x1b\n" +
+	"\tnode_name\x18\x04 \x01(\tR\bnodeName\x12;\n" +
+	"\n" +
+	"components\x18\x05 \x03(\v2\x1b.scheduler.ComponentSummaryR\n" +
+	"components\"\xb9\x03\n" +
+	"\x10ComponentSummary\x12!\n" +
+	"\fcomponent_id\x18\x01 \x01(\tR\vcomponentId\x12\x14\n" +
+	"\x05image\x18\x02 \x01(\tR\x05image\x12\x15\n" +
+	"\x06app_id\x18\x03 \x01(\tR\x05appId\x12?\n" +
+	"\x06labels\x18\x04 \x03(\v2'.scheduler.ComponentSummary.LabelsEntryR\x06labels\x12\x1f\n" +
+	"\vprovider_id\x18\x05 \x01(\tR\n" +
+	"providerId\x12#\n" +
+	"\rprovider_type\x18\x06 \x01(\tR\fproviderType\x12\x14\n" +
+	"\x05state\x18\a \x01(\tR\x05state\x129\n" +
+	"\x10resource_request\x18\b \x01(\v2\x0e.resource.InfoR\x0fresourceRequest\x12\x1c\n" +
+	"\tevictable\x18\t \x01(\bR\tevictable\x12$\n" +
+	"\x0eorigin_node_id\x18\n" +
+	" \x01(\tR\foriginNodeId\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"t\n" +
+	"\x1aReconcileComponentsRequest\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12=\n" +
+	"\n" +
+	"components\x18\x02 \x03(\v2\x1d.scheduler.DelegatedComponentR\n" +
+	"components\"X\n" +
+	"\x12DelegatedComponent\x12!\n" +
+	"\fcomponent_id\x18\x01 \x01(\tR\vcomponentId\x12\x1f\n" +
+	"\vprovider_id\x18\x02 \x01(\tR\n" +
+	"providerId\"\xf4\x01\n" +
+	"\x1bReconcileComponentsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12M\n" +
+	"\aactions\x18\x03 \x03(\v23.scheduler.ReconcileComponentsResponse.ActionsEntryR\aactions\x1aV\n" +
+	"\fActionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x120\n" +
+	"\x05value\x18\x02 \x01(\x0e2\x1a.scheduler.ReconcileActionR\x05value:\x028\x01\"\xc6\x03\n" +
+	"\x19TransferComponentsRequest\x12 \n" +
+	"\ffrom_node_id\x18\x01 \x01(\tR\n" +
+	"fromNodeId\x12\x1c\n" +
+	"\n" +
+	"to_node_id\x18\x02 \x01(\tR\btoNodeId\x120\n" +
+	"\x14upstream_zmq_address\x18\x03 \x01(\tR\x12upstreamZmqAddress\x124\n" +
+	"\x16upstream_store_address\x18\x04 \x01(\tR\x14upstreamStoreAddress\x126\n" +
+	"\x17upstream_logger_address\x18\x05 \x01(\tR\x15upstreamLoggerAddress\x12a\n" +
+	"\x0fupstream_tokens\x18\x06 \x03(\v28.scheduler.TransferComponentsRequest.UpstreamTokensEntryR\x0eupstreamTokens\x12#\n" +
+	"\rcomponent_ids\x18\a \x03(\tR\fcomponentIds\x1aA\n" +
+	"\x13UpstreamTokensEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc5\x02\n" +
+	"\x1aTransferComponentsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x17\n" +
+	"\anode_id\x18\x03 \x01(\tR\x06nodeId\x12\x1b\n" +
+	"\tnode_name\x18\x04 \x01(\tR\bnodeName\x12;\n" +
+	"\n" +
+	"components\x18\x05 \x03(\v2\x1b.scheduler.ComponentSummaryR\n" +
+	"components\x12I\n" +
+	"\x06failed\x18\x06 \x03(\v21.scheduler.TransferComponentsResponse.FailedEntryR\x06failed\x1a9\n" +
+	"\vFailedEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa0\x01\n" +
 	"\rComponentInfo\x12!\n" +
 	"\fcomponent_id\x18\x01 \x01(\tR\vcomponentId\x12\x14\n" +
 	"\x05image\x18\x02 \x01(\tR\x05image\x125\n" +
@@ -504,16 +2201,28 @@ const file_resource_scheduler_scheduler_proto_rawDesc = "" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\x122\n" +
 	"\x06status\x18\x03 \x01(\x0e2\x1a.scheduler.ComponentStatusR\x06status\x126\n" +
-	"\tcomponent\x18\x04 \x01(\v2\x18.scheduler.ComponentInfoR\tcomponent*\xa7\x01\n" +
+	"\tcomponent\x18\x04 \x01(\v2\x18.scheduler.ComponentInfoR\tcomponent*h\n" +
+	"\x0fReconcileAction\x12\x1c\n" +
+	"\x18RECONCILE_ACTION_PENDING\x10\x00\x12\x1a\n" +
+	"\x16RECONCILE_ACTION_ADOPT\x10\x01\x12\x1b\n" +
+	"\x17RECONCILE_ACTION_DELETE\x10\x02*\xa7\x01\n" +
 	"\x0fComponentStatus\x12\x1c\n" +
 	"\x18COMPONENT_STATUS_UNKNOWN\x10\x00\x12\x1e\n" +
 	"\x1aCOMPONENT_STATUS_DEPLOYING\x10\x01\x12\x1c\n" +
 	"\x18COMPONENT_STATUS_RUNNING\x10\x02\x12\x1c\n" +
 	"\x18COMPONENT_STATUS_STOPPED\x10\x03\x12\x1a\n" +
-	"\x16COMPONENT_STATUS_ERROR\x10\x042\xd2\x01\n" +
+	"\x16COMPONENT_STATUS_ERROR\x10\x042\xc9\a\n" +
 	"\x10SchedulerService\x12X\n" +
 	"\x0fDeployComponent\x12!.scheduler.DeployComponentRequest\x1a\".scheduler.DeployComponentResponse\x12d\n" +
-	"\x13GetDeploymentStatus\x12%.scheduler.GetDeploymentStatusRequest\x1a&.scheduler.GetDeploymentStatusResponseB=Z;github.com/9triver/iarnet/internal/proto/resource/schedulerb\x06proto3"
+	"\x13GetDeploymentStatus\x12%.scheduler.GetDeploymentStatusRequest\x1a&.scheduler.GetDeploymentStatusResponse\x12^\n" +
+	"\x11ProposeDeployment\x12#.scheduler.ProposeDeploymentRequest\x1a$.scheduler.ProposeDeploymentResponse\x12a\n" +
+	"\x12GetNodeUtilization\x12$.scheduler.GetNodeUtilizationRequest\x1a%.scheduler.GetNodeUtilizationResponse\x12^\n" +
+	"\x11UndeployComponent\x12#.scheduler.UndeployComponentRequest\x1a$.scheduler.UndeployComponentResponse\x12R\n" +
+	"\rListProviders\x12\x1f.scheduler.ListProvidersRequest\x1a .scheduler.ListProvidersResponse\x12^\n" +
+	"\x13ListRemoteProviders\x12%.scheduler.ListRemoteProvidersRequest\x1a .scheduler.ListProvidersResponse\x12U\n" +
+	"\x0eListComponents\x12 .scheduler.ListComponentsRequest\x1a!.scheduler.ListComponentsResponse\x12d\n" +
+	"\x13ReconcileComponents\x12%.scheduler.ReconcileComponentsRequest\x1a&.scheduler.ReconcileComponentsResponse\x12a\n" +
+	"\x12TransferComponents\x12$.scheduler.TransferComponentsRequest\x1a%.scheduler.TransferComponentsResponseB=Z;github.com/9triver/iarnet/internal/proto/resource/schedulerb\x06proto3"
 
 var (
 	file_resource_scheduler_scheduler_proto_rawDescOnce sync.Once
@@ -527,32 +2236,98 @@ func file_resource_scheduler_scheduler_proto_rawDescGZIP() []byte {
 	return file_resource_scheduler_scheduler_proto_rawDescData
 }
 
-var file_resource_scheduler_scheduler_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_resource_scheduler_scheduler_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_resource_scheduler_scheduler_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_resource_scheduler_scheduler_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
 var file_resource_scheduler_scheduler_proto_goTypes = []any{
-	(ComponentStatus)(0),                // 0: scheduler.ComponentStatus
-	(*DeployComponentRequest)(nil),      // 1: scheduler.DeployComponentRequest
-	(*DeployComponentResponse)(nil),     // 2: scheduler.DeployComponentResponse
-	(*ComponentInfo)(nil),               // 3: scheduler.ComponentInfo
-	(*GetDeploymentStatusRequest)(nil),  // 4: scheduler.GetDeploymentStatusRequest
-	(*GetDeploymentStatusResponse)(nil), // 5: scheduler.GetDeploymentStatusResponse
-	(*resource.Info)(nil),               // 6: resource.Info
+	(ReconcileAction)(0),                // 0: scheduler.ReconcileAction
+	(ComponentStatus)(0),                // 1: scheduler.ComponentStatus
+	(*DeployComponentRequest)(nil),      // 2: scheduler.DeployComponentRequest
+	(*DeployComponentResponse)(nil),     // 3: scheduler.DeployComponentResponse
+	(*UndeployComponentRequest)(nil),    // 4: scheduler.UndeployComponentRequest
+	(*UndeployComponentResponse)(nil),   // 5: scheduler.UndeployComponentResponse
+	(*ProposeDeploymentRequest)(nil),    // 6: scheduler.ProposeDeploymentRequest
+	(*ProposeDeploymentResponse)(nil),   // 7: scheduler.ProposeDeploymentResponse
+	(*GetNodeUtilizationRequest)(nil),   // 8: scheduler.GetNodeUtilizationRequest
+	(*GetNodeUtilizationResponse)(nil),  // 9: scheduler.GetNodeUtilizationResponse
+	(*ProviderUtilization)(nil),         // 10: scheduler.ProviderUtilization
+	(*ListProvidersRequest)(nil),        // 11: scheduler.ListProvidersRequest
+	(*ListRemoteProvidersRequest)(nil),  // 12: scheduler.ListRemoteProvidersRequest
+	(*ListProvidersResponse)(nil),       // 13: scheduler.ListProvidersResponse
+	(*ListComponentsRequest)(nil),       // 14: scheduler.ListComponentsRequest
+	(*ListComponentsResponse)(nil),      // 15: scheduler.ListComponentsResponse
+	(*ComponentSummary)(nil),            // 16: scheduler.ComponentSummary
+	(*ReconcileComponentsRequest)(nil),  // 17: scheduler.ReconcileComponentsRequest
+	(*DelegatedComponent)(nil),          // 18: scheduler.DelegatedComponent
+	(*ReconcileComponentsResponse)(nil), // 19: scheduler.ReconcileComponentsResponse
+	(*TransferComponentsRequest)(nil),   // 20: scheduler.TransferComponentsRequest
+	(*TransferComponentsResponse)(nil),  // 21: scheduler.TransferComponentsResponse
+	(*ComponentInfo)(nil),               // 22: scheduler.ComponentInfo
+	(*GetDeploymentStatusRequest)(nil),  // 23: scheduler.GetDeploymentStatusRequest
+	(*GetDeploymentStatusResponse)(nil), // 24: scheduler.GetDeploymentStatusResponse
+	nil,                                 // 25: scheduler.DeployComponentRequest.LabelsEntry
+	nil,                                 // 26: scheduler.ListComponentsRequest.LabelsEntry
+	nil,                                 // 27: scheduler.ComponentSummary.LabelsEntry
+	nil,                                 // 28: scheduler.ReconcileComponentsResponse.ActionsEntry
+	nil,                                 // 29: scheduler.TransferComponentsRequest.UpstreamTokensEntry
+	nil,                                 // 30: scheduler.TransferComponentsResponse.FailedEntry
+	(*resource.Info)(nil),               // 31: resource.Info
+	(*common.DataSource)(nil),           // 32: common.DataSource
+	(*common.SecurityContext)(nil),      // 33: common.SecurityContext
+	(*common.Sidecar)(nil),              // 34: common.Sidecar
+	(*resource.Capacity)(nil),           // 35: resource.Capacity
 }
 var file_resource_scheduler_scheduler_proto_depIdxs = []int32{
-	6, // 0: scheduler.DeployComponentRequest.resource_request:type_name -> resource.Info
-	3, // 1: scheduler.DeployComponentResponse.component:type_name -> scheduler.ComponentInfo
-	6, // 2: scheduler.ComponentInfo.resource_usage:type_name -> resource.Info
-	0, // 3: scheduler.GetDeploymentStatusResponse.status:type_name -> scheduler.ComponentStatus
-	3, // 4: scheduler.GetDeploymentStatusResponse.component:type_name -> scheduler.ComponentInfo
-	1, // 5: scheduler.SchedulerService.DeployComponent:input_type -> scheduler.DeployComponentRequest
-	4, // 6: scheduler.SchedulerService.GetDeploymentStatus:input_type -> scheduler.GetDeploymentStatusRequest
-	2, // 7: scheduler.SchedulerService.DeployComponent:output_type -> scheduler.DeployComponentResponse
-	5, // 8: scheduler.SchedulerService.GetDeploymentStatus:output_type -> scheduler.GetDeploymentStatusResponse
-	7, // [7:9] is the sub-list for method output_type
-	5, // [5:7] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	31, // 0: scheduler.DeployComponentRequest.resource_request:type_name -> resource.Info
+	32, // 1: scheduler.DeployComponentRequest.data_sources:type_name -> common.DataSource
+	33, // 2: scheduler.DeployComponentRequest.security_context:type_name -> common.SecurityContext
+	34, // 3: scheduler.DeployComponentRequest.sidecars:type_name -> common.Sidecar
+	25, // 4: scheduler.DeployComponentRequest.labels:type_name -> scheduler.DeployComponentRequest.LabelsEntry
+	22, // 5: scheduler.DeployComponentResponse.component:type_name -> scheduler.ComponentInfo
+	31, // 6: scheduler.ProposeDeploymentRequest.resource_request:type_name -> resource.Info
+	31, // 7: scheduler.ProposeDeploymentResponse.available:type_name -> resource.Info
+	35, // 8: scheduler.GetNodeUtilizationResponse.capacity:type_name -> resource.Capacity
+	10, // 9: scheduler.GetNodeUtilizationResponse.providers:type_name -> scheduler.ProviderUtilization
+	35, // 10: scheduler.ProviderUtilization.capacity:type_name -> resource.Capacity
+	11, // 11: scheduler.ListRemoteProvidersRequest.query:type_name -> scheduler.ListProvidersRequest
+	10, // 12: scheduler.ListProvidersResponse.providers:type_name -> scheduler.ProviderUtilization
+	26, // 13: scheduler.ListComponentsRequest.labels:type_name -> scheduler.ListComponentsRequest.LabelsEntry
+	16, // 14: scheduler.ListComponentsResponse.components:type_name -> scheduler.ComponentSummary
+	27, // 15: scheduler.ComponentSummary.labels:type_name -> scheduler.ComponentSummary.LabelsEntry
+	31, // 16: scheduler.ComponentSummary.resource_request:type_name -> resource.Info
+	18, // 17: scheduler.ReconcileComponentsRequest.components:type_name -> scheduler.DelegatedComponent
+	28, // 18: scheduler.ReconcileComponentsResponse.actions:type_name -> scheduler.ReconcileComponentsResponse.ActionsEntry
+	29, // 19: scheduler.TransferComponentsRequest.upstream_tokens:type_name -> scheduler.TransferComponentsRequest.UpstreamTokensEntry
+	16, // 20: scheduler.TransferComponentsResponse.components:type_name -> scheduler.ComponentSummary
+	30, // 21: scheduler.TransferComponentsResponse.failed:type_name -> scheduler.TransferComponentsResponse.FailedEntry
+	31, // 22: scheduler.ComponentInfo.resource_usage:type_name -> resource.Info
+	1,  // 23: scheduler.GetDeploymentStatusResponse.status:type_name -> scheduler.ComponentStatus
+	22, // 24: scheduler.GetDeploymentStatusResponse.component:type_name -> scheduler.ComponentInfo
+	0,  // 25: scheduler.ReconcileComponentsResponse.ActionsEntry.value:type_name -> scheduler.ReconcileAction
+	2,  // 26: scheduler.SchedulerService.DeployComponent:input_type -> scheduler.DeployComponentRequest
+	23, // 27: scheduler.SchedulerService.GetDeploymentStatus:input_type -> scheduler.GetDeploymentStatusRequest
+	6,  // 28: scheduler.SchedulerService.ProposeDeployment:input_type -> scheduler.ProposeDeploymentRequest
+	8,  // 29: scheduler.SchedulerService.GetNodeUtilization:input_type -> scheduler.GetNodeUtilizationRequest
+	4,  // 30: scheduler.SchedulerService.UndeployComponent:input_type -> scheduler.UndeployComponentRequest
+	11, // 31: scheduler.SchedulerService.ListProviders:input_type -> scheduler.ListProvidersRequest
+	12, // 32: scheduler.SchedulerService.ListRemoteProviders:input_type -> scheduler.ListRemoteProvidersRequest
+	14, // 33: scheduler.SchedulerService.ListComponents:input_type -> scheduler.ListComponentsRequest
+	17, // 34: scheduler.SchedulerService.ReconcileComponents:input_type -> scheduler.ReconcileComponentsRequest
+	20, // 35: scheduler.SchedulerService.TransferComponents:input_type -> scheduler.TransferComponentsRequest
+	3,  // 36: scheduler.SchedulerService.DeployComponent:output_type -> scheduler.DeployComponentResponse
+	24, // 37: scheduler.SchedulerService.GetDeploymentStatus:output_type -> scheduler.GetDeploymentStatusResponse
+	7,  // 38: scheduler.SchedulerService.ProposeDeployment:output_type -> scheduler.ProposeDeploymentResponse
+	9,  // 39: scheduler.SchedulerService.GetNodeUtilization:output_type -> scheduler.GetNodeUtilizationResponse
+	5,  // 40: scheduler.SchedulerService.UndeployComponent:output_type -> scheduler.UndeployComponentResponse
+	13, // 41: scheduler.SchedulerService.ListProviders:output_type -> scheduler.ListProvidersResponse
+	13, // 42: scheduler.SchedulerService.ListRemoteProviders:output_type -> scheduler.ListProvidersResponse
+	15, // 43: scheduler.SchedulerService.ListComponents:output_type -> scheduler.ListComponentsResponse
+	19, // 44: scheduler.SchedulerService.ReconcileComponents:output_type -> scheduler.ReconcileComponentsResponse
+	21, // 45: scheduler.SchedulerService.TransferComponents:output_type -> scheduler.TransferComponentsResponse
+	36, // [36:46] is the sub-list for method output_type
+	26, // [26:36] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
 }
 
 func init() { file_resource_scheduler_scheduler_proto_init() }
@@ -565,8 +2340,8 @@ func file_resource_scheduler_scheduler_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_resource_scheduler_scheduler_proto_rawDesc), len(file_resource_scheduler_scheduler_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   5,
+			NumEnums:      2,
+			NumMessages:   29,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
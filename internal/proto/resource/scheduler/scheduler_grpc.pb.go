@@ -21,6 +21,14 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	SchedulerService_DeployComponent_FullMethodName     = "/scheduler.SchedulerService/DeployComponent"
 	SchedulerService_GetDeploymentStatus_FullMethodName = "/scheduler.SchedulerService/GetDeploymentStatus"
+	SchedulerService_ProposeDeployment_FullMethodName   = "/scheduler.SchedulerService/ProposeDeployment"
+	SchedulerService_GetNodeUtilization_FullMethodName  = "/scheduler.SchedulerService/GetNodeUtilization"
+	SchedulerService_UndeployComponent_FullMethodName   = "/scheduler.SchedulerService/UndeployComponent"
+	SchedulerService_ListProviders_FullMethodName       = "/scheduler.SchedulerService/ListProviders"
+	SchedulerService_ListRemoteProviders_FullMethodName = "/scheduler.SchedulerService/ListRemoteProviders"
+	SchedulerService_ListComponents_FullMethodName      = "/scheduler.SchedulerService/ListComponents"
+	SchedulerService_ReconcileComponents_FullMethodName = "/scheduler.SchedulerService/ReconcileComponents"
+	SchedulerService_TransferComponents_FullMethodName  = "/scheduler.SchedulerService/TransferComponents"
 )
 
 // SchedulerServiceClient is the client API for SchedulerService service.
@@ -34,6 +42,25 @@ type SchedulerServiceClient interface {
 	DeployComponent(ctx context.Context, in *DeployComponentRequest, opts ...grpc.CallOption) (*DeployComponentResponse, error)
 	// GetDeploymentStatus 获取部署状态
 	GetDeploymentStatus(ctx context.Context, in *GetDeploymentStatusRequest, opts ...grpc.CallOption) (*GetDeploymentStatusResponse, error)
+	// ProposeDeployment 询问节点能否部署 component，不实际部署
+	// 委托部署前用于并行探测候选节点，节点接受后再通过 DeployComponent 提交
+	ProposeDeployment(ctx context.Context, in *ProposeDeploymentRequest, opts ...grpc.CallOption) (*ProposeDeploymentResponse, error)
+	// GetNodeUtilization 获取节点聚合后的资源利用率及各 provider 明细
+	GetNodeUtilization(ctx context.Context, in *GetNodeUtilizationRequest, opts ...grpc.CallOption) (*GetNodeUtilizationResponse, error)
+	// UndeployComponent 删除由 DeployComponent 部署的 component
+	// 调用方取消进行中的部署时用于补偿回滚
+	UndeployComponent(ctx context.Context, in *UndeployComponentRequest, opts ...grpc.CallOption) (*UndeployComponentResponse, error)
+	// ListProviders 分页列举本节点的 provider，支持字段掩码与自指定版本以来的增量
+	ListProviders(ctx context.Context, in *ListProvidersRequest, opts ...grpc.CallOption) (*ListProvidersResponse, error)
+	// ListRemoteProviders 经本节点分页列举同域其他节点的 provider，查询条件原样转发给目标节点
+	ListRemoteProviders(ctx context.Context, in *ListRemoteProvidersRequest, opts ...grpc.CallOption) (*ListProvidersResponse, error)
+	ListComponents(ctx context.Context, in *ListComponentsRequest, opts ...grpc.CallOption) (*ListComponentsResponse, error)
+	// ReconcileComponents 上报本节点上由接收方委托部署的 component，接收方逐个确认保留或删除
+	// 用于清理委托方提交部署后未收到响应（e.g., 响应丢失）而遗留在本节点的 component
+	ReconcileComponents(ctx context.Context, in *ReconcileComponentsRequest, opts ...grpc.CallOption) (*ReconcileComponentsResponse, error)
+	// TransferComponents 将本节点上由已离开的节点委托部署的 component 转移给接管节点（e.g., 域的 head）
+	// 本节点更新这些 component 的上游 ZMQ/Store/Logger 地址并在 provider 上重新部署，此后向接管节点对账
+	TransferComponents(ctx context.Context, in *TransferComponentsRequest, opts ...grpc.CallOption) (*TransferComponentsResponse, error)
 }
 
 type schedulerServiceClient struct {
@@ -64,6 +91,86 @@ func (c *schedulerServiceClient) GetDeploymentStatus(ctx context.Context, in *Ge
 	return out, nil
 }
 
+func (c *schedulerServiceClient) ProposeDeployment(ctx context.Context, in *ProposeDeploymentRequest, opts ...grpc.CallOption) (*ProposeDeploymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProposeDeploymentResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_ProposeDeployment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) GetNodeUtilization(ctx context.Context, in *GetNodeUtilizationRequest, opts ...grpc.CallOption) (*GetNodeUtilizationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNodeUtilizationResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_GetNodeUtilization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) UndeployComponent(ctx context.Context, in *UndeployComponentRequest, opts ...grpc.CallOption) (*UndeployComponentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UndeployComponentResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_UndeployComponent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) ListProviders(ctx context.Context, in *ListProvidersRequest, opts ...grpc.CallOption) (*ListProvidersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProvidersResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_ListProviders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) ListRemoteProviders(ctx context.Context, in *ListRemoteProvidersRequest, opts ...grpc.CallOption) (*ListProvidersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProvidersResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_ListRemoteProviders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) ListComponents(ctx context.Context, in *ListComponentsRequest, opts ...grpc.CallOption) (*ListComponentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListComponentsResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_ListComponents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) ReconcileComponents(ctx context.Context, in *ReconcileComponentsRequest, opts ...grpc.CallOption) (*ReconcileComponentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReconcileComponentsResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_ReconcileComponents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) TransferComponents(ctx context.Context, in *TransferComponentsRequest, opts ...grpc.CallOption) (*TransferComponentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferComponentsResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_TransferComponents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SchedulerServiceServer is the server API for SchedulerService service.
 // All implementations must embed UnimplementedSchedulerServiceServer
 // for forward compatibility.
@@ -75,6 +182,25 @@ type SchedulerServiceServer interface {
 	DeployComponent(context.Context, *DeployComponentRequest) (*DeployComponentResponse, error)
 	// GetDeploymentStatus 获取部署状态
 	GetDeploymentStatus(context.Context, *GetDeploymentStatusRequest) (*GetDeploymentStatusResponse, error)
+	// ProposeDeployment 询问节点能否部署 component，不实际部署
+	// 委托部署前用于并行探测候选节点，节点接受后再通过 DeployComponent 提交
+	ProposeDeployment(context.Context, *ProposeDeploymentRequest) (*ProposeDeploymentResponse, error)
+	// GetNodeUtilization 获取节点聚合后的资源利用率及各 provider 明细
+	GetNodeUtilization(context.Context, *GetNodeUtilizationRequest) (*GetNodeUtilizationResponse, error)
+	// UndeployComponent 删除由 DeployComponent 部署的 component
+	// 调用方取消进行中的部署时用于补偿回滚
+	UndeployComponent(context.Context, *UndeployComponentRequest) (*UndeployComponentResponse, error)
+	// ListProviders 分页列举本节点的 provider，支持字段掩码与自指定版本以来的增量
+	ListProviders(context.Context, *ListProvidersRequest) (*ListProvidersResponse, error)
+	// ListRemoteProviders 经本节点分页列举同域其他节点的 provider，查询条件原样转发给目标节点
+	ListRemoteProviders(context.Context, *ListRemoteProvidersRequest) (*ListProvidersResponse, error)
+	ListComponents(context.Context, *ListComponentsRequest) (*ListComponentsResponse, error)
+	// ReconcileComponents 上报本节点上由接收方委托部署的 component，接收方逐个确认保留或删除
+	// 用于清理委托方提交部署后未收到响应（e.g., 响应丢失）而遗留在本节点的 component
+	ReconcileComponents(context.Context, *ReconcileComponentsRequest) (*ReconcileComponentsResponse, error)
+	// TransferComponents 将本节点上由已离开的节点委托部署的 component 转移给接管节点（e.g., 域的 head）
+	// 本节点更新这些 component 的上游 ZMQ/Store/Logger 地址并在 provider 上重新部署，此后向接管节点对账
+	TransferComponents(context.Context, *TransferComponentsRequest) (*TransferComponentsResponse, error)
 	mustEmbedUnimplementedSchedulerServiceServer()
 }
 
@@ -91,6 +217,30 @@ func (UnimplementedSchedulerServiceServer) DeployComponent(context.Context, *Dep
 func (UnimplementedSchedulerServiceServer) GetDeploymentStatus(context.Context, *GetDeploymentStatusRequest) (*GetDeploymentStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetDeploymentStatus not implemented")
 }
+func (UnimplementedSchedulerServiceServer) ProposeDeployment(context.Context, *ProposeDeploymentRequest) (*ProposeDeploymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProposeDeployment not implemented")
+}
+func (UnimplementedSchedulerServiceServer) GetNodeUtilization(context.Context, *GetNodeUtilizationRequest) (*GetNodeUtilizationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNodeUtilization not implemented")
+}
+func (UnimplementedSchedulerServiceServer) UndeployComponent(context.Context, *UndeployComponentRequest) (*UndeployComponentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndeployComponent not implemented")
+}
+func (UnimplementedSchedulerServiceServer) ListProviders(context.Context, *ListProvidersRequest) (*ListProvidersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProviders not implemented")
+}
+func (UnimplementedSchedulerServiceServer) ListRemoteProviders(context.Context, *ListRemoteProvidersRequest) (*ListProvidersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRemoteProviders not implemented")
+}
+func (UnimplementedSchedulerServiceServer) ListComponents(context.Context, *ListComponentsRequest) (*ListComponentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListComponents not implemented")
+}
+func (UnimplementedSchedulerServiceServer) ReconcileComponents(context.Context, *ReconcileComponentsRequest) (*ReconcileComponentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcileComponents not implemented")
+}
+func (UnimplementedSchedulerServiceServer) TransferComponents(context.Context, *TransferComponentsRequest) (*TransferComponentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferComponents not implemented")
+}
 func (UnimplementedSchedulerServiceServer) mustEmbedUnimplementedSchedulerServiceServer() {}
 func (UnimplementedSchedulerServiceServer) testEmbeddedByValue()                          {}
 
@@ -148,6 +298,150 @@ func _SchedulerService_GetDeploymentStatus_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SchedulerService_ProposeDeployment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProposeDeploymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).ProposeDeployment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_ProposeDeployment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).ProposeDeployment(ctx, req.(*ProposeDeploymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_GetNodeUtilization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeUtilizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).GetNodeUtilization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_GetNodeUtilization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).GetNodeUtilization(ctx, req.(*GetNodeUtilizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_UndeployComponent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeployComponentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).UndeployComponent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_UndeployComponent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).UndeployComponent(ctx, req.(*UndeployComponentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_ListProviders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProvidersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).ListProviders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_ListProviders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).ListProviders(ctx, req.(*ListProvidersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_ListRemoteProviders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRemoteProvidersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).ListRemoteProviders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_ListRemoteProviders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).ListRemoteProviders(ctx, req.(*ListRemoteProvidersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_ListComponents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListComponentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).ListComponents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_ListComponents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).ListComponents(ctx, req.(*ListComponentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_ReconcileComponents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileComponentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).ReconcileComponents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_ReconcileComponents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).ReconcileComponents(ctx, req.(*ReconcileComponentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_TransferComponents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferComponentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).TransferComponents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_TransferComponents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).TransferComponents(ctx, req.(*TransferComponentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SchedulerService_ServiceDesc is the grpc.ServiceDesc for SchedulerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -163,6 +457,38 @@ var SchedulerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetDeploymentStatus",
 			Handler:    _SchedulerService_GetDeploymentStatus_Handler,
 		},
+		{
+			MethodName: "ProposeDeployment",
+			Handler:    _SchedulerService_ProposeDeployment_Handler,
+		},
+		{
+			MethodName: "GetNodeUtilization",
+			Handler:    _SchedulerService_GetNodeUtilization_Handler,
+		},
+		{
+			MethodName: "UndeployComponent",
+			Handler:    _SchedulerService_UndeployComponent_Handler,
+		},
+		{
+			MethodName: "ListProviders",
+			Handler:    _SchedulerService_ListProviders_Handler,
+		},
+		{
+			MethodName: "ListRemoteProviders",
+			Handler:    _SchedulerService_ListRemoteProviders_Handler,
+		},
+		{
+			MethodName: "ListComponents",
+			Handler:    _SchedulerService_ListComponents_Handler,
+		},
+		{
+			MethodName: "ReconcileComponents",
+			Handler:    _SchedulerService_ReconcileComponents_Handler,
+		},
+		{
+			MethodName: "TransferComponents",
+			Handler:    _SchedulerService_TransferComponents_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "resource/scheduler/scheduler.proto",
@@ -0,0 +1,203 @@
+// Package handover 节点滚动重启时在新旧进程之间交接监听端口
+// 旧进程以相同的参数启动新进程，并通过继承的文件描述符传递 HTTP、gRPC 的监听 socket，
+// 新旧进程在交接期间共同接受连接，端口不会出现无人监听的空窗；新进程启动完成后通过管道通知旧进程退出
+package handover
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// 旧进程传递给新进程的环境变量
+const (
+	envListeners = "IARNET_HANDOVER_LISTENERS" // 监听 socket，格式为 addr=fd,addr=fd
+	envReadyFD   = "IARNET_HANDOVER_READY_FD"  // 新进程启动完成后写入的管道
+	envState     = "IARNET_HANDOVER_STATE"     // 旧进程导出的会话状态文件
+)
+
+var (
+	mu sync.Mutex
+
+	// inherited 从旧进程继承、尚未被使用的监听 socket，按监听地址索引
+	inherited map[string]*os.File
+	parsed    bool
+
+	// listeners 本进程的监听 socket，交接时传递给新进程
+	listeners = make(map[string]*net.TCPListener)
+)
+
+// Listen 监听 TCP 地址；存在从旧进程继承的同一地址的 socket 时直接复用
+// 返回的 listener 会被记录下来，交接时传递给新进程
+func Listen(network, addr string) (net.Listener, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	parseInherited()
+
+	var lis net.Listener
+	if f, ok := inherited[addr]; ok {
+		delete(inherited, addr)
+		l, err := net.FileListener(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to use inherited listener for %s: %w", addr, err)
+		}
+		logrus.Infof("Using listener for %s inherited from previous process", addr)
+		lis = l
+	} else {
+		l, err := net.Listen(network, addr)
+		if err != nil {
+			return nil, err
+		}
+		lis = l
+	}
+
+	if tcp, ok := lis.(*net.TCPListener); ok {
+		listeners[addr] = tcp
+	}
+	return lis, nil
+}
+
+// parseInherited 解析继承的监听 socket，调用方需持有 mu
+func parseInherited() {
+	if parsed {
+		return
+	}
+	parsed = true
+	inherited = make(map[string]*os.File)
+	for _, entry := range strings.Split(os.Getenv(envListeners), ",") {
+		addr, fdStr, ok := strings.Cut(entry, "=")
+		if !ok {
+			continue
+		}
+		fd, err := strconv.Atoi(fdStr)
+		if err != nil {
+			logrus.Warnf("Ignoring invalid inherited listener %q", entry)
+			continue
+		}
+		inherited[addr] = os.NewFile(uintptr(fd), addr)
+	}
+	os.Unsetenv(envListeners)
+}
+
+// CloseUnused 关闭未被使用的继承 socket（如配置修改了端口），在所有服务启动后调用
+func CloseUnused() {
+	mu.Lock()
+	defer mu.Unlock()
+	parseInherited()
+	for addr, f := range inherited {
+		logrus.Infof("Closing unused inherited listener for %s", addr)
+		f.Close()
+	}
+	inherited = make(map[string]*os.File)
+}
+
+// StatePath 返回旧进程导出的会话状态文件，本进程不是由交接启动时返回空字符串
+func StatePath() string {
+	return os.Getenv(envState)
+}
+
+// NotifyReady 通知旧进程本进程已启动完成，旧进程随即退出；本进程不是由交接启动时不做任何事
+func NotifyReady() error {
+	fdStr := os.Getenv(envReadyFD)
+	if fdStr == "" {
+		return nil
+	}
+	os.Unsetenv(envReadyFD)
+	os.Unsetenv(envState)
+
+	fd, err := strconv.Atoi(fdStr)
+	if err != nil {
+		return fmt.Errorf("invalid ready fd %q: %w", fdStr, err)
+	}
+	pipe := os.NewFile(uintptr(fd), "handover-ready")
+	defer pipe.Close()
+	if _, err := pipe.Write([]byte{1}); err != nil {
+		return fmt.Errorf("failed to notify previous process: %w", err)
+	}
+	return nil
+}
+
+// Spawn 以相同的参数启动新进程，传递本进程的监听 socket 与会话状态文件，并在 timeout 内等待新进程启动完成
+// 新进程启动失败或超时时将其终止并返回错误，本进程可继续提供服务
+func Spawn(statePath string, timeout time.Duration) (*os.Process, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate executable: %w", err)
+	}
+
+	readyR, readyW, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ready pipe: %w", err)
+	}
+	defer readyR.Close()
+
+	// 子进程中 ExtraFiles[i] 的描述符为 3+i
+	files := []*os.File{readyW}
+	var entries []string
+	mu.Lock()
+	for addr, lis := range listeners {
+		f, err := lis.File()
+		if err != nil {
+			mu.Unlock()
+			closeFiles(files)
+			return nil, fmt.Errorf("failed to duplicate listener for %s: %w", addr, err)
+		}
+		entries = append(entries, fmt.Sprintf("%s=%d", addr, 3+len(files)))
+		files = append(files, f)
+	}
+	mu.Unlock()
+
+	cmd := exec.Command(executable, os.Args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = files
+	cmd.Env = append(os.Environ(),
+		envReadyFD+"=3",
+		envListeners+"="+strings.Join(entries, ","),
+		envState+"="+statePath,
+	)
+	err = cmd.Start()
+	closeFiles(files)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start new process: %w", err)
+	}
+	logrus.Infof("Started new process %d with %d inherited listener(s)", cmd.Process.Pid, len(entries))
+
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+	ready := make(chan error, 1)
+	go func() {
+		buf := make([]byte, 1)
+		_, err := io.ReadFull(readyR, buf)
+		ready <- err
+	}()
+
+	select {
+	case err := <-ready:
+		if err == nil {
+			return cmd.Process, nil
+		}
+		// 管道在写入前关闭，说明新进程已退出
+		cmd.Process.Kill()
+		return nil, fmt.Errorf("new process exited before becoming ready: %v", <-exited)
+	case <-time.After(timeout):
+		cmd.Process.Kill()
+		return nil, fmt.Errorf("new process did not become ready within %s", timeout)
+	}
+}
+
+func closeFiles(files []*os.File) {
+	for _, f := range files {
+		f.Close()
+	}
+}
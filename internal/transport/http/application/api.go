@@ -20,6 +20,7 @@ func RegisterRoutes(router *mux.Router, am *application.Manager) {
 	api := NewAPI(am)
 	router.HandleFunc("/application/stats", api.handleGetApplicationStats).Methods("GET")
 	router.HandleFunc("/application/runner-environments", api.handleGetRunnerEnvironments).Methods("GET")
+	router.HandleFunc("/application/controllers", api.handleGetControllers).Methods("GET")
 	router.HandleFunc("/application/apps", api.handleGetApplicationList).Methods("GET")
 	router.HandleFunc("/application/apps", api.handleCreateApplication).Methods("POST")
 	router.HandleFunc("/application/apps/{id}", api.handleGetApplicationById).Methods("GET")
@@ -27,6 +28,10 @@ func RegisterRoutes(router *mux.Router, am *application.Manager) {
 	router.HandleFunc("/application/apps/{id}", api.handleDeleteApplication).Methods("DELETE")
 	router.HandleFunc("/application/apps/{id}/run", api.handleRunApplication).Methods("POST")
 	router.HandleFunc("/application/apps/{id}/stop", api.handleStopApplication).Methods("POST")
+	router.HandleFunc("/application/apps/{id}/status", api.handleGetApplicationStatus).Methods("GET")
+	router.HandleFunc("/application/apps/{id}/slo", api.handleGetSLO).Methods("GET")
+	router.HandleFunc("/application/apps/{id}/slo", api.handleSetSLO).Methods("PUT")
+	router.HandleFunc("/application/apps/{id}/slo", api.handleDeleteSLO).Methods("DELETE")
 	// 文件管理相关路由
 	router.HandleFunc("/application/apps/{id}/files", api.handleGetFileTree).Methods("GET")
 	router.HandleFunc("/application/apps/{id}/files/content", api.handleGetFileContent).Methods("GET")
@@ -42,6 +47,18 @@ func RegisterRoutes(router *mux.Router, am *application.Manager) {
 	// 执行结果相关路由
 	router.HandleFunc("/application/apps/{id}/execution-result", api.handleGetExecutionResult).Methods("GET")
 	router.HandleFunc("/application/apps/{id}/logs", api.handleGetApplicationLogs).Methods("GET")
+	// 批处理任务相关路由
+	router.HandleFunc("/application/apps/{id}/jobs", api.handleListJobs).Methods("GET")
+	router.HandleFunc("/application/apps/{id}/jobs", api.handleSubmitJob).Methods("POST")
+	router.HandleFunc("/application/apps/{id}/jobs/{job_id}", api.handleGetJob).Methods("GET")
+	router.HandleFunc("/application/apps/{id}/jobs/{job_id}", api.handleDeleteJob).Methods("DELETE")
+	router.HandleFunc("/application/apps/{id}/jobs/{job_id}/cancel", api.handleCancelJob).Methods("POST")
+	router.HandleFunc("/application/apps/{id}/cronjobs", api.handleListCronJobs).Methods("GET")
+	router.HandleFunc("/application/apps/{id}/cronjobs", api.handleCreateCronJob).Methods("POST")
+	router.HandleFunc("/application/apps/{id}/cronjobs/{cron_id}", api.handleGetCronJob).Methods("GET")
+	router.HandleFunc("/application/apps/{id}/cronjobs/{cron_id}", api.handleDeleteCronJob).Methods("DELETE")
+	router.HandleFunc("/application/apps/{id}/cronjobs/{cron_id}/suspend", api.handleSuspendCronJob).Methods("POST")
+	router.HandleFunc("/application/apps/{id}/cronjobs/{cron_id}/resume", api.handleResumeCronJob).Methods("POST")
 }
 
 type API struct {
@@ -211,6 +228,12 @@ func (api *API) handleUpdateApplication(w http.ResponseWriter, r *http.Request)
 	if req.RunnerEnv != nil {
 		metadata.RunnerEnv = *req.RunnerEnv
 	}
+	if req.BuildCmd != nil {
+		metadata.BuildCmd = *req.BuildCmd
+	}
+	if req.BuildImage != nil {
+		metadata.BuildImage = *req.BuildImage
+	}
 
 	if err := api.am.UpdateAppMetadata(r.Context(), appID, metadata); err != nil {
 		logrus.Errorf("Failed to update app metadata: %v", err)
@@ -249,6 +272,9 @@ func (api *API) handleDeleteApplication(w http.ResponseWriter, r *http.Request)
 		// 继续删除，不因移除失败而中断
 	}
 
+	// 回收控制器
+	api.am.ReleaseController(ctx, appID)
+
 	// 清理工作目录
 	if err := api.am.CleanWorkDir(ctx, appID); err != nil {
 		logrus.Warnf("Failed to clean work dir for app %s: %v", appID, err)
@@ -307,24 +333,41 @@ func (api *API) handleStopApplication(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
-	// 停止 runner
-	if err := api.am.StopRunner(ctx, appID); err != nil {
-		logrus.Errorf("Failed to stop runner: %v", err)
+	// 停止 runner，更新状态并回收控制器
+	if err := api.am.StopApplication(ctx, appID); err != nil {
+		logrus.Errorf("Failed to stop application: %v", err)
 		response.InternalError("failed to stop application: " + err.Error()).WriteJSON(w)
 		return
 	}
 
-	// 更新应用状态为已停止
-	if err := api.am.UpdateAppStatus(ctx, appID, apptypes.AppStatusStopped); err != nil {
-		logrus.Errorf("Failed to update application status to stopped: %v", err)
-	}
-
 	// 获取更新后的应用信息
 	updatedMetadata, _ := api.am.GetAppMetadata(ctx, appID)
 	resp := FromAppMetadataToGetResponse(updatedMetadata)
 	response.Success(resp).WriteJSON(w)
 }
 
+// handleGetApplicationStatus 获取应用生命周期状态、component 明细和最近事件，供轮询使用
+func (api *API) handleGetApplicationStatus(w http.ResponseWriter, r *http.Request) {
+	appID := mux.Vars(r)["id"]
+	if appID == "" {
+		response.BadRequest("application id is required").WriteJSON(w)
+		return
+	}
+
+	report, err := api.am.GetApplicationStatus(r.Context(), appID)
+	if err != nil {
+		if strings.Contains(err.Error(), "application not found") {
+			response.NotFound("application not found").WriteJSON(w)
+			return
+		}
+		logrus.Errorf("Failed to get application status: %v", err)
+		response.InternalError("failed to get application status: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	response.Success(BuildGetApplicationStatusResponse(report)).WriteJSON(w)
+}
+
 func (api *API) handleGetApplicationStats(w http.ResponseWriter, r *http.Request) {
 	apps, err := api.am.GetAllAppMetadata(r.Context())
 	if err != nil {
@@ -345,6 +388,8 @@ func (api *API) handleGetApplicationStats(w http.ResponseWriter, r *http.Request
 		switch app.Status {
 		case apptypes.AppStatusRunning:
 			stats.Running++
+		case apptypes.AppStatusDegraded:
+			stats.Degraded++
 		case apptypes.AppStatusStopped:
 			stats.Stopped++
 		case apptypes.AppStatusUndeployed:
@@ -357,6 +402,11 @@ func (api *API) handleGetApplicationStats(w http.ResponseWriter, r *http.Request
 	response.Success(stats).WriteJSON(w)
 }
 
+// handleGetControllers 列出 ignis 控制器池中的控制器
+func (api *API) handleGetControllers(w http.ResponseWriter, r *http.Request) {
+	response.Success(BuildGetControllersResponse(api.am.ListControllers())).WriteJSON(w)
+}
+
 func (api *API) handleGetRunnerEnvironments(w http.ResponseWriter, r *http.Request) {
 	runnerEnvs := make([]string, 0)
 	for name := range api.am.GetRunnerImages() {
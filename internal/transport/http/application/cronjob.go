@@ -0,0 +1,113 @@
+package application
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/9triver/iarnet/internal/transport/http/util/response"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// handleCreateCronJob 为应用登记按 cron 表达式周期触发的 Job
+func (api *API) handleCreateCronJob(w http.ResponseWriter, r *http.Request) {
+	appID := mux.Vars(r)["id"]
+	req := CreateCronJobRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest("invalid request body: " + err.Error()).WriteJSON(w)
+		return
+	}
+	if req.Schedule == "" {
+		response.BadRequest("schedule is required").WriteJSON(w)
+		return
+	}
+	for _, limit := range []*int{req.SuccessfulJobsHistoryLimit, req.FailedJobsHistoryLimit, req.JobTemplate.BackoffLimit} {
+		if limit != nil && *limit < 0 {
+			response.BadRequest("history limits and backoff_limit must not be negative").WriteJSON(w)
+			return
+		}
+	}
+
+	status, err := api.am.CreateCronJob(r.Context(), appID, req.ToSpec())
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "application not found"):
+			response.NotFound("application not found").WriteJSON(w)
+		case strings.Contains(err.Error(), "not configured"):
+			response.ServiceUnavailable(err.Error()).WriteJSON(w)
+		case strings.Contains(err.Error(), "failed to save"):
+			logrus.Errorf("Failed to create cron job: %v", err)
+			response.InternalError(err.Error()).WriteJSON(w)
+		default:
+			response.BadRequest(err.Error()).WriteJSON(w)
+		}
+		return
+	}
+	response.Created(BuildCronJobResponse(status, nil)).WriteJSON(w)
+}
+
+// handleListCronJobs 列出应用的 CronJob
+func (api *API) handleListCronJobs(w http.ResponseWriter, r *http.Request) {
+	appID := mux.Vars(r)["id"]
+	cronJobs := api.am.ListCronJobs(appID)
+	resp := ListCronJobsResponse{
+		CronJobs: make([]CronJobResponse, 0, len(cronJobs)),
+		Total:    len(cronJobs),
+	}
+	for _, cj := range cronJobs {
+		resp.CronJobs = append(resp.CronJobs, BuildCronJobResponse(cj, api.am.ListCronJobJobs(cj.ID)))
+	}
+	response.Success(resp).WriteJSON(w)
+}
+
+// handleGetCronJob 获取 CronJob 定义、触发时间与保留的 Job
+func (api *API) handleGetCronJob(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	status, err := api.am.GetCronJob(vars["cron_id"])
+	if err != nil || status.AppID != vars["id"] {
+		response.NotFound("cron job not found").WriteJSON(w)
+		return
+	}
+	response.Success(BuildCronJobResponse(status, api.am.ListCronJobJobs(status.ID))).WriteJSON(w)
+}
+
+// handleSuspendCronJob 暂停 CronJob
+func (api *API) handleSuspendCronJob(w http.ResponseWriter, r *http.Request) {
+	api.setCronJobSuspend(w, r, true)
+}
+
+// handleResumeCronJob 恢复 CronJob
+func (api *API) handleResumeCronJob(w http.ResponseWriter, r *http.Request) {
+	api.setCronJobSuspend(w, r, false)
+}
+
+func (api *API) setCronJobSuspend(w http.ResponseWriter, r *http.Request, suspend bool) {
+	vars := mux.Vars(r)
+	if status, err := api.am.GetCronJob(vars["cron_id"]); err != nil || status.AppID != vars["id"] {
+		response.NotFound("cron job not found").WriteJSON(w)
+		return
+	}
+	status, err := api.am.SetCronJobSuspend(r.Context(), vars["cron_id"], suspend)
+	if err != nil {
+		logrus.Errorf("Failed to update cron job %s: %v", vars["cron_id"], err)
+		response.InternalError("failed to update cron job: " + err.Error()).WriteJSON(w)
+		return
+	}
+	response.Success(BuildCronJobResponse(status, api.am.ListCronJobJobs(status.ID))).WriteJSON(w)
+}
+
+// handleDeleteCronJob 删除 CronJob，已触发的 Job 继续运行
+func (api *API) handleDeleteCronJob(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if status, err := api.am.GetCronJob(vars["cron_id"]); err != nil || status.AppID != vars["id"] {
+		response.NotFound("cron job not found").WriteJSON(w)
+		return
+	}
+	if err := api.am.DeleteCronJob(r.Context(), vars["cron_id"]); err != nil {
+		logrus.Errorf("Failed to delete cron job %s: %v", vars["cron_id"], err)
+		response.InternalError("failed to delete cron job: " + err.Error()).WriteJSON(w)
+		return
+	}
+	response.Success(nil).WriteJSON(w)
+}
@@ -0,0 +1,94 @@
+package application
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/9triver/iarnet/internal/transport/http/util/response"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// handleSubmitJob 为应用提交 run-to-completion 的批处理任务
+func (api *API) handleSubmitJob(w http.ResponseWriter, r *http.Request) {
+	appID := mux.Vars(r)["id"]
+	req := SubmitJobRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest("invalid request body: " + err.Error()).WriteJSON(w)
+		return
+	}
+	if req.BackoffLimit != nil && *req.BackoffLimit < 0 {
+		response.BadRequest("backoff_limit must not be negative").WriteJSON(w)
+		return
+	}
+
+	status, err := api.am.SubmitJob(r.Context(), appID, req.ToSpec())
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "application not found"):
+			response.NotFound("application not found").WriteJSON(w)
+		case strings.Contains(err.Error(), "not configured"):
+			response.ServiceUnavailable(err.Error()).WriteJSON(w)
+		default:
+			response.BadRequest(err.Error()).WriteJSON(w)
+		}
+		return
+	}
+	response.Created(BuildJobResponse(status)).WriteJSON(w)
+}
+
+// handleListJobs 列出应用的 Job
+func (api *API) handleListJobs(w http.ResponseWriter, r *http.Request) {
+	appID := mux.Vars(r)["id"]
+	jobs := api.am.ListJobs(appID)
+	resp := ListJobsResponse{
+		Jobs:  make([]JobResponse, 0, len(jobs)),
+		Total: len(jobs),
+	}
+	for _, job := range jobs {
+		resp.Jobs = append(resp.Jobs, BuildJobResponse(job))
+	}
+	response.Success(resp).WriteJSON(w)
+}
+
+// handleGetJob 获取 Job 状态与执行记录，供轮询完成情况使用
+func (api *API) handleGetJob(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	status, err := api.am.GetJob(vars["job_id"])
+	if err != nil || status.AppID != vars["id"] {
+		response.NotFound("job not found").WriteJSON(w)
+		return
+	}
+	response.Success(BuildJobResponse(status)).WriteJSON(w)
+}
+
+// handleCancelJob 取消 Job 并删除其运行中的 component
+func (api *API) handleCancelJob(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if status, err := api.am.GetJob(vars["job_id"]); err != nil || status.AppID != vars["id"] {
+		response.NotFound("job not found").WriteJSON(w)
+		return
+	}
+	status, err := api.am.CancelJob(r.Context(), vars["job_id"])
+	if err != nil {
+		logrus.Errorf("Failed to cancel job %s: %v", vars["job_id"], err)
+		response.InternalError("failed to cancel job: " + err.Error()).WriteJSON(w)
+		return
+	}
+	response.Success(BuildJobResponse(status)).WriteJSON(w)
+}
+
+// handleDeleteJob 删除已结束的 Job 记录
+func (api *API) handleDeleteJob(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if status, err := api.am.GetJob(vars["job_id"]); err != nil || status.AppID != vars["id"] {
+		response.NotFound("job not found").WriteJSON(w)
+		return
+	}
+	if err := api.am.DeleteJob(vars["job_id"]); err != nil {
+		response.BadRequest(err.Error()).WriteJSON(w)
+		return
+	}
+	response.Success(nil).WriteJSON(w)
+}
@@ -0,0 +1,63 @@
+package application
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/9triver/iarnet/internal/transport/http/util/response"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// handleGetSLO 获取应用的 SLO 及当前窗口内的达成情况，未设置 SLO 时 slo 为空
+func (api *API) handleGetSLO(w http.ResponseWriter, r *http.Request) {
+	appID := mux.Vars(r)["id"]
+	status, err := api.am.GetSLOStatus(r.Context(), appID)
+	if err != nil {
+		if strings.Contains(err.Error(), "application not found") {
+			response.NotFound("application not found").WriteJSON(w)
+			return
+		}
+		logrus.Errorf("Failed to get SLO status of application %s: %v", appID, err)
+		response.InternalError("failed to get SLO status: " + err.Error()).WriteJSON(w)
+		return
+	}
+	response.Success(BuildGetSLOResponse(appID, status)).WriteJSON(w)
+}
+
+// handleSetSLO 设置应用的 SLO，替换已有的 SLO 并重新开始统计
+func (api *API) handleSetSLO(w http.ResponseWriter, r *http.Request) {
+	appID := mux.Vars(r)["id"]
+	req := SetSLORequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest("invalid request body: " + err.Error()).WriteJSON(w)
+		return
+	}
+	slo := req.ToSLO()
+	if err := api.am.SetSLO(r.Context(), appID, &slo); err != nil {
+		if strings.Contains(err.Error(), "application not found") {
+			response.NotFound("application not found").WriteJSON(w)
+			return
+		}
+		response.BadRequest(err.Error()).WriteJSON(w)
+		return
+	}
+	logrus.Infof("SLO of application %s set: %+v", appID, req)
+	api.handleGetSLO(w, r)
+}
+
+// handleDeleteSLO 移除应用的 SLO
+func (api *API) handleDeleteSLO(w http.ResponseWriter, r *http.Request) {
+	appID := mux.Vars(r)["id"]
+	if err := api.am.SetSLO(r.Context(), appID, nil); err != nil {
+		if strings.Contains(err.Error(), "application not found") {
+			response.NotFound("application not found").WriteJSON(w)
+			return
+		}
+		logrus.Errorf("Failed to remove SLO of application %s: %v", appID, err)
+		response.InternalError("failed to remove SLO: " + err.Error()).WriteJSON(w)
+		return
+	}
+	response.Success(map[string]string{"message": "SLO removed"}).WriteJSON(w)
+}
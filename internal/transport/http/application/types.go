@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/9triver/iarnet/internal/domain/application/types"
+	"github.com/9triver/iarnet/internal/domain/ignis/controller"
 	taskpkg "github.com/9triver/iarnet/internal/domain/ignis/task"
 )
 
@@ -12,11 +13,13 @@ import (
 type CreateApplicationRequest struct {
 	Name          string `json:"name" binding:"required"`       // 应用名称
 	GitURL        string `json:"git_url" binding:"required"`    // Git 仓库地址
-	Branch        string `json:"branch"`                        // Git 分支，默认为 "main"
+	Branch        string `json:"branch"`                        // Git 分支、标签或提交 SHA，默认为 "main"
 	Description   string `json:"description"`                   // 应用描述
 	ExecuteCmd    string `json:"execute_cmd"`                   // 执行命令
 	EnvInstallCmd string `json:"env_install_cmd"`               // 环境安装命令
 	RunnerEnv     string `json:"runner_env" binding:"required"` // 运行环境 (python/go/java)
+	BuildCmd      string `json:"build_cmd"`                     // 构建命令（可选），在 runner 镜像中执行
+	BuildImage    bool   `json:"build_image"`                   // 是否构建为 OCI 镜像后部署
 }
 
 // CreateApplicationResponse 创建应用响应
@@ -59,6 +62,10 @@ type ApplicationItem struct {
 	ExecuteCmd    string    `json:"execute_cmd"`     // 执行命令
 	EnvInstallCmd string    `json:"env_install_cmd"` // 环境安装命令
 	RunnerEnv     string    `json:"runner_env"`      // 运行环境
+	BuildCmd      string    `json:"build_cmd"`       // 构建命令
+	CommitSHA     string    `json:"commit_sha"`      // 当前提交 SHA
+	BuildImage    bool      `json:"build_image"`     // 是否构建为 OCI 镜像后部署
+	Image         string    `json:"image"`           // 最近一次构建出的镜像
 }
 
 func (r *ApplicationItem) FromAppMetadata(metadata types.AppMetadata) *ApplicationItem {
@@ -73,6 +80,10 @@ func (r *ApplicationItem) FromAppMetadata(metadata types.AppMetadata) *Applicati
 	r.ExecuteCmd = metadata.ExecuteCmd
 	r.EnvInstallCmd = metadata.EnvInstallCmd
 	r.RunnerEnv = metadata.RunnerEnv
+	r.BuildCmd = metadata.BuildCmd
+	r.CommitSHA = metadata.CommitSHA
+	r.BuildImage = metadata.BuildImage
+	r.Image = metadata.Image
 	return r
 }
 
@@ -89,6 +100,10 @@ type GetApplicationResponse struct {
 	ExecuteCmd    string    `json:"execute_cmd"`     // 执行命令
 	EnvInstallCmd string    `json:"env_install_cmd"` // 环境安装命令
 	RunnerEnv     string    `json:"runner_env"`      // 运行环境
+	BuildCmd      string    `json:"build_cmd"`       // 构建命令
+	CommitSHA     string    `json:"commit_sha"`      // 当前提交 SHA
+	BuildImage    bool      `json:"build_image"`     // 是否构建为 OCI 镜像后部署
+	Image         string    `json:"image"`           // 最近一次构建出的镜像
 	CreatedAt     time.Time `json:"created_at"`      // 创建时间（如果有）
 	UpdatedAt     time.Time `json:"updated_at"`      // 更新时间（如果有）
 }
@@ -104,11 +119,164 @@ type ErrorResponse struct {
 type ApplicationStats struct {
 	Total      int `json:"total"`      // 总应用数
 	Running    int `json:"running"`    // 运行中的应用数
+	Degraded   int `json:"degraded"`   // 部分 component 不健康的应用数
 	Stopped    int `json:"stopped"`    // 已停止的应用数
 	Undeployed int `json:"undeployed"` // 未部署的应用数
 	Failed     int `json:"failed"`     // 失败的应用数
 }
 
+// GetApplicationStatusResponse 应用状态响应
+type GetApplicationStatusResponse struct {
+	AppID      string                    `json:"app_id"`
+	Status     string                    `json:"status"`
+	Components []ComponentStatusResponse `json:"components"`
+	Events     []AppEventResponse        `json:"events"` // 按时间顺序
+}
+
+// ComponentStatusResponse 应用中单个 component 的状态
+type ComponentStatusResponse struct {
+	ComponentID string `json:"component_id"`
+	ActorID     string `json:"actor_id"`
+	Function    string `json:"function"`
+	ProviderID  string `json:"provider_id,omitempty"`
+	State       string `json:"state"` // deploying/running/unhealthy
+}
+
+// AppEventResponse 应用生命周期事件
+type AppEventResponse struct {
+	Time        time.Time `json:"time"`
+	From        string    `json:"from,omitempty"`
+	To          string    `json:"to,omitempty"`
+	ComponentID string    `json:"component_id,omitempty"`
+	Reason      string    `json:"reason"`
+}
+
+func BuildGetApplicationStatusResponse(report *types.AppStatusReport) GetApplicationStatusResponse {
+	resp := GetApplicationStatusResponse{
+		AppID:      string(report.AppID),
+		Status:     string(report.Status),
+		Components: make([]ComponentStatusResponse, 0, len(report.Components)),
+		Events:     make([]AppEventResponse, 0, len(report.Events)),
+	}
+	for _, c := range report.Components {
+		resp.Components = append(resp.Components, ComponentStatusResponse{
+			ComponentID: c.ComponentID,
+			ActorID:     c.ActorID,
+			Function:    c.Function,
+			ProviderID:  c.ProviderID,
+			State:       string(c.State),
+		})
+	}
+	for _, e := range report.Events {
+		resp.Events = append(resp.Events, AppEventResponse{
+			Time:        e.Time,
+			From:        string(e.From),
+			To:          string(e.To),
+			ComponentID: e.ComponentID,
+			Reason:      e.Reason,
+		})
+	}
+	return resp
+}
+
+// SetSLORequest 设置应用 SLO 请求，目标为 0 表示不约束该项
+type SetSLORequest struct {
+	LatencyP95Ms    int64   `json:"latency_p95_ms"`   // 函数调用 p95 延迟上限（毫秒）
+	MinAvailability float64 `json:"min_availability"` // 可用率下限（0-1）
+	WindowSeconds   int64   `json:"window_seconds"`   // 统计窗口（秒），0 表示默认 5 分钟
+}
+
+func (req *SetSLORequest) ToSLO() types.SLO {
+	return types.SLO{
+		LatencyP95:      time.Duration(req.LatencyP95Ms) * time.Millisecond,
+		MinAvailability: req.MinAvailability,
+		Window:          time.Duration(req.WindowSeconds) * time.Second,
+	}
+}
+
+// GetSLOResponse 应用 SLO 及其达成情况
+type GetSLOResponse struct {
+	AppID  string             `json:"app_id"`
+	SLO    *SetSLORequest     `json:"slo"`    // 未设置 SLO 时为空
+	Status *SLOStatusResponse `json:"status"` // 未设置 SLO 时为空
+}
+
+// SLOStatusResponse 当前窗口内的 SLO 达成情况
+type SLOStatusResponse struct {
+	LatencyP95Ms int64                  `json:"latency_p95_ms"` // 没有调用时为 0
+	Invocations  int                    `json:"invocations"`
+	Availability float64                `json:"availability"`
+	Violations   []SLOViolationResponse `json:"violations"`
+	EvaluatedAt  time.Time              `json:"evaluated_at"`
+}
+
+// SLOViolationResponse SLO 违反
+type SLOViolationResponse struct {
+	Kind         string   `json:"kind"` // latency/availability
+	Reason       string   `json:"reason"`
+	ComponentIDs []string `json:"component_ids,omitempty"`
+}
+
+func BuildGetSLOResponse(appID string, status *types.SLOStatus) GetSLOResponse {
+	resp := GetSLOResponse{AppID: appID}
+	if status == nil {
+		return resp
+	}
+	resp.SLO = &SetSLORequest{
+		LatencyP95Ms:    status.SLO.LatencyP95.Milliseconds(),
+		MinAvailability: status.SLO.MinAvailability,
+		WindowSeconds:   int64(status.SLO.Window / time.Second),
+	}
+	resp.Status = &SLOStatusResponse{
+		LatencyP95Ms: status.LatencyP95.Milliseconds(),
+		Invocations:  status.Invocations,
+		Availability: status.Availability,
+		Violations:   make([]SLOViolationResponse, 0, len(status.Violations)),
+		EvaluatedAt:  status.EvaluatedAt,
+	}
+	for _, v := range status.Violations {
+		resp.Status.Violations = append(resp.Status.Violations, SLOViolationResponse{
+			Kind:         string(v.Kind),
+			Reason:       v.Reason,
+			ComponentIDs: v.ComponentIDs,
+		})
+	}
+	return resp
+}
+
+// GetControllersResponse 控制器列表响应
+type GetControllersResponse struct {
+	Controllers []ControllerItem `json:"controllers"`
+	Total       int              `json:"total"`
+}
+
+// ControllerItem 控制器概要信息
+type ControllerItem struct {
+	AppID      string    `json:"app_id"`
+	Functions  int       `json:"functions"`
+	Actors     int       `json:"actors"`
+	Sessions   int       `json:"sessions"`
+	Connected  bool      `json:"connected"` // 是否有活跃的客户端连接
+	CreatedAt  time.Time `json:"created_at"`
+	LastActive time.Time `json:"last_active"`
+}
+
+func BuildGetControllersResponse(infos []controller.Info) GetControllersResponse {
+	items := make([]ControllerItem, 0, len(infos))
+	for _, info := range infos {
+		items = append(items, ControllerItem{
+			AppID:      info.AppID,
+			Functions:  info.Functions,
+			Actors:     info.Actors,
+			Sessions:   info.Sessions,
+			Connected:  info.Connected,
+			CreatedAt:  info.CreatedAt,
+			LastActive: info.LastActive,
+		})
+	}
+	return GetControllersResponse{Controllers: items, Total: len(items)}
+}
+
 // RunnerEnvironment 运行环境
 type RunnerEnvironment struct {
 	Name string `json:"name"` // 环境名称
@@ -128,6 +296,8 @@ type UpdateApplicationRequest struct {
 	ExecuteCmd    *string `json:"execute_cmd"`     // 执行命令（可选）
 	EnvInstallCmd *string `json:"env_install_cmd"` // 环境安装命令（可选）
 	RunnerEnv     *string `json:"runner_env"`      // 运行环境（可选）
+	BuildCmd      *string `json:"build_cmd"`       // 构建命令（可选）
+	BuildImage    *bool   `json:"build_image"`     // 是否构建为 OCI 镜像后部署（可选）
 }
 
 // ToAppMetadata 将 CreateApplicationRequest 转换为领域层的 AppMetadata
@@ -145,6 +315,8 @@ func (req *CreateApplicationRequest) ToAppMetadata() types.AppMetadata {
 		ExecuteCmd:    req.ExecuteCmd,
 		EnvInstallCmd: req.EnvInstallCmd,
 		RunnerEnv:     req.RunnerEnv,
+		BuildCmd:      req.BuildCmd,
+		BuildImage:    req.BuildImage,
 	}
 }
 
@@ -162,6 +334,10 @@ func ToApplicationItem(metadata types.AppMetadata) ApplicationItem {
 		ExecuteCmd:    metadata.ExecuteCmd,
 		EnvInstallCmd: metadata.EnvInstallCmd,
 		RunnerEnv:     metadata.RunnerEnv,
+		BuildCmd:      metadata.BuildCmd,
+		CommitSHA:     metadata.CommitSHA,
+		BuildImage:    metadata.BuildImage,
+		Image:         metadata.Image,
 	}
 }
 
@@ -179,6 +355,10 @@ func FromAppMetadataToGetResponse(metadata types.AppMetadata) GetApplicationResp
 		ExecuteCmd:    metadata.ExecuteCmd,
 		EnvInstallCmd: metadata.EnvInstallCmd,
 		RunnerEnv:     metadata.RunnerEnv,
+		BuildCmd:      metadata.BuildCmd,
+		CommitSHA:     metadata.CommitSHA,
+		BuildImage:    metadata.BuildImage,
+		Image:         metadata.Image,
 		CreatedAt:     metadata.LastDeployed, // 如果没有单独的 CreatedAt，使用 LastDeployed
 		UpdatedAt:     metadata.LastDeployed, // 如果没有单独的 UpdatedAt，使用 LastDeployed
 	}
@@ -525,3 +705,196 @@ func BuildGetApplicationActorsResponse(actors map[string][]*taskpkg.Actor) GetAp
 
 	return resp
 }
+
+// SubmitJobRequest 提交批处理任务的请求
+type SubmitJobRequest struct {
+	Name         string            `json:"name"`
+	RuntimeEnv   string            `json:"runtime_env,omitempty"` // 缺省为 python
+	CPU          int64             `json:"cpu"`                   // millicores
+	Memory       int64             `json:"memory"`                // bytes
+	GPU          int64             `json:"gpu"`
+	Env          map[string]string `json:"env,omitempty"`
+	Completions  int               `json:"completions,omitempty"`   // 缺省为 1
+	Parallelism  int               `json:"parallelism,omitempty"`   // 缺省为 1
+	BackoffLimit *int              `json:"backoff_limit,omitempty"` // 缺省为 6
+}
+
+// ToSpec 转换为 Job 定义，未指定 backoff limit 时交由领域层补全缺省值
+func (r *SubmitJobRequest) ToSpec() types.JobSpec {
+	spec := types.JobSpec{
+		Name:         r.Name,
+		RuntimeEnv:   r.RuntimeEnv,
+		CPU:          r.CPU,
+		Memory:       r.Memory,
+		GPU:          r.GPU,
+		Env:          r.Env,
+		Completions:  r.Completions,
+		Parallelism:  r.Parallelism,
+		BackoffLimit: -1,
+	}
+	if r.BackoffLimit != nil {
+		spec.BackoffLimit = *r.BackoffLimit
+	}
+	return spec
+}
+
+// JobResponse Job 状态
+type JobResponse struct {
+	ID           string               `json:"id"`
+	AppID        string               `json:"app_id"`
+	CronJobID    string               `json:"cron_job_id,omitempty"`
+	Name         string               `json:"name,omitempty"`
+	State        string               `json:"state"` // pending/running/succeeded/failed/cancelled
+	Completions  int                  `json:"completions"`
+	Parallelism  int                  `json:"parallelism"`
+	BackoffLimit int                  `json:"backoff_limit"`
+	Active       int                  `json:"active"`
+	Succeeded    int                  `json:"succeeded"`
+	Failed       int                  `json:"failed"`
+	Message      string               `json:"message,omitempty"`
+	Attempts     []JobAttemptResponse `json:"attempts"`
+	CreatedAt    time.Time            `json:"created_at"`
+	CompletedAt  *time.Time           `json:"completed_at,omitempty"`
+}
+
+// JobAttemptResponse Job 的一次执行
+type JobAttemptResponse struct {
+	Index       int        `json:"index"`
+	ComponentID string     `json:"component_id,omitempty"`
+	State       string     `json:"state"` // running/succeeded/failed
+	ExitCode    int32      `json:"exit_code"`
+	Reason      string     `json:"reason,omitempty"`
+	StartedAt   time.Time  `json:"started_at"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+}
+
+// ListJobsResponse 应用的 Job 列表
+type ListJobsResponse struct {
+	Jobs  []JobResponse `json:"jobs"`
+	Total int           `json:"total"`
+}
+
+func BuildJobResponse(status *types.JobStatus) JobResponse {
+	resp := JobResponse{
+		ID:           status.ID,
+		AppID:        status.AppID,
+		CronJobID:    status.CronJobID,
+		Name:         status.Spec.Name,
+		State:        string(status.State),
+		Completions:  status.Spec.Completions,
+		Parallelism:  status.Spec.Parallelism,
+		BackoffLimit: status.Spec.BackoffLimit,
+		Active:       status.Active,
+		Succeeded:    status.Succeeded,
+		Failed:       status.Failed,
+		Message:      status.Message,
+		Attempts:     make([]JobAttemptResponse, 0, len(status.Attempts)),
+		CreatedAt:    status.CreatedAt,
+	}
+	if !status.CompletedAt.IsZero() {
+		completedAt := status.CompletedAt
+		resp.CompletedAt = &completedAt
+	}
+	for _, a := range status.Attempts {
+		attempt := JobAttemptResponse{
+			Index:       a.Index,
+			ComponentID: a.ComponentID,
+			State:       string(a.State),
+			ExitCode:    a.ExitCode,
+			Reason:      a.Reason,
+			StartedAt:   a.StartedAt,
+		}
+		if !a.FinishedAt.IsZero() {
+			finishedAt := a.FinishedAt
+			attempt.FinishedAt = &finishedAt
+		}
+		resp.Attempts = append(resp.Attempts, attempt)
+	}
+	return resp
+}
+
+// CreateCronJobRequest 登记 CronJob 的请求
+type CreateCronJobRequest struct {
+	Name                       string           `json:"name"`
+	Schedule                   string           `json:"schedule"`                     // 5 字段 cron 表达式
+	Timezone                   string           `json:"timezone,omitempty"`           // IANA 时区，缺省为节点本地时区
+	ConcurrencyPolicy          string           `json:"concurrency_policy,omitempty"` // allow/forbid/replace，缺省为 allow
+	Suspend                    bool             `json:"suspend,omitempty"`
+	SuccessfulJobsHistoryLimit *int             `json:"successful_jobs_history_limit,omitempty"` // 缺省为 3
+	FailedJobsHistoryLimit     *int             `json:"failed_jobs_history_limit,omitempty"`     // 缺省为 1
+	JobTemplate                SubmitJobRequest `json:"job_template"`
+}
+
+// ToSpec 转换为 CronJob 定义，未指定的保留数交由领域层补全缺省值
+func (r *CreateCronJobRequest) ToSpec() types.CronJobSpec {
+	spec := types.CronJobSpec{
+		Name:                       r.Name,
+		Schedule:                   r.Schedule,
+		Timezone:                   r.Timezone,
+		ConcurrencyPolicy:          types.ConcurrencyPolicy(r.ConcurrencyPolicy),
+		Suspend:                    r.Suspend,
+		SuccessfulJobsHistoryLimit: -1,
+		FailedJobsHistoryLimit:     -1,
+		JobTemplate:                r.JobTemplate.ToSpec(),
+	}
+	if r.SuccessfulJobsHistoryLimit != nil {
+		spec.SuccessfulJobsHistoryLimit = *r.SuccessfulJobsHistoryLimit
+	}
+	if r.FailedJobsHistoryLimit != nil {
+		spec.FailedJobsHistoryLimit = *r.FailedJobsHistoryLimit
+	}
+	return spec
+}
+
+// CronJobResponse CronJob 定义与触发记录
+type CronJobResponse struct {
+	ID                         string     `json:"id"`
+	AppID                      string     `json:"app_id"`
+	Name                       string     `json:"name,omitempty"`
+	Schedule                   string     `json:"schedule"`
+	Timezone                   string     `json:"timezone,omitempty"`
+	ConcurrencyPolicy          string     `json:"concurrency_policy"`
+	Suspend                    bool       `json:"suspend"`
+	SuccessfulJobsHistoryLimit int        `json:"successful_jobs_history_limit"`
+	FailedJobsHistoryLimit     int        `json:"failed_jobs_history_limit"`
+	LastScheduleTime           *time.Time `json:"last_schedule_time,omitempty"`
+	NextScheduleTime           *time.Time `json:"next_schedule_time,omitempty"`
+	ActiveJobs                 []string   `json:"active_jobs"`
+	Jobs                       []string   `json:"jobs"` // 仍保留的全部 Job，按提交时间排序
+	CreatedAt                  time.Time  `json:"created_at"`
+}
+
+// ListCronJobsResponse 应用的 CronJob 列表
+type ListCronJobsResponse struct {
+	CronJobs []CronJobResponse `json:"cron_jobs"`
+	Total    int               `json:"total"`
+}
+
+func BuildCronJobResponse(status *types.CronJobStatus, jobs []*types.JobStatus) CronJobResponse {
+	resp := CronJobResponse{
+		ID:                         status.ID,
+		AppID:                      status.AppID,
+		Name:                       status.Spec.Name,
+		Schedule:                   status.Spec.Schedule,
+		Timezone:                   status.Spec.Timezone,
+		ConcurrencyPolicy:          string(status.Spec.ConcurrencyPolicy),
+		Suspend:                    status.Spec.Suspend,
+		SuccessfulJobsHistoryLimit: status.Spec.SuccessfulJobsHistoryLimit,
+		FailedJobsHistoryLimit:     status.Spec.FailedJobsHistoryLimit,
+		ActiveJobs:                 append([]string{}, status.ActiveJobs...),
+		Jobs:                       make([]string, 0, len(jobs)),
+		CreatedAt:                  status.CreatedAt,
+	}
+	if !status.LastScheduleTime.IsZero() {
+		last := status.LastScheduleTime
+		resp.LastScheduleTime = &last
+	}
+	if !status.NextScheduleTime.IsZero() {
+		next := status.NextScheduleTime
+		resp.NextScheduleTime = &next
+	}
+	for _, job := range jobs {
+		resp.Jobs = append(resp.Jobs, job.ID)
+	}
+	return resp
+}
@@ -4,19 +4,23 @@ import (
 	"context"
 	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/9triver/iarnet/internal/config"
 	"github.com/9triver/iarnet/internal/domain/resource"
+	"github.com/9triver/iarnet/internal/domain/resource/accounting"
 	"github.com/9triver/iarnet/internal/domain/resource/discovery"
 	"github.com/9triver/iarnet/internal/domain/resource/logger"
 	"github.com/9triver/iarnet/internal/domain/resource/provider"
 	"github.com/9triver/iarnet/internal/domain/resource/types"
+	"github.com/9triver/iarnet/internal/transport/http/util/rbac"
 	"github.com/9triver/iarnet/internal/transport/http/util/response"
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
@@ -26,10 +30,21 @@ func RegisterRoutes(router *mux.Router, resMgr *resource.Manager, cfg *config.Co
 	api := NewAPI(resMgr, cfg, discoveryService)
 	router.HandleFunc("/resource/capacity", api.handleGetResourceCapacity).Methods("GET")
 	router.HandleFunc("/resource/node/info", api.handleGetNodeInfo).Methods("GET")
+	router.HandleFunc("/resource/node/utilization", api.handleGetNodeUtilization).Methods("GET")
+	router.HandleFunc("/resource/rebalance", api.handleGetRebalanceReport).Methods("GET")
+	router.HandleFunc("/resource/affinity", api.handleGetAffinitySessions).Methods("GET")
+	router.HandleFunc("/resource/accounting/report", api.handleGetAccountingReport).Methods("GET")
+	router.HandleFunc("/resource/affinity/{key}", api.handleReleaseAffinity).Methods("DELETE")
+	router.HandleFunc("/resource/rebalance/plan", api.handlePlanRebalance).Methods("POST")
+	router.HandleFunc("/resource/feasibility", api.handleCheckFeasibility).Methods("POST")
 	router.HandleFunc("/resource/provider", api.handleGetResourceProviders).Methods("GET")
 	router.HandleFunc("/resource/provider/{id}/info", api.handleGetResourceProviderInfo).Methods("GET")
 	router.HandleFunc("/resource/provider/{id}/capacity", api.handleGetResourceProviderCapacity).Methods("GET")
 	router.HandleFunc("/resource/provider/{id}/usage", api.handleGetResourceProviderUsage).Methods("GET")
+	router.HandleFunc("/resource/provider/{id}/benchmark", api.handleBenchmarkResourceProvider).Methods("POST")
+	router.HandleFunc("/resource/provider/{id}/volumes", api.handleListProviderVolumes).Methods("GET")
+	router.HandleFunc("/resource/provider/{id}/volumes", api.handleCreateProviderVolume).Methods("POST")
+	router.HandleFunc("/resource/provider/{id}/volumes/{name}", api.handleDeleteProviderVolume).Methods("DELETE")
 	router.HandleFunc("/resource/provider/test", api.handleTestResourceProvider).Methods("POST")
 	router.HandleFunc("/resource/provider", api.handleRegisterResourceProvider).Methods("POST")
 	router.HandleFunc("/resource/provider/batch", api.handleBatchRegisterResourceProvider).Methods("POST")
@@ -39,11 +54,36 @@ func RegisterRoutes(router *mux.Router, resMgr *resource.Manager, cfg *config.Co
 	// Discovery 相关路由
 	router.HandleFunc("/resource/discovery/nodes", api.handleGetDiscoveredNodes).Methods("GET")
 
+	// 以上接口的 OpenAPI 文档，供外部工具生成客户端
+	router.HandleFunc("/resource/openapi.yaml", api.handleGetOpenAPISpec).Methods("GET")
+
+	// Component 相关路由
+	router.HandleFunc("/resource/components", api.handleListComponents).Methods("GET")
+	router.HandleFunc("/resource/components", api.authorizer.Require(rbac.PermissionComponentManage, api.handleDeployComponent)).Methods("POST")
+	router.HandleFunc("/resource/components/usage", api.handleListComponentUsage).Methods("GET")
+	router.HandleFunc("/resource/components/cluster", api.handleListClusterComponents).Methods("GET")
+	router.HandleFunc("/resource/components/adopt", api.authorizer.Require(rbac.PermissionComponentManage, api.handleAdoptComponents)).Methods("POST")
+	router.HandleFunc("/resource/components/{id}", api.handleGetComponent).Methods("GET")
+	router.HandleFunc("/resource/components/{id}", api.authorizer.Require(rbac.PermissionComponentManage, api.handleUndeployComponent)).Methods("DELETE")
+	router.HandleFunc("/resource/components/{id}/migrate", api.authorizer.Require(rbac.PermissionComponentManage, api.handleMigrateComponent)).Methods("POST")
 	router.HandleFunc("/resource/components/{id}/logs", api.handleGetComponentLogs).Methods("GET")
+	router.HandleFunc("/resource/components/{id}/staging", api.handleGetComponentStaging).Methods("GET")
+	router.HandleFunc("/resource/components/{id}/usage", api.handleGetComponentUsage).Methods("GET")
+	router.HandleFunc("/resource/components/{id}/wait", api.handleWaitComponent).Methods("GET")
+	router.HandleFunc("/resource/components/{id}/exec", api.authorizer.Require(rbac.PermissionComponentExec, api.handleExecComponent)).Methods("GET")
+	router.HandleFunc("/resource/components/{id}/port-forward", api.authorizer.Require(rbac.PermissionComponentPortForward, api.handlePortForwardComponent)).Methods("GET")
 }
 
 // handleGetDiscoveredNodes 获取通过 gossip 发现的节点列表
+// 查询参数 alive_only=true 时只返回存活节点（不包括 suspect 节点）；
+// label=key=value（可重复）时只返回具备全部标签的节点
 func (api *API) handleGetDiscoveredNodes(w http.ResponseWriter, r *http.Request) {
+	selector, err := parseLabelSelector(r.URL.Query()["label"])
+	if err != nil {
+		response.BadRequest(err.Error()).WriteJSON(w)
+		return
+	}
+
 	if api.discoveryService == nil {
 		// Discovery 服务未启用，返回空列表
 		resp := GetDiscoveredNodesResponse{
@@ -56,16 +96,30 @@ func (api *API) handleGetDiscoveredNodes(w http.ResponseWriter, r *http.Request)
 
 	// 获取已知节点
 	knownNodes := api.discoveryService.GetKnownNodes()
+	aliveOnly := r.URL.Query().Get("alive_only") == "true"
 	items := make([]DiscoveredNodeItem, 0, len(knownNodes))
 
 	for _, node := range knownNodes {
+		if aliveOnly && node.Liveness == discovery.NodeLivenessSuspect {
+			continue
+		}
+		if !types.MatchLabels(selector, node.Labels) {
+			continue
+		}
 		item := DiscoveredNodeItem{
 			NodeID:   node.NodeID,
 			NodeName: node.NodeName,
 			Address:  node.Address,
 			DomainID: node.DomainID,
 			Status:   string(node.Status),
+			Liveness: string(node.Liveness),
 			LastSeen: node.LastSeen.Format(time.RFC3339),
+
+			ProtocolVersion: node.ProtocolVersion,
+			Capabilities:    node.Capabilities,
+			Labels:          node.Labels,
+			HeadRole:        string(node.HeadRole),
+			HeadTerm:        node.HeadTerm,
 		}
 
 		// 转换资源容量
@@ -155,20 +209,46 @@ type DiscoveredNodeItem struct {
 	NodeName     string            `json:"node_name"`
 	Address      string            `json:"address"`
 	DomainID     string            `json:"domain_id"`
-	Status       string            `json:"status"` // online/offline/error
+	Status       string            `json:"status"`   // online/offline/error
+	Liveness     string            `json:"liveness"` // alive/suspect
 	CPU          *ResourceUsage    `json:"cpu,omitempty"`
 	Memory       *ResourceUsage    `json:"memory,omitempty"`
 	GPU          *ResourceUsage    `json:"gpu,omitempty"`
 	ResourceTags *ResourceTagsInfo `json:"resource_tags,omitempty"`
 	LastSeen     string            `json:"last_seen"` // RFC3339 格式
+
+	ProtocolVersion uint32            `json:"protocol_version"`       // 节点声明的协议版本，0 表示旧版节点（未声明）
+	Capabilities    []string          `json:"capabilities,omitempty"` // 节点声明的可选能力
+	Labels          map[string]string `json:"labels,omitempty"`       // 节点标签
+	HeadRole        string            `json:"head_role,omitempty"`    // head / standby，空表示普通节点
+	HeadTerm        uint64            `json:"head_term,omitempty"`    // head 任期
+}
+
+// parseLabelSelector 解析 key=value 形式的标签约束
+func parseLabelSelector(values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	selector := make(map[string]string, len(values))
+	for _, v := range values {
+		key, value, ok := strings.Cut(v, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid label selector %q, expected key=value", v)
+		}
+		selector[key] = value
+	}
+	return selector, nil
 }
 
 // GetNodeInfoResponse 返回当前节点与域信息
 type GetNodeInfoResponse struct {
-	NodeID     string `json:"node_id"`
-	NodeName   string `json:"node_name"`
-	DomainID   string `json:"domain_id"`
-	DomainName string `json:"domain_name"`
+	NodeID     string            `json:"node_id"`
+	NodeName   string            `json:"node_name"`
+	DomainID   string            `json:"domain_id"`
+	DomainName string            `json:"domain_name"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	HeadRole   string            `json:"head_role,omitempty"` // head / standby，空表示普通节点
+	HeadTerm   uint64            `json:"head_term,omitempty"`
 }
 
 // ResourceUsage 资源使用情况
@@ -190,6 +270,7 @@ type API struct {
 	resMgr           *resource.Manager
 	cfg              *config.Config
 	discoveryService discovery.Service
+	authorizer       *rbac.Authorizer
 }
 
 func NewAPI(resMgr *resource.Manager, cfg *config.Config, discoveryService discovery.Service) *API {
@@ -197,6 +278,7 @@ func NewAPI(resMgr *resource.Manager, cfg *config.Config, discoveryService disco
 		resMgr:           resMgr,
 		cfg:              cfg,
 		discoveryService: discoveryService,
+		authorizer:       rbac.NewAuthorizer(cfg.Transport.HTTP.RBAC),
 	}
 }
 
@@ -216,16 +298,185 @@ func (api *API) handleGetNodeInfo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	headRole, headTerm := api.resMgr.GetHeadRole()
 	resp := GetNodeInfoResponse{
 		NodeID:     api.resMgr.GetNodeID(),
 		NodeName:   api.resMgr.GetNodeName(),
 		DomainID:   api.resMgr.GetDomainID(),
 		DomainName: api.resMgr.GetDomainName(),
+		Labels:     api.resMgr.GetNodeLabels(),
+		HeadRole:   string(headRole),
+		HeadTerm:   headTerm,
 	}
 
 	response.Success(resp).WriteJSON(w)
 }
 
+// handleGetNodeUtilization 返回本节点聚合后的资源利用率及各 provider 明细
+func (api *API) handleGetNodeUtilization(w http.ResponseWriter, r *http.Request) {
+	if api.resMgr == nil {
+		response.InternalError("resource manager not initialized").WriteJSON(w)
+		return
+	}
+	utilization := api.resMgr.GetNodeUtilization(r.Context())
+	response.Success((&GetNodeUtilizationResponse{}).FromUtilization(utilization)).WriteJSON(w)
+}
+
+// handleGetAffinitySessions 列出未过期的会话亲和
+func (api *API) handleGetAffinitySessions(w http.ResponseWriter, r *http.Request) {
+	if api.resMgr == nil {
+		response.InternalError("resource manager not initialized").WriteJSON(w)
+		return
+	}
+	response.Success((&GetAffinitySessionsResponse{}).FromSessions(api.resMgr.ListAffinitySessions())).WriteJSON(w)
+}
+
+// handleReleaseAffinity 提前结束会话亲和
+func (api *API) handleReleaseAffinity(w http.ResponseWriter, r *http.Request) {
+	if api.resMgr == nil {
+		response.InternalError("resource manager not initialized").WriteJSON(w)
+		return
+	}
+	key := mux.Vars(r)["key"]
+	if !api.resMgr.ReleaseAffinity(key) {
+		response.NotFound("affinity session not found: " + key).WriteJSON(w)
+		return
+	}
+	response.Success(nil).WriteJSON(w)
+}
+
+// handleGetRebalanceReport 返回最近一次再平衡检查的结果，尚未检查过时 report 为空
+func (api *API) handleGetRebalanceReport(w http.ResponseWriter, r *http.Request) {
+	if api.resMgr == nil {
+		response.InternalError("resource manager not initialized").WriteJSON(w)
+		return
+	}
+	response.Success((&GetRebalanceReportResponse{}).FromReport(api.resMgr.GetRebalanceReport())).WriteJSON(w)
+}
+
+// handleGetAccountingReport 生成按域、应用汇总的资源占用报表
+// 期间通过 month=YYYY-MM（节点本地时区）或 from/to（RFC3339）指定，缺省为当月；format=csv 时导出 CSV
+func (api *API) handleGetAccountingReport(w http.ResponseWriter, r *http.Request) {
+	if api.resMgr == nil {
+		response.InternalError("resource manager not initialized").WriteJSON(w)
+		return
+	}
+
+	from, to, err := parseAccountingPeriod(r.URL.Query())
+	if err != nil {
+		response.BadRequest(err.Error()).WriteJSON(w)
+		return
+	}
+	report, err := api.resMgr.GetAccountingReport(r.Context(), from, to)
+	if err != nil {
+		response.InternalError(err.Error()).WriteJSON(w)
+		return
+	}
+
+	if strings.EqualFold(r.URL.Query().Get("format"), "csv") {
+		filename := fmt.Sprintf("accounting_%s_%s.csv", from.Format("20060102"), to.Format("20060102"))
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		if err := report.WriteCSV(w); err != nil {
+			logrus.Warnf("Failed to write accounting report CSV: %v", err)
+		}
+		return
+	}
+	response.Success((&GetAccountingReportResponse{}).FromReport(report)).WriteJSON(w)
+}
+
+// parseAccountingPeriod 解析报表期间
+func parseAccountingPeriod(query url.Values) (time.Time, time.Time, error) {
+	if month := strings.TrimSpace(query.Get("month")); month != "" {
+		t, err := time.ParseInLocation("2006-01", month, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid month, must be YYYY-MM")
+		}
+		from, to := accounting.MonthPeriod(t.Year(), t.Month(), time.Local)
+		return from, to, nil
+	}
+
+	fromParam, toParam := strings.TrimSpace(query.Get("from")), strings.TrimSpace(query.Get("to"))
+	if fromParam == "" && toParam == "" {
+		now := time.Now()
+		from, to := accounting.MonthPeriod(now.Year(), now.Month(), time.Local)
+		return from, to, nil
+	}
+	if fromParam == "" || toParam == "" {
+		return time.Time{}, time.Time{}, fmt.Errorf("from and to must be specified together")
+	}
+	from, err := time.Parse(time.RFC3339, fromParam)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid from, must be RFC3339")
+	}
+	to, err := time.Parse(time.RFC3339, toParam)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid to, must be RFC3339")
+	}
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("from must be before to")
+	}
+	return from, to, nil
+}
+
+// handlePlanRebalance 立即执行一次 dry-run 再平衡检查，返回迁移计划但不实际迁移
+func (api *API) handlePlanRebalance(w http.ResponseWriter, r *http.Request) {
+	if api.resMgr == nil {
+		response.InternalError("resource manager not initialized").WriteJSON(w)
+		return
+	}
+	report := api.resMgr.Rebalance(r.Context(), true)
+	response.Success((&GetRebalanceReportResponse{}).FromReport(report)).WriteJSON(w)
+}
+
+// handleCheckFeasibility 检查一组 component 能否全部部署，返回假设的放置方案或资源缺口，不产生任何部署
+func (api *API) handleCheckFeasibility(w http.ResponseWriter, r *http.Request) {
+	if api.resMgr == nil {
+		response.InternalError("resource manager not initialized").WriteJSON(w)
+		return
+	}
+	req := CheckFeasibilityRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest("invalid request body: " + err.Error()).WriteJSON(w)
+		return
+	}
+	if len(req.Components) == 0 {
+		response.BadRequest("components is required").WriteJSON(w)
+		return
+	}
+	runtimeEnv := req.RuntimeEnv
+	if runtimeEnv == "" {
+		runtimeEnv = types.RuntimeEnvPython
+	}
+
+	var requests []*types.Info
+	for i, c := range req.Components {
+		if c.CPU < 0 || c.Memory < 0 || c.GPU < 0 || c.Replicas < 0 {
+			response.BadRequest(fmt.Sprintf("components[%d]: resources and replicas must not be negative", i)).WriteJSON(w)
+			return
+		}
+		replicas := max(c.Replicas, 1)
+		for range replicas {
+			requests = append(requests, &types.Info{
+				CPU:          c.CPU,
+				Memory:       c.Memory,
+				GPU:          c.GPU,
+				Tags:         c.Tags,
+				NodeSelector: c.NodeSelector,
+				Stream:       c.Stream,
+			})
+		}
+	}
+
+	plan, err := api.resMgr.PlanDeployment(r.Context(), runtimeEnv, requests)
+	if err != nil {
+		logrus.Errorf("Failed to check deployment feasibility: %v", err)
+		response.InternalError("failed to check deployment feasibility: " + err.Error()).WriteJSON(w)
+		return
+	}
+	response.Success((&CheckFeasibilityResponse{}).FromPlan(plan)).WriteJSON(w)
+}
+
 func (api *API) handleGetResourceProviders(w http.ResponseWriter, r *http.Request) {
 	providers := api.resMgr.GetAllProviders()
 	items := make([]ProviderItem, 0, len(providers))
@@ -247,8 +498,14 @@ func (api *API) handleRegisterResourceProvider(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	capacityClass, err := types.ParseCapacityClass(req.CapacityClass)
+	if err != nil {
+		response.BadRequest(err.Error()).WriteJSON(w)
+		return
+	}
+
 	// 注册 provider
-	p, err := api.resMgr.RegisterProvider(req.Name, req.Host, req.Port)
+	p, err := api.resMgr.RegisterProvider(req.Name, req.Host, req.Port, capacityClass)
 	if err != nil {
 		logrus.Errorf("Failed to register provider: %v", err)
 		response.InternalError("failed to register provider: " + err.Error()).WriteJSON(w)
@@ -318,7 +575,7 @@ func (api *API) handleBatchRegisterResourceProvider(w http.ResponseWriter, r *ht
 		}
 
 		// 注册 provider（注意：Manager.RegisterProvider 没有 context 参数）
-		p, err := api.resMgr.RegisterProvider(req.Name, req.Host, req.Port)
+		p, err := api.resMgr.RegisterProvider(req.Name, req.Host, req.Port, types.CapacityClass(req.CapacityClass))
 		if err != nil {
 			logrus.Errorf("Failed to register provider %s (%s:%d): %v", req.Name, req.Host, req.Port, err)
 			result.Success = false
@@ -372,7 +629,7 @@ func parseCSVFile(file io.Reader) ([]RegisterResourceProviderRequest, []string)
 			continue
 		}
 
-		// CSV 格式：节点名称,地址:端口
+		// CSV 格式：节点名称,地址:端口[,容量类别]
 		// 注意：首行就是数据，不是表头
 		if len(record) < 2 {
 			errors = append(errors, fmt.Sprintf("第 %d 行列数不足，需要至少 2 列（节点名称、地址:端口）", lineNum))
@@ -418,10 +675,21 @@ func parseCSVFile(file io.Reader) ([]RegisterResourceProviderRequest, []string)
 			continue
 		}
 
+		// 可选的第三列：容量类别（guaranteed/best-effort）
+		capacityClass := ""
+		if len(record) >= 3 {
+			capacityClass = strings.TrimSpace(record[2])
+			if _, err := types.ParseCapacityClass(capacityClass); err != nil {
+				errors = append(errors, fmt.Sprintf("第 %d 行容量类别无效: %s（应为 guaranteed 或 best-effort）", lineNum, capacityClass))
+				continue
+			}
+		}
+
 		providers = append(providers, RegisterResourceProviderRequest{
-			Name: name,
-			Host: host,
-			Port: port,
+			Name:          name,
+			Host:          host,
+			Port:          port,
+			CapacityClass: capacityClass,
 		})
 	}
 
@@ -449,6 +717,10 @@ func (api *API) handleUpdateResourceProvider(w http.ResponseWriter, r *http.Requ
 		response.NotFound("provider not found").WriteJSON(w)
 		return
 	}
+	if provider.IsStatic() {
+		response.Forbidden("provider is managed by static_providers in the config file and is read-only").WriteJSON(w)
+		return
+	}
 
 	// 检查是否有需要更新的字段
 	hasUpdates := false
@@ -501,6 +773,10 @@ func (api *API) handleUnregisterResourceProvider(w http.ResponseWriter, r *http.
 
 	// 注销 provider
 	if err := api.resMgr.UnregisterProvider(providerID); err != nil {
+		if errors.Is(err, provider.ErrStaticProvider) {
+			response.Forbidden(err.Error()).WriteJSON(w)
+			return
+		}
 		logrus.Errorf("Failed to unregister provider %s: %v", providerID, err)
 		response.NotFound("provider not found: " + err.Error()).WriteJSON(w)
 		return
@@ -579,6 +855,92 @@ func (api *API) handleGetResourceProviderUsage(w http.ResponseWriter, r *http.Re
 	response.Success(resp).WriteJSON(w)
 }
 
+// handleBenchmarkResourceProvider 重新运行 provider 的微基准测试并返回结果
+func (api *API) handleBenchmarkResourceProvider(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	providerID := vars["id"]
+	if providerID == "" {
+		response.BadRequest("provider id is required").WriteJSON(w)
+		return
+	}
+
+	if api.resMgr.GetProvider(providerID) == nil {
+		response.NotFound("provider not found").WriteJSON(w)
+		return
+	}
+
+	bench, err := api.resMgr.BenchmarkProvider(r.Context(), providerID)
+	if err != nil {
+		response.InternalError("failed to benchmark provider: " + err.Error()).WriteJSON(w)
+		return
+	}
+	response.Success(benchmarkToInfo(bench)).WriteJSON(w)
+}
+
+// handleListProviderVolumes 列出 provider 上的命名卷
+func (api *API) handleListProviderVolumes(w http.ResponseWriter, r *http.Request) {
+	providerID := mux.Vars(r)["id"]
+	p := api.resMgr.GetProvider(providerID)
+	if p == nil {
+		response.NotFound("provider not found").WriteJSON(w)
+		return
+	}
+
+	volumes, err := p.ListVolumes(r.Context())
+	if err != nil {
+		response.InternalError("failed to list volumes: " + err.Error()).WriteJSON(w)
+		return
+	}
+	resp := &ListVolumesResponse{Volumes: make([]VolumeInfo, 0, len(volumes))}
+	for _, v := range volumes {
+		resp.Volumes = append(resp.Volumes, *(&VolumeInfo{}).FromVolume(v))
+	}
+	response.Success(resp).WriteJSON(w)
+}
+
+// handleCreateProviderVolume 在 provider 上创建命名卷
+func (api *API) handleCreateProviderVolume(w http.ResponseWriter, r *http.Request) {
+	providerID := mux.Vars(r)["id"]
+	p := api.resMgr.GetProvider(providerID)
+	if p == nil {
+		response.NotFound("provider not found").WriteJSON(w)
+		return
+	}
+
+	req := CreateVolumeRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest("invalid request body: " + err.Error()).WriteJSON(w)
+		return
+	}
+	if err := provider.ValidateVolumeName(req.Name); err != nil {
+		response.BadRequest(err.Error()).WriteJSON(w)
+		return
+	}
+
+	volume, err := p.CreateVolume(r.Context(), req.Name)
+	if err != nil {
+		response.InternalError("failed to create volume: " + err.Error()).WriteJSON(w)
+		return
+	}
+	response.Success((&VolumeInfo{}).FromVolume(volume)).WriteJSON(w)
+}
+
+// handleDeleteProviderVolume 删除 provider 上的命名卷
+func (api *API) handleDeleteProviderVolume(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	p := api.resMgr.GetProvider(vars["id"])
+	if p == nil {
+		response.NotFound("provider not found").WriteJSON(w)
+		return
+	}
+
+	if err := p.DeleteVolume(r.Context(), vars["name"]); err != nil {
+		response.InternalError("failed to delete volume: " + err.Error()).WriteJSON(w)
+		return
+	}
+	response.Success(nil).WriteJSON(w)
+}
+
 func (api *API) handleTestResourceProvider(w http.ResponseWriter, r *http.Request) {
 	req := TestResourceProviderRequest{}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -789,6 +1151,53 @@ func (api *API) handleGetComponentLogs(w http.ResponseWriter, r *http.Request) {
 	response.Success(resp).WriteJSON(w)
 }
 
+// handleGetComponentStaging 获取 component 的数据预置进度
+func (api *API) handleGetComponentStaging(w http.ResponseWriter, r *http.Request) {
+	componentID := mux.Vars(r)["id"]
+	if componentID == "" {
+		response.BadRequest("component id is required").WriteJSON(w)
+		return
+	}
+
+	items, err := api.resMgr.GetStagingStatus(r.Context(), componentID)
+	if err != nil {
+		logrus.Errorf("Failed to get staging status of component %s: %v", componentID, err)
+		response.InternalError("failed to get staging status: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	resp := (&GetStagingStatusResponse{}).FromProgress(componentID, items)
+	response.Success(resp).WriteJSON(w)
+}
+
+// handleGetComponentUsage 查询本节点 component 的实时资源使用情况
+func (api *API) handleGetComponentUsage(w http.ResponseWriter, r *http.Request) {
+	componentID := mux.Vars(r)["id"]
+	if componentID == "" {
+		response.BadRequest("component id is required").WriteJSON(w)
+		return
+	}
+
+	usage, err := api.resMgr.GetComponentUsage(r.Context(), componentID)
+	if err != nil {
+		logrus.Errorf("Failed to get usage of component %s: %v", componentID, err)
+		response.InternalError("failed to get component usage: " + err.Error()).WriteJSON(w)
+		return
+	}
+
+	response.Success((&ComponentUsageInfo{}).FromUsage(usage)).WriteJSON(w)
+}
+
+// handleListComponentUsage 查询本节点所有运行中 component 的实时资源使用情况
+func (api *API) handleListComponentUsage(w http.ResponseWriter, r *http.Request) {
+	usages := api.resMgr.ListComponentUsage(r.Context())
+	resp := &ListComponentUsageResponse{Components: make([]ComponentUsageInfo, 0, len(usages))}
+	for _, usage := range usages {
+		resp.Components = append(resp.Components, *(&ComponentUsageInfo{}).FromUsage(usage))
+	}
+	response.Success(resp).WriteJSON(w)
+}
+
 func parsePositiveInt(raw string, defaultVal int) (int, error) {
 	if raw == "" {
 		return defaultVal, nil
@@ -0,0 +1,272 @@
+package resource
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/9triver/iarnet/internal/domain/resource"
+	"github.com/9triver/iarnet/internal/domain/resource/component"
+	"github.com/9triver/iarnet/internal/domain/resource/provider"
+	"github.com/9triver/iarnet/internal/domain/resource/scheduler"
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+	"github.com/9triver/iarnet/internal/transport/http/util/response"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// component 结束等待的默认与最大超时（秒）
+const (
+	defaultWaitTimeoutSeconds = 60
+	maxWaitTimeoutSeconds     = 600
+)
+
+// openAPISpec resource 相关 REST 接口的 OpenAPI 描述
+//
+//go:embed openapi.yaml
+var openAPISpec []byte
+
+// handleGetOpenAPISpec 返回 resource 相关 REST 接口的 OpenAPI 文档
+func (api *API) handleGetOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	w.WriteHeader(http.StatusOK)
+	w.Write(openAPISpec)
+}
+
+// handleListComponents 列出本节点管理的 component
+func (api *API) handleListComponents(w http.ResponseWriter, r *http.Request) {
+	if api.resMgr == nil {
+		response.InternalError("resource manager not initialized").WriteJSON(w)
+		return
+	}
+	components := api.resMgr.GetAllComponents()
+	resp := ListComponentsResponse{
+		Components: make([]ComponentItem, 0, len(components)),
+		Total:      len(components),
+	}
+	for _, comp := range components {
+		resp.Components = append(resp.Components, *(&ComponentItem{}).FromComponent(comp))
+	}
+	response.Success(resp).WriteJSON(w)
+}
+
+// handleListClusterComponents 列出整个域的 component：本节点直接列举，同域其他节点通过 scheduler RPC 查询
+// 查询参数 app、label=key=value（可重复）、provider_type、state、origin 均为可选过滤条件
+func (api *API) handleListClusterComponents(w http.ResponseWriter, r *http.Request) {
+	if api.resMgr == nil {
+		response.InternalError("resource manager not initialized").WriteJSON(w)
+		return
+	}
+	params := r.URL.Query()
+	labels, err := parseLabelSelector(params["label"])
+	if err != nil {
+		response.BadRequest(err.Error()).WriteJSON(w)
+		return
+	}
+	query := scheduler.ComponentQuery{
+		AppID:        params.Get("app"),
+		Labels:       labels,
+		ProviderType: params.Get("provider_type"),
+		State:        params.Get("state"),
+		Origin:       params.Get("origin"),
+	}
+	if err := query.Validate(); err != nil {
+		response.BadRequest(err.Error()).WriteJSON(w)
+		return
+	}
+	result, err := api.resMgr.ListClusterComponents(r.Context(), query)
+	if err != nil {
+		logrus.Errorf("Failed to list cluster components: %v", err)
+		response.InternalError("failed to list cluster components: " + err.Error()).WriteJSON(w)
+		return
+	}
+	response.Success((&ListClusterComponentsResponse{}).FromClusterComponents(result)).WriteJSON(w)
+}
+
+// handleAdoptComponents 接管已离开的节点委托部署到域内各节点的 component
+// 各节点将这些 component 的上游地址更新为本节点并重新部署，本节点登记其路由
+func (api *API) handleAdoptComponents(w http.ResponseWriter, r *http.Request) {
+	if api.resMgr == nil {
+		response.InternalError("resource manager not initialized").WriteJSON(w)
+		return
+	}
+	req := AdoptComponentsRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest("invalid request body: " + err.Error()).WriteJSON(w)
+		return
+	}
+	if req.NodeID == "" {
+		response.BadRequest("node_id is required").WriteJSON(w)
+		return
+	}
+	report, err := api.resMgr.AdoptComponents(r.Context(), req.NodeID)
+	if errors.Is(err, resource.ErrNodeAlive) {
+		response.BadRequest(err.Error()).WriteJSON(w)
+		return
+	}
+	if err != nil {
+		logrus.Errorf("Failed to adopt components of node %s: %v", req.NodeID, err)
+		response.InternalError("failed to adopt components: " + err.Error()).WriteJSON(w)
+		return
+	}
+	response.Success((&AdoptComponentsResponse{}).FromAdoptionReport(report)).WriteJSON(w)
+}
+
+// handleGetComponent 返回单个 component 的信息
+func (api *API) handleGetComponent(w http.ResponseWriter, r *http.Request) {
+	if api.resMgr == nil {
+		response.InternalError("resource manager not initialized").WriteJSON(w)
+		return
+	}
+	componentID := mux.Vars(r)["id"]
+	comp := api.resMgr.GetComponent(componentID)
+	if comp == nil {
+		response.NotFound("component not found: " + componentID).WriteJSON(w)
+		return
+	}
+	response.Success((&ComponentItem{}).FromComponent(comp)).WriteJSON(w)
+}
+
+// handleWaitComponent 长轮询等待 component 实例结束（默认最多 60 秒，timeout_seconds 可调整）
+// 部署接口返回时实例只是已创建，调用方据此测量实例真正结束的时间
+func (api *API) handleWaitComponent(w http.ResponseWriter, r *http.Request) {
+	if api.resMgr == nil {
+		response.InternalError("resource manager not initialized").WriteJSON(w)
+		return
+	}
+	componentID := mux.Vars(r)["id"]
+	if api.resMgr.GetComponent(componentID) == nil {
+		response.NotFound("component not found: " + componentID).WriteJSON(w)
+		return
+	}
+	timeout, err := parsePositiveInt(r.URL.Query().Get("timeout_seconds"), defaultWaitTimeoutSeconds)
+	if err != nil || timeout > maxWaitTimeoutSeconds {
+		response.BadRequest(fmt.Sprintf("timeout_seconds must be in range 1-%d", maxWaitTimeoutSeconds)).WriteJSON(w)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(timeout)*time.Second)
+	defer cancel()
+	completion, err := api.resMgr.WaitComponentCompletion(ctx, componentID)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			response.Success(&WaitComponentResponse{ComponentID: componentID}).WriteJSON(w)
+			return
+		}
+		logrus.Errorf("Failed to wait for component %s: %v", componentID, err)
+		response.InternalError("failed to wait for component: " + err.Error()).WriteJSON(w)
+		return
+	}
+	response.Success((&WaitComponentResponse{}).FromCompletion(completion)).WriteJSON(w)
+}
+
+// handleDeployComponent 按资源请求部署 component，放置规则与 gRPC DeployComponent 相同
+func (api *API) handleDeployComponent(w http.ResponseWriter, r *http.Request) {
+	if api.resMgr == nil {
+		response.InternalError("resource manager not initialized").WriteJSON(w)
+		return
+	}
+	req := DeployComponentRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest("invalid request body: " + err.Error()).WriteJSON(w)
+		return
+	}
+	if req.CPU < 0 || req.Memory < 0 || req.GPU < 0 || req.TimeoutSeconds < 0 {
+		response.BadRequest("resources and timeout_seconds must not be negative").WriteJSON(w)
+		return
+	}
+	if err := component.ValidateLabels(req.Labels); err != nil {
+		response.BadRequest(err.Error()).WriteJSON(w)
+		return
+	}
+	sidecars := req.ToSidecars()
+	if err := provider.ValidateSidecars(sidecars); err != nil {
+		response.BadRequest(err.Error()).WriteJSON(w)
+		return
+	}
+	runtimeEnv := req.RuntimeEnv
+	if runtimeEnv == "" {
+		runtimeEnv = types.RuntimeEnvPython
+	}
+
+	// 调度按主容器与 sidecar 的合计资源进行，保证两者放置在同一个 provider 上
+	extra := provider.SidecarResources(sidecars)
+	ctx := provider.WithSidecars(r.Context(), sidecars)
+	ctx = component.WithComponentLabels(ctx, req.Labels)
+	if req.TimeoutSeconds > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(req.TimeoutSeconds)*time.Second)
+		defer cancel()
+	}
+
+	comp, err := api.resMgr.DeployComponent(ctx, runtimeEnv, &types.Info{
+		CPU:          req.CPU + extra.CPU,
+		Memory:       req.Memory + extra.Memory,
+		GPU:          req.GPU + extra.GPU,
+		Tags:         req.Tags,
+		NodeSelector: req.NodeSelector,
+		Stream:       req.Stream,
+	})
+	if err != nil {
+		logrus.Errorf("Failed to deploy component: %v", err)
+		if errors.Is(err, resource.ErrShuttingDown) {
+			response.ServiceUnavailable(err.Error()).WriteJSON(w)
+			return
+		}
+		response.InternalError("failed to deploy component: " + err.Error()).WriteJSON(w)
+		return
+	}
+	response.Created((&ComponentItem{}).FromComponent(comp)).WriteJSON(w)
+}
+
+// handleUndeployComponent 删除 component
+func (api *API) handleUndeployComponent(w http.ResponseWriter, r *http.Request) {
+	if api.resMgr == nil {
+		response.InternalError("resource manager not initialized").WriteJSON(w)
+		return
+	}
+	componentID := mux.Vars(r)["id"]
+	if api.resMgr.GetComponent(componentID) == nil {
+		response.NotFound("component not found: " + componentID).WriteJSON(w)
+		return
+	}
+	if err := api.resMgr.UndeployComponent(r.Context(), componentID); err != nil {
+		logrus.Errorf("Failed to undeploy component %s: %v", componentID, err)
+		response.InternalError("failed to undeploy component: " + err.Error()).WriteJSON(w)
+		return
+	}
+	response.Success(nil).WriteJSON(w)
+}
+
+// handleMigrateComponent 将 component 迁移到本节点的指定 provider
+func (api *API) handleMigrateComponent(w http.ResponseWriter, r *http.Request) {
+	if api.resMgr == nil {
+		response.InternalError("resource manager not initialized").WriteJSON(w)
+		return
+	}
+	componentID := mux.Vars(r)["id"]
+	req := MigrateComponentRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest("invalid request body: " + err.Error()).WriteJSON(w)
+		return
+	}
+	if req.ProviderID == "" {
+		response.BadRequest("provider_id is required").WriteJSON(w)
+		return
+	}
+	comp := api.resMgr.GetComponent(componentID)
+	if comp == nil {
+		response.NotFound("component not found: " + componentID).WriteJSON(w)
+		return
+	}
+	if err := api.resMgr.MigrateComponent(r.Context(), componentID, req.ProviderID); err != nil {
+		logrus.Errorf("Failed to migrate component %s to provider %s: %v", componentID, req.ProviderID, err)
+		response.InternalError("failed to migrate component: " + err.Error()).WriteJSON(w)
+		return
+	}
+	response.Success((&ComponentItem{}).FromComponent(comp)).WriteJSON(w)
+}
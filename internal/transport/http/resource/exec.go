@@ -0,0 +1,122 @@
+package resource
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/9triver/iarnet/internal/domain/resource/provider"
+	"github.com/9triver/iarnet/internal/transport/http/util/response"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+)
+
+// execUpgrader exec 与 port-forward 共用的 WebSocket upgrader
+var execUpgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool {
+		// 访问已由 RBAC 令牌控制，这里不再额外限制来源
+		return true
+	},
+}
+
+// handleExecComponent 在 component 内启动调试命令，并通过 WebSocket 双向转发标准输入输出
+// 查询参数:
+//   - cmd: 要执行的命令，可重复指定作为参数列表，缺省为 /bin/sh
+//   - tty: 是否分配伪终端，缺省为 true
+func (api *API) handleExecComponent(w http.ResponseWriter, r *http.Request) {
+	componentID := mux.Vars(r)["id"]
+	if componentID == "" {
+		response.BadRequest("component id is required").WriteJSON(w)
+		return
+	}
+
+	query := r.URL.Query()
+	opts := provider.ExecOptions{Command: query["cmd"], TTY: true}
+	if ttyParam := strings.TrimSpace(query.Get("tty")); ttyParam != "" {
+		tty, err := strconv.ParseBool(ttyParam)
+		if err != nil {
+			response.BadRequest("invalid tty: " + err.Error()).WriteJSON(w)
+			return
+		}
+		opts.TTY = tty
+	}
+
+	session, err := api.resMgr.ExecComponent(r.Context(), componentID, opts)
+	if err != nil {
+		logrus.Errorf("Failed to exec in component %s: %v", componentID, err)
+		response.InternalError(err.Error()).WriteJSON(w)
+		return
+	}
+	defer session.Close()
+
+	conn, err := execUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		logrus.Errorf("Failed to upgrade exec connection: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	logrus.Infof("Exec session started in component %s: %v", componentID, opts.Command)
+	go api.pumpExecInput(conn, session)
+	api.pumpExecOutput(conn, session)
+	logrus.Infof("Exec session in component %s closed", componentID)
+}
+
+// pumpExecInput 将 WebSocket 客户端消息转发到 Exec 会话
+func (api *API) pumpExecInput(conn *websocket.Conn, session *provider.ExecSession) {
+	for {
+		var msg ExecClientMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			// 客户端断开后关闭会话，使输出循环退出
+			session.Close()
+			return
+		}
+
+		var err error
+		switch msg.Type {
+		case "stdin":
+			err = session.Write([]byte(msg.Data))
+		case "resize":
+			err = session.Resize(msg.Rows, msg.Cols)
+		case "close":
+			err = session.CloseStdin()
+		default:
+			logrus.Warnf("Unknown exec message type: %s", msg.Type)
+		}
+		if err != nil {
+			logrus.Warnf("Failed to forward exec input: %v", err)
+			return
+		}
+	}
+}
+
+// pumpExecOutput 将 Exec 会话输出转发到 WebSocket 客户端，直到命令退出或会话关闭
+func (api *API) pumpExecOutput(conn *websocket.Conn, session *provider.ExecSession) {
+	for {
+		out, err := session.Recv()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			conn.WriteJSON(ExecServerMessage{Type: "error", Error: err.Error()})
+			return
+		}
+
+		if len(out.Stdout) > 0 {
+			if err := conn.WriteJSON(ExecServerMessage{Type: "stdout", Data: string(out.Stdout)}); err != nil {
+				return
+			}
+		}
+		if len(out.Stderr) > 0 {
+			if err := conn.WriteJSON(ExecServerMessage{Type: "stderr", Data: string(out.Stderr)}); err != nil {
+				return
+			}
+		}
+		if out.Exited {
+			conn.WriteJSON(ExecServerMessage{Type: "exit", ExitCode: out.ExitCode})
+			return
+		}
+	}
+}
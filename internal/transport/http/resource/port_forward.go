@@ -0,0 +1,100 @@
+package resource
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/9triver/iarnet/internal/domain/resource/provider"
+	"github.com/9triver/iarnet/internal/transport/http/util/response"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+)
+
+// portForwardBufferSize 单条 WebSocket 消息的最大字节数
+const portForwardBufferSize = 32 * 1024
+
+// portForwardCloseWrite 客户端发送该文本消息表示本地连接已结束发送（TCP 半关闭）
+const portForwardCloseWrite = "close_write"
+
+// handlePortForwardComponent 将一个 WebSocket 连接转发为到 component 端口的一条 TCP 连接
+// 每个 WebSocket 连接对应一条 TCP 连接，数据以二进制消息传输，文本消息 close_write 表示半关闭，
+// 关闭 WebSocket 即关闭连接
+// 查询参数:
+//   - port: component 内的目标端口
+func (api *API) handlePortForwardComponent(w http.ResponseWriter, r *http.Request) {
+	componentID := mux.Vars(r)["id"]
+	if componentID == "" {
+		response.BadRequest("component id is required").WriteJSON(w)
+		return
+	}
+
+	port, err := strconv.ParseUint(r.URL.Query().Get("port"), 10, 16)
+	if err != nil || port == 0 {
+		response.BadRequest("invalid port").WriteJSON(w)
+		return
+	}
+
+	tunnel, err := api.resMgr.PortForwardComponent(r.Context(), componentID, uint32(port))
+	if err != nil {
+		logrus.Errorf("Failed to port-forward to component %s: %v", componentID, err)
+		response.InternalError(err.Error()).WriteJSON(w)
+		return
+	}
+	defer tunnel.Close()
+
+	conn, err := execUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		logrus.Errorf("Failed to upgrade port-forward connection: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	logrus.Infof("Port-forward opened to component %s port %d", componentID, port)
+	go pumpPortForwardInput(conn, tunnel)
+	pumpPortForwardOutput(conn, tunnel)
+	logrus.Infof("Port-forward to component %s port %d closed", componentID, port)
+}
+
+// pumpPortForwardInput 将 WebSocket 二进制消息写入隧道
+func pumpPortForwardInput(conn *websocket.Conn, tunnel *provider.PortForwardConn) {
+	for {
+		msgType, data, err := conn.ReadMessage()
+		if err != nil {
+			// 客户端断开后关闭隧道，使输出循环退出
+			tunnel.Close()
+			return
+		}
+		if msgType == websocket.TextMessage && string(data) == portForwardCloseWrite {
+			// 客户端结束发送，半关闭隧道，继续等待 component 端的剩余响应
+			if err := tunnel.CloseWrite(); err != nil {
+				logrus.Warnf("Failed to half-close port-forward: %v", err)
+			}
+			continue
+		}
+		if msgType != websocket.BinaryMessage {
+			continue
+		}
+		if _, err := tunnel.Write(data); err != nil {
+			logrus.Warnf("Failed to forward port-forward data: %v", err)
+			return
+		}
+	}
+}
+
+// pumpPortForwardOutput 将隧道数据写回 WebSocket，直到 component 端关闭连接
+func pumpPortForwardOutput(conn *websocket.Conn, tunnel *provider.PortForwardConn) {
+	buf := make([]byte, portForwardBufferSize)
+	for {
+		n, err := tunnel.Read(buf)
+		if n > 0 {
+			if werr := conn.WriteMessage(websocket.BinaryMessage, buf[:n]); werr != nil {
+				return
+			}
+		}
+		if err != nil {
+			conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			return
+		}
+	}
+}
@@ -3,8 +3,13 @@ package resource
 import (
 	"time"
 
+	"github.com/9triver/iarnet/internal/domain/resource"
+	"github.com/9triver/iarnet/internal/domain/resource/accounting"
+	"github.com/9triver/iarnet/internal/domain/resource/component"
 	"github.com/9triver/iarnet/internal/domain/resource/provider"
+	"github.com/9triver/iarnet/internal/domain/resource/scheduler"
 	"github.com/9triver/iarnet/internal/domain/resource/types"
+	commonpb "github.com/9triver/iarnet/internal/proto/common"
 )
 
 // GetResourceCapacityResponse 获取资源容量响应
@@ -50,6 +55,180 @@ func (r *GetResourceCapacityResponse) FromCapacity(capacity *types.Capacity) *Ge
 	return r
 }
 
+// GetNodeUtilizationResponse 节点资源利用率响应
+type GetNodeUtilizationResponse struct {
+	NodeID    string                    `json:"node_id"`
+	NodeName  string                    `json:"node_name"`
+	Total     ResourceInfo              `json:"total"`     // 已连接 provider 的总资源
+	Used      ResourceInfo              `json:"used"`      // 已分配资源
+	Available ResourceInfo              `json:"available"` // 可用资源
+	Providers []ProviderUtilizationItem `json:"providers"` // 各 provider 明细
+}
+
+// ProviderUtilizationItem 单个 provider 的资源利用率
+type ProviderUtilizationItem struct {
+	ID         string                       `json:"id"`
+	Name       string                       `json:"name"`
+	Type       string                       `json:"type"`
+	Status     string                       `json:"status"`
+	Components int                          `json:"components"`         // 部署在该 provider 上的 component 数
+	Capacity   *GetResourceCapacityResponse `json:"capacity,omitempty"` // 未连接或获取失败时为空
+	Error      string                       `json:"error,omitempty"`
+}
+
+// FromUtilization 从领域层 NodeUtilization 转换为 HTTP 响应
+func (r *GetNodeUtilizationResponse) FromUtilization(utilization *scheduler.NodeUtilization) *GetNodeUtilizationResponse {
+	r.NodeID = utilization.NodeID
+	r.NodeName = utilization.NodeName
+	capacity := (&GetResourceCapacityResponse{}).FromCapacity(utilization.Capacity)
+	r.Total, r.Used, r.Available = capacity.Total, capacity.Used, capacity.Available
+	r.Providers = make([]ProviderUtilizationItem, 0, len(utilization.Providers))
+	for _, p := range utilization.Providers {
+		item := ProviderUtilizationItem{
+			ID:         p.ProviderID,
+			Name:       p.ProviderName,
+			Type:       p.ProviderType,
+			Status:     p.Status,
+			Components: p.Components,
+			Error:      p.Error,
+		}
+		if p.Capacity != nil {
+			item.Capacity = (&GetResourceCapacityResponse{}).FromCapacity(p.Capacity)
+		}
+		r.Providers = append(r.Providers, item)
+	}
+	return r
+}
+
+// GetAffinitySessionsResponse 会话亲和列表响应
+type GetAffinitySessionsResponse struct {
+	Sessions []AffinitySessionItem `json:"sessions"`
+	Total    int                   `json:"total"`
+}
+
+// AffinitySessionItem 会话亲和绑定
+type AffinitySessionItem struct {
+	Key         string    `json:"key"`
+	Scope       string    `json:"scope"` // node / provider / component
+	NodeID      string    `json:"node_id"`
+	ProviderID  string    `json:"provider_id"`
+	ComponentID string    `json:"component_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastUsed    time.Time `json:"last_used"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// FromSessions 从领域层 AffinitySession 列表转换为 HTTP 响应
+func (r *GetAffinitySessionsResponse) FromSessions(sessions []resource.AffinitySession) *GetAffinitySessionsResponse {
+	r.Sessions = make([]AffinitySessionItem, 0, len(sessions))
+	for _, s := range sessions {
+		r.Sessions = append(r.Sessions, AffinitySessionItem{
+			Key:         s.Key,
+			Scope:       string(s.Scope),
+			NodeID:      s.NodeID,
+			ProviderID:  s.ProviderID,
+			ComponentID: s.ComponentID,
+			CreatedAt:   s.CreatedAt,
+			LastUsed:    s.LastUsed,
+			ExpiresAt:   s.ExpiresAt,
+		})
+	}
+	r.Total = len(r.Sessions)
+	return r
+}
+
+// GetRebalanceReportResponse 再平衡检查结果响应
+type GetRebalanceReportResponse struct {
+	Report *RebalanceReportItem `json:"report"` // 尚未检查过时为空
+}
+
+// RebalanceReportItem 一次再平衡检查的结果
+type RebalanceReportItem struct {
+	Time         time.Time                `json:"time"`
+	DryRun       bool                     `json:"dry_run"`
+	ProviderSkew float64                  `json:"provider_skew"` // 本节点 provider 利用率最大值与最小值之差
+	NodeSkew     float64                  `json:"node_skew"`     // 已知节点利用率最大值与最小值之差
+	Budget       int                      `json:"budget"`        // 剩余迁移配额
+	Providers    []ProviderLoadItem       `json:"providers"`
+	Nodes        []NodeLoadItem           `json:"nodes"`
+	Migrations   []RebalanceMigrationItem `json:"migrations"`
+}
+
+// ProviderLoadItem provider 负载
+type ProviderLoadItem struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Utilization float64 `json:"utilization"`
+	FromUsage   bool    `json:"from_usage"` // 是否基于实时使用量（否则基于已分配量）
+}
+
+// NodeLoadItem 节点负载
+type NodeLoadItem struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Utilization float64 `json:"utilization"`
+}
+
+// RebalanceMigrationItem 迁移计划项
+type RebalanceMigrationItem struct {
+	ComponentID     string  `json:"component_id"`
+	FromProviderID  string  `json:"from_provider_id"`
+	ToProviderID    string  `json:"to_provider_id"`
+	FromUtilization float64 `json:"from_utilization"`
+	ToUtilization   float64 `json:"to_utilization"`
+	Reason          string  `json:"reason"`
+	Detail          string  `json:"detail,omitempty"`
+	Executed        bool    `json:"executed"`
+	Error           string  `json:"error,omitempty"`
+}
+
+// FromReport 从领域层 RebalanceReport 转换为 HTTP 响应
+func (r *GetRebalanceReportResponse) FromReport(report *resource.RebalanceReport) *GetRebalanceReportResponse {
+	if report == nil {
+		return r
+	}
+	item := &RebalanceReportItem{
+		Time:         report.Time,
+		DryRun:       report.DryRun,
+		ProviderSkew: report.ProviderSkew,
+		NodeSkew:     report.NodeSkew,
+		Budget:       report.Budget,
+		Providers:    make([]ProviderLoadItem, 0, len(report.Providers)),
+		Nodes:        make([]NodeLoadItem, 0, len(report.Nodes)),
+		Migrations:   make([]RebalanceMigrationItem, 0, len(report.Migrations)),
+	}
+	for _, p := range report.Providers {
+		item.Providers = append(item.Providers, ProviderLoadItem{
+			ID:          p.ProviderID,
+			Name:        p.ProviderName,
+			Utilization: p.Utilization,
+			FromUsage:   p.FromUsage,
+		})
+	}
+	for _, n := range report.Nodes {
+		item.Nodes = append(item.Nodes, NodeLoadItem{
+			ID:          n.NodeID,
+			Name:        n.NodeName,
+			Utilization: n.Utilization,
+		})
+	}
+	for _, m := range report.Migrations {
+		item.Migrations = append(item.Migrations, RebalanceMigrationItem{
+			ComponentID:     m.ComponentID,
+			FromProviderID:  m.FromProviderID,
+			ToProviderID:    m.ToProviderID,
+			FromUtilization: m.FromUtilization,
+			ToUtilization:   m.ToUtilization,
+			Reason:          m.Reason,
+			Detail:          m.Detail,
+			Executed:        m.Executed,
+			Error:           m.Error,
+		})
+	}
+	r.Report = item
+	return r
+}
+
 // GetResourceProvidersResponse 获取资源提供者列表响应
 type GetResourceProvidersResponse struct {
 	Providers []ProviderItem `json:"providers"` // 提供者列表
@@ -58,14 +237,36 @@ type GetResourceProvidersResponse struct {
 
 // ProviderItem 提供者列表项
 type ProviderItem struct {
-	ID             string            `json:"id"`                      // 提供者 ID
-	Name           string            `json:"name"`                    // 提供者名称
-	Type           string            `json:"type"`                    // 提供者类型
-	Host           string            `json:"host"`                    // 主机地址
-	Port           int               `json:"port"`                    // 端口
-	Status         string            `json:"status"`                  // 状态 (connected/disconnected)
-	LastUpdateTime time.Time         `json:"last_update_time"`        // 最后更新时间
-	ResourceTags   *ResourceTagsInfo `json:"resource_tags,omitempty"` // 资源标签
+	ID             string            `json:"id"`                       // 提供者 ID
+	Name           string            `json:"name"`                     // 提供者名称
+	Type           string            `json:"type"`                     // 提供者类型
+	Host           string            `json:"host"`                     // 主机地址
+	Port           int               `json:"port"`                     // 端口
+	Status         string            `json:"status"`                   // 状态 (connected/disconnected)
+	CapacityClass  string            `json:"capacity_class"`           // 容量类别 (guaranteed/best-effort)
+	Static         bool              `json:"static"`                   // 由配置文件管理，只读
+	LastUpdateTime time.Time         `json:"last_update_time"`         // 最后更新时间
+	ResourceTags   *ResourceTagsInfo `json:"resource_tags,omitempty"`  // 资源标签
+	CapacityAlert  *CapacityAlert    `json:"capacity_alert,omitempty"` // 已分配资源超过 provider 总容量的告警
+}
+
+// CapacityAlert provider 总容量下调到已分配资源以下的告警
+type CapacityAlert struct {
+	Total     ResourceInfo `json:"total"`     // provider 新的总容量
+	Allocated ResourceInfo `json:"allocated"` // 本节点放置在该 provider 上的 component 的资源请求之和
+	Since     time.Time    `json:"since"`     // 首次发现超出的时间
+}
+
+// capacityAlertToInfo 将领域层容量告警转换为响应结构，nil 时返回 nil
+func capacityAlertToInfo(alert *provider.CapacityAlert) *CapacityAlert {
+	if alert == nil {
+		return nil
+	}
+	return &CapacityAlert{
+		Total:     ResourceInfo{CPU: alert.Total.CPU, Memory: alert.Total.Memory, GPU: alert.Total.GPU},
+		Allocated: ResourceInfo{CPU: alert.Allocated.CPU, Memory: alert.Allocated.Memory, GPU: alert.Allocated.GPU},
+		Since:     alert.Since,
+	}
 }
 
 // FromProvider 从领域层 Provider 转换为 ProviderItem
@@ -76,8 +277,11 @@ func (p *ProviderItem) FromProvider(provider interface {
 	GetHost() string
 	GetPort() int
 	GetStatus() types.ProviderStatus
+	GetCapacityClass() types.CapacityClass
+	IsStatic() bool
 	GetLastUpdateTime() time.Time
 	GetResourceTags() *provider.ResourceTags
+	GetCapacityAlert() *provider.CapacityAlert
 }) *ProviderItem {
 	p.ID = provider.GetID()
 	p.Name = provider.GetName()
@@ -85,21 +289,17 @@ func (p *ProviderItem) FromProvider(provider interface {
 	p.Host = provider.GetHost()
 	p.Port = provider.GetPort()
 	p.Status = providerStatusToString(provider.GetStatus())
+	p.CapacityClass = string(provider.GetCapacityClass())
+	p.Static = provider.IsStatic()
 	p.LastUpdateTime = provider.GetLastUpdateTime()
 	p.ResourceTags = resourceTagsToInfo(provider.GetResourceTags())
+	p.CapacityAlert = capacityAlertToInfo(provider.GetCapacityAlert())
 	return p
 }
 
 // providerStatusToString 将 ProviderStatus 转换为字符串
 func providerStatusToString(status types.ProviderStatus) string {
-	switch status {
-	case types.ProviderStatusConnected:
-		return "connected"
-	case types.ProviderStatusDisconnected:
-		return "disconnected"
-	default:
-		return "unknown"
-	}
+	return status.String()
 }
 
 // RegisterResourceProviderRequest 注册资源提供者请求
@@ -107,6 +307,9 @@ type RegisterResourceProviderRequest struct {
 	Name string `json:"name" binding:"required"` // 提供者名称
 	Host string `json:"host" binding:"required"` // 主机地址
 	Port int    `json:"port" binding:"required"` // 端口
+	// CapacityClass 容量类别（guaranteed/best-effort），为空时为 guaranteed
+	// best-effort 表示容量可能随时消失，部署在其上的 component 会被标记为可驱逐
+	CapacityClass string `json:"capacity_class,omitempty"`
 }
 
 // UpdateResourceProviderRequest 更新资源提供者请求
@@ -160,6 +363,9 @@ type TestResourceProviderRequest struct {
 	Name string `json:"name" binding:"required"` // 提供者名称
 	Host string `json:"host" binding:"required"` // 主机地址
 	Port int    `json:"port" binding:"required"` // 端口
+	// CapacityClass 容量类别（guaranteed/best-effort），为空时为 guaranteed
+	// best-effort 表示容量可能随时消失，部署在其上的 component 会被标记为可驱逐
+	CapacityClass string `json:"capacity_class,omitempty"`
 }
 
 // TestResourceProviderResponse 测试资源提供者连接响应
@@ -172,14 +378,70 @@ type TestResourceProviderResponse struct {
 
 // GetResourceProviderInfoResponse 获取资源提供者信息响应
 type GetResourceProviderInfoResponse struct {
-	ID             string            `json:"id"`                      // 提供者 ID
-	Name           string            `json:"name"`                    // 提供者名称
-	Type           string            `json:"type"`                    // 提供者类型
-	Host           string            `json:"host"`                    // 主机地址
-	Port           int               `json:"port"`                    // 端口
-	Status         string            `json:"status"`                  // 状态 (connected/disconnected/unknown)
-	LastUpdateTime time.Time         `json:"last_update_time"`        // 最后更新时间
-	ResourceTags   *ResourceTagsInfo `json:"resource_tags,omitempty"` // 资源标签
+	ID             string              `json:"id"`                       // 提供者 ID
+	Name           string              `json:"name"`                     // 提供者名称
+	Type           string              `json:"type"`                     // 提供者类型
+	Host           string              `json:"host"`                     // 主机地址
+	Port           int                 `json:"port"`                     // 端口
+	Status         string              `json:"status"`                   // 状态 (connected/disconnected/unknown)
+	CapacityClass  string              `json:"capacity_class"`           // 容量类别 (guaranteed/best-effort)
+	Static         bool                `json:"static"`                   // 由配置文件管理，只读
+	LastUpdateTime time.Time           `json:"last_update_time"`         // 最后更新时间
+	ResourceTags   *ResourceTagsInfo   `json:"resource_tags,omitempty"`  // 资源标签
+	Benchmark      *BenchmarkInfo      `json:"benchmark,omitempty"`      // 微基准测试结果（未测量时为空）
+	Protocol       *ProtocolInfo       `json:"protocol,omitempty"`       // 与 provider 协商的协议（未连接时为空）
+	Architectures  []string            `json:"architectures,omitempty"`  // 可运行的 CPU 架构（旧版 provider 不上报）
+	CapacityAlert  *CapacityAlert      `json:"capacity_alert,omitempty"` // 已分配资源超过 provider 总容量的告警
+	GPUs           []types.GPUDevice   `json:"gpus,omitempty"`           // GPU 拓扑与逐卡分配情况（只按数量记账的 provider 不上报）
+	Streams        []types.MediaStream `json:"streams,omitempty"`        // 拥有的数据流（没有摄像头的 provider 不上报）
+}
+
+// ProtocolInfo 协商出的协议版本与能力
+type ProtocolInfo struct {
+	Version      uint32   `json:"version"`
+	Legacy       bool     `json:"legacy"` // 对端未声明协议版本（旧版），可选能力均不可用
+	Capabilities []string `json:"capabilities"`
+}
+
+// protocolToInfo 将协商结果转换为响应结构，nil 时返回 nil
+func protocolToInfo(n *commonpb.Negotiated) *ProtocolInfo {
+	if n == nil {
+		return nil
+	}
+	return &ProtocolInfo{
+		Version:      n.Version,
+		Legacy:       n.Legacy,
+		Capabilities: n.Capabilities(),
+	}
+}
+
+// BenchmarkInfo provider 微基准测试结果（吞吐单位均为 MiB/s）
+type BenchmarkInfo struct {
+	CPUScore            float64   `json:"cpu_score"`
+	MemoryBandwidthMBps float64   `json:"memory_bandwidth_mbps"`
+	DiskWriteMBps       float64   `json:"disk_write_mbps"`
+	DiskReadMBps        float64   `json:"disk_read_mbps"`
+	GPUPresent          bool      `json:"gpu_present"`
+	GPUCount            int       `json:"gpu_count"`
+	DurationMs          int64     `json:"duration_ms"`
+	MeasuredAt          time.Time `json:"measured_at"`
+}
+
+// benchmarkToInfo 将领域层基准测试结果转换为响应结构，nil 时返回 nil
+func benchmarkToInfo(bench *types.BenchmarkResult) *BenchmarkInfo {
+	if bench == nil {
+		return nil
+	}
+	return &BenchmarkInfo{
+		CPUScore:            bench.CPUScore,
+		MemoryBandwidthMBps: bench.MemoryBandwidthMBps,
+		DiskWriteMBps:       bench.DiskWriteMBps,
+		DiskReadMBps:        bench.DiskReadMBps,
+		GPUPresent:          bench.GPUPresent,
+		GPUCount:            bench.GPUCount,
+		DurationMs:          bench.DurationMs,
+		MeasuredAt:          bench.MeasuredAt,
+	}
 }
 
 // FromProvider 从领域层 Provider 转换为 GetResourceProviderInfoResponse
@@ -190,8 +452,16 @@ func (r *GetResourceProviderInfoResponse) FromProvider(provider interface {
 	GetHost() string
 	GetPort() int
 	GetStatus() types.ProviderStatus
+	GetCapacityClass() types.CapacityClass
+	IsStatic() bool
 	GetLastUpdateTime() time.Time
 	GetResourceTags() *provider.ResourceTags
+	GetBenchmark() *types.BenchmarkResult
+	GetProtocol() *commonpb.Negotiated
+	GetArchitectures() []string
+	GetCapacityAlert() *provider.CapacityAlert
+	GetGPUs() []types.GPUDevice
+	GetStreams() []types.MediaStream
 }) *GetResourceProviderInfoResponse {
 	r.ID = provider.GetID()
 	r.Name = provider.GetName()
@@ -199,8 +469,15 @@ func (r *GetResourceProviderInfoResponse) FromProvider(provider interface {
 	r.Host = provider.GetHost()
 	r.Port = provider.GetPort()
 	r.Status = providerStatusToString(provider.GetStatus())
+	r.Static = provider.IsStatic()
 	r.LastUpdateTime = provider.GetLastUpdateTime()
 	r.ResourceTags = resourceTagsToInfo(provider.GetResourceTags())
+	r.Benchmark = benchmarkToInfo(provider.GetBenchmark())
+	r.Protocol = protocolToInfo(provider.GetProtocol())
+	r.Architectures = provider.GetArchitectures()
+	r.CapacityAlert = capacityAlertToInfo(provider.GetCapacityAlert())
+	r.GPUs = provider.GetGPUs()
+	r.Streams = provider.GetStreams()
 	return r
 }
 
@@ -269,3 +546,421 @@ func resourceTagsToInfo(tags *provider.ResourceTags) *ResourceTagsInfo {
 		Camera: tags.Camera,
 	}
 }
+
+// ExecClientMessage component exec WebSocket 客户端消息
+// type: stdin（Data 为输入数据）、resize（Rows/Cols）、close（关闭标准输入）
+type ExecClientMessage struct {
+	Type string `json:"type"`
+	Data string `json:"data,omitempty"`
+	Rows uint32 `json:"rows,omitempty"`
+	Cols uint32 `json:"cols,omitempty"`
+}
+
+// ExecServerMessage component exec WebSocket 服务端消息
+// type: stdout、stderr（Data 为输出数据）、exit（ExitCode）、error（Error）
+type ExecServerMessage struct {
+	Type     string `json:"type"`
+	Data     string `json:"data,omitempty"`
+	ExitCode int32  `json:"exit_code"`
+	Error    string `json:"error,omitempty"`
+}
+
+// GetAccountingReportResponse 资源占用计费报表响应
+type GetAccountingReportResponse struct {
+	From        time.Time            `json:"from"`
+	To          time.Time            `json:"to"`
+	GeneratedAt time.Time            `json:"generated_at"`
+	Lines       []AccountingLineItem `json:"lines"`
+	Total       AccountingLineItem   `json:"total"`
+}
+
+// AccountingLineItem 一个应用在报表期间内的资源时长
+type AccountingLineItem struct {
+	DomainID      string  `json:"domain_id,omitempty"`
+	AppID         string  `json:"app_id,omitempty"`
+	Components    int     `json:"components"`
+	CPUMs         float64 `json:"cpu_ms"`          // CPU 核毫秒
+	MemoryGBHours float64 `json:"memory_gb_hours"` // 内存 GiB 小时
+	GPUHours      float64 `json:"gpu_hours"`       // GPU 卡小时
+}
+
+// FromReport 从领域报表转换
+func (r *GetAccountingReportResponse) FromReport(report *accounting.Report) *GetAccountingReportResponse {
+	r.From = report.From
+	r.To = report.To
+	r.GeneratedAt = report.GeneratedAt
+	r.Lines = make([]AccountingLineItem, 0, len(report.Lines))
+	for _, line := range report.Lines {
+		r.Lines = append(r.Lines, accountingLineItem(line))
+	}
+	r.Total = accountingLineItem(report.Total)
+	return r
+}
+
+func accountingLineItem(line accounting.Line) AccountingLineItem {
+	return AccountingLineItem{
+		DomainID:      line.DomainID,
+		AppID:         line.AppID,
+		Components:    line.Components,
+		CPUMs:         line.CPUMs,
+		MemoryGBHours: line.MemoryGBHours,
+		GPUHours:      line.GPUHours,
+	}
+}
+
+// CheckFeasibilityRequest 部署可行性检查请求
+type CheckFeasibilityRequest struct {
+	RuntimeEnv string                 `json:"runtime_env"` // 为空时为 python
+	Components []FeasibilityComponent `json:"components"`
+}
+
+// FeasibilityComponent 待检查的一类 component
+type FeasibilityComponent struct {
+	CPU          int64             `json:"cpu"`    // millicores
+	Memory       int64             `json:"memory"` // bytes
+	GPU          int64             `json:"gpu"`
+	Tags         []string          `json:"tags,omitempty"`
+	NodeSelector map[string]string `json:"node_selector,omitempty"`
+	Stream       string            `json:"stream,omitempty"`   // 绑定的数据流，只能放置到拥有该数据流的 provider
+	Replicas     int               `json:"replicas,omitempty"` // 副本数，为 0 时按 1 计
+}
+
+// CheckFeasibilityResponse 部署可行性检查结果
+type CheckFeasibilityResponse struct {
+	Feasible  bool                  `json:"feasible"`
+	Items     []FeasibilityPlanItem `json:"items"`     // 按请求展开副本后的顺序
+	Shortfall ResourceInfo          `json:"shortfall"` // 无法放置的 component 的资源总和
+}
+
+// FeasibilityPlanItem 一个 component 的假设放置
+type FeasibilityPlanItem struct {
+	Request    ResourceInfo  `json:"request"`
+	Placed     bool          `json:"placed"`
+	Kind       string        `json:"kind,omitempty"` // provider 或 peer
+	TargetID   string        `json:"target_id,omitempty"`
+	TargetName string        `json:"target_name,omitempty"`
+	Available  *ResourceInfo `json:"available,omitempty"` // 放置前目标的可用资源
+	Reason     string        `json:"reason,omitempty"`
+}
+
+// FromPlan 从领域层 DeploymentPlan 转换为 HTTP 响应
+func (r *CheckFeasibilityResponse) FromPlan(plan *resource.DeploymentPlan) *CheckFeasibilityResponse {
+	r.Feasible = plan.Feasible
+	r.Items = make([]FeasibilityPlanItem, 0, len(plan.Items))
+	for _, item := range plan.Items {
+		planItem := FeasibilityPlanItem{
+			Request:    ResourceInfo{CPU: item.Request.CPU, Memory: item.Request.Memory, GPU: item.Request.GPU},
+			Placed:     item.Placed,
+			Kind:       string(item.Kind),
+			TargetID:   item.TargetID,
+			TargetName: item.TargetName,
+			Reason:     item.Reason,
+		}
+		if item.Available != nil {
+			planItem.Available = &ResourceInfo{CPU: item.Available.CPU, Memory: item.Available.Memory, GPU: item.Available.GPU}
+		}
+		r.Items = append(r.Items, planItem)
+	}
+	r.Shortfall = ResourceInfo{CPU: plan.Shortfall.CPU, Memory: plan.Shortfall.Memory, GPU: plan.Shortfall.GPU}
+	return r
+}
+
+// GetStagingStatusResponse component 数据预置进度
+type GetStagingStatusResponse struct {
+	ComponentID string            `json:"component_id"`
+	Done        bool              `json:"done"` // 所有数据源均已预置完成
+	Items       []StagingItemInfo `json:"items"`
+}
+
+// StagingItemInfo 单个数据源的预置进度
+type StagingItemInfo struct {
+	Path       string `json:"path"`
+	State      string `json:"state"` // pending / downloading / verifying / done / failed
+	BytesDone  int64  `json:"bytes_done"`
+	BytesTotal int64  `json:"bytes_total"` // 未知时为 0
+	Error      string `json:"error,omitempty"`
+}
+
+// FromProgress 从领域层 StagingProgress 列表转换为 HTTP 响应
+func (r *GetStagingStatusResponse) FromProgress(componentID string, items []provider.StagingProgress) *GetStagingStatusResponse {
+	r.ComponentID = componentID
+	r.Done = true
+	r.Items = make([]StagingItemInfo, 0, len(items))
+	for _, item := range items {
+		r.Done = r.Done && item.State == "done"
+		r.Items = append(r.Items, StagingItemInfo{
+			Path:       item.Path,
+			State:      item.State,
+			BytesDone:  item.BytesDone,
+			BytesTotal: item.BytesTotal,
+			Error:      item.Error,
+		})
+	}
+	return r
+}
+
+// ComponentUsageInfo component 的实时资源使用情况
+type ComponentUsageInfo struct {
+	ComponentID string        `json:"component_id"`
+	ProviderID  string        `json:"provider_id"`
+	Usage       ResourceInfo  `json:"usage"`
+	Limit       *ResourceInfo `json:"limit,omitempty"` // 部署时分配的资源上限，provider 未上报时为空
+	MemoryRSS   int64         `json:"memory_rss"`      // 常驻内存（bytes），不含页缓存；无法获取时为 0
+	MemoryCache int64         `json:"memory_cache"`    // 页缓存（bytes）；无法获取时为 0
+	SampledAt   string        `json:"sampled_at"`
+}
+
+// FromUsage 从领域层 ComponentUsage 转换
+func (c *ComponentUsageInfo) FromUsage(usage *provider.ComponentUsage) *ComponentUsageInfo {
+	c.ComponentID = usage.ComponentID
+	c.ProviderID = usage.ProviderID
+	if usage.Usage != nil {
+		c.Usage = ResourceInfo{CPU: usage.Usage.CPU, Memory: usage.Usage.Memory, GPU: usage.Usage.GPU}
+	}
+	if usage.Limit != nil {
+		c.Limit = &ResourceInfo{CPU: usage.Limit.CPU, Memory: usage.Limit.Memory, GPU: usage.Limit.GPU}
+	}
+	c.MemoryRSS = usage.MemoryRSS
+	c.MemoryCache = usage.MemoryCache
+	c.SampledAt = usage.SampledAt.Format(time.RFC3339)
+	return c
+}
+
+// ListComponentUsageResponse 本节点运行中 component 的资源使用情况
+type ListComponentUsageResponse struct {
+	Components []ComponentUsageInfo `json:"components"`
+}
+
+// VolumeInfo provider 上的命名卷
+type VolumeInfo struct {
+	Name       string `json:"name"`
+	ProviderID string `json:"provider_id"`
+	CreatedAt  string `json:"created_at,omitempty"` // 未知时为空
+}
+
+// FromVolume 从领域层 Volume 转换
+func (v *VolumeInfo) FromVolume(volume *provider.Volume) *VolumeInfo {
+	v.Name = volume.Name
+	v.ProviderID = volume.ProviderID
+	if !volume.CreatedAt.IsZero() {
+		v.CreatedAt = volume.CreatedAt.Format(time.RFC3339)
+	}
+	return v
+}
+
+// ListVolumesResponse provider 上的命名卷列表
+type ListVolumesResponse struct {
+	Volumes []VolumeInfo `json:"volumes"`
+}
+
+// CreateVolumeRequest 创建命名卷的请求
+type CreateVolumeRequest struct {
+	Name string `json:"name"`
+}
+
+// ComponentItem component 信息
+type ComponentItem struct {
+	ID         string            `json:"id"`
+	ProviderID string            `json:"provider_id"` // 尚未放置或位于其他节点时为空
+	Image      string            `json:"image"`
+	AppID      string            `json:"app_id,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Resources  ResourceInfo      `json:"resources"`           // 部署时请求的资源
+	Evictable  bool              `json:"evictable,omitempty"` // 部署在 best-effort provider 上，可能被驱逐
+	Sidecars   []string          `json:"sidecars,omitempty"`  // sidecar 名称，资源已计入 resources
+}
+
+// FromComponent 从领域层 Component 转换
+func (c *ComponentItem) FromComponent(comp *component.Component) *ComponentItem {
+	c.ID = comp.GetID()
+	c.ProviderID = comp.GetProviderID()
+	c.Image = comp.GetImage()
+	c.AppID = comp.GetAppID()
+	c.Labels = comp.GetLabels()
+	if usage := comp.GetResourceUsage(); usage != nil {
+		c.Resources = ResourceInfo{CPU: usage.CPU, Memory: usage.Memory, GPU: usage.GPU}
+	}
+	c.Evictable = comp.IsEvictable()
+	for _, sc := range comp.GetSidecars() {
+		c.Sidecars = append(c.Sidecars, sc.Name)
+	}
+	return c
+}
+
+// WaitComponentResponse component 结束等待结果，Finished 为 false 表示等待超时、实例仍在运行
+type WaitComponentResponse struct {
+	ComponentID string     `json:"component_id"`
+	Finished    bool       `json:"finished"`
+	State       string     `json:"state,omitempty"` // exited 或 not_found
+	ExitCode    int32      `json:"exit_code"`
+	Reason      string     `json:"reason,omitempty"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+}
+
+// FromCompletion 从领域层 ComponentCompletion 转换
+func (r *WaitComponentResponse) FromCompletion(completion *resource.ComponentCompletion) *WaitComponentResponse {
+	r.ComponentID = completion.ComponentID
+	r.Finished = true
+	r.State = string(completion.State)
+	r.ExitCode = completion.ExitCode
+	r.Reason = completion.Reason
+	r.FinishedAt = &completion.FinishedAt
+	return r
+}
+
+// ListComponentsResponse component 列表
+type ListComponentsResponse struct {
+	Components []ComponentItem `json:"components"`
+	Total      int             `json:"total"`
+}
+
+// ListClusterComponentsResponse 整个域满足条件的 component
+type ListClusterComponentsResponse struct {
+	Components []ClusterComponentItem `json:"components"`
+	Total      int                    `json:"total"`
+	Nodes      []ClusterNodeItem      `json:"nodes"` // 各节点的查询结果，查询失败的节点带有 error
+}
+
+// ClusterComponentItem 域内某个节点上的 component
+type ClusterComponentItem struct {
+	ID           string            `json:"id"`
+	NodeID       string            `json:"node_id"`
+	NodeName     string            `json:"node_name"`
+	ProviderID   string            `json:"provider_id"` // 尚未放置时为空
+	ProviderType string            `json:"provider_type,omitempty"`
+	State        string            `json:"state"` // deploying / running / unreachable
+	Image        string            `json:"image"`
+	AppID        string            `json:"app_id,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty"`
+	Resources    ResourceInfo      `json:"resources"`
+	Evictable    bool              `json:"evictable,omitempty"`
+	Origin       string            `json:"origin,omitempty"` // 委托方节点 ID，节点自身发起的部署为空
+}
+
+// ClusterNodeItem 单个节点的查询结果
+type ClusterNodeItem struct {
+	NodeID     string `json:"node_id"`
+	NodeName   string `json:"node_name"`
+	Components int    `json:"components"`
+	Error      string `json:"error,omitempty"`
+}
+
+// FromClusterComponents 从领域层查询结果转换
+func (r *ListClusterComponentsResponse) FromClusterComponents(result *resource.ClusterComponents) *ListClusterComponentsResponse {
+	r.Components = clusterComponentItems(result.Components)
+	r.Total = len(r.Components)
+	r.Nodes = make([]ClusterNodeItem, 0, len(result.Nodes))
+	for _, n := range result.Nodes {
+		r.Nodes = append(r.Nodes, ClusterNodeItem{NodeID: n.NodeID, NodeName: n.NodeName, Components: n.Components, Error: n.Error})
+	}
+	return r
+}
+
+// clusterComponentItems 从领域层 component 摘要转换
+func clusterComponentItems(components []scheduler.ComponentSummary) []ClusterComponentItem {
+	items := make([]ClusterComponentItem, 0, len(components))
+	for _, c := range components {
+		item := ClusterComponentItem{
+			ID:           c.ID,
+			NodeID:       c.NodeID,
+			NodeName:     c.NodeName,
+			ProviderID:   c.ProviderID,
+			ProviderType: c.ProviderType,
+			State:        c.State,
+			Image:        c.Image,
+			AppID:        c.AppID,
+			Labels:       c.Labels,
+			Evictable:    c.Evictable,
+			Origin:       c.Origin,
+		}
+		if c.Resources != nil {
+			item.Resources = ResourceInfo{CPU: c.Resources.CPU, Memory: c.Resources.Memory, GPU: c.Resources.GPU}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// AdoptComponentsRequest 接管已离开节点委托部署的 component 的请求
+type AdoptComponentsRequest struct {
+	NodeID string `json:"node_id"` // 已离开的委托方节点 ID
+}
+
+// AdoptComponentsResponse 接管结果
+type AdoptComponentsResponse struct {
+	FromNodeID string                 `json:"from_node_id"`
+	Components []ClusterComponentItem `json:"components"` // 已接管的 component，node_id 为其所在节点
+	Total      int                    `json:"total"`
+	Nodes      []AdoptionNodeItem     `json:"nodes"` // 各节点的转移结果，失败的节点带有 error
+}
+
+// AdoptionNodeItem 单个节点的转移结果
+type AdoptionNodeItem struct {
+	NodeID   string            `json:"node_id"`
+	NodeName string            `json:"node_name"`
+	Adopted  int               `json:"adopted"`
+	Failed   map[string]string `json:"failed,omitempty"` // component ID -> 转移失败的原因
+	Error    string            `json:"error,omitempty"`
+}
+
+// FromAdoptionReport 从领域层接管结果转换
+func (r *AdoptComponentsResponse) FromAdoptionReport(report *resource.AdoptionReport) *AdoptComponentsResponse {
+	r.FromNodeID = report.FromNodeID
+	r.Components = clusterComponentItems(report.Components)
+	r.Total = len(r.Components)
+	r.Nodes = make([]AdoptionNodeItem, 0, len(report.Nodes))
+	for _, n := range report.Nodes {
+		r.Nodes = append(r.Nodes, AdoptionNodeItem{NodeID: n.NodeID, NodeName: n.NodeName, Adopted: n.Adopted, Failed: n.Failed, Error: n.Error})
+	}
+	return r
+}
+
+// DeployComponentRequest 部署 component 的请求
+type DeployComponentRequest struct {
+	RuntimeEnv     string            `json:"runtime_env,omitempty"` // 缺省为 python
+	CPU            int64             `json:"cpu"`                   // millicores
+	Memory         int64             `json:"memory"`                // bytes
+	GPU            int64             `json:"gpu"`
+	Tags           []string          `json:"tags,omitempty"`
+	NodeSelector   map[string]string `json:"node_selector,omitempty"`
+	Stream         string            `json:"stream,omitempty"`          // 绑定的数据流（摄像头接入组件），只能部署到拥有该数据流的 provider
+	Labels         map[string]string `json:"labels,omitempty"`          // component 标签，可用于查询整个域的 component
+	TimeoutSeconds int               `json:"timeout_seconds,omitempty"` // 部署超时，0 表示不限制
+	Sidecars       []SidecarRequest  `json:"sidecars,omitempty"`        // 与主容器部署到同一 provider 的 sidecar
+}
+
+// SidecarRequest sidecar 容器，资源与主容器的 cpu/memory/gpu 合并核算
+type SidecarRequest struct {
+	Name    string            `json:"name"`
+	Image   string            `json:"image"`
+	CPU     int64             `json:"cpu"`    // millicores
+	Memory  int64             `json:"memory"` // bytes
+	GPU     int64             `json:"gpu"`
+	Env     map[string]string `json:"env,omitempty"`
+	Command []string          `json:"command,omitempty"`
+}
+
+// ToSidecars 转换为领域层 Sidecar
+func (r *DeployComponentRequest) ToSidecars() []provider.Sidecar {
+	if len(r.Sidecars) == 0 {
+		return nil
+	}
+	sidecars := make([]provider.Sidecar, 0, len(r.Sidecars))
+	for _, sc := range r.Sidecars {
+		sidecars = append(sidecars, provider.Sidecar{
+			Name:    sc.Name,
+			Image:   sc.Image,
+			CPU:     sc.CPU,
+			Memory:  sc.Memory,
+			GPU:     sc.GPU,
+			Env:     sc.Env,
+			Command: sc.Command,
+		})
+	}
+	return sidecars
+}
+
+// MigrateComponentRequest 迁移 component 的请求
+type MigrateComponentRequest struct {
+	ProviderID string `json:"provider_id"` // 本节点上的目标 provider
+}
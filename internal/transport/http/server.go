@@ -2,17 +2,21 @@ package http
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/9triver/iarnet/internal/bootstrap/module"
 	"github.com/9triver/iarnet/internal/config"
 	"github.com/9triver/iarnet/internal/domain/application"
 	"github.com/9triver/iarnet/internal/domain/ignis"
 	"github.com/9triver/iarnet/internal/domain/resource"
 	"github.com/9triver/iarnet/internal/domain/resource/discovery"
+	"github.com/9triver/iarnet/internal/transport/handover"
 	applicationAPI "github.com/9triver/iarnet/internal/transport/http/application"
 	resourceAPI "github.com/9triver/iarnet/internal/transport/http/resource"
+	systemAPI "github.com/9triver/iarnet/internal/transport/http/system"
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
 )
@@ -24,6 +28,7 @@ type Options struct {
 	ResMgr           *resource.Manager
 	Platform         *ignis.Platform
 	DiscoveryService discovery.Service
+	Modules          *module.Registry
 }
 
 type Server struct {
@@ -35,13 +40,18 @@ func NewServer(opts Options) *Server {
 	router := mux.NewRouter()
 	applicationAPI.RegisterRoutes(router, opts.AppMgr)
 	resourceAPI.RegisterRoutes(router, opts.ResMgr, opts.Config, opts.DiscoveryService)
+	systemAPI.RegisterRoutes(router, opts.Modules, opts.Config)
 
 	return &Server{Server: &http.Server{Addr: fmt.Sprintf("0.0.0.0:%d", opts.Port), Handler: router}, Router: router}
 }
 
 func (s *Server) Start() {
+	lis, err := handover.Listen("tcp", s.Server.Addr)
+	if err != nil {
+		logrus.Fatalf("Failed to start HTTP server: %v", err)
+	}
 	go func() {
-		if err := s.Server.ListenAndServe(); err != nil {
+		if err := s.Server.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			logrus.Fatalf("Failed to start HTTP server: %v", err)
 		}
 	}()
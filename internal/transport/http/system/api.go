@@ -0,0 +1,198 @@
+package system
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/9triver/iarnet/internal/bootstrap/module"
+	"github.com/9triver/iarnet/internal/config"
+	"github.com/9triver/iarnet/internal/transport/http/util/rbac"
+	"github.com/9triver/iarnet/internal/transport/http/util/response"
+	"github.com/9triver/iarnet/internal/util"
+	"github.com/9triver/iarnet/internal/util/compress"
+	"github.com/9triver/iarnet/internal/util/msgguard"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+func RegisterRoutes(router *mux.Router, modules *module.Registry, cfg *config.Config) {
+	api := NewAPI(modules, cfg)
+	router.HandleFunc("/system/modules", api.handleGetModules).Methods("GET")
+	router.HandleFunc("/system/compression", api.handleGetCompression).Methods("GET")
+	router.HandleFunc("/system/messages", api.handleGetMessages).Methods("GET")
+	router.HandleFunc("/system/logging", api.handleGetLogging).Methods("GET")
+	router.HandleFunc("/system/logging", api.authorizer.Require(rbac.PermissionSystemLogging, api.handleUpdateLogging)).Methods("PUT")
+}
+
+type API struct {
+	modules    *module.Registry
+	authorizer *rbac.Authorizer
+}
+
+func NewAPI(modules *module.Registry, cfg *config.Config) *API {
+	return &API{
+		modules:    modules,
+		authorizer: rbac.NewAuthorizer(cfg.Transport.HTTP.RBAC),
+	}
+}
+
+// GetModulesResponse 节点各启动模块的状态
+type GetModulesResponse struct {
+	Healthy bool           `json:"healthy"` // 所有核心模块均在运行
+	Modules []module.State `json:"modules"`
+}
+
+func (api *API) handleGetModules(w http.ResponseWriter, r *http.Request) {
+	resp := GetModulesResponse{Healthy: true, Modules: []module.State{}}
+	if api.modules != nil {
+		resp.Healthy = api.modules.Healthy()
+		resp.Modules = api.modules.List()
+	}
+	response.Success(resp).WriteJSON(w)
+}
+
+// CompressionChannelItem 单个通道的负载压缩统计
+type CompressionChannelItem struct {
+	Channel            string  `json:"channel"`             // ignis / store / discovery / scheduler / zmq
+	Messages           int64   `json:"messages"`            // 经过该通道的消息数
+	CompressedMessages int64   `json:"compressed_messages"` // 实际被压缩的消息数
+	RawBytes           int64   `json:"raw_bytes"`           // 压缩前字节数
+	WireBytes          int64   `json:"wire_bytes"`          // 实际传输字节数
+	BytesSaved         int64   `json:"bytes_saved"`         // 节省的字节数
+	Ratio              float64 `json:"ratio"`               // 传输字节数 / 压缩前字节数
+}
+
+// GetCompressionResponse 节点启动以来各通道的负载压缩统计
+type GetCompressionResponse struct {
+	GRPCAlgorithm   string                   `json:"grpc_algorithm"` // gRPC 通道发送方向使用的算法
+	TotalBytesSaved int64                    `json:"total_bytes_saved"`
+	Channels        []CompressionChannelItem `json:"channels"`
+}
+
+func (api *API) handleGetCompression(w http.ResponseWriter, r *http.Request) {
+	resp := GetCompressionResponse{
+		GRPCAlgorithm: string(compress.GRPCAlgorithm()),
+		Channels:      []CompressionChannelItem{},
+	}
+	for _, s := range compress.Snapshot() {
+		resp.TotalBytesSaved += s.BytesSaved()
+		resp.Channels = append(resp.Channels, CompressionChannelItem{
+			Channel:            s.Channel,
+			Messages:           s.Messages,
+			CompressedMessages: s.CompressedMessages,
+			RawBytes:           s.RawBytes,
+			WireBytes:          s.WireBytes,
+			BytesSaved:         s.BytesSaved(),
+			Ratio:              s.Ratio(),
+		})
+	}
+	response.Success(resp).WriteJSON(w)
+}
+
+// MalformedPeerItem 单个对端发送的非法消息统计
+type MalformedPeerItem struct {
+	Channel      string     `json:"channel"`      // zmq / ignis
+	Peer         string     `json:"peer"`         // ZMQ 为 component 身份，ignis 为客户端 IP
+	BadMessages  int64      `json:"bad_messages"` // 节点启动以来的非法消息数
+	Blocks       int64      `json:"blocks"`       // 被断开的次数
+	LastReason   string     `json:"last_reason"`
+	LastSeen     time.Time  `json:"last_seen"`
+	Blocked      bool       `json:"blocked"`                 // 当前是否被拒绝
+	BlockedUntil *time.Time `json:"blocked_until,omitempty"` // 当前封禁的到期时间
+}
+
+// GetMessagesResponse 节点启动以来各边界收到的非法消息统计
+type GetMessagesResponse struct {
+	Peers []MalformedPeerItem `json:"peers"`
+}
+
+func (api *API) handleGetMessages(w http.ResponseWriter, r *http.Request) {
+	resp := GetMessagesResponse{Peers: []MalformedPeerItem{}}
+	now := time.Now()
+	for _, s := range msgguard.Snapshot() {
+		item := MalformedPeerItem{
+			Channel:     s.Channel,
+			Peer:        s.Peer,
+			BadMessages: s.BadMessages,
+			Blocks:      s.Blocks,
+			LastReason:  s.LastReason,
+			LastSeen:    s.LastSeen,
+		}
+		if now.Before(s.BlockedUntil) {
+			until := s.BlockedUntil
+			item.Blocked = true
+			item.BlockedUntil = &until
+		}
+		resp.Peers = append(resp.Peers, item)
+	}
+	response.Success(resp).WriteJSON(w)
+}
+
+// GetLoggingResponse 当前的日志格式与各模块级别
+type GetLoggingResponse struct {
+	Format  string            `json:"format"`  // text / json
+	Level   string            `json:"level"`   // 不属于任何模块的日志的级别
+	Modules map[string]string `json:"modules"` // 各模块的生效级别，未单独设置的模块为全局级别
+}
+
+// UpdateLoggingRequest 调整日志级别，未给出的字段保持不变
+type UpdateLoggingRequest struct {
+	Level   string            `json:"level,omitempty"`
+	Modules map[string]string `json:"modules,omitempty"` // 模块 -> 级别，级别为空表示恢复使用全局级别
+}
+
+func (api *API) handleGetLogging(w http.ResponseWriter, r *http.Request) {
+	response.Success(currentLogging()).WriteJSON(w)
+}
+
+// handleUpdateLogging 在运行时调整全局或模块的日志级别，节点重启后恢复为配置文件中的级别
+func (api *API) handleUpdateLogging(w http.ResponseWriter, r *http.Request) {
+	req := UpdateLoggingRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest("invalid request body: " + err.Error()).WriteJSON(w)
+		return
+	}
+	// 先校验全部字段，避免只应用了部分调整
+	if req.Level != "" {
+		if _, err := logrus.ParseLevel(req.Level); err != nil {
+			response.BadRequest(err.Error()).WriteJSON(w)
+			return
+		}
+	}
+	for module, level := range req.Modules {
+		if !slices.Contains(util.LogModules(), module) {
+			response.BadRequest("unknown log module: " + module).WriteJSON(w)
+			return
+		}
+		if level == "" {
+			continue
+		}
+		if _, err := logrus.ParseLevel(level); err != nil {
+			response.BadRequest(err.Error()).WriteJSON(w)
+			return
+		}
+	}
+
+	if req.Level != "" {
+		util.SetLogLevel(req.Level)
+	}
+	for module, level := range req.Modules {
+		util.SetModuleLogLevel(module, level)
+	}
+	logrus.Infof("Log levels updated: level=%q, modules=%v", req.Level, req.Modules)
+	response.Success(currentLogging()).WriteJSON(w)
+}
+
+func currentLogging() GetLoggingResponse {
+	format, level, overrides := util.LogLevels()
+	resp := GetLoggingResponse{Format: format, Level: level, Modules: make(map[string]string)}
+	for _, module := range util.LogModules() {
+		resp.Modules[module] = level
+		if moduleLevel, ok := overrides[module]; ok {
+			resp.Modules[module] = moduleLevel
+		}
+	}
+	return resp
+}
@@ -0,0 +1,97 @@
+package rbac
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/9triver/iarnet/internal/config"
+	"github.com/9triver/iarnet/internal/transport/http/util/response"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// PermissionComponentExec 在 component 内执行调试命令
+	PermissionComponentExec = "component:exec"
+	// PermissionComponentPortForward 将本地端口转发到 component 端口
+	PermissionComponentPortForward = "component:port-forward"
+	// PermissionComponentManage 部署、删除与迁移 component
+	PermissionComponentManage = "component:manage"
+	// PermissionSystemLogging 在运行时调整日志级别
+	PermissionSystemLogging = "system:logging"
+
+	// wildcardPermission 授予全部权限
+	wildcardPermission = "*"
+)
+
+// Authorizer 基于访问令牌和角色的权限校验器
+type Authorizer struct {
+	enabled bool
+	roles   map[string][]string
+	tokens  []config.RBACTokenConfig
+}
+
+// NewAuthorizer 根据配置创建权限校验器
+func NewAuthorizer(cfg config.RBACConfig) *Authorizer {
+	return &Authorizer{
+		enabled: cfg.Enabled,
+		roles:   cfg.Roles,
+		tokens:  cfg.Tokens,
+	}
+}
+
+// Require 包装需要指定权限的 handler
+// 未启用 RBAC 时受保护接口一律拒绝，避免在未配置的情况下暴露敏感操作
+func (a *Authorizer) Require(permission string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !a.enabled {
+			response.Forbidden("rbac is not enabled; " + permission + " is disabled").WriteJSON(w)
+			return
+		}
+
+		token := extractToken(r)
+		if token == "" {
+			response.Unauthorized("access token is required").WriteJSON(w)
+			return
+		}
+
+		subject, ok := a.authorize(token, permission)
+		if !ok {
+			logrus.Warnf("RBAC denied %s on %s %s", permission, r.Method, r.URL.Path)
+			response.Forbidden("permission " + permission + " denied").WriteJSON(w)
+			return
+		}
+
+		logrus.Infof("RBAC granted %s to %s on %s %s", permission, subject, r.Method, r.URL.Path)
+		next(w, r)
+	}
+}
+
+// authorize 校验令牌是否拥有指定权限，返回令牌所属主体
+func (a *Authorizer) authorize(token, permission string) (string, bool) {
+	for _, t := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(t.Token), []byte(token)) != 1 {
+			continue
+		}
+		for _, role := range t.Roles {
+			perms := a.roles[role]
+			if slices.Contains(perms, permission) || slices.Contains(perms, wildcardPermission) {
+				return t.Subject, true
+			}
+		}
+		return t.Subject, false
+	}
+	return "", false
+}
+
+// extractToken 从 Authorization 头或 access_token 查询参数中读取令牌
+// 浏览器的 WebSocket 无法自定义请求头，因此允许通过查询参数传递
+func extractToken(r *http.Request) string {
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		if token, ok := strings.CutPrefix(auth, "Bearer "); ok {
+			return strings.TrimSpace(token)
+		}
+	}
+	return r.URL.Query().Get("access_token")
+}
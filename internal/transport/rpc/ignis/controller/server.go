@@ -1,8 +1,16 @@
 package controller
 
 import (
+	"context"
+	"fmt"
+	"net"
+
 	"github.com/9triver/iarnet/internal/domain/ignis/controller"
 	ctrlpb "github.com/9triver/iarnet/internal/proto/ignis/controller"
+	"github.com/9triver/iarnet/internal/util/msgguard"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 type Server struct {
@@ -14,7 +22,57 @@ func NewServer(controllerService controller.Service) *Server {
 	return &Server{controllerService: controllerService}
 }
 
+// Session 处理客户端会话；不符合消息定义的消息被丢弃并计入客户端地址的非法消息数，
+// 非法消息过多的客户端被断开，封禁期间新的会话直接被拒绝
 func (s *Server) Session(stream ctrlpb.Service_SessionServer) error {
 	ctx := stream.Context()
-	return s.controllerService.HandleSession(ctx, stream.Recv, stream.Send)
+	guard := msgguard.For(msgguard.ChannelIgnis)
+	client := peerHost(ctx)
+	if guard.Blocked(client) {
+		return status.Error(codes.PermissionDenied, msgguard.ErrBlocked.Error())
+	}
+
+	recv := func() (*ctrlpb.Message, error) {
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				return nil, err
+			}
+			if err := validateMessage(msg); err != nil {
+				if guard.Reject(client, err) {
+					return nil, status.Error(codes.PermissionDenied, msgguard.ErrBlocked.Error())
+				}
+				continue
+			}
+			return msg, nil
+		}
+	}
+	return s.controllerService.HandleSession(ctx, recv, stream.Send)
+}
+
+// validateMessage 检查消息携带应用 ID、类型已知且携带命令
+func validateMessage(msg *ctrlpb.Message) error {
+	if msg.GetAppID() == "" {
+		return fmt.Errorf("message without application id")
+	}
+	if _, ok := ctrlpb.CommandType_name[int32(msg.GetType())]; !ok || msg.GetType() == ctrlpb.CommandType_UNSPECIFIED {
+		return fmt.Errorf("unknown command type %d", msg.GetType())
+	}
+	if msg.GetCommand() == nil {
+		return fmt.Errorf("%s message without command", msg.GetType())
+	}
+	return nil
+}
+
+// peerHost 客户端的 IP，同一主机的多个连接共用非法消息计数
+func peerHost(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "unknown"
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
 }
@@ -21,12 +21,14 @@ import (
 	resLoggerPB "github.com/9triver/iarnet/internal/proto/resource/logger"
 	schedulerpb "github.com/9triver/iarnet/internal/proto/resource/scheduler"
 	storepb "github.com/9triver/iarnet/internal/proto/resource/store"
+	"github.com/9triver/iarnet/internal/transport/handover"
 	appLoggerRPC "github.com/9triver/iarnet/internal/transport/rpc/application/logger"
 	controllerrpc "github.com/9triver/iarnet/internal/transport/rpc/ignis/controller"
 	discoveryrpc "github.com/9triver/iarnet/internal/transport/rpc/resource/discovery"
 	resLoggerRPC "github.com/9triver/iarnet/internal/transport/rpc/resource/logger"
 	schedulerrpc "github.com/9triver/iarnet/internal/transport/rpc/resource/scheduler"
 	storerpc "github.com/9triver/iarnet/internal/transport/rpc/resource/store"
+	"github.com/9triver/iarnet/internal/util/compress"
 )
 
 type server struct {
@@ -79,6 +81,18 @@ type Options struct {
 	ResourceLoggerServerOpts []grpc.ServerOption
 	DiscoveryServerOpts      []grpc.ServerOption
 	SchedulerServerOpts      []grpc.ServerOption
+	MaxRecvMsgSize           int // 各服务器接收的单条消息上限，0 表示使用 defaultMaxRecvMsgSize
+}
+
+// defaultMaxRecvMsgSize 未配置时的接收消息上限
+const defaultMaxRecvMsgSize = 512 * 1024 * 1024
+
+// maxRecvMsgSize 返回配置的接收消息上限
+func (o Options) maxRecvMsgSize() int {
+	if o.MaxRecvMsgSize > 0 {
+		return o.MaxRecvMsgSize
+	}
+	return defaultMaxRecvMsgSize
 }
 
 // Manager manages the lifecycle of RPC servers.
@@ -133,9 +147,10 @@ func (m *Manager) Start() error {
 	m.startOnce.Do(func() {
 		var startedServers []*server
 
-		// 配置 Ignis 服务器选项，添加最大接收消息大小限制，TODO: 加入配置文件
+		// 配置 Ignis 服务器选项，添加最大接收消息大小限制
 		ignisOpts := append([]grpc.ServerOption{}, m.Options.IgnisServerOpts...)
-		ignisOpts = append(ignisOpts, grpc.MaxRecvMsgSize(512*1024*1024))
+		ignisOpts = append(ignisOpts, grpc.MaxRecvMsgSize(m.Options.maxRecvMsgSize()))
+		ignisOpts = append(ignisOpts, compress.ServerOptions(compress.ChannelIgnis)...)
 
 		// 启动 Ignis 服务器
 		ignis, err := startServer(m.Options.IgnisAddr, ignisOpts, func(s *grpc.Server) {
@@ -149,9 +164,10 @@ func (m *Manager) Start() error {
 			startedServers = append(startedServers, ignis)
 		}
 
-		// 配置 Store 服务器选项，添加最大接收消息大小限制，TODO: 加入配置文件
+		// 配置 Store 服务器选项，添加最大接收消息大小限制
 		storeOpts := append([]grpc.ServerOption{}, m.Options.StoreServerOpts...)
-		storeOpts = append(storeOpts, grpc.MaxRecvMsgSize(512*1024*1024))
+		storeOpts = append(storeOpts, grpc.MaxRecvMsgSize(m.Options.maxRecvMsgSize()))
+		storeOpts = append(storeOpts, compress.ServerOptions(compress.ChannelStore)...)
 
 		// 启动 Store 服务器
 		store, err := startServer(m.Options.StoreAddr, storeOpts, func(s *grpc.Server) {
@@ -208,7 +224,8 @@ func (m *Manager) Start() error {
 		// 启动 Discovery 服务器（如果配置了）
 		if m.Options.DiscoveryAddr != "" && m.Options.DiscoveryService != nil && m.Options.DiscoveryManager != nil {
 			discoveryOpts := append([]grpc.ServerOption{}, m.Options.DiscoveryServerOpts...)
-			discoveryOpts = append(discoveryOpts, grpc.MaxRecvMsgSize(512*1024*1024))
+			discoveryOpts = append(discoveryOpts, grpc.MaxRecvMsgSize(m.Options.maxRecvMsgSize()))
+			discoveryOpts = append(discoveryOpts, compress.ServerOptions(compress.ChannelDiscovery)...)
 
 			discovery, err := startServer(m.Options.DiscoveryAddr, discoveryOpts, func(s *grpc.Server) {
 				discoverypb.RegisterDiscoveryServiceServer(s, discoveryrpc.NewServer(m.Options.DiscoveryService, m.Options.DiscoveryManager))
@@ -229,7 +246,8 @@ func (m *Manager) Start() error {
 		// 启动 Scheduler 服务器（如果配置了）
 		if m.Options.SchedulerAddr != "" && m.Options.SchedulerService != nil {
 			schedulerOpts := append([]grpc.ServerOption{}, m.Options.SchedulerServerOpts...)
-			schedulerOpts = append(schedulerOpts, grpc.MaxRecvMsgSize(512*1024*1024))
+			schedulerOpts = append(schedulerOpts, grpc.MaxRecvMsgSize(m.Options.maxRecvMsgSize()))
+			schedulerOpts = append(schedulerOpts, compress.ServerOptions(compress.ChannelScheduler)...)
 
 			scheduler, err := startServer(m.Options.SchedulerAddr, schedulerOpts, func(s *grpc.Server) {
 				schedulerpb.RegisterSchedulerServiceServer(s, schedulerrpc.NewServer(m.Options.SchedulerService))
@@ -284,7 +302,7 @@ func shutdownWithTimeout(s *server, timeout time.Duration) {
 }
 
 func startServer(addr string, opts []grpc.ServerOption, register func(*grpc.Server)) (*server, error) {
-	lis, err := net.Listen("tcp4", addr)
+	lis, err := handover.Listen("tcp4", addr)
 	if err != nil {
 		return nil, err
 	}
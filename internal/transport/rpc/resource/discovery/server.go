@@ -118,9 +118,10 @@ func (s *Server) QueryResources(ctx context.Context, req *discoverypb.ResourceQu
 
 	// 转换资源请求
 	resourceRequest := &discovery.ResourceRequest{
-		CPU:    req.ResourceRequest.Cpu,
-		Memory: req.ResourceRequest.Memory,
-		GPU:    req.ResourceRequest.Gpu,
+		CPU:          req.ResourceRequest.Cpu,
+		Memory:       req.ResourceRequest.Memory,
+		GPU:          req.ResourceRequest.Gpu,
+		NodeSelector: req.ResourceRequest.NodeSelector,
 	}
 
 	var requiredTags *discovery.ResourceTags
@@ -270,6 +271,24 @@ func convertPeerNodeToProto(node *discovery.PeerNode, gossipCount int) *discover
 		}
 	}
 
+	// 转换能耗画像
+	if node.EnergyProfile != nil {
+		protoNode.EnergyProfile = &discoverypb.EnergyProfile{
+			WattsPerCore:   node.EnergyProfile.WattsPerCore,
+			BatteryPowered: node.EnergyProfile.BatteryPowered,
+		}
+	}
+
+	if len(node.Labels) > 0 {
+		protoNode.Labels = make(map[string]string, len(node.Labels))
+		for k, v := range node.Labels {
+			protoNode.Labels[k] = v
+		}
+	}
+	protoNode.HeadRole = string(node.HeadRole)
+	protoNode.HeadTerm = node.HeadTerm
+	protoNode.HeadPriority = node.HeadPriority
+
 	return protoNode
 }
 
@@ -328,6 +347,24 @@ func convertProtoToPeerNode(proto *discoverypb.PeerNodeInfo) *discovery.PeerNode
 		}
 	}
 
+	// 转换能耗画像
+	if proto.EnergyProfile != nil {
+		node.EnergyProfile = &types.EnergyProfile{
+			WattsPerCore:   proto.EnergyProfile.WattsPerCore,
+			BatteryPowered: proto.EnergyProfile.BatteryPowered,
+		}
+	}
+
+	if len(proto.Labels) > 0 {
+		node.Labels = make(map[string]string, len(proto.Labels))
+		for k, v := range proto.Labels {
+			node.Labels[k] = v
+		}
+	}
+	node.HeadRole = discovery.HeadRole(proto.HeadRole)
+	node.HeadTerm = proto.HeadTerm
+	node.HeadPriority = proto.HeadPriority
+
 	return node
 }
 
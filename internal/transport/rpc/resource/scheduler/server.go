@@ -2,11 +2,16 @@ package scheduler
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/9triver/iarnet/internal/domain/resource/component"
+	"github.com/9triver/iarnet/internal/domain/resource/provider"
 	"github.com/9triver/iarnet/internal/domain/resource/scheduler"
 	"github.com/9triver/iarnet/internal/domain/resource/types"
 	resourcepb "github.com/9triver/iarnet/internal/proto/resource"
 	schedulerpb "github.com/9triver/iarnet/internal/proto/resource/scheduler"
+	"github.com/9triver/iarnet/internal/util/identity"
 	"github.com/sirupsen/logrus"
 )
 
@@ -31,21 +36,75 @@ func (s *Server) DeployComponent(ctx context.Context, req *schedulerpb.DeployCom
 			Error:   "request is required",
 		}, nil
 	}
+	// 委托方即调用方：对账时按委托方确认 component 的去留，不能冒用其他节点的 ID
+	if err := identity.CheckPeerNodeID(ctx, req.OriginNodeId); err != nil {
+		return nil, err
+	}
 
 	// 转换请求
 	deployReq := &scheduler.DeployRequest{
 		RuntimeEnv: types.RuntimeEnv(req.RuntimeEnv),
 		ResourceRequest: &types.Info{
-			CPU:    req.ResourceRequest.Cpu,
-			Memory: req.ResourceRequest.Memory,
-			GPU:    req.ResourceRequest.Gpu,
-			Tags:   req.ResourceRequest.Tags,
+			CPU:          req.ResourceRequest.Cpu,
+			Memory:       req.ResourceRequest.Memory,
+			GPU:          req.ResourceRequest.Gpu,
+			Tags:         req.ResourceRequest.Tags,
+			NodeSelector: req.ResourceRequest.NodeSelector,
+			Stream:       req.ResourceRequest.Stream,
 		},
 		TargetNodeID:          req.TargetNodeId,
 		TargetAddress:         req.TargetNodeAddress,
 		UpstreamZMQAddress:    req.UpstreamZmqAddress,
 		UpstreamStoreAddress:  req.UpstreamStoreAddress,
 		UpstreamLoggerAddress: req.UpstreamLoggerAddress,
+		UpstreamToken:         req.UpstreamToken,
+		ComponentID:           req.ComponentId,
+		DataSources:           provider.DataSourcesFromProto(req.DataSources),
+		SecurityContext:       provider.SecurityContextFromProto(req.SecurityContext),
+		Sidecars:              provider.SidecarsFromProto(req.Sidecars),
+		AppID:                 req.AppId,
+		Labels:                req.Labels,
+		OriginNodeID:          req.OriginNodeId,
+	}
+	if err := provider.ValidateDataSources(deployReq.DataSources); err != nil {
+		return &schedulerpb.DeployComponentResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+	if err := component.ValidateLabels(deployReq.Labels); err != nil {
+		return &schedulerpb.DeployComponentResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+	if err := provider.ValidateSidecars(deployReq.Sidecars); err != nil {
+		return &schedulerpb.DeployComponentResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+	if sc := deployReq.SecurityContext; sc != nil {
+		if err := sc.Validate(); err != nil {
+			return &schedulerpb.DeployComponentResponse{
+				Success: false,
+				Error:   err.Error(),
+			}, nil
+		}
+	}
+	if req.AffinityKey != "" {
+		scope, ok := provider.ParseAffinityScope(req.AffinityScope)
+		if !ok {
+			return &schedulerpb.DeployComponentResponse{
+				Success: false,
+				Error:   fmt.Sprintf("invalid affinity scope: %s", req.AffinityScope),
+			}, nil
+		}
+		deployReq.Affinity = &provider.Affinity{
+			Key:   req.AffinityKey,
+			Scope: scope,
+			TTL:   time.Duration(req.AffinityTtlSeconds) * time.Second,
+		}
 	}
 
 	// 调用服务
@@ -85,6 +144,30 @@ func (s *Server) DeployComponent(ctx context.Context, req *schedulerpb.DeployCom
 	return protoResp, nil
 }
 
+// UndeployComponent 删除 component
+func (s *Server) UndeployComponent(ctx context.Context, req *schedulerpb.UndeployComponentRequest) (*schedulerpb.UndeployComponentResponse, error) {
+	if req == nil || req.ComponentId == "" {
+		return &schedulerpb.UndeployComponentResponse{
+			Success: false,
+			Error:   "component_id is required",
+		}, nil
+	}
+
+	err := s.service.UndeployComponent(ctx, &scheduler.UndeployRequest{
+		ComponentID:   req.ComponentId,
+		TargetNodeID:  req.TargetNodeId,
+		TargetAddress: req.TargetNodeAddress,
+	})
+	if err != nil {
+		logrus.Errorf("Failed to undeploy component %s: %v", req.ComponentId, err)
+		return &schedulerpb.UndeployComponentResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+	return &schedulerpb.UndeployComponentResponse{Success: true}, nil
+}
+
 // GetDeploymentStatus 获取部署状态
 func (s *Server) GetDeploymentStatus(ctx context.Context, req *schedulerpb.GetDeploymentStatusRequest) (*schedulerpb.GetDeploymentStatusResponse, error) {
 	if req == nil {
@@ -129,6 +212,254 @@ func (s *Server) GetDeploymentStatus(ctx context.Context, req *schedulerpb.GetDe
 	return protoResp, nil
 }
 
+// ProposeDeployment 判断本节点能否部署 component，不实际部署
+func (s *Server) ProposeDeployment(ctx context.Context, req *schedulerpb.ProposeDeploymentRequest) (*schedulerpb.ProposeDeploymentResponse, error) {
+	if req == nil || req.ResourceRequest == nil {
+		return &schedulerpb.ProposeDeploymentResponse{
+			Accepted: false,
+			Reason:   "resource request is required",
+		}, nil
+	}
+
+	resp, err := s.service.ProposeDeployment(ctx, &scheduler.ProposeRequest{
+		RuntimeEnv: types.RuntimeEnv(req.RuntimeEnv),
+		ResourceRequest: &types.Info{
+			CPU:          req.ResourceRequest.Cpu,
+			Memory:       req.ResourceRequest.Memory,
+			GPU:          req.ResourceRequest.Gpu,
+			Tags:         req.ResourceRequest.Tags,
+			NodeSelector: req.ResourceRequest.NodeSelector,
+			Stream:       req.ResourceRequest.Stream,
+		},
+	})
+	if err != nil {
+		logrus.Errorf("Failed to evaluate deployment proposal: %v", err)
+		return &schedulerpb.ProposeDeploymentResponse{
+			Accepted: false,
+			Reason:   err.Error(),
+		}, nil
+	}
+
+	protoResp := &schedulerpb.ProposeDeploymentResponse{
+		Accepted: resp.Accepted,
+		Reason:   resp.Reason,
+		NodeId:   resp.NodeID,
+		NodeName: resp.NodeName,
+	}
+	if resp.Available != nil {
+		protoResp.Available = &resourcepb.Info{
+			Cpu:    resp.Available.CPU,
+			Memory: resp.Available.Memory,
+			Gpu:    resp.Available.GPU,
+		}
+	}
+	return protoResp, nil
+}
+
+// GetNodeUtilization 获取节点聚合后的资源利用率及各 provider 明细
+func (s *Server) GetNodeUtilization(ctx context.Context, req *schedulerpb.GetNodeUtilizationRequest) (*schedulerpb.GetNodeUtilizationResponse, error) {
+	utilization, err := s.service.GetNodeUtilization(ctx, req.GetNodeId())
+	if err != nil {
+		logrus.Errorf("Failed to get node utilization: %v", err)
+		return &schedulerpb.GetNodeUtilizationResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	protoResp := &schedulerpb.GetNodeUtilizationResponse{
+		Success:   true,
+		NodeId:    utilization.NodeID,
+		NodeName:  utilization.NodeName,
+		Capacity:  convertCapacityToProto(utilization.Capacity),
+		Providers: make([]*schedulerpb.ProviderUtilization, 0, len(utilization.Providers)),
+	}
+	for _, p := range utilization.Providers {
+		protoResp.Providers = append(protoResp.Providers, &schedulerpb.ProviderUtilization{
+			ProviderId:   p.ProviderID,
+			ProviderName: p.ProviderName,
+			ProviderType: p.ProviderType,
+			Status:       p.Status,
+			Components:   int32(p.Components),
+			Capacity:     convertCapacityToProto(p.Capacity),
+			Error:        p.Error,
+		})
+	}
+	return protoResp, nil
+}
+
+// ListProviders 分页列举本节点的 provider
+func (s *Server) ListProviders(ctx context.Context, req *schedulerpb.ListProvidersRequest) (*schedulerpb.ListProvidersResponse, error) {
+	list, err := s.service.ListProviders(ctx, providerListQueryFromProto(req))
+	if err != nil {
+		logrus.Warnf("Failed to list providers: %v", err)
+		return &schedulerpb.ListProvidersResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+	return convertProviderListToProto(list), nil
+}
+
+// ListRemoteProviders 经本节点分页列举同域其他节点的 provider
+func (s *Server) ListRemoteProviders(ctx context.Context, req *schedulerpb.ListRemoteProvidersRequest) (*schedulerpb.ListProvidersResponse, error) {
+	list, err := s.service.ListRemoteProviders(ctx, req.GetNodeId(), req.GetNodeAddress(), providerListQueryFromProto(req.GetQuery()))
+	if err != nil {
+		logrus.Warnf("Failed to list providers of node %s: %v", req.GetNodeId(), err)
+		return &schedulerpb.ListProvidersResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+	return convertProviderListToProto(list), nil
+}
+
+// ListComponents 按条件列举本节点上运行的 component
+func (s *Server) ListComponents(ctx context.Context, req *schedulerpb.ListComponentsRequest) (*schedulerpb.ListComponentsResponse, error) {
+	list, err := s.service.ListComponents(ctx, scheduler.ComponentQuery{
+		AppID:        req.GetAppId(),
+		Labels:       req.GetLabels(),
+		ProviderType: req.GetProviderType(),
+		State:        req.GetState(),
+		Origin:       req.GetOriginNodeId(),
+	})
+	if err != nil {
+		logrus.Warnf("Failed to list components: %v", err)
+		return &schedulerpb.ListComponentsResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	protoResp := &schedulerpb.ListComponentsResponse{
+		Success:    true,
+		NodeId:     list.NodeID,
+		NodeName:   list.NodeName,
+		Components: make([]*schedulerpb.ComponentSummary, 0, len(list.Components)),
+	}
+	for _, c := range list.Components {
+		protoResp.Components = append(protoResp.Components, c.ToProto())
+	}
+	return protoResp, nil
+}
+
+// ReconcileComponents 确认其他节点上报的、由本节点委托部署的 component 应保留还是删除
+func (s *Server) ReconcileComponents(ctx context.Context, req *schedulerpb.ReconcileComponentsRequest) (*schedulerpb.ReconcileComponentsResponse, error) {
+	if err := identity.CheckPeerNodeID(ctx, req.GetNodeId()); err != nil {
+		return nil, err
+	}
+	components := make([]scheduler.DelegatedComponent, 0, len(req.GetComponents()))
+	for _, c := range req.GetComponents() {
+		components = append(components, scheduler.DelegatedComponent{
+			ID:         c.GetComponentId(),
+			ProviderID: c.GetProviderId(),
+		})
+	}
+	actions, err := s.service.ReconcileComponents(ctx, req.GetNodeId(), components)
+	if err != nil {
+		logrus.Warnf("Failed to reconcile components reported by node %s: %v", req.GetNodeId(), err)
+		return &schedulerpb.ReconcileComponentsResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	protoResp := &schedulerpb.ReconcileComponentsResponse{
+		Success: true,
+		Actions: make(map[string]schedulerpb.ReconcileAction, len(actions)),
+	}
+	for id, action := range actions {
+		protoResp.Actions[id] = action.ToProto()
+	}
+	return protoResp, nil
+}
+
+// TransferComponents 将本节点上由已离开的节点委托部署的 component 转移给接管节点
+func (s *Server) TransferComponents(ctx context.Context, req *schedulerpb.TransferComponentsRequest) (*schedulerpb.TransferComponentsResponse, error) {
+	result, err := s.service.TransferComponents(ctx, scheduler.TransferRequest{
+		FromNodeID:            req.GetFromNodeId(),
+		ToNodeID:              req.GetToNodeId(),
+		UpstreamZMQAddress:    req.GetUpstreamZmqAddress(),
+		UpstreamStoreAddress:  req.GetUpstreamStoreAddress(),
+		UpstreamLoggerAddress: req.GetUpstreamLoggerAddress(),
+		UpstreamTokens:        req.GetUpstreamTokens(),
+		ComponentIDs:          req.GetComponentIds(),
+	})
+	if err != nil {
+		logrus.Warnf("Failed to transfer components of node %s to node %s: %v", req.GetFromNodeId(), req.GetToNodeId(), err)
+		return &schedulerpb.TransferComponentsResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	protoResp := &schedulerpb.TransferComponentsResponse{
+		Success:    true,
+		NodeId:     result.NodeID,
+		NodeName:   result.NodeName,
+		Components: make([]*schedulerpb.ComponentSummary, 0, len(result.Components)),
+		Failed:     result.Failed,
+	}
+	for _, c := range result.Components {
+		protoResp.Components = append(protoResp.Components, c.ToProto())
+	}
+	return protoResp, nil
+}
+
+// providerListQueryFromProto 转换分页查询条件，req 为 nil 时为默认查询
+func providerListQueryFromProto(req *schedulerpb.ListProvidersRequest) scheduler.ProviderListQuery {
+	return scheduler.ProviderListQuery{
+		PageSize:     int(req.GetPageSize()),
+		PageToken:    req.GetPageToken(),
+		Fields:       req.GetFields(),
+		SinceVersion: req.GetSinceVersion(),
+	}
+}
+
+// convertProviderListToProto 转换一页 provider 到 proto
+func convertProviderListToProto(list *scheduler.ProviderList) *schedulerpb.ListProvidersResponse {
+	protoResp := &schedulerpb.ListProvidersResponse{
+		Success:            true,
+		NodeId:             list.NodeID,
+		NodeName:           list.NodeName,
+		Providers:          make([]*schedulerpb.ProviderUtilization, 0, len(list.Providers)),
+		NextPageToken:      list.NextPageToken,
+		Version:            list.Version,
+		RemovedProviderIds: list.Removed,
+		Full:               list.Full,
+	}
+	for _, p := range list.Providers {
+		protoResp.Providers = append(protoResp.Providers, &schedulerpb.ProviderUtilization{
+			ProviderId:   p.ProviderID,
+			ProviderName: p.ProviderName,
+			ProviderType: p.ProviderType,
+			Status:       p.Status,
+			Components:   int32(p.Components),
+			Capacity:     convertCapacityToProto(p.Capacity),
+			Error:        p.Error,
+		})
+	}
+	return protoResp
+}
+
+// convertCapacityToProto 转换资源容量到 proto，capacity 为 nil 时返回 nil
+func convertCapacityToProto(capacity *types.Capacity) *resourcepb.Capacity {
+	if capacity == nil {
+		return nil
+	}
+	convert := func(info *types.Info) *resourcepb.Info {
+		if info == nil {
+			return &resourcepb.Info{}
+		}
+		return &resourcepb.Info{Cpu: info.CPU, Memory: info.Memory, Gpu: info.GPU}
+	}
+	return &resourcepb.Capacity{
+		Total:     convert(capacity.Total),
+		Used:      convert(capacity.Used),
+		Available: convert(capacity.Available),
+	}
+}
+
 // convertComponentStatusToProto 转换 Component 状态到 proto
 func convertComponentStatusToProto(status scheduler.ComponentStatus) schedulerpb.ComponentStatus {
 	switch status {
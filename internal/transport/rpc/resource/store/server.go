@@ -2,10 +2,15 @@ package store
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	domainstore "github.com/9triver/iarnet/internal/domain/resource/store"
+	commonpb "github.com/9triver/iarnet/internal/proto/common"
 	storepb "github.com/9triver/iarnet/internal/proto/resource/store"
+	"github.com/9triver/iarnet/internal/util/identity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type Server struct {
@@ -17,10 +22,37 @@ func NewServer(svc domainstore.Service) *Server {
 	return &Server{svc: svc}
 }
 
+// toStatus 将损坏的对象、越权访问与超过大小限制转换为对应的 gRPC 状态码，便于调用方区分
+func toStatus(err error) error {
+	var integrityErr *commonpb.IntegrityError
+	switch {
+	case errors.As(err, &integrityErr):
+		return status.Error(codes.DataLoss, err.Error())
+	case errors.Is(err, domainstore.ErrAccessDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, domainstore.ErrObjectTooLarge), errors.Is(err, domainstore.ErrStoreFull):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return err
+	}
+}
+
+// withPrincipal 通过 store 端口的访问均附加调用方，未出示 component 令牌的调用方为匿名调用方
+// 出示节点令牌的调用（节点转发到共用 store 的内部访问）视为节点内部访问，不附加调用方
+func withPrincipal(ctx context.Context) context.Context {
+	if identity.VerifiedNode(ctx) != "" {
+		return ctx
+	}
+	return domainstore.WithPrincipal(ctx, domainstore.Principal{
+		ComponentID: identity.VerifiedComponent(ctx),
+		AppID:       identity.VerifiedApp(ctx),
+	})
+}
+
 func (s *Server) SaveObject(ctx context.Context, req *storepb.SaveObjectRequest) (*storepb.SaveObjectResponse, error) {
-	ref, err := s.svc.SaveObject(ctx, req.Object)
+	ref, err := s.svc.SaveObject(withPrincipal(ctx), req.Object)
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err)
 	}
 	return &storepb.SaveObjectResponse{
 		ObjectRef: ref,
@@ -30,17 +62,17 @@ func (s *Server) SaveObject(ctx context.Context, req *storepb.SaveObjectRequest)
 }
 
 func (s *Server) GetObject(ctx context.Context, req *storepb.GetObjectRequest) (*storepb.GetObjectResponse, error) {
-	obj, err := s.svc.GetObject(ctx, req.ObjectRef)
+	obj, err := s.svc.GetObject(withPrincipal(ctx), req.ObjectRef)
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err)
 	}
 	return &storepb.GetObjectResponse{Object: obj}, nil
 }
 
 func (s *Server) GetStreamChunk(ctx context.Context, req *storepb.GetStreamChunkRequest) (*storepb.GetStreamChunkResponse, error) {
-	chunk, err := s.svc.GetStreamChunk(ctx, req.ObjectID, req.Offset)
+	chunk, err := s.svc.GetStreamChunk(withPrincipal(ctx), req.ObjectID, req.Offset)
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err)
 	}
 	return &storepb.GetStreamChunkResponse{Chunk: chunk}, nil
 }
@@ -49,8 +81,8 @@ func (s *Server) SaveStreamChunk(ctx context.Context, req *storepb.SaveStreamChu
 	if req == nil || req.Chunk == nil {
 		return nil, fmt.Errorf("chunk is required")
 	}
-	if err := s.svc.SaveStreamChunk(ctx, req.Chunk); err != nil {
-		return nil, err
+	if err := s.svc.SaveStreamChunk(withPrincipal(ctx), req.Chunk); err != nil {
+		return nil, toStatus(err)
 	}
 	return &storepb.SaveStreamChunkResponse{}, nil
 }
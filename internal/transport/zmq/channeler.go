@@ -3,20 +3,37 @@ package zmq
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
+	"time"
 
+	"github.com/9triver/iarnet/internal/domain/resource/component"
+	"github.com/9triver/iarnet/internal/util/compress"
+	"github.com/9triver/iarnet/internal/util/msgguard"
 	"github.com/sirupsen/logrus"
 	"gopkg.in/zeromq/goczmq.v4"
 )
 
+// maxIdentityBytes is the longest routing identity accepted, component IDs are far shorter
+const maxIdentityBytes = 255
+
 // ComponentChanneler wraps goczmq.Channeler for component communication
 // It provides a Router socket that components (Dealer) can connect to
 // Messages for unconnected components are queued and sent when they connect
+// Payloads above a size threshold are compressed for components that declared
+// the configured algorithm in their READY message
+// Malformed messages are counted per identity; identities that send too many
+// are disconnected and their messages dropped until the block expires
 type ComponentChanneler struct {
 	*goczmq.Channeler
 	mu              sync.RWMutex
 	pendingMessages map[string][][]byte // component ID -> pending messages
 	connected       map[string]bool     // component ID -> connected status
+	encodings       map[string][]string // component ID -> accepted frame compressions
+	algorithm       compress.Algorithm  // frame compression for outgoing payloads
+	minBytes        int                 // payloads smaller than this are sent uncompressed
+	maxFrameBytes   int                 // received frames larger than this (after decompression) are rejected
+	guard           *msgguard.Guard     // per-identity malformed message accounting
 	closed          bool                // whether the channeler is closed
 }
 
@@ -28,7 +45,48 @@ func NewChanneler(port int) *ComponentChanneler {
 		Channeler:       base,
 		pendingMessages: make(map[string][][]byte),
 		connected:       make(map[string]bool),
+		encodings:       make(map[string][]string),
+		algorithm:       compress.None,
+		guard:           msgguard.For(msgguard.ChannelZMQ),
+	}
+}
+
+// SetCompression configures frame compression for outgoing payloads of at least minBytes
+func (cc *ComponentChanneler) SetCompression(alg compress.Algorithm, minBytes int) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.algorithm = alg
+	cc.minBytes = minBytes
+}
+
+// SetMessageLimits configures the largest frame accepted from components, 0 disables the check
+func (cc *ComponentChanneler) SetMessageLimits(maxFrameBytes int) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.maxFrameBytes = maxFrameBytes
+}
+
+// SetAcceptEncodings records the frame compressions a component can decode
+// Components that never declare any only receive uncompressed frames
+func (cc *ComponentChanneler) SetAcceptEncodings(componentID string, encodings []string) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.encodings[componentID] = append([]string(nil), encodings...)
+}
+
+// encode compresses the payload when the component accepts the configured algorithm
+func (cc *ComponentChanneler) encode(componentID string, data []byte) []byte {
+	cc.mu.RLock()
+	alg, minBytes := cc.algorithm, cc.minBytes
+	accepted := slices.Contains(cc.encodings[componentID], string(alg))
+	cc.mu.RUnlock()
+
+	frame := data
+	if alg != compress.None && accepted && len(data) >= minBytes {
+		frame = encodeFrame(alg, data)
 	}
+	compress.Record(compress.ChannelZMQ, len(data), len(frame))
+	return frame
 }
 
 // Close destroys the ZMQ Channeler and releases all resources
@@ -71,7 +129,7 @@ func (cc *ComponentChanneler) Send(componentID string, data []byte) {
 		cc.mu.Unlock()
 
 		logrus.Infof("Component %s is connected, sending message immediately", componentID)
-		ch.SendChan <- [][]byte{[]byte(componentID), data}
+		ch.SendChan <- [][]byte{[]byte(componentID), cc.encode(componentID, data)}
 		logrus.Debugf("Sent message to component %s via ZMQ SendChan", componentID)
 	} else {
 		// Component not connected yet, queue the message
@@ -112,29 +170,108 @@ func (cc *ComponentChanneler) MarkConnected(componentID string) {
 
 	if len(pending) > 0 {
 		logrus.Infof("Component %s connected, flushing %d pending messages", componentID, len(pending))
+		cc.flush(componentID, pending)
+	}
+}
 
-		// Send all pending messages in a goroutine to avoid blocking the receiver
-		go func(compID string, msgs [][]byte) {
-			for i, data := range msgs {
-				cc.mu.RLock()
-				closed := cc.closed
-				ch := cc.Channeler
-				cc.mu.RUnlock()
+// flush sends pending messages in a goroutine to avoid blocking the receiver
+func (cc *ComponentChanneler) flush(componentID string, pending [][]byte) {
+	go func() {
+		for i, data := range pending {
+			cc.mu.RLock()
+			closed := cc.closed
+			ch := cc.Channeler
+			cc.mu.RUnlock()
 
-				if closed || ch == nil {
-					logrus.Warnf("Channeler closed while sending pending messages to component %s", compID)
-					return
-				}
-				ch.SendChan <- [][]byte{[]byte(compID), data}
-				logrus.Debugf("Sent pending message %d/%d to component %s", i+1, len(msgs), compID)
+			if closed || ch == nil {
+				logrus.Warnf("Channeler closed while sending pending messages to component %s", componentID)
+				return
 			}
-			logrus.Infof("Finished sending all %d pending messages to component %s", len(msgs), compID)
-		}(componentID, pending)
+			ch.SendChan <- [][]byte{[]byte(componentID), cc.encode(componentID, data)}
+			logrus.Debugf("Sent pending message %d/%d to component %s", i+1, len(pending), componentID)
+		}
+		logrus.Infof("Finished sending all %d pending messages to component %s", len(pending), componentID)
+	}()
+}
+
+// ExportSessions returns the accepted encodings and undelivered messages of every known component
+// Used when handing the node over to a new process
+func (cc *ComponentChanneler) ExportSessions() []component.SessionState {
+	cc.mu.RLock()
+	defer cc.mu.RUnlock()
+
+	ids := make(map[string]struct{})
+	for id := range cc.connected {
+		ids[id] = struct{}{}
+	}
+	for id := range cc.encodings {
+		ids[id] = struct{}{}
+	}
+	for id := range cc.pendingMessages {
+		ids[id] = struct{}{}
+	}
+
+	sessions := make([]component.SessionState, 0, len(ids))
+	for id := range ids {
+		sessions = append(sessions, component.SessionState{
+			ComponentID:     id,
+			AcceptEncodings: append([]string(nil), cc.encodings[id]...),
+			Pending:         append([][]byte(nil), cc.pendingMessages[id]...),
+		})
+	}
+	return sessions
+}
+
+// ResumeSessions restores sessions exported by the previous process
+// Dealers reconnect on their own but do not resend READY, so components are marked connected
+// once the grace period has passed; messages sent in the meantime are queued after the restored ones
+// Components that send a message earlier are marked connected immediately by the receiver
+func (cc *ComponentChanneler) ResumeSessions(sessions []component.SessionState, grace time.Duration) {
+	for _, session := range sessions {
+		id := session.ComponentID
+		cc.mu.Lock()
+		if len(session.AcceptEncodings) > 0 {
+			cc.encodings[id] = append([]string(nil), session.AcceptEncodings...)
+		}
+		var pending [][]byte
+		if cc.connected[id] {
+			// The component reconnected before the restore, send the restored messages right away
+			pending = session.Pending
+		} else if len(session.Pending) > 0 {
+			cc.pendingMessages[id] = append(append([][]byte(nil), session.Pending...), cc.pendingMessages[id]...)
+		}
+		cc.mu.Unlock()
+
+		if len(pending) > 0 {
+			cc.flush(id, pending)
+			continue
+		}
+		time.AfterFunc(grace, func() { cc.MarkConnected(id) })
 	}
 }
 
+// reject counts a malformed message from the identity and disconnects it once it is blocked
+func (cc *ComponentChanneler) reject(componentID string, reason error) {
+	if !cc.guard.Reject(componentID, reason) {
+		return
+	}
+	cc.disconnect(componentID)
+}
+
+// disconnect forgets the session of a blocked identity so that nothing more is routed to it
+// A legitimate component with the same ID has to send READY again once the block expires
+func (cc *ComponentChanneler) disconnect(componentID string) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	delete(cc.connected, componentID)
+	delete(cc.pendingMessages, componentID)
+	delete(cc.encodings, componentID)
+	logrus.Warnf("Disconnected component %s after too many malformed messages", componentID)
+}
+
 // StartReceiver starts a goroutine that processes received messages from components
-func (cc *ComponentChanneler) StartReceiver(ctx context.Context, onMessage func(componentID string, data []byte)) {
+// Messages rejected by onMessage count as malformed for the sending identity
+func (cc *ComponentChanneler) StartReceiver(ctx context.Context, onMessage func(componentID string, data []byte) error) {
 	go func() {
 		// 检查 Channeler 是否已初始化
 		cc.mu.RLock()
@@ -157,12 +294,27 @@ func (cc *ComponentChanneler) StartReceiver(ctx context.Context, onMessage func(
 					logrus.Info("ZMQ RecvChan closed")
 					return
 				}
-				if len(msg) < 2 {
-					logrus.Warnf("Received invalid message format, expected at least 2 frames, got %d", len(msg))
+				if len(msg) == 0 || len(msg[0]) == 0 || len(msg[0]) > maxIdentityBytes {
+					logrus.Warnf("Dropping message without a valid identity frame")
 					continue
 				}
 				componentID := string(msg[0])
-				data := msg[1]
+				if cc.guard.Blocked(componentID) {
+					continue
+				}
+				if len(msg) != 2 {
+					cc.reject(componentID, fmt.Errorf("expected 2 frames, got %d", len(msg)))
+					continue
+				}
+				cc.mu.RLock()
+				limit := cc.maxFrameBytes
+				cc.mu.RUnlock()
+				data, err := decodeFrame(msg[1], limit)
+				if err != nil {
+					cc.reject(componentID, fmt.Errorf("undecodable frame of %d bytes: %w", len(msg[1]), err))
+					continue
+				}
+				compress.Record(compress.ChannelZMQ, len(data), len(msg[1]))
 				logrus.Infof("Received message from component %s (size: %d bytes)", componentID, len(data))
 
 				// Mark component as connected and flush pending messages
@@ -172,7 +324,9 @@ func (cc *ComponentChanneler) StartReceiver(ctx context.Context, onMessage func(
 
 				// Call the callback
 				if onMessage != nil {
-					onMessage(componentID, data)
+					if err := onMessage(componentID, data); err != nil {
+						cc.reject(componentID, err)
+					}
 				}
 			}
 		}
@@ -0,0 +1,60 @@
+package zmq
+
+import (
+	"fmt"
+
+	"github.com/9triver/iarnet/internal/util/compress"
+)
+
+// 压缩帧格式：[frameMarker, codec, payload...]
+// 合法的 protobuf 消息首字节为非零的字段 tag，因此以 0x00 开头的帧不会与未压缩的消息混淆
+const frameMarker byte = 0x00
+
+// 帧内的压缩算法编号
+const (
+	codecNone byte = 0
+	codecGzip byte = 1
+	codecZstd byte = 2
+)
+
+var codecs = map[compress.Algorithm]byte{
+	compress.None: codecNone,
+	compress.Gzip: codecGzip,
+	compress.Zstd: codecZstd,
+}
+
+var algorithms = map[byte]compress.Algorithm{
+	codecNone: compress.None,
+	codecGzip: compress.Gzip,
+	codecZstd: compress.Zstd,
+}
+
+// encodeFrame 压缩数据并加上帧头，压缩后不小于原数据时原样返回
+func encodeFrame(alg compress.Algorithm, data []byte) []byte {
+	compressed, err := compress.Compress(alg, data)
+	if err != nil || len(compressed)+2 >= len(data) {
+		return data
+	}
+	frame := make([]byte, 0, len(compressed)+2)
+	frame = append(frame, frameMarker, codecs[alg])
+	return append(frame, compressed...)
+}
+
+// decodeFrame 解析帧头并解压，未压缩的帧原样返回
+// 帧或解压后的数据超过 limit 字节时返回 compress.ErrTooLarge，limit <= 0 表示不限制
+func decodeFrame(frame []byte, limit int) ([]byte, error) {
+	if limit > 0 && len(frame) > limit {
+		return nil, compress.ErrTooLarge
+	}
+	if len(frame) == 0 || frame[0] != frameMarker {
+		return frame, nil
+	}
+	if len(frame) < 2 {
+		return nil, fmt.Errorf("truncated compressed frame")
+	}
+	alg, ok := algorithms[frame[1]]
+	if !ok {
+		return nil, fmt.Errorf("unknown frame codec %d", frame[1])
+	}
+	return compress.DecompressLimit(alg, frame[2:], limit)
+}
@@ -0,0 +1,146 @@
+package util
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// 微基准测试参数：每项测试耗时控制在数百毫秒内，整体在数秒内完成
+const (
+	benchCPUDuration    = 500 * time.Millisecond
+	benchMemoryDuration = 300 * time.Millisecond
+	benchMemoryBuffer   = 64 * 1024 * 1024
+	benchDiskFileSize   = 64 * 1024 * 1024
+	benchDiskBlock      = 1024 * 1024
+)
+
+// MicroBenchmark 微基准测试结果（吞吐单位均为 MiB/s）
+type MicroBenchmark struct {
+	CPUScore            float64 // 单核 SHA-256 吞吐
+	MemoryBandwidthMBps float64 // 内存拷贝带宽
+	DiskWriteMBps       float64 // 顺序写（含 fsync）吞吐
+	DiskReadMBps        float64 // 顺序读吞吐，可能命中页缓存，仅作参考
+	Duration            time.Duration
+}
+
+// RunMicroBenchmark 在当前进程所在主机上依次测量 CPU、内存带宽和磁盘 IO
+// dir 为磁盘测试使用的目录，为空时使用系统临时目录；磁盘测试失败时只跳过该项
+func RunMicroBenchmark(ctx context.Context, dir string) (*MicroBenchmark, error) {
+	start := time.Now()
+	result := &MicroBenchmark{}
+
+	result.CPUScore = benchCPU(ctx)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	result.MemoryBandwidthMBps = benchMemory(ctx)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	write, read, err := benchDisk(ctx, dir)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+	} else {
+		result.DiskWriteMBps = write
+		result.DiskReadMBps = read
+	}
+
+	result.Duration = time.Since(start)
+	return result, nil
+}
+
+// mibPerSecond 将字节数和耗时换算为 MiB/s
+func mibPerSecond(bytes int64, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(bytes) / (1024 * 1024) / elapsed.Seconds()
+}
+
+// benchCPU 单 goroutine 持续计算 SHA-256，返回吞吐
+func benchCPU(ctx context.Context) float64 {
+	block := make([]byte, 64*1024)
+	for i := range block {
+		block[i] = byte(i)
+	}
+	var hashed int64
+	start := time.Now()
+	for time.Since(start) < benchCPUDuration && ctx.Err() == nil {
+		sum := sha256.Sum256(block)
+		block[0] = sum[0]
+		hashed += int64(len(block))
+	}
+	return mibPerSecond(hashed, time.Since(start))
+}
+
+// benchMemory 在两个大缓冲区之间反复拷贝，返回拷贝带宽（读写各计一次）
+func benchMemory(ctx context.Context) float64 {
+	src := make([]byte, benchMemoryBuffer)
+	dst := make([]byte, benchMemoryBuffer)
+	for i := 0; i < len(src); i += 4096 {
+		src[i] = byte(i)
+	}
+	var copied int64
+	start := time.Now()
+	for time.Since(start) < benchMemoryDuration && ctx.Err() == nil {
+		copy(dst, src)
+		copied += 2 * int64(len(src))
+	}
+	return mibPerSecond(copied, time.Since(start))
+}
+
+// benchDisk 顺序写入临时文件并 fsync，再顺序读回
+func benchDisk(ctx context.Context, dir string) (write, read float64, err error) {
+	f, err := os.CreateTemp(dir, "iarnet-bench-*")
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to create benchmark file: %w", err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	block := make([]byte, benchDiskBlock)
+	for i := range block {
+		block[i] = byte(i * 31)
+	}
+
+	start := time.Now()
+	for written := 0; written < benchDiskFileSize; written += len(block) {
+		if err := ctx.Err(); err != nil {
+			return 0, 0, err
+		}
+		if _, err := f.Write(block); err != nil {
+			return 0, 0, fmt.Errorf("failed to write benchmark file: %w", err)
+		}
+	}
+	if err := f.Sync(); err != nil {
+		return 0, 0, fmt.Errorf("failed to sync benchmark file: %w", err)
+	}
+	write = mibPerSecond(benchDiskFileSize, time.Since(start))
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return 0, 0, fmt.Errorf("failed to rewind benchmark file: %w", err)
+	}
+	start = time.Now()
+	var total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return 0, 0, err
+		}
+		n, err := f.Read(block)
+		total += int64(n)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to read benchmark file: %w", err)
+		}
+	}
+	read = mibPerSecond(total, time.Since(start))
+	return write, read, nil
+}
@@ -0,0 +1,130 @@
+// Package compress 提供大消息路径（gRPC 通道与 ZMQ 帧）的负载压缩与压缩统计
+package compress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Algorithm 压缩算法
+type Algorithm string
+
+const (
+	None Algorithm = "none"
+	Gzip Algorithm = "gzip"
+	Zstd Algorithm = "zstd"
+)
+
+// ParseAlgorithm 解析压缩算法，空字符串视为 none
+func ParseAlgorithm(s string) (Algorithm, error) {
+	switch Algorithm(s) {
+	case "", None:
+		return None, nil
+	case Gzip, Zstd:
+		return Algorithm(s), nil
+	}
+	return "", fmt.Errorf("unknown compression algorithm %q, must be none, gzip or zstd", s)
+}
+
+var (
+	zstdEncoders = sync.Pool{New: func() any {
+		enc, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+		return enc
+	}}
+	zstdDecoders = sync.Pool{New: func() any {
+		dec, _ := zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+		return dec
+	}}
+)
+
+// Compress 使用指定算法压缩数据，none 时原样返回
+func Compress(alg Algorithm, data []byte) ([]byte, error) {
+	switch alg {
+	case None, "":
+		return data, nil
+	case Gzip:
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case Zstd:
+		enc := zstdEncoders.Get().(*zstd.Encoder)
+		defer zstdEncoders.Put(enc)
+		return enc.EncodeAll(data, make([]byte, 0, len(data)/2)), nil
+	}
+	return nil, fmt.Errorf("unknown compression algorithm %q", alg)
+}
+
+// Decompress 使用指定算法解压数据，none 时原样返回
+func Decompress(alg Algorithm, data []byte) ([]byte, error) {
+	switch alg {
+	case None, "":
+		return data, nil
+	case Gzip:
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return io.ReadAll(r)
+	case Zstd:
+		dec := zstdDecoders.Get().(*zstd.Decoder)
+		defer zstdDecoders.Put(dec)
+		return dec.DecodeAll(data, nil)
+	}
+	return nil, fmt.Errorf("unknown compression algorithm %q", alg)
+}
+
+// ErrTooLarge 解压后的数据超过上限
+var ErrTooLarge = errors.New("decompressed data exceeds limit")
+
+// DecompressLimit 与 Decompress 相同，但解压后超过 limit 字节时返回 ErrTooLarge，用于防御解压炸弹；limit <= 0 表示不限制
+func DecompressLimit(alg Algorithm, data []byte, limit int) ([]byte, error) {
+	if limit <= 0 {
+		return Decompress(alg, data)
+	}
+	var r io.Reader
+	switch alg {
+	case None, "":
+		if len(data) > limit {
+			return nil, ErrTooLarge
+		}
+		return data, nil
+	case Gzip:
+		gr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer gr.Close()
+		r = gr
+	case Zstd:
+		dec := zstdDecoders.Get().(*zstd.Decoder)
+		defer zstdDecoders.Put(dec)
+		if err := dec.Reset(bytes.NewReader(data)); err != nil {
+			return nil, err
+		}
+		r = dec
+	default:
+		return nil, fmt.Errorf("unknown compression algorithm %q", alg)
+	}
+
+	out, err := io.ReadAll(io.LimitReader(r, int64(limit)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(out) > limit {
+		return nil, ErrTooLarge
+	}
+	return out, nil
+}
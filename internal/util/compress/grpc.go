@@ -0,0 +1,169 @@
+package compress
+
+import (
+	"context"
+	"io"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	commonpb "github.com/9triver/iarnet/internal/proto/common"
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	_ "google.golang.org/grpc/encoding/gzip" // 注册 gRPC gzip 压缩器
+	"google.golang.org/grpc/stats"
+)
+
+func init() {
+	encoding.RegisterCompressor(&zstdCompressor{})
+}
+
+// grpcAlgorithm gRPC 通道发送方向使用的压缩算法，默认不压缩
+var grpcAlgorithm atomic.Value
+
+// SetGRPCAlgorithm 设置 gRPC 通道发送方向使用的压缩算法
+// 接收方向始终可以解压 gzip 与 zstd，与该设置无关
+func SetGRPCAlgorithm(alg Algorithm) {
+	grpcAlgorithm.Store(alg)
+}
+
+// GRPCAlgorithm 返回 gRPC 通道发送方向使用的压缩算法
+func GRPCAlgorithm() Algorithm {
+	if alg, ok := grpcAlgorithm.Load().(Algorithm); ok {
+		return alg
+	}
+	return None
+}
+
+// Capability 返回声明支持该算法的协议能力名，none 时为空
+func (a Algorithm) Capability() string {
+	switch a {
+	case Gzip:
+		return commonpb.CapCompressionGzip
+	case Zstd:
+		return commonpb.CapCompressionZstd
+	}
+	return ""
+}
+
+// DialOptions 返回连接 peer 节点时使用的 gRPC 客户端选项
+// 仅在对端协商了对应压缩能力时才压缩请求，旧版对端收到的仍是未压缩数据
+func DialOptions(channel string, peer *commonpb.Negotiated) []grpc.DialOption {
+	opts := []grpc.DialOption{grpc.WithStatsHandler(&statsHandler{channel: channel})}
+	if alg := GRPCAlgorithm(); alg != None && peer.Supports(alg.Capability()) {
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.UseCompressor(string(alg))))
+	}
+	return opts
+}
+
+// ServerOptions 返回 gRPC 服务端选项
+// 客户端在 grpc-accept-encoding 中声明支持配置的算法时压缩响应；客户端自身压缩请求时，gRPC 默认以相同算法压缩响应
+func ServerOptions(channel string) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.StatsHandler(&statsHandler{channel: channel}),
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			setSendCompressor(ctx)
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			setSendCompressor(ss.Context())
+			return handler(srv, ss)
+		}),
+	}
+}
+
+// setSendCompressor 客户端支持时使用配置的算法压缩响应
+func setSendCompressor(ctx context.Context) {
+	alg := GRPCAlgorithm()
+	if alg == None {
+		return
+	}
+	supported, err := grpc.ClientSupportedCompressors(ctx)
+	if err != nil || !slices.Contains(supported, string(alg)) {
+		return
+	}
+	_ = grpc.SetSendCompressor(ctx, string(alg))
+}
+
+// statsHandler 按负载的原始大小与压缩后大小累计通道统计
+type statsHandler struct {
+	channel string
+}
+
+func (h *statsHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (h *statsHandler) HandleRPC(_ context.Context, s stats.RPCStats) {
+	switch p := s.(type) {
+	case *stats.InPayload:
+		Record(h.channel, p.Length, wireLength(p.Length, p.CompressedLength))
+	case *stats.OutPayload:
+		Record(h.channel, p.Length, wireLength(p.Length, p.CompressedLength))
+	}
+}
+
+func (h *statsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (h *statsHandler) HandleConn(context.Context, stats.ConnStats) {}
+
+func wireLength(length, compressed int) int {
+	if compressed <= 0 {
+		return length
+	}
+	return compressed
+}
+
+// zstdCompressor gRPC zstd 压缩器
+type zstdCompressor struct{}
+
+func (c *zstdCompressor) Name() string {
+	return string(Zstd)
+}
+
+func (c *zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	enc := zstdEncoders.Get().(*zstd.Encoder)
+	enc.Reset(w)
+	return &pooledEncoder{Encoder: enc}, nil
+}
+
+func (c *zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	dec := zstdDecoders.Get().(*zstd.Decoder)
+	if err := dec.Reset(r); err != nil {
+		zstdDecoders.Put(dec)
+		return nil, err
+	}
+	return &pooledDecoder{Decoder: dec}, nil
+}
+
+// pooledEncoder 关闭时将编码器归还到池
+type pooledEncoder struct {
+	*zstd.Encoder
+	once sync.Once
+}
+
+func (e *pooledEncoder) Close() error {
+	err := e.Encoder.Close()
+	e.once.Do(func() { zstdEncoders.Put(e.Encoder) })
+	return err
+}
+
+// pooledDecoder 读取结束时将解码器归还到池，归还后的读取均返回 io.EOF
+type pooledDecoder struct {
+	*zstd.Decoder
+}
+
+func (d *pooledDecoder) Read(p []byte) (int, error) {
+	if d.Decoder == nil {
+		return 0, io.EOF
+	}
+	n, err := d.Decoder.Read(p)
+	if err != nil {
+		zstdDecoders.Put(d.Decoder)
+		d.Decoder = nil
+	}
+	return n, err
+}
@@ -0,0 +1,85 @@
+package compress
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// 统计通道名称
+const (
+	ChannelIgnis     = "ignis"
+	ChannelStore     = "store"
+	ChannelDiscovery = "discovery"
+	ChannelScheduler = "scheduler"
+	ChannelZMQ       = "zmq"
+)
+
+// counter 单个通道的压缩统计
+type counter struct {
+	messages           atomic.Int64
+	compressedMessages atomic.Int64
+	rawBytes           atomic.Int64
+	wireBytes          atomic.Int64
+}
+
+// ChannelStats 单个通道的压缩统计快照
+type ChannelStats struct {
+	Channel            string
+	Messages           int64 // 经过该通道的消息数
+	CompressedMessages int64 // 实际被压缩的消息数
+	RawBytes           int64 // 压缩前的负载字节数
+	WireBytes          int64 // 实际传输的负载字节数
+}
+
+// BytesSaved 压缩节省的字节数
+func (s ChannelStats) BytesSaved() int64 {
+	return s.RawBytes - s.WireBytes
+}
+
+// Ratio 传输字节数与原始字节数之比，无流量时为 1
+func (s ChannelStats) Ratio() float64 {
+	if s.RawBytes == 0 {
+		return 1
+	}
+	return float64(s.WireBytes) / float64(s.RawBytes)
+}
+
+var counters sync.Map // channel -> *counter
+
+func channelCounter(channel string) *counter {
+	if c, ok := counters.Load(channel); ok {
+		return c.(*counter)
+	}
+	c, _ := counters.LoadOrStore(channel, &counter{})
+	return c.(*counter)
+}
+
+// Record 记录一条消息的原始大小与传输大小
+func Record(channel string, raw, wire int) {
+	c := channelCounter(channel)
+	c.messages.Add(1)
+	if wire < raw {
+		c.compressedMessages.Add(1)
+	}
+	c.rawBytes.Add(int64(raw))
+	c.wireBytes.Add(int64(wire))
+}
+
+// Snapshot 返回所有通道的压缩统计，按通道名排序
+func Snapshot() []ChannelStats {
+	var stats []ChannelStats
+	counters.Range(func(key, value any) bool {
+		c := value.(*counter)
+		stats = append(stats, ChannelStats{
+			Channel:            key.(string),
+			Messages:           c.messages.Load(),
+			CompressedMessages: c.compressedMessages.Load(),
+			RawBytes:           c.rawBytes.Load(),
+			WireBytes:          c.wireBytes.Load(),
+		})
+		return true
+	})
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Channel < stats[j].Channel })
+	return stats
+}
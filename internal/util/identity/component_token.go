@@ -0,0 +1,217 @@
+package identity
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UpstreamTokenEnv 注入 component 的上游令牌环境变量
+const UpstreamTokenEnv = "IARNET_UPSTREAM_TOKEN"
+
+// component 与 provider 回连 store/logger 时通过 gRPC metadata 出示令牌
+// 节点转发到共用 store 的内部调用出示节点令牌
+const (
+	mdComponentID    = "x-iarnet-component-id"
+	mdComponentToken = "x-iarnet-component-token"
+	mdTokenNodeID    = "x-iarnet-token-node-id"
+	mdNodeToken      = "x-iarnet-node-token"
+)
+
+// tokenKeyFileName 令牌密钥文件名；密钥在重启后保持不变，已运行的 component 持有的令牌继续有效
+const tokenKeyFileName = "upstream_token_key"
+
+// ErrInvalidComponentToken 令牌缺失或与 component ID 不匹配
+var ErrInvalidComponentToken = errors.New("invalid component token")
+
+// ComponentTokens 签发与校验与 component ID 绑定的上游令牌
+// 令牌为节点密钥对 component ID（及所属应用）的 HMAC，只有持有密钥的节点能够校验，无需保存已签发的令牌；
+// 同域节点与独立 store 使用同一密钥文件时，任一节点签发的令牌都可在共用的 store 校验
+type ComponentTokens struct {
+	key []byte
+}
+
+// LoadTokenKey 从指定文件加载令牌密钥，用于同域节点与独立 store 共用一个密钥
+func LoadTokenKey(keyFile string) (*ComponentTokens, error) {
+	key, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upstream token key %s: %w", keyFile, err)
+	}
+	if len(key) < 32 {
+		return nil, fmt.Errorf("upstream token key %s is too short", keyFile)
+	}
+	return &ComponentTokens{key: key}, nil
+}
+
+// LoadOrGenerateTokenKey 从数据目录加载令牌密钥，不存在时生成并保存（仅所有者可读）
+func LoadOrGenerateTokenKey(dataDir string) (*ComponentTokens, error) {
+	if dataDir == "" {
+		dataDir = "./data"
+	}
+	keyFile := filepath.Join(dataDir, tokenKeyFileName)
+	if _, err := os.Stat(keyFile); err == nil {
+		return LoadTokenKey(keyFile)
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read upstream token key %s: %w", keyFile, err)
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate upstream token key: %w", err)
+	}
+	if err := os.MkdirAll(dataDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create data directory %s: %w", dataDir, err)
+	}
+	if err := os.WriteFile(keyFile, key, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to save upstream token key %s: %w", keyFile, err)
+	}
+	logrus.Infof("Generated and saved new upstream token key to %s", keyFile)
+	return &ComponentTokens{key: key}, nil
+}
+
+func (t *ComponentTokens) sign(payload string) string {
+	mac := hmac.New(sha256.New, t.key)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Issue 为 component 签发令牌；appID 非空时令牌同时绑定所属应用，形如 <base64(appID)>.<mac>，
+// 不知道 component 所属应用的共用 store 据此按应用做访问控制
+func (t *ComponentTokens) Issue(componentID, appID string) string {
+	if appID == "" {
+		return t.sign("iarnet-component\x00" + componentID)
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(appID)) + "." +
+		t.sign("iarnet-component\x00"+componentID+"\x00"+appID)
+}
+
+// Verify 校验令牌是否由持有密钥的节点为该 component 签发，返回令牌绑定的应用（未绑定时为空）
+func (t *ComponentTokens) Verify(componentID, token string) (string, error) {
+	if componentID == "" || token == "" {
+		return "", fmt.Errorf("%w: component ID and token are required", ErrInvalidComponentToken)
+	}
+	var appID string
+	if encoded, _, ok := strings.Cut(token, "."); ok {
+		raw, err := base64.RawURLEncoding.DecodeString(encoded)
+		if err != nil || len(raw) == 0 {
+			return "", fmt.Errorf("%w: %s", ErrInvalidComponentToken, componentID)
+		}
+		appID = string(raw)
+	}
+	if !hmac.Equal([]byte(t.Issue(componentID, appID)), []byte(token)) {
+		return "", fmt.Errorf("%w: %s", ErrInvalidComponentToken, componentID)
+	}
+	return appID, nil
+}
+
+// issueNode 为节点内部调用签发令牌，与 component 令牌使用不同的签名前缀，component 不能以此冒充节点
+func (t *ComponentTokens) issueNode(nodeID string) string {
+	return t.sign("iarnet-node\x00" + nodeID)
+}
+
+// WithNodeToken 在节点转发到共用 store 的内部调用中附加节点 ID 与令牌，t 为 nil 时原样返回
+func (t *ComponentTokens) WithNodeToken(ctx context.Context, nodeID string) context.Context {
+	if t == nil {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, mdTokenNodeID, nodeID, mdNodeToken, t.issueNode(nodeID))
+}
+
+// WithComponentToken 在发往上游的调用中附加 component ID 与令牌，token 为空时原样返回
+func WithComponentToken(ctx context.Context, componentID, token string) context.Context {
+	if token == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, mdComponentID, componentID, mdComponentToken, token)
+}
+
+type verifiedComponentKey struct{}
+
+type verifiedAppKey struct{}
+
+type verifiedNodeKey struct{}
+
+// VerifiedComponent 返回调用方通过令牌校验的 component ID，未出示令牌时返回空
+func VerifiedComponent(ctx context.Context) string {
+	componentID, _ := ctx.Value(verifiedComponentKey{}).(string)
+	return componentID
+}
+
+// VerifiedApp 返回调用方令牌绑定的应用，令牌未绑定应用时返回空
+func VerifiedApp(ctx context.Context) string {
+	appID, _ := ctx.Value(verifiedAppKey{}).(string)
+	return appID
+}
+
+// VerifiedNode 返回出示了有效节点令牌的内部调用方节点 ID，不是节点内部调用时返回空
+func VerifiedNode(ctx context.Context) string {
+	nodeID, _ := ctx.Value(verifiedNodeKey{}).(string)
+	return nodeID
+}
+
+// ServerOptions 返回校验 component 令牌的 gRPC 服务端选项
+// 出示了令牌的调用必须通过校验，校验通过的 component ID 与绑定的应用可由 VerifiedComponent、VerifiedApp 获取，
+// 出示节点令牌的内部调用可由 VerifiedNode 识别；require 为 true 时未出示令牌的调用同样被拒绝
+func (t *ComponentTokens) ServerOptions(require bool) []grpc.ServerOption {
+	check := func(ctx context.Context, method string) (context.Context, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if nodeToken := firstValue(md, mdNodeToken); nodeToken != "" {
+			nodeID := firstValue(md, mdTokenNodeID)
+			if nodeID == "" || !hmac.Equal([]byte(t.issueNode(nodeID)), []byte(nodeToken)) {
+				logrus.Warnf("Rejected %s: invalid node token for %q", method, nodeID)
+				return nil, status.Error(codes.Unauthenticated, "invalid node token")
+			}
+			return context.WithValue(ctx, verifiedNodeKey{}, nodeID), nil
+		}
+		componentID, token := firstValue(md, mdComponentID), firstValue(md, mdComponentToken)
+		if token == "" && !require {
+			return ctx, nil
+		}
+		appID, err := t.Verify(componentID, token)
+		if err != nil {
+			logrus.Warnf("Rejected %s: %v", method, err)
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		ctx = context.WithValue(ctx, verifiedComponentKey{}, componentID)
+		return context.WithValue(ctx, verifiedAppKey{}, appID), nil
+	}
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			ctx, err := check(ctx, info.FullMethod)
+			if err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			ctx, err := check(ss.Context(), info.FullMethod)
+			if err != nil {
+				return err
+			}
+			return handler(srv, &verifiedStream{ServerStream: ss, ctx: ctx})
+		}),
+	}
+}
+
+// verifiedStream 携带校验结果的 ServerStream
+type verifiedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *verifiedStream) Context() context.Context {
+	return s.ctx
+}
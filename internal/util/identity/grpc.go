@@ -0,0 +1,138 @@
+package identity
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// 节点间调用的签名通过 gRPC metadata 携带，不改动各 RPC 的消息定义
+const (
+	mdNodeID    = "x-iarnet-node-id"
+	mdPublicKey = "x-iarnet-public-key"
+	mdTimestamp = "x-iarnet-timestamp"
+	mdSignature = "x-iarnet-signature"
+)
+
+// VerifyOptions 服务端校验节点签名的选项
+type VerifyOptions struct {
+	NodeID        string        // 本节点 ID，只接受签给本节点的请求，防止在其他节点上重放
+	RequireSigned bool          // 拒绝未签名的请求；为 false 时未签名的请求（如旧版节点）照常处理
+	MaxClockSkew  time.Duration // 签名时间与本地时间允许的最大偏差，<= 0 表示不校验
+}
+
+type peerNodeKey struct{}
+
+// PeerNodeID 返回服务端已校验签名的调用方节点 ID，未签名的请求返回 false
+func PeerNodeID(ctx context.Context) (string, bool) {
+	nodeID, ok := ctx.Value(peerNodeKey{}).(string)
+	return nodeID, ok
+}
+
+// CheckPeerNodeID 校验请求消息中声明的调用方节点 ID 与签名的节点 ID 一致
+// 签名只认证 metadata 中的节点 ID，消息中的节点 ID 需由处理方调用本函数核对；未签名的请求或 claimed 为空时不校验
+func CheckPeerNodeID(ctx context.Context, claimed string) error {
+	nodeID, ok := PeerNodeID(ctx)
+	if !ok || claimed == "" || claimed == nodeID {
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "request claims node %s but is signed by node %s", claimed, nodeID)
+}
+
+// UnaryClientInterceptor 返回向 targetNodeID 发起调用时为每次调用签名的客户端拦截器
+// 签名覆盖方法名、节点 ID、目标节点 ID、时间戳与请求消息
+func (id *Identity) UnaryClientInterceptor(targetNodeID string) grpc.UnaryClientInterceptor {
+	publicKey := base64.StdEncoding.EncodeToString(id.PublicKey())
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		msg, ok := req.(proto.Message)
+		if !ok {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		timestamp := strconv.FormatInt(time.Now().UnixNano(), 10)
+		payload, err := rpcPayload(method, id.nodeID, targetNodeID, timestamp, msg)
+		if err != nil {
+			return err
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx,
+			mdNodeID, id.nodeID,
+			mdPublicKey, publicKey,
+			mdTimestamp, timestamp,
+			mdSignature, base64.StdEncoding.EncodeToString(id.Sign(payload)),
+		)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// UnaryServerInterceptor 返回校验调用方节点签名的服务端拦截器
+// 签名无效、时间戳过期或 node ID 已绑定其他公钥时拒绝请求；校验通过的节点 ID 可用 PeerNodeID 取得
+func UnaryServerInterceptor(pins *Pins, opts VerifyOptions) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		nodeID := firstValue(md, mdNodeID)
+		if nodeID == "" {
+			if opts.RequireSigned {
+				return nil, status.Error(codes.Unauthenticated, "request is not signed by a node identity")
+			}
+			return handler(ctx, req)
+		}
+
+		if err := verifyRPC(md, nodeID, opts.NodeID, info.FullMethod, req, opts.MaxClockSkew); err != nil {
+			logrus.Warnf("Rejected %s from node %s: %v", info.FullMethod, nodeID, err)
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		publicKey, _ := base64.StdEncoding.DecodeString(firstValue(md, mdPublicKey))
+		if err := pins.Check(nodeID, publicKey); err != nil {
+			logrus.Warnf("Rejected %s from node %s: %v", info.FullMethod, nodeID, err)
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		return handler(context.WithValue(ctx, peerNodeKey{}, nodeID), req)
+	}
+}
+
+func verifyRPC(md metadata.MD, nodeID, targetNodeID, method string, req any, maxSkew time.Duration) error {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return errors.New("request is not a protobuf message")
+	}
+	timestamp := firstValue(md, mdTimestamp)
+	nanos, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.New("invalid signature timestamp")
+	}
+	if err := checkSkew(time.Unix(0, nanos), maxSkew); err != nil {
+		return err
+	}
+	publicKey, err := base64.StdEncoding.DecodeString(firstValue(md, mdPublicKey))
+	if err != nil {
+		return errors.New("invalid public key encoding")
+	}
+	signature, err := base64.StdEncoding.DecodeString(firstValue(md, mdSignature))
+	if err != nil {
+		return errors.New("invalid signature encoding")
+	}
+	payload, err := rpcPayload(method, nodeID, targetNodeID, timestamp, msg)
+	if err != nil {
+		return err
+	}
+	return Verify(publicKey, payload, signature)
+}
+
+func rpcPayload(method, nodeID, targetNodeID, timestamp string, msg proto.Message) ([]byte, error) {
+	return messagePayload("rpc\x00"+method+"\x00"+nodeID+"\x00"+targetNodeID+"\x00"+timestamp, msg)
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,119 @@
+// Package identity 节点身份密钥与签名
+// 每个节点持有一对 ed25519 密钥，私钥与 node_id 文件一起保存在数据目录中。
+// 节点向全局注册中心注册、上报健康检查以及向其他节点发起 scheduler 调用时用私钥签名，
+// 接收方首次见到某个 node_id 时记录其公钥（trust on first use），之后拒绝以同一 node_id 但不同密钥签名的请求
+package identity
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+)
+
+// keyFileName 私钥文件名，与 node_id 文件位于同一数据目录
+const keyFileName = "node_key"
+
+// ErrInvalidSignature 签名与公钥或内容不匹配
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// Identity 节点身份：node ID 与签名私钥
+type Identity struct {
+	nodeID     string
+	privateKey ed25519.PrivateKey
+}
+
+// LoadOrGenerate 从数据目录加载节点私钥，不存在时生成新的密钥对并保存（仅所有者可读）
+func LoadOrGenerate(dataDir, nodeID string) (*Identity, error) {
+	if dataDir == "" {
+		dataDir = "./data"
+	}
+	keyFile := filepath.Join(dataDir, keyFileName)
+
+	if data, err := os.ReadFile(keyFile); err == nil {
+		key, err := parsePrivateKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node key %s: %w", keyFile, err)
+		}
+		logrus.Infof("Loaded node key from %s", keyFile)
+		return &Identity{nodeID: nodeID, privateKey: key}, nil
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read node key %s: %w", keyFile, err)
+	}
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate node key: %w", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode node key: %w", err)
+	}
+	if err := os.MkdirAll(dataDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create data directory %s: %w", dataDir, err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		return nil, fmt.Errorf("failed to save node key %s: %w", keyFile, err)
+	}
+	logrus.Infof("Generated and saved new node key to %s", keyFile)
+	return &Identity{nodeID: nodeID, privateKey: key}, nil
+}
+
+func parsePrivateKey(data []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported key type %T, expected ed25519", key)
+	}
+	return edKey, nil
+}
+
+// NodeID 返回节点 ID
+func (id *Identity) NodeID() string {
+	return id.nodeID
+}
+
+// PublicKey 返回节点公钥
+func (id *Identity) PublicKey() ed25519.PublicKey {
+	return id.privateKey.Public().(ed25519.PublicKey)
+}
+
+// Sign 对 payload 签名
+func (id *Identity) Sign(payload []byte) []byte {
+	return ed25519.Sign(id.privateKey, payload)
+}
+
+// Verify 校验 publicKey 对 payload 的签名
+func Verify(publicKey, payload, signature []byte) error {
+	if len(publicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("%w: public key must be %d bytes, got %d", ErrInvalidSignature, ed25519.PublicKeySize, len(publicKey))
+	}
+	if !ed25519.Verify(publicKey, payload, signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// messagePayload 返回消息的签名内容：用途前缀加上消息的确定性编码
+// 新增字段使用更大的字段号时，旧版接收方保留的未知字段在编码末尾，签名内容与发送方一致
+func messagePayload(purpose string, msg proto.Message) ([]byte, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s payload: %w", purpose, err)
+	}
+	return append([]byte("iarnet-"+purpose+"\x00"), data...), nil
+}
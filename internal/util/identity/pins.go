@@ -0,0 +1,81 @@
+package identity
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrKeyMismatch node ID 已绑定其他公钥
+var ErrKeyMismatch = errors.New("node ID is bound to a different key")
+
+// Pins 记录各 node ID 首次出现时使用的公钥（trust on first use）
+// 绑定关系保存在文件中，重启后仍然有效；节点更换密钥后需由运维人员从文件中删除对应条目
+type Pins struct {
+	mu   sync.Mutex
+	path string
+	keys map[string][]byte
+}
+
+// LoadPins 从文件加载已绑定的公钥，文件不存在时从空开始；path 为空时只在内存中保存
+func LoadPins(path string) (*Pins, error) {
+	p := &Pins{path: path, keys: make(map[string][]byte)}
+	if path == "" {
+		return p, nil
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return p, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read peer keys %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &p.keys); err != nil {
+		return nil, fmt.Errorf("invalid peer keys %s: %w", path, err)
+	}
+	return p, nil
+}
+
+// Check 校验 nodeID 使用的公钥：未绑定时绑定并保存，已绑定其他公钥时返回 ErrKeyMismatch
+func (p *Pins) Check(nodeID string, publicKey []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if pinned, ok := p.keys[nodeID]; ok {
+		if !bytes.Equal(pinned, publicKey) {
+			return fmt.Errorf("%w: %s", ErrKeyMismatch, nodeID)
+		}
+		return nil
+	}
+	p.keys[nodeID] = bytes.Clone(publicKey)
+	logrus.Infof("Pinned public key of node %s", nodeID)
+	if err := p.save(); err != nil {
+		// 保存失败不影响本次校验，重启后重新绑定
+		logrus.Warnf("Failed to save peer keys: %v", err)
+	}
+	return nil
+}
+
+// save 写入临时文件后重命名，避免写入中断导致文件损坏
+func (p *Pins) save() error {
+	if p.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(p.keys, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p.path), 0o755); err != nil {
+		return err
+	}
+	tmp := p.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, p.path)
+}
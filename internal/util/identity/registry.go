@@ -0,0 +1,79 @@
+package identity
+
+import (
+	"fmt"
+	"time"
+
+	registrypb "github.com/9triver/iarnet/internal/proto/global/registry"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	purposeRegisterNode = "register-node"
+	purposeHealthCheck  = "health-check"
+)
+
+// SignRegisterNode 为注册请求填充公钥与签名
+func (id *Identity) SignRegisterNode(req *registrypb.RegisterNodeRequest) error {
+	req.PublicKey = id.PublicKey()
+	req.Signature = nil
+	payload, err := messagePayload(purposeRegisterNode, req)
+	if err != nil {
+		return err
+	}
+	req.Signature = id.Sign(payload)
+	return nil
+}
+
+// SignHealthCheck 为健康检查请求填充公钥与签名，请求中的 timestamp 一并被签名，用于防止重放
+func (id *Identity) SignHealthCheck(req *registrypb.HealthCheckRequest) error {
+	req.PublicKey = id.PublicKey()
+	req.Signature = nil
+	payload, err := messagePayload(purposeHealthCheck, req)
+	if err != nil {
+		return err
+	}
+	req.Signature = id.Sign(payload)
+	return nil
+}
+
+// VerifyRegisterNode 供全局注册中心校验注册请求的签名
+// 签名只证明请求由 public_key 对应的私钥签发，registry 还需用 Pins 校验 node_id 与公钥的绑定
+func VerifyRegisterNode(req *registrypb.RegisterNodeRequest) error {
+	unsigned := proto.CloneOf(req)
+	unsigned.Signature = nil
+	payload, err := messagePayload(purposeRegisterNode, unsigned)
+	if err != nil {
+		return err
+	}
+	return Verify(req.GetPublicKey(), payload, req.GetSignature())
+}
+
+// VerifyHealthCheck 供全局注册中心校验健康检查请求的签名与时间戳，maxSkew 为允许的时钟偏差
+func VerifyHealthCheck(req *registrypb.HealthCheckRequest, maxSkew time.Duration) error {
+	if err := checkSkew(time.Unix(0, req.GetTimestamp()), maxSkew); err != nil {
+		return err
+	}
+	unsigned := proto.CloneOf(req)
+	unsigned.Signature = nil
+	payload, err := messagePayload(purposeHealthCheck, unsigned)
+	if err != nil {
+		return err
+	}
+	return Verify(req.GetPublicKey(), payload, req.GetSignature())
+}
+
+// checkSkew 校验签名时间与本地时间的偏差
+func checkSkew(signedAt time.Time, maxSkew time.Duration) error {
+	if maxSkew <= 0 {
+		return nil
+	}
+	skew := time.Since(signedAt)
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > maxSkew {
+		return fmt.Errorf("%w: signed at %s, clock skew %s exceeds %s", ErrInvalidSignature, signedAt.Format(time.RFC3339), skew.Round(time.Second), maxSkew)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package util
+
+import "time"
+
+// AdaptiveInterval 在上下限之间自适应的轮询间隔：有活动时回到下限，空闲时每轮翻倍直到上限
+// 下限与上限相同时即为固定间隔；不是并发安全的，由单个轮询循环使用
+type AdaptiveInterval struct {
+	min     time.Duration
+	max     time.Duration
+	current time.Duration
+}
+
+// NewAdaptiveInterval 创建自适应间隔，初始为下限；上限小于下限时按下限处理
+func NewAdaptiveInterval(lower, upper time.Duration) *AdaptiveInterval {
+	return &AdaptiveInterval{min: lower, max: max(lower, upper), current: lower}
+}
+
+// Next 根据本轮是否有活动返回下一轮的间隔
+func (a *AdaptiveInterval) Next(active bool) time.Duration {
+	if active {
+		a.current = a.min
+	} else {
+		a.current = min(a.current*2, a.max)
+	}
+	return a.current
+}
+
+// Current 当前间隔
+func (a *AdaptiveInterval) Current() time.Duration {
+	return a.current
+}
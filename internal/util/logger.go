@@ -1,19 +1,215 @@
 package util
 
 import (
+	"fmt"
+	"maps"
 	"runtime"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
 )
 
+// 日志输出格式
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// logModules 可单独设置日志级别的模块，按输出日志的代码所在的包归属，靠前的优先匹配
+// 不属于任何模块的日志使用全局级别
+var logModules = []struct {
+	name string
+	pkg  string
+}{
+	{"scheduler", "internal/domain/resource/scheduler"},
+	{"scheduler", "internal/transport/rpc/resource/scheduler"},
+	{"discovery", "internal/domain/resource/discovery"},
+	{"discovery", "internal/transport/rpc/resource/discovery"},
+	{"providers", "internal/domain/resource/provider"},
+	{"resource", "internal/domain/resource"},
+	{"resource", "internal/transport/rpc/resource"},
+}
+
+// logState 当前的日志格式与各模块级别
+var logState = struct {
+	mu      sync.RWMutex
+	format  string
+	level   logrus.Level
+	modules map[string]logrus.Level // 未设置的模块使用全局级别
+}{
+	format:  LogFormatText,
+	level:   logrus.InfoLevel,
+	modules: make(map[string]logrus.Level),
+}
+
 func InitLogger() {
-	logrus.SetFormatter(&logrus.TextFormatter{
-		FullTimestamp:   true,
-		TimestampFormat: time.DateTime,
-		CallerPrettyfier: func(frame *runtime.Frame) (function string, file string) {
-			return frame.Function, "" // TODO: 生成包的简写
-		},
-	})
+	logrus.SetFormatter(&moduleFormatter{next: newFormatter(LogFormatText)})
 	logrus.SetReportCaller(true)
 }
+
+// ConfigureLogger 设置日志格式、全局级别与各模块级别，在 InitLogger 之后调用
+func ConfigureLogger(format, level string, modules map[string]string) error {
+	if err := SetLogFormat(format); err != nil {
+		return err
+	}
+	if err := SetLogLevel(level); err != nil {
+		return err
+	}
+	for module, moduleLevel := range modules {
+		if err := SetModuleLogLevel(module, moduleLevel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetLogFormat 设置日志输出格式（text 或 json）
+func SetLogFormat(format string) error {
+	if format != LogFormatText && format != LogFormatJSON {
+		return fmt.Errorf("unsupported log format %q, must be text or json", format)
+	}
+	logState.mu.Lock()
+	logState.format = format
+	logState.mu.Unlock()
+	logrus.SetFormatter(&moduleFormatter{next: newFormatter(format)})
+	return nil
+}
+
+// SetLogLevel 设置全局日志级别，未单独设置级别的模块随之变化
+func SetLogLevel(level string) error {
+	lvl, err := logrus.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	logState.mu.Lock()
+	defer logState.mu.Unlock()
+	logState.level = lvl
+	applyLoggerLevel()
+	return nil
+}
+
+// SetModuleLogLevel 设置模块的日志级别，level 为空时恢复使用全局级别
+func SetModuleLogLevel(module, level string) error {
+	if !slices.Contains(LogModules(), module) {
+		return fmt.Errorf("unknown log module %q, must be one of %s", module, strings.Join(LogModules(), ", "))
+	}
+	logState.mu.Lock()
+	defer logState.mu.Unlock()
+	if level == "" {
+		delete(logState.modules, module)
+	} else {
+		lvl, err := logrus.ParseLevel(level)
+		if err != nil {
+			return err
+		}
+		logState.modules[module] = lvl
+	}
+	applyLoggerLevel()
+	return nil
+}
+
+// LogModules 返回可单独设置日志级别的模块
+func LogModules() []string {
+	var names []string
+	for _, m := range logModules {
+		if !slices.Contains(names, m.name) {
+			names = append(names, m.name)
+		}
+	}
+	return names
+}
+
+// LogLevels 返回当前的日志格式、全局级别与单独设置了级别的模块
+func LogLevels() (string, string, map[string]string) {
+	logState.mu.RLock()
+	defer logState.mu.RUnlock()
+	modules := make(map[string]string, len(logState.modules))
+	for module, lvl := range logState.modules {
+		modules[module] = lvl.String()
+	}
+	return logState.format, logState.level.String(), modules
+}
+
+// applyLoggerLevel logrus 在格式化前按全局级别过滤，因此设置为所有级别中最详细的一个，
+// 再由 moduleFormatter 按模块过滤；调用方需持有 logState.mu
+func applyLoggerLevel() {
+	lvl := logState.level
+	for _, moduleLevel := range logState.modules {
+		lvl = max(lvl, moduleLevel)
+	}
+	logrus.SetLevel(lvl)
+}
+
+func newFormatter(format string) logrus.Formatter {
+	callerPrettyfier := func(frame *runtime.Frame) (function string, file string) {
+		return frame.Function, "" // TODO: 生成包的简写
+	}
+	if format == LogFormatJSON {
+		return &logrus.JSONFormatter{
+			TimestampFormat:  time.RFC3339Nano,
+			CallerPrettyfier: callerPrettyfier,
+		}
+	}
+	return &logrus.TextFormatter{
+		FullTimestamp:    true,
+		TimestampFormat:  time.DateTime,
+		CallerPrettyfier: callerPrettyfier,
+	}
+}
+
+// moduleFormatter 按日志所属模块的级别过滤，JSON 格式下附加 module 字段
+type moduleFormatter struct {
+	next logrus.Formatter
+}
+
+func (f *moduleFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	module := entryModule(entry)
+
+	logState.mu.RLock()
+	lvl, ok := logState.modules[module]
+	if !ok {
+		lvl = logState.level
+	}
+	format := logState.format
+	logState.mu.RUnlock()
+
+	if entry.Level > lvl {
+		return nil, nil
+	}
+	if format == LogFormatJSON && module != "" {
+		e := *entry
+		e.Data = make(logrus.Fields, len(entry.Data)+1)
+		maps.Copy(e.Data, entry.Data)
+		e.Data["module"] = module
+		return f.next.Format(&e)
+	}
+	return f.next.Format(entry)
+}
+
+// entryModule 根据调用方所在的包确定日志所属模块，无法确定时返回空字符串
+func entryModule(entry *logrus.Entry) string {
+	if entry.Caller == nil {
+		return ""
+	}
+	// 函数名形如 github.com/9triver/iarnet/internal/domain/resource/scheduler.(*service).Start
+	fn := entry.Caller.Function
+	slash := strings.LastIndex(fn, "/")
+	pkg := fn
+	if dot := strings.Index(fn[slash+1:], "."); dot >= 0 {
+		pkg = fn[:slash+1+dot]
+	}
+	idx := strings.Index(pkg, "internal/")
+	if idx < 0 {
+		return ""
+	}
+	pkg = pkg[idx:]
+	for _, m := range logModules {
+		if pkg == m.pkg || strings.HasPrefix(pkg, m.pkg+"/") {
+			return m.name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,186 @@
+// Package msgguard 统计 ZMQ、gRPC 流等边界上各对端发送的非法消息（超长、无法解码、不符合消息定义），
+// 对端在时间窗口内的非法消息达到上限后被封禁一段时间：封禁期间其消息一律丢弃，gRPC 流被断开
+package msgguard
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// 统计通道名称
+const (
+	ChannelZMQ   = "zmq"
+	ChannelIgnis = "ignis"
+)
+
+// maxTrackedPeers 单个通道记录的对端数上限，超过时清理已过期的记录，避免伪造大量对端耗尽内存
+const maxTrackedPeers = 4096
+
+// ErrBlocked 对端因非法消息过多被封禁
+var ErrBlocked = errors.New("peer blocked after too many malformed messages")
+
+// Limits 非法消息的容忍度
+type Limits struct {
+	MaxBadMessages int           // 时间窗口内允许的非法消息数，达到后封禁对端；0 表示只统计不封禁
+	Window         time.Duration // 统计窗口
+	BlockDuration  time.Duration // 封禁时长
+}
+
+// peerState 单个对端的统计
+type peerState struct {
+	total        int64
+	windowStart  time.Time
+	inWindow     int
+	lastReason   string
+	lastSeen     time.Time
+	blockedUntil time.Time
+	blocks       int64
+}
+
+// Guard 单个通道的非法消息统计与封禁
+type Guard struct {
+	channel string
+
+	mu     sync.Mutex
+	limits Limits
+	peers  map[string]*peerState
+}
+
+var (
+	guardsMu sync.RWMutex
+	guards   = make(map[string]*Guard) // channel -> guard
+)
+
+// For 返回通道的 Guard，首次调用时创建（默认只统计不封禁，由 SetLimits 配置上限）
+func For(channel string) *Guard {
+	guardsMu.RLock()
+	g, ok := guards[channel]
+	guardsMu.RUnlock()
+	if ok {
+		return g
+	}
+
+	guardsMu.Lock()
+	defer guardsMu.Unlock()
+	if g, ok := guards[channel]; ok {
+		return g
+	}
+	g = &Guard{channel: channel, peers: make(map[string]*peerState)}
+	guards[channel] = g
+	return g
+}
+
+// SetLimits 设置所有通道的容忍度
+func SetLimits(limits Limits) {
+	for _, channel := range []string{ChannelZMQ, ChannelIgnis} {
+		For(channel).SetLimits(limits)
+	}
+}
+
+// SetLimits 设置通道的容忍度，已有的封禁保持到期
+func (g *Guard) SetLimits(limits Limits) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.limits = limits
+}
+
+// Blocked 对端当前是否被封禁
+func (g *Guard) Blocked(peer string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	state, ok := g.peers[peer]
+	return ok && time.Now().Before(state.blockedUntil)
+}
+
+// Reject 记录对端的一条非法消息，返回对端是否因此被封禁
+func (g *Guard) Reject(peer string, reason error) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now()
+	state, ok := g.peers[peer]
+	if !ok {
+		if len(g.peers) >= maxTrackedPeers {
+			g.pruneLocked(now)
+		}
+		state = &peerState{windowStart: now}
+		g.peers[peer] = state
+	}
+	if now.Sub(state.windowStart) > g.limits.Window {
+		state.windowStart = now
+		state.inWindow = 0
+	}
+	state.total++
+	state.inWindow++
+	state.lastReason = reason.Error()
+	state.lastSeen = now
+
+	if g.limits.MaxBadMessages <= 0 || state.inWindow < g.limits.MaxBadMessages || now.Before(state.blockedUntil) {
+		logrus.Warnf("Malformed %s message from %s: %v", g.channel, peer, reason)
+		return false
+	}
+	state.blockedUntil = now.Add(g.limits.BlockDuration)
+	state.blocks++
+	state.inWindow = 0
+	logrus.Errorf("Blocking %s peer %s for %v after %d malformed messages within %v, last: %v",
+		g.channel, peer, g.limits.BlockDuration, g.limits.MaxBadMessages, g.limits.Window, reason)
+	return true
+}
+
+// pruneLocked 清理窗口已过且未被封禁的对端，调用方需持有 mu
+func (g *Guard) pruneLocked(now time.Time) {
+	for peer, state := range g.peers {
+		if now.Sub(state.lastSeen) > g.limits.Window && !now.Before(state.blockedUntil) {
+			delete(g.peers, peer)
+		}
+	}
+}
+
+// PeerStats 单个对端的非法消息统计
+type PeerStats struct {
+	Channel      string
+	Peer         string
+	BadMessages  int64     // 累计非法消息数
+	Blocks       int64     // 累计被封禁次数
+	LastReason   string    // 最近一条非法消息的原因
+	LastSeen     time.Time // 最近一条非法消息的时间
+	BlockedUntil time.Time // 封禁到期时间，未封禁时为零值或已过去的时间
+}
+
+// Snapshot 返回所有通道各对端的统计，按通道与对端排序
+func Snapshot() []PeerStats {
+	guardsMu.RLock()
+	list := make([]*Guard, 0, len(guards))
+	for _, g := range guards {
+		list = append(list, g)
+	}
+	guardsMu.RUnlock()
+
+	var stats []PeerStats
+	for _, g := range list {
+		g.mu.Lock()
+		for peer, state := range g.peers {
+			stats = append(stats, PeerStats{
+				Channel:      g.channel,
+				Peer:         peer,
+				BadMessages:  state.total,
+				Blocks:       state.blocks,
+				LastReason:   state.lastReason,
+				LastSeen:     state.lastSeen,
+				BlockedUntil: state.blockedUntil,
+			})
+		}
+		g.mu.Unlock()
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Channel != stats[j].Channel {
+			return stats[i].Channel < stats[j].Channel
+		}
+		return stats[i].Peer < stats[j].Peer
+	})
+	return stats
+}
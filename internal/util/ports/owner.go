@@ -0,0 +1,68 @@
+package ports
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// tcpListen /proc/net/tcp 中 LISTEN 状态的编码
+const tcpListen = "0A"
+
+// listenerOwner 通过 /proc 查找监听端口的进程名与进程号，无法识别（非 Linux 或权限不足）时返回空
+func listenerOwner(port int) (string, int) {
+	inodes := listenerInodes(port)
+	if len(inodes) == 0 {
+		return "", 0
+	}
+
+	procs, err := filepath.Glob("/proc/[0-9]*")
+	if err != nil {
+		return "", 0
+	}
+	for _, proc := range procs {
+		fds, err := os.ReadDir(filepath.Join(proc, "fd"))
+		if err != nil {
+			continue
+		}
+		for _, fd := range fds {
+			link, err := os.Readlink(filepath.Join(proc, "fd", fd.Name()))
+			if err != nil {
+				continue
+			}
+			if _, ok := inodes[link]; !ok {
+				continue
+			}
+			pid, _ := strconv.Atoi(filepath.Base(proc))
+			comm, _ := os.ReadFile(filepath.Join(proc, "comm"))
+			return strings.TrimSpace(string(comm)), pid
+		}
+	}
+	return "", 0
+}
+
+// listenerInodes 返回监听端口的 socket inode，格式与 /proc/<pid>/fd 的链接目标一致（socket:[inode]）
+func listenerInodes(port int) map[string]struct{} {
+	suffix := fmt.Sprintf(":%04X", port)
+	inodes := make(map[string]struct{})
+	for _, table := range []string{"/proc/net/tcp", "/proc/net/tcp6"} {
+		f, err := os.Open(table)
+		if err != nil {
+			continue
+		}
+		scanner := bufio.NewScanner(f)
+		scanner.Scan() // 表头
+		for scanner.Scan() {
+			fields := strings.Fields(scanner.Text())
+			if len(fields) < 10 || fields[3] != tcpListen || !strings.HasSuffix(fields[1], suffix) {
+				continue
+			}
+			inodes["socket:["+fields[9]+"]"] = struct{}{}
+		}
+		f.Close()
+	}
+	return inodes
+}
@@ -0,0 +1,254 @@
+// Package ports 在同一主机上运行的 iarnet 节点与各 provider 之间协调端口分配
+// 每个进程启动时为其监听端口登记租约（lease 目录下的 <port>.json），租约记录服务名与进程号；
+// 进程退出后租约自动失效。端口冲突时报告占用端口的服务，而非笼统的 bind 错误
+package ports
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// provider 未配置端口（port 为 0）时的分配范围
+const (
+	ProviderPortMin = 50051
+	ProviderPortMax = 50099
+)
+
+// EnvDir 指定租约目录的环境变量，同一主机上的进程需使用相同的目录
+const EnvDir = "IARNET_PORT_DIR"
+
+// Binding 服务的一个监听端口
+type Binding struct {
+	Service string // e.g., "iarnet store rpc"、"docker provider"
+	Port    int
+}
+
+// Lease 端口租约
+type Lease struct {
+	Service   string    `json:"service"`
+	Port      int       `json:"port"`
+	PID       int       `json:"pid"`
+	Command   string    `json:"command"`
+	ClaimedAt time.Time `json:"claimed_at"`
+}
+
+// ConflictError 端口已被其他服务占用
+type ConflictError struct {
+	Port    int
+	Service string // 申请端口的服务
+	Holder  string // 占用端口的服务，无法识别时为空
+	PID     int    // 占用端口的进程，无法识别时为 0
+}
+
+func (e *ConflictError) Error() string {
+	holder := e.Holder
+	if holder == "" {
+		holder = "another process"
+	}
+	if e.PID > 0 {
+		holder = fmt.Sprintf("%s (pid %d)", holder, e.PID)
+	}
+	return fmt.Sprintf("port %d for %s is already in use by %s", e.Port, e.Service, holder)
+}
+
+// Registry 端口租约登记
+type Registry struct {
+	dir string
+
+	mu   sync.Mutex
+	held map[int]*Lease
+}
+
+// DefaultDir 默认租约目录，可通过 IARNET_PORT_DIR 覆盖
+func DefaultDir() string {
+	if dir := os.Getenv(EnvDir); dir != "" {
+		return dir
+	}
+	return filepath.Join(os.TempDir(), "iarnet-ports")
+}
+
+// NewRegistry 创建使用指定租约目录的登记，dir 为空时使用 DefaultDir
+func NewRegistry(dir string) *Registry {
+	if dir == "" {
+		dir = DefaultDir()
+	}
+	return &Registry{dir: dir, held: make(map[int]*Lease)}
+}
+
+// Claim 为各端口登记租约并检查端口能否绑定，任一端口冲突时释放已登记的租约并返回 *ConflictError
+// 由滚动重启的父进程持有的租约视为本进程的租约
+func (r *Registry) Claim(bindings ...Binding) error {
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create port lease directory: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var claimed []int
+	for _, b := range bindings {
+		if err := r.claimLocked(b); err != nil {
+			for _, port := range claimed {
+				r.releaseLocked(port)
+			}
+			return err
+		}
+		claimed = append(claimed, b.Port)
+	}
+	return nil
+}
+
+// Allocate 在 [from, to] 范围内为服务分配一个空闲端口并登记租约
+func (r *Registry) Allocate(service string, from, to int) (int, error) {
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return 0, fmt.Errorf("failed to create port lease directory: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for port := from; port <= to; port++ {
+		err := r.claimLocked(Binding{Service: service, Port: port})
+		if err == nil {
+			return port, nil
+		}
+		var conflict *ConflictError
+		if !errors.As(err, &conflict) {
+			return 0, err
+		}
+	}
+	return 0, fmt.Errorf("no free port in range %d-%d for %s", from, to, service)
+}
+
+// ClaimOrAllocate 登记服务配置的端口；port 为 0 时在 [from, to] 范围内分配，返回最终使用的端口
+func (r *Registry) ClaimOrAllocate(service string, port, from, to int) (int, error) {
+	if port == 0 {
+		return r.Allocate(service, from, to)
+	}
+	if err := r.Claim(Binding{Service: service, Port: port}); err != nil {
+		return 0, err
+	}
+	return port, nil
+}
+
+// Close 释放本进程登记的所有租约
+func (r *Registry) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for port := range r.held {
+		r.releaseLocked(port)
+	}
+	return nil
+}
+
+// claimLocked 登记单个端口的租约，调用方需持有 mu
+func (r *Registry) claimLocked(b Binding) error {
+	if _, ok := r.held[b.Port]; ok {
+		return nil
+	}
+	path := r.leasePath(b.Port)
+	if existing, err := readLease(path); err == nil && alive(existing.PID) && !ours(existing.PID) {
+		return &ConflictError{Port: b.Port, Service: b.Service, Holder: existing.Service, PID: existing.PID}
+	}
+
+	// 已登记的租约属于本进程（或滚动重启的父进程）时端口可能已被继承的 socket 占用，不再探测
+	if !r.inherited(path) {
+		if err := probeBind(b.Port); err != nil {
+			conflict := &ConflictError{Port: b.Port, Service: b.Service}
+			conflict.Holder, conflict.PID = listenerOwner(b.Port)
+			return conflict
+		}
+	}
+
+	lease := &Lease{
+		Service:   b.Service,
+		Port:      b.Port,
+		PID:       os.Getpid(),
+		Command:   filepath.Base(os.Args[0]),
+		ClaimedAt: time.Now(),
+	}
+	if err := writeLease(path, lease); err != nil {
+		return err
+	}
+	r.held[b.Port] = lease
+	return nil
+}
+
+// releaseLocked 删除本进程持有的租约，已被其他进程接管的租约保留
+func (r *Registry) releaseLocked(port int) {
+	delete(r.held, port)
+	path := r.leasePath(port)
+	if lease, err := readLease(path); err == nil && lease.PID == os.Getpid() {
+		os.Remove(path)
+	}
+}
+
+// inherited 租约是否由滚动重启的父进程持有
+func (r *Registry) inherited(path string) bool {
+	lease, err := readLease(path)
+	return err == nil && lease.PID == os.Getppid() && alive(lease.PID)
+}
+
+func (r *Registry) leasePath(port int) string {
+	return filepath.Join(r.dir, strconv.Itoa(port)+".json")
+}
+
+func readLease(path string) (*Lease, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	lease := &Lease{}
+	if err := json.Unmarshal(data, lease); err != nil {
+		return nil, err
+	}
+	return lease, nil
+}
+
+// writeLease 先写临时文件再重命名，避免其他进程读到不完整的租约
+func writeLease(path string, lease *Lease) error {
+	data, err := json.Marshal(lease)
+	if err != nil {
+		return err
+	}
+	tmp := fmt.Sprintf("%s.%d.tmp", path, os.Getpid())
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write port lease: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write port lease: %w", err)
+	}
+	return nil
+}
+
+// probeBind 检查端口当前能否绑定
+func probeBind(port int) error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return err
+	}
+	return lis.Close()
+}
+
+// ours 租约是否属于本进程或滚动重启的父进程
+func ours(pid int) bool {
+	return pid == os.Getpid() || pid == os.Getppid()
+}
+
+// alive 进程是否仍在运行
+func alive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
@@ -0,0 +1,142 @@
+package tunnel
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Hub 节点侧接收 provider 的反向连接，并按 provider 名称交给 gRPC 客户端使用
+type Hub struct {
+	mu     sync.Mutex
+	tokens map[string]string        // provider 名称 -> 令牌，只接受登记过的 provider
+	conns  map[string]chan net.Conn // provider 名称 -> 尚未被 gRPC 客户端取走的连接
+	lis    net.Listener
+}
+
+// NewHub 创建 Hub
+func NewHub() *Hub {
+	return &Hub{
+		tokens: make(map[string]string),
+		conns:  make(map[string]chan net.Conn),
+	}
+}
+
+// Allow 允许指定名称的 provider 以该令牌建立反向连接
+func (h *Hub) Allow(name, token string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.tokens[name] = token
+	if _, ok := h.conns[name]; !ok {
+		h.conns[name] = make(chan net.Conn, 1)
+	}
+}
+
+// Serve 在 lis 上接收反向连接，直到 lis 被关闭
+func (h *Hub) Serve(lis net.Listener) error {
+	h.mu.Lock()
+	h.lis = lis
+	h.mu.Unlock()
+
+	for {
+		conn, err := lis.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go h.accept(conn)
+	}
+}
+
+// Close 停止接收反向连接，并关闭尚未被使用的连接
+func (h *Hub) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, ch := range h.conns {
+		select {
+		case conn := <-ch:
+			conn.Close()
+		default:
+		}
+	}
+	if h.lis == nil {
+		return nil
+	}
+	return h.lis.Close()
+}
+
+// accept 完成握手并将连接交给等待该 provider 的 gRPC 客户端
+// provider 重连时替换尚未被取走的旧连接
+func (h *Hub) accept(conn net.Conn) {
+	conn.SetDeadline(time.Now().Add(handshakeTimeout))
+	var hello Hello
+	if err := readFrame(conn, &hello); err != nil {
+		logrus.Warnf("Tunnel handshake from %s failed: %v", conn.RemoteAddr(), err)
+		conn.Close()
+		return
+	}
+
+	ch, err := h.authorize(hello)
+	if err != nil {
+		logrus.Warnf("Rejecting tunnel from %s: %v", conn.RemoteAddr(), err)
+		writeFrame(conn, helloReply{Error: err.Error()})
+		conn.Close()
+		return
+	}
+	if err := writeFrame(conn, helloReply{OK: true}); err != nil {
+		logrus.Warnf("Tunnel handshake from %s failed: %v", conn.RemoteAddr(), err)
+		conn.Close()
+		return
+	}
+	conn.SetDeadline(time.Time{})
+
+	for {
+		select {
+		case ch <- conn:
+			logrus.Infof("Provider %s connected through tunnel from %s", hello.Name, conn.RemoteAddr())
+			return
+		case stale := <-ch:
+			stale.Close()
+		}
+	}
+}
+
+func (h *Hub) authorize(hello Hello) (chan net.Conn, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	token, ok := h.tokens[hello.Name]
+	if !ok {
+		return nil, fmt.Errorf("unknown provider %q", hello.Name)
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(hello.Token)) != 1 {
+		return nil, fmt.Errorf("invalid token for provider %q", hello.Name)
+	}
+	return h.conns[hello.Name], nil
+}
+
+// Dialer 返回 gRPC 客户端使用的拨号函数：等待指定 provider 的下一条反向连接
+// provider 尚未连接时阻塞到 ctx 结束
+func (h *Hub) Dialer(name string) func(ctx context.Context, addr string) (net.Conn, error) {
+	return func(ctx context.Context, _ string) (net.Conn, error) {
+		h.mu.Lock()
+		ch, ok := h.conns[name]
+		h.mu.Unlock()
+		if !ok {
+			return nil, fmt.Errorf("provider %q is not allowed to connect through the tunnel", name)
+		}
+		select {
+		case conn := <-ch:
+			return conn, nil
+		case <-ctx.Done():
+			return nil, fmt.Errorf("provider %q has not connected through the tunnel: %w", name, ctx.Err())
+		}
+	}
+}
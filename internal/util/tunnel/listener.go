@@ -0,0 +1,112 @@
+package tunnel
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// 重新拨号的退避区间
+const (
+	minRedialDelay = time.Second
+	maxRedialDelay = 30 * time.Second
+)
+
+// listener provider 侧的反向 listener：Accept 主动拨号到节点并完成握手
+// 同一时间只保持一条连接，连接关闭后下一次 Accept 重新拨号
+type listener struct {
+	addr  string
+	hello Hello
+
+	idle chan struct{} // 当前没有活跃连接时可以拨号
+	done chan struct{}
+	once sync.Once
+}
+
+// Listen 返回主动连接节点 tunnel 端口（addr）的 listener，交给 grpc.Server.Serve 使用
+func Listen(addr string, hello Hello) net.Listener {
+	l := &listener{
+		addr:  addr,
+		hello: hello,
+		idle:  make(chan struct{}, 1),
+		done:  make(chan struct{}),
+	}
+	l.idle <- struct{}{}
+	return l
+}
+
+func (l *listener) Accept() (net.Conn, error) {
+	select {
+	case <-l.idle:
+	case <-l.done:
+		return nil, net.ErrClosed
+	}
+
+	delay := minRedialDelay
+	for {
+		conn, err := l.dial()
+		if err == nil {
+			logrus.Infof("Tunnel to node %s established", l.addr)
+			return &trackedConn{Conn: conn, onClose: l.release}, nil
+		}
+		logrus.Warnf("Failed to establish tunnel to node %s: %v, retrying in %v", l.addr, err, delay)
+
+		select {
+		case <-time.After(delay):
+		case <-l.done:
+			return nil, net.ErrClosed
+		}
+		delay = min(delay*2, maxRedialDelay)
+	}
+}
+
+func (l *listener) dial() (net.Conn, error) {
+	conn, err := net.DialTimeout("tcp", l.addr, handshakeTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if err := handshake(conn, l.hello); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// release 连接关闭后允许重新拨号
+func (l *listener) release() {
+	logrus.Infof("Tunnel to node %s closed", l.addr)
+	select {
+	case l.idle <- struct{}{}:
+	default:
+	}
+}
+
+func (l *listener) Close() error {
+	l.once.Do(func() { close(l.done) })
+	return nil
+}
+
+func (l *listener) Addr() net.Addr {
+	return tunnelAddr(l.addr)
+}
+
+// tunnelAddr 反向 listener 的地址，即节点的 tunnel 地址
+type tunnelAddr string
+
+func (a tunnelAddr) Network() string { return "tunnel" }
+func (a tunnelAddr) String() string  { return string(a) }
+
+// trackedConn 关闭时通知 listener
+type trackedConn struct {
+	net.Conn
+	once    sync.Once
+	onClose func()
+}
+
+func (c *trackedConn) Close() error {
+	err := c.Conn.Close()
+	c.once.Do(c.onClose)
+	return err
+}
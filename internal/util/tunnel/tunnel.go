@@ -0,0 +1,108 @@
+// Package tunnel 实现 provider 的反向连接模式
+// 位于 NAT 后的 provider 主动连接 iarnet 节点的 tunnel 端口，握手后角色反转：
+// provider 在这条连接上作为 gRPC 服务端，节点作为 gRPC 客户端调用 provider，所有 RPC 由 HTTP/2 在同一连接上复用
+package tunnel
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+// handshakeTimeout 握手超时
+const handshakeTimeout = 10 * time.Second
+
+// maxFrameSize 握手帧的最大长度
+const maxFrameSize = 64 * 1024
+
+// Hello provider 连接后发送的握手帧
+type Hello struct {
+	Name  string `json:"name"`  // 与节点 static_providers 中的 name 一致
+	Token string `json:"token"` // 与节点 static_providers 中的 token 一致
+}
+
+// helloReply 节点对握手的应答
+type helloReply struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// KeepaliveInterval 节点在空闲隧道上发送 HTTP/2 ping 的间隔，用于及时发现被 NAT 丢弃的连接
+const KeepaliveInterval = 30 * time.Second
+
+// ServerOptions provider 在隧道上提供 gRPC 服务时需要的选项：允许节点在空闲连接上发送 keepalive ping
+func ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             KeepaliveInterval / 2,
+			PermitWithoutStream: true,
+		}),
+	}
+}
+
+// DialOptions 节点通过隧道调用 provider 时使用的选项
+func DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                KeepaliveInterval,
+			Timeout:             10 * time.Second,
+			PermitWithoutStream: true,
+		}),
+	}
+}
+
+// writeFrame 写入 4 字节大端长度前缀的 JSON 帧
+// 握手使用定长帧而非按行读取，避免缓冲读取吞掉随后的 HTTP/2 数据
+func writeFrame(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	header := make([]byte, 4)
+	binary.BigEndian.PutUint32(header, uint32(len(data)))
+	if _, err := w.Write(append(header, data...)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// readFrame 读取一个长度前缀的 JSON 帧
+func readFrame(r io.Reader, v any) error {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return err
+	}
+	size := binary.BigEndian.Uint32(header)
+	if size > maxFrameSize {
+		return fmt.Errorf("handshake frame too large: %d bytes", size)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// handshake provider 侧握手：发送 Hello 并等待节点应答
+func handshake(conn net.Conn, hello Hello) error {
+	conn.SetDeadline(time.Now().Add(handshakeTimeout))
+	defer conn.SetDeadline(time.Time{})
+
+	if err := writeFrame(conn, hello); err != nil {
+		return fmt.Errorf("failed to send hello: %w", err)
+	}
+	var reply helloReply
+	if err := readFrame(conn, &reply); err != nil {
+		return fmt.Errorf("failed to read hello reply: %w", err)
+	}
+	if !reply.OK {
+		return fmt.Errorf("rejected by node: %s", reply.Error)
+	}
+	return nil
+}
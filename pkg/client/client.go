@@ -0,0 +1,204 @@
+// Package client 是 iarnet 节点管理 API 的 Go 客户端
+// 封装部署、component、日志、provider 与资源利用率等接口，处理 RBAC 令牌、context 取消与失败重试，
+// 供在自有控制面中嵌入 iarnet 的调用方使用，避免各自拼装请求与解析统一响应结构
+//
+// 用法:
+//
+//	c, err := client.New("http://node:8083", client.WithToken(os.Getenv("IARNET_TOKEN")))
+//	comp, err := c.DeployComponent(ctx, &client.DeployRequest{CPU: 500, Memory: 256 << 20})
+//	completion, err := c.WaitComponent(ctx, comp.ID, time.Minute)
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RetryPolicy 失败重试策略
+// 只有幂等请求（GET、DELETE）在连接失败或节点返回 502/503/504 时重试；
+// 部署等非幂等请求不重试，避免在节点已处理但响应丢失时重复部署
+type RetryPolicy struct {
+	MaxAttempts int           // 最多尝试次数（含首次），不大于 1 时不重试
+	Backoff     time.Duration // 首次重试前的等待，之后每次翻倍
+	MaxBackoff  time.Duration // 重试等待的上限
+}
+
+// DefaultRetryPolicy 默认重试策略
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 3, Backoff: 200 * time.Millisecond, MaxBackoff: 2 * time.Second}
+
+// APIError 节点返回的错误响应
+type APIError struct {
+	StatusCode int
+	Status     string
+	Message    string // 统一响应结构中的 error
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return "HTTP " + e.Status
+	}
+	return fmt.Sprintf("HTTP %s: %s", e.Status, e.Message)
+}
+
+// IsNotFound 判断错误是否为资源不存在
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// Client iarnet 节点管理 API 客户端，并发安全
+type Client struct {
+	server     string
+	token      string
+	httpClient *http.Client
+	retry      RetryPolicy
+}
+
+// Option 客户端选项
+type Option func(*Client)
+
+// WithToken 设置 RBAC 访问令牌，以 Bearer 方式随每个请求发送
+func WithToken(token string) Option {
+	return func(c *Client) { c.token = token }
+}
+
+// WithHTTPClient 使用自定义的 http.Client（如配置 TLS 或代理），默认为 http.DefaultClient
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// WithRetry 设置失败重试策略，默认为 DefaultRetryPolicy
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Client) { c.retry = policy }
+}
+
+// New 创建访问 server（如 http://node:8083）的客户端
+func New(server string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(server)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server address %q: %w", server, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid server address %q: expected http(s)://host:port", server)
+	}
+	c := &Client{
+		server:     strings.TrimSuffix(server, "/"),
+		httpClient: http.DefaultClient,
+		retry:      DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// Server 客户端访问的节点管理 API 地址
+func (c *Client) Server() string {
+	return c.server
+}
+
+// Header 携带访问令牌的请求头，供调用方自行建立 WebSocket 等连接时使用
+func (c *Client) Header() http.Header {
+	header := http.Header{}
+	if c.token != "" {
+		header.Set("Authorization", "Bearer "+c.token)
+	}
+	return header
+}
+
+// do 调用管理 API 并将统一响应结构中的 data 解析到 out（为 nil 时忽略）
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, in, out any) error {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+	target := c.server + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	attempts := 1
+	if method == http.MethodGet || method == http.MethodDelete {
+		attempts = max(c.retry.MaxAttempts, 1)
+	}
+	backoff := c.retry.Backoff
+	for attempt := 1; ; attempt++ {
+		err := c.send(ctx, method, target, body, out)
+		if err == nil || attempt >= attempts || !retryable(err) || ctx.Err() != nil {
+			return err
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return err
+		}
+		backoff = min(backoff*2, max(c.retry.MaxBackoff, c.retry.Backoff))
+	}
+}
+
+// send 发送一次请求
+func (c *Client) send(ctx context.Context, method, target string, body []byte, out any) error {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return err
+	}
+	req.Header = c.Header()
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Data  json.RawMessage `json:"data"`
+		Error string          `json:"error"`
+	}
+	decodeErr := json.NewDecoder(resp.Body).Decode(&result)
+	if resp.StatusCode >= 300 {
+		return &APIError{StatusCode: resp.StatusCode, Status: resp.Status, Message: result.Error}
+	}
+	if decodeErr != nil {
+		return fmt.Errorf("invalid response (HTTP %s): %w", resp.Status, decodeErr)
+	}
+	if out != nil && len(result.Data) > 0 {
+		if err := json.Unmarshal(result.Data, out); err != nil {
+			return fmt.Errorf("invalid response data: %w", err)
+		}
+	}
+	return nil
+}
+
+// retryable 判断失败是否可以重试：连接失败或节点暂时不可用
+func retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 节点允许的单次等待时长上限（秒）
+const maxWaitSeconds = 600
+
+// DeployComponent 部署 component，本节点没有合适的 provider 时由节点委托给同域其他节点
+// 部署返回只表示实例已创建，需要实例结束时间时使用 WaitComponent
+func (c *Client) DeployComponent(ctx context.Context, req *DeployRequest) (*Component, error) {
+	comp := &Component{}
+	if err := c.do(ctx, http.MethodPost, "/resource/components", nil, req, comp); err != nil {
+		return nil, err
+	}
+	return comp, nil
+}
+
+// GetComponent 获取本节点管理的 component，不存在时返回的错误满足 IsNotFound
+func (c *Client) GetComponent(ctx context.Context, componentID string) (*Component, error) {
+	comp := &Component{}
+	if err := c.do(ctx, http.MethodGet, componentPath(componentID), nil, nil, comp); err != nil {
+		return nil, err
+	}
+	return comp, nil
+}
+
+// ListComponents 列出本节点管理的 component
+func (c *Client) ListComponents(ctx context.Context) ([]Component, error) {
+	var resp struct {
+		Components []Component `json:"components"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/resource/components", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Components, nil
+}
+
+// ListClusterComponents 按条件列出整个域的 component，由节点向同域其他节点汇总
+// 个别节点查询失败时不返回错误，失败的节点记录在结果的 Nodes 中
+func (c *Client) ListClusterComponents(ctx context.Context, query ComponentQuery) (*ClusterComponents, error) {
+	params := url.Values{}
+	setParam(params, "app", query.AppID)
+	setParam(params, "provider_type", query.ProviderType)
+	setParam(params, "state", query.State)
+	setParam(params, "origin", query.Origin)
+	for key, value := range query.Labels {
+		params.Add("label", key+"="+value)
+	}
+	result := &ClusterComponents{}
+	if err := c.do(ctx, http.MethodGet, "/resource/components/cluster", params, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UndeployComponent 删除 component
+func (c *Client) UndeployComponent(ctx context.Context, componentID string) error {
+	return c.do(ctx, http.MethodDelete, componentPath(componentID), nil, nil, nil)
+}
+
+// WaitComponent 等待 component 实例结束，最多等待 timeout（按秒向上取整，不超过节点允许的 600 秒）
+// 超时时返回 Finished 为 false 的结果；需要更长的等待时由调用方循环调用
+func (c *Client) WaitComponent(ctx context.Context, componentID string, timeout time.Duration) (*Completion, error) {
+	seconds := min(max(int(math.Ceil(timeout.Seconds())), 1), maxWaitSeconds)
+	params := url.Values{"timeout_seconds": {strconv.Itoa(seconds)}}
+	completion := &Completion{}
+	if err := c.do(ctx, http.MethodGet, componentPath(componentID)+"/wait", params, nil, completion); err != nil {
+		return nil, err
+	}
+	return completion, nil
+}
+
+// GetComponentLogs 查询 component 日志
+func (c *Client) GetComponentLogs(ctx context.Context, componentID string, query LogQuery) (*ComponentLogs, error) {
+	params := url.Values{}
+	if query.Limit > 0 {
+		params.Set("limit", strconv.Itoa(query.Limit))
+	}
+	if query.Offset > 0 {
+		params.Set("offset", strconv.Itoa(query.Offset))
+	}
+	setParam(params, "level", query.Level)
+	if !query.Start.IsZero() {
+		params.Set("start_time", query.Start.Format(time.RFC3339))
+	}
+	if !query.End.IsZero() {
+		params.Set("end_time", query.End.Format(time.RFC3339))
+	}
+	logs := &ComponentLogs{}
+	if err := c.do(ctx, http.MethodGet, componentPath(componentID)+"/logs", params, nil, logs); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// GetNodeUtilization 获取节点聚合后的资源利用率及各 provider 明细
+func (c *Client) GetNodeUtilization(ctx context.Context) (*NodeUtilization, error) {
+	utilization := &NodeUtilization{}
+	if err := c.do(ctx, http.MethodGet, "/resource/node/utilization", nil, nil, utilization); err != nil {
+		return nil, err
+	}
+	return utilization, nil
+}
+
+// ListProviders 列出本节点注册的 provider
+func (c *Client) ListProviders(ctx context.Context) ([]Provider, error) {
+	var resp struct {
+		Providers []Provider `json:"providers"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/resource/provider", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Providers, nil
+}
+
+// PortForwardURL component 端口转发的 WebSocket 地址，连接时需携带 Header()
+// 每条 WebSocket 连接转发一条 TCP 连接，二进制消息为数据，文本消息 close_write 表示本端结束发送
+func (c *Client) PortForwardURL(componentID string, port string) (string, error) {
+	u, err := url.Parse(c.server)
+	if err != nil {
+		return "", err
+	}
+	switch u.Scheme {
+	case "https":
+		u.Scheme = "wss"
+	default:
+		u.Scheme = "ws"
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + componentPath(componentID) + "/port-forward"
+	u.RawQuery = url.Values{"port": {port}}.Encode()
+	return u.String(), nil
+}
+
+func componentPath(componentID string) string {
+	return "/resource/components/" + url.PathEscape(componentID)
+}
+
+func setParam(params url.Values, key, value string) {
+	if value != "" {
+		params.Set(key, value)
+	}
+}
@@ -0,0 +1,177 @@
+package client
+
+import "time"
+
+// Resources 资源数量
+type Resources struct {
+	CPU    int64 `json:"cpu"`    // millicores
+	Memory int64 `json:"memory"` // bytes
+	GPU    int64 `json:"gpu"`
+}
+
+// Component 本节点管理的 component
+type Component struct {
+	ID         string            `json:"id"`
+	ProviderID string            `json:"provider_id"` // 尚未放置或位于其他节点时为空
+	Image      string            `json:"image"`
+	AppID      string            `json:"app_id,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Resources  Resources         `json:"resources"`           // 部署时请求的资源
+	Evictable  bool              `json:"evictable,omitempty"` // 部署在 best-effort provider 上，可能被驱逐
+	Sidecars   []string          `json:"sidecars,omitempty"`  // sidecar 名称，资源已计入 Resources
+}
+
+// DeployRequest 部署 component 的请求，放置规则与节点间委托部署相同
+type DeployRequest struct {
+	RuntimeEnv     string            `json:"runtime_env,omitempty"` // 缺省为 python
+	CPU            int64             `json:"cpu"`                   // millicores
+	Memory         int64             `json:"memory"`                // bytes
+	GPU            int64             `json:"gpu"`
+	Tags           []string          `json:"tags,omitempty"`
+	NodeSelector   map[string]string `json:"node_selector,omitempty"`
+	Stream         string            `json:"stream,omitempty"` // 绑定的数据流，只能部署到拥有该数据流的 provider
+	Labels         map[string]string `json:"labels,omitempty"`
+	TimeoutSeconds int               `json:"timeout_seconds,omitempty"` // 部署超时，0 表示不限制
+	Sidecars       []Sidecar         `json:"sidecars,omitempty"`
+}
+
+// Sidecar 与主容器部署到同一 provider 的 sidecar，资源与主容器合并核算
+type Sidecar struct {
+	Name    string            `json:"name"`
+	Image   string            `json:"image"`
+	CPU     int64             `json:"cpu"`    // millicores
+	Memory  int64             `json:"memory"` // bytes
+	GPU     int64             `json:"gpu"`
+	Env     map[string]string `json:"env,omitempty"`
+	Command []string          `json:"command,omitempty"`
+}
+
+// Completion component 实例结束等待结果，Finished 为 false 表示等待超时、实例仍在运行
+type Completion struct {
+	ComponentID string     `json:"component_id"`
+	Finished    bool       `json:"finished"`
+	State       string     `json:"state,omitempty"` // exited，或实例在结束前被删除时为 not_found
+	ExitCode    int32      `json:"exit_code"`
+	Reason      string     `json:"reason,omitempty"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+}
+
+// ComponentQuery 查询整个域 component 的条件，各条件为空时不过滤
+type ComponentQuery struct {
+	AppID        string
+	Labels       map[string]string // 必须完全匹配的标签
+	ProviderType string
+	State        string // deploying / running / unreachable
+	Origin       string // 委托方节点 ID，只返回由该节点委托部署的 component
+}
+
+// ClusterComponents 整个域满足条件的 component
+type ClusterComponents struct {
+	Components []ClusterComponent `json:"components"`
+	Total      int                `json:"total"`
+	Nodes      []NodeResult       `json:"nodes"` // 各节点的查询结果，查询失败的节点带有 Error
+}
+
+// ClusterComponent 域内某个节点上的 component
+type ClusterComponent struct {
+	ID           string            `json:"id"`
+	NodeID       string            `json:"node_id"`
+	NodeName     string            `json:"node_name"`
+	ProviderID   string            `json:"provider_id"`
+	ProviderType string            `json:"provider_type,omitempty"`
+	State        string            `json:"state"`
+	Image        string            `json:"image"`
+	AppID        string            `json:"app_id,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty"`
+	Resources    Resources         `json:"resources"`
+	Evictable    bool              `json:"evictable,omitempty"`
+	Origin       string            `json:"origin,omitempty"` // 委托方节点 ID，节点自身发起的部署为空
+}
+
+// NodeResult 单个节点的查询结果
+type NodeResult struct {
+	NodeID     string `json:"node_id"`
+	NodeName   string `json:"node_name"`
+	Components int    `json:"components"`
+	Error      string `json:"error,omitempty"`
+}
+
+// LogQuery 查询 component 日志的条件
+type LogQuery struct {
+	Limit  int // 0 表示节点默认值（100）
+	Offset int
+	Level  string // 最低日志级别，为空表示全部
+	Start  time.Time
+	End    time.Time
+}
+
+// ComponentLogs component 日志
+type ComponentLogs struct {
+	ComponentID string     `json:"component_id"`
+	Logs        []LogEntry `json:"logs"`
+	Total       int        `json:"total"`
+	HasMore     bool       `json:"has_more"`
+}
+
+// LogEntry 一条 component 日志
+type LogEntry struct {
+	Timestamp time.Time  `json:"timestamp"`
+	Level     string     `json:"level"`
+	Message   string     `json:"message"`
+	Fields    []LogField `json:"fields,omitempty"`
+	Caller    *LogCaller `json:"caller,omitempty"`
+}
+
+// LogField 日志字段
+type LogField struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// LogCaller 日志调用位置
+type LogCaller struct {
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Function string `json:"function,omitempty"`
+}
+
+// NodeUtilization 节点资源利用率
+type NodeUtilization struct {
+	NodeID    string                `json:"node_id"`
+	NodeName  string                `json:"node_name"`
+	Total     Resources             `json:"total"`     // 已连接 provider 的总资源
+	Used      Resources             `json:"used"`      // 已分配资源
+	Available Resources             `json:"available"` // 可用资源
+	Providers []ProviderUtilization `json:"providers"`
+}
+
+// ProviderUtilization 单个 provider 的资源利用率
+type ProviderUtilization struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Type       string    `json:"type"`
+	Status     string    `json:"status"`
+	Components int       `json:"components"`
+	Capacity   *Capacity `json:"capacity,omitempty"` // 未连接或获取失败时为 nil
+	Error      string    `json:"error,omitempty"`
+}
+
+// Capacity 资源容量
+type Capacity struct {
+	Total     Resources `json:"total"`
+	Used      Resources `json:"used"`
+	Available Resources `json:"available"`
+}
+
+// Provider 本节点注册的 provider
+type Provider struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Type           string    `json:"type"`
+	Host           string    `json:"host"`
+	Port           int       `json:"port"`
+	Status         string    `json:"status"`         // connected / disconnected
+	CapacityClass  string    `json:"capacity_class"` // guaranteed / best-effort
+	Static         bool      `json:"static"`         // 由节点配置文件管理，只读
+	LastUpdateTime time.Time `json:"last_update_time"`
+}
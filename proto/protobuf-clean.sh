@@ -52,6 +52,18 @@ else
   echo "  ⚠ Python component proto directory not found: $PY_COMPONENT_PROTO_DIR"
 fi
 
+# Python generated files - SDK
+PY_SDK_PROTO_DIR="$PROJECT_ROOT/sdk/python/iarnet/proto"
+if [ -d "$PY_SDK_PROTO_DIR" ]; then
+  echo ">>> Cleaning Python generated files in $PY_SDK_PROTO_DIR..."
+  find "$PY_SDK_PROTO_DIR" -type f -name "*_pb2.py" -delete
+  find "$PY_SDK_PROTO_DIR" -type f -name "*_pb2.pyi" -delete
+  find "$PY_SDK_PROTO_DIR" -type f -name "*_pb2_grpc.py" -delete
+  echo "  ✓ Python files (SDK) cleaned"
+else
+  echo "  ⚠ Python SDK proto directory not found: $PY_SDK_PROTO_DIR"
+fi
+
 echo ""
 echo "=========================================="
 echo "Protobuf files cleaned!"
@@ -40,7 +40,7 @@ PROTOC_CMD="$PROTOC -I $BASE_DIR"
 PROTO_SRC="common/*.proto"
 
 GO_OUTPUT="$PROJECT_ROOT/internal/proto/"
-PY_OUTPUTS=("$PROJECT_ROOT/containers/envs/python/libs/lucas/lucas/actorc/protos/" "$PROJECT_ROOT/containers/component/python/proto/")
+PY_OUTPUTS=("$PROJECT_ROOT/containers/envs/python/libs/lucas/lucas/actorc/protos/" "$PROJECT_ROOT/containers/component/python/proto/" "$PROJECT_ROOT/sdk/python/iarnet/proto/")
 RUNNER_COMMON_OUTPUT="$PROJECT_ROOT/containers/images/runner/proto"
 
 # Go generation
@@ -91,6 +91,7 @@ GO_OUTPUT="$PROJECT_ROOT/internal/proto/ignis"
 PY_OUTPUT_COMPONENT="$PROJECT_ROOT/containers/component/python/proto/ignis"
 PY_OUTPUT_LUCAS_COMMON="$PROJECT_ROOT/containers/envs/python/libs/lucas/lucas/actorc/protos/common"
 PY_OUTPUT_LUCAS_CONTROLLER="$PROJECT_ROOT/containers/envs/python/libs/lucas/lucas/actorc/protos/controller"
+PY_OUTPUT_SDK_CONTROLLER="$PROJECT_ROOT/sdk/python/iarnet/proto/controller"
 
 # Go generation
 echo "  Generating Go files: $GO_OUTPUT"
@@ -141,6 +142,19 @@ PROTOC_CMD_LUCAS="$PROTOC -I . -I $BASE_DIR"
 $PROTOC_CMD_LUCAS --python_out="$PY_OUTPUT_LUCAS_CONTROLLER" --pyi_out="$PY_OUTPUT_LUCAS_CONTROLLER" --grpc_python_out="$PY_OUTPUT_LUCAS_CONTROLLER" *.proto
 cd ..
 
+# Python generation for the SDK (only controller, common is already generated)
+echo "  Generating Python files for SDK: $PY_OUTPUT_SDK_CONTROLLER"
+if [ ! -d "$PY_OUTPUT_SDK_CONTROLLER" ]; then
+  mkdir -p "$PY_OUTPUT_SDK_CONTROLLER"
+else
+  find "$PY_OUTPUT_SDK_CONTROLLER" -type f -name "*_pb2.py" -delete
+  find "$PY_OUTPUT_SDK_CONTROLLER" -type f -name "*_pb2.pyi" -delete
+  find "$PY_OUTPUT_SDK_CONTROLLER" -type f -name "*_pb2_grpc.py" -delete
+fi
+cd controller
+$PROTOC_CMD_LUCAS --python_out="$PY_OUTPUT_SDK_CONTROLLER" --pyi_out="$PY_OUTPUT_SDK_CONTROLLER" --grpc_python_out="$PY_OUTPUT_SDK_CONTROLLER" *.proto
+cd ..
+
 # ============================================================================
 # 3. Generate resource
 # ============================================================================
@@ -0,0 +1,434 @@
+// Package conformance 校验 provider 实现是否符合 iarnet 节点对 providerpb.ServiceServer 的约定
+//
+// 新 provider 在自己的测试中调用 Run 即可，被测实现通过进程内 gRPC 连接访问，请求与响应经过与节点调用相同的序列化：
+//
+//	func TestConformance(t *testing.T) {
+//		svc, err := provider.NewService(...)
+//		require.NoError(t, err)
+//		conformance.Run(t, conformance.Config{Server: svc, Image: "busybox:latest"})
+//	}
+//
+// 检查项：连接与鉴权、容量与健康检查、部署与删除的生命周期、并发部署下的容量记账，
+// 以及配置了 ZMQAdvertiseHost 与 component 镜像时 component 能否与 ZMQ_ADDR 建立会话
+package conformance
+
+import (
+	"context"
+	"net"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/9triver/iarnet/internal/proto/common"
+	resourcepb "github.com/9triver/iarnet/internal/proto/resource"
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+	"github.com/9triver/iarnet/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Config 被测 provider 与检查参数
+type Config struct {
+	// Server 被测实现，需处于未连接状态且已配置总容量；各检查项依次使用同一实例
+	Server providerpb.ServiceServer
+	// Token 被测实现配置的注册令牌，为空表示未配置令牌
+	Token string
+	// Image 部署检查使用的镜像，需已在 provider 可访问的位置
+	Image string
+	// Request 单个部署的资源请求，默认 100 millicores、64 MiB
+	Request *resourcepb.Info
+	// ConcurrentDeploys 并发部署检查同时发起的部署数，默认 8
+	ConcurrentDeploys int
+	// Timeout 单个 RPC 的超时，默认 60s；镜像需要拉取时应适当调大
+	Timeout time.Duration
+
+	// ZMQAdvertiseHost component 访问本测试进程的地址（e.g., 宿主机 IP）；为空时跳过 ZMQ 会话检查
+	ZMQAdvertiseHost string
+	// ComponentImage ZMQ 会话检查部署的 component 运行时镜像，启动后需连接 ZMQ_ADDR；为空时跳过 ZMQ 会话检查
+	ComponentImage string
+	// ZMQTimeout 等待 component 连接 ZMQ_ADDR 的超时，默认 2min
+	ZMQTimeout time.Duration
+}
+
+func (c *Config) setDefaults() {
+	if c.Request == nil {
+		c.Request = &resourcepb.Info{Cpu: 100, Memory: 64 << 20}
+	}
+	if c.ConcurrentDeploys <= 0 {
+		c.ConcurrentDeploys = 8
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = 60 * time.Second
+	}
+	if c.ZMQTimeout <= 0 {
+		c.ZMQTimeout = 2 * time.Minute
+	}
+}
+
+// suite 一次 Run 的共享状态
+type suite struct {
+	cfg          Config
+	client       providerpb.ServiceClient
+	providerID   string
+	capabilities []string
+}
+
+// Run 依次执行全部检查项，前一项失败不影响后续检查项的执行
+// 连接检查失败时后续检查项无法进行，直接结束
+func Run(t *testing.T, cfg Config) {
+	t.Helper()
+	require.NotNil(t, cfg.Server, "conformance: Config.Server is required")
+	require.NotEmpty(t, cfg.Image, "conformance: Config.Image is required")
+	cfg.setDefaults()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	providerpb.RegisterServiceServer(srv, cfg.Server)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///conformance",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	s := &suite{
+		cfg:        cfg,
+		client:     providerpb.NewServiceClient(conn),
+		providerID: util.GenIDWith("provider.conformance."),
+	}
+	if !t.Run("Connect", s.testConnect) {
+		return
+	}
+	t.Run("Auth", s.testAuth)
+	t.Run("Capacity", s.testCapacity)
+	t.Run("HealthCheck", s.testHealthCheck)
+	t.Run("Lifecycle", s.testLifecycle)
+	t.Run("ConcurrentDeploys", s.testConcurrentDeploys)
+	t.Run("ZMQSession", s.testZMQSession)
+	t.Run("Disconnect", s.testDisconnect)
+}
+
+func (s *suite) ctx(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), s.cfg.Timeout)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func (s *suite) supports(capability string) bool {
+	return slices.Contains(s.capabilities, capability)
+}
+
+func (s *suite) connectRequest(id string) *providerpb.ConnectRequest {
+	return &providerpb.ConnectRequest{
+		ProviderId: id,
+		Protocol:   common.NewProtocolInfo(common.ProviderCapabilities...),
+		Token:      s.cfg.Token,
+	}
+}
+
+// testConnect 连接握手：拒绝缺少 ID 或令牌错误的请求，成功后声明的能力均为节点已知的能力，
+// 上报的容量（可选）与 GetCapacity 一致，同一 ID 重复连接幂等，已连接时拒绝其他 ID
+func (s *suite) testConnect(t *testing.T) {
+	ctx := s.ctx(t)
+
+	resp, err := s.client.Connect(ctx, s.connectRequest(""))
+	require.NoError(t, err)
+	assert.False(t, resp.Success, "connect without provider ID must fail")
+	assert.NotEmpty(t, resp.Error)
+
+	if s.cfg.Token != "" {
+		req := s.connectRequest(s.providerID)
+		req.Token = s.cfg.Token + "-wrong"
+		resp, err := s.client.Connect(ctx, req)
+		require.NoError(t, err)
+		assert.False(t, resp.Success, "connect with a wrong token must fail")
+	}
+
+	resp, err = s.client.Connect(ctx, s.connectRequest(s.providerID))
+	require.NoError(t, err)
+	require.True(t, resp.Success, "connect failed: %s", resp.Error)
+	require.NotNil(t, resp.Protocol, "provider must report its protocol")
+	_, err = common.Negotiate(common.NewProtocolInfo(common.ProviderCapabilities...), resp.Protocol)
+	require.NoError(t, err, "provider protocol must be compatible with this node")
+	for _, capability := range resp.Protocol.Capabilities {
+		assert.Contains(t, common.ProviderCapabilities, capability, "unknown capability")
+	}
+	s.capabilities = resp.Protocol.Capabilities
+	if resp.Capacity != nil {
+		assertCapacityConsistent(t, resp.Capacity)
+		total := s.capacity(t).Total
+		assert.Equal(t, total.Cpu, resp.Capacity.Total.Cpu, "total CPU reported on connect must match GetCapacity")
+		assert.Equal(t, total.Memory, resp.Capacity.Total.Memory, "total memory reported on connect must match GetCapacity")
+		assert.Equal(t, total.Gpu, resp.Capacity.Total.Gpu, "total GPU reported on connect must match GetCapacity")
+	}
+
+	resp, err = s.client.Connect(ctx, s.connectRequest(s.providerID))
+	require.NoError(t, err)
+	assert.True(t, resp.Success, "reconnecting with the same provider ID must succeed: %s", resp.Error)
+
+	resp, err = s.client.Connect(ctx, s.connectRequest(s.providerID+".other"))
+	require.NoError(t, err)
+	assert.False(t, resp.Success, "connect with another provider ID must fail while connected")
+}
+
+// testAuth 已连接后，携带其他 provider_id 的请求必须被拒绝
+func (s *suite) testAuth(t *testing.T) {
+	ctx := s.ctx(t)
+	other := s.providerID + ".other"
+
+	_, err := s.client.HealthCheck(ctx, &providerpb.HealthCheckRequest{ProviderId: other})
+	assert.Error(t, err, "health check with another provider ID must fail")
+
+	deploy, err := s.client.Deploy(ctx, s.deployRequest(other, util.GenIDWith("comp.conformance.")))
+	assert.True(t, err != nil || deploy.Error != "", "deploy with another provider ID must fail")
+
+	if s.supports(common.CapUndeploy) {
+		undeploy, err := s.client.Undeploy(ctx, &providerpb.UndeployRequest{ProviderId: other, InstanceId: "comp.conformance.none"})
+		assert.True(t, err != nil || undeploy.Error != "", "undeploy with another provider ID must fail")
+	}
+}
+
+// testCapacity 容量一致性：可用 = 总量 - 已用，GetAvailable 与 GetCapacity 一致
+func (s *suite) testCapacity(t *testing.T) {
+	capacity := s.capacity(t)
+	assert.Positive(t, capacity.Total.Cpu, "total CPU")
+	assert.Positive(t, capacity.Total.Memory, "total memory")
+	assertCapacityConsistent(t, capacity)
+
+	available, err := s.client.GetAvailable(s.ctx(t), &providerpb.GetAvailableRequest{ProviderId: s.providerID})
+	require.NoError(t, err)
+	assert.Equal(t, capacity.Available.Cpu, available.Available.Cpu)
+	assert.Equal(t, capacity.Available.Memory, available.Available.Memory)
+	assert.Equal(t, capacity.Available.Gpu, available.Available.Gpu)
+}
+
+// testHealthCheck 健康检查上报的容量与 GetCapacity 一致，上报的 GPU 设备 ID 互不相同且被占用的不超过已用 GPU 数量
+func (s *suite) testHealthCheck(t *testing.T) {
+	resp, err := s.client.HealthCheck(s.ctx(t), &providerpb.HealthCheckRequest{ProviderId: s.providerID})
+	require.NoError(t, err)
+	require.NotNil(t, resp.Capacity)
+	assertCapacityConsistent(t, resp.Capacity)
+
+	capacity := s.capacity(t)
+	assert.Equal(t, capacity.Total.Cpu, resp.Capacity.Total.Cpu)
+	assert.Equal(t, capacity.Total.Memory, resp.Capacity.Total.Memory)
+	assert.Equal(t, capacity.Used.Cpu, resp.Capacity.Used.Cpu)
+	assert.Equal(t, capacity.Used.Memory, resp.Capacity.Used.Memory)
+
+	seen := make(map[string]bool)
+	var owned int64
+	for _, gpu := range resp.Gpus {
+		assert.False(t, seen[gpu.Id], "GPU %s reported twice", gpu.Id)
+		seen[gpu.Id] = true
+		if gpu.InstanceId != "" {
+			owned++
+		}
+	}
+	assert.LessOrEqual(t, owned, resp.Capacity.Used.Gpu, "GPUs owned by instances")
+}
+
+// testLifecycle 部署后已用容量增加请求的资源，重复的实例 ID 被拒绝，删除后容量归还、实例不再存在
+func (s *suite) testLifecycle(t *testing.T) {
+	ctx := s.ctx(t)
+	before := s.capacity(t).Used
+
+	id := util.GenIDWith("comp.conformance.")
+	resp, err := s.client.Deploy(ctx, s.deployRequest(s.providerID, id))
+	require.NoError(t, err)
+	require.Empty(t, resp.Error, "deploy failed")
+	s.cleanup(t, id)
+
+	after := s.capacity(t).Used
+	assert.Equal(t, before.Cpu+s.cfg.Request.Cpu, after.Cpu, "used CPU after deploy")
+	assert.Equal(t, before.Memory+s.cfg.Request.Memory, after.Memory, "used memory after deploy")
+
+	if s.supports(common.CapInstanceStatus) {
+		status, err := s.client.GetInstanceStatus(ctx, &providerpb.GetInstanceStatusRequest{ProviderId: s.providerID, InstanceId: id})
+		require.NoError(t, err)
+		assert.Empty(t, status.Error)
+		assert.Contains(t, []string{"pending", "running", "exited"}, status.State, "state of a deployed instance")
+	}
+
+	dup, err := s.client.Deploy(ctx, s.deployRequest(s.providerID, id))
+	require.NoError(t, err)
+	assert.NotEmpty(t, dup.Error, "deploying an existing instance ID must fail")
+	dupUsed := s.capacity(t).Used
+	assert.Equal(t, after.Cpu, dupUsed.Cpu, "a failed deploy must not keep its reservation")
+	assert.Equal(t, after.Memory, dupUsed.Memory, "a failed deploy must not keep its reservation")
+
+	if !s.supports(common.CapUndeploy) {
+		t.Log("provider does not declare undeploy, skipping undeploy checks")
+		return
+	}
+	undeploy, err := s.client.Undeploy(ctx, &providerpb.UndeployRequest{ProviderId: s.providerID, InstanceId: id})
+	require.NoError(t, err)
+	require.Empty(t, undeploy.Error, "undeploy failed")
+
+	released := s.capacity(t).Used
+	assert.Equal(t, before.Cpu, released.Cpu, "used CPU after undeploy")
+	assert.Equal(t, before.Memory, released.Memory, "used memory after undeploy")
+
+	again, err := s.client.Undeploy(ctx, &providerpb.UndeployRequest{ProviderId: s.providerID, InstanceId: id})
+	require.NoError(t, err)
+	assert.NotEmpty(t, again.Error, "undeploying a removed instance must fail")
+
+	if s.supports(common.CapInstanceStatus) {
+		status, err := s.client.GetInstanceStatus(ctx, &providerpb.GetInstanceStatusRequest{ProviderId: s.providerID, InstanceId: id})
+		require.NoError(t, err)
+		assert.Equal(t, "not_found", status.State, "state of a removed instance")
+	}
+}
+
+// testConcurrentDeploys 并发部署：成功数不超过部署前的可用容量，拒绝的部署标记 no_capacity 或返回错误，
+// 已用容量恰好增加成功部署的资源合计，全部删除后恢复
+func (s *suite) testConcurrentDeploys(t *testing.T) {
+	before := s.capacity(t)
+	n := s.cfg.ConcurrentDeploys
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		deployed  []string
+		rejected  int
+		failed    []string
+		transport []error
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id := util.GenIDWith("comp.conformance.")
+			resp, err := s.client.Deploy(s.ctx(t), s.deployRequest(s.providerID, id))
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err != nil:
+				transport = append(transport, err)
+			case resp.Error == "":
+				deployed = append(deployed, id)
+			case resp.NoCapacity:
+				rejected++
+			default:
+				failed = append(failed, resp.Error)
+			}
+		}()
+	}
+	wg.Wait()
+	for _, id := range deployed {
+		s.cleanup(t, id)
+	}
+	require.Empty(t, transport, "deploy RPC errors")
+	assert.Empty(t, failed, "deploys failed for reasons other than capacity")
+	require.NotEmpty(t, deployed, "no deploy succeeded")
+
+	fit := int64(n)
+	if s.cfg.Request.Cpu > 0 {
+		fit = min(fit, before.Available.Cpu/s.cfg.Request.Cpu)
+	}
+	if s.cfg.Request.Memory > 0 {
+		fit = min(fit, before.Available.Memory/s.cfg.Request.Memory)
+	}
+	assert.LessOrEqual(t, int64(len(deployed)), fit, "more deploys succeeded than the available capacity allows")
+	if int64(len(deployed)) < fit {
+		assert.Zero(t, rejected, "deploys were rejected for capacity although capacity was available")
+	}
+
+	after := s.capacity(t)
+	assertCapacityConsistent(t, after)
+	count := int64(len(deployed))
+	assert.Equal(t, before.Used.Cpu+count*s.cfg.Request.Cpu, after.Used.Cpu, "used CPU after concurrent deploys")
+	assert.Equal(t, before.Used.Memory+count*s.cfg.Request.Memory, after.Used.Memory, "used memory after concurrent deploys")
+
+	if !s.supports(common.CapUndeploy) {
+		return
+	}
+	var undeployWG sync.WaitGroup
+	for _, id := range deployed {
+		undeployWG.Add(1)
+		go func() {
+			defer undeployWG.Done()
+			resp, err := s.client.Undeploy(s.ctx(t), &providerpb.UndeployRequest{ProviderId: s.providerID, InstanceId: id})
+			if assert.NoError(t, err) {
+				assert.Empty(t, resp.Error, "undeploy %s", id)
+			}
+		}()
+	}
+	undeployWG.Wait()
+
+	released := s.capacity(t).Used
+	assert.Equal(t, before.Used.Cpu, released.Cpu, "used CPU after undeploying all")
+	assert.Equal(t, before.Used.Memory, released.Memory, "used memory after undeploying all")
+}
+
+// testDisconnect 断开后其他 ID 可以重新连接，随后恢复原连接供调用方继续使用
+func (s *suite) testDisconnect(t *testing.T) {
+	ctx := s.ctx(t)
+	_, err := s.client.Disconnect(ctx, &providerpb.DisconnectRequest{ProviderId: s.providerID})
+	require.NoError(t, err)
+
+	_, err = s.client.HealthCheck(ctx, &providerpb.HealthCheckRequest{ProviderId: s.providerID})
+	assert.Error(t, err, "health check after disconnect must fail")
+
+	other := s.providerID + ".next"
+	resp, err := s.client.Connect(ctx, s.connectRequest(other))
+	require.NoError(t, err)
+	assert.True(t, resp.Success, "connect after disconnect must succeed: %s", resp.Error)
+	if resp.Success {
+		_, err = s.client.Disconnect(ctx, &providerpb.DisconnectRequest{ProviderId: other})
+		assert.NoError(t, err)
+	}
+}
+
+func (s *suite) deployRequest(providerID, instanceID string) *providerpb.DeployRequest {
+	return &providerpb.DeployRequest{
+		InstanceId: instanceID,
+		Image:      s.cfg.Image,
+		ResourceRequest: &resourcepb.Info{
+			Cpu:    s.cfg.Request.Cpu,
+			Memory: s.cfg.Request.Memory,
+			Gpu:    s.cfg.Request.Gpu,
+		},
+		EnvVars:    map[string]string{"COMPONENT_ID": instanceID},
+		ProviderId: providerID,
+	}
+}
+
+func (s *suite) capacity(t *testing.T) *resourcepb.Capacity {
+	t.Helper()
+	resp, err := s.client.GetCapacity(s.ctx(t), &providerpb.GetCapacityRequest{ProviderId: s.providerID})
+	require.NoError(t, err)
+	require.NotNil(t, resp.Capacity)
+	require.NotNil(t, resp.Capacity.Total)
+	require.NotNil(t, resp.Capacity.Used)
+	require.NotNil(t, resp.Capacity.Available)
+	return resp.Capacity
+}
+
+// cleanup 测试结束时尽力删除实例，实例已删除时忽略错误
+func (s *suite) cleanup(t *testing.T, instanceID string) {
+	if !s.supports(common.CapUndeploy) {
+		t.Logf("provider does not declare undeploy, instance %s must be removed manually", instanceID)
+		return
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), s.cfg.Timeout)
+		defer cancel()
+		s.client.Undeploy(ctx, &providerpb.UndeployRequest{ProviderId: s.providerID, InstanceId: instanceID})
+	})
+}
+
+func assertCapacityConsistent(t *testing.T, c *resourcepb.Capacity) {
+	t.Helper()
+	assert.Equal(t, c.Total.Cpu-c.Used.Cpu, c.Available.Cpu, "available CPU must equal total - used")
+	assert.Equal(t, c.Total.Memory-c.Used.Memory, c.Available.Memory, "available memory must equal total - used")
+	assert.Equal(t, c.Total.Gpu-c.Used.Gpu, c.Available.Gpu, "available GPU must equal total - used")
+	assert.GreaterOrEqual(t, c.Used.Cpu, int64(0), "used CPU")
+	assert.GreaterOrEqual(t, c.Used.Memory, int64(0), "used memory")
+}
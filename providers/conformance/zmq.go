@@ -0,0 +1,63 @@
+package conformance
+
+import (
+	"io"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/9triver/iarnet/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// zmtpGreetingSize ZMTP 3.x 问候的签名部分：0xFF、8 字节填充、0x7F
+const zmtpGreetingSize = 10
+
+// testZMQSession 部署 component 运行时镜像，检查其能通过 provider 注入的 ZMQ_ADDR 连接到节点并开始 ZMTP 握手
+// 只检查会话的建立：provider 正确传递了环境变量，且容器网络能访问节点
+func (s *suite) testZMQSession(t *testing.T) {
+	if s.cfg.ZMQAdvertiseHost == "" || s.cfg.ComponentImage == "" {
+		t.Skip("ZMQAdvertiseHost and ComponentImage are not configured")
+	}
+
+	lis, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	defer lis.Close()
+	port := lis.Addr().(*net.TCPAddr).Port
+
+	greeting := make(chan []byte, 1)
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+		buf := make([]byte, zmtpGreetingSize)
+		if _, err := io.ReadFull(conn, buf); err != nil {
+			greeting <- nil
+			return
+		}
+		greeting <- buf
+	}()
+
+	id := util.GenIDWith("comp.conformance.")
+	req := s.deployRequest(s.providerID, id)
+	req.Image = s.cfg.ComponentImage
+	req.EnvVars["ZMQ_ADDR"] = net.JoinHostPort(s.cfg.ZMQAdvertiseHost, strconv.Itoa(port))
+	resp, err := s.client.Deploy(s.ctx(t), req)
+	require.NoError(t, err)
+	require.Empty(t, resp.Error, "deploy failed")
+	s.cleanup(t, id)
+
+	select {
+	case buf := <-greeting:
+		require.NotNil(t, buf, "component connected but did not send a ZMTP greeting")
+		assert.Equal(t, byte(0xFF), buf[0], "ZMTP signature")
+		assert.Equal(t, byte(0x7F), buf[zmtpGreetingSize-1], "ZMTP signature")
+	case <-time.After(s.cfg.ZMQTimeout):
+		t.Fatalf("component did not connect to ZMQ_ADDR within %v", s.cfg.ZMQTimeout)
+	}
+}
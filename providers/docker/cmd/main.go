@@ -1,16 +1,23 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"net"
 	"os"
 	"os/signal"
+	"slices"
 	"syscall"
+	"time"
 
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+	"github.com/9triver/iarnet/internal/proto/common"
 	resourcepb "github.com/9triver/iarnet/internal/proto/resource"
 	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
 	"github.com/9triver/iarnet/internal/util"
+	"github.com/9triver/iarnet/internal/util/ports"
+	"github.com/9triver/iarnet/internal/util/tunnel"
 	"github.com/9triver/iarnet/providers/docker/config"
 	"github.com/9triver/iarnet/providers/docker/provider"
 	"github.com/sirupsen/logrus"
@@ -60,15 +67,87 @@ func main() {
 	}
 	defer service.Close()
 
-	lis, err := net.Listen("tcp4", fmt.Sprintf(":%d", cfg.Server.Port))
-	if err != nil {
-		logrus.Fatalf("Failed to listen: %v", err)
+	if cfg.Server.Token != "" {
+		service.SetConnectToken(cfg.Server.Token)
+		logrus.Info("Connect token required for iarnet nodes")
 	}
 
-	srv := grpc.NewServer()
-	providerpb.RegisterServiceServer(srv, service)
+	if cfg.Energy.WattsPerCore > 0 || cfg.Energy.BatteryPowered {
+		service.SetEnergyProfile(cfg.Energy.WattsPerCore, cfg.Energy.BatteryPowered)
+		logrus.Infof("Reporting energy profile: %.2f W/core, battery powered: %v", cfg.Energy.WattsPerCore, cfg.Energy.BatteryPowered)
+	}
+
+	if len(cfg.ImageShare.Peers) > 0 || cfg.ImageShare.Token != "" {
+		service.SetImagePeers(cfg.ImageShare.Peers, cfg.ImageShare.Token)
+		logrus.Infof("Image sharing enabled with %d peers", len(cfg.ImageShare.Peers))
+		if cfg.ImageShare.Token == "" {
+			logrus.Warn("Image share token is not set, images will not be exported to peers")
+		}
+	}
+
+	if cfg.Staging.Workspace != "" || cfg.Staging.DownloadTimeoutSeconds > 0 {
+		service.SetStaging(cfg.Staging.Workspace, time.Duration(cfg.Staging.DownloadTimeoutSeconds)*time.Second)
+	}
+
+	if len(cfg.Volumes.AllowedHostPaths) > 0 {
+		service.SetAllowedHostPaths(cfg.Volumes.AllowedHostPaths)
+		logrus.Infof("Host path volumes allowed under %v", cfg.Volumes.AllowedHostPaths)
+	}
+
+	if sec := cfg.Security; sec.Default != nil || sec.SeccompProfilesDir != "" {
+		var defaultContext *common.SecurityContext
+		if d := sec.Default; d != nil {
+			defaultContext = &common.SecurityContext{
+				ReadOnlyRootFS:           d.ReadOnlyRootFS,
+				AllowPrivilegeEscalation: d.AllowPrivilegeEscalation,
+				DropCapabilities:         d.DropCapabilities,
+				AddCapabilities:          d.AddCapabilities,
+				SeccompProfile:           d.SeccompProfile,
+				AppArmorProfile:          d.AppArmorProfile,
+			}
+		}
+		service.SetSecurity(defaultContext, sec.SeccompProfilesDir)
+	}
+
+	if err := configureGPUs(service, cfg); err != nil {
+		logrus.Fatalf("Invalid GPU configuration: %v", err)
+	}
+
+	if err := configureStreams(service, cfg); err != nil {
+		logrus.Fatalf("Invalid stream configuration: %v", err)
+	}
+
+	var (
+		lis  net.Listener
+		opts []grpc.ServerOption
+	)
+	if t := cfg.Server.Tunnel; t.Address != "" {
+		// 反向连接模式：主动连接节点，在隧道上提供 gRPC 服务，断开后自动重连
+		if t.Name == "" {
+			logrus.Fatalf("server.tunnel.name is required when server.tunnel.address is set")
+		}
+		lis = tunnel.Listen(t.Address, tunnel.Hello{Name: t.Name, Token: cfg.Server.Token})
+		opts = tunnel.ServerOptions()
+		logrus.Infof("Docker provider gRPC server serving through tunnel to %s as %s", t.Address, t.Name)
+	} else {
+		// 登记端口租约，与同一主机上的 iarnet 节点和其他 provider 协调端口；未配置端口时自动分配
+		leases := ports.NewRegistry("")
+		defer leases.Close()
+		port, err := leases.ClaimOrAllocate("docker provider", cfg.Server.Port, ports.ProviderPortMin, ports.ProviderPortMax)
+		if err != nil {
+			logrus.Fatalf("Failed to reserve port: %v", err)
+		}
+		cfg.Server.Port = port
 
-	logrus.Infof("Docker provider gRPC server listening on :%d", cfg.Server.Port)
+		lis, err = net.Listen("tcp4", fmt.Sprintf(":%d", cfg.Server.Port))
+		if err != nil {
+			logrus.Fatalf("Failed to listen: %v", err)
+		}
+		logrus.Infof("Docker provider gRPC server listening on :%d", cfg.Server.Port)
+	}
+
+	srv := grpc.NewServer(opts...)
+	providerpb.RegisterServiceServer(srv, service)
 
 	go func() {
 		if err := srv.Serve(lis); err != nil {
@@ -84,3 +163,77 @@ func main() {
 	srv.GracefulStop()
 	logrus.Infof("Shutdown complete")
 }
+
+// configureGPUs 设置 GPU 拓扑：优先使用配置的 gpu_devices，否则在声明了 GPU 时通过 nvidia-smi 探测；
+// 探测失败时 GPU 只按数量记账
+func configureGPUs(service *provider.Service, cfg *config.Config) error {
+	var devices []provider.GPUDevice
+	if len(cfg.Resource.GPUDevices) > 0 {
+		for i, d := range cfg.Resource.GPUDevices {
+			if d.ID == "" {
+				return fmt.Errorf("gpu_devices[%d]: id is required", i)
+			}
+			memory, err := d.ParseMemory()
+			if err != nil {
+				return fmt.Errorf("gpu_devices[%d]: %w", i, err)
+			}
+			// 配置中以 0 表示未互联，上报时为 -1
+			group := int32(d.NVLinkGroup)
+			if group == 0 {
+				group = -1
+			}
+			devices = append(devices, provider.GPUDevice{
+				ID:          d.ID,
+				Index:       int32(i),
+				Name:        d.Name,
+				Memory:      memory,
+				NVLinkGroup: group,
+			})
+		}
+	} else if cfg.Resource.GPU > 0 {
+		detected, err := provider.DetectGPUs(context.Background())
+		if err != nil {
+			logrus.Warnf("GPU topology unavailable, GPUs are accounted by count only: %v", err)
+			return nil
+		}
+		devices = detected
+	}
+	if len(devices) == 0 {
+		return nil
+	}
+	if int64(len(devices)) < cfg.Resource.GPU {
+		logrus.Warnf("Configured %d GPUs but only %d devices are known, at most %d can be allocated",
+			cfg.Resource.GPU, len(devices), len(devices))
+	}
+	service.SetGPUDevices(devices)
+	logrus.Infof("GPU topology: %d devices", len(devices))
+	return nil
+}
+
+// configureStreams 设置本 provider 拥有的数据流，名称须唯一
+func configureStreams(service *provider.Service, cfg *config.Config) error {
+	if len(cfg.Streams) == 0 {
+		return nil
+	}
+	streams := make([]provider.Stream, 0, len(cfg.Streams))
+	names := make(map[string]bool, len(cfg.Streams))
+	for i, st := range cfg.Streams {
+		if st.Name == "" || st.URL == "" {
+			return fmt.Errorf("streams[%d]: name and url are required", i)
+		}
+		if names[st.Name] {
+			return fmt.Errorf("streams[%d]: duplicate name %s", i, st.Name)
+		}
+		names[st.Name] = true
+		if st.Kind != types.StreamKindRTSP && st.Kind != types.StreamKindV4L2 {
+			return fmt.Errorf("streams[%d]: kind must be one of %s, %s", i, types.StreamKindRTSP, types.StreamKindV4L2)
+		}
+		streams = append(streams, provider.Stream{Name: st.Name, Kind: st.Kind, URL: st.URL})
+	}
+	if !slices.Contains(cfg.ResourceTags, "camera") {
+		logrus.Warnf("Streams are configured but resource_tags does not include camera, other nodes will not delegate stream-bound deployments to this one")
+	}
+	service.SetStreams(streams)
+	logrus.Infof("Streams: %d configured", len(streams))
+	return nil
+}
@@ -12,15 +12,78 @@ import (
 
 // Config Docker provider 配置
 type Config struct {
-	Server       ServerConfig   `yaml:"server"`
-	Docker       DockerConfig   `yaml:"docker"`
-	Resource     ResourceConfig `yaml:"resource"`
-	ResourceTags []string       `yaml:"resource_tags"`
+	Server       ServerConfig     `yaml:"server"`
+	Docker       DockerConfig     `yaml:"docker"`
+	Resource     ResourceConfig   `yaml:"resource"`
+	ResourceTags []string         `yaml:"resource_tags"`
+	Energy       EnergyConfig     `yaml:"energy"`      // 能耗画像（可选）
+	ImageShare   ImageShareConfig `yaml:"image_share"` // P2P 镜像分发（可选）
+	Staging      StagingConfig    `yaml:"staging"`     // 数据预置（可选）
+	Volumes      VolumesConfig    `yaml:"volumes"`     // 卷挂载（可选）
+	Security     SecurityConfig   `yaml:"security"`    // 容器安全配置（可选）
+	Streams      []StreamConfig   `yaml:"streams"`     // 数据流（可选），需同时声明 camera 资源标签
+}
+
+// StreamConfig 数据流：本机摄像头（V4L2 设备）或主机可访问的网络摄像头（RTSP）
+// 通过健康检查上报给 iarnet，绑定该数据流的 component（如视频接入组件）只会部署到本 provider
+type StreamConfig struct {
+	Name string `yaml:"name"` // 数据流名称，部署请求按名称绑定
+	Kind string `yaml:"kind"` // rtsp / v4l2
+	URL  string `yaml:"url"`  // RTSP 地址，或 V4L2 设备路径（e.g., /dev/video0），部署时映射进容器的同一路径
+}
+
+// SecurityConfig 容器安全配置
+// 部署请求未携带安全配置时使用 default；未配置 default 时使用内置的受限配置：
+// 只读根文件系统、禁止提权、移除全部 capabilities、运行时默认的 seccomp 与 AppArmor profile
+type SecurityConfig struct {
+	Default            *SecurityContextConfig `yaml:"default"`
+	SeccompProfilesDir string                 `yaml:"seccomp_profiles_dir"` // 按名称引用的 seccomp profile 所在目录，文件名为 <name>.json
+}
+
+// SecurityContextConfig 单个容器的安全配置
+type SecurityContextConfig struct {
+	ReadOnlyRootFS           bool     `yaml:"read_only_root_fs"`
+	AllowPrivilegeEscalation bool     `yaml:"allow_privilege_escalation"`
+	DropCapabilities         []string `yaml:"drop_capabilities"`
+	AddCapabilities          []string `yaml:"add_capabilities"`
+	SeccompProfile           string   `yaml:"seccomp_profile"`  // 空为运行时默认，unconfined 或 seccomp_profiles_dir 中的 profile 名称
+	AppArmorProfile          string   `yaml:"apparmor_profile"` // 空为运行时默认，unconfined 或宿主机已加载的 profile 名称
+}
+
+// VolumesConfig 卷挂载配置
+// 命名卷始终可用；host-path 挂载只允许位于 allowed_host_paths 之下的目录，未配置时全部拒绝
+type VolumesConfig struct {
+	AllowedHostPaths []string `yaml:"allowed_host_paths"`
+}
+
+// StagingConfig 数据预置配置
+// component 声明的数据源在容器启动前下载到工作目录，路径通过 IARNET_DATA_DIR 环境变量告知 component
+type StagingConfig struct {
+	Workspace              string `yaml:"workspace"`                // 容器内的工作目录，默认 /workspace
+	DownloadTimeoutSeconds int    `yaml:"download_timeout_seconds"` // 单个数据源的下载超时，默认 600 秒
+}
+
+// ImageShareConfig P2P 镜像分发配置
+// 部署时本地缺少镜像会先向 peers 请求，再回退到从 registry 拉取
+type ImageShareConfig struct {
+	Peers []string `yaml:"peers"` // 同域 docker provider 的 gRPC 地址
+	Token string   `yaml:"token"` // 共享令牌，peer 间需一致；未设置时本 provider 不向 peer 导出镜像
 }
 
 // ServerConfig gRPC 服务器配置
 type ServerConfig struct {
-	Port int `yaml:"port"`
+	Port  int    `yaml:"port"`
+	Token string `yaml:"token"` // 注册令牌（可选），iarnet 节点连接时需出示一致的令牌
+
+	Tunnel TunnelConfig `yaml:"tunnel"` // 反向连接模式（可选），位于 NAT 后无法接受入站连接时使用
+}
+
+// TunnelConfig 反向连接配置
+// 配置 address 后 provider 不再监听端口，而是主动连接 iarnet 节点的 transport.tunnel 端口，
+// 节点经这条连接调用 provider；节点需在 static_providers 中以相同的 name 和 token 声明 reverse: true
+type TunnelConfig struct {
+	Address string `yaml:"address"` // e.g., "iarnet.example.com:50007"
+	Name    string `yaml:"name"`    // 与节点 static_providers 中的 name 一致
 }
 
 // DockerConfig Docker 引擎配置
@@ -37,23 +100,50 @@ type ResourceConfig struct {
 	CPU    int64  `yaml:"cpu"`    // CPU 容量，单位：millicores (1000 millicores = 1 core)
 	Memory string `yaml:"memory"` // 内存容量，支持格式：8Gi, 8GB, 8192Mi, 8192MB 等
 	GPU    int64  `yaml:"gpu"`    // GPU 数量
+
+	// GPU 拓扑（可选），未配置且 gpu > 0 时通过 nvidia-smi 探测；
+	// 已知拓扑时 GPU 按块独占分配给 component，同一 NVLink 组内的 GPU 优先分配给同一个多卡 component
+	GPUDevices []GPUDeviceConfig `yaml:"gpu_devices"`
+}
+
+// GPUDeviceConfig 单块 GPU
+type GPUDeviceConfig struct {
+	ID          string `yaml:"id"`           // 传给容器运行时的设备 ID，nvidia-smi 中的 UUID 或序号
+	Name        string `yaml:"name"`         // 型号（可选）
+	Memory      string `yaml:"memory"`       // 显存（可选），格式同 resource.memory
+	NVLinkGroup int    `yaml:"nvlink_group"` // NVLink 互联组，编号相同的 GPU 互联；0 表示未与其他 GPU 互联
+}
+
+// EnergyConfig 能耗画像配置，通过健康检查上报给 iarnet
+type EnergyConfig struct {
+	WattsPerCore   float64 `yaml:"watts_per_core"`  // 每核功耗（瓦），0 表示未知
+	BatteryPowered bool    `yaml:"battery_powered"` // 是否由电池供电
 }
 
 // ParseMemory 解析内存字符串为字节数
 // 支持格式：8Gi, 8GB, 8192Mi, 8192MB, 8192, 8G, 8M 等
 func (r *ResourceConfig) ParseMemory() (int64, error) {
-	if r.Memory == "" {
+	return parseMemory(r.Memory)
+}
+
+// ParseMemory 解析显存字符串为字节数，格式同 ResourceConfig.ParseMemory
+func (g *GPUDeviceConfig) ParseMemory() (int64, error) {
+	return parseMemory(g.Memory)
+}
+
+func parseMemory(memory string) (int64, error) {
+	if memory == "" {
 		return 0, nil
 	}
 
 	// 移除空格并转换为小写
-	memoryStr := strings.TrimSpace(strings.ToLower(r.Memory))
+	memoryStr := strings.TrimSpace(strings.ToLower(memory))
 
 	// 正则表达式匹配数字和单位
 	re := regexp.MustCompile(`^(\d+)([kmgt]?i?b?)$`)
 	matches := re.FindStringSubmatch(memoryStr)
 	if len(matches) != 3 {
-		return 0, fmt.Errorf("invalid memory format: %s, expected format like 8Gi, 8GB, 8192Mi", r.Memory)
+		return 0, fmt.Errorf("invalid memory format: %s, expected format like 8Gi, 8GB, 8192Mi", memory)
 	}
 
 	value, err := strconv.ParseInt(matches[1], 10, 64)
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+	"github.com/9triver/iarnet/internal/util"
+	"github.com/sirupsen/logrus"
+)
+
+// Benchmark 在 provider 所在主机上运行微基准测试（CPU、内存带宽、磁盘 IO），并通过 nvidia-smi 探测 GPU
+func (s *Service) Benchmark(ctx context.Context, req *providerpb.BenchmarkRequest) (*providerpb.BenchmarkResponse, error) {
+	if err := s.checkAuth(req.ProviderId, false); err != nil {
+		return &providerpb.BenchmarkResponse{
+			Error: fmt.Sprintf("authentication failed: %v", err),
+		}, nil
+	}
+
+	bench, err := util.RunMicroBenchmark(ctx, "")
+	if err != nil {
+		return &providerpb.BenchmarkResponse{
+			Error: fmt.Sprintf("benchmark failed: %v", err),
+		}, nil
+	}
+
+	gpuCount := countNvidiaGPUs(ctx)
+	result := &providerpb.BenchmarkResult{
+		CpuScore:            bench.CPUScore,
+		MemoryBandwidthMbps: bench.MemoryBandwidthMBps,
+		DiskWriteMbps:       bench.DiskWriteMBps,
+		DiskReadMbps:        bench.DiskReadMBps,
+		GpuPresent:          gpuCount > 0,
+		GpuCount:            int32(gpuCount),
+		DurationMs:          bench.Duration.Milliseconds(),
+	}
+	logrus.Infof("Benchmark finished in %v: cpu=%.0f MiB/s, memory=%.0f MiB/s, disk write=%.0f MiB/s, read=%.0f MiB/s, gpus=%d",
+		bench.Duration, result.CpuScore, result.MemoryBandwidthMbps, result.DiskWriteMbps, result.DiskReadMbps, gpuCount)
+	return &providerpb.BenchmarkResponse{Result: result}, nil
+}
+
+// countNvidiaGPUs 通过 nvidia-smi -L 统计 GPU 数量，nvidia-smi 不可用时返回 0
+func countNvidiaGPUs(ctx context.Context) int {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, "nvidia-smi", "-L").Output()
+	if err != nil {
+		return 0
+	}
+	count := 0
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "GPU ") {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	resourcepb "github.com/9triver/iarnet/internal/proto/resource"
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/filters"
+	"github.com/sirupsen/logrus"
+)
+
+// GetComponentUsage 按 component 容器上报实时资源使用情况
+// CPU 与内存来自 Docker Stats API，常驻内存与页缓存来自容器 cgroup 的内存统计
+// sidecar 容器的使用量计入所属 component
+func (s *Service) GetComponentUsage(ctx context.Context, req *providerpb.GetComponentUsageRequest) (*providerpb.GetComponentUsageResponse, error) {
+	if err := s.checkAuth(req.ProviderId, false); err != nil {
+		return &providerpb.GetComponentUsageResponse{
+			Error: fmt.Sprintf("authentication failed: %v", err),
+		}, nil
+	}
+
+	containers, err := s.client.ContainerList(ctx, container.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("label", "iarnet.provider_id="+s.GetProviderID())),
+	})
+	if err != nil {
+		return &providerpb.GetComponentUsageResponse{
+			Error: fmt.Sprintf("failed to list containers: %v", err),
+		}, nil
+	}
+
+	wanted := make(map[string]struct{}, len(req.InstanceIds))
+	for _, id := range req.InstanceIds {
+		wanted[id] = struct{}{}
+	}
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		usages = make(map[string]*providerpb.ComponentUsage)
+	)
+	// 限制并发数，避免过多 goroutine
+	semaphore := make(chan struct{}, 10)
+	for _, c := range containers {
+		if len(c.Names) == 0 {
+			continue
+		}
+		instanceID := strings.TrimPrefix(c.Names[0], "/")
+		if owner, ok := c.Labels[sidecarLabel]; ok {
+			instanceID = owner
+		}
+		if _, ok := wanted[instanceID]; len(wanted) > 0 && !ok {
+			continue
+		}
+
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(containerID, instanceID string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			usage, err := s.sampleComponentUsage(ctx, containerID, instanceID)
+			if err != nil {
+				logrus.Warnf("Failed to get usage of component %s: %v", instanceID, err)
+				return
+			}
+			mu.Lock()
+			if existing, ok := usages[instanceID]; ok {
+				mergeComponentUsage(existing, usage)
+			} else {
+				usages[instanceID] = usage
+			}
+			mu.Unlock()
+		}(c.ID, instanceID)
+	}
+	wg.Wait()
+
+	resp := &providerpb.GetComponentUsageResponse{}
+	for _, usage := range usages {
+		resp.Components = append(resp.Components, usage)
+	}
+	return resp, nil
+}
+
+// mergeComponentUsage 将同一 component 另一容器的使用量累加到 dst
+func mergeComponentUsage(dst, src *providerpb.ComponentUsage) {
+	dst.Usage.Cpu += src.Usage.Cpu
+	dst.Usage.Memory += src.Usage.Memory
+	dst.Usage.Gpu += src.Usage.Gpu
+	dst.MemoryRss += src.MemoryRss
+	dst.MemoryCache += src.MemoryCache
+	if src.Timestamp > dst.Timestamp {
+		dst.Timestamp = src.Timestamp
+	}
+	switch {
+	case dst.Limit == nil:
+		dst.Limit = src.Limit
+	case src.Limit != nil:
+		dst.Limit = &resourcepb.Info{
+			Cpu:    dst.Limit.Cpu + src.Limit.Cpu,
+			Memory: dst.Limit.Memory + src.Limit.Memory,
+			Gpu:    dst.Limit.Gpu + src.Limit.Gpu,
+		}
+	}
+}
+
+// sampleComponentUsage 采样单个 component 容器的资源使用情况
+func (s *Service) sampleComponentUsage(ctx context.Context, containerID, instanceID string) (*providerpb.ComponentUsage, error) {
+	containerCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	info, err := s.client.ContainerInspect(containerCtx, containerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect container: %w", err)
+	}
+	stats, err := sampleContainerStats(containerCtx, s.client, containerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stats: %w", err)
+	}
+
+	rss, cache := memoryBreakdown(stats.MemoryStats)
+	usage := &providerpb.ComponentUsage{
+		InstanceId: instanceID,
+		Usage: &resourcepb.Info{
+			Cpu:    calculateRealTimeCPU(stats, info),
+			Memory: int64(stats.MemoryStats.Usage),
+			Gpu:    getContainerGPUUsage(containerID, info),
+		},
+		MemoryRss:   rss,
+		MemoryCache: cache,
+		Timestamp:   stats.Read.UnixMilli(),
+	}
+	if info.HostConfig != nil {
+		usage.Limit = &resourcepb.Info{
+			Cpu:    info.HostConfig.NanoCPUs / 1e6,
+			Memory: info.HostConfig.Memory,
+			Gpu:    allocatedGPUs(info),
+		}
+	}
+	return usage, nil
+}
+
+// memoryBreakdown 从 cgroup 内存统计中取出常驻内存与页缓存
+// cgroup v1 使用 rss/cache，cgroup v2 使用 anon/file
+func memoryBreakdown(mem container.MemoryStats) (rss, cache int64) {
+	if v, ok := mem.Stats["anon"]; ok {
+		return int64(v), int64(mem.Stats["file"])
+	}
+	return int64(mem.Stats["rss"]), int64(mem.Stats["cache"])
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+	"github.com/moby/moby/api/types/container"
+	"github.com/sirupsen/logrus"
+)
+
+// egressChain Docker 预留给用户规则的链，转发流量在进入 Docker 自身规则前会先经过该链
+// 注意：容器访问宿主机本身的流量走 INPUT 链，不受该链约束
+const egressChain = "DOCKER-USER"
+
+// newEgressMAC 生成本地管理的单播 MAC 地址
+// 受出站策略约束的容器在创建时指定该地址，规则按源 MAC 匹配，可在容器启动、分配 IP 之前插入
+func newEgressMAC() (string, error) {
+	mac := make([]byte, 6)
+	if _, err := rand.Read(mac); err != nil {
+		return "", fmt.Errorf("failed to generate mac address: %w", err)
+	}
+	mac[0] = mac[0]&0xfe | 0x02
+	return net.HardwareAddr(mac).String(), nil
+}
+
+// egressRuleArgs 根据容器 MAC 地址与出站策略生成 iptables 规则参数
+// 返回顺序即插入顺序：由于使用 -I 插入链首，DROP 最先插入、最终位于放行规则之后
+// 没有放行规则时只保留 DROP 与回包规则，未命中的出站流量全部拒绝
+func egressRuleArgs(mac string, policy *providerpb.EgressPolicy) [][]string {
+	source := []string{"-m", "mac", "--mac-source", mac}
+	rules := [][]string{
+		append(slices.Clone(source), "-j", "DROP"),
+	}
+	for _, rule := range policy.GetAllow() {
+		destinations, err := resolveEgressDestination(rule.Destination)
+		if err != nil {
+			logrus.Warnf("Skipping egress rule for %s: %v", rule.Destination, err)
+			continue
+		}
+		protocols := []string{strings.ToLower(rule.Protocol)}
+		if rule.Port > 0 && rule.Protocol == "" {
+			// 指定端口时 iptables 要求同时指定协议
+			protocols = []string{"tcp", "udp"}
+		}
+		for _, dest := range destinations {
+			for _, proto := range protocols {
+				args := append(slices.Clone(source), "-d", dest)
+				if proto != "" {
+					args = append(args, "-p", proto)
+					if rule.Port > 0 {
+						args = append(args, "--dport", strconv.Itoa(int(rule.Port)))
+					}
+				}
+				rules = append(rules, append(args, "-j", "ACCEPT"))
+			}
+		}
+	}
+	// 放行已建立连接的回包
+	rules = append(rules, append(slices.Clone(source), "-m", "conntrack", "--ctstate", "ESTABLISHED,RELATED", "-j", "ACCEPT"))
+	return rules
+}
+
+// resolveEgressDestination 将目的地解析为 IP 或 CIDR 列表
+func resolveEgressDestination(dest string) ([]string, error) {
+	if dest == "" {
+		return nil, fmt.Errorf("empty destination")
+	}
+	if _, _, err := net.ParseCIDR(dest); err == nil {
+		return []string{dest}, nil
+	}
+	if ip := net.ParseIP(dest); ip != nil {
+		return []string{ip.String()}, nil
+	}
+	addrs, err := net.LookupHost(dest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve destination: %w", err)
+	}
+	var ipv4 []string
+	for _, addr := range addrs {
+		if ip := net.ParseIP(addr); ip != nil && ip.To4() != nil {
+			ipv4 = append(ipv4, addr)
+		}
+	}
+	return ipv4, nil
+}
+
+// applyEgressPolicy 按容器 MAC 地址插入出站规则，返回已插入的规则用于后续清理
+func applyEgressPolicy(mac string, policy *providerpb.EgressPolicy) ([][]string, error) {
+	var applied [][]string
+	for _, args := range egressRuleArgs(mac, policy) {
+		if err := runIptables(append([]string{"-I", egressChain}, args...)); err != nil {
+			removeEgressRules(applied)
+			return nil, fmt.Errorf("failed to enforce egress policy: %w", err)
+		}
+		applied = append(applied, args)
+	}
+	return applied, nil
+}
+
+// removeEgressRules 删除之前插入的出站规则
+func removeEgressRules(rules [][]string) {
+	for _, args := range rules {
+		if err := runIptables(append([]string{"-D", egressChain}, args...)); err != nil {
+			logrus.Warnf("Failed to remove egress rule %v: %v", args, err)
+		}
+	}
+}
+
+func runIptables(args []string) error {
+	out, err := exec.Command("iptables", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("iptables %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// cleanupEgressOnExit 等待已启动的容器退出后删除对应的出站规则
+func (s *Service) cleanupEgressOnExit(containerID string, rules [][]string) {
+	resultC, errC := s.client.ContainerWait(context.Background(), containerID, container.WaitConditionNotRunning)
+	select {
+	case <-resultC:
+	case err := <-errC:
+		logrus.Warnf("Failed to wait for container %s, removing egress rules: %v", containerID, err)
+	}
+	removeEgressRules(rules)
+}
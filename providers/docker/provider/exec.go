@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+	"github.com/moby/moby/api/pkg/stdcopy"
+	"github.com/moby/moby/api/types/container"
+	"github.com/sirupsen/logrus"
+)
+
+// defaultExecCommand 未指定命令时启动的 shell
+var defaultExecCommand = []string{"/bin/sh"}
+
+// execChunkSize 单条输出消息的最大字节数
+const execChunkSize = 32 * 1024
+
+// execStreamWriter 将命令输出写入 gRPC 流
+type execStreamWriter struct {
+	stream providerpb.Service_ExecServer
+	stderr bool
+}
+
+func (w *execStreamWriter) Write(p []byte) (int, error) {
+	data := append([]byte(nil), p...)
+	resp := &providerpb.ExecResponse{Stdout: data}
+	if w.stderr {
+		resp = &providerpb.ExecResponse{Stderr: data}
+	}
+	if err := w.stream.Send(resp); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Exec 在 component 容器内执行调试命令（docker exec），并通过双向流转发标准输入输出
+func (s *Service) Exec(stream providerpb.Service_ExecServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	start := first.GetStart()
+	if start == nil {
+		return stream.Send(&providerpb.ExecResponse{Error: "first exec message must be start"})
+	}
+	if err := s.checkAuth(start.ProviderId, false); err != nil {
+		return stream.Send(&providerpb.ExecResponse{Error: fmt.Sprintf("authentication failed: %v", err)})
+	}
+
+	ctx := stream.Context()
+	if err := s.checkManagedContainer(ctx, start.InstanceId); err != nil {
+		return stream.Send(&providerpb.ExecResponse{Error: err.Error()})
+	}
+
+	command := start.Command
+	if len(command) == 0 {
+		command = defaultExecCommand
+	}
+	execResp, err := s.client.ContainerExecCreate(ctx, start.InstanceId, container.ExecOptions{
+		Cmd:          command,
+		Tty:          start.Tty,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return stream.Send(&providerpb.ExecResponse{Error: fmt.Sprintf("failed to create exec: %v", err)})
+	}
+
+	attach, err := s.client.ContainerExecAttach(ctx, execResp.ID, container.ExecAttachOptions{Tty: start.Tty})
+	if err != nil {
+		return stream.Send(&providerpb.ExecResponse{Error: fmt.Sprintf("failed to attach exec: %v", err)})
+	}
+	defer attach.Close()
+
+	logrus.Infof("Exec started in container %s: %v", start.InstanceId, command)
+
+	// 转发客户端输入
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				attach.CloseWrite()
+				return
+			}
+			switch payload := req.Payload.(type) {
+			case *providerpb.ExecRequest_Stdin:
+				if _, err := attach.Conn.Write(payload.Stdin); err != nil {
+					logrus.Warnf("Failed to write exec stdin: %v", err)
+					return
+				}
+			case *providerpb.ExecRequest_Resize:
+				if err := s.client.ContainerExecResize(ctx, execResp.ID, container.ResizeOptions{
+					Height: uint(payload.Resize.Rows),
+					Width:  uint(payload.Resize.Cols),
+				}); err != nil {
+					logrus.Warnf("Failed to resize exec tty: %v", err)
+				}
+			case *providerpb.ExecRequest_CloseStdin:
+				attach.CloseWrite()
+			}
+		}
+	}()
+
+	// 转发命令输出，TTY 模式下输出不区分 stdout/stderr
+	stdout := &execStreamWriter{stream: stream}
+	stderr := &execStreamWriter{stream: stream, stderr: true}
+	if start.Tty {
+		_, err = io.CopyBuffer(stdout, attach.Reader, make([]byte, execChunkSize))
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, attach.Reader)
+	}
+	if err != nil && ctx.Err() == nil {
+		logrus.Warnf("Exec output stream for container %s ended: %v", start.InstanceId, err)
+	}
+
+	inspectCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	inspect, err := s.client.ContainerExecInspect(inspectCtx, execResp.ID)
+	if err != nil {
+		return stream.Send(&providerpb.ExecResponse{Error: fmt.Sprintf("failed to inspect exec: %v", err)})
+	}
+	logrus.Infof("Exec in container %s exited with code %d", start.InstanceId, inspect.ExitCode)
+	return stream.Send(&providerpb.ExecResponse{Exited: true, ExitCode: int32(inspect.ExitCode)})
+}
+
+// checkManagedContainer 确认容器由当前 provider 部署，避免通过 Exec 访问宿主机上的其他容器
+func (s *Service) checkManagedContainer(ctx context.Context, containerID string) error {
+	info, err := s.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("container %s not found: %w", containerID, err)
+	}
+	if info.Config == nil || info.Config.Labels["iarnet.provider_id"] != s.GetProviderID() {
+		return fmt.Errorf("container %s is not managed by this provider", containerID)
+	}
+	if info.State == nil || !info.State.Running {
+		return fmt.Errorf("container %s is not running", containerID)
+	}
+	return nil
+}
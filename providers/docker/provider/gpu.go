@@ -0,0 +1,284 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+	"github.com/moby/moby/api/types/container"
+	"github.com/sirupsen/logrus"
+)
+
+// gpuCountLabel 容器上记录分配的 GPU 数量的标签，Undeploy 时按此归还容量
+const gpuCountLabel = "iarnet.gpus"
+
+// noNVLinkGroup 未与其他 GPU 通过 NVLink 互联
+const noNVLinkGroup = -1
+
+// GPUDevice provider 主机上的一块 GPU
+type GPUDevice struct {
+	ID          string // 传给容器运行时的 device_ids，nvidia-smi 中的 UUID 或序号
+	Index       int32  // nvidia-smi 中的序号
+	Name        string // 型号
+	Memory      int64  // 显存（bytes），0 表示未知
+	NVLinkGroup int32  // NVLink 互联组，-1 表示未与其他 GPU 互联
+}
+
+// gpuSlot 一块 GPU 及占用它的 component 实例
+type gpuSlot struct {
+	GPUDevice
+	owner string
+}
+
+// SetGPUDevices 设置 GPU 拓扑，之后 GPU 按块独占分配给 component，并通过健康检查上报逐卡分配情况
+// 未设置时 GPU 只按数量记账，容器可见主机上的全部 GPU
+func (s *Service) SetGPUDevices(devices []GPUDevice) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gpus = make([]*gpuSlot, 0, len(devices))
+	for _, d := range devices {
+		s.gpus = append(s.gpus, &gpuSlot{GPUDevice: d})
+	}
+}
+
+// allocateGPUs 为实例独占分配 n 块空闲 GPU，返回设备 ID；未设置拓扑时返回 nil
+// 优先放入恰好容纳请求的最小 NVLink 组，单卡请求因此优先使用未互联或已部分占用的组，保留完整的组给多卡请求；
+// 没有能容纳请求的组时从空闲 GPU 最多的组开始跨组分配
+func (s *Service) allocateGPUs(instanceID string, n int64) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.gpus) == 0 || n <= 0 {
+		return nil, nil
+	}
+
+	// 按 NVLink 组归集空闲 GPU，未互联的 GPU 各自成组
+	var groups [][]*gpuSlot
+	byGroup := make(map[int32]int)
+	var free int64
+	for _, g := range s.gpus {
+		if g.owner != "" {
+			continue
+		}
+		free++
+		if g.NVLinkGroup == noNVLinkGroup {
+			groups = append(groups, []*gpuSlot{g})
+			continue
+		}
+		i, ok := byGroup[g.NVLinkGroup]
+		if !ok {
+			i = len(groups)
+			byGroup[g.NVLinkGroup] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], g)
+	}
+	if free < n {
+		return nil, fmt.Errorf("%d GPUs requested, %d free", n, free)
+	}
+
+	var picked []*gpuSlot
+	best := -1
+	for i, group := range groups {
+		if int64(len(group)) >= n && (best < 0 || len(group) < len(groups[best])) {
+			best = i
+		}
+	}
+	if best >= 0 {
+		picked = groups[best][:n]
+	} else {
+		sort.SliceStable(groups, func(i, j int) bool { return len(groups[i]) > len(groups[j]) })
+		for _, group := range groups {
+			for _, g := range group {
+				if int64(len(picked)) < n {
+					picked = append(picked, g)
+				}
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(picked))
+	for _, g := range picked {
+		g.owner = instanceID
+		ids = append(ids, g.ID)
+	}
+	return ids, nil
+}
+
+// releaseGPUs 归还实例占用的 GPU
+func (s *Service) releaseGPUs(instanceID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, g := range s.gpus {
+		if g.owner == instanceID {
+			g.owner = ""
+		}
+	}
+}
+
+// gpuDevicesProto 健康检查上报的 GPU 拓扑与逐卡分配情况
+func (s *Service) gpuDevicesProto() []*providerpb.GPUDevice {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.gpus) == 0 {
+		return nil
+	}
+	devices := make([]*providerpb.GPUDevice, 0, len(s.gpus))
+	for _, g := range s.gpus {
+		devices = append(devices, &providerpb.GPUDevice{
+			Id:          g.ID,
+			Index:       g.Index,
+			Name:        g.Name,
+			Memory:      g.Memory,
+			NvlinkGroup: g.NVLinkGroup,
+			InstanceId:  g.owner,
+		})
+	}
+	return devices
+}
+
+// gpuDeviceRequests 将分配的设备 ID 转换为容器的 GPU 设备请求
+func gpuDeviceRequests(ids []string) []container.DeviceRequest {
+	return []container.DeviceRequest{{
+		Driver:       "nvidia",
+		DeviceIDs:    ids,
+		Capabilities: [][]string{{"gpu"}},
+	}}
+}
+
+// containerGPUs 容器分配的 GPU 数量，优先使用部署时记录的标签
+func containerGPUs(info container.InspectResponse) int64 {
+	if info.Config != nil {
+		if n, err := strconv.ParseInt(info.Config.Labels[gpuCountLabel], 10, 64); err == nil {
+			return n
+		}
+	}
+	return allocatedGPUs(info)
+}
+
+// DetectGPUs 通过 nvidia-smi 查询主机的 GPU 及其 NVLink 拓扑，nvidia-smi 不可用时返回错误
+func DetectGPUs(ctx context.Context) ([]GPUDevice, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, "nvidia-smi",
+		"--query-gpu=index,uuid,name,memory.total", "--format=csv,noheader,nounits").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to query GPUs: %w", err)
+	}
+	var devices []GPUDevice
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Split(line, ",")
+		if len(fields) != 4 {
+			continue
+		}
+		index, err := strconv.ParseInt(strings.TrimSpace(fields[0]), 10, 32)
+		if err != nil {
+			continue
+		}
+		// memory.total 的单位为 MiB
+		memory, _ := strconv.ParseInt(strings.TrimSpace(fields[3]), 10, 64)
+		devices = append(devices, GPUDevice{
+			ID:          strings.TrimSpace(fields[1]),
+			Index:       int32(index),
+			Name:        strings.TrimSpace(fields[2]),
+			Memory:      memory << 20,
+			NVLinkGroup: noNVLinkGroup,
+		})
+	}
+
+	// 拓扑查询失败时视为没有 NVLink 互联
+	topo, err := exec.CommandContext(ctx, "nvidia-smi", "topo", "-m").Output()
+	if err != nil {
+		logrus.Warnf("Failed to query GPU topology, assuming no NVLink: %v", err)
+		return devices, nil
+	}
+	groups := parseNVLinkGroups(string(topo))
+	for i := range devices {
+		if group, ok := groups[devices[i].Index]; ok {
+			devices[i].NVLinkGroup = group
+		}
+	}
+	return devices, nil
+}
+
+// ansiEscape nvidia-smi topo -m 在表头中输出的终端控制字符
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// parseNVLinkGroups 解析 nvidia-smi topo -m 的连接矩阵，将通过 NVLink（NV#）直接或间接互联的 GPU 归为一组
+// 返回 GPU 序号到组编号的映射，组编号为组内最小的 GPU 序号；未互联的 GPU 不在结果中
+func parseNVLinkGroups(matrix string) map[int32]int32 {
+	parent := make(map[int32]int32)
+	find := func(i int32) int32 {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+		return i
+	}
+	union := func(a, b int32) {
+		for _, i := range []int32{a, b} {
+			if _, ok := parent[i]; !ok {
+				parent[i] = i
+			}
+		}
+		ra, rb := find(a), find(b)
+		if ra == rb {
+			return
+		}
+		if ra < rb {
+			parent[rb] = ra
+		} else {
+			parent[ra] = rb
+		}
+	}
+
+	// 表头中的 GPU 列，矩阵行中 GPU 名称之后依次为各列的连接类型
+	var columns []int32
+	for _, line := range strings.Split(ansiEscape.ReplaceAllString(matrix, ""), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if columns == nil {
+			for _, f := range fields {
+				if i, ok := gpuOrdinal(f); ok {
+					columns = append(columns, i)
+				}
+			}
+			continue
+		}
+		row, ok := gpuOrdinal(fields[0])
+		if !ok {
+			continue
+		}
+		for c, col := range columns {
+			if c+1 < len(fields) && strings.HasPrefix(fields[c+1], "NV") {
+				union(row, col)
+			}
+		}
+	}
+
+	groups := make(map[int32]int32, len(parent))
+	for i := range parent {
+		groups[i] = find(i)
+	}
+	return groups
+}
+
+// gpuOrdinal 解析 GPU0 形式的名称
+func gpuOrdinal(name string) (int32, bool) {
+	if !strings.HasPrefix(name, "GPU") {
+		return 0, false
+	}
+	i, err := strconv.ParseInt(strings.TrimPrefix(name, "GPU"), 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return int32(i), true
+}
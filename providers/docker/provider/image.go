@@ -0,0 +1,175 @@
+package provider
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"time"
+
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+	"github.com/moby/moby/api/types/image"
+	"github.com/moby/moby/client"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// imageChunkSize 镜像归档分块传输的大小
+const imageChunkSize = 1 << 20
+
+// peerFetchTimeout 从单个 peer 获取镜像的超时时间
+const peerFetchTimeout = 10 * time.Minute
+
+// SetImagePeers 设置同域 provider 地址，部署前优先从这些 peer 获取本地缺失的镜像
+// token 用于双向校验 ExportImage 请求，为空时本 provider 拒绝导出镜像
+func (s *Service) SetImagePeers(peers []string, token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.imagePeers = peers
+	s.imageShareToken = token
+}
+
+// ExportImage 将本地镜像以 docker save 格式流式发送给请求方
+// 只有配置了镜像共享及共享令牌、且已被 iarnet 节点连接的 provider 才导出，否则任何能访问端口的一方都可导出主机上的私有镜像
+func (s *Service) ExportImage(req *providerpb.ExportImageRequest, stream providerpb.Service_ExportImageServer) error {
+	s.mu.RLock()
+	token := s.imageShareToken
+	s.mu.RUnlock()
+	if token == "" {
+		return status.Error(codes.PermissionDenied, "image sharing is not enabled")
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(req.Token)) != 1 {
+		return status.Error(codes.PermissionDenied, "invalid image share token")
+	}
+	if err := s.checkConnected(); err != nil {
+		return status.Errorf(codes.PermissionDenied, "authentication failed: %v", err)
+	}
+
+	ctx := stream.Context()
+	if _, err := s.client.ImageInspect(ctx, req.Image); err != nil {
+		return status.Errorf(codes.NotFound, "image %s not found locally", req.Image)
+	}
+
+	reader, err := s.client.ImageSave(ctx, []string{req.Image})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to save image: %v", err)
+	}
+	defer reader.Close()
+
+	buf := make([]byte, imageChunkSize)
+	for {
+		n, err := reader.Read(buf)
+		if n > 0 {
+			if sendErr := stream.Send(&providerpb.ImageChunk{Data: buf[:n]}); sendErr != nil {
+				return sendErr
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to read image archive: %v", err)
+		}
+	}
+
+	logrus.Infof("Exported image %s to peer", req.Image)
+	return nil
+}
+
+// ensureImage 部署前确保镜像在本地可用
+// 依次尝试：本地已存在 -> 从同域 peer 获取 -> 从 registry 拉取
+func (s *Service) ensureImage(ctx context.Context, ref string) error {
+	if _, err := s.client.ImageInspect(ctx, ref); err == nil {
+		return nil
+	}
+
+	s.mu.RLock()
+	peers := append([]string(nil), s.imagePeers...)
+	token := s.imageShareToken
+	s.mu.RUnlock()
+
+	for _, peer := range peers {
+		if err := s.fetchImageFromPeer(ctx, peer, ref, token); err != nil {
+			logrus.Debugf("Failed to fetch image %s from peer %s: %v", ref, peer, err)
+			continue
+		}
+		logrus.Infof("Fetched image %s from peer %s", ref, peer)
+		return nil
+	}
+
+	logrus.Infof("Pulling image %s from registry", ref)
+	reader, err := s.client.ImagePull(ctx, ref, image.PullOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to pull image %s: %w", ref, err)
+	}
+	defer reader.Close()
+	if _, err := io.Copy(io.Discard, reader); err != nil {
+		return fmt.Errorf("failed to pull image %s: %w", ref, err)
+	}
+	return nil
+}
+
+// fetchImageFromPeer 从 peer 流式接收镜像归档并直接导入本地 Docker
+func (s *Service) fetchImageFromPeer(ctx context.Context, peer, ref, token string) error {
+	ctx, cancel := context.WithTimeout(ctx, peerFetchTimeout)
+	defer cancel()
+
+	conn, err := grpc.NewClient(peer, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	stream, err := providerpb.NewServiceClient(conn).ExportImage(ctx, &providerpb.ExportImageRequest{
+		Image: ref,
+		Token: token,
+	})
+	if err != nil {
+		return err
+	}
+
+	// 先接收第一个数据块，确认 peer 确实持有该镜像后再开始导入
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		if _, err := pw.Write(first.Data); err != nil {
+			return
+		}
+		for {
+			chunk, err := stream.Recv()
+			if err == io.EOF {
+				pw.Close()
+				return
+			}
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+			if _, err := pw.Write(chunk.Data); err != nil {
+				return
+			}
+		}
+	}()
+
+	resp, err := s.client.ImageLoad(ctx, pr, client.ImageLoadWithQuiet(true))
+	if err != nil {
+		pr.CloseWithError(err)
+		return err
+	}
+	defer resp.Body.Close()
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		return err
+	}
+
+	if _, err := s.client.ImageInspect(ctx, ref); err != nil {
+		return fmt.Errorf("image %s not available after load: %w", ref, err)
+	}
+	return nil
+}
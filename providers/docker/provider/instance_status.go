@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/moby/moby/api/types/container"
+)
+
+// GetInstanceStatus 查询 component 容器的运行状态与退出码
+func (s *Service) GetInstanceStatus(ctx context.Context, req *providerpb.GetInstanceStatusRequest) (*providerpb.GetInstanceStatusResponse, error) {
+	if err := s.checkAuth(req.ProviderId, false); err != nil {
+		return &providerpb.GetInstanceStatusResponse{
+			Error: fmt.Sprintf("authentication failed: %v", err),
+		}, nil
+	}
+
+	info, err := s.client.ContainerInspect(ctx, req.InstanceId)
+	if cerrdefs.IsNotFound(err) {
+		return &providerpb.GetInstanceStatusResponse{State: "not_found"}, nil
+	}
+	if err != nil {
+		return &providerpb.GetInstanceStatusResponse{
+			Error: fmt.Sprintf("failed to inspect container %s: %v", req.InstanceId, err),
+		}, nil
+	}
+	if info.Config == nil || info.Config.Labels["iarnet.provider_id"] != s.GetProviderID() {
+		return &providerpb.GetInstanceStatusResponse{
+			Error: fmt.Sprintf("container %s is not managed by this provider", req.InstanceId),
+		}, nil
+	}
+	if info.State == nil {
+		return &providerpb.GetInstanceStatusResponse{State: "pending"}, nil
+	}
+
+	switch info.State.Status {
+	case container.StateExited, container.StateDead:
+		resp := &providerpb.GetInstanceStatusResponse{
+			State:    "exited",
+			ExitCode: int32(info.State.ExitCode),
+			Reason:   info.State.Error,
+		}
+		if info.State.OOMKilled {
+			resp.Reason = "OOMKilled"
+		}
+		return resp, nil
+	case container.StateCreated:
+		return &providerpb.GetInstanceStatusResponse{State: "pending"}, nil
+	default:
+		return &providerpb.GetInstanceStatusResponse{State: "running"}, nil
+	}
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+	"github.com/sirupsen/logrus"
+)
+
+// portForwardDialTimeout 连接 component 端口的超时时间
+const portForwardDialTimeout = 10 * time.Second
+
+// PortForward 将双向流转发到 component 容器的指定端口
+// provider 与容器位于同一宿主机，直接连接容器在 docker 网络中的地址
+func (s *Service) PortForward(stream providerpb.Service_PortForwardServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	start := first.GetStart()
+	if start == nil {
+		return stream.Send(&providerpb.PortForwardResponse{Error: "first port-forward message must be start"})
+	}
+	if err := s.checkAuth(start.ProviderId, false); err != nil {
+		return stream.Send(&providerpb.PortForwardResponse{Error: fmt.Sprintf("authentication failed: %v", err)})
+	}
+
+	ctx := stream.Context()
+	if err := s.checkManagedContainer(ctx, start.InstanceId); err != nil {
+		return stream.Send(&providerpb.PortForwardResponse{Error: err.Error()})
+	}
+	ip, err := s.containerIP(ctx, start.InstanceId)
+	if err != nil {
+		return stream.Send(&providerpb.PortForwardResponse{Error: err.Error()})
+	}
+
+	addr := net.JoinHostPort(ip, strconv.Itoa(int(start.Port)))
+	conn, err := net.DialTimeout("tcp", addr, portForwardDialTimeout)
+	if err != nil {
+		return stream.Send(&providerpb.PortForwardResponse{Error: fmt.Sprintf("failed to connect %s: %v", addr, err)})
+	}
+	defer conn.Close()
+
+	logrus.Infof("Port-forward opened to container %s at %s", start.InstanceId, addr)
+	go forwardToConn(stream, conn)
+
+	buf := make([]byte, execChunkSize)
+	for {
+		n, err := conn.Read(buf)
+		if n > 0 {
+			data := append([]byte(nil), buf[:n]...)
+			if sendErr := stream.Send(&providerpb.PortForwardResponse{Data: data}); sendErr != nil {
+				return sendErr
+			}
+		}
+		if err != nil {
+			logrus.Infof("Port-forward to container %s at %s closed", start.InstanceId, addr)
+			return nil
+		}
+	}
+}
+
+// forwardToConn 将客户端数据写入 TCP 连接
+func forwardToConn(stream providerpb.Service_PortForwardServer, conn net.Conn) {
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			conn.Close()
+			return
+		}
+		switch payload := req.Payload.(type) {
+		case *providerpb.PortForwardRequest_Data:
+			if _, err := conn.Write(payload.Data); err != nil {
+				return
+			}
+		case *providerpb.PortForwardRequest_CloseWrite:
+			if tcp, ok := conn.(*net.TCPConn); ok {
+				tcp.CloseWrite()
+			}
+		}
+	}
+}
+
+// containerIP 获取容器在 docker 网络中的 IP 地址，优先使用 provider 配置的网络
+func (s *Service) containerIP(ctx context.Context, containerID string) (string, error) {
+	info, err := s.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect container %s: %w", containerID, err)
+	}
+	if info.NetworkSettings == nil {
+		return "", fmt.Errorf("container %s has no network", containerID)
+	}
+	if ep, ok := info.NetworkSettings.Networks[s.network]; ok && ep != nil && ep.IPAddress != "" {
+		return ep.IPAddress, nil
+	}
+	for _, ep := range info.NetworkSettings.Networks {
+		if ep != nil && ep.IPAddress != "" {
+			return ep.IPAddress, nil
+		}
+	}
+	return "", fmt.Errorf("container %s has no reachable IP address", containerID)
+}
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/9triver/iarnet/internal/proto/common"
+	"github.com/moby/moby/api/types/container"
+)
+
+// defaultSecurityContext 未配置时使用的默认安全配置：只读根文件系统、禁止提权、移除全部 capabilities，
+// seccomp 与 AppArmor 使用运行时默认 profile
+func defaultSecurityContext() *common.SecurityContext {
+	return &common.SecurityContext{
+		ReadOnlyRootFS:   true,
+		DropCapabilities: []string{"ALL"},
+	}
+}
+
+// readOnlyTmpfs 只读根文件系统时挂载到 /tmp 的 tmpfs，供 component 写入临时文件
+const readOnlyTmpfs = "rw,nosuid,nodev"
+
+// SetSecurity 设置部署请求未携带安全配置时使用的默认配置（nil 表示内置的受限配置），
+// 以及按名称引用的 seccomp profile 所在目录（<dir>/<name>.json）
+func (s *Service) SetSecurity(defaultContext *common.SecurityContext, seccompProfilesDir string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.defaultSecurity = defaultContext
+	s.seccompProfilesDir = seccompProfilesDir
+}
+
+// applySecurityContext 将安全配置写入容器主机配置，sc 为 nil 时使用默认配置
+func (s *Service) applySecurityContext(hostConfig *container.HostConfig, sc *common.SecurityContext) error {
+	s.mu.RLock()
+	defaultContext, profilesDir := s.defaultSecurity, s.seccompProfilesDir
+	s.mu.RUnlock()
+	if sc == nil {
+		sc = defaultContext
+	}
+	if sc == nil {
+		sc = defaultSecurityContext()
+	}
+
+	if sc.ReadOnlyRootFS {
+		hostConfig.ReadonlyRootfs = true
+		hostConfig.Tmpfs = map[string]string{"/tmp": readOnlyTmpfs}
+	}
+	if !sc.AllowPrivilegeEscalation {
+		hostConfig.SecurityOpt = append(hostConfig.SecurityOpt, "no-new-privileges:true")
+	}
+	hostConfig.CapDrop = sc.DropCapabilities
+	hostConfig.CapAdd = sc.AddCapabilities
+
+	switch sc.SeccompProfile {
+	case "":
+	case "unconfined":
+		hostConfig.SecurityOpt = append(hostConfig.SecurityOpt, "seccomp=unconfined")
+	default:
+		profile, err := loadSeccompProfile(profilesDir, sc.SeccompProfile)
+		if err != nil {
+			return err
+		}
+		hostConfig.SecurityOpt = append(hostConfig.SecurityOpt, "seccomp="+profile)
+	}
+
+	if sc.AppArmorProfile != "" {
+		hostConfig.SecurityOpt = append(hostConfig.SecurityOpt, "apparmor="+sc.AppArmorProfile)
+	}
+	return nil
+}
+
+// loadSeccompProfile 读取按名称引用的 seccomp profile，Docker API 需要 profile 的 JSON 内容而非路径
+func loadSeccompProfile(dir, name string) (string, error) {
+	if dir == "" {
+		return "", fmt.Errorf("seccomp profile %s requested, but no seccomp profiles directory is configured", name)
+	}
+	// 名称已由 iarnet 校验，这里再次确保不会越出 profile 目录
+	if filepath.Base(name) != name {
+		return "", fmt.Errorf("invalid seccomp profile name %q", name)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, name+".json"))
+	if err != nil {
+		return "", fmt.Errorf("failed to read seccomp profile %s: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return "", fmt.Errorf("invalid seccomp profile %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
@@ -2,8 +2,11 @@ package provider
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 	"os/exec"
 	"slices"
 	"strconv"
@@ -11,27 +14,63 @@ import (
 	"sync"
 	"time"
 
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+	"github.com/9triver/iarnet/internal/proto/common"
 	resourcepb "github.com/9triver/iarnet/internal/proto/resource"
 	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
 	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/mount"
 	"github.com/moby/moby/api/types/network"
 	"github.com/moby/moby/client"
 	"github.com/sirupsen/logrus"
 )
 
+// capabilities 本 provider 支持的可选能力，在 Connect 握手中声明
+var capabilities = []string{
+	common.CapUndeploy, common.CapBenchmark, common.CapWatchUsage, common.CapExec,
+	common.CapPortForward, common.CapExportImage, common.CapEgressPolicy, common.CapDataStaging,
+	common.CapVolumes, common.CapSecurity, common.CapInstanceStatus, common.CapComponentUsage,
+	common.CapSidecars,
+}
+
 const providerType = "docker"
 
 type Service struct {
 	providerpb.UnimplementedServiceServer
-	mu           sync.RWMutex
-	client       *client.Client
-	manager      *Manager // 健康检查状态管理器
-	resourceTags *providerpb.ResourceTags
-	network      string // 用于部署 component 容器的网络名称
+	mu            sync.RWMutex
+	client        *client.Client
+	manager       *Manager                  // 健康检查状态管理器
+	energyProfile *providerpb.EnergyProfile // 能耗画像（可选）
+	resourceTags  *providerpb.ResourceTags
+	network       string   // 用于部署 component 容器的网络名称
+	connectToken  string   // 注册令牌，非空时拒绝令牌不一致的 Connect 请求
+	architectures []string // Docker 守护进程所在主机的 CPU 架构
+
+	// P2P 镜像分发：部署前优先从同域 provider 获取缺失的镜像
+	imagePeers      []string
+	imageShareToken string
+
+	// 数据预置：部署前将数据集下载到容器工作目录
+	staging          stagingTracker
+	stagingWorkspace string
+	stagingTimeout   time.Duration
+
+	// 允许以 host-path 方式挂载的宿主机目录
+	allowedHostPaths []string
+
+	// 容器安全配置：部署请求未携带时使用的默认配置，以及按名称引用的 seccomp profile 所在目录
+	defaultSecurity    *common.SecurityContext
+	seccompProfilesDir string
 
 	// 资源容量管理（从配置文件读取）
 	totalCapacity *resourcepb.Info // 配置的总容量
 	allocated     *resourcepb.Info // 当前已分配的容量（内存中动态维护）
+
+	// GPU 拓扑与逐卡分配（可选），未设置时 GPU 只按数量记账
+	gpus []*gpuSlot
+
+	// 拥有的数据流（可选），部署请求可按名称绑定
+	streams []Stream
 }
 
 func NewService(host, tlsCertPath string, tlsVerify bool, apiVersion string, network string, resourceTags []string, totalCapacity *resourcepb.Info) (*Service, error) {
@@ -70,6 +109,15 @@ func NewService(host, tlsCertPath string, tlsVerify bool, apiVersion string, net
 
 	logrus.Infof("Successfully connected to Docker daemon at %s", host)
 
+	// 上报守护进程所在主机的 CPU 架构，供 iarnet 按镜像架构调度
+	var architectures []string
+	if info, err := cli.Info(ctx); err != nil {
+		logrus.Warnf("Failed to get Docker daemon info, architecture will not be reported: %v", err)
+	} else if arch := types.NormalizeArchitecture(info.Architecture); arch != "" {
+		architectures = []string{arch}
+		logrus.Infof("Docker daemon architecture: %s", arch)
+	}
+
 	// 创建健康检查管理器
 	// 健康检测超时时间：90 秒（假设 iarnet 每 30 秒检测一次，允许 3 次失败）
 	// 检查间隔：10 秒
@@ -101,6 +149,7 @@ func NewService(host, tlsCertPath string, tlsVerify bool, apiVersion string, net
 		},
 		totalCapacity: totalCapacity,
 		allocated:     allocated,
+		architectures: architectures,
 	}
 
 	// 启动健康检测超时监控
@@ -137,6 +186,26 @@ func (s *Service) Connect(ctx context.Context, req *providerpb.ConnectRequest) (
 		}, nil
 	}
 
+	s.mu.RLock()
+	token := s.connectToken
+	s.mu.RUnlock()
+	if token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(req.Token)) != 1 {
+		logrus.Warnf("Rejecting connection with invalid token for provider ID %s", req.ProviderId)
+		return &providerpb.ConnectResponse{
+			Success: false,
+			Error:   "invalid connect token",
+		}, nil
+	}
+
+	// 协商协议版本：旧版 iarnet 节点不携带 ProtocolInfo，按 legacy 处理
+	if _, err := common.Negotiate(common.NewProtocolInfo(capabilities...), req.Protocol); err != nil {
+		logrus.Errorf("Rejecting connection from incompatible controller: %v", err)
+		return &providerpb.ConnectResponse{
+			Success: false,
+			Error:   fmt.Sprintf("incompatible protocol: %v", err),
+		}, nil
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -159,9 +228,28 @@ func (s *Service) Connect(ctx context.Context, req *providerpb.ConnectRequest) (
 		ProviderType: &providerpb.ProviderType{
 			Name: providerType,
 		},
+		Protocol:      common.NewProtocolInfo(capabilities...),
+		Architectures: s.architectures,
+		Capacity:      s.capacityLocked(),
 	}, nil
 }
 
+// capacityLocked 当前的总容量、已分配与可用容量（副本），未配置总容量时返回 nil，调用方需持有 mu
+func (s *Service) capacityLocked() *resourcepb.Capacity {
+	if s.totalCapacity == nil || s.allocated == nil {
+		return nil
+	}
+	return &resourcepb.Capacity{
+		Total: &resourcepb.Info{Cpu: s.totalCapacity.Cpu, Memory: s.totalCapacity.Memory, Gpu: s.totalCapacity.Gpu},
+		Used:  &resourcepb.Info{Cpu: s.allocated.Cpu, Memory: s.allocated.Memory, Gpu: s.allocated.Gpu},
+		Available: &resourcepb.Info{
+			Cpu:    s.totalCapacity.Cpu - s.allocated.Cpu,
+			Memory: s.totalCapacity.Memory - s.allocated.Memory,
+			Gpu:    s.totalCapacity.Gpu - s.allocated.Gpu,
+		},
+	}
+}
+
 func (s *Service) GetCapacity(ctx context.Context, req *providerpb.GetCapacityRequest) (*providerpb.GetCapacityResponse, error) {
 	// 鉴权：如果 provider 已连接，需要验证 provider_id；如果未连接，允许访问
 	if err := s.checkAuth(req.ProviderId, true); err != nil {
@@ -246,6 +334,20 @@ func (s *Service) GetProviderID() string {
 	return s.manager.GetProviderID()
 }
 
+// errNotConnected provider 尚未被 iarnet 节点连接
+var errNotConnected = errors.New("provider not connected, please call AssignID first")
+
+// checkConnected 检查 provider 已被 iarnet 节点连接
+// 供 peer provider 调用的 RPC 使用：peer 无从得知节点分配的 provider_id，改由共享令牌鉴权
+func (s *Service) checkConnected() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.manager.GetProviderID() == "" {
+		return errNotConnected
+	}
+	return nil
+}
+
 // checkAuth 检查鉴权
 // 如果 provider 已经被连接（有 providerID），则必须验证请求中的 provider_id 是否匹配
 // 如果 provider 没有被连接（没有 providerID），则对于 GetCapacity 和 GetAvailable 允许访问（返回 true），对于其他方法返回 false
@@ -260,7 +362,7 @@ func (s *Service) checkAuth(requestProviderID string, allowUnconnected bool) err
 			return nil
 		}
 		// 其他方法需要先连接
-		return fmt.Errorf("provider not connected, please call AssignID first")
+		return errNotConnected
 	}
 
 	// 如果 provider 已经被连接，必须验证 provider_id
@@ -292,6 +394,105 @@ func (s *Service) Deploy(ctx context.Context, req *providerpb.DeployRequest) (*p
 	// 获取 provider ID 用于标记容器
 	providerID := s.manager.GetProviderID()
 
+	if !types.ArchitectureCompatible(s.architectures, req.Architectures) {
+		return &providerpb.DeployResponse{
+			Error: fmt.Sprintf("image %s supports %v, but docker host is %v", req.Image, req.Architectures, s.architectures),
+		}, nil
+	}
+
+	// 绑定数据流的部署只能在拥有该数据流的 provider 上运行
+	var stream *Stream
+	if name := req.ResourceRequest.GetStream(); name != "" {
+		st, err := s.lookupStream(name)
+		if err != nil {
+			return &providerpb.DeployResponse{
+				Error: err.Error(),
+			}, nil
+		}
+		stream = st
+	}
+
+	// 分配前原子地复核并预留容量：调度方基于缓存的可用容量做出决策，此时使用量可能已变化
+	// 主容器与 sidecar 合并预留
+	request := withSidecarResources(req.ResourceRequest, req.Sidecars)
+	if !s.reserve(request) {
+		logrus.Warnf("Rejecting deployment %s: insufficient capacity for CPU=%d, Memory=%d, GPU=%d",
+			req.InstanceId, request.Cpu, request.Memory, request.Gpu)
+		return &providerpb.DeployResponse{
+			Error:      "insufficient capacity",
+			NoCapacity: true,
+		}, nil
+	}
+	// 已知 GPU 拓扑时为主容器与各 sidecar 分别独占分配具体的 GPU，避免多个 component 挤在同一块 GPU 上
+	gpuIDs, err := s.allocateGPUs(req.InstanceId, req.ResourceRequest.GetGpu())
+	var sidecarGPUs map[string][]string
+	if err == nil {
+		sidecarGPUs, err = s.allocateSidecarGPUs(req.InstanceId, req.Sidecars)
+	}
+	if err != nil {
+		s.ReleaseResources(request.Cpu, request.Memory, request.Gpu)
+		s.releaseGPUs(req.InstanceId)
+		logrus.Warnf("Rejecting deployment %s: %v", req.InstanceId, err)
+		return &providerpb.DeployResponse{
+			Error:      err.Error(),
+			NoCapacity: true,
+		}, nil
+	}
+	// 部署失败时归还预留的容量
+	deployed := false
+	defer func() {
+		if !deployed {
+			s.ReleaseResources(request.Cpu, request.Memory, request.Gpu)
+			s.releaseGPUs(req.InstanceId)
+		}
+	}()
+
+	// 确保镜像在本地可用（本地 -> 同域 peer -> registry）
+	if err := s.ensureImage(ctx, req.Image); err != nil {
+		logrus.Errorf("Failed to prepare image: %v", err)
+		return &providerpb.DeployResponse{
+			Error: err.Error(),
+		}, nil
+	}
+	for _, sc := range req.Sidecars {
+		if err := s.ensureImage(ctx, sc.Image); err != nil {
+			logrus.Errorf("Failed to prepare image of sidecar %s: %v", sc.Name, err)
+			return &providerpb.DeployResponse{
+				Error: fmt.Sprintf("sidecar %s: %v", sc.Name, err),
+			}, nil
+		}
+	}
+
+	// 部署前下载并校验数据源，容器启动时数据已在工作目录中
+	var stagedFiles []stagedFile
+	workspace, _ := s.stagingConfig()
+	if len(req.DataSources) > 0 {
+		dir, files, err := s.stageData(ctx, req)
+		if err != nil {
+			logrus.Errorf("Failed to stage data for %s: %v", req.InstanceId, err)
+			return &providerpb.DeployResponse{
+				Error: err.Error(),
+			}, nil
+		}
+		defer os.RemoveAll(dir)
+		stagedFiles = files
+	}
+
+	// 卷挂载：host-path 需位于允许的目录下，命名卷不存在时创建
+	var mounts []mount.Mount
+	if len(req.Volumes) > 0 {
+		var err error
+		if mounts, err = s.buildMounts(ctx, req.Volumes); err != nil {
+			logrus.Errorf("Failed to prepare volumes for %s: %v", req.InstanceId, err)
+			return &providerpb.DeployResponse{
+				Error: err.Error(),
+			}, nil
+		}
+	}
+	if len(stagedFiles) > 0 {
+		mounts = append(mounts, workspaceMount(workspace))
+	}
+
 	// 创建容器配置
 	containerConfig := &container.Config{
 		Image: req.Image,
@@ -300,11 +501,15 @@ func (s *Service) Deploy(ctx context.Context, req *providerpb.DeployRequest) (*p
 			for k, v := range req.EnvVars {
 				env = append(env, k+"="+v)
 			}
+			if len(stagedFiles) > 0 {
+				env = append(env, stagingDataDirEnv+"="+workspace)
+			}
 			return env
 		}(),
 		Labels: map[string]string{
 			"iarnet.provider_id": providerID,
 			"iarnet.managed":     "true",
+			gpuCountLabel:        strconv.FormatInt(req.ResourceRequest.GetGpu(), 10),
 		},
 	}
 
@@ -321,9 +526,26 @@ func (s *Service) Deploy(ctx context.Context, req *providerpb.DeployRequest) (*p
 		ExtraHosts: []string{
 			"host.internal:host-gateway",
 		},
+		Mounts:  mounts,
 		Runtime: "nvidia",
 		// PortBindings: portBindings,
 	}
+	if len(gpuIDs) > 0 {
+		hostConfig.DeviceRequests = gpuDeviceRequests(gpuIDs)
+		logrus.Infof("Assigning GPUs %v to %s", gpuIDs, req.InstanceId)
+	}
+	if devices := streamDevices(stream); len(devices) > 0 {
+		hostConfig.Devices = devices
+		logrus.Infof("Binding stream %s (%s) to %s", stream.Name, stream.URL, req.InstanceId)
+	}
+
+	// 安全配置：请求未携带时使用 provider 的默认配置
+	if err := s.applySecurityContext(hostConfig, req.SecurityContext); err != nil {
+		logrus.Errorf("Failed to apply security context for %s: %v", req.InstanceId, err)
+		return &providerpb.DeployResponse{
+			Error: err.Error(),
+		}, nil
+	}
 
 	// 配置网络（如果指定了网络名称）
 	var networkingConfig *network.NetworkingConfig
@@ -336,6 +558,27 @@ func (s *Service) Deploy(ctx context.Context, req *providerpb.DeployRequest) (*p
 		logrus.Infof("Deploying container to network: %s", s.network)
 	}
 
+	// 出站策略：容器以指定的 MAC 地址接入网络，启动前按该地址插入 iptables 规则，未命中放行规则的出站流量全部拒绝
+	egress := req.GetEgressPolicy()
+	var egressMAC string
+	if egress.GetEnabled() {
+		if egressMAC, err = newEgressMAC(); err != nil {
+			return &providerpb.DeployResponse{
+				Error: err.Error(),
+			}, nil
+		}
+		networkName := s.network
+		if networkName == "" {
+			networkName = "bridge"
+		}
+		hostConfig.NetworkMode = container.NetworkMode(networkName)
+		networkingConfig = &network.NetworkingConfig{
+			EndpointsConfig: map[string]*network.EndpointSettings{
+				networkName: {MacAddress: egressMAC},
+			},
+		}
+	}
+
 	// 创建容器
 	resp, err := s.client.ContainerCreate(ctx, containerConfig, hostConfig, networkingConfig, nil, req.InstanceId)
 	if err != nil {
@@ -345,24 +588,63 @@ func (s *Service) Deploy(ctx context.Context, req *providerpb.DeployRequest) (*p
 		}, nil
 	}
 
+	if len(stagedFiles) > 0 {
+		if err := s.copyStagedData(ctx, resp.ID, workspace, stagedFiles); err != nil {
+			logrus.Errorf("Failed to copy staged data into container: %v", err)
+			if rmErr := s.client.ContainerRemove(context.WithoutCancel(ctx), resp.ID, container.RemoveOptions{Force: true}); rmErr != nil {
+				logrus.Warnf("Failed to remove container %s: %v", resp.ID, rmErr)
+			}
+			return &providerpb.DeployResponse{
+				Error: fmt.Sprintf("failed to copy staged data: %v", err),
+			}, nil
+		}
+		logrus.Infof("Staged %d data sources into %s of container %s", len(stagedFiles), workspace, resp.ID)
+	}
+
+	// 出站规则在容器启动前插入，workload 从第一个数据包起即受约束；规则无法生效时不启动容器
+	var egressRules [][]string
+	if egress.GetEnabled() {
+		if egressRules, err = applyEgressPolicy(egressMAC, egress); err != nil {
+			logrus.Errorf("Failed to enforce egress policy: %v", err)
+			if rmErr := s.client.ContainerRemove(context.WithoutCancel(ctx), resp.ID, container.RemoveOptions{Force: true}); rmErr != nil {
+				logrus.Warnf("Failed to remove container %s: %v", resp.ID, rmErr)
+			}
+			return &providerpb.DeployResponse{
+				Error: err.Error(),
+			}, nil
+		}
+		logrus.Infof("Applied %d egress rules to container %s", len(egressRules), resp.ID)
+	}
+
 	// 启动容器
 	err = s.client.ContainerStart(ctx, resp.ID, container.StartOptions{})
 	if err != nil {
 		logrus.Errorf("Failed to start container: %v", err)
+		removeEgressRules(egressRules)
 		return &providerpb.DeployResponse{
 			Error: err.Error(),
 		}, nil
 	}
+	if len(egressRules) > 0 {
+		go s.cleanupEgressOnExit(resp.ID, egressRules)
+	}
 
-	// 更新已分配的资源容量（在内存中维护）
-	s.mu.Lock()
-	s.allocated.Cpu += req.ResourceRequest.Cpu
-	s.allocated.Memory += req.ResourceRequest.Memory
-	s.allocated.Gpu += req.ResourceRequest.Gpu
-	s.mu.Unlock()
+	if len(req.Sidecars) > 0 {
+		if err := s.startSidecars(ctx, req, resp.ID, sidecarGPUs); err != nil {
+			logrus.Errorf("Failed to start sidecars of %s: %v", req.InstanceId, err)
+			if rmErr := s.client.ContainerRemove(context.WithoutCancel(ctx), resp.ID, container.RemoveOptions{Force: true}); rmErr != nil {
+				logrus.Warnf("Failed to remove container %s: %v", resp.ID, rmErr)
+			}
+			return &providerpb.DeployResponse{
+				Error: err.Error(),
+			}, nil
+		}
+	}
+
+	deployed = true
 
-	logrus.Infof("Container deployed successfully with ID: %s, allocated resources: CPU=%d, Memory=%d, GPU=%d",
-		resp.ID, req.ResourceRequest.Cpu, req.ResourceRequest.Memory, req.ResourceRequest.Gpu)
+	logrus.Infof("Container deployed successfully with ID: %s, %d sidecars, allocated resources: CPU=%d, Memory=%d, GPU=%d",
+		resp.ID, len(req.Sidecars), request.Cpu, request.Memory, request.Gpu)
 	return &providerpb.DeployResponse{
 		Error: "",
 	}, nil
@@ -413,12 +695,37 @@ func (s *Service) HealthCheck(ctx context.Context, req *providerpb.HealthCheckRe
 	// }
 	resourceTags := s.resourceTags
 
+	s.mu.RLock()
+	energyProfile := s.energyProfile
+	s.mu.RUnlock()
+
 	return &providerpb.HealthCheckResponse{
-		Capacity:     capacity,
-		ResourceTags: resourceTags,
+		Capacity:      capacity,
+		ResourceTags:  resourceTags,
+		EnergyProfile: energyProfile,
+		Architectures: s.architectures,
+		Gpus:          s.gpuDevicesProto(),
+		Streams:       s.streamsProto(),
 	}, nil
 }
 
+// SetConnectToken 设置注册令牌，iarnet 节点连接时需出示一致的令牌
+func (s *Service) SetConnectToken(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.connectToken = token
+}
+
+// SetEnergyProfile 设置通过健康检查上报的能耗画像
+func (s *Service) SetEnergyProfile(wattsPerCore float64, batteryPowered bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.energyProfile = &providerpb.EnergyProfile{
+		WattsPerCore:   wattsPerCore,
+		BatteryPowered: batteryPowered,
+	}
+}
+
 func (s *Service) Disconnect(ctx context.Context, req *providerpb.DisconnectRequest) (*providerpb.DisconnectResponse, error) {
 	// 鉴权：Disconnect 必须验证 provider_id，不允许未连接的 provider 断开连接
 	if err := s.checkAuth(req.ProviderId, false); err != nil {
@@ -437,6 +744,25 @@ func (s *Service) Disconnect(ctx context.Context, req *providerpb.DisconnectRequ
 	return &providerpb.DisconnectResponse{}, nil
 }
 
+// reserve 在同一把锁内复核剩余容量并累加已分配容量，容量不足时不做任何修改并返回 false
+// 未配置总容量时不做检查
+func (s *Service) reserve(request *resourcepb.Info) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if total := s.totalCapacity; total != nil {
+		if s.allocated.Cpu+request.Cpu > total.Cpu ||
+			s.allocated.Memory+request.Memory > total.Memory ||
+			s.allocated.Gpu+request.Gpu > total.Gpu {
+			return false
+		}
+	}
+	s.allocated.Cpu += request.Cpu
+	s.allocated.Memory += request.Memory
+	s.allocated.Gpu += request.Gpu
+	return true
+}
+
 // ReleaseResources 释放已分配的资源（当容器停止或删除时调用）
 // 这是一个内部方法，用于在容器停止/删除时释放资源
 func (s *Service) ReleaseResources(cpu, memory, gpu int64) {
@@ -536,54 +862,13 @@ func (s *Service) GetRealTimeUsage(ctx context.Context, req *providerpb.GetRealT
 				return
 			}
 
-			// 使用流式 API 获取实时统计信息
-			// 需要获取两个时间点的数据才能准确计算 CPU 使用率
-			stats, err := s.client.ContainerStats(containerCtx, containerID, true) // stream=true 启用流式 API
+			secondStats, err := sampleContainerStats(containerCtx, s.client, containerID)
 			if err != nil {
 				logrus.Warnf("Failed to get stats for container %s: %v", containerID, err)
 				usageChan <- containerUsage{0, 0, 0}
 				return
 			}
 
-			// 创建带超时的上下文，用于读取统计流
-			statsCtx, statsCancel := context.WithTimeout(containerCtx, 3*time.Second)
-
-			decoder := json.NewDecoder(stats.Body)
-			var firstStats *container.StatsResponse
-			var secondStats *container.StatsResponse
-
-			// 读取第一个数据点
-			var v1 container.StatsResponse
-			if err := decoder.Decode(&v1); err != nil {
-				stats.Body.Close()
-				statsCancel()
-				logrus.Warnf("Failed to decode first stats for container %s: %v", containerID, err)
-				usageChan <- containerUsage{0, 0, 0}
-				return
-			}
-			firstStats = &v1
-
-			// 等待一段时间（1秒）以获取第二个数据点，用于计算 CPU 使用率
-			select {
-			case <-time.After(1 * time.Second):
-				// 读取第二个数据点
-				var v2 container.StatsResponse
-				if err := decoder.Decode(&v2); err != nil {
-					logrus.Warnf("Failed to decode second stats for container %s: %v", containerID, err)
-					// 如果无法获取第二个数据点，使用第一个数据点的内存信息
-					secondStats = firstStats
-				} else {
-					secondStats = &v2
-				}
-			case <-statsCtx.Done():
-				logrus.Warnf("Timeout waiting for second stats for container %s", containerID)
-				secondStats = firstStats
-			}
-
-			// 关闭统计流和取消上下文
-			stats.Body.Close()
-			statsCancel()
-
 			// 使用第二个数据点（包含 PreCPUStats）来计算 CPU 使用率
 			containerCpu := calculateRealTimeCPU(secondStats, containerInfo)
 
@@ -627,6 +912,44 @@ func (s *Service) GetRealTimeUsage(ctx context.Context, req *providerpb.GetRealT
 	}, nil
 }
 
+// sampleContainerStats 使用流式 Stats API 读取容器的两个相邻数据点
+// 返回的第二个数据点携带 PreCPUStats，可用于计算 CPU 使用率；无法获取第二个数据点时退化为第一个
+func sampleContainerStats(ctx context.Context, cli *client.Client, containerID string) (*container.StatsResponse, error) {
+	// 需要获取两个时间点的数据才能准确计算 CPU 使用率
+	stats, err := cli.ContainerStats(ctx, containerID, true) // stream=true 启用流式 API
+	if err != nil {
+		return nil, err
+	}
+	defer stats.Body.Close()
+
+	// 创建带超时的上下文，用于读取统计流
+	statsCtx, statsCancel := context.WithTimeout(ctx, 3*time.Second)
+	defer statsCancel()
+
+	decoder := json.NewDecoder(stats.Body)
+
+	// 读取第一个数据点
+	var first container.StatsResponse
+	if err := decoder.Decode(&first); err != nil {
+		return nil, fmt.Errorf("failed to decode first stats: %w", err)
+	}
+
+	// 等待一段时间（1秒）以获取第二个数据点，用于计算 CPU 使用率
+	select {
+	case <-time.After(1 * time.Second):
+		var second container.StatsResponse
+		if err := decoder.Decode(&second); err != nil {
+			// 如果无法获取第二个数据点，使用第一个数据点的内存信息
+			logrus.Warnf("Failed to decode second stats for container %s: %v", containerID, err)
+			return &first, nil
+		}
+		return &second, nil
+	case <-statsCtx.Done():
+		logrus.Warnf("Timeout waiting for second stats for container %s", containerID)
+		return &first, nil
+	}
+}
+
 // calculateRealTimeCPU 计算容器实时使用的 CPU（millicores）
 // 直接使用 Docker stats API 返回的实际 CPU 使用数据，不基于分配资源计算
 // 计算方式与 docker stats 命令和 Docker Desktop 完全一致
@@ -678,20 +1001,7 @@ func calculateRealTimeCPU(v *container.StatsResponse, containerInfo container.In
 // 否则回退到统计分配的 GPU 设备数量
 func getContainerGPUUsage(containerID string, containerInfo container.InspectResponse) int64 {
 	// 首先检查容器是否分配了 GPU
-	var gpuCount int64
-	if containerInfo.HostConfig != nil && len(containerInfo.HostConfig.DeviceRequests) > 0 {
-		for _, dr := range containerInfo.HostConfig.DeviceRequests {
-			// 检查是否是 GPU 设备请求
-			if dr.Driver != "" && strings.Contains(strings.ToLower(dr.Driver), "nvidia") {
-				// 统计分配的 GPU 数量
-				if len(dr.DeviceIDs) > 0 {
-					gpuCount += int64(len(dr.DeviceIDs))
-				} else if dr.Count > 0 {
-					gpuCount += int64(dr.Count)
-				}
-			}
-		}
-	}
+	gpuCount := allocatedGPUs(containerInfo)
 
 	// 如果没有分配 GPU，返回 0
 	if gpuCount == 0 {
@@ -710,6 +1020,26 @@ func getContainerGPUUsage(containerID string, containerInfo container.InspectRes
 	return containerGPUUsage
 }
 
+// allocatedGPUs 统计容器分配的 GPU 设备数量
+func allocatedGPUs(containerInfo container.InspectResponse) int64 {
+	var gpuCount int64
+	if containerInfo.HostConfig == nil {
+		return 0
+	}
+	for _, dr := range containerInfo.HostConfig.DeviceRequests {
+		// 检查是否是 GPU 设备请求
+		if dr.Driver != "" && strings.Contains(strings.ToLower(dr.Driver), "nvidia") {
+			// 统计分配的 GPU 数量
+			if len(dr.DeviceIDs) > 0 {
+				gpuCount += int64(len(dr.DeviceIDs))
+			} else if dr.Count > 0 {
+				gpuCount += int64(dr.Count)
+			}
+		}
+	}
+	return gpuCount
+}
+
 // getContainerGPUUsageFromNvidiaSMI 通过 nvidia-smi 获取容器进程的实际 GPU 使用量
 // 通过查询容器内的进程 PID 来匹配 GPU 使用情况
 func getContainerGPUUsageFromNvidiaSMI(containerID string, containerInfo container.InspectResponse) (int64, error) {
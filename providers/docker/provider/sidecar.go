@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/9triver/iarnet/internal/proto/common"
+	resourcepb "github.com/9triver/iarnet/internal/proto/resource"
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/filters"
+	"github.com/sirupsen/logrus"
+)
+
+// sidecarLabel sidecar 容器上记录所属 component 实例 ID 的标签
+const sidecarLabel = "iarnet.sidecar_of"
+
+// sidecarContainerName sidecar 容器名：<实例 ID>.<sidecar 名称>
+func sidecarContainerName(instanceID, name string) string {
+	return instanceID + "." + name
+}
+
+// withSidecarResources 主容器与 sidecar 的资源合计，Deploy 按合计预留容量
+func withSidecarResources(request *resourcepb.Info, sidecars []*common.Sidecar) *resourcepb.Info {
+	total := &resourcepb.Info{Cpu: request.GetCpu(), Memory: request.GetMemory(), Gpu: request.GetGpu()}
+	for _, sc := range sidecars {
+		total.Cpu += sc.GetCPU()
+		total.Memory += sc.GetMemory()
+		total.Gpu += sc.GetGPU()
+	}
+	return total
+}
+
+// allocateSidecarGPUs 已知 GPU 拓扑时为申请 GPU 的 sidecar 逐个独占分配 GPU，返回 sidecar 名称 -> 设备 ID
+// GPU 记在实例名下，随实例一同归还；分配失败时由调用方归还实例已分配的 GPU
+func (s *Service) allocateSidecarGPUs(instanceID string, sidecars []*common.Sidecar) (map[string][]string, error) {
+	ids := make(map[string][]string)
+	for _, sc := range sidecars {
+		devices, err := s.allocateGPUs(instanceID, sc.GetGPU())
+		if err != nil {
+			return nil, fmt.Errorf("sidecar %s: %w", sc.GetName(), err)
+		}
+		if len(devices) > 0 {
+			ids[sc.GetName()] = devices
+		}
+	}
+	return ids, nil
+}
+
+// startSidecars 在主容器启动后创建并启动 sidecar 容器，gpuIDs 为各 sidecar 分配的 GPU
+// sidecar 加入主容器的网络命名空间，可通过 localhost 访问主容器；任一 sidecar 失败时删除已创建的 sidecar
+func (s *Service) startSidecars(ctx context.Context, req *providerpb.DeployRequest, mainID string, gpuIDs map[string][]string) error {
+	var created []string
+	cleanup := func() {
+		for _, id := range created {
+			if err := s.client.ContainerRemove(context.WithoutCancel(ctx), id, container.RemoveOptions{Force: true}); err != nil {
+				logrus.Warnf("Failed to remove sidecar container %s: %v", id, err)
+			}
+		}
+	}
+
+	for _, sc := range req.Sidecars {
+		env := make([]string, 0, len(sc.Env)+1)
+		for k, v := range sc.Env {
+			env = append(env, k+"="+v)
+		}
+		if componentID, ok := req.EnvVars["COMPONENT_ID"]; ok {
+			env = append(env, "COMPONENT_ID="+componentID)
+		}
+
+		config := &container.Config{
+			Image: sc.Image,
+			Env:   env,
+			Labels: map[string]string{
+				"iarnet.provider_id": s.GetProviderID(),
+				"iarnet.managed":     "true",
+				sidecarLabel:         req.InstanceId,
+				gpuCountLabel:        strconv.FormatInt(sc.GPU, 10),
+			},
+		}
+		if len(sc.Command) > 0 {
+			config.Entrypoint = sc.Command
+		}
+		mounts, err := s.buildMounts(ctx, sc.Volumes)
+		if err != nil {
+			cleanup()
+			return fmt.Errorf("sidecar %s: %w", sc.Name, err)
+		}
+		hostConfig := &container.HostConfig{
+			Resources: container.Resources{
+				NanoCPUs: sc.CPU * 1e6,
+				Memory:   sc.Memory,
+			},
+			NetworkMode: container.NetworkMode("container:" + mainID),
+			Mounts:      mounts,
+		}
+		if sc.GPU > 0 {
+			hostConfig.Runtime = "nvidia"
+			if ids := gpuIDs[sc.Name]; len(ids) > 0 {
+				hostConfig.DeviceRequests = gpuDeviceRequests(ids)
+				logrus.Infof("Assigning GPUs %v to sidecar %s of %s", ids, sc.Name, req.InstanceId)
+			}
+		}
+		if err := s.applySecurityContext(hostConfig, req.SecurityContext); err != nil {
+			cleanup()
+			return fmt.Errorf("sidecar %s: %w", sc.Name, err)
+		}
+
+		resp, err := s.client.ContainerCreate(ctx, config, hostConfig, nil, nil, sidecarContainerName(req.InstanceId, sc.Name))
+		if err != nil {
+			cleanup()
+			return fmt.Errorf("failed to create sidecar %s: %w", sc.Name, err)
+		}
+		created = append(created, resp.ID)
+		if err := s.client.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
+			cleanup()
+			return fmt.Errorf("failed to start sidecar %s: %w", sc.Name, err)
+		}
+	}
+	return nil
+}
+
+// removeSidecars 删除实例的 sidecar 容器并释放其资源
+func (s *Service) removeSidecars(ctx context.Context, instanceID string) {
+	containers, err := s.client.ContainerList(ctx, container.ListOptions{
+		All: true,
+		Filters: filters.NewArgs(
+			filters.Arg("label", "iarnet.provider_id="+s.GetProviderID()),
+			filters.Arg("label", sidecarLabel+"="+instanceID),
+		),
+	})
+	if err != nil {
+		logrus.Warnf("Failed to list sidecars of %s: %v", instanceID, err)
+		return
+	}
+	for _, c := range containers {
+		info, err := s.client.ContainerInspect(ctx, c.ID)
+		if err != nil {
+			logrus.Warnf("Failed to inspect sidecar container %s: %v", c.ID, err)
+			continue
+		}
+		if err := s.client.ContainerRemove(ctx, c.ID, container.RemoveOptions{Force: true, RemoveVolumes: true}); err != nil {
+			logrus.Warnf("Failed to remove sidecar container %s: %v", c.ID, err)
+			continue
+		}
+		if info.HostConfig != nil {
+			s.ReleaseResources(info.HostConfig.NanoCPUs/1e6, info.HostConfig.Memory, containerGPUs(info))
+		}
+	}
+}
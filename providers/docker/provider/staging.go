@@ -0,0 +1,343 @@
+package provider
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/9triver/iarnet/internal/proto/common"
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+	storepb "github.com/9triver/iarnet/internal/proto/resource/store"
+	"github.com/9triver/iarnet/internal/util/identity"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/mount"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// 数据预置的默认配置
+const (
+	defaultStagingWorkspace = "/workspace"
+	defaultStagingTimeout   = 10 * time.Minute
+)
+
+// stagingDataDirEnv 注入容器的环境变量，指向预置数据所在的工作目录
+const stagingDataDirEnv = "IARNET_DATA_DIR"
+
+// 数据源的预置状态
+const (
+	stagingPending     = "pending"
+	stagingDownloading = "downloading"
+	stagingVerifying   = "verifying"
+	stagingDone        = "done"
+	stagingFailed      = "failed"
+)
+
+// stagingTracker 记录各实例的数据预置进度，保留到实例被删除，便于部署完成后查询结果
+type stagingTracker struct {
+	mu        sync.Mutex
+	instances map[string][]*providerpb.StagingProgress
+}
+
+func (t *stagingTracker) start(instanceID string, sources []*common.DataSource) []*providerpb.StagingProgress {
+	items := make([]*providerpb.StagingProgress, 0, len(sources))
+	for _, source := range sources {
+		items = append(items, &providerpb.StagingProgress{Path: source.GetPath(), State: stagingPending})
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.instances == nil {
+		t.instances = make(map[string][]*providerpb.StagingProgress)
+	}
+	t.instances[instanceID] = items
+	return items
+}
+
+// update 在锁内修改进度，避免与查询并发读写
+func (t *stagingTracker) update(item *providerpb.StagingProgress, fn func(*providerpb.StagingProgress)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fn(item)
+}
+
+func (t *stagingTracker) snapshot(instanceID string) ([]*providerpb.StagingProgress, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	items, ok := t.instances[instanceID]
+	if !ok {
+		return nil, false
+	}
+	result := make([]*providerpb.StagingProgress, 0, len(items))
+	for _, item := range items {
+		result = append(result, &providerpb.StagingProgress{
+			Path:       item.Path,
+			State:      item.State,
+			BytesDone:  item.BytesDone,
+			BytesTotal: item.BytesTotal,
+			Error:      item.Error,
+		})
+	}
+	return result, true
+}
+
+func (t *stagingTracker) remove(instanceID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.instances, instanceID)
+}
+
+// SetStaging 设置数据预置的工作目录与单个数据源的下载超时，零值使用默认配置
+func (s *Service) SetStaging(workspace string, timeout time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stagingWorkspace = workspace
+	s.stagingTimeout = timeout
+}
+
+func (s *Service) stagingConfig() (string, time.Duration) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	workspace, timeout := s.stagingWorkspace, s.stagingTimeout
+	if workspace == "" {
+		workspace = defaultStagingWorkspace
+	}
+	if timeout <= 0 {
+		timeout = defaultStagingTimeout
+	}
+	return workspace, timeout
+}
+
+// stagedFile 已下载并校验到本地临时目录的数据源
+type stagedFile struct {
+	path  string // 工作目录内的相对路径
+	local string // 本地临时文件
+	size  int64
+}
+
+// stageData 将数据源依次下载到本地临时目录并校验，任一数据源失败即返回错误
+// 返回的临时目录由调用方在拷贝进容器后删除
+func (s *Service) stageData(ctx context.Context, req *providerpb.DeployRequest) (string, []stagedFile, error) {
+	items := s.staging.start(req.InstanceId, req.DataSources)
+	_, timeout := s.stagingConfig()
+
+	// 以 component 身份回连 store，启用上游令牌时由 store 校验
+	ctx = identity.WithComponentToken(ctx, req.EnvVars["COMPONENT_ID"], req.EnvVars[identity.UpstreamTokenEnv])
+
+	dir, err := os.MkdirTemp("", "iarnet-staging-")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+
+	files := make([]stagedFile, 0, len(req.DataSources))
+	for i, source := range req.DataSources {
+		item := items[i]
+		local := path.Join(dir, fmt.Sprintf("%d", i))
+		size, err := s.stageOne(ctx, timeout, req.DataStoreAddress, source, local, item)
+		if err != nil {
+			s.staging.update(item, func(p *providerpb.StagingProgress) {
+				p.State = stagingFailed
+				p.Error = err.Error()
+			})
+			os.RemoveAll(dir)
+			return "", nil, fmt.Errorf("failed to stage %s: %w", source.GetPath(), err)
+		}
+		s.staging.update(item, func(p *providerpb.StagingProgress) { p.State = stagingDone })
+		files = append(files, stagedFile{path: path.Clean(source.GetPath()), local: local, size: size})
+	}
+	return dir, files, nil
+}
+
+// stageOne 下载单个数据源到 local，并在指定了校验和时校验
+func (s *Service) stageOne(ctx context.Context, timeout time.Duration, storeAddr string, source *common.DataSource, local string, item *providerpb.StagingProgress) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	s.staging.update(item, func(p *providerpb.StagingProgress) { p.State = stagingDownloading })
+
+	file, err := os.Create(local)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	w := &progressWriter{tracker: &s.staging, item: item}
+	if source.GetURL() != "" {
+		err = s.downloadURL(ctx, source.GetURL(), io.MultiWriter(file, hash, w), item)
+	} else {
+		err = s.downloadObject(ctx, storeAddr, source.GetObjectID(), io.MultiWriter(file, hash, w), item)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if source.GetSHA256() != "" {
+		s.staging.update(item, func(p *providerpb.StagingProgress) { p.State = stagingVerifying })
+		if sum := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(sum, source.GetSHA256()) {
+			return 0, fmt.Errorf("sha256 mismatch: expected %s, got %s", source.GetSHA256(), sum)
+		}
+	}
+	return w.written, nil
+}
+
+func (s *Service) downloadURL(ctx context.Context, url string, w io.Writer, item *providerpb.StagingProgress) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	if resp.ContentLength > 0 {
+		s.staging.update(item, func(p *providerpb.StagingProgress) { p.BytesTotal = resp.ContentLength })
+	}
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// downloadObject 从应用 store 拉取对象，流式对象不支持预置
+func (s *Service) downloadObject(ctx context.Context, storeAddr, objectID string, w io.Writer, item *providerpb.StagingProgress) error {
+	if storeAddr == "" {
+		return fmt.Errorf("store address is required to stage object %s", objectID)
+	}
+	conn, err := grpc.NewClient(storeAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("failed to connect to store %s: %w", storeAddr, err)
+	}
+	defer conn.Close()
+
+	resp, err := storepb.NewServiceClient(conn).GetObject(ctx, &storepb.GetObjectRequest{
+		ObjectRef: &common.ObjectRef{ID: objectID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get object %s: %w", objectID, err)
+	}
+	object := resp.GetObject()
+	if object == nil {
+		return fmt.Errorf("object %s not found", objectID)
+	}
+	if object.IsStream {
+		return fmt.Errorf("object %s is a stream and cannot be staged", objectID)
+	}
+	if err := object.Verify(); err != nil {
+		return err
+	}
+	s.staging.update(item, func(p *providerpb.StagingProgress) { p.BytesTotal = int64(len(object.Data)) })
+	_, err = w.Write(object.Data)
+	return err
+}
+
+// progressWriter 统计已写入的字节数并更新进度
+type progressWriter struct {
+	tracker *stagingTracker
+	item    *providerpb.StagingProgress
+	written int64
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.written += int64(len(p))
+	done := w.written
+	w.tracker.update(w.item, func(item *providerpb.StagingProgress) { item.BytesDone = done })
+	return len(p), nil
+}
+
+// workspaceMount 预置数据时挂载到工作目录的匿名卷，随容器删除
+// 数据写入卷而非容器根文件系统，只读根文件系统的容器同样可以预置数据
+func workspaceMount(workspace string) mount.Mount {
+	return mount.Mount{Type: mount.TypeVolume, Target: workspace}
+}
+
+// copyStagedData 将已预置的文件打包为 tar 拷贝进尚未启动的容器的工作目录
+func (s *Service) copyStagedData(ctx context.Context, containerID, workspace string, files []stagedFile) error {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeStagingTar(pw, files))
+	}()
+	err := s.client.CopyToContainer(ctx, containerID, workspace, pr, container.CopyToContainerOptions{})
+	pr.Close()
+	return err
+}
+
+// writeStagingTar 以工作目录内的相对路径打包文件，并补齐中间目录
+func writeStagingTar(w io.Writer, files []stagedFile) error {
+	tw := tar.NewWriter(w)
+	dirs := make(map[string]struct{})
+	addDir := func(dir string) error {
+		var parents []string
+		for d := dir; d != "." && d != ""; d = path.Dir(d) {
+			if _, ok := dirs[d]; ok {
+				break
+			}
+			parents = append(parents, d)
+		}
+		for i := len(parents) - 1; i >= 0; i-- {
+			dirs[parents[i]] = struct{}{}
+			if err := tw.WriteHeader(&tar.Header{
+				Typeflag: tar.TypeDir,
+				Name:     parents[i] + "/",
+				Mode:     0o755,
+				ModTime:  time.Now(),
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, f := range files {
+		name := f.path
+		if err := addDir(path.Dir(name)); err != nil {
+			return err
+		}
+		if err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Mode:     0o644,
+			Size:     f.size,
+			ModTime:  time.Now(),
+		}); err != nil {
+			return err
+		}
+		file, err := os.Open(f.local)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(tw, file)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// GetStagingStatus 查询实例的数据预置进度
+func (s *Service) GetStagingStatus(ctx context.Context, req *providerpb.GetStagingStatusRequest) (*providerpb.GetStagingStatusResponse, error) {
+	if err := s.checkAuth(req.ProviderId, false); err != nil {
+		return &providerpb.GetStagingStatusResponse{
+			Error: fmt.Sprintf("authentication failed: %v", err),
+		}, nil
+	}
+
+	items, ok := s.staging.snapshot(req.InstanceId)
+	if !ok {
+		return &providerpb.GetStagingStatusResponse{
+			Error: fmt.Sprintf("no data staging recorded for instance %s", req.InstanceId),
+		}, nil
+	}
+	return &providerpb.GetStagingStatusResponse{Items: items}, nil
+}
@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+	"github.com/moby/moby/api/types/container"
+)
+
+// Stream 本机摄像头（V4L2 设备）或主机可访问的网络摄像头（RTSP），通过健康检查上报，部署请求按名称绑定
+type Stream struct {
+	Name string
+	Kind string // rtsp / v4l2
+	URL  string // RTSP 地址或 V4L2 设备路径
+}
+
+// SetStreams 设置本 provider 拥有的数据流
+func (s *Service) SetStreams(streams []Stream) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.streams = append([]Stream(nil), streams...)
+}
+
+// streamsProto 健康检查上报的数据流
+func (s *Service) streamsProto() []*providerpb.MediaStream {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var out []*providerpb.MediaStream
+	for _, st := range s.streams {
+		out = append(out, &providerpb.MediaStream{Name: st.Name, Kind: st.Kind, Url: st.URL})
+	}
+	return out
+}
+
+// lookupStream 查找部署请求绑定的数据流
+func (s *Service) lookupStream(name string) (*Stream, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for i := range s.streams {
+		if s.streams[i].Name == name {
+			st := s.streams[i]
+			return &st, nil
+		}
+	}
+	return nil, fmt.Errorf("stream %s is not available on this provider", name)
+}
+
+// streamDevices V4L2 数据流的设备映射，容器内使用与宿主机相同的路径；RTSP 数据流通过网络访问，不需要映射
+func streamDevices(st *Stream) []container.DeviceMapping {
+	if st == nil || st.Kind != types.StreamKindV4L2 {
+		return nil
+	}
+	return []container.DeviceMapping{{PathOnHost: st.URL, PathInContainer: st.URL, CgroupPermissions: "rwm"}}
+}
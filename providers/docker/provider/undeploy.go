@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+	"github.com/moby/moby/api/types/container"
+	"github.com/sirupsen/logrus"
+)
+
+// Undeploy 停止并删除 component 容器及其 sidecar，释放其占用的已分配资源
+func (s *Service) Undeploy(ctx context.Context, req *providerpb.UndeployRequest) (*providerpb.UndeployResponse, error) {
+	if err := s.checkAuth(req.ProviderId, false); err != nil {
+		return &providerpb.UndeployResponse{
+			Error: fmt.Sprintf("authentication failed: %v", err),
+		}, nil
+	}
+
+	info, err := s.client.ContainerInspect(ctx, req.InstanceId)
+	if err != nil {
+		return &providerpb.UndeployResponse{
+			Error: fmt.Sprintf("container %s not found: %v", req.InstanceId, err),
+		}, nil
+	}
+	if info.Config == nil || info.Config.Labels["iarnet.provider_id"] != s.GetProviderID() {
+		return &providerpb.UndeployResponse{
+			Error: fmt.Sprintf("container %s is not managed by this provider", req.InstanceId),
+		}, nil
+	}
+
+	if err := s.client.ContainerRemove(ctx, info.ID, container.RemoveOptions{Force: true, RemoveVolumes: true}); err != nil {
+		logrus.Errorf("Failed to remove container %s: %v", req.InstanceId, err)
+		return &providerpb.UndeployResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	// 与 Deploy 中的换算相反：1 millicore = 1e6 NanoCPUs
+	if info.HostConfig != nil {
+		s.ReleaseResources(info.HostConfig.NanoCPUs/1e6, info.HostConfig.Memory, containerGPUs(info))
+	}
+	s.releaseGPUs(req.InstanceId)
+
+	s.removeSidecars(ctx, req.InstanceId)
+	s.staging.remove(req.InstanceId)
+
+	logrus.Infof("Container %s undeployed", req.InstanceId)
+	return &providerpb.UndeployResponse{}, nil
+}
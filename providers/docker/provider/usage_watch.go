@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	resourcepb "github.com/9triver/iarnet/internal/proto/resource"
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// defaultUsageWatchInterval 未指定采样间隔时的默认值
+	defaultUsageWatchInterval = 2 * time.Second
+	// minUsageWatchInterval 允许的最小采样间隔，避免过于频繁地查询 Docker
+	minUsageWatchInterval = 500 * time.Millisecond
+)
+
+// WatchUsage 定期采样资源使用情况与容量，仅在发生变化时推送
+// 第一条推送包含使用情况与容量，之后只推送发生变化的部分
+func (s *Service) WatchUsage(req *providerpb.WatchUsageRequest, stream providerpb.Service_WatchUsageServer) error {
+	if err := s.checkAuth(req.ProviderId, false); err != nil {
+		return fmt.Errorf("authentication failed: %w", err)
+	}
+
+	interval := defaultUsageWatchInterval
+	if req.MinIntervalMs > 0 {
+		interval = max(time.Duration(req.MinIntervalMs)*time.Millisecond, minUsageWatchInterval)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	ctx := stream.Context()
+	var lastUsage *resourcepb.Info
+	var lastCapacity *resourcepb.Capacity
+	for {
+		usageResp, err := s.GetRealTimeUsage(ctx, &providerpb.GetRealTimeUsageRequest{ProviderId: req.ProviderId})
+		if err != nil {
+			return err
+		}
+		capacityResp, err := s.GetCapacity(ctx, &providerpb.GetCapacityRequest{ProviderId: req.ProviderId})
+		if err != nil {
+			return err
+		}
+
+		update := &providerpb.UsageUpdate{}
+		if !proto.Equal(usageResp.Usage, lastUsage) {
+			lastUsage = proto.Clone(usageResp.Usage).(*resourcepb.Info)
+			update.Usage = lastUsage
+		}
+		if !proto.Equal(capacityResp.Capacity, lastCapacity) {
+			// 容量中的 Used 与服务内部状态共享且会被原地修改，保存副本用于比较和发送
+			lastCapacity = proto.Clone(capacityResp.Capacity).(*resourcepb.Capacity)
+			update.Capacity = lastCapacity
+		}
+		if update.Usage != nil || update.Capacity != nil {
+			if err := stream.Send(update); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
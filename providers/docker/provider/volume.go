@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/9triver/iarnet/internal/proto/common"
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+	"github.com/moby/moby/api/types/filters"
+	"github.com/moby/moby/api/types/mount"
+	"github.com/moby/moby/api/types/volume"
+	"github.com/sirupsen/logrus"
+)
+
+// volumeManagedLabel 标记由 iarnet 创建的命名卷，ListVolumes/DeleteVolume 只处理带该标签的卷
+const volumeManagedLabel = "iarnet.managed"
+
+// SetAllowedHostPaths 设置允许以 host-path 方式挂载的宿主机目录，为空时拒绝所有 host-path 挂载
+func (s *Service) SetAllowedHostPaths(paths []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.allowedHostPaths = paths
+}
+
+// hostPathAllowed 判断宿主机路径是否位于允许的目录之下
+func (s *Service) hostPathAllowed(hostPath string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	clean := filepath.Clean(hostPath)
+	for _, allowed := range s.allowedHostPaths {
+		rel, err := filepath.Rel(filepath.Clean(allowed), clean)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, "../") {
+			return true
+		}
+	}
+	return false
+}
+
+// buildMounts 将部署请求中的卷转换为容器挂载，命名卷不存在时创建
+func (s *Service) buildMounts(ctx context.Context, volumes []*common.VolumeMount) ([]mount.Mount, error) {
+	mounts := make([]mount.Mount, 0, len(volumes))
+	for _, v := range volumes {
+		m := mount.Mount{
+			Target:   v.GetMountPath(),
+			ReadOnly: v.GetReadOnly(),
+		}
+		if v.GetHostPath() != "" {
+			if !s.hostPathAllowed(v.GetHostPath()) {
+				return nil, fmt.Errorf("host path %s is not allowed on this provider", v.GetHostPath())
+			}
+			m.Type = mount.TypeBind
+			m.Source = v.GetHostPath()
+		} else {
+			if _, err := s.createVolume(ctx, v.GetName()); err != nil {
+				return nil, err
+			}
+			m.Type = mount.TypeVolume
+			m.Source = v.GetName()
+		}
+		mounts = append(mounts, m)
+	}
+	return mounts, nil
+}
+
+// createVolume 创建带 iarnet 标签的本地命名卷，卷已存在时 Docker 返回现有的卷
+func (s *Service) createVolume(ctx context.Context, name string) (volume.Volume, error) {
+	vol, err := s.client.VolumeCreate(ctx, volume.CreateOptions{
+		Name:   name,
+		Driver: "local",
+		Labels: map[string]string{volumeManagedLabel: "true"},
+	})
+	if err != nil {
+		return volume.Volume{}, fmt.Errorf("failed to create volume %s: %w", name, err)
+	}
+	return vol, nil
+}
+
+func volumeToProto(v volume.Volume) *providerpb.Volume {
+	pb := &providerpb.Volume{Name: v.Name}
+	if createdAt, err := time.Parse(time.RFC3339Nano, v.CreatedAt); err == nil {
+		pb.CreatedAt = createdAt.Unix()
+	}
+	return pb
+}
+
+// CreateVolume 创建命名卷
+func (s *Service) CreateVolume(ctx context.Context, req *providerpb.CreateVolumeRequest) (*providerpb.CreateVolumeResponse, error) {
+	if err := s.checkAuth(req.ProviderId, false); err != nil {
+		return &providerpb.CreateVolumeResponse{
+			Error: fmt.Sprintf("authentication failed: %v", err),
+		}, nil
+	}
+
+	vol, err := s.createVolume(ctx, req.Name)
+	if err != nil {
+		return &providerpb.CreateVolumeResponse{Error: err.Error()}, nil
+	}
+	logrus.Infof("Volume %s created", req.Name)
+	return &providerpb.CreateVolumeResponse{Volume: volumeToProto(vol)}, nil
+}
+
+// ListVolumes 列出由 iarnet 创建的命名卷
+func (s *Service) ListVolumes(ctx context.Context, req *providerpb.ListVolumesRequest) (*providerpb.ListVolumesResponse, error) {
+	if err := s.checkAuth(req.ProviderId, false); err != nil {
+		return &providerpb.ListVolumesResponse{
+			Error: fmt.Sprintf("authentication failed: %v", err),
+		}, nil
+	}
+
+	resp, err := s.client.VolumeList(ctx, volume.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("label", volumeManagedLabel+"=true")),
+	})
+	if err != nil {
+		return &providerpb.ListVolumesResponse{
+			Error: fmt.Sprintf("failed to list volumes: %v", err),
+		}, nil
+	}
+	volumes := make([]*providerpb.Volume, 0, len(resp.Volumes))
+	for _, v := range resp.Volumes {
+		volumes = append(volumes, volumeToProto(*v))
+	}
+	return &providerpb.ListVolumesResponse{Volumes: volumes}, nil
+}
+
+// DeleteVolume 删除由 iarnet 创建的命名卷，仍被容器挂载时 Docker 拒绝删除
+func (s *Service) DeleteVolume(ctx context.Context, req *providerpb.DeleteVolumeRequest) (*providerpb.DeleteVolumeResponse, error) {
+	if err := s.checkAuth(req.ProviderId, false); err != nil {
+		return &providerpb.DeleteVolumeResponse{
+			Error: fmt.Sprintf("authentication failed: %v", err),
+		}, nil
+	}
+
+	vol, err := s.client.VolumeInspect(ctx, req.Name)
+	if err != nil {
+		return &providerpb.DeleteVolumeResponse{
+			Error: fmt.Sprintf("volume %s not found: %v", req.Name, err),
+		}, nil
+	}
+	if vol.Labels[volumeManagedLabel] != "true" {
+		return &providerpb.DeleteVolumeResponse{
+			Error: fmt.Sprintf("volume %s is not managed by iarnet", req.Name),
+		}, nil
+	}
+	if err := s.client.VolumeRemove(ctx, req.Name, false); err != nil {
+		return &providerpb.DeleteVolumeResponse{
+			Error: fmt.Sprintf("failed to delete volume %s: %v", req.Name, err),
+		}, nil
+	}
+	logrus.Infof("Volume %s deleted", req.Name)
+	return &providerpb.DeleteVolumeResponse{}, nil
+}
@@ -0,0 +1,37 @@
+package test
+
+import (
+	"os"
+	"testing"
+
+	resourcepb "github.com/9triver/iarnet/internal/proto/resource"
+	"github.com/9triver/iarnet/providers/conformance"
+	"github.com/9triver/iarnet/providers/docker/provider"
+	"github.com/stretchr/testify/require"
+)
+
+// TestService_Conformance 使用通用的 provider 一致性检查验证 docker provider
+// 设置 CONFORMANCE_ZMQ_HOST（容器访问本机的地址）与 CONFORMANCE_COMPONENT_IMAGE 时同时检查 ZMQ 会话
+func TestService_Conformance(t *testing.T) {
+	if !isDockerAvailable() {
+		t.Skip("Docker is not available, skipping test")
+	}
+
+	host := os.Getenv("DOCKER_HOST")
+	if host == "" {
+		host = "unix:///var/run/docker.sock"
+	}
+	svc, err := provider.NewService(host, "", false, "", "", []string{"cpu", "memory"}, &resourcepb.Info{
+		Cpu:    2000,
+		Memory: 2 << 30,
+	})
+	require.NoError(t, err)
+	defer svc.Close()
+
+	conformance.Run(t, conformance.Config{
+		Server:           svc,
+		Image:            "busybox:latest",
+		ZMQAdvertiseHost: os.Getenv("CONFORMANCE_ZMQ_HOST"),
+		ComponentImage:   os.Getenv("CONFORMANCE_COMPONENT_IMAGE"),
+	})
+}
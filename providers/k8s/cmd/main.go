@@ -11,6 +11,8 @@ import (
 	resourcepb "github.com/9triver/iarnet/internal/proto/resource"
 	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
 	"github.com/9triver/iarnet/internal/util"
+	"github.com/9triver/iarnet/internal/util/ports"
+	"github.com/9triver/iarnet/internal/util/tunnel"
 	"github.com/9triver/iarnet/providers/k8s/config"
 	"github.com/9triver/iarnet/providers/k8s/provider"
 	"github.com/sirupsen/logrus"
@@ -59,15 +61,47 @@ func main() {
 	}
 	defer service.Close()
 
-	lis, err := net.Listen("tcp4", fmt.Sprintf(":%d", cfg.Server.Port))
-	if err != nil {
-		logrus.Fatalf("Failed to listen: %v", err)
+	if cfg.Server.Token != "" {
+		service.SetConnectToken(cfg.Server.Token)
+		logrus.Info("Connect token required for iarnet nodes")
 	}
 
-	srv := grpc.NewServer()
-	providerpb.RegisterServiceServer(srv, service)
+	if cfg.Energy.WattsPerCore > 0 || cfg.Energy.BatteryPowered {
+		service.SetEnergyProfile(cfg.Energy.WattsPerCore, cfg.Energy.BatteryPowered)
+		logrus.Infof("Reporting energy profile: %.2f W/core, battery powered: %v", cfg.Energy.WattsPerCore, cfg.Energy.BatteryPowered)
+	}
+
+	var (
+		lis  net.Listener
+		opts []grpc.ServerOption
+	)
+	if t := cfg.Server.Tunnel; t.Address != "" {
+		// 反向连接模式：主动连接节点，在隧道上提供 gRPC 服务，断开后自动重连
+		if t.Name == "" {
+			logrus.Fatalf("server.tunnel.name is required when server.tunnel.address is set")
+		}
+		lis = tunnel.Listen(t.Address, tunnel.Hello{Name: t.Name, Token: cfg.Server.Token})
+		opts = tunnel.ServerOptions()
+		logrus.Infof("Kubernetes provider gRPC server serving through tunnel to %s as %s", t.Address, t.Name)
+	} else {
+		// 登记端口租约，与同一主机上的 iarnet 节点和其他 provider 协调端口；未配置端口时自动分配
+		leases := ports.NewRegistry("")
+		defer leases.Close()
+		port, err := leases.ClaimOrAllocate("kubernetes provider", cfg.Server.Port, ports.ProviderPortMin, ports.ProviderPortMax)
+		if err != nil {
+			logrus.Fatalf("Failed to reserve port: %v", err)
+		}
+		cfg.Server.Port = port
+
+		lis, err = net.Listen("tcp4", fmt.Sprintf(":%d", cfg.Server.Port))
+		if err != nil {
+			logrus.Fatalf("Failed to listen: %v", err)
+		}
+		logrus.Infof("Kubernetes provider gRPC server listening on :%d", cfg.Server.Port)
+	}
 
-	logrus.Infof("Kubernetes provider gRPC server listening on :%d", cfg.Server.Port)
+	srv := grpc.NewServer(opts...)
+	providerpb.RegisterServiceServer(srv, service)
 
 	go func() {
 		if err := srv.Serve(lis); err != nil {
@@ -83,4 +117,3 @@ func main() {
 	srv.GracefulStop()
 	logrus.Infof("Shutdown complete")
 }
-
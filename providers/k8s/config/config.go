@@ -16,11 +16,23 @@ type Config struct {
 	Kubernetes   KubernetesConfig `yaml:"kubernetes"`
 	Resource     ResourceConfig   `yaml:"resource"`
 	ResourceTags []string         `yaml:"resource_tags"`
+	Energy       EnergyConfig     `yaml:"energy"` // 能耗画像（可选）
 }
 
 // ServerConfig gRPC 服务器配置
 type ServerConfig struct {
-	Port int `yaml:"port"`
+	Port  int    `yaml:"port"`
+	Token string `yaml:"token"` // 注册令牌（可选），iarnet 节点连接时需出示一致的令牌
+
+	Tunnel TunnelConfig `yaml:"tunnel"` // 反向连接模式（可选），位于 NAT 后无法接受入站连接时使用
+}
+
+// TunnelConfig 反向连接配置
+// 配置 address 后 provider 不再监听端口，而是主动连接 iarnet 节点的 transport.tunnel 端口，
+// 节点经这条连接调用 provider；节点需在 static_providers 中以相同的 name 和 token 声明 reverse: true
+type TunnelConfig struct {
+	Address string `yaml:"address"` // e.g., "iarnet.example.com:50007"
+	Name    string `yaml:"name"`    // 与节点 static_providers 中的 name 一致
 }
 
 // KubernetesConfig Kubernetes 集群配置
@@ -38,6 +50,12 @@ type ResourceConfig struct {
 	GPU    int64  `yaml:"gpu"`    // GPU 数量
 }
 
+// EnergyConfig 能耗画像配置，通过健康检查上报给 iarnet
+type EnergyConfig struct {
+	WattsPerCore   float64 `yaml:"watts_per_core"`  // 每核功耗（瓦），0 表示未知
+	BatteryPowered bool    `yaml:"battery_powered"` // 是否由电池供电
+}
+
 // ParseMemory 解析内存字符串为字节数
 // 支持格式：8Gi, 8GB, 8192Mi, 8192MB, 8192, 8G, 8M 等
 func (r *ResourceConfig) ParseMemory() (int64, error) {
@@ -127,4 +145,3 @@ func getDefaultConfig() Config {
 		ResourceTags: []string{"cpu", "memory"},
 	}
 }
-
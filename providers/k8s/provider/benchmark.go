@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+	"github.com/9triver/iarnet/internal/util"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Benchmark 运行微基准测试
+// CPU、内存带宽和磁盘 IO 在 provider 进程所在节点上测量，作为集群节点性能的近似；
+// GPU 按集群各节点可分配的 nvidia.com/gpu 统计
+func (s *Service) Benchmark(ctx context.Context, req *providerpb.BenchmarkRequest) (*providerpb.BenchmarkResponse, error) {
+	if err := s.checkAuth(req.ProviderId, false); err != nil {
+		return &providerpb.BenchmarkResponse{
+			Error: fmt.Sprintf("authentication failed: %v", err),
+		}, nil
+	}
+
+	bench, err := util.RunMicroBenchmark(ctx, "")
+	if err != nil {
+		return &providerpb.BenchmarkResponse{
+			Error: fmt.Sprintf("benchmark failed: %v", err),
+		}, nil
+	}
+
+	var gpuCount int64
+	nodes, err := s.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logrus.Warnf("Failed to list nodes for GPU detection: %v", err)
+	} else {
+		for _, node := range nodes.Items {
+			if q, ok := node.Status.Allocatable["nvidia.com/gpu"]; ok {
+				gpuCount += q.Value()
+			}
+		}
+	}
+
+	result := &providerpb.BenchmarkResult{
+		CpuScore:            bench.CPUScore,
+		MemoryBandwidthMbps: bench.MemoryBandwidthMBps,
+		DiskWriteMbps:       bench.DiskWriteMBps,
+		DiskReadMbps:        bench.DiskReadMBps,
+		GpuPresent:          gpuCount > 0,
+		GpuCount:            int32(gpuCount),
+		DurationMs:          bench.Duration.Milliseconds(),
+	}
+	logrus.Infof("Benchmark finished in %v: cpu=%.0f MiB/s, memory=%.0f MiB/s, disk write=%.0f MiB/s, read=%.0f MiB/s, gpus=%d",
+		bench.Duration, result.CpuScore, result.MemoryBandwidthMbps, result.DiskWriteMbps, result.DiskReadMbps, gpuCount)
+	return &providerpb.BenchmarkResponse{Result: result}, nil
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	resourcepb "github.com/9triver/iarnet/internal/proto/resource"
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetComponentUsage 按 component Pod 上报实时资源使用情况
+// 使用量来自 metrics-server（工作集内存），资源上限取自 Pod 规格；metrics-server 不提供常驻内存与页缓存，对应字段为 0
+func (s *Service) GetComponentUsage(ctx context.Context, req *providerpb.GetComponentUsageRequest) (*providerpb.GetComponentUsageResponse, error) {
+	if err := s.checkAuth(req.ProviderId, false); err != nil {
+		return &providerpb.GetComponentUsageResponse{
+			Error: fmt.Sprintf("authentication failed: %v", err),
+		}, nil
+	}
+	if s.metricsClient == nil {
+		return &providerpb.GetComponentUsageResponse{
+			Error: "metrics client not available",
+		}, nil
+	}
+
+	labelSelector := fmt.Sprintf("iarnet.provider_id=%s,iarnet.managed=true", s.GetProviderID())
+	pods, err := s.clientset.CoreV1().Pods(s.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labelSelector,
+		FieldSelector: "status.phase=Running",
+	})
+	if err != nil {
+		return &providerpb.GetComponentUsageResponse{
+			Error: fmt.Sprintf("failed to list pods: %v", err),
+		}, nil
+	}
+	podMetricsList, err := s.metricsClient.MetricsV1beta1().PodMetricses(s.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labelSelector,
+	})
+	if err != nil {
+		return &providerpb.GetComponentUsageResponse{
+			Error: fmt.Sprintf("failed to get pod metrics: %v", err),
+		}, nil
+	}
+
+	wanted := make(map[string]struct{}, len(req.InstanceIds))
+	for _, id := range req.InstanceIds {
+		wanted[id] = struct{}{}
+	}
+	specs := make(map[string]*corev1.Pod, len(pods.Items))
+	for i := range pods.Items {
+		specs[pods.Items[i].Name] = &pods.Items[i]
+	}
+
+	var usages []*providerpb.ComponentUsage
+	for _, podMetrics := range podMetricsList.Items {
+		pod, ok := specs[podMetrics.Name]
+		if !ok {
+			continue
+		}
+		instanceID := pod.Labels["iarnet.instance_id"]
+		if _, ok := wanted[instanceID]; len(wanted) > 0 && !ok {
+			continue
+		}
+
+		usage := &resourcepb.Info{}
+		for _, c := range podMetrics.Containers {
+			if cpu := c.Usage.Cpu(); cpu != nil {
+				usage.Cpu += cpu.MilliValue()
+			}
+			if memory := c.Usage.Memory(); memory != nil {
+				usage.Memory += memory.Value()
+			}
+		}
+		limit := podLimit(pod)
+		// GPU 没有实时指标，按分配的数量计
+		usage.Gpu = limit.Gpu
+
+		usages = append(usages, &providerpb.ComponentUsage{
+			InstanceId: instanceID,
+			Usage:      usage,
+			Limit:      limit,
+			Timestamp:  podMetrics.Timestamp.UnixMilli(),
+		})
+	}
+	return &providerpb.GetComponentUsageResponse{Components: usages}, nil
+}
+
+// podLimit 汇总 Pod 各容器的资源上限，未设置上限的资源按请求量计
+func podLimit(pod *corev1.Pod) *resourcepb.Info {
+	limit := &resourcepb.Info{}
+	for _, c := range pod.Spec.Containers {
+		cpu := limitOrRequest(c, corev1.ResourceCPU)
+		memory := limitOrRequest(c, corev1.ResourceMemory)
+		gpu := limitOrRequest(c, "nvidia.com/gpu")
+		limit.Cpu += cpu.MilliValue()
+		limit.Memory += memory.Value()
+		limit.Gpu += gpu.Value()
+	}
+	return limit
+}
+
+func limitOrRequest(c corev1.Container, name corev1.ResourceName) resource.Quantity {
+	if q, ok := c.Resources.Limits[name]; ok {
+		return q
+	}
+	return c.Resources.Requests[name]
+}
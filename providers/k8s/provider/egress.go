@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// buildEgressNetworkPolicy 根据出站策略构建仅作用于单个 Pod 的 NetworkPolicy
+// 策略类型只包含 Egress，未命中任何规则的出站流量都会被拒绝
+func buildEgressNetworkPolicy(name, namespace, instanceID string, policy *providerpb.EgressPolicy) *networkingv1.NetworkPolicy {
+	var rules []networkingv1.NetworkPolicyEgressRule
+	for _, rule := range policy.Allow {
+		cidrs, err := resolveEgressCIDRs(rule.Destination)
+		if err != nil {
+			logrus.Warnf("Skipping egress rule for %s: %v", rule.Destination, err)
+			continue
+		}
+		var peers []networkingv1.NetworkPolicyPeer
+		for _, cidr := range cidrs {
+			peers = append(peers, networkingv1.NetworkPolicyPeer{
+				IPBlock: &networkingv1.IPBlock{CIDR: cidr},
+			})
+		}
+		if len(peers) == 0 {
+			continue
+		}
+		rules = append(rules, networkingv1.NetworkPolicyEgressRule{
+			To:    peers,
+			Ports: egressPorts(rule),
+		})
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"iarnet.managed":     "true",
+				"iarnet.instance_id": instanceID,
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"iarnet.instance_id": instanceID},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			Egress:      rules,
+		},
+	}
+}
+
+// egressPorts 将规则中的端口与协议转换为 NetworkPolicyPort，端口为 0 时不限制端口
+func egressPorts(rule *providerpb.EgressRule) []networkingv1.NetworkPolicyPort {
+	var protocols []corev1.Protocol
+	switch strings.ToLower(rule.Protocol) {
+	case "tcp":
+		protocols = []corev1.Protocol{corev1.ProtocolTCP}
+	case "udp":
+		protocols = []corev1.Protocol{corev1.ProtocolUDP}
+	default:
+		if rule.Port == 0 {
+			return nil
+		}
+		protocols = []corev1.Protocol{corev1.ProtocolTCP, corev1.ProtocolUDP}
+	}
+
+	var ports []networkingv1.NetworkPolicyPort
+	for _, proto := range protocols {
+		p := networkingv1.NetworkPolicyPort{Protocol: &proto}
+		if rule.Port > 0 {
+			port := intstr.FromInt32(rule.Port)
+			p.Port = &port
+		}
+		ports = append(ports, p)
+	}
+	return ports
+}
+
+// resolveEgressCIDRs 将目的地解析为 CIDR 列表
+func resolveEgressCIDRs(dest string) ([]string, error) {
+	if dest == "" {
+		return nil, fmt.Errorf("empty destination")
+	}
+	if _, _, err := net.ParseCIDR(dest); err == nil {
+		return []string{dest}, nil
+	}
+	if ip := net.ParseIP(dest); ip != nil {
+		return []string{hostCIDR(ip)}, nil
+	}
+	addrs, err := net.LookupHost(dest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve destination: %w", err)
+	}
+	var cidrs []string
+	for _, addr := range addrs {
+		if ip := net.ParseIP(addr); ip != nil {
+			cidrs = append(cidrs, hostCIDR(ip))
+		}
+	}
+	return cidrs, nil
+}
+
+func hostCIDR(ip net.IP) string {
+	if ip.To4() != nil {
+		return ip.String() + "/32"
+	}
+	return ip.String() + "/128"
+}
+
+// createEgressPolicy 在创建 Pod 之前创建 NetworkPolicy，避免 Pod 在策略生效前访问网络
+func (s *Service) createEgressPolicy(ctx context.Context, podName, instanceID string, policy *providerpb.EgressPolicy) error {
+	np := buildEgressNetworkPolicy(podName, s.namespace, instanceID, policy)
+	if _, err := s.clientset.NetworkingV1().NetworkPolicies(s.namespace).Create(ctx, np, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create egress network policy: %w", err)
+	}
+	return nil
+}
+
+// bindEgressPolicyToPod 将 NetworkPolicy 的 owner 设置为 Pod，Pod 删除时策略随之被回收
+func (s *Service) bindEgressPolicyToPod(ctx context.Context, pod *corev1.Pod) {
+	policies := s.clientset.NetworkingV1().NetworkPolicies(s.namespace)
+	np, err := policies.Get(ctx, pod.Name, metav1.GetOptions{})
+	if err != nil {
+		logrus.Warnf("Failed to get egress network policy %s: %v", pod.Name, err)
+		return
+	}
+	np.OwnerReferences = []metav1.OwnerReference{
+		*metav1.NewControllerRef(pod, corev1.SchemeGroupVersion.WithKind("Pod")),
+	}
+	if _, err := policies.Update(ctx, np, metav1.UpdateOptions{}); err != nil {
+		logrus.Warnf("Failed to bind egress network policy %s to pod: %v", pod.Name, err)
+	}
+}
+
+// deleteEgressPolicy 删除 NetworkPolicy（Pod 创建失败时调用）
+func (s *Service) deleteEgressPolicy(ctx context.Context, podName string) {
+	if err := s.clientset.NetworkingV1().NetworkPolicies(s.namespace).Delete(ctx, podName, metav1.DeleteOptions{}); err != nil {
+		logrus.Warnf("Failed to delete egress network policy %s: %v", podName, err)
+	}
+}
@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+// defaultExecCommand 未指定命令时启动的 shell
+var defaultExecCommand = []string{"/bin/sh"}
+
+// execStreamWriter 将命令输出写入 gRPC 流
+type execStreamWriter struct {
+	stream providerpb.Service_ExecServer
+	stderr bool
+}
+
+func (w *execStreamWriter) Write(p []byte) (int, error) {
+	data := append([]byte(nil), p...)
+	resp := &providerpb.ExecResponse{Stdout: data}
+	if w.stderr {
+		resp = &providerpb.ExecResponse{Stderr: data}
+	}
+	if err := w.stream.Send(resp); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// execSizeQueue 将客户端的终端尺寸调整转发给 remotecommand
+type execSizeQueue chan remotecommand.TerminalSize
+
+func (q execSizeQueue) Next() *remotecommand.TerminalSize {
+	size, ok := <-q
+	if !ok {
+		return nil
+	}
+	return &size
+}
+
+// Exec 在 component Pod 内执行调试命令（kubectl exec），并通过双向流转发标准输入输出
+func (s *Service) Exec(stream providerpb.Service_ExecServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	start := first.GetStart()
+	if start == nil {
+		return stream.Send(&providerpb.ExecResponse{Error: "first exec message must be start"})
+	}
+	if err := s.checkAuth(start.ProviderId, false); err != nil {
+		return stream.Send(&providerpb.ExecResponse{Error: fmt.Sprintf("authentication failed: %v", err)})
+	}
+
+	ctx := stream.Context()
+	podName := sanitizePodName(start.InstanceId)
+	if err := s.checkManagedPod(ctx, podName); err != nil {
+		return stream.Send(&providerpb.ExecResponse{Error: err.Error()})
+	}
+
+	command := start.Command
+	if len(command) == 0 {
+		command = defaultExecCommand
+	}
+	req := s.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(s.namespace).
+		Name(podName).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: "main",
+			Command:   command,
+			Stdin:     true,
+			Stdout:    true,
+			Stderr:    !start.Tty,
+			TTY:       start.Tty,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(s.restConfig, "POST", req.URL())
+	if err != nil {
+		return stream.Send(&providerpb.ExecResponse{Error: fmt.Sprintf("failed to create executor: %v", err)})
+	}
+
+	stdinReader, stdinWriter := io.Pipe()
+	sizes := make(execSizeQueue, 1)
+	execCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// 转发客户端输入
+	go func() {
+		defer close(sizes)
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				stdinWriter.Close()
+				return
+			}
+			switch payload := req.Payload.(type) {
+			case *providerpb.ExecRequest_Stdin:
+				if _, err := stdinWriter.Write(payload.Stdin); err != nil {
+					return
+				}
+			case *providerpb.ExecRequest_Resize:
+				select {
+				case sizes <- remotecommand.TerminalSize{Width: uint16(payload.Resize.Cols), Height: uint16(payload.Resize.Rows)}:
+				case <-execCtx.Done():
+					return
+				}
+			case *providerpb.ExecRequest_CloseStdin:
+				stdinWriter.Close()
+			}
+		}
+	}()
+
+	logrus.Infof("Exec started in pod %s: %v", podName, command)
+	opts := remotecommand.StreamOptions{
+		Stdin:  stdinReader,
+		Stdout: &execStreamWriter{stream: stream},
+		Tty:    start.Tty,
+	}
+	if start.Tty {
+		opts.TerminalSizeQueue = sizes
+	} else {
+		opts.Stderr = &execStreamWriter{stream: stream, stderr: true}
+	}
+
+	err = executor.StreamWithContext(execCtx, opts)
+	var exitErr utilexec.CodeExitError
+	switch {
+	case err == nil:
+		logrus.Infof("Exec in pod %s exited with code 0", podName)
+		return stream.Send(&providerpb.ExecResponse{Exited: true})
+	case errors.As(err, &exitErr):
+		logrus.Infof("Exec in pod %s exited with code %d", podName, exitErr.ExitStatus())
+		return stream.Send(&providerpb.ExecResponse{Exited: true, ExitCode: int32(exitErr.ExitStatus())})
+	default:
+		return stream.Send(&providerpb.ExecResponse{Error: fmt.Sprintf("exec failed: %v", err)})
+	}
+}
+
+// checkManagedPod 确认 Pod 由当前 provider 部署且正在运行
+func (s *Service) checkManagedPod(ctx context.Context, podName string) error {
+	pod, err := s.clientset.CoreV1().Pods(s.namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("pod %s not found: %w", podName, err)
+	}
+	if pod.Labels["iarnet.provider_id"] != s.GetProviderID() {
+		return fmt.Errorf("pod %s is not managed by this provider", podName)
+	}
+	if pod.Status.Phase != corev1.PodRunning {
+		return fmt.Errorf("pod %s is not running (phase: %s)", podName, pod.Status.Phase)
+	}
+	return nil
+}
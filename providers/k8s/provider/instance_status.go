@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetInstanceStatus 查询 component Pod 的运行状态，退出码取自 main 容器
+// Pod 的 RestartPolicy 为 Never，容器退出后 Pod 停留在 Succeeded/Failed 阶段直至被删除
+func (s *Service) GetInstanceStatus(ctx context.Context, req *providerpb.GetInstanceStatusRequest) (*providerpb.GetInstanceStatusResponse, error) {
+	if err := s.checkAuth(req.ProviderId, false); err != nil {
+		return &providerpb.GetInstanceStatusResponse{
+			Error: fmt.Sprintf("authentication failed: %v", err),
+		}, nil
+	}
+
+	podName := sanitizePodName(req.InstanceId)
+	pod, err := s.clientset.CoreV1().Pods(s.namespace).Get(ctx, podName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return &providerpb.GetInstanceStatusResponse{State: "not_found"}, nil
+	}
+	if err != nil {
+		return &providerpb.GetInstanceStatusResponse{
+			Error: fmt.Sprintf("failed to get pod %s: %v", podName, err),
+		}, nil
+	}
+	if pod.Labels["iarnet.provider_id"] != s.GetProviderID() {
+		return &providerpb.GetInstanceStatusResponse{
+			Error: fmt.Sprintf("pod %s is not managed by this provider", podName),
+		}, nil
+	}
+
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded, corev1.PodFailed:
+		resp := &providerpb.GetInstanceStatusResponse{State: "exited", Reason: pod.Status.Reason}
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Name == "main" && cs.State.Terminated != nil {
+				resp.ExitCode = cs.State.Terminated.ExitCode
+				resp.Reason = cs.State.Terminated.Reason
+			}
+		}
+		// 容器未启动即被终止（如被驱逐）时没有退出码，按失败处理
+		if pod.Status.Phase == corev1.PodFailed && resp.ExitCode == 0 {
+			resp.ExitCode = 1
+		}
+		return resp, nil
+	case corev1.PodRunning:
+		// 带 sidecar 的 Pod 在主容器结束后仍处于 Running，以主容器状态为准
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Name == "main" && cs.State.Terminated != nil {
+				return &providerpb.GetInstanceStatusResponse{
+					State:    "exited",
+					ExitCode: cs.State.Terminated.ExitCode,
+					Reason:   cs.State.Terminated.Reason,
+				}, nil
+			}
+		}
+		return &providerpb.GetInstanceStatusResponse{State: "running"}, nil
+	default:
+		return &providerpb.GetInstanceStatusResponse{State: "pending"}, nil
+	}
+}
@@ -110,4 +110,3 @@ func (m *Manager) monitor() {
 		}
 	}
 }
-
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// portForwardChunkSize 单条隧道消息的最大字节数
+const portForwardChunkSize = 32 * 1024
+
+// PortForward 通过 API server 的 portforward 子资源将双向流转发到 component Pod 的指定端口
+func (s *Service) PortForward(stream providerpb.Service_PortForwardServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	start := first.GetStart()
+	if start == nil {
+		return stream.Send(&providerpb.PortForwardResponse{Error: "first port-forward message must be start"})
+	}
+	if err := s.checkAuth(start.ProviderId, false); err != nil {
+		return stream.Send(&providerpb.PortForwardResponse{Error: fmt.Sprintf("authentication failed: %v", err)})
+	}
+
+	ctx := stream.Context()
+	podName := sanitizePodName(start.InstanceId)
+	if err := s.checkManagedPod(ctx, podName); err != nil {
+		return stream.Send(&providerpb.PortForwardResponse{Error: err.Error()})
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(s.restConfig)
+	if err != nil {
+		return stream.Send(&providerpb.PortForwardResponse{Error: fmt.Sprintf("failed to create spdy transport: %v", err)})
+	}
+	req := s.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(s.namespace).
+		Name(podName).
+		SubResource("portforward")
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", req.URL())
+
+	streamConn, _, err := dialer.Dial(portforward.PortForwardProtocolV1Name)
+	if err != nil {
+		return stream.Send(&providerpb.PortForwardResponse{Error: fmt.Sprintf("failed to dial pod %s: %v", podName, err)})
+	}
+	defer streamConn.Close()
+
+	// 与 kubectl port-forward 相同：每条连接需要一个 error 流和一个 data 流
+	headers := http.Header{}
+	headers.Set(corev1.StreamType, corev1.StreamTypeError)
+	headers.Set(corev1.PortHeader, strconv.Itoa(int(start.Port)))
+	headers.Set(corev1.PortForwardRequestIDHeader, "0")
+	errorStream, err := streamConn.CreateStream(headers)
+	if err != nil {
+		return stream.Send(&providerpb.PortForwardResponse{Error: fmt.Sprintf("failed to create error stream: %v", err)})
+	}
+	errorStream.Close()
+
+	headers.Set(corev1.StreamType, corev1.StreamTypeData)
+	dataStream, err := streamConn.CreateStream(headers)
+	if err != nil {
+		return stream.Send(&providerpb.PortForwardResponse{Error: fmt.Sprintf("failed to create data stream: %v", err)})
+	}
+	defer dataStream.Reset()
+
+	logrus.Infof("Port-forward opened to pod %s port %d", podName, start.Port)
+
+	// 转发客户端数据
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				dataStream.Reset()
+				return
+			}
+			switch payload := req.Payload.(type) {
+			case *providerpb.PortForwardRequest_Data:
+				if _, err := dataStream.Write(payload.Data); err != nil {
+					return
+				}
+			case *providerpb.PortForwardRequest_CloseWrite:
+				dataStream.Close()
+			}
+		}
+	}()
+
+	buf := make([]byte, portForwardChunkSize)
+	for {
+		n, err := dataStream.Read(buf)
+		if n > 0 {
+			data := append([]byte(nil), buf[:n]...)
+			if sendErr := stream.Send(&providerpb.PortForwardResponse{Data: data}); sendErr != nil {
+				return sendErr
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	if message, err := io.ReadAll(errorStream); err == nil && len(message) > 0 {
+		return stream.Send(&providerpb.PortForwardResponse{Error: string(message)})
+	}
+	logrus.Infof("Port-forward to pod %s port %d closed", podName, start.Port)
+	return nil
+}
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"crypto/subtle"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,6 +12,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/9triver/iarnet/internal/domain/resource/types"
+	"github.com/9triver/iarnet/internal/proto/common"
 	resourcepb "github.com/9triver/iarnet/internal/proto/resource"
 	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
 	"github.com/sirupsen/logrus"
@@ -23,6 +26,13 @@ import (
 	metricsv1beta1 "k8s.io/metrics/pkg/client/clientset/versioned"
 )
 
+// capabilities 本 provider 支持的可选能力，在 Connect 握手中声明
+var capabilities = []string{
+	common.CapUndeploy, common.CapBenchmark, common.CapWatchUsage, common.CapExec,
+	common.CapPortForward, common.CapEgressPolicy, common.CapInstanceStatus, common.CapComponentUsage,
+	common.CapSidecars,
+}
+
 const providerType = "kubernetes"
 
 // Service Kubernetes provider 服务实现
@@ -30,11 +40,15 @@ type Service struct {
 	providerpb.UnimplementedServiceServer
 	mu            sync.RWMutex
 	clientset     *kubernetes.Clientset
+	restConfig    *rest.Config // 用于 exec 等需要直接访问 API server 的操作
 	metricsClient *metricsv1beta1.Clientset
-	manager       *Manager // 健康检查状态管理器
+	manager       *Manager                  // 健康检查状态管理器
+	energyProfile *providerpb.EnergyProfile // 能耗画像（可选）
 	resourceTags  *providerpb.ResourceTags
-	namespace     string // 部署 Pod 的命名空间
-	labelSelector string // 用于筛选管理的 Pod 的标签选择器
+	namespace     string   // 部署 Pod 的命名空间
+	labelSelector string   // 用于筛选管理的 Pod 的标签选择器
+	connectToken  string   // 注册令牌，非空时拒绝令牌不一致的 Connect 请求
+	architectures []string // 集群节点的 CPU 架构（可能有多种）
 
 	// 资源容量管理（从配置文件读取）
 	totalCapacity *resourcepb.Info // 配置的总容量
@@ -95,6 +109,8 @@ func NewService(kubeconfig string, inCluster bool, namespace string, labelSelect
 
 	logrus.Infof("Successfully connected to Kubernetes cluster, namespace: %s", namespace)
 
+	architectures := detectNodeArchitectures(ctx, clientset)
+
 	// 创建健康检查管理器
 	// 健康检测超时时间：90 秒（假设 iarnet 每 30 秒检测一次，允许 3 次失败）
 	// 检查间隔：10 秒
@@ -116,6 +132,7 @@ func NewService(kubeconfig string, inCluster bool, namespace string, labelSelect
 
 	service := &Service{
 		clientset:     clientset,
+		restConfig:    config,
 		metricsClient: metricsClient,
 		manager:       manager,
 		namespace:     namespace,
@@ -128,6 +145,7 @@ func NewService(kubeconfig string, inCluster bool, namespace string, labelSelect
 		},
 		totalCapacity: totalCapacity,
 		allocated:     allocated,
+		architectures: architectures,
 	}
 
 	// 启动健康检测超时监控
@@ -136,6 +154,25 @@ func NewService(kubeconfig string, inCluster bool, namespace string, labelSelect
 	return service, nil
 }
 
+// detectNodeArchitectures 获取集群节点的 CPU 架构，用于 iarnet 按镜像架构调度
+// 没有列出节点的权限时不上报架构，iarnet 视为与任意镜像兼容
+func detectNodeArchitectures(ctx context.Context, clientset *kubernetes.Clientset) []string {
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logrus.Warnf("Failed to list nodes, architectures will not be reported: %v", err)
+		return nil
+	}
+	var archs []string
+	for _, node := range nodes.Items {
+		arch := types.NormalizeArchitecture(node.Status.NodeInfo.Architecture)
+		if arch != "" && !slices.Contains(archs, arch) {
+			archs = append(archs, arch)
+		}
+	}
+	logrus.Infof("Kubernetes node architectures: %v", archs)
+	return archs
+}
+
 // Close 关闭服务
 func (s *Service) Close() error {
 	// 停止健康检测监控
@@ -161,6 +198,26 @@ func (s *Service) Connect(ctx context.Context, req *providerpb.ConnectRequest) (
 		}, nil
 	}
 
+	s.mu.RLock()
+	token := s.connectToken
+	s.mu.RUnlock()
+	if token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(req.Token)) != 1 {
+		logrus.Warnf("Rejecting connection with invalid token for provider ID %s", req.ProviderId)
+		return &providerpb.ConnectResponse{
+			Success: false,
+			Error:   "invalid connect token",
+		}, nil
+	}
+
+	// 协商协议版本：旧版 iarnet 节点不携带 ProtocolInfo，按 legacy 处理
+	if _, err := common.Negotiate(common.NewProtocolInfo(capabilities...), req.Protocol); err != nil {
+		logrus.Errorf("Rejecting connection from incompatible controller: %v", err)
+		return &providerpb.ConnectResponse{
+			Success: false,
+			Error:   fmt.Sprintf("incompatible protocol: %v", err),
+		}, nil
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -183,9 +240,28 @@ func (s *Service) Connect(ctx context.Context, req *providerpb.ConnectRequest) (
 		ProviderType: &providerpb.ProviderType{
 			Name: providerType,
 		},
+		Protocol:      common.NewProtocolInfo(capabilities...),
+		Architectures: s.architectures,
+		Capacity:      s.capacityLocked(),
 	}, nil
 }
 
+// capacityLocked 当前的总容量、已分配与可用容量（副本），未配置总容量时返回 nil，调用方需持有 mu
+func (s *Service) capacityLocked() *resourcepb.Capacity {
+	if s.totalCapacity == nil || s.allocated == nil {
+		return nil
+	}
+	return &resourcepb.Capacity{
+		Total: &resourcepb.Info{Cpu: s.totalCapacity.Cpu, Memory: s.totalCapacity.Memory, Gpu: s.totalCapacity.Gpu},
+		Used:  &resourcepb.Info{Cpu: s.allocated.Cpu, Memory: s.allocated.Memory, Gpu: s.allocated.Gpu},
+		Available: &resourcepb.Info{
+			Cpu:    s.totalCapacity.Cpu - s.allocated.Cpu,
+			Memory: s.totalCapacity.Memory - s.allocated.Memory,
+			Gpu:    s.totalCapacity.Gpu - s.allocated.Gpu,
+		},
+	}
+}
+
 // GetCapacity 获取资源容量
 func (s *Service) GetCapacity(ctx context.Context, req *providerpb.GetCapacityRequest) (*providerpb.GetCapacityResponse, error) {
 	// 鉴权：如果 provider 已连接，需要验证 provider_id；如果未连接，允许访问
@@ -317,27 +393,58 @@ func (s *Service) Deploy(ctx context.Context, req *providerpb.DeployRequest) (*p
 	// 获取 provider ID 用于标记 Pod
 	providerID := s.manager.GetProviderID()
 
+	// 分配前原子地复核并预留容量：调度方基于缓存的可用容量做出决策，此时使用量可能已变化
+	// 主容器与 sidecar 合并预留
+	request := withSidecarResources(req.ResourceRequest, req.Sidecars)
+	if !s.reserve(request) {
+		logrus.Warnf("Rejecting deployment %s: insufficient capacity for CPU=%d, Memory=%d, GPU=%d",
+			req.InstanceId, request.Cpu, request.Memory, request.Gpu)
+		return &providerpb.DeployResponse{
+			Error:      "insufficient capacity",
+			NoCapacity: true,
+		}, nil
+	}
+	// 部署失败时归还预留的容量
+	deployed := false
+	defer func() {
+		if !deployed {
+			s.ReleaseResources(request.Cpu, request.Memory, request.Gpu)
+		}
+	}()
+
 	// 构建 Pod 规格
 	pod := s.buildPodSpec(req, providerID)
 
+	// 出站策略：先创建 NetworkPolicy，再创建 Pod
+	egress := req.GetEgressPolicy()
+	if egress.GetEnabled() {
+		if err := s.createEgressPolicy(ctx, pod.Name, req.InstanceId, egress); err != nil {
+			logrus.Errorf("Failed to enforce egress policy: %v", err)
+			return &providerpb.DeployResponse{
+				Error: err.Error(),
+			}, nil
+		}
+	}
+
 	// 创建 Pod
 	createdPod, err := s.clientset.CoreV1().Pods(s.namespace).Create(ctx, pod, metav1.CreateOptions{})
 	if err != nil {
 		logrus.Errorf("Failed to create Pod: %v", err)
+		if egress.GetEnabled() {
+			s.deleteEgressPolicy(ctx, pod.Name)
+		}
 		return &providerpb.DeployResponse{
 			Error: err.Error(),
 		}, nil
 	}
+	if egress.GetEnabled() {
+		s.bindEgressPolicyToPod(ctx, createdPod)
+	}
 
-	// 更新已分配的资源容量（在内存中维护）
-	s.mu.Lock()
-	s.allocated.Cpu += req.ResourceRequest.Cpu
-	s.allocated.Memory += req.ResourceRequest.Memory
-	s.allocated.Gpu += req.ResourceRequest.Gpu
-	s.mu.Unlock()
+	deployed = true
 
-	logrus.Infof("Pod deployed successfully: %s/%s, allocated resources: CPU=%d, Memory=%d, GPU=%d",
-		s.namespace, createdPod.Name, req.ResourceRequest.Cpu, req.ResourceRequest.Memory, req.ResourceRequest.Gpu)
+	logrus.Infof("Pod deployed successfully: %s/%s, %d sidecars, allocated resources: CPU=%d, Memory=%d, GPU=%d",
+		s.namespace, createdPod.Name, len(req.Sidecars), request.Cpu, request.Memory, request.Gpu)
 
 	return &providerpb.DeployResponse{
 		Error: "",
@@ -396,29 +503,7 @@ func (s *Service) buildPodSpec(req *providerpb.DeployRequest, providerID string)
 		})
 	}
 
-	// 构建资源限制
-	// CPU: millicores -> Kubernetes 使用 "m" 后缀表示 millicores
-	// Memory: bytes -> Kubernetes 使用整数表示字节
-	cpuQuantity := resource.NewMilliQuantity(req.ResourceRequest.Cpu, resource.DecimalSI)
-	memoryQuantity := resource.NewQuantity(req.ResourceRequest.Memory, resource.BinarySI)
-
-	resources := corev1.ResourceRequirements{
-		Requests: corev1.ResourceList{
-			corev1.ResourceCPU:    *cpuQuantity,
-			corev1.ResourceMemory: *memoryQuantity,
-		},
-		Limits: corev1.ResourceList{
-			corev1.ResourceCPU:    *cpuQuantity,
-			corev1.ResourceMemory: *memoryQuantity,
-		},
-	}
-
-	// 如果请求了 GPU，添加 GPU 资源限制
-	if req.ResourceRequest.Gpu > 0 {
-		gpuQuantity := resource.NewQuantity(req.ResourceRequest.Gpu, resource.DecimalSI)
-		resources.Requests["nvidia.com/gpu"] = *gpuQuantity
-		resources.Limits["nvidia.com/gpu"] = *gpuQuantity
-	}
+	resources := containerResources(req.ResourceRequest.Cpu, req.ResourceRequest.Memory, req.ResourceRequest.Gpu)
 
 	// 构建 Pod
 	pod := &corev1.Pod{
@@ -432,7 +517,7 @@ func (s *Service) buildPodSpec(req *providerpb.DeployRequest, providerID string)
 			},
 		},
 		Spec: corev1.PodSpec{
-			Containers: []corev1.Container{
+			Containers: append([]corev1.Container{
 				{
 					Name:            "main",
 					Image:           req.Image,
@@ -440,14 +525,58 @@ func (s *Service) buildPodSpec(req *providerpb.DeployRequest, providerID string)
 					Env:             envVars,
 					Resources:       resources,
 				},
-			},
+			}, sidecarContainers(req)...),
 			RestartPolicy: corev1.RestartPolicyNever,
 		},
 	}
 
+	// 镜像只支持部分架构时，将 Pod 限制在对应架构的节点上
+	if len(req.Architectures) > 0 {
+		pod.Spec.Affinity = &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{{
+							Key:      corev1.LabelArchStable,
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   req.Architectures,
+						}},
+					}},
+				},
+			},
+		}
+	}
+
 	return pod
 }
 
+// containerResources 构建容器的资源请求与限制
+// CPU: millicores -> Kubernetes 使用 "m" 后缀表示 millicores
+// Memory: bytes -> Kubernetes 使用整数表示字节
+func containerResources(cpu, memory, gpu int64) corev1.ResourceRequirements {
+	cpuQuantity := resource.NewMilliQuantity(cpu, resource.DecimalSI)
+	memoryQuantity := resource.NewQuantity(memory, resource.BinarySI)
+
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    *cpuQuantity,
+			corev1.ResourceMemory: *memoryQuantity,
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    *cpuQuantity,
+			corev1.ResourceMemory: *memoryQuantity,
+		},
+	}
+
+	// 如果请求了 GPU，添加 GPU 资源限制
+	if gpu > 0 {
+		gpuQuantity := resource.NewQuantity(gpu, resource.DecimalSI)
+		resources.Requests["nvidia.com/gpu"] = *gpuQuantity
+		resources.Limits["nvidia.com/gpu"] = *gpuQuantity
+	}
+	return resources
+}
+
 // HealthCheck 健康检查
 func (s *Service) HealthCheck(ctx context.Context, req *providerpb.HealthCheckRequest) (*providerpb.HealthCheckResponse, error) {
 	// 鉴权：HealthCheck 必须验证 provider_id，不允许未连接的 provider 健康检查
@@ -484,12 +613,35 @@ func (s *Service) HealthCheck(ctx context.Context, req *providerpb.HealthCheckRe
 
 	resourceTags := s.resourceTags
 
+	s.mu.RLock()
+	energyProfile := s.energyProfile
+	s.mu.RUnlock()
+
 	return &providerpb.HealthCheckResponse{
-		Capacity:     capacity,
-		ResourceTags: resourceTags,
+		Capacity:      capacity,
+		ResourceTags:  resourceTags,
+		EnergyProfile: energyProfile,
+		Architectures: s.architectures,
 	}, nil
 }
 
+// SetConnectToken 设置注册令牌，iarnet 节点连接时需出示一致的令牌
+func (s *Service) SetConnectToken(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.connectToken = token
+}
+
+// SetEnergyProfile 设置通过健康检查上报的能耗画像
+func (s *Service) SetEnergyProfile(wattsPerCore float64, batteryPowered bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.energyProfile = &providerpb.EnergyProfile{
+		WattsPerCore:   wattsPerCore,
+		BatteryPowered: batteryPowered,
+	}
+}
+
 // Disconnect 断开连接
 func (s *Service) Disconnect(ctx context.Context, req *providerpb.DisconnectRequest) (*providerpb.DisconnectResponse, error) {
 	// 鉴权：Disconnect 必须验证 provider_id，不允许未连接的 provider 断开连接
@@ -509,6 +661,25 @@ func (s *Service) Disconnect(ctx context.Context, req *providerpb.DisconnectRequ
 	return &providerpb.DisconnectResponse{}, nil
 }
 
+// reserve 在同一把锁内复核剩余容量并累加已分配容量，容量不足时不做任何修改并返回 false
+// 未配置总容量时不做检查
+func (s *Service) reserve(request *resourcepb.Info) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if total := s.totalCapacity; total != nil {
+		if s.allocated.Cpu+request.Cpu > total.Cpu ||
+			s.allocated.Memory+request.Memory > total.Memory ||
+			s.allocated.Gpu+request.Gpu > total.Gpu {
+			return false
+		}
+	}
+	s.allocated.Cpu += request.Cpu
+	s.allocated.Memory += request.Memory
+	s.allocated.Gpu += request.Gpu
+	return true
+}
+
 // ReleaseResources 释放已分配的资源（当 Pod 停止或删除时调用）
 func (s *Service) ReleaseResources(cpu, memory, gpu int64) {
 	s.mu.Lock()
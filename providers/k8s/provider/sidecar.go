@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"github.com/9triver/iarnet/internal/proto/common"
+	resourcepb "github.com/9triver/iarnet/internal/proto/resource"
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// withSidecarResources 主容器与 sidecar 的资源合计，Deploy 按合计预留容量
+func withSidecarResources(request *resourcepb.Info, sidecars []*common.Sidecar) *resourcepb.Info {
+	total := &resourcepb.Info{Cpu: request.GetCpu(), Memory: request.GetMemory(), Gpu: request.GetGpu()}
+	for _, sc := range sidecars {
+		total.Cpu += sc.GetCPU()
+		total.Memory += sc.GetMemory()
+		total.Gpu += sc.GetGPU()
+	}
+	return total
+}
+
+// sidecarContainers 构建 sidecar 容器，与主容器位于同一 Pod、共享网络
+// sidecar 的资源请求单独声明，Kubernetes 调度时按 Pod 内所有容器的合计放置
+func sidecarContainers(req *providerpb.DeployRequest) []corev1.Container {
+	containers := make([]corev1.Container, 0, len(req.Sidecars))
+	for _, sc := range req.Sidecars {
+		var env []corev1.EnvVar
+		for k, v := range sc.Env {
+			env = append(env, corev1.EnvVar{Name: k, Value: v})
+		}
+		if componentID, ok := req.EnvVars["COMPONENT_ID"]; ok {
+			env = append(env, corev1.EnvVar{Name: "COMPONENT_ID", Value: componentID})
+		}
+		containers = append(containers, corev1.Container{
+			Name:            sc.Name,
+			Image:           sc.Image,
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Command:         sc.Command,
+			Env:             env,
+			Resources:       containerResources(sc.CPU, sc.Memory, sc.GPU),
+		})
+	}
+	return containers
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Undeploy 删除 component Pod，释放其占用的已分配资源
+// 出站 NetworkPolicy 的 owner 为 Pod，会随 Pod 一起被回收
+func (s *Service) Undeploy(ctx context.Context, req *providerpb.UndeployRequest) (*providerpb.UndeployResponse, error) {
+	if err := s.checkAuth(req.ProviderId, false); err != nil {
+		return &providerpb.UndeployResponse{
+			Error: fmt.Sprintf("authentication failed: %v", err),
+		}, nil
+	}
+
+	podName := sanitizePodName(req.InstanceId)
+	pods := s.clientset.CoreV1().Pods(s.namespace)
+	pod, err := pods.Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return &providerpb.UndeployResponse{
+			Error: fmt.Sprintf("pod %s not found: %v", podName, err),
+		}, nil
+	}
+	if pod.Labels["iarnet.provider_id"] != s.GetProviderID() {
+		return &providerpb.UndeployResponse{
+			Error: fmt.Sprintf("pod %s is not managed by this provider", podName),
+		}, nil
+	}
+
+	if err := pods.Delete(ctx, podName, metav1.DeleteOptions{}); err != nil {
+		logrus.Errorf("Failed to delete pod %s: %v", podName, err)
+		return &providerpb.UndeployResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	var cpu, memory, gpu int64
+	for _, c := range pod.Spec.Containers {
+		cpu += c.Resources.Requests.Cpu().MilliValue()
+		memory += c.Resources.Requests.Memory().Value()
+		if q, ok := c.Resources.Requests["nvidia.com/gpu"]; ok {
+			gpu += q.Value()
+		}
+	}
+	s.ReleaseResources(cpu, memory, gpu)
+
+	logrus.Infof("Pod %s/%s undeployed", s.namespace, podName)
+	return &providerpb.UndeployResponse{}, nil
+}
@@ -0,0 +1,32 @@
+package test
+
+import (
+	"os"
+	"testing"
+
+	resourcepb "github.com/9triver/iarnet/internal/proto/resource"
+	"github.com/9triver/iarnet/providers/conformance"
+	"github.com/9triver/iarnet/providers/k8s/provider"
+	"github.com/stretchr/testify/require"
+)
+
+// TestService_Conformance 使用通用的 provider 一致性检查验证 k8s provider
+// 设置 CONFORMANCE_ZMQ_HOST（Pod 访问本机的地址）与 CONFORMANCE_COMPONENT_IMAGE 时同时检查 ZMQ 会话
+func TestService_Conformance(t *testing.T) {
+	if !isKubernetesAvailable() {
+		t.Skip("Kubernetes is not available, skipping test")
+	}
+
+	svc, err := provider.NewService(getKubeconfig(), false, "default", "iarnet.managed=true", []string{"cpu", "memory"}, &resourcepb.Info{
+		Cpu:    2000,
+		Memory: 2 << 30,
+	})
+	require.NoError(t, err)
+
+	conformance.Run(t, conformance.Config{
+		Server:           svc,
+		Image:            "busybox:latest",
+		ZMQAdvertiseHost: os.Getenv("CONFORMANCE_ZMQ_HOST"),
+		ComponentImage:   os.Getenv("CONFORMANCE_COMPONENT_IMAGE"),
+	})
+}
@@ -0,0 +1,29 @@
+"""
+iarnet Python SDK
+- Client: 节点管理 API 客户端，提交应用、查询状态与日志、管理批处理任务
+- iarnet.ignis.Session: ignis 控制器会话，在 notebook 中直接驱动 ignis（需要 grpcio）
+"""
+
+from .client import (
+    APP_STATUS_FAILED,
+    APP_STATUS_READY,
+    APP_STATUS_RUNNING,
+    JOB_FINISHED_STATES,
+    APIError,
+    Client,
+    RetryPolicy,
+    WaitTimeout,
+)
+
+__version__ = "0.1.0"
+
+__all__ = [
+    "APP_STATUS_FAILED",
+    "APP_STATUS_READY",
+    "APP_STATUS_RUNNING",
+    "JOB_FINISHED_STATES",
+    "APIError",
+    "Client",
+    "RetryPolicy",
+    "WaitTimeout",
+]
@@ -0,0 +1,305 @@
+"""
+iarnet 应用管理 API 客户端
+封装应用的创建、运行、状态查询、日志与批处理任务接口，处理访问令牌、统一响应结构与失败重试
+"""
+
+import json
+import time
+import urllib.error
+import urllib.parse
+import urllib.request
+from dataclasses import dataclass
+from datetime import datetime
+from typing import Any, Dict, Iterable, List, Optional
+
+# 应用准备就绪（仓库已克隆、制品已构建）后的状态
+APP_STATUS_READY = "idle"
+# 应用进入失败状态，需查看状态中的事件或日志
+APP_STATUS_FAILED = "error"
+# 应用运行中的状态
+APP_STATUS_RUNNING = ("running", "degraded")
+
+# Job 的结束状态
+JOB_FINISHED_STATES = ("succeeded", "failed", "cancelled")
+
+
+class APIError(Exception):
+    """节点返回的错误响应"""
+
+    def __init__(self, status_code: int, reason: str, message: str = ""):
+        self.status_code = status_code
+        self.reason = reason
+        self.message = message  # 统一响应结构中的 error
+        if message:
+            super().__init__(f"HTTP {status_code} {reason}: {message}")
+        else:
+            super().__init__(f"HTTP {status_code} {reason}")
+
+    @property
+    def not_found(self) -> bool:
+        return self.status_code == 404
+
+
+class WaitTimeout(Exception):
+    """等待应用或 Job 达到目标状态超时"""
+
+
+@dataclass
+class RetryPolicy:
+    """
+    失败重试策略
+    只有幂等请求（GET、DELETE）在连接失败或节点返回 502/503/504 时重试；
+    创建应用、提交 Job 等非幂等请求不重试，避免在节点已处理但响应丢失时重复提交
+    """
+
+    max_attempts: int = 3  # 最多尝试次数（含首次），不大于 1 时不重试
+    backoff: float = 0.2  # 首次重试前的等待（秒），之后每次翻倍
+    max_backoff: float = 2.0  # 重试等待的上限（秒）
+
+
+_RETRYABLE_STATUS = (502, 503, 504)
+
+
+class Client:
+    """
+    iarnet 节点管理 API 客户端
+
+    用法:
+
+        client = Client("http://node:8083", token=os.getenv("IARNET_TOKEN"))
+        app_id = client.submit_application("demo", "https://github.com/org/demo.git", "python")
+        client.wait_application(app_id, APP_STATUS_RUNNING)
+    """
+
+    def __init__(self, server: str, token: Optional[str] = None, timeout: float = 30.0,
+                 retry: Optional[RetryPolicy] = None):
+        parsed = urllib.parse.urlparse(server)
+        if parsed.scheme not in ("http", "https") or not parsed.netloc:
+            raise ValueError(f"invalid server address {server!r}: expected http(s)://host:port")
+        self.server = server.rstrip("/")
+        self.token = token
+        self.timeout = timeout
+        self.retry = retry or RetryPolicy()
+
+    # ------------------------------------------------------------------
+    # 应用
+    # ------------------------------------------------------------------
+
+    def list_applications(self) -> List[Dict[str, Any]]:
+        """列出本节点的应用"""
+        return self._do("GET", "/application/apps").get("applications") or []
+
+    def create_application(self, name: str, git_url: str, runner_env: str, branch: str = "",
+                           description: str = "", execute_cmd: str = "", env_install_cmd: str = "",
+                           build_cmd: str = "", build_image: bool = False) -> str:
+        """
+        从 Git 仓库创建应用，返回应用 ID
+        节点在后台克隆仓库（及构建制品），完成后应用进入 APP_STATUS_READY 状态
+        """
+        body = {
+            "name": name,
+            "git_url": git_url,
+            "runner_env": runner_env,
+            "branch": branch,
+            "description": description,
+            "execute_cmd": execute_cmd,
+            "env_install_cmd": env_install_cmd,
+            "build_cmd": build_cmd,
+            "build_image": build_image,
+        }
+        return self._do("POST", "/application/apps", body=body)["id"]
+
+    def get_application(self, app_id: str) -> Dict[str, Any]:
+        """获取应用元数据，不存在时抛出 not_found 为 True 的 APIError"""
+        return self._do("GET", _app_path(app_id))
+
+    def delete_application(self, app_id: str) -> None:
+        """删除应用"""
+        self._do("DELETE", _app_path(app_id))
+
+    def run_application(self, app_id: str) -> Dict[str, Any]:
+        """运行已就绪的应用，返回更新后的应用元数据"""
+        return self._do("POST", _app_path(app_id) + "/run")
+
+    def stop_application(self, app_id: str) -> Dict[str, Any]:
+        """停止应用，返回更新后的应用元数据"""
+        return self._do("POST", _app_path(app_id) + "/stop")
+
+    def get_status(self, app_id: str) -> Dict[str, Any]:
+        """获取应用生命周期状态、component 明细与最近事件"""
+        return self._do("GET", _app_path(app_id) + "/status")
+
+    def wait_application(self, app_id: str, statuses: Iterable[str], timeout: float = 600.0,
+                         interval: float = 2.0) -> Dict[str, Any]:
+        """
+        轮询应用状态直到进入 statuses 之一，返回此时的状态
+        应用进入 APP_STATUS_FAILED 且不在 statuses 中时抛出 RuntimeError，超时抛出 WaitTimeout
+        """
+        targets = {statuses} if isinstance(statuses, str) else set(statuses)
+        deadline = time.monotonic() + timeout
+        while True:
+            status = self.get_status(app_id)
+            if status.get("status") in targets:
+                return status
+            if status.get("status") == APP_STATUS_FAILED:
+                raise RuntimeError(f"application {app_id} failed: {_last_reason(status)}")
+            if time.monotonic() >= deadline:
+                raise WaitTimeout(f"application {app_id} is {status.get('status')} after {timeout}s")
+            time.sleep(interval)
+
+    def submit_application(self, name: str, git_url: str, runner_env: str, ready_timeout: float = 600.0,
+                           **kwargs: Any) -> str:
+        """
+        创建应用、等待仓库克隆（及制品构建）完成后运行，返回应用 ID
+        kwargs 为 create_application 的其余参数
+        """
+        app_id = self.create_application(name, git_url, runner_env, **kwargs)
+        self.wait_application(app_id, APP_STATUS_READY, timeout=ready_timeout)
+        self.run_application(app_id)
+        return app_id
+
+    def get_logs(self, app_id: str, limit: int = 0, offset: int = 0, level: str = "",
+                 start: Optional[datetime] = None, end: Optional[datetime] = None) -> Dict[str, Any]:
+        """查询应用日志，limit 为 0 时使用节点默认值（100），level 为最低日志级别"""
+        params: Dict[str, Any] = {}
+        if limit > 0:
+            params["limit"] = limit
+        if offset > 0:
+            params["offset"] = offset
+        if level:
+            params["level"] = level
+        if start is not None:
+            params["start_time"] = _rfc3339(start)
+        if end is not None:
+            params["end_time"] = _rfc3339(end)
+        return self._do("GET", _app_path(app_id) + "/logs", params=params)
+
+    # ------------------------------------------------------------------
+    # 批处理任务
+    # ------------------------------------------------------------------
+
+    def submit_job(self, app_id: str, name: str = "", cpu: int = 0, memory: int = 0, gpu: int = 0,
+                   runtime_env: str = "", env: Optional[Dict[str, str]] = None, completions: int = 0,
+                   parallelism: int = 0, backoff_limit: Optional[int] = None) -> Dict[str, Any]:
+        """
+        为应用提交 run-to-completion 的 Job，返回 Job 状态
+        cpu 单位为 millicores，memory 单位为字节；completions、parallelism 为 0 时缺省为 1
+        """
+        body: Dict[str, Any] = {"name": name, "cpu": cpu, "memory": memory, "gpu": gpu}
+        if runtime_env:
+            body["runtime_env"] = runtime_env
+        if env:
+            body["env"] = env
+        if completions:
+            body["completions"] = completions
+        if parallelism:
+            body["parallelism"] = parallelism
+        if backoff_limit is not None:
+            body["backoff_limit"] = backoff_limit
+        return self._do("POST", _app_path(app_id) + "/jobs", body=body)
+
+    def list_jobs(self, app_id: str) -> List[Dict[str, Any]]:
+        """列出应用的 Job"""
+        return self._do("GET", _app_path(app_id) + "/jobs").get("jobs") or []
+
+    def get_job(self, app_id: str, job_id: str) -> Dict[str, Any]:
+        """获取 Job 状态与执行记录"""
+        return self._do("GET", _job_path(app_id, job_id))
+
+    def cancel_job(self, app_id: str, job_id: str) -> Dict[str, Any]:
+        """取消 Job 并删除其运行中的 component"""
+        return self._do("POST", _job_path(app_id, job_id) + "/cancel")
+
+    def delete_job(self, app_id: str, job_id: str) -> None:
+        """删除已结束的 Job 记录"""
+        self._do("DELETE", _job_path(app_id, job_id))
+
+    def wait_job(self, app_id: str, job_id: str, timeout: float = 3600.0, interval: float = 2.0) -> Dict[str, Any]:
+        """轮询 Job 直到结束（succeeded/failed/cancelled），返回最终状态；超时抛出 WaitTimeout"""
+        deadline = time.monotonic() + timeout
+        while True:
+            job = self.get_job(app_id, job_id)
+            if job.get("state") in JOB_FINISHED_STATES:
+                return job
+            if time.monotonic() >= deadline:
+                raise WaitTimeout(f"job {job_id} is {job.get('state')} after {timeout}s")
+            time.sleep(interval)
+
+    # ------------------------------------------------------------------
+    # 请求
+    # ------------------------------------------------------------------
+
+    def headers(self) -> Dict[str, str]:
+        """携带访问令牌的请求头"""
+        if not self.token:
+            return {}
+        return {"Authorization": f"Bearer {self.token}"}
+
+    def _do(self, method: str, path: str, params: Optional[Dict[str, Any]] = None,
+            body: Optional[Dict[str, Any]] = None) -> Any:
+        """调用管理 API，返回统一响应结构中的 data"""
+        url = self.server + path
+        if params:
+            url += "?" + urllib.parse.urlencode(params)
+        data = json.dumps(body).encode() if body is not None else None
+
+        attempts = max(self.retry.max_attempts, 1) if method in ("GET", "DELETE") else 1
+        backoff = self.retry.backoff
+        for attempt in range(1, attempts + 1):
+            try:
+                return self._send(method, url, data)
+            except (APIError, OSError) as e:
+                if attempt >= attempts or not _retryable(e):
+                    raise
+            time.sleep(backoff)
+            backoff = min(backoff * 2, max(self.retry.max_backoff, self.retry.backoff))
+
+    def _send(self, method: str, url: str, data: Optional[bytes]) -> Any:
+        """发送一次请求"""
+        headers = self.headers()
+        if data is not None:
+            headers["Content-Type"] = "application/json"
+        req = urllib.request.Request(url, data=data, method=method, headers=headers)
+        try:
+            with urllib.request.urlopen(req, timeout=self.timeout) as resp:
+                payload = resp.read()
+        except urllib.error.HTTPError as e:
+            raise APIError(e.code, e.reason, _error_message(e.read())) from None
+        try:
+            result = json.loads(payload) if payload else {}
+        except ValueError as e:
+            raise RuntimeError(f"invalid response: {e}") from None
+        return result.get("data") or {}
+
+
+def _retryable(e: Exception) -> bool:
+    """连接失败或节点暂时不可用时可以重试"""
+    if isinstance(e, APIError):
+        return e.status_code in _RETRYABLE_STATUS
+    return isinstance(e, OSError)
+
+
+def _error_message(payload: bytes) -> str:
+    try:
+        return json.loads(payload).get("error") or ""
+    except (ValueError, AttributeError):
+        return ""
+
+
+def _last_reason(status: Dict[str, Any]) -> str:
+    events = status.get("events") or []
+    return events[-1].get("reason", "") if events else ""
+
+
+def _rfc3339(t: datetime) -> str:
+    if t.tzinfo is None:
+        t = t.astimezone()
+    return t.isoformat(timespec="seconds")
+
+
+def _app_path(app_id: str) -> str:
+    return "/application/apps/" + urllib.parse.quote(app_id, safe="")
+
+
+def _job_path(app_id: str, job_id: str) -> str:
+    return _app_path(app_id) + "/jobs/" + urllib.parse.quote(job_id, safe="")
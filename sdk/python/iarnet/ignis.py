@@ -0,0 +1,113 @@
+"""
+ignis 控制器会话
+在 notebook 中直接驱动 ignis：与节点的 ignis gRPC 服务（默认端口 50001）建立双向流会话，
+发送函数、数据与调用命令，接收执行结果；消息由 proto/ignis/controller 生成的代码定义
+"""
+
+import queue
+from typing import Iterator, Optional, Sequence, Tuple
+
+import grpc
+
+# 生成代码以 common.xxx_pb2、controller_pb2 的名称相互导入，这里使用相同的模块名，避免重复注册 proto 文件
+from . import proto  # noqa: F401
+from common import messages_pb2
+import controller_pb2
+import controller_pb2_grpc
+
+# 命令消息与 Message 中对应的类型和字段，与 Go 侧 controller.NewMessage 一致
+_COMMANDS = {
+    messages_pb2.Ack: (controller_pb2.ACK, "Ack"),
+    messages_pb2.Ready: (controller_pb2.FR_READY, "Ready"),
+    controller_pb2.AppendData: (controller_pb2.FR_APPEND_DATA, "AppendData"),
+    controller_pb2.AppendActor: (controller_pb2.FR_APPEND_ACTOR, "AppendActor"),
+    controller_pb2.AppendPyFunc: (controller_pb2.FR_APPEND_PY_FUNC, "AppendPyFunc"),
+    controller_pb2.AppendPyClass: (controller_pb2.FR_APPEND_PY_CLASS, "AppendPyClass"),
+    controller_pb2.AppendArg: (controller_pb2.FR_APPEND_ARG, "AppendArg"),
+    controller_pb2.AppendClassMethodArg: (controller_pb2.FR_APPEND_CLASS_METHOD_ARG, "AppendClassMethodArg"),
+    controller_pb2.Invoke: (controller_pb2.FR_INVOKE, "Invoke"),
+    controller_pb2.ReturnResult: (controller_pb2.BK_RETURN_RESULT, "ReturnResult"),
+    controller_pb2.AppendDAGNode: (controller_pb2.FR_APPEND_DAG_NODE, "AppendDAGNode"),
+    controller_pb2.RequestObject: (controller_pb2.FR_REQUEST_OBJECT, "RequestObject"),
+    controller_pb2.ResponseObject: (controller_pb2.BK_RESPONSE_OBJECT, "ResponseObject"),
+}
+
+
+def new_message(app_id: str, command) -> controller_pb2.Message:
+    """将命令包装为携带应用 ID 的会话消息"""
+    try:
+        command_type, field = _COMMANDS[type(command)]
+    except KeyError:
+        raise TypeError(f"unsupported command {type(command).__name__}") from None
+    msg = controller_pb2.Message(Type=command_type, AppID=app_id)
+    getattr(msg, field).CopyFrom(command)
+    return msg
+
+
+class Session:
+    """
+    应用的 ignis 控制器会话，每个应用同时只能有一个会话
+    节点未预先为应用创建控制器且未开启自动创建时，会话在收到第一条消息后被拒绝
+
+    用法:
+
+        with Session.connect("node:50001", app_id) as session:
+            session.ready()
+            session.send(controller_pb2.AppendPyFunc(Name="add", Params=["a", "b"], PickledObject=blob))
+            for msg in session:
+                ...
+    """
+
+    def __init__(self, channel: grpc.Channel, app_id: str,
+                 metadata: Optional[Sequence[Tuple[str, str]]] = None, owns_channel: bool = False):
+        if not app_id:
+            raise ValueError("application id is required")
+        self.app_id = app_id
+        self._channel = channel
+        self._owns_channel = owns_channel
+        self._requests: "queue.Queue[Optional[controller_pb2.Message]]" = queue.Queue()
+        stub = controller_pb2_grpc.ServiceStub(channel)
+        self._responses = stub.Session(self._outgoing(), metadata=metadata)
+
+    @classmethod
+    def connect(cls, address: str, app_id: str, credentials: Optional[grpc.ChannelCredentials] = None,
+                compression: Optional[grpc.Compression] = None,
+                options: Optional[Sequence[Tuple[str, object]]] = None) -> "Session":
+        """连接节点的 ignis 服务并为应用打开会话，credentials 为空时使用明文连接"""
+        if credentials is None:
+            channel = grpc.insecure_channel(address, options=options, compression=compression)
+        else:
+            channel = grpc.secure_channel(address, credentials, options=options, compression=compression)
+        return cls(channel, app_id, owns_channel=True)
+
+    def send(self, command) -> None:
+        """发送命令，命令类型由消息类型推断"""
+        self._requests.put(new_message(self.app_id, command))
+
+    def ready(self, accept_encodings: Sequence[str] = ()) -> None:
+        """通知控制器客户端已就绪"""
+        self.send(messages_pb2.Ready(AcceptEncodings=list(accept_encodings)))
+
+    def __iter__(self) -> Iterator[controller_pb2.Message]:
+        """按顺序接收控制器发来的消息，会话结束时停止"""
+        return iter(self._responses)
+
+    def close(self) -> None:
+        """结束发送并关闭会话"""
+        self._requests.put(None)
+        self._responses.cancel()
+        if self._owns_channel:
+            self._channel.close()
+
+    def __enter__(self) -> "Session":
+        return self
+
+    def __exit__(self, *exc) -> None:
+        self.close()
+
+    def _outgoing(self) -> Iterator[controller_pb2.Message]:
+        while True:
+            msg = self._requests.get()
+            if msg is None:
+                return
+            yield msg
@@ -0,0 +1,11 @@
+"""
+ignis 控制器会话的 protobuf 生成代码，由 proto/protobuf-gen.sh 生成，与节点的 proto 定义同步
+添加 proto 及 controller 目录到 sys.path，使生成代码中的 from common import ... 与 import controller_pb2 可以工作
+"""
+import os
+import sys
+
+_current_dir = os.path.dirname(os.path.abspath(__file__))
+for _path in (_current_dir, os.path.join(_current_dir, "controller")):
+    if _path not in sys.path:
+        sys.path.insert(0, _path)
@@ -0,0 +1,21 @@
+from google.protobuf.internal import containers as _containers
+from google.protobuf import descriptor as _descriptor
+from google.protobuf import message as _message
+from collections.abc import Iterable as _Iterable
+from typing import ClassVar as _ClassVar, Optional as _Optional
+
+DESCRIPTOR: _descriptor.FileDescriptor
+
+class Ack(_message.Message):
+    __slots__ = ("Error",)
+    ERROR_FIELD_NUMBER: _ClassVar[int]
+    Error: str
+    def __init__(self, Error: _Optional[str] = ...) -> None: ...
+
+class Ready(_message.Message):
+    __slots__ = ("AcceptEncodings", "UpstreamToken")
+    ACCEPTENCODINGS_FIELD_NUMBER: _ClassVar[int]
+    UPSTREAMTOKEN_FIELD_NUMBER: _ClassVar[int]
+    AcceptEncodings: _containers.RepeatedScalarFieldContainer[str]
+    UpstreamToken: str
+    def __init__(self, AcceptEncodings: _Optional[_Iterable[str]] = ..., UpstreamToken: _Optional[str] = ...) -> None: ...
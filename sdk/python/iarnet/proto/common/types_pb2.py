@@ -0,0 +1,55 @@
+# -*- coding: utf-8 -*-
+# Generated by the protocol buffer compiler.  DO NOT EDIT!
+# NO CHECKED-IN PROTOBUF GENCODE
+# source: common/types.proto
+# Protobuf Python Version: 6.31.1
+"""Generated protocol buffer code."""
+from google.protobuf import descriptor as _descriptor
+from google.protobuf import descriptor_pool as _descriptor_pool
+from google.protobuf import runtime_version as _runtime_version
+from google.protobuf import symbol_database as _symbol_database
+from google.protobuf.internal import builder as _builder
+_runtime_version.ValidateProtobufRuntimeVersion(
+    _runtime_version.Domain.PUBLIC,
+    6,
+    31,
+    1,
+    '',
+    'common/types.proto'
+)
+# @@protoc_insertion_point(imports)
+
+_sym_db = _symbol_database.Default()
+
+
+
+
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x12\x63ommon/types.proto\x12\x06\x63ommon\"7\n\tObjectRef\x12\n\n\x02ID\x18\x01 \x01(\t\x12\x0e\n\x06Source\x18\x02 \x01(\t\x12\x0e\n\x06\x44igest\x18\x03 \x01(\t\"I\n\nDataSource\x12\x0b\n\x03URL\x18\x01 \x01(\t\x12\x10\n\x08ObjectID\x18\x02 \x01(\t\x12\x0c\n\x04Path\x18\x03 \x01(\t\x12\x0e\n\x06SHA256\x18\x04 \x01(\t\"R\n\x0bVolumeMount\x12\x0c\n\x04Name\x18\x01 \x01(\t\x12\x10\n\x08HostPath\x18\x02 \x01(\t\x12\x11\n\tMountPath\x18\x03 \x01(\t\x12\x10\n\x08ReadOnly\x18\x04 \x01(\x08\"\xaf\x01\n\x0fSecurityContext\x12\x16\n\x0eReadOnlyRootFS\x18\x01 \x01(\x08\x12 \n\x18\x41llowPrivilegeEscalation\x18\x02 \x01(\x08\x12\x18\n\x10\x44ropCapabilities\x18\x03 \x03(\t\x12\x17\n\x0f\x41\x64\x64\x43\x61pabilities\x18\x04 \x03(\t\x12\x16\n\x0eSeccompProfile\x18\x05 \x01(\t\x12\x17\n\x0f\x41ppArmorProfile\x18\x06 \x01(\t\"\xda\x01\n\x07Sidecar\x12\x0c\n\x04Name\x18\x01 \x01(\t\x12\r\n\x05Image\x18\x02 \x01(\t\x12\x0b\n\x03\x43PU\x18\x03 \x01(\x03\x12\x0e\n\x06Memory\x18\x04 \x01(\x03\x12\x0b\n\x03GPU\x18\x05 \x01(\x03\x12%\n\x03\x45nv\x18\x06 \x03(\x0b\x32\x18.common.Sidecar.EnvEntry\x12\x0f\n\x07\x43ommand\x18\x07 \x03(\t\x12$\n\x07Volumes\x18\x08 \x03(\x0b\x32\x13.common.VolumeMount\x1a*\n\x08\x45nvEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\"\x7f\n\rEncodedObject\x12\n\n\x02ID\x18\x01 \x01(\t\x12\x0c\n\x04\x44\x61ta\x18\x02 \x01(\x0c\x12\x0e\n\x06Source\x18\x03 \x01(\t\x12\"\n\x08Language\x18\x04 \x01(\x0e\x32\x10.common.Language\x12\x10\n\x08IsStream\x18\x05 \x01(\x08\x12\x0e\n\x06\x44igest\x18\x06 \x01(\t\"q\n\x0bStreamChunk\x12\x10\n\x08ObjectID\x18\x01 \x01(\t\x12\x0e\n\x06Offset\x18\x02 \x01(\x03\x12\x0b\n\x03\x45oS\x18\x03 \x01(\x08\x12$\n\x05Value\x18\x04 \x01(\x0b\x32\x15.common.EncodedObject\x12\r\n\x05\x45rror\x18\x05 \x01(\t*I\n\x08Language\x12\x10\n\x0cLANG_UNKNOWN\x10\x00\x12\r\n\tLANG_JSON\x10\x01\x12\x0b\n\x07LANG_GO\x10\x02\x12\x0f\n\x0bLANG_PYTHON\x10\x03\x42\x31Z/github.com/9triver/iarnet/internal/proto/commonb\x06proto3')
+
+_globals = globals()
+_builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
+_builder.BuildTopDescriptorsAndMessages(DESCRIPTOR, 'common.types_pb2', _globals)
+if not _descriptor._USE_C_DESCRIPTORS:
+  _globals['DESCRIPTOR']._loaded_options = None
+  _globals['DESCRIPTOR']._serialized_options = b'Z/github.com/9triver/iarnet/internal/proto/common'
+  _globals['_SIDECAR_ENVENTRY']._loaded_options = None
+  _globals['_SIDECAR_ENVENTRY']._serialized_options = b'8\001'
+  _globals['_LANGUAGE']._serialized_start=889
+  _globals['_LANGUAGE']._serialized_end=962
+  _globals['_OBJECTREF']._serialized_start=30
+  _globals['_OBJECTREF']._serialized_end=85
+  _globals['_DATASOURCE']._serialized_start=87
+  _globals['_DATASOURCE']._serialized_end=160
+  _globals['_VOLUMEMOUNT']._serialized_start=162
+  _globals['_VOLUMEMOUNT']._serialized_end=244
+  _globals['_SECURITYCONTEXT']._serialized_start=247
+  _globals['_SECURITYCONTEXT']._serialized_end=422
+  _globals['_SIDECAR']._serialized_start=425
+  _globals['_SIDECAR']._serialized_end=643
+  _globals['_SIDECAR_ENVENTRY']._serialized_start=601
+  _globals['_SIDECAR_ENVENTRY']._serialized_end=643
+  _globals['_ENCODEDOBJECT']._serialized_start=645
+  _globals['_ENCODEDOBJECT']._serialized_end=772
+  _globals['_STREAMCHUNK']._serialized_start=774
+  _globals['_STREAMCHUNK']._serialized_end=887
+# @@protoc_insertion_point(module_scope)
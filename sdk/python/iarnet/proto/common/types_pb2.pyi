@@ -0,0 +1,126 @@
+from google.protobuf.internal import containers as _containers
+from google.protobuf.internal import enum_type_wrapper as _enum_type_wrapper
+from google.protobuf import descriptor as _descriptor
+from google.protobuf import message as _message
+from collections.abc import Iterable as _Iterable, Mapping as _Mapping
+from typing import ClassVar as _ClassVar, Optional as _Optional, Union as _Union
+
+DESCRIPTOR: _descriptor.FileDescriptor
+
+class Language(int, metaclass=_enum_type_wrapper.EnumTypeWrapper):
+    __slots__ = ()
+    LANG_UNKNOWN: _ClassVar[Language]
+    LANG_JSON: _ClassVar[Language]
+    LANG_GO: _ClassVar[Language]
+    LANG_PYTHON: _ClassVar[Language]
+LANG_UNKNOWN: Language
+LANG_JSON: Language
+LANG_GO: Language
+LANG_PYTHON: Language
+
+class ObjectRef(_message.Message):
+    __slots__ = ("ID", "Source", "Digest")
+    ID_FIELD_NUMBER: _ClassVar[int]
+    SOURCE_FIELD_NUMBER: _ClassVar[int]
+    DIGEST_FIELD_NUMBER: _ClassVar[int]
+    ID: str
+    Source: str
+    Digest: str
+    def __init__(self, ID: _Optional[str] = ..., Source: _Optional[str] = ..., Digest: _Optional[str] = ...) -> None: ...
+
+class DataSource(_message.Message):
+    __slots__ = ("URL", "ObjectID", "Path", "SHA256")
+    URL_FIELD_NUMBER: _ClassVar[int]
+    OBJECTID_FIELD_NUMBER: _ClassVar[int]
+    PATH_FIELD_NUMBER: _ClassVar[int]
+    SHA256_FIELD_NUMBER: _ClassVar[int]
+    URL: str
+    ObjectID: str
+    Path: str
+    SHA256: str
+    def __init__(self, URL: _Optional[str] = ..., ObjectID: _Optional[str] = ..., Path: _Optional[str] = ..., SHA256: _Optional[str] = ...) -> None: ...
+
+class VolumeMount(_message.Message):
+    __slots__ = ("Name", "HostPath", "MountPath", "ReadOnly")
+    NAME_FIELD_NUMBER: _ClassVar[int]
+    HOSTPATH_FIELD_NUMBER: _ClassVar[int]
+    MOUNTPATH_FIELD_NUMBER: _ClassVar[int]
+    READONLY_FIELD_NUMBER: _ClassVar[int]
+    Name: str
+    HostPath: str
+    MountPath: str
+    ReadOnly: bool
+    def __init__(self, Name: _Optional[str] = ..., HostPath: _Optional[str] = ..., MountPath: _Optional[str] = ..., ReadOnly: bool = ...) -> None: ...
+
+class SecurityContext(_message.Message):
+    __slots__ = ("ReadOnlyRootFS", "AllowPrivilegeEscalation", "DropCapabilities", "AddCapabilities", "SeccompProfile", "AppArmorProfile")
+    READONLYROOTFS_FIELD_NUMBER: _ClassVar[int]
+    ALLOWPRIVILEGEESCALATION_FIELD_NUMBER: _ClassVar[int]
+    DROPCAPABILITIES_FIELD_NUMBER: _ClassVar[int]
+    ADDCAPABILITIES_FIELD_NUMBER: _ClassVar[int]
+    SECCOMPPROFILE_FIELD_NUMBER: _ClassVar[int]
+    APPARMORPROFILE_FIELD_NUMBER: _ClassVar[int]
+    ReadOnlyRootFS: bool
+    AllowPrivilegeEscalation: bool
+    DropCapabilities: _containers.RepeatedScalarFieldContainer[str]
+    AddCapabilities: _containers.RepeatedScalarFieldContainer[str]
+    SeccompProfile: str
+    AppArmorProfile: str
+    def __init__(self, ReadOnlyRootFS: bool = ..., AllowPrivilegeEscalation: bool = ..., DropCapabilities: _Optional[_Iterable[str]] = ..., AddCapabilities: _Optional[_Iterable[str]] = ..., SeccompProfile: _Optional[str] = ..., AppArmorProfile: _Optional[str] = ...) -> None: ...
+
+class Sidecar(_message.Message):
+    __slots__ = ("Name", "Image", "CPU", "Memory", "GPU", "Env", "Command", "Volumes")
+    class EnvEntry(_message.Message):
+        __slots__ = ("key", "value")
+        KEY_FIELD_NUMBER: _ClassVar[int]
+        VALUE_FIELD_NUMBER: _ClassVar[int]
+        key: str
+        value: str
+        def __init__(self, key: _Optional[str] = ..., value: _Optional[str] = ...) -> None: ...
+    NAME_FIELD_NUMBER: _ClassVar[int]
+    IMAGE_FIELD_NUMBER: _ClassVar[int]
+    CPU_FIELD_NUMBER: _ClassVar[int]
+    MEMORY_FIELD_NUMBER: _ClassVar[int]
+    GPU_FIELD_NUMBER: _ClassVar[int]
+    ENV_FIELD_NUMBER: _ClassVar[int]
+    COMMAND_FIELD_NUMBER: _ClassVar[int]
+    VOLUMES_FIELD_NUMBER: _ClassVar[int]
+    Name: str
+    Image: str
+    CPU: int
+    Memory: int
+    GPU: int
+    Env: _containers.ScalarMap[str, str]
+    Command: _containers.RepeatedScalarFieldContainer[str]
+    Volumes: _containers.RepeatedCompositeFieldContainer[VolumeMount]
+    def __init__(self, Name: _Optional[str] = ..., Image: _Optional[str] = ..., CPU: _Optional[int] = ..., Memory: _Optional[int] = ..., GPU: _Optional[int] = ..., Env: _Optional[_Mapping[str, str]] = ..., Command: _Optional[_Iterable[str]] = ..., Volumes: _Optional[_Iterable[_Union[VolumeMount, _Mapping]]] = ...) -> None: ...
+
+class EncodedObject(_message.Message):
+    __slots__ = ("ID", "Data", "Source", "Language", "IsStream", "Digest")
+    ID_FIELD_NUMBER: _ClassVar[int]
+    DATA_FIELD_NUMBER: _ClassVar[int]
+    SOURCE_FIELD_NUMBER: _ClassVar[int]
+    LANGUAGE_FIELD_NUMBER: _ClassVar[int]
+    ISSTREAM_FIELD_NUMBER: _ClassVar[int]
+    DIGEST_FIELD_NUMBER: _ClassVar[int]
+    ID: str
+    Data: bytes
+    Source: str
+    Language: Language
+    IsStream: bool
+    Digest: str
+    def __init__(self, ID: _Optional[str] = ..., Data: _Optional[bytes] = ..., Source: _Optional[str] = ..., Language: _Optional[_Union[Language, str]] = ..., IsStream: bool = ..., Digest: _Optional[str] = ...) -> None: ...
+
+class StreamChunk(_message.Message):
+    __slots__ = ("ObjectID", "Offset", "EoS", "Value", "Error")
+    OBJECTID_FIELD_NUMBER: _ClassVar[int]
+    OFFSET_FIELD_NUMBER: _ClassVar[int]
+    EOS_FIELD_NUMBER: _ClassVar[int]
+    VALUE_FIELD_NUMBER: _ClassVar[int]
+    ERROR_FIELD_NUMBER: _ClassVar[int]
+    ObjectID: str
+    Offset: int
+    EoS: bool
+    Value: EncodedObject
+    Error: str
+    def __init__(self, ObjectID: _Optional[str] = ..., Offset: _Optional[int] = ..., EoS: bool = ..., Value: _Optional[_Union[EncodedObject, _Mapping]] = ..., Error: _Optional[str] = ...) -> None: ...
@@ -0,0 +1,3 @@
+"""
+Controller protobuf types
+"""
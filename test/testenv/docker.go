@@ -0,0 +1,124 @@
+package testenv
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// dockerProviderConfig docker provider 配置中测试环境需要设置的部分
+type dockerProviderConfig struct {
+	Server struct {
+		Port int `yaml:"port"`
+	} `yaml:"server"`
+	Docker struct {
+		Host    string `yaml:"host"`
+		Network string `yaml:"network"`
+	} `yaml:"docker"`
+	Resource struct {
+		CPU    int64  `yaml:"cpu"`
+		Memory string `yaml:"memory"`
+		GPU    int64  `yaml:"gpu"`
+	} `yaml:"resource"`
+	ResourceTags []string `yaml:"resource_tags"`
+}
+
+// DockerProvider 以子进程运行的 docker provider，component 以容器形式运行在本机 Docker 上
+type DockerProvider struct {
+	name string
+	addr string
+	proc *process
+}
+
+// dockerHost DOCKER_HOST，未设置时为本机 unix socket
+func dockerHost() string {
+	if host := os.Getenv("DOCKER_HOST"); host != "" {
+		return host
+	}
+	return "unix:///var/run/docker.sock"
+}
+
+// dockerAvailable DOCKER_HOST 指向的 Docker 是否可连接
+// 节点的 application 模块启动时需要 Docker，docker provider 同样需要
+func dockerAvailable() bool {
+	network, addr, ok := strings.Cut(dockerHost(), "://")
+	if !ok {
+		return false
+	}
+	conn, err := net.DialTimeout(network, addr, time.Second)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// startDockerProvider 构建并启动 docker provider，等待其 gRPC 端口可连接
+func startDockerProvider(t *testing.T, name string, spec ProviderSpec) *DockerProvider {
+	t.Helper()
+	bin := binary(t, EnvDockerProviderBinary, "providers/docker", "./cmd")
+
+	dir := filepath.Join(t.TempDir(), name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("testenv: failed to create directory for provider %s: %v", name, err)
+	}
+
+	var cfg dockerProviderConfig
+	cfg.Server.Port = freePort(t)
+	cfg.Docker.Host = dockerHost()
+	cfg.Docker.Network = "bridge"
+	cfg.Resource.CPU = spec.CPU
+	cfg.Resource.Memory = strconv.FormatInt(spec.Memory, 10)
+	cfg.Resource.GPU = spec.GPU
+	cfg.ResourceTags = []string{"cpu", "memory"}
+	configPath := filepath.Join(dir, "config.yaml")
+	writeYAML(t, configPath, &cfg)
+
+	p := &DockerProvider{
+		name: name,
+		addr: net.JoinHostPort("127.0.0.1", strconv.Itoa(cfg.Server.Port)),
+		proc: startProcess(t, name, dir, bin, []string{"IARNET_PORT_DIR=" + dir}, "-config", configPath),
+	}
+	p.proc.reportOnFailure(t)
+	t.Cleanup(p.proc.stop)
+
+	waitFor(t, startTimeout, func() error {
+		if p.proc.exited() {
+			t.Fatalf("testenv: docker provider %s exited: %v", name, p.proc.err)
+		}
+		conn, err := net.DialTimeout("tcp", p.addr, time.Second)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	})
+	return p
+}
+
+// Name provider 在节点 static_providers 中的名称
+func (p *DockerProvider) Name() string {
+	return p.name
+}
+
+// Addr provider 的 gRPC 地址
+func (p *DockerProvider) Addr() string {
+	return p.addr
+}
+
+// writeYAML 将配置写入文件
+func writeYAML(t *testing.T, path string, v any) {
+	t.Helper()
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		t.Fatalf("testenv: failed to marshal %s: %v", path, err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("testenv: failed to write %s: %v", path, err)
+	}
+}
@@ -0,0 +1,286 @@
+// Package testenv 为跨节点集成测试编排完整的 iarnet 环境
+// 在本机启动 N 个 iarnet 节点进程、一个进程内的全局注册中心，以及进程内的 mock provider 或以子进程运行的 docker provider，
+// 自动分配端口、生成各节点的配置（同域、互为 initial_peers、static_providers 指向各自的 provider），
+// 并提供部署、查询 component 与定位实例所在 provider 的辅助方法，用于复现和测试跨节点委托等多节点行为
+//
+// 节点启动时需要连接 Docker（DOCKER_HOST），不可用时跳过测试；
+// 节点二进制由 go build ./cmd 构建（需要 libzmq），也可通过 IARNET_BIN 指定预先构建的二进制，构建失败时跳过测试
+package testenv
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/9triver/iarnet/internal/config"
+	resourcepb "github.com/9triver/iarnet/internal/proto/resource"
+)
+
+// 默认值
+const (
+	defaultDomainID = "domain.testenv"
+	defaultImage    = "iarnet/component:python_3.11-latest"
+	defaultCPU      = 4000    // millicores
+	defaultMemory   = 4 << 30 // bytes
+
+	// Token 节点 HTTP 接口的访问令牌，绑定全部权限
+	Token = "testenv-token"
+
+	startTimeout    = 60 * time.Second
+	convergeTimeout = 60 * time.Second
+	pollInterval    = 200 * time.Millisecond
+)
+
+// ProviderKind provider 类型
+type ProviderKind string
+
+const (
+	ProviderMock   ProviderKind = "mock"   // 进程内 provider，只做容量核算
+	ProviderDocker ProviderKind = "docker" // 子进程运行的 docker provider，需要本机 Docker
+)
+
+// ProviderSpec 节点上的一个 provider
+type ProviderSpec struct {
+	Kind   ProviderKind // 缺省为 mock
+	CPU    int64        // millicores，缺省 4000
+	Memory int64        // bytes，缺省 4Gi
+	GPU    int64
+}
+
+// NodeSpec 一个 iarnet 节点
+type NodeSpec struct {
+	Name      string            // 缺省为 node.<序号>
+	Labels    map[string]string // 节点标签
+	Providers []ProviderSpec    // 通过 static_providers 接入的 provider，为空表示节点没有本地资源
+
+	// Configure 在生成的配置写入文件前调整配置，e.g., 开启 rebalance 或修改 delegation 探测参数
+	Configure func(cfg *config.Config)
+}
+
+// Options 测试环境配置
+type Options struct {
+	Nodes      []NodeSpec
+	DomainID   string // 所有节点所在的域，缺省为 domain.testenv
+	NoRegistry bool   // 不启动全局注册中心
+	NoWait     bool   // 不等待节点间 gossip 发现收敛
+}
+
+// Env 运行中的测试环境，测试结束时自动停止所有进程
+type Env struct {
+	Registry *Registry // NoRegistry 时为 nil
+	Nodes    []*Node
+}
+
+// Start 启动测试环境：注册中心、各节点的 provider 与节点进程，等待节点就绪并互相发现
+func Start(t *testing.T, opts Options) *Env {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("testenv: skipping multi-node environment in short mode")
+	}
+	if len(opts.Nodes) == 0 {
+		t.Fatal("testenv: at least one node is required")
+	}
+	if opts.DomainID == "" {
+		opts.DomainID = defaultDomainID
+	}
+	if !dockerAvailable() {
+		t.Skipf("testenv: docker is not available at %s", dockerHost())
+	}
+	bin := binary(t, EnvNodeBinary, ".", "./cmd")
+
+	env := &Env{}
+	if !opts.NoRegistry {
+		env.Registry = startRegistry(t)
+	}
+
+	root := t.TempDir()
+	for i, spec := range opts.Nodes {
+		if spec.Name == "" {
+			spec.Name = fmt.Sprintf("node.%d", i+1)
+		}
+		node := &Node{Name: spec.Name, dir: filepath.Join(root, spec.Name), bin: bin}
+		if err := os.MkdirAll(node.dir, 0o755); err != nil {
+			t.Fatalf("testenv: failed to create directory for %s: %v", spec.Name, err)
+		}
+		node.Config = nodeConfig(t, spec, opts.DomainID)
+		if env.Registry != nil {
+			node.Config.Resource.GlobalRegistryAddr = env.Registry.Addr()
+		}
+		node.startProviders(t, spec.Providers)
+		env.Nodes = append(env.Nodes, node)
+	}
+
+	// 所有节点的端口确定后再互相配置为 initial_peers
+	for i, node := range env.Nodes {
+		for j, peer := range env.Nodes {
+			if i != j {
+				node.Config.InitialPeers = append(node.Config.InitialPeers, peer.discoveryAddr())
+			}
+		}
+		if spec := opts.Nodes[i]; spec.Configure != nil {
+			spec.Configure(node.Config)
+		}
+		if err := node.Config.Validate(); err != nil {
+			t.Fatalf("testenv: invalid config for %s: %v", node.Name, err)
+		}
+		writeYAML(t, node.configPath(), node.Config)
+	}
+
+	for _, node := range env.Nodes {
+		node.start(t)
+	}
+	for _, node := range env.Nodes {
+		node.waitReady(t)
+	}
+	if !opts.NoWait && len(env.Nodes) > 1 {
+		env.WaitForPeers(t)
+	}
+	return env
+}
+
+// nodeConfig 生成节点配置：以 Defaults 为基础，所有端口随机分配，路径使用默认的相对路径（节点进程在自己的目录下运行）
+func nodeConfig(t *testing.T, spec NodeSpec, domainID string) *config.Config {
+	t.Helper()
+	cfg := config.Defaults()
+	cfg.Host = "127.0.0.1"
+	cfg.Logging.Level = "debug"
+	cfg.ShutdownTimeoutSeconds = 5
+	cfg.Application.RunnerImages = map[string]string{"python:3.11-latest": "iarnet/runner:python_3.11-latest"}
+
+	cfg.Resource.Name = spec.Name
+	cfg.Resource.Description = "testenv " + spec.Name
+	cfg.Resource.DomainID = domainID
+	cfg.Resource.Labels = spec.Labels
+	cfg.Resource.ComponentImages = map[string]string{"python": defaultImage}
+	cfg.Resource.StaticProviderRetrySeconds = 1
+	cfg.Resource.Discovery.Enabled = true
+	cfg.Resource.Discovery.GossipIntervalSeconds = 1
+	cfg.Resource.Discovery.AntiEntropyIntervalSeconds = 5
+
+	cfg.Transport.HTTP.Port = freePort(t)
+	cfg.Transport.HTTP.RBAC = config.RBACConfig{
+		Enabled: true,
+		Roles:   map[string][]string{"admin": {"*"}},
+		Tokens:  []config.RBACTokenConfig{{Token: Token, Subject: "testenv", Roles: []string{"admin"}}},
+	}
+	cfg.Transport.ZMQ.Port = freePort(t)
+	cfg.Transport.RPC.Resource.Port = freePort(t)
+	cfg.Transport.RPC.Ignis.Port = freePort(t)
+	cfg.Transport.RPC.Store.Port = freePort(t)
+	cfg.Transport.RPC.Logger.Port = freePort(t)
+	cfg.Transport.RPC.ResourceLogger.Port = freePort(t)
+	cfg.Transport.RPC.Discovery.Port = freePort(t)
+	cfg.Transport.RPC.Scheduler.Port = freePort(t)
+	cfg.Transport.Tunnel.Port = freePort(t)
+	return cfg
+}
+
+// WaitForPeers 等待每个节点都发现了其余所有节点
+func (e *Env) WaitForPeers(t *testing.T) {
+	t.Helper()
+	for _, node := range e.Nodes {
+		waitFor(t, convergeTimeout, func() error {
+			peers, err := node.Peers()
+			if err != nil {
+				return err
+			}
+			known := make(map[string]bool, len(peers))
+			for _, peer := range peers {
+				known[peer.NodeName] = true
+			}
+			for _, other := range e.Nodes {
+				if other != node && !known[other.Name] {
+					return fmt.Errorf("%s has not discovered %s", node.Name, other.Name)
+				}
+			}
+			return nil
+		})
+	}
+}
+
+// WaitRegistered 等待所有节点在全局注册中心完成注册并上报过健康检查
+func (e *Env) WaitRegistered(t *testing.T) {
+	t.Helper()
+	if e.Registry == nil {
+		t.Fatal("testenv: environment was started without a registry")
+	}
+	for _, node := range e.Nodes {
+		waitFor(t, convergeTimeout, func() error {
+			registered, ok := e.Registry.Node(node.ID())
+			if !ok {
+				return fmt.Errorf("%s is not registered", node.Name)
+			}
+			if registered.LastReport.IsZero() {
+				return fmt.Errorf("%s has not reported health", node.Name)
+			}
+			return nil
+		})
+	}
+}
+
+// Placement 实例所在的节点与 mock provider，实例不在任何 mock provider 上时返回 nil
+func (e *Env) Placement(instanceID string) (*Node, *MockProvider) {
+	for _, node := range e.Nodes {
+		for _, p := range node.Mocks {
+			for _, d := range p.Deployments() {
+				if d.InstanceID == instanceID {
+					return node, p
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// startProviders 启动节点的 provider 并配置为 static_providers
+func (n *Node) startProviders(t *testing.T, specs []ProviderSpec) {
+	t.Helper()
+	for i, spec := range specs {
+		if spec.CPU == 0 && spec.Memory == 0 && spec.GPU == 0 {
+			spec.CPU, spec.Memory = defaultCPU, defaultMemory
+		}
+		name := fmt.Sprintf("%s-provider-%d", n.Name, i+1)
+
+		var addr string
+		switch spec.Kind {
+		case ProviderMock, "":
+			p := startMockProvider(t, name, &resourcepb.Info{Cpu: spec.CPU, Memory: spec.Memory, Gpu: spec.GPU})
+			n.Mocks = append(n.Mocks, p)
+			addr = p.Addr()
+		case ProviderDocker:
+			p := startDockerProvider(t, name, spec)
+			n.Dockers = append(n.Dockers, p)
+			addr = p.Addr()
+		default:
+			t.Fatalf("testenv: unknown provider kind %q", spec.Kind)
+		}
+
+		host, port, _ := net.SplitHostPort(addr)
+		portNum, _ := strconv.Atoi(port)
+		n.Config.Resource.StaticProviders = append(n.Config.Resource.StaticProviders, config.StaticProviderConfig{
+			Name: name,
+			Host: host,
+			Port: portNum,
+		})
+	}
+}
+
+// waitFor 轮询 check 直到返回 nil，超时后以最后一次的错误结束测试
+func waitFor(t *testing.T, timeout time.Duration, check func() error) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for {
+		err := check()
+		if err == nil {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("testenv: timed out after %v: %v", timeout, err)
+		}
+		time.Sleep(pollInterval)
+	}
+}
@@ -0,0 +1,213 @@
+package testenv
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/9triver/iarnet/internal/config"
+	resourcehttp "github.com/9triver/iarnet/internal/transport/http/resource"
+	systemhttp "github.com/9triver/iarnet/internal/transport/http/system"
+)
+
+// Node 由测试环境启动的 iarnet 节点进程
+type Node struct {
+	Name    string
+	Config  *config.Config // 写入节点配置文件的配置
+	Mocks   []*MockProvider
+	Dockers []*DockerProvider
+
+	dir string
+	bin string
+
+	mu   sync.Mutex
+	proc *process
+	id   string
+}
+
+// URL 节点 HTTP 接口地址
+func (n *Node) URL() string {
+	return "http://" + net.JoinHostPort(n.Config.Host, strconv.Itoa(n.Config.Transport.HTTP.Port))
+}
+
+// Dir 节点的工作目录，包含配置文件、数据目录与日志
+func (n *Node) Dir() string {
+	return n.dir
+}
+
+// LogPath 节点进程的日志文件
+func (n *Node) LogPath() string {
+	return filepath.Join(n.dir, n.Name+".log")
+}
+
+// ID 节点 ID，节点就绪后有效
+func (n *Node) ID() string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.id
+}
+
+func (n *Node) configPath() string {
+	return filepath.Join(n.dir, "config.yaml")
+}
+
+// IgnisAddr 节点 ignis 控制器 gRPC 服务的地址
+func (n *Node) IgnisAddr() string {
+	return net.JoinHostPort(n.Config.Host, strconv.Itoa(n.Config.Transport.RPC.Ignis.Port))
+}
+
+// SchedulerAddr 节点 scheduler gRPC 服务的地址
+func (n *Node) SchedulerAddr() string {
+	return net.JoinHostPort(n.Config.Host, strconv.Itoa(n.Config.Transport.RPC.Scheduler.Port))
+}
+
+// discoveryAddr 节点 gossip 发现服务的地址，写入其他节点的 initial_peers
+func (n *Node) discoveryAddr() string {
+	return net.JoinHostPort(n.Config.Host, strconv.Itoa(n.Config.Transport.RPC.Discovery.Port))
+}
+
+// start 启动节点进程，测试结束时停止
+func (n *Node) start(t *testing.T) {
+	t.Helper()
+	proc := startProcess(t, n.Name, n.dir, n.bin, []string{"IARNET_PORT_DIR=" + n.dir}, "-config", n.configPath())
+	proc.reportOnFailure(t)
+	t.Cleanup(proc.stop)
+	n.mu.Lock()
+	n.proc = proc
+	n.mu.Unlock()
+}
+
+// waitReady 等待节点 HTTP 接口可用并记录节点 ID
+func (n *Node) waitReady(t *testing.T) {
+	t.Helper()
+	waitFor(t, startTimeout, func() error {
+		n.mu.Lock()
+		proc := n.proc
+		n.mu.Unlock()
+		if proc.exited() {
+			t.Fatalf("testenv: %s exited: %v", n.Name, proc.err)
+		}
+		info, err := n.Info()
+		if err != nil {
+			return err
+		}
+		n.mu.Lock()
+		n.id = info.NodeID
+		n.mu.Unlock()
+		return nil
+	})
+}
+
+// Stop 停止节点进程（SIGTERM），用于测试节点故障；provider 保持运行
+func (n *Node) Stop() {
+	n.mu.Lock()
+	proc := n.proc
+	n.mu.Unlock()
+	proc.stop()
+}
+
+// Restart 使用相同的配置与数据目录重新启动节点，并等待其就绪
+func (n *Node) Restart(t *testing.T) {
+	t.Helper()
+	n.Stop()
+	n.start(t)
+	n.waitReady(t)
+}
+
+// Info 查询节点信息
+func (n *Node) Info() (*resourcehttp.GetNodeInfoResponse, error) {
+	var info resourcehttp.GetNodeInfoResponse
+	if err := n.do(http.MethodGet, "/resource/node/info", nil, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// Peers 查询节点通过 gossip 发现的其他节点
+func (n *Node) Peers() ([]resourcehttp.DiscoveredNodeItem, error) {
+	var resp resourcehttp.GetDiscoveredNodesResponse
+	if err := n.do(http.MethodGet, "/resource/discovery/nodes", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Nodes, nil
+}
+
+// Deploy 通过节点部署 component，放置（包括委托到其他节点）由节点决定
+func (n *Node) Deploy(req *resourcehttp.DeployComponentRequest) (*resourcehttp.ComponentItem, error) {
+	var item resourcehttp.ComponentItem
+	if err := n.do(http.MethodPost, "/resource/components", req, &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// Undeploy 删除节点上的 component
+func (n *Node) Undeploy(componentID string) error {
+	return n.do(http.MethodDelete, "/resource/components/"+componentID, nil, nil)
+}
+
+// Components 列出节点管理的 component
+func (n *Node) Components() ([]resourcehttp.ComponentItem, error) {
+	var resp resourcehttp.ListComponentsResponse
+	if err := n.do(http.MethodGet, "/resource/components", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Components, nil
+}
+
+// MalformedPeers 查询节点记录的发送过非法消息的对端
+func (n *Node) MalformedPeers() ([]systemhttp.MalformedPeerItem, error) {
+	var resp systemhttp.GetMessagesResponse
+	if err := n.do(http.MethodGet, "/system/messages", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Peers, nil
+}
+
+// do 调用节点 HTTP 接口，响应中的 data 解码到 out；非 2xx 响应返回包含错误信息的 error
+func (n *Node) do(method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, n.URL()+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+Token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var envelope struct {
+		Data  json.RawMessage `json:"data"`
+		Error string          `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("%s %s: status %d: failed to decode response: %w", method, path, resp.StatusCode, err)
+	}
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%s %s: status %d: %s", method, path, resp.StatusCode, envelope.Error)
+	}
+	if out == nil || len(envelope.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(envelope.Data, out)
+}
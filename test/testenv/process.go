@@ -0,0 +1,176 @@
+package testenv
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// 预先构建的二进制，设置后跳过构建
+const (
+	EnvNodeBinary           = "IARNET_BIN"
+	EnvDockerProviderBinary = "IARNET_DOCKER_PROVIDER_BIN"
+)
+
+// stopTimeout 进程收到 SIGTERM 后的最长等待时间，超时后强制结束
+const stopTimeout = 10 * time.Second
+
+// logTailLines 测试失败时输出的进程日志行数
+const logTailLines = 50
+
+var (
+	buildMu  sync.Mutex
+	builds   = make(map[string]string) // 包目录 -> 二进制路径，同一测试进程内只构建一次
+	buildDir string
+
+	portsMu sync.Mutex
+	ports   = make(map[int]bool) // 已分配的端口，避免释放后被再次分配给其他服务
+)
+
+// repoRoot 仓库根目录
+func repoRoot() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..")
+}
+
+// binary 返回二进制路径：优先使用环境变量指定的二进制，否则在 module 目录下构建 pkg
+// 构建失败（e.g., 缺少 libzmq）时跳过测试
+func binary(t *testing.T, env, module, pkg string) string {
+	t.Helper()
+	if path := os.Getenv(env); path != "" {
+		return path
+	}
+
+	buildMu.Lock()
+	defer buildMu.Unlock()
+	key := filepath.Join(module, pkg)
+	if path, ok := builds[key]; ok {
+		return path
+	}
+	if buildDir == "" {
+		dir, err := os.MkdirTemp("", "iarnet-testenv-")
+		if err != nil {
+			t.Fatalf("testenv: failed to create build directory: %v", err)
+		}
+		buildDir = dir
+	}
+
+	path := filepath.Join(buildDir, strings.ReplaceAll(strings.Trim(key, "./"), "/", "-"))
+	cmd := exec.Command("go", "build", "-o", path, pkg)
+	cmd.Dir = filepath.Join(repoRoot(), module)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Skipf("testenv: failed to build %s (set %s to use a prebuilt binary): %v\n%s", key, env, err, out)
+	}
+	builds[key] = path
+	return path
+}
+
+// freePort 分配一个空闲的本地 TCP 端口，同一测试进程内不会重复分配
+func freePort(t *testing.T) int {
+	t.Helper()
+	portsMu.Lock()
+	defer portsMu.Unlock()
+	for {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("testenv: failed to allocate port: %v", err)
+		}
+		port := lis.Addr().(*net.TCPAddr).Port
+		lis.Close()
+		if !ports[port] {
+			ports[port] = true
+			return port
+		}
+	}
+}
+
+// process 由测试环境启动的子进程，输出写入日志文件
+type process struct {
+	name    string
+	logPath string
+	cmd     *exec.Cmd
+	done    chan struct{}
+	err     error
+}
+
+// startProcess 启动子进程，env 追加到当前进程的环境变量之后
+func startProcess(t *testing.T, name, dir, bin string, env []string, args ...string) *process {
+	t.Helper()
+	logPath := filepath.Join(dir, name+".log")
+	logFile, err := os.Create(logPath)
+	if err != nil {
+		t.Fatalf("testenv: failed to create log file for %s: %v", name, err)
+	}
+	cmd := exec.Command(bin, args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	if err := cmd.Start(); err != nil {
+		logFile.Close()
+		t.Fatalf("testenv: failed to start %s: %v", name, err)
+	}
+
+	p := &process{name: name, logPath: logPath, cmd: cmd, done: make(chan struct{})}
+	go func() {
+		p.err = cmd.Wait()
+		logFile.Close()
+		close(p.done)
+	}()
+	return p
+}
+
+// exited 进程是否已退出
+func (p *process) exited() bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// stop 发送 SIGTERM 等待进程退出，超时后强制结束
+func (p *process) stop() {
+	if p.exited() {
+		return
+	}
+	p.cmd.Process.Signal(syscall.SIGTERM)
+	select {
+	case <-p.done:
+	case <-time.After(stopTimeout):
+		p.cmd.Process.Kill()
+		<-p.done
+	}
+}
+
+// logTail 进程日志的最后 n 行
+func (p *process) logTail(n int) string {
+	data, err := os.ReadFile(p.logPath)
+	if err != nil {
+		return fmt.Sprintf("<failed to read %s: %v>", p.logPath, err)
+	}
+	lines := bytes.Split(bytes.TrimRight(data, "\n"), []byte("\n"))
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return string(bytes.Join(lines, []byte("\n")))
+}
+
+// reportOnFailure 测试失败时输出进程日志的末尾，便于定位多节点问题
+func (p *process) reportOnFailure(t *testing.T) {
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Logf("testenv: last %d lines of %s (%s):\n%s", logTailLines, p.name, p.logPath, p.logTail(logTailLines))
+		}
+	})
+}
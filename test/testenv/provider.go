@@ -0,0 +1,211 @@
+package testenv
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/9triver/iarnet/internal/proto/common"
+	resourcepb "github.com/9triver/iarnet/internal/proto/resource"
+	providerpb "github.com/9triver/iarnet/internal/proto/resource/provider"
+	"google.golang.org/grpc"
+)
+
+// mockProviderType 进程内 provider 上报的类型
+const mockProviderType = "mock"
+
+// Deployment 进程内 provider 上的一次部署
+type Deployment struct {
+	InstanceID string
+	Image      string
+	Request    *resourcepb.Info // 含 sidecar 的合计资源
+	EnvVars    map[string]string
+}
+
+// MockProvider 进程内 provider，只做容量核算、不运行容器，用于验证放置与跨节点委托
+type MockProvider struct {
+	providerpb.UnimplementedServiceServer
+
+	name   string
+	addr   string
+	server *grpc.Server
+
+	mu          sync.Mutex
+	providerID  string
+	total       *resourcepb.Info
+	allocated   *resourcepb.Info
+	deployments map[string]*Deployment
+	failDeploy  error
+}
+
+// startMockProvider 在随机端口上启动进程内 provider，测试结束时停止
+func startMockProvider(t *testing.T, name string, capacity *resourcepb.Info) *MockProvider {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("testenv: failed to listen for provider %s: %v", name, err)
+	}
+	p := &MockProvider{
+		name:        name,
+		addr:        lis.Addr().String(),
+		server:      grpc.NewServer(),
+		total:       capacity,
+		allocated:   &resourcepb.Info{},
+		deployments: make(map[string]*Deployment),
+	}
+	providerpb.RegisterServiceServer(p.server, p)
+	go p.server.Serve(lis)
+	t.Cleanup(p.server.Stop)
+	return p
+}
+
+// Name provider 在节点 static_providers 中的名称
+func (p *MockProvider) Name() string {
+	return p.name
+}
+
+// Addr provider 的 gRPC 地址
+func (p *MockProvider) Addr() string {
+	return p.addr
+}
+
+// ProviderID 节点连接时分配的 provider ID，未连接时为空
+func (p *MockProvider) ProviderID() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.providerID
+}
+
+// Deployments 返回当前部署在该 provider 上的实例
+func (p *MockProvider) Deployments() []Deployment {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	deployments := make([]Deployment, 0, len(p.deployments))
+	for _, d := range p.deployments {
+		deployments = append(deployments, *d)
+	}
+	return deployments
+}
+
+// Allocated 当前已分配的资源
+func (p *MockProvider) Allocated() *resourcepb.Info {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return &resourcepb.Info{Cpu: p.allocated.Cpu, Memory: p.allocated.Memory, Gpu: p.allocated.Gpu}
+}
+
+// FailDeploy 之后的部署均返回 err，nil 恢复正常，用于模拟 provider 故障
+func (p *MockProvider) FailDeploy(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.failDeploy = err
+}
+
+func (p *MockProvider) Connect(ctx context.Context, req *providerpb.ConnectRequest) (*providerpb.ConnectResponse, error) {
+	if req.ProviderId == "" {
+		return &providerpb.ConnectResponse{Success: false, Error: "provider id is required"}, nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.providerID != "" && p.providerID != req.ProviderId {
+		return &providerpb.ConnectResponse{Success: false, Error: fmt.Sprintf("provider already connected: %s", p.providerID)}, nil
+	}
+	p.providerID = req.ProviderId
+	return &providerpb.ConnectResponse{
+		Success:      true,
+		ProviderType: &providerpb.ProviderType{Name: mockProviderType},
+		Protocol:     common.NewProtocolInfo(common.CapUndeploy, common.CapSidecars),
+	}, nil
+}
+
+func (p *MockProvider) Disconnect(ctx context.Context, req *providerpb.DisconnectRequest) (*providerpb.DisconnectResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if req.ProviderId == p.providerID {
+		p.providerID = ""
+	}
+	return &providerpb.DisconnectResponse{}, nil
+}
+
+func (p *MockProvider) GetCapacity(ctx context.Context, req *providerpb.GetCapacityRequest) (*providerpb.GetCapacityResponse, error) {
+	return &providerpb.GetCapacityResponse{Capacity: p.capacity()}, nil
+}
+
+func (p *MockProvider) GetAvailable(ctx context.Context, req *providerpb.GetAvailableRequest) (*providerpb.GetAvailableResponse, error) {
+	return &providerpb.GetAvailableResponse{Available: p.capacity().Available}, nil
+}
+
+func (p *MockProvider) HealthCheck(ctx context.Context, req *providerpb.HealthCheckRequest) (*providerpb.HealthCheckResponse, error) {
+	return &providerpb.HealthCheckResponse{
+		Capacity:     p.capacity(),
+		ResourceTags: &providerpb.ResourceTags{Cpu: true, Memory: true, Gpu: p.total.Gpu > 0},
+	}, nil
+}
+
+func (p *MockProvider) Deploy(ctx context.Context, req *providerpb.DeployRequest) (*providerpb.DeployResponse, error) {
+	request := &resourcepb.Info{
+		Cpu:    req.ResourceRequest.GetCpu(),
+		Memory: req.ResourceRequest.GetMemory(),
+		Gpu:    req.ResourceRequest.GetGpu(),
+	}
+	for _, sc := range req.Sidecars {
+		request.Cpu += sc.GetCPU()
+		request.Memory += sc.GetMemory()
+		request.Gpu += sc.GetGPU()
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.failDeploy != nil {
+		return &providerpb.DeployResponse{Error: p.failDeploy.Error()}, nil
+	}
+	if p.allocated.Cpu+request.Cpu > p.total.Cpu ||
+		p.allocated.Memory+request.Memory > p.total.Memory ||
+		p.allocated.Gpu+request.Gpu > p.total.Gpu {
+		return &providerpb.DeployResponse{Error: "insufficient capacity", NoCapacity: true}, nil
+	}
+	if _, ok := p.deployments[req.InstanceId]; ok {
+		return &providerpb.DeployResponse{Error: fmt.Sprintf("instance %s already exists", req.InstanceId)}, nil
+	}
+	p.allocated.Cpu += request.Cpu
+	p.allocated.Memory += request.Memory
+	p.allocated.Gpu += request.Gpu
+	p.deployments[req.InstanceId] = &Deployment{
+		InstanceID: req.InstanceId,
+		Image:      req.Image,
+		Request:    request,
+		EnvVars:    req.EnvVars,
+	}
+	return &providerpb.DeployResponse{}, nil
+}
+
+func (p *MockProvider) Undeploy(ctx context.Context, req *providerpb.UndeployRequest) (*providerpb.UndeployResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	d, ok := p.deployments[req.InstanceId]
+	if !ok {
+		return &providerpb.UndeployResponse{}, nil
+	}
+	p.allocated.Cpu -= d.Request.Cpu
+	p.allocated.Memory -= d.Request.Memory
+	p.allocated.Gpu -= d.Request.Gpu
+	delete(p.deployments, req.InstanceId)
+	return &providerpb.UndeployResponse{}, nil
+}
+
+// capacity 当前容量快照
+func (p *MockProvider) capacity() *resourcepb.Capacity {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return &resourcepb.Capacity{
+		Total: &resourcepb.Info{Cpu: p.total.Cpu, Memory: p.total.Memory, Gpu: p.total.Gpu},
+		Used:  &resourcepb.Info{Cpu: p.allocated.Cpu, Memory: p.allocated.Memory, Gpu: p.allocated.Gpu},
+		Available: &resourcepb.Info{
+			Cpu:    p.total.Cpu - p.allocated.Cpu,
+			Memory: p.total.Memory - p.allocated.Memory,
+			Gpu:    p.total.Gpu - p.allocated.Gpu,
+		},
+	}
+}
@@ -0,0 +1,119 @@
+package testenv
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/9triver/iarnet/internal/proto/common"
+	registrypb "github.com/9triver/iarnet/internal/proto/global/registry"
+	"google.golang.org/grpc"
+)
+
+// RegisteredNode 全局注册中心记录的节点
+type RegisteredNode struct {
+	NodeID     string
+	NodeName   string
+	DomainID   string
+	Address    string // 最近一次健康检查上报的地址
+	IsHead     bool
+	Capacity   *registrypb.ResourceCapacity
+	LastReport time.Time
+}
+
+// Registry 进程内的全局注册中心，记录节点的注册与健康检查
+type Registry struct {
+	registrypb.UnimplementedServiceServer
+
+	addr   string
+	server *grpc.Server
+
+	mu    sync.RWMutex
+	nodes map[string]*RegisteredNode
+}
+
+// startRegistry 在随机端口上启动全局注册中心，测试结束时停止
+func startRegistry(t *testing.T) *Registry {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("testenv: failed to listen for registry: %v", err)
+	}
+	r := &Registry{
+		addr:   lis.Addr().String(),
+		server: grpc.NewServer(),
+		nodes:  make(map[string]*RegisteredNode),
+	}
+	registrypb.RegisterServiceServer(r.server, r)
+	go r.server.Serve(lis)
+	t.Cleanup(r.server.Stop)
+	return r
+}
+
+// Addr 注册中心地址，写入节点的 resource.global_registry_addr
+func (r *Registry) Addr() string {
+	return r.addr
+}
+
+func (r *Registry) RegisterNode(ctx context.Context, req *registrypb.RegisterNodeRequest) (*registrypb.RegisterNodeResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	node, ok := r.nodes[req.NodeId]
+	if !ok {
+		node = &RegisteredNode{NodeID: req.NodeId}
+		r.nodes[req.NodeId] = node
+	}
+	node.NodeName = req.NodeName
+	node.DomainID = req.DomainId
+	return &registrypb.RegisterNodeResponse{
+		DomainName: req.DomainId,
+		Protocol:   common.NewProtocolInfo(),
+	}, nil
+}
+
+func (r *Registry) HealthCheck(ctx context.Context, req *registrypb.HealthCheckRequest) (*registrypb.HealthCheckResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	node, ok := r.nodes[req.NodeId]
+	if !ok {
+		return &registrypb.HealthCheckResponse{
+			ServerTimestamp:   time.Now().UnixNano(),
+			RequireReregister: true,
+			StatusCode:        "warning",
+			Message:           "node not registered",
+		}, nil
+	}
+	node.Address = req.Address
+	node.IsHead = req.IsHead
+	node.Capacity = req.ResourceCapacity
+	node.LastReport = time.Now()
+	return &registrypb.HealthCheckResponse{
+		ServerTimestamp:            time.Now().UnixNano(),
+		RecommendedIntervalSeconds: 1,
+		StatusCode:                 "success",
+	}, nil
+}
+
+// Nodes 返回已注册节点的快照
+func (r *Registry) Nodes() []RegisteredNode {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	nodes := make([]RegisteredNode, 0, len(r.nodes))
+	for _, node := range r.nodes {
+		nodes = append(nodes, *node)
+	}
+	return nodes
+}
+
+// Node 按节点 ID 查找已注册节点
+func (r *Registry) Node(nodeID string) (RegisteredNode, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	node, ok := r.nodes[nodeID]
+	if !ok {
+		return RegisteredNode{}, false
+	}
+	return *node, true
+}
@@ -0,0 +1,188 @@
+package testenv
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/9triver/iarnet/internal/config"
+	ctrlpb "github.com/9triver/iarnet/internal/proto/ignis/controller"
+	schedulerpb "github.com/9triver/iarnet/internal/proto/resource/scheduler"
+	resourcehttp "github.com/9triver/iarnet/internal/transport/http/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v2"
+)
+
+// TestNodeConfigValid 生成的节点配置通过语义校验，且各节点的端口互不冲突
+func TestNodeConfigValid(t *testing.T) {
+	a := nodeConfig(t, NodeSpec{Name: "node.1"}, defaultDomainID)
+	b := nodeConfig(t, NodeSpec{Name: "node.2"}, defaultDomainID)
+	require.NoError(t, a.Validate())
+	require.NoError(t, b.Validate())
+
+	// 写入文件后可被节点按原样加载（空集合加载后为空值而非 nil，按序列化结果比较）
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeYAML(t, path, a)
+	loaded, err := config.LoadConfig(path)
+	require.NoError(t, err)
+	want, err := yaml.Marshal(a)
+	require.NoError(t, err)
+	got, err := yaml.Marshal(loaded)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+
+	seen := make(map[int]bool)
+	for _, port := range []int{
+		a.Transport.HTTP.Port, a.Transport.ZMQ.Port, a.Transport.RPC.Discovery.Port, a.Transport.RPC.Scheduler.Port,
+		b.Transport.HTTP.Port, b.Transport.ZMQ.Port, b.Transport.RPC.Discovery.Port, b.Transport.RPC.Scheduler.Port,
+	} {
+		assert.False(t, seen[port], "port %d allocated twice", port)
+		seen[port] = true
+	}
+}
+
+// TestCrossNodeDelegation 没有本地 provider 的节点将部署委托给同域节点
+func TestCrossNodeDelegation(t *testing.T) {
+	env := Start(t, Options{
+		Nodes: []NodeSpec{
+			{Name: "node.1"},
+			{Name: "node.2", Providers: []ProviderSpec{{Kind: ProviderMock, CPU: 2000, Memory: 1 << 30}}},
+		},
+	})
+	env.WaitRegistered(t)
+
+	comp, err := env.Nodes[0].Deploy(&resourcehttp.DeployComponentRequest{CPU: 500, Memory: 128 << 20})
+	require.NoError(t, err)
+
+	node, provider := env.Placement(comp.ID)
+	require.NotNil(t, provider, "component %s is not placed on any provider", comp.ID)
+	assert.Equal(t, "node.2", node.Name)
+	assert.Contains(t, comp.ProviderID, "@"+node.ID(), "origin node should record the remote placement")
+	assert.Equal(t, int64(500), provider.Allocated().Cpu)
+}
+
+// TestMalformedIgnisMessages 发送非法消息过多的 ignis 客户端被断开，封禁期间新的会话被拒绝
+func TestMalformedIgnisMessages(t *testing.T) {
+	env := Start(t, Options{
+		Nodes: []NodeSpec{{
+			Name: "node.1",
+			Configure: func(cfg *config.Config) {
+				cfg.Transport.MessageLimits.MaxBadMessages = 3
+			},
+		}},
+		NoWait: true,
+	})
+	node := env.Nodes[0]
+
+	conn, err := grpc.NewClient(node.IgnisAddr(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := ctrlpb.NewServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	stream, err := client.Session(ctx)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		// 没有应用 ID 与命令的消息
+		require.NoError(t, stream.Send(&ctrlpb.Message{Type: ctrlpb.CommandType_FR_READY}))
+	}
+	_, err = stream.Recv()
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// 封禁期间新的会话直接被拒绝
+	stream, err = client.Session(ctx)
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	peers, err := node.MalformedPeers()
+	require.NoError(t, err)
+	require.Len(t, peers, 1)
+	assert.Equal(t, "ignis", peers[0].Channel)
+	assert.Equal(t, int64(3), peers[0].BadMessages)
+	assert.True(t, peers[0].Blocked)
+}
+
+// TestListProvidersPagination 分页列举 provider：按 ID 排序分页、字段掩码不返回容量，
+// 没有变化时增量为空，部署后只返回使用量变化的 provider
+func TestListProvidersPagination(t *testing.T) {
+	providers := make([]ProviderSpec, 5)
+	for i := range providers {
+		providers[i] = ProviderSpec{Kind: ProviderMock, CPU: 2000, Memory: 1 << 30}
+	}
+	env := Start(t, Options{Nodes: []NodeSpec{{Name: "node.1", Providers: providers}}})
+	env.WaitRegistered(t)
+	node := env.Nodes[0]
+
+	conn, err := grpc.NewClient(node.SchedulerAddr(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := schedulerpb.NewSchedulerServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var (
+		ids     []string
+		token   string
+		version uint64
+	)
+	for {
+		resp, err := client.ListProviders(ctx, &schedulerpb.ListProvidersRequest{
+			PageSize:  2,
+			PageToken: token,
+			Fields:    []string{"status"},
+		})
+		require.NoError(t, err)
+		require.True(t, resp.Success, resp.Error)
+		assert.LessOrEqual(t, len(resp.Providers), 2)
+		for _, p := range resp.Providers {
+			assert.Equal(t, "connected", p.Status)
+			assert.Nil(t, p.Capacity, "capacity is not in the field mask")
+			ids = append(ids, p.ProviderId)
+		}
+		if token == "" {
+			version = resp.Version
+		}
+		if token = resp.NextPageToken; token == "" {
+			break
+		}
+	}
+	assert.Len(t, ids, 5)
+	assert.IsIncreasing(t, ids)
+
+	resp, err := client.ListProviders(ctx, &schedulerpb.ListProvidersRequest{SinceVersion: version})
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Error)
+	assert.False(t, resp.Full)
+	assert.Empty(t, resp.Providers, "nothing changed since the last listing")
+
+	comp, err := node.Deploy(&resourcehttp.DeployComponentRequest{CPU: 500, Memory: 128 << 20})
+	require.NoError(t, err)
+	waitFor(t, 30*time.Second, func() error {
+		resp, err = client.ListProviders(ctx, &schedulerpb.ListProvidersRequest{SinceVersion: version})
+		if err != nil {
+			return err
+		}
+		if len(resp.Providers) == 0 {
+			return fmt.Errorf("no changes reported yet")
+		}
+		return nil
+	})
+	require.Len(t, resp.Providers, 1)
+	assert.Equal(t, comp.ProviderID, resp.Providers[0].ProviderId)
+	assert.Greater(t, resp.Version, version)
+
+	// 过旧的版本返回全量结果
+	resp, err = client.ListProviders(ctx, &schedulerpb.ListProvidersRequest{SinceVersion: 1})
+	require.NoError(t, err)
+	assert.True(t, resp.Full)
+	assert.Len(t, resp.Providers, 5)
+}
@@ -0,0 +1,135 @@
+package testutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/9triver/iarnet/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func at(value string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// TestParseCron 合法与非法的表达式
+func TestParseCron(t *testing.T) {
+	cases := []struct {
+		expr  string
+		valid bool
+	}{
+		{"* * * * *", true},
+		{"*/15 9-17 * * 1-5", true},
+		{"5/15 * * * *", true},
+		{"10-30/10 * * * *", true},
+		{"0 0 1,15 * 0", true},
+		{"0 0 * * 7", true},
+		{"0 0 30 2 *", true},
+		{"* * * *", false},
+		{"* * * * * *", false},
+		{"60 * * * *", false},
+		{"60/5 * * * *", false},
+		{"*/0 * * * *", false},
+		{"*/x * * * *", false},
+		{"30-10 * * * *", false},
+		{"a * * * *", false},
+		{"* 24 * * *", false},
+		{"* * 0 * *", false},
+		{"* * * 13 *", false},
+		{"* * * * 8", false},
+	}
+	for _, c := range cases {
+		t.Run(c.expr, func(t *testing.T) {
+			schedule, err := util.ParseCron(c.expr)
+			if !c.valid {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, c.expr, schedule.String())
+		})
+	}
+}
+
+// TestCronMatches 步长、周日的两种写法以及日与周的“或”规则
+func TestCronMatches(t *testing.T) {
+	cases := []struct {
+		name  string
+		expr  string
+		time  string
+		match bool
+	}{
+		{"step from star", "*/20 * * * *", "2026-10-16 10:40", true},
+		{"step from star miss", "*/20 * * * *", "2026-10-16 10:41", false},
+		{"step from value", "5/15 * * * *", "2026-10-16 10:50", true},
+		{"step from value start", "5/15 * * * *", "2026-10-16 10:05", true},
+		{"step from value before start", "5/15 * * * *", "2026-10-16 10:00", false},
+		{"step from value off step", "5/15 * * * *", "2026-10-16 10:06", false},
+		{"step in range", "10-30/10 * * * *", "2026-10-16 10:30", true},
+		{"step past range", "10-30/10 * * * *", "2026-10-16 10:40", false},
+		{"hour range", "* 9-17 * * *", "2026-10-16 17:59", true},
+		{"hour range miss", "* 9-17 * * *", "2026-10-16 18:00", false},
+		{"sunday as 0", "0 0 * * 0", "2026-10-18 00:00", true},
+		{"sunday as 7", "0 0 * * 7", "2026-10-18 00:00", true},
+		{"sunday as 7 on saturday", "0 0 * * 7", "2026-10-17 00:00", false},
+		{"weekdays", "* * * * 1-5", "2026-10-16 12:00", true},
+		{"weekdays on weekend", "* * * * 1-5", "2026-10-17 12:00", false},
+		{"day of month only", "0 0 13 * *", "2026-10-13 00:00", true},
+		{"day of month only miss", "0 0 13 * *", "2026-10-16 00:00", false},
+		{"day of week only", "0 0 * * 5", "2026-10-16 00:00", true},
+		{"day of week only miss", "0 0 * * 5", "2026-10-13 00:00", false},
+		{"dom or dow by day of month", "0 0 13 * 5", "2026-10-13 00:00", true},
+		{"dom or dow by day of week", "0 0 13 * 5", "2026-10-16 00:00", true},
+		{"dom or dow neither", "0 0 13 * 5", "2026-11-14 00:00", false},
+		{"month", "* * * 10 *", "2026-10-16 00:00", true},
+		{"month miss", "* * * 11 *", "2026-10-16 00:00", false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			schedule, err := util.ParseCron(c.expr)
+			require.NoError(t, err)
+			assert.Equal(t, c.match, schedule.Matches(at(c.time)))
+		})
+	}
+}
+
+// TestCronNext 下一个匹配的整分钟时刻，不可能的日期返回零值
+func TestCronNext(t *testing.T) {
+	cases := []struct {
+		name  string
+		expr  string
+		after string
+		next  string // 为空表示没有匹配的时刻
+	}{
+		{"next step", "*/20 * * * *", "2026-10-16 10:21", "2026-10-16 10:40"},
+		{"strictly after", "*/20 * * * *", "2026-10-16 10:20", "2026-10-16 10:40"},
+		{"step from value", "5/15 * * * *", "2026-10-16 10:06", "2026-10-16 10:20"},
+		{"step from value wraps hour", "5/15 * * * *", "2026-10-16 10:50", "2026-10-16 11:05"},
+		{"weekday morning after friday", "0 9 * * 1-5", "2026-10-16 17:00", "2026-10-19 09:00"},
+		{"sunday as 7", "30 8 * * 7", "2026-10-17 12:00", "2026-10-18 08:30"},
+		{"month rollover", "0 0 1 * *", "2026-12-15 00:00", "2027-01-01 00:00"},
+		{"dom or dow by day of month", "0 0 13 * 5", "2026-10-10 12:00", "2026-10-13 00:00"},
+		{"dom or dow by day of week", "0 0 13 * 5", "2026-10-13 01:00", "2026-10-16 00:00"},
+		{"leap day", "0 0 29 2 *", "2026-03-01 00:00", "2028-02-29 00:00"},
+		{"impossible february 30", "0 0 30 2 *", "2026-10-16 00:00", ""},
+		{"impossible april 31", "0 0 31 4 *", "2026-10-16 00:00", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			schedule, err := util.ParseCron(c.expr)
+			require.NoError(t, err)
+			next := schedule.Next(at(c.after))
+			if c.next == "" {
+				assert.True(t, next.IsZero(), "expected no match, got %v", next)
+				return
+			}
+			assert.Equal(t, at(c.next), next)
+			assert.True(t, schedule.Matches(next))
+		})
+	}
+}
@@ -158,7 +158,7 @@ export default function ApplicationsPage() {
           description: app.description || "",
           gitUrl: app.git_url,
           branch: app.branch,
-          status: (app.status === "idle" ? "idle" : app.status === "running" ? "running" : app.status === "stopped" ? "stopped" : app.status === "error" ? "error" : app.status === "deploying" ? "deploying" : app.status === "cloning" ? "cloning" : "idle") as Application["status"],
+          status: (app.status === "idle" ? "idle" : app.status === "running" ? "running" : app.status === "stopped" ? "stopped" : app.status === "error" ? "error" : app.status === "deploying" ? "deploying" : app.status === "cloning" ? "cloning" : app.status === "pending" ? "pending" : app.status === "degraded" ? "degraded" : "idle") as Application["status"],
           lastDeployed,
           runnerEnv: app.runner_env,
           containerId: app.container_id,
@@ -404,6 +404,14 @@ export default function ApplicationsPage() {
             克隆中
           </Badge>
         )
+      case "pending":
+        return <Badge variant="secondary">等待中</Badge>
+      case "degraded":
+        return (
+          <Badge variant="default" className="bg-orange-500">
+            降级运行
+          </Badge>
+        )
     }
   }
 
@@ -171,7 +171,7 @@ export interface Application {
   description: string
   gitUrl?: string
   branch?: string
-  status: "idle" | "running" | "stopped" | "error" | "deploying" | "cloning"
+  status: "idle" | "running" | "stopped" | "error" | "deploying" | "cloning" | "pending" | "degraded"
   lastDeployed?: string
   runnerEnv?: string
   containerId?: string